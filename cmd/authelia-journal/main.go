@@ -0,0 +1,122 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Command authelia-journal replays the authelia users events journal,
+// to inspect the timeline of a user or to rebuild the users KV store,
+// and reports the changes the sync of the users to Authelia would apply.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/authelia"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	logging "github.com/linuxfoundation/lfx-v2-auth-service/pkg/log"
+)
+
+func init() {
+	logging.InitStructureLogConfig()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [flags] timeline <username> | rebuild | sync-plan\n", os.Args[0])
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+// envOr returns the environment variable, or the fallback when unset
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func main() {
+	natsURL := flag.String("nats-url", os.Getenv("NATS_URL"), "NATS server URL")
+	timeout := flag.Duration("timeout", 10*time.Second, "NATS request timeout")
+	namespace := flag.String("namespace", envOr(constants.AutheliaConfigMapNamespaceEnvKey, "lfx"), "namespace of the Authelia resources (sync-plan)")
+	configMap := flag.String("configmap", envOr(constants.AutheliaConfigMapNameEnvKey, "authelia-users"), "ConfigMap of the Authelia users (sync-plan)")
+	secret := flag.String("secret", envOr(constants.AutheliaSecretNameEnvKey, "authelia-users"), "Secret of the Authelia users passwords (sync-plan)")
+	daemonSet := flag.String("daemonset", envOr(constants.AutheliaDaemonSetNameEnvKey, "lfx-platform-authelia"), "DaemonSet of Authelia (sync-plan)")
+	exitCode := flag.Bool("exit-code", false, "exit with status 3 when the sync-plan has changes")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+	}
+
+	if *natsURL == "" {
+		*natsURL = "nats://localhost:4222"
+	}
+
+	ctx := context.Background()
+
+	natsClient, err := nats.NewClient(ctx, nats.Config{URL: *natsURL, Timeout: *timeout})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to NATS: %v\n", err)
+		os.Exit(1)
+	}
+	defer natsClient.Close()
+
+	for _, bucket := range []string{constants.KVBucketNameAutheliaUsers, constants.KVBucketNameAutheliaEmailOTP} {
+		if err := natsClient.KeyValueStore(ctx, bucket); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to open KV bucket %s: %v\n", bucket, err)
+			os.Exit(1)
+		}
+	}
+
+	switch args[0] {
+	case "timeline":
+		if len(args) != 2 {
+			usage()
+		}
+		events, err := authelia.UserTimeline(ctx, natsClient, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to replay the journal: %v\n", err)
+			os.Exit(1)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to encode event: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	case "rebuild":
+		rebuilt, err := authelia.RebuildStorage(ctx, natsClient)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to rebuild the users KV store: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("rebuilt %d users from the journal\n", rebuilt)
+	case "sync-plan":
+		report, err := authelia.PlanSync(ctx, map[string]string{
+			"namespace":       *namespace,
+			"configmap-name":  *configMap,
+			"secret-name":     *secret,
+			"daemon-set-name": *daemonSet,
+		}, natsClient)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to plan the sync: %v\n", err)
+			os.Exit(1)
+		}
+		if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to encode the report: %v\n", err)
+			os.Exit(1)
+		}
+		if *exitCode && len(report.Changes) > 0 {
+			os.Exit(3)
+		}
+	default:
+		usage()
+	}
+}
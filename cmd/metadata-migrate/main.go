@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Command metadata-migrate rewrites the metadata of every Auth0 user when metadata fields are
+// renamed. The users are read page by page and patched one by one with the M2M credentials of
+// the service, paced to spare the Management API limits. The progress is recorded in the
+// migrations KV bucket after every page, running the command again resumes an interrupted run.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/auth0"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	logging "github.com/linuxfoundation/lfx-v2-auth-service/pkg/log"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/metadatamigrate"
+)
+
+func init() {
+	logging.InitStructureLogConfig()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s -name <migration> -rename old=new[,old=new] [flags]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "the Auth0 tenant is read from %s or %s, the M2M credentials as for the service\n", constants.Auth0DomainEnvKey, constants.Auth0TenantEnvKey)
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	natsURL := flag.String("nats-url", os.Getenv("NATS_URL"), "NATS server URL")
+	timeout := flag.Duration("timeout", 10*time.Second, "NATS request timeout")
+	name := flag.String("name", "", "name of the migration, it keys the checkpoint")
+	renameList := flag.String("rename", "", "comma separated metadata fields to rename, old=new")
+	rate := flag.Float64("rate", 5, "maximum number of Management API requests per second")
+	dryRun := flag.Bool("dry-run", false, "count the users to patch without patching them")
+	reset := flag.Bool("reset", false, "start the migration over from the first user")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 0 || *name == "" || *rate <= 0 {
+		usage()
+	}
+	renames, err := metadatamigrate.ParseRenames(*renameList)
+	if err != nil {
+		fail("%v", err)
+	}
+	if len(renames) == 0 {
+		usage()
+	}
+
+	domain := os.Getenv(constants.Auth0DomainEnvKey)
+	if tenant := os.Getenv(constants.Auth0TenantEnvKey); domain == "" && tenant != "" {
+		domain = fmt.Sprintf("%s.auth0.com", tenant)
+	}
+
+	if *natsURL == "" {
+		*natsURL = "nats://localhost:4222"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// the requests are paced on the client side, and the rate limited ones wait for the reset
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetryWait = time.Minute
+	httpConfig.RateLimits = []httpclient.EndpointRateLimit{{PathPrefix: "/api/v2/users", Rate: *rate, Burst: 1}}
+	client, err := auth0.NewMetadataMigrationClient(ctx, httpConfig, auth0.Config{Domain: domain})
+	if err != nil {
+		fail("failed to create the Auth0 client: %v", err)
+	}
+
+	natsClient, err := nats.NewClient(ctx, nats.Config{URL: *natsURL, Timeout: *timeout})
+	if err != nil {
+		fail("failed to connect to NATS: %v", err)
+	}
+	defer natsClient.Close()
+
+	js, err := natsClient.JetStream()
+	if err != nil {
+		fail("failed to create JetStream client: %v", err)
+	}
+	kv, err := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  constants.KVBucketNameMigrations,
+		History: 1,
+		Storage: jetstream.FileStorage,
+	})
+	if errors.Is(err, jetstream.ErrBucketExists) {
+		kv, err = js.KeyValue(ctx, constants.KVBucketNameMigrations)
+	}
+	if err != nil {
+		fail("failed to initialize the %s KV bucket: %v", constants.KVBucketNameMigrations, err)
+	}
+
+	runner, err := metadatamigrate.NewRunner(*name, client, client, metadatamigrate.NewKVCheckpointStore(kv),
+		metadatamigrate.RenameFields(renames),
+		metadatamigrate.WithDryRun(*dryRun),
+	)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if *reset && !*dryRun {
+		if err := runner.Reset(ctx); err != nil {
+			fail("failed to reset the migration: %v", err)
+		}
+	}
+
+	checkpoint, err := runner.Run(ctx)
+	if checkpoint != nil {
+		fmt.Printf("scanned %d users, patched %d, failed %d\n", checkpoint.Scanned, checkpoint.Patched, checkpoint.Failed)
+		for _, userID := range checkpoint.FailedUsers {
+			fmt.Printf("failed: %s\n", userID)
+		}
+	}
+	if err != nil {
+		fail("migration interrupted, run it again to resume: %v", err)
+	}
+	if checkpoint.Done && !*dryRun {
+		fmt.Printf("migration %s completed\n", *name)
+	}
+}
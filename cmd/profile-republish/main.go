@@ -0,0 +1,146 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Command profile-republish asks the running auth service to publish the profile documents
+// of the users to the profiles stream again, to rebuild a search index or to backfill the stream.
+// It sends a republish profile changed event per user, the service reads each user through the
+// identity provider, so the rate is bounded to spare the provider API limits.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/eventschema"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/profilestream"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	logging "github.com/linuxfoundation/lfx-v2-auth-service/pkg/log"
+)
+
+func init() {
+	logging.InitStructureLogConfig()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [flags]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "republishes the users with a document in the %s stream, and the subs of -subs\n", constants.StreamNameProfiles)
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+// readSubs reads one sub per line, the empty lines are skipped
+func readSubs(r io.Reader) ([]string, error) {
+	var subs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if sub := strings.TrimSpace(scanner.Text()); sub != "" {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, scanner.Err()
+}
+
+func main() {
+	natsURL := flag.String("nats-url", os.Getenv("NATS_URL"), "NATS server URL")
+	timeout := flag.Duration("timeout", 10*time.Second, "NATS request timeout")
+	subsFile := flag.String("subs", "", "file with one sub per line to republish as well, - for stdin")
+	streamSubs := flag.Bool("stream", true, "republish the users with a document in the stream")
+	rate := flag.Int("rate", 20, "maximum number of users republished per second")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 0 || *rate <= 0 || (*subsFile == "" && !*streamSubs) {
+		usage()
+	}
+
+	if *natsURL == "" {
+		*natsURL = "nats://localhost:4222"
+	}
+
+	ctx := context.Background()
+
+	schemas, err := eventschema.NewRegistry()
+	if err != nil {
+		fail("failed to load the event schemas: %v", err)
+	}
+
+	natsClient, err := nats.NewClient(ctx, nats.Config{URL: *natsURL, Timeout: *timeout}, nats.WithEventSchemas(schemas))
+	if err != nil {
+		fail("failed to connect to NATS: %v", err)
+	}
+	defer natsClient.Close()
+
+	seen := make(map[string]struct{})
+	var subs []string
+	add := func(more []string) {
+		for _, sub := range more {
+			if _, ok := seen[sub]; !ok {
+				seen[sub] = struct{}{}
+				subs = append(subs, sub)
+			}
+		}
+	}
+
+	if *streamSubs {
+		js, err := natsClient.JetStream()
+		if err != nil {
+			fail("failed to create JetStream client: %v", err)
+		}
+		listed, err := profilestream.ListSubs(ctx, js)
+		if err != nil {
+			fail("failed to list the profiles stream: %v", err)
+		}
+		add(listed)
+	}
+
+	if *subsFile != "" {
+		input := os.Stdin
+		if *subsFile != "-" {
+			file, err := os.Open(*subsFile)
+			if err != nil {
+				fail("failed to open %s: %v", *subsFile, err)
+			}
+			defer file.Close()
+			input = file
+		}
+		read, err := readSubs(input)
+		if err != nil {
+			fail("failed to read the subs: %v", err)
+		}
+		add(read)
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(*rate))
+	defer ticker.Stop()
+
+	for i, sub := range subs {
+		data, err := json.Marshal(&model.UserProfileChanged{
+			Sub:       sub,
+			Reason:    model.ProfileChangeRepublish,
+			ChangedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			fail("failed to marshal the republish event: %v", err)
+		}
+		if err := natsClient.Publish(ctx, constants.UserProfileChangedSubject, data); err != nil {
+			fail("failed to publish the republish event (%d/%d sent): %v", i, len(subs), err)
+		}
+		<-ticker.C
+	}
+
+	fmt.Printf("requested the republish of %d users\n", len(subs))
+}
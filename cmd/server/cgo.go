@@ -0,0 +1,9 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+//go:build cgo
+
+package main
+
+// cgoEnabled reports whether the binary links the C library, the released binaries are static
+const cgoEnabled = true
@@ -13,6 +13,380 @@ var _ = dsl.API("auth", func() {
 	dsl.Version("1.0")
 })
 
+// UserInfoAddress is the OIDC standard address claim
+var UserInfoAddress = dsl.Type("UserInfoAddress", func() {
+	dsl.Attribute("street_address", dsl.String, "Street address")
+	dsl.Attribute("locality", dsl.String, "City or locality")
+	dsl.Attribute("region", dsl.String, "State, province or region")
+	dsl.Attribute("postal_code", dsl.String, "Zip or postal code")
+	dsl.Attribute("country", dsl.String, "Country name")
+})
+
+// UserInfo is the OIDC standard claims of the token bearer
+var UserInfo = dsl.Type("UserInfo", func() {
+	dsl.Attribute("sub", dsl.String, "Subject identifier", func() {
+		dsl.Example("auth0|123456789")
+	})
+	dsl.Attribute("name", dsl.String, "Full name")
+	dsl.Attribute("given_name", dsl.String, "Given name")
+	dsl.Attribute("family_name", dsl.String, "Family name")
+	dsl.Attribute("preferred_username", dsl.String, "Username")
+	dsl.Attribute("picture", dsl.String, "Profile picture URL")
+	dsl.Attribute("email", dsl.String, "Primary email")
+	dsl.Attribute("zoneinfo", dsl.String, "Time zone")
+	dsl.Attribute("phone_number", dsl.String, "Phone number")
+	dsl.Attribute("address", UserInfoAddress, "Postal address")
+	dsl.Required("sub")
+})
+
+// ProfileChange is the notification of a change of the profile of the token bearer
+var ProfileChange = dsl.Type("ProfileChange", func() {
+	dsl.Attribute("sub", dsl.String, "Subject identifier of the user", func() {
+		dsl.Example("auth0|123456789")
+	})
+	dsl.Attribute("reason", dsl.String, "Operation that changed the profile", func() {
+		dsl.Enum("subscribed", "update", "admin_update", "enrichment", "delete", "restore", "identity_link", "identity_unlink", "primary_email", "merge")
+	})
+	dsl.Attribute("changed_at", dsl.String, "Time of the change", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("sub", "reason", "changed_at")
+})
+
+// AdminEvent is an internal event bridged to the admin dashboards
+var AdminEvent = dsl.Type("AdminEvent", func() {
+	dsl.Attribute("kind", dsl.String, "Kind of the event", func() {
+		dsl.Enum("subscribed", "sync_status", "audit", "user_merged", "profile_changed")
+	})
+	dsl.Attribute("at", dsl.String, "Time the event was bridged", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Attribute("data", dsl.MapOf(dsl.String, dsl.Any), "Event data, the user identifiers are redacted")
+	dsl.Attribute("dropped", dsl.Int, "Number of events dropped before this one because the client was behind")
+	dsl.Required("kind", "at")
+})
+
+// SharedProfile is the view of a profile resolved from a share link
+var SharedProfile = dsl.Type("SharedProfile", func() {
+	dsl.Attribute("username", dsl.String, "Username")
+	dsl.Attribute("name", dsl.String, "Full name")
+	dsl.Attribute("picture", dsl.String, "Profile picture URL")
+	dsl.Attribute("job_title", dsl.String, "Job title")
+	dsl.Attribute("organization", dsl.String, "Organization")
+	dsl.Attribute("organization_verified", dsl.Boolean, "Whether the organization matches a verified email domain")
+})
+
+// UserDataReply is the reply of the REST mirrors of the NATS operations, the same as the NATS reply
+var UserDataReply = dsl.Type("UserDataReply", func() {
+	dsl.Attribute("success", dsl.Boolean, "Whether the operation succeeded")
+	dsl.Attribute("message", dsl.String, "Outcome of the operation, when it has no data")
+	dsl.Attribute("data", dsl.Any, "Data of the operation, the same as the data of the NATS reply")
+	dsl.Attribute("error", dsl.String, "Reason of the failure", func() {
+		// the error replies implement the error interface, which owns the Error name
+		dsl.Meta("struct:field:name", "Reason")
+	})
+	dsl.Attribute("error_code", dsl.String, "Stable identifier of the failure, like the error_code of the NATS reply", func() {
+		dsl.Example("not_found")
+	})
+	dsl.Attribute("retryable", dsl.Boolean, "Whether the failure is transient")
+	dsl.Attribute("retry_after_ms", dsl.Int64, "Delay before retrying a transient failure, in milliseconds")
+	dsl.Attribute("stale", dsl.Boolean, "Whether the data was served from the users cache while the identity provider is unavailable")
+	dsl.Attribute("age", dsl.Int64, "How long ago the stale data was cached, in seconds")
+	dsl.Required("success")
+})
+
+// FailedReply is the reply of the REST mirrors to a failed operation
+var FailedReply = dsl.Type("FailedReply", func() {
+	dsl.Extend(UserDataReply)
+})
+
+// FailedUnauthorizedReply is the reply of the REST mirrors to an operation refused for the credentials
+var FailedUnauthorizedReply = dsl.Type("FailedUnauthorizedReply", func() {
+	dsl.Extend(UserDataReply)
+})
+
+// FailedForbiddenReply is the reply of the REST mirrors to an operation not allowed to the token bearer
+var FailedForbiddenReply = dsl.Type("FailedForbiddenReply", func() {
+	dsl.Extend(UserDataReply)
+})
+
+// FailedNotFoundReply is the reply of the REST mirrors to an operation on a missing resource
+var FailedNotFoundReply = dsl.Type("FailedNotFoundReply", func() {
+	dsl.Extend(UserDataReply)
+})
+
+// RetryableReply is the reply of the REST mirrors to an operation failed on a transient error
+var RetryableReply = dsl.Type("RetryableReply", func() {
+	dsl.Extend(UserDataReply)
+})
+
+// mirrorHeaders maps the NATS message headers honoured by the REST mirrors, the X-Caller-Token
+// header is left out so the REST callers are unknown
+func mirrorHeaders() {
+	dsl.Header("authorization:Authorization")
+	dsl.Header("accept_language:Accept-Language")
+}
+
+// mirrorPayload describes the attributes of the payloads of the REST mirrors set by mirrorHeaders
+func mirrorPayload() {
+	dsl.Attribute("authorization", dsl.String, "Bearer access token", func() {
+		dsl.Example("Bearer eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...")
+	})
+	dsl.Attribute("accept_language", dsl.String, "Locale of the messages of the reply", func() {
+		dsl.Example("pt-BR")
+	})
+}
+
+// mirrorErrors declares the errors of the REST mirrors
+func mirrorErrors() {
+	dsl.Error("Unauthorized", dsl.String, "Missing or invalid bearer token")
+	dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable")
+	dsl.Error("Failed", FailedReply, "The operation failed, the reply carries the reason")
+	dsl.Error("FailedUnauthorized", FailedUnauthorizedReply, "The operation was refused for the credentials, the reply carries the reason")
+	dsl.Error("FailedForbidden", FailedForbiddenReply, "The operation is not allowed to the token bearer, the reply carries the reason")
+	dsl.Error("FailedNotFound", FailedNotFoundReply, "The resource of the operation was not found, the reply carries the reason")
+	dsl.Error("Retryable", RetryableReply, "The operation failed on a transient error, the reply carries the retry hint")
+}
+
+// mirrorResponses maps the errors of the REST mirrors to the HTTP statuses
+func mirrorResponses() {
+	dsl.Response(dsl.StatusOK)
+	dsl.Response("Unauthorized", dsl.StatusUnauthorized)
+	dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+	dsl.Response("Failed", dsl.StatusUnprocessableEntity)
+	dsl.Response("FailedUnauthorized", dsl.StatusUnauthorized)
+	dsl.Response("FailedForbidden", dsl.StatusForbidden)
+	dsl.Response("FailedNotFound", dsl.StatusNotFound)
+	dsl.Response("Retryable", dsl.StatusServiceUnavailable)
+}
+
+// CallerAllowlistEntry is an entry of the caller allowlist, the id is the calling service itself
+var CallerAllowlistEntry = dsl.Type("CallerAllowlistEntry", func() {
+	dsl.Attribute("id", dsl.String, "Calling service, as named by its service token in the X-Caller-Token header", func() {
+		dsl.Example("cla-service")
+	})
+	dsl.Attribute("description", dsl.String, "Free text description of the entry")
+	dsl.Attribute("capabilities", dsl.ArrayOf(dsl.String, func() {
+		dsl.Enum("email_owner", "verbose_errors", "metadata_enrichment")
+	}), "Capabilities granted to the calling service, sorted")
+	dsl.Attribute("created_at", dsl.String, "Time the entry was created", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Attribute("updated_at", dsl.String, "Time the entry last changed, putting the same state again doesn't change it", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("id", "capabilities", "created_at", "updated_at")
+})
+
+// EmailBackupCodes are the one-time codes verifying an email in place of the OTP
+var EmailBackupCodes = dsl.Type("EmailBackupCodes", func() {
+	dsl.Attribute("email", dsl.String, "Email the codes verify", func() {
+		dsl.Format(dsl.FormatEmail)
+		dsl.Example("jane@personal.example")
+	})
+	dsl.Attribute("codes", dsl.ArrayOf(dsl.String), "One-time codes, sent as the OTP of the email verification. They are only returned here.", func() {
+		dsl.Example([]string{"K7QM2-XH4PD", "9VRTN-C3WEA"})
+	})
+	dsl.Attribute("expires_at", dsl.String, "Time the codes expire", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("email", "codes", "expires_at")
+})
+
+// FieldProvenance is the origin of the value of a metadata field
+var FieldProvenance = dsl.Type("FieldProvenance", func() {
+	dsl.Attribute("source", dsl.String, "Who set the value", func() {
+		dsl.Enum("user", "admin", "enrichment")
+	})
+	dsl.Attribute("actor", dsl.String, "Subject of the user or admin, or calling service of the enrichment job", func() {
+		dsl.Example("auth0|123456789")
+	})
+	dsl.Attribute("updated_at", dsl.String, "Time the value was set", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("source", "updated_at")
+})
+
+// AdminUserMetadata is the metadata of a user with the provenance of its fields
+var AdminUserMetadata = dsl.Type("AdminUserMetadata", func() {
+	dsl.Attribute("user_id", dsl.String, "Identifier of the user", func() {
+		dsl.Example("auth0|123456789")
+	})
+	dsl.Attribute("username", dsl.String, "Username of the user")
+	dsl.Attribute("user_metadata", dsl.MapOf(dsl.String, dsl.Any), "Metadata of the user")
+	dsl.Attribute("provenance", dsl.MapOf(dsl.String, FieldProvenance), "Provenance of the metadata fields, the fields without provenance were set before it was tracked or by the identity provider")
+	dsl.Required("user_id", "user_metadata", "provenance")
+})
+
+// SupportBundleBuild identifies the build of the replica
+var SupportBundleBuild = dsl.Type("SupportBundleBuild", func() {
+	dsl.Attribute("version", dsl.String, "Version of the service", func() {
+		dsl.Example("v1.4.0")
+	})
+	dsl.Attribute("build_time", dsl.String, "Time the binary was built")
+	dsl.Attribute("git_commit", dsl.String, "Commit the binary was built from")
+	dsl.Attribute("go_version", dsl.String, "Go runtime version", func() {
+		dsl.Example("go1.24.4")
+	})
+	dsl.Required("version", "build_time", "git_commit", "go_version")
+})
+
+// SupportBundleConfigError is a configuration problem, as reported by validate-config
+var SupportBundleConfigError = dsl.Type("SupportBundleConfigError", func() {
+	dsl.Attribute("component", dsl.String, "Part of the service the configuration belongs to", func() {
+		dsl.Example("user_repository")
+	})
+	dsl.Attribute("key", dsl.String, "Environment variable at fault, when it can be pinned down")
+	dsl.Attribute("message", dsl.String, "Description of the problem")
+	dsl.Required("component", "message")
+})
+
+// SupportBundleProvider is the recent health of an upstream identity provider
+var SupportBundleProvider = dsl.Type("SupportBundleProvider", func() {
+	dsl.Attribute("provider", dsl.String, "Identity provider", func() {
+		dsl.Example("auth0")
+	})
+	dsl.Attribute("samples", dsl.Int, "Number of upstream calls in the window, retries included")
+	dsl.Attribute("failures", dsl.Int, "Number of failed upstream calls in the window")
+	dsl.Attribute("error_rate", dsl.Float64, "Ratio of failed upstream calls")
+	dsl.Attribute("p95_ms", dsl.Int64, "95th percentile latency of the calls, in milliseconds")
+	dsl.Attribute("circuit_state", dsl.String, "State of the circuit of the provider", func() {
+		dsl.Enum("closed", "open", "half_open")
+	})
+	dsl.Attribute("last_failure_at", dsl.String, "Time of the last failed call in the window", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("provider", "samples", "failures", "error_rate", "p95_ms", "circuit_state")
+})
+
+// SupportBundleUserCache is the usage of the users cache since the replica started
+var SupportBundleUserCache = dsl.Type("SupportBundleUserCache", func() {
+	dsl.Attribute("kind", dsl.String, "Users cache backend", func() {
+		dsl.Enum("memory", "nats")
+	})
+	dsl.Attribute("hits", dsl.Int64, "Number of lookups served from the cache")
+	dsl.Attribute("misses", dsl.Int64, "Number of lookups sent to the identity provider")
+	dsl.Attribute("entries", dsl.Int, "Number of cached lookup keys, only reported by the memory cache")
+	dsl.Required("kind", "hits", "misses")
+})
+
+// SupportBundleSyncStatus is the outcome of the last sync of the Authelia users seen by the replica
+var SupportBundleSyncStatus = dsl.Type("SupportBundleSyncStatus", func() {
+	dsl.Attribute("succeeded", dsl.Boolean, "Whether the sync succeeded")
+	dsl.Attribute("changed", dsl.Int, "Number of users changed by the sync")
+	dsl.Attribute("error", dsl.String, "Error of the failed sync")
+	dsl.Attribute("synced_at", dsl.String, "Time of the sync", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("succeeded", "changed", "synced_at")
+})
+
+// SupportBundle gathers the non-sensitive diagnostics of a replica, for incident tickets
+var SupportBundle = dsl.Type("SupportBundle", func() {
+	dsl.Attribute("generated_at", dsl.String, "Time the bundle was generated", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Attribute("build", SupportBundleBuild, "Build of the replica")
+	dsl.Attribute("config", dsl.MapOf(dsl.String, dsl.String), "Effective configuration from the environment, the secrets are redacted")
+	dsl.Attribute("config_errors", dsl.ArrayOf(SupportBundleConfigError), "Problems found in the configuration")
+	dsl.Attribute("providers", dsl.ArrayOf(SupportBundleProvider), "Recent health of the upstream identity providers")
+	dsl.Attribute("user_cache", SupportBundleUserCache, "Usage of the users cache, when enabled")
+	dsl.Attribute("last_sync", SupportBundleSyncStatus, "Last sync of the Authelia users seen by the replica, if any")
+	dsl.Required("generated_at", "build", "config", "config_errors", "providers")
+})
+
+// WebhookDelivery is an attempt to post an audit event to the audit webhook
+var WebhookDelivery = dsl.Type("WebhookDelivery", func() {
+	dsl.Attribute("action", dsl.String, "Action of the audit event", func() {
+		dsl.Example("user_metadata.update")
+	})
+	dsl.Attribute("status_code", dsl.Int, "HTTP status of the webhook reply, absent when the webhook wasn't reached")
+	dsl.Attribute("error", dsl.String, "Error of the failed delivery")
+	dsl.Attribute("duration_ms", dsl.Int64, "Duration of the delivery in milliseconds")
+	dsl.Attribute("delivered_at", dsl.String, "Time of the delivery", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("action", "duration_ms", "delivered_at")
+})
+
+// WebhookDeliveries are the last deliveries of the audit webhook seen by a replica
+var WebhookDeliveries = dsl.Type("WebhookDeliveries", func() {
+	dsl.Attribute("deliveries", dsl.ArrayOf(WebhookDelivery), "Last deliveries, the most recent first")
+	dsl.Required("deliveries")
+})
+
+// RunbookStep is the outcome of a step of a runbook operation
+var RunbookStep = dsl.Type("RunbookStep", func() {
+	dsl.Attribute("name", dsl.String, "Name of the step", func() {
+		dsl.Example("verify")
+	})
+	dsl.Attribute("outcome", dsl.String, "Outcome of the step", func() {
+		dsl.Enum("success", "failure", "skipped")
+	})
+	dsl.Attribute("detail", dsl.String, "Detail of the step, the error when it failed", func() {
+		dsl.Example("accepted by the identity provider")
+	})
+	dsl.Required("name", "outcome")
+})
+
+// RunbookResult is the outcome of a runbook operation, it failed when one of its steps did
+var RunbookResult = dsl.Type("RunbookResult", func() {
+	dsl.Attribute("operation", dsl.String, "Audit action of the operation", func() {
+		dsl.Example("runbook.m2m_token_renew")
+	})
+	dsl.Attribute("target", dsl.String, "Target of the operation", func() {
+		dsl.Example("m2m_token")
+	})
+	dsl.Attribute("outcome", dsl.String, "Outcome of the operation", func() {
+		dsl.Enum("success", "failure")
+	})
+	dsl.Attribute("steps", dsl.ArrayOf(RunbookStep), "Steps of the operation, in the order they were run")
+	dsl.Attribute("at", dsl.String, "Time of the operation", func() {
+		dsl.Format(dsl.FormatDateTime)
+	})
+	dsl.Required("operation", "target", "outcome", "steps", "at")
+})
+
+// EventSchema is the JSON Schema of the events published on a subject
+var EventSchema = dsl.Type("EventSchema", func() {
+	dsl.Attribute("subject", dsl.String, "NATS subject the events are published on", func() {
+		dsl.Example("lfx.auth-service.user_profile.changed")
+	})
+	dsl.Attribute("title", dsl.String, "Name of the event type", func() {
+		dsl.Example("UserProfileChanged")
+	})
+	dsl.Attribute("schema", dsl.Any, "JSON Schema (draft 2020-12) of the events")
+	dsl.Required("subject", "title", "schema")
+})
+
+// adminConfigPayload describes the authorization of the admin configuration resources
+func adminConfigPayload() {
+	dsl.Attribute("authorization", dsl.String, "Bearer access token of an admin", func() {
+		dsl.Example("Bearer eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...")
+	})
+}
+
+// adminConfigErrors declares the errors of the admin configuration resources
+func adminConfigErrors() {
+	dsl.Error("BadRequest", dsl.String, "Invalid resource")
+	dsl.Error("Unauthorized", dsl.String, "Missing or invalid bearer token")
+	dsl.Error("Forbidden", dsl.String, "Not an admin")
+	dsl.Error("NotFound", dsl.String, "Resource not found")
+	dsl.Error("Conflict", dsl.String, "Resource changed concurrently, try again")
+	dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable, or the resource kind is disabled")
+}
+
+// adminConfigResponses maps the errors of the admin configuration resources to the HTTP statuses
+func adminConfigResponses() {
+	dsl.Header("authorization:Authorization")
+	dsl.Response("BadRequest", dsl.StatusBadRequest)
+	dsl.Response("Unauthorized", dsl.StatusUnauthorized)
+	dsl.Response("Forbidden", dsl.StatusForbidden)
+	dsl.Response("NotFound", dsl.StatusNotFound)
+	dsl.Response("Conflict", dsl.StatusConflict)
+	dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+}
+
 // Service describes the health check service
 var _ = dsl.Service("auth-service", func() {
 	dsl.Description("Auth service")
@@ -52,4 +426,480 @@ var _ = dsl.Service("auth-service", func() {
 			})
 		})
 	})
+
+	// OIDC UserInfo endpoint
+	dsl.Method("userinfo", func() {
+		dsl.Description("Return the OIDC standard claims of the bearer of the access token.")
+		dsl.Payload(func() {
+			dsl.Attribute("authorization", dsl.String, "Bearer access token", func() {
+				dsl.Example("Bearer eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...")
+			})
+		})
+		dsl.Result(UserInfo)
+
+		dsl.Error("Unauthorized", dsl.String, "Missing or invalid bearer token")
+		dsl.Error("NotFound", dsl.String, "User not found")
+		dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable")
+
+		dsl.HTTP(func() {
+			dsl.GET("/userinfo")
+			dsl.Header("authorization:Authorization")
+			dsl.Response(dsl.StatusOK)
+			dsl.Response("Unauthorized", dsl.StatusUnauthorized)
+			dsl.Response("NotFound", dsl.StatusNotFound)
+			dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+		})
+	})
+
+	// Profile change notifications for browsers, which can't join NATS
+	dsl.Method("profile_events", func() {
+		dsl.Description("Stream the profile changes of the bearer of the access token as Server-Sent Events.")
+		dsl.Payload(func() {
+			dsl.Attribute("authorization", dsl.String, "Bearer access token", func() {
+				dsl.Example("Bearer eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...")
+			})
+			dsl.Attribute("access_token", dsl.String, "Access token, for the clients unable to set headers (EventSource)")
+		})
+		dsl.StreamingResult(ProfileChange)
+
+		dsl.Error("Unauthorized", dsl.String, "Missing or invalid bearer token")
+		dsl.Error("TooManyRequests", dsl.String, "Too many open streams")
+		dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable")
+
+		dsl.HTTP(func() {
+			dsl.GET("/userinfo/events")
+			dsl.Header("authorization:Authorization")
+			dsl.Param("access_token")
+			dsl.ServerSentEvents()
+			dsl.Response(dsl.StatusOK)
+			dsl.Response("Unauthorized", dsl.StatusUnauthorized)
+			dsl.Response("TooManyRequests", dsl.StatusTooManyRequests)
+			dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+		})
+	})
+
+	// Internal events for the admin dashboards
+	dsl.Method("admin_events", func() {
+		dsl.Description("Stream the internal events (sync status, audit, merges, profile changes) to an admin dashboard over a WebSocket.")
+		dsl.Payload(func() {
+			dsl.Attribute("authorization", dsl.String, "Bearer access token of an admin", func() {
+				dsl.Example("Bearer eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...")
+			})
+			dsl.Attribute("access_token", dsl.String, "Access token, for the clients unable to set headers (browser WebSocket)")
+			dsl.Attribute("kinds", dsl.ArrayOf(dsl.String), "Kinds of events to receive, all of them when omitted", func() {
+				dsl.Example([]string{"sync_status", "audit"})
+			})
+		})
+		dsl.StreamingResult(AdminEvent)
+
+		dsl.Error("BadRequest", dsl.String, "Invalid event kinds")
+		dsl.Error("Unauthorized", dsl.String, "Missing or invalid bearer token")
+		dsl.Error("Forbidden", dsl.String, "Not an admin")
+		dsl.Error("TooManyRequests", dsl.String, "Too many open streams")
+		dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable")
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/events")
+			dsl.Header("authorization:Authorization")
+			dsl.Param("access_token")
+			dsl.Param("kinds")
+			dsl.Response(dsl.StatusOK)
+			dsl.Response("BadRequest", dsl.StatusBadRequest)
+			dsl.Response("Unauthorized", dsl.StatusUnauthorized)
+			dsl.Response("Forbidden", dsl.StatusForbidden)
+			dsl.Response("TooManyRequests", dsl.StatusTooManyRequests)
+			dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+		})
+	})
+
+	// REST mirrors of the NATS operations, for the internal consumers unable to use NATS
+	dsl.Method("get_user_metadata", func() {
+		dsl.Description("Return the metadata of a user, like lfx.auth-service.user_metadata.read. The token must grant the read:users_metadata scope, unless the user is the token bearer (current).")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("sub", dsl.String, "Subject identifier or username of the user, current for the token bearer", func() {
+				dsl.Example("auth0|123456789")
+			})
+			dsl.Attribute("format", dsl.String, "Output mode of the metadata, like the X-Response-Format header of the NATS requests", func() {
+				dsl.Enum("oidc")
+			})
+			dsl.Required("sub")
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/users/{sub}/metadata")
+			mirrorHeaders()
+			dsl.Header("format:X-Response-Format")
+			mirrorResponses()
+		})
+	})
+
+	dsl.Method("update_current_user_metadata", func() {
+		dsl.Description("Update the metadata of the token bearer, like lfx.auth-service.user_metadata.update. The token must grant the update:current_user_metadata scope.")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("user_metadata", dsl.MapOf(dsl.String, dsl.Any), "Metadata fields to update", func() {
+				dsl.Example(map[string]any{"job_title": "Software Engineer"})
+			})
+			dsl.Required("user_metadata")
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.PATCH("/users/current/metadata")
+			mirrorHeaders()
+			dsl.Body(func() {
+				dsl.Attribute("user_metadata")
+			})
+			mirrorResponses()
+		})
+	})
+
+	dsl.Method("start_email_linking", func() {
+		dsl.Description("Send the one time password verifying an alternate email of the token bearer, like lfx.auth-service.email_linking.send_verification.")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("email", dsl.String, "Alternate email to verify", func() {
+				dsl.Example("john.doe@example.com")
+			})
+			dsl.Required("email")
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/users/current/email-linking")
+			mirrorHeaders()
+			dsl.Body(func() {
+				dsl.Attribute("email")
+			})
+			mirrorResponses()
+		})
+	})
+
+	dsl.Method("verify_email_linking", func() {
+		dsl.Description("Verify an alternate email of the token bearer with the one time password, like lfx.auth-service.email_linking.verify.")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("email", dsl.String, "Alternate email to verify", func() {
+				dsl.Example("john.doe@example.com")
+			})
+			dsl.Attribute("otp", dsl.String, "One time password sent to the email", func() {
+				dsl.Example("123456")
+			})
+			dsl.Required("email", "otp")
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/users/current/email-linking/verify")
+			mirrorHeaders()
+			dsl.Body(func() {
+				dsl.Attribute("email")
+				dsl.Attribute("otp")
+			})
+			mirrorResponses()
+		})
+	})
+
+	dsl.Method("change_primary_email", func() {
+		dsl.Description("Change the primary email of the token bearer to an email verified with the email linking flow, like lfx.auth-service.primary_email.change.")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("identity_token_ref", dsl.String, "Reference returned by the verification of the new email", func() {
+				dsl.Example("3q2-7wXy...")
+			})
+			dsl.Required("identity_token_ref")
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/users/current/primary-email")
+			mirrorHeaders()
+			dsl.Body(func() {
+				dsl.Attribute("identity_token_ref")
+			})
+			mirrorResponses()
+		})
+	})
+
+	dsl.Method("upload_current_user_picture", func() {
+		dsl.Description("Store the image of the body as the profile picture of the token bearer, the picture metadata field is set to its URL like lfx.auth-service.user_metadata.update. The token must grant the update:current_user_metadata scope.")
+		dsl.Payload(func() {
+			mirrorPayload()
+			dsl.Attribute("content_type", dsl.String, "Type of the image: PNG, JPEG, GIF or WebP", func() {
+				dsl.Example("image/png")
+			})
+		})
+		dsl.Result(UserDataReply)
+		mirrorErrors()
+
+		dsl.HTTP(func() {
+			dsl.PUT("/users/current/picture")
+			mirrorHeaders()
+			dsl.Header("content_type:Content-Type")
+			dsl.SkipRequestBodyEncodeDecode()
+			mirrorResponses()
+		})
+	})
+
+	// Profile share links, resolved outside authenticated contexts
+	dsl.Method("resolve_profile_share", func() {
+		dsl.Description("Return the shared view of the profile of a share link, no access token is required.")
+		dsl.Payload(func() {
+			dsl.Attribute("token", dsl.String, "Token of the share link")
+			dsl.Required("token")
+		})
+		dsl.Result(SharedProfile)
+
+		dsl.Error("NotFound", dsl.String, "Invalid or expired share link, or the profile no longer exists")
+		dsl.Error("ServiceUnavailable", dsl.String, "Service unavailable")
+
+		dsl.HTTP(func() {
+			dsl.GET("/profiles/shared/{token}")
+			dsl.Response(dsl.StatusOK)
+			dsl.Response("NotFound", dsl.StatusNotFound)
+			dsl.Response("ServiceUnavailable", dsl.StatusServiceUnavailable)
+		})
+	})
+
+	// Schemas of the published events, for the consumers generating the event types
+	dsl.Method("list_event_schemas", func() {
+		dsl.Description("List the JSON Schemas of the events published by the service, sorted by subject, no access token is required.")
+		dsl.Result(dsl.ArrayOf(EventSchema))
+
+		dsl.HTTP(func() {
+			dsl.GET("/events/schemas")
+			dsl.Response(dsl.StatusOK)
+		})
+	})
+
+	dsl.Method("get_event_schema", func() {
+		dsl.Description("Return the JSON Schema of the events published on a subject, no access token is required.")
+		dsl.Payload(func() {
+			dsl.Attribute("subject", dsl.String, "NATS subject the events are published on")
+			dsl.Required("subject")
+		})
+		dsl.Result(EventSchema)
+
+		dsl.Error("NotFound", dsl.String, "No event is published on the subject")
+
+		dsl.HTTP(func() {
+			dsl.GET("/events/schemas/{subject}")
+			dsl.Response(dsl.StatusOK)
+			dsl.Response("NotFound", dsl.StatusNotFound)
+		})
+	})
+
+	// Admin configuration resources, with stable ids and idempotent PUTs for declarative clients (Terraform)
+	dsl.Method("list_callers", func() {
+		dsl.Description("List the caller allowlist, sorted by id.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+		})
+		dsl.Result(dsl.ArrayOf(CallerAllowlistEntry))
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/callers")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("get_caller", func() {
+		dsl.Description("Return an entry of the caller allowlist.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("id", dsl.String, "Calling service")
+			dsl.Required("id")
+		})
+		dsl.Result(CallerAllowlistEntry)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/callers/{id}")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("put_caller", func() {
+		dsl.Description("Create or replace an entry of the caller allowlist, putting the same state again changes nothing.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("id", dsl.String, "Calling service, as named by its service token in the X-Caller-Token header", func() {
+				dsl.Example("cla-service")
+			})
+			dsl.Attribute("description", dsl.String, "Free text description of the entry")
+			dsl.Attribute("capabilities", dsl.ArrayOf(dsl.String), "Capabilities granted to the calling service", func() {
+				dsl.Example([]string{"email_owner"})
+			})
+			dsl.Required("id", "capabilities")
+		})
+		dsl.Result(CallerAllowlistEntry)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.PUT("/admin/callers/{id}")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("delete_caller", func() {
+		dsl.Description("Remove an entry of the caller allowlist.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("id", dsl.String, "Calling service")
+			dsl.Required("id")
+		})
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.DELETE("/admin/callers/{id}")
+			dsl.Response(dsl.StatusNoContent)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("get_admin_user_metadata", func() {
+		dsl.Description("Return the metadata of a user with the provenance of its fields.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("user", dsl.String, "Username or subject identifier of the user", func() {
+				dsl.Example("auth0|123456789")
+			})
+			dsl.Required("user")
+		})
+		dsl.Result(AdminUserMetadata)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/users/{user}/metadata")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("issue_email_backup_codes", func() {
+		dsl.Description("Issue one-time backup codes verifying an email in place of the OTP, replacing the previous codes of the email.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("email", dsl.String, "Email to verify", func() {
+				dsl.Example("jane@personal.example")
+			})
+			dsl.Required("email")
+		})
+		dsl.Result(EmailBackupCodes)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/admin/email-backup-codes")
+			dsl.Response(dsl.StatusCreated)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("purge_user_cache", func() {
+		dsl.Description("Remove a user from the users cache, the next lookups read it again from the identity provider.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("user", dsl.String, "Username or subject identifier of the user", func() {
+				dsl.Example("auth0|123456789")
+			})
+			dsl.Required("user")
+		})
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/admin/users/{user}/cache/purge")
+			dsl.Response(dsl.StatusNoContent)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("list_webhook_deliveries", func() {
+		dsl.Description("List the last deliveries of the audit webhook seen by the replica.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+		})
+		dsl.Result(WebhookDeliveries)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/webhook-deliveries")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("get_support_bundle", func() {
+		dsl.Description("Collect the non-sensitive diagnostics of the replica into a bundle to attach to incident tickets.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+		})
+		dsl.Result(SupportBundle)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.GET("/admin/support-bundle")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("renew_m2m_token", func() {
+		dsl.Description("Renew the M2M tokens of the identity provider and verify its API accepts them, after the client credentials are rotated.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+		})
+		dsl.Result(RunbookResult)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/admin/runbook/m2m-token/renew")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("refresh_jwks", func() {
+		dsl.Description("Fetch the JWKS verifying the user tokens now, after the signing keys are rotated.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+		})
+		dsl.Result(RunbookResult)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/admin/runbook/jwks/refresh")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
+
+	dsl.Method("rebuild_email_index", func() {
+		dsl.Description("Rebuild the email index of a user in the identity provider, when it keeps one, and repair the users cache and the profiles stream from its record.")
+		dsl.Payload(func() {
+			adminConfigPayload()
+			dsl.Attribute("user", dsl.String, "Username, email or subject identifier of the user", func() {
+				dsl.Example("auth0|123456789")
+			})
+			dsl.Required("user")
+		})
+		dsl.Result(RunbookResult)
+		adminConfigErrors()
+
+		dsl.HTTP(func() {
+			dsl.POST("/admin/users/{user}/email-index/rebuild")
+			dsl.Response(dsl.StatusOK)
+			adminConfigResponses()
+		})
+	})
 })
@@ -7,18 +7,27 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/service"
 	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
 	authserver "github.com/linuxfoundation/lfx-v2-auth-service/gen/http/auth_service/server"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/adminui"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/objectstorage"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 
+	"github.com/gorilla/websocket"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"goa.design/clue/debug"
 	goahttp "goa.design/goa/v3/http"
 )
 
-// handleHTTPServer starts the HTTP server for health check endpoints
+// handleHTTPServer starts the HTTP server (health checks, userinfo and the event streams),
+// the server is shut down gracefully once the context is canceled
 func handleHTTPServer(ctx context.Context, host string, authEndpoints *authservice.Endpoints, wg *sync.WaitGroup, errc chan<- error, dbg bool) {
 
 	// Provide the transport specific request decoder and response encoder.
@@ -49,14 +58,31 @@ func handleHTTPServer(ctx context.Context, host string, authEndpoints *authservi
 	)
 	{
 		eh := errorHandler(ctx)
-		authServer = authserver.New(authEndpoints, mux, dec, enc, eh, nil)
+		authServer = authserver.New(authEndpoints, mux, dec, enc, eh, nil,
+			websocketUpgrader(), authserver.NewConnConfigurer(cancelOnDisconnect))
 	}
 	// Configure the mux.
 	authserver.Mount(mux, authServer)
 
+	// The admin UI is a static page, its actions call the admin endpoints with the token of the admin
+	if adminUI, _ := strconv.ParseBool(os.Getenv(constants.AdminUIEnvKey)); adminUI {
+		ui := adminui.Handler()
+		mux.Handle(http.MethodGet, adminui.Prefix, ui.ServeHTTP)
+		mux.Handle(http.MethodGet, adminui.Prefix+"/{*filepath}", ui.ServeHTTP)
+		slog.InfoContext(ctx, "admin UI mounted", "pattern", adminui.Prefix)
+	}
+
+	// The local storage of the profile pictures serves them itself, the buckets serve their own
+	if files, ok := service.ProfilePictureStorage().(http.Handler); ok {
+		mux.Handle(http.MethodGet, objectstorage.LocalPrefix+"/{*filepath}", files.ServeHTTP)
+		slog.InfoContext(ctx, "profile pictures mounted", "pattern", objectstorage.LocalPrefix)
+	}
+
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
+	handler = watermark(handler, service.ResponseMetaFromEnv())
+	handler = requestLogger(handler)
 	if dbg {
 		// Log query and response bodies if debug logs are enabled.
 		handler = debug.HTTP()(handler)
@@ -99,6 +125,41 @@ func handleHTTPServer(ctx context.Context, host string, authEndpoints *authservi
 	}()
 }
 
+// websocketUpgrader upgrades the admin events connections, the browsers send the origin
+// of the dashboard, only the same origin is accepted when no dashboard origin is configured
+func websocketUpgrader() goahttp.Upgrader {
+	upgrader := &websocket.Upgrader{}
+
+	origins := make(map[string]struct{})
+	for _, origin := range strings.Split(os.Getenv(constants.AdminDashboardOriginsEnvKey), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins[strings.TrimSuffix(origin, "/")] = struct{}{}
+		}
+	}
+	if len(origins) > 0 {
+		upgrader.CheckOrigin = func(r *http.Request) bool {
+			_, allowed := origins[r.Header.Get("Origin")]
+			return allowed
+		}
+	}
+	return upgrader
+}
+
+// cancelOnDisconnect reads the connection, the streams only write to it, so the request
+// is canceled (and the subscription released) once the client is gone
+func cancelOnDisconnect(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn {
+	conn.SetReadLimit(512)
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return conn
+}
+
 // errorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
 // to correlate.
@@ -49,6 +49,7 @@ func main() {
 		bind = flag.String("bind", "*", "interface to bind on")
 	)
 	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [validate-config]\n", os.Args[0])
 		flag.PrintDefaults()
 		os.Exit(2)
 	}
@@ -56,6 +57,11 @@ func main() {
 
 	ctx := context.Background()
 
+	// Validate the configuration and exit, without starting the servers
+	if flag.Arg(0) == validateConfigCommand {
+		os.Exit(validateConfig(ctx, os.Stdout))
+	}
+
 	// Set up OpenTelemetry SDK.
 	// Command-line/environment OTEL_SERVICE_VERSION takes precedence over
 	// the build-time Version variable.
@@ -82,9 +88,16 @@ func main() {
 		"http-port", *port,
 		"graceful-shutdown-seconds", gracefulShutdownSeconds,
 	)
+	if cgoEnabled {
+		slog.WarnContext(ctx, "the binary is built with cgo, it needs the C library of the host and is not the static release build")
+	}
 
 	// Initialize the health service
-	authSvc := service.NewAuthService()
+	authSvc := service.NewAuthService(service.BuildInfo{
+		Version:   Version,
+		BuildTime: BuildTime,
+		GitCommit: GitCommit,
+	})
 
 	// Wrap the service in endpoints
 	authEndpoints := authservice.NewEndpoints(authSvc)
@@ -121,13 +134,18 @@ func main() {
 		"signal", <-errc,
 	)
 
-	// Send cancellation signal to the goroutines
-	cancel()
-
 	// Create a timeout context for graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), gracefulShutdownSeconds*time.Second)
 	defer shutdownCancel()
 
+	// Stop taking NATS requests and let the in-flight ones complete before cancelling them
+	if err := service.DrainSubscriptions(shutdownCtx); err != nil {
+		slog.ErrorContext(ctx, "failed to drain NATS subscriptions", "error", err)
+	}
+
+	// Send cancellation signal to the goroutines
+	cancel()
+
 	// Wait for all goroutines to finish with timeout
 	done := make(chan struct{})
 	go func() {
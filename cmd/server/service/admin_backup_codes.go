@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// IssueEmailBackupCodes issues the one-time backup codes verifying an email in place of the OTP
+func (s *authService) IssueEmailBackupCodes(ctx context.Context, p *authservice.IssueEmailBackupCodesPayload) (*authservice.EmailBackupCodes, error) {
+	admin, errAdmin := s.authenticateAdmin(ctx, stringValue(p.Authorization), constants.AdminConfigRequiredScope)
+	if errAdmin != nil {
+		return nil, errAdmin
+	}
+	store := s.backupCodeStore()
+	if store == nil {
+		return nil, authservice.ServiceUnavailable("email backup codes are disabled")
+	}
+
+	issued, errIssue := store.IssueBackupCodes(ctx, p.Email)
+	if errIssue != nil {
+		return nil, adminConfigError(ctx, errIssue)
+	}
+
+	slog.InfoContext(ctx, "audit: email backup codes issued",
+		"sub", redaction.Redact(admin.ProfileSub()),
+		"email", redaction.RedactEmail(issued.Email),
+		"count", len(issued.Codes),
+		"expires_at", issued.ExpiresAt,
+	)
+	return &authservice.EmailBackupCodes{
+		Email:     issued.Email,
+		Codes:     issued.Codes,
+		ExpiresAt: issued.ExpiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// authenticateAdmin verifies the bearer token grants the scope to one of the admins, and returns the admin
+func (s *authService) authenticateAdmin(ctx context.Context, authorization, scope string) (*model.User, error) {
+	user, errAuthenticate := s.authenticate(ctx, authorization, scope)
+	if errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+	if _, isAdmin := s.adminSubs[user.ProfileSub()]; !isAdmin {
+		slog.WarnContext(ctx, "admin request denied, the user is not an admin",
+			"sub", redaction.Redact(user.ProfileSub()),
+		)
+		return nil, authservice.Forbidden("not an admin")
+	}
+	return user, nil
+}
+
+// callerAllowlistFor authenticates the admin and returns the caller allowlist
+func (s *authService) callerAllowlistFor(ctx context.Context, authorization *string) (*model.User, port.CallerAllowlist, error) {
+	admin, errAdmin := s.authenticateAdmin(ctx, stringValue(authorization), constants.AdminConfigRequiredScope)
+	if errAdmin != nil {
+		return nil, nil, errAdmin
+	}
+	allowlist := s.callerAllowlist()
+	if allowlist == nil {
+		return nil, nil, authservice.ServiceUnavailable("caller allowlist is disabled")
+	}
+	return admin, allowlist, nil
+}
+
+// ListCallers returns the caller allowlist
+func (s *authService) ListCallers(ctx context.Context, p *authservice.ListCallersPayload) ([]*authservice.CallerAllowlistEntry, error) {
+	_, allowlist, err := s.callerAllowlistFor(ctx, p.Authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, errList := allowlist.ListCallers(ctx)
+	if errList != nil {
+		return nil, adminConfigError(ctx, errList)
+	}
+
+	result := make([]*authservice.CallerAllowlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, newCallerAllowlistEntry(entry))
+	}
+	return result, nil
+}
+
+// GetCaller returns an entry of the caller allowlist
+func (s *authService) GetCaller(ctx context.Context, p *authservice.GetCallerPayload) (*authservice.CallerAllowlistEntry, error) {
+	_, allowlist, err := s.callerAllowlistFor(ctx, p.Authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, errGet := allowlist.GetCaller(ctx, p.ID)
+	if errGet != nil {
+		return nil, adminConfigError(ctx, errGet)
+	}
+	return newCallerAllowlistEntry(entry), nil
+}
+
+// PutCaller creates or replaces an entry of the caller allowlist
+func (s *authService) PutCaller(ctx context.Context, p *authservice.PutCallerPayload) (*authservice.CallerAllowlistEntry, error) {
+	admin, allowlist, err := s.callerAllowlistFor(ctx, p.Authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &model.CallerAllowlistEntry{
+		ID:          p.ID,
+		Description: stringValue(p.Description),
+	}
+	for _, capability := range p.Capabilities {
+		entry.Capabilities = append(entry.Capabilities, model.CallerCapability(capability))
+	}
+
+	stored, created, errPut := allowlist.PutCaller(ctx, entry)
+	if errPut != nil {
+		return nil, adminConfigError(ctx, errPut)
+	}
+
+	slog.InfoContext(ctx, "audit: caller allowlist entry put",
+		"sub", redaction.Redact(admin.ProfileSub()),
+		"caller", stored.ID,
+		"capabilities", stored.Capabilities,
+		"created", created,
+	)
+	return newCallerAllowlistEntry(stored), nil
+}
+
+// DeleteCaller removes an entry of the caller allowlist
+func (s *authService) DeleteCaller(ctx context.Context, p *authservice.DeleteCallerPayload) error {
+	admin, allowlist, err := s.callerAllowlistFor(ctx, p.Authorization)
+	if err != nil {
+		return err
+	}
+
+	if errDelete := allowlist.DeleteCaller(ctx, p.ID); errDelete != nil {
+		return adminConfigError(ctx, errDelete)
+	}
+
+	slog.InfoContext(ctx, "audit: caller allowlist entry deleted",
+		"sub", redaction.Redact(admin.ProfileSub()),
+		"caller", p.ID,
+	)
+	return nil
+}
+
+// adminConfigError maps the domain errors to the errors of the admin configuration resources
+func adminConfigError(ctx context.Context, err error) error {
+	var (
+		validation errs.Validation
+		notFound   errs.NotFound
+		conflict   errs.Conflict
+	)
+	switch {
+	case errors.As(err, &validation):
+		return authservice.BadRequest(err.Error())
+	case errors.As(err, &notFound):
+		return authservice.NotFound(err.Error())
+	case errors.As(err, &conflict):
+		return authservice.Conflict(err.Error())
+	default:
+		slog.ErrorContext(ctx, "admin configuration request failed", "error", err)
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+}
+
+func newCallerAllowlistEntry(entry *model.CallerAllowlistEntry) *authservice.CallerAllowlistEntry {
+	result := &authservice.CallerAllowlistEntry{
+		ID:           entry.ID,
+		Capabilities: make([]string, 0, len(entry.Capabilities)),
+		CreatedAt:    entry.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    entry.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if entry.Description != "" {
+		result.Description = &entry.Description
+	}
+	for _, capability := range entry.Capabilities {
+		result.Capabilities = append(result.Capabilities, string(capability))
+	}
+	return result
+}
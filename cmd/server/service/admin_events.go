@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// adminEventSubscribed is the kind of the first event of the stream, it's not an internal event
+const adminEventSubscribed = "subscribed"
+
+// AdminEvents streams the internal events to an admin dashboard over a WebSocket until the client
+// disconnects. The token must grant the admin events scope and its subject must be one of the admins.
+func (s *authService) AdminEvents(ctx context.Context, p *authservice.AdminEventsPayload, stream authservice.AdminEventsServerStream) error {
+	defer stream.Close()
+
+	var (
+		authorization string
+		requested     []string
+	)
+	if p != nil {
+		// the browser WebSocket API can't set headers, the token comes as query parameter
+		authorization = bearerAuthorization(p.Authorization, p.AccessToken)
+		requested = p.Kinds
+	}
+
+	kinds, errKinds := model.ParseAdminEventKinds(requested)
+	if errKinds != nil {
+		return authservice.BadRequest(errKinds.Error())
+	}
+
+	user, errAdmin := s.authenticateAdmin(ctx, authorization, constants.AdminEventsRequiredScope)
+	if errAdmin != nil {
+		return errAdmin
+	}
+
+	feed := s.adminFeed()
+	if feed == nil {
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	events, errSubscribe := feed.SubscribeAdminEvents(ctx, kinds)
+	if errSubscribe != nil {
+		var tooMany errs.TooManyRequests
+		if errors.As(errSubscribe, &tooMany) {
+			return authservice.TooManyRequests(errSubscribe.Error())
+		}
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	slog.InfoContext(ctx, "audit: admin events stream opened",
+		"sub", redaction.Redact(user.ProfileSub()),
+		"kinds", kinds,
+	)
+
+	// the first event upgrades the connection, the dashboard knows it won't miss the next events
+	subscribed := make([]any, 0, len(kinds))
+	for _, kind := range kinds {
+		subscribed = append(subscribed, string(kind))
+	}
+	if err := stream.SendWithContext(ctx, &authservice.AdminEvent{
+		Kind: adminEventSubscribed,
+		At:   time.Now().UTC().Format(time.RFC3339),
+		Data: map[string]any{"kinds": subscribed},
+	}); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.SendWithContext(ctx, newAdminEvent(event)); err != nil {
+			slog.DebugContext(ctx, "admin events stream closed", "error", err)
+			return nil
+		}
+	}
+
+	// the channel is closed once the client is gone
+	return nil
+}
+
+func newAdminEvent(event model.AdminEvent) *authservice.AdminEvent {
+	result := &authservice.AdminEvent{
+		Kind: string(event.Kind),
+		At:   event.At.Format(time.RFC3339),
+		Data: event.Data,
+	}
+	if event.Dropped > 0 {
+		result.Dropped = &event.Dropped
+	}
+	return result
+}
+
+// parseAdminSubs parses the comma separated subjects of the admins
+func parseAdminSubs(value string) map[string]struct{} {
+	subs := make(map[string]struct{})
+	for _, sub := range strings.Split(value, ",") {
+		if sub = strings.TrimSpace(sub); sub != "" {
+			subs[sub] = struct{}{}
+		}
+	}
+	return subs
+}
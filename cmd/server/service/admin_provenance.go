@@ -0,0 +1,78 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// GetAdminUserMetadata returns the metadata of a user with the provenance of its fields
+func (s *authService) GetAdminUserMetadata(ctx context.Context, p *authservice.GetAdminUserMetadataPayload) (*authservice.AdminUserMetadata, error) {
+	if _, errAdmin := s.authenticateAdmin(ctx, stringValue(p.Authorization), constants.AdminConfigRequiredScope); errAdmin != nil {
+		return nil, errAdmin
+	}
+	userReader := s.userReader()
+	if userReader == nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	user, errLookup := userReader.MetadataLookup(ctx, p.User)
+	if errLookup != nil {
+		return nil, adminConfigError(ctx, errLookup)
+	}
+	user, errGet := userReader.GetUser(ctx, user)
+	if errGet != nil {
+		return nil, adminConfigError(ctx, errGet)
+	}
+
+	// the provenance is empty when it isn't recorded, the lookup still serves the support actions
+	var provenance model.MetadataProvenance
+	if provenanceStore := s.provenanceStore(); provenanceStore != nil {
+		recorded, errProvenance := provenanceStore.GetProvenance(ctx, user.UserID)
+		if errProvenance != nil {
+			return nil, adminConfigError(ctx, errProvenance)
+		}
+		provenance = recorded
+	}
+
+	return newAdminUserMetadata(user, provenance)
+}
+
+func newAdminUserMetadata(user *model.User, provenance model.MetadataProvenance) (*authservice.AdminUserMetadata, error) {
+	result := &authservice.AdminUserMetadata{
+		UserID:       user.UserID,
+		UserMetadata: map[string]any{},
+		Provenance:   make(map[string]*authservice.FieldProvenance, len(provenance)),
+	}
+	if user.Username != "" {
+		result.Username = &user.Username
+	}
+	if user.UserMetadata != nil {
+		data, errMarshal := json.Marshal(user.UserMetadata)
+		if errMarshal != nil {
+			return nil, authservice.ServiceUnavailable("auth service unavailable")
+		}
+		if errUnmarshal := json.Unmarshal(data, &result.UserMetadata); errUnmarshal != nil {
+			return nil, authservice.ServiceUnavailable("auth service unavailable")
+		}
+	}
+	for field, origin := range provenance {
+		entry := &authservice.FieldProvenance{
+			Source:    string(origin.Source),
+			UpdatedAt: origin.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if origin.Actor != "" {
+			actor := origin.Actor
+			entry.Actor = &actor
+		}
+		result.Provenance[field] = entry
+	}
+	return result, nil
+}
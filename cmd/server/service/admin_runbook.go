@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/runbook"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// RenewM2mToken renews the M2M tokens of the identity provider and verifies its API accepts them
+func (s *authService) RenewM2mToken(ctx context.Context, p *authservice.RenewM2mTokenPayload) (*authservice.RunbookResult, error) {
+	return s.runRunbook(ctx, stringValue(p.Authorization), func(r *runbook.Runbook, actor string) (*runbook.Result, error) {
+		return r.RenewM2MToken(ctx, actor)
+	})
+}
+
+// RefreshJwks fetches the JWKS verifying the user tokens now
+func (s *authService) RefreshJwks(ctx context.Context, p *authservice.RefreshJwksPayload) (*authservice.RunbookResult, error) {
+	return s.runRunbook(ctx, stringValue(p.Authorization), func(r *runbook.Runbook, actor string) (*runbook.Result, error) {
+		return r.RefreshJWKS(ctx, actor)
+	})
+}
+
+// RebuildEmailIndex rebuilds the email index of the user and repairs the internal indices from its record
+func (s *authService) RebuildEmailIndex(ctx context.Context, p *authservice.RebuildEmailIndexPayload) (*authservice.RunbookResult, error) {
+	return s.runRunbook(ctx, stringValue(p.Authorization), func(r *runbook.Runbook, actor string) (*runbook.Result, error) {
+		return r.RebuildEmailIndex(ctx, actor, p.User)
+	})
+}
+
+// runRunbook runs a runbook operation on behalf of the admin, the step failures are reported in
+// the result
+func (s *authService) runRunbook(ctx context.Context, authorization string, operation func(r *runbook.Runbook, actor string) (*runbook.Result, error)) (*authservice.RunbookResult, error) {
+	admin, errAdmin := s.authenticateAdmin(ctx, authorization, constants.AdminConfigRequiredScope)
+	if errAdmin != nil {
+		return nil, errAdmin
+	}
+	r := s.runbook()
+	if r == nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	result, errOperation := operation(r, admin.ProfileSub())
+	if errOperation != nil {
+		return nil, adminConfigError(ctx, errOperation)
+	}
+
+	slog.InfoContext(ctx, "audit: runbook operation run",
+		"sub", redaction.Redact(admin.ProfileSub()),
+		"operation", result.Operation,
+		"target", redaction.Redact(result.Target),
+		"outcome", result.Outcome,
+	)
+
+	reply := &authservice.RunbookResult{
+		Operation: string(result.Operation),
+		Target:    result.Target,
+		Outcome:   string(result.Outcome),
+		Steps:     make([]*authservice.RunbookStep, 0, len(result.Steps)),
+		At:        result.At.Format(time.RFC3339),
+	}
+	for _, step := range result.Steps {
+		reply.Steps = append(reply.Steps, &authservice.RunbookStep{
+			Name:    step.Name,
+			Outcome: string(step.Outcome),
+			Detail:  optionalString(step.Detail),
+		})
+	}
+	return reply, nil
+}
@@ -0,0 +1,73 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// PurgeUserCache removes a user from the users cache, under all the keys it is cached
+func (s *authService) PurgeUserCache(ctx context.Context, p *authservice.PurgeUserCachePayload) error {
+	admin, errAdmin := s.authenticateAdmin(ctx, stringValue(p.Authorization), constants.AdminConfigRequiredScope)
+	if errAdmin != nil {
+		return errAdmin
+	}
+	cache := s.userCache()
+	if cache == nil {
+		return authservice.ServiceUnavailable("user cache is disabled")
+	}
+	userReader := s.userReader()
+	if userReader == nil {
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	user, errLookup := userReader.MetadataLookup(ctx, p.User)
+	if errLookup != nil {
+		return adminConfigError(ctx, errLookup)
+	}
+	// the keys of the cached entry and of the lookup are both removed
+	if errRepair := cache.Repair(ctx, user.UserID, user); errRepair != nil {
+		return adminConfigError(ctx, errRepair)
+	}
+
+	slog.InfoContext(ctx, "audit: user cache purged",
+		"sub", redaction.Redact(admin.ProfileSub()),
+		"user_id", redaction.Redact(user.UserID),
+	)
+	return nil
+}
+
+// ListWebhookDeliveries lists the last deliveries of the audit webhook seen by the replica
+func (s *authService) ListWebhookDeliveries(ctx context.Context, p *authservice.ListWebhookDeliveriesPayload) (*authservice.WebhookDeliveries, error) {
+	if _, errAdmin := s.authenticateAdmin(ctx, stringValue(p.Authorization), constants.AdminConfigRequiredScope); errAdmin != nil {
+		return nil, errAdmin
+	}
+	reader := s.webhookDeliveries()
+	if reader == nil {
+		return nil, authservice.ServiceUnavailable("audit webhook is disabled")
+	}
+
+	deliveries := reader.WebhookDeliveries()
+	result := &authservice.WebhookDeliveries{Deliveries: make([]*authservice.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		entry := &authservice.WebhookDelivery{
+			Action:      string(delivery.Action),
+			Error:       optionalString(delivery.Error),
+			DurationMs:  delivery.Duration.Milliseconds(),
+			DeliveredAt: delivery.DeliveredAt.UTC().Format(time.RFC3339),
+		}
+		if delivery.StatusCode != 0 {
+			statusCode := delivery.StatusCode
+			entry.StatusCode = &statusCode
+		}
+		result.Deliveries = append(result.Deliveries, entry)
+	}
+	return result, nil
+}
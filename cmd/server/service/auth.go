@@ -6,13 +6,50 @@ package service
 import (
 	"context"
 	"fmt"
+	"os"
 
 	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/runbook"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/usercache"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 )
 
 type authService struct {
 	natsClient *nats.NATSClient
+	build      BuildInfo
+
+	// userReader, profileFeed, adminFeed, messageHandler, callerAllowlist, backupCodeStore,
+	// provenanceStore, userCache and runbook return the dependencies initialized with the NATS subscriptions, nil until then
+	userReader      func() port.UserReader
+	profileFeed     func() port.ProfileChangeSubscriber
+	adminFeed       func() port.AdminEventSubscriber
+	messageHandler  func() *MessageHandlerService
+	callerAllowlist func() port.CallerAllowlist
+	backupCodeStore func() port.EmailBackupCodeStore
+	provenanceStore func() port.MetadataProvenanceStore
+	userCache       func() *usercache.UserReaderWriter
+	runbook         func() *runbook.Runbook
+
+	// webhookDeliveries returns the last deliveries of the audit webhook, nil when the webhook sink
+	// isn't configured
+	webhookDeliveries func() port.WebhookDeliveryReader
+
+	// providerStatuses is the recent health of the upstream identity providers
+	providerStatuses port.ProviderStatusReader
+
+	// eventSchemas are the schemas of the published events
+	eventSchemas port.EventSchemaRegistry
+
+	// pictureStorage is the storage of the uploaded profile pictures, nil when the uploads are
+	// disabled, the pictures are at most pictureMaxSize bytes
+	pictureStorage port.ObjectStorage
+	pictureMaxSize int
+
+	// adminSubs are the subjects of the admins allowed to stream the internal events and to manage
+	// the configuration resources
+	adminSubs map[string]struct{}
 }
 
 // Livez implements the liveness check endpoint
@@ -33,9 +70,26 @@ func (s *authService) Readyz(ctx context.Context) ([]byte, error) {
 	return []byte("OK"), nil
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService() authservice.Service {
+// NewAuthService creates a new auth service, the build is reported in the support bundles
+func NewAuthService(build BuildInfo) authservice.Service {
+	pictureStorage, pictureMaxSize := profilePictures()
 	return &authService{
-		natsClient: getNATSClient(),
+		natsClient:        getNATSClient(),
+		build:             build,
+		userReader:        getUserReader,
+		profileFeed:       getProfileFeed,
+		adminFeed:         getAdminFeed,
+		messageHandler:    getMessageHandler,
+		callerAllowlist:   getCallerAllowlist,
+		backupCodeStore:   getEmailBackupCodeStore,
+		provenanceStore:   getMetadataProvenanceStore,
+		userCache:         getUserCache,
+		runbook:           getRunbook,
+		webhookDeliveries: getWebhookDeliveries,
+		providerStatuses:  providerScoreboard,
+		eventSchemas:      getEventSchemas(),
+		pictureStorage:    pictureStorage,
+		pictureMaxSize:    pictureMaxSize,
+		adminSubs:         parseAdminSubs(os.Getenv(constants.AdminDashboardSubsEnvKey)),
 	}
 }
@@ -0,0 +1,280 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/authelia"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/otplockout"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/sendlimit"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/usage"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sharelink"
+)
+
+// ConfigError is a configuration problem found by ValidateConfig
+type ConfigError struct {
+	// Component is the part of the service the configuration belongs to
+	Component string `json:"component"`
+	// Key is the environment variable at fault, when it can be pinned down
+	Key string `json:"key,omitempty"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// configValidator collects the configuration errors
+type configValidator struct {
+	errors []ConfigError
+}
+
+// add records err for the component, the joined errors are recorded one by one
+func (v *configValidator) add(component, key string, err error) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, inner := range joined.Unwrap() {
+			v.add(component, key, inner)
+		}
+		return
+	}
+	v.errors = append(v.errors, ConfigError{Component: component, Key: key, Message: err.Error()})
+}
+
+// boolean checks the environment variable is a boolean when set
+func (v *configValidator) boolean(component, key string) {
+	if value := os.Getenv(key); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			v.add(component, key, fmt.Errorf("invalid %s value %s, expected a boolean", key, value))
+		}
+	}
+}
+
+// absoluteURL checks the environment variable is an absolute URL when set
+func (v *configValidator) absoluteURL(component, key, value string) {
+	if value == "" {
+		return
+	}
+	if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		v.add(component, key, fmt.Errorf("invalid %s value %s, expected an absolute URL", key, value))
+	}
+}
+
+// ValidateConfig loads the configuration of the service from the environment and runs the
+// validators of every component, without connecting to NATS or to the identity provider
+func ValidateConfig(ctx context.Context) []ConfigError {
+	v := &configValidator{}
+
+	validateUserRepositoryConfig(ctx, v)
+
+	_, errNATS := natsConfigFromEnv()
+	v.add("nats", "", errNATS)
+	_, _, errWorkers := workerPoolsFromEnv()
+	v.add("nats", constants.MessageWorkersEnvKey, errWorkers)
+
+	_, errCache := userCacheConfigFromEnv()
+	v.add("user_cache", "", errCache)
+	_, _, errDedupe := userSearchDedupeWindowFromEnv()
+	v.add("user_cache", constants.UserSearchDedupeWindowEnvKey, errDedupe)
+
+	_, errConsistencyAudit := consistencyAuditConfigFromEnv()
+	v.add("consistency_audit", "", errConsistencyAudit)
+
+	_, errSlowRequests := slowRequestLoggerFromEnv()
+	v.add("slow_requests", "", errSlowRequests)
+
+	_, errContracts := contractTrackerFromEnv()
+	v.add("contracts", "", errContracts)
+
+	_, errMiddlewares := middlewareChainFromEnv(nil, nil, nil)
+	v.add("middlewares", constants.MessageMiddlewaresEnvKey, errMiddlewares)
+
+	_, errAudit := auditSinkKindsFromEnv()
+	v.add("audit", "", errAudit)
+	v.absoluteURL("audit", constants.AuditWebhookURLEnvKey, os.Getenv(constants.AuditWebhookURLEnvKey))
+
+	for _, key := range []string{
+		constants.EmailNormalizationEnvKey,
+		constants.UsageAccountingEnvKey,
+		constants.DistributedLocksEnvKey,
+		constants.StorageMigrationsEnvKey,
+		constants.ProfileStreamEnvKey,
+		constants.ProfileTypeaheadEnvKey,
+		constants.ConsistencyAuditEnvKey,
+		constants.CallerAllowlistEnvKey,
+		constants.EmailBackupCodesEnvKey,
+		constants.MetadataProvenanceEnvKey,
+		constants.AdminUIEnvKey,
+		constants.PhoneLinkingEnvKey,
+		constants.EmailNotificationsEnvKey,
+	} {
+		v.boolean("features", key)
+	}
+	_, errChannel := model.ParsePhoneChannel(os.Getenv(constants.PhoneLinkingChannelEnvKey))
+	v.add("features", constants.PhoneLinkingChannelEnvKey, errChannel)
+
+	_, errCallerIssuers := oidc.ParseIssuers(os.Getenv(constants.CallerTokenIssuersEnvKey))
+	v.add("callers", constants.CallerTokenIssuersEnvKey, errCallerIssuers)
+
+	_, errDomains := model.ParseOrganizationDomains(os.Getenv(constants.OrganizationDomainsEnvKey))
+	v.add("policies", constants.OrganizationDomainsEnvKey, errDomains)
+	_, errPolicies := model.ParseResponsePolicies(os.Getenv(constants.ResponsePoliciesEnvKey))
+	v.add("policies", constants.ResponsePoliciesEnvKey, errPolicies)
+	_, errBudgets := usage.ParseBudgets(os.Getenv(constants.CostBudgetsEnvKey))
+	v.add("policies", constants.CostBudgetsEnvKey, errBudgets)
+	_, errSendLimits := sendlimit.ParseLimits(os.Getenv(constants.EmailLinkingSendLimitsEnvKey))
+	v.add("policies", constants.EmailLinkingSendLimitsEnvKey, errSendLimits)
+	switch store := os.Getenv(constants.EmailLinkingSendLimitStoreEnvKey); store {
+	case "", "memory", "nats":
+	default:
+		v.add("policies", constants.EmailLinkingSendLimitStoreEnvKey, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingSendLimitStoreEnvKey, store))
+	}
+	switch store := os.Getenv(constants.EmailLinkingCodeStoreEnvKey); store {
+	case "", "memory", "nats":
+	default:
+		v.add("policies", constants.EmailLinkingCodeStoreEnvKey, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingCodeStoreEnvKey, store))
+	}
+	_, errLockout := otplockout.ParsePolicy(os.Getenv(constants.EmailLinkingLockoutEnvKey))
+	v.add("policies", constants.EmailLinkingLockoutEnvKey, errLockout)
+	switch store := os.Getenv(constants.EmailLinkingLockoutStoreEnvKey); store {
+	case "", "memory", "nats":
+	default:
+		v.add("policies", constants.EmailLinkingLockoutStoreEnvKey, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingLockoutStoreEnvKey, store))
+	}
+
+	// the signer isn't created, it would load the signing keys from their store
+	_, errShareOpts := profileLinkSignerOptionsFromEnv()
+	v.add("profile_share", constants.ProfileShareMaxTTLEnvKey, errShareOpts)
+	if secret := os.Getenv(constants.ProfileShareSecretEnvKey); secret != "" {
+		_, errSecret := sharelink.NewSigner([]byte(secret))
+		v.add("profile_share", constants.ProfileShareSecretEnvKey, errSecret)
+	}
+	keysConfig, errKeys := signingKeysConfigFromEnv()
+	v.add("signing_keys", "", errKeys)
+	if errKeys == nil && keysConfig.store != "" {
+		maxTTL := sharelink.DefaultMaxTTL
+		if value, err := time.ParseDuration(os.Getenv(constants.ProfileShareMaxTTLEnvKey)); err == nil {
+			maxTTL = value
+		}
+		if keysConfig.retention < maxTTL {
+			v.add("signing_keys", constants.SigningKeysRetentionEnvKey, fmt.Errorf("the retention %s is shorter than %s %s, the share links would fail before they expire", keysConfig.retention, constants.ProfileShareMaxTTLEnvKey, maxTTL))
+		}
+	}
+	v.absoluteURL("profile_share", constants.ProfileShareBaseURLEnvKey, os.Getenv(constants.ProfileShareBaseURLEnvKey))
+
+	_, _, errPictures := profilePictureStorageFromEnv()
+	v.add("profile_pictures", "", errPictures)
+
+	_, errSealer := newTokenReferenceSealer(ctx)
+	v.add("token_references", "", errSealer)
+	_, _, errBackupCodes := emailBackupCodesFromEnv()
+	v.add("backup_codes", "", errBackupCodes)
+
+	if provenance, _ := strconv.ParseBool(os.Getenv(constants.MetadataProvenanceEnvKey)); !provenance && strings.TrimSpace(os.Getenv(constants.MetadataEnrichmentCallersEnvKey)) != "" {
+		v.add("enrichment", constants.MetadataEnrichmentCallersEnvKey, fmt.Errorf("requires %s", constants.MetadataProvenanceEnvKey))
+	}
+
+	// the nats policy isn't sent any request, the requester isn't needed
+	_, errPolicy := metadataPolicyFromEnv(nil)
+	v.add("metadata_policy", "", errPolicy)
+
+	if adminUI, _ := strconv.ParseBool(os.Getenv(constants.AdminUIEnvKey)); adminUI && strings.TrimSpace(os.Getenv(constants.AdminDashboardSubsEnvKey)) == "" {
+		v.add("admin", constants.AdminUIEnvKey, fmt.Errorf("requires %s", constants.AdminDashboardSubsEnvKey))
+	}
+	for _, origin := range strings.Split(os.Getenv(constants.AdminDashboardOriginsEnvKey), ",") {
+		v.absoluteURL("admin", constants.AdminDashboardOriginsEnvKey, strings.TrimSpace(origin))
+	}
+
+	return v.errors
+}
+
+// validateUserRepositoryConfig validates the configuration of the identity provider selected
+// via USER_REPOSITORY_TYPE, the providers sending their own emails also validate the email settings
+func validateUserRepositoryConfig(ctx context.Context, v *configValidator) {
+	const component = "user_repository"
+
+	userRepositoryType := os.Getenv(constants.UserRepositoryTypeEnvKey)
+	if userRepositoryType == "" {
+		userRepositoryType = constants.UserRepositoryTypeMock
+	}
+
+	sendsEmails := false
+	switch userRepositoryType {
+	case constants.UserRepositoryTypeMock:
+		// the error names the variable at fault
+		_, errMock := mockOptionsFromEnv()
+		v.add(component, "", errMock)
+	case constants.UserRepositoryTypeAuth0:
+		// the error names the variable at fault
+		config, errConfig := auth0ConfigFromEnv()
+		v.add(component, "", errConfig)
+		v.absoluteURL(component, constants.Auth0CanaryJWKSURLEnvKey, config.CanaryJWKSURL)
+		_, errRateLimits := httpclient.ParseRateLimits(os.Getenv(constants.Auth0RateLimitsEnvKey))
+		v.add(component, constants.Auth0RateLimitsEnvKey, errRateLimits)
+		_, errIssuers := oidc.ParseIssuers(os.Getenv(constants.Auth0TrustedIssuersEnvKey))
+		v.add(component, constants.Auth0TrustedIssuersEnvKey, errIssuers)
+		switch mode := httpclient.CassetteMode(strings.ToLower(os.Getenv(constants.Auth0CassetteModeEnvKey))); mode {
+		case "", httpclient.CassetteModeRecord:
+		case httpclient.CassetteModeReplay:
+			// replaying is offline, the cassette is only read
+			cassette, errCassette := auth0CassetteFromEnv()
+			if errCassette != nil {
+				v.add(component, constants.Auth0CassettePathEnvKey, errCassette)
+				return
+			}
+			config.Cassette = cassette
+		default:
+			v.add(component, constants.Auth0CassetteModeEnvKey, fmt.Errorf("invalid cassette mode %q, expected %s or %s", mode, httpclient.CassetteModeRecord, httpclient.CassetteModeReplay))
+		}
+		v.add(component, "", config.Validate(ctx))
+		additionalConfigs, errAdditional := auth0AdditionalConfigsFromEnv()
+		v.add(component, constants.Auth0TenantsEnvKey, errAdditional)
+		for _, additional := range additionalConfigs {
+			v.absoluteURL(component, additional.EnvKey(constants.Auth0CanaryJWKSURLEnvKey), additional.CanaryJWKSURL)
+			additional.Cassette = config.Cassette
+			v.add(component, "", additional.Validate(ctx))
+		}
+	case constants.UserRepositoryTypeKeycloak:
+		sendsEmails = true
+		v.add(component, "", keycloakConfigFromEnv().Validate())
+	case constants.UserRepositoryTypeOkta:
+		sendsEmails = true
+		config, errConfig := oktaConfigFromEnv()
+		if errConfig != nil {
+			v.add(component, constants.OktaPrivateBase64KeyEnvKey, errConfig)
+			break
+		}
+		v.add(component, "", config.Validate())
+	case constants.UserRepositoryTypeCognito:
+		sendsEmails = true
+		v.add(component, "", cognitoConfigFromEnv().Validate())
+	case constants.UserRepositoryTypeAuthelia:
+		sendsEmails = true
+		v.boolean(component, constants.AutheliaEventSourcingEnvKey)
+		v.boolean(component, constants.AutheliaSyncDryRunEnvKey)
+		v.add(component, "", authelia.ValidateConfig(autheliaConfigFromEnv()))
+	default:
+		v.add(component, constants.UserRepositoryTypeEnvKey, fmt.Errorf("unsupported user repository type: %s", userRepositoryType))
+	}
+
+	// the notifications are sent by the service whatever the provider
+	if notifications, _ := strconv.ParseBool(os.Getenv(constants.EmailNotificationsEnvKey)); notifications {
+		sendsEmails = true
+	}
+	if sendsEmails {
+		_, errSender := email.NewTemplatedSender()
+		v.add("email", "", errSender)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// ListEventSchemas returns the JSON Schemas of the published events, sorted by subject
+func (s *authService) ListEventSchemas(ctx context.Context) ([]*authservice.EventSchema, error) {
+	schemas := s.eventSchemas.EventSchemas()
+	result := make([]*authservice.EventSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		result = append(result, eventSchemaResult(schema))
+	}
+	return result, nil
+}
+
+// GetEventSchema returns the JSON Schema of the events published on the subject
+func (s *authService) GetEventSchema(ctx context.Context, p *authservice.GetEventSchemaPayload) (*authservice.EventSchema, error) {
+	for _, schema := range s.eventSchemas.EventSchemas() {
+		if schema.Subject == p.Subject {
+			return eventSchemaResult(schema), nil
+		}
+	}
+	return nil, authservice.NotFound("no event is published on the subject")
+}
+
+// eventSchemaResult returns the schema as served, the schema document is written as is
+func eventSchemaResult(schema model.EventSchema) *authservice.EventSchema {
+	return &authservice.EventSchema{
+		Subject: schema.Subject,
+		Title:   schema.Title,
+		Schema:  schema.Schema,
+	}
+}
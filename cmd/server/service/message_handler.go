@@ -7,15 +7,29 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
 
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/service"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/log"
 )
 
 // MessageHandlerService handles NATS messages using the service layer
 type MessageHandlerService struct {
 	messageHandler port.MessageHandler
+
+	// middlewares wrap the handlers with the cross-cutting concerns (recovery, usage accounting,
+	// slow requests log), optional
+	middlewares *service.MiddlewareChain
+
+	// responseMeta identifies the deployment in the meta block of the responses
+	responseMeta model.ResponseMeta
+
+	// callerAuthenticator identifies the callers by their service token, the callers are unknown when nil
+	callerAuthenticator port.CallerAuthenticator
 }
 
 // HandleMessage routes NATS messages to appropriate handlers
@@ -25,40 +39,34 @@ func (mhs *MessageHandlerService) HandleMessage(ctx context.Context, msg port.Tr
 
 	slog.DebugContext(ctx, "handling NATS message")
 
-	handlers := map[string]func(ctx context.Context, msg port.TransportMessenger) ([]byte, error){
-		// user read/write operations
-		constants.UserMetadataUpdateSubject: mhs.messageHandler.UpdateUser,
-		constants.UserMetadataReadSubject:   mhs.messageHandler.GetUserMetadata,
-		constants.UserEmailReadSubject:      mhs.messageHandler.GetUserEmails,
-		// lookup operations
-		constants.UserEmailToUserSubject: mhs.messageHandler.EmailToUsername,
-		constants.UserEmailToSubSubject:  mhs.messageHandler.EmailToSub,
-		// email linking operations
-		constants.EmailLinkingSendVerificationSubject: mhs.messageHandler.StartEmailLinking,
-		constants.EmailLinkingVerifySubject:           mhs.messageHandler.VerifyEmailLinking,
-		// identity linking/unlinking/listing operations
-		constants.UserIdentityLinkSubject:   mhs.messageHandler.LinkIdentity,
-		constants.UserIdentityUnlinkSubject: mhs.messageHandler.UnlinkIdentity,
-		constants.UserIdentityListSubject:   mhs.messageHandler.ListIdentities,
-	}
-
-	handler, ok := handlers[subject]
+	registered, ok := service.LookupSubject(subject)
 	if !ok {
 		slog.WarnContext(ctx, "unknown subject")
-		mhs.respondWithError(ctx, msg, "unknown subject")
+		mhs.respondWithError(ctx, msg, "unknown subject", errs.CodeNotFound)
 		return
 	}
+	handler := registered.HandlerOf(mhs.messageHandler)
+
+	ctx = service.ContextWithCaller(ctx, mhs.authenticateCaller(ctx, msg))
+	ctx = model.ContextWithTenant(ctx, msg.Header(constants.TenantHeader))
+	ctx = service.ContextWithVerboseErrors(ctx, strings.EqualFold(strings.TrimSpace(msg.Header(constants.ValidationDetailHeader)), constants.ValidationDetailVerbose))
 
-	response, errHandler := handler(ctx, msg)
+	response, errHandler := mhs.middlewares.Then(handler)(ctx, msg)
 	if errHandler != nil {
 		slog.ErrorContext(ctx, "error handling message",
 			"error", errHandler,
 			"subject", subject,
 		)
-		mhs.respondWithError(ctx, msg, errHandler.Error())
+		mhs.respondWithError(ctx, msg, errHandler.Error(), errs.Code(errHandler))
 		return
 	}
 
+	response = service.LocalizeResponse(msg, response)
+	if service.WantsEnvelope(msg) {
+		response = service.EnvelopeResponse(response)
+	}
+	response = service.WatermarkResponse(response, mhs.responseMeta)
+
 	errRespond := msg.Respond(response)
 	if errRespond != nil {
 		slog.ErrorContext(ctx, "error responding to NATS message", "error", errRespond)
@@ -68,8 +76,28 @@ func (mhs *MessageHandlerService) HandleMessage(ctx context.Context, msg port.Tr
 	slog.DebugContext(ctx, "responded to NATS message", "response", string(response))
 }
 
-func (mhs *MessageHandlerService) respondWithError(ctx context.Context, msg port.TransportMessenger, errorMsg string) {
+// authenticateCaller returns the calling service proven by the service token of the message, empty
+// when there is none or it isn't valid. Any client of the bus can set a header, only a token signed
+// by a trusted issuer identifies the caller.
+func (mhs *MessageHandlerService) authenticateCaller(ctx context.Context, msg port.TransportMessenger) string {
+	token := msg.Header(constants.CallerTokenHeader)
+	if token == "" || mhs.callerAuthenticator == nil {
+		return ""
+	}
+	caller, err := mhs.callerAuthenticator.AuthenticateCaller(ctx, token)
+	if err != nil {
+		slog.WarnContext(ctx, "invalid caller token, the caller is unknown", "error", err)
+		return ""
+	}
+	return caller
+}
+
+func (mhs *MessageHandlerService) respondWithError(ctx context.Context, msg port.TransportMessenger, errorMsg, code string) {
 	payload, _ := json.Marshal(map[string]string{"error": errorMsg})
+	if service.WantsEnvelope(msg) {
+		payload = service.EnvelopeError(errorMsg, code)
+	}
+	payload = service.WatermarkResponse(payload, mhs.responseMeta)
 	if err := msg.Respond(payload); err != nil {
 		slog.ErrorContext(ctx, "failed to send error response", "error", err)
 	}
@@ -79,5 +107,6 @@ func (mhs *MessageHandlerService) respondWithError(ctx context.Context, msg port
 func NewMessageHandlerService(messageHandler port.MessageHandler) *MessageHandlerService {
 	return &MessageHandlerService{
 		messageHandler: messageHandler,
+		responseMeta:   ResponseMetaFromEnv(),
 	}
 }
@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// ProfileEvents streams the profile changes of the token bearer as Server-Sent Events until
+// the client disconnects, browsers can't join NATS to get the profile changed events
+func (s *authService) ProfileEvents(ctx context.Context, p *authservice.ProfileEventsPayload, stream authservice.ProfileEventsServerStream) error {
+	var authorization string
+	if p != nil {
+		// EventSource can't set headers, the token comes as query parameter
+		authorization = bearerAuthorization(p.Authorization, p.AccessToken)
+	}
+
+	user, errAuthenticate := s.authenticate(ctx, authorization)
+	if errAuthenticate != nil {
+		return errAuthenticate
+	}
+
+	feed := s.profileFeed()
+	if feed == nil {
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	events, errSubscribe := feed.SubscribeProfileChanges(ctx, user.ProfileSub())
+	if errSubscribe != nil {
+		var tooMany errs.TooManyRequests
+		if errors.As(errSubscribe, &tooMany) {
+			return authservice.TooManyRequests(errSubscribe.Error())
+		}
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	// the first event opens the stream, the client knows it won't miss the next changes
+	subscribed := model.UserProfileChanged{
+		Sub:       user.ProfileSub(),
+		Reason:    model.ProfileChangeSubscribed,
+		ChangedAt: time.Now().UTC(),
+	}
+	if err := stream.SendWithContext(ctx, newProfileChange(subscribed)); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.SendWithContext(ctx, newProfileChange(event)); err != nil {
+			slog.DebugContext(ctx, "profile stream closed", "error", err)
+			return nil
+		}
+	}
+
+	// the channel is closed once the client is gone
+	return nil
+}
+
+func newProfileChange(event model.UserProfileChanged) *authservice.ProfileChange {
+	return &authservice.ProfileChange{
+		Sub:       event.Sub,
+		Reason:    string(event.Reason),
+		ChangedAt: event.ChangedAt.Format(time.RFC3339),
+	}
+}
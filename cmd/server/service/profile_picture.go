@@ -0,0 +1,135 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/objectstorage"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+var (
+	// profilePictureStorage stores the uploaded profile pictures, nil when the uploads are disabled
+	profilePictureStorage     port.ObjectStorage
+	profilePictureMaxSize     int
+	profilePictureStorageOnce sync.Once
+)
+
+// profilePictureStorageFromEnv returns the storage of the profile pictures and their maximum size,
+// the storage is nil when PROFILE_PICTURE_STORAGE isn't set
+func profilePictureStorageFromEnv() (port.ObjectStorage, int, error) {
+	maxSize := model.DefaultProfilePictureMaxSize
+	if value := os.Getenv(constants.ProfilePictureMaxSizeEnvKey); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return nil, 0, fmt.Errorf("invalid %s value %s, expected a positive number of bytes", constants.ProfilePictureMaxSizeEnvKey, value)
+		}
+		maxSize = parsed
+	}
+
+	backend := os.Getenv(constants.ProfilePictureStorageEnvKey)
+	if backend == "" {
+		return nil, maxSize, nil
+	}
+	storage, err := objectstorage.New(objectstorage.Config{
+		Backend:  backend,
+		Bucket:   os.Getenv(constants.ProfilePictureBucketEnvKey),
+		Region:   os.Getenv(constants.ProfilePictureRegionEnvKey),
+		Endpoint: os.Getenv(constants.ProfilePictureEndpointEnvKey),
+		Credentials: sigv4.Credentials{
+			AccessKeyID:     os.Getenv(constants.AWSAccessKeyIDEnvKey),
+			SecretAccessKey: os.Getenv(constants.AWSSecretAccessKeyEnvKey),
+			SessionToken:    os.Getenv(constants.AWSSessionTokenEnvKey),
+		},
+		Dir:     os.Getenv(constants.ProfilePictureDirEnvKey),
+		BaseURL: os.Getenv(constants.ProfilePictureBaseURLEnvKey),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return storage, maxSize, nil
+}
+
+// profilePictures returns the storage of the uploaded profile pictures and their maximum size, the
+// storage is nil when the uploads are disabled
+func profilePictures() (port.ObjectStorage, int) {
+	profilePictureStorageOnce.Do(func() {
+		storage, maxSize, err := profilePictureStorageFromEnv()
+		if err != nil {
+			log.Fatalf("failed to create the profile picture storage: %v", err)
+		}
+		profilePictureStorage, profilePictureMaxSize = storage, maxSize
+	})
+	return profilePictureStorage, profilePictureMaxSize
+}
+
+// ProfilePictureStorage returns the storage of the uploaded profile pictures, nil when the uploads
+// are disabled. The local storage serves the pictures itself, it implements http.Handler.
+func ProfilePictureStorage() port.ObjectStorage {
+	storage, _ := profilePictures()
+	return storage
+}
+
+// UploadCurrentUserPicture stores the profile picture of the token bearer, then mirrors the update
+// of its picture metadata field so the update is published like any other
+func (s *authService) UploadCurrentUserPicture(ctx context.Context, p *authservice.UploadCurrentUserPicturePayload, body io.ReadCloser) (*authservice.UserDataReply, error) {
+	defer body.Close()
+
+	authorization := stringValue(p.Authorization)
+	user, errAuthenticate := s.authenticate(ctx, authorization, constants.UserUpdateMetadataRequiredScope)
+	if errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	storage := s.pictureStorage
+	if storage == nil {
+		reason := "the profile picture uploads are not enabled"
+		return nil, &authservice.FailedReply{Reason: &reason}
+	}
+
+	// one byte over the limit tells the oversized pictures apart
+	data, errRead := io.ReadAll(io.LimitReader(body, int64(s.pictureMaxSize)+1))
+	if errRead != nil {
+		slog.WarnContext(ctx, "failed to read the profile picture", "error", errRead)
+		reason := "failed to read the profile picture"
+		return nil, &authservice.FailedReply{Reason: &reason}
+	}
+	picture, errPicture := model.NewProfilePicture(stringValue(p.ContentType), data, s.pictureMaxSize)
+	if errPicture != nil {
+		reason := errPicture.Error()
+		return nil, &authservice.FailedReply{Reason: &reason}
+	}
+
+	pictureURL, errPut := storage.PutObject(ctx, picture.Key(cmp.Or(user.UserID, user.Sub)), picture.ContentType, picture.Data)
+	if errPut != nil {
+		slog.ErrorContext(ctx, "failed to store the profile picture", "error", errPut, "user", user)
+		return nil, authservice.ServiceUnavailable("failed to store the profile picture")
+	}
+
+	update, errMarshal := json.Marshal(map[string]any{
+		"token": bearerToken(authorization),
+		"user_metadata": map[string]any{
+			"picture": pictureURL,
+		},
+	})
+	if errMarshal != nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return s.mirror(ctx, constants.UserMetadataUpdateSubject, update, mirrorHeaders(p.AcceptLanguage))
+}
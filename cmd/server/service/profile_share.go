@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// ResolveProfileShare returns the shared view of the profile of a share link, the link is the
+// only credential so the failures other than the transient ones are all reported as not found
+func (s *authService) ResolveProfileShare(ctx context.Context, p *authservice.ResolveProfileSharePayload) (*authservice.SharedProfile, error) {
+	reply, errMirror := s.mirror(ctx, constants.ProfileShareResolveSubject, []byte(p.Token), map[string]string{})
+	if errMirror != nil {
+		var failed *authservice.FailedReply
+		if errors.As(errMirror, &failed) {
+			return nil, authservice.NotFound("profile not found")
+		}
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	// the data is the shared view already, decoded from the reply
+	data, errMarshal := json.Marshal(reply.Data)
+	var profile model.SharedProfile
+	if errMarshal == nil {
+		errMarshal = json.Unmarshal(data, &profile)
+	}
+	if errMarshal != nil {
+		slog.ErrorContext(ctx, "invalid shared profile", "error", errMarshal)
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	return &authservice.SharedProfile{
+		Username:             optional(profile.Username),
+		Name:                 optional(profile.Name),
+		Picture:              optional(profile.Picture),
+		JobTitle:             optional(profile.JobTitle),
+		Organization:         optional(profile.Organization),
+		OrganizationVerified: &profile.OrganizationVerified,
+	}, nil
+}
@@ -5,22 +5,62 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/adminfeed"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/audit"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/auth0"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/authelia"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/backupcodes"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/callers"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/cognito"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/consistency"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/contracts"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/dedupe"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/eventschema"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/k8s"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/keycloak"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/mock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/moderation"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/okta"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/otplockout"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/profilefeed"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/profileindex"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/profilestream"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/provenance"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/runbook"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/scoreboard"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/sendlimit"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/usage"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/usercache"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/vault"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/service"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/lock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sharelink"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokenref"
+
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 var (
@@ -28,61 +68,1140 @@ var (
 	natsClient *nats.NATSClient
 
 	natsDoOnce sync.Once
+
+	// eventSchemas validate the published events and are served to their consumers
+	eventSchemas     *eventschema.Registry
+	eventSchemasOnce sync.Once
+
+	// notificationSender emails the notifications to the users, nil when EMAIL_NOTIFICATIONS isn't set
+	notificationSender     port.TemplatedEmailSender
+	notificationSenderOnce sync.Once
+
+	// providerScoreboard tracks the recent health of the upstream identity providers
+	providerScoreboard = scoreboard.New(scoreboard.DefaultWindow)
+
+	// sharedUserReader, sharedProfileFeed, sharedAdminFeed, sharedMessageHandler and sharedCallerAllowlist
+	// are shared with the HTTP endpoints once the NATS subscriptions are started
+	sharedUserReader      port.UserReader
+	sharedProfileFeed     port.ProfileChangeSubscriber
+	sharedAdminFeed       port.AdminEventSubscriber
+	sharedMessageHandler  *MessageHandlerService
+	sharedCallerAllowlist port.CallerAllowlist
+	// sharedBackupCodeStore is the backup codes store, nil when EMAIL_BACKUP_CODES isn't set
+	sharedBackupCodeStore port.EmailBackupCodeStore
+	// sharedProvenanceStore is the metadata provenance store, nil when METADATA_PROVENANCE isn't set
+	sharedProvenanceStore port.MetadataProvenanceStore
+	// sharedUserCache is the users cache, nil when USER_CACHE isn't set
+	sharedUserCache *usercache.UserReaderWriter
+	// sharedRunbook runs the runbook operations of the admins
+	sharedRunbook *runbook.Runbook
+	// sharedWebhookDeliveries are the deliveries of the audit webhook, nil when AUDIT_SINKS has no webhook
+	sharedWebhookDeliveries port.WebhookDeliveryReader
+	sharedMu                sync.RWMutex
 )
 
+// ResponseMetaFromEnv returns the identifiers of the deployment stamped on the responses, the
+// instance is the host name (the pod name) unless SERVICE_INSTANCE is set
+func ResponseMetaFromEnv() model.ResponseMeta {
+	instance := os.Getenv(constants.ServiceInstanceEnvKey)
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	return model.ResponseMeta{
+		Environment: os.Getenv(constants.ServiceEnvironmentEnvKey),
+		Instance:    instance,
+	}
+}
+
+// slowRequestLoggerFromEnv returns the logger of the slow requests, nil when SLOW_REQUEST_THRESHOLD is 0
+func slowRequestLoggerFromEnv() (*service.SlowRequestLogger, error) {
+	threshold := service.DefaultSlowRequestThreshold
+	if value := os.Getenv(constants.SlowRequestThresholdEnvKey); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.SlowRequestThresholdEnvKey, value)
+		}
+		threshold = parsed
+	}
+	if threshold == 0 {
+		return nil, nil
+	}
+
+	var opts []service.SlowRequestOption
+	if value := os.Getenv(constants.SlowRequestSampleRateEnvKey); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a number between 0 and 1", constants.SlowRequestSampleRateEnvKey, value)
+		}
+		opts = append(opts, service.WithSlowRequestSampleRate(rate))
+	}
+	return service.NewSlowRequestLogger(threshold, opts...), nil
+}
+
+// contractTrackerFromEnv returns the tracker of the consumer contracts, nil when CONSUMER_CONTRACTS is empty
+func contractTrackerFromEnv() (*contracts.Tracker, error) {
+	parsed, err := contracts.ParseContracts(os.Getenv(constants.ConsumerContractsEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %w", constants.ConsumerContractsEnvKey, err)
+	}
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+
+	window := contracts.DefaultWindow
+	if value := os.Getenv(constants.ConsumerSLIWindowEnvKey); value != "" {
+		window, err = time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.ConsumerSLIWindowEnvKey, value)
+		}
+	}
+	return contracts.NewTracker(parsed, window), nil
+}
+
+// middlewareChainFromEnv builds the middlewares of the message handlers, in the order of
+// MESSAGE_MIDDLEWARES when set
+func middlewareChainFromEnv(usageRecorder port.UsageRecorder, sliRecorder port.SLIRecorder, slowRequests *service.SlowRequestLogger) (*service.MiddlewareChain, error) {
+	chain := service.NewMiddlewareChain().
+		Register(service.MiddlewareRecover, service.RecoverMiddleware()).
+		Register(service.MiddlewareUsage, service.UsageMiddleware(usageRecorder)).
+		Register(service.MiddlewareSLI, service.SLIMiddleware(sliRecorder)).
+		Register(service.MiddlewareSlowRequests, slowRequests.Middleware())
+
+	order := service.DefaultMiddlewareOrder
+	if value, ok := os.LookupEnv(constants.MessageMiddlewaresEnvKey); ok {
+		order = strings.Split(value, ",")
+	}
+	if err := chain.Order(order...); err != nil {
+		return nil, fmt.Errorf("invalid %s value: %w", constants.MessageMiddlewaresEnvKey, err)
+	}
+	return chain, nil
+}
+
+// natsConfigFromEnv loads the NATS client configuration from the environment
+func natsConfigFromEnv() (nats.Config, error) {
+	natsURL := os.Getenv("NATS_URL")
+	if natsURL == "" {
+		natsURL = "nats://localhost:4222"
+	}
+
+	natsTimeout := os.Getenv("NATS_TIMEOUT")
+	if natsTimeout == "" {
+		natsTimeout = "10s"
+	}
+	natsTimeoutDuration, err := time.ParseDuration(natsTimeout)
+	if err != nil {
+		return nats.Config{}, fmt.Errorf("invalid NATS timeout duration: %w", err)
+	}
+
+	natsMaxReconnect := os.Getenv("NATS_MAX_RECONNECT")
+	if natsMaxReconnect == "" {
+		natsMaxReconnect = "3"
+	}
+	natsMaxReconnectInt, err := strconv.Atoi(natsMaxReconnect)
+	if err != nil {
+		return nats.Config{}, fmt.Errorf("invalid NATS max reconnect value %s: %w", natsMaxReconnect, err)
+	}
+
+	natsReconnectWait := os.Getenv("NATS_RECONNECT_WAIT")
+	if natsReconnectWait == "" {
+		natsReconnectWait = "2s"
+	}
+	natsReconnectWaitDuration, err := time.ParseDuration(natsReconnectWait)
+	if err != nil {
+		return nats.Config{}, fmt.Errorf("invalid NATS reconnect wait duration %s : %w", natsReconnectWait, err)
+	}
+
+	return nats.Config{
+		URL:           natsURL,
+		Timeout:       natsTimeoutDuration,
+		MaxReconnect:  natsMaxReconnectInt,
+		ReconnectWait: natsReconnectWaitDuration,
+	}, nil
+}
+
+// workerPoolsFromEnv returns the worker pools handling the NATS requests and the subjects of the
+// batch pool, the pools are disabled when MESSAGE_WORKERS isn't set
+func workerPoolsFromEnv() (nats.WorkerPoolSizes, []string, error) {
+	sizes, err := nats.ParseWorkerPools(os.Getenv(constants.MessageWorkersEnvKey))
+	if err != nil {
+		return nats.WorkerPoolSizes{}, nil, fmt.Errorf("invalid %s value: %w", constants.MessageWorkersEnvKey, err)
+	}
+
+	batchSubjects := nats.DefaultBatchSubjects
+	if value := strings.TrimSpace(os.Getenv(constants.MessageBatchSubjectsEnvKey)); value != "" {
+		batchSubjects = nil
+		for _, subject := range strings.Split(value, ",") {
+			if subject = strings.TrimSpace(subject); subject != "" {
+				batchSubjects = append(batchSubjects, subject)
+			}
+		}
+	}
+	return sizes, batchSubjects, nil
+}
+
 func natsInit(ctx context.Context) {
 
 	natsDoOnce.Do(func() {
-		natsURL := os.Getenv("NATS_URL")
-		if natsURL == "" {
-			natsURL = "nats://localhost:4222"
+		config, errConfig := natsConfigFromEnv()
+		if errConfig != nil {
+			log.Fatal(errConfig)
+		}
+
+		workers, batchSubjects, errWorkers := workerPoolsFromEnv()
+		if errWorkers != nil {
+			log.Fatal(errWorkers)
+		}
+		if workers.Enabled() {
+			slog.InfoContext(ctx, "NATS requests handled by priority",
+				"interactive_workers", workers.Interactive,
+				"batch_workers", workers.Batch,
+				"batch_subjects", batchSubjects,
+			)
 		}
 
-		natsTimeout := os.Getenv("NATS_TIMEOUT")
-		if natsTimeout == "" {
-			natsTimeout = "10s"
+		client, errNewClient := nats.NewClient(ctx, config,
+			nats.WithEventSchemas(getEventSchemas()),
+			nats.WithWorkerPools(workers, batchSubjects),
+		)
+		if errNewClient != nil {
+			log.Fatalf("failed to create NATS client: %v", errNewClient)
 		}
-		natsTimeoutDuration, err := time.ParseDuration(natsTimeout)
+		natsClient = client
+	})
+}
+
+// getEventSchemas returns the registry of the schemas of the published events, the schemas are
+// embedded so a failure to load them is a build defect
+func getEventSchemas() *eventschema.Registry {
+	eventSchemasOnce.Do(func() {
+		registry, err := eventschema.NewRegistry()
 		if err != nil {
-			log.Fatalf("invalid NATS timeout duration: %v", err)
+			log.Fatalf("failed to load the event schemas: %v", err)
 		}
+		eventSchemas = registry
+	})
+	return eventSchemas
+}
 
-		natsMaxReconnect := os.Getenv("NATS_MAX_RECONNECT")
-		if natsMaxReconnect == "" {
-			natsMaxReconnect = "3"
+// getNotificationSender returns the sender of the notification emails, shared by the notifiers so
+// they honor the same rate limits, nil unless EMAIL_NOTIFICATIONS is set to true
+func getNotificationSender() port.TemplatedEmailSender {
+	notificationSenderOnce.Do(func() {
+		if enabled, _ := strconv.ParseBool(os.Getenv(constants.EmailNotificationsEnvKey)); !enabled {
+			return
 		}
-		natsMaxReconnectInt, err := strconv.Atoi(natsMaxReconnect)
+		sender, err := email.NewTemplatedSender()
 		if err != nil {
-			log.Fatalf("invalid NATS max reconnect value %s: %v", natsMaxReconnect, err)
+			log.Fatalf("failed to create the notification email sender: %v", err)
 		}
+		notificationSender = sender
+	})
+	return notificationSender
+}
+
+// emailNormalizationInit applies the email normalization opt-out, the provider specific
+// rules are enabled unless EMAIL_NORMALIZATION is set to false
+func emailNormalizationInit(ctx context.Context) {
+	value := os.Getenv(constants.EmailNormalizationEnvKey)
+	if value == "" {
+		return
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("invalid %s value %s: %v", constants.EmailNormalizationEnvKey, value, err)
+	}
+	emailnorm.SetEnabled(enabled)
+	slog.DebugContext(ctx, "email normalization configured", "enabled", enabled)
+}
+
+// newUsageAccountant creates the usage accountant when USAGE_ACCOUNTING is enabled,
+// the counts are flushed to the usage KV bucket until the context is done
+func newUsageAccountant(ctx context.Context) (*usage.Accountant, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.UsageAccountingEnvKey))
+	if !enabled {
+		return nil, nil
+	}
+
+	kv, err := usageKVStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accountant := usage.NewAccountant(kv)
+	go accountant.Run(ctx, usage.DefaultFlushInterval)
 
-		natsReconnectWait := os.Getenv("NATS_RECONNECT_WAIT")
-		if natsReconnectWait == "" {
-			natsReconnectWait = "2s"
+	slog.DebugContext(ctx, "usage accounting enabled", "bucket", constants.KVBucketNameUsage)
+	return accountant, nil
+}
+
+// auditSinkKindsFromEnv parses AUDIT_SINKS, the log sink when unset
+func auditSinkKindsFromEnv() ([]audit.SinkKind, error) {
+	value, set := os.LookupEnv(constants.AuditSinksEnvKey)
+	if !set {
+		return []audit.SinkKind{audit.SinkLog}, nil
+	}
+	kinds, err := audit.ParseSinkKinds(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %s: %w", constants.AuditSinksEnvKey, value, err)
+	}
+	for _, kind := range kinds {
+		switch {
+		case kind == audit.SinkFile && os.Getenv(constants.AuditFilePathEnvKey) == "":
+			return nil, fmt.Errorf("%s is required by the file audit sink", constants.AuditFilePathEnvKey)
+		case kind == audit.SinkWebhook && os.Getenv(constants.AuditWebhookURLEnvKey) == "":
+			return nil, fmt.Errorf("%s is required by the webhook audit sink", constants.AuditWebhookURLEnvKey)
 		}
-		natsReconnectWaitDuration, err := time.ParseDuration(natsReconnectWait)
+	}
+	return kinds, nil
+}
+
+// newAuditSink creates the sinks of the audit events configured via AUDIT_SINKS, nil when none is
+func newAuditSink(ctx context.Context) (port.AuditSink, error) {
+	kinds, err := auditSinkKindsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+
+	var sinks audit.Sinks
+	for _, kind := range kinds {
+		switch kind {
+		case audit.SinkLog:
+			sinks = append(sinks, audit.NewLogSink(nil))
+		case audit.SinkNATS:
+			if natsClient == nil {
+				return nil, fmt.Errorf("NATS client not initialized")
+			}
+			sinks = append(sinks, audit.NewPublisherSink(natsClient, constants.UserAuditSubject))
+		case audit.SinkFile:
+			sink, errFile := audit.NewFileSink(os.Getenv(constants.AuditFilePathEnvKey))
+			if errFile != nil {
+				return nil, errFile
+			}
+			sinks = append(sinks, sink)
+		case audit.SinkWebhook:
+			sink := audit.NewWebhookSink(
+				httpclient.NewClient(httpclient.DefaultConfig()),
+				os.Getenv(constants.AuditWebhookURLEnvKey),
+				os.Getenv(constants.AuditWebhookTokenEnvKey),
+			)
+			setWebhookDeliveries(sink)
+			sinks = append(sinks, sink)
+		}
+	}
+
+	slog.DebugContext(ctx, "audit sinks enabled", "sinks", kinds)
+	return sinks, nil
+}
+
+// newCostGuard creates the guard of the expensive operations when COST_BUDGETS limits any caller,
+// the budget counts are kept in the usage KV bucket
+func newCostGuard(ctx context.Context) (*usage.Guard, error) {
+	budgets, err := usage.ParseBudgets(os.Getenv(constants.CostBudgetsEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cost budgets: %w", err)
+	}
+	if !budgets.Enabled() {
+		return nil, nil
+	}
+
+	kv, err := usageKVStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "cost guardrails enabled",
+		"default_budget", budgets.Default,
+		"overrides", len(budgets.Callers),
+	)
+	return usage.NewGuard(kv, budgets), nil
+}
+
+// newEmailSendLimiter creates the limits of the verification emails of the email linking, the counts
+// are kept in memory unless EMAIL_LINKING_SEND_LIMIT_STORE selects the usage KV bucket
+func newEmailSendLimiter(ctx context.Context) (*sendlimit.Limiter, error) {
+	limits, err := sendlimit.ParseLimits(os.Getenv(constants.EmailLinkingSendLimitsEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email linking send limits: %w", err)
+	}
+
+	var store sendlimit.Store
+	switch kind := os.Getenv(constants.EmailLinkingSendLimitStoreEnvKey); kind {
+	case "", "memory":
+		store = sendlimit.NewMemoryStore()
+	case "nats":
+		kv, errKV := usageKVStore(ctx)
+		if errKV != nil {
+			return nil, errKV
+		}
+		store = sendlimit.NewKVStore(kv)
+	default:
+		return nil, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingSendLimitStoreEnvKey, kind)
+	}
+
+	slog.DebugContext(ctx, "email linking send limits enabled",
+		"per_email", limits.Email.Count,
+		"per_user", limits.User.Count,
+	)
+	return sendlimit.New(store, limits), nil
+}
+
+// newOTPLockout creates the lockout of the email verification after repeated failed attempts, the
+// attempts are shared by the replicas through the usage KV bucket unless EMAIL_LINKING_LOCKOUT_STORE
+// keeps them in memory
+func newOTPLockout(ctx context.Context) (*otplockout.Lockout, error) {
+	policy, err := otplockout.ParsePolicy(os.Getenv(constants.EmailLinkingLockoutEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email linking lockout: %w", err)
+	}
+
+	var store otplockout.Store
+	switch kind := os.Getenv(constants.EmailLinkingLockoutStoreEnvKey); kind {
+	case "memory":
+		store = otplockout.NewMemoryStore()
+	case "", "nats":
+		natsInit(ctx)
+		kv, errKV := usageKVStore(ctx)
+		if errKV != nil {
+			return nil, errKV
+		}
+		store = otplockout.NewKVStore(kv)
+	default:
+		return nil, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingLockoutStoreEnvKey, kind)
+	}
+
+	slog.DebugContext(ctx, "email linking lockout enabled",
+		"max_failures", policy.MaxFailures,
+		"cooldown", policy.Cooldown,
+	)
+	return otplockout.New(store, policy), nil
+}
+
+// newEmailLinkingCodeStore creates the store of the pending alternate email OTPs of the providers
+// verifying them, shared by the replicas through the email linking codes KV bucket unless
+// EMAIL_LINKING_CODE_STORE keeps them in memory
+func newEmailLinkingCodeStore(ctx context.Context) (emaillinking.Store, error) {
+	switch kind := os.Getenv(constants.EmailLinkingCodeStoreEnvKey); kind {
+	case "memory":
+		return emaillinking.NewMemoryStore(nil), nil
+	case "", "nats":
+		natsInit(ctx)
+		if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameEmailLinkingCodes); err != nil {
+			return nil, fmt.Errorf("failed to initialize email linking codes KV bucket: %w", err)
+		}
+		kv, _ := natsClient.GetKVStore(constants.KVBucketNameEmailLinkingCodes)
+		return emaillinking.NewKVStore(kv), nil
+	default:
+		return nil, fmt.Errorf("invalid %s value %s, expected memory or nats", constants.EmailLinkingCodeStoreEnvKey, kind)
+	}
+}
+
+// newUserLocker creates the per-user locks serializing the updates of the same user, shared
+// by the replicas through the locks KV bucket when DISTRIBUTED_LOCKS is enabled
+func newUserLocker(ctx context.Context) (port.UserLocker, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.DistributedLocksEnvKey))
+	if !enabled {
+		return service.NewLocalUserLocker(), nil
+	}
+
+	kv, exists := natsClient.GetKVStore(constants.KVBucketNameLocks)
+	if !exists {
+		return nil, fmt.Errorf("KV bucket %s is not initialized", constants.KVBucketNameLocks)
+	}
+
+	slog.DebugContext(ctx, "distributed user locks enabled", "bucket", constants.KVBucketNameLocks)
+	return lock.NewLocker(kv), nil
+}
+
+// newProfileSearcher creates the typeahead index of the replica when PROFILE_TYPEAHEAD is enabled,
+// loaded from the profiles stream and kept up to date until the context is done
+func newProfileSearcher(ctx context.Context) (*profileindex.Index, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.ProfileTypeaheadEnvKey))
+	if !enabled {
+		return nil, nil
+	}
+
+	js, err := natsClient.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	index := profileindex.New()
+	if err := index.Follow(ctx, js); err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "typeahead search enabled", "stream", constants.StreamNameProfiles)
+	return index, nil
+}
+
+// newProfileLinkSigner signs the profile share links with the keys rotated in SIGNING_KEYS_STORE, or with
+// PROFILE_SHARE_SECRET without a store, the share links are disabled when neither is set
+func newProfileLinkSigner(ctx context.Context) (*sharelink.Signer, error) {
+	secret := os.Getenv(constants.ProfileShareSecretEnvKey)
+	keysConfig, errKeys := signingKeysConfigFromEnv()
+	if errKeys != nil {
+		return nil, errKeys
+	}
+	if secret == "" && keysConfig.store == "" {
+		return nil, nil
+	}
+
+	opts, errOpts := profileLinkSignerOptionsFromEnv()
+	if errOpts != nil {
+		return nil, errOpts
+	}
+	if secret != "" {
+		signer, err := sharelink.NewSigner([]byte(secret), opts...)
 		if err != nil {
-			log.Fatalf("invalid NATS reconnect wait duration %s : %v", natsReconnectWait, err)
+			return nil, fmt.Errorf("invalid %s: %w", constants.ProfileShareSecretEnvKey, err)
 		}
+		if keysConfig.store == "" {
+			slog.DebugContext(ctx, "profile share links enabled")
+			return signer, nil
+		}
+	}
+
+	// the secret keeps verifying the links signed before the keys were rotated
+
+	keys, errManager := newSigningKeys(ctx, keysConfig, []byte(secret))
+	if errManager != nil {
+		return nil, errManager
+	}
 
-		config := nats.Config{
-			URL:           natsURL,
-			Timeout:       natsTimeoutDuration,
-			MaxReconnect:  natsMaxReconnectInt,
-			ReconnectWait: natsReconnectWaitDuration,
+	slog.DebugContext(ctx, "profile share links enabled", "signing_keys_store", keysConfig.store)
+	return sharelink.NewRotatingSigner(keys, opts...), nil
+}
+
+// profileLinkSignerOptionsFromEnv returns the options of the profile share links signer
+func profileLinkSignerOptionsFromEnv() ([]sharelink.Option, error) {
+	var opts []sharelink.Option
+	if value := os.Getenv(constants.ProfileShareMaxTTLEnvKey); value != "" {
+		maxTTL, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %s: %w", constants.ProfileShareMaxTTLEnvKey, value, err)
 		}
+		opts = append(opts, sharelink.WithMaxTTL(maxTTL))
+	}
+	return opts, nil
+}
 
-		client, errNewClient := nats.NewClient(ctx, config)
-		if errNewClient != nil {
-			log.Fatalf("failed to create NATS client: %v", errNewClient)
+// signingKeysConfig is the configuration of the rotated keys signing the internal tokens, the
+// rotation is disabled when the store is empty
+type signingKeysConfig struct {
+	store     string
+	rotation  time.Duration
+	retention time.Duration
+	namespace string
+	secret    string
+	vault     vault.Config
+}
+
+// signingKeysConfigFromEnv returns the configuration of the signing keys, it doesn't contact the store
+func signingKeysConfigFromEnv() (signingKeysConfig, error) {
+	config := signingKeysConfig{
+		store:     os.Getenv(constants.SigningKeysStoreEnvKey),
+		rotation:  keyring.DefaultRotation,
+		retention: keyring.DefaultRetention,
+	}
+
+	for _, setting := range []struct {
+		key    string
+		target *time.Duration
+	}{
+		{key: constants.SigningKeysRotationEnvKey, target: &config.rotation},
+		{key: constants.SigningKeysRetentionEnvKey, target: &config.retention},
+	} {
+		value := os.Getenv(setting.key)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return config, fmt.Errorf("invalid %s value %s, expected a positive duration", setting.key, value)
+		}
+		*setting.target = duration
+	}
+
+	switch config.store {
+	case "":
+	case "kubernetes":
+		config.namespace = os.Getenv(constants.SigningKeysNamespaceEnvKey)
+		config.secret = os.Getenv(constants.SigningKeysSecretEnvKey)
+		if config.secret == "" {
+			config.secret = k8s.DefaultSigningKeysSecret
+		}
+	case "vault":
+		config.vault = vault.Config{
+			Address: os.Getenv(constants.VaultAddrEnvKey),
+			Token:   os.Getenv(constants.VaultTokenEnvKey),
+			Path:    os.Getenv(constants.SigningKeysVaultPathEnvKey),
+		}
+		if _, err := vault.NewKeyStore(config.vault); err != nil {
+			return config, err
+		}
+	default:
+		return config, fmt.Errorf("invalid %s value %s, expected kubernetes or vault", constants.SigningKeysStoreEnvKey, config.store)
+	}
+	return config, nil
+}
+
+// newSigningKeys loads the signing keys from their store and reloads them in the background,
+// rotating them when due
+func newSigningKeys(ctx context.Context, config signingKeysConfig, legacy []byte) (*keyring.Manager, error) {
+	var store keyring.Store
+	switch config.store {
+	case "kubernetes":
+		secretStore, err := k8s.NewSecretKeyStore(ctx, config.namespace, config.secret)
+		if err != nil {
+			return nil, err
+		}
+		store = secretStore
+	case "vault":
+		vaultStore, err := vault.NewKeyStore(config.vault)
+		if err != nil {
+			return nil, err
+		}
+		store = vaultStore
+	}
+
+	opts := []keyring.Option{
+		keyring.WithRotation(config.rotation),
+		keyring.WithRetention(config.retention),
+	}
+	if len(legacy) > 0 {
+		opts = append(opts, keyring.WithLegacyKey(legacy))
+	}
+	manager, err := keyring.NewManager(ctx, store, opts...)
+	if err != nil {
+		return nil, err
+	}
+	go manager.Run(ctx, keyring.DefaultRefresh)
+
+	return manager, nil
+}
+
+// newTokenReferenceSealer encrypts the references of the issued ID tokens with TOKEN_REFERENCE_SECRET,
+// the raw tokens are returned when it's not set
+func newTokenReferenceSealer(ctx context.Context) (*tokenref.Sealer, error) {
+	secret := os.Getenv(constants.TokenReferenceSecretEnvKey)
+	if secret == "" {
+		return nil, nil
+	}
+
+	var opts []tokenref.Option
+	if value := os.Getenv(constants.TokenReferenceTTLEnvKey); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.TokenReferenceTTLEnvKey, value)
+		}
+		opts = append(opts, tokenref.WithTTL(ttl))
+	}
+
+	sealer, err := tokenref.NewSealer([]byte(secret), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", constants.TokenReferenceSecretEnvKey, err)
+	}
+
+	slog.DebugContext(ctx, "token references enabled")
+	return sealer, nil
+}
+
+// emailBackupCodesFromEnv returns the options of the backup codes store, enabled by EMAIL_BACKUP_CODES
+func emailBackupCodesFromEnv() (bool, []backupcodes.Option, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.EmailBackupCodesEnvKey))
+	if !enabled {
+		return false, nil, nil
+	}
+
+	// the verified emails are returned as token references, there is no ID token to link
+	if os.Getenv(constants.TokenReferenceSecretEnvKey) == "" {
+		return false, nil, fmt.Errorf("%s requires %s", constants.EmailBackupCodesEnvKey, constants.TokenReferenceSecretEnvKey)
+	}
+
+	var opts []backupcodes.Option
+	if value := os.Getenv(constants.EmailBackupCodesTTLEnvKey); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			return false, nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.EmailBackupCodesTTLEnvKey, value)
+		}
+		opts = append(opts, backupcodes.WithTTL(ttl))
+	}
+	return true, opts, nil
+}
+
+// newEmailBackupCodeStore creates the backup codes store on the backup codes KV bucket when EMAIL_BACKUP_CODES is enabled
+func newEmailBackupCodeStore(ctx context.Context) (*backupcodes.Store, error) {
+	enabled, opts, err := emailBackupCodesFromEnv()
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameBackupCodes); err != nil {
+		return nil, fmt.Errorf("failed to initialize backup codes KV bucket: %w", err)
+	}
+	kv, _ := natsClient.GetKVStore(constants.KVBucketNameBackupCodes)
+
+	slog.DebugContext(ctx, "email backup codes enabled", "bucket", constants.KVBucketNameBackupCodes)
+	return backupcodes.New(kv, opts...), nil
+}
+
+// metadataPolicyFromEnv returns the chain of the moderation policies listed in METADATA_POLICY,
+// nil when the metadata updates aren't moderated, the nats policy sends its requests with the requester
+func metadataPolicyFromEnv(requester port.Requester) (port.MetadataPolicy, error) {
+	var chain moderation.Chain
+	for _, name := range strings.Split(os.Getenv(constants.MetadataPolicyEnvKey), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "blocklist":
+			opts := []moderation.BlocklistOption{
+				moderation.WithBlockedWords(strings.Split(os.Getenv(constants.MetadataPolicyBlockedWordsEnvKey), ",")...),
+			}
+			if value := os.Getenv(constants.MetadataPolicyLinksEnvKey); value != "" {
+				linkAction, err := moderation.ParseAction(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s: %w", constants.MetadataPolicyLinksEnvKey, err)
+				}
+				opts = append(opts, moderation.WithLinkAction(linkAction))
+			}
+			chain = append(chain, moderation.NewBlocklist(opts...))
+		case "nats":
+			subject := strings.TrimSpace(os.Getenv(constants.MetadataPolicySubjectEnvKey))
+			if subject == "" {
+				return nil, fmt.Errorf("the nats policy requires %s", constants.MetadataPolicySubjectEnvKey)
+			}
+			opts := []moderation.NATSOption{}
+			if value := os.Getenv(constants.MetadataPolicyTimeoutEnvKey); value != "" {
+				timeout, err := time.ParseDuration(value)
+				if err != nil || timeout <= 0 {
+					return nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.MetadataPolicyTimeoutEnvKey, value)
+				}
+				opts = append(opts, moderation.WithTimeout(timeout))
+			}
+			if value := os.Getenv(constants.MetadataPolicyFailOpenEnvKey); value != "" {
+				failOpen, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s value %s: %w", constants.MetadataPolicyFailOpenEnvKey, value, err)
+				}
+				opts = append(opts, moderation.WithFailOpen(failOpen))
+			}
+			chain = append(chain, moderation.NewNATSPolicy(requester, subject, opts...))
+		default:
+			return nil, fmt.Errorf("invalid %s value %s, expected blocklist or nats", constants.MetadataPolicyEnvKey, name)
+		}
+	}
+	if len(chain) == 0 {
+		return nil, nil
+	}
+	return chain, nil
+}
+
+// newMetadataProvenanceStore creates the provenance store on the provenance KV bucket when METADATA_PROVENANCE is enabled
+func newMetadataProvenanceStore(ctx context.Context) (*provenance.Store, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.MetadataProvenanceEnvKey))
+	if !enabled {
+		return nil, nil
+	}
+
+	if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameProvenance); err != nil {
+		return nil, fmt.Errorf("failed to initialize provenance KV bucket: %w", err)
+	}
+	kv, _ := natsClient.GetKVStore(constants.KVBucketNameProvenance)
+
+	slog.DebugContext(ctx, "metadata provenance enabled", "bucket", constants.KVBucketNameProvenance)
+	return provenance.New(kv), nil
+}
+
+// newCallerAllowlist creates the caller allowlist on the callers KV bucket when CALLER_ALLOWLIST is enabled
+func newCallerAllowlist(ctx context.Context) (*callers.Store, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.CallerAllowlistEnvKey))
+	if !enabled {
+		return nil, nil
+	}
+
+	if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameCallers); err != nil {
+		return nil, fmt.Errorf("failed to initialize callers KV bucket: %w", err)
+	}
+	kv, _ := natsClient.GetKVStore(constants.KVBucketNameCallers)
+
+	slog.DebugContext(ctx, "caller allowlist enabled", "bucket", constants.KVBucketNameCallers)
+	return callers.New(kv), nil
+}
+
+// newCallerAuthenticator creates the authenticator of the service tokens of the callers signed by the
+// CALLER_TOKEN_ISSUERS, nil when none is trusted so no caller is identified
+func newCallerAuthenticator(ctx context.Context) (*callers.TokenAuthenticator, error) {
+	issuers, err := oidc.ParseIssuers(os.Getenv(constants.CallerTokenIssuersEnvKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", constants.CallerTokenIssuersEnvKey, err)
+	}
+	if len(issuers) == 0 {
+		slog.WarnContext(ctx, "no caller token issuer trusted, the callers are unknown and granted nothing",
+			"env", constants.CallerTokenIssuersEnvKey,
+		)
+		return nil, nil
+	}
+
+	verifier, err := oidc.NewVerifier(ctx, httpclient.NewClient(httpclient.DefaultConfig()), issuers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the caller token verifier: %w", err)
+	}
+	return callers.NewTokenAuthenticator(verifier, os.Getenv(constants.CallerTokenClaimEnvKey)), nil
+}
+
+// userCacheConfig is the configuration of the users cache, disabled when the kind is empty
+type userCacheConfig struct {
+	kind string
+	ttl  time.Duration
+	size int
+	// maxStaleness is the age of the oldest user served while the identity provider is unavailable,
+	// zero disables the stale fallback
+	maxStaleness time.Duration
+}
+
+// userCacheConfigFromEnv loads the users cache configuration from the environment
+func userCacheConfigFromEnv() (userCacheConfig, error) {
+	config := userCacheConfig{
+		kind: os.Getenv(constants.UserCacheEnvKey),
+		ttl:  usercache.DefaultTTL,
+		size: usercache.DefaultSize,
+	}
+
+	switch config.kind {
+	case "", constants.UserCacheMemory, constants.UserCacheNATS:
+	default:
+		return config, fmt.Errorf("invalid %s value %s, expected %s or %s", constants.UserCacheEnvKey, config.kind, constants.UserCacheMemory, constants.UserCacheNATS)
+	}
+
+	if value := os.Getenv(constants.UserCacheTTLEnvKey); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("invalid %s value %s: %w", constants.UserCacheTTLEnvKey, value, err)
+		}
+		config.ttl = parsed
+	}
+	if value := os.Getenv(constants.UserCacheSizeEnvKey); value != "" && config.kind == constants.UserCacheMemory {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return config, fmt.Errorf("invalid %s value %s: %w", constants.UserCacheSizeEnvKey, value, err)
+		}
+		config.size = parsed
+	}
+	if value := os.Getenv(constants.UserCacheStaleFallbackEnvKey); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return config, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.UserCacheStaleFallbackEnvKey, value)
+		}
+		if config.kind == "" {
+			return config, fmt.Errorf("%s requires %s", constants.UserCacheStaleFallbackEnvKey, constants.UserCacheEnvKey)
+		}
+		config.maxStaleness = parsed
+	}
+	return config, nil
+}
+
+// userSearchDedupeWindowFromEnv returns the window of the user search dedupe, false when it's disabled
+func userSearchDedupeWindowFromEnv() (time.Duration, bool, error) {
+	value := os.Getenv(constants.UserSearchDedupeWindowEnvKey)
+	if value == "" {
+		return 0, false, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		return 0, false, fmt.Errorf("invalid %s value %s, expected a duration", constants.UserSearchDedupeWindowEnvKey, value)
+	}
+	return window, true, nil
+}
+
+// newDedupedUserReaderWriter collapses the identical user searches of the identity provider when
+// USER_SEARCH_DEDUPE_WINDOW is set
+func newDedupedUserReaderWriter(ctx context.Context, provider port.UserReaderWriter) (port.UserReaderWriter, error) {
+	window, enabled, err := userSearchDedupeWindowFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return provider, nil
+	}
+
+	slog.DebugContext(ctx, "user search dedupe enabled", "window", window)
+	return dedupe.NewUserReaderWriter(provider, window), nil
+}
+
+// newCachedUserReaderWriter serves the user lookups from the users cache when USER_CACHE is set, the
+// users are invalidated on the profile changed events of every replica
+func newCachedUserReaderWriter(ctx context.Context, provider port.UserReaderWriter) (port.UserReaderWriter, error) {
+	config, errConfig := userCacheConfigFromEnv()
+	if errConfig != nil {
+		return nil, errConfig
+	}
+
+	var cache port.UserCache
+	switch config.kind {
+	case "":
+		return provider, nil
+	case constants.UserCacheMemory:
+		cache = usercache.NewLRU(config.size, config.ttl)
+	case constants.UserCacheNATS:
+		if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameUserCache); err != nil {
+			return nil, fmt.Errorf("failed to initialize user cache KV bucket: %w", err)
+		}
+		kv, _ := natsClient.GetKVStore(constants.KVBucketNameUserCache)
+		cache = usercache.NewKV(kv, config.ttl)
+	}
+
+	var opts []usercache.ReaderOption
+	if config.maxStaleness > 0 {
+		opts = append(opts, usercache.WithStaleFallback(config.maxStaleness))
+	}
+	cached := usercache.NewUserReaderWriter(provider, cache, opts...)
+	if _, err := natsClient.Subscribe(ctx, constants.UserProfileChangedSubject, cached.Handle); err != nil {
+		return nil, fmt.Errorf("failed to subscribe the user cache to profile changed events: %w", err)
+	}
+	setUserCache(cached)
+
+	slog.DebugContext(ctx, "user cache enabled",
+		"cache", config.kind,
+		"ttl", config.ttl,
+		"stale_fallback", config.maxStaleness,
+	)
+	return cached, nil
+}
+
+// usageKVStore initializes the usage KV bucket, shared by the usage accounting and the cost guardrails
+func usageKVStore(ctx context.Context) (jetstream.KeyValue, error) {
+	if err := natsClient.KeyValueStore(ctx, constants.KVBucketNameUsage); err != nil {
+		return nil, fmt.Errorf("failed to initialize usage KV bucket: %w", err)
+	}
+	kv, _ := natsClient.GetKVStore(constants.KVBucketNameUsage)
+	return kv, nil
+}
+
+// auth0ConfigFromEnv loads the Auth0 configuration from the environment, the M2M credentials
+// are loaded by the client itself
+func auth0ConfigFromEnv() (auth0.Config, error) {
+	return auth0TenantConfigFromEnv("")
+}
+
+// auth0TenantConfigFromEnv loads the configuration of the Auth0 tenant from the environment, the
+// variables of an additional tenant are prefixed with its alias, see auth0.Config.EnvKey
+func auth0TenantConfigFromEnv(alias string) (auth0.Config, error) {
+	config := auth0.Config{
+		Alias:            alias,
+		CanarySampleRate: jwtparser.DefaultCanarySampleRate,
+	}
+
+	auth0Tenant := os.Getenv(config.EnvKey(constants.Auth0TenantEnvKey))
+	auth0Domain := os.Getenv(config.EnvKey(constants.Auth0DomainEnvKey))
+	if auth0Domain == "" && auth0Tenant != "" {
+		// Default to tenant.auth0.com if domain is not explicitly set
+		auth0Domain = fmt.Sprintf("%s.auth0.com", auth0Tenant)
+	}
+	config.Tenant = auth0Tenant
+	config.Domain = auth0Domain
+	config.OrganizationAdminClaim = os.Getenv(config.EnvKey(constants.Auth0OrganizationAdminClaimEnvKey))
+	config.CanaryJWKSURL = os.Getenv(config.EnvKey(constants.Auth0CanaryJWKSURLEnvKey))
+
+	if value := os.Getenv(config.EnvKey(constants.Auth0CanarySampleRateEnvKey)); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return config, fmt.Errorf("invalid %s value %s, expected a number between 0 and 1", config.EnvKey(constants.Auth0CanarySampleRateEnvKey), value)
+		}
+		config.CanarySampleRate = rate
+	}
+
+	if value := os.Getenv(config.EnvKey(constants.Auth0SearchMaxPagesEnvKey)); value != "" {
+		pages, err := strconv.Atoi(value)
+		if err != nil || pages < 1 || pages > auth0.MaxSearchPages {
+			return config, fmt.Errorf("invalid %s value %s, expected a number between 1 and %d", config.EnvKey(constants.Auth0SearchMaxPagesEnvKey), value, auth0.MaxSearchPages)
+		}
+		config.SearchMaxPages = pages
+	}
+	return config, nil
+}
+
+// auth0AdditionalConfigsFromEnv loads the configurations of the additional Auth0 tenants listed in
+// AUTH0_ADDITIONAL_TENANTS, nil when the deployment serves a single tenant
+func auth0AdditionalConfigsFromEnv() ([]auth0.Config, error) {
+	var configs []auth0.Config
+	for _, alias := range strings.Split(os.Getenv(constants.Auth0TenantsEnvKey), ",") {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		if slices.ContainsFunc(configs, func(config auth0.Config) bool { return strings.EqualFold(config.Alias, alias) }) {
+			return nil, fmt.Errorf("invalid %s, duplicate tenant %s", constants.Auth0TenantsEnvKey, alias)
+		}
+
+		config, errConfig := auth0TenantConfigFromEnv(alias)
+		if errConfig != nil {
+			return nil, errConfig
+		}
+		if config.Domain == "" {
+			return nil, fmt.Errorf("%s or %s is required for the %s tenant", config.EnvKey(constants.Auth0TenantEnvKey), config.EnvKey(constants.Auth0DomainEnvKey), alias)
+		}
+		trustedIssuers, errIssuers := oidc.ParseIssuers(os.Getenv(config.EnvKey(constants.Auth0TrustedIssuersEnvKey)))
+		if errIssuers != nil {
+			return nil, fmt.Errorf("invalid %s: %w", config.EnvKey(constants.Auth0TrustedIssuersEnvKey), errIssuers)
+		}
+		config.TrustedIssuers = trustedIssuers
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// auth0CassetteFromEnv opens the cassette configured via AUTH0_CASSETTE_MODE, nil when not set
+func auth0CassetteFromEnv() (*httpclient.Cassette, error) {
+	mode := os.Getenv(constants.Auth0CassetteModeEnvKey)
+	if mode == "" {
+		return nil, nil
+	}
+	return httpclient.NewCassette(httpclient.CassetteMode(strings.ToLower(mode)), auth0CassettePath(), nil)
+}
+
+// auth0CassettePath is the path of the Auth0 cassette, AUTH0_CASSETTE_PATH or cassettes/auth0.json
+func auth0CassettePath() string {
+	if path := os.Getenv(constants.Auth0CassettePathEnvKey); path != "" {
+		return path
+	}
+	return "cassettes/auth0.json"
+}
+
+// keycloakConfigFromEnv loads the Keycloak configuration from the environment
+func keycloakConfigFromEnv() keycloak.Config {
+	return keycloak.Config{
+		URL:          os.Getenv(constants.KeycloakURLEnvKey),
+		Realm:        os.Getenv(constants.KeycloakRealmEnvKey),
+		ClientID:     os.Getenv(constants.KeycloakClientIDEnvKey),
+		ClientSecret: os.Getenv(constants.KeycloakClientSecretEnvKey),
+		Audience:     os.Getenv(constants.KeycloakAudienceEnvKey),
+	}
+}
+
+// oktaConfigFromEnv loads the Okta configuration from the environment, the private key is base64 encoded
+func oktaConfigFromEnv() (okta.Config, error) {
+	privateKey, errDecode := base64.StdEncoding.DecodeString(os.Getenv(constants.OktaPrivateBase64KeyEnvKey))
+	if errDecode != nil {
+		return okta.Config{}, fmt.Errorf("failed to base64-decode %s: %w", constants.OktaPrivateBase64KeyEnvKey, errDecode)
+	}
+
+	return okta.Config{
+		Domain:     os.Getenv(constants.OktaDomainEnvKey),
+		ClientID:   os.Getenv(constants.OktaClientIDEnvKey),
+		PrivateKey: string(privateKey),
+		Scopes:     strings.Fields(os.Getenv(constants.OktaScopesEnvKey)),
+		Issuer:     os.Getenv(constants.OktaIssuerEnvKey),
+		Audience:   os.Getenv(constants.OktaAudienceEnvKey),
+	}, nil
+}
+
+// cognitoConfigFromEnv loads the Cognito configuration from the environment, the requests are signed
+// with the AWS credentials
+func cognitoConfigFromEnv() cognito.Config {
+	return cognito.Config{
+		UserPoolID: os.Getenv(constants.CognitoUserPoolIDEnvKey),
+		Region:     os.Getenv(constants.CognitoRegionEnvKey),
+		Endpoint:   os.Getenv(constants.CognitoEndpointEnvKey),
+		Credentials: sigv4.Credentials{
+			AccessKeyID:     os.Getenv(constants.AWSAccessKeyIDEnvKey),
+			SecretAccessKey: os.Getenv(constants.AWSSecretAccessKeyEnvKey),
+			SessionToken:    os.Getenv(constants.AWSSessionTokenEnvKey),
+		},
+		ClientID:       os.Getenv(constants.CognitoClientIDEnvKey),
+		ResourceServer: os.Getenv(constants.CognitoResourceServerEnvKey),
+		LinkingSecret:  os.Getenv(constants.CognitoLinkingSecretEnvKey),
+	}
+}
+
+// autheliaConfigFromEnv loads the Authelia configuration from the environment
+func autheliaConfigFromEnv() map[string]string {
+	configMapName := os.Getenv(constants.AutheliaConfigMapNameEnvKey)
+	if configMapName == "" {
+		configMapName = "authelia-users"
+	}
+	configMapNamespace := os.Getenv(constants.AutheliaConfigMapNamespaceEnvKey)
+	if configMapNamespace == "" {
+		configMapNamespace = "lfx"
+	}
+
+	daemonSetName := os.Getenv(constants.AutheliaDaemonSetNameEnvKey)
+	if daemonSetName == "" {
+		daemonSetName = "lfx-platform-authelia"
+	}
+
+	secretName := os.Getenv(constants.AutheliaSecretNameEnvKey)
+	if secretName == "" {
+		secretName = "authelia-users"
+	}
+
+	oidcUserInfoURL := os.Getenv(constants.AutheliaOIDCUserInfoURLEnvKey)
+	if oidcUserInfoURL == "" {
+		oidcUserInfoURL = "https://auth.k8s.orb.local/api/oidc/userinfo"
+	}
+
+	return map[string]string{
+		"configmap-name":       configMapName,
+		"namespace":            configMapNamespace,
+		"daemon-set-name":      daemonSetName,
+		"secret-name":          secretName,
+		"oidc-userinfo-url":    oidcUserInfoURL,
+		"restore-grace-period": os.Getenv(constants.AutheliaRestoreGracePeriodEnvKey),
+		"stale-profile-months": os.Getenv(constants.AutheliaStaleProfileMonthsEnvKey),
+		"stale-profile-scan":   os.Getenv(constants.AutheliaStaleProfileScanIntervalEnvKey),
+		"event-sourcing":       os.Getenv(constants.AutheliaEventSourcingEnvKey),
+		"region":               os.Getenv(constants.ServiceRegionEnvKey),
+		"index-reconcile":      os.Getenv(constants.AutheliaIndexReconcileIntervalEnvKey),
+		"distributed-locks":    os.Getenv(constants.DistributedLocksEnvKey),
+		"sync-dry-run":         os.Getenv(constants.AutheliaSyncDryRunEnvKey),
+	}
+}
+
+// mockOptionsFromEnv loads the simulated latency and failures of the mock provider, so staging
+// can mimic the response times of the production provider, and the file its users are loaded from
+func mockOptionsFromEnv() ([]mock.Option, error) {
+	var opts []mock.Option
+	if value := os.Getenv(constants.MockProviderLatencyEnvKey); value != "" {
+		minLatency, maxLatency, err := mock.ParseLatency(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %s, expected milliseconds or a min-max range", constants.MockProviderLatencyEnvKey, value)
+		}
+		opts = append(opts, mock.WithLatency(minLatency, maxLatency))
+	}
+	if value := os.Getenv(constants.MockProviderErrorRateEnvKey); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a number between 0 and 1", constants.MockProviderErrorRateEnvKey, value)
+		}
+		opts = append(opts, mock.WithErrorRate(rate))
+	}
+	indexCheckInterval := mock.DefaultIndexCheckInterval
+	if value := os.Getenv(constants.MockIndexCheckIntervalEnvKey); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a duration", constants.MockIndexCheckIntervalEnvKey, value)
+		}
+		indexCheckInterval = interval
+	}
+	opts = append(opts, mock.WithIndexCheckInterval(indexCheckInterval))
+	if path := os.Getenv(constants.MockUsersFileEnvKey); path != "" {
+		opts = append(opts, mock.WithUsersFile(path))
+	}
+	faultsSpec, latenciesSpec := os.Getenv(constants.MockProviderFaultsEnvKey), os.Getenv(constants.MockProviderOperationLatencyEnvKey)
+	if faultsSpec != "" || latenciesSpec != "" {
+		faults, err := mock.ParseOperationFaults(faultsSpec, latenciesSpec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s or %s value: %w", constants.MockProviderFaultsEnvKey, constants.MockProviderOperationLatencyEnvKey, err)
 		}
-		natsClient = client
-	})
+		opts = append(opts, mock.WithOperationFaults(faults))
+	}
+	return opts, nil
 }
 
 // newUserReaderWriter creates a UserReaderWriter implementation based on the environment variable.
-// Set USER_REPOSITORY_TYPE to "mock" to explicitly use mock, or "auth0" to use Auth0.
-func newUserReaderWriter(ctx context.Context) port.UserReaderWriter {
+// Set USER_REPOSITORY_TYPE to "mock" to explicitly use mock, "auth0" to use Auth0, "authelia"
+// to use Authelia, "keycloak" to use Keycloak, "okta" to use Okta or "cognito" to use AWS Cognito.
+func newUserReaderWriter(ctx context.Context, auditSink port.AuditSink) port.UserReaderWriter {
 
 	userRepositoryType := os.Getenv(constants.UserRepositoryTypeEnvKey)
 	if userRepositoryType == "" {
@@ -91,79 +1210,157 @@ func newUserReaderWriter(ctx context.Context) port.UserReaderWriter {
 
 	switch userRepositoryType {
 	case constants.UserRepositoryTypeMock:
+		mockOptions, errConfig := mockOptionsFromEnv()
+		if errConfig != nil {
+			log.Fatal(errConfig)
+		}
 		slog.DebugContext(ctx, "using mock user repository implementation")
-		return mock.NewUserReaderWriter(ctx)
+		return mock.NewUserReaderWriter(ctx, mockOptions...)
 	case constants.UserRepositoryTypeAuth0:
 
 		// Load Auth0 configuration from environment variables
-		auth0Tenant := os.Getenv(constants.Auth0TenantEnvKey)
-		auth0Domain := os.Getenv(constants.Auth0DomainEnvKey)
+		auth0Config, errConfig := auth0ConfigFromEnv()
+		if errConfig != nil {
+			log.Fatal(errConfig)
+		}
 
 		slog.DebugContext(ctx, "using Auth0 user repository implementation",
-			"tenant", auth0Tenant,
-			"domain", auth0Domain,
+			"tenant", auth0Config.Tenant,
+			"domain", auth0Config.Domain,
 		)
 
-		if auth0Domain == "" {
-			// Default to tenant.auth0.com if domain is not explicitly set
-			auth0Domain = fmt.Sprintf("%s.auth0.com", auth0Tenant)
+		// Record the Auth0 traffic, or replay it offline, in development
+		cassette, errCassette := auth0CassetteFromEnv()
+		if errCassette != nil {
+			log.Fatalf("failed to open the Auth0 cassette: %v", errCassette)
+		}
+		if cassette != nil {
+			slog.WarnContext(ctx, "Auth0 traffic goes through a cassette, development only",
+				"mode", cassette.Mode(),
+				"path", auth0CassettePath(),
+			)
+			auth0Config.Cassette = cassette
+		}
+
+		httpConfig := httpclient.DefaultConfig()
+		httpConfig.Recorder = providerScoreboard.Recorder(constants.UserRepositoryTypeAuth0)
+		rateLimits, errRateLimits := httpclient.ParseRateLimits(os.Getenv(constants.Auth0RateLimitsEnvKey))
+		if errRateLimits != nil {
+			log.Fatalf("invalid %s: %v", constants.Auth0RateLimitsEnvKey, errRateLimits)
 		}
+		httpConfig.RateLimits = rateLimits
 
-		auth0Config := auth0.Config{
-			Tenant: auth0Tenant,
-			Domain: auth0Domain,
+		trustedIssuers, errIssuers := oidc.ParseIssuers(os.Getenv(constants.Auth0TrustedIssuersEnvKey))
+		if errIssuers != nil {
+			log.Fatalf("invalid %s: %v", constants.Auth0TrustedIssuersEnvKey, errIssuers)
 		}
+		auth0Config.TrustedIssuers = trustedIssuers
 
-		slog.DebugContext(ctx, "Auth0 client initialized with M2M token support",
-			"tenant", auth0Tenant,
-			"domain", auth0Domain,
-		)
+		// the additional tenants share the rate limits settings and the cassette of the primary one
+		additionalConfigs, errAdditional := auth0AdditionalConfigsFromEnv()
+		if errAdditional != nil {
+			log.Fatal(errAdditional)
+		}
+		for i := range additionalConfigs {
+			additionalConfigs[i].Cassette = auth0Config.Cassette
+			slog.DebugContext(ctx, "serving an additional Auth0 tenant",
+				"alias", additionalConfigs[i].Alias,
+				"domain", additionalConfigs[i].Domain,
+			)
+		}
 
-		userReaderWriter, err := auth0.NewUserReaderWriter(ctx, httpclient.DefaultConfig(), auth0Config)
+		userReaderWriter, err := auth0.NewMultiTenantUserReaderWriter(ctx, httpConfig, auth0Config, additionalConfigs...)
 		if err != nil {
 			log.Fatalf("failed to create Auth0 user reader writer: %v", err)
 		}
 
 		return userReaderWriter
-	case constants.UserRepositoryTypeAuthelia:
-		// Initialize NATS client first for Authelia NATS storage
-		natsInit(ctx)
+	case constants.UserRepositoryTypeKeycloak:
 
-		// Load Authelia configuration from environment variables
-		configMapName := os.Getenv(constants.AutheliaConfigMapNameEnvKey)
-		if configMapName == "" {
-			configMapName = "authelia-users"
+		// Load Keycloak configuration from environment variables
+		keycloakConfig := keycloakConfigFromEnv()
+		codeStore, errCodeStore := newEmailLinkingCodeStore(ctx)
+		if errCodeStore != nil {
+			log.Fatal(errCodeStore)
 		}
-		configMapNamespace := os.Getenv(constants.AutheliaConfigMapNamespaceEnvKey)
-		if configMapNamespace == "" {
-			configMapNamespace = "lfx"
+		keycloakConfig.VerificationCodes = codeStore
+
+		slog.DebugContext(ctx, "using Keycloak user repository implementation",
+			"url", keycloakConfig.URL,
+			"realm", keycloakConfig.Realm,
+		)
+
+		httpConfig := httpclient.DefaultConfig()
+		httpConfig.Recorder = providerScoreboard.Recorder(constants.UserRepositoryTypeKeycloak)
+
+		userReaderWriter, err := keycloak.NewUserReaderWriter(ctx, httpConfig, keycloakConfig)
+		if err != nil {
+			log.Fatalf("failed to create Keycloak user reader writer: %v", err)
 		}
 
-		daemonSetName := os.Getenv(constants.AutheliaDaemonSetNameEnvKey)
-		if daemonSetName == "" {
-			daemonSetName = "lfx-platform-authelia"
+		return userReaderWriter
+	case constants.UserRepositoryTypeOkta:
+
+		// Load Okta configuration from environment variables, the private key is base64 encoded
+		oktaConfig, errConfig := oktaConfigFromEnv()
+		if errConfig != nil {
+			log.Fatal(errConfig)
 		}
+		codeStore, errCodeStore := newEmailLinkingCodeStore(ctx)
+		if errCodeStore != nil {
+			log.Fatal(errCodeStore)
+		}
+		oktaConfig.VerificationCodes = codeStore
+
+		slog.DebugContext(ctx, "using Okta user repository implementation",
+			"domain", oktaConfig.Domain,
+		)
+
+		httpConfig := httpclient.DefaultConfig()
+		httpConfig.Recorder = providerScoreboard.Recorder(constants.UserRepositoryTypeOkta)
 
-		secretName := os.Getenv(constants.AutheliaSecretNameEnvKey)
-		if secretName == "" {
-			secretName = "authelia-users"
+		userReaderWriter, err := okta.NewUserReaderWriter(ctx, httpConfig, oktaConfig)
+		if err != nil {
+			log.Fatalf("failed to create Okta user reader writer: %v", err)
 		}
 
-		oidcUserInfoURL := os.Getenv(constants.AutheliaOIDCUserInfoURLEnvKey)
-		if oidcUserInfoURL == "" {
-			oidcUserInfoURL = "https://auth.k8s.orb.local/api/oidc/userinfo"
+		return userReaderWriter
+	case constants.UserRepositoryTypeCognito:
+
+		// Load Cognito configuration from environment variables
+		cognitoConfig := cognitoConfigFromEnv()
+		codeStore, errCodeStore := newEmailLinkingCodeStore(ctx)
+		if errCodeStore != nil {
+			log.Fatal(errCodeStore)
 		}
+		cognitoConfig.VerificationCodes = codeStore
+
+		slog.DebugContext(ctx, "using Cognito user repository implementation",
+			"user_pool_id", cognitoConfig.UserPoolID,
+			"region", cognitoConfig.Region,
+		)
+
+		httpConfig := httpclient.DefaultConfig()
+		httpConfig.Recorder = providerScoreboard.Recorder(constants.UserRepositoryTypeCognito)
 
-		config := map[string]string{
-			"configmap-name":    configMapName,
-			"namespace":         configMapNamespace,
-			"daemon-set-name":   daemonSetName,
-			"secret-name":       secretName,
-			"oidc-userinfo-url": oidcUserInfoURL,
+		userReaderWriter, err := cognito.NewUserReaderWriter(ctx, httpConfig, cognitoConfig)
+		if err != nil {
+			log.Fatalf("failed to create Cognito user reader writer: %v", err)
 		}
 
+		return userReaderWriter
+	case constants.UserRepositoryTypeAuthelia:
+		// Initialize NATS client first for Authelia NATS storage
+		natsInit(ctx)
+
+		// Load Authelia configuration from environment variables
+		config := autheliaConfigFromEnv()
+
 		// Create Authelia user repository with NATS client for storage
-		userWriter, err := authelia.NewUserReaderWriter(ctx, config, natsClient)
+		userWriter, err := authelia.NewUserReaderWriter(ctx, config, natsClient,
+			authelia.WithAuditSink(auditSink),
+			authelia.WithNotificationSender(getNotificationSender()),
+		)
 		if err != nil {
 			log.Fatalf("failed to create Authelia user repository: %v", err)
 		}
@@ -181,7 +1378,226 @@ func QueueSubscriptions(ctx context.Context) error {
 	// Initialize NATS client first
 	natsInit(ctx)
 
-	userReaderWriter := newUserReaderWriter(ctx)
+	// the email index keys depend on the normalization, it must be set before the stores are loaded
+	emailNormalizationInit(ctx)
+
+	// the audit sinks record the user mutations, the Authelia syncs included
+	auditSink, errAuditSink := newAuditSink(ctx)
+	if errAuditSink != nil {
+		return errAuditSink
+	}
+
+	provider := newUserReaderWriter(ctx, auditSink)
+
+	// the identical searches storming the provider are collapsed when enabled
+	deduped, errDedupe := newDedupedUserReaderWriter(ctx, provider)
+	if errDedupe != nil {
+		return errDedupe
+	}
+
+	// the lookups are served from the users cache when enabled
+	userReaderWriter, errUserCache := newCachedUserReaderWriter(ctx, deduped)
+	if errUserCache != nil {
+		return errUserCache
+	}
+	setUserReader(userReaderWriter)
+
+	// the optional capabilities are those of the provider, the cache doesn't expose them, their
+	// handlers publish the profile changed events that invalidate the cache
+
+	// soft-delete and restore are only available for providers backed by internal stores
+	userDeleter, _ := provider.(port.UserDeleter)
+
+	// delegated updates are only available for providers able to resolve organization admins
+	organizationAdminWriter, _ := provider.(port.OrganizationAdminWriter)
+
+	// WebAuthn authenticators are only available for providers exposing them
+	authenticatorManager, _ := provider.(port.AuthenticatorManager)
+
+	// account merge is only available for providers able to link accounts
+	userMerger, _ := provider.(port.UserMerger)
+
+	// the membership by email hash is only available for providers backed by the KV email index
+	emailHashMatcher, _ := provider.(port.EmailHashMatcher)
+
+	// the enrichment jobs are only served by providers able to patch the metadata with the service credentials
+	metadataEnricher, _ := provider.(port.UserMetadataEnricher)
+
+	// the emails verified with a backup code are only linked by providers able to link them without an ID token
+	verifiedEmailLinker, _ := provider.(port.VerifiedEmailLinker)
+
+	// the users are only reset and seeded at runtime by the mock provider
+	userFixtureLoader, _ := provider.(port.UserFixtureLoader)
+
+	// the faults are only injected at runtime in the mock provider
+	faultInjector, _ := provider.(port.FaultInjector)
+
+	// the alternate emails are only unlinked by address by providers able to resolve their identity
+	emailUnlinker, _ := provider.(port.AlternateEmailUnlinker)
+
+	// the primary email is only changed by providers able to update it with the service credentials
+	primaryEmailChanger, _ := provider.(port.PrimaryEmailChanger)
+
+	// the phone numbers are only verified by providers with a passwordless SMS connection, when enabled
+	var verificationHandler port.VerificationHandler
+	if enabled, _ := strconv.ParseBool(os.Getenv(constants.PhoneLinkingEnvKey)); enabled {
+		verificationHandler, _ = provider.(port.VerificationHandler)
+		if verificationHandler == nil {
+			slog.WarnContext(ctx, "phone linking enabled but not supported by the identity provider")
+		}
+	}
+	phoneLinkingChannel, errChannel := model.ParsePhoneChannel(os.Getenv(constants.PhoneLinkingChannelEnvKey))
+	if errChannel != nil {
+		return errChannel
+	}
+
+	// usage accounting is optional, keep the interfaces nil when disabled
+	var (
+		usageRecorder port.UsageRecorder
+		usageReader   port.UsageReader
+	)
+	accountant, errAccountant := newUsageAccountant(ctx)
+	if errAccountant != nil {
+		return errAccountant
+	}
+	if accountant != nil {
+		usageRecorder, usageReader = accountant, accountant
+	}
+
+	// the slow requests log is on by default, keep it nil when disabled
+	slowRequests, errSlowRequests := slowRequestLoggerFromEnv()
+	if errSlowRequests != nil {
+		return errSlowRequests
+	}
+
+	// consumer contracts are optional, keep the interfaces nil when none is registered
+	var (
+		sliRecorder          port.SLIRecorder
+		contractReportReader port.ContractReportReader
+	)
+	tracker, errTracker := contractTrackerFromEnv()
+	if errTracker != nil {
+		return errTracker
+	}
+	if tracker != nil {
+		sliRecorder, contractReportReader = tracker, tracker
+	}
+
+	middlewares, errMiddlewares := middlewareChainFromEnv(usageRecorder, sliRecorder, slowRequests)
+	if errMiddlewares != nil {
+		return errMiddlewares
+	}
+
+	// cost guardrails are optional, keep the interface nil when no caller is limited
+	var costGuard port.CostGuard
+	guard, errGuard := newCostGuard(ctx)
+	if errGuard != nil {
+		return errGuard
+	}
+	if guard != nil {
+		costGuard = guard
+	}
+
+	emailSendLimiter, errSendLimiter := newEmailSendLimiter(ctx)
+	if errSendLimiter != nil {
+		return errSendLimiter
+	}
+
+	otpLockout, errLockout := newOTPLockout(ctx)
+	if errLockout != nil {
+		return errLockout
+	}
+
+	userLocker, errUserLocker := newUserLocker(ctx)
+	if errUserLocker != nil {
+		return errUserLocker
+	}
+
+	// typeahead search is optional, keep the interface nil when disabled
+	var profileSearcher port.ProfileSearcher
+	index, errIndex := newProfileSearcher(ctx)
+	if errIndex != nil {
+		return errIndex
+	}
+	if index != nil {
+		profileSearcher = index
+	}
+
+	// profile share links are optional, keep the interface nil when disabled
+	var profileLinkSigner port.ProfileLinkSigner
+	signer, errSigner := newProfileLinkSigner(ctx)
+	if errSigner != nil {
+		return errSigner
+	}
+	if signer != nil {
+		profileLinkSigner = signer
+	}
+
+	// token references are optional, keep the interface nil when disabled
+	var tokenRefSealer port.TokenReferenceSealer
+	sealer, errSealer := newTokenReferenceSealer(ctx)
+	if errSealer != nil {
+		return errSealer
+	}
+	if sealer != nil {
+		tokenRefSealer = sealer
+	}
+
+	// the backup codes are optional, keep the interface nil when disabled
+	var backupCodeStore port.EmailBackupCodeStore
+	codes, errCodes := newEmailBackupCodeStore(ctx)
+	if errCodes != nil {
+		return errCodes
+	}
+	if codes != nil {
+		backupCodeStore = codes
+	}
+
+	// the metadata provenance is optional, keep the interface nil when disabled
+	var provenanceStore port.MetadataProvenanceStore
+	provenanceKV, errProvenance := newMetadataProvenanceStore(ctx)
+	if errProvenance != nil {
+		return errProvenance
+	}
+	if provenanceKV != nil {
+		provenanceStore = provenanceKV
+	}
+
+	// the caller allowlist is optional, keep the interface nil when disabled
+	var callerAllowlist port.CallerAllowlist
+	allowlist, errAllowlist := newCallerAllowlist(ctx)
+	if errAllowlist != nil {
+		return errAllowlist
+	}
+	if allowlist != nil {
+		callerAllowlist = allowlist
+	}
+
+	// the callers are only identified by their service tokens, keep the interface nil when none is trusted
+	var callerAuthenticator port.CallerAuthenticator
+	authenticator, errAuthenticator := newCallerAuthenticator(ctx)
+	if errAuthenticator != nil {
+		return errAuthenticator
+	}
+	if authenticator != nil {
+		callerAuthenticator = authenticator
+	}
+
+	// the metadata moderation is optional, keep the interface nil when disabled
+	metadataPolicy, errPolicy := metadataPolicyFromEnv(natsClient)
+	if errPolicy != nil {
+		return errPolicy
+	}
+
+	organizationDomains, errOrganizationDomains := model.ParseOrganizationDomains(os.Getenv(constants.OrganizationDomainsEnvKey))
+	if errOrganizationDomains != nil {
+		return fmt.Errorf("invalid organization domains: %w", errOrganizationDomains)
+	}
+
+	responsePolicies, errResponsePolicies := model.ParseResponsePolicies(os.Getenv(constants.ResponsePoliciesEnvKey))
+	if errResponsePolicies != nil {
+		return fmt.Errorf("invalid response policies: %w", errResponsePolicies)
+	}
 
 	messageHandlerService := &MessageHandlerService{
 		messageHandler: service.NewMessageHandlerOrchestrator(
@@ -194,51 +1610,481 @@ func QueueSubscriptions(ctx context.Context) error {
 			service.WithEmailHandlerForMessageHandler(
 				userReaderWriter,
 			),
+			service.WithVerificationHandlerForMessageHandler(
+				verificationHandler,
+			),
+			service.WithPhoneLinkingChannelForMessageHandler(
+				phoneLinkingChannel,
+			),
 			service.WithIdentityLinkerForMessageHandler(
 				userReaderWriter,
 			),
 			service.WithIdentityUnlinkerForMessageHandler(
 				userReaderWriter,
 			),
+			service.WithAlternateEmailUnlinkerForMessageHandler(
+				emailUnlinker,
+			),
+			service.WithUserDeleterForMessageHandler(
+				userDeleter,
+			),
+			service.WithOrganizationDomainsForMessageHandler(
+				organizationDomains,
+			),
+			service.WithOrganizationAdminWriterForMessageHandler(
+				organizationAdminWriter,
+			),
+			service.WithAuthenticatorManagerForMessageHandler(
+				authenticatorManager,
+			),
+			service.WithUserMergerForMessageHandler(
+				userMerger,
+			),
+			service.WithEventPublisherForMessageHandler(
+				natsClient,
+			),
+			service.WithAuditSinkForMessageHandler(
+				auditSink,
+			),
+			service.WithProviderStatusReaderForMessageHandler(
+				providerScoreboard,
+			),
+			service.WithUsageReaderForMessageHandler(
+				usageReader,
+			),
+			service.WithContractReportReaderForMessageHandler(
+				contractReportReader,
+			),
+			service.WithCostGuardForMessageHandler(
+				costGuard,
+			),
+			service.WithEmailSendLimiterForMessageHandler(
+				emailSendLimiter,
+			),
+			service.WithOTPAttemptGuardForMessageHandler(
+				otpLockout,
+			),
+			service.WithUserLockerForMessageHandler(
+				userLocker,
+			),
+			service.WithResponsePoliciesForMessageHandler(
+				responsePolicies,
+			),
+			service.WithProfileSearcherForMessageHandler(
+				profileSearcher,
+			),
+			service.WithEmailOwnerCallersForMessageHandler(
+				strings.Split(os.Getenv(constants.EmailOwnerCallersEnvKey), ",")...,
+			),
+			service.WithVerboseErrorCallersForMessageHandler(
+				strings.Split(os.Getenv(constants.VerboseErrorCallersEnvKey), ",")...,
+			),
+			service.WithCallerAllowlistForMessageHandler(
+				callerAllowlist,
+			),
+			service.WithEmailHashMatcherForMessageHandler(
+				emailHashMatcher,
+			),
+			service.WithProfileLinkSignerForMessageHandler(
+				profileLinkSigner,
+				os.Getenv(constants.ProfileShareBaseURLEnvKey),
+			),
+			service.WithTokenReferenceSealerForMessageHandler(
+				tokenRefSealer,
+			),
+			service.WithEmailBackupCodeStoreForMessageHandler(
+				backupCodeStore,
+			),
+			service.WithVerifiedEmailLinkerForMessageHandler(
+				verifiedEmailLinker,
+			),
+			service.WithPrimaryEmailChangerForMessageHandler(
+				primaryEmailChanger,
+			),
+			service.WithNotificationSenderForMessageHandler(
+				getNotificationSender(),
+			),
+			service.WithMetadataProvenanceStoreForMessageHandler(
+				provenanceStore,
+			),
+			service.WithMetadataEnricherForMessageHandler(
+				metadataEnricher,
+				strings.Split(os.Getenv(constants.MetadataEnrichmentCallersEnvKey), ",")...,
+			),
+			service.WithMetadataPolicyForMessageHandler(
+				metadataPolicy,
+			),
+			service.WithUserFixtureLoaderForMessageHandler(
+				userFixtureLoader,
+			),
+			service.WithFaultInjectorForMessageHandler(
+				faultInjector,
+			),
 		),
+		middlewares:         middlewares,
+		responseMeta:        ResponseMetaFromEnv(),
+		callerAuthenticator: callerAuthenticator,
 	}
 
+	// the REST mirrors are served by the same handlers
+	setMessageHandler(messageHandlerService)
+	setCallerAllowlist(callerAllowlist)
+	setEmailBackupCodeStore(backupCodeStore)
+	setMetadataProvenanceStore(provenanceStore)
+
 	// Get the NATS client - we need to access it directly
 	natsClient := getNATSClient()
 	if natsClient == nil {
 		return fmt.Errorf("NATS client not initialized")
 	}
 
-	// Start subscriptions for each subject
-	subjects := map[string]func(context.Context, port.TransportMessenger){
-		constants.UserMetadataUpdateSubject:           messageHandlerService.HandleMessage,
-		constants.UserEmailToUserSubject:              messageHandlerService.HandleMessage,
-		constants.UserEmailToSubSubject:               messageHandlerService.HandleMessage,
-		constants.UserMetadataReadSubject:             messageHandlerService.HandleMessage,
-		constants.UserEmailReadSubject:                messageHandlerService.HandleMessage,
-		constants.EmailLinkingSendVerificationSubject: messageHandlerService.HandleMessage,
-		constants.EmailLinkingVerifySubject:           messageHandlerService.HandleMessage,
-		constants.UserIdentityLinkSubject:             messageHandlerService.HandleMessage,
-		constants.UserIdentityUnlinkSubject:           messageHandlerService.HandleMessage,
-		constants.UserIdentityListSubject:             messageHandlerService.HandleMessage,
-		// Add more subjects here as needed
-	}
-
-	for subject, handler := range subjects {
-		slog.DebugContext(ctx, "subscribing to NATS subject", "subject", subject)
-		if _, err := natsClient.SubscribeWithTransportMessenger(ctx, subject, constants.AuthServiceQueue, handler); err != nil {
+	// Start subscriptions for each subject of the registry
+	for _, subject := range service.Subjects() {
+		slog.DebugContext(ctx, "subscribing to NATS subject", "subject", subject.Name)
+		if _, err := natsClient.SubscribeWithTransportMessenger(ctx, subject.Name, constants.AuthServiceQueue, messageHandlerService.HandleMessage); err != nil {
 			slog.ErrorContext(ctx, "failed to subscribe to NATS subject",
 				"error", err,
-				"subject", subject,
+				"subject", subject.Name,
 			)
-			return fmt.Errorf("failed to subscribe to subject %s: %w", subject, err)
+			return fmt.Errorf("failed to subscribe to subject %s: %w", subject.Name, err)
 		}
 	}
 
+	if err := profileFeedInit(ctx); err != nil {
+		return err
+	}
+
+	if err := adminFeedInit(ctx); err != nil {
+		return err
+	}
+
+	// the documents are read from the provider, the cache may not be invalidated yet
+	profiles, errProfileStream := profileStreamInit(ctx, provider)
+	if errProfileStream != nil {
+		return errProfileStream
+	}
+
+	// the audited users are read from the provider, never from the cache
+	if err := consistencyAuditInit(ctx, provider, profiles); err != nil {
+		return err
+	}
+
+	// the runbook operations read the users from the provider and repair the same indices
+	runbookInit(provider, auditSink, profiles)
+
 	slog.DebugContext(ctx, "NATS subscriptions started successfully")
 	return nil
 }
 
+// DrainSubscriptions stops taking NATS requests on shutdown, the requests already received
+// are handled and replied to first
+func DrainSubscriptions(ctx context.Context) error {
+	if natsClient == nil {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "draining NATS subscriptions")
+	return natsClient.DrainSubscriptions(ctx)
+}
+
+// setUserReader shares the user reader of the identity provider with the HTTP endpoints
+func setUserReader(reader port.UserReader) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedUserReader = reader
+}
+
+// getUserReader returns the user reader of the identity provider, nil until the NATS subscriptions are started
+func getUserReader() port.UserReader {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedUserReader
+}
+
+// setMessageHandler shares the NATS message handlers with the REST mirrors
+func setMessageHandler(handler *MessageHandlerService) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedMessageHandler = handler
+}
+
+// getMessageHandler returns the NATS message handlers, nil until the NATS subscriptions are started
+func getMessageHandler() *MessageHandlerService {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedMessageHandler
+}
+
+// setCallerAllowlist shares the caller allowlist with the admin REST API
+func setCallerAllowlist(allowlist port.CallerAllowlist) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedCallerAllowlist = allowlist
+}
+
+// getCallerAllowlist returns the caller allowlist, nil until the subscriptions are started or when disabled
+func getCallerAllowlist() port.CallerAllowlist {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedCallerAllowlist
+}
+
+// setEmailBackupCodeStore shares the backup codes store with the admin REST API
+func setEmailBackupCodeStore(store port.EmailBackupCodeStore) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedBackupCodeStore = store
+}
+
+// getEmailBackupCodeStore returns the backup codes store, nil until the subscriptions are started or when disabled
+func getEmailBackupCodeStore() port.EmailBackupCodeStore {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedBackupCodeStore
+}
+
+// setMetadataProvenanceStore shares the metadata provenance store with the admin REST API
+func setMetadataProvenanceStore(store port.MetadataProvenanceStore) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedProvenanceStore = store
+}
+
+// getMetadataProvenanceStore returns the metadata provenance store, nil until the subscriptions are started or when disabled
+func getMetadataProvenanceStore() port.MetadataProvenanceStore {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedProvenanceStore
+}
+
+// setProfileFeed shares the profile changes feed with the HTTP endpoints
+func setProfileFeed(feed port.ProfileChangeSubscriber) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedProfileFeed = feed
+}
+
+// getProfileFeed returns the profile changes feed, nil until the NATS subscriptions are started
+func getProfileFeed() port.ProfileChangeSubscriber {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedProfileFeed
+}
+
+// profileFeedInit subscribes the profile changes feed to the profile changed events,
+// on every replica since the HTTP streams are spread across them
+func profileFeedInit(ctx context.Context) error {
+	feed := profilefeed.New(profilefeed.DefaultMaxSubscriptionsPerUser)
+	if _, err := natsClient.Subscribe(ctx, constants.UserProfileChangedSubject, feed.Dispatch); err != nil {
+		return fmt.Errorf("failed to subscribe to profile changed events: %w", err)
+	}
+	setProfileFeed(feed)
+	return nil
+}
+
+// setUserCache shares the users cache with the HTTP endpoints
+func setUserCache(cache *usercache.UserReaderWriter) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedUserCache = cache
+}
+
+// getUserCache returns the users cache, nil when disabled or until the NATS subscriptions are started
+func getUserCache() *usercache.UserReaderWriter {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedUserCache
+}
+
+// runbookInit shares the runbook operations with the admin endpoints, the email index rebuild
+// repairs the users cache and the profiles stream when they are enabled
+func runbookInit(provider port.UserReader, auditSink port.AuditSink, profiles *profilestream.Publisher) {
+	opts := []runbook.Option{runbook.WithAuditSink(auditSink)}
+	if cache := getUserCache(); cache != nil {
+		opts = append(opts, runbook.WithIndex(consistencyIndexUserCache, cache))
+	}
+	if profiles != nil {
+		opts = append(opts, runbook.WithIndex(consistencyIndexProfileStream, profiles))
+	}
+	setRunbook(runbook.New(provider, opts...))
+}
+
+// setRunbook shares the runbook operations with the HTTP endpoints
+func setRunbook(r *runbook.Runbook) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedRunbook = r
+}
+
+// getRunbook returns the runbook operations, nil until the NATS subscriptions are started
+func getRunbook() *runbook.Runbook {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedRunbook
+}
+
+// setWebhookDeliveries shares the deliveries of the audit webhook with the HTTP endpoints
+func setWebhookDeliveries(reader port.WebhookDeliveryReader) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedWebhookDeliveries = reader
+}
+
+// getWebhookDeliveries returns the deliveries of the audit webhook, nil when disabled or until the
+// NATS subscriptions are started
+func getWebhookDeliveries() port.WebhookDeliveryReader {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedWebhookDeliveries
+}
+
+// setAdminFeed shares the admin events feed with the HTTP endpoints
+func setAdminFeed(feed port.AdminEventSubscriber) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedAdminFeed = feed
+}
+
+// getAdminFeed returns the admin events feed, nil until the NATS subscriptions are started
+func getAdminFeed() port.AdminEventSubscriber {
+	sharedMu.RLock()
+	defer sharedMu.RUnlock()
+	return sharedAdminFeed
+}
+
+// adminFeedInit subscribes the admin events feed to the events bridged to the admin dashboards,
+// only when admins are configured
+func adminFeedInit(ctx context.Context) error {
+	if len(parseAdminSubs(os.Getenv(constants.AdminDashboardSubsEnvKey))) == 0 {
+		slog.DebugContext(ctx, "admin events disabled, no admin configured")
+		return nil
+	}
+
+	feed := adminfeed.New(adminfeed.DefaultMaxSubscriptions)
+	for subject, kind := range adminfeed.Subjects {
+		if _, err := natsClient.Subscribe(ctx, subject, feed.Handler(kind)); err != nil {
+			return fmt.Errorf("failed to subscribe to admin events %s: %w", subject, err)
+		}
+	}
+	setAdminFeed(feed)
+	return nil
+}
+
+// profileStreamInit publishes the public profile documents of the changed users to the profiles
+// stream when PROFILE_STREAM is enabled, each profile changed event is handled by a single replica
+func profileStreamInit(ctx context.Context, reader port.UserReader) (*profilestream.Publisher, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv(constants.ProfileStreamEnvKey))
+	if !enabled {
+		return nil, nil
+	}
+
+	js, err := natsClient.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	publisher, err := profilestream.New(ctx, js, reader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := natsClient.QueueSubscribe(ctx, constants.UserProfileChangedSubject, constants.AuthServiceQueue, publisher.Handle); err != nil {
+		return nil, fmt.Errorf("failed to subscribe the profile stream to profile changed events: %w", err)
+	}
+
+	slog.DebugContext(ctx, "profile stream enabled", "stream", constants.StreamNameProfiles)
+	return publisher, nil
+}
+
+const (
+	// consistencyIndexUserCache and consistencyIndexProfileStream are the audited indices reported by the metrics
+	consistencyIndexUserCache     = "user_cache"
+	consistencyIndexProfileStream = "profile_stream"
+
+	// consistencyAuditLockName elects the replica running the consistency audits
+	consistencyAuditLockName = "consistency/audit"
+)
+
+// consistencyAuditConfigFromEnv loads the options of the consistency audit from the environment,
+// nil when CONSISTENCY_AUDIT is disabled
+func consistencyAuditConfigFromEnv() ([]consistency.Option, error) {
+	if enabled, _ := strconv.ParseBool(os.Getenv(constants.ConsistencyAuditEnvKey)); !enabled {
+		return nil, nil
+	}
+
+	var opts []consistency.Option
+	if value := os.Getenv(constants.ConsistencyAuditIntervalEnvKey); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a positive duration", constants.ConsistencyAuditIntervalEnvKey, value)
+		}
+		opts = append(opts, consistency.WithInterval(interval))
+	}
+	if value := os.Getenv(constants.ConsistencyAuditSampleSizeEnvKey); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid %s value %s, expected a positive number", constants.ConsistencyAuditSampleSizeEnvKey, value)
+		}
+		opts = append(opts, consistency.WithSampleSize(size))
+	}
+	if value := os.Getenv(constants.ConsistencyAuditRepairEnvKey); value != "" {
+		repair, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %s, expected a boolean", constants.ConsistencyAuditRepairEnvKey, value)
+		}
+		opts = append(opts, consistency.WithRepair(repair))
+	}
+	return opts, nil
+}
+
+// consistencyAuditInit audits periodically a sample of the users of the users cache and of the
+// profiles stream against the identity provider when CONSISTENCY_AUDIT is enabled, on a single
+// replica when the replicas are coordinated
+func consistencyAuditInit(ctx context.Context, provider port.UserReader, profiles *profilestream.Publisher) error {
+	opts, errConfig := consistencyAuditConfigFromEnv()
+	if errConfig != nil {
+		return errConfig
+	}
+	if opts == nil {
+		return nil
+	}
+
+	var indices []string
+	if cache := getUserCache(); cache != nil {
+		opts = append(opts, consistency.WithIndex(consistencyIndexUserCache, cache), consistency.WithSampler(cache.Subs))
+		indices = append(indices, consistencyIndexUserCache)
+	}
+	if profiles != nil {
+		js, err := natsClient.JetStream()
+		if err != nil {
+			return err
+		}
+		opts = append(opts,
+			consistency.WithIndex(consistencyIndexProfileStream, profiles),
+			consistency.WithSampler(func(ctx context.Context) ([]string, error) {
+				return profilestream.ListSubs(ctx, js)
+			}),
+		)
+		indices = append(indices, consistencyIndexProfileStream)
+	}
+	if len(indices) == 0 {
+		slog.WarnContext(ctx, "consistency audit enabled without any index to audit, enable USER_CACHE or PROFILE_STREAM")
+		return nil
+	}
+
+	auditor := consistency.New(provider, opts...)
+	if enabled, _ := strconv.ParseBool(os.Getenv(constants.DistributedLocksEnvKey)); enabled {
+		kv, exists := natsClient.GetKVStore(constants.KVBucketNameLocks)
+		if !exists {
+			return fmt.Errorf("KV bucket %s is not initialized", constants.KVBucketNameLocks)
+		}
+		go lock.NewLocker(kv).Lead(ctx, consistencyAuditLockName, auditor.Run)
+	} else {
+		go auditor.Run(ctx)
+	}
+
+	slog.DebugContext(ctx, "consistency audit enabled", "indices", indices)
+	return nil
+}
+
 // getNATSClient returns the initialized NATS client
 // This is a helper function to access the client for subscription management
 func getNATSClient() *nats.NATSClient {
@@ -0,0 +1,167 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// supportBundleConfigPrefixes are the prefixes of the environment variables configuring the service
+var supportBundleConfigPrefixes = []string{
+	"ADMIN_", "AUDIT_", "AUTH0_", "AUTHELIA_", "AWS_", "CALLER_", "COGNITO_", "COST_", "DISTRIBUTED_", "EMAIL_", "KEYCLOAK_",
+	"LOG_", "MOCK_", "NATS_", "OKTA_", "ORGANIZATION_", "OTEL_", "PROFILE_", "RESPONSE_", "SERVICE_", "SLOW_",
+	"STORAGE_", "USAGE_", "USER_",
+}
+
+// BuildInfo identifies the build of the binary, set via ldflags
+type BuildInfo struct {
+	Version   string
+	BuildTime string
+	GitCommit string
+}
+
+// lastEventReader is implemented by the admin events feed keeping the last event of each kind
+type lastEventReader interface {
+	LastEvent(kind model.AdminEventKind) (model.AdminEvent, bool)
+}
+
+// GetSupportBundle collects the non-sensitive diagnostics of the replica
+func (s *authService) GetSupportBundle(ctx context.Context, p *authservice.GetSupportBundlePayload) (*authservice.SupportBundle, error) {
+	admin, errAdmin := s.authenticateAdmin(ctx, stringValue(p.Authorization), constants.AdminConfigRequiredScope)
+	if errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	bundle := &authservice.SupportBundle{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Build: &authservice.SupportBundleBuild{
+			Version:   s.build.Version,
+			BuildTime: s.build.BuildTime,
+			GitCommit: s.build.GitCommit,
+			GoVersion: runtime.Version(),
+		},
+		Config:       effectiveConfig(os.Environ()),
+		ConfigErrors: []*authservice.SupportBundleConfigError{},
+		Providers:    []*authservice.SupportBundleProvider{},
+	}
+
+	for _, configError := range ValidateConfig(ctx) {
+		bundle.ConfigErrors = append(bundle.ConfigErrors, &authservice.SupportBundleConfigError{
+			Component: configError.Component,
+			Key:       optionalString(configError.Key),
+			Message:   configError.Message,
+		})
+	}
+
+	for _, status := range s.providerStatuses.ProviderStatuses(ctx) {
+		provider := &authservice.SupportBundleProvider{
+			Provider:     status.Provider,
+			Samples:      status.Samples,
+			Failures:     status.Failures,
+			ErrorRate:    status.ErrorRate,
+			P95Ms:        status.P95Ms,
+			CircuitState: status.CircuitState,
+		}
+		if status.LastFailureAt != nil {
+			lastFailureAt := status.LastFailureAt.UTC().Format(time.RFC3339)
+			provider.LastFailureAt = &lastFailureAt
+		}
+		bundle.Providers = append(bundle.Providers, provider)
+	}
+
+	if cache := s.userCache(); cache != nil {
+		stats := cache.Stats()
+		bundle.UserCache = &authservice.SupportBundleUserCache{
+			Kind:    os.Getenv(constants.UserCacheEnvKey),
+			Hits:    stats.Hits,
+			Misses:  stats.Misses,
+			Entries: stats.Entries,
+		}
+	}
+
+	if feed, ok := s.adminFeed().(lastEventReader); ok {
+		bundle.LastSync = lastSyncStatus(ctx, feed)
+	}
+
+	slog.InfoContext(ctx, "support bundle generated",
+		"admin", redaction.Redact(admin.ProfileSub()),
+		"config_errors", len(bundle.ConfigErrors),
+	)
+	return bundle, nil
+}
+
+// effectiveConfig returns the environment variables configuring the service, the secrets redacted
+// and the subjects of the admins hidden
+func effectiveConfig(environ []string) map[string]string {
+	config := make(map[string]string)
+	for _, entry := range environ {
+		key, value, _ := strings.Cut(entry, "=")
+		if !hasConfigPrefix(key) {
+			continue
+		}
+		if key == constants.AdminDashboardSubsEnvKey {
+			subs := strings.Split(value, ",")
+			for i, sub := range subs {
+				subs[i] = redaction.Redact(strings.TrimSpace(sub))
+			}
+			config[key] = strings.Join(subs, ",")
+			continue
+		}
+		config[key] = redaction.RedactSetting(key, value)
+	}
+	return config
+}
+
+func hasConfigPrefix(key string) bool {
+	for _, prefix := range supportBundleConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastSyncStatus returns the last sync status event received by the replica, nil when none was
+func lastSyncStatus(ctx context.Context, feed lastEventReader) *authservice.SupportBundleSyncStatus {
+	event, ok := feed.LastEvent(model.AdminEventSyncStatus)
+	if !ok {
+		return nil
+	}
+
+	var status model.SyncStatus
+	data, errMarshal := json.Marshal(event.Data)
+	if errMarshal == nil {
+		errMarshal = json.Unmarshal(data, &status)
+	}
+	if errMarshal != nil {
+		slog.WarnContext(ctx, "invalid sync status event", "error", errMarshal)
+		return nil
+	}
+
+	return &authservice.SupportBundleSyncStatus{
+		Succeeded: status.Succeeded,
+		Changed:   status.Changed,
+		Error:     optionalString(status.Error),
+		SyncedAt:  status.SyncedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// optionalString returns nil for the empty string
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
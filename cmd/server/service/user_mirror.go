@@ -0,0 +1,236 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// currentUser is the path value of the token bearer in the REST mirrors
+const currentUser = "current"
+
+// mirrorMessage is an HTTP request of a REST mirror handed to the NATS message handlers,
+// the reply is kept for the HTTP response
+type mirrorMessage struct {
+	subject string
+	data    []byte
+	headers map[string]string
+	reply   []byte
+}
+
+func (m *mirrorMessage) Subject() string {
+	return m.subject
+}
+
+func (m *mirrorMessage) Data() []byte {
+	return m.data
+}
+
+func (m *mirrorMessage) Header(key string) string {
+	return m.headers[key]
+}
+
+func (m *mirrorMessage) Respond(data []byte) error {
+	m.reply = data
+	return nil
+}
+
+// GetUserMetadata mirrors the user metadata read, the token must grant the read scope
+// unless the user is the token bearer
+func (s *authService) GetUserMetadata(ctx context.Context, p *authservice.GetUserMetadataPayload) (*authservice.UserDataReply, error) {
+	sub := strings.TrimSpace(p.Sub)
+
+	var scopes []string
+	if sub != currentUser {
+		scopes = append(scopes, constants.UserReadMetadataRequiredScope)
+	}
+	if _, errAuthenticate := s.authenticate(ctx, stringValue(p.Authorization), scopes...); errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	input := sub
+	if sub == currentUser {
+		// the token resolves the bearer like the token input of the NATS request
+		input = bearerToken(stringValue(p.Authorization))
+	}
+
+	headers := mirrorHeaders(p.AcceptLanguage)
+	headers[constants.ResponseFormatHeader] = stringValue(p.Format)
+
+	return s.mirror(ctx, constants.UserMetadataReadSubject, []byte(input), headers)
+}
+
+// UpdateCurrentUserMetadata mirrors the user metadata update of the token bearer
+func (s *authService) UpdateCurrentUserMetadata(ctx context.Context, p *authservice.UpdateCurrentUserMetadataPayload) (*authservice.UserDataReply, error) {
+	authorization := stringValue(p.Authorization)
+	if _, errAuthenticate := s.authenticate(ctx, authorization, constants.UserUpdateMetadataRequiredScope); errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	data, errMarshal := json.Marshal(map[string]any{
+		"token":         bearerToken(authorization),
+		"user_metadata": p.UserMetadata,
+	})
+	if errMarshal != nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return s.mirror(ctx, constants.UserMetadataUpdateSubject, data, mirrorHeaders(p.AcceptLanguage))
+}
+
+// StartEmailLinking mirrors the verification email of an alternate email of the token bearer
+func (s *authService) StartEmailLinking(ctx context.Context, p *authservice.StartEmailLinkingPayload) (*authservice.UserDataReply, error) {
+	authorization := stringValue(p.Authorization)
+	if _, errAuthenticate := s.authenticate(ctx, authorization); errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	// the token limits the verification emails per user
+	data, errMarshal := json.Marshal(map[string]any{
+		"user": map[string]string{
+			"auth_token": bearerToken(authorization),
+		},
+		"email": p.Email,
+	})
+	if errMarshal != nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return s.mirror(ctx, constants.EmailLinkingSendVerificationSubject, data, mirrorHeaders(p.AcceptLanguage))
+}
+
+// VerifyEmailLinking mirrors the verification of an alternate email of the token bearer
+func (s *authService) VerifyEmailLinking(ctx context.Context, p *authservice.VerifyEmailLinkingPayload) (*authservice.UserDataReply, error) {
+	authorization := stringValue(p.Authorization)
+	if _, errAuthenticate := s.authenticate(ctx, authorization); errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	// the token binds the reference returned to the bearer
+	data, errMarshal := json.Marshal(map[string]any{
+		"user": map[string]string{
+			"auth_token": bearerToken(authorization),
+		},
+		"email": p.Email,
+		"otp":   p.Otp,
+	})
+	if errMarshal != nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return s.mirror(ctx, constants.EmailLinkingVerifySubject, data, mirrorHeaders(p.AcceptLanguage))
+}
+
+// ChangePrimaryEmail mirrors the change of the primary email of the token bearer
+func (s *authService) ChangePrimaryEmail(ctx context.Context, p *authservice.ChangePrimaryEmailPayload) (*authservice.UserDataReply, error) {
+	authorization := stringValue(p.Authorization)
+	if _, errAuthenticate := s.authenticate(ctx, authorization, constants.UserUpdateIdentityRequiredScope); errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	data, errMarshal := json.Marshal(map[string]any{
+		"user": map[string]string{
+			"auth_token": bearerToken(authorization),
+		},
+		"identity_token_ref": p.IdentityTokenRef,
+	})
+	if errMarshal != nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return s.mirror(ctx, constants.PrimaryEmailChangeSubject, data, mirrorHeaders(p.AcceptLanguage))
+}
+
+// mirror hands the request to the NATS message handler of the subject, so the REST mirrors
+// share the usage accounting, response policies and localization of the NATS operations
+func (s *authService) mirror(ctx context.Context, subject string, data []byte, headers map[string]string) (*authservice.UserDataReply, error) {
+	handler := s.messageHandler()
+	if handler == nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	msg := &mirrorMessage{subject: subject, data: data, headers: headers}
+	handler.HandleMessage(ctx, msg)
+
+	var reply service.UserDataResponse
+	if err := json.Unmarshal(msg.reply, &reply); err != nil {
+		slog.ErrorContext(ctx, "invalid reply of the message handler", "error", err, "subject", subject)
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	return mirrorReply(reply)
+}
+
+// mirrorReply maps the reply of the message handler to the REST reply, the failures are
+// returned as errors so they get their HTTP status
+func mirrorReply(reply service.UserDataResponse) (*authservice.UserDataReply, error) {
+	optionalString := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	switch {
+	case reply.Success:
+		success := &authservice.UserDataReply{
+			Success: true,
+			Message: optionalString(reply.Message),
+			Data:    reply.Data,
+		}
+		if reply.Stale {
+			success.Stale, success.Age = &reply.Stale, &reply.Age
+		}
+		return success, nil
+	case reply.Retryable:
+		return nil, &authservice.RetryableReply{
+			Reason:       optionalString(reply.Error),
+			ErrorCode:    optionalString(reply.ErrorCode),
+			Retryable:    &reply.Retryable,
+			RetryAfterMs: &reply.RetryAfterMs,
+		}
+	}
+
+	reason, errorCode := optionalString(reply.Error), optionalString(reply.ErrorCode)
+	switch reply.ErrorCode {
+	case errs.CodeUnauthorized:
+		return nil, &authservice.FailedUnauthorizedReply{Reason: reason, ErrorCode: errorCode}
+	case errs.CodeForbidden:
+		return nil, &authservice.FailedForbiddenReply{Reason: reason, ErrorCode: errorCode}
+	case errs.CodeNotFound:
+		return nil, &authservice.FailedNotFoundReply{Reason: reason, ErrorCode: errorCode}
+	default:
+		return nil, &authservice.FailedReply{Reason: reason, ErrorCode: errorCode}
+	}
+}
+
+// mirrorHeaders returns the NATS message headers of the HTTP headers of a REST mirror request,
+// the calling service is left unset as the HTTP requests can't vouch for it
+func mirrorHeaders(acceptLanguage *string) map[string]string {
+	return map[string]string{
+		constants.AcceptLanguageHeader: stringValue(acceptLanguage),
+	}
+}
+
+// bearerToken returns the token of the Authorization header, already authenticated
+func bearerToken(authorization string) string {
+	token, _ := jwt.LooksLikeJWT(strings.TrimSpace(authorization))
+	return token
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
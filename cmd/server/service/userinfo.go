@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// Userinfo implements the OIDC UserInfo endpoint, the claims are assembled from the
+// identity provider user and its metadata
+func (s *authService) Userinfo(ctx context.Context, p *authservice.UserinfoPayload) (*authservice.UserInfo, error) {
+	var authorization string
+	if p != nil && p.Authorization != nil {
+		authorization = *p.Authorization
+	}
+
+	user, errAuthenticate := s.authenticate(ctx, authorization)
+	if errAuthenticate != nil {
+		return nil, errAuthenticate
+	}
+
+	user, errGetUser := s.userReader().GetUser(ctx, user)
+	if errGetUser != nil {
+		slog.ErrorContext(ctx, "error getting userinfo", "error", errGetUser)
+		return nil, userinfoError(errGetUser)
+	}
+
+	return newUserInfo(user.OIDCClaims()), nil
+}
+
+// bearerAuthorization returns the Authorization header, or builds it from the access token
+// query parameter for the browser APIs unable to set headers
+func bearerAuthorization(authorization, accessToken *string) string {
+	switch {
+	case authorization != nil:
+		return *authorization
+	case accessToken != nil && strings.TrimSpace(*accessToken) != "":
+		return "Bearer " + strings.TrimSpace(*accessToken)
+	default:
+		return ""
+	}
+}
+
+// authenticate verifies the bearer token of the Authorization header and resolves its subject,
+// the token must grant the required scopes when any
+func (s *authService) authenticate(ctx context.Context, authorization string, requiredScopes ...string) (*model.User, error) {
+	authorization = strings.TrimSpace(authorization)
+
+	scheme, _, _ := strings.Cut(authorization, " ")
+	token, isJWT := jwt.LooksLikeJWT(authorization)
+	if !strings.EqualFold(scheme, "Bearer") || !isJWT {
+		return nil, authservice.Unauthorized("missing or invalid bearer token")
+	}
+
+	userReader := s.userReader()
+	if userReader == nil {
+		return nil, authservice.ServiceUnavailable("auth service unavailable")
+	}
+
+	user, errLookup := userReader.MetadataLookup(ctx, token, requiredScopes...)
+	if errLookup != nil {
+		slog.WarnContext(ctx, "bearer token rejected", "error", errLookup)
+		return nil, userinfoError(errLookup)
+	}
+	return user, nil
+}
+
+// userinfoError maps the domain errors to the userinfo endpoint errors
+func userinfoError(err error) error {
+	var (
+		validation   errs.Validation
+		unauthorized errs.Unauthorized
+		forbidden    errs.Forbidden
+		notFound     errs.NotFound
+	)
+	switch {
+	case errors.As(err, &validation), errors.As(err, &unauthorized), errors.As(err, &forbidden):
+		return authservice.Unauthorized("invalid bearer token")
+	case errors.As(err, &notFound):
+		return authservice.NotFound("user not found")
+	default:
+		return authservice.ServiceUnavailable("auth service unavailable")
+	}
+}
+
+func newUserInfo(claims *model.OIDCClaims) *authservice.UserInfo {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	info := &authservice.UserInfo{
+		Sub:               claims.Sub,
+		Name:              optional(claims.Name),
+		GivenName:         optional(claims.GivenName),
+		FamilyName:        optional(claims.FamilyName),
+		PreferredUsername: optional(claims.PreferredUsername),
+		Picture:           optional(claims.Picture),
+		Email:             optional(claims.Email),
+		Zoneinfo:          optional(claims.Zoneinfo),
+		PhoneNumber:       optional(claims.PhoneNumber),
+	}
+	if claims.Address != nil {
+		info.Address = &authservice.UserInfoAddress{
+			StreetAddress: optional(claims.Address.StreetAddress),
+			Locality:      optional(claims.Address.Locality),
+			Region:        optional(claims.Address.Region),
+			PostalCode:    optional(claims.Address.PostalCode),
+			Country:       optional(claims.Address.Country),
+		}
+	}
+	return info
+}
@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/service"
+)
+
+// validateConfigCommand validates the configuration for the deployment pipelines
+const validateConfigCommand = "validate-config"
+
+// validateConfigReport is the machine-readable output of the validate-config command
+type validateConfigReport struct {
+	Valid  bool                  `json:"valid"`
+	Errors []service.ConfigError `json:"errors"`
+}
+
+// validateConfig writes the report of the configuration validation to out and returns
+// the exit code, non-zero when the configuration is invalid
+func validateConfig(ctx context.Context, out io.Writer) int {
+	// the report is written to stdout, keep the logs apart
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	configErrors := service.ValidateConfig(ctx)
+	report := validateConfigReport{
+		Valid:  len(configErrors) == 0,
+		Errors: configErrors,
+	}
+	if report.Errors == nil {
+		report.Errors = []service.ConfigError{}
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		slog.ErrorContext(ctx, "failed to write the configuration report", "error", err)
+		return 2
+	}
+
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
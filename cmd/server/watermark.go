@@ -0,0 +1,25 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// watermark stamps every response with the environment and the instance of the service, so a
+// client hitting the wrong environment sees it in its logs
+func watermark(next http.Handler, meta model.ResponseMeta) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if meta.Environment != "" {
+			w.Header().Set(constants.EnvironmentHeader, meta.Environment)
+		}
+		if meta.Instance != "" {
+			w.Header().Set(constants.InstanceHeader, meta.Instance)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -9,21 +9,70 @@ package authservice
 
 import (
 	"context"
+	"io"
 
 	goa "goa.design/goa/v3/pkg"
 )
 
 // Client is the "auth-service" service client.
 type Client struct {
-	LivezEndpoint  goa.Endpoint
-	ReadyzEndpoint goa.Endpoint
+	LivezEndpoint                     goa.Endpoint
+	ReadyzEndpoint                    goa.Endpoint
+	UserinfoEndpoint                  goa.Endpoint
+	ProfileEventsEndpoint             goa.Endpoint
+	AdminEventsEndpoint               goa.Endpoint
+	GetUserMetadataEndpoint           goa.Endpoint
+	UpdateCurrentUserMetadataEndpoint goa.Endpoint
+	StartEmailLinkingEndpoint         goa.Endpoint
+	VerifyEmailLinkingEndpoint        goa.Endpoint
+	ChangePrimaryEmailEndpoint        goa.Endpoint
+	UploadCurrentUserPictureEndpoint  goa.Endpoint
+	ResolveProfileShareEndpoint       goa.Endpoint
+	ListEventSchemasEndpoint          goa.Endpoint
+	GetEventSchemaEndpoint            goa.Endpoint
+	ListCallersEndpoint               goa.Endpoint
+	GetCallerEndpoint                 goa.Endpoint
+	PutCallerEndpoint                 goa.Endpoint
+	DeleteCallerEndpoint              goa.Endpoint
+	GetAdminUserMetadataEndpoint      goa.Endpoint
+	IssueEmailBackupCodesEndpoint     goa.Endpoint
+	PurgeUserCacheEndpoint            goa.Endpoint
+	ListWebhookDeliveriesEndpoint     goa.Endpoint
+	GetSupportBundleEndpoint          goa.Endpoint
+	RenewM2mTokenEndpoint             goa.Endpoint
+	RefreshJwksEndpoint               goa.Endpoint
+	RebuildEmailIndexEndpoint         goa.Endpoint
 }
 
 // NewClient initializes a "auth-service" service client given the endpoints.
-func NewClient(livez, readyz goa.Endpoint) *Client {
+func NewClient(livez, readyz, userinfo, profileEvents, adminEvents, getUserMetadata, updateCurrentUserMetadata, startEmailLinking, verifyEmailLinking, changePrimaryEmail, uploadCurrentUserPicture, resolveProfileShare, listEventSchemas, getEventSchema, listCallers, getCaller, putCaller, deleteCaller, getAdminUserMetadata, issueEmailBackupCodes, purgeUserCache, listWebhookDeliveries, getSupportBundle, renewM2mToken, refreshJwks, rebuildEmailIndex goa.Endpoint) *Client {
 	return &Client{
-		LivezEndpoint:  livez,
-		ReadyzEndpoint: readyz,
+		LivezEndpoint:                     livez,
+		ReadyzEndpoint:                    readyz,
+		UserinfoEndpoint:                  userinfo,
+		ProfileEventsEndpoint:             profileEvents,
+		AdminEventsEndpoint:               adminEvents,
+		GetUserMetadataEndpoint:           getUserMetadata,
+		UpdateCurrentUserMetadataEndpoint: updateCurrentUserMetadata,
+		StartEmailLinkingEndpoint:         startEmailLinking,
+		VerifyEmailLinkingEndpoint:        verifyEmailLinking,
+		ChangePrimaryEmailEndpoint:        changePrimaryEmail,
+		UploadCurrentUserPictureEndpoint:  uploadCurrentUserPicture,
+		ResolveProfileShareEndpoint:       resolveProfileShare,
+		ListEventSchemasEndpoint:          listEventSchemas,
+		GetEventSchemaEndpoint:            getEventSchema,
+		ListCallersEndpoint:               listCallers,
+		GetCallerEndpoint:                 getCaller,
+		PutCallerEndpoint:                 putCaller,
+		DeleteCallerEndpoint:              deleteCaller,
+		GetAdminUserMetadataEndpoint:      getAdminUserMetadata,
+		IssueEmailBackupCodesEndpoint:     issueEmailBackupCodes,
+		PurgeUserCacheEndpoint:            purgeUserCache,
+		ListWebhookDeliveriesEndpoint:     listWebhookDeliveries,
+		GetSupportBundleEndpoint:          getSupportBundle,
+		RenewM2mTokenEndpoint:             renewM2mToken,
+		RefreshJwksEndpoint:               refreshJwks,
+		RebuildEmailIndexEndpoint:         rebuildEmailIndex,
 	}
 }
 
@@ -49,3 +98,427 @@ func (c *Client) Readyz(ctx context.Context) (res []byte, err error) {
 	}
 	return ires.([]byte), nil
 }
+
+// Userinfo calls the "userinfo" endpoint of the "auth-service" service.
+// Userinfo may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "NotFound" (type NotFound)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) Userinfo(ctx context.Context, p *UserinfoPayload) (res *UserInfo, err error) {
+	var ires any
+	ires, err = c.UserinfoEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserInfo), nil
+}
+
+// ProfileEvents calls the "profile_events" endpoint of the "auth-service"
+// service.
+// ProfileEvents may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "TooManyRequests" (type TooManyRequests)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) ProfileEvents(ctx context.Context, p *ProfileEventsPayload) (res ProfileEventsClientStream, err error) {
+	var ires any
+	ires, err = c.ProfileEventsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(ProfileEventsClientStream), nil
+}
+
+// AdminEvents calls the "admin_events" endpoint of the "auth-service" service.
+// AdminEvents may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "TooManyRequests" (type TooManyRequests)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) AdminEvents(ctx context.Context, p *AdminEventsPayload) (res AdminEventsClientStream, err error) {
+	var ires any
+	ires, err = c.AdminEventsEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(AdminEventsClientStream), nil
+}
+
+// GetUserMetadata calls the "get_user_metadata" endpoint of the "auth-service"
+// service.
+// GetUserMetadata may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) GetUserMetadata(ctx context.Context, p *GetUserMetadataPayload) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.GetUserMetadataEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// UpdateCurrentUserMetadata calls the "update_current_user_metadata" endpoint
+// of the "auth-service" service.
+// UpdateCurrentUserMetadata may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) UpdateCurrentUserMetadata(ctx context.Context, p *UpdateCurrentUserMetadataPayload) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.UpdateCurrentUserMetadataEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// StartEmailLinking calls the "start_email_linking" endpoint of the
+// "auth-service" service.
+// StartEmailLinking may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) StartEmailLinking(ctx context.Context, p *StartEmailLinkingPayload) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.StartEmailLinkingEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// VerifyEmailLinking calls the "verify_email_linking" endpoint of the
+// "auth-service" service.
+// VerifyEmailLinking may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) VerifyEmailLinking(ctx context.Context, p *VerifyEmailLinkingPayload) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.VerifyEmailLinkingEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// ChangePrimaryEmail calls the "change_primary_email" endpoint of the
+// "auth-service" service.
+// ChangePrimaryEmail may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) ChangePrimaryEmail(ctx context.Context, p *ChangePrimaryEmailPayload) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.ChangePrimaryEmailEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// UploadCurrentUserPicture calls the "upload_current_user_picture" endpoint of
+// the "auth-service" service.
+// UploadCurrentUserPicture may return the following errors:
+//   - "Unauthorized" (type Unauthorized)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - "Failed" (type *FailedReply): The operation failed, the reply carries the reason
+//   - "FailedUnauthorized" (type *FailedUnauthorizedReply): The operation was refused for the credentials, the reply carries the reason
+//   - "FailedForbidden" (type *FailedForbiddenReply): The operation is not allowed to the token bearer, the reply carries the reason
+//   - "FailedNotFound" (type *FailedNotFoundReply): The resource of the operation was not found, the reply carries the reason
+//   - "Retryable" (type *RetryableReply): The operation failed on a transient error, the reply carries the retry hint
+//   - error: internal error
+func (c *Client) UploadCurrentUserPicture(ctx context.Context, p *UploadCurrentUserPicturePayload, req io.ReadCloser) (res *UserDataReply, err error) {
+	var ires any
+	ires, err = c.UploadCurrentUserPictureEndpoint(ctx, &UploadCurrentUserPictureRequestData{Payload: p, Body: req})
+	if err != nil {
+		return
+	}
+	return ires.(*UserDataReply), nil
+}
+
+// ResolveProfileShare calls the "resolve_profile_share" endpoint of the
+// "auth-service" service.
+// ResolveProfileShare may return the following errors:
+//   - "NotFound" (type NotFound)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) ResolveProfileShare(ctx context.Context, p *ResolveProfileSharePayload) (res *SharedProfile, err error) {
+	var ires any
+	ires, err = c.ResolveProfileShareEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SharedProfile), nil
+}
+
+// ListEventSchemas calls the "list_event_schemas" endpoint of the
+// "auth-service" service.
+func (c *Client) ListEventSchemas(ctx context.Context) (res []*EventSchema, err error) {
+	var ires any
+	ires, err = c.ListEventSchemasEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.([]*EventSchema), nil
+}
+
+// GetEventSchema calls the "get_event_schema" endpoint of the "auth-service"
+// service.
+// GetEventSchema may return the following errors:
+//   - "NotFound" (type NotFound)
+//   - error: internal error
+func (c *Client) GetEventSchema(ctx context.Context, p *GetEventSchemaPayload) (res *EventSchema, err error) {
+	var ires any
+	ires, err = c.GetEventSchemaEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*EventSchema), nil
+}
+
+// ListCallers calls the "list_callers" endpoint of the "auth-service" service.
+// ListCallers may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) ListCallers(ctx context.Context, p *ListCallersPayload) (res []*CallerAllowlistEntry, err error) {
+	var ires any
+	ires, err = c.ListCallersEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.([]*CallerAllowlistEntry), nil
+}
+
+// GetCaller calls the "get_caller" endpoint of the "auth-service" service.
+// GetCaller may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) GetCaller(ctx context.Context, p *GetCallerPayload) (res *CallerAllowlistEntry, err error) {
+	var ires any
+	ires, err = c.GetCallerEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*CallerAllowlistEntry), nil
+}
+
+// PutCaller calls the "put_caller" endpoint of the "auth-service" service.
+// PutCaller may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) PutCaller(ctx context.Context, p *PutCallerPayload) (res *CallerAllowlistEntry, err error) {
+	var ires any
+	ires, err = c.PutCallerEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*CallerAllowlistEntry), nil
+}
+
+// DeleteCaller calls the "delete_caller" endpoint of the "auth-service"
+// service.
+// DeleteCaller may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) DeleteCaller(ctx context.Context, p *DeleteCallerPayload) (err error) {
+	_, err = c.DeleteCallerEndpoint(ctx, p)
+	return
+}
+
+// GetAdminUserMetadata calls the "get_admin_user_metadata" endpoint of the
+// "auth-service" service.
+// GetAdminUserMetadata may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) GetAdminUserMetadata(ctx context.Context, p *GetAdminUserMetadataPayload) (res *AdminUserMetadata, err error) {
+	var ires any
+	ires, err = c.GetAdminUserMetadataEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*AdminUserMetadata), nil
+}
+
+// IssueEmailBackupCodes calls the "issue_email_backup_codes" endpoint of the
+// "auth-service" service.
+// IssueEmailBackupCodes may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) IssueEmailBackupCodes(ctx context.Context, p *IssueEmailBackupCodesPayload) (res *EmailBackupCodes, err error) {
+	var ires any
+	ires, err = c.IssueEmailBackupCodesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*EmailBackupCodes), nil
+}
+
+// PurgeUserCache calls the "purge_user_cache" endpoint of the "auth-service"
+// service.
+// PurgeUserCache may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) PurgeUserCache(ctx context.Context, p *PurgeUserCachePayload) (err error) {
+	_, err = c.PurgeUserCacheEndpoint(ctx, p)
+	return
+}
+
+// ListWebhookDeliveries calls the "list_webhook_deliveries" endpoint of the
+// "auth-service" service.
+// ListWebhookDeliveries may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) ListWebhookDeliveries(ctx context.Context, p *ListWebhookDeliveriesPayload) (res *WebhookDeliveries, err error) {
+	var ires any
+	ires, err = c.ListWebhookDeliveriesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*WebhookDeliveries), nil
+}
+
+// GetSupportBundle calls the "get_support_bundle" endpoint of the
+// "auth-service" service.
+// GetSupportBundle may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) GetSupportBundle(ctx context.Context, p *GetSupportBundlePayload) (res *SupportBundle, err error) {
+	var ires any
+	ires, err = c.GetSupportBundleEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SupportBundle), nil
+}
+
+// RenewM2mToken calls the "renew_m2m_token" endpoint of the "auth-service"
+// service.
+// RenewM2mToken may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) RenewM2mToken(ctx context.Context, p *RenewM2mTokenPayload) (res *RunbookResult, err error) {
+	var ires any
+	ires, err = c.RenewM2mTokenEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*RunbookResult), nil
+}
+
+// RefreshJwks calls the "refresh_jwks" endpoint of the "auth-service" service.
+// RefreshJwks may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) RefreshJwks(ctx context.Context, p *RefreshJwksPayload) (res *RunbookResult, err error) {
+	var ires any
+	ires, err = c.RefreshJwksEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*RunbookResult), nil
+}
+
+// RebuildEmailIndex calls the "rebuild_email_index" endpoint of the
+// "auth-service" service.
+// RebuildEmailIndex may return the following errors:
+//   - "BadRequest" (type BadRequest)
+//   - "Unauthorized" (type Unauthorized)
+//   - "Forbidden" (type Forbidden)
+//   - "NotFound" (type NotFound)
+//   - "Conflict" (type Conflict)
+//   - "ServiceUnavailable" (type ServiceUnavailable)
+//   - error: internal error
+func (c *Client) RebuildEmailIndex(ctx context.Context, p *RebuildEmailIndexPayload) (res *RunbookResult, err error) {
+	var ires any
+	ires, err = c.RebuildEmailIndexEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*RunbookResult), nil
+}
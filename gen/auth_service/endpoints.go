@@ -9,21 +9,97 @@ package authservice
 
 import (
 	"context"
+	"io"
 
 	goa "goa.design/goa/v3/pkg"
 )
 
 // Endpoints wraps the "auth-service" service endpoints.
 type Endpoints struct {
-	Livez  goa.Endpoint
-	Readyz goa.Endpoint
+	Livez                     goa.Endpoint
+	Readyz                    goa.Endpoint
+	Userinfo                  goa.Endpoint
+	ProfileEvents             goa.Endpoint
+	AdminEvents               goa.Endpoint
+	GetUserMetadata           goa.Endpoint
+	UpdateCurrentUserMetadata goa.Endpoint
+	StartEmailLinking         goa.Endpoint
+	VerifyEmailLinking        goa.Endpoint
+	ChangePrimaryEmail        goa.Endpoint
+	UploadCurrentUserPicture  goa.Endpoint
+	ResolveProfileShare       goa.Endpoint
+	ListEventSchemas          goa.Endpoint
+	GetEventSchema            goa.Endpoint
+	ListCallers               goa.Endpoint
+	GetCaller                 goa.Endpoint
+	PutCaller                 goa.Endpoint
+	DeleteCaller              goa.Endpoint
+	GetAdminUserMetadata      goa.Endpoint
+	IssueEmailBackupCodes     goa.Endpoint
+	PurgeUserCache            goa.Endpoint
+	ListWebhookDeliveries     goa.Endpoint
+	GetSupportBundle          goa.Endpoint
+	RenewM2mToken             goa.Endpoint
+	RefreshJwks               goa.Endpoint
+	RebuildEmailIndex         goa.Endpoint
+}
+
+// ProfileEventsEndpointInput holds both the payload and the server stream of
+// the "profile_events" method.
+type ProfileEventsEndpointInput struct {
+	// Payload is the method payload.
+	Payload *ProfileEventsPayload
+	// Stream is the server stream used by the "profile_events" method to send data.
+	Stream ProfileEventsServerStream
+}
+
+// AdminEventsEndpointInput holds both the payload and the server stream of the
+// "admin_events" method.
+type AdminEventsEndpointInput struct {
+	// Payload is the method payload.
+	Payload *AdminEventsPayload
+	// Stream is the server stream used by the "admin_events" method to send data.
+	Stream AdminEventsServerStream
+}
+
+// UploadCurrentUserPictureRequestData holds both the payload and the HTTP
+// request body reader of the "upload_current_user_picture" method.
+type UploadCurrentUserPictureRequestData struct {
+	// Payload is the method payload.
+	Payload *UploadCurrentUserPicturePayload
+	// Body streams the HTTP request body.
+	Body io.ReadCloser
 }
 
 // NewEndpoints wraps the methods of the "auth-service" service with endpoints.
 func NewEndpoints(s Service) *Endpoints {
 	return &Endpoints{
-		Livez:  NewLivezEndpoint(s),
-		Readyz: NewReadyzEndpoint(s),
+		Livez:                     NewLivezEndpoint(s),
+		Readyz:                    NewReadyzEndpoint(s),
+		Userinfo:                  NewUserinfoEndpoint(s),
+		ProfileEvents:             NewProfileEventsEndpoint(s),
+		AdminEvents:               NewAdminEventsEndpoint(s),
+		GetUserMetadata:           NewGetUserMetadataEndpoint(s),
+		UpdateCurrentUserMetadata: NewUpdateCurrentUserMetadataEndpoint(s),
+		StartEmailLinking:         NewStartEmailLinkingEndpoint(s),
+		VerifyEmailLinking:        NewVerifyEmailLinkingEndpoint(s),
+		ChangePrimaryEmail:        NewChangePrimaryEmailEndpoint(s),
+		UploadCurrentUserPicture:  NewUploadCurrentUserPictureEndpoint(s),
+		ResolveProfileShare:       NewResolveProfileShareEndpoint(s),
+		ListEventSchemas:          NewListEventSchemasEndpoint(s),
+		GetEventSchema:            NewGetEventSchemaEndpoint(s),
+		ListCallers:               NewListCallersEndpoint(s),
+		GetCaller:                 NewGetCallerEndpoint(s),
+		PutCaller:                 NewPutCallerEndpoint(s),
+		DeleteCaller:              NewDeleteCallerEndpoint(s),
+		GetAdminUserMetadata:      NewGetAdminUserMetadataEndpoint(s),
+		IssueEmailBackupCodes:     NewIssueEmailBackupCodesEndpoint(s),
+		PurgeUserCache:            NewPurgeUserCacheEndpoint(s),
+		ListWebhookDeliveries:     NewListWebhookDeliveriesEndpoint(s),
+		GetSupportBundle:          NewGetSupportBundleEndpoint(s),
+		RenewM2mToken:             NewRenewM2mTokenEndpoint(s),
+		RefreshJwks:               NewRefreshJwksEndpoint(s),
+		RebuildEmailIndex:         NewRebuildEmailIndexEndpoint(s),
 	}
 }
 
@@ -31,6 +107,30 @@ func NewEndpoints(s Service) *Endpoints {
 func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.Livez = m(e.Livez)
 	e.Readyz = m(e.Readyz)
+	e.Userinfo = m(e.Userinfo)
+	e.ProfileEvents = m(e.ProfileEvents)
+	e.AdminEvents = m(e.AdminEvents)
+	e.GetUserMetadata = m(e.GetUserMetadata)
+	e.UpdateCurrentUserMetadata = m(e.UpdateCurrentUserMetadata)
+	e.StartEmailLinking = m(e.StartEmailLinking)
+	e.VerifyEmailLinking = m(e.VerifyEmailLinking)
+	e.ChangePrimaryEmail = m(e.ChangePrimaryEmail)
+	e.UploadCurrentUserPicture = m(e.UploadCurrentUserPicture)
+	e.ResolveProfileShare = m(e.ResolveProfileShare)
+	e.ListEventSchemas = m(e.ListEventSchemas)
+	e.GetEventSchema = m(e.GetEventSchema)
+	e.ListCallers = m(e.ListCallers)
+	e.GetCaller = m(e.GetCaller)
+	e.PutCaller = m(e.PutCaller)
+	e.DeleteCaller = m(e.DeleteCaller)
+	e.GetAdminUserMetadata = m(e.GetAdminUserMetadata)
+	e.IssueEmailBackupCodes = m(e.IssueEmailBackupCodes)
+	e.PurgeUserCache = m(e.PurgeUserCache)
+	e.ListWebhookDeliveries = m(e.ListWebhookDeliveries)
+	e.GetSupportBundle = m(e.GetSupportBundle)
+	e.RenewM2mToken = m(e.RenewM2mToken)
+	e.RefreshJwks = m(e.RefreshJwks)
+	e.RebuildEmailIndex = m(e.RebuildEmailIndex)
 }
 
 // NewLivezEndpoint returns an endpoint function that calls the method "livez"
@@ -48,3 +148,218 @@ func NewReadyzEndpoint(s Service) goa.Endpoint {
 		return s.Readyz(ctx)
 	}
 }
+
+// NewUserinfoEndpoint returns an endpoint function that calls the method
+// "userinfo" of service "auth-service".
+func NewUserinfoEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*UserinfoPayload)
+		return s.Userinfo(ctx, p)
+	}
+}
+
+// NewProfileEventsEndpoint returns an endpoint function that calls the method
+// "profile_events" of service "auth-service".
+func NewProfileEventsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		ep := req.(*ProfileEventsEndpointInput)
+		return nil, s.ProfileEvents(ctx, ep.Payload, ep.Stream)
+	}
+}
+
+// NewAdminEventsEndpoint returns an endpoint function that calls the method
+// "admin_events" of service "auth-service".
+func NewAdminEventsEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		ep := req.(*AdminEventsEndpointInput)
+		return nil, s.AdminEvents(ctx, ep.Payload, ep.Stream)
+	}
+}
+
+// NewGetUserMetadataEndpoint returns an endpoint function that calls the
+// method "get_user_metadata" of service "auth-service".
+func NewGetUserMetadataEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetUserMetadataPayload)
+		return s.GetUserMetadata(ctx, p)
+	}
+}
+
+// NewUpdateCurrentUserMetadataEndpoint returns an endpoint function that calls
+// the method "update_current_user_metadata" of service "auth-service".
+func NewUpdateCurrentUserMetadataEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*UpdateCurrentUserMetadataPayload)
+		return s.UpdateCurrentUserMetadata(ctx, p)
+	}
+}
+
+// NewStartEmailLinkingEndpoint returns an endpoint function that calls the
+// method "start_email_linking" of service "auth-service".
+func NewStartEmailLinkingEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*StartEmailLinkingPayload)
+		return s.StartEmailLinking(ctx, p)
+	}
+}
+
+// NewVerifyEmailLinkingEndpoint returns an endpoint function that calls the
+// method "verify_email_linking" of service "auth-service".
+func NewVerifyEmailLinkingEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*VerifyEmailLinkingPayload)
+		return s.VerifyEmailLinking(ctx, p)
+	}
+}
+
+// NewChangePrimaryEmailEndpoint returns an endpoint function that calls the
+// method "change_primary_email" of service "auth-service".
+func NewChangePrimaryEmailEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ChangePrimaryEmailPayload)
+		return s.ChangePrimaryEmail(ctx, p)
+	}
+}
+
+// NewUploadCurrentUserPictureEndpoint returns an endpoint function that calls
+// the method "upload_current_user_picture" of service "auth-service".
+func NewUploadCurrentUserPictureEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		ep := req.(*UploadCurrentUserPictureRequestData)
+		return s.UploadCurrentUserPicture(ctx, ep.Payload, ep.Body)
+	}
+}
+
+// NewResolveProfileShareEndpoint returns an endpoint function that calls the
+// method "resolve_profile_share" of service "auth-service".
+func NewResolveProfileShareEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ResolveProfileSharePayload)
+		return s.ResolveProfileShare(ctx, p)
+	}
+}
+
+// NewListEventSchemasEndpoint returns an endpoint function that calls the
+// method "list_event_schemas" of service "auth-service".
+func NewListEventSchemasEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		return s.ListEventSchemas(ctx)
+	}
+}
+
+// NewGetEventSchemaEndpoint returns an endpoint function that calls the method
+// "get_event_schema" of service "auth-service".
+func NewGetEventSchemaEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetEventSchemaPayload)
+		return s.GetEventSchema(ctx, p)
+	}
+}
+
+// NewListCallersEndpoint returns an endpoint function that calls the method
+// "list_callers" of service "auth-service".
+func NewListCallersEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListCallersPayload)
+		return s.ListCallers(ctx, p)
+	}
+}
+
+// NewGetCallerEndpoint returns an endpoint function that calls the method
+// "get_caller" of service "auth-service".
+func NewGetCallerEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetCallerPayload)
+		return s.GetCaller(ctx, p)
+	}
+}
+
+// NewPutCallerEndpoint returns an endpoint function that calls the method
+// "put_caller" of service "auth-service".
+func NewPutCallerEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PutCallerPayload)
+		return s.PutCaller(ctx, p)
+	}
+}
+
+// NewDeleteCallerEndpoint returns an endpoint function that calls the method
+// "delete_caller" of service "auth-service".
+func NewDeleteCallerEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*DeleteCallerPayload)
+		return nil, s.DeleteCaller(ctx, p)
+	}
+}
+
+// NewGetAdminUserMetadataEndpoint returns an endpoint function that calls the
+// method "get_admin_user_metadata" of service "auth-service".
+func NewGetAdminUserMetadataEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetAdminUserMetadataPayload)
+		return s.GetAdminUserMetadata(ctx, p)
+	}
+}
+
+// NewIssueEmailBackupCodesEndpoint returns an endpoint function that calls the
+// method "issue_email_backup_codes" of service "auth-service".
+func NewIssueEmailBackupCodesEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*IssueEmailBackupCodesPayload)
+		return s.IssueEmailBackupCodes(ctx, p)
+	}
+}
+
+// NewPurgeUserCacheEndpoint returns an endpoint function that calls the method
+// "purge_user_cache" of service "auth-service".
+func NewPurgeUserCacheEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PurgeUserCachePayload)
+		return nil, s.PurgeUserCache(ctx, p)
+	}
+}
+
+// NewListWebhookDeliveriesEndpoint returns an endpoint function that calls the
+// method "list_webhook_deliveries" of service "auth-service".
+func NewListWebhookDeliveriesEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListWebhookDeliveriesPayload)
+		return s.ListWebhookDeliveries(ctx, p)
+	}
+}
+
+// NewGetSupportBundleEndpoint returns an endpoint function that calls the
+// method "get_support_bundle" of service "auth-service".
+func NewGetSupportBundleEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetSupportBundlePayload)
+		return s.GetSupportBundle(ctx, p)
+	}
+}
+
+// NewRenewM2mTokenEndpoint returns an endpoint function that calls the method
+// "renew_m2m_token" of service "auth-service".
+func NewRenewM2mTokenEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RenewM2mTokenPayload)
+		return s.RenewM2mToken(ctx, p)
+	}
+}
+
+// NewRefreshJwksEndpoint returns an endpoint function that calls the method
+// "refresh_jwks" of service "auth-service".
+func NewRefreshJwksEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RefreshJwksPayload)
+		return s.RefreshJwks(ctx, p)
+	}
+}
+
+// NewRebuildEmailIndexEndpoint returns an endpoint function that calls the
+// method "rebuild_email_index" of service "auth-service".
+func NewRebuildEmailIndexEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*RebuildEmailIndexPayload)
+		return s.RebuildEmailIndex(ctx, p)
+	}
+}
@@ -9,6 +9,7 @@ package authservice
 
 import (
 	"context"
+	"io"
 )
 
 // Auth service
@@ -17,6 +18,76 @@ type Service interface {
 	Livez(context.Context) (res []byte, err error)
 	// Check if the service is ready to accept requests.
 	Readyz(context.Context) (res []byte, err error)
+	// Return the OIDC standard claims of the bearer of the access token.
+	Userinfo(context.Context, *UserinfoPayload) (res *UserInfo, err error)
+	// Stream the profile changes of the bearer of the access token as Server-Sent
+	// Events.
+	ProfileEvents(context.Context, *ProfileEventsPayload, ProfileEventsServerStream) (err error)
+	// Stream the internal events (sync status, audit, merges, profile changes) to
+	// an admin dashboard over a WebSocket.
+	AdminEvents(context.Context, *AdminEventsPayload, AdminEventsServerStream) (err error)
+	// Return the metadata of a user, like lfx.auth-service.user_metadata.read. The
+	// token must grant the read:users_metadata scope, unless the user is the token
+	// bearer (current).
+	GetUserMetadata(context.Context, *GetUserMetadataPayload) (res *UserDataReply, err error)
+	// Update the metadata of the token bearer, like
+	// lfx.auth-service.user_metadata.update. The token must grant the
+	// update:current_user_metadata scope.
+	UpdateCurrentUserMetadata(context.Context, *UpdateCurrentUserMetadataPayload) (res *UserDataReply, err error)
+	// Send the one time password verifying an alternate email of the token bearer,
+	// like lfx.auth-service.email_linking.send_verification.
+	StartEmailLinking(context.Context, *StartEmailLinkingPayload) (res *UserDataReply, err error)
+	// Verify an alternate email of the token bearer with the one time password,
+	// like lfx.auth-service.email_linking.verify.
+	VerifyEmailLinking(context.Context, *VerifyEmailLinkingPayload) (res *UserDataReply, err error)
+	// Change the primary email of the token bearer to an email verified with the
+	// email linking flow, like lfx.auth-service.primary_email.change.
+	ChangePrimaryEmail(context.Context, *ChangePrimaryEmailPayload) (res *UserDataReply, err error)
+	// Store the image of the body as the profile picture of the token bearer, the
+	// picture metadata field is set to its URL like
+	// lfx.auth-service.user_metadata.update. The token must grant the
+	// update:current_user_metadata scope.
+	UploadCurrentUserPicture(context.Context, *UploadCurrentUserPicturePayload, io.ReadCloser) (res *UserDataReply, err error)
+	// Return the shared view of the profile of a share link, no access token is
+	// required.
+	ResolveProfileShare(context.Context, *ResolveProfileSharePayload) (res *SharedProfile, err error)
+	// List the JSON Schemas of the events published by the service, sorted by
+	// subject, no access token is required.
+	ListEventSchemas(context.Context) (res []*EventSchema, err error)
+	// Return the JSON Schema of the events published on a subject, no access token
+	// is required.
+	GetEventSchema(context.Context, *GetEventSchemaPayload) (res *EventSchema, err error)
+	// List the caller allowlist, sorted by id.
+	ListCallers(context.Context, *ListCallersPayload) (res []*CallerAllowlistEntry, err error)
+	// Return an entry of the caller allowlist.
+	GetCaller(context.Context, *GetCallerPayload) (res *CallerAllowlistEntry, err error)
+	// Create or replace an entry of the caller allowlist, putting the same state
+	// again changes nothing.
+	PutCaller(context.Context, *PutCallerPayload) (res *CallerAllowlistEntry, err error)
+	// Remove an entry of the caller allowlist.
+	DeleteCaller(context.Context, *DeleteCallerPayload) (err error)
+	// Return the metadata of a user with the provenance of its fields.
+	GetAdminUserMetadata(context.Context, *GetAdminUserMetadataPayload) (res *AdminUserMetadata, err error)
+	// Issue one-time backup codes verifying an email in place of the OTP,
+	// replacing the previous codes of the email.
+	IssueEmailBackupCodes(context.Context, *IssueEmailBackupCodesPayload) (res *EmailBackupCodes, err error)
+	// Remove a user from the users cache, the next lookups read it again from the
+	// identity provider.
+	PurgeUserCache(context.Context, *PurgeUserCachePayload) (err error)
+	// List the last deliveries of the audit webhook seen by the replica.
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesPayload) (res *WebhookDeliveries, err error)
+	// Collect the non-sensitive diagnostics of the replica into a bundle to attach
+	// to incident tickets.
+	GetSupportBundle(context.Context, *GetSupportBundlePayload) (res *SupportBundle, err error)
+	// Renew the M2M tokens of the identity provider and verify its API accepts
+	// them, after the client credentials are rotated.
+	RenewM2mToken(context.Context, *RenewM2mTokenPayload) (res *RunbookResult, err error)
+	// Fetch the JWKS verifying the user tokens now, after the signing keys are
+	// rotated.
+	RefreshJwks(context.Context, *RefreshJwksPayload) (res *RunbookResult, err error)
+	// Rebuild the email index of a user in the identity provider, when it keeps
+	// one, and repair the users cache and the profiles stream from its record.
+	RebuildEmailIndex(context.Context, *RebuildEmailIndexPayload) (res *RunbookResult, err error)
 }
 
 // APIName is the name of the API as defined in the design.
@@ -33,11 +104,832 @@ const ServiceName = "auth-service"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [2]string{"livez", "readyz"}
+var MethodNames = [26]string{"livez", "readyz", "userinfo", "profile_events", "admin_events", "get_user_metadata", "update_current_user_metadata", "start_email_linking", "verify_email_linking", "change_primary_email", "upload_current_user_picture", "resolve_profile_share", "list_event_schemas", "get_event_schema", "list_callers", "get_caller", "put_caller", "delete_caller", "get_admin_user_metadata", "issue_email_backup_codes", "purge_user_cache", "list_webhook_deliveries", "get_support_bundle", "renew_m2m_token", "refresh_jwks", "rebuild_email_index"}
+
+// ProfileEventsServerStream allows streaming instances of *ProfileChange to
+// the client.
+type ProfileEventsServerStream interface {
+	// Send streams instances of "ProfileChange".
+	Send(*ProfileChange) error
+	// SendWithContext streams instances of "ProfileChange" with context.
+	SendWithContext(context.Context, *ProfileChange) error
+	// Close closes the stream.
+	Close() error
+}
+
+// ProfileEventsClientStream allows streaming instances of *ProfileChange to
+// the client.
+type ProfileEventsClientStream interface {
+	// Recv reads instances of "ProfileChange" from the stream.
+	Recv() (*ProfileChange, error)
+	// RecvWithContext reads instances of "ProfileChange" from the stream with
+	// context.
+	RecvWithContext(context.Context) (*ProfileChange, error)
+}
+
+// AdminEventsServerStream allows streaming instances of *AdminEvent to the
+// client.
+type AdminEventsServerStream interface {
+	// Send streams instances of "AdminEvent".
+	Send(*AdminEvent) error
+	// SendWithContext streams instances of "AdminEvent" with context.
+	SendWithContext(context.Context, *AdminEvent) error
+	// Close closes the stream.
+	Close() error
+}
+
+// AdminEventsClientStream allows streaming instances of *AdminEvent to the
+// client.
+type AdminEventsClientStream interface {
+	// Recv reads instances of "AdminEvent" from the stream.
+	Recv() (*AdminEvent, error)
+	// RecvWithContext reads instances of "AdminEvent" from the stream with context.
+	RecvWithContext(context.Context) (*AdminEvent, error)
+}
+
+// AdminEvent is the result type of the auth-service service admin_events
+// method.
+type AdminEvent struct {
+	// Kind of the event
+	Kind string
+	// Time the event was bridged
+	At string
+	// Event data, the user identifiers are redacted
+	Data map[string]any
+	// Number of events dropped before this one because the client was behind
+	Dropped *int
+}
+
+// AdminEventsPayload is the payload type of the auth-service service
+// admin_events method.
+type AdminEventsPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Access token, for the clients unable to set headers (browser WebSocket)
+	AccessToken *string
+	// Kinds of events to receive, all of them when omitted
+	Kinds []string
+}
+
+// AdminUserMetadata is the result type of the auth-service service
+// get_admin_user_metadata method.
+type AdminUserMetadata struct {
+	// Identifier of the user
+	UserID string
+	// Username of the user
+	Username *string
+	// Metadata of the user
+	UserMetadata map[string]any
+	// Provenance of the metadata fields, the fields without provenance were set
+	// before it was tracked or by the identity provider
+	Provenance map[string]*FieldProvenance
+}
+
+// Invalid event kinds
+type BadRequest string
+
+// CallerAllowlistEntry is the result type of the auth-service service
+// get_caller method.
+type CallerAllowlistEntry struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID string
+	// Free text description of the entry
+	Description *string
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string
+	// Time the entry was created
+	CreatedAt string
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt string
+}
+
+// ChangePrimaryEmailPayload is the payload type of the auth-service service
+// change_primary_email method.
+type ChangePrimaryEmailPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Reference returned by the verification of the new email
+	IdentityTokenRef string
+}
+
+// Resource changed concurrently, try again
+type Conflict string
+
+// DeleteCallerPayload is the payload type of the auth-service service
+// delete_caller method.
+type DeleteCallerPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Calling service
+	ID string
+}
+
+// EmailBackupCodes is the result type of the auth-service service
+// issue_email_backup_codes method.
+type EmailBackupCodes struct {
+	// Email the codes verify
+	Email string
+	// One-time codes, sent as the OTP of the email verification. They are only
+	// returned here.
+	Codes []string
+	// Time the codes expire
+	ExpiresAt string
+}
+
+// EventSchema is the result type of the auth-service service get_event_schema
+// method.
+type EventSchema struct {
+	// NATS subject the events are published on
+	Subject string
+	// Name of the event type
+	Title string
+	// JSON Schema (draft 2020-12) of the events
+	Schema any
+}
+
+type FailedForbiddenReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+type FailedNotFoundReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+type FailedReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+type FailedUnauthorizedReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+type FieldProvenance struct {
+	// Who set the value
+	Source string
+	// Subject of the user or admin, or calling service of the enrichment job
+	Actor *string
+	// Time the value was set
+	UpdatedAt string
+}
+
+// Not an admin
+type Forbidden string
+
+// GetAdminUserMetadataPayload is the payload type of the auth-service service
+// get_admin_user_metadata method.
+type GetAdminUserMetadataPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Username or subject identifier of the user
+	User string
+}
+
+// GetCallerPayload is the payload type of the auth-service service get_caller
+// method.
+type GetCallerPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Calling service
+	ID string
+}
+
+// GetEventSchemaPayload is the payload type of the auth-service service
+// get_event_schema method.
+type GetEventSchemaPayload struct {
+	// NATS subject the events are published on
+	Subject string
+}
+
+// GetSupportBundlePayload is the payload type of the auth-service service
+// get_support_bundle method.
+type GetSupportBundlePayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+}
+
+// GetUserMetadataPayload is the payload type of the auth-service service
+// get_user_metadata method.
+type GetUserMetadataPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Subject identifier or username of the user, current for the token bearer
+	Sub string
+	// Output mode of the metadata, like the X-Response-Format header of the NATS
+	// requests
+	Format *string
+}
+
+// IssueEmailBackupCodesPayload is the payload type of the auth-service service
+// issue_email_backup_codes method.
+type IssueEmailBackupCodesPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Email to verify
+	Email string
+}
+
+// ListCallersPayload is the payload type of the auth-service service
+// list_callers method.
+type ListCallersPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+}
+
+// ListWebhookDeliveriesPayload is the payload type of the auth-service service
+// list_webhook_deliveries method.
+type ListWebhookDeliveriesPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+}
+
+// User not found
+type NotFound string
+
+// ProfileChange is the result type of the auth-service service profile_events
+// method.
+type ProfileChange struct {
+	// Subject identifier of the user
+	Sub string
+	// Operation that changed the profile
+	Reason string
+	// Time of the change
+	ChangedAt string
+}
+
+// ProfileEventsPayload is the payload type of the auth-service service
+// profile_events method.
+type ProfileEventsPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Access token, for the clients unable to set headers (EventSource)
+	AccessToken *string
+}
+
+// PurgeUserCachePayload is the payload type of the auth-service service
+// purge_user_cache method.
+type PurgeUserCachePayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Username or subject identifier of the user
+	User string
+}
+
+// PutCallerPayload is the payload type of the auth-service service put_caller
+// method.
+type PutCallerPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID string
+	// Free text description of the entry
+	Description *string
+	// Capabilities granted to the calling service
+	Capabilities []string
+}
+
+// RebuildEmailIndexPayload is the payload type of the auth-service service
+// rebuild_email_index method.
+type RebuildEmailIndexPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+	// Username, email or subject identifier of the user
+	User string
+}
+
+// RefreshJwksPayload is the payload type of the auth-service service
+// refresh_jwks method.
+type RefreshJwksPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+}
+
+// RenewM2mTokenPayload is the payload type of the auth-service service
+// renew_m2m_token method.
+type RenewM2mTokenPayload struct {
+	// Bearer access token of an admin
+	Authorization *string
+}
+
+// ResolveProfileSharePayload is the payload type of the auth-service service
+// resolve_profile_share method.
+type ResolveProfileSharePayload struct {
+	// Token of the share link
+	Token string
+}
+
+type RetryableReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+// RunbookResult is the result type of the auth-service service renew_m2m_token
+// method.
+type RunbookResult struct {
+	// Audit action of the operation
+	Operation string
+	// Target of the operation
+	Target string
+	// Outcome of the operation
+	Outcome string
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStep
+	// Time of the operation
+	At string
+}
+
+type RunbookStep struct {
+	// Name of the step
+	Name string
+	// Outcome of the step
+	Outcome string
+	// Detail of the step, the error when it failed
+	Detail *string
+}
 
 // Service unavailable
 type ServiceUnavailable string
 
+// SharedProfile is the result type of the auth-service service
+// resolve_profile_share method.
+type SharedProfile struct {
+	// Username
+	Username *string
+	// Full name
+	Name *string
+	// Profile picture URL
+	Picture *string
+	// Job title
+	JobTitle *string
+	// Organization
+	Organization *string
+	// Whether the organization matches a verified email domain
+	OrganizationVerified *bool
+}
+
+// StartEmailLinkingPayload is the payload type of the auth-service service
+// start_email_linking method.
+type StartEmailLinkingPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Alternate email to verify
+	Email string
+}
+
+// SupportBundle is the result type of the auth-service service
+// get_support_bundle method.
+type SupportBundle struct {
+	// Time the bundle was generated
+	GeneratedAt string
+	// Build of the replica
+	Build *SupportBundleBuild
+	// Effective configuration from the environment, the secrets are redacted
+	Config map[string]string
+	// Problems found in the configuration
+	ConfigErrors []*SupportBundleConfigError
+	// Recent health of the upstream identity providers
+	Providers []*SupportBundleProvider
+	// Usage of the users cache, when enabled
+	UserCache *SupportBundleUserCache
+	// Last sync of the Authelia users seen by the replica, if any
+	LastSync *SupportBundleSyncStatus
+}
+
+type SupportBundleBuild struct {
+	// Version of the service
+	Version string
+	// Time the binary was built
+	BuildTime string
+	// Commit the binary was built from
+	GitCommit string
+	// Go runtime version
+	GoVersion string
+}
+
+type SupportBundleConfigError struct {
+	// Part of the service the configuration belongs to
+	Component string
+	// Environment variable at fault, when it can be pinned down
+	Key *string
+	// Description of the problem
+	Message string
+}
+
+type SupportBundleProvider struct {
+	// Identity provider
+	Provider string
+	// Number of upstream calls in the window, retries included
+	Samples int
+	// Number of failed upstream calls in the window
+	Failures int
+	// Ratio of failed upstream calls
+	ErrorRate float64
+	// 95th percentile latency of the calls, in milliseconds
+	P95Ms int64
+	// State of the circuit of the provider
+	CircuitState string
+	// Time of the last failed call in the window
+	LastFailureAt *string
+}
+
+type SupportBundleSyncStatus struct {
+	// Whether the sync succeeded
+	Succeeded bool
+	// Number of users changed by the sync
+	Changed int
+	// Error of the failed sync
+	Error *string
+	// Time of the sync
+	SyncedAt string
+}
+
+type SupportBundleUserCache struct {
+	// Users cache backend
+	Kind string
+	// Number of lookups served from the cache
+	Hits int64
+	// Number of lookups sent to the identity provider
+	Misses int64
+	// Number of cached lookup keys, only reported by the memory cache
+	Entries *int
+}
+
+// Too many open streams
+type TooManyRequests string
+
+// Missing or invalid bearer token
+type Unauthorized string
+
+// UpdateCurrentUserMetadataPayload is the payload type of the auth-service
+// service update_current_user_metadata method.
+type UpdateCurrentUserMetadataPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Metadata fields to update
+	UserMetadata map[string]any
+}
+
+// UploadCurrentUserPicturePayload is the payload type of the auth-service
+// service upload_current_user_picture method.
+type UploadCurrentUserPicturePayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Type of the image: PNG, JPEG, GIF or WebP
+	ContentType *string
+}
+
+// UserDataReply is the result type of the auth-service service
+// get_user_metadata method.
+type UserDataReply struct {
+	// Whether the operation succeeded
+	Success bool
+	// Outcome of the operation, when it has no data
+	Message *string
+	// Data of the operation, the same as the data of the NATS reply
+	Data any
+	// Reason of the failure
+	Reason *string
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string
+	// Whether the failure is transient
+	Retryable *bool
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool
+	// How long ago the stale data was cached, in seconds
+	Age *int64
+}
+
+// UserInfo is the result type of the auth-service service userinfo method.
+type UserInfo struct {
+	// Subject identifier
+	Sub string
+	// Full name
+	Name *string
+	// Given name
+	GivenName *string
+	// Family name
+	FamilyName *string
+	// Username
+	PreferredUsername *string
+	// Profile picture URL
+	Picture *string
+	// Primary email
+	Email *string
+	// Time zone
+	Zoneinfo *string
+	// Phone number
+	PhoneNumber *string
+	// Postal address
+	Address *UserInfoAddress
+}
+
+type UserInfoAddress struct {
+	// Street address
+	StreetAddress *string
+	// City or locality
+	Locality *string
+	// State, province or region
+	Region *string
+	// Zip or postal code
+	PostalCode *string
+	// Country name
+	Country *string
+}
+
+// UserinfoPayload is the payload type of the auth-service service userinfo
+// method.
+type UserinfoPayload struct {
+	// Bearer access token
+	Authorization *string
+}
+
+// VerifyEmailLinkingPayload is the payload type of the auth-service service
+// verify_email_linking method.
+type VerifyEmailLinkingPayload struct {
+	// Bearer access token
+	Authorization *string
+	// Locale of the messages of the reply
+	AcceptLanguage *string
+	// Alternate email to verify
+	Email string
+	// One time password sent to the email
+	Otp string
+}
+
+// WebhookDeliveries is the result type of the auth-service service
+// list_webhook_deliveries method.
+type WebhookDeliveries struct {
+	// Last deliveries, the most recent first
+	Deliveries []*WebhookDelivery
+}
+
+type WebhookDelivery struct {
+	// Action of the audit event
+	Action string
+	// HTTP status of the webhook reply, absent when the webhook wasn't reached
+	StatusCode *int
+	// Error of the failed delivery
+	Error *string
+	// Duration of the delivery in milliseconds
+	DurationMs int64
+	// Time of the delivery
+	DeliveredAt string
+}
+
+// Error returns an error description.
+func (e BadRequest) Error() string {
+	return "Invalid event kinds"
+}
+
+// ErrorName returns "BadRequest".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e BadRequest) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "BadRequest".
+func (e BadRequest) GoaErrorName() string {
+	return "BadRequest"
+}
+
+// Error returns an error description.
+func (e Conflict) Error() string {
+	return "Resource changed concurrently, try again"
+}
+
+// ErrorName returns "Conflict".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e Conflict) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "Conflict".
+func (e Conflict) GoaErrorName() string {
+	return "Conflict"
+}
+
+// Error returns an error description.
+func (e *FailedForbiddenReply) Error() string {
+	return ""
+}
+
+// ErrorName returns "FailedForbiddenReply".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e *FailedForbiddenReply) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "FailedForbiddenReply".
+func (e *FailedForbiddenReply) GoaErrorName() string {
+	return "FailedForbidden"
+}
+
+// Error returns an error description.
+func (e *FailedNotFoundReply) Error() string {
+	return ""
+}
+
+// ErrorName returns "FailedNotFoundReply".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e *FailedNotFoundReply) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "FailedNotFoundReply".
+func (e *FailedNotFoundReply) GoaErrorName() string {
+	return "FailedNotFound"
+}
+
+// Error returns an error description.
+func (e *FailedReply) Error() string {
+	return ""
+}
+
+// ErrorName returns "FailedReply".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e *FailedReply) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "FailedReply".
+func (e *FailedReply) GoaErrorName() string {
+	return "Failed"
+}
+
+// Error returns an error description.
+func (e *FailedUnauthorizedReply) Error() string {
+	return ""
+}
+
+// ErrorName returns "FailedUnauthorizedReply".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e *FailedUnauthorizedReply) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "FailedUnauthorizedReply".
+func (e *FailedUnauthorizedReply) GoaErrorName() string {
+	return "FailedUnauthorized"
+}
+
+// Error returns an error description.
+func (e Forbidden) Error() string {
+	return "Not an admin"
+}
+
+// ErrorName returns "Forbidden".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e Forbidden) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "Forbidden".
+func (e Forbidden) GoaErrorName() string {
+	return "Forbidden"
+}
+
+// Error returns an error description.
+func (e NotFound) Error() string {
+	return "User not found"
+}
+
+// ErrorName returns "NotFound".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e NotFound) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "NotFound".
+func (e NotFound) GoaErrorName() string {
+	return "NotFound"
+}
+
+// Error returns an error description.
+func (e *RetryableReply) Error() string {
+	return ""
+}
+
+// ErrorName returns "RetryableReply".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e *RetryableReply) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "RetryableReply".
+func (e *RetryableReply) GoaErrorName() string {
+	return "Retryable"
+}
+
 // Error returns an error description.
 func (e ServiceUnavailable) Error() string {
 	return "Service unavailable"
@@ -54,3 +946,37 @@ func (e ServiceUnavailable) ErrorName() string {
 func (e ServiceUnavailable) GoaErrorName() string {
 	return "ServiceUnavailable"
 }
+
+// Error returns an error description.
+func (e TooManyRequests) Error() string {
+	return "Too many open streams"
+}
+
+// ErrorName returns "TooManyRequests".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e TooManyRequests) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "TooManyRequests".
+func (e TooManyRequests) GoaErrorName() string {
+	return "TooManyRequests"
+}
+
+// Error returns an error description.
+func (e Unauthorized) Error() string {
+	return "Missing or invalid bearer token"
+}
+
+// ErrorName returns "Unauthorized".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e Unauthorized) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "Unauthorized".
+func (e Unauthorized) GoaErrorName() string {
+	return "Unauthorized"
+}
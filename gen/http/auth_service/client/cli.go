@@ -6,3 +6,575 @@
 // $ goa gen github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/design
 
 package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// BuildUserinfoPayload builds the payload for the auth-service userinfo
+// endpoint from CLI flags.
+func BuildUserinfoPayload(authServiceUserinfoAuthorization string) (*authservice.UserinfoPayload, error) {
+	var authorization *string
+	{
+		if authServiceUserinfoAuthorization != "" {
+			authorization = &authServiceUserinfoAuthorization
+		}
+	}
+	v := &authservice.UserinfoPayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildProfileEventsPayload builds the payload for the auth-service
+// profile_events endpoint from CLI flags.
+func BuildProfileEventsPayload(authServiceProfileEventsAccessToken string, authServiceProfileEventsAuthorization string) (*authservice.ProfileEventsPayload, error) {
+	var accessToken *string
+	{
+		if authServiceProfileEventsAccessToken != "" {
+			accessToken = &authServiceProfileEventsAccessToken
+		}
+	}
+	var authorization *string
+	{
+		if authServiceProfileEventsAuthorization != "" {
+			authorization = &authServiceProfileEventsAuthorization
+		}
+	}
+	v := &authservice.ProfileEventsPayload{}
+	v.AccessToken = accessToken
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildAdminEventsPayload builds the payload for the auth-service admin_events
+// endpoint from CLI flags.
+func BuildAdminEventsPayload(authServiceAdminEventsAccessToken string, authServiceAdminEventsKinds string, authServiceAdminEventsAuthorization string) (*authservice.AdminEventsPayload, error) {
+	var err error
+	var accessToken *string
+	{
+		if authServiceAdminEventsAccessToken != "" {
+			accessToken = &authServiceAdminEventsAccessToken
+		}
+	}
+	var kinds []string
+	{
+		if authServiceAdminEventsKinds != "" {
+			err = json.Unmarshal([]byte(authServiceAdminEventsKinds), &kinds)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON for kinds, \nerror: %s, \nexample of valid JSON:\n%s", err, "'[\n      \"sync_status\",\n      \"audit\"\n   ]'")
+			}
+		}
+	}
+	var authorization *string
+	{
+		if authServiceAdminEventsAuthorization != "" {
+			authorization = &authServiceAdminEventsAuthorization
+		}
+	}
+	v := &authservice.AdminEventsPayload{}
+	v.AccessToken = accessToken
+	v.Kinds = kinds
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildGetUserMetadataPayload builds the payload for the auth-service
+// get_user_metadata endpoint from CLI flags.
+func BuildGetUserMetadataPayload(authServiceGetUserMetadataSub string, authServiceGetUserMetadataAuthorization string, authServiceGetUserMetadataAcceptLanguage string, authServiceGetUserMetadataFormat string) (*authservice.GetUserMetadataPayload, error) {
+	var err error
+	var sub string
+	{
+		sub = authServiceGetUserMetadataSub
+	}
+	var authorization *string
+	{
+		if authServiceGetUserMetadataAuthorization != "" {
+			authorization = &authServiceGetUserMetadataAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceGetUserMetadataAcceptLanguage != "" {
+			acceptLanguage = &authServiceGetUserMetadataAcceptLanguage
+		}
+	}
+	var format *string
+	{
+		if authServiceGetUserMetadataFormat != "" {
+			format = &authServiceGetUserMetadataFormat
+			if !(*format == "oidc") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("format", *format, []any{"oidc"}))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := &authservice.GetUserMetadataPayload{}
+	v.Sub = sub
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+	v.Format = format
+
+	return v, nil
+}
+
+// BuildUpdateCurrentUserMetadataPayload builds the payload for the
+// auth-service update_current_user_metadata endpoint from CLI flags.
+func BuildUpdateCurrentUserMetadataPayload(authServiceUpdateCurrentUserMetadataBody string, authServiceUpdateCurrentUserMetadataAuthorization string, authServiceUpdateCurrentUserMetadataAcceptLanguage string) (*authservice.UpdateCurrentUserMetadataPayload, error) {
+	var err error
+	var body struct {
+		// Metadata fields to update
+		UserMetadata map[string]any `form:"user_metadata" json:"user_metadata" xml:"user_metadata"`
+	}
+	{
+		err = json.Unmarshal([]byte(authServiceUpdateCurrentUserMetadataBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"user_metadata\": {\n         \"job_title\": \"Software Engineer\"\n      }\n   }'")
+		}
+	}
+	var authorization *string
+	{
+		if authServiceUpdateCurrentUserMetadataAuthorization != "" {
+			authorization = &authServiceUpdateCurrentUserMetadataAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceUpdateCurrentUserMetadataAcceptLanguage != "" {
+			acceptLanguage = &authServiceUpdateCurrentUserMetadataAcceptLanguage
+		}
+	}
+	v := &authservice.UpdateCurrentUserMetadataPayload{}
+	if body.UserMetadata != nil {
+		v.UserMetadata = make(map[string]any, len(body.UserMetadata))
+		for key, val := range body.UserMetadata {
+			tk := key
+			tv := val
+			v.UserMetadata[tk] = tv
+		}
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v, nil
+}
+
+// BuildStartEmailLinkingPayload builds the payload for the auth-service
+// start_email_linking endpoint from CLI flags.
+func BuildStartEmailLinkingPayload(authServiceStartEmailLinkingBody string, authServiceStartEmailLinkingAuthorization string, authServiceStartEmailLinkingAcceptLanguage string) (*authservice.StartEmailLinkingPayload, error) {
+	var err error
+	var body struct {
+		// Alternate email to verify
+		Email *string `form:"email" json:"email" xml:"email"`
+	}
+	{
+		err = json.Unmarshal([]byte(authServiceStartEmailLinkingBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"email\": \"john.doe@example.com\"\n   }'")
+		}
+	}
+	var authorization *string
+	{
+		if authServiceStartEmailLinkingAuthorization != "" {
+			authorization = &authServiceStartEmailLinkingAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceStartEmailLinkingAcceptLanguage != "" {
+			acceptLanguage = &authServiceStartEmailLinkingAcceptLanguage
+		}
+	}
+	v := &authservice.StartEmailLinkingPayload{}
+	if body.Email != nil {
+		v.Email = *body.Email
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v, nil
+}
+
+// BuildVerifyEmailLinkingPayload builds the payload for the auth-service
+// verify_email_linking endpoint from CLI flags.
+func BuildVerifyEmailLinkingPayload(authServiceVerifyEmailLinkingBody string, authServiceVerifyEmailLinkingAuthorization string, authServiceVerifyEmailLinkingAcceptLanguage string) (*authservice.VerifyEmailLinkingPayload, error) {
+	var err error
+	var body struct {
+		// Alternate email to verify
+		Email *string `form:"email" json:"email" xml:"email"`
+		// One time password sent to the email
+		Otp *string `form:"otp" json:"otp" xml:"otp"`
+	}
+	{
+		err = json.Unmarshal([]byte(authServiceVerifyEmailLinkingBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"email\": \"john.doe@example.com\",\n      \"otp\": \"123456\"\n   }'")
+		}
+	}
+	var authorization *string
+	{
+		if authServiceVerifyEmailLinkingAuthorization != "" {
+			authorization = &authServiceVerifyEmailLinkingAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceVerifyEmailLinkingAcceptLanguage != "" {
+			acceptLanguage = &authServiceVerifyEmailLinkingAcceptLanguage
+		}
+	}
+	v := &authservice.VerifyEmailLinkingPayload{}
+	if body.Email != nil {
+		v.Email = *body.Email
+	}
+	if body.Otp != nil {
+		v.Otp = *body.Otp
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v, nil
+}
+
+// BuildChangePrimaryEmailPayload builds the payload for the auth-service
+// change_primary_email endpoint from CLI flags.
+func BuildChangePrimaryEmailPayload(authServiceChangePrimaryEmailBody string, authServiceChangePrimaryEmailAuthorization string, authServiceChangePrimaryEmailAcceptLanguage string) (*authservice.ChangePrimaryEmailPayload, error) {
+	var err error
+	var body struct {
+		// Reference returned by the verification of the new email
+		IdentityTokenRef *string `form:"identity_token_ref" json:"identity_token_ref" xml:"identity_token_ref"`
+	}
+	{
+		err = json.Unmarshal([]byte(authServiceChangePrimaryEmailBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"identity_token_ref\": \"3q2-7wXy...\"\n   }'")
+		}
+	}
+	var authorization *string
+	{
+		if authServiceChangePrimaryEmailAuthorization != "" {
+			authorization = &authServiceChangePrimaryEmailAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceChangePrimaryEmailAcceptLanguage != "" {
+			acceptLanguage = &authServiceChangePrimaryEmailAcceptLanguage
+		}
+	}
+	v := &authservice.ChangePrimaryEmailPayload{}
+	if body.IdentityTokenRef != nil {
+		v.IdentityTokenRef = *body.IdentityTokenRef
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v, nil
+}
+
+// BuildUploadCurrentUserPicturePayload builds the payload for the auth-service
+// upload_current_user_picture endpoint from CLI flags.
+func BuildUploadCurrentUserPicturePayload(authServiceUploadCurrentUserPictureAuthorization string, authServiceUploadCurrentUserPictureAcceptLanguage string, authServiceUploadCurrentUserPictureContentType string) (*authservice.UploadCurrentUserPicturePayload, error) {
+	var authorization *string
+	{
+		if authServiceUploadCurrentUserPictureAuthorization != "" {
+			authorization = &authServiceUploadCurrentUserPictureAuthorization
+		}
+	}
+	var acceptLanguage *string
+	{
+		if authServiceUploadCurrentUserPictureAcceptLanguage != "" {
+			acceptLanguage = &authServiceUploadCurrentUserPictureAcceptLanguage
+		}
+	}
+	var contentType *string
+	{
+		if authServiceUploadCurrentUserPictureContentType != "" {
+			contentType = &authServiceUploadCurrentUserPictureContentType
+		}
+	}
+	v := &authservice.UploadCurrentUserPicturePayload{}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+	v.ContentType = contentType
+
+	return v, nil
+}
+
+// BuildResolveProfileSharePayload builds the payload for the auth-service
+// resolve_profile_share endpoint from CLI flags.
+func BuildResolveProfileSharePayload(authServiceResolveProfileShareToken string) (*authservice.ResolveProfileSharePayload, error) {
+	var token string
+	{
+		token = authServiceResolveProfileShareToken
+	}
+	v := &authservice.ResolveProfileSharePayload{}
+	v.Token = token
+
+	return v, nil
+}
+
+// BuildGetEventSchemaPayload builds the payload for the auth-service
+// get_event_schema endpoint from CLI flags.
+func BuildGetEventSchemaPayload(authServiceGetEventSchemaSubject string) (*authservice.GetEventSchemaPayload, error) {
+	var subject string
+	{
+		subject = authServiceGetEventSchemaSubject
+	}
+	v := &authservice.GetEventSchemaPayload{}
+	v.Subject = subject
+
+	return v, nil
+}
+
+// BuildListCallersPayload builds the payload for the auth-service list_callers
+// endpoint from CLI flags.
+func BuildListCallersPayload(authServiceListCallersAuthorization string) (*authservice.ListCallersPayload, error) {
+	var authorization *string
+	{
+		if authServiceListCallersAuthorization != "" {
+			authorization = &authServiceListCallersAuthorization
+		}
+	}
+	v := &authservice.ListCallersPayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildGetCallerPayload builds the payload for the auth-service get_caller
+// endpoint from CLI flags.
+func BuildGetCallerPayload(authServiceGetCallerID string, authServiceGetCallerAuthorization string) (*authservice.GetCallerPayload, error) {
+	var id string
+	{
+		id = authServiceGetCallerID
+	}
+	var authorization *string
+	{
+		if authServiceGetCallerAuthorization != "" {
+			authorization = &authServiceGetCallerAuthorization
+		}
+	}
+	v := &authservice.GetCallerPayload{}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildPutCallerPayload builds the payload for the auth-service put_caller
+// endpoint from CLI flags.
+func BuildPutCallerPayload(authServicePutCallerBody string, authServicePutCallerID string, authServicePutCallerAuthorization string) (*authservice.PutCallerPayload, error) {
+	var err error
+	var body PutCallerRequestBody
+	{
+		err = json.Unmarshal([]byte(authServicePutCallerBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"capabilities\": [\n         \"email_owner\"\n      ],\n      \"description\": \"Fugiat voluptates.\"\n   }'")
+		}
+		if body.Capabilities == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("capabilities", "body"))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var id string
+	{
+		id = authServicePutCallerID
+	}
+	var authorization *string
+	{
+		if authServicePutCallerAuthorization != "" {
+			authorization = &authServicePutCallerAuthorization
+		}
+	}
+	v := &authservice.PutCallerPayload{
+		Description: body.Description,
+	}
+	if body.Capabilities != nil {
+		v.Capabilities = make([]string, len(body.Capabilities))
+		for i, val := range body.Capabilities {
+			v.Capabilities[i] = val
+		}
+	} else {
+		v.Capabilities = []string{}
+	}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildDeleteCallerPayload builds the payload for the auth-service
+// delete_caller endpoint from CLI flags.
+func BuildDeleteCallerPayload(authServiceDeleteCallerID string, authServiceDeleteCallerAuthorization string) (*authservice.DeleteCallerPayload, error) {
+	var id string
+	{
+		id = authServiceDeleteCallerID
+	}
+	var authorization *string
+	{
+		if authServiceDeleteCallerAuthorization != "" {
+			authorization = &authServiceDeleteCallerAuthorization
+		}
+	}
+	v := &authservice.DeleteCallerPayload{}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildGetAdminUserMetadataPayload builds the payload for the auth-service
+// get_admin_user_metadata endpoint from CLI flags.
+func BuildGetAdminUserMetadataPayload(authServiceGetAdminUserMetadataUser string, authServiceGetAdminUserMetadataAuthorization string) (*authservice.GetAdminUserMetadataPayload, error) {
+	var user string
+	{
+		user = authServiceGetAdminUserMetadataUser
+	}
+	var authorization *string
+	{
+		if authServiceGetAdminUserMetadataAuthorization != "" {
+			authorization = &authServiceGetAdminUserMetadataAuthorization
+		}
+	}
+	v := &authservice.GetAdminUserMetadataPayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildIssueEmailBackupCodesPayload builds the payload for the auth-service
+// issue_email_backup_codes endpoint from CLI flags.
+func BuildIssueEmailBackupCodesPayload(authServiceIssueEmailBackupCodesBody string, authServiceIssueEmailBackupCodesAuthorization string) (*authservice.IssueEmailBackupCodesPayload, error) {
+	var err error
+	var body IssueEmailBackupCodesRequestBody
+	{
+		err = json.Unmarshal([]byte(authServiceIssueEmailBackupCodesBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"email\": \"jane@personal.example\"\n   }'")
+		}
+	}
+	var authorization *string
+	{
+		if authServiceIssueEmailBackupCodesAuthorization != "" {
+			authorization = &authServiceIssueEmailBackupCodesAuthorization
+		}
+	}
+	v := &authservice.IssueEmailBackupCodesPayload{
+		Email: body.Email,
+	}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildPurgeUserCachePayload builds the payload for the auth-service
+// purge_user_cache endpoint from CLI flags.
+func BuildPurgeUserCachePayload(authServicePurgeUserCacheUser string, authServicePurgeUserCacheAuthorization string) (*authservice.PurgeUserCachePayload, error) {
+	var user string
+	{
+		user = authServicePurgeUserCacheUser
+	}
+	var authorization *string
+	{
+		if authServicePurgeUserCacheAuthorization != "" {
+			authorization = &authServicePurgeUserCacheAuthorization
+		}
+	}
+	v := &authservice.PurgeUserCachePayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildListWebhookDeliveriesPayload builds the payload for the auth-service
+// list_webhook_deliveries endpoint from CLI flags.
+func BuildListWebhookDeliveriesPayload(authServiceListWebhookDeliveriesAuthorization string) (*authservice.ListWebhookDeliveriesPayload, error) {
+	var authorization *string
+	{
+		if authServiceListWebhookDeliveriesAuthorization != "" {
+			authorization = &authServiceListWebhookDeliveriesAuthorization
+		}
+	}
+	v := &authservice.ListWebhookDeliveriesPayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildGetSupportBundlePayload builds the payload for the auth-service
+// get_support_bundle endpoint from CLI flags.
+func BuildGetSupportBundlePayload(authServiceGetSupportBundleAuthorization string) (*authservice.GetSupportBundlePayload, error) {
+	var authorization *string
+	{
+		if authServiceGetSupportBundleAuthorization != "" {
+			authorization = &authServiceGetSupportBundleAuthorization
+		}
+	}
+	v := &authservice.GetSupportBundlePayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildRenewM2mTokenPayload builds the payload for the auth-service
+// renew_m2m_token endpoint from CLI flags.
+func BuildRenewM2mTokenPayload(authServiceRenewM2mTokenAuthorization string) (*authservice.RenewM2mTokenPayload, error) {
+	var authorization *string
+	{
+		if authServiceRenewM2mTokenAuthorization != "" {
+			authorization = &authServiceRenewM2mTokenAuthorization
+		}
+	}
+	v := &authservice.RenewM2mTokenPayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildRefreshJwksPayload builds the payload for the auth-service refresh_jwks
+// endpoint from CLI flags.
+func BuildRefreshJwksPayload(authServiceRefreshJwksAuthorization string) (*authservice.RefreshJwksPayload, error) {
+	var authorization *string
+	{
+		if authServiceRefreshJwksAuthorization != "" {
+			authorization = &authServiceRefreshJwksAuthorization
+		}
+	}
+	v := &authservice.RefreshJwksPayload{}
+	v.Authorization = authorization
+
+	return v, nil
+}
+
+// BuildRebuildEmailIndexPayload builds the payload for the auth-service
+// rebuild_email_index endpoint from CLI flags.
+func BuildRebuildEmailIndexPayload(authServiceRebuildEmailIndexUser string, authServiceRebuildEmailIndexAuthorization string) (*authservice.RebuildEmailIndexPayload, error) {
+	var user string
+	{
+		user = authServiceRebuildEmailIndexUser
+	}
+	var authorization *string
+	{
+		if authServiceRebuildEmailIndexAuthorization != "" {
+			authorization = &authServiceRebuildEmailIndexAuthorization
+		}
+	}
+	v := &authservice.RebuildEmailIndexPayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v, nil
+}
@@ -9,8 +9,12 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/gorilla/websocket"
 	goahttp "goa.design/goa/v3/http"
 	goa "goa.design/goa/v3/pkg"
 )
@@ -23,14 +27,112 @@ type Client struct {
 	// Readyz Doer is the HTTP client used to make requests to the readyz endpoint.
 	ReadyzDoer goahttp.Doer
 
+	// Userinfo Doer is the HTTP client used to make requests to the userinfo
+	// endpoint.
+	UserinfoDoer goahttp.Doer
+
+	// ProfileEvents Doer is the HTTP client used to make requests to the
+	// profile_events endpoint.
+	ProfileEventsDoer goahttp.Doer
+
+	// AdminEvents Doer is the HTTP client used to make requests to the
+	// admin_events endpoint.
+	AdminEventsDoer goahttp.Doer
+
+	// GetUserMetadata Doer is the HTTP client used to make requests to the
+	// get_user_metadata endpoint.
+	GetUserMetadataDoer goahttp.Doer
+
+	// UpdateCurrentUserMetadata Doer is the HTTP client used to make requests to
+	// the update_current_user_metadata endpoint.
+	UpdateCurrentUserMetadataDoer goahttp.Doer
+
+	// StartEmailLinking Doer is the HTTP client used to make requests to the
+	// start_email_linking endpoint.
+	StartEmailLinkingDoer goahttp.Doer
+
+	// VerifyEmailLinking Doer is the HTTP client used to make requests to the
+	// verify_email_linking endpoint.
+	VerifyEmailLinkingDoer goahttp.Doer
+
+	// ChangePrimaryEmail Doer is the HTTP client used to make requests to the
+	// change_primary_email endpoint.
+	ChangePrimaryEmailDoer goahttp.Doer
+
+	// UploadCurrentUserPicture Doer is the HTTP client used to make requests to
+	// the upload_current_user_picture endpoint.
+	UploadCurrentUserPictureDoer goahttp.Doer
+
+	// ResolveProfileShare Doer is the HTTP client used to make requests to the
+	// resolve_profile_share endpoint.
+	ResolveProfileShareDoer goahttp.Doer
+
+	// ListEventSchemas Doer is the HTTP client used to make requests to the
+	// list_event_schemas endpoint.
+	ListEventSchemasDoer goahttp.Doer
+
+	// GetEventSchema Doer is the HTTP client used to make requests to the
+	// get_event_schema endpoint.
+	GetEventSchemaDoer goahttp.Doer
+
+	// ListCallers Doer is the HTTP client used to make requests to the
+	// list_callers endpoint.
+	ListCallersDoer goahttp.Doer
+
+	// GetCaller Doer is the HTTP client used to make requests to the get_caller
+	// endpoint.
+	GetCallerDoer goahttp.Doer
+
+	// PutCaller Doer is the HTTP client used to make requests to the put_caller
+	// endpoint.
+	PutCallerDoer goahttp.Doer
+
+	// DeleteCaller Doer is the HTTP client used to make requests to the
+	// delete_caller endpoint.
+	DeleteCallerDoer goahttp.Doer
+
+	// GetAdminUserMetadata Doer is the HTTP client used to make requests to the
+	// get_admin_user_metadata endpoint.
+	GetAdminUserMetadataDoer goahttp.Doer
+
+	// IssueEmailBackupCodes Doer is the HTTP client used to make requests to the
+	// issue_email_backup_codes endpoint.
+	IssueEmailBackupCodesDoer goahttp.Doer
+
+	// PurgeUserCache Doer is the HTTP client used to make requests to the
+	// purge_user_cache endpoint.
+	PurgeUserCacheDoer goahttp.Doer
+
+	// ListWebhookDeliveries Doer is the HTTP client used to make requests to the
+	// list_webhook_deliveries endpoint.
+	ListWebhookDeliveriesDoer goahttp.Doer
+
+	// GetSupportBundle Doer is the HTTP client used to make requests to the
+	// get_support_bundle endpoint.
+	GetSupportBundleDoer goahttp.Doer
+
+	// RenewM2mToken Doer is the HTTP client used to make requests to the
+	// renew_m2m_token endpoint.
+	RenewM2mTokenDoer goahttp.Doer
+
+	// RefreshJwks Doer is the HTTP client used to make requests to the
+	// refresh_jwks endpoint.
+	RefreshJwksDoer goahttp.Doer
+
+	// RebuildEmailIndex Doer is the HTTP client used to make requests to the
+	// rebuild_email_index endpoint.
+	RebuildEmailIndexDoer goahttp.Doer
+
 	// RestoreResponseBody controls whether the response bodies are reset after
 	// decoding so they can be read again.
 	RestoreResponseBody bool
 
-	scheme  string
-	host    string
-	encoder func(*http.Request) goahttp.Encoder
-	decoder func(*http.Response) goahttp.Decoder
+	scheme     string
+	host       string
+	encoder    func(*http.Request) goahttp.Encoder
+	decoder    func(*http.Response) goahttp.Decoder
+	dialer     goahttp.Dialer
+	configurer *ConnConfigurer
 }
 
 // NewClient instantiates HTTP clients for all the auth-service service servers.
@@ -41,15 +143,46 @@ func NewClient(
 	enc func(*http.Request) goahttp.Encoder,
 	dec func(*http.Response) goahttp.Decoder,
 	restoreBody bool,
+	dialer goahttp.Dialer,
+	cfn *ConnConfigurer,
 ) *Client {
+	if cfn == nil {
+		cfn = &ConnConfigurer{}
+	}
 	return &Client{
-		LivezDoer:           doer,
-		ReadyzDoer:          doer,
-		RestoreResponseBody: restoreBody,
-		scheme:              scheme,
-		host:                host,
-		decoder:             dec,
-		encoder:             enc,
+		LivezDoer:                     doer,
+		ReadyzDoer:                    doer,
+		UserinfoDoer:                  doer,
+		ProfileEventsDoer:             doer,
+		AdminEventsDoer:               doer,
+		GetUserMetadataDoer:           doer,
+		UpdateCurrentUserMetadataDoer: doer,
+		StartEmailLinkingDoer:         doer,
+		VerifyEmailLinkingDoer:        doer,
+		ChangePrimaryEmailDoer:        doer,
+		UploadCurrentUserPictureDoer:  doer,
+		ResolveProfileShareDoer:       doer,
+		ListEventSchemasDoer:          doer,
+		GetEventSchemaDoer:            doer,
+		ListCallersDoer:               doer,
+		GetCallerDoer:                 doer,
+		PutCallerDoer:                 doer,
+		DeleteCallerDoer:              doer,
+		GetAdminUserMetadataDoer:      doer,
+		IssueEmailBackupCodesDoer:     doer,
+		PurgeUserCacheDoer:            doer,
+		ListWebhookDeliveriesDoer:     doer,
+		GetSupportBundleDoer:          doer,
+		RenewM2mTokenDoer:             doer,
+		RefreshJwksDoer:               doer,
+		RebuildEmailIndexDoer:         doer,
+		RestoreResponseBody:           restoreBody,
+		scheme:                        scheme,
+		host:                          host,
+		decoder:                       dec,
+		encoder:                       enc,
+		dialer:                        dialer,
+		configurer:                    cfn,
 	}
 }
 
@@ -90,3 +223,594 @@ func (c *Client) Readyz() goa.Endpoint {
 		return decodeResponse(resp)
 	}
 }
+
+// Userinfo returns an endpoint that makes HTTP requests to the auth-service
+// service userinfo server.
+func (c *Client) Userinfo() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeUserinfoRequest(c.encoder)
+		decodeResponse = DecodeUserinfoResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildUserinfoRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.UserinfoDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "userinfo", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ProfileEvents returns an endpoint that makes HTTP requests to the
+// auth-service service profile_events server.
+func (c *Client) ProfileEvents() goa.Endpoint {
+	var (
+		encodeRequest = EncodeProfileEventsRequest(c.encoder)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildProfileEventsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		// For SSE endpoints, connect and return a stream
+		resp, err := c.ProfileEventsDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "profile_events", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status from SSE endpoint: %d", resp.StatusCode)
+		}
+
+		contentType := resp.Header.Get("Content-Type")
+		if contentType != "" && !strings.HasPrefix(contentType, "text/event-stream") {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected content type: %s (expected text/event-stream)", contentType)
+		}
+
+		return NewProfileEventsStream(resp, c.decoder), nil
+	}
+}
+
+// AdminEvents returns an endpoint that makes HTTP requests to the auth-service
+// service admin_events server.
+func (c *Client) AdminEvents() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeAdminEventsRequest(c.encoder)
+		decodeResponse = DecodeAdminEventsResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildAdminEventsRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		conn, resp, err := c.dialer.DialContext(ctx, req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("auth-service", "admin_events", err)
+		}
+		if c.configurer.AdminEventsFn != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			conn = c.configurer.AdminEventsFn(conn, cancel)
+		}
+		go func() {
+			<-ctx.Done()
+			conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, "client closing connection"),
+				time.Now().Add(time.Second),
+			)
+			conn.Close()
+		}()
+		stream := &AdminEventsClientStream{conn: conn}
+		return stream, nil
+	}
+}
+
+// GetUserMetadata returns an endpoint that makes HTTP requests to the
+// auth-service service get_user_metadata server.
+func (c *Client) GetUserMetadata() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetUserMetadataRequest(c.encoder)
+		decodeResponse = DecodeGetUserMetadataResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetUserMetadataRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetUserMetadataDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "get_user_metadata", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// UpdateCurrentUserMetadata returns an endpoint that makes HTTP requests to
+// the auth-service service update_current_user_metadata server.
+func (c *Client) UpdateCurrentUserMetadata() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeUpdateCurrentUserMetadataRequest(c.encoder)
+		decodeResponse = DecodeUpdateCurrentUserMetadataResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildUpdateCurrentUserMetadataRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.UpdateCurrentUserMetadataDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "update_current_user_metadata", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// StartEmailLinking returns an endpoint that makes HTTP requests to the
+// auth-service service start_email_linking server.
+func (c *Client) StartEmailLinking() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStartEmailLinkingRequest(c.encoder)
+		decodeResponse = DecodeStartEmailLinkingResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildStartEmailLinkingRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.StartEmailLinkingDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "start_email_linking", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// VerifyEmailLinking returns an endpoint that makes HTTP requests to the
+// auth-service service verify_email_linking server.
+func (c *Client) VerifyEmailLinking() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeVerifyEmailLinkingRequest(c.encoder)
+		decodeResponse = DecodeVerifyEmailLinkingResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildVerifyEmailLinkingRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.VerifyEmailLinkingDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "verify_email_linking", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ChangePrimaryEmail returns an endpoint that makes HTTP requests to the
+// auth-service service change_primary_email server.
+func (c *Client) ChangePrimaryEmail() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeChangePrimaryEmailRequest(c.encoder)
+		decodeResponse = DecodeChangePrimaryEmailResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildChangePrimaryEmailRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ChangePrimaryEmailDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "change_primary_email", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// UploadCurrentUserPicture returns an endpoint that makes HTTP requests to the
+// auth-service service upload_current_user_picture server.
+func (c *Client) UploadCurrentUserPicture() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeUploadCurrentUserPictureRequest(c.encoder)
+		decodeResponse = DecodeUploadCurrentUserPictureResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildUploadCurrentUserPictureRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.UploadCurrentUserPictureDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "upload_current_user_picture", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ResolveProfileShare returns an endpoint that makes HTTP requests to the
+// auth-service service resolve_profile_share server.
+func (c *Client) ResolveProfileShare() goa.Endpoint {
+	var (
+		decodeResponse = DecodeResolveProfileShareResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildResolveProfileShareRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ResolveProfileShareDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "resolve_profile_share", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ListEventSchemas returns an endpoint that makes HTTP requests to the
+// auth-service service list_event_schemas server.
+func (c *Client) ListEventSchemas() goa.Endpoint {
+	var (
+		decodeResponse = DecodeListEventSchemasResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildListEventSchemasRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ListEventSchemasDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "list_event_schemas", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetEventSchema returns an endpoint that makes HTTP requests to the
+// auth-service service get_event_schema server.
+func (c *Client) GetEventSchema() goa.Endpoint {
+	var (
+		decodeResponse = DecodeGetEventSchemaResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetEventSchemaRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetEventSchemaDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "get_event_schema", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ListCallers returns an endpoint that makes HTTP requests to the auth-service
+// service list_callers server.
+func (c *Client) ListCallers() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeListCallersRequest(c.encoder)
+		decodeResponse = DecodeListCallersResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildListCallersRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ListCallersDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "list_callers", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetCaller returns an endpoint that makes HTTP requests to the auth-service
+// service get_caller server.
+func (c *Client) GetCaller() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetCallerRequest(c.encoder)
+		decodeResponse = DecodeGetCallerResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetCallerRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetCallerDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "get_caller", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PutCaller returns an endpoint that makes HTTP requests to the auth-service
+// service put_caller server.
+func (c *Client) PutCaller() goa.Endpoint {
+	var (
+		encodeRequest  = EncodePutCallerRequest(c.encoder)
+		decodeResponse = DecodePutCallerResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPutCallerRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PutCallerDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "put_caller", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// DeleteCaller returns an endpoint that makes HTTP requests to the
+// auth-service service delete_caller server.
+func (c *Client) DeleteCaller() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeDeleteCallerRequest(c.encoder)
+		decodeResponse = DecodeDeleteCallerResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildDeleteCallerRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.DeleteCallerDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "delete_caller", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetAdminUserMetadata returns an endpoint that makes HTTP requests to the
+// auth-service service get_admin_user_metadata server.
+func (c *Client) GetAdminUserMetadata() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetAdminUserMetadataRequest(c.encoder)
+		decodeResponse = DecodeGetAdminUserMetadataResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetAdminUserMetadataRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetAdminUserMetadataDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "get_admin_user_metadata", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// IssueEmailBackupCodes returns an endpoint that makes HTTP requests to the
+// auth-service service issue_email_backup_codes server.
+func (c *Client) IssueEmailBackupCodes() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeIssueEmailBackupCodesRequest(c.encoder)
+		decodeResponse = DecodeIssueEmailBackupCodesResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildIssueEmailBackupCodesRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.IssueEmailBackupCodesDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "issue_email_backup_codes", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// PurgeUserCache returns an endpoint that makes HTTP requests to the
+// auth-service service purge_user_cache server.
+func (c *Client) PurgeUserCache() goa.Endpoint {
+	var (
+		encodeRequest  = EncodePurgeUserCacheRequest(c.encoder)
+		decodeResponse = DecodePurgeUserCacheResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildPurgeUserCacheRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.PurgeUserCacheDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "purge_user_cache", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// ListWebhookDeliveries returns an endpoint that makes HTTP requests to the
+// auth-service service list_webhook_deliveries server.
+func (c *Client) ListWebhookDeliveries() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeListWebhookDeliveriesRequest(c.encoder)
+		decodeResponse = DecodeListWebhookDeliveriesResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildListWebhookDeliveriesRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.ListWebhookDeliveriesDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "list_webhook_deliveries", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// GetSupportBundle returns an endpoint that makes HTTP requests to the
+// auth-service service get_support_bundle server.
+func (c *Client) GetSupportBundle() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeGetSupportBundleRequest(c.encoder)
+		decodeResponse = DecodeGetSupportBundleResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildGetSupportBundleRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GetSupportBundleDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "get_support_bundle", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// RenewM2mToken returns an endpoint that makes HTTP requests to the
+// auth-service service renew_m2m_token server.
+func (c *Client) RenewM2mToken() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeRenewM2mTokenRequest(c.encoder)
+		decodeResponse = DecodeRenewM2mTokenResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildRenewM2mTokenRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.RenewM2mTokenDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "renew_m2m_token", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// RefreshJwks returns an endpoint that makes HTTP requests to the auth-service
+// service refresh_jwks server.
+func (c *Client) RefreshJwks() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeRefreshJwksRequest(c.encoder)
+		decodeResponse = DecodeRefreshJwksResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildRefreshJwksRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.RefreshJwksDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "refresh_jwks", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+
+// RebuildEmailIndex returns an endpoint that makes HTTP requests to the
+// auth-service service rebuild_email_index server.
+func (c *Client) RebuildEmailIndex() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeRebuildEmailIndexRequest(c.encoder)
+		decodeResponse = DecodeRebuildEmailIndexResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildRebuildEmailIndexRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.RebuildEmailIndexDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("auth-service", "rebuild_email_index", err)
+		}
+		return decodeResponse(resp)
+	}
+}
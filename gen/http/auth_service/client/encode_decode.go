@@ -13,8 +13,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
 	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
 )
 
 // BuildLivezRequest instantiates a HTTP request object with method and path
@@ -129,3 +132,3666 @@ func DecodeReadyzResponse(decoder func(*http.Response) goahttp.Decoder, restoreB
 		}
 	}
 }
+
+// BuildUserinfoRequest instantiates a HTTP request object with method and path
+// set to call the "auth-service" service "userinfo" endpoint
+func (c *Client) BuildUserinfoRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UserinfoAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "userinfo", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeUserinfoRequest returns an encoder for requests sent to the
+// auth-service userinfo server.
+func EncodeUserinfoRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.UserinfoPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "userinfo", "*authservice.UserinfoPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeUserinfoResponse returns a decoder for responses returned by the
+// auth-service userinfo endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+// DecodeUserinfoResponse may return the following errors:
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeUserinfoResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body UserinfoResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "userinfo", err)
+			}
+			err = ValidateUserinfoResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "userinfo", err)
+			}
+			res := NewUserinfoUserInfoOK(&body)
+			return res, nil
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "userinfo", err)
+			}
+			return nil, NewUserinfoNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "userinfo", err)
+			}
+			return nil, NewUserinfoServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "userinfo", err)
+			}
+			return nil, NewUserinfoUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "userinfo", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildProfileEventsRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "profile_events" endpoint
+func (c *Client) BuildProfileEventsRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ProfileEventsAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "profile_events", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeProfileEventsRequest returns an encoder for requests sent to the
+// auth-service profile_events server.
+func EncodeProfileEventsRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.ProfileEventsPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "profile_events", "*authservice.ProfileEventsPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		values := req.URL.Query()
+		if p.AccessToken != nil {
+			values.Add("access_token", *p.AccessToken)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeProfileEventsResponse returns a decoder for responses returned by the
+// auth-service profile_events endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeProfileEventsResponse may return the following errors:
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "TooManyRequests" (type authservice.TooManyRequests): http.StatusTooManyRequests
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeProfileEventsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ProfileEventsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "profile_events", err)
+			}
+			err = ValidateProfileEventsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "profile_events", err)
+			}
+			res := NewProfileEventsProfileChangeOK(&body)
+			return res, nil
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "profile_events", err)
+			}
+			return nil, NewProfileEventsServiceUnavailable(body)
+		case http.StatusTooManyRequests:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "profile_events", err)
+			}
+			return nil, NewProfileEventsTooManyRequests(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "profile_events", err)
+			}
+			return nil, NewProfileEventsUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "profile_events", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildAdminEventsRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "admin_events" endpoint
+func (c *Client) BuildAdminEventsRequest(ctx context.Context, v any) (*http.Request, error) {
+	scheme := c.scheme
+	switch c.scheme {
+	case "http":
+		scheme = "ws"
+	case "https":
+		scheme = "wss"
+	}
+	u := &url.URL{Scheme: scheme, Host: c.host, Path: AdminEventsAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "admin_events", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeAdminEventsRequest returns an encoder for requests sent to the
+// auth-service admin_events server.
+func EncodeAdminEventsRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.AdminEventsPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "admin_events", "*authservice.AdminEventsPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		values := req.URL.Query()
+		if p.AccessToken != nil {
+			values.Add("access_token", *p.AccessToken)
+		}
+		for _, value := range p.Kinds {
+			values.Add("kinds", value)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// DecodeAdminEventsResponse returns a decoder for responses returned by the
+// auth-service admin_events endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeAdminEventsResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "TooManyRequests" (type authservice.TooManyRequests): http.StatusTooManyRequests
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeAdminEventsResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body AdminEventsResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			err = ValidateAdminEventsResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "admin_events", err)
+			}
+			res := NewAdminEventsAdminEventOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			return nil, NewAdminEventsBadRequest(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			return nil, NewAdminEventsForbidden(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			return nil, NewAdminEventsServiceUnavailable(body)
+		case http.StatusTooManyRequests:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			return nil, NewAdminEventsTooManyRequests(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "admin_events", err)
+			}
+			return nil, NewAdminEventsUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "admin_events", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetUserMetadataRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "get_user_metadata" endpoint
+func (c *Client) BuildGetUserMetadataRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		sub string
+	)
+	{
+		p, ok := v.(*authservice.GetUserMetadataPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "get_user_metadata", "*authservice.GetUserMetadataPayload", v)
+		}
+		sub = p.Sub
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetUserMetadataAuthServicePath(sub)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "get_user_metadata", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetUserMetadataRequest returns an encoder for requests sent to the
+// auth-service get_user_metadata server.
+func EncodeGetUserMetadataRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.GetUserMetadataPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "get_user_metadata", "*authservice.GetUserMetadataPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		if p.Format != nil {
+			head := *p.Format
+			req.Header.Set("X-Response-Format", head)
+		}
+		return nil
+	}
+}
+
+// DecodeGetUserMetadataResponse returns a decoder for responses returned by
+// the auth-service get_user_metadata endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeGetUserMetadataResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeGetUserMetadataResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetUserMetadataResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+			}
+			err = ValidateGetUserMetadataResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+			}
+			res := NewGetUserMetadataUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body GetUserMetadataFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+			}
+			err = ValidateGetUserMetadataFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+			}
+			return nil, NewGetUserMetadataFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body GetUserMetadataFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+			}
+			err = ValidateGetUserMetadataFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+			}
+			return nil, NewGetUserMetadataFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body GetUserMetadataFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+			}
+			err = ValidateGetUserMetadataFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+			}
+			return nil, NewGetUserMetadataFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body GetUserMetadataFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+				}
+				err = ValidateGetUserMetadataFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+				}
+				return nil, NewGetUserMetadataFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+				}
+				return nil, NewGetUserMetadataUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "get_user_metadata", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body GetUserMetadataRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+				}
+				err = ValidateGetUserMetadataRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "get_user_metadata", err)
+				}
+				return nil, NewGetUserMetadataRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "get_user_metadata", err)
+				}
+				return nil, NewGetUserMetadataServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "get_user_metadata", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "get_user_metadata", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildUpdateCurrentUserMetadataRequest instantiates a HTTP request object
+// with method and path set to call the "auth-service" service
+// "update_current_user_metadata" endpoint
+func (c *Client) BuildUpdateCurrentUserMetadataRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UpdateCurrentUserMetadataAuthServicePath()}
+	req, err := http.NewRequest("PATCH", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "update_current_user_metadata", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeUpdateCurrentUserMetadataRequest returns an encoder for requests sent
+// to the auth-service update_current_user_metadata server.
+func EncodeUpdateCurrentUserMetadataRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.UpdateCurrentUserMetadataPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "update_current_user_metadata", "*authservice.UpdateCurrentUserMetadataPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		body := p
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "update_current_user_metadata", err)
+		}
+		return nil
+	}
+}
+
+// DecodeUpdateCurrentUserMetadataResponse returns a decoder for responses
+// returned by the auth-service update_current_user_metadata endpoint.
+// restoreBody controls whether the response body should be restored after
+// having been read.
+// DecodeUpdateCurrentUserMetadataResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeUpdateCurrentUserMetadataResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body UpdateCurrentUserMetadataResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+			}
+			err = ValidateUpdateCurrentUserMetadataResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+			}
+			res := NewUpdateCurrentUserMetadataUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body UpdateCurrentUserMetadataFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+			}
+			err = ValidateUpdateCurrentUserMetadataFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+			}
+			return nil, NewUpdateCurrentUserMetadataFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body UpdateCurrentUserMetadataFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+			}
+			err = ValidateUpdateCurrentUserMetadataFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+			}
+			return nil, NewUpdateCurrentUserMetadataFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body UpdateCurrentUserMetadataFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+			}
+			err = ValidateUpdateCurrentUserMetadataFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+			}
+			return nil, NewUpdateCurrentUserMetadataFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body UpdateCurrentUserMetadataFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+				}
+				err = ValidateUpdateCurrentUserMetadataFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+				}
+				return nil, NewUpdateCurrentUserMetadataFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+				}
+				return nil, NewUpdateCurrentUserMetadataUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "update_current_user_metadata", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body UpdateCurrentUserMetadataRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+				}
+				err = ValidateUpdateCurrentUserMetadataRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "update_current_user_metadata", err)
+				}
+				return nil, NewUpdateCurrentUserMetadataRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "update_current_user_metadata", err)
+				}
+				return nil, NewUpdateCurrentUserMetadataServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "update_current_user_metadata", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "update_current_user_metadata", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildStartEmailLinkingRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "start_email_linking"
+// endpoint
+func (c *Client) BuildStartEmailLinkingRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: StartEmailLinkingAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "start_email_linking", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeStartEmailLinkingRequest returns an encoder for requests sent to the
+// auth-service start_email_linking server.
+func EncodeStartEmailLinkingRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.StartEmailLinkingPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "start_email_linking", "*authservice.StartEmailLinkingPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		body := p
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "start_email_linking", err)
+		}
+		return nil
+	}
+}
+
+// DecodeStartEmailLinkingResponse returns a decoder for responses returned by
+// the auth-service start_email_linking endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeStartEmailLinkingResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeStartEmailLinkingResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body StartEmailLinkingResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+			}
+			err = ValidateStartEmailLinkingResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+			}
+			res := NewStartEmailLinkingUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body StartEmailLinkingFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+			}
+			err = ValidateStartEmailLinkingFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+			}
+			return nil, NewStartEmailLinkingFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body StartEmailLinkingFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+			}
+			err = ValidateStartEmailLinkingFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+			}
+			return nil, NewStartEmailLinkingFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body StartEmailLinkingFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+			}
+			err = ValidateStartEmailLinkingFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+			}
+			return nil, NewStartEmailLinkingFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body StartEmailLinkingFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+				}
+				err = ValidateStartEmailLinkingFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+				}
+				return nil, NewStartEmailLinkingFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+				}
+				return nil, NewStartEmailLinkingUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "start_email_linking", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body StartEmailLinkingRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+				}
+				err = ValidateStartEmailLinkingRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "start_email_linking", err)
+				}
+				return nil, NewStartEmailLinkingRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "start_email_linking", err)
+				}
+				return nil, NewStartEmailLinkingServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "start_email_linking", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "start_email_linking", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildVerifyEmailLinkingRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "verify_email_linking" endpoint
+func (c *Client) BuildVerifyEmailLinkingRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: VerifyEmailLinkingAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "verify_email_linking", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeVerifyEmailLinkingRequest returns an encoder for requests sent to the
+// auth-service verify_email_linking server.
+func EncodeVerifyEmailLinkingRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.VerifyEmailLinkingPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "verify_email_linking", "*authservice.VerifyEmailLinkingPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		body := p
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "verify_email_linking", err)
+		}
+		return nil
+	}
+}
+
+// DecodeVerifyEmailLinkingResponse returns a decoder for responses returned by
+// the auth-service verify_email_linking endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeVerifyEmailLinkingResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeVerifyEmailLinkingResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body VerifyEmailLinkingResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+			}
+			err = ValidateVerifyEmailLinkingResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+			}
+			res := NewVerifyEmailLinkingUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body VerifyEmailLinkingFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+			}
+			err = ValidateVerifyEmailLinkingFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+			}
+			return nil, NewVerifyEmailLinkingFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body VerifyEmailLinkingFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+			}
+			err = ValidateVerifyEmailLinkingFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+			}
+			return nil, NewVerifyEmailLinkingFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body VerifyEmailLinkingFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+			}
+			err = ValidateVerifyEmailLinkingFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+			}
+			return nil, NewVerifyEmailLinkingFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body VerifyEmailLinkingFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+				}
+				err = ValidateVerifyEmailLinkingFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+				}
+				return nil, NewVerifyEmailLinkingFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+				}
+				return nil, NewVerifyEmailLinkingUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "verify_email_linking", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body VerifyEmailLinkingRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+				}
+				err = ValidateVerifyEmailLinkingRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "verify_email_linking", err)
+				}
+				return nil, NewVerifyEmailLinkingRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "verify_email_linking", err)
+				}
+				return nil, NewVerifyEmailLinkingServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "verify_email_linking", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "verify_email_linking", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildChangePrimaryEmailRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "change_primary_email" endpoint
+func (c *Client) BuildChangePrimaryEmailRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ChangePrimaryEmailAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "change_primary_email", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeChangePrimaryEmailRequest returns an encoder for requests sent to the
+// auth-service change_primary_email server.
+func EncodeChangePrimaryEmailRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.ChangePrimaryEmailPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "change_primary_email", "*authservice.ChangePrimaryEmailPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		body := p
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "change_primary_email", err)
+		}
+		return nil
+	}
+}
+
+// DecodeChangePrimaryEmailResponse returns a decoder for responses returned by
+// the auth-service change_primary_email endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeChangePrimaryEmailResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeChangePrimaryEmailResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ChangePrimaryEmailResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+			}
+			err = ValidateChangePrimaryEmailResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+			}
+			res := NewChangePrimaryEmailUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body ChangePrimaryEmailFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+			}
+			err = ValidateChangePrimaryEmailFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+			}
+			return nil, NewChangePrimaryEmailFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body ChangePrimaryEmailFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+			}
+			err = ValidateChangePrimaryEmailFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+			}
+			return nil, NewChangePrimaryEmailFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body ChangePrimaryEmailFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+			}
+			err = ValidateChangePrimaryEmailFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+			}
+			return nil, NewChangePrimaryEmailFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body ChangePrimaryEmailFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+				}
+				err = ValidateChangePrimaryEmailFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+				}
+				return nil, NewChangePrimaryEmailFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+				}
+				return nil, NewChangePrimaryEmailUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "change_primary_email", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body ChangePrimaryEmailRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+				}
+				err = ValidateChangePrimaryEmailRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "change_primary_email", err)
+				}
+				return nil, NewChangePrimaryEmailRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "change_primary_email", err)
+				}
+				return nil, NewChangePrimaryEmailServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "change_primary_email", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "change_primary_email", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildUploadCurrentUserPictureRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "upload_current_user_picture" endpoint
+func (c *Client) BuildUploadCurrentUserPictureRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		body io.Reader
+	)
+	rd, ok := v.(*authservice.UploadCurrentUserPictureRequestData)
+	if !ok {
+		return nil, goahttp.ErrInvalidType("auth-service", "upload_current_user_picture", "authservice.UploadCurrentUserPictureRequestData", v)
+	}
+	body = rd.Body
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: UploadCurrentUserPictureAuthServicePath()}
+	req, err := http.NewRequest("PUT", u.String(), body)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "upload_current_user_picture", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeUploadCurrentUserPictureRequest returns an encoder for requests sent
+// to the auth-service upload_current_user_picture server.
+func EncodeUploadCurrentUserPictureRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		data, ok := v.(*authservice.UploadCurrentUserPictureRequestData)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "upload_current_user_picture", "*authservice.UploadCurrentUserPictureRequestData", v)
+		}
+		p := data.Payload
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		if p.AcceptLanguage != nil {
+			head := *p.AcceptLanguage
+			req.Header.Set("Accept-Language", head)
+		}
+		if p.ContentType != nil {
+			head := *p.ContentType
+			req.Header.Set("Content-Type", head)
+		}
+		return nil
+	}
+}
+
+// DecodeUploadCurrentUserPictureResponse returns a decoder for responses
+// returned by the auth-service upload_current_user_picture endpoint.
+// restoreBody controls whether the response body should be restored after
+// having been read.
+// DecodeUploadCurrentUserPictureResponse may return the following errors:
+//   - "FailedForbidden" (type *authservice.FailedForbiddenReply): http.StatusForbidden
+//   - "FailedNotFound" (type *authservice.FailedNotFoundReply): http.StatusNotFound
+//   - "Failed" (type *authservice.FailedReply): http.StatusUnprocessableEntity
+//   - "FailedUnauthorized" (type *authservice.FailedUnauthorizedReply): http.StatusUnauthorized
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - "Retryable" (type *authservice.RetryableReply): http.StatusServiceUnavailable
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeUploadCurrentUserPictureResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body UploadCurrentUserPictureResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+			}
+			err = ValidateUploadCurrentUserPictureResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+			}
+			res := NewUploadCurrentUserPictureUserDataReplyOK(&body)
+			return res, nil
+		case http.StatusForbidden:
+			var (
+				body UploadCurrentUserPictureFailedForbiddenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+			}
+			err = ValidateUploadCurrentUserPictureFailedForbiddenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+			}
+			return nil, NewUploadCurrentUserPictureFailedForbidden(&body)
+		case http.StatusNotFound:
+			var (
+				body UploadCurrentUserPictureFailedNotFoundResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+			}
+			err = ValidateUploadCurrentUserPictureFailedNotFoundResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+			}
+			return nil, NewUploadCurrentUserPictureFailedNotFound(&body)
+		case http.StatusUnprocessableEntity:
+			var (
+				body UploadCurrentUserPictureFailedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+			}
+			err = ValidateUploadCurrentUserPictureFailedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+			}
+			return nil, NewUploadCurrentUserPictureFailed(&body)
+		case http.StatusUnauthorized:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "FailedUnauthorized":
+				var (
+					body UploadCurrentUserPictureFailedUnauthorizedResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+				}
+				err = ValidateUploadCurrentUserPictureFailedUnauthorizedResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+				}
+				return nil, NewUploadCurrentUserPictureFailedUnauthorized(&body)
+			case "Unauthorized":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+				}
+				return nil, NewUploadCurrentUserPictureUnauthorized(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "upload_current_user_picture", resp.StatusCode, string(body))
+			}
+		case http.StatusServiceUnavailable:
+			en := resp.Header.Get("goa-error")
+			switch en {
+			case "Retryable":
+				var (
+					body UploadCurrentUserPictureRetryableResponseBody
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+				}
+				err = ValidateUploadCurrentUserPictureRetryableResponseBody(&body)
+				if err != nil {
+					return nil, goahttp.ErrValidationError("auth-service", "upload_current_user_picture", err)
+				}
+				return nil, NewUploadCurrentUserPictureRetryable(&body)
+			case "ServiceUnavailable":
+				var (
+					body string
+					err  error
+				)
+				err = decoder(resp).Decode(&body)
+				if err != nil {
+					return nil, goahttp.ErrDecodingError("auth-service", "upload_current_user_picture", err)
+				}
+				return nil, NewUploadCurrentUserPictureServiceUnavailable(body)
+			default:
+				body, _ := io.ReadAll(resp.Body)
+				return nil, goahttp.ErrInvalidResponse("auth-service", "upload_current_user_picture", resp.StatusCode, string(body))
+			}
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "upload_current_user_picture", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// // BuildUploadCurrentUserPictureStreamPayload creates a streaming endpoint
+// request payload from the method payload and the path to the file to be
+// streamed
+func BuildUploadCurrentUserPictureStreamPayload(payload any, fpath string) (*authservice.UploadCurrentUserPictureRequestData, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	return &authservice.UploadCurrentUserPictureRequestData{
+		Payload: payload.(*authservice.UploadCurrentUserPicturePayload),
+		Body:    f,
+	}, nil
+}
+
+// BuildResolveProfileShareRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "resolve_profile_share" endpoint
+func (c *Client) BuildResolveProfileShareRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		token string
+	)
+	{
+		p, ok := v.(*authservice.ResolveProfileSharePayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "resolve_profile_share", "*authservice.ResolveProfileSharePayload", v)
+		}
+		token = p.Token
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ResolveProfileShareAuthServicePath(token)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "resolve_profile_share", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeResolveProfileShareResponse returns a decoder for responses returned
+// by the auth-service resolve_profile_share endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+// DecodeResolveProfileShareResponse may return the following errors:
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - error: internal error
+func DecodeResolveProfileShareResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ResolveProfileShareResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "resolve_profile_share", err)
+			}
+			res := NewResolveProfileShareSharedProfileOK(&body)
+			return res, nil
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "resolve_profile_share", err)
+			}
+			return nil, NewResolveProfileShareNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "resolve_profile_share", err)
+			}
+			return nil, NewResolveProfileShareServiceUnavailable(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "resolve_profile_share", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildListEventSchemasRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "list_event_schemas" endpoint
+func (c *Client) BuildListEventSchemasRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListEventSchemasAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "list_event_schemas", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeListEventSchemasResponse returns a decoder for responses returned by
+// the auth-service list_event_schemas endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+func DecodeListEventSchemasResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ListEventSchemasResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_event_schemas", err)
+			}
+			for _, e := range body {
+				if e != nil {
+					if err2 := ValidateEventSchemaResponse(e); err2 != nil {
+						err = goa.MergeErrors(err, err2)
+					}
+				}
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "list_event_schemas", err)
+			}
+			res := NewListEventSchemasEventSchemaOK(body)
+			return res, nil
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "list_event_schemas", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetEventSchemaRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "get_event_schema" endpoint
+func (c *Client) BuildGetEventSchemaRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		subject string
+	)
+	{
+		p, ok := v.(*authservice.GetEventSchemaPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "get_event_schema", "*authservice.GetEventSchemaPayload", v)
+		}
+		subject = p.Subject
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetEventSchemaAuthServicePath(subject)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "get_event_schema", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// DecodeGetEventSchemaResponse returns a decoder for responses returned by the
+// auth-service get_event_schema endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeGetEventSchemaResponse may return the following errors:
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - error: internal error
+func DecodeGetEventSchemaResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetEventSchemaResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_event_schema", err)
+			}
+			err = ValidateGetEventSchemaResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_event_schema", err)
+			}
+			res := NewGetEventSchemaEventSchemaOK(&body)
+			return res, nil
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_event_schema", err)
+			}
+			return nil, NewGetEventSchemaNotFound(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "get_event_schema", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildListCallersRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "list_callers" endpoint
+func (c *Client) BuildListCallersRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListCallersAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "list_callers", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeListCallersRequest returns an encoder for requests sent to the
+// auth-service list_callers server.
+func EncodeListCallersRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.ListCallersPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "list_callers", "*authservice.ListCallersPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeListCallersResponse returns a decoder for responses returned by the
+// auth-service list_callers endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeListCallersResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeListCallersResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ListCallersResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			for _, e := range body {
+				if e != nil {
+					if err2 := ValidateCallerAllowlistEntryResponse(e); err2 != nil {
+						err = goa.MergeErrors(err, err2)
+					}
+				}
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "list_callers", err)
+			}
+			res := NewListCallersCallerAllowlistEntryOK(body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_callers", err)
+			}
+			return nil, NewListCallersUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "list_callers", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetCallerRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "get_caller" endpoint
+func (c *Client) BuildGetCallerRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		id string
+	)
+	{
+		p, ok := v.(*authservice.GetCallerPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "get_caller", "*authservice.GetCallerPayload", v)
+		}
+		id = p.ID
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetCallerAuthServicePath(id)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "get_caller", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetCallerRequest returns an encoder for requests sent to the
+// auth-service get_caller server.
+func EncodeGetCallerRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.GetCallerPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "get_caller", "*authservice.GetCallerPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeGetCallerResponse returns a decoder for responses returned by the
+// auth-service get_caller endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+// DecodeGetCallerResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeGetCallerResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetCallerResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			err = ValidateGetCallerResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_caller", err)
+			}
+			res := NewGetCallerCallerAllowlistEntryOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_caller", err)
+			}
+			return nil, NewGetCallerUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "get_caller", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPutCallerRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "put_caller" endpoint
+func (c *Client) BuildPutCallerRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		id string
+	)
+	{
+		p, ok := v.(*authservice.PutCallerPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "put_caller", "*authservice.PutCallerPayload", v)
+		}
+		id = p.ID
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PutCallerAuthServicePath(id)}
+	req, err := http.NewRequest("PUT", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "put_caller", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodePutCallerRequest returns an encoder for requests sent to the
+// auth-service put_caller server.
+func EncodePutCallerRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.PutCallerPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "put_caller", "*authservice.PutCallerPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		body := NewPutCallerRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "put_caller", err)
+		}
+		return nil
+	}
+}
+
+// DecodePutCallerResponse returns a decoder for responses returned by the
+// auth-service put_caller endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+// DecodePutCallerResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodePutCallerResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body PutCallerResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			err = ValidatePutCallerResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "put_caller", err)
+			}
+			res := NewPutCallerCallerAllowlistEntryOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "put_caller", err)
+			}
+			return nil, NewPutCallerUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "put_caller", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildDeleteCallerRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "delete_caller" endpoint
+func (c *Client) BuildDeleteCallerRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		id string
+	)
+	{
+		p, ok := v.(*authservice.DeleteCallerPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "delete_caller", "*authservice.DeleteCallerPayload", v)
+		}
+		id = p.ID
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: DeleteCallerAuthServicePath(id)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "delete_caller", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeDeleteCallerRequest returns an encoder for requests sent to the
+// auth-service delete_caller server.
+func EncodeDeleteCallerRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.DeleteCallerPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "delete_caller", "*authservice.DeleteCallerPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeDeleteCallerResponse returns a decoder for responses returned by the
+// auth-service delete_caller endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeDeleteCallerResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeDeleteCallerResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "delete_caller", err)
+			}
+			return nil, NewDeleteCallerUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "delete_caller", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetAdminUserMetadataRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "get_admin_user_metadata" endpoint
+func (c *Client) BuildGetAdminUserMetadataRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		user string
+	)
+	{
+		p, ok := v.(*authservice.GetAdminUserMetadataPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "get_admin_user_metadata", "*authservice.GetAdminUserMetadataPayload", v)
+		}
+		user = p.User
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetAdminUserMetadataAuthServicePath(user)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "get_admin_user_metadata", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetAdminUserMetadataRequest returns an encoder for requests sent to
+// the auth-service get_admin_user_metadata server.
+func EncodeGetAdminUserMetadataRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.GetAdminUserMetadataPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "get_admin_user_metadata", "*authservice.GetAdminUserMetadataPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeGetAdminUserMetadataResponse returns a decoder for responses returned
+// by the auth-service get_admin_user_metadata endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+// DecodeGetAdminUserMetadataResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeGetAdminUserMetadataResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetAdminUserMetadataResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			err = ValidateGetAdminUserMetadataResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_admin_user_metadata", err)
+			}
+			res := NewGetAdminUserMetadataAdminUserMetadataOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_admin_user_metadata", err)
+			}
+			return nil, NewGetAdminUserMetadataUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "get_admin_user_metadata", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildIssueEmailBackupCodesRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "issue_email_backup_codes" endpoint
+func (c *Client) BuildIssueEmailBackupCodesRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: IssueEmailBackupCodesAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "issue_email_backup_codes", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeIssueEmailBackupCodesRequest returns an encoder for requests sent to
+// the auth-service issue_email_backup_codes server.
+func EncodeIssueEmailBackupCodesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.IssueEmailBackupCodesPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "issue_email_backup_codes", "*authservice.IssueEmailBackupCodesPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		body := NewIssueEmailBackupCodesRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("auth-service", "issue_email_backup_codes", err)
+		}
+		return nil
+	}
+}
+
+// DecodeIssueEmailBackupCodesResponse returns a decoder for responses returned
+// by the auth-service issue_email_backup_codes endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+// DecodeIssueEmailBackupCodesResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeIssueEmailBackupCodesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusCreated:
+			var (
+				body IssueEmailBackupCodesResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			err = ValidateIssueEmailBackupCodesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "issue_email_backup_codes", err)
+			}
+			res := NewIssueEmailBackupCodesEmailBackupCodesCreated(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "issue_email_backup_codes", err)
+			}
+			return nil, NewIssueEmailBackupCodesUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "issue_email_backup_codes", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildPurgeUserCacheRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "purge_user_cache" endpoint
+func (c *Client) BuildPurgeUserCacheRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		user string
+	)
+	{
+		p, ok := v.(*authservice.PurgeUserCachePayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "purge_user_cache", "*authservice.PurgeUserCachePayload", v)
+		}
+		user = p.User
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: PurgeUserCacheAuthServicePath(user)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "purge_user_cache", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodePurgeUserCacheRequest returns an encoder for requests sent to the
+// auth-service purge_user_cache server.
+func EncodePurgeUserCacheRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.PurgeUserCachePayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "purge_user_cache", "*authservice.PurgeUserCachePayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodePurgeUserCacheResponse returns a decoder for responses returned by the
+// auth-service purge_user_cache endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodePurgeUserCacheResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodePurgeUserCacheResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "purge_user_cache", err)
+			}
+			return nil, NewPurgeUserCacheUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "purge_user_cache", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildListWebhookDeliveriesRequest instantiates a HTTP request object with
+// method and path set to call the "auth-service" service
+// "list_webhook_deliveries" endpoint
+func (c *Client) BuildListWebhookDeliveriesRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListWebhookDeliveriesAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "list_webhook_deliveries", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeListWebhookDeliveriesRequest returns an encoder for requests sent to
+// the auth-service list_webhook_deliveries server.
+func EncodeListWebhookDeliveriesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.ListWebhookDeliveriesPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "list_webhook_deliveries", "*authservice.ListWebhookDeliveriesPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeListWebhookDeliveriesResponse returns a decoder for responses returned
+// by the auth-service list_webhook_deliveries endpoint. restoreBody controls
+// whether the response body should be restored after having been read.
+// DecodeListWebhookDeliveriesResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeListWebhookDeliveriesResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body ListWebhookDeliveriesResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			err = ValidateListWebhookDeliveriesResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "list_webhook_deliveries", err)
+			}
+			res := NewListWebhookDeliveriesWebhookDeliveriesOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "list_webhook_deliveries", err)
+			}
+			return nil, NewListWebhookDeliveriesUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "list_webhook_deliveries", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildGetSupportBundleRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "get_support_bundle" endpoint
+func (c *Client) BuildGetSupportBundleRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: GetSupportBundleAuthServicePath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "get_support_bundle", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeGetSupportBundleRequest returns an encoder for requests sent to the
+// auth-service get_support_bundle server.
+func EncodeGetSupportBundleRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.GetSupportBundlePayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "get_support_bundle", "*authservice.GetSupportBundlePayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeGetSupportBundleResponse returns a decoder for responses returned by
+// the auth-service get_support_bundle endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeGetSupportBundleResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeGetSupportBundleResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body GetSupportBundleResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			err = ValidateGetSupportBundleResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "get_support_bundle", err)
+			}
+			res := NewGetSupportBundleSupportBundleOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "get_support_bundle", err)
+			}
+			return nil, NewGetSupportBundleUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "get_support_bundle", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildRenewM2mTokenRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "renew_m2m_token" endpoint
+func (c *Client) BuildRenewM2mTokenRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: RenewM2mTokenAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "renew_m2m_token", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeRenewM2mTokenRequest returns an encoder for requests sent to the
+// auth-service renew_m2m_token server.
+func EncodeRenewM2mTokenRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.RenewM2mTokenPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "renew_m2m_token", "*authservice.RenewM2mTokenPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeRenewM2mTokenResponse returns a decoder for responses returned by the
+// auth-service renew_m2m_token endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeRenewM2mTokenResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeRenewM2mTokenResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body RenewM2mTokenResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			err = ValidateRenewM2mTokenResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "renew_m2m_token", err)
+			}
+			res := NewRenewM2mTokenRunbookResultOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "renew_m2m_token", err)
+			}
+			return nil, NewRenewM2mTokenUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "renew_m2m_token", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildRefreshJwksRequest instantiates a HTTP request object with method and
+// path set to call the "auth-service" service "refresh_jwks" endpoint
+func (c *Client) BuildRefreshJwksRequest(ctx context.Context, v any) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: RefreshJwksAuthServicePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "refresh_jwks", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeRefreshJwksRequest returns an encoder for requests sent to the
+// auth-service refresh_jwks server.
+func EncodeRefreshJwksRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.RefreshJwksPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "refresh_jwks", "*authservice.RefreshJwksPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeRefreshJwksResponse returns a decoder for responses returned by the
+// auth-service refresh_jwks endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+// DecodeRefreshJwksResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeRefreshJwksResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body RefreshJwksResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			err = ValidateRefreshJwksResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "refresh_jwks", err)
+			}
+			res := NewRefreshJwksRunbookResultOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "refresh_jwks", err)
+			}
+			return nil, NewRefreshJwksUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "refresh_jwks", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// BuildRebuildEmailIndexRequest instantiates a HTTP request object with method
+// and path set to call the "auth-service" service "rebuild_email_index"
+// endpoint
+func (c *Client) BuildRebuildEmailIndexRequest(ctx context.Context, v any) (*http.Request, error) {
+	var (
+		user string
+	)
+	{
+		p, ok := v.(*authservice.RebuildEmailIndexPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("auth-service", "rebuild_email_index", "*authservice.RebuildEmailIndexPayload", v)
+		}
+		user = p.User
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: RebuildEmailIndexAuthServicePath(user)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("auth-service", "rebuild_email_index", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+
+// EncodeRebuildEmailIndexRequest returns an encoder for requests sent to the
+// auth-service rebuild_email_index server.
+func EncodeRebuildEmailIndexRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, any) error {
+	return func(req *http.Request, v any) error {
+		p, ok := v.(*authservice.RebuildEmailIndexPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("auth-service", "rebuild_email_index", "*authservice.RebuildEmailIndexPayload", v)
+		}
+		if p.Authorization != nil {
+			head := *p.Authorization
+			req.Header.Set("Authorization", head)
+		}
+		return nil
+	}
+}
+
+// DecodeRebuildEmailIndexResponse returns a decoder for responses returned by
+// the auth-service rebuild_email_index endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+// DecodeRebuildEmailIndexResponse may return the following errors:
+//   - "BadRequest" (type authservice.BadRequest): http.StatusBadRequest
+//   - "Conflict" (type authservice.Conflict): http.StatusConflict
+//   - "Forbidden" (type authservice.Forbidden): http.StatusForbidden
+//   - "NotFound" (type authservice.NotFound): http.StatusNotFound
+//   - "ServiceUnavailable" (type authservice.ServiceUnavailable): http.StatusServiceUnavailable
+//   - "Unauthorized" (type authservice.Unauthorized): http.StatusUnauthorized
+//   - error: internal error
+func DecodeRebuildEmailIndexResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (any, error) {
+	return func(resp *http.Response) (any, error) {
+		if restoreBody {
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = io.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body RebuildEmailIndexResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			err = ValidateRebuildEmailIndexResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("auth-service", "rebuild_email_index", err)
+			}
+			res := NewRebuildEmailIndexRunbookResultOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexBadRequest(body)
+		case http.StatusConflict:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexConflict(body)
+		case http.StatusForbidden:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexForbidden(body)
+		case http.StatusNotFound:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexNotFound(body)
+		case http.StatusServiceUnavailable:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexServiceUnavailable(body)
+		case http.StatusUnauthorized:
+			var (
+				body string
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("auth-service", "rebuild_email_index", err)
+			}
+			return nil, NewRebuildEmailIndexUnauthorized(body)
+		default:
+			body, _ := io.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("auth-service", "rebuild_email_index", resp.StatusCode, string(body))
+		}
+	}
+}
+
+// unmarshalUserInfoAddressResponseBodyToAuthserviceUserInfoAddress builds a
+// value of type *authservice.UserInfoAddress from a value of type
+// *UserInfoAddressResponseBody.
+func unmarshalUserInfoAddressResponseBodyToAuthserviceUserInfoAddress(v *UserInfoAddressResponseBody) *authservice.UserInfoAddress {
+	if v == nil {
+		return nil
+	}
+	res := &authservice.UserInfoAddress{
+		StreetAddress: v.StreetAddress,
+		Locality:      v.Locality,
+		Region:        v.Region,
+		PostalCode:    v.PostalCode,
+		Country:       v.Country,
+	}
+
+	return res
+}
+
+// unmarshalEventSchemaResponseToAuthserviceEventSchema builds a value of type
+// *authservice.EventSchema from a value of type *EventSchemaResponse.
+func unmarshalEventSchemaResponseToAuthserviceEventSchema(v *EventSchemaResponse) *authservice.EventSchema {
+	res := &authservice.EventSchema{
+		Subject: *v.Subject,
+		Title:   *v.Title,
+		Schema:  v.Schema,
+	}
+
+	return res
+}
+
+// unmarshalCallerAllowlistEntryResponseToAuthserviceCallerAllowlistEntry
+// builds a value of type *authservice.CallerAllowlistEntry from a value of
+// type *CallerAllowlistEntryResponse.
+func unmarshalCallerAllowlistEntryResponseToAuthserviceCallerAllowlistEntry(v *CallerAllowlistEntryResponse) *authservice.CallerAllowlistEntry {
+	res := &authservice.CallerAllowlistEntry{
+		ID:          *v.ID,
+		Description: v.Description,
+		CreatedAt:   *v.CreatedAt,
+		UpdatedAt:   *v.UpdatedAt,
+	}
+	res.Capabilities = make([]string, len(v.Capabilities))
+	for i, val := range v.Capabilities {
+		res.Capabilities[i] = val
+	}
+
+	return res
+}
+
+// unmarshalFieldProvenanceResponseBodyToAuthserviceFieldProvenance builds a
+// value of type *authservice.FieldProvenance from a value of type
+// *FieldProvenanceResponseBody.
+func unmarshalFieldProvenanceResponseBodyToAuthserviceFieldProvenance(v *FieldProvenanceResponseBody) *authservice.FieldProvenance {
+	res := &authservice.FieldProvenance{
+		Source:    *v.Source,
+		Actor:     v.Actor,
+		UpdatedAt: *v.UpdatedAt,
+	}
+
+	return res
+}
+
+// unmarshalWebhookDeliveryResponseBodyToAuthserviceWebhookDelivery builds a
+// value of type *authservice.WebhookDelivery from a value of type
+// *WebhookDeliveryResponseBody.
+func unmarshalWebhookDeliveryResponseBodyToAuthserviceWebhookDelivery(v *WebhookDeliveryResponseBody) *authservice.WebhookDelivery {
+	res := &authservice.WebhookDelivery{
+		Action:      *v.Action,
+		StatusCode:  v.StatusCode,
+		Error:       v.Error,
+		DurationMs:  *v.DurationMs,
+		DeliveredAt: *v.DeliveredAt,
+	}
+
+	return res
+}
+
+// unmarshalSupportBundleBuildResponseBodyToAuthserviceSupportBundleBuild
+// builds a value of type *authservice.SupportBundleBuild from a value of type
+// *SupportBundleBuildResponseBody.
+func unmarshalSupportBundleBuildResponseBodyToAuthserviceSupportBundleBuild(v *SupportBundleBuildResponseBody) *authservice.SupportBundleBuild {
+	res := &authservice.SupportBundleBuild{
+		Version:   *v.Version,
+		BuildTime: *v.BuildTime,
+		GitCommit: *v.GitCommit,
+		GoVersion: *v.GoVersion,
+	}
+
+	return res
+}
+
+// unmarshalSupportBundleConfigErrorResponseBodyToAuthserviceSupportBundleConfigError
+// builds a value of type *authservice.SupportBundleConfigError from a value of
+// type *SupportBundleConfigErrorResponseBody.
+func unmarshalSupportBundleConfigErrorResponseBodyToAuthserviceSupportBundleConfigError(v *SupportBundleConfigErrorResponseBody) *authservice.SupportBundleConfigError {
+	res := &authservice.SupportBundleConfigError{
+		Component: *v.Component,
+		Key:       v.Key,
+		Message:   *v.Message,
+	}
+
+	return res
+}
+
+// unmarshalSupportBundleProviderResponseBodyToAuthserviceSupportBundleProvider
+// builds a value of type *authservice.SupportBundleProvider from a value of
+// type *SupportBundleProviderResponseBody.
+func unmarshalSupportBundleProviderResponseBodyToAuthserviceSupportBundleProvider(v *SupportBundleProviderResponseBody) *authservice.SupportBundleProvider {
+	res := &authservice.SupportBundleProvider{
+		Provider:      *v.Provider,
+		Samples:       *v.Samples,
+		Failures:      *v.Failures,
+		ErrorRate:     *v.ErrorRate,
+		P95Ms:         *v.P95Ms,
+		CircuitState:  *v.CircuitState,
+		LastFailureAt: v.LastFailureAt,
+	}
+
+	return res
+}
+
+// unmarshalSupportBundleUserCacheResponseBodyToAuthserviceSupportBundleUserCache
+// builds a value of type *authservice.SupportBundleUserCache from a value of
+// type *SupportBundleUserCacheResponseBody.
+func unmarshalSupportBundleUserCacheResponseBodyToAuthserviceSupportBundleUserCache(v *SupportBundleUserCacheResponseBody) *authservice.SupportBundleUserCache {
+	if v == nil {
+		return nil
+	}
+	res := &authservice.SupportBundleUserCache{
+		Kind:    *v.Kind,
+		Hits:    *v.Hits,
+		Misses:  *v.Misses,
+		Entries: v.Entries,
+	}
+
+	return res
+}
+
+// unmarshalSupportBundleSyncStatusResponseBodyToAuthserviceSupportBundleSyncStatus
+// builds a value of type *authservice.SupportBundleSyncStatus from a value of
+// type *SupportBundleSyncStatusResponseBody.
+func unmarshalSupportBundleSyncStatusResponseBodyToAuthserviceSupportBundleSyncStatus(v *SupportBundleSyncStatusResponseBody) *authservice.SupportBundleSyncStatus {
+	if v == nil {
+		return nil
+	}
+	res := &authservice.SupportBundleSyncStatus{
+		Succeeded: *v.Succeeded,
+		Changed:   *v.Changed,
+		Error:     v.Error,
+		SyncedAt:  *v.SyncedAt,
+	}
+
+	return res
+}
+
+// unmarshalRunbookStepResponseBodyToAuthserviceRunbookStep builds a value of
+// type *authservice.RunbookStep from a value of type *RunbookStepResponseBody.
+func unmarshalRunbookStepResponseBodyToAuthserviceRunbookStep(v *RunbookStepResponseBody) *authservice.RunbookStep {
+	res := &authservice.RunbookStep{
+		Name:    *v.Name,
+		Outcome: *v.Outcome,
+		Detail:  v.Detail,
+	}
+
+	return res
+}
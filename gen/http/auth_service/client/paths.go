@@ -7,6 +7,10 @@
 
 package client
 
+import (
+	"fmt"
+)
+
 // LivezAuthServicePath returns the URL path to the auth-service service livez HTTP endpoint.
 func LivezAuthServicePath() string {
 	return "/livez"
@@ -16,3 +20,123 @@ func LivezAuthServicePath() string {
 func ReadyzAuthServicePath() string {
 	return "/readyz"
 }
+
+// UserinfoAuthServicePath returns the URL path to the auth-service service userinfo HTTP endpoint.
+func UserinfoAuthServicePath() string {
+	return "/userinfo"
+}
+
+// ProfileEventsAuthServicePath returns the URL path to the auth-service service profile_events HTTP endpoint.
+func ProfileEventsAuthServicePath() string {
+	return "/userinfo/events"
+}
+
+// AdminEventsAuthServicePath returns the URL path to the auth-service service admin_events HTTP endpoint.
+func AdminEventsAuthServicePath() string {
+	return "/admin/events"
+}
+
+// GetUserMetadataAuthServicePath returns the URL path to the auth-service service get_user_metadata HTTP endpoint.
+func GetUserMetadataAuthServicePath(sub string) string {
+	return fmt.Sprintf("/users/%v/metadata", sub)
+}
+
+// UpdateCurrentUserMetadataAuthServicePath returns the URL path to the auth-service service update_current_user_metadata HTTP endpoint.
+func UpdateCurrentUserMetadataAuthServicePath() string {
+	return "/users/current/metadata"
+}
+
+// StartEmailLinkingAuthServicePath returns the URL path to the auth-service service start_email_linking HTTP endpoint.
+func StartEmailLinkingAuthServicePath() string {
+	return "/users/current/email-linking"
+}
+
+// VerifyEmailLinkingAuthServicePath returns the URL path to the auth-service service verify_email_linking HTTP endpoint.
+func VerifyEmailLinkingAuthServicePath() string {
+	return "/users/current/email-linking/verify"
+}
+
+// ChangePrimaryEmailAuthServicePath returns the URL path to the auth-service service change_primary_email HTTP endpoint.
+func ChangePrimaryEmailAuthServicePath() string {
+	return "/users/current/primary-email"
+}
+
+// UploadCurrentUserPictureAuthServicePath returns the URL path to the auth-service service upload_current_user_picture HTTP endpoint.
+func UploadCurrentUserPictureAuthServicePath() string {
+	return "/users/current/picture"
+}
+
+// ResolveProfileShareAuthServicePath returns the URL path to the auth-service service resolve_profile_share HTTP endpoint.
+func ResolveProfileShareAuthServicePath(token string) string {
+	return fmt.Sprintf("/profiles/shared/%v", token)
+}
+
+// ListEventSchemasAuthServicePath returns the URL path to the auth-service service list_event_schemas HTTP endpoint.
+func ListEventSchemasAuthServicePath() string {
+	return "/events/schemas"
+}
+
+// GetEventSchemaAuthServicePath returns the URL path to the auth-service service get_event_schema HTTP endpoint.
+func GetEventSchemaAuthServicePath(subject string) string {
+	return fmt.Sprintf("/events/schemas/%v", subject)
+}
+
+// ListCallersAuthServicePath returns the URL path to the auth-service service list_callers HTTP endpoint.
+func ListCallersAuthServicePath() string {
+	return "/admin/callers"
+}
+
+// GetCallerAuthServicePath returns the URL path to the auth-service service get_caller HTTP endpoint.
+func GetCallerAuthServicePath(id string) string {
+	return fmt.Sprintf("/admin/callers/%v", id)
+}
+
+// PutCallerAuthServicePath returns the URL path to the auth-service service put_caller HTTP endpoint.
+func PutCallerAuthServicePath(id string) string {
+	return fmt.Sprintf("/admin/callers/%v", id)
+}
+
+// DeleteCallerAuthServicePath returns the URL path to the auth-service service delete_caller HTTP endpoint.
+func DeleteCallerAuthServicePath(id string) string {
+	return fmt.Sprintf("/admin/callers/%v", id)
+}
+
+// GetAdminUserMetadataAuthServicePath returns the URL path to the auth-service service get_admin_user_metadata HTTP endpoint.
+func GetAdminUserMetadataAuthServicePath(user string) string {
+	return fmt.Sprintf("/admin/users/%v/metadata", user)
+}
+
+// IssueEmailBackupCodesAuthServicePath returns the URL path to the auth-service service issue_email_backup_codes HTTP endpoint.
+func IssueEmailBackupCodesAuthServicePath() string {
+	return "/admin/email-backup-codes"
+}
+
+// PurgeUserCacheAuthServicePath returns the URL path to the auth-service service purge_user_cache HTTP endpoint.
+func PurgeUserCacheAuthServicePath(user string) string {
+	return fmt.Sprintf("/admin/users/%v/cache/purge", user)
+}
+
+// ListWebhookDeliveriesAuthServicePath returns the URL path to the auth-service service list_webhook_deliveries HTTP endpoint.
+func ListWebhookDeliveriesAuthServicePath() string {
+	return "/admin/webhook-deliveries"
+}
+
+// GetSupportBundleAuthServicePath returns the URL path to the auth-service service get_support_bundle HTTP endpoint.
+func GetSupportBundleAuthServicePath() string {
+	return "/admin/support-bundle"
+}
+
+// RenewM2mTokenAuthServicePath returns the URL path to the auth-service service renew_m2m_token HTTP endpoint.
+func RenewM2mTokenAuthServicePath() string {
+	return "/admin/runbook/m2m-token/renew"
+}
+
+// RefreshJwksAuthServicePath returns the URL path to the auth-service service refresh_jwks HTTP endpoint.
+func RefreshJwksAuthServicePath() string {
+	return "/admin/runbook/jwks/refresh"
+}
+
+// RebuildEmailIndexAuthServicePath returns the URL path to the auth-service service rebuild_email_index HTTP endpoint.
+func RebuildEmailIndexAuthServicePath(user string) string {
+	return fmt.Sprintf("/admin/users/%v/email-index/rebuild", user)
+}
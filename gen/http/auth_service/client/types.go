@@ -9,8 +9,1298 @@ package client
 
 import (
 	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	goa "goa.design/goa/v3/pkg"
 )
 
+// PutCallerRequestBody is the type of the "auth-service" service "put_caller"
+// endpoint HTTP request body.
+type PutCallerRequestBody struct {
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service
+	Capabilities []string `form:"capabilities" json:"capabilities" xml:"capabilities"`
+}
+
+// IssueEmailBackupCodesRequestBody is the type of the "auth-service" service
+// "issue_email_backup_codes" endpoint HTTP request body.
+type IssueEmailBackupCodesRequestBody struct {
+	// Email to verify
+	Email string `form:"email" json:"email" xml:"email"`
+}
+
+// UserinfoResponseBody is the type of the "auth-service" service "userinfo"
+// endpoint HTTP response body.
+type UserinfoResponseBody struct {
+	// Subject identifier
+	Sub *string `form:"sub,omitempty" json:"sub,omitempty" xml:"sub,omitempty"`
+	// Full name
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Given name
+	GivenName *string `form:"given_name,omitempty" json:"given_name,omitempty" xml:"given_name,omitempty"`
+	// Family name
+	FamilyName *string `form:"family_name,omitempty" json:"family_name,omitempty" xml:"family_name,omitempty"`
+	// Username
+	PreferredUsername *string `form:"preferred_username,omitempty" json:"preferred_username,omitempty" xml:"preferred_username,omitempty"`
+	// Profile picture URL
+	Picture *string `form:"picture,omitempty" json:"picture,omitempty" xml:"picture,omitempty"`
+	// Primary email
+	Email *string `form:"email,omitempty" json:"email,omitempty" xml:"email,omitempty"`
+	// Time zone
+	Zoneinfo *string `form:"zoneinfo,omitempty" json:"zoneinfo,omitempty" xml:"zoneinfo,omitempty"`
+	// Phone number
+	PhoneNumber *string `form:"phone_number,omitempty" json:"phone_number,omitempty" xml:"phone_number,omitempty"`
+	// Postal address
+	Address *UserInfoAddressResponseBody `form:"address,omitempty" json:"address,omitempty" xml:"address,omitempty"`
+}
+
+// ProfileEventsResponseBody is the type of the "auth-service" service
+// "profile_events" endpoint HTTP response body.
+type ProfileEventsResponseBody struct {
+	// Subject identifier of the user
+	Sub *string `form:"sub,omitempty" json:"sub,omitempty" xml:"sub,omitempty"`
+	// Operation that changed the profile
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty" xml:"reason,omitempty"`
+	// Time of the change
+	ChangedAt *string `form:"changed_at,omitempty" json:"changed_at,omitempty" xml:"changed_at,omitempty"`
+}
+
+// AdminEventsResponseBody is the type of the "auth-service" service
+// "admin_events" endpoint HTTP response body.
+type AdminEventsResponseBody struct {
+	// Kind of the event
+	Kind *string `form:"kind,omitempty" json:"kind,omitempty" xml:"kind,omitempty"`
+	// Time the event was bridged
+	At *string `form:"at,omitempty" json:"at,omitempty" xml:"at,omitempty"`
+	// Event data, the user identifiers are redacted
+	Data map[string]any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Number of events dropped before this one because the client was behind
+	Dropped *int `form:"dropped,omitempty" json:"dropped,omitempty" xml:"dropped,omitempty"`
+}
+
+// GetUserMetadataResponseBody is the type of the "auth-service" service
+// "get_user_metadata" endpoint HTTP response body.
+type GetUserMetadataResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataResponseBody is the type of the "auth-service"
+// service "update_current_user_metadata" endpoint HTTP response body.
+type UpdateCurrentUserMetadataResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingResponseBody is the type of the "auth-service" service
+// "start_email_linking" endpoint HTTP response body.
+type StartEmailLinkingResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingResponseBody is the type of the "auth-service" service
+// "verify_email_linking" endpoint HTTP response body.
+type VerifyEmailLinkingResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailResponseBody is the type of the "auth-service" service
+// "change_primary_email" endpoint HTTP response body.
+type ChangePrimaryEmailResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureResponseBody is the type of the "auth-service"
+// service "upload_current_user_picture" endpoint HTTP response body.
+type UploadCurrentUserPictureResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ResolveProfileShareResponseBody is the type of the "auth-service" service
+// "resolve_profile_share" endpoint HTTP response body.
+type ResolveProfileShareResponseBody struct {
+	// Username
+	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Full name
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Profile picture URL
+	Picture *string `form:"picture,omitempty" json:"picture,omitempty" xml:"picture,omitempty"`
+	// Job title
+	JobTitle *string `form:"job_title,omitempty" json:"job_title,omitempty" xml:"job_title,omitempty"`
+	// Organization
+	Organization *string `form:"organization,omitempty" json:"organization,omitempty" xml:"organization,omitempty"`
+	// Whether the organization matches a verified email domain
+	OrganizationVerified *bool `form:"organization_verified,omitempty" json:"organization_verified,omitempty" xml:"organization_verified,omitempty"`
+}
+
+// ListEventSchemasResponseBody is the type of the "auth-service" service
+// "list_event_schemas" endpoint HTTP response body.
+type ListEventSchemasResponseBody []*EventSchemaResponse
+
+// GetEventSchemaResponseBody is the type of the "auth-service" service
+// "get_event_schema" endpoint HTTP response body.
+type GetEventSchemaResponseBody struct {
+	// NATS subject the events are published on
+	Subject *string `form:"subject,omitempty" json:"subject,omitempty" xml:"subject,omitempty"`
+	// Name of the event type
+	Title *string `form:"title,omitempty" json:"title,omitempty" xml:"title,omitempty"`
+	// JSON Schema (draft 2020-12) of the events
+	Schema any `form:"schema,omitempty" json:"schema,omitempty" xml:"schema,omitempty"`
+}
+
+// ListCallersResponseBody is the type of the "auth-service" service
+// "list_callers" endpoint HTTP response body.
+type ListCallersResponseBody []*CallerAllowlistEntryResponse
+
+// GetCallerResponseBody is the type of the "auth-service" service "get_caller"
+// endpoint HTTP response body.
+type GetCallerResponseBody struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities,omitempty" json:"capabilities,omitempty" xml:"capabilities,omitempty"`
+	// Time the entry was created
+	CreatedAt *string `form:"created_at,omitempty" json:"created_at,omitempty" xml:"created_at,omitempty"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt *string `form:"updated_at,omitempty" json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// PutCallerResponseBody is the type of the "auth-service" service "put_caller"
+// endpoint HTTP response body.
+type PutCallerResponseBody struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities,omitempty" json:"capabilities,omitempty" xml:"capabilities,omitempty"`
+	// Time the entry was created
+	CreatedAt *string `form:"created_at,omitempty" json:"created_at,omitempty" xml:"created_at,omitempty"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt *string `form:"updated_at,omitempty" json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// GetAdminUserMetadataResponseBody is the type of the "auth-service" service
+// "get_admin_user_metadata" endpoint HTTP response body.
+type GetAdminUserMetadataResponseBody struct {
+	// Identifier of the user
+	UserID *string `form:"user_id,omitempty" json:"user_id,omitempty" xml:"user_id,omitempty"`
+	// Username of the user
+	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Metadata of the user
+	UserMetadata map[string]any `form:"user_metadata,omitempty" json:"user_metadata,omitempty" xml:"user_metadata,omitempty"`
+	// Provenance of the metadata fields, the fields without provenance were set
+	// before it was tracked or by the identity provider
+	Provenance map[string]*FieldProvenanceResponseBody `form:"provenance,omitempty" json:"provenance,omitempty" xml:"provenance,omitempty"`
+}
+
+// IssueEmailBackupCodesResponseBody is the type of the "auth-service" service
+// "issue_email_backup_codes" endpoint HTTP response body.
+type IssueEmailBackupCodesResponseBody struct {
+	// Email the codes verify
+	Email *string `form:"email,omitempty" json:"email,omitempty" xml:"email,omitempty"`
+	// One-time codes, sent as the OTP of the email verification. They are only
+	// returned here.
+	Codes []string `form:"codes,omitempty" json:"codes,omitempty" xml:"codes,omitempty"`
+	// Time the codes expire
+	ExpiresAt *string `form:"expires_at,omitempty" json:"expires_at,omitempty" xml:"expires_at,omitempty"`
+}
+
+// ListWebhookDeliveriesResponseBody is the type of the "auth-service" service
+// "list_webhook_deliveries" endpoint HTTP response body.
+type ListWebhookDeliveriesResponseBody struct {
+	// Last deliveries, the most recent first
+	Deliveries []*WebhookDeliveryResponseBody `form:"deliveries,omitempty" json:"deliveries,omitempty" xml:"deliveries,omitempty"`
+}
+
+// GetSupportBundleResponseBody is the type of the "auth-service" service
+// "get_support_bundle" endpoint HTTP response body.
+type GetSupportBundleResponseBody struct {
+	// Time the bundle was generated
+	GeneratedAt *string `form:"generated_at,omitempty" json:"generated_at,omitempty" xml:"generated_at,omitempty"`
+	// Build of the replica
+	Build *SupportBundleBuildResponseBody `form:"build,omitempty" json:"build,omitempty" xml:"build,omitempty"`
+	// Effective configuration from the environment, the secrets are redacted
+	Config map[string]string `form:"config,omitempty" json:"config,omitempty" xml:"config,omitempty"`
+	// Problems found in the configuration
+	ConfigErrors []*SupportBundleConfigErrorResponseBody `form:"config_errors,omitempty" json:"config_errors,omitempty" xml:"config_errors,omitempty"`
+	// Recent health of the upstream identity providers
+	Providers []*SupportBundleProviderResponseBody `form:"providers,omitempty" json:"providers,omitempty" xml:"providers,omitempty"`
+	// Usage of the users cache, when enabled
+	UserCache *SupportBundleUserCacheResponseBody `form:"user_cache,omitempty" json:"user_cache,omitempty" xml:"user_cache,omitempty"`
+	// Last sync of the Authelia users seen by the replica, if any
+	LastSync *SupportBundleSyncStatusResponseBody `form:"last_sync,omitempty" json:"last_sync,omitempty" xml:"last_sync,omitempty"`
+}
+
+// RenewM2mTokenResponseBody is the type of the "auth-service" service
+// "renew_m2m_token" endpoint HTTP response body.
+type RenewM2mTokenResponseBody struct {
+	// Audit action of the operation
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty" xml:"operation,omitempty"`
+	// Target of the operation
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+	// Outcome of the operation
+	Outcome *string `form:"outcome,omitempty" json:"outcome,omitempty" xml:"outcome,omitempty"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps,omitempty" json:"steps,omitempty" xml:"steps,omitempty"`
+	// Time of the operation
+	At *string `form:"at,omitempty" json:"at,omitempty" xml:"at,omitempty"`
+}
+
+// RefreshJwksResponseBody is the type of the "auth-service" service
+// "refresh_jwks" endpoint HTTP response body.
+type RefreshJwksResponseBody struct {
+	// Audit action of the operation
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty" xml:"operation,omitempty"`
+	// Target of the operation
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+	// Outcome of the operation
+	Outcome *string `form:"outcome,omitempty" json:"outcome,omitempty" xml:"outcome,omitempty"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps,omitempty" json:"steps,omitempty" xml:"steps,omitempty"`
+	// Time of the operation
+	At *string `form:"at,omitempty" json:"at,omitempty" xml:"at,omitempty"`
+}
+
+// RebuildEmailIndexResponseBody is the type of the "auth-service" service
+// "rebuild_email_index" endpoint HTTP response body.
+type RebuildEmailIndexResponseBody struct {
+	// Audit action of the operation
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty" xml:"operation,omitempty"`
+	// Target of the operation
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
+	// Outcome of the operation
+	Outcome *string `form:"outcome,omitempty" json:"outcome,omitempty" xml:"outcome,omitempty"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps,omitempty" json:"steps,omitempty" xml:"steps,omitempty"`
+	// Time of the operation
+	At *string `form:"at,omitempty" json:"at,omitempty" xml:"at,omitempty"`
+}
+
+// GetUserMetadataFailedForbiddenResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the
+// "FailedForbidden" error.
+type GetUserMetadataFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedNotFoundResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the
+// "FailedNotFound" error.
+type GetUserMetadataFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedResponseBody is the type of the "auth-service" service
+// "get_user_metadata" endpoint HTTP response body for the "Failed" error.
+type GetUserMetadataFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "get_user_metadata" endpoint HTTP response body for
+// the "FailedUnauthorized" error.
+type GetUserMetadataFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataRetryableResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the "Retryable"
+// error.
+type GetUserMetadataRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedForbiddenResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedForbidden" error.
+type UpdateCurrentUserMetadataFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedNotFoundResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedNotFound" error.
+type UpdateCurrentUserMetadataFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "Failed" error.
+type UpdateCurrentUserMetadataFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedUnauthorized" error.
+type UpdateCurrentUserMetadataFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataRetryableResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "Retryable" error.
+type UpdateCurrentUserMetadataRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedForbiddenResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedForbidden" error.
+type StartEmailLinkingFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedNotFoundResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedNotFound" error.
+type StartEmailLinkingFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedResponseBody is the type of the "auth-service"
+// service "start_email_linking" endpoint HTTP response body for the "Failed"
+// error.
+type StartEmailLinkingFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedUnauthorized" error.
+type StartEmailLinkingFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingRetryableResponseBody is the type of the "auth-service"
+// service "start_email_linking" endpoint HTTP response body for the
+// "Retryable" error.
+type StartEmailLinkingRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedForbiddenResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedForbidden" error.
+type VerifyEmailLinkingFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedNotFoundResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedNotFound" error.
+type VerifyEmailLinkingFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedResponseBody is the type of the "auth-service"
+// service "verify_email_linking" endpoint HTTP response body for the "Failed"
+// error.
+type VerifyEmailLinkingFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedUnauthorized" error.
+type VerifyEmailLinkingFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingRetryableResponseBody is the type of the "auth-service"
+// service "verify_email_linking" endpoint HTTP response body for the
+// "Retryable" error.
+type VerifyEmailLinkingRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedForbiddenResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedForbidden" error.
+type ChangePrimaryEmailFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedNotFoundResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedNotFound" error.
+type ChangePrimaryEmailFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedResponseBody is the type of the "auth-service"
+// service "change_primary_email" endpoint HTTP response body for the "Failed"
+// error.
+type ChangePrimaryEmailFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedUnauthorized" error.
+type ChangePrimaryEmailFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailRetryableResponseBody is the type of the "auth-service"
+// service "change_primary_email" endpoint HTTP response body for the
+// "Retryable" error.
+type ChangePrimaryEmailRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedForbiddenResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedForbidden" error.
+type UploadCurrentUserPictureFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedNotFoundResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedNotFound" error.
+type UploadCurrentUserPictureFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedResponseBody is the type of the "auth-service"
+// service "upload_current_user_picture" endpoint HTTP response body for the
+// "Failed" error.
+type UploadCurrentUserPictureFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedUnauthorized" error.
+type UploadCurrentUserPictureFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureRetryableResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "Retryable" error.
+type UploadCurrentUserPictureRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success *bool `form:"success,omitempty" json:"success,omitempty" xml:"success,omitempty"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UserInfoAddressResponseBody is used to define fields on response body types.
+type UserInfoAddressResponseBody struct {
+	// Street address
+	StreetAddress *string `form:"street_address,omitempty" json:"street_address,omitempty" xml:"street_address,omitempty"`
+	// City or locality
+	Locality *string `form:"locality,omitempty" json:"locality,omitempty" xml:"locality,omitempty"`
+	// State, province or region
+	Region *string `form:"region,omitempty" json:"region,omitempty" xml:"region,omitempty"`
+	// Zip or postal code
+	PostalCode *string `form:"postal_code,omitempty" json:"postal_code,omitempty" xml:"postal_code,omitempty"`
+	// Country name
+	Country *string `form:"country,omitempty" json:"country,omitempty" xml:"country,omitempty"`
+}
+
+// EventSchemaResponse is used to define fields on response body types.
+type EventSchemaResponse struct {
+	// NATS subject the events are published on
+	Subject *string `form:"subject,omitempty" json:"subject,omitempty" xml:"subject,omitempty"`
+	// Name of the event type
+	Title *string `form:"title,omitempty" json:"title,omitempty" xml:"title,omitempty"`
+	// JSON Schema (draft 2020-12) of the events
+	Schema any `form:"schema,omitempty" json:"schema,omitempty" xml:"schema,omitempty"`
+}
+
+// CallerAllowlistEntryResponse is used to define fields on response body types.
+type CallerAllowlistEntryResponse struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID *string `form:"id,omitempty" json:"id,omitempty" xml:"id,omitempty"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities,omitempty" json:"capabilities,omitempty" xml:"capabilities,omitempty"`
+	// Time the entry was created
+	CreatedAt *string `form:"created_at,omitempty" json:"created_at,omitempty" xml:"created_at,omitempty"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt *string `form:"updated_at,omitempty" json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// FieldProvenanceResponseBody is used to define fields on response body types.
+type FieldProvenanceResponseBody struct {
+	// Who set the value
+	Source *string `form:"source,omitempty" json:"source,omitempty" xml:"source,omitempty"`
+	// Subject of the user or admin, or calling service of the enrichment job
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Time the value was set
+	UpdatedAt *string `form:"updated_at,omitempty" json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// WebhookDeliveryResponseBody is used to define fields on response body types.
+type WebhookDeliveryResponseBody struct {
+	// Action of the audit event
+	Action *string `form:"action,omitempty" json:"action,omitempty" xml:"action,omitempty"`
+	// HTTP status of the webhook reply, absent when the webhook wasn't reached
+	StatusCode *int `form:"status_code,omitempty" json:"status_code,omitempty" xml:"status_code,omitempty"`
+	// Error of the failed delivery
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Duration of the delivery in milliseconds
+	DurationMs *int64 `form:"duration_ms,omitempty" json:"duration_ms,omitempty" xml:"duration_ms,omitempty"`
+	// Time of the delivery
+	DeliveredAt *string `form:"delivered_at,omitempty" json:"delivered_at,omitempty" xml:"delivered_at,omitempty"`
+}
+
+// SupportBundleBuildResponseBody is used to define fields on response body
+// types.
+type SupportBundleBuildResponseBody struct {
+	// Version of the service
+	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
+	// Time the binary was built
+	BuildTime *string `form:"build_time,omitempty" json:"build_time,omitempty" xml:"build_time,omitempty"`
+	// Commit the binary was built from
+	GitCommit *string `form:"git_commit,omitempty" json:"git_commit,omitempty" xml:"git_commit,omitempty"`
+	// Go runtime version
+	GoVersion *string `form:"go_version,omitempty" json:"go_version,omitempty" xml:"go_version,omitempty"`
+}
+
+// SupportBundleConfigErrorResponseBody is used to define fields on response
+// body types.
+type SupportBundleConfigErrorResponseBody struct {
+	// Part of the service the configuration belongs to
+	Component *string `form:"component,omitempty" json:"component,omitempty" xml:"component,omitempty"`
+	// Environment variable at fault, when it can be pinned down
+	Key *string `form:"key,omitempty" json:"key,omitempty" xml:"key,omitempty"`
+	// Description of the problem
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+}
+
+// SupportBundleProviderResponseBody is used to define fields on response body
+// types.
+type SupportBundleProviderResponseBody struct {
+	// Identity provider
+	Provider *string `form:"provider,omitempty" json:"provider,omitempty" xml:"provider,omitempty"`
+	// Number of upstream calls in the window, retries included
+	Samples *int `form:"samples,omitempty" json:"samples,omitempty" xml:"samples,omitempty"`
+	// Number of failed upstream calls in the window
+	Failures *int `form:"failures,omitempty" json:"failures,omitempty" xml:"failures,omitempty"`
+	// Ratio of failed upstream calls
+	ErrorRate *float64 `form:"error_rate,omitempty" json:"error_rate,omitempty" xml:"error_rate,omitempty"`
+	// 95th percentile latency of the calls, in milliseconds
+	P95Ms *int64 `form:"p95_ms,omitempty" json:"p95_ms,omitempty" xml:"p95_ms,omitempty"`
+	// State of the circuit of the provider
+	CircuitState *string `form:"circuit_state,omitempty" json:"circuit_state,omitempty" xml:"circuit_state,omitempty"`
+	// Time of the last failed call in the window
+	LastFailureAt *string `form:"last_failure_at,omitempty" json:"last_failure_at,omitempty" xml:"last_failure_at,omitempty"`
+}
+
+// SupportBundleUserCacheResponseBody is used to define fields on response body
+// types.
+type SupportBundleUserCacheResponseBody struct {
+	// Users cache backend
+	Kind *string `form:"kind,omitempty" json:"kind,omitempty" xml:"kind,omitempty"`
+	// Number of lookups served from the cache
+	Hits *int64 `form:"hits,omitempty" json:"hits,omitempty" xml:"hits,omitempty"`
+	// Number of lookups sent to the identity provider
+	Misses *int64 `form:"misses,omitempty" json:"misses,omitempty" xml:"misses,omitempty"`
+	// Number of cached lookup keys, only reported by the memory cache
+	Entries *int `form:"entries,omitempty" json:"entries,omitempty" xml:"entries,omitempty"`
+}
+
+// SupportBundleSyncStatusResponseBody is used to define fields on response
+// body types.
+type SupportBundleSyncStatusResponseBody struct {
+	// Whether the sync succeeded
+	Succeeded *bool `form:"succeeded,omitempty" json:"succeeded,omitempty" xml:"succeeded,omitempty"`
+	// Number of users changed by the sync
+	Changed *int `form:"changed,omitempty" json:"changed,omitempty" xml:"changed,omitempty"`
+	// Error of the failed sync
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Time of the sync
+	SyncedAt *string `form:"synced_at,omitempty" json:"synced_at,omitempty" xml:"synced_at,omitempty"`
+}
+
+// RunbookStepResponseBody is used to define fields on response body types.
+type RunbookStepResponseBody struct {
+	// Name of the step
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Outcome of the step
+	Outcome *string `form:"outcome,omitempty" json:"outcome,omitempty" xml:"outcome,omitempty"`
+	// Detail of the step, the error when it failed
+	Detail *string `form:"detail,omitempty" json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+// NewPutCallerRequestBody builds the HTTP request body from the payload of the
+// "put_caller" endpoint of the "auth-service" service.
+func NewPutCallerRequestBody(p *authservice.PutCallerPayload) *PutCallerRequestBody {
+	body := &PutCallerRequestBody{
+		Description: p.Description,
+	}
+	if p.Capabilities != nil {
+		body.Capabilities = make([]string, len(p.Capabilities))
+		for i, val := range p.Capabilities {
+			body.Capabilities[i] = val
+		}
+	} else {
+		body.Capabilities = []string{}
+	}
+	return body
+}
+
+// NewIssueEmailBackupCodesRequestBody builds the HTTP request body from the
+// payload of the "issue_email_backup_codes" endpoint of the "auth-service"
+// service.
+func NewIssueEmailBackupCodesRequestBody(p *authservice.IssueEmailBackupCodesPayload) *IssueEmailBackupCodesRequestBody {
+	body := &IssueEmailBackupCodesRequestBody{
+		Email: p.Email,
+	}
+	return body
+}
+
 // NewReadyzServiceUnavailable builds a auth-service service readyz endpoint
 // ServiceUnavailable error.
 func NewReadyzServiceUnavailable(body string) authservice.ServiceUnavailable {
@@ -18,3 +1308,2613 @@ func NewReadyzServiceUnavailable(body string) authservice.ServiceUnavailable {
 
 	return v
 }
+
+// NewUserinfoUserInfoOK builds a "auth-service" service "userinfo" endpoint
+// result from a HTTP "OK" response.
+func NewUserinfoUserInfoOK(body *UserinfoResponseBody) *authservice.UserInfo {
+	v := &authservice.UserInfo{
+		Sub:               *body.Sub,
+		Name:              body.Name,
+		GivenName:         body.GivenName,
+		FamilyName:        body.FamilyName,
+		PreferredUsername: body.PreferredUsername,
+		Picture:           body.Picture,
+		Email:             body.Email,
+		Zoneinfo:          body.Zoneinfo,
+		PhoneNumber:       body.PhoneNumber,
+	}
+	if body.Address != nil {
+		v.Address = unmarshalUserInfoAddressResponseBodyToAuthserviceUserInfoAddress(body.Address)
+	}
+
+	return v
+}
+
+// NewUserinfoNotFound builds a auth-service service userinfo endpoint NotFound
+// error.
+func NewUserinfoNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewUserinfoServiceUnavailable builds a auth-service service userinfo
+// endpoint ServiceUnavailable error.
+func NewUserinfoServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewUserinfoUnauthorized builds a auth-service service userinfo endpoint
+// Unauthorized error.
+func NewUserinfoUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewProfileEventsProfileChangeOK builds a "auth-service" service
+// "profile_events" endpoint result from a HTTP "OK" response.
+func NewProfileEventsProfileChangeOK(body *ProfileEventsResponseBody) *authservice.ProfileChange {
+	v := &authservice.ProfileChange{
+		Sub:       *body.Sub,
+		Reason:    *body.Reason,
+		ChangedAt: *body.ChangedAt,
+	}
+
+	return v
+}
+
+// NewProfileEventsServiceUnavailable builds a auth-service service
+// profile_events endpoint ServiceUnavailable error.
+func NewProfileEventsServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewProfileEventsTooManyRequests builds a auth-service service profile_events
+// endpoint TooManyRequests error.
+func NewProfileEventsTooManyRequests(body string) authservice.TooManyRequests {
+	v := authservice.TooManyRequests(body)
+
+	return v
+}
+
+// NewProfileEventsUnauthorized builds a auth-service service profile_events
+// endpoint Unauthorized error.
+func NewProfileEventsUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewAdminEventsAdminEventOK builds a "auth-service" service "admin_events"
+// endpoint result from a HTTP "OK" response.
+func NewAdminEventsAdminEventOK(body *AdminEventsResponseBody) *authservice.AdminEvent {
+	v := &authservice.AdminEvent{
+		Kind:    *body.Kind,
+		At:      *body.At,
+		Dropped: body.Dropped,
+	}
+	if body.Data != nil {
+		v.Data = make(map[string]any, len(body.Data))
+		for key, val := range body.Data {
+			tk := key
+			tv := val
+			v.Data[tk] = tv
+		}
+	}
+
+	return v
+}
+
+// NewAdminEventsBadRequest builds a auth-service service admin_events endpoint
+// BadRequest error.
+func NewAdminEventsBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewAdminEventsForbidden builds a auth-service service admin_events endpoint
+// Forbidden error.
+func NewAdminEventsForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewAdminEventsServiceUnavailable builds a auth-service service admin_events
+// endpoint ServiceUnavailable error.
+func NewAdminEventsServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewAdminEventsTooManyRequests builds a auth-service service admin_events
+// endpoint TooManyRequests error.
+func NewAdminEventsTooManyRequests(body string) authservice.TooManyRequests {
+	v := authservice.TooManyRequests(body)
+
+	return v
+}
+
+// NewAdminEventsUnauthorized builds a auth-service service admin_events
+// endpoint Unauthorized error.
+func NewAdminEventsUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewGetUserMetadataUserDataReplyOK builds a "auth-service" service
+// "get_user_metadata" endpoint result from a HTTP "OK" response.
+func NewGetUserMetadataUserDataReplyOK(body *GetUserMetadataResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataFailedForbidden builds a auth-service service
+// get_user_metadata endpoint FailedForbidden error.
+func NewGetUserMetadataFailedForbidden(body *GetUserMetadataFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataFailedNotFound builds a auth-service service
+// get_user_metadata endpoint FailedNotFound error.
+func NewGetUserMetadataFailedNotFound(body *GetUserMetadataFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataFailed builds a auth-service service get_user_metadata
+// endpoint Failed error.
+func NewGetUserMetadataFailed(body *GetUserMetadataFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataFailedUnauthorized builds a auth-service service
+// get_user_metadata endpoint FailedUnauthorized error.
+func NewGetUserMetadataFailedUnauthorized(body *GetUserMetadataFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataUnauthorized builds a auth-service service
+// get_user_metadata endpoint Unauthorized error.
+func NewGetUserMetadataUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewGetUserMetadataRetryable builds a auth-service service get_user_metadata
+// endpoint Retryable error.
+func NewGetUserMetadataRetryable(body *GetUserMetadataRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewGetUserMetadataServiceUnavailable builds a auth-service service
+// get_user_metadata endpoint ServiceUnavailable error.
+func NewGetUserMetadataServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataUserDataReplyOK builds a "auth-service" service
+// "update_current_user_metadata" endpoint result from a HTTP "OK" response.
+func NewUpdateCurrentUserMetadataUserDataReplyOK(body *UpdateCurrentUserMetadataResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataFailedForbidden builds a auth-service service
+// update_current_user_metadata endpoint FailedForbidden error.
+func NewUpdateCurrentUserMetadataFailedForbidden(body *UpdateCurrentUserMetadataFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataFailedNotFound builds a auth-service service
+// update_current_user_metadata endpoint FailedNotFound error.
+func NewUpdateCurrentUserMetadataFailedNotFound(body *UpdateCurrentUserMetadataFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataFailed builds a auth-service service
+// update_current_user_metadata endpoint Failed error.
+func NewUpdateCurrentUserMetadataFailed(body *UpdateCurrentUserMetadataFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataFailedUnauthorized builds a auth-service service
+// update_current_user_metadata endpoint FailedUnauthorized error.
+func NewUpdateCurrentUserMetadataFailedUnauthorized(body *UpdateCurrentUserMetadataFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataUnauthorized builds a auth-service service
+// update_current_user_metadata endpoint Unauthorized error.
+func NewUpdateCurrentUserMetadataUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataRetryable builds a auth-service service
+// update_current_user_metadata endpoint Retryable error.
+func NewUpdateCurrentUserMetadataRetryable(body *UpdateCurrentUserMetadataRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataServiceUnavailable builds a auth-service service
+// update_current_user_metadata endpoint ServiceUnavailable error.
+func NewUpdateCurrentUserMetadataServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewStartEmailLinkingUserDataReplyOK builds a "auth-service" service
+// "start_email_linking" endpoint result from a HTTP "OK" response.
+func NewStartEmailLinkingUserDataReplyOK(body *StartEmailLinkingResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingFailedForbidden builds a auth-service service
+// start_email_linking endpoint FailedForbidden error.
+func NewStartEmailLinkingFailedForbidden(body *StartEmailLinkingFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingFailedNotFound builds a auth-service service
+// start_email_linking endpoint FailedNotFound error.
+func NewStartEmailLinkingFailedNotFound(body *StartEmailLinkingFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingFailed builds a auth-service service start_email_linking
+// endpoint Failed error.
+func NewStartEmailLinkingFailed(body *StartEmailLinkingFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingFailedUnauthorized builds a auth-service service
+// start_email_linking endpoint FailedUnauthorized error.
+func NewStartEmailLinkingFailedUnauthorized(body *StartEmailLinkingFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingUnauthorized builds a auth-service service
+// start_email_linking endpoint Unauthorized error.
+func NewStartEmailLinkingUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewStartEmailLinkingRetryable builds a auth-service service
+// start_email_linking endpoint Retryable error.
+func NewStartEmailLinkingRetryable(body *StartEmailLinkingRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewStartEmailLinkingServiceUnavailable builds a auth-service service
+// start_email_linking endpoint ServiceUnavailable error.
+func NewStartEmailLinkingServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewVerifyEmailLinkingUserDataReplyOK builds a "auth-service" service
+// "verify_email_linking" endpoint result from a HTTP "OK" response.
+func NewVerifyEmailLinkingUserDataReplyOK(body *VerifyEmailLinkingResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingFailedForbidden builds a auth-service service
+// verify_email_linking endpoint FailedForbidden error.
+func NewVerifyEmailLinkingFailedForbidden(body *VerifyEmailLinkingFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingFailedNotFound builds a auth-service service
+// verify_email_linking endpoint FailedNotFound error.
+func NewVerifyEmailLinkingFailedNotFound(body *VerifyEmailLinkingFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingFailed builds a auth-service service
+// verify_email_linking endpoint Failed error.
+func NewVerifyEmailLinkingFailed(body *VerifyEmailLinkingFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingFailedUnauthorized builds a auth-service service
+// verify_email_linking endpoint FailedUnauthorized error.
+func NewVerifyEmailLinkingFailedUnauthorized(body *VerifyEmailLinkingFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingUnauthorized builds a auth-service service
+// verify_email_linking endpoint Unauthorized error.
+func NewVerifyEmailLinkingUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewVerifyEmailLinkingRetryable builds a auth-service service
+// verify_email_linking endpoint Retryable error.
+func NewVerifyEmailLinkingRetryable(body *VerifyEmailLinkingRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewVerifyEmailLinkingServiceUnavailable builds a auth-service service
+// verify_email_linking endpoint ServiceUnavailable error.
+func NewVerifyEmailLinkingServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewChangePrimaryEmailUserDataReplyOK builds a "auth-service" service
+// "change_primary_email" endpoint result from a HTTP "OK" response.
+func NewChangePrimaryEmailUserDataReplyOK(body *ChangePrimaryEmailResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailFailedForbidden builds a auth-service service
+// change_primary_email endpoint FailedForbidden error.
+func NewChangePrimaryEmailFailedForbidden(body *ChangePrimaryEmailFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailFailedNotFound builds a auth-service service
+// change_primary_email endpoint FailedNotFound error.
+func NewChangePrimaryEmailFailedNotFound(body *ChangePrimaryEmailFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailFailed builds a auth-service service
+// change_primary_email endpoint Failed error.
+func NewChangePrimaryEmailFailed(body *ChangePrimaryEmailFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailFailedUnauthorized builds a auth-service service
+// change_primary_email endpoint FailedUnauthorized error.
+func NewChangePrimaryEmailFailedUnauthorized(body *ChangePrimaryEmailFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailUnauthorized builds a auth-service service
+// change_primary_email endpoint Unauthorized error.
+func NewChangePrimaryEmailUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewChangePrimaryEmailRetryable builds a auth-service service
+// change_primary_email endpoint Retryable error.
+func NewChangePrimaryEmailRetryable(body *ChangePrimaryEmailRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewChangePrimaryEmailServiceUnavailable builds a auth-service service
+// change_primary_email endpoint ServiceUnavailable error.
+func NewChangePrimaryEmailServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewUploadCurrentUserPictureUserDataReplyOK builds a "auth-service" service
+// "upload_current_user_picture" endpoint result from a HTTP "OK" response.
+func NewUploadCurrentUserPictureUserDataReplyOK(body *UploadCurrentUserPictureResponseBody) *authservice.UserDataReply {
+	v := &authservice.UserDataReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureFailedForbidden builds a auth-service service
+// upload_current_user_picture endpoint FailedForbidden error.
+func NewUploadCurrentUserPictureFailedForbidden(body *UploadCurrentUserPictureFailedForbiddenResponseBody) *authservice.FailedForbiddenReply {
+	v := &authservice.FailedForbiddenReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureFailedNotFound builds a auth-service service
+// upload_current_user_picture endpoint FailedNotFound error.
+func NewUploadCurrentUserPictureFailedNotFound(body *UploadCurrentUserPictureFailedNotFoundResponseBody) *authservice.FailedNotFoundReply {
+	v := &authservice.FailedNotFoundReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureFailed builds a auth-service service
+// upload_current_user_picture endpoint Failed error.
+func NewUploadCurrentUserPictureFailed(body *UploadCurrentUserPictureFailedResponseBody) *authservice.FailedReply {
+	v := &authservice.FailedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureFailedUnauthorized builds a auth-service service
+// upload_current_user_picture endpoint FailedUnauthorized error.
+func NewUploadCurrentUserPictureFailedUnauthorized(body *UploadCurrentUserPictureFailedUnauthorizedResponseBody) *authservice.FailedUnauthorizedReply {
+	v := &authservice.FailedUnauthorizedReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureUnauthorized builds a auth-service service
+// upload_current_user_picture endpoint Unauthorized error.
+func NewUploadCurrentUserPictureUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewUploadCurrentUserPictureRetryable builds a auth-service service
+// upload_current_user_picture endpoint Retryable error.
+func NewUploadCurrentUserPictureRetryable(body *UploadCurrentUserPictureRetryableResponseBody) *authservice.RetryableReply {
+	v := &authservice.RetryableReply{
+		Success:      *body.Success,
+		Message:      body.Message,
+		Data:         body.Data,
+		Reason:       body.Reason,
+		ErrorCode:    body.ErrorCode,
+		Retryable:    body.Retryable,
+		RetryAfterMs: body.RetryAfterMs,
+		Stale:        body.Stale,
+		Age:          body.Age,
+	}
+
+	return v
+}
+
+// NewUploadCurrentUserPictureServiceUnavailable builds a auth-service service
+// upload_current_user_picture endpoint ServiceUnavailable error.
+func NewUploadCurrentUserPictureServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewResolveProfileShareSharedProfileOK builds a "auth-service" service
+// "resolve_profile_share" endpoint result from a HTTP "OK" response.
+func NewResolveProfileShareSharedProfileOK(body *ResolveProfileShareResponseBody) *authservice.SharedProfile {
+	v := &authservice.SharedProfile{
+		Username:             body.Username,
+		Name:                 body.Name,
+		Picture:              body.Picture,
+		JobTitle:             body.JobTitle,
+		Organization:         body.Organization,
+		OrganizationVerified: body.OrganizationVerified,
+	}
+
+	return v
+}
+
+// NewResolveProfileShareNotFound builds a auth-service service
+// resolve_profile_share endpoint NotFound error.
+func NewResolveProfileShareNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewResolveProfileShareServiceUnavailable builds a auth-service service
+// resolve_profile_share endpoint ServiceUnavailable error.
+func NewResolveProfileShareServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewListEventSchemasEventSchemaOK builds a "auth-service" service
+// "list_event_schemas" endpoint result from a HTTP "OK" response.
+func NewListEventSchemasEventSchemaOK(body []*EventSchemaResponse) []*authservice.EventSchema {
+	v := make([]*authservice.EventSchema, len(body))
+	for i, val := range body {
+		if val == nil {
+			v[i] = nil
+			continue
+		}
+		v[i] = unmarshalEventSchemaResponseToAuthserviceEventSchema(val)
+	}
+
+	return v
+}
+
+// NewGetEventSchemaEventSchemaOK builds a "auth-service" service
+// "get_event_schema" endpoint result from a HTTP "OK" response.
+func NewGetEventSchemaEventSchemaOK(body *GetEventSchemaResponseBody) *authservice.EventSchema {
+	v := &authservice.EventSchema{
+		Subject: *body.Subject,
+		Title:   *body.Title,
+		Schema:  body.Schema,
+	}
+
+	return v
+}
+
+// NewGetEventSchemaNotFound builds a auth-service service get_event_schema
+// endpoint NotFound error.
+func NewGetEventSchemaNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewListCallersCallerAllowlistEntryOK builds a "auth-service" service
+// "list_callers" endpoint result from a HTTP "OK" response.
+func NewListCallersCallerAllowlistEntryOK(body []*CallerAllowlistEntryResponse) []*authservice.CallerAllowlistEntry {
+	v := make([]*authservice.CallerAllowlistEntry, len(body))
+	for i, val := range body {
+		if val == nil {
+			v[i] = nil
+			continue
+		}
+		v[i] = unmarshalCallerAllowlistEntryResponseToAuthserviceCallerAllowlistEntry(val)
+	}
+
+	return v
+}
+
+// NewListCallersBadRequest builds a auth-service service list_callers endpoint
+// BadRequest error.
+func NewListCallersBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewListCallersConflict builds a auth-service service list_callers endpoint
+// Conflict error.
+func NewListCallersConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewListCallersForbidden builds a auth-service service list_callers endpoint
+// Forbidden error.
+func NewListCallersForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewListCallersNotFound builds a auth-service service list_callers endpoint
+// NotFound error.
+func NewListCallersNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewListCallersServiceUnavailable builds a auth-service service list_callers
+// endpoint ServiceUnavailable error.
+func NewListCallersServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewListCallersUnauthorized builds a auth-service service list_callers
+// endpoint Unauthorized error.
+func NewListCallersUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewGetCallerCallerAllowlistEntryOK builds a "auth-service" service
+// "get_caller" endpoint result from a HTTP "OK" response.
+func NewGetCallerCallerAllowlistEntryOK(body *GetCallerResponseBody) *authservice.CallerAllowlistEntry {
+	v := &authservice.CallerAllowlistEntry{
+		ID:          *body.ID,
+		Description: body.Description,
+		CreatedAt:   *body.CreatedAt,
+		UpdatedAt:   *body.UpdatedAt,
+	}
+	v.Capabilities = make([]string, len(body.Capabilities))
+	for i, val := range body.Capabilities {
+		v.Capabilities[i] = val
+	}
+
+	return v
+}
+
+// NewGetCallerBadRequest builds a auth-service service get_caller endpoint
+// BadRequest error.
+func NewGetCallerBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewGetCallerConflict builds a auth-service service get_caller endpoint
+// Conflict error.
+func NewGetCallerConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewGetCallerForbidden builds a auth-service service get_caller endpoint
+// Forbidden error.
+func NewGetCallerForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewGetCallerNotFound builds a auth-service service get_caller endpoint
+// NotFound error.
+func NewGetCallerNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewGetCallerServiceUnavailable builds a auth-service service get_caller
+// endpoint ServiceUnavailable error.
+func NewGetCallerServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewGetCallerUnauthorized builds a auth-service service get_caller endpoint
+// Unauthorized error.
+func NewGetCallerUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewPutCallerCallerAllowlistEntryOK builds a "auth-service" service
+// "put_caller" endpoint result from a HTTP "OK" response.
+func NewPutCallerCallerAllowlistEntryOK(body *PutCallerResponseBody) *authservice.CallerAllowlistEntry {
+	v := &authservice.CallerAllowlistEntry{
+		ID:          *body.ID,
+		Description: body.Description,
+		CreatedAt:   *body.CreatedAt,
+		UpdatedAt:   *body.UpdatedAt,
+	}
+	v.Capabilities = make([]string, len(body.Capabilities))
+	for i, val := range body.Capabilities {
+		v.Capabilities[i] = val
+	}
+
+	return v
+}
+
+// NewPutCallerBadRequest builds a auth-service service put_caller endpoint
+// BadRequest error.
+func NewPutCallerBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewPutCallerConflict builds a auth-service service put_caller endpoint
+// Conflict error.
+func NewPutCallerConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewPutCallerForbidden builds a auth-service service put_caller endpoint
+// Forbidden error.
+func NewPutCallerForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewPutCallerNotFound builds a auth-service service put_caller endpoint
+// NotFound error.
+func NewPutCallerNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewPutCallerServiceUnavailable builds a auth-service service put_caller
+// endpoint ServiceUnavailable error.
+func NewPutCallerServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewPutCallerUnauthorized builds a auth-service service put_caller endpoint
+// Unauthorized error.
+func NewPutCallerUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewDeleteCallerBadRequest builds a auth-service service delete_caller
+// endpoint BadRequest error.
+func NewDeleteCallerBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewDeleteCallerConflict builds a auth-service service delete_caller endpoint
+// Conflict error.
+func NewDeleteCallerConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewDeleteCallerForbidden builds a auth-service service delete_caller
+// endpoint Forbidden error.
+func NewDeleteCallerForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewDeleteCallerNotFound builds a auth-service service delete_caller endpoint
+// NotFound error.
+func NewDeleteCallerNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewDeleteCallerServiceUnavailable builds a auth-service service
+// delete_caller endpoint ServiceUnavailable error.
+func NewDeleteCallerServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewDeleteCallerUnauthorized builds a auth-service service delete_caller
+// endpoint Unauthorized error.
+func NewDeleteCallerUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataAdminUserMetadataOK builds a "auth-service" service
+// "get_admin_user_metadata" endpoint result from a HTTP "OK" response.
+func NewGetAdminUserMetadataAdminUserMetadataOK(body *GetAdminUserMetadataResponseBody) *authservice.AdminUserMetadata {
+	v := &authservice.AdminUserMetadata{
+		UserID:   *body.UserID,
+		Username: body.Username,
+	}
+	v.UserMetadata = make(map[string]any, len(body.UserMetadata))
+	for key, val := range body.UserMetadata {
+		tk := key
+		tv := val
+		v.UserMetadata[tk] = tv
+	}
+	v.Provenance = make(map[string]*authservice.FieldProvenance, len(body.Provenance))
+	for key, val := range body.Provenance {
+		tk := key
+		if val == nil {
+			v.Provenance[tk] = nil
+			continue
+		}
+		v.Provenance[tk] = unmarshalFieldProvenanceResponseBodyToAuthserviceFieldProvenance(val)
+	}
+
+	return v
+}
+
+// NewGetAdminUserMetadataBadRequest builds a auth-service service
+// get_admin_user_metadata endpoint BadRequest error.
+func NewGetAdminUserMetadataBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataConflict builds a auth-service service
+// get_admin_user_metadata endpoint Conflict error.
+func NewGetAdminUserMetadataConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataForbidden builds a auth-service service
+// get_admin_user_metadata endpoint Forbidden error.
+func NewGetAdminUserMetadataForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataNotFound builds a auth-service service
+// get_admin_user_metadata endpoint NotFound error.
+func NewGetAdminUserMetadataNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataServiceUnavailable builds a auth-service service
+// get_admin_user_metadata endpoint ServiceUnavailable error.
+func NewGetAdminUserMetadataServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewGetAdminUserMetadataUnauthorized builds a auth-service service
+// get_admin_user_metadata endpoint Unauthorized error.
+func NewGetAdminUserMetadataUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesEmailBackupCodesCreated builds a "auth-service"
+// service "issue_email_backup_codes" endpoint result from a HTTP "Created"
+// response.
+func NewIssueEmailBackupCodesEmailBackupCodesCreated(body *IssueEmailBackupCodesResponseBody) *authservice.EmailBackupCodes {
+	v := &authservice.EmailBackupCodes{
+		Email:     *body.Email,
+		ExpiresAt: *body.ExpiresAt,
+	}
+	v.Codes = make([]string, len(body.Codes))
+	for i, val := range body.Codes {
+		v.Codes[i] = val
+	}
+
+	return v
+}
+
+// NewIssueEmailBackupCodesBadRequest builds a auth-service service
+// issue_email_backup_codes endpoint BadRequest error.
+func NewIssueEmailBackupCodesBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesConflict builds a auth-service service
+// issue_email_backup_codes endpoint Conflict error.
+func NewIssueEmailBackupCodesConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesForbidden builds a auth-service service
+// issue_email_backup_codes endpoint Forbidden error.
+func NewIssueEmailBackupCodesForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesNotFound builds a auth-service service
+// issue_email_backup_codes endpoint NotFound error.
+func NewIssueEmailBackupCodesNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesServiceUnavailable builds a auth-service service
+// issue_email_backup_codes endpoint ServiceUnavailable error.
+func NewIssueEmailBackupCodesServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewIssueEmailBackupCodesUnauthorized builds a auth-service service
+// issue_email_backup_codes endpoint Unauthorized error.
+func NewIssueEmailBackupCodesUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewPurgeUserCacheBadRequest builds a auth-service service purge_user_cache
+// endpoint BadRequest error.
+func NewPurgeUserCacheBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewPurgeUserCacheConflict builds a auth-service service purge_user_cache
+// endpoint Conflict error.
+func NewPurgeUserCacheConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewPurgeUserCacheForbidden builds a auth-service service purge_user_cache
+// endpoint Forbidden error.
+func NewPurgeUserCacheForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewPurgeUserCacheNotFound builds a auth-service service purge_user_cache
+// endpoint NotFound error.
+func NewPurgeUserCacheNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewPurgeUserCacheServiceUnavailable builds a auth-service service
+// purge_user_cache endpoint ServiceUnavailable error.
+func NewPurgeUserCacheServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewPurgeUserCacheUnauthorized builds a auth-service service purge_user_cache
+// endpoint Unauthorized error.
+func NewPurgeUserCacheUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesWebhookDeliveriesOK builds a "auth-service" service
+// "list_webhook_deliveries" endpoint result from a HTTP "OK" response.
+func NewListWebhookDeliveriesWebhookDeliveriesOK(body *ListWebhookDeliveriesResponseBody) *authservice.WebhookDeliveries {
+	v := &authservice.WebhookDeliveries{}
+	v.Deliveries = make([]*authservice.WebhookDelivery, len(body.Deliveries))
+	for i, val := range body.Deliveries {
+		if val == nil {
+			v.Deliveries[i] = nil
+			continue
+		}
+		v.Deliveries[i] = unmarshalWebhookDeliveryResponseBodyToAuthserviceWebhookDelivery(val)
+	}
+
+	return v
+}
+
+// NewListWebhookDeliveriesBadRequest builds a auth-service service
+// list_webhook_deliveries endpoint BadRequest error.
+func NewListWebhookDeliveriesBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesConflict builds a auth-service service
+// list_webhook_deliveries endpoint Conflict error.
+func NewListWebhookDeliveriesConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesForbidden builds a auth-service service
+// list_webhook_deliveries endpoint Forbidden error.
+func NewListWebhookDeliveriesForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesNotFound builds a auth-service service
+// list_webhook_deliveries endpoint NotFound error.
+func NewListWebhookDeliveriesNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesServiceUnavailable builds a auth-service service
+// list_webhook_deliveries endpoint ServiceUnavailable error.
+func NewListWebhookDeliveriesServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewListWebhookDeliveriesUnauthorized builds a auth-service service
+// list_webhook_deliveries endpoint Unauthorized error.
+func NewListWebhookDeliveriesUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewGetSupportBundleSupportBundleOK builds a "auth-service" service
+// "get_support_bundle" endpoint result from a HTTP "OK" response.
+func NewGetSupportBundleSupportBundleOK(body *GetSupportBundleResponseBody) *authservice.SupportBundle {
+	v := &authservice.SupportBundle{
+		GeneratedAt: *body.GeneratedAt,
+	}
+	v.Build = unmarshalSupportBundleBuildResponseBodyToAuthserviceSupportBundleBuild(body.Build)
+	v.Config = make(map[string]string, len(body.Config))
+	for key, val := range body.Config {
+		tk := key
+		tv := val
+		v.Config[tk] = tv
+	}
+	v.ConfigErrors = make([]*authservice.SupportBundleConfigError, len(body.ConfigErrors))
+	for i, val := range body.ConfigErrors {
+		if val == nil {
+			v.ConfigErrors[i] = nil
+			continue
+		}
+		v.ConfigErrors[i] = unmarshalSupportBundleConfigErrorResponseBodyToAuthserviceSupportBundleConfigError(val)
+	}
+	v.Providers = make([]*authservice.SupportBundleProvider, len(body.Providers))
+	for i, val := range body.Providers {
+		if val == nil {
+			v.Providers[i] = nil
+			continue
+		}
+		v.Providers[i] = unmarshalSupportBundleProviderResponseBodyToAuthserviceSupportBundleProvider(val)
+	}
+	if body.UserCache != nil {
+		v.UserCache = unmarshalSupportBundleUserCacheResponseBodyToAuthserviceSupportBundleUserCache(body.UserCache)
+	}
+	if body.LastSync != nil {
+		v.LastSync = unmarshalSupportBundleSyncStatusResponseBodyToAuthserviceSupportBundleSyncStatus(body.LastSync)
+	}
+
+	return v
+}
+
+// NewGetSupportBundleBadRequest builds a auth-service service
+// get_support_bundle endpoint BadRequest error.
+func NewGetSupportBundleBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewGetSupportBundleConflict builds a auth-service service get_support_bundle
+// endpoint Conflict error.
+func NewGetSupportBundleConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewGetSupportBundleForbidden builds a auth-service service
+// get_support_bundle endpoint Forbidden error.
+func NewGetSupportBundleForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewGetSupportBundleNotFound builds a auth-service service get_support_bundle
+// endpoint NotFound error.
+func NewGetSupportBundleNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewGetSupportBundleServiceUnavailable builds a auth-service service
+// get_support_bundle endpoint ServiceUnavailable error.
+func NewGetSupportBundleServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewGetSupportBundleUnauthorized builds a auth-service service
+// get_support_bundle endpoint Unauthorized error.
+func NewGetSupportBundleUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewRenewM2mTokenRunbookResultOK builds a "auth-service" service
+// "renew_m2m_token" endpoint result from a HTTP "OK" response.
+func NewRenewM2mTokenRunbookResultOK(body *RenewM2mTokenResponseBody) *authservice.RunbookResult {
+	v := &authservice.RunbookResult{
+		Operation: *body.Operation,
+		Target:    *body.Target,
+		Outcome:   *body.Outcome,
+		At:        *body.At,
+	}
+	v.Steps = make([]*authservice.RunbookStep, len(body.Steps))
+	for i, val := range body.Steps {
+		if val == nil {
+			v.Steps[i] = nil
+			continue
+		}
+		v.Steps[i] = unmarshalRunbookStepResponseBodyToAuthserviceRunbookStep(val)
+	}
+
+	return v
+}
+
+// NewRenewM2mTokenBadRequest builds a auth-service service renew_m2m_token
+// endpoint BadRequest error.
+func NewRenewM2mTokenBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewRenewM2mTokenConflict builds a auth-service service renew_m2m_token
+// endpoint Conflict error.
+func NewRenewM2mTokenConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewRenewM2mTokenForbidden builds a auth-service service renew_m2m_token
+// endpoint Forbidden error.
+func NewRenewM2mTokenForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewRenewM2mTokenNotFound builds a auth-service service renew_m2m_token
+// endpoint NotFound error.
+func NewRenewM2mTokenNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewRenewM2mTokenServiceUnavailable builds a auth-service service
+// renew_m2m_token endpoint ServiceUnavailable error.
+func NewRenewM2mTokenServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewRenewM2mTokenUnauthorized builds a auth-service service renew_m2m_token
+// endpoint Unauthorized error.
+func NewRenewM2mTokenUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewRefreshJwksRunbookResultOK builds a "auth-service" service "refresh_jwks"
+// endpoint result from a HTTP "OK" response.
+func NewRefreshJwksRunbookResultOK(body *RefreshJwksResponseBody) *authservice.RunbookResult {
+	v := &authservice.RunbookResult{
+		Operation: *body.Operation,
+		Target:    *body.Target,
+		Outcome:   *body.Outcome,
+		At:        *body.At,
+	}
+	v.Steps = make([]*authservice.RunbookStep, len(body.Steps))
+	for i, val := range body.Steps {
+		if val == nil {
+			v.Steps[i] = nil
+			continue
+		}
+		v.Steps[i] = unmarshalRunbookStepResponseBodyToAuthserviceRunbookStep(val)
+	}
+
+	return v
+}
+
+// NewRefreshJwksBadRequest builds a auth-service service refresh_jwks endpoint
+// BadRequest error.
+func NewRefreshJwksBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewRefreshJwksConflict builds a auth-service service refresh_jwks endpoint
+// Conflict error.
+func NewRefreshJwksConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewRefreshJwksForbidden builds a auth-service service refresh_jwks endpoint
+// Forbidden error.
+func NewRefreshJwksForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewRefreshJwksNotFound builds a auth-service service refresh_jwks endpoint
+// NotFound error.
+func NewRefreshJwksNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewRefreshJwksServiceUnavailable builds a auth-service service refresh_jwks
+// endpoint ServiceUnavailable error.
+func NewRefreshJwksServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewRefreshJwksUnauthorized builds a auth-service service refresh_jwks
+// endpoint Unauthorized error.
+func NewRefreshJwksUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexRunbookResultOK builds a "auth-service" service
+// "rebuild_email_index" endpoint result from a HTTP "OK" response.
+func NewRebuildEmailIndexRunbookResultOK(body *RebuildEmailIndexResponseBody) *authservice.RunbookResult {
+	v := &authservice.RunbookResult{
+		Operation: *body.Operation,
+		Target:    *body.Target,
+		Outcome:   *body.Outcome,
+		At:        *body.At,
+	}
+	v.Steps = make([]*authservice.RunbookStep, len(body.Steps))
+	for i, val := range body.Steps {
+		if val == nil {
+			v.Steps[i] = nil
+			continue
+		}
+		v.Steps[i] = unmarshalRunbookStepResponseBodyToAuthserviceRunbookStep(val)
+	}
+
+	return v
+}
+
+// NewRebuildEmailIndexBadRequest builds a auth-service service
+// rebuild_email_index endpoint BadRequest error.
+func NewRebuildEmailIndexBadRequest(body string) authservice.BadRequest {
+	v := authservice.BadRequest(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexConflict builds a auth-service service
+// rebuild_email_index endpoint Conflict error.
+func NewRebuildEmailIndexConflict(body string) authservice.Conflict {
+	v := authservice.Conflict(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexForbidden builds a auth-service service
+// rebuild_email_index endpoint Forbidden error.
+func NewRebuildEmailIndexForbidden(body string) authservice.Forbidden {
+	v := authservice.Forbidden(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexNotFound builds a auth-service service
+// rebuild_email_index endpoint NotFound error.
+func NewRebuildEmailIndexNotFound(body string) authservice.NotFound {
+	v := authservice.NotFound(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexServiceUnavailable builds a auth-service service
+// rebuild_email_index endpoint ServiceUnavailable error.
+func NewRebuildEmailIndexServiceUnavailable(body string) authservice.ServiceUnavailable {
+	v := authservice.ServiceUnavailable(body)
+
+	return v
+}
+
+// NewRebuildEmailIndexUnauthorized builds a auth-service service
+// rebuild_email_index endpoint Unauthorized error.
+func NewRebuildEmailIndexUnauthorized(body string) authservice.Unauthorized {
+	v := authservice.Unauthorized(body)
+
+	return v
+}
+
+// ValidateUserinfoResponseBody runs the validations defined on
+// UserinfoResponseBody
+func ValidateUserinfoResponseBody(body *UserinfoResponseBody) (err error) {
+	if body.Sub == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("sub", "body"))
+	}
+	return
+}
+
+// ValidateProfileEventsResponseBody runs the validations defined on
+// profile_events_response_body
+func ValidateProfileEventsResponseBody(body *ProfileEventsResponseBody) (err error) {
+	if body.Sub == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("sub", "body"))
+	}
+	if body.Reason == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("reason", "body"))
+	}
+	if body.ChangedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("changed_at", "body"))
+	}
+	if body.Reason != nil {
+		if !(*body.Reason == "subscribed" || *body.Reason == "update" || *body.Reason == "admin_update" || *body.Reason == "enrichment" || *body.Reason == "delete" || *body.Reason == "restore" || *body.Reason == "identity_link" || *body.Reason == "identity_unlink" || *body.Reason == "primary_email" || *body.Reason == "merge") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.reason", *body.Reason, []any{"subscribed", "update", "admin_update", "enrichment", "delete", "restore", "identity_link", "identity_unlink", "primary_email", "merge"}))
+		}
+	}
+	if body.ChangedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.changed_at", *body.ChangedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateAdminEventsResponseBody runs the validations defined on
+// admin_events_response_body
+func ValidateAdminEventsResponseBody(body *AdminEventsResponseBody) (err error) {
+	if body.Kind == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("kind", "body"))
+	}
+	if body.At == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("at", "body"))
+	}
+	if body.Kind != nil {
+		if !(*body.Kind == "subscribed" || *body.Kind == "sync_status" || *body.Kind == "audit" || *body.Kind == "user_merged" || *body.Kind == "profile_changed") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.kind", *body.Kind, []any{"subscribed", "sync_status", "audit", "user_merged", "profile_changed"}))
+		}
+	}
+	if body.At != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.at", *body.At, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateGetUserMetadataResponseBody runs the validations defined on
+// get_user_metadata_response_body
+func ValidateGetUserMetadataResponseBody(body *GetUserMetadataResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataResponseBody runs the validations defined
+// on update_current_user_metadata_response_body
+func ValidateUpdateCurrentUserMetadataResponseBody(body *UpdateCurrentUserMetadataResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingResponseBody runs the validations defined on
+// start_email_linking_response_body
+func ValidateStartEmailLinkingResponseBody(body *StartEmailLinkingResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingResponseBody runs the validations defined on
+// verify_email_linking_response_body
+func ValidateVerifyEmailLinkingResponseBody(body *VerifyEmailLinkingResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailResponseBody runs the validations defined on
+// change_primary_email_response_body
+func ValidateChangePrimaryEmailResponseBody(body *ChangePrimaryEmailResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureResponseBody runs the validations defined on
+// upload_current_user_picture_response_body
+func ValidateUploadCurrentUserPictureResponseBody(body *UploadCurrentUserPictureResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateGetEventSchemaResponseBody runs the validations defined on
+// get_event_schema_response_body
+func ValidateGetEventSchemaResponseBody(body *GetEventSchemaResponseBody) (err error) {
+	if body.Subject == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("subject", "body"))
+	}
+	if body.Title == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("title", "body"))
+	}
+	if body.Schema == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("schema", "body"))
+	}
+	return
+}
+
+// ValidateGetCallerResponseBody runs the validations defined on
+// get_caller_response_body
+func ValidateGetCallerResponseBody(body *GetCallerResponseBody) (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	if body.Capabilities == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("capabilities", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("created_at", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updated_at", "body"))
+	}
+	for _, e := range body.Capabilities {
+		if !(e == "email_owner" || e == "verbose_errors" || e == "metadata_enrichment") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.capabilities[*]", e, []any{"email_owner", "verbose_errors", "metadata_enrichment"}))
+		}
+	}
+	if body.CreatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.created_at", *body.CreatedAt, goa.FormatDateTime))
+	}
+	if body.UpdatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.updated_at", *body.UpdatedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidatePutCallerResponseBody runs the validations defined on
+// put_caller_response_body
+func ValidatePutCallerResponseBody(body *PutCallerResponseBody) (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	if body.Capabilities == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("capabilities", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("created_at", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updated_at", "body"))
+	}
+	for _, e := range body.Capabilities {
+		if !(e == "email_owner" || e == "verbose_errors" || e == "metadata_enrichment") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.capabilities[*]", e, []any{"email_owner", "verbose_errors", "metadata_enrichment"}))
+		}
+	}
+	if body.CreatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.created_at", *body.CreatedAt, goa.FormatDateTime))
+	}
+	if body.UpdatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.updated_at", *body.UpdatedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateGetAdminUserMetadataResponseBody runs the validations defined on
+// get_admin_user_metadata_response_body
+func ValidateGetAdminUserMetadataResponseBody(body *GetAdminUserMetadataResponseBody) (err error) {
+	if body.UserID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("user_id", "body"))
+	}
+	if body.UserMetadata == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("user_metadata", "body"))
+	}
+	if body.Provenance == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("provenance", "body"))
+	}
+	for _, v := range body.Provenance {
+		if v != nil {
+			if err2 := ValidateFieldProvenanceResponseBody(v); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateIssueEmailBackupCodesResponseBody runs the validations defined on
+// issue_email_backup_codes_response_body
+func ValidateIssueEmailBackupCodesResponseBody(body *IssueEmailBackupCodesResponseBody) (err error) {
+	if body.Email == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("email", "body"))
+	}
+	if body.Codes == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("codes", "body"))
+	}
+	if body.ExpiresAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("expires_at", "body"))
+	}
+	if body.Email != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.email", *body.Email, goa.FormatEmail))
+	}
+	if body.ExpiresAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.expires_at", *body.ExpiresAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateListWebhookDeliveriesResponseBody runs the validations defined on
+// list_webhook_deliveries_response_body
+func ValidateListWebhookDeliveriesResponseBody(body *ListWebhookDeliveriesResponseBody) (err error) {
+	if body.Deliveries == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("deliveries", "body"))
+	}
+	for _, e := range body.Deliveries {
+		if e != nil {
+			if err2 := ValidateWebhookDeliveryResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateGetSupportBundleResponseBody runs the validations defined on
+// get_support_bundle_response_body
+func ValidateGetSupportBundleResponseBody(body *GetSupportBundleResponseBody) (err error) {
+	if body.GeneratedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("generated_at", "body"))
+	}
+	if body.Build == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("build", "body"))
+	}
+	if body.Config == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("config", "body"))
+	}
+	if body.ConfigErrors == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("config_errors", "body"))
+	}
+	if body.Providers == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("providers", "body"))
+	}
+	if body.GeneratedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.generated_at", *body.GeneratedAt, goa.FormatDateTime))
+	}
+	if body.Build != nil {
+		if err2 := ValidateSupportBundleBuildResponseBody(body.Build); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	for _, e := range body.ConfigErrors {
+		if e != nil {
+			if err2 := ValidateSupportBundleConfigErrorResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	for _, e := range body.Providers {
+		if e != nil {
+			if err2 := ValidateSupportBundleProviderResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.UserCache != nil {
+		if err2 := ValidateSupportBundleUserCacheResponseBody(body.UserCache); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.LastSync != nil {
+		if err2 := ValidateSupportBundleSyncStatusResponseBody(body.LastSync); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateRenewM2mTokenResponseBody runs the validations defined on
+// renew_m2m_token_response_body
+func ValidateRenewM2mTokenResponseBody(body *RenewM2mTokenResponseBody) (err error) {
+	if body.Operation == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("operation", "body"))
+	}
+	if body.Target == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("target", "body"))
+	}
+	if body.Outcome == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("outcome", "body"))
+	}
+	if body.Steps == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("steps", "body"))
+	}
+	if body.At == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("at", "body"))
+	}
+	if body.Outcome != nil {
+		if !(*body.Outcome == "success" || *body.Outcome == "failure") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.outcome", *body.Outcome, []any{"success", "failure"}))
+		}
+	}
+	for _, e := range body.Steps {
+		if e != nil {
+			if err2 := ValidateRunbookStepResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.At != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.at", *body.At, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateRefreshJwksResponseBody runs the validations defined on
+// refresh_jwks_response_body
+func ValidateRefreshJwksResponseBody(body *RefreshJwksResponseBody) (err error) {
+	if body.Operation == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("operation", "body"))
+	}
+	if body.Target == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("target", "body"))
+	}
+	if body.Outcome == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("outcome", "body"))
+	}
+	if body.Steps == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("steps", "body"))
+	}
+	if body.At == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("at", "body"))
+	}
+	if body.Outcome != nil {
+		if !(*body.Outcome == "success" || *body.Outcome == "failure") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.outcome", *body.Outcome, []any{"success", "failure"}))
+		}
+	}
+	for _, e := range body.Steps {
+		if e != nil {
+			if err2 := ValidateRunbookStepResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.At != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.at", *body.At, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateRebuildEmailIndexResponseBody runs the validations defined on
+// rebuild_email_index_response_body
+func ValidateRebuildEmailIndexResponseBody(body *RebuildEmailIndexResponseBody) (err error) {
+	if body.Operation == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("operation", "body"))
+	}
+	if body.Target == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("target", "body"))
+	}
+	if body.Outcome == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("outcome", "body"))
+	}
+	if body.Steps == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("steps", "body"))
+	}
+	if body.At == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("at", "body"))
+	}
+	if body.Outcome != nil {
+		if !(*body.Outcome == "success" || *body.Outcome == "failure") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.outcome", *body.Outcome, []any{"success", "failure"}))
+		}
+	}
+	for _, e := range body.Steps {
+		if e != nil {
+			if err2 := ValidateRunbookStepResponseBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.At != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.at", *body.At, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateGetUserMetadataFailedForbiddenResponseBody runs the validations
+// defined on get_user_metadata_FailedForbidden_response_body
+func ValidateGetUserMetadataFailedForbiddenResponseBody(body *GetUserMetadataFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateGetUserMetadataFailedNotFoundResponseBody runs the validations
+// defined on get_user_metadata_FailedNotFound_response_body
+func ValidateGetUserMetadataFailedNotFoundResponseBody(body *GetUserMetadataFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateGetUserMetadataFailedResponseBody runs the validations defined on
+// get_user_metadata_Failed_response_body
+func ValidateGetUserMetadataFailedResponseBody(body *GetUserMetadataFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateGetUserMetadataFailedUnauthorizedResponseBody runs the validations
+// defined on get_user_metadata_FailedUnauthorized_response_body
+func ValidateGetUserMetadataFailedUnauthorizedResponseBody(body *GetUserMetadataFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateGetUserMetadataRetryableResponseBody runs the validations defined on
+// get_user_metadata_Retryable_response_body
+func ValidateGetUserMetadataRetryableResponseBody(body *GetUserMetadataRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataFailedForbiddenResponseBody runs the
+// validations defined on
+// update_current_user_metadata_FailedForbidden_response_body
+func ValidateUpdateCurrentUserMetadataFailedForbiddenResponseBody(body *UpdateCurrentUserMetadataFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataFailedNotFoundResponseBody runs the
+// validations defined on
+// update_current_user_metadata_FailedNotFound_response_body
+func ValidateUpdateCurrentUserMetadataFailedNotFoundResponseBody(body *UpdateCurrentUserMetadataFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataFailedResponseBody runs the validations
+// defined on update_current_user_metadata_Failed_response_body
+func ValidateUpdateCurrentUserMetadataFailedResponseBody(body *UpdateCurrentUserMetadataFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataFailedUnauthorizedResponseBody runs the
+// validations defined on
+// update_current_user_metadata_FailedUnauthorized_response_body
+func ValidateUpdateCurrentUserMetadataFailedUnauthorizedResponseBody(body *UpdateCurrentUserMetadataFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUpdateCurrentUserMetadataRetryableResponseBody runs the validations
+// defined on update_current_user_metadata_Retryable_response_body
+func ValidateUpdateCurrentUserMetadataRetryableResponseBody(body *UpdateCurrentUserMetadataRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingFailedForbiddenResponseBody runs the validations
+// defined on start_email_linking_FailedForbidden_response_body
+func ValidateStartEmailLinkingFailedForbiddenResponseBody(body *StartEmailLinkingFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingFailedNotFoundResponseBody runs the validations
+// defined on start_email_linking_FailedNotFound_response_body
+func ValidateStartEmailLinkingFailedNotFoundResponseBody(body *StartEmailLinkingFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingFailedResponseBody runs the validations defined on
+// start_email_linking_Failed_response_body
+func ValidateStartEmailLinkingFailedResponseBody(body *StartEmailLinkingFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingFailedUnauthorizedResponseBody runs the validations
+// defined on start_email_linking_FailedUnauthorized_response_body
+func ValidateStartEmailLinkingFailedUnauthorizedResponseBody(body *StartEmailLinkingFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateStartEmailLinkingRetryableResponseBody runs the validations defined
+// on start_email_linking_Retryable_response_body
+func ValidateStartEmailLinkingRetryableResponseBody(body *StartEmailLinkingRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingFailedForbiddenResponseBody runs the validations
+// defined on verify_email_linking_FailedForbidden_response_body
+func ValidateVerifyEmailLinkingFailedForbiddenResponseBody(body *VerifyEmailLinkingFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingFailedNotFoundResponseBody runs the validations
+// defined on verify_email_linking_FailedNotFound_response_body
+func ValidateVerifyEmailLinkingFailedNotFoundResponseBody(body *VerifyEmailLinkingFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingFailedResponseBody runs the validations defined on
+// verify_email_linking_Failed_response_body
+func ValidateVerifyEmailLinkingFailedResponseBody(body *VerifyEmailLinkingFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingFailedUnauthorizedResponseBody runs the
+// validations defined on verify_email_linking_FailedUnauthorized_response_body
+func ValidateVerifyEmailLinkingFailedUnauthorizedResponseBody(body *VerifyEmailLinkingFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateVerifyEmailLinkingRetryableResponseBody runs the validations defined
+// on verify_email_linking_Retryable_response_body
+func ValidateVerifyEmailLinkingRetryableResponseBody(body *VerifyEmailLinkingRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailFailedForbiddenResponseBody runs the validations
+// defined on change_primary_email_FailedForbidden_response_body
+func ValidateChangePrimaryEmailFailedForbiddenResponseBody(body *ChangePrimaryEmailFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailFailedNotFoundResponseBody runs the validations
+// defined on change_primary_email_FailedNotFound_response_body
+func ValidateChangePrimaryEmailFailedNotFoundResponseBody(body *ChangePrimaryEmailFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailFailedResponseBody runs the validations defined on
+// change_primary_email_Failed_response_body
+func ValidateChangePrimaryEmailFailedResponseBody(body *ChangePrimaryEmailFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailFailedUnauthorizedResponseBody runs the
+// validations defined on change_primary_email_FailedUnauthorized_response_body
+func ValidateChangePrimaryEmailFailedUnauthorizedResponseBody(body *ChangePrimaryEmailFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateChangePrimaryEmailRetryableResponseBody runs the validations defined
+// on change_primary_email_Retryable_response_body
+func ValidateChangePrimaryEmailRetryableResponseBody(body *ChangePrimaryEmailRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureFailedForbiddenResponseBody runs the
+// validations defined on
+// upload_current_user_picture_FailedForbidden_response_body
+func ValidateUploadCurrentUserPictureFailedForbiddenResponseBody(body *UploadCurrentUserPictureFailedForbiddenResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureFailedNotFoundResponseBody runs the
+// validations defined on
+// upload_current_user_picture_FailedNotFound_response_body
+func ValidateUploadCurrentUserPictureFailedNotFoundResponseBody(body *UploadCurrentUserPictureFailedNotFoundResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureFailedResponseBody runs the validations
+// defined on upload_current_user_picture_Failed_response_body
+func ValidateUploadCurrentUserPictureFailedResponseBody(body *UploadCurrentUserPictureFailedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureFailedUnauthorizedResponseBody runs the
+// validations defined on
+// upload_current_user_picture_FailedUnauthorized_response_body
+func ValidateUploadCurrentUserPictureFailedUnauthorizedResponseBody(body *UploadCurrentUserPictureFailedUnauthorizedResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateUploadCurrentUserPictureRetryableResponseBody runs the validations
+// defined on upload_current_user_picture_Retryable_response_body
+func ValidateUploadCurrentUserPictureRetryableResponseBody(body *UploadCurrentUserPictureRetryableResponseBody) (err error) {
+	if body.Success == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("success", "body"))
+	}
+	return
+}
+
+// ValidateEventSchemaResponse runs the validations defined on
+// EventSchemaResponse
+func ValidateEventSchemaResponse(body *EventSchemaResponse) (err error) {
+	if body.Subject == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("subject", "body"))
+	}
+	if body.Title == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("title", "body"))
+	}
+	if body.Schema == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("schema", "body"))
+	}
+	return
+}
+
+// ValidateCallerAllowlistEntryResponse runs the validations defined on
+// CallerAllowlistEntryResponse
+func ValidateCallerAllowlistEntryResponse(body *CallerAllowlistEntryResponse) (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	if body.Capabilities == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("capabilities", "body"))
+	}
+	if body.CreatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("created_at", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updated_at", "body"))
+	}
+	for _, e := range body.Capabilities {
+		if !(e == "email_owner" || e == "verbose_errors" || e == "metadata_enrichment") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.capabilities[*]", e, []any{"email_owner", "verbose_errors", "metadata_enrichment"}))
+		}
+	}
+	if body.CreatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.created_at", *body.CreatedAt, goa.FormatDateTime))
+	}
+	if body.UpdatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.updated_at", *body.UpdatedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateFieldProvenanceResponseBody runs the validations defined on
+// FieldProvenanceResponseBody
+func ValidateFieldProvenanceResponseBody(body *FieldProvenanceResponseBody) (err error) {
+	if body.Source == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("source", "body"))
+	}
+	if body.UpdatedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("updated_at", "body"))
+	}
+	if body.Source != nil {
+		if !(*body.Source == "user" || *body.Source == "admin" || *body.Source == "enrichment") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.source", *body.Source, []any{"user", "admin", "enrichment"}))
+		}
+	}
+	if body.UpdatedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.updated_at", *body.UpdatedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateWebhookDeliveryResponseBody runs the validations defined on
+// WebhookDeliveryResponseBody
+func ValidateWebhookDeliveryResponseBody(body *WebhookDeliveryResponseBody) (err error) {
+	if body.Action == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("action", "body"))
+	}
+	if body.DurationMs == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("duration_ms", "body"))
+	}
+	if body.DeliveredAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("delivered_at", "body"))
+	}
+	if body.DeliveredAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.delivered_at", *body.DeliveredAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateSupportBundleBuildResponseBody runs the validations defined on
+// SupportBundleBuildResponseBody
+func ValidateSupportBundleBuildResponseBody(body *SupportBundleBuildResponseBody) (err error) {
+	if body.Version == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("version", "body"))
+	}
+	if body.BuildTime == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("build_time", "body"))
+	}
+	if body.GitCommit == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("git_commit", "body"))
+	}
+	if body.GoVersion == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("go_version", "body"))
+	}
+	return
+}
+
+// ValidateSupportBundleConfigErrorResponseBody runs the validations defined on
+// SupportBundleConfigErrorResponseBody
+func ValidateSupportBundleConfigErrorResponseBody(body *SupportBundleConfigErrorResponseBody) (err error) {
+	if body.Component == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("component", "body"))
+	}
+	if body.Message == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("message", "body"))
+	}
+	return
+}
+
+// ValidateSupportBundleProviderResponseBody runs the validations defined on
+// SupportBundleProviderResponseBody
+func ValidateSupportBundleProviderResponseBody(body *SupportBundleProviderResponseBody) (err error) {
+	if body.Provider == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("provider", "body"))
+	}
+	if body.Samples == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("samples", "body"))
+	}
+	if body.Failures == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("failures", "body"))
+	}
+	if body.ErrorRate == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("error_rate", "body"))
+	}
+	if body.P95Ms == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("p95_ms", "body"))
+	}
+	if body.CircuitState == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("circuit_state", "body"))
+	}
+	if body.CircuitState != nil {
+		if !(*body.CircuitState == "closed" || *body.CircuitState == "open" || *body.CircuitState == "half_open") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.circuit_state", *body.CircuitState, []any{"closed", "open", "half_open"}))
+		}
+	}
+	if body.LastFailureAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.last_failure_at", *body.LastFailureAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateSupportBundleUserCacheResponseBody runs the validations defined on
+// SupportBundleUserCacheResponseBody
+func ValidateSupportBundleUserCacheResponseBody(body *SupportBundleUserCacheResponseBody) (err error) {
+	if body.Kind == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("kind", "body"))
+	}
+	if body.Hits == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("hits", "body"))
+	}
+	if body.Misses == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("misses", "body"))
+	}
+	if body.Kind != nil {
+		if !(*body.Kind == "memory" || *body.Kind == "nats") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.kind", *body.Kind, []any{"memory", "nats"}))
+		}
+	}
+	return
+}
+
+// ValidateSupportBundleSyncStatusResponseBody runs the validations defined on
+// SupportBundleSyncStatusResponseBody
+func ValidateSupportBundleSyncStatusResponseBody(body *SupportBundleSyncStatusResponseBody) (err error) {
+	if body.Succeeded == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("succeeded", "body"))
+	}
+	if body.Changed == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("changed", "body"))
+	}
+	if body.SyncedAt == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("synced_at", "body"))
+	}
+	if body.SyncedAt != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.synced_at", *body.SyncedAt, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateRunbookStepResponseBody runs the validations defined on
+// RunbookStepResponseBody
+func ValidateRunbookStepResponseBody(body *RunbookStepResponseBody) (err error) {
+	if body.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "body"))
+	}
+	if body.Outcome == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("outcome", "body"))
+	}
+	if body.Outcome != nil {
+		if !(*body.Outcome == "success" || *body.Outcome == "failure" || *body.Outcome == "skipped") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.outcome", *body.Outcome, []any{"success", "failure", "skipped"}))
+		}
+	}
+	return
+}
@@ -0,0 +1,68 @@
+// Code generated by goa v3.23.3, DO NOT EDIT.
+//
+// auth-service WebSocket client streaming
+//
+// Command:
+// $ goa gen github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/design
+
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/gorilla/websocket"
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// ConnConfigurer holds the websocket connection configurer functions for the
+// streaming endpoints in "auth-service" service.
+type ConnConfigurer struct {
+	AdminEventsFn goahttp.ConnConfigureFunc
+}
+
+// AdminEventsClientStream implements the authservice.AdminEventsClientStream
+// interface.
+type AdminEventsClientStream struct {
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+}
+
+// NewConnConfigurer initializes the websocket connection configurer function
+// with fn for all the streaming endpoints in "auth-service" service.
+func NewConnConfigurer(fn goahttp.ConnConfigureFunc) *ConnConfigurer {
+	return &ConnConfigurer{
+		AdminEventsFn: fn,
+	}
+}
+
+// Recv reads instances of "authservice.AdminEvent" from the "admin_events"
+// endpoint websocket connection.
+func (s *AdminEventsClientStream) Recv() (*authservice.AdminEvent, error) {
+	var (
+		rv   *authservice.AdminEvent
+		body AdminEventsResponseBody
+		err  error
+	)
+	err = s.conn.ReadJSON(&body)
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		s.conn.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	err = ValidateAdminEventsResponseBody(&body)
+	if err != nil {
+		return rv, err
+	}
+	res := NewAdminEventsAdminEventOK(&body)
+	return res, nil
+}
+
+// RecvWithContext reads instances of "authservice.AdminEvent" from the
+// "admin_events" endpoint websocket connection with context.
+func (s *AdminEventsClientStream) RecvWithContext(ctx context.Context) (*authservice.AdminEvent, error) {
+	return s.Recv()
+}
@@ -10,6 +10,7 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 
 	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
@@ -67,3 +68,2582 @@ func EncodeReadyzError(encoder func(context.Context, http.ResponseWriter) goahtt
 		}
 	}
 }
+
+// EncodeUserinfoResponse returns an encoder for responses returned by the
+// auth-service userinfo endpoint.
+func EncodeUserinfoResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserInfo)
+		enc := encoder(ctx, w)
+		body := NewUserinfoResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeUserinfoRequest returns a decoder for requests sent to the
+// auth-service userinfo endpoint.
+func DecodeUserinfoRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.UserinfoPayload, error) {
+	return func(r *http.Request) (*authservice.UserinfoPayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewUserinfoPayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeUserinfoError returns an encoder for errors returned by the userinfo
+// auth-service endpoint.
+func EncodeUserinfoError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeProfileEventsResponse returns an encoder for responses returned by the
+// auth-service profile_events endpoint.
+func EncodeProfileEventsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.ProfileChange)
+		enc := encoder(ctx, w)
+		body := NewProfileEventsResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeProfileEventsRequest returns a decoder for requests sent to the
+// auth-service profile_events endpoint.
+func DecodeProfileEventsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.ProfileEventsPayload, error) {
+	return func(r *http.Request) (*authservice.ProfileEventsPayload, error) {
+		var (
+			accessToken   *string
+			authorization *string
+		)
+		accessTokenRaw := r.URL.Query().Get("access_token")
+		if accessTokenRaw != "" {
+			accessToken = &accessTokenRaw
+		}
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewProfileEventsPayload(accessToken, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeProfileEventsError returns an encoder for errors returned by the
+// profile_events auth-service endpoint.
+func EncodeProfileEventsError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "TooManyRequests":
+			var res authservice.TooManyRequests
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusTooManyRequests)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// DecodeAdminEventsRequest returns a decoder for requests sent to the
+// auth-service admin_events endpoint.
+func DecodeAdminEventsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.AdminEventsPayload, error) {
+	return func(r *http.Request) (*authservice.AdminEventsPayload, error) {
+		var (
+			accessToken   *string
+			kinds         []string
+			authorization *string
+		)
+		qp := r.URL.Query()
+		accessTokenRaw := qp.Get("access_token")
+		if accessTokenRaw != "" {
+			accessToken = &accessTokenRaw
+		}
+		kinds = qp["kinds"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewAdminEventsPayload(accessToken, kinds, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeAdminEventsError returns an encoder for errors returned by the
+// admin_events auth-service endpoint.
+func EncodeAdminEventsError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "TooManyRequests":
+			var res authservice.TooManyRequests
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusTooManyRequests)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeGetUserMetadataResponse returns an encoder for responses returned by
+// the auth-service get_user_metadata endpoint.
+func EncodeGetUserMetadataResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewGetUserMetadataResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetUserMetadataRequest returns a decoder for requests sent to the
+// auth-service get_user_metadata endpoint.
+func DecodeGetUserMetadataRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.GetUserMetadataPayload, error) {
+	return func(r *http.Request) (*authservice.GetUserMetadataPayload, error) {
+		var (
+			sub            string
+			authorization  *string
+			acceptLanguage *string
+			format         *string
+			err            error
+
+			params = mux.Vars(r)
+		)
+		sub = params["sub"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		formatRaw := r.Header.Get("X-Response-Format")
+		if formatRaw != "" {
+			format = &formatRaw
+		}
+		if format != nil {
+			if !(*format == "oidc") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("format", *format, []any{"oidc"}))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewGetUserMetadataPayload(sub, authorization, acceptLanguage, format)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetUserMetadataError returns an encoder for errors returned by the
+// get_user_metadata auth-service endpoint.
+func EncodeGetUserMetadataError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetUserMetadataFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetUserMetadataFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetUserMetadataFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetUserMetadataFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetUserMetadataRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeUpdateCurrentUserMetadataResponse returns an encoder for responses
+// returned by the auth-service update_current_user_metadata endpoint.
+func EncodeUpdateCurrentUserMetadataResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewUpdateCurrentUserMetadataResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeUpdateCurrentUserMetadataRequest returns a decoder for requests sent
+// to the auth-service update_current_user_metadata endpoint.
+func DecodeUpdateCurrentUserMetadataRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.UpdateCurrentUserMetadataPayload, error) {
+	return func(r *http.Request) (*authservice.UpdateCurrentUserMetadataPayload, error) {
+		var (
+			body struct {
+				// Metadata fields to update
+				UserMetadata map[string]any `form:"user_metadata" json:"user_metadata" xml:"user_metadata"`
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			authorization  *string
+			acceptLanguage *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		payload := NewUpdateCurrentUserMetadataPayload(body, authorization, acceptLanguage)
+
+		return payload, nil
+	}
+}
+
+// EncodeUpdateCurrentUserMetadataError returns an encoder for errors returned
+// by the update_current_user_metadata auth-service endpoint.
+func EncodeUpdateCurrentUserMetadataError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUpdateCurrentUserMetadataFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUpdateCurrentUserMetadataFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUpdateCurrentUserMetadataFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUpdateCurrentUserMetadataFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUpdateCurrentUserMetadataRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeStartEmailLinkingResponse returns an encoder for responses returned by
+// the auth-service start_email_linking endpoint.
+func EncodeStartEmailLinkingResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewStartEmailLinkingResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeStartEmailLinkingRequest returns a decoder for requests sent to the
+// auth-service start_email_linking endpoint.
+func DecodeStartEmailLinkingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.StartEmailLinkingPayload, error) {
+	return func(r *http.Request) (*authservice.StartEmailLinkingPayload, error) {
+		var (
+			body struct {
+				// Alternate email to verify
+				Email *string `form:"email" json:"email" xml:"email"`
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			authorization  *string
+			acceptLanguage *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		payload := NewStartEmailLinkingPayload(body, authorization, acceptLanguage)
+
+		return payload, nil
+	}
+}
+
+// EncodeStartEmailLinkingError returns an encoder for errors returned by the
+// start_email_linking auth-service endpoint.
+func EncodeStartEmailLinkingError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewStartEmailLinkingFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewStartEmailLinkingFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewStartEmailLinkingFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewStartEmailLinkingFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewStartEmailLinkingRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeVerifyEmailLinkingResponse returns an encoder for responses returned
+// by the auth-service verify_email_linking endpoint.
+func EncodeVerifyEmailLinkingResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewVerifyEmailLinkingResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeVerifyEmailLinkingRequest returns a decoder for requests sent to the
+// auth-service verify_email_linking endpoint.
+func DecodeVerifyEmailLinkingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.VerifyEmailLinkingPayload, error) {
+	return func(r *http.Request) (*authservice.VerifyEmailLinkingPayload, error) {
+		var (
+			body struct {
+				// Alternate email to verify
+				Email *string `form:"email" json:"email" xml:"email"`
+				// One time password sent to the email
+				Otp *string `form:"otp" json:"otp" xml:"otp"`
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			authorization  *string
+			acceptLanguage *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		payload := NewVerifyEmailLinkingPayload(body, authorization, acceptLanguage)
+
+		return payload, nil
+	}
+}
+
+// EncodeVerifyEmailLinkingError returns an encoder for errors returned by the
+// verify_email_linking auth-service endpoint.
+func EncodeVerifyEmailLinkingError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewVerifyEmailLinkingFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewVerifyEmailLinkingFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewVerifyEmailLinkingFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewVerifyEmailLinkingFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewVerifyEmailLinkingRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeChangePrimaryEmailResponse returns an encoder for responses returned
+// by the auth-service change_primary_email endpoint.
+func EncodeChangePrimaryEmailResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewChangePrimaryEmailResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeChangePrimaryEmailRequest returns a decoder for requests sent to the
+// auth-service change_primary_email endpoint.
+func DecodeChangePrimaryEmailRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.ChangePrimaryEmailPayload, error) {
+	return func(r *http.Request) (*authservice.ChangePrimaryEmailPayload, error) {
+		var (
+			body struct {
+				// Reference returned by the verification of the new email
+				IdentityTokenRef *string `form:"identity_token_ref" json:"identity_token_ref" xml:"identity_token_ref"`
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			authorization  *string
+			acceptLanguage *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		payload := NewChangePrimaryEmailPayload(body, authorization, acceptLanguage)
+
+		return payload, nil
+	}
+}
+
+// EncodeChangePrimaryEmailError returns an encoder for errors returned by the
+// change_primary_email auth-service endpoint.
+func EncodeChangePrimaryEmailError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewChangePrimaryEmailFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewChangePrimaryEmailFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewChangePrimaryEmailFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewChangePrimaryEmailFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewChangePrimaryEmailRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeUploadCurrentUserPictureResponse returns an encoder for responses
+// returned by the auth-service upload_current_user_picture endpoint.
+func EncodeUploadCurrentUserPictureResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.UserDataReply)
+		enc := encoder(ctx, w)
+		body := NewUploadCurrentUserPictureResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeUploadCurrentUserPictureRequest returns a decoder for requests sent to
+// the auth-service upload_current_user_picture endpoint.
+func DecodeUploadCurrentUserPictureRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.UploadCurrentUserPicturePayload, error) {
+	return func(r *http.Request) (*authservice.UploadCurrentUserPicturePayload, error) {
+		var (
+			authorization  *string
+			acceptLanguage *string
+			contentType    *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		acceptLanguageRaw := r.Header.Get("Accept-Language")
+		if acceptLanguageRaw != "" {
+			acceptLanguage = &acceptLanguageRaw
+		}
+		contentTypeRaw := r.Header.Get("Content-Type")
+		if contentTypeRaw != "" {
+			contentType = &contentTypeRaw
+		}
+		payload := NewUploadCurrentUserPicturePayload(authorization, acceptLanguage, contentType)
+
+		return payload, nil
+	}
+}
+
+// EncodeUploadCurrentUserPictureError returns an encoder for errors returned
+// by the upload_current_user_picture auth-service endpoint.
+func EncodeUploadCurrentUserPictureError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "FailedForbidden":
+			var res *authservice.FailedForbiddenReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUploadCurrentUserPictureFailedForbiddenResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "FailedNotFound":
+			var res *authservice.FailedNotFoundReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUploadCurrentUserPictureFailedNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "Failed":
+			var res *authservice.FailedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUploadCurrentUserPictureFailedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		case "FailedUnauthorized":
+			var res *authservice.FailedUnauthorizedReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUploadCurrentUserPictureFailedUnauthorizedResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		case "Retryable":
+			var res *authservice.RetryableReply
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			var body any
+			if formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewUploadCurrentUserPictureRetryableResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeResolveProfileShareResponse returns an encoder for responses returned
+// by the auth-service resolve_profile_share endpoint.
+func EncodeResolveProfileShareResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.SharedProfile)
+		enc := encoder(ctx, w)
+		body := NewResolveProfileShareResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeResolveProfileShareRequest returns a decoder for requests sent to the
+// auth-service resolve_profile_share endpoint.
+func DecodeResolveProfileShareRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.ResolveProfileSharePayload, error) {
+	return func(r *http.Request) (*authservice.ResolveProfileSharePayload, error) {
+		var (
+			token string
+
+			params = mux.Vars(r)
+		)
+		token = params["token"]
+		payload := NewResolveProfileSharePayload(token)
+
+		return payload, nil
+	}
+}
+
+// EncodeResolveProfileShareError returns an encoder for errors returned by the
+// resolve_profile_share auth-service endpoint.
+func EncodeResolveProfileShareError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListEventSchemasResponse returns an encoder for responses returned by
+// the auth-service list_event_schemas endpoint.
+func EncodeListEventSchemasResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.([]*authservice.EventSchema)
+		enc := encoder(ctx, w)
+		body := NewListEventSchemasResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// EncodeGetEventSchemaResponse returns an encoder for responses returned by
+// the auth-service get_event_schema endpoint.
+func EncodeGetEventSchemaResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.EventSchema)
+		enc := encoder(ctx, w)
+		body := NewGetEventSchemaResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetEventSchemaRequest returns a decoder for requests sent to the
+// auth-service get_event_schema endpoint.
+func DecodeGetEventSchemaRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.GetEventSchemaPayload, error) {
+	return func(r *http.Request) (*authservice.GetEventSchemaPayload, error) {
+		var (
+			subject string
+
+			params = mux.Vars(r)
+		)
+		subject = params["subject"]
+		payload := NewGetEventSchemaPayload(subject)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetEventSchemaError returns an encoder for errors returned by the
+// get_event_schema auth-service endpoint.
+func EncodeGetEventSchemaError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListCallersResponse returns an encoder for responses returned by the
+// auth-service list_callers endpoint.
+func EncodeListCallersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.([]*authservice.CallerAllowlistEntry)
+		enc := encoder(ctx, w)
+		body := NewListCallersResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListCallersRequest returns a decoder for requests sent to the
+// auth-service list_callers endpoint.
+func DecodeListCallersRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.ListCallersPayload, error) {
+	return func(r *http.Request) (*authservice.ListCallersPayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewListCallersPayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeListCallersError returns an encoder for errors returned by the
+// list_callers auth-service endpoint.
+func EncodeListCallersError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeGetCallerResponse returns an encoder for responses returned by the
+// auth-service get_caller endpoint.
+func EncodeGetCallerResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.CallerAllowlistEntry)
+		enc := encoder(ctx, w)
+		body := NewGetCallerResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetCallerRequest returns a decoder for requests sent to the
+// auth-service get_caller endpoint.
+func DecodeGetCallerRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.GetCallerPayload, error) {
+	return func(r *http.Request) (*authservice.GetCallerPayload, error) {
+		var (
+			id            string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewGetCallerPayload(id, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetCallerError returns an encoder for errors returned by the
+// get_caller auth-service endpoint.
+func EncodeGetCallerError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodePutCallerResponse returns an encoder for responses returned by the
+// auth-service put_caller endpoint.
+func EncodePutCallerResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.CallerAllowlistEntry)
+		enc := encoder(ctx, w)
+		body := NewPutCallerResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePutCallerRequest returns a decoder for requests sent to the
+// auth-service put_caller endpoint.
+func DecodePutCallerRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.PutCallerPayload, error) {
+	return func(r *http.Request) (*authservice.PutCallerPayload, error) {
+		var (
+			body PutCallerRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidatePutCallerRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			id            string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewPutCallerPayload(&body, id, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodePutCallerError returns an encoder for errors returned by the
+// put_caller auth-service endpoint.
+func EncodePutCallerError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeDeleteCallerResponse returns an encoder for responses returned by the
+// auth-service delete_caller endpoint.
+func EncodeDeleteCallerResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+// DecodeDeleteCallerRequest returns a decoder for requests sent to the
+// auth-service delete_caller endpoint.
+func DecodeDeleteCallerRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.DeleteCallerPayload, error) {
+	return func(r *http.Request) (*authservice.DeleteCallerPayload, error) {
+		var (
+			id            string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewDeleteCallerPayload(id, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeDeleteCallerError returns an encoder for errors returned by the
+// delete_caller auth-service endpoint.
+func EncodeDeleteCallerError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeGetAdminUserMetadataResponse returns an encoder for responses returned
+// by the auth-service get_admin_user_metadata endpoint.
+func EncodeGetAdminUserMetadataResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.AdminUserMetadata)
+		enc := encoder(ctx, w)
+		body := NewGetAdminUserMetadataResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetAdminUserMetadataRequest returns a decoder for requests sent to the
+// auth-service get_admin_user_metadata endpoint.
+func DecodeGetAdminUserMetadataRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.GetAdminUserMetadataPayload, error) {
+	return func(r *http.Request) (*authservice.GetAdminUserMetadataPayload, error) {
+		var (
+			user          string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		user = params["user"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewGetAdminUserMetadataPayload(user, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetAdminUserMetadataError returns an encoder for errors returned by
+// the get_admin_user_metadata auth-service endpoint.
+func EncodeGetAdminUserMetadataError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeIssueEmailBackupCodesResponse returns an encoder for responses
+// returned by the auth-service issue_email_backup_codes endpoint.
+func EncodeIssueEmailBackupCodesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.EmailBackupCodes)
+		enc := encoder(ctx, w)
+		body := NewIssueEmailBackupCodesResponseBody(res)
+		w.WriteHeader(http.StatusCreated)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeIssueEmailBackupCodesRequest returns a decoder for requests sent to
+// the auth-service issue_email_backup_codes endpoint.
+func DecodeIssueEmailBackupCodesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.IssueEmailBackupCodesPayload, error) {
+	return func(r *http.Request) (*authservice.IssueEmailBackupCodesPayload, error) {
+		var (
+			body IssueEmailBackupCodesRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return nil, gerr
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateIssueEmailBackupCodesRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewIssueEmailBackupCodesPayload(&body, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeIssueEmailBackupCodesError returns an encoder for errors returned by
+// the issue_email_backup_codes auth-service endpoint.
+func EncodeIssueEmailBackupCodesError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodePurgeUserCacheResponse returns an encoder for responses returned by
+// the auth-service purge_user_cache endpoint.
+func EncodePurgeUserCacheResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+// DecodePurgeUserCacheRequest returns a decoder for requests sent to the
+// auth-service purge_user_cache endpoint.
+func DecodePurgeUserCacheRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.PurgeUserCachePayload, error) {
+	return func(r *http.Request) (*authservice.PurgeUserCachePayload, error) {
+		var (
+			user          string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		user = params["user"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewPurgeUserCachePayload(user, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodePurgeUserCacheError returns an encoder for errors returned by the
+// purge_user_cache auth-service endpoint.
+func EncodePurgeUserCacheError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListWebhookDeliveriesResponse returns an encoder for responses
+// returned by the auth-service list_webhook_deliveries endpoint.
+func EncodeListWebhookDeliveriesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.WebhookDeliveries)
+		enc := encoder(ctx, w)
+		body := NewListWebhookDeliveriesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListWebhookDeliveriesRequest returns a decoder for requests sent to
+// the auth-service list_webhook_deliveries endpoint.
+func DecodeListWebhookDeliveriesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.ListWebhookDeliveriesPayload, error) {
+	return func(r *http.Request) (*authservice.ListWebhookDeliveriesPayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewListWebhookDeliveriesPayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeListWebhookDeliveriesError returns an encoder for errors returned by
+// the list_webhook_deliveries auth-service endpoint.
+func EncodeListWebhookDeliveriesError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeGetSupportBundleResponse returns an encoder for responses returned by
+// the auth-service get_support_bundle endpoint.
+func EncodeGetSupportBundleResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.SupportBundle)
+		enc := encoder(ctx, w)
+		body := NewGetSupportBundleResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetSupportBundleRequest returns a decoder for requests sent to the
+// auth-service get_support_bundle endpoint.
+func DecodeGetSupportBundleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.GetSupportBundlePayload, error) {
+	return func(r *http.Request) (*authservice.GetSupportBundlePayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewGetSupportBundlePayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeGetSupportBundleError returns an encoder for errors returned by the
+// get_support_bundle auth-service endpoint.
+func EncodeGetSupportBundleError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRenewM2mTokenResponse returns an encoder for responses returned by the
+// auth-service renew_m2m_token endpoint.
+func EncodeRenewM2mTokenResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.RunbookResult)
+		enc := encoder(ctx, w)
+		body := NewRenewM2mTokenResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRenewM2mTokenRequest returns a decoder for requests sent to the
+// auth-service renew_m2m_token endpoint.
+func DecodeRenewM2mTokenRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.RenewM2mTokenPayload, error) {
+	return func(r *http.Request) (*authservice.RenewM2mTokenPayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewRenewM2mTokenPayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeRenewM2mTokenError returns an encoder for errors returned by the
+// renew_m2m_token auth-service endpoint.
+func EncodeRenewM2mTokenError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRefreshJwksResponse returns an encoder for responses returned by the
+// auth-service refresh_jwks endpoint.
+func EncodeRefreshJwksResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.RunbookResult)
+		enc := encoder(ctx, w)
+		body := NewRefreshJwksResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRefreshJwksRequest returns a decoder for requests sent to the
+// auth-service refresh_jwks endpoint.
+func DecodeRefreshJwksRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.RefreshJwksPayload, error) {
+	return func(r *http.Request) (*authservice.RefreshJwksPayload, error) {
+		var (
+			authorization *string
+		)
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewRefreshJwksPayload(authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeRefreshJwksError returns an encoder for errors returned by the
+// refresh_jwks auth-service endpoint.
+func EncodeRefreshJwksError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeRebuildEmailIndexResponse returns an encoder for responses returned by
+// the auth-service rebuild_email_index endpoint.
+func EncodeRebuildEmailIndexResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*authservice.RunbookResult)
+		enc := encoder(ctx, w)
+		body := NewRebuildEmailIndexResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeRebuildEmailIndexRequest returns a decoder for requests sent to the
+// auth-service rebuild_email_index endpoint.
+func DecodeRebuildEmailIndexRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*authservice.RebuildEmailIndexPayload, error) {
+	return func(r *http.Request) (*authservice.RebuildEmailIndexPayload, error) {
+		var (
+			user          string
+			authorization *string
+
+			params = mux.Vars(r)
+		)
+		user = params["user"]
+		authorizationRaw := r.Header.Get("Authorization")
+		if authorizationRaw != "" {
+			authorization = &authorizationRaw
+		}
+		payload := NewRebuildEmailIndexPayload(user, authorization)
+
+		return payload, nil
+	}
+}
+
+// EncodeRebuildEmailIndexError returns an encoder for errors returned by the
+// rebuild_email_index auth-service endpoint.
+func EncodeRebuildEmailIndexError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "BadRequest":
+			var res authservice.BadRequest
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		case "Conflict":
+			var res authservice.Conflict
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusConflict)
+			return enc.Encode(body)
+		case "Forbidden":
+			var res authservice.Forbidden
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusForbidden)
+			return enc.Encode(body)
+		case "NotFound":
+			var res authservice.NotFound
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "ServiceUnavailable":
+			var res authservice.ServiceUnavailable
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return enc.Encode(body)
+		case "Unauthorized":
+			var res authservice.Unauthorized
+			errors.As(v, &res)
+			enc := encoder(ctx, w)
+			body := res
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnauthorized)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// marshalAuthserviceUserInfoAddressToUserInfoAddressResponseBody builds a
+// value of type *UserInfoAddressResponseBody from a value of type
+// *authservice.UserInfoAddress.
+func marshalAuthserviceUserInfoAddressToUserInfoAddressResponseBody(v *authservice.UserInfoAddress) *UserInfoAddressResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &UserInfoAddressResponseBody{
+		StreetAddress: v.StreetAddress,
+		Locality:      v.Locality,
+		Region:        v.Region,
+		PostalCode:    v.PostalCode,
+		Country:       v.Country,
+	}
+
+	return res
+}
+
+// marshalAuthserviceEventSchemaToEventSchemaResponse builds a value of type
+// *EventSchemaResponse from a value of type *authservice.EventSchema.
+func marshalAuthserviceEventSchemaToEventSchemaResponse(v *authservice.EventSchema) *EventSchemaResponse {
+	res := &EventSchemaResponse{
+		Subject: v.Subject,
+		Title:   v.Title,
+		Schema:  v.Schema,
+	}
+
+	return res
+}
+
+// marshalAuthserviceCallerAllowlistEntryToCallerAllowlistEntryResponse builds
+// a value of type *CallerAllowlistEntryResponse from a value of type
+// *authservice.CallerAllowlistEntry.
+func marshalAuthserviceCallerAllowlistEntryToCallerAllowlistEntryResponse(v *authservice.CallerAllowlistEntry) *CallerAllowlistEntryResponse {
+	res := &CallerAllowlistEntryResponse{
+		ID:          v.ID,
+		Description: v.Description,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+	if v.Capabilities != nil {
+		res.Capabilities = make([]string, len(v.Capabilities))
+		for i, val := range v.Capabilities {
+			res.Capabilities[i] = val
+		}
+	} else {
+		res.Capabilities = []string{}
+	}
+
+	return res
+}
+
+// marshalAuthserviceFieldProvenanceToFieldProvenanceResponseBody builds a
+// value of type *FieldProvenanceResponseBody from a value of type
+// *authservice.FieldProvenance.
+func marshalAuthserviceFieldProvenanceToFieldProvenanceResponseBody(v *authservice.FieldProvenance) *FieldProvenanceResponseBody {
+	res := &FieldProvenanceResponseBody{
+		Source:    v.Source,
+		Actor:     v.Actor,
+		UpdatedAt: v.UpdatedAt,
+	}
+
+	return res
+}
+
+// marshalAuthserviceWebhookDeliveryToWebhookDeliveryResponseBody builds a
+// value of type *WebhookDeliveryResponseBody from a value of type
+// *authservice.WebhookDelivery.
+func marshalAuthserviceWebhookDeliveryToWebhookDeliveryResponseBody(v *authservice.WebhookDelivery) *WebhookDeliveryResponseBody {
+	res := &WebhookDeliveryResponseBody{
+		Action:      v.Action,
+		StatusCode:  v.StatusCode,
+		Error:       v.Error,
+		DurationMs:  v.DurationMs,
+		DeliveredAt: v.DeliveredAt,
+	}
+
+	return res
+}
+
+// marshalAuthserviceSupportBundleBuildToSupportBundleBuildResponseBody builds
+// a value of type *SupportBundleBuildResponseBody from a value of type
+// *authservice.SupportBundleBuild.
+func marshalAuthserviceSupportBundleBuildToSupportBundleBuildResponseBody(v *authservice.SupportBundleBuild) *SupportBundleBuildResponseBody {
+	res := &SupportBundleBuildResponseBody{
+		Version:   v.Version,
+		BuildTime: v.BuildTime,
+		GitCommit: v.GitCommit,
+		GoVersion: v.GoVersion,
+	}
+
+	return res
+}
+
+// marshalAuthserviceSupportBundleConfigErrorToSupportBundleConfigErrorResponseBody
+// builds a value of type *SupportBundleConfigErrorResponseBody from a value of
+// type *authservice.SupportBundleConfigError.
+func marshalAuthserviceSupportBundleConfigErrorToSupportBundleConfigErrorResponseBody(v *authservice.SupportBundleConfigError) *SupportBundleConfigErrorResponseBody {
+	res := &SupportBundleConfigErrorResponseBody{
+		Component: v.Component,
+		Key:       v.Key,
+		Message:   v.Message,
+	}
+
+	return res
+}
+
+// marshalAuthserviceSupportBundleProviderToSupportBundleProviderResponseBody
+// builds a value of type *SupportBundleProviderResponseBody from a value of
+// type *authservice.SupportBundleProvider.
+func marshalAuthserviceSupportBundleProviderToSupportBundleProviderResponseBody(v *authservice.SupportBundleProvider) *SupportBundleProviderResponseBody {
+	res := &SupportBundleProviderResponseBody{
+		Provider:      v.Provider,
+		Samples:       v.Samples,
+		Failures:      v.Failures,
+		ErrorRate:     v.ErrorRate,
+		P95Ms:         v.P95Ms,
+		CircuitState:  v.CircuitState,
+		LastFailureAt: v.LastFailureAt,
+	}
+
+	return res
+}
+
+// marshalAuthserviceSupportBundleUserCacheToSupportBundleUserCacheResponseBody
+// builds a value of type *SupportBundleUserCacheResponseBody from a value of
+// type *authservice.SupportBundleUserCache.
+func marshalAuthserviceSupportBundleUserCacheToSupportBundleUserCacheResponseBody(v *authservice.SupportBundleUserCache) *SupportBundleUserCacheResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &SupportBundleUserCacheResponseBody{
+		Kind:    v.Kind,
+		Hits:    v.Hits,
+		Misses:  v.Misses,
+		Entries: v.Entries,
+	}
+
+	return res
+}
+
+// marshalAuthserviceSupportBundleSyncStatusToSupportBundleSyncStatusResponseBody
+// builds a value of type *SupportBundleSyncStatusResponseBody from a value of
+// type *authservice.SupportBundleSyncStatus.
+func marshalAuthserviceSupportBundleSyncStatusToSupportBundleSyncStatusResponseBody(v *authservice.SupportBundleSyncStatus) *SupportBundleSyncStatusResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &SupportBundleSyncStatusResponseBody{
+		Succeeded: v.Succeeded,
+		Changed:   v.Changed,
+		Error:     v.Error,
+		SyncedAt:  v.SyncedAt,
+	}
+
+	return res
+}
+
+// marshalAuthserviceRunbookStepToRunbookStepResponseBody builds a value of
+// type *RunbookStepResponseBody from a value of type *authservice.RunbookStep.
+func marshalAuthserviceRunbookStepToRunbookStepResponseBody(v *authservice.RunbookStep) *RunbookStepResponseBody {
+	res := &RunbookStepResponseBody{
+		Name:    v.Name,
+		Outcome: v.Outcome,
+		Detail:  v.Detail,
+	}
+
+	return res
+}
@@ -18,9 +18,33 @@ import (
 
 // Server lists the auth-service service endpoint HTTP handlers.
 type Server struct {
-	Mounts []*MountPoint
-	Livez  http.Handler
-	Readyz http.Handler
+	Mounts                    []*MountPoint
+	Livez                     http.Handler
+	Readyz                    http.Handler
+	Userinfo                  http.Handler
+	ProfileEvents             http.Handler
+	AdminEvents               http.Handler
+	GetUserMetadata           http.Handler
+	UpdateCurrentUserMetadata http.Handler
+	StartEmailLinking         http.Handler
+	VerifyEmailLinking        http.Handler
+	ChangePrimaryEmail        http.Handler
+	UploadCurrentUserPicture  http.Handler
+	ResolveProfileShare       http.Handler
+	ListEventSchemas          http.Handler
+	GetEventSchema            http.Handler
+	ListCallers               http.Handler
+	GetCaller                 http.Handler
+	PutCaller                 http.Handler
+	DeleteCaller              http.Handler
+	GetAdminUserMetadata      http.Handler
+	IssueEmailBackupCodes     http.Handler
+	PurgeUserCache            http.Handler
+	ListWebhookDeliveries     http.Handler
+	GetSupportBundle          http.Handler
+	RenewM2mToken             http.Handler
+	RefreshJwks               http.Handler
+	RebuildEmailIndex         http.Handler
 }
 
 // MountPoint holds information about the mounted endpoints.
@@ -47,14 +71,67 @@ func New(
 	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	errhandler func(context.Context, http.ResponseWriter, error),
 	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	upgrader goahttp.Upgrader,
+	configurer *ConnConfigurer,
 ) *Server {
+	if configurer == nil {
+		configurer = &ConnConfigurer{}
+	}
 	return &Server{
 		Mounts: []*MountPoint{
 			{"Livez", "GET", "/livez"},
 			{"Readyz", "GET", "/readyz"},
+			{"Userinfo", "GET", "/userinfo"},
+			{"ProfileEvents", "GET", "/userinfo/events"},
+			{"AdminEvents", "GET", "/admin/events"},
+			{"GetUserMetadata", "GET", "/users/{sub}/metadata"},
+			{"UpdateCurrentUserMetadata", "PATCH", "/users/current/metadata"},
+			{"StartEmailLinking", "POST", "/users/current/email-linking"},
+			{"VerifyEmailLinking", "POST", "/users/current/email-linking/verify"},
+			{"ChangePrimaryEmail", "POST", "/users/current/primary-email"},
+			{"UploadCurrentUserPicture", "PUT", "/users/current/picture"},
+			{"ResolveProfileShare", "GET", "/profiles/shared/{token}"},
+			{"ListEventSchemas", "GET", "/events/schemas"},
+			{"GetEventSchema", "GET", "/events/schemas/{subject}"},
+			{"ListCallers", "GET", "/admin/callers"},
+			{"GetCaller", "GET", "/admin/callers/{id}"},
+			{"PutCaller", "PUT", "/admin/callers/{id}"},
+			{"DeleteCaller", "DELETE", "/admin/callers/{id}"},
+			{"GetAdminUserMetadata", "GET", "/admin/users/{user}/metadata"},
+			{"IssueEmailBackupCodes", "POST", "/admin/email-backup-codes"},
+			{"PurgeUserCache", "POST", "/admin/users/{user}/cache/purge"},
+			{"ListWebhookDeliveries", "GET", "/admin/webhook-deliveries"},
+			{"GetSupportBundle", "GET", "/admin/support-bundle"},
+			{"RenewM2mToken", "POST", "/admin/runbook/m2m-token/renew"},
+			{"RefreshJwks", "POST", "/admin/runbook/jwks/refresh"},
+			{"RebuildEmailIndex", "POST", "/admin/users/{user}/email-index/rebuild"},
 		},
-		Livez:  NewLivezHandler(e.Livez, mux, decoder, encoder, errhandler, formatter),
-		Readyz: NewReadyzHandler(e.Readyz, mux, decoder, encoder, errhandler, formatter),
+		Livez:                     NewLivezHandler(e.Livez, mux, decoder, encoder, errhandler, formatter),
+		Readyz:                    NewReadyzHandler(e.Readyz, mux, decoder, encoder, errhandler, formatter),
+		Userinfo:                  NewUserinfoHandler(e.Userinfo, mux, decoder, encoder, errhandler, formatter),
+		ProfileEvents:             NewProfileEventsHandler(e.ProfileEvents, mux, decoder, encoder, errhandler, formatter),
+		AdminEvents:               NewAdminEventsHandler(e.AdminEvents, mux, decoder, encoder, errhandler, formatter, upgrader, configurer.AdminEventsFn),
+		GetUserMetadata:           NewGetUserMetadataHandler(e.GetUserMetadata, mux, decoder, encoder, errhandler, formatter),
+		UpdateCurrentUserMetadata: NewUpdateCurrentUserMetadataHandler(e.UpdateCurrentUserMetadata, mux, decoder, encoder, errhandler, formatter),
+		StartEmailLinking:         NewStartEmailLinkingHandler(e.StartEmailLinking, mux, decoder, encoder, errhandler, formatter),
+		VerifyEmailLinking:        NewVerifyEmailLinkingHandler(e.VerifyEmailLinking, mux, decoder, encoder, errhandler, formatter),
+		ChangePrimaryEmail:        NewChangePrimaryEmailHandler(e.ChangePrimaryEmail, mux, decoder, encoder, errhandler, formatter),
+		UploadCurrentUserPicture:  NewUploadCurrentUserPictureHandler(e.UploadCurrentUserPicture, mux, decoder, encoder, errhandler, formatter),
+		ResolveProfileShare:       NewResolveProfileShareHandler(e.ResolveProfileShare, mux, decoder, encoder, errhandler, formatter),
+		ListEventSchemas:          NewListEventSchemasHandler(e.ListEventSchemas, mux, decoder, encoder, errhandler, formatter),
+		GetEventSchema:            NewGetEventSchemaHandler(e.GetEventSchema, mux, decoder, encoder, errhandler, formatter),
+		ListCallers:               NewListCallersHandler(e.ListCallers, mux, decoder, encoder, errhandler, formatter),
+		GetCaller:                 NewGetCallerHandler(e.GetCaller, mux, decoder, encoder, errhandler, formatter),
+		PutCaller:                 NewPutCallerHandler(e.PutCaller, mux, decoder, encoder, errhandler, formatter),
+		DeleteCaller:              NewDeleteCallerHandler(e.DeleteCaller, mux, decoder, encoder, errhandler, formatter),
+		GetAdminUserMetadata:      NewGetAdminUserMetadataHandler(e.GetAdminUserMetadata, mux, decoder, encoder, errhandler, formatter),
+		IssueEmailBackupCodes:     NewIssueEmailBackupCodesHandler(e.IssueEmailBackupCodes, mux, decoder, encoder, errhandler, formatter),
+		PurgeUserCache:            NewPurgeUserCacheHandler(e.PurgeUserCache, mux, decoder, encoder, errhandler, formatter),
+		ListWebhookDeliveries:     NewListWebhookDeliveriesHandler(e.ListWebhookDeliveries, mux, decoder, encoder, errhandler, formatter),
+		GetSupportBundle:          NewGetSupportBundleHandler(e.GetSupportBundle, mux, decoder, encoder, errhandler, formatter),
+		RenewM2mToken:             NewRenewM2mTokenHandler(e.RenewM2mToken, mux, decoder, encoder, errhandler, formatter),
+		RefreshJwks:               NewRefreshJwksHandler(e.RefreshJwks, mux, decoder, encoder, errhandler, formatter),
+		RebuildEmailIndex:         NewRebuildEmailIndexHandler(e.RebuildEmailIndex, mux, decoder, encoder, errhandler, formatter),
 	}
 }
 
@@ -65,6 +142,30 @@ func (s *Server) Service() string { return "auth-service" }
 func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.Livez = m(s.Livez)
 	s.Readyz = m(s.Readyz)
+	s.Userinfo = m(s.Userinfo)
+	s.ProfileEvents = m(s.ProfileEvents)
+	s.AdminEvents = m(s.AdminEvents)
+	s.GetUserMetadata = m(s.GetUserMetadata)
+	s.UpdateCurrentUserMetadata = m(s.UpdateCurrentUserMetadata)
+	s.StartEmailLinking = m(s.StartEmailLinking)
+	s.VerifyEmailLinking = m(s.VerifyEmailLinking)
+	s.ChangePrimaryEmail = m(s.ChangePrimaryEmail)
+	s.UploadCurrentUserPicture = m(s.UploadCurrentUserPicture)
+	s.ResolveProfileShare = m(s.ResolveProfileShare)
+	s.ListEventSchemas = m(s.ListEventSchemas)
+	s.GetEventSchema = m(s.GetEventSchema)
+	s.ListCallers = m(s.ListCallers)
+	s.GetCaller = m(s.GetCaller)
+	s.PutCaller = m(s.PutCaller)
+	s.DeleteCaller = m(s.DeleteCaller)
+	s.GetAdminUserMetadata = m(s.GetAdminUserMetadata)
+	s.IssueEmailBackupCodes = m(s.IssueEmailBackupCodes)
+	s.PurgeUserCache = m(s.PurgeUserCache)
+	s.ListWebhookDeliveries = m(s.ListWebhookDeliveries)
+	s.GetSupportBundle = m(s.GetSupportBundle)
+	s.RenewM2mToken = m(s.RenewM2mToken)
+	s.RefreshJwks = m(s.RefreshJwks)
+	s.RebuildEmailIndex = m(s.RebuildEmailIndex)
 }
 
 // MethodNames returns the methods served.
@@ -74,6 +175,30 @@ func (s *Server) MethodNames() []string { return authservice.MethodNames[:] }
 func Mount(mux goahttp.Muxer, h *Server) {
 	MountLivezHandler(mux, h.Livez)
 	MountReadyzHandler(mux, h.Readyz)
+	MountUserinfoHandler(mux, h.Userinfo)
+	MountProfileEventsHandler(mux, h.ProfileEvents)
+	MountAdminEventsHandler(mux, h.AdminEvents)
+	MountGetUserMetadataHandler(mux, h.GetUserMetadata)
+	MountUpdateCurrentUserMetadataHandler(mux, h.UpdateCurrentUserMetadata)
+	MountStartEmailLinkingHandler(mux, h.StartEmailLinking)
+	MountVerifyEmailLinkingHandler(mux, h.VerifyEmailLinking)
+	MountChangePrimaryEmailHandler(mux, h.ChangePrimaryEmail)
+	MountUploadCurrentUserPictureHandler(mux, h.UploadCurrentUserPicture)
+	MountResolveProfileShareHandler(mux, h.ResolveProfileShare)
+	MountListEventSchemasHandler(mux, h.ListEventSchemas)
+	MountGetEventSchemaHandler(mux, h.GetEventSchema)
+	MountListCallersHandler(mux, h.ListCallers)
+	MountGetCallerHandler(mux, h.GetCaller)
+	MountPutCallerHandler(mux, h.PutCaller)
+	MountDeleteCallerHandler(mux, h.DeleteCaller)
+	MountGetAdminUserMetadataHandler(mux, h.GetAdminUserMetadata)
+	MountIssueEmailBackupCodesHandler(mux, h.IssueEmailBackupCodes)
+	MountPurgeUserCacheHandler(mux, h.PurgeUserCache)
+	MountListWebhookDeliveriesHandler(mux, h.ListWebhookDeliveries)
+	MountGetSupportBundleHandler(mux, h.GetSupportBundle)
+	MountRenewM2mTokenHandler(mux, h.RenewM2mToken)
+	MountRefreshJwksHandler(mux, h.RefreshJwks)
+	MountRebuildEmailIndexHandler(mux, h.RebuildEmailIndex)
 }
 
 // Mount configures the mux to serve the auth-service endpoints.
@@ -172,3 +297,1297 @@ func NewReadyzHandler(
 		}
 	})
 }
+
+// MountUserinfoHandler configures the mux to serve the "auth-service" service
+// "userinfo" endpoint.
+func MountUserinfoHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/userinfo", f)
+}
+
+// NewUserinfoHandler creates a HTTP handler which loads the HTTP request and
+// calls the "auth-service" service "userinfo" endpoint.
+func NewUserinfoHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeUserinfoRequest(mux, decoder)
+		encodeResponse = EncodeUserinfoResponse(encoder)
+		encodeError    = EncodeUserinfoError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "userinfo")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountProfileEventsHandler configures the mux to serve the "auth-service"
+// service "profile_events" endpoint.
+func MountProfileEventsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/userinfo/events", f)
+}
+
+// NewProfileEventsHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "profile_events" endpoint.
+func NewProfileEventsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest = DecodeProfileEventsRequest(mux, decoder)
+		encodeError   = EncodeProfileEventsError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "profile_events")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		v := &authservice.ProfileEventsEndpointInput{
+			Stream: &ProfileEventsServerStream{
+				w: w,
+				r: r,
+			},
+			Payload: payload,
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+
+// MountAdminEventsHandler configures the mux to serve the "auth-service"
+// service "admin_events" endpoint.
+func MountAdminEventsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/events", f)
+}
+
+// NewAdminEventsHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "admin_events" endpoint.
+func NewAdminEventsHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	upgrader goahttp.Upgrader,
+	configurer goahttp.ConnConfigureFunc,
+) http.Handler {
+	var (
+		decodeRequest = DecodeAdminEventsRequest(mux, decoder)
+		encodeError   = EncodeAdminEventsError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "admin_events")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		v := &authservice.AdminEventsEndpointInput{
+			Stream: &AdminEventsServerStream{
+				upgrader:   upgrader,
+				configurer: configurer,
+				cancel:     cancel,
+				w:          w,
+				r:          r,
+			},
+			Payload: payload,
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			var stream *AdminEventsServerStream
+			if wrapper, ok := v.Stream.(interface{ Unwrap() any }); ok {
+				stream = wrapper.Unwrap().(*AdminEventsServerStream)
+			} else {
+				stream = v.Stream.(*AdminEventsServerStream)
+			}
+			if stream != nil && stream.conn != nil {
+				// Response writer has been hijacked, do not encode the error
+				if errhandler != nil {
+					errhandler(ctx, w, err)
+				}
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+
+// MountGetUserMetadataHandler configures the mux to serve the "auth-service"
+// service "get_user_metadata" endpoint.
+func MountGetUserMetadataHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/users/{sub}/metadata", f)
+}
+
+// NewGetUserMetadataHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "get_user_metadata" endpoint.
+func NewGetUserMetadataHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetUserMetadataRequest(mux, decoder)
+		encodeResponse = EncodeGetUserMetadataResponse(encoder)
+		encodeError    = EncodeGetUserMetadataError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "get_user_metadata")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountUpdateCurrentUserMetadataHandler configures the mux to serve the
+// "auth-service" service "update_current_user_metadata" endpoint.
+func MountUpdateCurrentUserMetadataHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PATCH", "/users/current/metadata", f)
+}
+
+// NewUpdateCurrentUserMetadataHandler creates a HTTP handler which loads the
+// HTTP request and calls the "auth-service" service
+// "update_current_user_metadata" endpoint.
+func NewUpdateCurrentUserMetadataHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeUpdateCurrentUserMetadataRequest(mux, decoder)
+		encodeResponse = EncodeUpdateCurrentUserMetadataResponse(encoder)
+		encodeError    = EncodeUpdateCurrentUserMetadataError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "update_current_user_metadata")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountStartEmailLinkingHandler configures the mux to serve the "auth-service"
+// service "start_email_linking" endpoint.
+func MountStartEmailLinkingHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/users/current/email-linking", f)
+}
+
+// NewStartEmailLinkingHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "start_email_linking" endpoint.
+func NewStartEmailLinkingHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeStartEmailLinkingRequest(mux, decoder)
+		encodeResponse = EncodeStartEmailLinkingResponse(encoder)
+		encodeError    = EncodeStartEmailLinkingError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "start_email_linking")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountVerifyEmailLinkingHandler configures the mux to serve the
+// "auth-service" service "verify_email_linking" endpoint.
+func MountVerifyEmailLinkingHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/users/current/email-linking/verify", f)
+}
+
+// NewVerifyEmailLinkingHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "verify_email_linking" endpoint.
+func NewVerifyEmailLinkingHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeVerifyEmailLinkingRequest(mux, decoder)
+		encodeResponse = EncodeVerifyEmailLinkingResponse(encoder)
+		encodeError    = EncodeVerifyEmailLinkingError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "verify_email_linking")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountChangePrimaryEmailHandler configures the mux to serve the
+// "auth-service" service "change_primary_email" endpoint.
+func MountChangePrimaryEmailHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/users/current/primary-email", f)
+}
+
+// NewChangePrimaryEmailHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "change_primary_email" endpoint.
+func NewChangePrimaryEmailHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeChangePrimaryEmailRequest(mux, decoder)
+		encodeResponse = EncodeChangePrimaryEmailResponse(encoder)
+		encodeError    = EncodeChangePrimaryEmailError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "change_primary_email")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountUploadCurrentUserPictureHandler configures the mux to serve the
+// "auth-service" service "upload_current_user_picture" endpoint.
+func MountUploadCurrentUserPictureHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/users/current/picture", f)
+}
+
+// NewUploadCurrentUserPictureHandler creates a HTTP handler which loads the
+// HTTP request and calls the "auth-service" service
+// "upload_current_user_picture" endpoint.
+func NewUploadCurrentUserPictureHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeUploadCurrentUserPictureRequest(mux, decoder)
+		encodeResponse = EncodeUploadCurrentUserPictureResponse(encoder)
+		encodeError    = EncodeUploadCurrentUserPictureError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "upload_current_user_picture")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		data := &authservice.UploadCurrentUserPictureRequestData{Payload: payload, Body: r.Body}
+		res, err := endpoint(ctx, data)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountResolveProfileShareHandler configures the mux to serve the
+// "auth-service" service "resolve_profile_share" endpoint.
+func MountResolveProfileShareHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/profiles/shared/{token}", f)
+}
+
+// NewResolveProfileShareHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "resolve_profile_share"
+// endpoint.
+func NewResolveProfileShareHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeResolveProfileShareRequest(mux, decoder)
+		encodeResponse = EncodeResolveProfileShareResponse(encoder)
+		encodeError    = EncodeResolveProfileShareError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "resolve_profile_share")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountListEventSchemasHandler configures the mux to serve the "auth-service"
+// service "list_event_schemas" endpoint.
+func MountListEventSchemasHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/events/schemas", f)
+}
+
+// NewListEventSchemasHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "list_event_schemas" endpoint.
+func NewListEventSchemasHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeResponse = EncodeListEventSchemasResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "list_event_schemas")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		var err error
+		res, err := endpoint(ctx, nil)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountGetEventSchemaHandler configures the mux to serve the "auth-service"
+// service "get_event_schema" endpoint.
+func MountGetEventSchemaHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/events/schemas/{subject}", f)
+}
+
+// NewGetEventSchemaHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "get_event_schema" endpoint.
+func NewGetEventSchemaHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetEventSchemaRequest(mux, decoder)
+		encodeResponse = EncodeGetEventSchemaResponse(encoder)
+		encodeError    = EncodeGetEventSchemaError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "get_event_schema")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountListCallersHandler configures the mux to serve the "auth-service"
+// service "list_callers" endpoint.
+func MountListCallersHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/callers", f)
+}
+
+// NewListCallersHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "list_callers" endpoint.
+func NewListCallersHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListCallersRequest(mux, decoder)
+		encodeResponse = EncodeListCallersResponse(encoder)
+		encodeError    = EncodeListCallersError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "list_callers")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountGetCallerHandler configures the mux to serve the "auth-service" service
+// "get_caller" endpoint.
+func MountGetCallerHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/callers/{id}", f)
+}
+
+// NewGetCallerHandler creates a HTTP handler which loads the HTTP request and
+// calls the "auth-service" service "get_caller" endpoint.
+func NewGetCallerHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetCallerRequest(mux, decoder)
+		encodeResponse = EncodeGetCallerResponse(encoder)
+		encodeError    = EncodeGetCallerError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "get_caller")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountPutCallerHandler configures the mux to serve the "auth-service" service
+// "put_caller" endpoint.
+func MountPutCallerHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("PUT", "/admin/callers/{id}", f)
+}
+
+// NewPutCallerHandler creates a HTTP handler which loads the HTTP request and
+// calls the "auth-service" service "put_caller" endpoint.
+func NewPutCallerHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePutCallerRequest(mux, decoder)
+		encodeResponse = EncodePutCallerResponse(encoder)
+		encodeError    = EncodePutCallerError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "put_caller")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountDeleteCallerHandler configures the mux to serve the "auth-service"
+// service "delete_caller" endpoint.
+func MountDeleteCallerHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/admin/callers/{id}", f)
+}
+
+// NewDeleteCallerHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "delete_caller" endpoint.
+func NewDeleteCallerHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeDeleteCallerRequest(mux, decoder)
+		encodeResponse = EncodeDeleteCallerResponse(encoder)
+		encodeError    = EncodeDeleteCallerError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "delete_caller")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountGetAdminUserMetadataHandler configures the mux to serve the
+// "auth-service" service "get_admin_user_metadata" endpoint.
+func MountGetAdminUserMetadataHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/users/{user}/metadata", f)
+}
+
+// NewGetAdminUserMetadataHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "get_admin_user_metadata"
+// endpoint.
+func NewGetAdminUserMetadataHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetAdminUserMetadataRequest(mux, decoder)
+		encodeResponse = EncodeGetAdminUserMetadataResponse(encoder)
+		encodeError    = EncodeGetAdminUserMetadataError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "get_admin_user_metadata")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountIssueEmailBackupCodesHandler configures the mux to serve the
+// "auth-service" service "issue_email_backup_codes" endpoint.
+func MountIssueEmailBackupCodesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/admin/email-backup-codes", f)
+}
+
+// NewIssueEmailBackupCodesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "issue_email_backup_codes"
+// endpoint.
+func NewIssueEmailBackupCodesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeIssueEmailBackupCodesRequest(mux, decoder)
+		encodeResponse = EncodeIssueEmailBackupCodesResponse(encoder)
+		encodeError    = EncodeIssueEmailBackupCodesError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "issue_email_backup_codes")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountPurgeUserCacheHandler configures the mux to serve the "auth-service"
+// service "purge_user_cache" endpoint.
+func MountPurgeUserCacheHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/admin/users/{user}/cache/purge", f)
+}
+
+// NewPurgeUserCacheHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "purge_user_cache" endpoint.
+func NewPurgeUserCacheHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePurgeUserCacheRequest(mux, decoder)
+		encodeResponse = EncodePurgeUserCacheResponse(encoder)
+		encodeError    = EncodePurgeUserCacheError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "purge_user_cache")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountListWebhookDeliveriesHandler configures the mux to serve the
+// "auth-service" service "list_webhook_deliveries" endpoint.
+func MountListWebhookDeliveriesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/webhook-deliveries", f)
+}
+
+// NewListWebhookDeliveriesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "list_webhook_deliveries"
+// endpoint.
+func NewListWebhookDeliveriesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListWebhookDeliveriesRequest(mux, decoder)
+		encodeResponse = EncodeListWebhookDeliveriesResponse(encoder)
+		encodeError    = EncodeListWebhookDeliveriesError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "list_webhook_deliveries")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountGetSupportBundleHandler configures the mux to serve the "auth-service"
+// service "get_support_bundle" endpoint.
+func MountGetSupportBundleHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/admin/support-bundle", f)
+}
+
+// NewGetSupportBundleHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "get_support_bundle" endpoint.
+func NewGetSupportBundleHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetSupportBundleRequest(mux, decoder)
+		encodeResponse = EncodeGetSupportBundleResponse(encoder)
+		encodeError    = EncodeGetSupportBundleError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "get_support_bundle")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountRenewM2mTokenHandler configures the mux to serve the "auth-service"
+// service "renew_m2m_token" endpoint.
+func MountRenewM2mTokenHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/admin/runbook/m2m-token/renew", f)
+}
+
+// NewRenewM2mTokenHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "renew_m2m_token" endpoint.
+func NewRenewM2mTokenHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRenewM2mTokenRequest(mux, decoder)
+		encodeResponse = EncodeRenewM2mTokenResponse(encoder)
+		encodeError    = EncodeRenewM2mTokenError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "renew_m2m_token")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountRefreshJwksHandler configures the mux to serve the "auth-service"
+// service "refresh_jwks" endpoint.
+func MountRefreshJwksHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/admin/runbook/jwks/refresh", f)
+}
+
+// NewRefreshJwksHandler creates a HTTP handler which loads the HTTP request
+// and calls the "auth-service" service "refresh_jwks" endpoint.
+func NewRefreshJwksHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRefreshJwksRequest(mux, decoder)
+		encodeResponse = EncodeRefreshJwksResponse(encoder)
+		encodeError    = EncodeRefreshJwksError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "refresh_jwks")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountRebuildEmailIndexHandler configures the mux to serve the "auth-service"
+// service "rebuild_email_index" endpoint.
+func MountRebuildEmailIndexHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/admin/users/{user}/email-index/rebuild", f)
+}
+
+// NewRebuildEmailIndexHandler creates a HTTP handler which loads the HTTP
+// request and calls the "auth-service" service "rebuild_email_index" endpoint.
+func NewRebuildEmailIndexHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeRebuildEmailIndexRequest(mux, decoder)
+		encodeResponse = EncodeRebuildEmailIndexResponse(encoder)
+		encodeError    = EncodeRebuildEmailIndexError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "rebuild_email_index")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "auth-service")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
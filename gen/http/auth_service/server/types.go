@@ -6,3 +6,2530 @@
 // $ goa gen github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/design
 
 package server
+
+import (
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// PutCallerRequestBody is the type of the "auth-service" service "put_caller"
+// endpoint HTTP request body.
+type PutCallerRequestBody struct {
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service
+	Capabilities []string `form:"capabilities,omitempty" json:"capabilities,omitempty" xml:"capabilities,omitempty"`
+}
+
+// IssueEmailBackupCodesRequestBody is the type of the "auth-service" service
+// "issue_email_backup_codes" endpoint HTTP request body.
+type IssueEmailBackupCodesRequestBody struct {
+	// Email to verify
+	Email *string `form:"email,omitempty" json:"email,omitempty" xml:"email,omitempty"`
+}
+
+// UserinfoResponseBody is the type of the "auth-service" service "userinfo"
+// endpoint HTTP response body.
+type UserinfoResponseBody struct {
+	// Subject identifier
+	Sub string `form:"sub" json:"sub" xml:"sub"`
+	// Full name
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Given name
+	GivenName *string `form:"given_name,omitempty" json:"given_name,omitempty" xml:"given_name,omitempty"`
+	// Family name
+	FamilyName *string `form:"family_name,omitempty" json:"family_name,omitempty" xml:"family_name,omitempty"`
+	// Username
+	PreferredUsername *string `form:"preferred_username,omitempty" json:"preferred_username,omitempty" xml:"preferred_username,omitempty"`
+	// Profile picture URL
+	Picture *string `form:"picture,omitempty" json:"picture,omitempty" xml:"picture,omitempty"`
+	// Primary email
+	Email *string `form:"email,omitempty" json:"email,omitempty" xml:"email,omitempty"`
+	// Time zone
+	Zoneinfo *string `form:"zoneinfo,omitempty" json:"zoneinfo,omitempty" xml:"zoneinfo,omitempty"`
+	// Phone number
+	PhoneNumber *string `form:"phone_number,omitempty" json:"phone_number,omitempty" xml:"phone_number,omitempty"`
+	// Postal address
+	Address *UserInfoAddressResponseBody `form:"address,omitempty" json:"address,omitempty" xml:"address,omitempty"`
+}
+
+// ProfileEventsResponseBody is the type of the "auth-service" service
+// "profile_events" endpoint HTTP response body.
+type ProfileEventsResponseBody struct {
+	// Subject identifier of the user
+	Sub string `form:"sub" json:"sub" xml:"sub"`
+	// Operation that changed the profile
+	Reason string `form:"reason" json:"reason" xml:"reason"`
+	// Time of the change
+	ChangedAt string `form:"changed_at" json:"changed_at" xml:"changed_at"`
+}
+
+// AdminEventsResponseBody is the type of the "auth-service" service
+// "admin_events" endpoint HTTP response body.
+type AdminEventsResponseBody struct {
+	// Kind of the event
+	Kind string `form:"kind" json:"kind" xml:"kind"`
+	// Time the event was bridged
+	At string `form:"at" json:"at" xml:"at"`
+	// Event data, the user identifiers are redacted
+	Data map[string]any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Number of events dropped before this one because the client was behind
+	Dropped *int `form:"dropped,omitempty" json:"dropped,omitempty" xml:"dropped,omitempty"`
+}
+
+// GetUserMetadataResponseBody is the type of the "auth-service" service
+// "get_user_metadata" endpoint HTTP response body.
+type GetUserMetadataResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataResponseBody is the type of the "auth-service"
+// service "update_current_user_metadata" endpoint HTTP response body.
+type UpdateCurrentUserMetadataResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingResponseBody is the type of the "auth-service" service
+// "start_email_linking" endpoint HTTP response body.
+type StartEmailLinkingResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingResponseBody is the type of the "auth-service" service
+// "verify_email_linking" endpoint HTTP response body.
+type VerifyEmailLinkingResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailResponseBody is the type of the "auth-service" service
+// "change_primary_email" endpoint HTTP response body.
+type ChangePrimaryEmailResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureResponseBody is the type of the "auth-service"
+// service "upload_current_user_picture" endpoint HTTP response body.
+type UploadCurrentUserPictureResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ResolveProfileShareResponseBody is the type of the "auth-service" service
+// "resolve_profile_share" endpoint HTTP response body.
+type ResolveProfileShareResponseBody struct {
+	// Username
+	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Full name
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// Profile picture URL
+	Picture *string `form:"picture,omitempty" json:"picture,omitempty" xml:"picture,omitempty"`
+	// Job title
+	JobTitle *string `form:"job_title,omitempty" json:"job_title,omitempty" xml:"job_title,omitempty"`
+	// Organization
+	Organization *string `form:"organization,omitempty" json:"organization,omitempty" xml:"organization,omitempty"`
+	// Whether the organization matches a verified email domain
+	OrganizationVerified *bool `form:"organization_verified,omitempty" json:"organization_verified,omitempty" xml:"organization_verified,omitempty"`
+}
+
+// ListEventSchemasResponseBody is the type of the "auth-service" service
+// "list_event_schemas" endpoint HTTP response body.
+type ListEventSchemasResponseBody []*EventSchemaResponse
+
+// GetEventSchemaResponseBody is the type of the "auth-service" service
+// "get_event_schema" endpoint HTTP response body.
+type GetEventSchemaResponseBody struct {
+	// NATS subject the events are published on
+	Subject string `form:"subject" json:"subject" xml:"subject"`
+	// Name of the event type
+	Title string `form:"title" json:"title" xml:"title"`
+	// JSON Schema (draft 2020-12) of the events
+	Schema any `form:"schema" json:"schema" xml:"schema"`
+}
+
+// ListCallersResponseBody is the type of the "auth-service" service
+// "list_callers" endpoint HTTP response body.
+type ListCallersResponseBody []*CallerAllowlistEntryResponse
+
+// GetCallerResponseBody is the type of the "auth-service" service "get_caller"
+// endpoint HTTP response body.
+type GetCallerResponseBody struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID string `form:"id" json:"id" xml:"id"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities" json:"capabilities" xml:"capabilities"`
+	// Time the entry was created
+	CreatedAt string `form:"created_at" json:"created_at" xml:"created_at"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt string `form:"updated_at" json:"updated_at" xml:"updated_at"`
+}
+
+// PutCallerResponseBody is the type of the "auth-service" service "put_caller"
+// endpoint HTTP response body.
+type PutCallerResponseBody struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID string `form:"id" json:"id" xml:"id"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities" json:"capabilities" xml:"capabilities"`
+	// Time the entry was created
+	CreatedAt string `form:"created_at" json:"created_at" xml:"created_at"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt string `form:"updated_at" json:"updated_at" xml:"updated_at"`
+}
+
+// GetAdminUserMetadataResponseBody is the type of the "auth-service" service
+// "get_admin_user_metadata" endpoint HTTP response body.
+type GetAdminUserMetadataResponseBody struct {
+	// Identifier of the user
+	UserID string `form:"user_id" json:"user_id" xml:"user_id"`
+	// Username of the user
+	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Metadata of the user
+	UserMetadata map[string]any `form:"user_metadata" json:"user_metadata" xml:"user_metadata"`
+	// Provenance of the metadata fields, the fields without provenance were set
+	// before it was tracked or by the identity provider
+	Provenance map[string]*FieldProvenanceResponseBody `form:"provenance" json:"provenance" xml:"provenance"`
+}
+
+// IssueEmailBackupCodesResponseBody is the type of the "auth-service" service
+// "issue_email_backup_codes" endpoint HTTP response body.
+type IssueEmailBackupCodesResponseBody struct {
+	// Email the codes verify
+	Email string `form:"email" json:"email" xml:"email"`
+	// One-time codes, sent as the OTP of the email verification. They are only
+	// returned here.
+	Codes []string `form:"codes" json:"codes" xml:"codes"`
+	// Time the codes expire
+	ExpiresAt string `form:"expires_at" json:"expires_at" xml:"expires_at"`
+}
+
+// ListWebhookDeliveriesResponseBody is the type of the "auth-service" service
+// "list_webhook_deliveries" endpoint HTTP response body.
+type ListWebhookDeliveriesResponseBody struct {
+	// Last deliveries, the most recent first
+	Deliveries []*WebhookDeliveryResponseBody `form:"deliveries" json:"deliveries" xml:"deliveries"`
+}
+
+// GetSupportBundleResponseBody is the type of the "auth-service" service
+// "get_support_bundle" endpoint HTTP response body.
+type GetSupportBundleResponseBody struct {
+	// Time the bundle was generated
+	GeneratedAt string `form:"generated_at" json:"generated_at" xml:"generated_at"`
+	// Build of the replica
+	Build *SupportBundleBuildResponseBody `form:"build" json:"build" xml:"build"`
+	// Effective configuration from the environment, the secrets are redacted
+	Config map[string]string `form:"config" json:"config" xml:"config"`
+	// Problems found in the configuration
+	ConfigErrors []*SupportBundleConfigErrorResponseBody `form:"config_errors" json:"config_errors" xml:"config_errors"`
+	// Recent health of the upstream identity providers
+	Providers []*SupportBundleProviderResponseBody `form:"providers" json:"providers" xml:"providers"`
+	// Usage of the users cache, when enabled
+	UserCache *SupportBundleUserCacheResponseBody `form:"user_cache,omitempty" json:"user_cache,omitempty" xml:"user_cache,omitempty"`
+	// Last sync of the Authelia users seen by the replica, if any
+	LastSync *SupportBundleSyncStatusResponseBody `form:"last_sync,omitempty" json:"last_sync,omitempty" xml:"last_sync,omitempty"`
+}
+
+// RenewM2mTokenResponseBody is the type of the "auth-service" service
+// "renew_m2m_token" endpoint HTTP response body.
+type RenewM2mTokenResponseBody struct {
+	// Audit action of the operation
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Target of the operation
+	Target string `form:"target" json:"target" xml:"target"`
+	// Outcome of the operation
+	Outcome string `form:"outcome" json:"outcome" xml:"outcome"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps" json:"steps" xml:"steps"`
+	// Time of the operation
+	At string `form:"at" json:"at" xml:"at"`
+}
+
+// RefreshJwksResponseBody is the type of the "auth-service" service
+// "refresh_jwks" endpoint HTTP response body.
+type RefreshJwksResponseBody struct {
+	// Audit action of the operation
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Target of the operation
+	Target string `form:"target" json:"target" xml:"target"`
+	// Outcome of the operation
+	Outcome string `form:"outcome" json:"outcome" xml:"outcome"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps" json:"steps" xml:"steps"`
+	// Time of the operation
+	At string `form:"at" json:"at" xml:"at"`
+}
+
+// RebuildEmailIndexResponseBody is the type of the "auth-service" service
+// "rebuild_email_index" endpoint HTTP response body.
+type RebuildEmailIndexResponseBody struct {
+	// Audit action of the operation
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Target of the operation
+	Target string `form:"target" json:"target" xml:"target"`
+	// Outcome of the operation
+	Outcome string `form:"outcome" json:"outcome" xml:"outcome"`
+	// Steps of the operation, in the order they were run
+	Steps []*RunbookStepResponseBody `form:"steps" json:"steps" xml:"steps"`
+	// Time of the operation
+	At string `form:"at" json:"at" xml:"at"`
+}
+
+// GetUserMetadataFailedForbiddenResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the
+// "FailedForbidden" error.
+type GetUserMetadataFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedNotFoundResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the
+// "FailedNotFound" error.
+type GetUserMetadataFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedResponseBody is the type of the "auth-service" service
+// "get_user_metadata" endpoint HTTP response body for the "Failed" error.
+type GetUserMetadataFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "get_user_metadata" endpoint HTTP response body for
+// the "FailedUnauthorized" error.
+type GetUserMetadataFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// GetUserMetadataRetryableResponseBody is the type of the "auth-service"
+// service "get_user_metadata" endpoint HTTP response body for the "Retryable"
+// error.
+type GetUserMetadataRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedForbiddenResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedForbidden" error.
+type UpdateCurrentUserMetadataFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedNotFoundResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedNotFound" error.
+type UpdateCurrentUserMetadataFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "Failed" error.
+type UpdateCurrentUserMetadataFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "FailedUnauthorized" error.
+type UpdateCurrentUserMetadataFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UpdateCurrentUserMetadataRetryableResponseBody is the type of the
+// "auth-service" service "update_current_user_metadata" endpoint HTTP response
+// body for the "Retryable" error.
+type UpdateCurrentUserMetadataRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedForbiddenResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedForbidden" error.
+type StartEmailLinkingFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedNotFoundResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedNotFound" error.
+type StartEmailLinkingFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedResponseBody is the type of the "auth-service"
+// service "start_email_linking" endpoint HTTP response body for the "Failed"
+// error.
+type StartEmailLinkingFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "start_email_linking" endpoint HTTP response body for
+// the "FailedUnauthorized" error.
+type StartEmailLinkingFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// StartEmailLinkingRetryableResponseBody is the type of the "auth-service"
+// service "start_email_linking" endpoint HTTP response body for the
+// "Retryable" error.
+type StartEmailLinkingRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedForbiddenResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedForbidden" error.
+type VerifyEmailLinkingFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedNotFoundResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedNotFound" error.
+type VerifyEmailLinkingFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedResponseBody is the type of the "auth-service"
+// service "verify_email_linking" endpoint HTTP response body for the "Failed"
+// error.
+type VerifyEmailLinkingFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "verify_email_linking" endpoint HTTP response body
+// for the "FailedUnauthorized" error.
+type VerifyEmailLinkingFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// VerifyEmailLinkingRetryableResponseBody is the type of the "auth-service"
+// service "verify_email_linking" endpoint HTTP response body for the
+// "Retryable" error.
+type VerifyEmailLinkingRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedForbiddenResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedForbidden" error.
+type ChangePrimaryEmailFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedNotFoundResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedNotFound" error.
+type ChangePrimaryEmailFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedResponseBody is the type of the "auth-service"
+// service "change_primary_email" endpoint HTTP response body for the "Failed"
+// error.
+type ChangePrimaryEmailFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "change_primary_email" endpoint HTTP response body
+// for the "FailedUnauthorized" error.
+type ChangePrimaryEmailFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// ChangePrimaryEmailRetryableResponseBody is the type of the "auth-service"
+// service "change_primary_email" endpoint HTTP response body for the
+// "Retryable" error.
+type ChangePrimaryEmailRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedForbiddenResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedForbidden" error.
+type UploadCurrentUserPictureFailedForbiddenResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedNotFoundResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedNotFound" error.
+type UploadCurrentUserPictureFailedNotFoundResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedResponseBody is the type of the "auth-service"
+// service "upload_current_user_picture" endpoint HTTP response body for the
+// "Failed" error.
+type UploadCurrentUserPictureFailedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureFailedUnauthorizedResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "FailedUnauthorized" error.
+type UploadCurrentUserPictureFailedUnauthorizedResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UploadCurrentUserPictureRetryableResponseBody is the type of the
+// "auth-service" service "upload_current_user_picture" endpoint HTTP response
+// body for the "Retryable" error.
+type UploadCurrentUserPictureRetryableResponseBody struct {
+	// Whether the operation succeeded
+	Success bool `form:"success" json:"success" xml:"success"`
+	// Outcome of the operation, when it has no data
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+	// Data of the operation, the same as the data of the NATS reply
+	Data any `form:"data,omitempty" json:"data,omitempty" xml:"data,omitempty"`
+	// Reason of the failure
+	Reason *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Stable identifier of the failure, like the error_code of the NATS reply
+	ErrorCode *string `form:"error_code,omitempty" json:"error_code,omitempty" xml:"error_code,omitempty"`
+	// Whether the failure is transient
+	Retryable *bool `form:"retryable,omitempty" json:"retryable,omitempty" xml:"retryable,omitempty"`
+	// Delay before retrying a transient failure, in milliseconds
+	RetryAfterMs *int64 `form:"retry_after_ms,omitempty" json:"retry_after_ms,omitempty" xml:"retry_after_ms,omitempty"`
+	// Whether the data was served from the users cache while the identity provider
+	// is unavailable
+	Stale *bool `form:"stale,omitempty" json:"stale,omitempty" xml:"stale,omitempty"`
+	// How long ago the stale data was cached, in seconds
+	Age *int64 `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"`
+}
+
+// UserInfoAddressResponseBody is used to define fields on response body types.
+type UserInfoAddressResponseBody struct {
+	// Street address
+	StreetAddress *string `form:"street_address,omitempty" json:"street_address,omitempty" xml:"street_address,omitempty"`
+	// City or locality
+	Locality *string `form:"locality,omitempty" json:"locality,omitempty" xml:"locality,omitempty"`
+	// State, province or region
+	Region *string `form:"region,omitempty" json:"region,omitempty" xml:"region,omitempty"`
+	// Zip or postal code
+	PostalCode *string `form:"postal_code,omitempty" json:"postal_code,omitempty" xml:"postal_code,omitempty"`
+	// Country name
+	Country *string `form:"country,omitempty" json:"country,omitempty" xml:"country,omitempty"`
+}
+
+// EventSchemaResponse is used to define fields on response body types.
+type EventSchemaResponse struct {
+	// NATS subject the events are published on
+	Subject string `form:"subject" json:"subject" xml:"subject"`
+	// Name of the event type
+	Title string `form:"title" json:"title" xml:"title"`
+	// JSON Schema (draft 2020-12) of the events
+	Schema any `form:"schema" json:"schema" xml:"schema"`
+}
+
+// CallerAllowlistEntryResponse is used to define fields on response body types.
+type CallerAllowlistEntryResponse struct {
+	// Calling service, as named by its service token in the X-Caller-Token header
+	ID string `form:"id" json:"id" xml:"id"`
+	// Free text description of the entry
+	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Capabilities granted to the calling service, sorted
+	Capabilities []string `form:"capabilities" json:"capabilities" xml:"capabilities"`
+	// Time the entry was created
+	CreatedAt string `form:"created_at" json:"created_at" xml:"created_at"`
+	// Time the entry last changed, putting the same state again doesn't change it
+	UpdatedAt string `form:"updated_at" json:"updated_at" xml:"updated_at"`
+}
+
+// FieldProvenanceResponseBody is used to define fields on response body types.
+type FieldProvenanceResponseBody struct {
+	// Who set the value
+	Source string `form:"source" json:"source" xml:"source"`
+	// Subject of the user or admin, or calling service of the enrichment job
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty" xml:"actor,omitempty"`
+	// Time the value was set
+	UpdatedAt string `form:"updated_at" json:"updated_at" xml:"updated_at"`
+}
+
+// WebhookDeliveryResponseBody is used to define fields on response body types.
+type WebhookDeliveryResponseBody struct {
+	// Action of the audit event
+	Action string `form:"action" json:"action" xml:"action"`
+	// HTTP status of the webhook reply, absent when the webhook wasn't reached
+	StatusCode *int `form:"status_code,omitempty" json:"status_code,omitempty" xml:"status_code,omitempty"`
+	// Error of the failed delivery
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Duration of the delivery in milliseconds
+	DurationMs int64 `form:"duration_ms" json:"duration_ms" xml:"duration_ms"`
+	// Time of the delivery
+	DeliveredAt string `form:"delivered_at" json:"delivered_at" xml:"delivered_at"`
+}
+
+// SupportBundleBuildResponseBody is used to define fields on response body
+// types.
+type SupportBundleBuildResponseBody struct {
+	// Version of the service
+	Version string `form:"version" json:"version" xml:"version"`
+	// Time the binary was built
+	BuildTime string `form:"build_time" json:"build_time" xml:"build_time"`
+	// Commit the binary was built from
+	GitCommit string `form:"git_commit" json:"git_commit" xml:"git_commit"`
+	// Go runtime version
+	GoVersion string `form:"go_version" json:"go_version" xml:"go_version"`
+}
+
+// SupportBundleConfigErrorResponseBody is used to define fields on response
+// body types.
+type SupportBundleConfigErrorResponseBody struct {
+	// Part of the service the configuration belongs to
+	Component string `form:"component" json:"component" xml:"component"`
+	// Environment variable at fault, when it can be pinned down
+	Key *string `form:"key,omitempty" json:"key,omitempty" xml:"key,omitempty"`
+	// Description of the problem
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// SupportBundleProviderResponseBody is used to define fields on response body
+// types.
+type SupportBundleProviderResponseBody struct {
+	// Identity provider
+	Provider string `form:"provider" json:"provider" xml:"provider"`
+	// Number of upstream calls in the window, retries included
+	Samples int `form:"samples" json:"samples" xml:"samples"`
+	// Number of failed upstream calls in the window
+	Failures int `form:"failures" json:"failures" xml:"failures"`
+	// Ratio of failed upstream calls
+	ErrorRate float64 `form:"error_rate" json:"error_rate" xml:"error_rate"`
+	// 95th percentile latency of the calls, in milliseconds
+	P95Ms int64 `form:"p95_ms" json:"p95_ms" xml:"p95_ms"`
+	// State of the circuit of the provider
+	CircuitState string `form:"circuit_state" json:"circuit_state" xml:"circuit_state"`
+	// Time of the last failed call in the window
+	LastFailureAt *string `form:"last_failure_at,omitempty" json:"last_failure_at,omitempty" xml:"last_failure_at,omitempty"`
+}
+
+// SupportBundleUserCacheResponseBody is used to define fields on response body
+// types.
+type SupportBundleUserCacheResponseBody struct {
+	// Users cache backend
+	Kind string `form:"kind" json:"kind" xml:"kind"`
+	// Number of lookups served from the cache
+	Hits int64 `form:"hits" json:"hits" xml:"hits"`
+	// Number of lookups sent to the identity provider
+	Misses int64 `form:"misses" json:"misses" xml:"misses"`
+	// Number of cached lookup keys, only reported by the memory cache
+	Entries *int `form:"entries,omitempty" json:"entries,omitempty" xml:"entries,omitempty"`
+}
+
+// SupportBundleSyncStatusResponseBody is used to define fields on response
+// body types.
+type SupportBundleSyncStatusResponseBody struct {
+	// Whether the sync succeeded
+	Succeeded bool `form:"succeeded" json:"succeeded" xml:"succeeded"`
+	// Number of users changed by the sync
+	Changed int `form:"changed" json:"changed" xml:"changed"`
+	// Error of the failed sync
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Time of the sync
+	SyncedAt string `form:"synced_at" json:"synced_at" xml:"synced_at"`
+}
+
+// RunbookStepResponseBody is used to define fields on response body types.
+type RunbookStepResponseBody struct {
+	// Name of the step
+	Name string `form:"name" json:"name" xml:"name"`
+	// Outcome of the step
+	Outcome string `form:"outcome" json:"outcome" xml:"outcome"`
+	// Detail of the step, the error when it failed
+	Detail *string `form:"detail,omitempty" json:"detail,omitempty" xml:"detail,omitempty"`
+}
+
+// NewUserinfoResponseBody builds the HTTP response body from the result of the
+// "userinfo" endpoint of the "auth-service" service.
+func NewUserinfoResponseBody(res *authservice.UserInfo) *UserinfoResponseBody {
+	body := &UserinfoResponseBody{
+		Sub:               res.Sub,
+		Name:              res.Name,
+		GivenName:         res.GivenName,
+		FamilyName:        res.FamilyName,
+		PreferredUsername: res.PreferredUsername,
+		Picture:           res.Picture,
+		Email:             res.Email,
+		Zoneinfo:          res.Zoneinfo,
+		PhoneNumber:       res.PhoneNumber,
+	}
+	if res.Address != nil {
+		body.Address = marshalAuthserviceUserInfoAddressToUserInfoAddressResponseBody(res.Address)
+	}
+	return body
+}
+
+// NewProfileEventsResponseBody builds the HTTP response body from the result
+// of the "profile_events" endpoint of the "auth-service" service.
+func NewProfileEventsResponseBody(res *authservice.ProfileChange) *ProfileEventsResponseBody {
+	body := &ProfileEventsResponseBody{
+		Sub:       res.Sub,
+		Reason:    res.Reason,
+		ChangedAt: res.ChangedAt,
+	}
+	return body
+}
+
+// NewAdminEventsResponseBody builds the HTTP response body from the result of
+// the "admin_events" endpoint of the "auth-service" service.
+func NewAdminEventsResponseBody(res *authservice.AdminEvent) *AdminEventsResponseBody {
+	body := &AdminEventsResponseBody{
+		Kind:    res.Kind,
+		At:      res.At,
+		Dropped: res.Dropped,
+	}
+	if res.Data != nil {
+		body.Data = make(map[string]any, len(res.Data))
+		for key, val := range res.Data {
+			tk := key
+			tv := val
+			body.Data[tk] = tv
+		}
+	}
+	return body
+}
+
+// NewGetUserMetadataResponseBody builds the HTTP response body from the result
+// of the "get_user_metadata" endpoint of the "auth-service" service.
+func NewGetUserMetadataResponseBody(res *authservice.UserDataReply) *GetUserMetadataResponseBody {
+	body := &GetUserMetadataResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataResponseBody builds the HTTP response body from
+// the result of the "update_current_user_metadata" endpoint of the
+// "auth-service" service.
+func NewUpdateCurrentUserMetadataResponseBody(res *authservice.UserDataReply) *UpdateCurrentUserMetadataResponseBody {
+	body := &UpdateCurrentUserMetadataResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingResponseBody builds the HTTP response body from the
+// result of the "start_email_linking" endpoint of the "auth-service" service.
+func NewStartEmailLinkingResponseBody(res *authservice.UserDataReply) *StartEmailLinkingResponseBody {
+	body := &StartEmailLinkingResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingResponseBody builds the HTTP response body from the
+// result of the "verify_email_linking" endpoint of the "auth-service" service.
+func NewVerifyEmailLinkingResponseBody(res *authservice.UserDataReply) *VerifyEmailLinkingResponseBody {
+	body := &VerifyEmailLinkingResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailResponseBody builds the HTTP response body from the
+// result of the "change_primary_email" endpoint of the "auth-service" service.
+func NewChangePrimaryEmailResponseBody(res *authservice.UserDataReply) *ChangePrimaryEmailResponseBody {
+	body := &ChangePrimaryEmailResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureResponseBody builds the HTTP response body from
+// the result of the "upload_current_user_picture" endpoint of the
+// "auth-service" service.
+func NewUploadCurrentUserPictureResponseBody(res *authservice.UserDataReply) *UploadCurrentUserPictureResponseBody {
+	body := &UploadCurrentUserPictureResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewResolveProfileShareResponseBody builds the HTTP response body from the
+// result of the "resolve_profile_share" endpoint of the "auth-service" service.
+func NewResolveProfileShareResponseBody(res *authservice.SharedProfile) *ResolveProfileShareResponseBody {
+	body := &ResolveProfileShareResponseBody{
+		Username:             res.Username,
+		Name:                 res.Name,
+		Picture:              res.Picture,
+		JobTitle:             res.JobTitle,
+		Organization:         res.Organization,
+		OrganizationVerified: res.OrganizationVerified,
+	}
+	return body
+}
+
+// NewListEventSchemasResponseBody builds the HTTP response body from the
+// result of the "list_event_schemas" endpoint of the "auth-service" service.
+func NewListEventSchemasResponseBody(res []*authservice.EventSchema) ListEventSchemasResponseBody {
+	body := make([]*EventSchemaResponse, len(res))
+	for i, val := range res {
+		if val == nil {
+			body[i] = nil
+			continue
+		}
+		body[i] = marshalAuthserviceEventSchemaToEventSchemaResponse(val)
+	}
+	return body
+}
+
+// NewGetEventSchemaResponseBody builds the HTTP response body from the result
+// of the "get_event_schema" endpoint of the "auth-service" service.
+func NewGetEventSchemaResponseBody(res *authservice.EventSchema) *GetEventSchemaResponseBody {
+	body := &GetEventSchemaResponseBody{
+		Subject: res.Subject,
+		Title:   res.Title,
+		Schema:  res.Schema,
+	}
+	return body
+}
+
+// NewListCallersResponseBody builds the HTTP response body from the result of
+// the "list_callers" endpoint of the "auth-service" service.
+func NewListCallersResponseBody(res []*authservice.CallerAllowlistEntry) ListCallersResponseBody {
+	body := make([]*CallerAllowlistEntryResponse, len(res))
+	for i, val := range res {
+		if val == nil {
+			body[i] = nil
+			continue
+		}
+		body[i] = marshalAuthserviceCallerAllowlistEntryToCallerAllowlistEntryResponse(val)
+	}
+	return body
+}
+
+// NewGetCallerResponseBody builds the HTTP response body from the result of
+// the "get_caller" endpoint of the "auth-service" service.
+func NewGetCallerResponseBody(res *authservice.CallerAllowlistEntry) *GetCallerResponseBody {
+	body := &GetCallerResponseBody{
+		ID:          res.ID,
+		Description: res.Description,
+		CreatedAt:   res.CreatedAt,
+		UpdatedAt:   res.UpdatedAt,
+	}
+	if res.Capabilities != nil {
+		body.Capabilities = make([]string, len(res.Capabilities))
+		for i, val := range res.Capabilities {
+			body.Capabilities[i] = val
+		}
+	} else {
+		body.Capabilities = []string{}
+	}
+	return body
+}
+
+// NewPutCallerResponseBody builds the HTTP response body from the result of
+// the "put_caller" endpoint of the "auth-service" service.
+func NewPutCallerResponseBody(res *authservice.CallerAllowlistEntry) *PutCallerResponseBody {
+	body := &PutCallerResponseBody{
+		ID:          res.ID,
+		Description: res.Description,
+		CreatedAt:   res.CreatedAt,
+		UpdatedAt:   res.UpdatedAt,
+	}
+	if res.Capabilities != nil {
+		body.Capabilities = make([]string, len(res.Capabilities))
+		for i, val := range res.Capabilities {
+			body.Capabilities[i] = val
+		}
+	} else {
+		body.Capabilities = []string{}
+	}
+	return body
+}
+
+// NewGetAdminUserMetadataResponseBody builds the HTTP response body from the
+// result of the "get_admin_user_metadata" endpoint of the "auth-service"
+// service.
+func NewGetAdminUserMetadataResponseBody(res *authservice.AdminUserMetadata) *GetAdminUserMetadataResponseBody {
+	body := &GetAdminUserMetadataResponseBody{
+		UserID:   res.UserID,
+		Username: res.Username,
+	}
+	if res.UserMetadata != nil {
+		body.UserMetadata = make(map[string]any, len(res.UserMetadata))
+		for key, val := range res.UserMetadata {
+			tk := key
+			tv := val
+			body.UserMetadata[tk] = tv
+		}
+	}
+	if res.Provenance != nil {
+		body.Provenance = make(map[string]*FieldProvenanceResponseBody, len(res.Provenance))
+		for key, val := range res.Provenance {
+			tk := key
+			if val == nil {
+				body.Provenance[tk] = nil
+				continue
+			}
+			body.Provenance[tk] = marshalAuthserviceFieldProvenanceToFieldProvenanceResponseBody(val)
+		}
+	}
+	return body
+}
+
+// NewIssueEmailBackupCodesResponseBody builds the HTTP response body from the
+// result of the "issue_email_backup_codes" endpoint of the "auth-service"
+// service.
+func NewIssueEmailBackupCodesResponseBody(res *authservice.EmailBackupCodes) *IssueEmailBackupCodesResponseBody {
+	body := &IssueEmailBackupCodesResponseBody{
+		Email:     res.Email,
+		ExpiresAt: res.ExpiresAt,
+	}
+	if res.Codes != nil {
+		body.Codes = make([]string, len(res.Codes))
+		for i, val := range res.Codes {
+			body.Codes[i] = val
+		}
+	} else {
+		body.Codes = []string{}
+	}
+	return body
+}
+
+// NewListWebhookDeliveriesResponseBody builds the HTTP response body from the
+// result of the "list_webhook_deliveries" endpoint of the "auth-service"
+// service.
+func NewListWebhookDeliveriesResponseBody(res *authservice.WebhookDeliveries) *ListWebhookDeliveriesResponseBody {
+	body := &ListWebhookDeliveriesResponseBody{}
+	if res.Deliveries != nil {
+		body.Deliveries = make([]*WebhookDeliveryResponseBody, len(res.Deliveries))
+		for i, val := range res.Deliveries {
+			if val == nil {
+				body.Deliveries[i] = nil
+				continue
+			}
+			body.Deliveries[i] = marshalAuthserviceWebhookDeliveryToWebhookDeliveryResponseBody(val)
+		}
+	} else {
+		body.Deliveries = []*WebhookDeliveryResponseBody{}
+	}
+	return body
+}
+
+// NewGetSupportBundleResponseBody builds the HTTP response body from the
+// result of the "get_support_bundle" endpoint of the "auth-service" service.
+func NewGetSupportBundleResponseBody(res *authservice.SupportBundle) *GetSupportBundleResponseBody {
+	body := &GetSupportBundleResponseBody{
+		GeneratedAt: res.GeneratedAt,
+	}
+	if res.Build != nil {
+		body.Build = marshalAuthserviceSupportBundleBuildToSupportBundleBuildResponseBody(res.Build)
+	}
+	if res.Config != nil {
+		body.Config = make(map[string]string, len(res.Config))
+		for key, val := range res.Config {
+			tk := key
+			tv := val
+			body.Config[tk] = tv
+		}
+	}
+	if res.ConfigErrors != nil {
+		body.ConfigErrors = make([]*SupportBundleConfigErrorResponseBody, len(res.ConfigErrors))
+		for i, val := range res.ConfigErrors {
+			if val == nil {
+				body.ConfigErrors[i] = nil
+				continue
+			}
+			body.ConfigErrors[i] = marshalAuthserviceSupportBundleConfigErrorToSupportBundleConfigErrorResponseBody(val)
+		}
+	} else {
+		body.ConfigErrors = []*SupportBundleConfigErrorResponseBody{}
+	}
+	if res.Providers != nil {
+		body.Providers = make([]*SupportBundleProviderResponseBody, len(res.Providers))
+		for i, val := range res.Providers {
+			if val == nil {
+				body.Providers[i] = nil
+				continue
+			}
+			body.Providers[i] = marshalAuthserviceSupportBundleProviderToSupportBundleProviderResponseBody(val)
+		}
+	} else {
+		body.Providers = []*SupportBundleProviderResponseBody{}
+	}
+	if res.UserCache != nil {
+		body.UserCache = marshalAuthserviceSupportBundleUserCacheToSupportBundleUserCacheResponseBody(res.UserCache)
+	}
+	if res.LastSync != nil {
+		body.LastSync = marshalAuthserviceSupportBundleSyncStatusToSupportBundleSyncStatusResponseBody(res.LastSync)
+	}
+	return body
+}
+
+// NewRenewM2mTokenResponseBody builds the HTTP response body from the result
+// of the "renew_m2m_token" endpoint of the "auth-service" service.
+func NewRenewM2mTokenResponseBody(res *authservice.RunbookResult) *RenewM2mTokenResponseBody {
+	body := &RenewM2mTokenResponseBody{
+		Operation: res.Operation,
+		Target:    res.Target,
+		Outcome:   res.Outcome,
+		At:        res.At,
+	}
+	if res.Steps != nil {
+		body.Steps = make([]*RunbookStepResponseBody, len(res.Steps))
+		for i, val := range res.Steps {
+			if val == nil {
+				body.Steps[i] = nil
+				continue
+			}
+			body.Steps[i] = marshalAuthserviceRunbookStepToRunbookStepResponseBody(val)
+		}
+	} else {
+		body.Steps = []*RunbookStepResponseBody{}
+	}
+	return body
+}
+
+// NewRefreshJwksResponseBody builds the HTTP response body from the result of
+// the "refresh_jwks" endpoint of the "auth-service" service.
+func NewRefreshJwksResponseBody(res *authservice.RunbookResult) *RefreshJwksResponseBody {
+	body := &RefreshJwksResponseBody{
+		Operation: res.Operation,
+		Target:    res.Target,
+		Outcome:   res.Outcome,
+		At:        res.At,
+	}
+	if res.Steps != nil {
+		body.Steps = make([]*RunbookStepResponseBody, len(res.Steps))
+		for i, val := range res.Steps {
+			if val == nil {
+				body.Steps[i] = nil
+				continue
+			}
+			body.Steps[i] = marshalAuthserviceRunbookStepToRunbookStepResponseBody(val)
+		}
+	} else {
+		body.Steps = []*RunbookStepResponseBody{}
+	}
+	return body
+}
+
+// NewRebuildEmailIndexResponseBody builds the HTTP response body from the
+// result of the "rebuild_email_index" endpoint of the "auth-service" service.
+func NewRebuildEmailIndexResponseBody(res *authservice.RunbookResult) *RebuildEmailIndexResponseBody {
+	body := &RebuildEmailIndexResponseBody{
+		Operation: res.Operation,
+		Target:    res.Target,
+		Outcome:   res.Outcome,
+		At:        res.At,
+	}
+	if res.Steps != nil {
+		body.Steps = make([]*RunbookStepResponseBody, len(res.Steps))
+		for i, val := range res.Steps {
+			if val == nil {
+				body.Steps[i] = nil
+				continue
+			}
+			body.Steps[i] = marshalAuthserviceRunbookStepToRunbookStepResponseBody(val)
+		}
+	} else {
+		body.Steps = []*RunbookStepResponseBody{}
+	}
+	return body
+}
+
+// NewGetUserMetadataFailedForbiddenResponseBody builds the HTTP response body
+// from the result of the "get_user_metadata" endpoint of the "auth-service"
+// service.
+func NewGetUserMetadataFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *GetUserMetadataFailedForbiddenResponseBody {
+	body := &GetUserMetadataFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewGetUserMetadataFailedNotFoundResponseBody builds the HTTP response body
+// from the result of the "get_user_metadata" endpoint of the "auth-service"
+// service.
+func NewGetUserMetadataFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *GetUserMetadataFailedNotFoundResponseBody {
+	body := &GetUserMetadataFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewGetUserMetadataFailedResponseBody builds the HTTP response body from the
+// result of the "get_user_metadata" endpoint of the "auth-service" service.
+func NewGetUserMetadataFailedResponseBody(res *authservice.FailedReply) *GetUserMetadataFailedResponseBody {
+	body := &GetUserMetadataFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewGetUserMetadataFailedUnauthorizedResponseBody builds the HTTP response
+// body from the result of the "get_user_metadata" endpoint of the
+// "auth-service" service.
+func NewGetUserMetadataFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *GetUserMetadataFailedUnauthorizedResponseBody {
+	body := &GetUserMetadataFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewGetUserMetadataRetryableResponseBody builds the HTTP response body from
+// the result of the "get_user_metadata" endpoint of the "auth-service" service.
+func NewGetUserMetadataRetryableResponseBody(res *authservice.RetryableReply) *GetUserMetadataRetryableResponseBody {
+	body := &GetUserMetadataRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataFailedForbiddenResponseBody builds the HTTP
+// response body from the result of the "update_current_user_metadata" endpoint
+// of the "auth-service" service.
+func NewUpdateCurrentUserMetadataFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *UpdateCurrentUserMetadataFailedForbiddenResponseBody {
+	body := &UpdateCurrentUserMetadataFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataFailedNotFoundResponseBody builds the HTTP
+// response body from the result of the "update_current_user_metadata" endpoint
+// of the "auth-service" service.
+func NewUpdateCurrentUserMetadataFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *UpdateCurrentUserMetadataFailedNotFoundResponseBody {
+	body := &UpdateCurrentUserMetadataFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataFailedResponseBody builds the HTTP response body
+// from the result of the "update_current_user_metadata" endpoint of the
+// "auth-service" service.
+func NewUpdateCurrentUserMetadataFailedResponseBody(res *authservice.FailedReply) *UpdateCurrentUserMetadataFailedResponseBody {
+	body := &UpdateCurrentUserMetadataFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataFailedUnauthorizedResponseBody builds the HTTP
+// response body from the result of the "update_current_user_metadata" endpoint
+// of the "auth-service" service.
+func NewUpdateCurrentUserMetadataFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *UpdateCurrentUserMetadataFailedUnauthorizedResponseBody {
+	body := &UpdateCurrentUserMetadataFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUpdateCurrentUserMetadataRetryableResponseBody builds the HTTP response
+// body from the result of the "update_current_user_metadata" endpoint of the
+// "auth-service" service.
+func NewUpdateCurrentUserMetadataRetryableResponseBody(res *authservice.RetryableReply) *UpdateCurrentUserMetadataRetryableResponseBody {
+	body := &UpdateCurrentUserMetadataRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingFailedForbiddenResponseBody builds the HTTP response
+// body from the result of the "start_email_linking" endpoint of the
+// "auth-service" service.
+func NewStartEmailLinkingFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *StartEmailLinkingFailedForbiddenResponseBody {
+	body := &StartEmailLinkingFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingFailedNotFoundResponseBody builds the HTTP response body
+// from the result of the "start_email_linking" endpoint of the "auth-service"
+// service.
+func NewStartEmailLinkingFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *StartEmailLinkingFailedNotFoundResponseBody {
+	body := &StartEmailLinkingFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingFailedResponseBody builds the HTTP response body from
+// the result of the "start_email_linking" endpoint of the "auth-service"
+// service.
+func NewStartEmailLinkingFailedResponseBody(res *authservice.FailedReply) *StartEmailLinkingFailedResponseBody {
+	body := &StartEmailLinkingFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingFailedUnauthorizedResponseBody builds the HTTP response
+// body from the result of the "start_email_linking" endpoint of the
+// "auth-service" service.
+func NewStartEmailLinkingFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *StartEmailLinkingFailedUnauthorizedResponseBody {
+	body := &StartEmailLinkingFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewStartEmailLinkingRetryableResponseBody builds the HTTP response body from
+// the result of the "start_email_linking" endpoint of the "auth-service"
+// service.
+func NewStartEmailLinkingRetryableResponseBody(res *authservice.RetryableReply) *StartEmailLinkingRetryableResponseBody {
+	body := &StartEmailLinkingRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingFailedForbiddenResponseBody builds the HTTP response
+// body from the result of the "verify_email_linking" endpoint of the
+// "auth-service" service.
+func NewVerifyEmailLinkingFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *VerifyEmailLinkingFailedForbiddenResponseBody {
+	body := &VerifyEmailLinkingFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingFailedNotFoundResponseBody builds the HTTP response
+// body from the result of the "verify_email_linking" endpoint of the
+// "auth-service" service.
+func NewVerifyEmailLinkingFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *VerifyEmailLinkingFailedNotFoundResponseBody {
+	body := &VerifyEmailLinkingFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingFailedResponseBody builds the HTTP response body from
+// the result of the "verify_email_linking" endpoint of the "auth-service"
+// service.
+func NewVerifyEmailLinkingFailedResponseBody(res *authservice.FailedReply) *VerifyEmailLinkingFailedResponseBody {
+	body := &VerifyEmailLinkingFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingFailedUnauthorizedResponseBody builds the HTTP response
+// body from the result of the "verify_email_linking" endpoint of the
+// "auth-service" service.
+func NewVerifyEmailLinkingFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *VerifyEmailLinkingFailedUnauthorizedResponseBody {
+	body := &VerifyEmailLinkingFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewVerifyEmailLinkingRetryableResponseBody builds the HTTP response body
+// from the result of the "verify_email_linking" endpoint of the "auth-service"
+// service.
+func NewVerifyEmailLinkingRetryableResponseBody(res *authservice.RetryableReply) *VerifyEmailLinkingRetryableResponseBody {
+	body := &VerifyEmailLinkingRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailFailedForbiddenResponseBody builds the HTTP response
+// body from the result of the "change_primary_email" endpoint of the
+// "auth-service" service.
+func NewChangePrimaryEmailFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *ChangePrimaryEmailFailedForbiddenResponseBody {
+	body := &ChangePrimaryEmailFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailFailedNotFoundResponseBody builds the HTTP response
+// body from the result of the "change_primary_email" endpoint of the
+// "auth-service" service.
+func NewChangePrimaryEmailFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *ChangePrimaryEmailFailedNotFoundResponseBody {
+	body := &ChangePrimaryEmailFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailFailedResponseBody builds the HTTP response body from
+// the result of the "change_primary_email" endpoint of the "auth-service"
+// service.
+func NewChangePrimaryEmailFailedResponseBody(res *authservice.FailedReply) *ChangePrimaryEmailFailedResponseBody {
+	body := &ChangePrimaryEmailFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailFailedUnauthorizedResponseBody builds the HTTP response
+// body from the result of the "change_primary_email" endpoint of the
+// "auth-service" service.
+func NewChangePrimaryEmailFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *ChangePrimaryEmailFailedUnauthorizedResponseBody {
+	body := &ChangePrimaryEmailFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewChangePrimaryEmailRetryableResponseBody builds the HTTP response body
+// from the result of the "change_primary_email" endpoint of the "auth-service"
+// service.
+func NewChangePrimaryEmailRetryableResponseBody(res *authservice.RetryableReply) *ChangePrimaryEmailRetryableResponseBody {
+	body := &ChangePrimaryEmailRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureFailedForbiddenResponseBody builds the HTTP
+// response body from the result of the "upload_current_user_picture" endpoint
+// of the "auth-service" service.
+func NewUploadCurrentUserPictureFailedForbiddenResponseBody(res *authservice.FailedForbiddenReply) *UploadCurrentUserPictureFailedForbiddenResponseBody {
+	body := &UploadCurrentUserPictureFailedForbiddenResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureFailedNotFoundResponseBody builds the HTTP
+// response body from the result of the "upload_current_user_picture" endpoint
+// of the "auth-service" service.
+func NewUploadCurrentUserPictureFailedNotFoundResponseBody(res *authservice.FailedNotFoundReply) *UploadCurrentUserPictureFailedNotFoundResponseBody {
+	body := &UploadCurrentUserPictureFailedNotFoundResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureFailedResponseBody builds the HTTP response body
+// from the result of the "upload_current_user_picture" endpoint of the
+// "auth-service" service.
+func NewUploadCurrentUserPictureFailedResponseBody(res *authservice.FailedReply) *UploadCurrentUserPictureFailedResponseBody {
+	body := &UploadCurrentUserPictureFailedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureFailedUnauthorizedResponseBody builds the HTTP
+// response body from the result of the "upload_current_user_picture" endpoint
+// of the "auth-service" service.
+func NewUploadCurrentUserPictureFailedUnauthorizedResponseBody(res *authservice.FailedUnauthorizedReply) *UploadCurrentUserPictureFailedUnauthorizedResponseBody {
+	body := &UploadCurrentUserPictureFailedUnauthorizedResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUploadCurrentUserPictureRetryableResponseBody builds the HTTP response
+// body from the result of the "upload_current_user_picture" endpoint of the
+// "auth-service" service.
+func NewUploadCurrentUserPictureRetryableResponseBody(res *authservice.RetryableReply) *UploadCurrentUserPictureRetryableResponseBody {
+	body := &UploadCurrentUserPictureRetryableResponseBody{
+		Success:      res.Success,
+		Message:      res.Message,
+		Data:         res.Data,
+		Reason:       res.Reason,
+		ErrorCode:    res.ErrorCode,
+		Retryable:    res.Retryable,
+		RetryAfterMs: res.RetryAfterMs,
+		Stale:        res.Stale,
+		Age:          res.Age,
+	}
+	return body
+}
+
+// NewUserinfoPayload builds a auth-service service userinfo endpoint payload.
+func NewUserinfoPayload(authorization *string) *authservice.UserinfoPayload {
+	v := &authservice.UserinfoPayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewProfileEventsPayload builds a auth-service service profile_events
+// endpoint payload.
+func NewProfileEventsPayload(accessToken *string, authorization *string) *authservice.ProfileEventsPayload {
+	v := &authservice.ProfileEventsPayload{}
+	v.AccessToken = accessToken
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewAdminEventsPayload builds a auth-service service admin_events endpoint
+// payload.
+func NewAdminEventsPayload(accessToken *string, kinds []string, authorization *string) *authservice.AdminEventsPayload {
+	v := &authservice.AdminEventsPayload{}
+	v.AccessToken = accessToken
+	v.Kinds = kinds
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewGetUserMetadataPayload builds a auth-service service get_user_metadata
+// endpoint payload.
+func NewGetUserMetadataPayload(sub string, authorization *string, acceptLanguage *string, format *string) *authservice.GetUserMetadataPayload {
+	v := &authservice.GetUserMetadataPayload{}
+	v.Sub = sub
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+	v.Format = format
+
+	return v
+}
+
+// NewUpdateCurrentUserMetadataPayload builds a auth-service service
+// update_current_user_metadata endpoint payload.
+func NewUpdateCurrentUserMetadataPayload(body struct {
+	// Metadata fields to update
+	UserMetadata map[string]any `form:"user_metadata" json:"user_metadata" xml:"user_metadata"`
+}, authorization *string, acceptLanguage *string) *authservice.UpdateCurrentUserMetadataPayload {
+	v := &authservice.UpdateCurrentUserMetadataPayload{}
+	if body.UserMetadata != nil {
+		v.UserMetadata = make(map[string]any, len(body.UserMetadata))
+		for key, val := range body.UserMetadata {
+			tk := key
+			tv := val
+			v.UserMetadata[tk] = tv
+		}
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v
+}
+
+// NewStartEmailLinkingPayload builds a auth-service service
+// start_email_linking endpoint payload.
+func NewStartEmailLinkingPayload(body struct {
+	// Alternate email to verify
+	Email *string `form:"email" json:"email" xml:"email"`
+}, authorization *string, acceptLanguage *string) *authservice.StartEmailLinkingPayload {
+	v := &authservice.StartEmailLinkingPayload{}
+	if body.Email != nil {
+		v.Email = *body.Email
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v
+}
+
+// NewVerifyEmailLinkingPayload builds a auth-service service
+// verify_email_linking endpoint payload.
+func NewVerifyEmailLinkingPayload(body struct {
+	// Alternate email to verify
+	Email *string `form:"email" json:"email" xml:"email"`
+	// One time password sent to the email
+	Otp *string `form:"otp" json:"otp" xml:"otp"`
+}, authorization *string, acceptLanguage *string) *authservice.VerifyEmailLinkingPayload {
+	v := &authservice.VerifyEmailLinkingPayload{}
+	if body.Email != nil {
+		v.Email = *body.Email
+	}
+	if body.Otp != nil {
+		v.Otp = *body.Otp
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v
+}
+
+// NewChangePrimaryEmailPayload builds a auth-service service
+// change_primary_email endpoint payload.
+func NewChangePrimaryEmailPayload(body struct {
+	// Reference returned by the verification of the new email
+	IdentityTokenRef *string `form:"identity_token_ref" json:"identity_token_ref" xml:"identity_token_ref"`
+}, authorization *string, acceptLanguage *string) *authservice.ChangePrimaryEmailPayload {
+	v := &authservice.ChangePrimaryEmailPayload{}
+	if body.IdentityTokenRef != nil {
+		v.IdentityTokenRef = *body.IdentityTokenRef
+	}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+
+	return v
+}
+
+// NewUploadCurrentUserPicturePayload builds a auth-service service
+// upload_current_user_picture endpoint payload.
+func NewUploadCurrentUserPicturePayload(authorization *string, acceptLanguage *string, contentType *string) *authservice.UploadCurrentUserPicturePayload {
+	v := &authservice.UploadCurrentUserPicturePayload{}
+	v.Authorization = authorization
+	v.AcceptLanguage = acceptLanguage
+	v.ContentType = contentType
+
+	return v
+}
+
+// NewResolveProfileSharePayload builds a auth-service service
+// resolve_profile_share endpoint payload.
+func NewResolveProfileSharePayload(token string) *authservice.ResolveProfileSharePayload {
+	v := &authservice.ResolveProfileSharePayload{}
+	v.Token = token
+
+	return v
+}
+
+// NewGetEventSchemaPayload builds a auth-service service get_event_schema
+// endpoint payload.
+func NewGetEventSchemaPayload(subject string) *authservice.GetEventSchemaPayload {
+	v := &authservice.GetEventSchemaPayload{}
+	v.Subject = subject
+
+	return v
+}
+
+// NewListCallersPayload builds a auth-service service list_callers endpoint
+// payload.
+func NewListCallersPayload(authorization *string) *authservice.ListCallersPayload {
+	v := &authservice.ListCallersPayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewGetCallerPayload builds a auth-service service get_caller endpoint
+// payload.
+func NewGetCallerPayload(id string, authorization *string) *authservice.GetCallerPayload {
+	v := &authservice.GetCallerPayload{}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewPutCallerPayload builds a auth-service service put_caller endpoint
+// payload.
+func NewPutCallerPayload(body *PutCallerRequestBody, id string, authorization *string) *authservice.PutCallerPayload {
+	v := &authservice.PutCallerPayload{
+		Description: body.Description,
+	}
+	v.Capabilities = make([]string, len(body.Capabilities))
+	for i, val := range body.Capabilities {
+		v.Capabilities[i] = val
+	}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewDeleteCallerPayload builds a auth-service service delete_caller endpoint
+// payload.
+func NewDeleteCallerPayload(id string, authorization *string) *authservice.DeleteCallerPayload {
+	v := &authservice.DeleteCallerPayload{}
+	v.ID = id
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewGetAdminUserMetadataPayload builds a auth-service service
+// get_admin_user_metadata endpoint payload.
+func NewGetAdminUserMetadataPayload(user string, authorization *string) *authservice.GetAdminUserMetadataPayload {
+	v := &authservice.GetAdminUserMetadataPayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewIssueEmailBackupCodesPayload builds a auth-service service
+// issue_email_backup_codes endpoint payload.
+func NewIssueEmailBackupCodesPayload(body *IssueEmailBackupCodesRequestBody, authorization *string) *authservice.IssueEmailBackupCodesPayload {
+	v := &authservice.IssueEmailBackupCodesPayload{
+		Email: *body.Email,
+	}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewPurgeUserCachePayload builds a auth-service service purge_user_cache
+// endpoint payload.
+func NewPurgeUserCachePayload(user string, authorization *string) *authservice.PurgeUserCachePayload {
+	v := &authservice.PurgeUserCachePayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewListWebhookDeliveriesPayload builds a auth-service service
+// list_webhook_deliveries endpoint payload.
+func NewListWebhookDeliveriesPayload(authorization *string) *authservice.ListWebhookDeliveriesPayload {
+	v := &authservice.ListWebhookDeliveriesPayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewGetSupportBundlePayload builds a auth-service service get_support_bundle
+// endpoint payload.
+func NewGetSupportBundlePayload(authorization *string) *authservice.GetSupportBundlePayload {
+	v := &authservice.GetSupportBundlePayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewRenewM2mTokenPayload builds a auth-service service renew_m2m_token
+// endpoint payload.
+func NewRenewM2mTokenPayload(authorization *string) *authservice.RenewM2mTokenPayload {
+	v := &authservice.RenewM2mTokenPayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewRefreshJwksPayload builds a auth-service service refresh_jwks endpoint
+// payload.
+func NewRefreshJwksPayload(authorization *string) *authservice.RefreshJwksPayload {
+	v := &authservice.RefreshJwksPayload{}
+	v.Authorization = authorization
+
+	return v
+}
+
+// NewRebuildEmailIndexPayload builds a auth-service service
+// rebuild_email_index endpoint payload.
+func NewRebuildEmailIndexPayload(user string, authorization *string) *authservice.RebuildEmailIndexPayload {
+	v := &authservice.RebuildEmailIndexPayload{}
+	v.User = user
+	v.Authorization = authorization
+
+	return v
+}
+
+// ValidatePutCallerRequestBody runs the validations defined on
+// put_caller_request_body
+func ValidatePutCallerRequestBody(body *PutCallerRequestBody) (err error) {
+	if body.Capabilities == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("capabilities", "body"))
+	}
+	return
+}
+
+// ValidateIssueEmailBackupCodesRequestBody runs the validations defined on
+// issue_email_backup_codes_request_body
+func ValidateIssueEmailBackupCodesRequestBody(body *IssueEmailBackupCodesRequestBody) (err error) {
+	if body.Email == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("email", "body"))
+	}
+	return
+}
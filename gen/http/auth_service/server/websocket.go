@@ -0,0 +1,100 @@
+// Code generated by goa v3.23.3, DO NOT EDIT.
+//
+// auth-service WebSocket server streaming
+//
+// Command:
+// $ goa gen github.com/linuxfoundation/lfx-v2-auth-service/cmd/server/design
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	authservice "github.com/linuxfoundation/lfx-v2-auth-service/gen/auth_service"
+	goahttp "goa.design/goa/v3/http"
+)
+
+// ConnConfigurer holds the websocket connection configurer functions for the
+// streaming endpoints in "auth-service" service.
+type ConnConfigurer struct {
+	AdminEventsFn goahttp.ConnConfigureFunc
+}
+
+// AdminEventsServerStream implements the authservice.AdminEventsServerStream
+// interface.
+type AdminEventsServerStream struct {
+	once sync.Once
+	// upgrader is the websocket connection upgrader.
+	upgrader goahttp.Upgrader
+	// configurer is the websocket connection configurer.
+	configurer goahttp.ConnConfigureFunc
+	// cancel is the context cancellation function which cancels the request
+	// context when invoked.
+	cancel context.CancelFunc
+	// w is the HTTP response writer used in upgrading the connection.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+}
+
+// NewConnConfigurer initializes the websocket connection configurer function
+// with fn for all the streaming endpoints in "auth-service" service.
+func NewConnConfigurer(fn goahttp.ConnConfigureFunc) *ConnConfigurer {
+	return &ConnConfigurer{
+		AdminEventsFn: fn,
+	}
+}
+
+// Send streams instances of "authservice.AdminEvent" to the "admin_events"
+// endpoint websocket connection.
+func (s *AdminEventsServerStream) Send(v *authservice.AdminEvent) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+	})
+	if err != nil {
+		return err
+	}
+	res := v
+	body := NewAdminEventsResponseBody(res)
+	return s.conn.WriteJSON(body)
+}
+
+// SendWithContext streams instances of "authservice.AdminEvent" to the
+// "admin_events" endpoint websocket connection with context.
+func (s *AdminEventsServerStream) SendWithContext(ctx context.Context, v *authservice.AdminEvent) error {
+	return s.Send(v)
+}
+
+// Close closes the "admin_events" endpoint websocket connection.
+func (s *AdminEventsServerStream) Close() error {
+	var err error
+	if s.conn == nil {
+		return nil
+	}
+	if err = s.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "server closing connection"),
+		time.Now().Add(time.Second),
+	); err != nil {
+		return err
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,214 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// AdminEventKind is the kind of an internal event bridged to the admin dashboards
+type AdminEventKind string
+
+const (
+	// AdminEventSyncStatus is the outcome of a sync of the Authelia users
+	AdminEventSyncStatus AdminEventKind = "sync_status"
+
+	// AdminEventAudit is an audited operation (organization admin updates, merges)
+	AdminEventAudit AdminEventKind = "audit"
+
+	// AdminEventUserMerged is the merge of two accounts
+	AdminEventUserMerged AdminEventKind = "user_merged"
+
+	// AdminEventProfileChanged is the change of a user profile
+	AdminEventProfileChanged AdminEventKind = "profile_changed"
+)
+
+// AdminEventKinds are all the kinds of events bridged to the admin dashboards
+var AdminEventKinds = []AdminEventKind{
+	AdminEventSyncStatus,
+	AdminEventAudit,
+	AdminEventUserMerged,
+	AdminEventProfileChanged,
+}
+
+// ParseAdminEventKinds parses the kinds a dashboard is interested in, none means all of them
+func ParseAdminEventKinds(kinds []string) ([]AdminEventKind, error) {
+	if len(kinds) == 0 {
+		return AdminEventKinds, nil
+	}
+
+	parsed := make([]AdminEventKind, 0, len(kinds))
+	for _, kind := range kinds {
+		for _, value := range strings.Split(kind, ",") {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value == "" {
+				continue
+			}
+			known := false
+			for _, k := range AdminEventKinds {
+				if string(k) == value {
+					parsed = append(parsed, k)
+					known = true
+					break
+				}
+			}
+			if !known {
+				return nil, errs.NewValidation("unknown event kind: " + value)
+			}
+		}
+	}
+	if len(parsed) == 0 {
+		return AdminEventKinds, nil
+	}
+	return parsed, nil
+}
+
+// AdminEvent is an internal event as seen by the admin dashboards, the identifiers
+// of the users in the data are redacted
+type AdminEvent struct {
+	Kind AdminEventKind `json:"kind"`
+	At   time.Time      `json:"at"`
+	Data map[string]any `json:"data,omitempty"`
+
+	// Dropped is the number of events dropped before this one because the dashboard was behind
+	Dropped int `json:"dropped,omitempty"`
+}
+
+// AuditAction is an audited operation
+type AuditAction string
+
+const (
+	// AuditActionOrganizationAdminUpdate is the update of a member profile by an organization admin
+	AuditActionOrganizationAdminUpdate AuditAction = "user_metadata.admin_update"
+
+	// AuditActionMerge is the merge of two accounts
+	AuditActionMerge AuditAction = "user.merge"
+
+	// AuditActionUserUpdate is the update of the user metadata by the user
+	AuditActionUserUpdate AuditAction = "user_metadata.update"
+
+	// AuditActionEmailLinkingStart is the verification email sent to link an alternate email
+	AuditActionEmailLinkingStart AuditAction = "email_linking.start"
+
+	// AuditActionEmailLinkingVerify is the verification of an alternate email
+	AuditActionEmailLinkingVerify AuditAction = "email_linking.verify"
+
+	// AuditActionEmailLinkingLockout is the lock of the verification of an email after repeated failed attempts
+	AuditActionEmailLinkingLockout AuditAction = "email_linking.lockout"
+
+	// AuditActionPhoneLinkingStart is the verification code sent to link a phone number
+	AuditActionPhoneLinkingStart AuditAction = "phone_linking.start"
+
+	// AuditActionPhoneLinkingVerify is the verification of a phone number
+	AuditActionPhoneLinkingVerify AuditAction = "phone_linking.verify"
+
+	// AuditActionPhoneLinkingLockout is the lock of the verification of a phone number after repeated failed attempts
+	AuditActionPhoneLinkingLockout AuditAction = "phone_linking.lockout"
+
+	// AuditActionPrimaryEmailChange is the change of the primary email by the user
+	AuditActionPrimaryEmailChange AuditAction = "user.primary_email_change"
+
+	// AuditActionEnrichment is the update of the user metadata by an enrichment job
+	AuditActionEnrichment AuditAction = "user_metadata.enrich"
+
+	// AuditActionUserSync is the sync of the Authelia users from the storage to the orchestrator
+	AuditActionUserSync AuditAction = "users.sync"
+
+	// AuditActionRunbookM2MToken is the renewal and verification of the M2M tokens by an admin
+	AuditActionRunbookM2MToken AuditAction = "runbook.m2m_token_renew"
+
+	// AuditActionRunbookJWKS is the refresh of the JWKS verifying the user tokens by an admin
+	AuditActionRunbookJWKS AuditAction = "runbook.jwks_refresh"
+
+	// AuditActionRunbookEmailIndex is the rebuild of the email index of a user by an admin
+	AuditActionRunbookEmailIndex AuditAction = "runbook.email_index_rebuild"
+)
+
+// AuditOutcome is the outcome of an audited operation
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess is an operation which succeeded
+	AuditOutcomeSuccess AuditOutcome = "success"
+
+	// AuditOutcomeFailure is an operation which failed
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditEvent is the event emitted after an audited operation
+type AuditEvent struct {
+	Action AuditAction `json:"action"`
+	// Actor is the sub of the user performing the operation, or the caller service when unknown
+	Actor  string `json:"actor,omitempty"`
+	Target string `json:"target"`
+	// Changes are the changed fields with their redacted values, empty for the cleared fields
+	Changes map[string]string `json:"changes,omitempty"`
+	Outcome AuditOutcome      `json:"outcome,omitempty"`
+	// Error is the failure of the operation
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+	At      time.Time      `json:"at"`
+}
+
+// Fail records the failure of the operation
+func (e *AuditEvent) Fail(err error) {
+	e.Outcome = AuditOutcomeFailure
+	if err != nil {
+		e.Error = err.Error()
+	}
+}
+
+// WebhookDelivery is an attempt to post an audit event to the audit webhook
+type WebhookDelivery struct {
+	Action AuditAction `json:"action"`
+	// StatusCode is the HTTP status of the reply, zero when the webhook wasn't reached
+	StatusCode  int           `json:"status_code,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	DeliveredAt time.Time     `json:"delivered_at"`
+}
+
+// SyncStatus is the event emitted after a sync of the Authelia users
+// from the storage to the orchestrator (ConfigMap, Secrets)
+type SyncStatus struct {
+	Succeeded bool      `json:"succeeded"`
+	Changed   int       `json:"changed"`
+	Error     string    `json:"error,omitempty"`
+	SyncedAt  time.Time `json:"synced_at"`
+	// DryRun is a sync computing the changes without applying them, Changed is the number of users to sync
+	DryRun  bool         `json:"dry_run,omitempty"`
+	Summary *SyncSummary `json:"summary,omitempty"`
+}
+
+// SyncChange is the change a sync of the Authelia users applies to a user, the username is redacted
+type SyncChange struct {
+	Username string `json:"username"`
+	// Action is orchestrator_creation, orchestrator_update or storage_creation
+	Action string `json:"action"`
+}
+
+// SyncSummary counts the users of a sync of the Authelia users by change
+type SyncSummary struct {
+	// OrchestratorCreations are the users of the storage missing from the orchestrator
+	OrchestratorCreations int `json:"orchestrator_creations"`
+	// OrchestratorUpdates are the users whose email or soft-delete differ in the orchestrator
+	OrchestratorUpdates int `json:"orchestrator_updates"`
+	// StorageCreations are the users of the orchestrator missing from the storage
+	StorageCreations int `json:"storage_creations"`
+	// Unchanged are the users already in sync
+	Unchanged int `json:"unchanged"`
+}
+
+// SyncReport is the outcome of the comparison of the Authelia users of the storage and of the
+// orchestrator, the changes are sorted by username
+type SyncReport struct {
+	DryRun  bool         `json:"dry_run"`
+	Summary SyncSummary  `json:"summary"`
+	Changes []SyncChange `json:"changes"`
+	// RestartNeeded is set when the orchestrator is updated, Authelia is restarted to load the users
+	RestartNeeded bool `json:"restart_needed"`
+}
@@ -0,0 +1,39 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseAdminEventKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		kinds   []string
+		want    []AdminEventKind
+		wantErr bool
+	}{
+		{name: "none means all", want: AdminEventKinds},
+		{name: "blank means all", kinds: []string{" "}, want: AdminEventKinds},
+		{
+			name:  "repeated and comma separated",
+			kinds: []string{"Audit", "sync_status, user_merged"},
+			want:  []AdminEventKind{AdminEventAudit, AdminEventSyncStatus, AdminEventUserMerged},
+		},
+		{name: "unknown kind", kinds: []string{"audit,everything"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdminEventKinds(tt.kinds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAdminEventKinds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseAdminEventKinds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,19 @@
 
 package model
 
+import "time"
+
 // AuthResponse represents the response from the authentication service that contains
 // common fields for all authentication responses.
 type AuthResponse struct {
 	AccessToken string `json:"access_token"`
-	IDToken     string `json:"id_token"`
-	Scope       string `json:"scope"`
-	ExpiresIn   int    `json:"expires_in"`
-	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token,omitempty"`
+	// IDTokenRef is the opaque reference returned instead of the ID token when the token references
+	// are enabled, resolved by the identity linking
+	IDTokenRef string `json:"id_token_ref,omitempty"`
+	// IDTokenRefExpiresAt is the time the reference stops resolving
+	IDTokenRefExpiresAt *time.Time `json:"id_token_ref_expires_at,omitempty"`
+	Scope               string     `json:"scope"`
+	ExpiresIn           int        `json:"expires_in"`
+	TokenType           string     `json:"token_type"`
 }
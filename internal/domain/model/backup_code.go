@@ -0,0 +1,42 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// BackupCodeAlphabet are the characters of the backup codes, without the ones read alike (0/O, 1/I)
+const BackupCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// backupCodeGroup is the length of the groups of a backup code, XXXXX-XXXXX
+const backupCodeGroup = 5
+
+// EmailBackupCodes are the one-time codes verifying an email in place of the OTP, for the users whose
+// mail filters drop the OTP emails. An admin hands them out through another channel.
+type EmailBackupCodes struct {
+	Email     string    `json:"email"`
+	Codes     []string  `json:"codes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NormalizeBackupCode returns the code in its canonical form, upper case and without the spaces
+func NormalizeBackupCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
+
+// IsBackupCode reports whether the code has the form of a backup code rather than an OTP
+func IsBackupCode(code string) bool {
+	code = NormalizeBackupCode(code)
+	if len(code) != 2*backupCodeGroup+1 || code[backupCodeGroup] != '-' {
+		return false
+	}
+	for i, r := range code {
+		if i != backupCodeGroup && !strings.ContainsRune(BackupCodeAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// CallerCapability is a privilege granted to a calling service on top of the operations open to all of them
+type CallerCapability string
+
+const (
+	// CallerCapabilityEmailOwner allows a calling service to see the owner of a verified email
+	CallerCapabilityEmailOwner CallerCapability = "email_owner"
+
+	// CallerCapabilityVerboseErrors allows a calling service to get every rule failed by its invalid requests
+	CallerCapabilityVerboseErrors CallerCapability = "verbose_errors"
+
+	// CallerCapabilityMetadataEnrichment allows a calling service (an enrichment job) to fill the user metadata
+	CallerCapabilityMetadataEnrichment CallerCapability = "metadata_enrichment"
+
+	// maxCallerDescriptionLength bounds the free text description of an entry
+	maxCallerDescriptionLength = 256
+)
+
+// callerCapabilities are the known capabilities
+var callerCapabilities = []CallerCapability{
+	CallerCapabilityEmailOwner,
+	CallerCapabilityVerboseErrors,
+	CallerCapabilityMetadataEnrichment,
+}
+
+// callerIDPattern is the form of the caller identifiers, as named by the service tokens of the callers
+var callerIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// CallerAllowlistEntry grants capabilities to a calling service. The ID is the caller identity itself,
+// so the entries have stable identifiers and can be managed declaratively (e.g. by Terraform).
+type CallerAllowlistEntry struct {
+	ID           string             `json:"id"`
+	Description  string             `json:"description,omitempty"`
+	Capabilities []CallerCapability `json:"capabilities"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// ValidateCallerID validates a caller identifier
+func ValidateCallerID(id string) error {
+	if !callerIDPattern.MatchString(id) {
+		return errors.NewValidation(fmt.Sprintf("invalid caller id %q, expected up to 64 lowercase letters, digits, dots, dashes or underscores", id))
+	}
+	return nil
+}
+
+// Validate validates the entry, and sorts and dedups its capabilities so the same desired state
+// is always stored the same way
+func (e *CallerAllowlistEntry) Validate() error {
+	if err := ValidateCallerID(e.ID); err != nil {
+		return err
+	}
+	e.Description = strings.TrimSpace(e.Description)
+	if len(e.Description) > maxCallerDescriptionLength {
+		return errors.NewValidation(fmt.Sprintf("description must be at most %d characters", maxCallerDescriptionLength))
+	}
+	for _, capability := range e.Capabilities {
+		if !slices.Contains(callerCapabilities, capability) {
+			return errors.NewValidation(fmt.Sprintf("unknown capability %q", capability))
+		}
+	}
+	slices.Sort(e.Capabilities)
+	e.Capabilities = slices.Compact(e.Capabilities)
+	if e.Capabilities == nil {
+		e.Capabilities = []CallerCapability{}
+	}
+	return nil
+}
+
+// Has reports whether the entry grants the capability
+func (e *CallerAllowlistEntry) Has(capability CallerCapability) bool {
+	return e != nil && slices.Contains(e.Capabilities, capability)
+}
+
+// SameSpec reports whether the entries declare the same state, the timestamps aside
+func (e *CallerAllowlistEntry) SameSpec(other *CallerAllowlistEntry) bool {
+	return e.ID == other.ID &&
+		e.Description == other.Description &&
+		slices.Equal(e.Capabilities, other.Capabilities)
+}
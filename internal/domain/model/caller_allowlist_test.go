@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCallerAllowlistEntry_Validate(t *testing.T) {
+	tests := []struct {
+		name             string
+		entry            CallerAllowlistEntry
+		wantErr          bool
+		wantCapabilities []CallerCapability
+	}{
+		{
+			name:             "capabilities are deduped",
+			entry:            CallerAllowlistEntry{ID: "cla-service", Capabilities: []CallerCapability{CallerCapabilityEmailOwner, CallerCapabilityEmailOwner}},
+			wantCapabilities: []CallerCapability{CallerCapabilityEmailOwner},
+		},
+		{name: "no capabilities", entry: CallerAllowlistEntry{ID: "cla.service_v2"}, wantCapabilities: []CallerCapability{}},
+		{name: "uppercase id", entry: CallerAllowlistEntry{ID: "CLA-service"}, wantErr: true},
+		{name: "empty id", entry: CallerAllowlistEntry{}, wantErr: true},
+		{name: "id too long", entry: CallerAllowlistEntry{ID: strings.Repeat("a", 65)}, wantErr: true},
+		{name: "unknown capability", entry: CallerAllowlistEntry{ID: "cla-service", Capabilities: []CallerCapability{"admin"}}, wantErr: true},
+		{name: "description too long", entry: CallerAllowlistEntry{ID: "cla-service", Description: strings.Repeat("a", 257)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(tt.entry.Capabilities, tt.wantCapabilities) {
+				t.Errorf("Validate() capabilities = %v, want %v", tt.entry.Capabilities, tt.wantCapabilities)
+			}
+		})
+	}
+}
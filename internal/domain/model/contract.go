@@ -0,0 +1,34 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+const (
+	// ContractBreachLatency is a contract whose p95 latency is above its latency budget
+	ContractBreachLatency = "latency"
+	// ContractBreachErrors is a contract whose error rate is above its error budget
+	ContractBreachErrors = "errors"
+)
+
+// ContractSLI is the service level of an operation measured for a consuming service, against the
+// budgets of the contract the consumer registered for it
+type ContractSLI struct {
+	Caller    string  `json:"caller"`
+	Operation string  `json:"operation"`
+	Samples   int     `json:"samples"`
+	Failures  int     `json:"failures"`
+	P95Ms     int64   `json:"p95_ms"`
+	ErrorRate float64 `json:"error_rate"`
+
+	// LatencyBudgetMs and ErrorBudget are the budgets of the contract, zero when not set
+	LatencyBudgetMs int64   `json:"latency_budget_ms,omitempty"`
+	ErrorBudget     float64 `json:"error_budget,omitempty"`
+
+	// Breaches lists the budgets exceeded in the window, ContractBreachLatency and ContractBreachErrors
+	Breaches []string `json:"breaches,omitempty"`
+}
+
+// Breached reports whether any budget of the contract is exceeded
+func (c ContractSLI) Breached() bool {
+	return len(c.Breaches) > 0
+}
@@ -0,0 +1,16 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+// CostClass classifies the identity provider operations by how much of the tenant quota they use
+type CostClass string
+
+const (
+	// CostClassCheap is the class of the operations by id, like loading a user by its user_id
+	CostClassCheap CostClass = "cheap"
+
+	// CostClassExpensive is the class of the search operations, like finding a user by email
+	// or username, the identity providers apply much lower limits to them
+	CostClassExpensive CostClass = "expensive"
+)
@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// delegatedMetadataFields are the user metadata fields organization admins can update
+var delegatedMetadataFields = []string{"organization", "job_title"}
+
+// OrganizationAdmin is a user allowed to manage the profile of the members of some organizations
+type OrganizationAdmin struct {
+	UserID        string
+	Organizations []string
+}
+
+// Manages reports whether the admin manages the given organization
+func (a *OrganizationAdmin) Manages(organization string) bool {
+	organization = normalizeOrganization(organization)
+	if organization == "" {
+		return false
+	}
+	for _, managed := range a.Organizations {
+		if normalizeOrganization(managed) == organization {
+			return true
+		}
+	}
+	return false
+}
+
+// DelegatedUserUpdate is the request of an organization admin to update the profile of a member
+type DelegatedUserUpdate struct {
+	// Token is the organization admin token
+	Token string `json:"token"`
+	// User is the username or sub of the member being updated
+	User         string        `json:"user"`
+	UserMetadata *UserMetadata `json:"user_metadata"`
+}
+
+// Validate validates the request and enforces the fields organization admins are allowed to update
+func (d *DelegatedUserUpdate) Validate() error {
+	d.Token = strings.TrimSpace(d.Token)
+	d.User = strings.TrimSpace(d.User)
+
+	if d.Token == "" {
+		return errors.NewValidation("token is required")
+	}
+	if d.User == "" {
+		return errors.NewValidation("user is required")
+	}
+
+	fields := d.UserMetadata.Fields()
+	if len(fields) == 0 {
+		return errors.NewValidation("user_metadata is required")
+	}
+	for _, field := range fields {
+		if !slices.Contains(delegatedMetadataFields, field) {
+			return errors.NewForbidden(fmt.Sprintf("field %s can't be updated by organization admins", field))
+		}
+	}
+	return nil
+}
+
+// Fields returns the json names of the metadata fields that are set
+func (a *UserMetadata) Fields() []string {
+	if a == nil {
+		return nil
+	}
+	var fields []string
+	value := reflect.ValueOf(a).Elem()
+	for i := range value.NumField() {
+		if value.Field(i).IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
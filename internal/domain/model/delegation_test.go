@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestDelegatedUserUpdate_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   *DelegatedUserUpdate
+		wantErr   bool
+		forbidden bool
+	}{
+		{
+			name: "delegated fields only",
+			request: &DelegatedUserUpdate{
+				Token: "token",
+				User:  "jane",
+				UserMetadata: &UserMetadata{
+					Organization: converters.StringPtr("CNCF"),
+					JobTitle:     converters.StringPtr("Engineer"),
+				},
+			},
+		},
+		{
+			name: "non delegated field",
+			request: &DelegatedUserUpdate{
+				Token: "token",
+				User:  "jane",
+				UserMetadata: &UserMetadata{
+					JobTitle: converters.StringPtr("Engineer"),
+					Name:     converters.StringPtr("Jane"),
+				},
+			},
+			wantErr:   true,
+			forbidden: true,
+		},
+		{
+			name:    "missing token",
+			request: &DelegatedUserUpdate{User: "jane", UserMetadata: &UserMetadata{JobTitle: converters.StringPtr("Engineer")}},
+			wantErr: true,
+		},
+		{
+			name:    "missing user",
+			request: &DelegatedUserUpdate{Token: "token", UserMetadata: &UserMetadata{JobTitle: converters.StringPtr("Engineer")}},
+			wantErr: true,
+		},
+		{
+			name:    "empty metadata",
+			request: &DelegatedUserUpdate{Token: "token", User: "jane", UserMetadata: &UserMetadata{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, isForbidden := err.(errors.Forbidden); isForbidden != tt.forbidden {
+				t.Errorf("Validate() error type = %T, forbidden %v", err, tt.forbidden)
+			}
+		})
+	}
+}
+
+func TestUserMetadata_Fields(t *testing.T) {
+	metadata := &UserMetadata{
+		Name:                 converters.StringPtr("Jane"),
+		TShirtSize:           converters.StringPtr("M"),
+		OrganizationVerified: converters.BoolPtr(true),
+	}
+	want := []string{"name", "t_shirt_size", "organization_verified"}
+	if got := metadata.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+
+	var empty *UserMetadata
+	if got := empty.Fields(); got != nil {
+		t.Errorf("Fields() on nil = %v, want nil", got)
+	}
+}
+
+func TestOrganizationAdmin_Manages(t *testing.T) {
+	admin := &OrganizationAdmin{Organizations: []string{"CNCF", " OpenSSF "}}
+
+	for organization, want := range map[string]bool{
+		"cncf":    true,
+		"openssf": true,
+		"Other":   false,
+		"":        false,
+	} {
+		if got := admin.Manages(organization); got != want {
+			t.Errorf("Manages(%q) = %v, want %v", organization, got, want)
+		}
+	}
+}
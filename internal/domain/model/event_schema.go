@@ -0,0 +1,15 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "encoding/json"
+
+// EventSchema is the JSON Schema of the events published on a subject, consumers can generate
+// the types of the events from it
+type EventSchema struct {
+	Subject string
+	// Title is the name of the event type
+	Title  string
+	Schema json.RawMessage
+}
@@ -0,0 +1,74 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// FaultKind is the error injected in an operation of a test identity provider
+type FaultKind string
+
+const (
+	// FaultServiceUnavailable fails with a service unavailable error, the provider is down
+	FaultServiceUnavailable FaultKind = "service_unavailable"
+	// FaultRateLimited fails with a too many requests error, the provider quota is exhausted
+	FaultRateLimited FaultKind = "rate_limited"
+	// FaultTimeout hangs until the request deadline, the provider doesn't answer
+	FaultTimeout FaultKind = "timeout"
+	// FaultNotFound fails with a not found error
+	FaultNotFound FaultKind = "not_found"
+	// FaultConflict fails with a conflict error
+	FaultConflict FaultKind = "conflict"
+	// FaultUnauthorized fails with an unauthorized error, the provider credentials are rejected
+	FaultUnauthorized FaultKind = "unauthorized"
+	// FaultUnexpected fails with an unexpected error
+	FaultUnexpected FaultKind = "unexpected"
+)
+
+// faultKinds are all the injectable errors
+var faultKinds = []FaultKind{
+	FaultServiceUnavailable,
+	FaultRateLimited,
+	FaultTimeout,
+	FaultNotFound,
+	FaultConflict,
+	FaultUnauthorized,
+	FaultUnexpected,
+}
+
+// ParseFaultKind parses the error injected in an operation
+func ParseFaultKind(kind string) (FaultKind, error) {
+	for _, known := range faultKinds {
+		if FaultKind(strings.ToLower(strings.TrimSpace(kind))) == known {
+			return known, nil
+		}
+	}
+	return "", errors.NewValidation(fmt.Sprintf("invalid fault %q, expected one of %v", kind, faultKinds))
+}
+
+// OperationFault is the fault injected in an operation of a test identity provider
+type OperationFault struct {
+	// Error is the error injected, none when only delayed
+	Error FaultKind `json:"error,omitempty"`
+	// Rate is the share of the calls failing, between 0 and 1, all of them when zero
+	Rate float64 `json:"rate,omitempty"`
+	// LatencyMs delays the calls in milliseconds, fixed ("150") or a range ("80-400"), in place
+	// of the latency of all the operations
+	LatencyMs string `json:"latency_ms,omitempty"`
+}
+
+// ProviderFaults are the faults injected in the operations of a test identity provider, so the
+// callers can test their retries and fallbacks
+type ProviderFaults struct {
+	// LatencyMs delays every operation in milliseconds, fixed ("150") or a range ("80-400")
+	LatencyMs string `json:"latency_ms,omitempty"`
+	// ErrorRate is the share of every operation failing with a service unavailable error
+	ErrorRate float64 `json:"error_rate,omitempty"`
+	// Operations are the faults of the operations by name, e.g. search_user
+	Operations map[string]OperationFault `json:"operations,omitempty"`
+}
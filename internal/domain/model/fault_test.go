@@ -0,0 +1,32 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "testing"
+
+func TestParseFaultKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    FaultKind
+		wantErr bool
+	}{
+		{name: "known", kind: "rate_limited", want: FaultRateLimited},
+		{name: "case and spaces", kind: " Timeout ", want: FaultTimeout},
+		{name: "unknown", kind: "teapot", wantErr: true},
+		{name: "empty", kind: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFaultKind(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFaultKind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFaultKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ type LinkIdentity struct {
 	LinkWith struct {
 		// IdentityToken is the ID token obtained from the passwordless verification flow.
 		IdentityToken string `json:"identity_token"`
+		// IdentityTokenRef is the reference of the ID token returned by the email verification when the
+		// token references are enabled, resolved to IdentityToken before linking.
+		IdentityTokenRef string `json:"identity_token_ref,omitempty"`
 	} `json:"link_with"`
 }
 
@@ -49,3 +52,64 @@ type UnlinkIdentity struct {
 		IdentityID string `json:"identity_id"`
 	} `json:"unlink"`
 }
+
+// UnlinkAlternateEmail represents a request to unlink an alternate email from a user account, the
+// email identity is resolved from the linked identities of the user.
+type UnlinkAlternateEmail struct {
+	// User contains the authenticated user's information needed to authorize the unlinking action.
+	User struct {
+		// UserID is the primary user's ID, populated from the auth_token sub claim.
+		UserID string `json:"user_id"`
+		// AuthToken is the JWT token with the update:current_user_identities scope.
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+
+	// Email is the alternate email to unlink, the primary email can't be unlinked.
+	Email string `json:"email"`
+}
+
+// VerifyEmailLinking represents a request to verify an alternate email with its one time password or
+// backup code. With the token, the reference returned is bound to the user, as required to change its
+// primary email.
+type VerifyEmailLinking struct {
+	// User contains the authenticated user's information, the token is optional.
+	User struct {
+		// UserID is the user's ID, populated from the auth_token sub claim.
+		UserID string `json:"user_id"`
+		// AuthToken is the JWT token of the user verifying the email.
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+
+	Email
+}
+
+// StartEmailLinking represents a request to send the verification email of an alternate email. The
+// request can also be the bare email, the token is needed to limit the sends per user.
+type StartEmailLinking struct {
+	// User contains the authenticated user's information, the token is optional.
+	User struct {
+		// UserID is the user's ID, populated from the auth_token sub claim.
+		UserID string `json:"user_id"`
+		// AuthToken is the JWT token of the user requesting the verification.
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+
+	// Email is the alternate email to verify.
+	Email string `json:"email"`
+}
+
+// ChangePrimaryEmail represents a request to change the primary email of a user account to an email
+// verified with the email linking flow.
+type ChangePrimaryEmail struct {
+	// User contains the authenticated user's information needed to authorize the change.
+	User struct {
+		// UserID is the user's ID, populated from the auth_token sub claim.
+		UserID string `json:"user_id"`
+		// AuthToken is the JWT token with the update:current_user_identities scope.
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+
+	// IdentityTokenRef is the reference returned by the verification of the new email, it proves the
+	// ownership of the email.
+	IdentityTokenRef string `json:"identity_token_ref"`
+}
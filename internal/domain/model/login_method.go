@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+// LoginMethod is a way the user can sign in, derived from the identity connections
+type LoginMethod string
+
+const (
+	// LoginMethodPasskey is a WebAuthn security key or passkey
+	LoginMethodPasskey LoginMethod = "passkey"
+	// LoginMethodGoogle is the Google social connection
+	LoginMethodGoogle LoginMethod = "google"
+	// LoginMethodGitHub is the GitHub social connection
+	LoginMethodGitHub LoginMethod = "github"
+	// LoginMethodLinkedIn is the LinkedIn social connection
+	LoginMethodLinkedIn LoginMethod = "linkedin"
+	// LoginMethodPassword is the username and password database connection
+	LoginMethodPassword LoginMethod = "password"
+	// LoginMethodEmail is the passwordless email (magic link or code) connection
+	LoginMethodEmail LoginMethod = "email"
+)
+
+// loginMethodsByStrength lists the login methods from the strongest to the weakest,
+// passkeys are phishing resistant and social providers enforce their own MFA policies
+var loginMethodsByStrength = []LoginMethod{
+	LoginMethodPasskey,
+	LoginMethodGoogle,
+	LoginMethodGitHub,
+	LoginMethodLinkedIn,
+	LoginMethodPassword,
+	LoginMethodEmail,
+}
+
+// identityLoginMethods maps the identity providers to their login method
+var identityLoginMethods = map[string]LoginMethod{
+	"auth0":         LoginMethodPassword,
+	"google-oauth2": LoginMethodGoogle,
+	"github":        LoginMethodGitHub,
+	"linkedin":      LoginMethodLinkedIn,
+	"email":         LoginMethodEmail,
+}
+
+// LoginMethods returns the login methods available to the user from its identities,
+// ordered from the strongest to the weakest. Providers without a known login method are ignored.
+// Passkeys are not identities, set hasPasskey when the user registered at least one.
+func (u *User) LoginMethods(hasPasskey bool) []LoginMethod {
+	available := make(map[LoginMethod]bool)
+	if hasPasskey {
+		available[LoginMethodPasskey] = true
+	}
+	for _, identity := range u.Identities {
+		if method, ok := identityLoginMethods[identity.Provider]; ok {
+			available[method] = true
+		}
+	}
+
+	var methods []LoginMethod
+	for _, method := range loginMethodsByStrength {
+		if available[method] {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// RecommendedLoginMethod returns the strongest of the given login methods, the login screen
+// pre-selects it and nudges the user to register a passkey when it is not one.
+// It returns an empty method when the list is empty.
+func RecommendedLoginMethod(methods []LoginMethod) LoginMethod {
+	for _, method := range loginMethodsByStrength {
+		for _, available := range methods {
+			if method == available {
+				return method
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUser_LoginMethods(t *testing.T) {
+	tests := []struct {
+		name        string
+		identities  []Identity
+		hasPasskey  bool
+		want        []LoginMethod
+		recommended LoginMethod
+	}{
+		{
+			name:        "no identities",
+			want:        nil,
+			recommended: "",
+		},
+		{
+			name:        "password only",
+			identities:  []Identity{{Provider: "auth0"}},
+			want:        []LoginMethod{LoginMethodPassword},
+			recommended: LoginMethodPassword,
+		},
+		{
+			name: "social and password ordered by strength",
+			identities: []Identity{
+				{Provider: "auth0"},
+				{Provider: "github"},
+				{Provider: "google-oauth2"},
+				{Provider: "github"},
+			},
+			want:        []LoginMethod{LoginMethodGoogle, LoginMethodGitHub, LoginMethodPassword},
+			recommended: LoginMethodGoogle,
+		},
+		{
+			name:        "passkey is the strongest",
+			identities:  []Identity{{Provider: "auth0"}, {Provider: "linkedin"}},
+			hasPasskey:  true,
+			want:        []LoginMethod{LoginMethodPasskey, LoginMethodLinkedIn, LoginMethodPassword},
+			recommended: LoginMethodPasskey,
+		},
+		{
+			name:        "unknown providers are ignored",
+			identities:  []Identity{{Provider: "samlp"}, {Provider: "email"}},
+			want:        []LoginMethod{LoginMethodEmail},
+			recommended: LoginMethodEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Identities: tt.identities}
+
+			got := user.LoginMethods(tt.hasPasskey)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoginMethods() = %v, want %v", got, tt.want)
+			}
+			if recommended := RecommendedLoginMethod(got); recommended != tt.recommended {
+				t.Errorf("RecommendedLoginMethod() = %q, want %q", recommended, tt.recommended)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// MergePolicy decides which account wins when both accounts have a different value for a metadata field
+type MergePolicy string
+
+const (
+	// MergePolicyPrimary keeps the primary account values, the secondary account only fills the gaps
+	MergePolicyPrimary MergePolicy = "primary"
+	// MergePolicySecondary keeps the secondary account values, the primary account only fills the gaps
+	MergePolicySecondary MergePolicy = "secondary"
+)
+
+// ParseMergePolicy parses the conflict policy of a merge, the primary account wins by default
+func ParseMergePolicy(policy string) (MergePolicy, error) {
+	switch MergePolicy(strings.ToLower(strings.TrimSpace(policy))) {
+	case "", MergePolicyPrimary:
+		return MergePolicyPrimary, nil
+	case MergePolicySecondary:
+		return MergePolicySecondary, nil
+	}
+	return "", errors.NewValidation(fmt.Sprintf("invalid conflict policy: %q", policy))
+}
+
+// derivedMetadataFields are computed by the service and never merged
+var derivedMetadataFields = []string{"organization_verified"}
+
+// MergeUserMetadata merges the metadata of two accounts, fields set only in one of them are kept and
+// conflicting fields are resolved using the policy. It also returns the json name of the conflicting fields.
+// Blank values are considered unset.
+func MergeUserMetadata(primary, secondary *UserMetadata, policy MergePolicy) (*UserMetadata, []string) {
+	winner, loser := primary, secondary
+	if policy == MergePolicySecondary {
+		winner, loser = secondary, primary
+	}
+
+	merged := &UserMetadata{}
+	mergedValue := reflect.ValueOf(merged).Elem()
+
+	field := func(metadata *UserMetadata, i int) reflect.Value {
+		if metadata == nil {
+			return reflect.Value{}
+		}
+		value := reflect.ValueOf(metadata).Elem().Field(i)
+		if value.IsNil() || (value.Elem().Kind() == reflect.String && strings.TrimSpace(value.Elem().String()) == "") {
+			return reflect.Value{}
+		}
+		return value
+	}
+
+	var conflicts []string
+	for i := range mergedValue.NumField() {
+		name, _, _ := strings.Cut(mergedValue.Type().Field(i).Tag.Get("json"), ",")
+		if slices.Contains(derivedMetadataFields, name) {
+			continue
+		}
+
+		winning, losing := field(winner, i), field(loser, i)
+		switch {
+		case winning.IsValid():
+			mergedValue.Field(i).Set(winning)
+			if losing.IsValid() && !reflect.DeepEqual(winning.Interface(), losing.Interface()) {
+				conflicts = append(conflicts, name)
+			}
+		case losing.IsValid():
+			mergedValue.Field(i).Set(losing)
+		}
+	}
+	return merged, conflicts
+}
+
+// UserMerge is the request to merge the secondary account into the primary one,
+// once merged the user signs in to the primary account with any of the identities of both
+type UserMerge struct {
+	Primary   *User
+	Secondary *User
+	// Metadata is the merged metadata stored in the primary account
+	Metadata *UserMetadata
+}
+
+// UserMerged is the event emitted once two accounts are merged, downstream services
+// should move the data owned by the secondary account to the primary one
+type UserMerged struct {
+	PrimarySub        string      `json:"primary_sub"`
+	PrimaryUsername   string      `json:"primary_username,omitempty"`
+	SecondarySub      string      `json:"secondary_sub"`
+	SecondaryUsername string      `json:"secondary_username,omitempty"`
+	ConflictPolicy    MergePolicy `json:"conflict_policy"`
+	Conflicts         []string    `json:"conflicts,omitempty"`
+	MergedAt          time.Time   `json:"merged_at"`
+}
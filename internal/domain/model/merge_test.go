@@ -0,0 +1,100 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMergePolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    MergePolicy
+		wantErr bool
+	}{
+		{policy: "", want: MergePolicyPrimary},
+		{policy: "primary", want: MergePolicyPrimary},
+		{policy: " Secondary ", want: MergePolicySecondary},
+		{policy: "newest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			got, err := ParseMergePolicy(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMergePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMergePolicy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeUserMetadata(t *testing.T) {
+	str := func(s string) *string { return &s }
+	verified := true
+
+	primary := &UserMetadata{
+		Name:                 str("Jane Doe"),
+		JobTitle:             str("Engineer"),
+		City:                 str("  "),
+		OrganizationVerified: &verified,
+	}
+	secondary := &UserMetadata{
+		Name:         str("Jane D."),
+		Organization: str("CNCF"),
+		City:         str("Lisbon"),
+	}
+
+	tests := []struct {
+		name          string
+		primary       *UserMetadata
+		secondary     *UserMetadata
+		policy        MergePolicy
+		want          *UserMetadata
+		wantConflicts []string
+	}{
+		{
+			name:          "primary wins",
+			primary:       primary,
+			secondary:     secondary,
+			policy:        MergePolicyPrimary,
+			want:          &UserMetadata{Name: str("Jane Doe"), JobTitle: str("Engineer"), Organization: str("CNCF"), City: str("Lisbon")},
+			wantConflicts: []string{"name"},
+		},
+		{
+			name:          "secondary wins",
+			primary:       primary,
+			secondary:     secondary,
+			policy:        MergePolicySecondary,
+			want:          &UserMetadata{Name: str("Jane D."), JobTitle: str("Engineer"), Organization: str("CNCF"), City: str("Lisbon")},
+			wantConflicts: []string{"name"},
+		},
+		{
+			name:      "missing primary metadata",
+			secondary: secondary,
+			policy:    MergePolicyPrimary,
+			want:      &UserMetadata{Name: str("Jane D."), Organization: str("CNCF"), City: str("Lisbon")},
+		},
+		{
+			name:   "nothing to merge",
+			policy: MergePolicyPrimary,
+			want:   &UserMetadata{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := MergeUserMetadata(tt.primary, tt.secondary, tt.policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeUserMetadata() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.wantConflicts) {
+				t.Errorf("MergeUserMetadata() conflicts = %v, want %v", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
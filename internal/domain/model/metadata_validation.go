@@ -0,0 +1,127 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	// the runtime images have no zoneinfo database, the timezones are validated against the embedded one
+	_ "time/tzdata"
+)
+
+// TShirtSizes are the t-shirt sizes of the user metadata
+var TShirtSizes = []string{"XS", "S", "M", "L", "XL", "XXL", "XXXL"}
+
+// countryCodes are the ISO 3166-1 alpha-2 codes of the countries
+var countryCodes = func() map[string]struct{} {
+	codes := make(map[string]struct{})
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS
+		BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE
+		EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM
+		HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC
+		LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ NA
+		NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW
+		SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO
+		TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`) {
+		codes[code] = struct{}{}
+	}
+	return codes
+}()
+
+// isTimezone checks if the value is an IANA timezone, like America/Los_Angeles
+func isTimezone(value string) bool {
+	if value == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(value)
+	return err == nil
+}
+
+// isCountryCode checks if the value is an ISO 3166-1 alpha-2 country code, like US
+func isCountryCode(value string) bool {
+	_, ok := countryCodes[value]
+	return ok
+}
+
+// isPictureURL checks if the value is an https URL, http is only allowed for the local hosts of
+// the development environments
+func isPictureURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	switch parsed.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := parsed.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+	return false
+}
+
+// Violations returns every rule failed by the values of the metadata fields, the empty values
+// are not checked
+func (a *UserMetadata) Violations() ValidationViolations {
+	if a == nil {
+		return nil
+	}
+
+	var violations ValidationViolations
+	maxLength := func(field string, value *string) {
+		if value == nil || utf8.RuneCountInString(*value) <= metadataMaxLengths[field] {
+			return
+		}
+		violations = append(violations, ValidationViolation{
+			Field:       "user_metadata." + field,
+			Rule:        ValidationRuleMaxLength,
+			Description: fmt.Sprintf("%s must be at most %d characters", field, metadataMaxLengths[field]),
+		})
+	}
+	maxLength("name", a.Name)
+	maxLength("given_name", a.GivenName)
+	maxLength("family_name", a.FamilyName)
+	maxLength("job_title", a.JobTitle)
+	maxLength("organization", a.Organization)
+	maxLength("state_province", a.StateProvince)
+	maxLength("city", a.City)
+	maxLength("address", a.Address)
+	maxLength("postal_code", a.PostalCode)
+	maxLength("picture", a.Picture)
+
+	check := func(field string, value *string, rule ValidationRule, valid func(string) bool, description string) {
+		if value == nil || *value == "" || valid(*value) {
+			return
+		}
+		violations = append(violations, ValidationViolation{
+			Field:       "user_metadata." + field,
+			Rule:        rule,
+			Description: fmt.Sprintf("%s %s", field, description),
+		})
+	}
+
+	check("zoneinfo", a.Zoneinfo, ValidationRuleFormat, isTimezone,
+		"must be an IANA timezone, like America/Los_Angeles")
+	check("country", a.Country, ValidationRuleFormat, isCountryCode,
+		"must be an ISO 3166-1 alpha-2 country code, like US")
+	check("t_shirt_size", a.TShirtSize, ValidationRuleEnum, func(value string) bool { return slices.Contains(TShirtSizes, value) },
+		fmt.Sprintf("must be one of %s", strings.Join(TShirtSizes, ", ")))
+	check("phone_number", a.PhoneNumber, ValidationRuleFormat, func(value string) bool { return e164.MatchString(normalizePhoneNumber(value)) },
+		"must be in the E.164 format, like +14155550123")
+	check("picture", a.Picture, ValidationRuleFormat, isPictureURL,
+		"must be an https URL")
+
+	return violations
+}
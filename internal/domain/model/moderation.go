@@ -0,0 +1,78 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "fmt"
+
+// ModerationAction is what a metadata policy does with a value
+type ModerationAction string
+
+const (
+	// ModerationActionAllow keeps the value
+	ModerationActionAllow ModerationAction = "allow"
+	// ModerationActionRedact replaces the value with the redacted one
+	ModerationActionRedact ModerationAction = "redact"
+	// ModerationActionReject fails the update
+	ModerationActionReject ModerationAction = "reject"
+)
+
+// ModerationDecision is the decision of a metadata policy on the value of a field
+type ModerationDecision struct {
+	// Field is the metadata field, e.g. organization
+	Field  string           `json:"field"`
+	Action ModerationAction `json:"action"`
+	// Value replaces the value of a redacted field
+	Value string `json:"value,omitempty"`
+	// Reason is told to the user when the value is rejected
+	Reason string `json:"reason,omitempty"`
+}
+
+// Moderate applies the decisions of the metadata policies, the redacted values are replaced and
+// the rejected ones are returned as violations
+func (a *UserMetadata) Moderate(decisions []ModerationDecision) ValidationViolations {
+	if a == nil {
+		return nil
+	}
+
+	var violations ValidationViolations
+	fields := a.clearableFields()
+	for _, decision := range decisions {
+		value, ok := fields[decision.Field]
+		if !ok || *value == nil {
+			continue
+		}
+		switch decision.Action {
+		case ModerationActionAllow:
+		case ModerationActionRedact:
+			redacted := decision.Value
+			*value = &redacted
+		case ModerationActionReject:
+			description := fmt.Sprintf("%s is not allowed", decision.Field)
+			if decision.Reason != "" {
+				description = fmt.Sprintf("%s: %s", description, decision.Reason)
+			}
+			violations = append(violations, ValidationViolation{
+				Field:       "user_metadata." + decision.Field,
+				Rule:        ValidationRulePolicy,
+				Description: description,
+			})
+		}
+	}
+	return violations
+}
+
+// ModeratedValues returns the values of the set fields reviewed by the metadata policies, by field,
+// the empty values are left out
+func (a *UserMetadata) ModeratedValues() map[string]string {
+	if a == nil {
+		return nil
+	}
+	values := make(map[string]string)
+	for field, value := range a.clearableFields() {
+		if *value != nil && **value != "" {
+			values[field] = **value
+		}
+	}
+	return values
+}
@@ -0,0 +1,35 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserMetadata_Moderate(t *testing.T) {
+	name, title, org := "Jane ***", "Engineer", "ACME https://spam.example"
+	metadata := &UserMetadata{Name: &name, JobTitle: &title, Organization: &org}
+
+	violations := metadata.Moderate([]ModerationDecision{
+		{Field: "name", Action: ModerationActionRedact, Value: "Jane"},
+		{Field: "job_title", Action: ModerationActionAllow},
+		{Field: "organization", Action: ModerationActionReject, Reason: "links are not allowed"},
+		// the decisions on unset or unknown fields are ignored
+		{Field: "city", Action: ModerationActionReject},
+		{Field: "username", Action: ModerationActionReject},
+	})
+
+	assert.Equal(t, "Jane", *metadata.Name)
+	assert.Equal(t, "Engineer", *metadata.JobTitle)
+	assert.Equal(t, ValidationViolations{{
+		Field:       "user_metadata.organization",
+		Rule:        ValidationRulePolicy,
+		Description: "organization is not allowed: links are not allowed",
+	}}, violations)
+
+	assert.Equal(t, map[string]string{"name": "Jane", "job_title": "Engineer", "organization": org}, metadata.ModeratedValues())
+	assert.Nil(t, (*UserMetadata)(nil).Moderate([]ModerationDecision{{Field: "name", Action: ModerationActionReject}}))
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// NudgeReasonUnverifiedEmail is set when the user has alternate emails that were never verified
+	NudgeReasonUnverifiedEmail = "unverified_email"
+	// NudgeReasonMissingOrganization is set when the user has no organization in the profile
+	NudgeReasonMissingOrganization = "missing_organization"
+)
+
+// ProfileNudge is the event emitted when a profile is stale and should be re-verified
+type ProfileNudge struct {
+	Username  string    `json:"username"`
+	Sub       string    `json:"sub,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Reasons   []string  `json:"reasons"`
+
+	// OriginRegion is the region that emitted the event, so consumers of
+	// replicated streams can tell the events of each region apart
+	OriginRegion string `json:"origin_region,omitempty"`
+}
+
+// ReverificationReasons returns why the user profile should be re-verified,
+// an empty result means the profile data is considered verified
+func (u *User) ReverificationReasons() []string {
+	var reasons []string
+
+	for _, email := range u.AlternateEmails {
+		if !email.Verified {
+			reasons = append(reasons, NudgeReasonUnverifiedEmail)
+			break
+		}
+	}
+
+	if u.UserMetadata == nil || u.UserMetadata.Organization == nil || strings.TrimSpace(*u.UserMetadata.Organization) == "" {
+		reasons = append(reasons, NudgeReasonMissingOrganization)
+	}
+
+	return reasons
+}
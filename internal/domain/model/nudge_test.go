@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+)
+
+func TestUser_ReverificationReasons(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want []string
+	}{
+		{
+			name: "verified profile",
+			user: &User{
+				AlternateEmails: []Email{{Email: "alt@example.com", Verified: true}},
+				UserMetadata:    &UserMetadata{Organization: converters.StringPtr("LF")},
+			},
+			want: nil,
+		},
+		{
+			name: "unverified alternate email",
+			user: &User{
+				AlternateEmails: []Email{
+					{Email: "alt1@example.com", Verified: true},
+					{Email: "alt2@example.com"},
+					{Email: "alt3@example.com"},
+				},
+				UserMetadata: &UserMetadata{Organization: converters.StringPtr("LF")},
+			},
+			want: []string{NudgeReasonUnverifiedEmail},
+		},
+		{
+			name: "no metadata",
+			user: &User{},
+			want: []string{NudgeReasonMissingOrganization},
+		},
+		{
+			name: "blank organization and unverified email",
+			user: &User{
+				AlternateEmails: []Email{{Email: "alt@example.com"}},
+				UserMetadata:    &UserMetadata{Organization: converters.StringPtr("  ")},
+			},
+			want: []string{NudgeReasonUnverifiedEmail, NudgeReasonMissingOrganization},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.user.ReverificationReasons()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReverificationReasons() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
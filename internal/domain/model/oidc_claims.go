@@ -0,0 +1,73 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+// OIDCAddress is the OIDC standard address claim
+type OIDCAddress struct {
+	StreetAddress string `json:"street_address,omitempty"`
+	Locality      string `json:"locality,omitempty"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	Country       string `json:"country,omitempty"`
+}
+
+// OIDCClaims are the user attributes named after the OIDC standard claims
+// (OpenID Connect Core 1.0, section 5.1), so consumers can reuse their OIDC deserializers.
+// The attributes without standard claim (job title, organization, t-shirt size) are not included.
+type OIDCClaims struct {
+	Sub               string       `json:"sub"`
+	Name              string       `json:"name,omitempty"`
+	GivenName         string       `json:"given_name,omitempty"`
+	FamilyName        string       `json:"family_name,omitempty"`
+	PreferredUsername string       `json:"preferred_username,omitempty"`
+	Picture           string       `json:"picture,omitempty"`
+	Email             string       `json:"email,omitempty"`
+	Zoneinfo          string       `json:"zoneinfo,omitempty"`
+	PhoneNumber       string       `json:"phone_number,omitempty"`
+	Address           *OIDCAddress `json:"address,omitempty"`
+}
+
+// OIDCClaims maps the user and its metadata to the OIDC standard claims
+func (u *User) OIDCClaims() *OIDCClaims {
+	claims := &OIDCClaims{
+		Sub:               u.Sub,
+		PreferredUsername: u.Username,
+		Email:             u.PrimaryEmail,
+	}
+	if claims.Sub == "" {
+		claims.Sub = u.UserID
+	}
+
+	metadata := u.UserMetadata
+	if metadata == nil {
+		return claims
+	}
+
+	value := func(field *string) string {
+		if field == nil {
+			return ""
+		}
+		return *field
+	}
+
+	claims.Name = value(metadata.Name)
+	claims.GivenName = value(metadata.GivenName)
+	claims.FamilyName = value(metadata.FamilyName)
+	claims.Picture = value(metadata.Picture)
+	claims.Zoneinfo = value(metadata.Zoneinfo)
+	claims.PhoneNumber = value(metadata.PhoneNumber)
+
+	address := OIDCAddress{
+		StreetAddress: value(metadata.Address),
+		Locality:      value(metadata.City),
+		Region:        value(metadata.StateProvince),
+		PostalCode:    value(metadata.PostalCode),
+		Country:       value(metadata.Country),
+	}
+	if address != (OIDCAddress{}) {
+		claims.Address = &address
+	}
+
+	return claims
+}
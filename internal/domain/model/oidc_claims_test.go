@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUser_OIDCClaims(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{
+			name: "user without metadata",
+			user: &User{UserID: "auth0|123", Username: "jdoe", PrimaryEmail: "jdoe@example.com"},
+			want: `{"sub":"auth0|123","preferred_username":"jdoe","email":"jdoe@example.com"}`,
+		},
+		{
+			name: "metadata mapped to the standard claims",
+			user: &User{
+				UserID: "auth0|123",
+				Sub:    "auth0|456",
+				UserMetadata: &UserMetadata{
+					Name:          strPtr("John Doe"),
+					GivenName:     strPtr("John"),
+					FamilyName:    strPtr("Doe"),
+					Picture:       strPtr("https://example.com/avatar.jpg"),
+					Zoneinfo:      strPtr("America/Los_Angeles"),
+					PhoneNumber:   strPtr("+1-555-0123"),
+					JobTitle:      strPtr("Software Engineer"),
+					Address:       strPtr("123 Main Street"),
+					City:          strPtr("San Francisco"),
+					StateProvince: strPtr("California"),
+					PostalCode:    strPtr("94102"),
+					Country:       strPtr("United States"),
+				},
+			},
+			want: `{"sub":"auth0|456","name":"John Doe","given_name":"John","family_name":"Doe",` +
+				`"picture":"https://example.com/avatar.jpg","zoneinfo":"America/Los_Angeles","phone_number":"+1-555-0123",` +
+				`"address":{"street_address":"123 Main Street","locality":"San Francisco","region":"California",` +
+				`"postal_code":"94102","country":"United States"}}`,
+		},
+		{
+			name: "address omitted without address fields",
+			user: &User{UserID: "auth0|123", UserMetadata: &UserMetadata{Name: strPtr("John Doe")}},
+			want: `{"sub":"auth0|123","name":"John Doe"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.user.OIDCClaims())
+			if err != nil {
+				t.Fatalf("failed to marshal claims: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("OIDCClaims() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
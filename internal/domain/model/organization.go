@@ -0,0 +1,88 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// OrganizationDomains maps a normalized organization name to its registered email domains
+type OrganizationDomains map[string][]string
+
+func normalizeOrganization(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ParseOrganizationDomains parses organization domains from a spec of the form
+// "Org A=a.org,a.io;Org B=b.com"
+func ParseOrganizationDomains(spec string) (OrganizationDomains, error) {
+	domains := make(OrganizationDomains)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, list, found := strings.Cut(entry, "=")
+		if !found || normalizeOrganization(name) == "" {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid organization domains entry: %q", entry))
+		}
+		for _, domain := range strings.Split(list, ",") {
+			domain = strings.ToLower(strings.TrimSpace(domain))
+			if domain == "" {
+				continue
+			}
+			domains[normalizeOrganization(name)] = append(domains[normalizeOrganization(name)], domain)
+		}
+	}
+	return domains, nil
+}
+
+// IsVerified reports whether any of the given emails belongs to one of the organization
+// domains, subdomains of a registered domain are accepted as well
+func (d OrganizationDomains) IsVerified(organization string, emails ...string) bool {
+	registered := d[normalizeOrganization(organization)]
+	if len(registered) == 0 {
+		return false
+	}
+	for _, email := range emails {
+		at := strings.LastIndex(email, "@")
+		if at < 0 {
+			continue
+		}
+		emailDomain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+		for _, domain := range registered {
+			if emailDomain == domain || strings.HasSuffix(emailDomain, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VerifiedEmails returns the verified primary email and alternate emails of the user, the primary
+// email is left out unless the identity provider verified it
+func (u *User) VerifiedEmails() []string {
+	var emails []string
+	if u.PrimaryEmail != "" && u.PrimaryEmailVerified {
+		emails = append(emails, u.PrimaryEmail)
+	}
+	for _, email := range u.AlternateEmails {
+		if email.Verified {
+			emails = append(emails, email.Email)
+		}
+	}
+	return emails
+}
+
+// OrganizationVerified computes the organization verified badge for the given organization
+// against the user verified emails
+func (u *User) OrganizationVerified(domains OrganizationDomains, organization string) bool {
+	if strings.TrimSpace(organization) == "" {
+		return false
+	}
+	return domains.IsVerified(organization, u.VerifiedEmails()...)
+}
@@ -0,0 +1,122 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOrganizationDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    OrganizationDomains
+		wantErr bool
+	}{
+		{
+			name: "empty spec",
+			spec: "",
+			want: OrganizationDomains{},
+		},
+		{
+			name: "multiple organizations",
+			spec: "The Linux Foundation=linuxfoundation.org, LFX.dev ; CNCF=cncf.io;",
+			want: OrganizationDomains{
+				"the linux foundation": {"linuxfoundation.org", "lfx.dev"},
+				"cncf":                 {"cncf.io"},
+			},
+		},
+		{
+			name:    "missing separator",
+			spec:    "CNCF",
+			wantErr: true,
+		},
+		{
+			name:    "missing organization name",
+			spec:    "=cncf.io",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOrganizationDomains(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOrganizationDomains() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOrganizationDomains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_OrganizationVerified(t *testing.T) {
+	domains := OrganizationDomains{"cncf": {"cncf.io"}}
+
+	tests := []struct {
+		name         string
+		user         *User
+		organization string
+		want         bool
+	}{
+		{
+			name:         "primary email matches",
+			user:         &User{PrimaryEmail: "jane@cncf.io", PrimaryEmailVerified: true},
+			organization: "CNCF",
+			want:         true,
+		},
+		{
+			name:         "unverified primary email does not match",
+			user:         &User{PrimaryEmail: "jane@cncf.io"},
+			organization: "CNCF",
+			want:         false,
+		},
+		{
+			name:         "subdomain matches",
+			user:         &User{PrimaryEmail: "jane@mail.cncf.io", PrimaryEmailVerified: true},
+			organization: "cncf",
+			want:         true,
+		},
+		{
+			name:         "verified alternate email matches",
+			user:         &User{PrimaryEmail: "jane@example.com", AlternateEmails: []Email{{Email: "jane@cncf.io", Verified: true}}},
+			organization: "cncf",
+			want:         true,
+		},
+		{
+			name:         "unverified alternate email does not match",
+			user:         &User{PrimaryEmail: "jane@example.com", AlternateEmails: []Email{{Email: "jane@cncf.io"}}},
+			organization: "cncf",
+			want:         false,
+		},
+		{
+			name:         "lookalike domain does not match",
+			user:         &User{PrimaryEmail: "jane@notcncf.io", PrimaryEmailVerified: true},
+			organization: "cncf",
+			want:         false,
+		},
+		{
+			name:         "unregistered organization",
+			user:         &User{PrimaryEmail: "jane@cncf.io", PrimaryEmailVerified: true},
+			organization: "Other",
+			want:         false,
+		},
+		{
+			name:         "empty organization",
+			user:         &User{PrimaryEmail: "jane@cncf.io", PrimaryEmailVerified: true},
+			organization: "",
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.OrganizationVerified(domains, tt.organization); got != tt.want {
+				t.Errorf("OrganizationVerified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// TypeaheadMinQueryLength is the minimum number of characters of a typeahead query,
+	// shorter prefixes would list most of the users
+	TypeaheadMinQueryLength = 2
+
+	// TypeaheadDefaultLimit is the number of typeahead matches returned when the limit is not set
+	TypeaheadDefaultLimit = 10
+
+	// TypeaheadMaxLimit caps the number of typeahead matches of a query
+	TypeaheadMaxLimit = 25
+)
+
+// ProfileDocument is the public view of a user profile published for the people search across LFX.
+// It never carries the emails, the phone number, the postal address or the other private attributes,
+// search services index it as is.
+type ProfileDocument struct {
+	Sub                  string    `json:"sub"`
+	Username             string    `json:"username,omitempty"`
+	Name                 string    `json:"name,omitempty"`
+	GivenName            string    `json:"given_name,omitempty"`
+	FamilyName           string    `json:"family_name,omitempty"`
+	Picture              string    `json:"picture,omitempty"`
+	JobTitle             string    `json:"job_title,omitempty"`
+	Organization         string    `json:"organization,omitempty"`
+	OrganizationVerified bool      `json:"organization_verified,omitempty"`
+	Country              string    `json:"country,omitempty"`
+	Deleted              bool      `json:"deleted,omitempty"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// ProfileDocument returns the public view of the user, as of the given time
+func (u *User) ProfileDocument(at time.Time) *ProfileDocument {
+	document := &ProfileDocument{
+		Sub:       u.ProfileSub(),
+		Username:  u.Username,
+		UpdatedAt: at,
+	}
+
+	metadata := u.UserMetadata
+	if metadata == nil {
+		return document
+	}
+
+	value := func(field *string) string {
+		if field == nil {
+			return ""
+		}
+		return *field
+	}
+
+	document.Name = value(metadata.Name)
+	document.GivenName = value(metadata.GivenName)
+	document.FamilyName = value(metadata.FamilyName)
+	document.Picture = value(metadata.Picture)
+	document.JobTitle = value(metadata.JobTitle)
+	document.Organization = value(metadata.Organization)
+	document.OrganizationVerified = metadata.OrganizationVerified != nil && *metadata.OrganizationVerified
+	document.Country = value(metadata.Country)
+
+	return document
+}
+
+// DeletedProfileDocument is the tombstone of a user no longer found, search services drop it from the index
+func DeletedProfileDocument(sub string, at time.Time) *ProfileDocument {
+	return &ProfileDocument{
+		Sub:       sub,
+		Deleted:   true,
+		UpdatedAt: at,
+	}
+}
+
+// TypeaheadMatch is a user matching a typeahead query, for the @-mention features of the LFX tools.
+// It only carries what a mention needs to be displayed, out of the public profile document.
+type TypeaheadMatch struct {
+	Sub      string `json:"sub"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Picture  string `json:"picture,omitempty"`
+}
+
+// TypeaheadMatch returns the typeahead match of the user of the document, the name
+// falls back to the given and family names
+func (d *ProfileDocument) TypeaheadMatch() TypeaheadMatch {
+	name := d.Name
+	if name == "" {
+		name = strings.TrimSpace(d.GivenName + " " + d.FamilyName)
+	}
+	return TypeaheadMatch{
+		Sub:      d.Sub,
+		Username: d.Username,
+		Name:     name,
+		Picture:  d.Picture,
+	}
+}
+
+// TypeaheadLimit returns the number of matches of a typeahead query, the default when
+// not set and capped to TypeaheadMaxLimit
+func TypeaheadLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return TypeaheadDefaultLimit
+	case limit > TypeaheadMaxLimit:
+		return TypeaheadMaxLimit
+	default:
+		return limit
+	}
+}
+
+// SharedProfile is the view of a profile resolved from a share link, seen outside authenticated
+// contexts. It's limited to what a speaker or maintainer card displays, without the subject identifier.
+type SharedProfile struct {
+	Username             string `json:"username,omitempty"`
+	Name                 string `json:"name,omitempty"`
+	Picture              string `json:"picture,omitempty"`
+	JobTitle             string `json:"job_title,omitempty"`
+	Organization         string `json:"organization,omitempty"`
+	OrganizationVerified bool   `json:"organization_verified,omitempty"`
+}
+
+// SharedProfile returns the shared view of the user of the document
+func (d *ProfileDocument) SharedProfile() SharedProfile {
+	match := d.TypeaheadMatch()
+	return SharedProfile{
+		Username:             match.Username,
+		Name:                 match.Name,
+		Picture:              match.Picture,
+		JobTitle:             d.JobTitle,
+		Organization:         d.Organization,
+		OrganizationVerified: d.OrganizationVerified,
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ProfileDocument(t *testing.T) {
+	at := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		user     *User
+		expected *ProfileDocument
+	}{
+		{
+			name: "public attributes only",
+			user: &User{
+				UserID:          "auth0|123",
+				Username:        "jdoe",
+				PrimaryEmail:    "jane@example.com",
+				AlternateEmails: []Email{{Email: "jane@work.example.com", Verified: true}},
+				UserMetadata: &UserMetadata{
+					Name:                 converters.StringPtr("Jane Doe"),
+					GivenName:            converters.StringPtr("Jane"),
+					FamilyName:           converters.StringPtr("Doe"),
+					JobTitle:             converters.StringPtr("Engineer"),
+					Organization:         converters.StringPtr("The Linux Foundation"),
+					OrganizationVerified: converters.BoolPtr(true),
+					Country:              converters.StringPtr("Portugal"),
+					City:                 converters.StringPtr("Lisbon"),
+					Address:              converters.StringPtr("1 Main St"),
+					PostalCode:           converters.StringPtr("1000-001"),
+					PhoneNumber:          converters.StringPtr("+351 000 000 000"),
+					TShirtSize:           converters.StringPtr("M"),
+				},
+			},
+			expected: &ProfileDocument{
+				Sub:                  "auth0|123",
+				Username:             "jdoe",
+				Name:                 "Jane Doe",
+				GivenName:            "Jane",
+				FamilyName:           "Doe",
+				JobTitle:             "Engineer",
+				Organization:         "The Linux Foundation",
+				OrganizationVerified: true,
+				Country:              "Portugal",
+				UpdatedAt:            at,
+			},
+		},
+		{
+			name:     "without metadata",
+			user:     &User{Sub: "auth0|123", Username: "jdoe"},
+			expected: &ProfileDocument{Sub: "auth0|123", Username: "jdoe", UpdatedAt: at},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.user.ProfileDocument(at))
+		})
+	}
+}
+
+func TestDeletedProfileDocument(t *testing.T) {
+	at := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	data, err := json.Marshal(DeletedProfileDocument("auth0|123", at))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sub":"auth0|123","deleted":true,"updated_at":"2026-10-16T10:00:00Z"}`, string(data))
+}
+
+func TestProfileDocument_TypeaheadMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document ProfileDocument
+		expected TypeaheadMatch
+	}{
+		{
+			name: "display name",
+			document: ProfileDocument{
+				Sub:          "auth0|123",
+				Username:     "jdoe",
+				Name:         "Jane Doe",
+				GivenName:    "Jane",
+				Picture:      "https://example.com/jane.png",
+				Organization: "The Linux Foundation",
+			},
+			expected: TypeaheadMatch{Sub: "auth0|123", Username: "jdoe", Name: "Jane Doe", Picture: "https://example.com/jane.png"},
+		},
+		{
+			name:     "given and family names",
+			document: ProfileDocument{Sub: "auth0|123", Username: "jdoe", GivenName: "Jane", FamilyName: "Doe"},
+			expected: TypeaheadMatch{Sub: "auth0|123", Username: "jdoe", Name: "Jane Doe"},
+		},
+		{
+			name:     "given name only",
+			document: ProfileDocument{Sub: "auth0|123", GivenName: "Jane"},
+			expected: TypeaheadMatch{Sub: "auth0|123", Name: "Jane"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.document.TypeaheadMatch())
+		})
+	}
+}
+
+func TestTypeaheadLimit(t *testing.T) {
+	assert.Equal(t, TypeaheadDefaultLimit, TypeaheadLimit(0))
+	assert.Equal(t, TypeaheadDefaultLimit, TypeaheadLimit(-1))
+	assert.Equal(t, 5, TypeaheadLimit(5))
+	assert.Equal(t, TypeaheadMaxLimit, TypeaheadLimit(1000))
+}
@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "time"
+
+// ProfileChangeReason is the operation that changed a user profile
+type ProfileChangeReason string
+
+const (
+	// ProfileChangeSubscribed is sent first on the profile change streams, it's not a change
+	ProfileChangeSubscribed ProfileChangeReason = "subscribed"
+
+	// ProfileChangeUpdate is a profile update by the user
+	ProfileChangeUpdate ProfileChangeReason = "update"
+
+	// ProfileChangeAdminUpdate is a profile update by an organization admin
+	ProfileChangeAdminUpdate ProfileChangeReason = "admin_update"
+
+	// ProfileChangeEnrichment is a profile update by an enrichment job
+	ProfileChangeEnrichment ProfileChangeReason = "enrichment"
+
+	// ProfileChangeDelete is the soft delete of a user
+	ProfileChangeDelete ProfileChangeReason = "delete"
+
+	// ProfileChangeRestore is the restore of a soft-deleted user
+	ProfileChangeRestore ProfileChangeReason = "restore"
+
+	// ProfileChangeIdentityLink is the link of an identity (a new alternate email, for example)
+	ProfileChangeIdentityLink ProfileChangeReason = "identity_link"
+
+	// ProfileChangeIdentityUnlink is the unlink of an identity
+	ProfileChangeIdentityUnlink ProfileChangeReason = "identity_unlink"
+
+	// ProfileChangePrimaryEmail is the change of the primary email
+	ProfileChangePrimaryEmail ProfileChangeReason = "primary_email"
+
+	// ProfileChangeMerge is the merge of another account into the user, or of the user into another account
+	ProfileChangeMerge ProfileChangeReason = "merge"
+
+	// ProfileChangeRepublish asks to publish the profile to the search stream again, it's not a change
+	ProfileChangeRepublish ProfileChangeReason = "republish"
+)
+
+// UserProfileChanged is the event emitted after a change of a user profile, it doesn't carry
+// the profile, consumers read it again (the UI refreshes it after an edit from another device)
+type UserProfileChanged struct {
+	Sub       string              `json:"sub"`
+	Reason    ProfileChangeReason `json:"reason"`
+	ChangedAt time.Time           `json:"changed_at"`
+}
+
+// ProfileSub returns the identifier of the user used by the profile change events,
+// the user_id and the sub are the same for the token based lookups
+func (u *User) ProfileSub() string {
+	if u.UserID != "" {
+		return u.UserID
+	}
+	return u.Sub
+}
@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// DefaultProfilePictureMaxSize is the default maximum size of the uploaded profile pictures
+const DefaultProfilePictureMaxSize = 2 << 20
+
+// profilePictureTypes are the image types accepted as profile pictures, with the extension of
+// their objects. SVG isn't accepted, the documents can carry scripts.
+var profilePictureTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProfilePicture is an image uploaded as the profile picture of a user, the content type is
+// sniffed from the image itself
+type ProfilePicture struct {
+	ContentType string
+	Data        []byte
+}
+
+// NewProfilePicture validates the uploaded image, the declared content type must match the
+// sniffed one when set
+func NewProfilePicture(declaredType string, data []byte, maxSize int) (*ProfilePicture, error) {
+	if len(data) == 0 {
+		return nil, errors.NewValidation("the profile picture is empty")
+	}
+	if maxSize > 0 && len(data) > maxSize {
+		return nil, errors.NewValidation(fmt.Sprintf("the profile picture exceeds %d bytes", maxSize))
+	}
+
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if _, ok := profilePictureTypes[sniffed]; !ok {
+		return nil, errors.NewValidation("the profile picture must be a PNG, JPEG, GIF or WebP image")
+	}
+	if declared, _, errParse := mime.ParseMediaType(strings.TrimSpace(declaredType)); declaredType != "" && (errParse != nil || declared != sniffed) {
+		return nil, errors.NewValidation(fmt.Sprintf("the profile picture is a %s image, not %s", sniffed, declaredType))
+	}
+
+	return &ProfilePicture{ContentType: sniffed, Data: data}, nil
+}
+
+// Key returns the object key of the picture of the user, the user ID is hashed so it isn't
+// exposed in the public URL, and the content hash gives every new picture a new URL
+func (p *ProfilePicture) Key(userID string) string {
+	userHash := sha256.Sum256([]byte(userID))
+	contentHash := sha256.Sum256(p.Data)
+	return fmt.Sprintf("profile-pictures/%s/%s%s",
+		hex.EncodeToString(userHash[:16]),
+		hex.EncodeToString(contentHash[:8]),
+		profilePictureTypes[p.ContentType],
+	)
+}
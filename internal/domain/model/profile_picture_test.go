@@ -0,0 +1,80 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var (
+	pngHeader  = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	jpegHeader = []byte("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00")
+	webpHeader = []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+)
+
+func TestNewProfilePicture(t *testing.T) {
+	tests := []struct {
+		name         string
+		declaredType string
+		data         []byte
+		wantType     string
+		wantErr      bool
+	}{
+		{name: "png", declaredType: "image/png", data: pngHeader, wantType: "image/png"},
+		{name: "jpeg without declared type", data: jpegHeader, wantType: "image/jpeg"},
+		{name: "webp with parameters", declaredType: "image/webp; q=1", data: webpHeader, wantType: "image/webp"},
+		{name: "declared type mismatch", declaredType: "image/png", data: jpegHeader, wantErr: true},
+		{name: "invalid declared type", declaredType: "not a type", data: pngHeader, wantErr: true},
+		{name: "svg", declaredType: "image/svg+xml", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), wantErr: true},
+		{name: "html", data: []byte("<html><script>alert(1)</script></html>"), wantErr: true},
+		{name: "empty", declaredType: "image/png", wantErr: true},
+		{name: "too large", declaredType: "image/png", data: append(pngHeader, bytes.Repeat([]byte{0}, 1024)...), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			picture, err := NewProfilePicture(tt.declaredType, tt.data, 1024)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewProfilePicture() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewProfilePicture() error = %v", err)
+			}
+			if picture.ContentType != tt.wantType {
+				t.Errorf("ContentType = %q, want %q", picture.ContentType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestProfilePicture_Key(t *testing.T) {
+	picture, err := NewProfilePicture("", pngHeader, DefaultProfilePictureMaxSize)
+	if err != nil {
+		t.Fatalf("NewProfilePicture() error = %v", err)
+	}
+
+	key := picture.Key("auth0|123456789")
+	if !strings.HasPrefix(key, "profile-pictures/") || !strings.HasSuffix(key, ".png") {
+		t.Errorf("Key() = %q, want a png object under profile-pictures/", key)
+	}
+	if strings.Contains(key, "123456789") {
+		t.Errorf("Key() = %q exposes the user ID", key)
+	}
+	if key != picture.Key("auth0|123456789") {
+		t.Errorf("Key() isn't stable")
+	}
+	if key == picture.Key("auth0|987654321") {
+		t.Errorf("Key() is the same for two users")
+	}
+
+	other := &ProfilePicture{ContentType: "image/png", Data: append(pngHeader, 0)}
+	if key == other.Key("auth0|123456789") {
+		t.Errorf("Key() is the same for two pictures")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// ProvenanceSource is who set the value of a metadata field
+type ProvenanceSource string
+
+const (
+	// ProvenanceSourceUser is a value entered by the user
+	ProvenanceSourceUser ProvenanceSource = "user"
+	// ProvenanceSourceAdmin is a value entered by an organization admin
+	ProvenanceSourceAdmin ProvenanceSource = "admin"
+	// ProvenanceSourceEnrichment is a value set by an enrichment job
+	ProvenanceSourceEnrichment ProvenanceSource = "enrichment"
+)
+
+// FieldProvenance is the origin of the value of a metadata field
+type FieldProvenance struct {
+	Source ProvenanceSource `json:"source"`
+	// Actor is the sub of the user or admin, or the calling service of the enrichment job
+	Actor     string    `json:"actor,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EnteredByPerson reports whether the value was entered by a person, the enrichment jobs never
+// overwrite it
+func (p FieldProvenance) EnteredByPerson() bool {
+	return p.Source == ProvenanceSourceUser || p.Source == ProvenanceSourceAdmin
+}
+
+// MetadataProvenance is the provenance of the metadata fields of a user, by JSON name. The fields
+// without provenance were set before it was tracked, or by the identity provider.
+type MetadataProvenance map[string]FieldProvenance
+
+// Record sets the provenance of the fields, organization_verified is derived and skipped
+func (p MetadataProvenance) Record(fields []string, provenance FieldProvenance) {
+	for _, field := range fields {
+		if field == "organization_verified" {
+			continue
+		}
+		p[field] = provenance
+	}
+}
+
+// Protected returns the fields entered by a person, sorted. The fields already set in the current
+// metadata are protected too unless an enrichment set them, they may have been entered before the
+// provenance was tracked.
+func (p MetadataProvenance) Protected(fields []string, current *UserMetadata) []string {
+	var protected []string
+	for _, field := range fields {
+		origin := p[field]
+		if origin.EnteredByPerson() || (current.filled(field) && origin.Source != ProvenanceSourceEnrichment) {
+			protected = append(protected, field)
+		}
+	}
+	slices.Sort(protected)
+	return protected
+}
+
+// filled reports whether the field has a non-empty value
+func (a *UserMetadata) filled(field string) bool {
+	if a == nil {
+		return false
+	}
+	value, ok := a.clearableFields()[field]
+	return ok && *value != nil && strings.TrimSpace(**value) != ""
+}
+
+// Without returns a copy of the metadata without the fields
+func (a *UserMetadata) Without(fields []string) *UserMetadata {
+	clone := a.Clone()
+	if clone == nil {
+		return nil
+	}
+	clearable := clone.clearableFields()
+	for _, field := range fields {
+		if value, ok := clearable[field]; ok {
+			*value = nil
+		}
+	}
+	return clone
+}
+
+// EnrichUserMetadata is the request of an enrichment job to fill the metadata of a user, the fields
+// entered by a person or already set are skipped
+type EnrichUserMetadata struct {
+	// User is the username or sub of the user being enriched
+	User         string        `json:"user"`
+	UserMetadata *UserMetadata `json:"user_metadata"`
+}
+
+// Validate validates the request, organization_verified is derived and can't be enriched
+func (e *EnrichUserMetadata) Validate() error {
+	e.User = strings.TrimSpace(e.User)
+	if e.User == "" {
+		return errors.NewValidation("user is required")
+	}
+	if len(e.UserMetadata.Fields()) == 0 {
+		return errors.NewValidation("user_metadata is required")
+	}
+	if e.UserMetadata.OrganizationVerified != nil {
+		return errors.NewValidation("organization_verified can't be enriched")
+	}
+	return nil
+}
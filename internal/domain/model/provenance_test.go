@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+)
+
+func TestMetadataProvenance(t *testing.T) {
+	at := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	provenance := MetadataProvenance{}
+	provenance.Record([]string{"job_title", "organization", "organization_verified"}, FieldProvenance{Source: ProvenanceSourceUser, UpdatedAt: at})
+	provenance.Record([]string{"country"}, FieldProvenance{Source: ProvenanceSourceAdmin, UpdatedAt: at})
+	provenance.Record([]string{"city"}, FieldProvenance{Source: ProvenanceSourceEnrichment, UpdatedAt: at})
+
+	assert.NotContains(t, provenance, "organization_verified")
+	assert.Equal(t, []string{"country", "job_title"}, provenance.Protected([]string{"job_title", "city", "country", "name"}, nil))
+	assert.Empty(t, provenance.Protected([]string{"city", "name"}, nil))
+
+	current := &UserMetadata{
+		City:       converters.StringPtr("Porto"),
+		Name:       converters.StringPtr("Jane Doe"),
+		GivenName:  converters.StringPtr(" "),
+		PostalCode: converters.StringPtr("4000"),
+	}
+	assert.Equal(t, []string{"name", "postal_code"}, provenance.Protected([]string{"city", "name", "given_name", "postal_code", "address"}, current),
+		"the values already set are kept, unless an enrichment set them")
+}
+
+func TestUserMetadata_Without(t *testing.T) {
+	metadata := &UserMetadata{
+		JobTitle: converters.StringPtr("Maintainer"),
+		City:     converters.StringPtr("Lisbon"),
+	}
+
+	without := metadata.Without([]string{"job_title", "unknown"})
+	require.NotNil(t, without)
+	assert.Equal(t, []string{"city"}, without.Fields())
+	assert.Equal(t, "Maintainer", *metadata.JobTitle, "the metadata is left untouched")
+	assert.Nil(t, (*UserMetadata)(nil).Without([]string{"city"}))
+}
+
+func TestEnrichUserMetadata_Validate(t *testing.T) {
+	valid := &EnrichUserMetadata{User: " jane ", UserMetadata: &UserMetadata{City: converters.StringPtr("Lisbon")}}
+	require.NoError(t, valid.Validate())
+	assert.Equal(t, "jane", valid.User)
+
+	assert.Error(t, (&EnrichUserMetadata{UserMetadata: &UserMetadata{City: converters.StringPtr("Lisbon")}}).Validate())
+	assert.Error(t, (&EnrichUserMetadata{User: "jane"}).Validate())
+	assert.Error(t, (&EnrichUserMetadata{User: "jane", UserMetadata: &UserMetadata{OrganizationVerified: converters.BoolPtr(true)}}).Validate())
+}
@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "time"
+
+const (
+	// CircuitStateClosed is reported when the provider is healthy
+	CircuitStateClosed = "closed"
+	// CircuitStateOpen is reported when most recent calls to the provider are failing
+	CircuitStateOpen = "open"
+	// CircuitStateHalfOpen is reported when the provider is failing but the last call succeeded
+	CircuitStateHalfOpen = "half_open"
+)
+
+// ProviderStatus is the recent health of an upstream identity provider, so callers
+// can fall back (e.g. to cached display names) when identity is degraded
+type ProviderStatus struct {
+	Provider      string     `json:"provider"`
+	Samples       int        `json:"samples"`
+	Failures      int        `json:"failures"`
+	P95Ms         int64      `json:"p95_ms"`
+	ErrorRate     float64    `json:"error_rate"`
+	CircuitState  string     `json:"circuit_state"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+}
@@ -0,0 +1,16 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+// ResponseMeta identifies the deployment that served a request, stamped on the responses so a
+// client talking to the wrong environment (e.g. a staging client hitting production) sees it
+type ResponseMeta struct {
+	Environment string `json:"environment,omitempty"`
+	Instance    string `json:"instance,omitempty"`
+}
+
+// IsZero reports whether there is nothing to stamp
+func (m ResponseMeta) IsZero() bool {
+	return m.Environment == "" && m.Instance == ""
+}
@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// ResponsePolicies maps a calling service to the response fields it must never see,
+// the fields are the JSON names of the reply data (e.g. primary_email, phone_number)
+type ResponsePolicies map[string]map[string]struct{}
+
+func normalizePolicyCaller(caller string) string {
+	return strings.ToLower(strings.TrimSpace(caller))
+}
+
+// ParseResponsePolicies parses the response policies from a spec of the form
+// "reporting-service=primary_email,alternate_emails;search-service=phone_number"
+func ParseResponsePolicies(spec string) (ResponsePolicies, error) {
+	policies := make(ResponsePolicies)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		caller, list, found := strings.Cut(entry, "=")
+		if !found || normalizePolicyCaller(caller) == "" {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid response policy entry: %q", entry))
+		}
+		for _, field := range strings.Split(list, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if policies[normalizePolicyCaller(caller)] == nil {
+				policies[normalizePolicyCaller(caller)] = make(map[string]struct{})
+			}
+			policies[normalizePolicyCaller(caller)][field] = struct{}{}
+		}
+	}
+	return policies, nil
+}
+
+// Mask returns the fields the caller must not see, nil when the caller has no policy
+func (p ResponsePolicies) Mask(caller string) map[string]struct{} {
+	return p[normalizePolicyCaller(caller)]
+}
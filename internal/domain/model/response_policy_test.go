@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+)
+
+func TestParseResponsePolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		caller  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty spec", spec: "", caller: "reporting-service"},
+		{
+			name:   "fields of the caller",
+			spec:   "Reporting-Service=primary_email, alternate_emails;search-service=phone_number",
+			caller: " reporting-service",
+			want:   []string{"primary_email", "alternate_emails"},
+		},
+		{name: "caller without policy", spec: "search-service=phone_number", caller: "reporting-service"},
+		{name: "missing fields separator", spec: "reporting-service", wantErr: true},
+		{name: "missing caller", spec: "=primary_email", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := ParseResponsePolicies(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResponsePolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			mask := policies.Mask(tt.caller)
+			if len(mask) != len(tt.want) {
+				t.Fatalf("Mask() = %v, want %v", mask, tt.want)
+			}
+			for _, field := range tt.want {
+				if _, ok := mask[field]; !ok {
+					t.Errorf("Mask() = %v, missing %q", mask, field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// metadataMaxLengths are the longest values, in characters, of the metadata fields sent
+// to the identity providers, the fields of a closed format (country, phone number...) are bounded by it
+var metadataMaxLengths = map[string]int{
+	"name":           256,
+	"given_name":     128,
+	"family_name":    128,
+	"job_title":      128,
+	"organization":   256,
+	"state_province": 128,
+	"city":           128,
+	"address":        512,
+	"postal_code":    32,
+	"picture":        2048,
+}
+
+// stripInvisible removes the control characters (line breaks included) and the invisible format
+// characters (zero-width spaces and joiners, byte order marks, bidirectional overrides)
+func stripInvisible(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// sanitizeIdentifier trims the identifier and strips its invisible characters, its spelling is
+// otherwise kept as the identity providers match it
+func sanitizeIdentifier(value string) string {
+	return strings.TrimSpace(stripInvisible(value))
+}
+
+// sanitizeText normalizes the text to NFC, strips its invisible characters and collapses its
+// whitespace, the line breaks and tabs included, into single spaces
+func sanitizeText(value string) string {
+	value = norm.NFC.String(value)
+	// the whitespace control characters separate words, they are collapsed rather than stripped
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, value)
+	return strings.Join(strings.Fields(stripInvisible(value)), " ")
+}
@@ -0,0 +1,24 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"strings"
+)
+
+// tenantContextKey is the context key of the tenant hint
+type tenantContextKey struct{}
+
+// ContextWithTenant returns a copy of the context carrying the tenant hint of the request, the
+// identity provider tenant serving it when the deployment serves several, see constants.TenantHeader
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, strings.TrimSpace(tenant))
+}
+
+// TenantFromContext returns the tenant hint of the request, empty when the request has none
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
@@ -0,0 +1,15 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+// UnknownCaller is the caller of the requests without caller identity
+const UnknownCaller = "unknown"
+
+// UsageRecord is the number of requests of a caller for an operation on a given day (UTC)
+type UsageRecord struct {
+	Date      string `json:"date"`
+	Caller    string `json:"caller"`
+	Operation string `json:"operation"`
+	Count     int64  `json:"count"`
+}
@@ -9,22 +9,34 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
 // User represents a user in the system
 type User struct {
-	Token           string        `json:"token" yaml:"token"`
-	UserID          string        `json:"user_id" yaml:"user_id"`
-	Sub             string        `json:"sub,omitempty" yaml:"sub,omitempty"`
-	Username        string        `json:"username" yaml:"username"`
-	PrimaryEmail    string        `json:"primary_email" yaml:"primary_email"`
-	AlternateEmails []Email       `json:"alternate_emails,omitempty" yaml:"alternate_emails,omitempty"`
-	Identities      []Identity    `json:"identities,omitempty" yaml:"identities,omitempty"`
-	UserMetadata    *UserMetadata `json:"user_metadata,omitempty" yaml:"user_metadata,omitempty"`
+	Token        string `json:"token" yaml:"token"`
+	UserID       string `json:"user_id" yaml:"user_id"`
+	Sub          string `json:"sub,omitempty" yaml:"sub,omitempty"`
+	Username     string `json:"username" yaml:"username"`
+	PrimaryEmail string `json:"primary_email" yaml:"primary_email"`
+	// PrimaryEmailVerified is whether the identity provider verified the primary email
+	PrimaryEmailVerified bool          `json:"primary_email_verified,omitempty" yaml:"primary_email_verified,omitempty"`
+	AlternateEmails      []Email       `json:"alternate_emails,omitempty" yaml:"alternate_emails,omitempty"`
+	Identities           []Identity    `json:"identities,omitempty" yaml:"identities,omitempty"`
+	UserMetadata         *UserMetadata `json:"user_metadata,omitempty" yaml:"user_metadata,omitempty"`
+
+	// ClearFields are the user_metadata fields an update removes, the fields not set nor cleared
+	// are left untouched
+	ClearFields []string `json:"clear_fields,omitempty" yaml:"clear_fields,omitempty"`
+
+	// StaleAge is the age of a cached copy served past its expiration while the identity provider
+	// is unavailable, zero when the user is fresh
+	StaleAge time.Duration `json:"-" yaml:"-"`
 }
 
 // UserMetadata represents the metadata of a user
@@ -43,34 +55,90 @@ type UserMetadata struct {
 	PostalCode    *string `json:"postal_code,omitempty" yaml:"postal_code,omitempty"`
 	PhoneNumber   *string `json:"phone_number,omitempty" yaml:"phone_number,omitempty"`
 	TShirtSize    *string `json:"t_shirt_size,omitempty" yaml:"t_shirt_size,omitempty"`
+
+	// OrganizationVerified is derived by the service, it's true when one of the user
+	// verified email domains belongs to the organization, see OrganizationDomains
+	OrganizationVerified *bool `json:"organization_verified,omitempty" yaml:"organization_verified,omitempty"`
+}
+
+// Stale reports whether the user is a cached copy served while the identity provider is unavailable
+func (u *User) Stale() bool {
+	return u.StaleAge > 0
 }
 
-// Validate validates the user data and returns an error if validation fails
+// Validate validates the user data and returns the error of the first rule failed
 func (u *User) Validate() error {
+	return u.Violations().Err()
+}
 
-	errRequiredMsg := func(field string) string {
-		return fmt.Sprintf("%s is required", field)
+// Violations returns every rule failed by the user data, Validate only reports the first one
+func (u *User) Violations() ValidationViolations {
+	var violations ValidationViolations
+
+	required := func(field string) {
+		violations = append(violations, ValidationViolation{
+			Field:       field,
+			Rule:        ValidationRuleRequired,
+			Description: fmt.Sprintf("%s is required", field),
+		})
 	}
 
 	if strings.TrimSpace(u.Token) == "" {
-		return errors.NewValidation(errRequiredMsg("token"))
+		required("token")
 	}
 
-	if u.UserMetadata == nil {
-		return errors.NewValidation(errRequiredMsg("user_metadata"))
+	if u.UserMetadata == nil && len(u.ClearFields) == 0 {
+		required("user_metadata")
 	}
+	violations = append(violations, u.UserMetadata.Violations()...)
 
-	return nil
+	var metadata UserMetadata
+	if u.UserMetadata != nil {
+		metadata = *u.UserMetadata
+	}
+	fields := metadata.clearableFields()
+	for i, field := range u.ClearFields {
+		path := fmt.Sprintf("clear_fields[%d]", i)
+		value, clearable := fields[field]
+		if !clearable {
+			violations = append(violations, ValidationViolation{
+				Field:       path,
+				Rule:        ValidationRuleClearable,
+				Description: fmt.Sprintf("%s can't be cleared", field),
+			})
+			continue
+		}
+		if *value != nil {
+			violations = append(violations, ValidationViolation{
+				Field:       path,
+				Rule:        ValidationRuleExclusive,
+				Description: fmt.Sprintf("%s can't be both set and cleared", field),
+			})
+		}
+	}
+
+	return violations
 }
 
-// UserSanitize sanitizes the user data by cleaning up string fields
+// UserSanitize sanitizes the user data by cleaning up string fields, the metadata values are
+// normalized to NFC with their control and zero-width characters stripped and their whitespace
+// collapsed, the identifiers only lose their invisible characters
 func (u *User) UserSanitize() {
 	// Sanitize basic user fields
 	u.Token = strings.TrimSpace(u.Token)
-	u.UserID = strings.TrimSpace(u.UserID)
-	u.Sub = strings.TrimSpace(u.Sub)
-	u.Username = strings.TrimSpace(u.Username)
-	u.PrimaryEmail = strings.TrimSpace(u.PrimaryEmail)
+	u.UserID = sanitizeIdentifier(u.UserID)
+	u.Sub = sanitizeIdentifier(u.Sub)
+	u.Username = sanitizeIdentifier(u.Username)
+	u.PrimaryEmail = sanitizeIdentifier(u.PrimaryEmail)
+
+	var clearFields []string
+	for _, field := range u.ClearFields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field != "" && !slices.Contains(clearFields, field) {
+			clearFields = append(clearFields, field)
+		}
+	}
+	u.ClearFields = clearFields
 
 	// Sanitize UserMetadata if it exists
 	if u.UserMetadata != nil {
@@ -95,9 +163,10 @@ func (u User) buildIndexKey(ctx context.Context, kind, data string) string {
 	return key
 }
 
-// BuildEmailIndexKey builds the index key for the email
+// BuildEmailIndexKey builds the index key for the email, the different spellings
+// of the same mailbox share the key, see emailnorm.Canonical
 func (u User) BuildEmailIndexKey(ctx context.Context) string {
-	data := strings.TrimSpace(strings.ToLower(u.PrimaryEmail))
+	data := emailnorm.Canonical(u.PrimaryEmail)
 	if data == "" {
 		return ""
 	}
@@ -106,7 +175,7 @@ func (u User) BuildEmailIndexKey(ctx context.Context) string {
 
 // BuildAlternateEmailIndexKey builds the index key for the alternate email
 func (u User) BuildAlternateEmailIndexKey(ctx context.Context, alternateEmail string) string {
-	data := strings.TrimSpace(strings.ToLower(alternateEmail))
+	data := emailnorm.Canonical(alternateEmail)
 	if data == "" {
 		return ""
 	}
@@ -124,47 +193,14 @@ func (u User) BuildSubIndexKey(ctx context.Context) string {
 
 // sanitize sanitizes the user metadata by cleaning up string fields
 func (um *UserMetadata) userMetadataSanitize() {
-	if um.Name != nil {
-		*um.Name = strings.TrimSpace(*um.Name)
-	}
-	if um.GivenName != nil {
-		*um.GivenName = strings.TrimSpace(*um.GivenName)
-	}
-	if um.FamilyName != nil {
-		*um.FamilyName = strings.TrimSpace(*um.FamilyName)
-	}
-	if um.JobTitle != nil {
-		*um.JobTitle = strings.TrimSpace(*um.JobTitle)
-	}
-	if um.Organization != nil {
-		*um.Organization = strings.TrimSpace(*um.Organization)
-	}
-	if um.Country != nil {
-		*um.Country = strings.TrimSpace(*um.Country)
-	}
-	if um.StateProvince != nil {
-		*um.StateProvince = strings.TrimSpace(*um.StateProvince)
-	}
-	if um.City != nil {
-		*um.City = strings.TrimSpace(*um.City)
-	}
-	if um.Address != nil {
-		*um.Address = strings.TrimSpace(*um.Address)
-	}
-	if um.PostalCode != nil {
-		*um.PostalCode = strings.TrimSpace(*um.PostalCode)
-	}
-	if um.PhoneNumber != nil {
-		*um.PhoneNumber = strings.TrimSpace(*um.PhoneNumber)
-	}
-	if um.TShirtSize != nil {
-		*um.TShirtSize = strings.TrimSpace(*um.TShirtSize)
-	}
-	if um.Picture != nil {
-		*um.Picture = strings.TrimSpace(*um.Picture)
-	}
-	if um.Zoneinfo != nil {
-		*um.Zoneinfo = strings.TrimSpace(*um.Zoneinfo)
+	for _, value := range []*string{
+		um.Name, um.GivenName, um.FamilyName, um.JobTitle, um.Organization, um.Country,
+		um.StateProvince, um.City, um.Address, um.PostalCode, um.PhoneNumber, um.TShirtSize,
+		um.Picture, um.Zoneinfo,
+	} {
+		if value != nil {
+			*value = sanitizeText(*value)
+		}
 	}
 }
 
@@ -246,5 +282,142 @@ func (a *UserMetadata) Patch(update *UserMetadata) bool {
 		updated = true
 	}
 
+	if update.OrganizationVerified != nil {
+		a.OrganizationVerified = update.OrganizationVerified
+		updated = true
+	}
+
 	return updated
 }
+
+// clearableFields are the user_metadata fields the updates can clear, by JSON name. The
+// organization_verified badge is derived, it's cleared with the organization.
+func (a *UserMetadata) clearableFields() map[string]**string {
+	return map[string]**string{
+		"picture":        &a.Picture,
+		"zoneinfo":       &a.Zoneinfo,
+		"name":           &a.Name,
+		"given_name":     &a.GivenName,
+		"family_name":    &a.FamilyName,
+		"job_title":      &a.JobTitle,
+		"organization":   &a.Organization,
+		"country":        &a.Country,
+		"state_province": &a.StateProvince,
+		"city":           &a.City,
+		"address":        &a.Address,
+		"postal_code":    &a.PostalCode,
+		"phone_number":   &a.PhoneNumber,
+		"t_shirt_size":   &a.TShirtSize,
+	}
+}
+
+// Clear removes the fields, it reports whether any of them was set
+func (a *UserMetadata) Clear(fields []string) bool {
+	cleared := false
+	clearable := a.clearableFields()
+	for _, field := range fields {
+		value, ok := clearable[field]
+		if !ok || *value == nil {
+			continue
+		}
+		*value = nil
+		cleared = true
+		if field == "organization" {
+			a.OrganizationVerified = nil
+		}
+	}
+	return cleared
+}
+
+// ClearedMetadataFields returns the user_metadata fields removed by the update, with the
+// organization_verified badge when the organization is cleared
+func (u *User) ClearedMetadataFields() []string {
+	fields := slices.Clone(u.ClearFields)
+	if slices.Contains(fields, "organization") {
+		fields = append(fields, "organization_verified")
+	}
+	return fields
+}
+
+// Clone returns a deep copy of the user, nil for a nil user
+func (u *User) Clone() *User {
+	if u == nil {
+		return nil
+	}
+	clone := *u
+	clone.AlternateEmails = slices.Clone(u.AlternateEmails)
+	clone.Identities = slices.Clone(u.Identities)
+	clone.ClearFields = slices.Clone(u.ClearFields)
+	clone.UserMetadata = u.UserMetadata.Clone()
+	return &clone
+}
+
+// Clone returns a deep copy of the user metadata, nil for a nil metadata
+func (a *UserMetadata) Clone() *UserMetadata {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	for _, value := range clone.clearableFields() {
+		if *value != nil {
+			copied := **value
+			*value = &copied
+		}
+	}
+	if a.OrganizationVerified != nil {
+		verified := *a.OrganizationVerified
+		clone.OrganizationVerified = &verified
+	}
+	return &clone
+}
+
+// LogValue implements slog.LogValuer, a user is logged with its redacted identifiers only so the
+// whole user can be logged without leaking its emails, token or metadata
+func (u *User) LogValue() slog.Value {
+	if u == nil {
+		return slog.StringValue("<nil>")
+	}
+	attrs := make([]slog.Attr, 0, 8)
+	for _, field := range []struct{ key, value string }{
+		{"user_id", redaction.Redact(u.UserID)},
+		{"sub", redaction.Redact(u.Sub)},
+		{"username", redaction.Redact(u.Username)},
+		{"primary_email", redaction.RedactEmail(u.PrimaryEmail)},
+	} {
+		if field.value != "" {
+			attrs = append(attrs, slog.String(field.key, field.value))
+		}
+	}
+	if len(u.AlternateEmails) > 0 {
+		attrs = append(attrs, slog.Int("alternate_emails", len(u.AlternateEmails)))
+	}
+	if len(u.Identities) > 0 {
+		attrs = append(attrs, slog.Int("identities", len(u.Identities)))
+	}
+	if u.Token != "" {
+		attrs = append(attrs, slog.Bool("has_token", true))
+	}
+	if u.UserMetadata != nil {
+		attrs = append(attrs, slog.Any("user_metadata", u.UserMetadata))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, the metadata is logged as the names of the fields set, the
+// values are personal data
+func (a *UserMetadata) LogValue() slog.Value {
+	if a == nil {
+		return slog.StringValue("<nil>")
+	}
+	var fields []string
+	for name, value := range a.clearableFields() {
+		if *value != nil {
+			fields = append(fields, name)
+		}
+	}
+	if a.OrganizationVerified != nil {
+		fields = append(fields, "organization_verified")
+	}
+	slices.Sort(fields)
+	return slog.GroupValue(slog.Any("fields", fields))
+}
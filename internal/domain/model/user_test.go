@@ -4,14 +4,19 @@
 package model
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
 func TestUser_Validate(t *testing.T) {
@@ -95,6 +100,51 @@ func TestUser_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "clear fields without user_metadata",
+			user: &User{
+				Token:       "valid-token",
+				ClearFields: []string{"phone_number", "address"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "clear fields along with other fields set",
+			user: &User{
+				Token:        "valid-token",
+				ClearFields:  []string{"phone_number"},
+				UserMetadata: &UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "clear a field which can't be cleared",
+			user: &User{
+				Token:       "valid-token",
+				ClearFields: []string{"organization_verified"},
+			},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name: "clear an unknown field",
+			user: &User{
+				Token:       "valid-token",
+				ClearFields: []string{"nickname"},
+			},
+			wantErr: true,
+			errType: "validation",
+		},
+		{
+			name: "set and clear the same field",
+			user: &User{
+				Token:        "valid-token",
+				ClearFields:  []string{"job_title"},
+				UserMetadata: &UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+			},
+			wantErr: true,
+			errType: "validation",
+		},
 	}
 
 	for _, tt := range tests {
@@ -185,6 +235,21 @@ func TestUser_UserSanitize(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "strip invisible characters of the identifiers",
+			user: &User{
+				Token:        "token",
+				Username:     "user\u200bname",
+				UserID:       "user-123\n",
+				PrimaryEmail: "\ufeffuser@example.com\r\n",
+			},
+			expected: &User{
+				Token:        "token",
+				Username:     "username",
+				UserID:       "user-123",
+				PrimaryEmail: "user@example.com",
+			},
+		},
 		{
 			name: "sanitize user with nil metadata",
 			user: &User{
@@ -392,6 +457,30 @@ func TestUserMetadata_userMetadataSanitize(t *testing.T) {
 			t.Errorf("Organization not sanitized correctly")
 		}
 	})
+
+	t.Run("normalize unicode and strip invisible characters", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			value string
+			want  string
+		}{
+			{name: "decomposed accent is composed", value: "Jose\u0301 Mari\u0301a", want: "Jos\u00e9 Mar\u00eda"},
+			{name: "zero-width characters are stripped", value: "Ja\u200bne\u200d\ufeff", want: "Jane"},
+			{name: "bidirectional override is stripped", value: "\u202eenaJ", want: "enaJ"},
+			{name: "control characters are stripped", value: "Jane\x00\x07 Doe", want: "Jane Doe"},
+			{name: "header injection is flattened", value: "ACME\r\nX-Admin: true", want: "ACME X-Admin: true"},
+			{name: "internal whitespace is collapsed", value: "123  Main\tSt\u00a0\u00a0Apt 4", want: "123 Main St Apt 4"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				metadata := &UserMetadata{Name: converters.StringPtr(tt.value)}
+				metadata.userMetadataSanitize()
+				if *metadata.Name != tt.want {
+					t.Errorf("Name = %q, want %q", *metadata.Name, tt.want)
+				}
+			})
+		}
+	})
 }
 
 func TestUser_buildIndexKey(t *testing.T) {
@@ -853,6 +942,15 @@ func TestUser_BuildEmailIndexKey_Normalization(t *testing.T) {
 				"uSeR@eXaMpLe.CoM",
 			},
 		},
+		{
+			name: "provider specific normalization",
+			emails: []string{
+				"johndoe@gmail.com",
+				"John.Doe@gmail.com",
+				"john.doe+lfx@Gmail.com",
+				"j.o.h.n.d.o.e@googlemail.com",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1038,53 +1136,10 @@ func TestUserMetadata_Patch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Make a deep copy of original to avoid modifying test data
-			originalCopy := &UserMetadata{}
-			if tt.original != nil {
-				*originalCopy = *tt.original
-				// Copy pointer fields
-				if tt.original.Picture != nil {
-					originalCopy.Picture = converters.StringPtr(*tt.original.Picture)
-				}
-				if tt.original.Zoneinfo != nil {
-					originalCopy.Zoneinfo = converters.StringPtr(*tt.original.Zoneinfo)
-				}
-				if tt.original.Name != nil {
-					originalCopy.Name = converters.StringPtr(*tt.original.Name)
-				}
-				if tt.original.GivenName != nil {
-					originalCopy.GivenName = converters.StringPtr(*tt.original.GivenName)
-				}
-				if tt.original.FamilyName != nil {
-					originalCopy.FamilyName = converters.StringPtr(*tt.original.FamilyName)
-				}
-				if tt.original.JobTitle != nil {
-					originalCopy.JobTitle = converters.StringPtr(*tt.original.JobTitle)
-				}
-				if tt.original.Organization != nil {
-					originalCopy.Organization = converters.StringPtr(*tt.original.Organization)
-				}
-				if tt.original.Country != nil {
-					originalCopy.Country = converters.StringPtr(*tt.original.Country)
-				}
-				if tt.original.StateProvince != nil {
-					originalCopy.StateProvince = converters.StringPtr(*tt.original.StateProvince)
-				}
-				if tt.original.City != nil {
-					originalCopy.City = converters.StringPtr(*tt.original.City)
-				}
-				if tt.original.Address != nil {
-					originalCopy.Address = converters.StringPtr(*tt.original.Address)
-				}
-				if tt.original.PostalCode != nil {
-					originalCopy.PostalCode = converters.StringPtr(*tt.original.PostalCode)
-				}
-				if tt.original.PhoneNumber != nil {
-					originalCopy.PhoneNumber = converters.StringPtr(*tt.original.PhoneNumber)
-				}
-				if tt.original.TShirtSize != nil {
-					originalCopy.TShirtSize = converters.StringPtr(*tt.original.TShirtSize)
-				}
+			// Clone the original to avoid modifying test data
+			originalCopy := tt.original.Clone()
+			if originalCopy == nil {
+				originalCopy = &UserMetadata{}
 			}
 
 			result := originalCopy.Patch(tt.update)
@@ -1178,3 +1233,271 @@ func TestUserMetadata_Patch_Idempotency(t *testing.T) {
 		t.Errorf("Organization fields don't match after multiple patches")
 	}
 }
+
+func TestUserMetadata_Clear(t *testing.T) {
+	verified := true
+
+	tests := []struct {
+		name        string
+		metadata    *UserMetadata
+		fields      []string
+		wantCleared bool
+		want        *UserMetadata
+	}{
+		{
+			name: "clears the fields",
+			metadata: &UserMetadata{
+				Name:        converters.StringPtr("John Doe"),
+				PhoneNumber: converters.StringPtr("+1 555 0100"),
+				Address:     converters.StringPtr("1 Main St"),
+			},
+			fields:      []string{"phone_number", "address"},
+			wantCleared: true,
+			want:        &UserMetadata{Name: converters.StringPtr("John Doe")},
+		},
+		{
+			name: "clearing the organization clears the verified badge",
+			metadata: &UserMetadata{
+				Organization:         converters.StringPtr("ACME"),
+				OrganizationVerified: &verified,
+			},
+			fields:      []string{"organization"},
+			wantCleared: true,
+			want:        &UserMetadata{},
+		},
+		{
+			name:        "fields already unset",
+			metadata:    &UserMetadata{Name: converters.StringPtr("John Doe")},
+			fields:      []string{"phone_number"},
+			wantCleared: false,
+			want:        &UserMetadata{Name: converters.StringPtr("John Doe")},
+		},
+		{
+			name:        "unknown fields are ignored",
+			metadata:    &UserMetadata{Name: converters.StringPtr("John Doe")},
+			fields:      []string{"nickname"},
+			wantCleared: false,
+			want:        &UserMetadata{Name: converters.StringPtr("John Doe")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleared := tt.metadata.Clear(tt.fields)
+			if cleared != tt.wantCleared {
+				t.Errorf("UserMetadata.Clear() = %v, want %v", cleared, tt.wantCleared)
+			}
+			if !reflect.DeepEqual(tt.metadata, tt.want) {
+				t.Errorf("UserMetadata.Clear() metadata = %+v, want %+v", tt.metadata, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_ClearFieldsSanitize(t *testing.T) {
+	user := &User{ClearFields: []string{" Phone_Number ", "phone_number", "", "ORGANIZATION"}}
+	user.UserSanitize()
+
+	want := []string{"phone_number", "organization"}
+	if !reflect.DeepEqual(user.ClearFields, want) {
+		t.Errorf("UserSanitize() clear_fields = %v, want %v", user.ClearFields, want)
+	}
+	if got := user.ClearedMetadataFields(); !reflect.DeepEqual(got, append(want, "organization_verified")) {
+		t.Errorf("ClearedMetadataFields() = %v", got)
+	}
+}
+
+func TestUser_Violations(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want ValidationViolations
+	}{
+		{
+			name: "valid user",
+			user: &User{Token: "token", UserMetadata: &UserMetadata{Name: converters.StringPtr("Jane")}},
+		},
+		{
+			name: "every violation with its path",
+			user: &User{
+				UserMetadata: &UserMetadata{PhoneNumber: converters.StringPtr("+1 555")},
+				ClearFields:  []string{"name", "username", "phone_number"},
+			},
+			want: ValidationViolations{
+				{Field: "token", Rule: ValidationRuleRequired, Description: "token is required"},
+				{Field: "user_metadata.phone_number", Rule: ValidationRuleFormat, Description: "phone_number must be in the E.164 format, like +14155550123"},
+				{Field: "clear_fields[1]", Rule: ValidationRuleClearable, Description: "username can't be cleared"},
+				{Field: "clear_fields[2]", Rule: ValidationRuleExclusive, Description: "phone_number can't be both set and cleared"},
+			},
+		},
+		{
+			name: "missing token and metadata",
+			user: &User{Token: " "},
+			want: ValidationViolations{
+				{Field: "token", Rule: ValidationRuleRequired, Description: "token is required"},
+				{Field: "user_metadata", Rule: ValidationRuleRequired, Description: "user_metadata is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.user.Violations()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Violations() = %+v, want %+v", got, tt.want)
+			}
+
+			// the error of the request stays the first violation
+			err := got.Err()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Err() = %v, want nil", err)
+				}
+				return
+			}
+			if _, ok := err.(errors.Validation); !ok || err.Error() != tt.want[0].Description {
+				t.Errorf("Err() = %v, want validation error %q", err, tt.want[0].Description)
+			}
+		})
+	}
+}
+
+func TestUserMetadata_Violations(t *testing.T) {
+	valid := &UserMetadata{
+		Zoneinfo:    converters.StringPtr("America/Los_Angeles"),
+		Country:     converters.StringPtr("PT"),
+		TShirtSize:  converters.StringPtr("XL"),
+		PhoneNumber: converters.StringPtr("+1 (415) 555-0123"),
+		Picture:     converters.StringPtr("https://example.com/jane.png"),
+		Name:        converters.StringPtr("anything goes"),
+	}
+	if got := valid.Violations(); len(got) != 0 {
+		t.Errorf("Violations() = %+v, want none", got)
+	}
+	if got := (&UserMetadata{Country: converters.StringPtr(""), Picture: converters.StringPtr("http://localhost:8080/objects/jane.png")}).Violations(); len(got) != 0 {
+		t.Errorf("Violations() = %+v, want none for the empty values and the local pictures", got)
+	}
+
+	invalid := &UserMetadata{
+		Zoneinfo:    converters.StringPtr("Aetheria/Skylands"),
+		Country:     converters.StringPtr("United States"),
+		TShirtSize:  converters.StringPtr("medium"),
+		PhoneNumber: converters.StringPtr("+1-555-STORM-01"),
+		Picture:     converters.StringPtr("http://example.com/jane.png"),
+	}
+	want := ValidationViolations{
+		{Field: "user_metadata.zoneinfo", Rule: ValidationRuleFormat, Description: "zoneinfo must be an IANA timezone, like America/Los_Angeles"},
+		{Field: "user_metadata.country", Rule: ValidationRuleFormat, Description: "country must be an ISO 3166-1 alpha-2 country code, like US"},
+		{Field: "user_metadata.t_shirt_size", Rule: ValidationRuleEnum, Description: "t_shirt_size must be one of XS, S, M, L, XL, XXL, XXXL"},
+		{Field: "user_metadata.phone_number", Rule: ValidationRuleFormat, Description: "phone_number must be in the E.164 format, like +14155550123"},
+		{Field: "user_metadata.picture", Rule: ValidationRuleFormat, Description: "picture must be an https URL"},
+	}
+	if got := invalid.Violations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Violations() = %+v, want %+v", got, want)
+	}
+
+	for _, zoneinfo := range []string{"Local", "UTC ", "../etc/passwd"} {
+		if got := (&UserMetadata{Zoneinfo: converters.StringPtr(zoneinfo)}).Violations(); len(got) != 1 {
+			t.Errorf("Violations() of zoneinfo %q = %+v, want one", zoneinfo, got)
+		}
+	}
+
+	tooLong := &UserMetadata{
+		Name:       converters.StringPtr(strings.Repeat("\u00e9", 257)),
+		GivenName:  converters.StringPtr(strings.Repeat("\u00e9", 128)),
+		PostalCode: converters.StringPtr(strings.Repeat("9", 33)),
+	}
+	wantTooLong := ValidationViolations{
+		{Field: "user_metadata.name", Rule: ValidationRuleMaxLength, Description: "name must be at most 256 characters"},
+		{Field: "user_metadata.postal_code", Rule: ValidationRuleMaxLength, Description: "postal_code must be at most 32 characters"},
+	}
+	if got := tooLong.Violations(); !reflect.DeepEqual(got, wantTooLong) {
+		t.Errorf("Violations() = %+v, want %+v", got, wantTooLong)
+	}
+}
+
+func TestUser_Clone(t *testing.T) {
+	verified := true
+	user := &User{
+		UserID:          "auth0|jane",
+		AlternateEmails: []Email{{Email: "jane@example.com", Verified: true}},
+		Identities:      []Identity{{Provider: "github", IdentityID: "42"}},
+		ClearFields:     []string{"city"},
+		UserMetadata: &UserMetadata{
+			Name:                 converters.StringPtr("Jane"),
+			TShirtSize:           converters.StringPtr("M"),
+			OrganizationVerified: &verified,
+		},
+	}
+
+	clone := user.Clone()
+	if !reflect.DeepEqual(clone, user) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, user)
+	}
+
+	*clone.UserMetadata.Name = "changed"
+	*clone.UserMetadata.TShirtSize = "XL"
+	*clone.UserMetadata.OrganizationVerified = false
+	clone.AlternateEmails[0].Verified = false
+	clone.Identities[0].Provider = "linkedin"
+	clone.ClearFields[0] = "name"
+
+	if *user.UserMetadata.Name != "Jane" || *user.UserMetadata.TShirtSize != "M" || !*user.UserMetadata.OrganizationVerified ||
+		!user.AlternateEmails[0].Verified || user.Identities[0].Provider != "github" || user.ClearFields[0] != "city" {
+		t.Errorf("Clone() shares state with the original: %+v", user)
+	}
+
+	if (*User)(nil).Clone() != nil || (*UserMetadata)(nil).Clone() != nil {
+		t.Error("Clone() of nil should be nil")
+	}
+}
+
+func TestUser_LogValue(t *testing.T) {
+	name, title := "Jane Doe", "Engineer"
+	user := &User{
+		Token:           "eyJhbGciOiJSUzI1NiJ9.secret-payload.signature",
+		UserID:          "auth0|123456789",
+		Username:        "janedoe",
+		PrimaryEmail:    "jane.doe@example.com",
+		AlternateEmails: []Email{{Email: "jane@personal.example.org", Verified: true}},
+		UserMetadata:    &UserMetadata{Name: &name, JobTitle: &title},
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("user", "user", user)
+	output := buf.String()
+
+	for _, leaked := range []string{"auth0|123456789", "janedoe", "jane.doe@example.com", "jane@personal.example.org", "secret-payload", "Jane Doe", "Engineer"} {
+		if strings.Contains(output, leaked) {
+			t.Errorf("log output leaks %q: %s", leaked, output)
+		}
+	}
+
+	var entry struct {
+		User map[string]any `json:"user"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode the log output: %v", err)
+	}
+	want := map[string]any{
+		"user_id":          redaction.Redact(user.UserID),
+		"username":         redaction.Redact(user.Username),
+		"primary_email":    redaction.RedactEmail(user.PrimaryEmail),
+		"alternate_emails": float64(1),
+		"has_token":        true,
+		"user_metadata":    map[string]any{"fields": []any{"job_title", "name"}},
+	}
+	if !reflect.DeepEqual(entry.User, want) {
+		t.Errorf("LogValue() = %v, want %v", entry.User, want)
+	}
+
+	var nilUser *User
+	if got := nilUser.LogValue().String(); got != "<nil>" {
+		t.Errorf("LogValue() of a nil user = %q, want <nil>", got)
+	}
+	var nilMetadata *UserMetadata
+	if got := nilMetadata.LogValue().String(); got != "<nil>" {
+		t.Errorf("LogValue() of nil metadata = %q, want <nil>", got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// ValidationRule is the kind of constraint a field failed
+type ValidationRule string
+
+const (
+	// ValidationRuleRequired is a missing field
+	ValidationRuleRequired ValidationRule = "required"
+
+	// ValidationRuleClearable is a field which can't be cleared
+	ValidationRuleClearable ValidationRule = "clearable"
+
+	// ValidationRuleExclusive is a field both set and cleared
+	ValidationRuleExclusive ValidationRule = "exclusive"
+
+	// ValidationRuleFormat is a field not in the expected format, e.g. a phone number not in E.164
+	ValidationRuleFormat ValidationRule = "format"
+
+	// ValidationRuleEnum is a field not one of the allowed values
+	ValidationRuleEnum ValidationRule = "enum"
+
+	// ValidationRuleMaxLength is a field longer than the identity providers accept
+	ValidationRuleMaxLength ValidationRule = "max_length"
+
+	// ValidationRulePolicy is a field rejected by the moderation policy, e.g. a link in a name
+	ValidationRulePolicy ValidationRule = "policy"
+)
+
+// ValidationViolation is a rule failed by a field of a request
+type ValidationViolation struct {
+	// Field is the path of the field, e.g. user_metadata or clear_fields[1]
+	Field string         `json:"field"`
+	Rule  ValidationRule `json:"rule"`
+	// Description is the terse message of the violation, returned as the error of the request
+	Description string `json:"description"`
+}
+
+// ValidationViolations are all the rules failed by a request, in the order they were checked
+type ValidationViolations []ValidationViolation
+
+// Err returns the validation error of the first violation, nil when there is none
+func (v ValidationViolations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return errors.NewValidation(v[0].Description)
+}
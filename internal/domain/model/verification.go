@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// VerificationChannel is the delivery channel of a one time password
+type VerificationChannel string
+
+const (
+	// VerificationChannelEmail delivers the one time password by email
+	VerificationChannelEmail VerificationChannel = "email"
+
+	// VerificationChannelSMS delivers the one time password by SMS
+	VerificationChannelSMS VerificationChannel = "sms"
+
+	// VerificationChannelWhatsApp delivers the one time password by WhatsApp message
+	VerificationChannelWhatsApp VerificationChannel = "whatsapp"
+)
+
+// IsPhone reports whether the channel delivers to a phone number
+func (c VerificationChannel) IsPhone() bool {
+	return c == VerificationChannelSMS || c == VerificationChannelWhatsApp
+}
+
+// ParsePhoneChannel parses the delivery channel of the phone verifications, SMS when empty
+func ParsePhoneChannel(value string) (VerificationChannel, error) {
+	channel := VerificationChannel(strings.ToLower(strings.TrimSpace(value)))
+	if channel == "" {
+		return VerificationChannelSMS, nil
+	}
+	if !channel.IsPhone() {
+		return "", fmt.Errorf("invalid phone verification channel %q, expected sms or whatsapp", value)
+	}
+	return channel, nil
+}
+
+// Verification is the one time password verification of a contact of a user, the recipient is
+// the email or the phone number of the channel
+type Verification struct {
+	Channel   VerificationChannel
+	Recipient string
+	OTP       string
+}
+
+// e164 matches the phone numbers in the E.164 format, like +14155550123
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// PhoneLinking represents a request of the phone number linking, the verification code is sent to
+// the phone number and verified with the OTP. The token of the user is required to send the code.
+type PhoneLinking struct {
+	// User contains the authenticated user's information.
+	User struct {
+		// UserID is the user's ID, populated from the auth_token sub claim.
+		UserID string `json:"user_id"`
+		// AuthToken is the JWT token of the user requesting the verification.
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+
+	// PhoneNumber is the phone number to verify, in the E.164 format.
+	PhoneNumber string `json:"phone_number"`
+
+	// OTP is the one time password received on the phone, only used by the verification.
+	OTP string `json:"otp,omitempty"`
+}
+
+// normalizePhoneNumber returns the phone number without the spaces, dashes and parentheses
+// commonly used to format it
+func normalizePhoneNumber(phoneNumber string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(phoneNumber))
+}
+
+// NormalizedPhoneNumber returns the phone number without the spaces, dashes and parentheses
+// commonly used to format it
+func (p *PhoneLinking) NormalizedPhoneNumber() string {
+	return normalizePhoneNumber(p.PhoneNumber)
+}
+
+// IsValidPhoneNumber checks if the phone number is in the E.164 format
+func (p *PhoneLinking) IsValidPhoneNumber() bool {
+	return e164.MatchString(p.NormalizedPhoneNumber())
+}
@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "testing"
+
+func TestPhoneLinking_IsValidPhoneNumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		phone      string
+		expected   bool
+		normalized string
+	}{
+		{name: "E.164", phone: "+14155550123", expected: true, normalized: "+14155550123"},
+		{name: "formatted", phone: " +1 (415) 555-0123 ", expected: true, normalized: "+14155550123"},
+		{name: "dotted", phone: "+44.20.7946.0958", expected: true, normalized: "+442079460958"},
+		{name: "missing country code", phone: "4155550123", normalized: "4155550123"},
+		{name: "leading zero", phone: "+04155550123", normalized: "+04155550123"},
+		{name: "too short", phone: "+12345", normalized: "+12345"},
+		{name: "too long", phone: "+1234567890123456", normalized: "+1234567890123456"},
+		{name: "letters", phone: "+1415CALLNOW", normalized: "+1415CALLNOW"},
+		{name: "empty", phone: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &PhoneLinking{PhoneNumber: tt.phone}
+			if got := request.IsValidPhoneNumber(); got != tt.expected {
+				t.Errorf("IsValidPhoneNumber() = %v, want %v", got, tt.expected)
+			}
+			if got := request.NormalizedPhoneNumber(); got != tt.normalized {
+				t.Errorf("NormalizedPhoneNumber() = %q, want %q", got, tt.normalized)
+			}
+		})
+	}
+}
+
+func TestParsePhoneChannel(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected VerificationChannel
+		wantErr  bool
+	}{
+		{value: "", expected: VerificationChannelSMS},
+		{value: "sms", expected: VerificationChannelSMS},
+		{value: " WhatsApp ", expected: VerificationChannelWhatsApp},
+		{value: "email", wantErr: true},
+		{value: "fax", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			channel, err := ParsePhoneChannel(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePhoneChannel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if channel != tt.expected {
+				t.Errorf("ParsePhoneChannel() = %q, want %q", channel, tt.expected)
+			}
+		})
+	}
+}
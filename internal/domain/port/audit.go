@@ -0,0 +1,21 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// AuditSink defines the behavior for recording the audit events of the user mutations
+type AuditSink interface {
+	Record(ctx context.Context, event *model.AuditEvent) error
+}
+
+// WebhookDeliveryReader defines the behavior for reading the last deliveries of the audit webhook,
+// the most recent first
+type WebhookDeliveryReader interface {
+	WebhookDeliveries() []model.WebhookDelivery
+}
@@ -0,0 +1,26 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// CallerAllowlist manages the capabilities granted to the calling services
+type CallerAllowlist interface {
+	GetCaller(ctx context.Context, id string) (*model.CallerAllowlistEntry, error)
+	ListCallers(ctx context.Context) ([]*model.CallerAllowlistEntry, error)
+	// PutCaller creates or replaces the entry, putting the same state again changes nothing,
+	// created reports whether the entry didn't exist
+	PutCaller(ctx context.Context, entry *model.CallerAllowlistEntry) (stored *model.CallerAllowlistEntry, created bool, err error)
+	DeleteCaller(ctx context.Context, id string) error
+}
+
+// CallerAuthenticator returns the calling service proven by its service token, the capabilities,
+// budgets and usage are attributed to it
+type CallerAuthenticator interface {
+	AuthenticateCaller(ctx context.Context, token string) (string, error)
+}
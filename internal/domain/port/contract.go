@@ -0,0 +1,22 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// SLIRecorder defines the behavior of the per consumer service level recording, it must not
+// block the request. The operations without a contract of the caller are ignored.
+type SLIRecorder interface {
+	RecordSLI(caller, operation string, latency time.Duration, failed bool)
+}
+
+// ContractReportReader defines the behavior of the report of the consumer contracts
+type ContractReportReader interface {
+	ContractReport(ctx context.Context) []model.ContractSLI
+}
@@ -14,3 +14,9 @@ type EmailSender interface {
 	// SendEmail sends an email message
 	SendEmail(ctx context.Context, message *model.EmailMessage) error
 }
+
+// TemplatedEmailSender defines the behavior for rendering and sending templated emails
+type TemplatedEmailSender interface {
+	// SendTemplatedEmail renders the named template with the given data and sends it to the recipient
+	SendTemplatedEmail(ctx context.Context, templateName, to string, data any) error
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// EventPublisher defines the behavior for publishing fire-and-forget events consumed by other services
+type EventPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// Requester defines the behavior of the requests to other services, the reply is awaited until
+// the context is done
+type Requester interface {
+	Request(ctx context.Context, subject string, data []byte) ([]byte, error)
+}
+
+// EventSchemaRegistry defines the behavior of the registry of the JSON Schemas of the published
+// events, the events are validated before being published
+type EventSchemaRegistry interface {
+	// ValidateEvent validates the event against the schema of the subject, an event published on a
+	// subject without a schema is invalid
+	ValidateEvent(subject string, data []byte) error
+	// EventSchemas returns the schemas of the published events, ordered by subject
+	EventSchemas() []model.EventSchema
+}
+
+// ProfileChangeSubscriber defines the behavior of the subscriptions to the profile changes of a user,
+// the channel is closed once the context is done
+type ProfileChangeSubscriber interface {
+	SubscribeProfileChanges(ctx context.Context, sub string) (<-chan model.UserProfileChanged, error)
+}
+
+// AdminEventSubscriber defines the behavior of the subscriptions of the admin dashboards to the
+// internal events of the given kinds, the channel is closed once the context is done
+type AdminEventSubscriber interface {
+	SubscribeAdminEvents(ctx context.Context, kinds []model.AdminEventKind) (<-chan model.AdminEvent, error)
+}
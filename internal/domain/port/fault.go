@@ -0,0 +1,19 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// FaultInjector defines the behavior of the test identity providers failing their operations on
+// demand, so the callers can test their retries and fallbacks
+type FaultInjector interface {
+	// Faults returns the faults injected
+	Faults() model.ProviderFaults
+	// SetFaults replaces the faults injected, none when empty
+	SetFaults(ctx context.Context, faults model.ProviderFaults) error
+}
@@ -8,6 +8,22 @@ import "context"
 // MessageHandler defines the behavior of the all domain handlers
 type MessageHandler interface {
 	UserHandler
+	StatusHandler
+	FixtureHandler
+}
+
+// FixtureHandler defines the behavior of the handlers setting up the users and the faults of the
+// test providers
+type FixtureHandler interface {
+	SeedMockUsers(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	SetMockFaults(ctx context.Context, msg TransportMessenger) ([]byte, error)
+}
+
+// StatusHandler defines the behavior of the service status handlers
+type StatusHandler interface {
+	ProviderStatus(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	UsageReport(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	ContractReport(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
 
 // UserHandler defines the behavior of the user domain handlers
@@ -15,12 +31,16 @@ type UserHandler interface {
 	UserWriteHandler
 	UserReaderHandler
 	UserLookupHandler
+	UserSearchHandler
 	UserLinkHandler
+	UserAuthenticatorHandler
+	ProfileShareHandler
 }
 
 // UserReadHandler defines the behavior of the user read/lookup domain handlers
 type UserReaderHandler interface {
 	GetUserMetadata(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	BulkGetUserMetadata(ctx context.Context, msg TransportMessenger) ([]byte, error)
 	GetUserEmails(ctx context.Context, msg TransportMessenger) ([]byte, error)
 	ListIdentities(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
@@ -29,16 +49,34 @@ type UserReaderHandler interface {
 type UserLookupHandler interface {
 	EmailToUsername(ctx context.Context, msg TransportMessenger) ([]byte, error)
 	EmailToSub(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	AlternateEmailToSub(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	UsernameToEmail(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	SubToEmail(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	SubToUsername(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	ResolveRoster(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	IsEmailVerified(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	EmailHashMembership(ctx context.Context, msg TransportMessenger) ([]byte, error)
+}
+
+// UserSearchHandler defines the behavior of the user search domain handlers
+type UserSearchHandler interface {
+	Typeahead(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
 
 // UserWriteHandler defines the behavior of the user write domain handlers
 type UserWriteHandler interface {
 	UpdateUser(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	SoftDeleteUser(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	RestoreUser(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	UpdateUserAsOrganizationAdmin(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	EnrichUserMetadata(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	MergeUsers(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
 
 // UserLinkHandler defines the behavior of the user link/alternate email domain handlers
 type UserLinkHandler interface {
 	EmailLinkingHandler
+	PhoneLinkingHandler
 	IdentityLinkingHandler
 	// it will handle social account linking, etc
 }
@@ -47,6 +85,8 @@ type UserLinkHandler interface {
 type IdentityLinkingHandler interface {
 	LinkIdentity(ctx context.Context, msg TransportMessenger) ([]byte, error)
 	UnlinkIdentity(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	UnlinkAlternateEmail(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	ChangePrimaryEmail(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
 
 // EmailLinkingHandler defines the behavior of the email linking domain handlers
@@ -54,3 +94,21 @@ type EmailLinkingHandler interface {
 	StartEmailLinking(ctx context.Context, msg TransportMessenger) ([]byte, error)
 	VerifyEmailLinking(ctx context.Context, msg TransportMessenger) ([]byte, error)
 }
+
+// PhoneLinkingHandler defines the behavior of the phone number linking domain handlers
+type PhoneLinkingHandler interface {
+	StartPhoneLinking(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	VerifyPhoneLinking(ctx context.Context, msg TransportMessenger) ([]byte, error)
+}
+
+// ProfileShareHandler defines the behavior of the profile share links handlers
+type ProfileShareHandler interface {
+	CreateProfileShareLink(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	ResolveProfileShareLink(ctx context.Context, msg TransportMessenger) ([]byte, error)
+}
+
+// UserAuthenticatorHandler defines the behavior of the WebAuthn authenticators domain handlers
+type UserAuthenticatorHandler interface {
+	ListAuthenticators(ctx context.Context, msg TransportMessenger) ([]byte, error)
+	DeleteAuthenticator(ctx context.Context, msg TransportMessenger) ([]byte, error)
+}
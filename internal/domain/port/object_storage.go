@@ -0,0 +1,12 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import "context"
+
+// ObjectStorage stores the objects served to the browsers, like the profile pictures
+type ObjectStorage interface {
+	// PutObject stores the object under the key, replacing it, and returns its public URL
+	PutObject(ctx context.Context, key, contentType string, data []byte) (string, error)
+}
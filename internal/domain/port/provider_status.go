@@ -0,0 +1,15 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// ProviderStatusReader reports the recent health of the upstream identity providers
+type ProviderStatusReader interface {
+	ProviderStatuses(ctx context.Context) []model.ProviderStatus
+}
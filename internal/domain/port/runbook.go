@@ -0,0 +1,35 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// M2MTokenRenewer defines the behavior of the identity providers calling their API with M2M tokens
+type M2MTokenRenewer interface {
+	// RenewM2MToken replaces the cached M2M tokens with new ones issued for the current credentials,
+	// it returns when the first of them expires
+	RenewM2MToken(ctx context.Context) (time.Time, error)
+	// VerifyM2MToken checks the API of the provider accepts the cached M2M tokens
+	VerifyM2MToken(ctx context.Context) error
+}
+
+// JWKSRefresher defines the behavior of the identity providers verifying the tokens with the keys of
+// their JWKS
+type JWKSRefresher interface {
+	// RefreshJWKS fetches the JWKS now and returns the number of signing keys loaded
+	RefreshJWKS(ctx context.Context) (int, error)
+}
+
+// EmailIndexRebuilder defines the behavior of the identity providers keeping a lookup index of the
+// emails of their users
+type EmailIndexRebuilder interface {
+	// RebuildEmailIndex rewrites the lookup keys of the user from its record and returns the number
+	// of keys fixed
+	RebuildEmailIndex(ctx context.Context, user *model.User) (int, error)
+}
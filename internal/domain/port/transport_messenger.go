@@ -7,5 +7,6 @@ package port
 type TransportMessenger interface {
 	Subject() string
 	Data() []byte
+	Header(key string) string
 	Respond(data []byte) error
 }
@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// UsageRecorder defines the behavior of the usage accounting, it must not block the request
+type UsageRecorder interface {
+	RecordUsage(caller, operation string)
+}
+
+// UsageReader defines the behavior of the usage report, the days are inclusive
+// and an empty caller reports all the callers
+type UsageReader interface {
+	UsageReport(ctx context.Context, from, to time.Time, caller string) ([]model.UsageRecord, error)
+}
+
+// CostGuard defines the behavior of the cost guardrails, Charge accounts an operation of the
+// class to the caller and fails with a TooManyRequests error when the caller exhausted its
+// daily budget for the class
+type CostGuard interface {
+	Charge(ctx context.Context, caller string, class model.CostClass) error
+}
+
+// EmailSendLimiter defines the behavior of the limits of the verification emails, Allow records
+// a send to the email on behalf of the user and fails with a TooManyRequests error once the
+// email or the user exhausted its sends, the user is empty when unknown
+type EmailSendLimiter interface {
+	Allow(ctx context.Context, email, userID string) error
+}
+
+// OTPAttemptGuard defines the behavior of the lockout of the email verification, Check fails with
+// a TooManyRequests error while the verification of the email is locked, Fail records a failed
+// attempt and reports whether it locked the email, and Succeed forgets the failed attempts
+type OTPAttemptGuard interface {
+	Check(ctx context.Context, email string) error
+	Fail(ctx context.Context, email string) (failures int64, locked bool)
+	Succeed(ctx context.Context, email string)
+}
@@ -5,8 +5,10 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 )
 
 // UserReaderWriter defines the behavior of the user reader writer
@@ -20,7 +22,7 @@ type UserReaderWriter interface {
 // UserReader defines the behavior of the user reader
 type UserReader interface {
 	GetUser(ctx context.Context, user *model.User) (*model.User, error)
-	SearchUser(ctx context.Context, user *model.User, criteria string) (*model.User, error)
+	SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error)
 	MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error)
 }
 
@@ -41,3 +43,123 @@ type EmailHandler interface {
 	SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error
 	VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error)
 }
+
+// EmailBackupCodeStore defines the behavior of the one-time backup codes verifying an email in place
+// of the OTP, stored hashed until they expire
+type EmailBackupCodeStore interface {
+	// IssueBackupCodes replaces the backup codes of the email with new ones
+	IssueBackupCodes(ctx context.Context, email string) (*model.EmailBackupCodes, error)
+	// RedeemBackupCode consumes the code, a validation error when it's not a valid code of the email
+	RedeemBackupCode(ctx context.Context, email, code string) error
+}
+
+// VerifiedEmailLinker defines the behavior of the linking of an email verified by the service (with a
+// backup code) instead of the identity provider, the user is identified by its token
+type VerifiedEmailLinker interface {
+	LinkVerifiedEmail(ctx context.Context, user *model.User, email string) error
+}
+
+// AlternateEmailUnlinker defines the behavior of the unlinking of an alternate email by its address,
+// the identity of the email is resolved by the provider
+type AlternateEmailUnlinker interface {
+	UnlinkAlternateEmail(ctx context.Context, request *model.UnlinkAlternateEmail) error
+}
+
+// PrimaryEmailChanger defines the behavior of the change of the primary email of a user to an email
+// verified by the service, the updated user is returned
+type PrimaryEmailChanger interface {
+	ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error)
+}
+
+// UserCache defines the behavior of the cache of the users read from the identity provider, the
+// keys are the lookup keys of the users (sub, username, emails), an expired key is a miss
+type UserCache interface {
+	Get(ctx context.Context, key string) (*model.User, bool)
+	Set(ctx context.Context, user *model.User, keys ...string)
+	Delete(ctx context.Context, keys ...string)
+}
+
+// UserDeleter defines the behavior of the user soft-delete and restore operations
+// supported by internal stores
+type UserDeleter interface {
+	SoftDeleteUser(ctx context.Context, user *model.User) error
+	RestoreUser(ctx context.Context, user *model.User) (*model.User, error)
+}
+
+// EmailHashMatcher defines the behavior of the email membership by index hash, for the callers
+// not allowed to see the plaintext emails, see model.User.BuildEmailIndexKey
+type EmailHashMatcher interface {
+	MatchEmailHashes(ctx context.Context, hashes []string) (map[string]bool, error)
+}
+
+// ProfileLinkSigner defines the behavior of the signed tokens of the profile share links
+type ProfileLinkSigner interface {
+	Sign(sub string, ttl time.Duration) (string, time.Time, error)
+	Verify(token string) (string, error)
+}
+
+// MetadataPolicy defines the behavior of the moderation of the metadata set by the users, the
+// values are reviewed before the update reaches the identity provider
+type MetadataPolicy interface {
+	Review(ctx context.Context, metadata *model.UserMetadata) ([]model.ModerationDecision, error)
+}
+
+// TokenReferenceSealer defines the behavior of the opaque references returned instead of the raw tokens
+type TokenReferenceSealer interface {
+	Seal(kind, token string) (string, time.Time, error)
+	Open(kind, ref string) (string, error)
+}
+
+// OrganizationAdminWriter defines the behavior of the profile updates delegated to organization admins
+type OrganizationAdminWriter interface {
+	// OrganizationAdminLookup verifies the admin token and returns the organizations the admin manages
+	OrganizationAdminLookup(ctx context.Context, token string) (*model.OrganizationAdmin, error)
+	// UpdateUserAsOrganizationAdmin patches the member metadata using the service credentials
+	UpdateUserAsOrganizationAdmin(ctx context.Context, user *model.User) (*model.User, error)
+}
+
+// UserMetadataEnricher defines the behavior of the metadata updates of the enrichment jobs, the user
+// is identified by its user_id and the metadata is patched using the service credentials
+type UserMetadataEnricher interface {
+	EnrichUserMetadata(ctx context.Context, user *model.User) (*model.User, error)
+}
+
+// MetadataProvenanceStore defines the behavior of the provenance of the metadata fields, by user_id
+type MetadataProvenanceStore interface {
+	GetProvenance(ctx context.Context, userID string) (model.MetadataProvenance, error)
+	// RecordProvenance sets the provenance of the fields, leaving the other fields untouched
+	RecordProvenance(ctx context.Context, userID string, fields []string, provenance model.FieldProvenance) error
+}
+
+// UserMerger defines the behavior of the account merge, the secondary account identities are linked
+// to the primary account and the merged metadata is stored in the primary account
+type UserMerger interface {
+	MergeUsers(ctx context.Context, merge *model.UserMerge) (*model.User, error)
+}
+
+// AuthenticatorManager defines the behavior of the WebAuthn authenticators management
+type AuthenticatorManager interface {
+	ListAuthenticators(ctx context.Context, user *model.User) ([]model.Authenticator, error)
+	DeleteAuthenticator(ctx context.Context, user *model.User, authenticatorID string) error
+}
+
+// UserLocker defines the behavior of the per-user locks serializing the concurrent updates
+// of the same user, the keys are the identifiers of the users (sub, user_id) and the returned
+// function releases the locks
+type UserLocker interface {
+	LockUsers(ctx context.Context, keys ...string) (unlock func(), err error)
+}
+
+// ProfileSearcher defines the behavior of the typeahead search of the public profiles, the
+// users whose username or name starts with the query, up to limit matches
+type ProfileSearcher interface {
+	Typeahead(ctx context.Context, query string, limit int) ([]model.TypeaheadMatch, error)
+}
+
+// VerificationHandler defines the behavior of the one time password verification of the contacts of
+// the users on a delivery channel, the email channel being the one of the EmailHandler. Verify returns
+// the tokens of the passwordless identity of the recipient, to be linked to the user.
+type VerificationHandler interface {
+	SendVerification(ctx context.Context, verification *model.Verification) error
+	Verify(ctx context.Context, verification *model.Verification) (*model.AuthResponse, error)
+}
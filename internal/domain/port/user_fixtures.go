@@ -0,0 +1,19 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package port
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// UserFixtureLoader defines the behavior of the test identity providers whose users are set up at
+// runtime, so the e2e tests prepare their scenarios without rebuilding the service
+type UserFixtureLoader interface {
+	// ResetUsers replaces the users with the fixtures they were loaded from and returns their number
+	ResetUsers(ctx context.Context) (int, error)
+	// SeedUsers adds the users, replacing those with the same user id, and returns their number
+	SeedUsers(ctx context.Context, users []*model.User) (int, error)
+}
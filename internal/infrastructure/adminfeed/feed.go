@@ -0,0 +1,191 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package adminfeed bridges the internal events received from NATS (sync status, audit stream,
+// merges, profile changes) to the admin dashboards connected to this replica. The identifiers
+// of the users are redacted before the events leave the service.
+package adminfeed
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultMaxSubscriptions bounds the dashboards connected to a replica
+	DefaultMaxSubscriptions = 20
+
+	// subscriptionBuffer is the number of events kept for a slow dashboard, the next ones are
+	// dropped and the count of dropped events is reported with the next delivered event
+	subscriptionBuffer = 64
+)
+
+// Subjects are the NATS subjects bridged to the admin dashboards and the kind of their events
+var Subjects = map[string]model.AdminEventKind{
+	constants.AutheliaSyncStatusSubject: model.AdminEventSyncStatus,
+	constants.AuditEventSubject:         model.AdminEventAudit,
+	constants.UserMergedSubject:         model.AdminEventUserMerged,
+	constants.UserProfileChangedSubject: model.AdminEventProfileChanged,
+}
+
+// redactedFields are the fields identifying a user, at any depth of the event data
+var redactedFields = map[string]struct{}{
+	"sub":                {},
+	"user_id":            {},
+	"username":           {},
+	"primary_sub":        {},
+	"primary_username":   {},
+	"secondary_sub":      {},
+	"secondary_username": {},
+	"actor":              {},
+	"target":             {},
+}
+
+// subscription is a connected dashboard, dropped counts the events it missed since the last delivery
+type subscription struct {
+	kinds   map[model.AdminEventKind]struct{}
+	events  chan model.AdminEvent
+	dropped int
+}
+
+// Feed dispatches the internal events to the dashboards subscribed to their kind
+type Feed struct {
+	maxSubscriptions int
+	now              func() time.Time
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+	// last is the last event of each kind, whether or not a dashboard was subscribed
+	last map[model.AdminEventKind]model.AdminEvent
+}
+
+// SubscribeAdminEvents subscribes to the events of the given kinds until the context is done
+func (f *Feed) SubscribeAdminEvents(ctx context.Context, kinds []model.AdminEventKind) (<-chan model.AdminEvent, error) {
+	if len(kinds) == 0 {
+		return nil, errs.NewValidation("at least one event kind is required")
+	}
+
+	s := &subscription{
+		kinds:  make(map[model.AdminEventKind]struct{}, len(kinds)),
+		events: make(chan model.AdminEvent, subscriptionBuffer),
+	}
+	for _, kind := range kinds {
+		s.kinds[kind] = struct{}{}
+	}
+
+	f.mu.Lock()
+	if len(f.subscriptions) >= f.maxSubscriptions {
+		f.mu.Unlock()
+		return nil, errs.NewTooManyRequests("too many open admin event streams")
+	}
+	f.subscriptions[s] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscriptions, s)
+		close(s.events)
+	}()
+
+	return s.events, nil
+}
+
+// Handler returns the NATS message handler dispatching the events of the given kind
+func (f *Feed) Handler(kind model.AdminEventKind) func(ctx context.Context, data []byte) {
+	return func(ctx context.Context, data []byte) {
+		f.Dispatch(ctx, kind, data)
+	}
+}
+
+// Dispatch redacts an event and notifies the dashboards subscribed to its kind, it never blocks
+func (f *Feed) Dispatch(ctx context.Context, kind model.AdminEventKind, data []byte) {
+	var payload map[string]any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		slog.WarnContext(ctx, "invalid admin event", "kind", kind, "error", err)
+		return
+	}
+
+	event := model.AdminEvent{
+		Kind: kind,
+		At:   f.now().UTC(),
+		Data: redact(payload).(map[string]any),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.last[kind] = event
+	for s := range f.subscriptions {
+		if _, ok := s.kinds[kind]; !ok {
+			continue
+		}
+		event.Dropped = s.dropped
+		select {
+		case s.events <- event:
+			s.dropped = 0
+		default:
+			s.dropped++
+		}
+	}
+}
+
+// LastEvent returns the last event of the kind received by the replica, already redacted
+func (f *Feed) LastEvent(kind model.AdminEventKind) (model.AdminEvent, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	event, ok := f.last[kind]
+	return event, ok
+}
+
+// redact replaces the user identifiers of the event data with their redacted form
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if text, ok := nested.(string); ok {
+				switch {
+				case strings.Contains(strings.ToLower(key), "email"):
+					v[key] = redaction.RedactEmail(text)
+				default:
+					if _, sensitive := redactedFields[strings.ToLower(key)]; sensitive {
+						v[key] = redaction.Redact(text)
+					}
+				}
+				continue
+			}
+			v[key] = redact(nested)
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			v[i] = redact(nested)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// New creates a feed accepting up to maxSubscriptions dashboards
+func New(maxSubscriptions int) *Feed {
+	if maxSubscriptions <= 0 {
+		maxSubscriptions = DefaultMaxSubscriptions
+	}
+	return &Feed{
+		maxSubscriptions: maxSubscriptions,
+		now:              time.Now,
+		subscriptions:    make(map[*subscription]struct{}),
+		last:             make(map[model.AdminEventKind]model.AdminEvent),
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package adminfeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func receive(t *testing.T, ch <-chan model.AdminEvent) (model.AdminEvent, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the subscription")
+		return model.AdminEvent{}, false
+	}
+}
+
+func TestFeed_Dispatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := New(0)
+	audit, err := feed.SubscribeAdminEvents(ctx, []model.AdminEventKind{model.AdminEventAudit})
+	if err != nil {
+		t.Fatalf("SubscribeAdminEvents() unexpected error: %v", err)
+	}
+	sync, err := feed.SubscribeAdminEvents(ctx, []model.AdminEventKind{model.AdminEventSyncStatus})
+	if err != nil {
+		t.Fatalf("SubscribeAdminEvents() unexpected error: %v", err)
+	}
+
+	feed.Handler(model.AdminEventAudit)(ctx, []byte(`{
+		"action": "user.merge",
+		"actor": "auth0|primary",
+		"target": "github|secondary",
+		"details": {"conflict_policy": "prefer_primary", "emails": [{"email": "jdoe@example.com"}]}
+	}`))
+	feed.Dispatch(ctx, model.AdminEventAudit, []byte(`not json`))
+
+	event, _ := receive(t, audit)
+	if event.Kind != model.AdminEventAudit || event.Data["action"] != "user.merge" {
+		t.Fatalf("received %+v", event)
+	}
+	if event.Data["actor"] != "aut****" || event.Data["target"] != "git****" {
+		t.Errorf("data = %v, the identifiers must be redacted", event.Data)
+	}
+	details := event.Data["details"].(map[string]any)
+	if details["conflict_policy"] != "prefer_primary" {
+		t.Errorf("details = %v, the other fields must be kept", details)
+	}
+	if email := details["emails"].([]any)[0].(map[string]any)["email"]; email == "jdoe@example.com" {
+		t.Errorf("nested email = %v, must be redacted", email)
+	}
+
+	select {
+	case event := <-sync:
+		t.Errorf("sync status subscription received %+v", event)
+	default:
+	}
+}
+
+func TestFeed_LastEvent(t *testing.T) {
+	ctx := context.Background()
+	feed := New(0)
+
+	if _, ok := feed.LastEvent(model.AdminEventSyncStatus); ok {
+		t.Fatal("LastEvent() found an event before any was received")
+	}
+
+	// kept even without any dashboard subscribed
+	feed.Dispatch(ctx, model.AdminEventSyncStatus, []byte(`{"succeeded":false,"changed":0,"error":"conflict"}`))
+	feed.Dispatch(ctx, model.AdminEventSyncStatus, []byte(`{"succeeded":true,"changed":3}`))
+	feed.Dispatch(ctx, model.AdminEventAudit, []byte(`{"action":"user.merge","actor":"auth0|primary"}`))
+
+	event, ok := feed.LastEvent(model.AdminEventSyncStatus)
+	if !ok {
+		t.Fatal("LastEvent() found no sync status")
+	}
+	if event.Data["succeeded"] != true || event.Data["changed"] != float64(3) {
+		t.Errorf("LastEvent() = %+v, want the last sync status", event)
+	}
+	if audit, _ := feed.LastEvent(model.AdminEventAudit); audit.Data["actor"] != "aut****" {
+		t.Errorf("LastEvent() data = %v, the identifiers must be redacted", audit.Data)
+	}
+}
+
+func TestFeed_SlowSubscriberDropsEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := New(0)
+	ch, err := feed.SubscribeAdminEvents(ctx, model.AdminEventKinds)
+	if err != nil {
+		t.Fatalf("SubscribeAdminEvents() unexpected error: %v", err)
+	}
+
+	for i := 0; i < subscriptionBuffer+5; i++ {
+		feed.Dispatch(ctx, model.AdminEventProfileChanged, []byte(`{"sub":"auth0|123","reason":"update"}`))
+	}
+	if len(ch) != subscriptionBuffer {
+		t.Fatalf("pending events = %d, want %d", len(ch), subscriptionBuffer)
+	}
+
+	// once the dashboard catches up, the next event reports the events it missed
+	for i := 0; i < subscriptionBuffer; i++ {
+		receive(t, ch)
+	}
+	feed.Dispatch(ctx, model.AdminEventProfileChanged, []byte(`{"sub":"auth0|123","reason":"update"}`))
+	if event, _ := receive(t, ch); event.Dropped != 5 {
+		t.Errorf("Dropped = %d, want 5", event.Dropped)
+	}
+	feed.Dispatch(ctx, model.AdminEventProfileChanged, []byte(`{"sub":"auth0|123","reason":"update"}`))
+	if event, _ := receive(t, ch); event.Dropped != 0 {
+		t.Errorf("Dropped = %d, want 0 once reported", event.Dropped)
+	}
+}
+
+func TestFeed_SubscriptionLifecycle(t *testing.T) {
+	feed := New(1)
+
+	if _, err := feed.SubscribeAdminEvents(context.Background(), nil); err == nil {
+		t.Error("SubscribeAdminEvents() without kinds, want an error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := feed.SubscribeAdminEvents(ctx, model.AdminEventKinds)
+	if err != nil {
+		t.Fatalf("SubscribeAdminEvents() unexpected error: %v", err)
+	}
+
+	_, err = feed.SubscribeAdminEvents(context.Background(), model.AdminEventKinds)
+	var tooMany errs.TooManyRequests
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("SubscribeAdminEvents() over the limit error = %v, want TooManyRequests", err)
+	}
+
+	cancel()
+	if _, ok := receive(t, ch); ok {
+		t.Fatal("channel must be closed once the context is done")
+	}
+
+	if _, err := feed.SubscribeAdminEvents(context.Background(), model.AdminEventKinds); err != nil {
+		t.Errorf("SubscribeAdminEvents() after close unexpected error: %v", err)
+	}
+}
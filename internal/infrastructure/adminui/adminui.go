@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package adminui serves the admin UI, a small page embedded in the binary running the most
+// common support actions (user lookup, cache purge, sync status, webhook deliveries) against the
+// admin REST endpoints. The page holds no data: the admin enters their bearer token, kept in the
+// session storage of the browser, and every action is authorized by the admin endpoints.
+package adminui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// Prefix is the path the admin UI is served under
+const Prefix = "/admin/ui"
+
+//go:embed static
+var staticFS embed.FS
+
+// securityHeaders restrict the page to its own scripts and styles, and keep it out of frames
+var securityHeaders = map[string]string{
+	"Content-Security-Policy": "default-src 'self'; connect-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'",
+	"X-Content-Type-Options":  "nosniff",
+	"X-Frame-Options":         "DENY",
+	"Referrer-Policy":         "no-referrer",
+	"Cache-Control":           "no-cache",
+}
+
+// Handler serves the files of the admin UI under Prefix, the bare prefix is redirected to the page
+func Handler() http.Handler {
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		// the files are embedded, a failure is a build defect
+		panic(err)
+	}
+	files := http.StripPrefix(Prefix, http.FileServer(http.FS(static)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == Prefix {
+			http.Redirect(w, r, Prefix+"/", http.StatusMovedPermanently)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, Prefix+"/") {
+			http.NotFound(w, r)
+			return
+		}
+		for key, value := range securityHeaders {
+			w.Header().Set(key, value)
+		}
+		files.ServeHTTP(w, r)
+	})
+}
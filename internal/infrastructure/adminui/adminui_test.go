@@ -0,0 +1,46 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package adminui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	handler := Handler()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantContent string
+		wantHeaders bool
+	}{
+		{name: "page", path: "/admin/ui/", wantStatus: http.StatusOK, wantContent: "User lookup", wantHeaders: true},
+		{name: "script", path: "/admin/ui/app.js", wantStatus: http.StatusOK, wantContent: "/admin/webhook-deliveries", wantHeaders: true},
+		{name: "bare prefix is redirected", path: "/admin/ui", wantStatus: http.StatusMovedPermanently},
+		{name: "unknown file", path: "/admin/ui/missing.js", wantStatus: http.StatusNotFound, wantHeaders: true},
+		{name: "outside the prefix", path: "/admin/events", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.wantStatus, recorder.Code)
+			assert.True(t, strings.Contains(recorder.Body.String(), tt.wantContent))
+			if tt.wantHeaders {
+				assert.Contains(t, recorder.Header().Get("Content-Security-Policy"), "default-src 'self'")
+				assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+			}
+		})
+	}
+}
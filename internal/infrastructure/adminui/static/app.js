@@ -0,0 +1,162 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// The admin UI calls the admin REST endpoints with the token of the admin, kept in the session
+// storage so it is dropped with the tab. The values are always rendered as text.
+"use strict";
+
+const tokenKey = "lfx-auth-admin-token";
+
+function token() {
+  return sessionStorage.getItem(tokenKey) || "";
+}
+
+function setStatus(message, failed) {
+  const status = document.getElementById("status");
+  status.textContent = message;
+  status.classList.toggle("error", Boolean(failed));
+}
+
+async function call(method, path) {
+  if (!token()) {
+    throw new Error("enter an admin token first");
+  }
+  const response = await fetch(path, {
+    method: method,
+    headers: { Authorization: "Bearer " + token() },
+    cache: "no-store",
+  });
+  if (!response.ok) {
+    const body = await response.text();
+    let message = body;
+    try {
+      const parsed = JSON.parse(body);
+      message = parsed.message || parsed;
+    } catch (e) {
+      // not a JSON error
+    }
+    throw new Error(response.status + " " + (message || response.statusText));
+  }
+  if (response.status === 204) {
+    return null;
+  }
+  return response.json();
+}
+
+// renderRows renders the rows of objects as a table with the given columns
+function renderRows(table, columns, rows) {
+  table.replaceChildren();
+  const head = table.insertRow();
+  for (const column of columns) {
+    const th = document.createElement("th");
+    th.textContent = column;
+    head.appendChild(th);
+  }
+  if (rows.length === 0) {
+    const cell = table.insertRow().insertCell();
+    cell.colSpan = columns.length;
+    cell.textContent = "none";
+    return;
+  }
+  for (const row of rows) {
+    const tr = table.insertRow();
+    for (const column of columns) {
+      const value = row[column];
+      tr.insertCell().textContent = value === undefined || value === null ? "" : String(value);
+    }
+  }
+}
+
+async function run(action) {
+  setStatus("loading...");
+  try {
+    const message = await action();
+    setStatus(message || "");
+  } catch (e) {
+    setStatus(e.message, true);
+  }
+}
+
+function userPath(form, suffix) {
+  const user = new FormData(form).get("user").trim();
+  return "/admin/users/" + encodeURIComponent(user) + suffix;
+}
+
+function lookup(event) {
+  event.preventDefault();
+  const result = document.getElementById("lookup-result");
+  result.textContent = "";
+  run(async () => {
+    const metadata = await call("GET", userPath(event.target, "/metadata"));
+    result.textContent = JSON.stringify(metadata, null, 2);
+  });
+}
+
+function purge(event) {
+  event.preventDefault();
+  run(async () => {
+    await call("POST", userPath(event.target, "/cache/purge"));
+    return "user purged from the cache";
+  });
+}
+
+function syncStatus() {
+  run(async () => {
+    const bundle = await call("GET", "/admin/support-bundle");
+    renderRows(document.getElementById("sync-last"), ["succeeded", "changed", "error", "synced_at"],
+      bundle.last_sync ? [bundle.last_sync] : []);
+    renderRows(document.getElementById("sync-providers"),
+      ["provider", "samples", "failures", "error_rate", "p95_ms", "circuit_state", "last_failure_at"],
+      bundle.providers || []);
+    renderRows(document.getElementById("sync-cache"), ["kind", "hits", "misses", "entries"],
+      bundle.user_cache ? [bundle.user_cache] : []);
+    return "generated at " + bundle.generated_at;
+  });
+}
+
+function webhookDeliveries() {
+  run(async () => {
+    const result = await call("GET", "/admin/webhook-deliveries");
+    renderRows(document.getElementById("webhooks-result"),
+      ["delivered_at", "action", "status_code", "duration_ms", "error"], result.deliveries);
+  });
+}
+
+const loaders = { sync: syncStatus, webhooks: webhookDeliveries };
+
+function showPage() {
+  const page = (location.hash || "#lookup").slice(1);
+  for (const section of document.querySelectorAll("[data-page]")) {
+    section.classList.toggle("active", section.id === page);
+  }
+  for (const link of document.querySelectorAll("nav a")) {
+    link.classList.toggle("active", link.getAttribute("href") === "#" + page);
+  }
+  setStatus("");
+  if (loaders[page] && token()) {
+    loaders[page]();
+  }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  document.getElementById("token-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const input = document.getElementById("token");
+    const value = input.value.trim().replace(/^Bearer\s+/i, "");
+    input.value = "";
+    if (value) {
+      sessionStorage.setItem(tokenKey, value);
+      setStatus("token saved for this tab");
+    }
+  });
+  document.getElementById("token-clear").addEventListener("click", () => {
+    sessionStorage.removeItem(tokenKey);
+    setStatus("token cleared");
+  });
+  document.getElementById("lookup-form").addEventListener("submit", lookup);
+  document.getElementById("cache-form").addEventListener("submit", purge);
+  document.getElementById("sync-refresh").addEventListener("click", syncStatus);
+  document.getElementById("webhooks-refresh").addEventListener("click", webhookDeliveries);
+  window.addEventListener("hashchange", showPage);
+  showPage();
+});
@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// FileSink appends the events to a JSON lines file, one event per line
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens the file, created when missing, to append the events to it
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to open the audit file", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Record appends the event to the file
+func (s *FileSink) Record(_ context.Context, event *model.AuditEvent) error {
+	data, errMarshal := json.Marshal(event)
+	if errMarshal != nil {
+		return errs.NewUnexpected("failed to marshal audit event", errMarshal)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, errWrite := s.file.Write(append(data, '\n')); errWrite != nil {
+		return errs.NewUnexpected("failed to write the audit file", errWrite)
+	}
+	return nil
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
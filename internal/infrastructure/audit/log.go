@@ -0,0 +1,49 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// LogSink records the events in the service logs, the failures at the warning level
+type LogSink struct {
+	logger *slog.Logger
+}
+
+// NewLogSink creates a sink logging with the logger, the default logger when nil
+func NewLogSink(logger *slog.Logger) *LogSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogSink{logger: logger}
+}
+
+// Record logs the event
+func (s *LogSink) Record(ctx context.Context, event *model.AuditEvent) error {
+	level := slog.LevelInfo
+	if event.Outcome == model.AuditOutcomeFailure {
+		level = slog.LevelWarn
+	}
+
+	attrs := []any{
+		"action", event.Action,
+		"actor", redaction.Redact(event.Actor),
+		"target", redaction.Redact(event.Target),
+		"outcome", event.Outcome,
+		"at", event.At,
+	}
+	if len(event.Changes) > 0 {
+		attrs = append(attrs, "changes", event.Changes)
+	}
+	if event.Error != "" {
+		attrs = append(attrs, "error", event.Error)
+	}
+	s.logger.Log(ctx, level, "audit: "+string(event.Action), attrs...)
+	return nil
+}
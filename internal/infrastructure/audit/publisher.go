@@ -0,0 +1,37 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// PublisherSink publishes the events on a NATS subject
+type PublisherSink struct {
+	publisher port.EventPublisher
+	subject   string
+}
+
+// NewPublisherSink creates a sink publishing the events on the subject
+func NewPublisherSink(publisher port.EventPublisher, subject string) *PublisherSink {
+	return &PublisherSink{publisher: publisher, subject: subject}
+}
+
+// Record publishes the event
+func (s *PublisherSink) Record(ctx context.Context, event *model.AuditEvent) error {
+	data, errMarshal := json.Marshal(event)
+	if errMarshal != nil {
+		return errs.NewUnexpected("failed to marshal audit event", errMarshal)
+	}
+	if errPublish := s.publisher.Publish(ctx, s.subject, data); errPublish != nil {
+		return errs.NewUnexpected(fmt.Sprintf("failed to publish audit event on %s", s.subject), errPublish)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package audit records the audit events of the user mutations (updates, email linking, syncs)
+// in the configured sinks: the service logs, a NATS subject, a JSON lines file or a webhook.
+// The events carry the redacted values of the changed fields, never the values themselves.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// SinkKind is a kind of audit sink
+type SinkKind string
+
+const (
+	// SinkLog records the events in the service logs
+	SinkLog SinkKind = "log"
+
+	// SinkNATS publishes the events on a NATS subject
+	SinkNATS SinkKind = "nats"
+
+	// SinkFile appends the events to a JSON lines file
+	SinkFile SinkKind = "file"
+
+	// SinkWebhook posts the events to a webhook
+	SinkWebhook SinkKind = "webhook"
+)
+
+// ParseSinkKinds parses a comma separated list of sink kinds, duplicates are ignored
+func ParseSinkKinds(spec string) ([]SinkKind, error) {
+	var kinds []SinkKind
+	for _, value := range strings.Split(spec, ",") {
+		kind := SinkKind(strings.ToLower(strings.TrimSpace(value)))
+		switch kind {
+		case "":
+			continue
+		case SinkLog, SinkNATS, SinkFile, SinkWebhook:
+		default:
+			return nil, errs.NewValidation(fmt.Sprintf("unknown audit sink %s, expected log, nats, file or webhook", value))
+		}
+		if !slices.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+// Sinks records the events in every sink, a failing sink doesn't keep the others from recording
+type Sinks []port.AuditSink
+
+// Record records the event in every sink and returns the joined failures
+func (s Sinks) Record(ctx context.Context, event *model.AuditEvent) error {
+	var errRecord []error
+	for _, sink := range s {
+		if err := sink.Record(ctx, event); err != nil {
+			errRecord = append(errRecord, err)
+		}
+	}
+	return errors.Join(errRecord...)
+}
@@ -0,0 +1,209 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() *model.AuditEvent {
+	return &model.AuditEvent{
+		Action:  model.AuditActionUserUpdate,
+		Actor:   "auth0|zephyr001",
+		Target:  "auth0|zephyr001",
+		Changes: map[string]string{"job_title": "Clo****", "phone_number": ""},
+		Outcome: model.AuditOutcomeSuccess,
+		At:      time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestParseSinkKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []SinkKind
+		wantErr bool
+	}{
+		{name: "empty", spec: ""},
+		{name: "single", spec: "log", want: []SinkKind{SinkLog}},
+		{name: "several", spec: "log, NATS,file,webhook", want: []SinkKind{SinkLog, SinkNATS, SinkFile, SinkWebhook}},
+		{name: "duplicates ignored", spec: "log,log", want: []SinkKind{SinkLog}},
+		{name: "unknown", spec: "log,syslog", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSinkKinds(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// failingSink fails to record the events
+type failingSink struct{}
+
+func (failingSink) Record(context.Context, *model.AuditEvent) error {
+	return errors.New("sink down")
+}
+
+// recordingSink records the events
+type recordingSink struct {
+	events []*model.AuditEvent
+}
+
+func (r *recordingSink) Record(_ context.Context, event *model.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestSinks_Record(t *testing.T) {
+	recording := &recordingSink{}
+	err := Sinks{failingSink{}, recording}.Record(context.Background(), testEvent())
+
+	require.Error(t, err)
+	assert.Len(t, recording.events, 1, "a failing sink doesn't keep the others from recording")
+}
+
+func TestLogSink_Record(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewLogSink(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	event := testEvent()
+	event.Fail(errors.New("user not found"))
+	require.NoError(t, sink.Record(context.Background(), event))
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "audit: user_metadata.update", line["msg"])
+	assert.Equal(t, "aut****", line["actor"])
+	assert.Equal(t, "failure", line["outcome"])
+	assert.Equal(t, "user not found", line["error"])
+}
+
+// mockPublisher records the published messages
+type mockPublisher struct {
+	subject string
+	data    []byte
+}
+
+func (m *mockPublisher) Publish(_ context.Context, subject string, data []byte) error {
+	m.subject, m.data = subject, data
+	return nil
+}
+
+func TestPublisherSink_Record(t *testing.T) {
+	publisher := &mockPublisher{}
+	require.NoError(t, NewPublisherSink(publisher, "lfx.audit.user").Record(context.Background(), testEvent()))
+
+	assert.Equal(t, "lfx.audit.user", publisher.subject)
+	var event model.AuditEvent
+	require.NoError(t, json.Unmarshal(publisher.data, &event))
+	assert.Equal(t, *testEvent(), event)
+}
+
+func TestFileSink_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Record(context.Background(), testEvent()))
+	require.NoError(t, sink.Record(context.Background(), testEvent()))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event model.AuditEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		assert.Equal(t, model.AuditActionUserUpdate, event.Action)
+		lines++
+	}
+	assert.Equal(t, 2, lines)
+}
+
+func TestWebhookSink_Record(t *testing.T) {
+	var (
+		authorization string
+		body          []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	config := httpclient.DefaultConfig()
+	config.MaxRetries = 0
+	sink := NewWebhookSink(httpclient.NewClient(config), server.URL, "webhook-token")
+	require.NoError(t, sink.Record(context.Background(), testEvent()))
+
+	assert.Equal(t, "Bearer webhook-token", authorization)
+	assert.True(t, strings.Contains(string(body), `"action":"user_metadata.update"`))
+
+	deliveries := sink.WebhookDeliveries()
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, model.AuditActionUserUpdate, deliveries[0].Action)
+	assert.Equal(t, http.StatusAccepted, deliveries[0].StatusCode)
+	assert.Empty(t, deliveries[0].Error)
+}
+
+func TestWebhookSink_WebhookDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "webhook unavailable", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := httpclient.DefaultConfig()
+	config.MaxRetries = 0
+	sink := NewWebhookSink(httpclient.NewClient(config), server.URL, "")
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	sink.now = func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls) * time.Second)
+	}
+
+	for i := 0; i < DefaultMaxDeliveries+5; i++ {
+		assert.Error(t, sink.Record(context.Background(), testEvent()))
+	}
+
+	deliveries := sink.WebhookDeliveries()
+	require.Len(t, deliveries, DefaultMaxDeliveries, "only the last deliveries are kept")
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+	assert.NotEmpty(t, deliveries[0].Error)
+	assert.Equal(t, time.Second, deliveries[0].Duration)
+	for i := 1; i < len(deliveries); i++ {
+		assert.True(t, deliveries[i].DeliveredAt.Before(deliveries[i-1].DeliveredAt), "the most recent first")
+	}
+	assert.Equal(t, start.Add(time.Duration(2*5+1)*time.Second), deliveries[len(deliveries)-1].DeliveredAt)
+}
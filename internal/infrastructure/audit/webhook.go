@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+// DefaultMaxDeliveries is the number of deliveries kept for the admins
+const DefaultMaxDeliveries = 50
+
+// WebhookSink posts the events to a webhook, authenticated by a bearer token when set.
+// The last deliveries are kept in memory, so the admins can check the webhook is reached.
+type WebhookSink struct {
+	httpClient *httpclient.Client
+	url        string
+	token      string
+	now        func() time.Time
+
+	mu         sync.Mutex
+	deliveries []model.WebhookDelivery
+	next       int
+}
+
+// NewWebhookSink creates a sink posting the events to the URL
+func NewWebhookSink(httpClient *httpclient.Client, url, token string) *WebhookSink {
+	return &WebhookSink{
+		httpClient: httpClient,
+		url:        url,
+		token:      token,
+		now:        time.Now,
+		deliveries: make([]model.WebhookDelivery, 0, DefaultMaxDeliveries),
+	}
+}
+
+// Record posts the event
+func (s *WebhookSink) Record(ctx context.Context, event *model.AuditEvent) error {
+	apiRequest := httpclient.NewAPIRequest(
+		s.httpClient,
+		httpclient.WithMethod(http.MethodPost),
+		httpclient.WithURL(s.url),
+		httpclient.WithToken(s.token),
+		httpclient.WithBody(event),
+		httpclient.WithDescription("audit webhook"),
+	)
+
+	start := s.now()
+	statusCode, err := apiRequest.Call(ctx, nil)
+	delivery := model.WebhookDelivery{
+		Action:      event.Action,
+		StatusCode:  statusCode,
+		Duration:    s.now().Sub(start),
+		DeliveredAt: start.UTC(),
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	s.remember(delivery)
+	return err
+}
+
+// remember keeps the delivery, replacing the oldest one once full
+func (s *WebhookSink) remember(delivery model.WebhookDelivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.deliveries) < DefaultMaxDeliveries {
+		s.deliveries = append(s.deliveries, delivery)
+		return
+	}
+	s.deliveries[s.next] = delivery
+	s.next = (s.next + 1) % DefaultMaxDeliveries
+}
+
+// WebhookDeliveries returns the last deliveries, the most recent first
+func (s *WebhookSink) WebhookDeliveries() []model.WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deliveries := make([]model.WebhookDelivery, 0, len(s.deliveries))
+	for i := len(s.deliveries) - 1; i >= 0; i-- {
+		deliveries = append(deliveries, s.deliveries[(s.next+i)%len(s.deliveries)])
+	}
+	return deliveries
+}
@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// authenticationMethod is the Auth0 authentication method as returned by the Management API
+type authenticationMethod struct {
+	ID                   string     `json:"id"`
+	Type                 string     `json:"type"`
+	Name                 string     `json:"name,omitempty"`
+	CredentialDeviceType string     `json:"credential_device_type,omitempty"`
+	CredentialBackedUp   bool       `json:"credential_backed_up,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	LastAuthAt           *time.Time `json:"last_auth_at,omitempty"`
+}
+
+// isWebAuthn reports whether the authentication method is a security key or a passkey
+func (a authenticationMethod) isWebAuthn() bool {
+	switch a.Type {
+	case "webauthn-roaming", "webauthn-platform", "passkey":
+		return true
+	}
+	return false
+}
+
+func (a authenticationMethod) toAuthenticator() model.Authenticator {
+	return model.Authenticator{
+		ID:         a.ID,
+		Type:       a.Type,
+		Name:       a.Name,
+		DeviceType: a.CredentialDeviceType,
+		BackedUp:   a.CredentialBackedUp,
+		CreatedAt:  a.CreatedAt,
+		LastUsedAt: a.LastAuthAt,
+	}
+}
+
+// ListAuthenticators returns the WebAuthn authenticators registered by the user,
+// other authentication methods (password, OTP, push) are filtered out
+func (u *userReaderWriter) ListAuthenticators(ctx context.Context, user *model.User) ([]model.Authenticator, error) {
+
+	if user == nil || strings.TrimSpace(user.UserID) == "" {
+		return nil, errors.NewValidation("user_id is required to list authenticators")
+	}
+
+	methods, err := u.authenticationMethods(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticators := make([]model.Authenticator, 0, len(methods))
+	for _, method := range methods {
+		if method.isWebAuthn() {
+			authenticators = append(authenticators, method.toAuthenticator())
+		}
+	}
+	return authenticators, nil
+}
+
+// DeleteAuthenticator removes a WebAuthn authenticator from the user, the id must belong
+// to one of the user's WebAuthn authenticators so other methods can't be removed this way
+func (u *userReaderWriter) DeleteAuthenticator(ctx context.Context, user *model.User, authenticatorID string) error {
+
+	if user == nil || strings.TrimSpace(user.UserID) == "" {
+		return errors.NewValidation("user_id is required to delete an authenticator")
+	}
+	if strings.TrimSpace(authenticatorID) == "" {
+		return errors.NewValidation("authenticator_id is required")
+	}
+
+	methods, err := u.authenticationMethods(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, method := range methods {
+		if method.ID == authenticatorID && method.isWebAuthn() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.NewNotFound("authenticator not found")
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodDelete),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s/authentication-methods/%s",
+			u.config.Domain, url.PathEscape(user.UserID), url.PathEscape(authenticatorID))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("delete user authentication method"),
+	)
+
+	statusCode, errCall := apiRequest.Call(ctx, nil)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to delete authentication method in Auth0",
+			"error", errCall,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(user.UserID),
+		)
+		return errors.NewUnexpected("failed to delete authenticator", errCall)
+	}
+
+	slog.InfoContext(ctx, "authenticator deleted",
+		"user_id", redaction.Redact(user.UserID),
+		"authenticator_id", authenticatorID,
+	)
+	return nil
+}
+
+// authenticationMethods fetches all the authentication methods of the user using the M2M token
+func (u *userReaderWriter) authenticationMethods(ctx context.Context, userID string) ([]authenticationMethod, error) {
+
+	if strings.TrimSpace(u.config.Domain) == "" {
+		return nil, errors.NewValidation("Auth0 domain configuration is missing")
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return nil, errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s/authentication-methods",
+			u.config.Domain, url.PathEscape(userID))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("list user authentication methods"),
+	)
+
+	var methods []authenticationMethod
+	statusCode, errCall := apiRequest.Call(ctx, &methods)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to list authentication methods in Auth0",
+			"error", errCall,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(userID),
+		)
+		return nil, errors.NewUnexpected("failed to list authenticators", errCall)
+	}
+	return methods, nil
+}
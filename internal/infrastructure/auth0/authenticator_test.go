@@ -0,0 +1,46 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticationMethod_IsWebAuthn(t *testing.T) {
+	tests := []struct {
+		methodType string
+		want       bool
+	}{
+		{methodType: "webauthn-roaming", want: true},
+		{methodType: "webauthn-platform", want: true},
+		{methodType: "passkey", want: true},
+		{methodType: "password", want: false},
+		{methodType: "totp", want: false},
+		{methodType: "phone", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.methodType, func(t *testing.T) {
+			assert.Equal(t, tt.want, authenticationMethod{Type: tt.methodType}.isWebAuthn())
+		})
+	}
+}
+
+func TestUserReaderWriter_Authenticators_Validation(t *testing.T) {
+	ctx := context.Background()
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	_, err := u.ListAuthenticators(ctx, nil)
+	assert.Error(t, err)
+
+	err = u.DeleteAuthenticator(ctx, &model.User{UserID: "auth0|123"}, " ")
+	assert.Error(t, err)
+
+	err = u.DeleteAuthenticator(ctx, &model.User{}, "passkey|dev_1")
+	assert.Error(t, err)
+}
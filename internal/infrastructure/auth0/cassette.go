@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+
+	"github.com/auth0/go-auth0/authentication"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+// replayClientID stands in for the client credentials when replaying a cassette,
+// the credentials are sanitized from the recorded requests
+const replayClientID = "replay"
+
+// replaying reports whether the Auth0 traffic is replayed from a cassette, the tenant
+// credentials are not required then
+func (c Config) replaying() bool {
+	return c.Cassette != nil && c.Cassette.Mode() == httpclient.CassetteModeReplay
+}
+
+// authenticationOptions routes the traffic of the Auth0 SDK clients through the cassette, if any
+func (c Config) authenticationOptions() []authentication.Option {
+	if c.Cassette == nil {
+		return nil
+	}
+	return []authentication.Option{
+		authentication.WithClient(&http.Client{Transport: c.Cassette}),
+	}
+}
+
+// replayPrivateKey generates a throwaway key to sign the M2M client assertions of a replay,
+// base64 encoded like the configured one
+func replayPrivateKey() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantTransport stands in for the Auth0 tenant
+type tenantTransport func(req *http.Request) (*http.Response, error)
+
+func (f tenantTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestM2MTokenManager_Cassette(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "auth0.json")
+	t.Setenv(constants.Auth0AudienceEnvKey, "https://api.lfx.dev/")
+
+	calls := 0
+	tenant := tenantTransport(func(req *http.Request) (*http.Response, error) {
+		calls++
+		body := `{"access_token":"tenant-token","expires_in":86400,"token_type":"Bearer"}`
+		if req.URL.Path == "/.well-known/jwks.json" {
+			body = `{"keys":[]}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	// record with the tenant credentials
+	key, err := replayPrivateKey()
+	require.NoError(t, err)
+	t.Setenv(constants.Auth0M2MClientIDEnvKey, "m2m-client")
+	t.Setenv(constants.Auth0M2MPrivateBase64KeyEnvKey, key)
+
+	recorder, err := httpclient.NewCassette(httpclient.CassetteModeRecord, path, tenant)
+	require.NoError(t, err)
+	manager, err := NewM2MTokenManager(ctx, Config{Domain: "lfx.auth0.com", Cassette: recorder})
+	require.NoError(t, err)
+	token, err := manager.GetToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-token", token)
+
+	// replay without the tenant credentials nor the tenant
+	t.Setenv(constants.Auth0M2MClientIDEnvKey, "")
+	t.Setenv(constants.Auth0M2MPrivateBase64KeyEnvKey, "")
+
+	player, err := httpclient.NewCassette(httpclient.CassetteModeReplay, path, nil)
+	require.NoError(t, err)
+	manager, err = NewM2MTokenManager(ctx, Config{Domain: "lfx.auth0.com", Cassette: player})
+	require.NoError(t, err)
+	token, err = manager.GetToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "REDACTED", token)
+	assert.Equal(t, 2, calls, "the replay must not reach the tenant")
+
+	// the credentials are still required without a cassette
+	_, err = NewM2MTokenManager(ctx, Config{Domain: "lfx.auth0.com"})
+	assert.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	ctx := context.Background()
+	key, err := replayPrivateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		config  Config
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: Config{Domain: "lfx.auth0.com"},
+		},
+		{
+			name:    "missing tenant and domain",
+			config:  Config{},
+			wantErr: "tenant or domain",
+		},
+		{
+			name:    "missing client ID",
+			config:  Config{Domain: "lfx.auth0.com"},
+			env:     map[string]string{constants.Auth0M2MClientIDEnvKey: ""},
+			wantErr: constants.Auth0M2MClientIDEnvKey,
+		},
+		{
+			name:    "key not PEM",
+			config:  Config{Domain: "lfx.auth0.com"},
+			env:     map[string]string{constants.Auth0M2MPrivateBase64KeyEnvKey: "bm90IGEga2V5"},
+			wantErr: "PEM",
+		},
+		{
+			name:    "missing profile client",
+			config:  Config{Domain: "lfx.auth0.com"},
+			env:     map[string]string{constants.Auth0LFXProfileClientSecretEnvKey: ""},
+			wantErr: constants.Auth0LFXProfileClientSecretEnvKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.Auth0AudienceEnvKey, "https://api.lfx.dev/")
+			t.Setenv(constants.Auth0M2MClientIDEnvKey, "m2m-client")
+			t.Setenv(constants.Auth0M2MPrivateBase64KeyEnvKey, key)
+			t.Setenv(constants.Auth0LFXProfileClientIDEnvKey, "profile-client")
+			t.Setenv(constants.Auth0LFXProfileClientSecretEnvKey, "profile-secret")
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			err := tt.config.Validate(ctx)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
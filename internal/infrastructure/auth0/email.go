@@ -5,12 +5,15 @@ package auth0
 
 import (
 	"context"
+	stderrors "errors"
 	"log/slog"
+	"net/http"
 
 	"github.com/auth0/go-auth0/authentication"
 	"github.com/auth0/go-auth0/authentication/oauth"
 	"github.com/auth0/go-auth0/authentication/passwordless"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
@@ -22,6 +25,8 @@ type emailLinkingFlow struct {
 type passwordlessFlow interface {
 	SendEmail(ctx context.Context, request passwordless.SendEmailRequest) (*passwordless.SendEmailResponse, error)
 	LoginWithEmail(ctx context.Context, request passwordless.LoginWithEmailRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error)
+	SendSMS(ctx context.Context, request passwordless.SendSMSRequest) (*passwordless.SendSMSResponse, error)
+	LoginWithSMS(ctx context.Context, request passwordless.LoginWithSMSRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error)
 }
 
 type auth0PasswordlessFlow struct {
@@ -42,6 +47,30 @@ func (a *auth0PasswordlessFlow) LoginWithEmail(ctx context.Context, request pass
 	return a.authConfig.Passwordless.LoginWithEmail(ctx, request, options)
 }
 
+func (a *auth0PasswordlessFlow) SendSMS(ctx context.Context, request passwordless.SendSMSRequest) (*passwordless.SendSMSResponse, error) {
+	if a.authConfig == nil {
+		return nil, errors.NewUnexpected("auth0 authentication client not configured")
+	}
+	return a.authConfig.Passwordless.SendSMS(ctx, request)
+}
+
+func (a *auth0PasswordlessFlow) LoginWithSMS(ctx context.Context, request passwordless.LoginWithSMSRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error) {
+	if a.authConfig == nil {
+		return nil, errors.NewUnexpected("auth0 authentication client not configured")
+	}
+	return a.authConfig.Passwordless.LoginWithSMS(ctx, request, options)
+}
+
+// passwordlessError returns the error of a failed passwordless call, the codes rejected by Auth0
+// are client errors so they are told apart from the outages
+func passwordlessError(message string, err error) error {
+	var authErr *authentication.Error
+	if stderrors.As(err, &authErr) && authErr.StatusCode >= http.StatusBadRequest && authErr.StatusCode < http.StatusInternalServerError {
+		return httpclient.ErrorFromStatusCode(authErr.StatusCode, message)
+	}
+	return errors.NewUnexpected(message, err)
+}
+
 // StartPasswordlessFlow initiates a passwordless authentication flow by sending an OTP to the user's email
 // This is used in the alternate email linking flow to send a verification code to the alternate email address.
 func (e *emailLinkingFlow) StartPasswordlessFlow(ctx context.Context, email string) error {
@@ -62,7 +91,7 @@ func (e *emailLinkingFlow) StartPasswordlessFlow(ctx context.Context, email stri
 		slog.ErrorContext(ctx, "failed to send passwordless email",
 			"error", err,
 			"email", redaction.Redact(email))
-		return errors.NewUnexpected("failed to start passwordless flow", err)
+		return passwordlessError("failed to start passwordless flow", err)
 	}
 
 	slog.DebugContext(ctx, "passwordless flow started successfully",
@@ -95,7 +124,7 @@ func (e *emailLinkingFlow) ExchangeOTPForToken(ctx context.Context, email, otp s
 		slog.ErrorContext(ctx, "failed to exchange OTP for token",
 			"error", err,
 			"email", redaction.Redact(email))
-		return nil, errors.NewUnexpected("failed to exchange OTP for token", err)
+		return nil, passwordlessError("failed to exchange OTP for token", err)
 	}
 
 	slog.DebugContext(ctx, "OTP exchange successful",
@@ -18,6 +18,8 @@ import (
 type mockPasswordlessFlow struct {
 	sendEmailFunc      func(ctx context.Context, request passwordless.SendEmailRequest) (*passwordless.SendEmailResponse, error)
 	loginWithEmailFunc func(ctx context.Context, request passwordless.LoginWithEmailRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error)
+	sendSMSFunc        func(ctx context.Context, request passwordless.SendSMSRequest) (*passwordless.SendSMSResponse, error)
+	loginWithSMSFunc   func(ctx context.Context, request passwordless.LoginWithSMSRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error)
 }
 
 func (m *mockPasswordlessFlow) SendEmail(ctx context.Context, request passwordless.SendEmailRequest) (*passwordless.SendEmailResponse, error) {
@@ -34,6 +36,20 @@ func (m *mockPasswordlessFlow) LoginWithEmail(ctx context.Context, request passw
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockPasswordlessFlow) SendSMS(ctx context.Context, request passwordless.SendSMSRequest) (*passwordless.SendSMSResponse, error) {
+	if m.sendSMSFunc != nil {
+		return m.sendSMSFunc(ctx, request)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockPasswordlessFlow) LoginWithSMS(ctx context.Context, request passwordless.LoginWithSMSRequest, options oauth.IDTokenValidationOptions) (*oauth.TokenSet, error) {
+	if m.loginWithSMSFunc != nil {
+		return m.loginWithSMSFunc(ctx, request, options)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func TestEmailLinkingFlow_StartPasswordlessFlow(t *testing.T) {
 	ctx := context.Background()
 
@@ -21,13 +21,31 @@ const (
 	emailAuthenticationFilter            = "email"
 )
 
+const (
+	// DefaultSearchMaxPages is the default maximum number of pages read by a search
+	DefaultSearchMaxPages = 5
+
+	// MaxSearchPages is the maximum number of pages of a search, the search engine returns
+	// at most 1000 users per query
+	MaxSearchPages = 1000 / searchPerPage
+
+	// searchPerPage is the number of users per page of the search engine
+	searchPerPage = 50
+)
+
 var (
 	// criteriaEndpointMapping is a map of criteria types and their corresponding API endpoints
-	criteriaEndpointMapping = map[string]string{
+	criteriaEndpointMapping = map[constants.CriteriaType]string{
 		constants.CriteriaTypeEmail:          "users-by-email?email=%s",
 		constants.CriteriaTypeUsername:       `users?q=identities.user_id:%s&search_engine=v3`,
 		constants.CriteriaTypeAlternateEmail: `users?q=identities.profileData.email:%s&search_engine=v3`,
 	}
+
+	// paginatedCriteria are the criteria searched with the search engine, its results are paginated
+	paginatedCriteria = map[constants.CriteriaType]bool{
+		constants.CriteriaTypeUsername:       true,
+		constants.CriteriaTypeAlternateEmail: true,
+	}
 )
 
 type userFilterer interface {
@@ -152,7 +170,7 @@ func (a *alternateEmailFilter) Filter(ctx context.Context, auth0User *Auth0User)
 
 // newUserFilterer creates a new user filterer based on the criteria type
 // each filter might have a different way to filter the user, so we need to return the arguments and the filter function
-func newUserFilterer(criteriaType string, user *model.User) userFilterer {
+func newUserFilterer(criteriaType constants.CriteriaType, user *model.User) userFilterer {
 
 	switch criteriaType {
 
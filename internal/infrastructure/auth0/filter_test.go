@@ -25,7 +25,7 @@ func Test_newUserFilterer(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		criteriaType string
+		criteriaType constants.CriteriaType
 		want         userFilterer
 	}{
 		{
@@ -664,15 +664,9 @@ func Test_alternateEmailFilter_Filter(t *testing.T) {
 }
 
 func Test_criteriaEndpointMapping(t *testing.T) {
-	// Test that all expected criteria types have endpoints defined
-	expectedCriteria := []string{
-		constants.CriteriaTypeEmail,
-		constants.CriteriaTypeUsername,
-		constants.CriteriaTypeAlternateEmail,
-	}
-
-	for _, criteria := range expectedCriteria {
-		t.Run("has endpoint for "+criteria, func(t *testing.T) {
+	// Test that all criteria types have endpoints defined
+	for _, criteria := range constants.CriteriaTypes() {
+		t.Run("has endpoint for "+string(criteria), func(t *testing.T) {
 			endpoint, exists := criteriaEndpointMapping[criteria]
 			assert.True(t, exists, "endpoint should exist for criteria: %s", criteria)
 			assert.NotEmpty(t, endpoint, "endpoint should not be empty for criteria: %s", criteria)
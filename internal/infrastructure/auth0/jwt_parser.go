@@ -10,10 +10,12 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
 	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
@@ -23,10 +25,24 @@ type JWTVerificationConfig struct {
 	PublicKey *rsa.PublicKey
 	// ExpectedIssuer is the expected JWT issuer (e.g., "https://your-domain.auth0.com/")
 	ExpectedIssuer string
-	// ExpectedAudience is the expected JWT audience
-	ExpectedAudience string
+	// ExpectedAudiences are the JWT audiences accepted, the token must carry one of them
+	ExpectedAudiences []string
 	// JWKSURL is the URL to fetch JSON Web Key Set (optional, alternative to PublicKey)
 	JWKSURL string
+	// OIDC verifies the tokens of several trusted issuers instead of the key above, optional
+	OIDC *oidc.Verifier
+
+	// canary compares a candidate verifier to this one on a sample of the tokens, optional
+	canary *jwtparser.Canary
+
+	// mu guards the PublicKey replaced by the JWKS refreshes
+	mu sync.RWMutex
+}
+
+// withCanary verifies a sample of the tokens with the candidate too, the disagreements are
+// logged and counted but the result of this configuration is always the one returned
+func (j *JWTVerificationConfig) withCanary(candidate *JWTVerificationConfig, opts ...jwtparser.CanaryOption) {
+	j.canary = jwtparser.NewCanary(j.verify, candidate.verify, opts...)
 }
 
 // JWTVerify verifies a JWT token with the specified required scope
@@ -37,15 +53,31 @@ func (j *JWTVerificationConfig) JWTVerify(ctx context.Context, token string, req
 		return nil, errors.NewValidation("JWT verification configuration is required")
 	}
 
+	if j.canary != nil {
+		return j.canary.Verify(ctx, token, requiredScope...)
+	}
+	return j.verify(ctx, token, requiredScope...)
+}
+
+// verify verifies the token with the key, issuer and audiences of the configuration
+func (j *JWTVerificationConfig) verify(ctx context.Context, token string, requiredScope ...string) (*jwtparser.Claims, error) {
+	if j.OIDC != nil {
+		return j.OIDC.Verify(ctx, token, requiredScope...)
+	}
+
+	j.mu.RLock()
+	publicKey := j.PublicKey
+	j.mu.RUnlock()
+
 	// Configure JWT parsing options with signature verification
 	opts := &jwtparser.ParseOptions{
 		RequireExpiration: true,
 		AllowBearerPrefix: true,
 		RequireSubject:    true,
 		VerifySignature:   true,
-		SigningKey:        j.PublicKey,
+		SigningKey:        publicKey,
 		ExpectedIssuer:    j.ExpectedIssuer,
-		ExpectedAudience:  j.ExpectedAudience,
+		ExpectedAudiences: j.ExpectedAudiences,
 	}
 
 	if len(requiredScope) > 0 {
@@ -72,11 +104,35 @@ func (j *JWTVerificationConfig) JWTVerify(ctx context.Context, token string, req
 	return claims, nil
 }
 
+// refresh fetches the JWKS of the configuration again and returns the number of signing keys loaded,
+// the key of a configuration without JWKS can't be refreshed
+func (j *JWTVerificationConfig) refresh(ctx context.Context, domain string, httpClient *httpclient.Client) (int, error) {
+	if j.OIDC != nil {
+		return j.OIDC.Refresh(ctx)
+	}
+	if j.JWKSURL == "" {
+		return 0, errors.NewUnexpected("the JWT verification key isn't loaded from a JWKS")
+	}
+
+	fresh, err := newJWTVerificationConfigFromJWKS(ctx, j.JWKSURL, domain, httpClient)
+	if err != nil {
+		return 0, err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.PublicKey = fresh.PublicKey
+	return 1, nil
+}
+
 // NewJWTVerificationConfig creates a JWT verification configuration
 func NewJWTVerificationConfig(ctx context.Context, domain string, httpClient *httpclient.Client) (*JWTVerificationConfig, error) {
 	// Try to load from JWKS URL first (recommended for Auth0)
-	jwksURL := fmt.Sprintf("https://%s/.well-known/jwks.json", domain)
+	return newJWTVerificationConfigFromJWKS(ctx, fmt.Sprintf("https://%s/.well-known/jwks.json", domain), domain, httpClient)
+}
 
+// newJWTVerificationConfigFromJWKS creates a JWT verification configuration for the tokens of the
+// domain, signed with the first RSA signing key of the JWKS
+func newJWTVerificationConfigFromJWKS(ctx context.Context, jwksURL, domain string, httpClient *httpclient.Client) (*JWTVerificationConfig, error) {
 	// Fetch JWKS from Auth0 using the existing httpclient
 	apiRequest := httpclient.NewAPIRequest(
 		httpClient,
@@ -126,16 +182,41 @@ func NewJWTVerificationConfig(ctx context.Context, domain string, httpClient *ht
 			slog.InfoContext(ctx, "JWT signature verification enabled",
 				"issuer", expectedIssuer,
 				"audience", expectedAudience,
+				"jwks_url", jwksURL,
 				"key_id", key.Kid)
 
 			return &JWTVerificationConfig{
-				PublicKey:        publicKey,
-				ExpectedIssuer:   expectedIssuer,
-				ExpectedAudience: expectedAudience,
-				JWKSURL:          jwksURL,
+				PublicKey:         publicKey,
+				ExpectedIssuer:    expectedIssuer,
+				ExpectedAudiences: []string{expectedAudience},
+				JWKSURL:           jwksURL,
 			}, nil
 		}
 	}
 
 	return nil, errors.NewUnexpected("no suitable RSA key found in JWKS for signature verification")
 }
+
+// newOIDCVerificationConfig creates a JWT verification configuration for the tokens of the tenant
+// and of the trusted issuers (e.g. the custom domains of the tenant), discovered with OpenID
+// Connect. The tenant is trusted with the Management API audience unless it's listed.
+func newOIDCVerificationConfig(ctx context.Context, domain string, trustedIssuers []oidc.Issuer, httpClient *httpclient.Client) (*JWTVerificationConfig, error) {
+	tenant := oidc.Issuer{
+		URL:       fmt.Sprintf("https://%s/", domain),
+		Audiences: []string{fmt.Sprintf("https://%s/api/v2/", domain)},
+	}
+	issuers := []oidc.Issuer{tenant}
+	for _, issuer := range trustedIssuers {
+		if issuer.URL == tenant.URL {
+			issuers[0] = issuer
+			continue
+		}
+		issuers = append(issuers, issuer)
+	}
+
+	verifier, err := oidc.NewVerifier(ctx, httpClient, issuers)
+	if err != nil {
+		return nil, err
+	}
+	return &JWTVerificationConfig{OIDC: verifier}, nil
+}
@@ -7,13 +7,19 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
 )
 
 func TestJWTVerification(t *testing.T) {
@@ -26,9 +32,9 @@ func TestJWTVerification(t *testing.T) {
 
 	// Create JWT verification config
 	jwtVerify := &JWTVerificationConfig{
-		PublicKey:        publicKey,
-		ExpectedIssuer:   "https://test.auth0.com/",
-		ExpectedAudience: "https://test.auth0.com/api/v2/",
+		PublicKey:         publicKey,
+		ExpectedIssuer:    "https://test.auth0.com/",
+		ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 	}
 
 	tests := []struct {
@@ -106,9 +112,9 @@ func TestMetadataLookupWithJWTVerification(t *testing.T) {
 
 	// Create JWT verification config
 	jwtConfig := &JWTVerificationConfig{
-		PublicKey:        publicKey,
-		ExpectedIssuer:   "https://test.auth0.com/",
-		ExpectedAudience: "https://test.auth0.com/api/v2/",
+		PublicKey:         publicKey,
+		ExpectedIssuer:    "https://test.auth0.com/",
+		ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 	}
 
 	// Create Auth0 config
@@ -385,3 +391,87 @@ func TestMetadataLookupWithoutJWTVerificationConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDCVerificationConfig(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	publicKey, err := jwk.FromRaw(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to create JWK: %v", err)
+	}
+	_ = publicKey.Set(jwk.KeyIDKey, "tenant-key")
+
+	// the tenant and its custom domain share the signing keys, the custom domain is under /custom/
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL + "/", "jwks_uri": server.URL + "/.well-known/jwks.json"})
+	})
+	mux.HandleFunc("GET /custom/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL + "/custom/", "jwks_uri": server.URL + "/.well-known/jwks.json"})
+	})
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []any{publicKey}})
+	})
+	server = httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetries = 0
+	httpConfig.Transport = server.Client().Transport
+	domain := strings.TrimPrefix(server.URL, "https://")
+
+	jwtVerify, err := newOIDCVerificationConfig(context.Background(), domain, []oidc.Issuer{
+		{URL: server.URL + "/custom/", Audiences: []string{"https://api.example.org/"}},
+	}, httpclient.NewClient(httpConfig))
+	if err != nil {
+		t.Fatalf("newOIDCVerificationConfig() unexpected error: %v", err)
+	}
+
+	sign := func(issuer, audience string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":   "auth0|123",
+			"iss":   issuer,
+			"aud":   audience,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "update:current_user_metadata",
+		})
+		token.Header["kid"] = "tenant-key"
+		signed, errSign := token.SignedString(privateKey)
+		if errSign != nil {
+			t.Fatalf("Failed to sign token: %v", errSign)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "tenant token", token: sign(server.URL+"/", server.URL+"/api/v2/")},
+		{name: "custom domain token", token: sign(server.URL+"/custom/", "https://api.example.org/")},
+		{name: "tenant token for another audience", token: sign(server.URL+"/", "https://api.example.org/"), wantErr: true},
+		{name: "untrusted issuer", token: sign("https://evil.example.com/", "https://api.example.org/"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := jwtVerify.JWTVerify(context.Background(), tt.token, constants.UserUpdateMetadataRequiredScope)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("JWTVerify() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JWTVerify() unexpected error: %v", err)
+			}
+			if claims.Subject != "auth0|123" {
+				t.Errorf("JWTVerify() subject = %q, want auth0|123", claims.Subject)
+			}
+		})
+	}
+}
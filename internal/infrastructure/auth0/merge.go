@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// linkAccountPayload links a secondary account by its identity, used with the M2M token
+type linkAccountPayload struct {
+	Provider string `json:"provider"`
+	UserID   string `json:"user_id"`
+}
+
+// splitUserID splits an Auth0 user id of the form provider|id
+func splitUserID(userID string) (provider, id string, err error) {
+	provider, id, found := strings.Cut(strings.TrimSpace(userID), "|")
+	if !found || provider == "" || id == "" {
+		return "", "", errors.NewValidation(fmt.Sprintf("invalid user_id: %q", redaction.Redact(userID)))
+	}
+	return provider, id, nil
+}
+
+// MergeUsers merges the secondary account into the primary one using the M2M token, the caller
+// is responsible for verifying the ownership of both accounts.
+//
+// Auth0 drops the secondary account metadata once linked, so the merged metadata is stored first:
+// when the link fails the merge can be retried and produces the same result.
+func (u *userReaderWriter) MergeUsers(ctx context.Context, merge *model.UserMerge) (*model.User, error) {
+
+	if merge == nil || merge.Primary == nil || merge.Secondary == nil {
+		return nil, errors.NewValidation("primary and secondary accounts are required to merge")
+	}
+	if strings.TrimSpace(merge.Primary.UserID) == "" {
+		return nil, errors.NewValidation("user_id is required to merge")
+	}
+
+	provider, id, errSplit := splitUserID(merge.Secondary.UserID)
+	if errSplit != nil {
+		return nil, errSplit
+	}
+
+	merged := &model.User{UserMetadata: merge.Metadata}
+	if merge.Metadata != nil {
+		updated, errPatch := u.patchUserMetadata(ctx, merge.Primary.UserID, merge.Metadata, "update merged user metadata")
+		if errPatch != nil {
+			return nil, errPatch
+		}
+		merged = updated
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return nil, errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodPost),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s/identities", u.config.Domain, url.PathEscape(merge.Primary.UserID))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("link secondary account to merge"),
+		httpclient.WithBody(linkAccountPayload{Provider: provider, UserID: id}),
+	)
+
+	var linkedIdentities []any
+	statusCode, errCall := apiRequest.Call(ctx, &linkedIdentities)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to link secondary account in Auth0",
+			"error", errCall,
+			"status_code", statusCode,
+			"primary", redaction.Redact(merge.Primary.UserID),
+			"secondary", redaction.Redact(merge.Secondary.UserID),
+		)
+		return nil, errors.NewUnexpected("failed to link accounts", errCall)
+	}
+
+	merged.UserID = merge.Primary.UserID
+	return merged, nil
+}
@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitUserID(t *testing.T) {
+	tests := []struct {
+		userID       string
+		wantProvider string
+		wantID       string
+		wantErr      bool
+	}{
+		{userID: "auth0|abc123", wantProvider: "auth0", wantID: "abc123"},
+		{userID: "google-oauth2|1045", wantProvider: "google-oauth2", wantID: "1045"},
+		{userID: "oauth2|github|42", wantProvider: "oauth2", wantID: "github|42"},
+		{userID: "abc123", wantErr: true},
+		{userID: "auth0|", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			provider, id, err := splitUserID(tt.userID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProvider, provider)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestUserReaderWriter_MergeUsers_Validation(t *testing.T) {
+	ctx := context.Background()
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	_, err := u.MergeUsers(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = u.MergeUsers(ctx, &model.UserMerge{
+		Primary:   &model.User{UserID: "auth0|primary"},
+		Secondary: &model.User{UserID: "secondary"},
+	})
+	assert.Error(t, err)
+}
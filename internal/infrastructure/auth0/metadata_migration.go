@@ -0,0 +1,188 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/metadatamigrate"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// migrationPerPage is the number of users per page of the metadata migrations
+const migrationPerPage = 100
+
+// migrationUser is a user of the metadata migrations, with its raw metadata
+type migrationUser struct {
+	UserID       string         `json:"user_id"`
+	CreatedAt    string         `json:"created_at"`
+	UserMetadata map[string]any `json:"user_metadata"`
+}
+
+// migrationPosition is the position of a page of the metadata migrations. The search engine
+// returns at most 1000 users per query, so the users are listed by creation time: each page is
+// a query of the users created at or after the last one listed, skipping those already listed.
+type migrationPosition struct {
+	CreatedAt string `json:"created_at"`
+	Skip      int    `json:"skip"`
+}
+
+func (p migrationPosition) encode() string {
+	data, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeMigrationPosition(position string) (migrationPosition, error) {
+	var p migrationPosition
+	if position == "" {
+		return p, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(position)
+	if err == nil {
+		err = json.Unmarshal(data, &p)
+	}
+	if err != nil || p.CreatedAt == "" || p.Skip < 0 {
+		return p, errors.NewValidation("invalid migration position")
+	}
+	return p, nil
+}
+
+// MetadataMigrationClient lists and patches the raw metadata of the users of the tenant with the
+// M2M token, for the metadata migrations run outside of the service
+type MetadataMigrationClient struct {
+	domain        string
+	tokenManager  *TokenManager
+	httpClient    *httpclient.Client
+	errorResponse *ErrorResponse
+}
+
+// ListUsersMetadata returns the page of the users at the position, by creation time
+func (c *MetadataMigrationClient) ListUsersMetadata(ctx context.Context, position string) ([]metadatamigrate.User, string, error) {
+	current, errPosition := decodeMigrationPosition(position)
+	if errPosition != nil {
+		return nil, "", errPosition
+	}
+	page, skip := current.Skip/migrationPerPage, current.Skip%migrationPerPage
+	if (page+1)*migrationPerPage > 1000 {
+		return nil, "", errors.NewUnexpected("too many users created at the same time to be listed")
+	}
+
+	query := url.Values{}
+	query.Set("search_engine", "v3")
+	query.Set("sort", "created_at:1")
+	query.Set("fields", "user_id,created_at,user_metadata")
+	query.Set("include_fields", "true")
+	query.Set("per_page", fmt.Sprint(migrationPerPage))
+	query.Set("page", fmt.Sprint(page))
+	if current.CreatedAt != "" {
+		query.Set("q", fmt.Sprintf(`created_at:["%s" TO *]`, current.CreatedAt))
+	}
+
+	m2mToken, errGetToken := c.tokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return nil, "", errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		c.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users?%s", c.domain, query.Encode())),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("list users metadata"),
+	)
+
+	var users []migrationUser
+	statusCode, errCall := apiRequest.Call(ctx, &users)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to list users from Auth0", "error", errCall, "status_code", statusCode)
+		return nil, "", httpclient.ErrorFromCall(statusCode, c.errorResponse.ErrorMessage(errCall.Error()), errCall)
+	}
+
+	if skip > len(users) {
+		skip = len(users)
+	}
+	listed := users[skip:]
+	result := make([]metadatamigrate.User, 0, len(listed))
+	for _, user := range listed {
+		result = append(result, metadatamigrate.User{UserID: user.UserID, Metadata: user.UserMetadata})
+	}
+
+	// a partial page is the last one
+	if len(users) < migrationPerPage {
+		return result, "", nil
+	}
+
+	// the next query starts at the creation time of the last user, skipping the users of that
+	// time already listed
+	last := users[len(users)-1].CreatedAt
+	next := migrationPosition{CreatedAt: last}
+	if last == current.CreatedAt {
+		next.Skip = current.Skip - skip
+	}
+	for _, user := range users {
+		if user.CreatedAt == last {
+			next.Skip++
+		}
+	}
+	return result, next.encode(), nil
+}
+
+// PatchUserMetadata merges the patch into the metadata of the user, a nil value removes the field
+func (c *MetadataMigrationClient) PatchUserMetadata(ctx context.Context, userID string, patch map[string]any) error {
+	if strings.TrimSpace(userID) == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	m2mToken, errGetToken := c.tokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		c.httpClient,
+		httpclient.WithMethod(http.MethodPatch),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s", c.domain, url.PathEscape(userID))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("migrate user metadata"),
+		httpclient.WithBody(map[string]any{"user_metadata": patch}),
+	)
+
+	statusCode, errCall := apiRequest.Call(ctx, nil)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to patch user metadata in Auth0",
+			"error", errCall,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(userID),
+		)
+		return httpclient.ErrorFromCall(statusCode, c.errorResponse.ErrorMessage(errCall.Error()), errCall)
+	}
+	return nil
+}
+
+// NewMetadataMigrationClient creates the metadata migration client of the tenant, the M2M
+// credentials are loaded from the environment as for the service
+func NewMetadataMigrationClient(ctx context.Context, httpConfig httpclient.Config, config Config) (*MetadataMigrationClient, error) {
+	if config.Domain == "" {
+		return nil, errors.NewValidation("the Auth0 domain is required")
+	}
+	tokenManager, err := NewM2MTokenManager(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create M2M token manager: %w", err)
+	}
+	return &MetadataMigrationClient{
+		domain:        config.Domain,
+		tokenManager:  tokenManager,
+		httpClient:    httpclient.NewClient(httpConfig),
+		errorResponse: NewErrorResponse(),
+	}, nil
+}
@@ -0,0 +1,102 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+func TestMetadataMigrationClient_ListUsersMetadata(t *testing.T) {
+	// 250 users, created by bursts of 70 at the same time
+	var users []migrationUser
+	for i := range 250 {
+		users = append(users, migrationUser{
+			UserID:       fmt.Sprintf("auth0|%03d", i),
+			CreatedAt:    fmt.Sprintf("2026-01-01T00:00:%02d.000Z", i/70),
+			UserMetadata: map[string]any{"title": "engineer"},
+		})
+	}
+
+	var patched map[string]any
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer m2m-token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			assert.Equal(t, "created_at:1", query.Get("sort"))
+			from := ""
+			if q := query.Get("q"); q != "" {
+				from = strings.TrimSuffix(strings.TrimPrefix(q, `created_at:["`), `" TO *]`)
+			}
+			var matching []migrationUser
+			for _, user := range users {
+				if user.CreatedAt >= from {
+					matching = append(matching, user)
+				}
+			}
+			page, _ := strconv.Atoi(query.Get("page"))
+			perPage, _ := strconv.Atoi(query.Get("per_page"))
+			start, end := min(page*perPage, len(matching)), min((page+1)*perPage, len(matching))
+			_ = json.NewEncoder(w).Encode(matching[start:end])
+		case http.MethodPatch:
+			assert.Equal(t, "/api/v2/users/auth0|001", r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.Transport = server.Client().Transport
+	client := &MetadataMigrationClient{
+		domain:        strings.TrimPrefix(server.URL, "https://"),
+		tokenManager:  &TokenManager{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "m2m-token"})},
+		httpClient:    httpclient.NewClient(httpConfig),
+		errorResponse: NewErrorResponse(),
+	}
+	ctx := context.Background()
+
+	var (
+		listed   []string
+		position string
+		pages    int
+	)
+	for {
+		page, next, err := client.ListUsersMetadata(ctx, position)
+		require.NoError(t, err)
+		for _, user := range page {
+			listed = append(listed, user.UserID)
+			assert.Equal(t, map[string]any{"title": "engineer"}, user.Metadata)
+		}
+		pages++
+		require.Less(t, pages, 10)
+		if next == "" {
+			break
+		}
+		position = next
+	}
+
+	require.Len(t, listed, len(users), "every user is listed once")
+	assert.True(t, sort.StringsAreSorted(listed))
+
+	_, _, err := client.ListUsersMetadata(ctx, "not a position")
+	assert.Error(t, err)
+
+	require.NoError(t, client.PatchUserMetadata(ctx, "auth0|001", map[string]any{"job_title": "engineer", "title": nil}))
+	assert.Equal(t, map[string]any{"user_metadata": map[string]any{"job_title": "engineer", "title": nil}}, patched)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 )
@@ -61,6 +62,38 @@ type Auth0UserMetadata struct {
 	PhoneNumber   *string `json:"phone_number"`
 	TShirtSize    *string `json:"t_shirt_size"`
 	Zoneinfo      *string `json:"zoneinfo"`
+
+	OrganizationVerified *bool `json:"organization_verified"`
+}
+
+// alternateEmails returns the emails of the user besides the primary one: the alternate_email
+// entries and the emails of the linked identities (passwordless email or social), without
+// duplicates. An email is verified when any of its sources verified it.
+func (u *Auth0User) alternateEmails() []model.Email {
+	var alternateEmails []model.Email
+	seen := make(map[string]int)
+	add := func(email string, verified bool) {
+		key := strings.ToLower(strings.TrimSpace(email))
+		if key == "" || key == strings.ToLower(u.Email) {
+			return
+		}
+		if i, ok := seen[key]; ok {
+			alternateEmails[i].Verified = alternateEmails[i].Verified || verified
+			return
+		}
+		seen[key] = len(alternateEmails)
+		alternateEmails = append(alternateEmails, model.Email{Email: email, Verified: verified})
+	}
+
+	for _, alternateEmail := range u.AlternateEmail {
+		add(alternateEmail.Email, alternateEmail.EmailVerified)
+	}
+	for _, identity := range u.Identities {
+		if identity.ProfileData != nil {
+			add(identity.ProfileData.Email, identity.ProfileData.EmailVerified)
+		}
+	}
+	return alternateEmails
 }
 
 // ToUser converts an Auth0User to a User
@@ -82,16 +115,9 @@ func (u *Auth0User) ToUser() *model.User {
 			PhoneNumber:   u.UserMetadata.PhoneNumber,
 			TShirtSize:    u.UserMetadata.TShirtSize,
 			Zoneinfo:      u.UserMetadata.Zoneinfo,
-		}
-	}
 
-	var alternateEmails []model.Email
-	for _, alternateEmail := range u.AlternateEmail {
-		alternateEmail := model.Email{
-			Email:    alternateEmail.Email,
-			Verified: alternateEmail.EmailVerified,
+			OrganizationVerified: u.UserMetadata.OrganizationVerified,
 		}
-		alternateEmails = append(alternateEmails, alternateEmail)
 	}
 
 	var identities []model.Identity
@@ -121,12 +147,13 @@ func (u *Auth0User) ToUser() *model.User {
 	}
 
 	return &model.User{
-		UserID:          u.UserID,
-		Username:        u.Username,
-		PrimaryEmail:    u.Email,
-		AlternateEmails: alternateEmails,
-		Identities:      identities,
-		UserMetadata:    meta,
+		UserID:               u.UserID,
+		Username:             u.Username,
+		PrimaryEmail:         u.Email,
+		PrimaryEmailVerified: u.EmailVerified,
+		AlternateEmails:      u.alternateEmails(),
+		Identities:           identities,
+		UserMetadata:         meta,
 	}
 }
 
@@ -42,6 +42,7 @@ func TestAuth0User_ToUser(t *testing.T) {
 				assert.Equal(t, "auth0|abc123", user.UserID)
 				assert.Equal(t, "johndoe", user.Username)
 				assert.Equal(t, "john@example.com", user.PrimaryEmail)
+				assert.True(t, user.PrimaryEmailVerified)
 
 				require.Len(t, user.Identities, 1)
 				id := user.Identities[0]
@@ -225,6 +226,28 @@ func TestAuth0User_ToUser(t *testing.T) {
 				assert.False(t, user.AlternateEmails[1].Verified)
 			},
 		},
+		{
+			name: "linked identities emails are alternate emails",
+			auth0User: Auth0User{
+				UserID: "auth0|abc123",
+				Email:  "john@example.com",
+				AlternateEmail: []Auth0ProfileData{
+					{Email: "john@personal.example", EmailVerified: false},
+				},
+				Identities: []Auth0Identity{
+					{Provider: "auth0", UserID: "abc123"},
+					{Provider: "email", UserID: "email-1", ProfileData: &Auth0ProfileData{Email: "John@Personal.example", EmailVerified: true}},
+					{Provider: "google-oauth2", UserID: "google-1", IsSocial: true, ProfileData: &Auth0ProfileData{Email: "john@gmail.com", EmailVerified: true}},
+					{Provider: "github", UserID: "github-1", IsSocial: true, ProfileData: &Auth0ProfileData{Email: "JOHN@example.com", EmailVerified: true}},
+				},
+			},
+			validate: func(t *testing.T, user *model.User) {
+				assert.Equal(t, []model.Email{
+					{Email: "john@personal.example", Verified: true},
+					{Email: "john@gmail.com", Verified: true},
+				}, user.AlternateEmails)
+			},
+		},
 	}
 
 	for _, tt := range tests {
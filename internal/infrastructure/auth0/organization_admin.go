@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// DefaultOrganizationAdminClaim is the custom claim listing the organizations the user administers
+const DefaultOrganizationAdminClaim = "https://sso.linuxfoundation.org/claims/org_admin"
+
+// claimOrganizations reads the organizations from the claim, it can be a single string or a list
+func claimOrganizations(value any) []string {
+	var organizations []string
+	switch v := value.(type) {
+	case string:
+		organizations = append(organizations, v)
+	case []string:
+		organizations = append(organizations, v...)
+	case []any:
+		for _, item := range v {
+			if organization, ok := item.(string); ok {
+				organizations = append(organizations, organization)
+			}
+		}
+	}
+
+	result := organizations[:0]
+	for _, organization := range organizations {
+		if strings.TrimSpace(organization) != "" {
+			result = append(result, organization)
+		}
+	}
+	return result
+}
+
+// OrganizationAdminLookup verifies the admin token and returns the organizations from the admin claim
+func (u *userReaderWriter) OrganizationAdminLookup(ctx context.Context, token string) (*model.OrganizationAdmin, error) {
+
+	claims, errJwtVerify := u.config.JWTVerificationConfig.JWTVerify(ctx, token, constants.UserUpdateMetadataRequiredScope)
+	if errJwtVerify != nil {
+		return nil, errJwtVerify
+	}
+
+	claim := u.config.OrganizationAdminClaim
+	if claim == "" {
+		claim = DefaultOrganizationAdminClaim
+	}
+
+	organizations := claimOrganizations(claims.Raw[claim])
+	if len(organizations) == 0 {
+		slog.WarnContext(ctx, "organization admin claim is missing",
+			"user_id", redaction.Redact(claims.Subject),
+			"claim", claim,
+		)
+		return nil, errors.NewForbidden("user is not an organization admin")
+	}
+
+	return &model.OrganizationAdmin{
+		UserID:        claims.Subject,
+		Organizations: organizations,
+	}, nil
+}
+
+// UpdateUserAsOrganizationAdmin patches the member metadata using the M2M token,
+// the caller is responsible for the authorization checks
+func (u *userReaderWriter) UpdateUserAsOrganizationAdmin(ctx context.Context, user *model.User) (*model.User, error) {
+
+	if user == nil || user.UserID == "" {
+		return nil, errors.NewValidation("user_id is required to update user")
+	}
+	if user.UserMetadata == nil {
+		return nil, errors.NewValidation("user_metadata is required for update")
+	}
+
+	return u.patchUserMetadata(ctx, user.UserID, user.UserMetadata, "update user metadata as organization admin")
+}
+
+// EnrichUserMetadata patches the metadata of an enrichment job using the M2M token, the caller is
+// responsible for skipping the fields entered by a person
+func (u *userReaderWriter) EnrichUserMetadata(ctx context.Context, user *model.User) (*model.User, error) {
+
+	if user == nil || user.UserID == "" {
+		return nil, errors.NewValidation("user_id is required to update user")
+	}
+	if user.UserMetadata == nil {
+		return nil, errors.NewValidation("user_metadata is required for update")
+	}
+
+	return u.patchUserMetadata(ctx, user.UserID, user.UserMetadata, "enrich user metadata")
+}
+
+// patchUserMetadata patches the user metadata using the M2M token
+func (u *userReaderWriter) patchUserMetadata(ctx context.Context, userID string, metadata *model.UserMetadata, description string) (*model.User, error) {
+
+	if strings.TrimSpace(u.config.Domain) == "" {
+		return nil, errors.NewValidation("Auth0 domain configuration is missing")
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return nil, errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodPatch),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s", u.config.Domain, userID)),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription(description),
+		httpclient.WithBody(userUpdateRequest{UserMetadata: metadata}),
+	)
+
+	var auth0Response struct {
+		UserMetadata *model.UserMetadata `json:"user_metadata,omitempty"`
+	}
+
+	statusCode, errCall := apiRequest.Call(ctx, &auth0Response)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "failed to update user in Auth0",
+			"error", errCall,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(userID),
+		)
+		return nil, errors.NewUnexpected("failed to update user in Auth0", errCall)
+	}
+
+	return &model.User{
+		UserMetadata: auth0Response.UserMetadata,
+	}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserReaderWriter_OrganizationAdminLookup(t *testing.T) {
+	ctx := context.Background()
+	jwtConfig, privateKey := createTestJWTVerificationConfig(t)
+
+	createToken := func(extra jwt.MapClaims) string {
+		claims := jwt.MapClaims{
+			"sub":   "auth0|admin",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "update:current_user_metadata",
+			"iss":   "https://test.auth0.com/",
+			"aud":   "https://test.auth0.com/api/v2/",
+		}
+		for key, value := range extra {
+			claims[key] = value
+		}
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	tests := []struct {
+		name              string
+		claim             string
+		token             string
+		wantOrganizations []string
+		wantForbidden     bool
+		wantError         bool
+	}{
+		{
+			name:              "list claim with the default name",
+			token:             createToken(jwt.MapClaims{DefaultOrganizationAdminClaim: []string{"CNCF", "", "OpenSSF"}}),
+			wantOrganizations: []string{"CNCF", "OpenSSF"},
+		},
+		{
+			name:              "string claim with a custom name",
+			claim:             "org_admin",
+			token:             createToken(jwt.MapClaims{"org_admin": "CNCF"}),
+			wantOrganizations: []string{"CNCF"},
+		},
+		{
+			name:          "missing claim",
+			token:         createToken(nil),
+			wantForbidden: true,
+		},
+		{
+			name:      "invalid token",
+			token:     "not-a-token",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &userReaderWriter{config: Config{
+				JWTVerificationConfig:  jwtConfig,
+				OrganizationAdminClaim: tt.claim,
+			}}
+
+			admin, err := u.OrganizationAdminLookup(ctx, tt.token)
+			switch {
+			case tt.wantForbidden:
+				require.Error(t, err)
+				_, isForbidden := err.(errors.Forbidden)
+				assert.True(t, isForbidden, "expected forbidden error, got %T", err)
+			case tt.wantError:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "auth0|admin", admin.UserID)
+				assert.Equal(t, tt.wantOrganizations, admin.Organizations)
+			}
+		})
+	}
+}
+
+func TestUserReaderWriter_UpdateUserAsOrganizationAdmin_Validation(t *testing.T) {
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	_, err := u.UpdateUserAsOrganizationAdmin(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestUserReaderWriter_EnrichUserMetadata_Validation(t *testing.T) {
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	_, err := u.EnrichUserMetadata(context.Background(), nil)
+	assert.Error(t, err)
+	_, err = u.EnrichUserMetadata(context.Background(), &model.User{UserID: "auth0|jane"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/auth0/go-auth0/authentication/oauth"
+	"github.com/auth0/go-auth0/authentication/passwordless"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// StartPhonePasswordlessFlow sends an OTP to the phone number with the sms passwordless connection.
+// Auth0 delivers the code with the phone provider of the connection, the WhatsApp channel requires
+// a provider sending on WhatsApp (like a Twilio WhatsApp sender or a custom phone provider).
+func (e *emailLinkingFlow) StartPhonePasswordlessFlow(ctx context.Context, phoneNumber string) error {
+
+	if e == nil || e.flow == nil {
+		return errors.NewUnexpected("passwordless flow not configured")
+	}
+
+	request := passwordless.SendSMSRequest{
+		PhoneNumber: phoneNumber,
+		Connection:  "sms",
+	}
+
+	response, err := e.flow.SendSMS(ctx, request)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send passwordless SMS",
+			"error", err,
+			"phone_number", redaction.Redact(phoneNumber))
+		return passwordlessError("failed to start passwordless flow", err)
+	}
+
+	slog.DebugContext(ctx, "phone passwordless flow started successfully",
+		"phone_number", redaction.Redact(response.PhoneNumber),
+		"passwordless_flow_id", response.ID)
+
+	return nil
+}
+
+// ExchangePhoneOTPForToken exchanges the OTP sent to the phone number for the tokens of its
+// passwordless identity, the ID token is linked to the user by the identity linking
+func (e *emailLinkingFlow) ExchangePhoneOTPForToken(ctx context.Context, phoneNumber, otp string) (*TokenResponse, error) {
+
+	if e == nil || e.flow == nil {
+		return nil, errors.NewUnexpected("passwordless flow not configured")
+	}
+
+	request := passwordless.LoginWithSMSRequest{
+		PhoneNumber: phoneNumber,
+		Code:        otp,
+		Realm:       "sms",
+		Scope:       "openid profile phone",
+	}
+
+	tokenSet, err := e.flow.LoginWithSMS(ctx, request, oauth.IDTokenValidationOptions{})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to exchange phone OTP for token",
+			"error", err,
+			"phone_number", redaction.Redact(phoneNumber))
+		return nil, passwordlessError("failed to exchange OTP for token", err)
+	}
+
+	return &TokenResponse{
+		AccessToken:  tokenSet.AccessToken,
+		IDToken:      tokenSet.IDToken,
+		TokenType:    tokenSet.TokenType,
+		ExpiresIn:    int64(tokenSet.ExpiresIn),
+		RefreshToken: tokenSet.RefreshToken,
+		Scope:        tokenSet.Scope,
+	}, nil
+}
+
+// SendVerification sends the OTP of the verification on its channel
+func (u *userReaderWriter) SendVerification(ctx context.Context, verification *model.Verification) error {
+	if verification == nil || verification.Recipient == "" {
+		return errors.NewValidation("verification recipient is required")
+	}
+
+	switch {
+	case verification.Channel == model.VerificationChannelEmail:
+		return u.SendVerificationAlternateEmail(ctx, verification.Recipient)
+	case verification.Channel.IsPhone():
+		if u.emailLinkingFlow == nil {
+			return errors.NewUnexpected("phone linking flow not configured")
+		}
+		return u.emailLinkingFlow.StartPhonePasswordlessFlow(ctx, verification.Recipient)
+	}
+	return errors.NewValidation("unsupported verification channel")
+}
+
+// Verify exchanges the OTP of the verification for the tokens of the passwordless identity of its recipient
+func (u *userReaderWriter) Verify(ctx context.Context, verification *model.Verification) (*model.AuthResponse, error) {
+	if verification == nil || verification.Recipient == "" || verification.OTP == "" {
+		return nil, errors.NewValidation("verification recipient and OTP are required")
+	}
+
+	switch {
+	case verification.Channel == model.VerificationChannelEmail:
+		return u.VerifyAlternateEmail(ctx, &model.Email{Email: verification.Recipient, OTP: verification.OTP})
+	case !verification.Channel.IsPhone():
+		return nil, errors.NewValidation("unsupported verification channel")
+	}
+
+	if u.emailLinkingFlow == nil {
+		return nil, errors.NewUnexpected("phone linking flow not configured")
+	}
+
+	tokenResp, errExchange := u.emailLinkingFlow.ExchangePhoneOTPForToken(ctx, verification.Recipient, verification.OTP)
+	if errExchange != nil {
+		return nil, errExchange
+	}
+
+	slog.DebugContext(ctx, "phone number verified successfully",
+		"phone_number", redaction.Redact(verification.Recipient),
+	)
+
+	return &model.AuthResponse{
+		AccessToken: tokenResp.AccessToken,
+		IDToken:     tokenResp.IDToken,
+		Scope:       tokenResp.Scope,
+		ExpiresIn:   int(tokenResp.ExpiresIn),
+		TokenType:   tokenResp.TokenType,
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/auth0/go-auth0/authentication"
+	"github.com/auth0/go-auth0/authentication/oauth"
+	"github.com/auth0/go-auth0/authentication/passwordless"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestUserReaderWriter_PhoneVerification(t *testing.T) {
+	ctx := context.Background()
+
+	var sent []passwordless.SendSMSRequest
+	flow := &mockPasswordlessFlow{
+		sendSMSFunc: func(_ context.Context, request passwordless.SendSMSRequest) (*passwordless.SendSMSResponse, error) {
+			sent = append(sent, request)
+			return &passwordless.SendSMSResponse{ID: "flow-id", PhoneNumber: request.PhoneNumber}, nil
+		},
+		loginWithSMSFunc: func(_ context.Context, request passwordless.LoginWithSMSRequest, _ oauth.IDTokenValidationOptions) (*oauth.TokenSet, error) {
+			assert.Equal(t, "sms", request.Realm)
+			assert.Equal(t, "+14155550123", request.PhoneNumber)
+			if request.Code != "123456" {
+				return nil, &authentication.Error{StatusCode: http.StatusForbidden, Err: "invalid_grant", Message: "Wrong phone number or verification code."}
+			}
+			return &oauth.TokenSet{AccessToken: "access-token", IDToken: "id-token", TokenType: "Bearer", ExpiresIn: 3600}, nil
+		},
+	}
+	u := &userReaderWriter{emailLinkingFlow: &emailLinkingFlow{flow: flow}}
+
+	for _, channel := range []model.VerificationChannel{model.VerificationChannelSMS, model.VerificationChannelWhatsApp} {
+		require.NoError(t, u.SendVerification(ctx, &model.Verification{Channel: channel, Recipient: "+14155550123"}))
+	}
+	require.Len(t, sent, 2)
+	assert.Equal(t, "sms", sent[0].Connection, "every phone channel is served by the sms connection")
+
+	response, err := u.Verify(ctx, &model.Verification{Channel: model.VerificationChannelSMS, Recipient: "+14155550123", OTP: "123456"})
+	require.NoError(t, err)
+	assert.Equal(t, "id-token", response.IDToken)
+	assert.Equal(t, 3600, response.ExpiresIn)
+
+	_, err = u.Verify(ctx, &model.Verification{Channel: model.VerificationChannelSMS, Recipient: "+14155550123", OTP: "000000"})
+	assert.Equal(t, errs.CodeForbidden, errs.Code(err), "a wrong code is rejected as a client error")
+
+	_, err = u.Verify(ctx, &model.Verification{Channel: "fax", Recipient: "+14155550123", OTP: "123456"})
+	assert.Equal(t, errs.CodeValidation, errs.Code(err))
+	assert.Equal(t, errs.CodeValidation, errs.Code(u.SendVerification(ctx, &model.Verification{Channel: model.VerificationChannelSMS})))
+}
+
+func TestPasswordlessError(t *testing.T) {
+	err := passwordlessError("failed to exchange OTP for token", &authentication.Error{StatusCode: http.StatusBadRequest})
+	assert.Equal(t, errs.CodeValidation, errs.Code(err))
+
+	err = passwordlessError("failed to exchange OTP for token", &authentication.Error{StatusCode: http.StatusServiceUnavailable})
+	assert.Equal(t, errs.CodeUnexpected, errs.Code(err))
+
+	err = passwordlessError("failed to exchange OTP for token", errors.New("connection reset"))
+	assert.Equal(t, errs.CodeUnexpected, errs.Code(err))
+	assert.Contains(t, err.Error(), "failed to exchange OTP for token")
+}
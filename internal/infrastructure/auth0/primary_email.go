@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// databaseProvider is the provider of the identities of the database connections
+const databaseProvider = "auth0"
+
+// primaryEmailUpdateRequest updates the email of the primary identity. The email was verified by the
+// service, so it's marked as verified and Auth0 doesn't send its own verification email.
+type primaryEmailUpdateRequest struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	VerifyEmail   bool   `json:"verify_email"`
+	Connection    string `json:"connection"`
+}
+
+// ChangePrimaryEmail changes the email of the primary identity of the user using the M2M token, the
+// caller is responsible for the verification of the email. Only the database users are supported,
+// the email of the social users is managed by their provider.
+func (u *userReaderWriter) ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error) {
+	if user == nil || strings.TrimSpace(user.UserID) == "" {
+		return nil, errors.NewValidation("user_id is required")
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !(&model.Email{Email: email}).IsValidEmail() {
+		return nil, errors.NewValidation("invalid email")
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return nil, errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	userURL := fmt.Sprintf("https://%s/api/v2/users/%s", u.config.Domain, url.PathEscape(user.UserID))
+
+	get := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(userURL),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("get user identities"),
+	)
+
+	var current Auth0User
+	statusCode, errGet := get.Call(ctx, &current)
+	if errGet != nil {
+		slog.ErrorContext(ctx, "failed to get user from Auth0",
+			"error", errGet,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(user.UserID),
+		)
+		msg := u.errorResponse.ErrorMessage(errGet.Error())
+		return nil, httpclient.ErrorFromCall(statusCode, msg, errGet)
+	}
+	if len(current.Identities) == 0 || current.Identities[0].Provider != databaseProvider {
+		return nil, errors.NewValidation("the primary email of the account is managed by its identity provider")
+	}
+
+	patch := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodPatch),
+		httpclient.WithURL(userURL),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("change primary email"),
+		httpclient.WithBody(primaryEmailUpdateRequest{
+			Email:         email,
+			EmailVerified: true,
+			VerifyEmail:   false,
+			Connection:    current.Identities[0].Connection,
+		}),
+	)
+
+	var updated Auth0User
+	statusCode, errPatch := patch.Call(ctx, &updated)
+	if errPatch != nil {
+		slog.ErrorContext(ctx, "failed to change primary email in Auth0",
+			"error", errPatch,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(user.UserID),
+		)
+		msg := u.errorResponse.ErrorMessage(errPatch.Error())
+		return nil, httpclient.ErrorFromCall(statusCode, msg, errPatch)
+	}
+
+	slog.DebugContext(ctx, "primary email changed successfully",
+		"user_id", redaction.Redact(user.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return updated.ToUser(), nil
+}
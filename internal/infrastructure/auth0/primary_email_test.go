@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestUserReaderWriter_ChangePrimaryEmail(t *testing.T) {
+	tests := []struct {
+		name        string
+		primary     Auth0Identity
+		wantPatched bool
+		wantErr     bool
+	}{
+		{
+			name:        "database user",
+			primary:     Auth0Identity{Connection: "Username-Password-Authentication", Provider: "auth0", UserID: "jane"},
+			wantPatched: true,
+		},
+		{
+			name:    "social user rejected",
+			primary: Auth0Identity{Connection: "github", Provider: "github", UserID: float64(1234), IsSocial: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched *primaryEmailUpdateRequest
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer m2m-token", r.Header.Get("Authorization"))
+				if r.URL.Path != "/api/v2/users/auth0|jane" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					_ = json.NewEncoder(w).Encode(Auth0User{UserID: "auth0|jane", Email: "jane@example.com", Identities: []Auth0Identity{tt.primary}})
+				case http.MethodPatch:
+					patched = &primaryEmailUpdateRequest{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(patched))
+					_ = json.NewEncoder(w).Encode(Auth0User{UserID: "auth0|jane", Email: patched.Email, EmailVerified: true})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			}))
+			defer server.Close()
+
+			httpConfig := httpclient.DefaultConfig()
+			httpConfig.Transport = server.Client().Transport
+			u := &userReaderWriter{
+				httpClient: httpclient.NewClient(httpConfig),
+				config: Config{
+					Domain:          strings.TrimPrefix(server.URL, "https://"),
+					M2MTokenManager: &TokenManager{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "m2m-token"})},
+				},
+			}
+
+			updated, err := u.ChangePrimaryEmail(context.Background(), &model.User{UserID: "auth0|jane"}, " Jane@Personal.example ")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, patched)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, patched)
+			assert.Equal(t, primaryEmailUpdateRequest{
+				Email:         "jane@personal.example",
+				EmailVerified: true,
+				Connection:    "Username-Password-Authentication",
+			}, *patched)
+			assert.Equal(t, "jane@personal.example", updated.PrimaryEmail)
+		})
+	}
+}
+
+func TestUserReaderWriter_ChangePrimaryEmail_Validation(t *testing.T) {
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	_, err := u.ChangePrimaryEmail(context.Background(), nil, "jane@personal.example")
+	assert.Error(t, err)
+	_, err = u.ChangePrimaryEmail(context.Background(), &model.User{UserID: "auth0|jane"}, "not an email")
+	assert.Error(t, err)
+}
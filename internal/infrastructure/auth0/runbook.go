@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+// RenewM2MToken replaces the cached M2M token of the tenant with one issued for the current credentials
+func (u *userReaderWriter) RenewM2MToken(ctx context.Context) (time.Time, error) {
+	if u.config.M2MTokenManager == nil {
+		return time.Time{}, errors.NewUnexpected("M2M token manager is not configured")
+	}
+	return u.config.M2MTokenManager.Renew(ctx)
+}
+
+// VerifyM2MToken reads a single user ID with the M2M token, the Management API rejects a token
+// issued for revoked credentials or without the read:users scope
+func (u *userReaderWriter) VerifyM2MToken(ctx context.Context) error {
+	if u.config.M2MTokenManager == nil {
+		return errors.NewUnexpected("M2M token manager is not configured")
+	}
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	apiRequest := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users?per_page=1&fields=user_id&include_fields=true", u.config.Domain)),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("verify M2M token"),
+	)
+
+	var users []struct {
+		UserID string `json:"user_id"`
+	}
+	statusCode, errCall := apiRequest.Call(ctx, &users)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "M2M token rejected by the Management API", "error", errCall, "status_code", statusCode)
+		return httpclient.ErrorFromCall(statusCode, u.errorResponse.ErrorMessage(errCall.Error()), errCall)
+	}
+	return nil
+}
+
+// RefreshJWKS fetches the JWKS verifying the user tokens of the tenant now
+func (u *userReaderWriter) RefreshJWKS(ctx context.Context) (int, error) {
+	if u.config.JWTVerificationConfig == nil {
+		return 0, errors.NewUnexpected("JWT verification configuration is required")
+	}
+	return u.config.JWTVerificationConfig.refresh(ctx, u.config.Domain, u.httpClient)
+}
+
+// distinct returns the tenants once each, the primary tenant first
+func (r *tenantRouter) distinct() []*userReaderWriter {
+	tenants := []*userReaderWriter{r.primary}
+	seen := map[*userReaderWriter]bool{r.primary: true}
+	for _, tenant := range r.tenants {
+		if !seen[tenant] {
+			seen[tenant] = true
+			tenants = append(tenants, tenant)
+		}
+	}
+	return tenants
+}
+
+// RenewM2MToken renews the M2M token of every tenant and returns when the first of them expires
+func (r *tenantRouter) RenewM2MToken(ctx context.Context) (time.Time, error) {
+	var expiry time.Time
+	for _, tenant := range r.distinct() {
+		tenantExpiry, err := tenant.RenewM2MToken(ctx)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("tenant %s: %w", tenant.config.Domain, err)
+		}
+		if expiry.IsZero() || tenantExpiry.Before(expiry) {
+			expiry = tenantExpiry
+		}
+	}
+	return expiry, nil
+}
+
+// VerifyM2MToken verifies the M2M token of every tenant
+func (r *tenantRouter) VerifyM2MToken(ctx context.Context) error {
+	for _, tenant := range r.distinct() {
+		if err := tenant.VerifyM2MToken(ctx); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant.config.Domain, err)
+		}
+	}
+	return nil
+}
+
+// RefreshJWKS refreshes the JWKS of every tenant and returns the number of signing keys loaded
+func (r *tenantRouter) RefreshJWKS(ctx context.Context) (int, error) {
+	loaded := 0
+	for _, tenant := range r.distinct() {
+		keys, err := tenant.RefreshJWKS(ctx)
+		loaded += keys
+		if err != nil {
+			return loaded, fmt.Errorf("tenant %s: %w", tenant.config.Domain, err)
+		}
+	}
+	return loaded, nil
+}
@@ -0,0 +1,318 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// tenantRouter serves the users of several Auth0 tenants from one deployment, each tenant has its
+// own M2M token manager and JWKS. The requests carrying a user token are served by the tenant that
+// issued it, the other ones by the tenant of the request hint, see model.TenantFromContext, or the
+// primary tenant when there is none.
+type tenantRouter struct {
+	primary *userReaderWriter
+	// tenants are the tenants by alias and by domain
+	tenants map[string]*userReaderWriter
+	// issuers are the tenants by the issuers they verify, the tenant itself and its trusted issuers
+	issuers map[string]*userReaderWriter
+}
+
+// issuerKey normalizes the issuer, the Auth0 issuers end with a slash the trusted ones may not
+func issuerKey(issuer string) string {
+	return strings.TrimSuffix(strings.TrimSpace(issuer), "/")
+}
+
+// register adds the tenant, a duplicated alias, domain or issuer is a configuration error
+func (r *tenantRouter) register(tenant *userReaderWriter) error {
+	keys := []string{strings.ToLower(tenant.config.Domain)}
+	if tenant.config.Alias != "" {
+		keys = append(keys, strings.ToLower(tenant.config.Alias))
+	}
+	for _, key := range keys {
+		if _, exists := r.tenants[key]; exists {
+			return errors.NewValidation(fmt.Sprintf("duplicate Auth0 tenant %q", key))
+		}
+		r.tenants[key] = tenant
+	}
+
+	issuers := []string{fmt.Sprintf("https://%s/", tenant.config.Domain)}
+	for _, trusted := range tenant.config.TrustedIssuers {
+		issuers = append(issuers, trusted.URL)
+	}
+	for _, issuer := range issuers {
+		key := issuerKey(issuer)
+		if _, exists := r.issuers[key]; exists {
+			return errors.NewValidation(fmt.Sprintf("issuer %q is trusted by several Auth0 tenants", issuer))
+		}
+		r.issuers[key] = tenant
+	}
+	return nil
+}
+
+// route returns the tenant serving the request, the one that issued the token when there is one
+func (r *tenantRouter) route(ctx context.Context, token string) (*userReaderWriter, error) {
+	if cleanToken, isJWT := jwt.LooksLikeJWT(token); isJWT {
+		issuer, errIssuer := jwt.ExtractIssuer(ctx, cleanToken)
+		if errIssuer == nil {
+			if tenant, ok := r.issuers[issuerKey(issuer)]; ok {
+				return tenant, nil
+			}
+		}
+		// the token is verified, and rejected, by the tenant of the hint or the primary one
+		slog.DebugContext(ctx, "token issuer is not an Auth0 tenant", "issuer", issuer)
+	}
+
+	hint := model.TenantFromContext(ctx)
+	if hint == "" {
+		return r.primary, nil
+	}
+	tenant, ok := r.tenants[strings.ToLower(hint)]
+	if !ok {
+		return nil, errors.NewValidation(fmt.Sprintf("unknown tenant: %s", hint))
+	}
+	return tenant, nil
+}
+
+func (r *tenantRouter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.SearchUser(ctx, user, criteria)
+}
+
+func (r *tenantRouter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.GetUser(ctx, user)
+}
+
+func (r *tenantRouter) MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error) {
+	tenant, err := r.route(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.MetadataLookup(ctx, input, requiredScopes...)
+}
+
+func (r *tenantRouter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.UpdateUser(ctx, user)
+}
+
+func (r *tenantRouter) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
+	tenant, err := r.route(ctx, "")
+	if err != nil {
+		return err
+	}
+	return tenant.SendVerificationAlternateEmail(ctx, alternateEmail)
+}
+
+func (r *tenantRouter) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
+	tenant, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return tenant.VerifyAlternateEmail(ctx, email)
+}
+
+func (r *tenantRouter) SendVerification(ctx context.Context, verification *model.Verification) error {
+	tenant, err := r.route(ctx, "")
+	if err != nil {
+		return err
+	}
+	return tenant.SendVerification(ctx, verification)
+}
+
+func (r *tenantRouter) Verify(ctx context.Context, verification *model.Verification) (*model.AuthResponse, error) {
+	tenant, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return tenant.Verify(ctx, verification)
+}
+
+func (r *tenantRouter) ValidateLinkRequest(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	tenant, err := r.route(ctx, request.User.AuthToken)
+	if err != nil {
+		return err
+	}
+	return tenant.ValidateLinkRequest(ctx, request)
+}
+
+func (r *tenantRouter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	tenant, err := r.route(ctx, request.User.AuthToken)
+	if err != nil {
+		return err
+	}
+	return tenant.LinkIdentity(ctx, request)
+}
+
+func (r *tenantRouter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("unlink identity request is required")
+	}
+	tenant, err := r.route(ctx, request.User.AuthToken)
+	if err != nil {
+		return err
+	}
+	return tenant.UnlinkIdentity(ctx, request)
+}
+
+func (r *tenantRouter) UnlinkAlternateEmail(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+	if request == nil {
+		return errors.NewValidation("unlink alternate email request is required")
+	}
+	tenant, err := r.route(ctx, request.User.AuthToken)
+	if err != nil {
+		return err
+	}
+	return tenant.UnlinkAlternateEmail(ctx, request)
+}
+
+func (r *tenantRouter) OrganizationAdminLookup(ctx context.Context, token string) (*model.OrganizationAdmin, error) {
+	tenant, err := r.route(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.OrganizationAdminLookup(ctx, token)
+}
+
+func (r *tenantRouter) UpdateUserAsOrganizationAdmin(ctx context.Context, user *model.User) (*model.User, error) {
+	if user == nil {
+		return nil, errors.NewValidation("user_id is required to update user")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.UpdateUserAsOrganizationAdmin(ctx, user)
+}
+
+func (r *tenantRouter) EnrichUserMetadata(ctx context.Context, user *model.User) (*model.User, error) {
+	if user == nil {
+		return nil, errors.NewValidation("user_id is required to update user")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.EnrichUserMetadata(ctx, user)
+}
+
+func (r *tenantRouter) MergeUsers(ctx context.Context, merge *model.UserMerge) (*model.User, error) {
+	if merge == nil || merge.Primary == nil {
+		return nil, errors.NewValidation("primary and secondary users are required to merge")
+	}
+	tenant, err := r.route(ctx, merge.Primary.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.MergeUsers(ctx, merge)
+}
+
+func (r *tenantRouter) ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error) {
+	if user == nil {
+		return nil, errors.NewValidation("user_id is required")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.ChangePrimaryEmail(ctx, user, email)
+}
+
+func (r *tenantRouter) LinkVerifiedEmail(ctx context.Context, user *model.User, email string) error {
+	if user == nil {
+		return errors.NewValidation("user_id is required")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return err
+	}
+	return tenant.LinkVerifiedEmail(ctx, user, email)
+}
+
+func (r *tenantRouter) ListAuthenticators(ctx context.Context, user *model.User) ([]model.Authenticator, error) {
+	if user == nil {
+		return nil, errors.NewValidation("user_id is required to list authenticators")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.ListAuthenticators(ctx, user)
+}
+
+func (r *tenantRouter) DeleteAuthenticator(ctx context.Context, user *model.User, authenticatorID string) error {
+	if user == nil {
+		return errors.NewValidation("user_id is required to delete an authenticator")
+	}
+	tenant, err := r.route(ctx, user.Token)
+	if err != nil {
+		return err
+	}
+	return tenant.DeleteAuthenticator(ctx, user, authenticatorID)
+}
+
+// NewMultiTenantUserReaderWriter creates a UserReaderWriter serving the users of the primary tenant
+// and the additional ones, each tenant with its own credentials, see Config.Alias. It's the user
+// reader writer of the primary tenant when there is no additional tenant.
+func NewMultiTenantUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, primary Config, additional ...Config) (port.UserReaderWriter, error) {
+	primaryTenant, err := newUserReaderWriter(ctx, httpConfig, primary)
+	if err != nil {
+		return nil, err
+	}
+	if len(additional) == 0 {
+		return primaryTenant, nil
+	}
+
+	router := &tenantRouter{
+		primary: primaryTenant,
+		tenants: make(map[string]*userReaderWriter),
+		issuers: make(map[string]*userReaderWriter),
+	}
+	if errRegister := router.register(primaryTenant); errRegister != nil {
+		return nil, errRegister
+	}
+	for _, config := range additional {
+		if strings.TrimSpace(config.Alias) == "" {
+			return nil, errors.NewValidation("the additional Auth0 tenants require an alias")
+		}
+		tenant, errTenant := newUserReaderWriter(ctx, httpConfig, config)
+		if errTenant != nil {
+			return nil, fmt.Errorf("failed to create the Auth0 tenant %s: %w", config.Alias, errTenant)
+		}
+		if errRegister := router.register(tenant); errRegister != nil {
+			return nil, errRegister
+		}
+		slog.DebugContext(ctx, "Auth0 tenant registered",
+			"alias", config.Alias,
+			"domain", config.Domain,
+		)
+	}
+	return router, nil
+}
@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_EnvKey(t *testing.T) {
+	assert.Equal(t, "AUTH0_M2M_CLIENT_ID", Config{}.EnvKey("AUTH0_M2M_CLIENT_ID"))
+	assert.Equal(t, "AUTH0_COMMUNITY_M2M_CLIENT_ID", Config{Alias: "community"}.EnvKey("AUTH0_M2M_CLIENT_ID"))
+	assert.Equal(t, "AUTH0_LF_STAGING_DOMAIN", Config{Alias: "lf-staging"}.EnvKey("AUTH0_DOMAIN"))
+}
+
+func TestTenantRouter_Route(t *testing.T) {
+	ctx := context.Background()
+
+	primary := &userReaderWriter{config: Config{Domain: "staging.auth0.com"}}
+	community := &userReaderWriter{config: Config{
+		Alias:          "community",
+		Domain:         "community.auth0.com",
+		TrustedIssuers: []oidc.Issuer{{URL: "https://sso.community.example.org"}},
+	}}
+
+	router := &tenantRouter{
+		primary: primary,
+		tenants: make(map[string]*userReaderWriter),
+		issuers: make(map[string]*userReaderWriter),
+	}
+	require.NoError(t, router.register(primary))
+	require.NoError(t, router.register(community))
+
+	token := func(issuer string) string {
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "auth0|user",
+			"iss": issuer,
+		}).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	tests := []struct {
+		name      string
+		hint      string
+		token     string
+		want      *userReaderWriter
+		wantError bool
+	}{
+		{
+			name: "no token nor hint is the primary tenant",
+			want: primary,
+		},
+		{
+			name:  "token of the additional tenant",
+			token: token("https://community.auth0.com/"),
+			want:  community,
+		},
+		{
+			name:  "token of a trusted issuer of the additional tenant",
+			token: "Bearer " + token("https://sso.community.example.org/"),
+			want:  community,
+		},
+		{
+			name:  "token issuer wins over the hint",
+			hint:  "community",
+			token: token("https://staging.auth0.com/"),
+			want:  primary,
+		},
+		{
+			name:  "unknown issuer falls back to the hint",
+			hint:  "community",
+			token: token("https://unknown.example.org/"),
+			want:  community,
+		},
+		{
+			name: "hint by alias",
+			hint: "Community",
+			want: community,
+		},
+		{
+			name: "hint by domain",
+			hint: "community.auth0.com",
+			want: community,
+		},
+		{
+			name:      "unknown hint",
+			hint:      "production",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant, err := router.route(model.ContextWithTenant(ctx, tt.hint), tt.token)
+			if tt.wantError {
+				require.Error(t, err)
+				_, isValidation := err.(errors.Validation)
+				assert.True(t, isValidation, "expected validation error, got %T", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, tt.want, tenant)
+		})
+	}
+}
+
+func TestTenantRouter_Register_Duplicates(t *testing.T) {
+	newRouter := func() *tenantRouter {
+		return &tenantRouter{
+			tenants: make(map[string]*userReaderWriter),
+			issuers: make(map[string]*userReaderWriter),
+		}
+	}
+
+	router := newRouter()
+	require.NoError(t, router.register(&userReaderWriter{config: Config{Domain: "staging.auth0.com"}}))
+	assert.Error(t, router.register(&userReaderWriter{config: Config{Alias: "staging", Domain: "staging.auth0.com"}}))
+
+	router = newRouter()
+	require.NoError(t, router.register(&userReaderWriter{config: Config{
+		Domain:         "staging.auth0.com",
+		TrustedIssuers: []oidc.Issuer{{URL: "https://sso.example.org/"}},
+	}}))
+	assert.Error(t, router.register(&userReaderWriter{config: Config{
+		Alias:          "community",
+		Domain:         "community.auth0.com",
+		TrustedIssuers: []oidc.Issuer{{URL: "https://sso.example.org"}},
+	}}))
+}
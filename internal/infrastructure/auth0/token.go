@@ -16,6 +16,7 @@ import (
 	"github.com/auth0/go-auth0/authentication/oauth"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokensource"
 
 	"golang.org/x/oauth2"
 )
@@ -102,6 +103,21 @@ func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
 	return token.AccessToken, nil
 }
 
+// Renew replaces the cached token with one issued for the current credentials and returns when it expires
+func (tm *TokenManager) Renew(ctx context.Context) (time.Time, error) {
+	renewing, ok := tm.tokenSource.(interface{ Renew() (*oauth2.Token, error) })
+	if !ok {
+		return time.Time{}, errors.NewUnexpected("the M2M token can't be renewed")
+	}
+	token, err := renewing.Renew()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to renew M2M token: %w", err)
+	}
+
+	slog.InfoContext(ctx, "M2M token renewed", "expires_at", token.Expiry)
+	return token.Expiry, nil
+}
+
 // IsTokenExpired checks if the current token is expired
 func (tm *TokenManager) IsTokenExpired() bool {
 	token, err := tm.tokenSource.Token()
@@ -140,31 +156,43 @@ func (tm *TokenManager) GetTokenInfo() (*TokenInfo, error) {
 
 // loadM2MConfigFromEnv loads M2M configuration from environment variables or secrets
 func loadM2MConfigFromEnv(ctx context.Context, config Config) (m2mConfig, error) {
-	clientID := os.Getenv(constants.Auth0M2MClientIDEnvKey)
+	clientID := os.Getenv(config.EnvKey(constants.Auth0M2MClientIDEnvKey))
+	replay := config.replaying()
+	if clientID == "" && replay {
+		clientID = replayClientID
+	}
 	if clientID == "" {
-		return m2mConfig{}, errors.NewUnexpected(constants.Auth0M2MClientIDEnvKey + " is required")
+		return m2mConfig{}, errors.NewUnexpected(config.EnvKey(constants.Auth0M2MClientIDEnvKey) + " is required")
 	}
 
-	audience := os.Getenv(constants.Auth0AudienceEnvKey)
+	audience := os.Getenv(config.EnvKey(constants.Auth0AudienceEnvKey))
 	if audience == "" {
-		return m2mConfig{}, errors.NewUnexpected(constants.Auth0AudienceEnvKey + " is required")
+		return m2mConfig{}, errors.NewUnexpected(config.EnvKey(constants.Auth0AudienceEnvKey) + " is required")
 	}
 
 	// private key is base64 encoded
-	privateKey := os.Getenv(constants.Auth0M2MPrivateBase64KeyEnvKey)
+	privateKey := os.Getenv(config.EnvKey(constants.Auth0M2MPrivateBase64KeyEnvKey))
+	if privateKey == "" && replay {
+		// the client assertion is sanitized from the cassette, any key signs it
+		generated, errGenerate := replayPrivateKey()
+		if errGenerate != nil {
+			return m2mConfig{}, errors.NewUnexpected("failed to generate the replay private key", errGenerate)
+		}
+		privateKey = generated
+	}
 	if privateKey == "" {
-		return m2mConfig{}, errors.NewUnexpected(constants.Auth0M2MPrivateBase64KeyEnvKey + " is required")
+		return m2mConfig{}, errors.NewUnexpected(config.EnvKey(constants.Auth0M2MPrivateBase64KeyEnvKey) + " is required")
 	}
 
 	decoded, err := base64.StdEncoding.DecodeString(privateKey)
 	if err != nil {
-		return m2mConfig{}, errors.NewUnexpected("failed to base64-decode "+constants.Auth0M2MPrivateBase64KeyEnvKey, err)
+		return m2mConfig{}, errors.NewUnexpected("failed to base64-decode "+config.EnvKey(constants.Auth0M2MPrivateBase64KeyEnvKey), err)
 	}
 	privateKey = string(decoded)
 	//
 
 	// Optional organization
-	organization := os.Getenv("AUTH0_ORGANIZATION")
+	organization := os.Getenv(config.EnvKey("AUTH0_ORGANIZATION"))
 
 	slog.DebugContext(ctx, "M2M configuration loaded")
 
@@ -188,8 +216,10 @@ func NewM2MTokenManager(ctx context.Context, config Config) (*TokenManager, erro
 	authConfig, err := authentication.New(
 		ctx,
 		config.Domain,
-		authentication.WithClientID(m2mConfig.ClientID),
-		authentication.WithClientAssertion(m2mConfig.PrivateKey, "RS256"),
+		append(config.authenticationOptions(),
+			authentication.WithClientID(m2mConfig.ClientID),
+			authentication.WithClientAssertion(m2mConfig.PrivateKey, "RS256"),
+		)...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Auth0 client: %w", err)
@@ -203,15 +233,15 @@ func NewM2MTokenManager(ctx context.Context, config Config) (*TokenManager, erro
 		organization: m2mConfig.Organization,
 	}
 
-	// Wrap with oauth2.ReuseTokenSource for automatic caching and renewal
-	reuseTokenSource := oauth2.ReuseTokenSource(nil, tokenSource)
+	// Cache the token, renewed in the background before it expires
+	renewingTokenSource := tokensource.NewRenewing(ctx, "auth0", tokenSource)
 
 	// Create HTTP client that automatically handles token management
-	httpClient := oauth2.NewClient(ctx, reuseTokenSource)
+	httpClient := oauth2.NewClient(ctx, renewingTokenSource)
 
 	return &TokenManager{
 		httpClient:  httpClient,
-		tokenSource: reuseTokenSource,
+		tokenSource: renewingTokenSource,
 		config:      m2mConfig,
 		authConfig:  authConfig,
 	}, nil
@@ -219,23 +249,33 @@ func NewM2MTokenManager(ctx context.Context, config Config) (*TokenManager, erro
 
 // NewProfileClientAuthConfig creates an Auth0 authentication client for LFX Profile
 // using client ID and client secret for passwordless flows
-func NewProfileClientAuthConfig(ctx context.Context, domain string) (*authentication.Authentication, error) {
-	clientID := os.Getenv(constants.Auth0LFXProfileClientIDEnvKey)
+func NewProfileClientAuthConfig(ctx context.Context, config Config) (*authentication.Authentication, error) {
+	domain := config.Domain
+
+	clientID := os.Getenv(config.EnvKey(constants.Auth0LFXProfileClientIDEnvKey))
+	if clientID == "" && config.replaying() {
+		clientID = replayClientID
+	}
 	if clientID == "" {
-		return nil, errors.NewUnexpected(constants.Auth0LFXProfileClientIDEnvKey + " is required for email linking flow")
+		return nil, errors.NewUnexpected(config.EnvKey(constants.Auth0LFXProfileClientIDEnvKey) + " is required for email linking flow")
 	}
 
-	clientSecret := os.Getenv(constants.Auth0LFXProfileClientSecretEnvKey)
+	clientSecret := os.Getenv(config.EnvKey(constants.Auth0LFXProfileClientSecretEnvKey))
+	if clientSecret == "" && config.replaying() {
+		clientSecret = replayClientID
+	}
 	if clientSecret == "" {
-		return nil, errors.NewUnexpected(constants.Auth0LFXProfileClientSecretEnvKey + " is required for email linking flow")
+		return nil, errors.NewUnexpected(config.EnvKey(constants.Auth0LFXProfileClientSecretEnvKey) + " is required for email linking flow")
 	}
 
 	// Create Auth0 authentication client with client secret
 	authConfig, err := authentication.New(
 		ctx,
 		domain,
-		authentication.WithClientID(clientID),
-		authentication.WithClientSecret(clientSecret),
+		append(config.authenticationOptions(),
+			authentication.WithClientID(clientID),
+			authentication.WithClientSecret(clientSecret),
+		)...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Auth0 LFX Profile client: %w", err)
@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// emailIdentity returns the passwordless email identity of the email among the identities of the
+// user. The first identity is the primary one in Auth0, it's never returned.
+func emailIdentity(user *model.User, email string) (model.Identity, error) {
+	if strings.EqualFold(user.PrimaryEmail, email) {
+		return model.Identity{}, errors.NewValidation("the primary email can't be unlinked")
+	}
+	for i, identity := range user.Identities {
+		if identity.Provider != emailAuthenticationFilter || !strings.EqualFold(identity.Email, email) {
+			continue
+		}
+		if i == 0 {
+			return model.Identity{}, errors.NewValidation("the primary identity can't be unlinked")
+		}
+		return identity, nil
+	}
+	return model.Identity{}, errors.NewNotFound("alternate email is not linked to the user")
+}
+
+// UnlinkAlternateEmail unlinks the passwordless email identity of an alternate email from the user.
+// The identities are read with the M2M token, the identity is unlinked with the user token
+// (DELETE /api/v2/users/{id}/identities/{provider}/{user_id}).
+func (u *userReaderWriter) UnlinkAlternateEmail(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+	if u.identityLinkingFlow == nil {
+		return errors.NewUnexpected("identity linking flow not configured")
+	}
+
+	if request == nil {
+		return errors.NewValidation("unlink alternate email request is required")
+	}
+
+	if strings.TrimSpace(request.User.UserID) == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	if request.User.AuthToken == "" {
+		return errors.NewValidation("user_token is required")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	if !(&model.Email{Email: email}).IsValidEmail() {
+		return errors.NewValidation("invalid email")
+	}
+
+	user, errGetUser := u.GetUser(ctx, &model.User{UserID: request.User.UserID})
+	if errGetUser != nil {
+		return errGetUser
+	}
+
+	identity, errIdentity := emailIdentity(user, email)
+	if errIdentity != nil {
+		return errIdentity
+	}
+
+	errUnlink := u.identityLinkingFlow.UnlinkIdentityFromUser(
+		ctx,
+		request.User.UserID,
+		request.User.AuthToken,
+		identity.Provider,
+		identity.IdentityID,
+	)
+	if errUnlink != nil {
+		return errUnlink
+	}
+
+	slog.DebugContext(ctx, "alternate email unlinked successfully",
+		"user_id", redaction.Redact(request.User.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return nil
+}
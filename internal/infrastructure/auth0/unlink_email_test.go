@@ -0,0 +1,125 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestUserReaderWriter_UnlinkAlternateEmail(t *testing.T) {
+	auth0User := Auth0User{
+		UserID: "auth0|jane",
+		Email:  "jane@example.com",
+		Identities: []Auth0Identity{
+			{Connection: "Username-Password-Authentication", Provider: "auth0", UserID: "jane"},
+			{Connection: "email", Provider: "email", UserID: "email-id", ProfileData: &Auth0ProfileData{Email: "jane@personal.example", EmailVerified: true}},
+			{Connection: "github", Provider: "github", UserID: float64(1234), IsSocial: true, ProfileData: &Auth0ProfileData{Email: "jane@github.example"}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		email        string
+		wantUnlinked string
+		wantErr      error
+	}{
+		{
+			name:         "alternate email unlinked",
+			email:        " Jane@Personal.example ",
+			wantUnlinked: "/api/v2/users/auth0|jane/identities/email/email-id",
+		},
+		{
+			name:    "primary email rejected",
+			email:   "jane@example.com",
+			wantErr: errs.Validation{},
+		},
+		{
+			name:    "social identity email is not an alternate email",
+			email:   "jane@github.example",
+			wantErr: errs.NotFound{},
+		},
+		{
+			name:    "email not linked",
+			email:   "john@example.com",
+			wantErr: errs.NotFound{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var unlinked string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v2/users/auth0|jane":
+					assert.Equal(t, "Bearer m2m-token", r.Header.Get("Authorization"))
+					_ = json.NewEncoder(w).Encode(auth0User)
+				case r.Method == http.MethodDelete:
+					assert.Equal(t, "Bearer user-token", r.Header.Get("Authorization"))
+					unlinked = r.URL.Path
+					_, _ = w.Write([]byte(`[]`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			httpConfig := httpclient.DefaultConfig()
+			httpConfig.Transport = server.Client().Transport
+			httpClient := httpclient.NewClient(httpConfig)
+			domain := strings.TrimPrefix(server.URL, "https://")
+			u := &userReaderWriter{
+				httpClient:          httpClient,
+				identityLinkingFlow: newIdentityLinkingFlow(domain, httpClient),
+				config: Config{
+					Domain:          domain,
+					M2MTokenManager: &TokenManager{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "m2m-token"})},
+				},
+			}
+
+			request := &model.UnlinkAlternateEmail{Email: tt.email}
+			request.User.UserID = "auth0|jane"
+			request.User.AuthToken = "user-token"
+
+			err := u.UnlinkAlternateEmail(context.Background(), request)
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Empty(t, unlinked)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUnlinked, unlinked)
+		})
+	}
+}
+
+func TestUserReaderWriter_UnlinkAlternateEmail_Validation(t *testing.T) {
+	u := &userReaderWriter{
+		identityLinkingFlow: &identityLinkingFlow{},
+		config:              Config{Domain: "test.auth0.com"},
+	}
+
+	request := &model.UnlinkAlternateEmail{Email: "jane@personal.example"}
+	assert.Error(t, u.UnlinkAlternateEmail(context.Background(), nil))
+	assert.Error(t, u.UnlinkAlternateEmail(context.Background(), request))
+
+	request.User.UserID = "auth0|jane"
+	assert.Error(t, u.UnlinkAlternateEmail(context.Background(), request))
+
+	request.User.AuthToken = "user-token"
+	request.Email = "not an email"
+	assert.Error(t, u.UnlinkAlternateEmail(context.Background(), request))
+}
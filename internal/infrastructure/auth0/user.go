@@ -5,9 +5,12 @@ package auth0
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
@@ -16,6 +19,7 @@ import (
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/oidc"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
@@ -25,15 +29,98 @@ const auth0SubPrefix = "auth0|"
 type Config struct {
 	Tenant string
 	Domain string
+	// Alias names an additional tenant of a multi-tenant deployment, its credentials are read from
+	// the variables prefixed with the alias, see EnvKey. Empty for the primary tenant.
+	Alias string
 	// M2MTokenManager for machine-to-machine authentication
 	M2MTokenManager *TokenManager
 	// JWTVerificationConfig for JWT signature verification
 	JWTVerificationConfig *JWTVerificationConfig
+	// OrganizationAdminClaim is the custom claim listing the organizations the user administers
+	OrganizationAdminClaim string
+	// Cassette records the Auth0 traffic or replays it offline in development, optional
+	Cassette *httpclient.Cassette
+	// CanaryJWKSURL is a JWKS source compared to the tenant one on a sample of the tokens before
+	// switching to it, optional
+	CanaryJWKSURL string
+	// CanarySampleRate is the share of the tokens verified with the canary JWKS source
+	CanarySampleRate float64
+	// TrustedIssuers are the OpenID Connect issuers trusted in addition to the tenant, their
+	// tokens must be issued for the users of the tenant, optional
+	TrustedIssuers []oidc.Issuer
+	// SearchMaxPages bounds the pages of the users read by a search, DefaultSearchMaxPages when zero
+	SearchMaxPages int
+}
+
+// searchMaxPages returns the maximum number of pages read by a search
+func (c Config) searchMaxPages() int {
+	if c.SearchMaxPages <= 0 {
+		return DefaultSearchMaxPages
+	}
+	return min(c.SearchMaxPages, MaxSearchPages)
+}
+
+// EnvKey returns the environment variable of the tenant configuration, the variables of an
+// additional tenant are prefixed with its alias, e.g. AUTH0_COMMUNITY_M2M_CLIENT_ID
+func (c Config) EnvKey(key string) string {
+	if c.Alias == "" {
+		return key
+	}
+	alias := strings.ToUpper(strings.ReplaceAll(c.Alias, "-", "_"))
+	return "AUTH0_" + alias + "_" + strings.TrimPrefix(key, "AUTH0_")
+}
+
+// Validate checks the configuration and the credentials loaded from the environment without
+// calling Auth0, the client credentials are optional when replaying a cassette
+func (c Config) Validate(ctx context.Context) error {
+	if strings.TrimSpace(c.Tenant) == "" && strings.TrimSpace(c.Domain) == "" {
+		return errors.NewValidation("Auth0 tenant or domain is required")
+	}
+
+	m2m, err := loadM2MConfigFromEnv(ctx, c)
+	if err != nil {
+		return errors.NewValidation("invalid Auth0 M2M configuration", err)
+	}
+	if block, _ := pem.Decode([]byte(m2m.PrivateKey)); block == nil {
+		return errors.NewValidation(c.EnvKey(constants.Auth0M2MPrivateBase64KeyEnvKey) + " is not a PEM encoded private key")
+	}
+
+	if !c.replaying() {
+		clientIDKey, clientSecretKey := c.EnvKey(constants.Auth0LFXProfileClientIDEnvKey), c.EnvKey(constants.Auth0LFXProfileClientSecretEnvKey)
+		if os.Getenv(clientIDKey) == "" || os.Getenv(clientSecretKey) == "" {
+			return errors.NewValidation(clientIDKey + " and " + clientSecretKey + " are required for email linking flow")
+		}
+	}
+	return nil
 }
 
 // userUpdateRequest represents the request body for updating a user in Auth0
 type userUpdateRequest struct {
-	UserMetadata *model.UserMetadata `json:"user_metadata,omitempty"`
+	UserMetadata any `json:"user_metadata,omitempty"`
+}
+
+// metadataUpdate returns the user_metadata of the update request, Auth0 merges it with the
+// current one and removes the fields set to null
+func metadataUpdate(user *model.User) (any, error) {
+	cleared := user.ClearedMetadataFields()
+	if len(cleared) == 0 {
+		return user.UserMetadata, nil
+	}
+
+	fields := make(map[string]any)
+	if user.UserMetadata != nil {
+		raw, errMarshal := json.Marshal(user.UserMetadata)
+		if errMarshal != nil {
+			return nil, errors.NewUnexpected("failed to marshal user metadata", errMarshal)
+		}
+		if errUnmarshal := json.Unmarshal(raw, &fields); errUnmarshal != nil {
+			return nil, errors.NewUnexpected("failed to unmarshal user metadata", errUnmarshal)
+		}
+	}
+	for _, field := range cleared {
+		fields[field] = nil
+	}
+	return fields, nil
 }
 
 type userReaderWriter struct {
@@ -44,7 +131,7 @@ type userReaderWriter struct {
 	errorResponse       *ErrorResponse
 }
 
-func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 
 	filterer := newUserFilterer(criteria, user)
 	if filterer == nil {
@@ -67,60 +154,86 @@ func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, cri
 	}
 
 	endpointWithParam := fmt.Sprintf(endpoint, args...)
-	url := fmt.Sprintf("https://%s/api/v2/%s", u.config.Domain, endpointWithParam)
 
-	apiRequest := httpclient.NewAPIRequest(
-		u.httpClient,
-		httpclient.WithMethod(http.MethodGet),
-		httpclient.WithURL(url),
-		httpclient.WithToken(user.Token),
-		httpclient.WithDescription("search user"),
-	)
+	// the search engine returns the users by pages, the other endpoints return them all at once
+	pages, paginated := 1, paginatedCriteria[criteria]
+	if paginated {
+		pages = u.config.searchMaxPages()
+	}
 
-	var users []Auth0User
+	for page := range pages {
+		url := fmt.Sprintf("https://%s/api/v2/%s", u.config.Domain, endpointWithParam)
+		if paginated {
+			url += fmt.Sprintf("&per_page=%d&page=%d", searchPerPage, page)
+		}
 
-	statusCode, errCall := apiRequest.Call(ctx, &users)
-	if errCall != nil {
-		slog.ErrorContext(ctx, "failed to search user",
-			"error", errCall,
-			"status_code", statusCode,
+		apiRequest := httpclient.NewAPIRequest(
+			u.httpClient,
+			httpclient.WithMethod(http.MethodGet),
+			httpclient.WithURL(url),
+			httpclient.WithToken(user.Token),
+			httpclient.WithDescription("search user"),
 		)
-		return nil, errors.NewUnexpected("failed to search user", errCall)
-	}
 
-	if len(users) == 0 {
-		return nil, errors.NewNotFound("user not found")
-	}
+		var users []Auth0User
 
-	slog.DebugContext(ctx, "users found, checking if the user is the one with the correct identity",
-		"criteria", criteria,
-	)
+		statusCode, errCall := apiRequest.Call(ctx, &users)
+		if errCall != nil {
+			slog.ErrorContext(ctx, "failed to search user",
+				"error", errCall,
+				"status_code", statusCode,
+				"page", page,
+			)
+			if statusCode == http.StatusTooManyRequests {
+				return nil, httpclient.ErrorFromCall(statusCode, "failed to search user", errCall)
+			}
+			return nil, errors.NewUnexpected("failed to search user", errCall)
+		}
 
-	for _, userResult := range users {
-		// identities.user_id:{{username}} AND identities.connection:Username-Password-Authentication (and other connections)
-		// It doesn't work like an AND, it works like an IN clause
-		// (check if it contains the username and the connection, but they might not be in  the same identity)
-		// So it's necessary to check if the identity is the one we are looking for
-		found, err := filterer.Filter(ctx, &userResult)
-		if err != nil {
-			return nil, err
+		if len(users) == 0 {
+			break
+		}
+
+		slog.DebugContext(ctx, "users found, checking if the user is the one with the correct identity",
+			"criteria", criteria,
+			"page", page,
+		)
+
+		for _, userResult := range users {
+			// identities.user_id:{{username}} AND identities.connection:Username-Password-Authentication (and other connections)
+			// It doesn't work like an AND, it works like an IN clause
+			// (check if it contains the username and the connection, but they might not be in  the same identity)
+			// So it's necessary to check if the identity is the one we are looking for
+			found, err := filterer.Filter(ctx, &userResult)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			return userResult.ToUser(), nil
+		}
+
+		// a partial page is the last one
+		if !paginated || len(users) < searchPerPage {
+			break
 		}
-		if !found {
-			continue
+		if page == pages-1 {
+			slog.WarnContext(ctx, "user not found within the searched pages, more users match the criteria",
+				"criteria", criteria,
+				"max_pages", pages,
+			)
 		}
-		return userResult.ToUser(), nil
 	}
 	return nil, errors.NewNotFound("user not found")
 }
 
 func (u *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
 
-	slog.DebugContext(ctx, "getting user", "user_id", user.UserID)
+	slog.DebugContext(ctx, "getting user", "user", user)
 
 	if user.Token == "" {
-		slog.DebugContext(ctx, "getting M2M token",
-			"user_id", redaction.Redact(user.UserID),
-		)
+		slog.DebugContext(ctx, "getting M2M token", "user", user)
 
 		m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
 		if errGetToken != nil {
@@ -154,21 +267,21 @@ func (u *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*mode
 		slog.ErrorContext(ctx, "failed to get user from Auth0",
 			"error", errCall,
 			"status_code", statusCode,
-			"user_id", user.UserID,
+			"user", user,
 		)
 		msg := u.errorResponse.ErrorMessage(errCall.Error())
-		return nil, httpclient.ErrorFromStatusCode(statusCode, msg)
+		return nil, httpclient.ErrorFromCall(statusCode, msg, errCall)
 	}
 
 	if auth0User == nil {
 		slog.ErrorContext(ctx, "failed to get user from Auth0",
 			"status_code", statusCode,
-			"user_id", user.UserID,
+			"user", user,
 		)
 		return nil, errors.NewNotFound("user not found")
 	}
 
-	slog.DebugContext(ctx, "user retrieved successfully", "user_id", user.UserID)
+	slog.DebugContext(ctx, "user retrieved successfully", "user", user)
 
 	return auth0User.ToUser(), nil
 }
@@ -210,7 +323,7 @@ func (u *userReaderWriter) MetadataLookup(ctx context.Context, input string, req
 		user.Sub = claims.Subject
 
 		slog.DebugContext(ctx, "JWT signature verification successful for metadata lookup",
-			"sub", user.Sub,
+			"user", user,
 		)
 		return user, nil
 
@@ -253,10 +366,14 @@ func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 	}
 
 	// Prepare the request body for updating user metadata
-	if user.UserMetadata == nil {
+	if user.UserMetadata == nil && len(user.ClearFields) == 0 {
 		return nil, errors.NewValidation("user_metadata is required for update")
 	}
-	updateRequest := userUpdateRequest{UserMetadata: user.UserMetadata}
+	metadata, errMetadata := metadataUpdate(user)
+	if errMetadata != nil {
+		return nil, errMetadata
+	}
+	updateRequest := userUpdateRequest{UserMetadata: metadata}
 
 	// Call Auth0 Management API to update the user
 	apiRequest := httpclient.NewAPIRequest(
@@ -277,7 +394,7 @@ func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 		slog.ErrorContext(ctx, "failed to update user in Auth0",
 			"error", errCall,
 			"status_code", statusCode,
-			"user_id", user.UserID,
+			"user", user,
 		)
 		return nil, errors.NewUnexpected("failed to update user in Auth0", errCall)
 	}
@@ -288,7 +405,7 @@ func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 	}
 
 	slog.DebugContext(ctx, "user updated successfully",
-		"user_id", user.UserID,
+		"user", user,
 	)
 	return updatedUser, nil
 }
@@ -455,6 +572,11 @@ func (u *userReaderWriter) UnlinkIdentity(ctx context.Context, request *model.Un
 
 // NewUserReaderWriter  creates a new UserReaderWriter with the provided configuration
 func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, auth0Config Config) (port.UserReaderWriter, error) {
+	return newUserReaderWriter(ctx, httpConfig, auth0Config)
+}
+
+// newUserReaderWriter creates the user reader writer of a single tenant
+func newUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, auth0Config Config) (*userReaderWriter, error) {
 
 	// Add M2M token manager to config
 	m2mTokenManager, err := NewM2MTokenManager(ctx, auth0Config)
@@ -465,11 +587,21 @@ func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, auth
 	auth0Config.M2MTokenManager = m2mTokenManager
 
 	// Create httpClient first
+	if auth0Config.Cassette != nil {
+		httpConfig.Transport = auth0Config.Cassette
+	}
 	httpClient := httpclient.NewClient(httpConfig)
 
 	// JWT verification config is required
 	if auth0Config.JWTVerificationConfig == nil {
-		jwtConfig, errNewJWTVerificationConfig := NewJWTVerificationConfig(ctx, auth0Config.Domain, httpClient)
+		var jwtConfig *JWTVerificationConfig
+		var errNewJWTVerificationConfig error
+		if len(auth0Config.TrustedIssuers) > 0 {
+			// the tenant and the trusted issuers are discovered with OpenID Connect
+			jwtConfig, errNewJWTVerificationConfig = newOIDCVerificationConfig(ctx, auth0Config.Domain, auth0Config.TrustedIssuers, httpClient)
+		} else {
+			jwtConfig, errNewJWTVerificationConfig = NewJWTVerificationConfig(ctx, auth0Config.Domain, httpClient)
+		}
 		if errNewJWTVerificationConfig != nil {
 			return nil, errors.NewUnexpected("failed to create JWT verification config", errNewJWTVerificationConfig)
 		}
@@ -477,10 +609,23 @@ func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, auth
 			return nil, errors.NewUnexpected("JWT verification configuration is required but could not be created")
 		}
 		auth0Config.JWTVerificationConfig = jwtConfig
+
+		// the canary is best effort, it never blocks the start
+		if auth0Config.CanaryJWKSURL != "" {
+			candidate, errCandidate := newJWTVerificationConfigFromJWKS(ctx, auth0Config.CanaryJWKSURL, auth0Config.Domain, httpClient)
+			if errCandidate != nil {
+				slog.WarnContext(ctx, "JWT verifier canary disabled, failed to load the candidate JWKS",
+					"jwks_url", auth0Config.CanaryJWKSURL,
+					"error", errCandidate,
+				)
+			} else {
+				jwtConfig.withCanary(candidate, jwt.WithCanarySampleRate(auth0Config.CanarySampleRate))
+			}
+		}
 	}
 
 	// Create profile client auth config for email linking flow (passwordless)
-	profileClientAuthConfig, err := NewProfileClientAuthConfig(ctx, auth0Config.Domain)
+	profileClientAuthConfig, err := NewProfileClientAuthConfig(ctx, auth0Config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create profile client auth config: %w", err)
 	}
@@ -8,6 +8,10 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +20,7 @@ import (
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,9 +32,9 @@ func createTestJWTVerificationConfig(t *testing.T) (*JWTVerificationConfig, *rsa
 	require.NoError(t, err)
 
 	return &JWTVerificationConfig{
-		PublicKey:        &privateKey.PublicKey,
-		ExpectedIssuer:   "https://test.auth0.com/",
-		ExpectedAudience: "https://test.auth0.com/api/v2/",
+		PublicKey:         &privateKey.PublicKey,
+		ExpectedIssuer:    "https://test.auth0.com/",
+		ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 	}, privateKey
 }
 
@@ -458,6 +463,27 @@ func TestUserReaderWriter_UpdateUser_JSONSerialization(t *testing.T) {
 	t.Logf("Complete JSON output: %s", string(jsonData))
 }
 
+func TestMetadataUpdate(t *testing.T) {
+	t.Run("without cleared fields the metadata is sent as is", func(t *testing.T) {
+		metadata := &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")}
+		update, err := metadataUpdate(&model.User{UserMetadata: metadata})
+		require.NoError(t, err)
+		assert.Same(t, metadata, update)
+	})
+
+	t.Run("cleared fields are sent as null", func(t *testing.T) {
+		update, err := metadataUpdate(&model.User{
+			UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+			ClearFields:  []string{"phone_number", "organization"},
+		})
+		require.NoError(t, err)
+
+		body, errMarshal := json.Marshal(userUpdateRequest{UserMetadata: update})
+		require.NoError(t, errMarshal)
+		assert.JSONEq(t, `{"user_metadata":{"job_title":"Engineer","phone_number":null,"organization":null,"organization_verified":null}}`, string(body))
+	})
+}
+
 // TestUserReaderWriter_UpdateUser_ConfigValidation tests configuration validation in UpdateUser
 func TestUserReaderWriter_UpdateUser_ConfigValidation(t *testing.T) {
 	ctx := context.Background()
@@ -776,3 +802,95 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestUserReaderWriter_SearchUser_Pagination(t *testing.T) {
+	// the user with the alternate email is on the second page of the results
+	var pages []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		assert.Equal(t, strconv.Itoa(searchPerPage), r.URL.Query().Get("per_page"))
+
+		var users []Auth0User
+		switch r.URL.Query().Get("page") {
+		case "0":
+			for i := range searchPerPage {
+				users = append(users, Auth0User{
+					UserID: fmt.Sprintf("auth0|other-%d", i),
+					Identities: []Auth0Identity{{
+						Connection:  emailAuthenticationFilter,
+						ProfileData: &Auth0ProfileData{Email: fmt.Sprintf("other-%d@example.com", i)},
+					}},
+				})
+			}
+		case "1":
+			users = append(users, Auth0User{
+				UserID: "auth0|jdoe",
+				Identities: []Auth0Identity{{
+					Connection:  emailAuthenticationFilter,
+					ProfileData: &Auth0ProfileData{Email: "jdoe@example.com"},
+				}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	}))
+	defer server.Close()
+
+	newReaderWriter := func(maxPages int) *userReaderWriter {
+		httpConfig := httpclient.DefaultConfig()
+		httpConfig.Transport = server.Client().Transport
+		return &userReaderWriter{
+			httpClient: httpclient.NewClient(httpConfig),
+			config: Config{
+				Domain:         strings.TrimPrefix(server.URL, "https://"),
+				SearchMaxPages: maxPages,
+			},
+		}
+	}
+	search := func() *model.User {
+		return &model.User{
+			Token:           "token",
+			AlternateEmails: []model.Email{{Email: "jdoe@example.com"}},
+		}
+	}
+
+	found, err := newReaderWriter(0).SearchUser(context.Background(), search(), constants.CriteriaTypeAlternateEmail)
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|jdoe", found.UserID)
+	assert.Equal(t, []string{"0", "1"}, pages)
+
+	// the user is beyond the pages read
+	pages = nil
+	_, err = newReaderWriter(1).SearchUser(context.Background(), search(), constants.CriteriaTypeAlternateEmail)
+	require.Error(t, err)
+	_, isNotFound := err.(errors.NotFound)
+	assert.True(t, isNotFound, "expected not found error, got %T", err)
+	assert.Equal(t, []string{"0"}, pages)
+}
+
+func TestUserReaderWriter_GetUser_RetriesExhausted(t *testing.T) {
+	// Auth0 keeps failing, the error of the last attempt is parsed as usual
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"statusCode":503,"error":"Service Unavailable","message":"The service is temporarily unavailable"}`))
+	}))
+	defer server.Close()
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.Transport = server.Client().Transport
+	httpConfig.RetryDelay = time.Millisecond
+	writer := &userReaderWriter{
+		httpClient:    httpclient.NewClient(httpConfig),
+		errorResponse: NewErrorResponse(),
+		config:        Config{Domain: strings.TrimPrefix(server.URL, "https://")},
+	}
+
+	_, err := writer.GetUser(context.Background(), &model.User{UserID: "auth0|123", Token: "token"})
+	require.Error(t, err)
+	assert.Equal(t, httpConfig.MaxRetries+1, requests)
+	_, isUnavailable := err.(errors.ServiceUnavailable)
+	assert.True(t, isUnavailable, "expected service unavailable error, got %T", err)
+	assert.Equal(t, "The service is temporarily unavailable", err.Error())
+}
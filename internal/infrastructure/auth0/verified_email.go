@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// passwordlessUserRequest creates the user of the passwordless email connection, the email was
+// verified by the service
+type passwordlessUserRequest struct {
+	Connection    string `json:"connection"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// passwordlessUser returns the user of the passwordless email connection with the email, created
+// when the email never signed in
+func (u *userReaderWriter) passwordlessUser(ctx context.Context, m2mToken, email string) (string, error) {
+	lookup := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users-by-email?email=%s", u.config.Domain, url.QueryEscape(email))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("lookup passwordless user by email"),
+	)
+
+	var users []Auth0User
+	statusCode, errLookup := lookup.Call(ctx, &users)
+	if errLookup != nil {
+		slog.ErrorContext(ctx, "failed to lookup passwordless user in Auth0",
+			"error", errLookup,
+			"status_code", statusCode,
+		)
+		return "", errors.NewUnexpected("failed to lookup passwordless user", errLookup)
+	}
+	for _, user := range users {
+		if strings.HasPrefix(user.UserID, emailAuthenticationFilter+"|") {
+			return user.UserID, nil
+		}
+	}
+
+	create := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodPost),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users", u.config.Domain)),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("create passwordless user"),
+		httpclient.WithBody(passwordlessUserRequest{
+			Connection:    emailAuthenticationFilter,
+			Email:         email,
+			EmailVerified: true,
+		}),
+	)
+
+	var created Auth0User
+	statusCode, errCreate := create.Call(ctx, &created)
+	if errCreate != nil {
+		slog.ErrorContext(ctx, "failed to create passwordless user in Auth0",
+			"error", errCreate,
+			"status_code", statusCode,
+		)
+		return "", errors.NewUnexpected("failed to create passwordless user", errCreate)
+	}
+	return created.UserID, nil
+}
+
+// LinkVerifiedEmail links the passwordless email identity of an email verified by the service
+// (with a backup code) to the user, using the M2M token: there is no ID token of the email to link
+// with the user token. The caller is responsible for the verification of the email.
+func (u *userReaderWriter) LinkVerifiedEmail(ctx context.Context, user *model.User, email string) error {
+	if user == nil || strings.TrimSpace(user.UserID) == "" {
+		return errors.NewValidation("user_id is required")
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !(&model.Email{Email: email}).IsValidEmail() {
+		return errors.NewValidation("invalid email")
+	}
+
+	m2mToken, errGetToken := u.config.M2MTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	secondaryUserID, errSecondary := u.passwordlessUser(ctx, m2mToken, email)
+	if errSecondary != nil {
+		return errSecondary
+	}
+	provider, id, errSplit := splitUserID(secondaryUserID)
+	if errSplit != nil {
+		return errSplit
+	}
+
+	link := httpclient.NewAPIRequest(
+		u.httpClient,
+		httpclient.WithMethod(http.MethodPost),
+		httpclient.WithURL(fmt.Sprintf("https://%s/api/v2/users/%s/identities", u.config.Domain, url.PathEscape(user.UserID))),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription("link verified email"),
+		httpclient.WithBody(linkAccountPayload{Provider: provider, UserID: id}),
+	)
+
+	var linkedIdentities []any
+	statusCode, errLink := link.Call(ctx, &linkedIdentities)
+	if errLink != nil {
+		slog.ErrorContext(ctx, "failed to link verified email in Auth0",
+			"error", errLink,
+			"status_code", statusCode,
+			"user_id", redaction.Redact(user.UserID),
+		)
+		return errors.NewUnexpected("failed to link verified email", errLink)
+	}
+
+	slog.DebugContext(ctx, "verified email linked successfully",
+		"user_id", redaction.Redact(user.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return nil
+}
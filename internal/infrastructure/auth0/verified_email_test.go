@@ -0,0 +1,94 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package auth0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestUserReaderWriter_LinkVerifiedEmail(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     []Auth0User
+		wantCreated  bool
+		wantLinkedID string
+	}{
+		{
+			name:         "passwordless user created",
+			existing:     []Auth0User{{UserID: "auth0|other"}},
+			wantCreated:  true,
+			wantLinkedID: "new-email-id",
+		},
+		{
+			name:         "existing passwordless user",
+			existing:     []Auth0User{{UserID: "email|existing-id"}},
+			wantLinkedID: "existing-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				created bool
+				linked  linkAccountPayload
+			)
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer m2m-token", r.Header.Get("Authorization"))
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v2/users-by-email":
+					assert.Equal(t, "jane@personal.example", r.URL.Query().Get("email"))
+					_ = json.NewEncoder(w).Encode(tt.existing)
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v2/users":
+					var request passwordlessUserRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+					assert.Equal(t, passwordlessUserRequest{Connection: "email", Email: "jane@personal.example", EmailVerified: true}, request)
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(Auth0User{UserID: "email|new-email-id"})
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v2/users/auth0|jane/identities":
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&linked))
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`[]`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			httpConfig := httpclient.DefaultConfig()
+			httpConfig.Transport = server.Client().Transport
+			u := &userReaderWriter{
+				httpClient: httpclient.NewClient(httpConfig),
+				config: Config{
+					Domain:          strings.TrimPrefix(server.URL, "https://"),
+					M2MTokenManager: &TokenManager{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "m2m-token"})},
+				},
+			}
+
+			err := u.LinkVerifiedEmail(context.Background(), &model.User{UserID: "auth0|jane"}, " Jane@Personal.example ")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, linkAccountPayload{Provider: "email", UserID: tt.wantLinkedID}, linked)
+		})
+	}
+}
+
+func TestUserReaderWriter_LinkVerifiedEmail_Validation(t *testing.T) {
+	u := &userReaderWriter{config: Config{Domain: "test.auth0.com"}}
+
+	assert.Error(t, u.LinkVerifiedEmail(context.Background(), nil, "jane@personal.example"))
+	assert.Error(t, u.LinkVerifiedEmail(context.Background(), &model.User{UserID: "auth0|jane"}, "not an email"))
+}
@@ -5,12 +5,11 @@ package authelia
 
 import (
 	"context"
-	"fmt"
+	stderrors "errors"
 	"log/slog"
 
-	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
-	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/smtp"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/password"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
@@ -22,10 +21,10 @@ type passwordlessFlow interface {
 }
 
 type autheliaPasswordlessFlow struct {
-	emailSender port.EmailSender
+	emailSender port.TemplatedEmailSender
 }
 
-func (a *autheliaPasswordlessFlow) SendEmail(ctx context.Context, email string) (string, error) {
+func (a *autheliaPasswordlessFlow) SendEmail(ctx context.Context, emailAddress string) (string, error) {
 
 	otp, err := password.OnlyNumbers(6)
 	if err != nil {
@@ -33,21 +32,18 @@ func (a *autheliaPasswordlessFlow) SendEmail(ctx context.Context, email string)
 		return "", errors.NewUnexpected("failed to generate OTP", err)
 	}
 
-	message := &model.EmailMessage{
-		From:    "noreply@lfx.dev",
-		To:      email,
-		Subject: "Welcome to Linux Foundation",
-		Body:    fmt.Sprintf("Your verification code is: %s", otp),
-	}
-
-	errSendEmail := a.emailSender.SendEmail(ctx, message)
+	errSendEmail := a.emailSender.SendTemplatedEmail(ctx, email.TemplateEmailVerification, emailAddress, map[string]string{"OTP": otp})
 	if errSendEmail != nil {
 		slog.ErrorContext(ctx, "failed to send email", "error", errSendEmail)
+		var tooManyRequests errors.TooManyRequests
+		if stderrors.As(errSendEmail, &tooManyRequests) {
+			return "", errSendEmail
+		}
 		return "", errors.NewUnexpected("failed to send email", errSendEmail)
 	}
 
 	slog.InfoContext(ctx, "passwordless flow email sent",
-		"email", redaction.RedactEmail(email),
+		"email", redaction.RedactEmail(emailAddress),
 	)
 
 	return otp, nil
@@ -57,8 +53,12 @@ func (a *autheliaPasswordlessFlow) LoginWithEmail(ctx context.Context) error {
 	return nil
 }
 
-func newEmailLinkingFlow() passwordlessFlow {
-	return &autheliaPasswordlessFlow{
-		emailSender: smtp.NewSender(),
+func newEmailLinkingFlow() (passwordlessFlow, error) {
+	emailSender, err := email.NewTemplatedSender()
+	if err != nil {
+		return nil, err
 	}
+	return &autheliaPasswordlessFlow{
+		emailSender: emailSender,
+	}, nil
 }
@@ -0,0 +1,291 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	userEventSet     = "user_set"
+	userEventDeleted = "user_deleted"
+
+	// journalFetchBatch is the number of events fetched at once while replaying
+	journalFetchBatch = 256
+)
+
+// UserEvent is a change of an authelia user, the journal keeps all of them in order
+type UserEvent struct {
+	Sequence     uint64               `json:"-"`
+	Type         string               `json:"type"`
+	Username     string               `json:"username"`
+	At           time.Time            `json:"at"`
+	OriginRegion string               `json:"origin_region,omitempty"`
+	User         *AutheliaUserStorage `json:"user,omitempty"`
+}
+
+// userEventJournal appends and replays the authelia user events
+type userEventJournal interface {
+	Append(ctx context.Context, event UserEvent) error
+	Replay(ctx context.Context, fn func(UserEvent) error) error
+}
+
+// natsUserEventJournal implements userEventJournal on a JetStream stream
+type natsUserEventJournal struct {
+	js     jetstream.JetStream
+	stream string
+}
+
+// Append publishes the event and waits for the stream acknowledgement
+func (n *natsUserEventJournal) Append(ctx context.Context, event UserEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errs.NewUnexpected("failed to marshal user event", err)
+	}
+
+	subject := fmt.Sprintf("%s.%s", constants.AutheliaUserEventsSubject, event.Type)
+	if _, err := n.js.Publish(ctx, subject, data); err != nil {
+		return errs.NewUnexpected("failed to append user event to the journal", err)
+	}
+	return nil
+}
+
+// Replay calls fn for every event in the stream, from the oldest to the newest
+func (n *natsUserEventJournal) Replay(ctx context.Context, fn func(UserEvent) error) error {
+
+	stream, err := n.js.Stream(ctx, n.stream)
+	if err != nil {
+		return errs.NewUnexpected("failed to get user events stream", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return errs.NewUnexpected("failed to get user events stream info", err)
+	}
+	lastSequence := info.State.LastSeq
+	if info.State.Msgs == 0 {
+		return nil
+	}
+
+	consumer, err := n.js.OrderedConsumer(ctx, n.stream, jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+	})
+	if err != nil {
+		return errs.NewUnexpected("failed to create user events consumer", err)
+	}
+
+	for {
+		batch, err := consumer.Fetch(journalFetchBatch, jetstream.FetchMaxWait(time.Second))
+		if err != nil {
+			return errs.NewUnexpected("failed to fetch user events", err)
+		}
+
+		for msg := range batch.Messages() {
+			metadata, err := msg.Metadata()
+			if err != nil {
+				return errs.NewUnexpected("failed to read user event metadata", err)
+			}
+
+			var event UserEvent
+			if err := json.Unmarshal(msg.Data(), &event); err != nil {
+				return errs.NewUnexpected("failed to unmarshal user event", err)
+			}
+			event.Sequence = metadata.Sequence.Stream
+
+			if err := fn(event); err != nil {
+				return err
+			}
+			if event.Sequence >= lastSequence {
+				return nil
+			}
+		}
+		if batch.Error() != nil {
+			return errs.NewUnexpected("failed to fetch user events", batch.Error())
+		}
+	}
+}
+
+// eventSourcedStorage journals every user change before applying it to the KV store,
+// which then works as a projection that can be rebuilt from the journal
+type eventSourcedStorage struct {
+	internalStorageReaderWriter
+	journal userEventJournal
+	region  string
+	now     func() time.Time
+}
+
+func (e *eventSourcedStorage) appendUser(ctx context.Context, eventType string, user *AutheliaUser) error {
+	errAppend := e.journal.Append(ctx, UserEvent{
+		Type:         eventType,
+		Username:     user.Username,
+		At:           e.now(),
+		OriginRegion: e.region,
+		User:         user.ToStorage(),
+	})
+	if errAppend != nil {
+		slog.ErrorContext(ctx, "failed to journal user event",
+			"error", errAppend,
+			"type", eventType,
+			"username", redaction.Redact(user.Username),
+		)
+	}
+	return errAppend
+}
+
+// SetUser journals the new user state and stores it
+func (e *eventSourcedStorage) SetUser(ctx context.Context, user *AutheliaUser) (any, error) {
+	result, err := e.internalStorageReaderWriter.SetUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if errAppend := e.appendUser(ctx, userEventSet, user); errAppend != nil {
+		return nil, errAppend
+	}
+	return result, nil
+}
+
+// UpdateUserWithRevision journals the new user state and stores it
+func (e *eventSourcedStorage) UpdateUserWithRevision(ctx context.Context, user *AutheliaUser, revision uint64) error {
+	if err := e.internalStorageReaderWriter.UpdateUserWithRevision(ctx, user, revision); err != nil {
+		return err
+	}
+	return e.appendUser(ctx, userEventSet, user)
+}
+
+// SoftDeleteUser journals the tombstone and soft-deletes the user
+func (e *eventSourcedStorage) SoftDeleteUser(ctx context.Context, key string) error {
+	user, err := e.GetUser(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := e.internalStorageReaderWriter.SoftDeleteUser(ctx, key); err != nil {
+		return err
+	}
+	return e.journal.Append(ctx, UserEvent{
+		Type:         userEventDeleted,
+		Username:     user.Username,
+		At:           e.now(),
+		OriginRegion: e.region,
+	})
+}
+
+// RestoreUser journals the restored user state and restores it
+func (e *eventSourcedStorage) RestoreUser(ctx context.Context, key string, gracePeriod time.Duration) (*AutheliaUser, error) {
+	user, err := e.internalStorageReaderWriter.RestoreUser(ctx, key, gracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	if errAppend := e.appendUser(ctx, userEventSet, user); errAppend != nil {
+		return nil, errAppend
+	}
+	return user, nil
+}
+
+// projectUsers replays the journal and returns the latest state of every user
+func projectUsers(ctx context.Context, journal userEventJournal) (map[string]*AutheliaUser, error) {
+	states := make(map[string]*AutheliaUserStorage)
+
+	errReplay := journal.Replay(ctx, func(event UserEvent) error {
+		switch event.Type {
+		case userEventSet:
+			if event.User != nil {
+				states[event.Username] = event.User
+			}
+		case userEventDeleted:
+			if state, ok := states[event.Username]; ok {
+				deletedAt := event.At
+				state.DeletedAt = &deletedAt
+			}
+		default:
+			slog.WarnContext(ctx, "unknown user event type, skipping",
+				"type", event.Type,
+				"sequence", event.Sequence,
+			)
+		}
+		return nil
+	})
+	if errReplay != nil {
+		return nil, errReplay
+	}
+
+	users := make(map[string]*AutheliaUser, len(states))
+	for username, state := range states {
+		user := &AutheliaUser{}
+		user.FromStorage(state)
+		users[username] = user
+	}
+	return users, nil
+}
+
+// rebuildStorage writes the journal projection into the storage, overwriting the stored users
+func rebuildStorage(ctx context.Context, journal userEventJournal, storage internalStorageWriter) (int, error) {
+	users, err := projectUsers(ctx, journal)
+	if err != nil {
+		return 0, err
+	}
+	for username, user := range users {
+		if _, err := storage.SetUser(ctx, user); err != nil {
+			return 0, errs.NewUnexpected(fmt.Sprintf("failed to rebuild user %s", redaction.Redact(username)), err)
+		}
+	}
+	return len(users), nil
+}
+
+// userTimeline returns all the journaled events of the user, oldest first
+func userTimeline(ctx context.Context, journal userEventJournal, username string) ([]UserEvent, error) {
+	var events []UserEvent
+	err := journal.Replay(ctx, func(event UserEvent) error {
+		if event.Username == username {
+			events = append(events, event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+func newNATSUserEventJournal(ctx context.Context, natsClient *nats.NATSClient) (userEventJournal, error) {
+	js, err := natsClient.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := js.Stream(ctx, constants.StreamNameAutheliaUserEvents); err != nil {
+		return nil, errs.NewUnexpected("user events stream not found in NATS", err)
+	}
+	return &natsUserEventJournal{
+		js:     js,
+		stream: constants.StreamNameAutheliaUserEvents,
+	}, nil
+}
+
+// RebuildStorage rebuilds the authelia users KV store from the events journal
+func RebuildStorage(ctx context.Context, natsClient *nats.NATSClient) (int, error) {
+	journal, err := newNATSUserEventJournal(ctx, natsClient)
+	if err != nil {
+		return 0, err
+	}
+	storage, err := newNATSUserStorage(ctx, natsClient, "")
+	if err != nil {
+		return 0, err
+	}
+	return rebuildStorage(ctx, journal, storage)
+}
+
+// UserTimeline returns the journaled events of the user, oldest first
+func UserTimeline(ctx context.Context, natsClient *nats.NATSClient, username string) ([]UserEvent, error) {
+	journal, err := newNATSUserEventJournal(ctx, natsClient)
+	if err != nil {
+		return nil, err
+	}
+	return userTimeline(ctx, journal, username)
+}
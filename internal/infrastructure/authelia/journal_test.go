@@ -0,0 +1,128 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// memoryJournal is an in-memory userEventJournal for testing
+type memoryJournal struct {
+	events    []UserEvent
+	appendErr error
+}
+
+func (m *memoryJournal) Append(ctx context.Context, event UserEvent) error {
+	if m.appendErr != nil {
+		return m.appendErr
+	}
+	event.Sequence = uint64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *memoryJournal) Replay(ctx context.Context, fn func(UserEvent) error) error {
+	for _, event := range m.events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestEventSourcedStorage_JournalsChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	inner := &mockStorageReaderWriter{users: map[string]*AutheliaUser{}}
+	journal := &memoryJournal{}
+	storage := &eventSourcedStorage{
+		internalStorageReaderWriter: inner,
+		journal:                     journal,
+		now:                         func() time.Time { return now },
+	}
+
+	user := &AutheliaUser{User: &model.User{Username: "jane"}, Email: "jane@example.com"}
+	if _, err := storage.SetUser(ctx, user); err != nil {
+		t.Fatalf("SetUser() unexpected error: %v", err)
+	}
+	if err := storage.UpdateUserWithRevision(ctx, user, 1); err != nil {
+		t.Fatalf("UpdateUserWithRevision() unexpected error: %v", err)
+	}
+	if err := storage.SoftDeleteUser(ctx, "jane"); err != nil {
+		t.Fatalf("SoftDeleteUser() unexpected error: %v", err)
+	}
+
+	wantTypes := []string{userEventSet, userEventSet, userEventDeleted}
+	if len(journal.events) != len(wantTypes) {
+		t.Fatalf("journaled %d events, want %d", len(journal.events), len(wantTypes))
+	}
+	for i, event := range journal.events {
+		if event.Type != wantTypes[i] || event.Username != "jane" {
+			t.Errorf("event %d = %s/%s, want %s/jane", i, event.Type, event.Username, wantTypes[i])
+		}
+	}
+
+	t.Run("journal failure is reported", func(t *testing.T) {
+		journal.appendErr = errors.New("stream unavailable")
+		defer func() { journal.appendErr = nil }()
+		if _, err := storage.SetUser(ctx, user); err == nil {
+			t.Error("SetUser() expected error when the journal is unavailable")
+		}
+	})
+}
+
+func TestRebuildStorage(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	journal := &memoryJournal{}
+	_ = journal.Append(ctx, UserEvent{Type: userEventSet, Username: "jane", User: &AutheliaUserStorage{Username: "jane", Email: "old@example.com"}})
+	_ = journal.Append(ctx, UserEvent{Type: userEventSet, Username: "john", User: &AutheliaUserStorage{Username: "john", Email: "john@example.com"}})
+	_ = journal.Append(ctx, UserEvent{Type: userEventSet, Username: "jane", User: &AutheliaUserStorage{Username: "jane", Email: "jane@example.com"}})
+	_ = journal.Append(ctx, UserEvent{Type: userEventDeleted, Username: "john", At: deletedAt})
+	_ = journal.Append(ctx, UserEvent{Type: "unknown", Username: "jane"})
+
+	target := &mockStorageReaderWriter{}
+	rebuilt, err := rebuildStorage(ctx, journal, target)
+	if err != nil {
+		t.Fatalf("rebuildStorage() unexpected error: %v", err)
+	}
+	if rebuilt != 2 {
+		t.Fatalf("rebuildStorage() = %d, want 2", rebuilt)
+	}
+	if got := target.users["jane"].Email; got != "jane@example.com" {
+		t.Errorf("jane email = %q, want the latest state", got)
+	}
+	if john := target.users["john"]; !john.IsDeleted() || !john.DeletedAt.Equal(deletedAt) {
+		t.Errorf("john should be soft-deleted at %v, got %v", deletedAt, john.DeletedAt)
+	}
+
+	t.Run("write failure", func(t *testing.T) {
+		if _, err := rebuildStorage(ctx, journal, &mockStorageReaderWriter{setErr: errors.New("kv down")}); err == nil {
+			t.Error("rebuildStorage() expected error")
+		}
+	})
+}
+
+func TestUserTimeline(t *testing.T) {
+	ctx := context.Background()
+	journal := &memoryJournal{}
+	_ = journal.Append(ctx, UserEvent{Type: userEventSet, Username: "jane"})
+	_ = journal.Append(ctx, UserEvent{Type: userEventSet, Username: "john"})
+	_ = journal.Append(ctx, UserEvent{Type: userEventDeleted, Username: "jane"})
+
+	events, err := userTimeline(ctx, journal, "jane")
+	if err != nil {
+		t.Fatalf("userTimeline() unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].Sequence != 1 || events[1].Sequence != 3 {
+		t.Errorf("userTimeline() = %+v, want sequences 1 and 3", events)
+	}
+}
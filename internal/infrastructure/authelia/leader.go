@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/lock"
+)
+
+const (
+	// syncLockName serializes the startup sync of the replicas, each one restarts the origin
+	syncLockName = "authelia/sync"
+	// staleProfileScanLockName elects the replica running the stale profile scans
+	staleProfileScanLockName = "authelia/stale-profile-scan"
+	// indexReconcileLockName elects the replica reconciling the lookup index
+	indexReconcileLockName = "authelia/index-reconcile"
+	// emailReindexLockName elects the replica rebuilding the email lookup keys
+	emailReindexLockName = "authelia/email-reindex"
+
+	// syncLockWait bounds the wait for the sync of another replica, the sync runs anyway after it
+	syncLockWait = 2 * time.Minute
+)
+
+// syncUsersLocked runs the sync holding the sync lock when the replicas are coordinated,
+// so the replicas starting together don't update and restart the origin over each other
+func (u *userReaderWriter) syncUsersLocked(ctx context.Context, locker *lock.Locker) error {
+	if locker == nil {
+		return u.sync.syncUsers(ctx, u.storage, u.orchestrator)
+	}
+
+	acquireCtx, cancel := context.WithTimeout(ctx, syncLockWait)
+	defer cancel()
+
+	lk, err := locker.Acquire(acquireCtx, syncLockName)
+	if err != nil {
+		return err
+	}
+	syncCtx, release := lk.Hold(ctx)
+	defer release()
+
+	return u.sync.syncUsers(syncCtx, u.storage, u.orchestrator)
+}
+
+// runJob runs the background job until the context is done, on a single replica at a time
+// when the replicas are coordinated
+func runJob(ctx context.Context, locker *lock.Locker, name string, job func(ctx context.Context)) {
+	if locker == nil {
+		go job(ctx)
+		return
+	}
+	go locker.Lead(ctx, name, job)
+}
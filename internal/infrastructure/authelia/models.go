@@ -30,6 +30,15 @@ type AutheliaUser struct {
 	DisplayName string    `json:"displayname"` // display name for Authelia
 	CreatedAt   time.Time `json:"created_at"`  // creation timestamp
 	UpdatedAt   time.Time `json:"updated_at"`  // update timestamp
+	Disabled    bool      `json:"disabled"`    // disabled flag from the Authelia users database
+
+	// DeletedAt is the soft-delete tombstone, the user is kept in storage
+	// until the restore grace period is over
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Region is the region that last wrote the user, used to resolve concurrent
+	// writes when running active/active across regions
+	Region string `json:"region,omitempty"`
 
 	// not part of the user model, but used to track if the user is missing from the orchestrator
 	// or if the password needs to be updated
@@ -48,6 +57,8 @@ type AutheliaUserStorage struct {
 	Identities     []model.Identity    `json:"identities,omitempty"`      // linked social identities
 	CreatedAt      time.Time           `json:"created_at"`                // creation timestamp
 	UpdatedAt      time.Time           `json:"updated_at"`                // update timestamp
+	DeletedAt      *time.Time          `json:"deleted_at,omitempty"`      // soft-delete tombstone
+	Region         string              `json:"region,omitempty"`          // region of the last write
 }
 
 // SetUsername sets the username for the user
@@ -84,9 +95,16 @@ func (a *AutheliaUser) ToStorage() *AutheliaUserStorage {
 		Identities:     identities,
 		CreatedAt:      a.CreatedAt,
 		UpdatedAt:      a.UpdatedAt,
+		DeletedAt:      a.DeletedAt,
+		Region:         a.Region,
 	}
 }
 
+// IsDeleted reports whether the user has been soft-deleted
+func (a *AutheliaUser) IsDeleted() bool {
+	return a.DeletedAt != nil
+}
+
 // FromStorage converts AutheliaUserStorage to AutheliaUser
 func (a *AutheliaUser) FromStorage(storage *AutheliaUserStorage) {
 	if a.User == nil {
@@ -95,6 +113,8 @@ func (a *AutheliaUser) FromStorage(storage *AutheliaUserStorage) {
 	a.Username = storage.Username
 	a.UserMetadata = storage.UserMetadata
 	a.PrimaryEmail = storage.Email
+	// the emails of the users database are provisioned by the operators, Authelia reports them verified
+	a.PrimaryEmailVerified = storage.Email != ""
 	a.AlternateEmails = storage.AlternateEmail
 	a.Identities = storage.Identities
 	// for consistency in naming across implementations,
@@ -105,6 +125,8 @@ func (a *AutheliaUser) FromStorage(storage *AutheliaUserStorage) {
 	a.DisplayName = storage.DisplayName
 	a.CreatedAt = storage.CreatedAt
 	a.UpdatedAt = storage.UpdatedAt
+	a.DeletedAt = storage.DeletedAt
+	a.Region = storage.Region
 }
 
 // AutheliaUserYAML represents the YAML structure for Authelia users_database.yml
@@ -112,6 +134,7 @@ type AutheliaUserYAML struct {
 	DisplayName string `yaml:"displayname"`
 	Password    string `yaml:"password"`
 	Email       string `yaml:"email"`
+	Disabled    bool   `yaml:"disabled,omitempty"`
 }
 
 // ToAutheliaYAML converts AutheliaUser to the format expected by Authelia
@@ -120,6 +143,7 @@ func (a *AutheliaUser) ToAutheliaYAML() AutheliaUserYAML {
 		DisplayName: a.DisplayName,
 		Password:    a.Password,
 		Email:       a.Email,
+		Disabled:    a.IsDeleted(),
 	}
 }
 
@@ -0,0 +1,223 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// defaultIndexReconcileInterval is how often the lookup index is reconciled with the users
+const defaultIndexReconcileInterval = 10 * time.Minute
+
+// lookupEntry is the value of a lookup key, a last-writer-wins register so the
+// index converges when two regions write the same key concurrently
+type lookupEntry struct {
+	Username  string    `json:"username"`
+	Region    string    `json:"region,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// parseLookupEntry decodes a lookup value, values written before the multi-region
+// support are the plain username and lose against any timestamped entry
+func parseLookupEntry(value []byte) lookupEntry {
+	var entry lookupEntry
+	if len(value) > 0 && value[0] == '{' && json.Unmarshal(value, &entry) == nil && entry.Username != "" {
+		return entry
+	}
+	return lookupEntry{Username: string(value)}
+}
+
+// wins reports whether the entry takes precedence over the other one, the latest
+// update wins and ties are broken by region and username, so every region picks
+// the same winner whatever the order the writes are seen in
+func (l lookupEntry) wins(other lookupEntry) bool {
+	if !l.UpdatedAt.Equal(other.UpdatedAt) {
+		return l.UpdatedAt.After(other.UpdatedAt)
+	}
+	if l.Region != other.Region {
+		return l.Region > other.Region
+	}
+	return l.Username > other.Username
+}
+
+// mergeLookup returns the winner of the two entries
+func mergeLookup(a, b lookupEntry) lookupEntry {
+	if b.wins(a) {
+		return b
+	}
+	return a
+}
+
+// expectedLookups derives the lookup index from the users, when several users claim
+// the same key the last updated one wins
+func expectedLookups(ctx context.Context, storage internalStorageReader, users map[string]*AutheliaUser) map[string]lookupEntry {
+	expected := make(map[string]lookupEntry)
+	claim := func(key string, entry lookupEntry) {
+		if current, ok := expected[key]; ok {
+			entry = mergeLookup(current, entry)
+		}
+		expected[key] = entry
+	}
+
+	for username, user := range users {
+		if user == nil || user.User == nil {
+			continue
+		}
+		user.SetUsername(username)
+		entry := lookupEntry{Username: username, Region: user.Region, UpdatedAt: user.UpdatedAt}
+
+		if user.Email != "" {
+			claim(storage.BuildLookupKey(ctx, "email", user.BuildEmailIndexKey(ctx)), entry)
+		}
+		for _, alternateEmail := range user.AlternateEmails {
+			claim(storage.BuildLookupKey(ctx, "email", user.BuildAlternateEmailIndexKey(ctx, alternateEmail.Email)), entry)
+		}
+		if user.Sub != "" {
+			claim(storage.BuildLookupKey(ctx, "sub", user.BuildSubIndexKey(ctx)), entry)
+		}
+	}
+	return expected
+}
+
+// lookupIndex is the low level access to the lookup keys needed by the reconciliation
+type lookupIndex interface {
+	ListLookups(ctx context.Context) (map[string]lookupEntry, error)
+	PutLookup(ctx context.Context, key string, entry lookupEntry) error
+	DeleteLookup(ctx context.Context, key string) error
+	EmailIndexVersion(ctx context.Context) (string, error)
+	SetEmailIndexVersion(ctx context.Context, version string) error
+}
+
+// indexReconciler rewrites the lookup keys from the users records, fixing the
+// entries left behind by concurrent writes replicated from other regions
+type indexReconciler struct {
+	storage  internalStorageReader
+	index    lookupIndex
+	interval time.Duration
+}
+
+// reconcile runs a single pass and returns the number of keys rewritten or deleted
+func (r *indexReconciler) reconcile(ctx context.Context) (int, error) {
+	users, errList := r.storage.ListUsers(ctx)
+	if errList != nil {
+		return 0, errList
+	}
+	current, errLookups := r.index.ListLookups(ctx)
+	if errLookups != nil {
+		return 0, errLookups
+	}
+
+	expected := expectedLookups(ctx, r.storage, users)
+
+	fixed := 0
+	for key, entry := range expected {
+		stored, ok := current[key]
+		if ok && stored == entry {
+			continue
+		}
+		if ok && stored.Username != entry.Username {
+			slog.WarnContext(ctx, "lookup key conflict resolved",
+				"key", redaction.Redact(key),
+				"stored_region", stored.Region,
+				"winner_region", entry.Region,
+			)
+		}
+		if errPut := r.index.PutLookup(ctx, key, entry); errPut != nil {
+			return fixed, errPut
+		}
+		fixed++
+	}
+
+	for key := range current {
+		if _, ok := expected[key]; ok {
+			continue
+		}
+		// no user claims the key anymore, e.g. an alternate email removed in another region
+		if errDelete := r.index.DeleteLookup(ctx, key); errDelete != nil {
+			return fixed, errDelete
+		}
+		fixed++
+	}
+
+	slog.InfoContext(ctx, "lookup index reconciled",
+		"users", len(users),
+		"lookups", len(current),
+		"fixed", fixed,
+	)
+	return fixed, nil
+}
+
+// reindex rebuilds the email lookup keys when the canonicalization rules changed since they were
+// built, e.g. on the first start with the rules or when EMAIL_NORMALIZATION is toggled
+func (r *indexReconciler) reindex(ctx context.Context) error {
+	built, errVersion := r.index.EmailIndexVersion(ctx)
+	if errVersion != nil {
+		return errVersion
+	}
+	version := emailnorm.Version()
+	if built == version {
+		return nil
+	}
+
+	fixed, errReconcile := r.reconcile(ctx)
+	if errReconcile != nil {
+		return errReconcile
+	}
+	slog.InfoContext(ctx, "email lookup keys rebuilt",
+		"built_version", built,
+		"version", version,
+		"fixed", fixed,
+	)
+	return r.index.SetEmailIndexVersion(ctx, version)
+}
+
+// runReindex rebuilds the email lookup keys when needed, then holds the job until the context
+// is done so the elected replica doesn't run it again
+func (r *indexReconciler) runReindex(ctx context.Context) {
+	if err := r.reindex(ctx); err != nil {
+		slog.WarnContext(ctx, "email lookup keys rebuild failed", "error", err)
+		return
+	}
+	<-ctx.Done()
+}
+
+// run reconciles the index periodically until the context is cancelled
+func (r *indexReconciler) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.reconcile(ctx); err != nil {
+				slog.WarnContext(ctx, "lookup index reconciliation failed", "error", err)
+			}
+		}
+	}
+}
+
+func newIndexReconciler(storage internalStorageReader, index lookupIndex, interval time.Duration) *indexReconciler {
+	if interval <= 0 {
+		interval = defaultIndexReconcileInterval
+	}
+	return &indexReconciler{
+		storage:  storage,
+		index:    index,
+		interval: interval,
+	}
+}
+
+// isLookupKey reports whether the key belongs to the lookup index
+func isLookupKey(key string) bool {
+	return strings.HasPrefix(key, kvLookupPrefix)
+}
@@ -0,0 +1,259 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
+)
+
+func TestParseLookupEntry(t *testing.T) {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  lookupEntry
+	}{
+		{name: "legacy username", value: "jane", want: lookupEntry{Username: "jane"}},
+		{
+			name:  "timestamped entry",
+			value: `{"username":"jane","region":"eu","updated_at":"2025-01-01T00:00:00Z"}`,
+			want:  lookupEntry{Username: "jane", Region: "eu", UpdatedAt: at},
+		},
+		{name: "json without username", value: `{"region":"eu"}`, want: lookupEntry{Username: `{"region":"eu"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLookupEntry([]byte(tt.value)); got != tt.want {
+				t.Errorf("parseLookupEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLookup(t *testing.T) {
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Second)
+
+	tests := []struct {
+		name string
+		a, b lookupEntry
+		want lookupEntry
+	}{
+		{
+			name: "latest update wins",
+			a:    lookupEntry{Username: "jane", Region: "us", UpdatedAt: older},
+			b:    lookupEntry{Username: "john", Region: "eu", UpdatedAt: newer},
+			want: lookupEntry{Username: "john", Region: "eu", UpdatedAt: newer},
+		},
+		{
+			name: "tie broken by region",
+			a:    lookupEntry{Username: "jane", Region: "us", UpdatedAt: older},
+			b:    lookupEntry{Username: "john", Region: "eu", UpdatedAt: older},
+			want: lookupEntry{Username: "jane", Region: "us", UpdatedAt: older},
+		},
+		{
+			name: "tie broken by username",
+			a:    lookupEntry{Username: "jane", Region: "eu", UpdatedAt: older},
+			b:    lookupEntry{Username: "john", Region: "eu", UpdatedAt: older},
+			want: lookupEntry{Username: "john", Region: "eu", UpdatedAt: older},
+		},
+		{
+			name: "legacy entry loses",
+			a:    lookupEntry{Username: "jane"},
+			b:    lookupEntry{Username: "john", Region: "eu", UpdatedAt: older},
+			want: lookupEntry{Username: "john", Region: "eu", UpdatedAt: older},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the merge must not depend on the order the writes are seen in
+			if got := mergeLookup(tt.a, tt.b); got != tt.want {
+				t.Errorf("mergeLookup(a, b) = %+v, want %+v", got, tt.want)
+			}
+			if got := mergeLookup(tt.b, tt.a); got != tt.want {
+				t.Errorf("mergeLookup(b, a) = %+v, want %+v", got, tt.want)
+			}
+			if got := mergeLookup(tt.a, tt.a); got != tt.a {
+				t.Errorf("mergeLookup(a, a) = %+v, want %+v", got, tt.a)
+			}
+		})
+	}
+}
+
+// mockLookupIndex is an in-memory lookupIndex for testing
+type mockLookupIndex struct {
+	lookups map[string]lookupEntry
+	version string
+	putErr  error
+}
+
+func (m *mockLookupIndex) ListLookups(ctx context.Context) (map[string]lookupEntry, error) {
+	current := make(map[string]lookupEntry, len(m.lookups))
+	for key, entry := range m.lookups {
+		current[key] = entry
+	}
+	return current, nil
+}
+
+func (m *mockLookupIndex) PutLookup(ctx context.Context, key string, entry lookupEntry) error {
+	if m.putErr != nil {
+		return m.putErr
+	}
+	m.lookups[key] = entry
+	return nil
+}
+
+func (m *mockLookupIndex) DeleteLookup(ctx context.Context, key string) error {
+	delete(m.lookups, key)
+	return nil
+}
+
+func (m *mockLookupIndex) EmailIndexVersion(ctx context.Context) (string, error) {
+	return m.version, nil
+}
+
+func (m *mockLookupIndex) SetEmailIndexVersion(ctx context.Context, version string) error {
+	m.version = version
+	return nil
+}
+
+func TestIndexReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Minute)
+
+	// the same alternate email was linked to two users, in two regions
+	users := map[string]*AutheliaUser{
+		"jane": {
+			User: &model.User{
+				PrimaryEmail:    "jane@example.com",
+				AlternateEmails: []model.Email{{Email: "shared@example.com"}},
+			},
+			Email:     "jane@example.com",
+			Region:    "us",
+			UpdatedAt: older,
+		},
+		"john": {
+			User: &model.User{
+				Sub:             "john-sub",
+				AlternateEmails: []model.Email{{Email: "shared@example.com"}},
+			},
+			Region:    "eu",
+			UpdatedAt: newer,
+		},
+	}
+	storage := &mockStorageReaderWriter{users: users}
+
+	jane := lookupEntry{Username: "jane", Region: "us", UpdatedAt: older}
+	john := lookupEntry{Username: "john", Region: "eu", UpdatedAt: newer}
+	janeEmailKey := storage.BuildLookupKey(ctx, "email", users["jane"].BuildEmailIndexKey(ctx))
+	sharedKey := storage.BuildLookupKey(ctx, "email", users["jane"].BuildAlternateEmailIndexKey(ctx, "shared@example.com"))
+	johnSubKey := storage.BuildLookupKey(ctx, "sub", users["john"].BuildSubIndexKey(ctx))
+
+	index := &mockLookupIndex{lookups: map[string]lookupEntry{
+		janeEmailKey:     jane,
+		sharedKey:        jane, // the replicated write of the older region landed last
+		"email:orphaned": {Username: "jane"},
+	}}
+
+	reconciler := newIndexReconciler(storage, index, 0)
+	if reconciler.interval != defaultIndexReconcileInterval {
+		t.Errorf("interval = %v, want the default", reconciler.interval)
+	}
+
+	fixed, err := reconciler.reconcile(ctx)
+	if err != nil {
+		t.Fatalf("reconcile() unexpected error: %v", err)
+	}
+	if fixed != 3 {
+		t.Errorf("reconcile() fixed %d keys, want 3", fixed)
+	}
+
+	want := map[string]lookupEntry{
+		janeEmailKey: jane,
+		sharedKey:    john,
+		johnSubKey:   john,
+	}
+	if len(index.lookups) != len(want) {
+		t.Fatalf("index has %d keys, want %d: %+v", len(index.lookups), len(want), index.lookups)
+	}
+	for key, entry := range want {
+		if index.lookups[key] != entry {
+			t.Errorf("lookup %s = %+v, want %+v", key, index.lookups[key], entry)
+		}
+	}
+
+	t.Run("converged index is left untouched", func(t *testing.T) {
+		fixed, err := reconciler.reconcile(ctx)
+		if err != nil || fixed != 0 {
+			t.Errorf("reconcile() = %d, %v, want 0, nil", fixed, err)
+		}
+	})
+
+	t.Run("errors are reported", func(t *testing.T) {
+		failing := newIndexReconciler(&mockStorageReaderWriter{listErr: errors.New("kv down")}, index, time.Minute)
+		if _, err := failing.reconcile(ctx); err == nil {
+			t.Error("reconcile() expected error when listing users fails")
+		}
+		index.putErr = errors.New("kv down")
+		delete(index.lookups, johnSubKey)
+		if _, err := reconciler.reconcile(ctx); err == nil {
+			t.Error("reconcile() expected error when writing fails")
+		}
+	})
+}
+
+func TestIndexReconciler_Reindex(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() { emailnorm.SetEnabled(true) })
+
+	users := map[string]*AutheliaUser{
+		"john": {
+			User:  &model.User{PrimaryEmail: "John.Doe@gmail.com"},
+			Email: "John.Doe@gmail.com",
+		},
+	}
+	storage := &mockStorageReaderWriter{users: users}
+
+	// the index was built before the canonicalization rules, keyed by the lowercased email
+	emailnorm.SetEnabled(false)
+	lowercaseKey := storage.BuildLookupKey(ctx, "email", users["john"].BuildEmailIndexKey(ctx))
+	emailnorm.SetEnabled(true)
+	canonicalKey := storage.BuildLookupKey(ctx, "email", users["john"].BuildEmailIndexKey(ctx))
+
+	index := &mockLookupIndex{lookups: map[string]lookupEntry{lowercaseKey: {Username: "john"}}}
+	reconciler := newIndexReconciler(storage, index, 0)
+
+	if err := reconciler.reindex(ctx); err != nil {
+		t.Fatalf("reindex() unexpected error: %v", err)
+	}
+	if _, ok := index.lookups[lowercaseKey]; ok {
+		t.Error("reindex() kept the key of the previous rules")
+	}
+	if index.lookups[canonicalKey].Username != "john" {
+		t.Errorf("reindex() lookup %s = %+v, want john", canonicalKey, index.lookups[canonicalKey])
+	}
+	if index.version != emailnorm.Version() {
+		t.Errorf("reindex() recorded version %q, want %q", index.version, emailnorm.Version())
+	}
+
+	t.Run("index built with the current rules is left untouched", func(t *testing.T) {
+		index.lookups["email:orphaned"] = lookupEntry{Username: "john"}
+		if err := reconciler.reindex(ctx); err != nil {
+			t.Fatalf("reindex() unexpected error: %v", err)
+		}
+		if _, ok := index.lookups["email:orphaned"]; !ok {
+			t.Error("reindex() ran with the rules unchanged")
+		}
+	})
+}
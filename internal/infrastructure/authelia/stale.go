@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// defaultStaleProfileScanInterval is how often the storage is scanned for stale profiles
+const defaultStaleProfileScanInterval = 24 * time.Hour
+
+// eventPublisher publishes fire-and-forget events, implemented by the NATS client
+type eventPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// staleProfileScanner flags profiles not updated in a while that still have
+// unverified data, and emits a nudge event for each of them, also emailed to the
+// user when a notifier is set
+type staleProfileScanner struct {
+	storage    internalStorageReader
+	publisher  eventPublisher
+	notifier   port.TemplatedEmailSender
+	staleAfter int // months
+	interval   time.Duration
+	region     string
+	now        func() time.Time
+	gauge      metric.Int64Gauge
+}
+
+// scan runs a single pass over the storage and returns the nudges emitted
+func (s *staleProfileScanner) scan(ctx context.Context) ([]model.ProfileNudge, error) {
+
+	users, errList := s.storage.ListUsers(ctx)
+	if errList != nil {
+		slog.ErrorContext(ctx, "failed to list users for stale profile scan", "error", errList)
+		return nil, errList
+	}
+
+	cutoff := s.now().AddDate(0, -s.staleAfter, 0)
+	reasonCount := make(map[string]int64)
+
+	var nudges []model.ProfileNudge
+	for key, user := range users {
+		if user == nil || user.IsDeleted() || user.UpdatedAt.IsZero() || user.UpdatedAt.After(cutoff) {
+			continue
+		}
+		user.SetUsername(key)
+
+		reasons := user.ReverificationReasons()
+		if len(reasons) == 0 {
+			continue
+		}
+
+		nudge := model.ProfileNudge{
+			Username:     user.Username,
+			Sub:          user.Sub,
+			Email:        user.Email,
+			UpdatedAt:    user.UpdatedAt,
+			Reasons:      reasons,
+			OriginRegion: s.region,
+		}
+
+		data, errMarshal := json.Marshal(nudge)
+		if errMarshal != nil {
+			slog.ErrorContext(ctx, "failed to marshal profile nudge", "error", errMarshal)
+			continue
+		}
+		if errPublish := s.publisher.Publish(ctx, constants.UserProfileNudgeSubject, data); errPublish != nil {
+			// keep going, the profile will be picked up again on the next scan
+			slog.WarnContext(ctx, "failed to publish profile nudge",
+				"error", errPublish,
+				"username", redaction.Redact(user.Username),
+			)
+			continue
+		}
+
+		s.notify(ctx, nudge)
+
+		for _, reason := range reasons {
+			reasonCount[reason]++
+		}
+		nudges = append(nudges, nudge)
+	}
+
+	if s.gauge != nil {
+		s.gauge.Record(ctx, int64(len(nudges)))
+		for _, reason := range []string{model.NudgeReasonUnverifiedEmail, model.NudgeReasonMissingOrganization} {
+			s.gauge.Record(ctx, reasonCount[reason], metric.WithAttributes(attribute.String("reason", reason)))
+		}
+	}
+
+	slog.InfoContext(ctx, "stale profile scan completed",
+		"scanned", len(users),
+		"nudged", len(nudges),
+	)
+
+	return nudges, nil
+}
+
+// notify emails the nudge to the user, the failures are only logged since the event was published
+func (s *staleProfileScanner) notify(ctx context.Context, nudge model.ProfileNudge) {
+	if s.notifier == nil || nudge.Email == "" {
+		return
+	}
+	errSend := s.notifier.SendTemplatedEmail(ctx, email.TemplateProfileNudge, nudge.Email, map[string]any{
+		"Username": nudge.Username,
+		"Reasons":  nudge.Reasons,
+	})
+	if errSend != nil {
+		slog.WarnContext(ctx, "failed to email profile nudge",
+			"error", errSend,
+			"username", redaction.Redact(nudge.Username),
+		)
+	}
+}
+
+// run scans the storage periodically until the context is cancelled
+func (s *staleProfileScanner) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.scan(ctx); err != nil {
+				slog.WarnContext(ctx, "stale profile scan failed", "error", err)
+			}
+		}
+	}
+}
+
+func newStaleProfileScanner(storage internalStorageReader, publisher eventPublisher, staleAfter int, interval time.Duration) *staleProfileScanner {
+	if interval <= 0 {
+		interval = defaultStaleProfileScanInterval
+	}
+
+	gauge, errGauge := otel.Meter(constants.ServiceName).Int64Gauge(
+		"auth_service.profiles.stale",
+		metric.WithDescription("Number of stale profiles with unverified data found by the last scan"),
+	)
+	if errGauge != nil {
+		slog.Warn("failed to create stale profile gauge", "error", errGauge)
+	}
+
+	return &staleProfileScanner{
+		storage:    storage,
+		publisher:  publisher,
+		staleAfter: staleAfter,
+		interval:   interval,
+		now:        time.Now,
+		gauge:      gauge,
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package authelia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+)
+
+type mockEventPublisher struct {
+	subjects []string
+	events   [][]byte
+	err      error
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.subjects = append(m.subjects, subject)
+	m.events = append(m.events, data)
+	return nil
+}
+
+type mockTemplatedEmailSender struct {
+	templates  []string
+	recipients []string
+	err        error
+}
+
+func (m *mockTemplatedEmailSender) SendTemplatedEmail(ctx context.Context, templateName, to string, data any) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.templates = append(m.templates, templateName)
+	m.recipients = append(m.recipients, to)
+	return nil
+}
+
+func TestStaleProfileScanner_Scan(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, -7, 0)
+	deletedAt := now.AddDate(0, -1, 0)
+
+	users := map[string]*AutheliaUser{
+		"stale-unverified": {
+			User: &model.User{
+				AlternateEmails: []model.Email{{Email: "alt@example.com"}},
+				UserMetadata:    &model.UserMetadata{Organization: converters.StringPtr("LF")},
+			},
+			Email:     "stale@example.com",
+			UpdatedAt: old,
+		},
+		"stale-verified": {
+			User: &model.User{
+				UserMetadata: &model.UserMetadata{Organization: converters.StringPtr("LF")},
+			},
+			UpdatedAt: old,
+		},
+		"recent-unverified": {
+			User:      &model.User{},
+			UpdatedAt: now.AddDate(0, -1, 0),
+		},
+		"stale-deleted": {
+			User:      &model.User{},
+			UpdatedAt: old,
+			DeletedAt: &deletedAt,
+		},
+	}
+
+	t.Run("publishes nudges for stale unverified profiles", func(t *testing.T) {
+		publisher := &mockEventPublisher{}
+		scanner := newStaleProfileScanner(&mockStorageReaderWriter{users: users}, publisher, 6, 0)
+		scanner.now = func() time.Time { return now }
+
+		nudges, err := scanner.scan(context.Background())
+		if err != nil {
+			t.Fatalf("scan() unexpected error: %v", err)
+		}
+		if len(nudges) != 1 || nudges[0].Username != "stale-unverified" {
+			t.Fatalf("scan() nudges = %+v, want only stale-unverified", nudges)
+		}
+		if len(publisher.subjects) != 1 || publisher.subjects[0] != constants.UserProfileNudgeSubject {
+			t.Fatalf("published subjects = %v", publisher.subjects)
+		}
+
+		var event model.ProfileNudge
+		if err := json.Unmarshal(publisher.events[0], &event); err != nil {
+			t.Fatalf("failed to unmarshal event: %v", err)
+		}
+		if event.Email != "stale@example.com" || len(event.Reasons) != 1 || event.Reasons[0] != model.NudgeReasonUnverifiedEmail {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	})
+
+	t.Run("emails the nudges when a notifier is set", func(t *testing.T) {
+		notifier := &mockTemplatedEmailSender{}
+		scanner := newStaleProfileScanner(&mockStorageReaderWriter{users: users}, &mockEventPublisher{}, 6, 0)
+		scanner.now = func() time.Time { return now }
+		scanner.notifier = notifier
+
+		if _, err := scanner.scan(context.Background()); err != nil {
+			t.Fatalf("scan() unexpected error: %v", err)
+		}
+		if len(notifier.recipients) != 1 || notifier.recipients[0] != "stale@example.com" || notifier.templates[0] != email.TemplateProfileNudge {
+			t.Errorf("emailed %v %v, want the profile nudge to stale@example.com", notifier.templates, notifier.recipients)
+		}
+
+		// the nudge is published even when the email fails
+		notifier.err = errors.New("smtp down")
+		nudges, err := scanner.scan(context.Background())
+		if err != nil || len(nudges) != 1 {
+			t.Errorf("scan() = %d nudges, %v, want 1 nudge", len(nudges), err)
+		}
+	})
+
+	t.Run("publish failures are skipped", func(t *testing.T) {
+		publisher := &mockEventPublisher{err: errors.New("nats down")}
+		scanner := newStaleProfileScanner(&mockStorageReaderWriter{users: users}, publisher, 6, 0)
+		scanner.now = func() time.Time { return now }
+
+		nudges, err := scanner.scan(context.Background())
+		if err != nil {
+			t.Fatalf("scan() unexpected error: %v", err)
+		}
+		if len(nudges) != 0 {
+			t.Errorf("scan() nudges = %d, want 0", len(nudges))
+		}
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		scanner := newStaleProfileScanner(&mockStorageReaderWriter{listErr: errors.New("boom")}, &mockEventPublisher{}, 6, 0)
+		if _, err := scanner.scan(context.Background()); err == nil {
+			t.Error("scan() expected error")
+		}
+	})
+}
@@ -13,13 +13,22 @@ import (
 	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 	"github.com/nats-io/nats.go/jetstream"
 )
 
 const (
 	kvLookupPrefix = "lookup/"
+	// kvMetaPrefix is the prefix of the keys describing the index, neither users nor lookups
+	kvMetaPrefix = "meta/"
+	// kvEmailIndexVersionKey records the canonicalization rules the email lookup keys were built with
+	kvEmailIndexVersionKey = kvMetaPrefix + "email-index-version"
+
+	// maxLookupWriteAttempts bounds the compare-and-set retries of a lookup key write
+	maxLookupWriteAttempts = 3
 )
 
 type internalStorageReaderWriter interface {
@@ -38,6 +47,8 @@ type internalStorageReader interface {
 type internalStorageWriter interface {
 	SetUser(ctx context.Context, user *AutheliaUser) (any, error)
 	UpdateUserWithRevision(ctx context.Context, user *AutheliaUser, revision uint64) error
+	SoftDeleteUser(ctx context.Context, key string) error
+	RestoreUser(ctx context.Context, key string, gracePeriod time.Duration) (*AutheliaUser, error)
 }
 
 type emailHandler interface {
@@ -49,6 +60,9 @@ type emailHandler interface {
 type natsUserStorage struct {
 	natsClient *nats.NATSClient
 	kvStore    map[string]jetstream.KeyValue
+	region     string
+	// clock is the time source of the timestamps and the restore grace period
+	clock clock.Clock
 }
 
 func (n *natsUserStorage) lookupUser(ctx context.Context, key string) (string, error) {
@@ -64,7 +78,7 @@ func (n *natsUserStorage) lookupUser(ctx context.Context, key string) (string, e
 		}
 		return "", errs.NewUnexpected("failed to get user from NATS KV", err)
 	}
-	return string(entry.Value()), nil
+	return parseLookupEntry(entry.Value()).Username, nil
 }
 
 func (n *natsUserStorage) GetUser(ctx context.Context, key string) (*AutheliaUser, error) {
@@ -76,6 +90,20 @@ func (n *natsUserStorage) GetUser(ctx context.Context, key string) (*AutheliaUse
 }
 
 func (n *natsUserStorage) GetUserWithRevision(ctx context.Context, key string) (*AutheliaUser, uint64, error) {
+	user, revision, err := n.getUserWithRevision(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// soft-deleted users are only visible to ListUsers and RestoreUser
+	if user.IsDeleted() {
+		return nil, 0, errs.NewNotFound("user not found")
+	}
+	return user, revision, nil
+}
+
+// getUserWithRevision returns the stored user, including soft-deleted ones
+func (n *natsUserStorage) getUserWithRevision(ctx context.Context, key string) (*AutheliaUser, uint64, error) {
 
 	if key == "" {
 		return nil, 0, errs.NewUnexpected("key is required")
@@ -118,12 +146,13 @@ func (n *natsUserStorage) ListUsers(ctx context.Context) (map[string]*AutheliaUs
 	// Retrieve each user
 	for _, key := range keys {
 
-		// Skip lookup keys since they are not users
-		if strings.HasPrefix(key, kvLookupPrefix) {
+		// Skip lookup and meta keys since they are not users
+		if strings.HasPrefix(key, kvLookupPrefix) || strings.HasPrefix(key, kvMetaPrefix) {
 			continue
 		}
 
-		user, err := n.GetUser(ctx, key)
+		// soft-deleted users are listed so the sync can disable them in the orchestrator
+		user, _, err := n.getUserWithRevision(ctx, key)
 		if err != nil {
 			slog.WarnContext(ctx, "failed to get user during list operation",
 				"username", key, "error", err)
@@ -135,40 +164,141 @@ func (n *natsUserStorage) ListUsers(ctx context.Context) (map[string]*AutheliaUs
 	return users, nil
 }
 
+// putLookup writes a lookup key unless it is owned by another user with a newer
+// entry, e.g. replicated from another region, the index reconciliation settles it
+func (n *natsUserStorage) putLookup(ctx context.Context, key string, entry lookupEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errs.NewUnexpected("failed to marshal lookup entry", err)
+	}
+
+	kv := n.kvStore[constants.KVBucketNameAutheliaUsers]
+	for attempt := 0; attempt < maxLookupWriteAttempts; attempt++ {
+		current, errGet := kv.Get(ctx, key)
+		if errGet != nil {
+			if !errors.Is(errGet, jetstream.ErrKeyNotFound) {
+				return errGet
+			}
+			if _, errCreate := kv.Create(ctx, key, data); errCreate == nil || !errors.Is(errCreate, jetstream.ErrKeyExists) {
+				return errCreate
+			}
+			continue
+		}
+
+		stored := parseLookupEntry(current.Value())
+		if stored.Username != entry.Username && stored.wins(entry) {
+			slog.WarnContext(ctx, "lookup key owned by a newer write, skipping",
+				"key", redaction.Redact(key),
+				"stored_region", stored.Region,
+			)
+			return nil
+		}
+		if _, errUpdate := kv.Update(ctx, key, data, current.Revision()); errUpdate == nil {
+			return nil
+		}
+		// the key changed in between, read it again
+	}
+	return errs.NewConflict("lookup key has been modified by another process, please retry")
+}
+
 func (n *natsUserStorage) setLookupKeys(ctx context.Context, user *AutheliaUser) error {
+	entry := lookupEntry{Username: user.Username, Region: user.Region, UpdatedAt: user.UpdatedAt}
+
 	if user.Email != "" {
-		_, errPutLookup := n.kvStore[constants.KVBucketNameAutheliaUsers].Put(ctx, n.BuildLookupKey(ctx, "email", user.BuildEmailIndexKey(ctx)), []byte(user.Username))
-		if errPutLookup != nil {
+		if errPutLookup := n.putLookup(ctx, n.BuildLookupKey(ctx, "email", user.BuildEmailIndexKey(ctx)), entry); errPutLookup != nil {
 			return errs.NewUnexpected("failed to set lookup key in NATS KV", errPutLookup)
 		}
 	}
 
-	if len(user.AlternateEmails) > 0 {
-		for _, alternateEmail := range user.AlternateEmails {
-			_, errPutLookup := n.kvStore[constants.KVBucketNameAutheliaUsers].Put(ctx, n.BuildLookupKey(ctx, "email", user.BuildAlternateEmailIndexKey(ctx, alternateEmail.Email)), []byte(user.Username))
-			if errPutLookup != nil {
-				return errs.NewUnexpected("failed to set alternate email lookup key in NATS KV", errPutLookup)
-			}
+	for _, alternateEmail := range user.AlternateEmails {
+		if errPutLookup := n.putLookup(ctx, n.BuildLookupKey(ctx, "email", user.BuildAlternateEmailIndexKey(ctx, alternateEmail.Email)), entry); errPutLookup != nil {
+			return errs.NewUnexpected("failed to set alternate email lookup key in NATS KV", errPutLookup)
 		}
 	}
 
 	if user.Sub != "" {
-		_, errPutLookup := n.kvStore[constants.KVBucketNameAutheliaUsers].Put(ctx, n.BuildLookupKey(ctx, "sub", user.BuildSubIndexKey(ctx)), []byte(user.Username))
-		if errPutLookup != nil {
+		if errPutLookup := n.putLookup(ctx, n.BuildLookupKey(ctx, "sub", user.BuildSubIndexKey(ctx)), entry); errPutLookup != nil {
 			return errs.NewUnexpected("failed to set sub lookup key in NATS KV", errPutLookup)
 		}
 	}
 	return nil
 }
 
+// ListLookups returns every lookup key with its entry
+func (n *natsUserStorage) ListLookups(ctx context.Context) (map[string]lookupEntry, error) {
+	kv := n.kvStore[constants.KVBucketNameAutheliaUsers]
+	keys, err := kv.Keys(ctx)
+	if err != nil && !errors.Is(err, jetstream.ErrNoKeysFound) {
+		return nil, errs.NewUnexpected("failed to list keys from NATS KV", err)
+	}
+
+	lookups := make(map[string]lookupEntry)
+	for _, key := range keys {
+		if !isLookupKey(key) {
+			continue
+		}
+		entry, errGet := kv.Get(ctx, key)
+		if errGet != nil {
+			if errors.Is(errGet, jetstream.ErrKeyNotFound) {
+				continue
+			}
+			return nil, errs.NewUnexpected("failed to get lookup key from NATS KV", errGet)
+		}
+		lookups[key] = parseLookupEntry(entry.Value())
+	}
+	return lookups, nil
+}
+
+// PutLookup overwrites a lookup key
+func (n *natsUserStorage) PutLookup(ctx context.Context, key string, entry lookupEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errs.NewUnexpected("failed to marshal lookup entry", err)
+	}
+	if _, errPut := n.kvStore[constants.KVBucketNameAutheliaUsers].Put(ctx, key, data); errPut != nil {
+		return errs.NewUnexpected("failed to set lookup key in NATS KV", errPut)
+	}
+	return nil
+}
+
+// DeleteLookup removes a lookup key
+func (n *natsUserStorage) DeleteLookup(ctx context.Context, key string) error {
+	if errDelete := n.kvStore[constants.KVBucketNameAutheliaUsers].Delete(ctx, key); errDelete != nil {
+		return errs.NewUnexpected("failed to delete lookup key from NATS KV", errDelete)
+	}
+	return nil
+}
+
+// EmailIndexVersion returns the canonicalization rules the email lookup keys were built with,
+// empty when they were built before it was recorded
+func (n *natsUserStorage) EmailIndexVersion(ctx context.Context) (string, error) {
+	entry, err := n.kvStore[constants.KVBucketNameAutheliaUsers].Get(ctx, kvEmailIndexVersionKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errs.NewUnexpected("failed to get email index version from NATS KV", err)
+	}
+	return string(entry.Value()), nil
+}
+
+// SetEmailIndexVersion records the canonicalization rules the email lookup keys were built with
+func (n *natsUserStorage) SetEmailIndexVersion(ctx context.Context, version string) error {
+	if _, errPut := n.kvStore[constants.KVBucketNameAutheliaUsers].Put(ctx, kvEmailIndexVersionKey, []byte(version)); errPut != nil {
+		return errs.NewUnexpected("failed to set email index version in NATS KV", errPut)
+	}
+	return nil
+}
+
 func (n *natsUserStorage) SetUser(ctx context.Context, user *AutheliaUser) (any, error) {
 
-	// Update timestamp
-	user.UpdatedAt = time.Now()
+	// Update timestamp and origin region
+	user.UpdatedAt = n.clock.Now()
+	n.tagRegion(user)
 
 	// If this is a new user (no CreatedAt), set it
 	if user.CreatedAt.IsZero() {
-		user.CreatedAt = time.Now()
+		user.CreatedAt = n.clock.Now()
 	}
 
 	// Convert to storage format (excludes sensitive fields)
@@ -196,8 +326,9 @@ func (n *natsUserStorage) SetUser(ctx context.Context, user *AutheliaUser) (any,
 
 func (n *natsUserStorage) UpdateUserWithRevision(ctx context.Context, user *AutheliaUser, revision uint64) error {
 
-	// Update timestamp
-	user.UpdatedAt = time.Now()
+	// Update timestamp and origin region
+	user.UpdatedAt = n.clock.Now()
+	n.tagRegion(user)
 
 	// Convert to storage format (excludes sensitive fields)
 	storageUser := user.ToStorage()
@@ -227,6 +358,45 @@ func (n *natsUserStorage) UpdateUserWithRevision(ctx context.Context, user *Auth
 	return nil
 }
 
+// SoftDeleteUser marks the user as deleted, keeping the record and its lookup keys
+// so it can be restored within the grace period
+func (n *natsUserStorage) SoftDeleteUser(ctx context.Context, key string) error {
+
+	user, revision, err := n.GetUserWithRevision(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	deletedAt := n.clock.Now()
+	user.DeletedAt = &deletedAt
+
+	return n.UpdateUserWithRevision(ctx, user, revision)
+}
+
+// RestoreUser removes the soft-delete tombstone if the user was deleted within the grace period
+func (n *natsUserStorage) RestoreUser(ctx context.Context, key string, gracePeriod time.Duration) (*AutheliaUser, error) {
+
+	user, revision, err := n.getUserWithRevision(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsDeleted() {
+		return nil, errs.NewValidation("user is not deleted")
+	}
+
+	if n.clock.Now().Sub(*user.DeletedAt) > gracePeriod {
+		return nil, errs.NewValidation("restore grace period has expired")
+	}
+
+	user.DeletedAt = nil
+	if errUpdate := n.UpdateUserWithRevision(ctx, user, revision); errUpdate != nil {
+		return nil, errUpdate
+	}
+
+	return user, nil
+}
+
 // CreateVerificationCode stores a verification code (OTP) for an email address in the email OTP bucket
 // The key is the email address and the value is the OTP code as a string
 func (n *natsUserStorage) CreateVerificationCode(ctx context.Context, email, otp string) error {
@@ -245,7 +415,7 @@ func (n *natsUserStorage) CreateVerificationCode(ctx context.Context, email, otp
 	}
 
 	slog.InfoContext(ctx, "verification code stored successfully",
-		"email", email,
+		"email", redaction.RedactEmail(email),
 	)
 
 	return nil
@@ -269,12 +439,19 @@ func (n *natsUserStorage) GetVerificationCode(ctx context.Context, email string)
 	otp := string(entry.Value())
 
 	slog.InfoContext(ctx, "verification code retrieved successfully",
-		"email", email,
+		"email", redaction.RedactEmail(email),
 	)
 
 	return otp, nil
 }
 
+// tagRegion sets the region of the write, single region deployments leave it empty
+func (n *natsUserStorage) tagRegion(user *AutheliaUser) {
+	if n.region != "" {
+		user.Region = n.region
+	}
+}
+
 // BuildLookupKey builds the lookup key for the given lookup key and key
 func (n *natsUserStorage) BuildLookupKey(ctx context.Context, lookupKey, key string) string {
 	prefix := fmt.Sprintf(constants.KVLookupPrefixAuthelia, lookupKey)
@@ -282,7 +459,7 @@ func (n *natsUserStorage) BuildLookupKey(ctx context.Context, lookupKey, key str
 }
 
 // newNATSUserStorage creates a new NATS-based user storage
-func newNATSUserStorage(ctx context.Context, natsClient *nats.NATSClient) (internalStorageReaderWriter, error) {
+func newNATSUserStorage(ctx context.Context, natsClient *nats.NATSClient, region string) (*natsUserStorage, error) {
 	// Get the KV store for authelia users
 	kvStores := make(map[string]jetstream.KeyValue)
 	for _, bucketName := range []string{constants.KVBucketNameAutheliaUsers, constants.KVBucketNameAutheliaEmailOTP} {
@@ -297,5 +474,7 @@ func newNATSUserStorage(ctx context.Context, natsClient *nats.NATSClient) (inter
 	return &natsUserStorage{
 		natsClient: natsClient,
 		kvStore:    kvStores,
+		region:     region,
+		clock:      clock.System,
 	}, nil
 }
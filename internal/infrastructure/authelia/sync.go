@@ -4,14 +4,22 @@
 package authelia
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/nats"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/concurrent"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/password"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 	"go.yaml.in/yaml/v2"
 )
 
@@ -25,6 +33,14 @@ const (
 type sync struct {
 	usersStorageMap     map[string]*AutheliaUser
 	userOrchestratorMap map[string]*AutheliaUser
+
+	// dryRun computes and logs the changes without applying them
+	dryRun bool
+
+	// changed is the number of users synced by the last run
+	changed int
+	// report is the changes of the last run
+	report *model.SyncReport
 }
 
 func (s *sync) compareUsers(storage, orchestrator map[string]*AutheliaUser) map[string]*AutheliaUser {
@@ -49,6 +65,13 @@ func (s *sync) compareUsers(storage, orchestrator map[string]*AutheliaUser) map[
 			continue
 		}
 
+		// Soft-deleted users must be disabled in the orchestrator (and re-enabled once restored)
+		if user.IsDeleted() != orchestratorUser.Disabled {
+			user.actionNeeded = actionNeededOrchestratorUpdate
+			merged[key] = user
+			continue
+		}
+
 		// No changes needed
 		user.actionNeeded = actionNeededNone
 		if merged[key] != nil && merged[key].actionNeeded != "" {
@@ -79,6 +102,53 @@ func (s *sync) compareUsers(storage, orchestrator map[string]*AutheliaUser) map[
 
 }
 
+// newReport summarizes the changes of the compared users
+func (s *sync) newReport(usersToSync map[string]*AutheliaUser) *model.SyncReport {
+	report := &model.SyncReport{DryRun: s.dryRun, Changes: []model.SyncChange{}}
+	for username, user := range usersToSync {
+		switch user.actionNeeded {
+		case actionNeededOrchestratorCreation:
+			report.Summary.OrchestratorCreations++
+		case actionNeededOrchestratorUpdate:
+			report.Summary.OrchestratorUpdates++
+		case actionNeededStorageCreation:
+			report.Summary.StorageCreations++
+		default:
+			report.Summary.Unchanged++
+			continue
+		}
+		report.Changes = append(report.Changes, model.SyncChange{
+			Username: redaction.Redact(username),
+			Action:   user.actionNeeded,
+		})
+	}
+	slices.SortFunc(report.Changes, func(a, b model.SyncChange) int {
+		return cmp.Compare(a.Username, b.Username)
+	})
+	report.RestartNeeded = report.Summary.OrchestratorCreations+report.Summary.OrchestratorUpdates > 0
+	return report
+}
+
+// logReport logs the changes of a dry run, the report is what CI pipelines check
+func (s *sync) logReport(ctx context.Context) {
+	slog.InfoContext(ctx, "authelia sync dry run, no change applied",
+		"orchestrator_creations", s.report.Summary.OrchestratorCreations,
+		"orchestrator_updates", s.report.Summary.OrchestratorUpdates,
+		"storage_creations", s.report.Summary.StorageCreations,
+		"unchanged", s.report.Summary.Unchanged,
+		"restart_needed", s.report.RestartNeeded,
+		"report", s.report,
+	)
+}
+
+// plan loads and compares the users, without applying the changes
+func (s *sync) plan(ctx context.Context, storage internalStorageReaderWriter, orchestrator internalOrchestrator) (*model.SyncReport, error) {
+	if errLoadUsers := s.loadUsers(ctx, storage, orchestrator); errLoadUsers != nil {
+		return nil, errLoadUsers
+	}
+	return s.newReport(s.compareUsers(s.usersStorageMap, s.userOrchestratorMap)), nil
+}
+
 func (s *sync) loadUsers(ctx context.Context, storage internalStorageReaderWriter, orchestrator internalOrchestrator) error {
 
 	functions := []func() error{
@@ -151,9 +221,16 @@ func (s *sync) syncUsers(ctx context.Context, storage internalStorageReaderWrite
 	changedSecretsEntries := make(map[string][]byte)
 
 	usersToSync := s.compareUsers(s.usersStorageMap, s.userOrchestratorMap)
+	s.report = s.newReport(usersToSync)
+	s.changed = len(s.report.Changes)
+	if s.dryRun {
+		s.logReport(ctx)
+		return nil
+	}
+
 	for username, user := range usersToSync {
 		slog.DebugContext(ctx, "user needs action",
-			"username", username,
+			"username", redaction.Redact(username),
 			"action", user.actionNeeded,
 		)
 
@@ -223,3 +300,74 @@ func (s *sync) syncUsers(ctx context.Context, storage internalStorageReaderWrite
 
 	return nil
 }
+
+// publishStatus emits the status of the last sync for the admin dashboards, a failure is only logged
+func (s *sync) publishStatus(ctx context.Context, publisher eventPublisher, errSync error) {
+	if publisher == nil {
+		return
+	}
+
+	status := model.SyncStatus{
+		Succeeded: errSync == nil,
+		Changed:   s.changed,
+		SyncedAt:  time.Now().UTC(),
+	}
+	if errSync != nil {
+		status.Error = errSync.Error()
+	}
+	if s.dryRun {
+		status.DryRun = true
+		if s.report != nil {
+			status.Summary = &s.report.Summary
+		}
+	}
+
+	data, errMarshal := json.Marshal(status)
+	if errMarshal != nil {
+		slog.ErrorContext(ctx, "failed to marshal sync status event", "error", errMarshal)
+		return
+	}
+
+	if errPublish := publisher.Publish(ctx, constants.AutheliaSyncStatusSubject, data); errPublish != nil {
+		slog.ErrorContext(ctx, "failed to publish sync status event", "error", errPublish)
+	}
+}
+
+// audit records the sync in the audit sink, a failure is only logged
+func (s *sync) audit(ctx context.Context, sink port.AuditSink, errSync error) {
+	if sink == nil {
+		return
+	}
+
+	event := &model.AuditEvent{
+		Action:  model.AuditActionUserSync,
+		Target:  "authelia",
+		Outcome: model.AuditOutcomeSuccess,
+		Details: map[string]any{"changed": s.changed},
+		At:      time.Now().UTC(),
+	}
+	if errSync != nil {
+		event.Fail(errSync)
+	}
+	if s.dryRun {
+		event.Details["dry_run"] = true
+	}
+
+	if errRecord := sink.Record(ctx, event); errRecord != nil {
+		slog.ErrorContext(ctx, "failed to record sync audit event", "error", errRecord)
+	}
+}
+
+// PlanSync compares the users of the storage and of the orchestrator and reports the changes a
+// sync would apply, without applying them, so CI pipelines can check them before a rollout
+func PlanSync(ctx context.Context, config map[string]string, natsClient *nats.NATSClient) (*model.SyncReport, error) {
+	storage, errStorage := newNATSUserStorage(ctx, natsClient, config["region"])
+	if errStorage != nil {
+		return nil, errStorage
+	}
+	orchestrator, errOrchestrator := newK8sUserOrchestrator(ctx, config)
+	if errOrchestrator != nil {
+		return nil, errOrchestrator
+	}
+	return (&sync{dryRun: true}).plan(ctx, storage, orchestrator)
+}
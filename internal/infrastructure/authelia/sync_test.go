@@ -5,10 +5,14 @@ package authelia
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 )
 
 // Mock implementations for testing
@@ -30,7 +34,7 @@ func (m *mockStorageReaderWriter) GetUser(ctx context.Context, key string) (*Aut
 	if foundUser, exists := m.users[key]; exists {
 		return foundUser, nil
 	}
-	return nil, errors.New("user not found")
+	return nil, errs.NewNotFound("user not found")
 }
 
 func (m *mockStorageReaderWriter) BuildLookupKey(ctx context.Context, lookupKey, key string) string {
@@ -75,6 +79,31 @@ func (m *mockStorageReaderWriter) UpdateUserWithRevision(ctx context.Context, us
 	return nil
 }
 
+func (m *mockStorageReaderWriter) SoftDeleteUser(ctx context.Context, key string) error {
+	user, err := m.GetUser(ctx, key)
+	if err != nil {
+		return err
+	}
+	deletedAt := time.Now()
+	user.DeletedAt = &deletedAt
+	return nil
+}
+
+func (m *mockStorageReaderWriter) RestoreUser(ctx context.Context, key string, gracePeriod time.Duration) (*AutheliaUser, error) {
+	user, err := m.GetUser(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsDeleted() {
+		return nil, errors.New("user is not deleted")
+	}
+	if time.Since(*user.DeletedAt) > gracePeriod {
+		return nil, errors.New("restore grace period has expired")
+	}
+	user.DeletedAt = nil
+	return user, nil
+}
+
 func (m *mockStorageReaderWriter) CreateVerificationCode(ctx context.Context, email, otp string) error {
 	return nil
 }
@@ -192,6 +221,44 @@ func TestSync_CompareUsers(t *testing.T) {
 				"user1": actionNeededNone,
 			},
 		},
+		{
+			name: "soft-deleted user must be disabled",
+			storage: map[string]*AutheliaUser{
+				"user1": {
+					User:      &model.User{Username: "user1"},
+					Email:     "user1@example.com",
+					DeletedAt: &time.Time{},
+				},
+			},
+			orchestrator: map[string]*AutheliaUser{
+				"user1": {
+					User:  &model.User{Username: "user1"},
+					Email: "user1@example.com",
+				},
+			},
+			expected: map[string]string{
+				"user1": actionNeededOrchestratorUpdate,
+			},
+		},
+		{
+			name: "restored user must be re-enabled",
+			storage: map[string]*AutheliaUser{
+				"user1": {
+					User:  &model.User{Username: "user1"},
+					Email: "user1@example.com",
+				},
+			},
+			orchestrator: map[string]*AutheliaUser{
+				"user1": {
+					User:     &model.User{Username: "user1"},
+					Email:    "user1@example.com",
+					Disabled: true,
+				},
+			},
+			expected: map[string]string{
+				"user1": actionNeededOrchestratorUpdate,
+			},
+		},
 		{
 			name: "multiple users with different actions",
 			storage: map[string]*AutheliaUser{
@@ -636,3 +703,153 @@ func TestSync_SyncUsers_StorageCreation(t *testing.T) {
 		t.Error("syncUsers() should not update orchestrator for storage creation")
 	}
 }
+
+func TestSync_SyncUsers_DryRun(t *testing.T) {
+	ctx := context.Background()
+
+	deletedAt := time.Now()
+	storageUsers := map[string]*AutheliaUser{
+		"user1": {User: &model.User{Username: "user1"}, Password: "hash1", Email: "user1@example.com"},
+		"user2": {User: &model.User{Username: "user2"}, Password: "hash2", Email: "user2@example.com", DeletedAt: &deletedAt},
+		"user3": {User: &model.User{Username: "user3"}, Password: "hash3", Email: "user3@example.com"},
+	}
+	orchestratorUsers := map[string]any{
+		"users": map[string]any{
+			"user2": map[string]any{"password": "hash2", "email": "user2@example.com"},
+			"user3": map[string]any{"password": "hash3", "email": "user3@example.com"},
+			"user4": map[string]any{"password": "hash4", "email": "user4@example.com"},
+		},
+	}
+
+	s := &sync{dryRun: true}
+	mockStorage := &mockStorageReaderWriter{users: storageUsers}
+	mockOrch := &mockOrchestrator{users: orchestratorUsers}
+
+	if err := s.syncUsers(ctx, mockStorage, mockOrch); err != nil {
+		t.Fatalf("syncUsers() failed: %v", err)
+	}
+
+	// nothing is applied
+	if mockOrch.updateOriginCalled || mockOrch.updateSecretsCalled || mockOrch.restartCalled {
+		t.Error("syncUsers() should not change the orchestrator in a dry run")
+	}
+	if _, exists := mockStorage.users["user4"]; exists || mockStorage.users["user1"].Password != "hash1" {
+		t.Error("syncUsers() should not change the storage in a dry run")
+	}
+
+	want := model.SyncSummary{OrchestratorCreations: 1, OrchestratorUpdates: 1, StorageCreations: 1, Unchanged: 1}
+	if s.report == nil || s.report.Summary != want || !s.report.DryRun || !s.report.RestartNeeded {
+		t.Fatalf("report = %+v, want %+v", s.report, want)
+	}
+	if len(s.report.Changes) != 3 || s.changed != 3 {
+		t.Errorf("changes = %+v, changed = %d, want 3", s.report.Changes, s.changed)
+	}
+
+	// the summary is published with the status
+	publisher := &mockEventPublisher{}
+	s.publishStatus(ctx, publisher, nil)
+	var status model.SyncStatus
+	if err := json.Unmarshal(publisher.events[0], &status); err != nil {
+		t.Fatalf("failed to unmarshal sync status: %v", err)
+	}
+	if !status.DryRun || status.Summary == nil || *status.Summary != want {
+		t.Errorf("status = %+v, want the dry run summary", status)
+	}
+}
+
+func TestSync_Plan(t *testing.T) {
+	ctx := context.Background()
+
+	s := &sync{dryRun: true}
+	mockStorage := &mockStorageReaderWriter{users: map[string]*AutheliaUser{
+		"user1": {User: &model.User{Username: "user1"}, Password: "hash1", Email: "user1@example.com"},
+	}}
+	mockOrch := &mockOrchestrator{users: map[string]any{
+		"users": map[string]any{
+			"user1": map[string]any{"password": "hash1", "email": "user1@example.com"},
+		},
+	}}
+
+	report, err := s.plan(ctx, mockStorage, mockOrch)
+	if err != nil {
+		t.Fatalf("plan() failed: %v", err)
+	}
+	if len(report.Changes) != 0 || report.Summary.Unchanged != 1 || report.RestartNeeded {
+		t.Errorf("report = %+v, want the users in sync", report)
+	}
+
+	mockOrch.loadErr = errors.New("configmap not found")
+	if _, err := s.plan(ctx, mockStorage, mockOrch); err == nil {
+		t.Error("plan() should fail when the users can't be loaded")
+	}
+}
+
+func TestSync_PublishStatus(t *testing.T) {
+	ctx := context.Background()
+
+	s := &sync{}
+	mockStorage := &mockStorageReaderWriter{users: map[string]*AutheliaUser{}}
+	mockOrch := &mockOrchestrator{users: map[string]any{
+		"users": map[string]any{
+			"user1": map[string]any{"password": "hash1", "email": "user1@example.com"},
+			"user2": map[string]any{"password": "hash2", "email": "user2@example.com"},
+		},
+	}}
+
+	errSync := s.syncUsers(ctx, mockStorage, mockOrch)
+	publisher := &mockEventPublisher{}
+	s.publishStatus(ctx, publisher, errSync)
+
+	if len(publisher.subjects) != 1 || publisher.subjects[0] != constants.AutheliaSyncStatusSubject {
+		t.Fatalf("subjects = %v, want the sync status", publisher.subjects)
+	}
+	var status model.SyncStatus
+	if err := json.Unmarshal(publisher.events[0], &status); err != nil {
+		t.Fatalf("failed to unmarshal sync status: %v", err)
+	}
+	if !status.Succeeded || status.Changed != 2 || status.Error != "" {
+		t.Errorf("status = %+v, want 2 users synced", status)
+	}
+
+	// a failed sync is reported with its error
+	publisher = &mockEventPublisher{}
+	s.publishStatus(ctx, publisher, errors.New("configmap not found"))
+	if err := json.Unmarshal(publisher.events[0], &status); err != nil {
+		t.Fatalf("failed to unmarshal sync status: %v", err)
+	}
+	if status.Succeeded || status.Error != "configmap not found" {
+		t.Errorf("status = %+v, want the failure", status)
+	}
+}
+
+// recordingAuditSink records the audit events
+type recordingAuditSink struct {
+	events []*model.AuditEvent
+}
+
+func (r *recordingAuditSink) Record(_ context.Context, event *model.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestSync_Audit(t *testing.T) {
+	ctx := context.Background()
+	s := &sync{changed: 3}
+
+	// no sink, nothing to record
+	s.audit(ctx, nil, nil)
+
+	sink := &recordingAuditSink{}
+	s.audit(ctx, sink, nil)
+	s.audit(ctx, sink, errors.New("configmap not found"))
+
+	if len(sink.events) != 2 {
+		t.Fatalf("recorded %d events, want 2", len(sink.events))
+	}
+	if event := sink.events[0]; event.Action != model.AuditActionUserSync || event.Outcome != model.AuditOutcomeSuccess || event.Details["changed"] != 3 {
+		t.Errorf("event = %+v, want the successful sync", event)
+	}
+	if event := sink.events[1]; event.Outcome != model.AuditOutcomeFailure || event.Error != "configmap not found" {
+		t.Errorf("event = %+v, want the failure", event)
+	}
+}
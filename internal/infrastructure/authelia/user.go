@@ -5,9 +5,11 @@ package authelia
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,17 +22,42 @@ import (
 	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/lock"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
 
+// defaultRestoreGracePeriod is how long a soft-deleted user can be restored
+const defaultRestoreGracePeriod = 30 * 24 * time.Hour
+
 // userReaderWriter implements UserReaderWriter with pluggable storage and ConfigMap sync
 type userReaderWriter struct {
 	oidcUserInfoURL  string
+	restoreGrace     time.Duration
 	sync             *sync
 	storage          internalStorageReaderWriter
 	orchestrator     internalOrchestrator
 	emailLinkingFlow passwordlessFlow
 	httpClient       *httpclient.Client
+	auditSink        port.AuditSink
+	notifier         port.TemplatedEmailSender
+}
+
+// Option configures the Authelia UserReaderWriter
+type Option func(*userReaderWriter)
+
+// WithAuditSink sets the sink recording the audit events of the syncs
+func WithAuditSink(sink port.AuditSink) Option {
+	return func(u *userReaderWriter) {
+		u.auditSink = sink
+	}
+}
+
+// WithNotificationSender sets the sender emailing the stale profile nudges to the users, the nudges
+// are only published when nil
+func WithNotificationSender(sender port.TemplatedEmailSender) Option {
+	return func(u *userReaderWriter) {
+		u.notifier = sender
+	}
 }
 
 // fetchOIDCUserInfo fetches user information from the OIDC userinfo endpoint
@@ -67,13 +94,13 @@ func (a *userReaderWriter) fetchOIDCUserInfo(ctx context.Context, token string)
 }
 
 // SearchUser searches for a user in storage
-func (a *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+func (a *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 
 	if user == nil {
 		return nil, errs.NewValidation("user is required")
 	}
 
-	param := func(criteriaType string) string {
+	param := func(criteriaType constants.CriteriaType) string {
 		switch criteriaType {
 		case constants.CriteriaTypeEmail:
 			slog.DebugContext(ctx, "searching user",
@@ -121,6 +148,17 @@ func (a *userReaderWriter) SearchUser(ctx context.Context, user *model.User, cri
 
 }
 
+// storageKey returns the storage key for the user, by username or sub
+func (a *userReaderWriter) storageKey(ctx context.Context, user *model.User) string {
+	if user.Username != "" {
+		return user.Username
+	}
+	if user.Sub != "" {
+		return a.storage.BuildLookupKey(ctx, "sub", user.BuildSubIndexKey(ctx))
+	}
+	return ""
+}
+
 // GetUser retrieves a user from storage
 func (a *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
 
@@ -128,14 +166,7 @@ func (a *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*mode
 		return nil, errs.NewValidation("user is required")
 	}
 
-	key := ""
-	if user.Username != "" {
-		key = user.Username
-	}
-
-	if key == "" && user.Sub != "" {
-		key = a.storage.BuildLookupKey(ctx, "sub", user.BuildSubIndexKey(ctx))
-	}
+	key := a.storageKey(ctx, user)
 
 	existingUser, err := a.storage.GetUser(ctx, key)
 	if err != nil {
@@ -204,16 +235,14 @@ func (a *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 		userInfo, err := a.fetchOIDCUserInfo(ctx, user.Token)
 		if err != nil {
 			slog.WarnContext(ctx, "failed to fetch OIDC userinfo, skipping sub update",
-				"username", user.Username,
+				"user", user,
 				"error", err,
 			)
 		}
 		if userInfo != nil && userInfo.Sub != "" {
 			user.Sub = userInfo.Sub
 			slog.DebugContext(ctx, "updated user sub from OIDC userinfo",
-				"username", user.Username,
-				"preferred_username", userInfo.PreferredUsername,
-				"sub", userInfo.Sub,
+				"user", user,
 			)
 			if user.Username == "" {
 				user.Username = userInfo.PreferredUsername
@@ -232,9 +261,9 @@ func (a *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 	existingUser, err := a.storage.GetUser(ctx, existingAutheliaUser.Username)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to get existing user from storage",
-			"username", user.Username,
+			"user", user,
 			"error", err,
-			"key", existingAutheliaUser.Username,
+			"key", redaction.Redact(existingAutheliaUser.Username),
 		)
 		return nil, errs.NewUnexpected("failed to get existing user from storage", err)
 	}
@@ -244,10 +273,7 @@ func (a *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 	if user.Sub != "" && existingUser.Sub != user.Sub {
 		existingUser.Sub = user.Sub
 		subUpdated = true
-		slog.InfoContext(ctx, "updated user sub field in storage",
-			"username", user.Username,
-			"sub", redaction.Redact(user.Sub),
-		)
+		slog.InfoContext(ctx, "updated user sub field in storage", "user", user)
 	}
 
 	// Update UserMetadata if provided - patch individual metadata fields
@@ -257,6 +283,9 @@ func (a *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 			existingUser.UserMetadata = &model.UserMetadata{}
 		}
 		metadataUpdated = existingUser.UserMetadata.Patch(user.UserMetadata)
+		if existingUser.UserMetadata.Clear(user.ClearFields) {
+			metadataUpdated = true
+		}
 	}
 
 	// Save to storage if any updates were made
@@ -264,15 +293,14 @@ func (a *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*m
 		_, err = a.storage.SetUser(ctx, existingUser)
 		if err != nil {
 			slog.ErrorContext(ctx, "failed to update user in storage",
-				"username", user.Username,
+				"user", user,
 				"error", err,
 			)
 			return nil, errs.NewUnexpected("failed to update user in storage", err)
 		}
 	}
 
-	slog.InfoContext(ctx, "user updated successfully in storage",
-		"username", user.Username)
+	slog.InfoContext(ctx, "user updated successfully in storage", "user", user)
 
 	return existingUser.User, nil
 }
@@ -541,25 +569,177 @@ func (a *userReaderWriter) UnlinkIdentity(ctx context.Context, request *model.Un
 	return nil
 }
 
+// SoftDeleteUser marks the user as deleted in storage, the user can be restored within the grace period
+func (a *userReaderWriter) SoftDeleteUser(ctx context.Context, user *model.User) error {
+	if user == nil {
+		return errs.NewValidation("user is required")
+	}
+
+	key := a.storageKey(ctx, user)
+	if key == "" {
+		return errs.NewValidation("username or sub is required")
+	}
+
+	if err := a.storage.SoftDeleteUser(ctx, key); err != nil {
+		slog.ErrorContext(ctx, "failed to soft-delete user",
+			"key", redaction.Redact(key),
+			"error", err,
+		)
+		return err
+	}
+
+	slog.InfoContext(ctx, "user soft-deleted",
+		"key", redaction.Redact(key),
+		"restore_grace_period", a.restoreGrace.String(),
+	)
+	return nil
+}
+
+// RestoreUser restores a soft-deleted user if the grace period has not expired
+func (a *userReaderWriter) RestoreUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if user == nil {
+		return nil, errs.NewValidation("user is required")
+	}
+
+	key := a.storageKey(ctx, user)
+	if key == "" {
+		return nil, errs.NewValidation("username or sub is required")
+	}
+
+	restored, err := a.storage.RestoreUser(ctx, key, a.restoreGrace)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to restore user",
+			"key", redaction.Redact(key),
+			"error", err,
+		)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user restored", "key", redaction.Redact(key))
+	return restored.User, nil
+}
+
+// MatchEmailHashes reports which of the email index hashes belong to a user, the primary and the
+// alternate emails share the email index so both are matched
+func (a *userReaderWriter) MatchEmailHashes(ctx context.Context, hashes []string) (map[string]bool, error) {
+	members := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		_, err := a.storage.GetUser(ctx, a.storage.BuildLookupKey(ctx, "email", hash))
+		if err != nil {
+			var notFound errs.NotFound
+			if !errors.As(err, &notFound) {
+				return nil, err
+			}
+		}
+		members[hash] = err == nil
+	}
+	return members, nil
+}
+
+// settings are the parsed values of the configuration of the repository
+type settings struct {
+	restoreGrace       time.Duration
+	staleProfileMonths int
+	staleProfileScan   time.Duration
+	indexReconcile     time.Duration
+	syncDryRun         bool
+}
+
+// parseSettings parses the durations and counts of the configuration, the unset ones keep their defaults
+func parseSettings(config map[string]string) (settings, error) {
+	parsed := settings{restoreGrace: defaultRestoreGracePeriod}
+
+	var validations []error
+	duration := func(key, name string, target *time.Duration) {
+		if value := config[key]; value != "" {
+			d, errParse := time.ParseDuration(value)
+			if errParse != nil {
+				validations = append(validations, errs.NewValidation("invalid "+name, errParse))
+				return
+			}
+			*target = d
+		}
+	}
+
+	duration("restore-grace-period", "restore grace period", &parsed.restoreGrace)
+	duration("stale-profile-scan", "stale profile scan interval", &parsed.staleProfileScan)
+	duration("index-reconcile", "index reconcile interval", &parsed.indexReconcile)
+
+	if value := config["stale-profile-months"]; value != "" {
+		months, errAtoi := strconv.Atoi(value)
+		if errAtoi != nil || months < 0 {
+			validations = append(validations, errs.NewValidation("invalid stale profile months", errAtoi))
+		}
+		parsed.staleProfileMonths = months
+	}
+
+	if value := config["sync-dry-run"]; value != "" {
+		dryRun, errParse := strconv.ParseBool(value)
+		if errParse != nil {
+			validations = append(validations, errs.NewValidation("invalid sync dry run", errParse))
+		}
+		parsed.syncDryRun = dryRun
+	}
+
+	return parsed, errors.Join(validations...)
+}
+
+// ValidateConfig validates the configuration of the repository without connecting to NATS or Kubernetes
+func ValidateConfig(config map[string]string) error {
+	_, err := parseSettings(config)
+	return err
+}
+
 // NewUserReaderWriter creates a new Authelia User repository
-func NewUserReaderWriter(ctx context.Context, config map[string]string, natsClient *nats.NATSClient) (port.UserReaderWriter, error) {
-	// Set defaults in case of not set
+func NewUserReaderWriter(ctx context.Context, config map[string]string, natsClient *nats.NATSClient, opts ...Option) (port.UserReaderWriter, error) {
+
+	settings, errSettings := parseSettings(config)
+	if errSettings != nil {
+		return nil, errSettings
+	}
+
+	emailLinkingFlow, errEmailLinkingFlow := newEmailLinkingFlow()
+	if errEmailLinkingFlow != nil {
+		slog.ErrorContext(ctx, "failed to create email linking flow", "error", errEmailLinkingFlow)
+		return nil, errEmailLinkingFlow
+	}
 
 	u := &userReaderWriter{
-		sync:             &sync{},
+		sync:             &sync{dryRun: settings.syncDryRun},
+		restoreGrace:     settings.restoreGrace,
 		oidcUserInfoURL:  config["oidc-userinfo-url"],
-		emailLinkingFlow: newEmailLinkingFlow(),
+		emailLinkingFlow: emailLinkingFlow,
 		httpClient:       httpclient.NewClient(httpclient.DefaultConfig()),
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
 
 	// Initialize storage using NATS KV store
+	var index lookupIndex
 	if u.storage == nil {
-		storage, errNATSUserStorage := newNATSUserStorage(ctx, natsClient)
+		storage, errNATSUserStorage := newNATSUserStorage(ctx, natsClient, config["region"])
 		if errNATSUserStorage != nil {
 			slog.ErrorContext(ctx, "failed to create storage", "error", errNATSUserStorage)
 			return nil, errNATSUserStorage
 		}
 		u.storage = storage
+		index = storage
+
+		// Journal every change so the KV store can be rebuilt from the events stream
+		if eventSourcing, _ := strconv.ParseBool(config["event-sourcing"]); eventSourcing {
+			journal, errJournal := newNATSUserEventJournal(ctx, natsClient)
+			if errJournal != nil {
+				slog.ErrorContext(ctx, "failed to create user events journal", "error", errJournal)
+				return nil, errJournal
+			}
+			u.storage = &eventSourcedStorage{
+				internalStorageReaderWriter: storage,
+				journal:                     journal,
+				region:                      config["region"],
+				now:                         time.Now,
+			}
+		}
 	}
 
 	// Initialize orchestrator using K8S to update the ConfigMap, Secrets and DaemonSet
@@ -572,10 +752,42 @@ func NewUserReaderWriter(ctx context.Context, config map[string]string, natsClie
 		u.orchestrator = orchestrator
 	}
 
-	errSyncUsers := u.sync.syncUsers(ctx, u.storage, u.orchestrator)
+	// Coordinate the sync and the background jobs of the replicas, only when enabled
+	var locker *lock.Locker
+	if distributedLocks, _ := strconv.ParseBool(config["distributed-locks"]); distributedLocks && natsClient != nil {
+		kv, exists := natsClient.GetKVStore(constants.KVBucketNameLocks)
+		if !exists {
+			return nil, errs.NewUnexpected("locks KV bucket not found in NATS client")
+		}
+		locker = lock.NewLocker(kv)
+	}
+
+	errSyncUsers := u.syncUsersLocked(ctx, locker)
 	if errSyncUsers != nil {
 		slog.WarnContext(ctx, "failed to sync from storage to orchestrator", "error", errSyncUsers)
 	}
+	if natsClient != nil {
+		u.sync.publishStatus(ctx, natsClient, errSyncUsers)
+	}
+	u.sync.audit(ctx, u.auditSink, errSyncUsers)
+
+	// Start the stale profile scanner, only when enabled
+	if settings.staleProfileMonths > 0 {
+		scanner := newStaleProfileScanner(u.storage, natsClient, settings.staleProfileMonths, settings.staleProfileScan)
+		scanner.region = config["region"]
+		scanner.notifier = u.notifier
+		runJob(ctx, locker, staleProfileScanLockName, scanner.run)
+	}
+
+	// Rebuild the email lookup keys when the canonicalization rules changed since they were built
+	if index != nil {
+		runJob(ctx, locker, emailReindexLockName, newIndexReconciler(u.storage, index, 0).runReindex)
+	}
+
+	// Reconcile the lookup index periodically when running active/active across regions
+	if settings.indexReconcile > 0 && index != nil {
+		runJob(ctx, locker, indexReconcileLockName, newIndexReconciler(u.storage, index, settings.indexReconcile).run)
+	}
 
 	return u, nil
 }
@@ -5,10 +5,13 @@ package authelia
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 )
 
 func TestUserWriter_UpdateUser_MetadataPatchBehavior(t *testing.T) {
@@ -135,3 +138,160 @@ func TestUserReaderWriter_MetadataLookup(t *testing.T) {
 		})
 	}
 }
+
+func TestUserReaderWriter_SoftDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		deletedAgo   time.Duration
+		restoreGrace time.Duration
+		wantRestored bool
+	}{
+		{
+			name:         "restore within grace period",
+			restoreGrace: time.Hour,
+			wantRestored: true,
+		},
+		{
+			name:         "restore after grace period",
+			deletedAgo:   2 * time.Hour,
+			restoreGrace: time.Hour,
+			wantRestored: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := &mockStorageReaderWriter{
+				users: map[string]*AutheliaUser{
+					"testuser": {User: &model.User{Username: "testuser"}},
+				},
+			}
+			u := &userReaderWriter{storage: mockStorage, restoreGrace: tt.restoreGrace}
+
+			if err := u.SoftDeleteUser(ctx, &model.User{Username: "testuser"}); err != nil {
+				t.Fatalf("SoftDeleteUser() failed: %v", err)
+			}
+			stored := mockStorage.users["testuser"]
+			if !stored.IsDeleted() {
+				t.Fatal("SoftDeleteUser() should set the tombstone")
+			}
+			deletedAt := stored.DeletedAt.Add(-tt.deletedAgo)
+			stored.DeletedAt = &deletedAt
+
+			restored, err := u.RestoreUser(ctx, &model.User{Username: "testuser"})
+			if tt.wantRestored {
+				if err != nil {
+					t.Fatalf("RestoreUser() failed: %v", err)
+				}
+				if restored.Username != "testuser" || stored.IsDeleted() {
+					t.Error("RestoreUser() should clear the tombstone")
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("RestoreUser() should fail after the grace period")
+			}
+			if !stored.IsDeleted() {
+				t.Error("RestoreUser() should keep the tombstone after the grace period")
+			}
+		})
+	}
+}
+
+func TestUserReaderWriter_SoftDeleteUser_RequiresIdentifier(t *testing.T) {
+	u := &userReaderWriter{storage: &mockStorageReaderWriter{}}
+
+	if err := u.SoftDeleteUser(context.Background(), &model.User{}); err == nil {
+		t.Fatal("SoftDeleteUser() should require a username or sub")
+	}
+}
+
+func TestUserReaderWriter_MatchEmailHashes(t *testing.T) {
+	ctx := context.Background()
+
+	jane := &AutheliaUser{User: &model.User{Username: "jane"}}
+	primary := model.User{PrimaryEmail: "Jane@Example.com"}.BuildEmailIndexKey(ctx)
+	alternate := model.User{}.BuildAlternateEmailIndexKey(ctx, "jane@work.example.com")
+	unknown := model.User{PrimaryEmail: "nobody@example.com"}.BuildEmailIndexKey(ctx)
+
+	storage := &mockStorageReaderWriter{}
+	storage.users = map[string]*AutheliaUser{
+		storage.BuildLookupKey(ctx, "email", primary):   jane,
+		storage.BuildLookupKey(ctx, "email", alternate): jane,
+	}
+	u := &userReaderWriter{storage: storage}
+
+	members, err := u.MatchEmailHashes(ctx, []string{primary, alternate, unknown})
+	if err != nil {
+		t.Fatalf("MatchEmailHashes() unexpected error: %v", err)
+	}
+	want := map[string]bool{primary: true, alternate: true, unknown: false}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("MatchEmailHashes() = %v, want %v", members, want)
+	}
+
+	storage.getUserErr = errs.NewServiceUnavailable("nats unavailable")
+	if _, err := u.MatchEmailHashes(ctx, []string{primary}); err == nil {
+		t.Error("MatchEmailHashes() should fail when the index can't be read")
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		want    settings
+		wantErr int
+	}{
+		{
+			name:   "defaults",
+			config: map[string]string{},
+			want:   settings{restoreGrace: defaultRestoreGracePeriod},
+		},
+		{
+			name: "all set",
+			config: map[string]string{
+				"restore-grace-period": "48h",
+				"stale-profile-months": "18",
+				"stale-profile-scan":   "6h",
+				"index-reconcile":      "15m",
+				"sync-dry-run":         "true",
+			},
+			want: settings{restoreGrace: 48 * time.Hour, staleProfileMonths: 18, staleProfileScan: 6 * time.Hour, indexReconcile: 15 * time.Minute, syncDryRun: true},
+		},
+		{
+			name: "every invalid value is reported",
+			config: map[string]string{
+				"restore-grace-period": "two days",
+				"stale-profile-months": "-1",
+				"index-reconcile":      "often",
+				"sync-dry-run":         "maybe",
+			},
+			wantErr: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSettings(tt.config)
+			if tt.wantErr == 0 {
+				if err != nil {
+					t.Fatalf("parseSettings() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("parseSettings() = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+			joined, ok := err.(interface{ Unwrap() []error })
+			if !ok || len(joined.Unwrap()) != tt.wantErr {
+				t.Fatalf("parseSettings() error = %v, want %d errors", err, tt.wantErr)
+			}
+			if err := ValidateConfig(tt.config); err == nil {
+				t.Error("ValidateConfig() expected an error")
+			}
+		})
+	}
+}
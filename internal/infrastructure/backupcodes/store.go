@@ -0,0 +1,229 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package backupcodes stores the one-time backup codes verifying an email in place of the OTP in a
+// NATS KV bucket, one key per email, so a code issued on a replica can be redeemed on another one.
+//
+// Only the SHA-256 hashes of the codes are stored, salted with the email: the bucket content doesn't
+// allow to verify an email. The codes expire together, issuing new codes replaces the previous ones.
+package backupcodes
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultTTL is how long the backup codes can be redeemed after they were issued
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultCount is the number of backup codes issued at once
+	DefaultCount = 5
+
+	// maxRedeemAttempts is the number of attempts to consume a code when another replica writes the key concurrently
+	maxRedeemAttempts = 5
+)
+
+// errInvalidCode is the error of a code that isn't a valid code of the email, expired ones included,
+// so the callers can't tell them apart
+var errInvalidCode = errs.NewValidation("invalid backup code")
+
+// kvStore is the subset of the NATS KV bucket used by the store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Put(ctx context.Context, key string, value []byte) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+	Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error
+}
+
+// record is the value of the key of an email
+type record struct {
+	Hashes    []string  `json:"hashes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Store is the backup codes of the backup codes KV bucket
+type Store struct {
+	kv    kvStore
+	ttl   time.Duration
+	count int
+	clock clock.Clock
+}
+
+// Option configures the store
+type Option func(*Store)
+
+// WithTTL sets how long the backup codes can be redeemed after they were issued
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Store) {
+		s.ttl = ttl
+	}
+}
+
+// WithCount sets the number of backup codes issued at once
+func WithCount(count int) Option {
+	return func(s *Store) {
+		s.count = count
+	}
+}
+
+// WithClock sets the time source of the expirations
+func WithClock(c clock.Clock) Option {
+	return func(s *Store) {
+		s.clock = c
+	}
+}
+
+// normalizeEmail returns the email the codes are bound to
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// key returns the key of the email, the emails aren't valid KV keys
+func key(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// hash returns the hash of the code of the email
+func hash(email, code string) string {
+	sum := sha256.Sum256([]byte(email + ":" + model.NormalizeBackupCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateCode returns a random code of the form XXXXX-XXXXX
+func generateCode() (string, error) {
+	alphabet := big.NewInt(int64(len(model.BackupCodeAlphabet)))
+	var code strings.Builder
+	for i := range 10 {
+		if i == 5 {
+			code.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, alphabet)
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(model.BackupCodeAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// IssueBackupCodes replaces the backup codes of the email with new ones, the codes are only
+// returned here
+func (s *Store) IssueBackupCodes(ctx context.Context, email string) (*model.EmailBackupCodes, error) {
+	email = normalizeEmail(email)
+	if !(&model.Email{Email: email}).IsValidEmail() {
+		return nil, errs.NewValidation("invalid email")
+	}
+
+	issued := &model.EmailBackupCodes{
+		Email:     email,
+		Codes:     make([]string, 0, s.count),
+		ExpiresAt: s.clock.Now().Add(s.ttl).UTC().Truncate(time.Second),
+	}
+	stored := record{ExpiresAt: issued.ExpiresAt}
+	for range s.count {
+		code, err := generateCode()
+		if err != nil {
+			return nil, errs.NewUnexpected("failed to generate backup code", err)
+		}
+		issued.Codes = append(issued.Codes, code)
+		stored.Hashes = append(stored.Hashes, hash(email, code))
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to marshal backup codes", err)
+	}
+	if _, err := s.kv.Put(ctx, key(email), data); err != nil {
+		return nil, errs.NewServiceUnavailable("failed to store the backup codes", err)
+	}
+	return issued, nil
+}
+
+// RedeemBackupCode consumes the code of the email, it can't be redeemed again
+func (s *Store) RedeemBackupCode(ctx context.Context, email, code string) error {
+	email = normalizeEmail(email)
+	k, h := key(email), hash(email, code)
+
+	for range maxRedeemAttempts {
+		value, err := s.kv.Get(ctx, k)
+		if err != nil {
+			if errors.Is(err, jetstream.ErrKeyNotFound) {
+				return errInvalidCode
+			}
+			return errs.NewServiceUnavailable("failed to read the backup codes", err)
+		}
+
+		var stored record
+		if err := json.Unmarshal(value.Value(), &stored); err != nil {
+			return errs.NewUnexpected("invalid backup codes", err)
+		}
+		if !s.clock.Now().Before(stored.ExpiresAt) {
+			if errDelete := s.kv.Delete(ctx, k, jetstream.LastRevision(value.Revision())); errDelete != nil {
+				slog.DebugContext(ctx, "failed to delete the expired backup codes", "error", errDelete)
+			}
+			return errInvalidCode
+		}
+
+		match := -1
+		for i, candidate := range stored.Hashes {
+			if subtle.ConstantTimeCompare([]byte(candidate), []byte(h)) == 1 {
+				match = i
+			}
+		}
+		if match < 0 {
+			return errInvalidCode
+		}
+
+		stored.Hashes = append(stored.Hashes[:match], stored.Hashes[match+1:]...)
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return errs.NewUnexpected("failed to marshal backup codes", err)
+		}
+		if _, errUpdate := s.kv.Update(ctx, k, data, value.Revision()); errUpdate != nil {
+			slog.DebugContext(ctx, "backup codes changed concurrently, retrying",
+				"email", redaction.RedactEmail(email),
+				"error", errUpdate,
+			)
+			continue
+		}
+		return nil
+	}
+
+	return errs.NewConflict("backup codes changed concurrently, try again")
+}
+
+// New creates the backup codes store on the backup codes KV bucket
+func New(kv jetstream.KeyValue, opts ...Option) *Store {
+	return newStore(kv, opts...)
+}
+
+func newStore(kv kvStore, opts ...Option) *Store {
+	s := &Store{
+		kv:    kv,
+		ttl:   DefaultTTL,
+		count: DefaultCount,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.clock = clock.Or(s.clock)
+	return s
+}
@@ -0,0 +1,131 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package backupcodes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next updates fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Put(ctx context.Context, key string, value []byte) (uint64, error) {
+	revision := f.data[key].revision + 1
+	f.data[key] = fakeEntry{value: value, revision: revision}
+	return revision, nil
+}
+
+func (f *fakeKV) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func (f *fakeKV) Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error {
+	delete(f.data, key)
+	return nil
+}
+
+func TestStore_IssueAndRedeem(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	store := newStore(kv, WithCount(3), WithTTL(time.Hour), WithClock(fake))
+
+	issued, err := store.IssueBackupCodes(ctx, " Jane@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", issued.Email)
+	assert.Equal(t, fake.Now().Add(time.Hour), issued.ExpiresAt)
+	require.Len(t, issued.Codes, 3)
+	for _, code := range issued.Codes {
+		assert.True(t, model.IsBackupCode(code), code)
+	}
+
+	// only the hashes are stored
+	for _, entry := range kv.data {
+		for _, code := range issued.Codes {
+			assert.NotContains(t, string(entry.value), code)
+		}
+		assert.NotContains(t, string(entry.value), "jane@example.com")
+	}
+
+	// a code is redeemed once, in any case
+	require.NoError(t, store.RedeemBackupCode(ctx, "jane@example.com", strings.ToLower(issued.Codes[0])))
+	assert.Equal(t, errInvalidCode, store.RedeemBackupCode(ctx, "jane@example.com", issued.Codes[0]))
+
+	// the codes are bound to the email
+	assert.Equal(t, errInvalidCode, store.RedeemBackupCode(ctx, "john@example.com", issued.Codes[1]))
+	assert.Equal(t, errInvalidCode, store.RedeemBackupCode(ctx, "jane@example.com", "AAAAA-AAAAA"))
+
+	// concurrent writes are retried
+	kv.conflicts = 2
+	require.NoError(t, store.RedeemBackupCode(ctx, "jane@example.com", issued.Codes[1]))
+
+	// issuing new codes replaces the previous ones
+	reissued, err := store.IssueBackupCodes(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, errInvalidCode, store.RedeemBackupCode(ctx, "jane@example.com", issued.Codes[2]))
+
+	// the codes expire
+	fake.Advance(time.Hour)
+	assert.Equal(t, errInvalidCode, store.RedeemBackupCode(ctx, "jane@example.com", reissued.Codes[0]))
+	assert.Empty(t, kv.data)
+}
+
+func TestStore_Errors(t *testing.T) {
+	ctx := context.Background()
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	store := newStore(kv)
+
+	_, err := store.IssueBackupCodes(ctx, "not an email")
+	var validation errs.Validation
+	assert.ErrorAs(t, err, &validation)
+
+	issued, err := store.IssueBackupCodes(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Len(t, issued.Codes, DefaultCount)
+
+	kv.conflicts = maxRedeemAttempts
+	var conflict errs.Conflict
+	assert.ErrorAs(t, store.RedeemBackupCode(ctx, "jane@example.com", issued.Codes[0]), &conflict)
+}
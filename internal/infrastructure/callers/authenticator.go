@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package callers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// DefaultCallerClaim is the claim of the service tokens naming the caller
+const DefaultCallerClaim = "sub"
+
+// tokenVerifier verifies the service tokens, implemented by oidc.Verifier
+type tokenVerifier interface {
+	Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, error)
+}
+
+// TokenAuthenticator authenticates the callers with the service tokens of the trusted issuers,
+// e.g. the client credentials tokens of the calling services
+type TokenAuthenticator struct {
+	verifier tokenVerifier
+	claim    string
+}
+
+// AuthenticateCaller verifies the service token and returns the caller named by its claim
+func (a *TokenAuthenticator) AuthenticateCaller(ctx context.Context, token string) (string, error) {
+	claims, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	caller := claims.Subject
+	if a.claim != DefaultCallerClaim {
+		caller, _ = claims.GetStringClaim(a.claim)
+	}
+	caller = strings.ToLower(strings.TrimSpace(caller))
+	if caller == "" {
+		return "", errs.NewUnauthorized("the service token does not name the caller")
+	}
+	if errID := model.ValidateCallerID(caller); errID != nil {
+		return "", errID
+	}
+	return caller, nil
+}
+
+// NewTokenAuthenticator creates the authenticator of the service tokens verified by the verifier,
+// the caller is named by the claim (the subject when empty)
+func NewTokenAuthenticator(verifier tokenVerifier, claim string) *TokenAuthenticator {
+	if claim == "" {
+		claim = DefaultCallerClaim
+	}
+	return &TokenAuthenticator{verifier: verifier, claim: claim}
+}
@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package callers
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// fakeVerifier accepts the tokens it knows the claims of
+type fakeVerifier map[string]*jwtparser.Claims
+
+func (f fakeVerifier) Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, error) {
+	claims, ok := f[token]
+	if !ok {
+		return nil, errs.NewUnauthorized("invalid token")
+	}
+	return claims, nil
+}
+
+func TestTokenAuthenticator_AuthenticateCaller(t *testing.T) {
+	verifier := fakeVerifier{
+		"cla":      {Subject: "CLA-Service"},
+		"m2m":      {Subject: "abc123@clients", Raw: map[string]any{"service": "project-service"}},
+		"no-claim": {Subject: "abc123@clients"},
+	}
+
+	tests := []struct {
+		name    string
+		claim   string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "subject names the caller", token: "cla", want: "cla-service"},
+		{name: "configured claim names the caller", claim: "service", token: "m2m", want: "project-service"},
+		{name: "subject not a caller id", token: "m2m", wantErr: true},
+		{name: "missing claim", claim: "service", token: "no-claim", wantErr: true},
+		{name: "unverified token", token: "forged", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller, err := NewTokenAuthenticator(verifier, tt.claim).AuthenticateCaller(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuthenticateCaller() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if caller != tt.want {
+				t.Errorf("AuthenticateCaller() = %q, want %q", caller, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package callers stores the caller allowlist in a NATS KV bucket, one key per calling service,
+// so the entries are shared by the replicas and managed at runtime instead of in the environment.
+package callers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// maxPutAttempts is the number of attempts to write an entry when another replica writes it concurrently
+const maxPutAttempts = 5
+
+// kvStore is the subset of the NATS KV bucket used by the store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+	Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error
+	ListKeys(ctx context.Context, opts ...jetstream.WatchOpt) (jetstream.KeyLister, error)
+}
+
+// Store is the caller allowlist of the callers KV bucket, the keys are the caller ids
+type Store struct {
+	kv    kvStore
+	clock clock.Clock
+}
+
+// Option configures the store
+type Option func(*Store)
+
+// WithClock sets the time source of the entry timestamps
+func WithClock(c clock.Clock) Option {
+	return func(s *Store) {
+		s.clock = c
+	}
+}
+
+// get returns the entry and its revision
+func (s *Store) get(ctx context.Context, id string) (*model.CallerAllowlistEntry, uint64, error) {
+	value, err := s.kv.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil, 0, errs.NewNotFound("caller not found")
+		}
+		return nil, 0, errs.NewServiceUnavailable("failed to read the caller allowlist", err)
+	}
+
+	var entry model.CallerAllowlistEntry
+	if err := json.Unmarshal(value.Value(), &entry); err != nil {
+		return nil, 0, errs.NewUnexpected("invalid caller allowlist entry", err)
+	}
+	return &entry, value.Revision(), nil
+}
+
+// GetCaller returns the entry of the caller
+func (s *Store) GetCaller(ctx context.Context, id string) (*model.CallerAllowlistEntry, error) {
+	if err := model.ValidateCallerID(id); err != nil {
+		return nil, err
+	}
+	entry, _, err := s.get(ctx, id)
+	return entry, err
+}
+
+// ListCallers returns all the entries, sorted by id
+func (s *Store) ListCallers(ctx context.Context) ([]*model.CallerAllowlistEntry, error) {
+	lister, err := s.kv.ListKeys(ctx)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoKeysFound) {
+			return []*model.CallerAllowlistEntry{}, nil
+		}
+		return nil, errs.NewServiceUnavailable("failed to list the caller allowlist", err)
+	}
+
+	entries := []*model.CallerAllowlistEntry{}
+	for key := range lister.Keys() {
+		entry, _, errGet := s.get(ctx, key)
+		if errGet != nil {
+			var notFound errs.NotFound
+			if errors.As(errGet, &notFound) {
+				// deleted while listing
+				continue
+			}
+			return nil, errGet
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+// PutCaller creates or replaces the entry of the caller. Putting the state already stored is a no-op
+// keeping the timestamps, so a declarative client sees no change on every apply.
+func (s *Store) PutCaller(ctx context.Context, entry *model.CallerAllowlistEntry) (*model.CallerAllowlistEntry, bool, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	for range maxPutAttempts {
+		now := s.clock.Now().UTC()
+
+		current, revision, errGet := s.get(ctx, entry.ID)
+		var notFound errs.NotFound
+		switch {
+		case errors.As(errGet, &notFound):
+			stored := *entry
+			stored.CreatedAt, stored.UpdatedAt = now, now
+			data, errMarshal := json.Marshal(&stored)
+			if errMarshal != nil {
+				return nil, false, errs.NewUnexpected("failed to marshal caller allowlist entry", errMarshal)
+			}
+			if _, errCreate := s.kv.Create(ctx, entry.ID, data); errCreate != nil {
+				if errors.Is(errCreate, jetstream.ErrKeyExists) {
+					continue
+				}
+				return nil, false, errs.NewServiceUnavailable("failed to write the caller allowlist", errCreate)
+			}
+			return &stored, true, nil
+
+		case errGet != nil:
+			return nil, false, errGet
+
+		case current.SameSpec(entry):
+			return current, false, nil
+		}
+
+		stored := *entry
+		stored.CreatedAt, stored.UpdatedAt = current.CreatedAt, now
+		data, errMarshal := json.Marshal(&stored)
+		if errMarshal != nil {
+			return nil, false, errs.NewUnexpected("failed to marshal caller allowlist entry", errMarshal)
+		}
+		if _, errUpdate := s.kv.Update(ctx, entry.ID, data, revision); errUpdate != nil {
+			slog.DebugContext(ctx, "caller allowlist entry changed concurrently, retrying",
+				"caller", entry.ID,
+				"error", errUpdate,
+			)
+			continue
+		}
+		return &stored, false, nil
+	}
+
+	return nil, false, errs.NewConflict("caller allowlist entry changed concurrently, try again")
+}
+
+// DeleteCaller removes the entry of the caller
+func (s *Store) DeleteCaller(ctx context.Context, id string) error {
+	if err := model.ValidateCallerID(id); err != nil {
+		return err
+	}
+	if _, _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.kv.Delete(ctx, id); err != nil {
+		return errs.NewServiceUnavailable("failed to delete from the caller allowlist", err)
+	}
+	return nil
+}
+
+// New creates the caller allowlist on the callers KV bucket
+func New(kv jetstream.KeyValue, opts ...Option) *Store {
+	return newStore(kv, opts...)
+}
+
+func newStore(kv kvStore, opts ...Option) *Store {
+	s := &Store{kv: kv}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.clock = clock.Or(s.clock)
+	return s
+}
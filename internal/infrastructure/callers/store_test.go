@@ -0,0 +1,183 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package callers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+type fakeLister struct {
+	jetstream.KeyLister
+	keys chan string
+}
+
+func (l fakeLister) Keys() <-chan string { return l.keys }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next updates fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error) {
+	if _, ok := f.data[key]; ok {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.data[key] = fakeEntry{value: value, revision: 1}
+	return 1, nil
+}
+
+func (f *fakeKV) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func (f *fakeKV) Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error {
+	delete(f.data, key)
+	return nil
+}
+
+func (f *fakeKV) ListKeys(ctx context.Context, opts ...jetstream.WatchOpt) (jetstream.KeyLister, error) {
+	if len(f.data) == 0 {
+		return nil, jetstream.ErrNoKeysFound
+	}
+	keys := make(chan string, len(f.data))
+	for key := range f.data {
+		keys <- key
+	}
+	close(keys)
+	return fakeLister{keys: keys}, nil
+}
+
+func TestStore_PutCaller(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(created)
+	kv := &fakeKV{data: map[string]fakeEntry{}}
+	store := newStore(kv, WithClock(fake))
+
+	entry := func(capabilities ...model.CallerCapability) *model.CallerAllowlistEntry {
+		return &model.CallerAllowlistEntry{ID: "cla-service", Description: " CLA checks ", Capabilities: capabilities}
+	}
+
+	stored, isNew, err := store.PutCaller(ctx, entry(model.CallerCapabilityEmailOwner, model.CallerCapabilityEmailOwner))
+	if err != nil || !isNew {
+		t.Fatalf("PutCaller() = %v, %v, want a new entry", isNew, err)
+	}
+	if stored.Description != "CLA checks" || len(stored.Capabilities) != 1 || !stored.CreatedAt.Equal(created) {
+		t.Errorf("PutCaller() stored %+v", stored)
+	}
+
+	t.Run("same state is a no-op", func(t *testing.T) {
+		fake.Advance(time.Hour)
+		again, isNew, err := store.PutCaller(ctx, entry(model.CallerCapabilityEmailOwner))
+		if err != nil || isNew || !again.UpdatedAt.Equal(created) {
+			t.Errorf("PutCaller() = %+v, %v, %v, want the unchanged entry", again, isNew, err)
+		}
+		if kv.data["cla-service"].revision != 1 {
+			t.Errorf("PutCaller() rewrote the entry, revision %d", kv.data["cla-service"].revision)
+		}
+	})
+
+	t.Run("change keeps the creation time", func(t *testing.T) {
+		kv.conflicts = 1
+		changed, isNew, err := store.PutCaller(ctx, entry())
+		if err != nil || isNew {
+			t.Fatalf("PutCaller() = %v, %v", isNew, err)
+		}
+		if !changed.CreatedAt.Equal(created) || !changed.UpdatedAt.Equal(fake.Now()) || changed.Has(model.CallerCapabilityEmailOwner) {
+			t.Errorf("PutCaller() stored %+v", changed)
+		}
+	})
+
+	t.Run("concurrent writers", func(t *testing.T) {
+		kv.conflicts = maxPutAttempts
+		var conflict errs.Conflict
+		if _, _, err := store.PutCaller(ctx, entry(model.CallerCapabilityEmailOwner)); !errors.As(err, &conflict) {
+			t.Errorf("PutCaller() error = %v, want a conflict", err)
+		}
+		kv.conflicts = 0
+	})
+
+	invalid := []*model.CallerAllowlistEntry{
+		{ID: "CLA Service"},
+		{ID: "cla-service", Capabilities: []model.CallerCapability{"admin"}},
+	}
+	for _, tt := range invalid {
+		var validation errs.Validation
+		if _, _, err := store.PutCaller(ctx, tt); !errors.As(err, &validation) {
+			t.Errorf("PutCaller(%+v) error = %v, want a validation error", tt, err)
+		}
+	}
+}
+
+func TestStore_GetListDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(&fakeKV{data: map[string]fakeEntry{}})
+
+	entries, err := store.ListCallers(ctx)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("ListCallers() of an empty bucket = %v, %v", entries, err)
+	}
+
+	for _, id := range []string{"project-service", "cla-service"} {
+		if _, _, err := store.PutCaller(ctx, &model.CallerAllowlistEntry{ID: id}); err != nil {
+			t.Fatalf("PutCaller() unexpected error: %v", err)
+		}
+	}
+
+	entries, err = store.ListCallers(ctx)
+	if err != nil || len(entries) != 2 || entries[0].ID != "cla-service" || entries[1].ID != "project-service" {
+		t.Errorf("ListCallers() = %v, %v, want the entries sorted by id", entries, err)
+	}
+
+	if entry, err := store.GetCaller(ctx, "cla-service"); err != nil || entry.ID != "cla-service" {
+		t.Errorf("GetCaller() = %v, %v", entry, err)
+	}
+
+	if err := store.DeleteCaller(ctx, "cla-service"); err != nil {
+		t.Fatalf("DeleteCaller() unexpected error: %v", err)
+	}
+
+	var notFound errs.NotFound
+	if _, err := store.GetCaller(ctx, "cla-service"); !errors.As(err, &notFound) {
+		t.Errorf("GetCaller() of a deleted caller error = %v, want not found", err)
+	}
+	if err := store.DeleteCaller(ctx, "cla-service"); !errors.As(err, &notFound) {
+		t.Errorf("DeleteCaller() of a deleted caller error = %v, want not found", err)
+	}
+}
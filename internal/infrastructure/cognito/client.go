@@ -0,0 +1,204 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+const (
+	cognitoService = "cognito-idp"
+
+	// targetPrefix is the prefix of the X-Amz-Target header naming the API action
+	targetPrefix = "AWSCognitoIdentityProviderService."
+
+	contentType = "application/x-amz-json-1.1"
+)
+
+// Config holds the configuration for the Cognito User Pools API
+type Config struct {
+	// UserPoolID is the user pool of the users, e.g. us-east-1_AbC123
+	UserPoolID string
+	// Region is the region of the user pool, derived from the user pool id when empty
+	Region string
+	// Endpoint is the Cognito API endpoint, derived from the region when empty
+	Endpoint string
+	// Credentials are the AWS credentials signing the requests, the IAM principal must be allowed
+	// the cognito-idp Admin* and ListUsers actions on the user pool
+	Credentials sigv4.Credentials
+	// ClientID is the expected app client of the user tokens, not checked when empty
+	ClientID string
+	// ResourceServer is the identifier of the resource server defining the custom scopes, the
+	// scopes of the user tokens are prefixed with it (e.g. https://api.lfx.dev/update:current_user_metadata)
+	ResourceServer string
+	// LinkingSecret signs the identity tokens of the verified alternate emails
+	LinkingSecret string
+	// EmailSender delivers the alternate email OTPs, configured from the environment when nil
+	EmailSender port.TemplatedEmailSender
+	// VerificationCodes keeps the pending alternate email OTPs, in the memory of the replica when nil
+	VerificationCodes emaillinking.Store
+	// Clock is the time source of the signatures and the OTP expirations, the system clock when nil
+	Clock clock.Clock
+}
+
+// Validate checks the configuration is complete, without calling Cognito
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.UserPoolID) == "" {
+		return errors.NewValidation("Cognito user pool id is required")
+	}
+	if c.region() == "" {
+		return errors.NewValidation(fmt.Sprintf("invalid Cognito user pool id %q, expected <region>_<id>", c.UserPoolID))
+	}
+	if c.Endpoint != "" {
+		if parsed, err := url.Parse(c.Endpoint); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return errors.NewValidation(fmt.Sprintf("invalid Cognito endpoint %q, expected an absolute URL", c.Endpoint))
+		}
+	}
+	if c.Credentials.AccessKeyID == "" || c.Credentials.SecretAccessKey == "" {
+		return errors.NewValidation("AWS credentials are required for Cognito")
+	}
+	if c.LinkingSecret == "" {
+		return errors.NewValidation("Cognito linking secret is required")
+	}
+	return nil
+}
+
+// region is the region of the user pool, the prefix of its id when not configured
+func (c Config) region() string {
+	if c.Region != "" {
+		return c.Region
+	}
+	region, _, found := strings.Cut(c.UserPoolID, "_")
+	if !found {
+		return ""
+	}
+	return region
+}
+
+// endpoint is the URL of the Cognito API
+func (c Config) endpoint() string {
+	if c.Endpoint != "" {
+		return strings.TrimRight(c.Endpoint, "/") + "/"
+	}
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/", c.region())
+}
+
+// issuer is the issuer of the user pool tokens, under the endpoint when configured (e.g. LocalStack)
+func (c Config) issuer() string {
+	return c.endpoint() + c.UserPoolID
+}
+
+// scope is the scope of the user tokens, prefixed with the resource server when configured
+func (c Config) scope(scope string) string {
+	if c.ResourceServer == "" {
+		return scope
+	}
+	return strings.TrimRight(c.ResourceServer, "/") + "/" + scope
+}
+
+// apiError is the error body of the Cognito API
+type apiError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// errorFromAPI maps the Cognito exceptions to the service errors, Cognito replies 400 to most of them
+func errorFromAPI(statusCode int, body []byte, message string) error {
+	var response apiError
+	if err := json.Unmarshal(body, &response); err != nil || response.Type == "" {
+		return httpclient.ErrorFromStatusCode(statusCode, message)
+	}
+
+	// the type can be namespaced, e.g. com.amazonaws.cognito#UserNotFoundException
+	exception := response.Type[strings.LastIndex(response.Type, "#")+1:]
+	switch exception {
+	case "UserNotFoundException":
+		return errors.NewNotFound("user not found")
+	case "InvalidParameterException", "AliasExistsException":
+		return errors.NewValidation(fmt.Sprintf("%s: %s", message, response.Message))
+	case "TooManyRequestsException", "LimitExceededException":
+		return errors.NewTooManyRequests(message)
+	case "NotAuthorizedException":
+		return errors.NewForbidden(message)
+	case "InternalErrorException":
+		return errors.NewServiceUnavailable(message)
+	}
+	return httpclient.ErrorFromStatusCode(statusCode, message)
+}
+
+// client is the Cognito User Pools API client, the requests are signed with SigV4
+type client struct {
+	config     Config
+	httpClient *httpclient.Client
+	clock      clock.Clock
+}
+
+// call calls the API action, the user pool id is set by the request
+func (c *client) call(ctx context.Context, action string, request, response any, description string) error {
+	payload, errMarshal := json.Marshal(request)
+	if errMarshal != nil {
+		return errors.NewUnexpected(fmt.Sprintf("failed to marshal %s request", action), errMarshal)
+	}
+
+	// the request is only signed here, the headers are sent by the HTTP client
+	signed, errRequest := http.NewRequestWithContext(ctx, http.MethodPost, c.config.endpoint(), bytes.NewReader(payload))
+	if errRequest != nil {
+		return errors.NewUnexpected("failed to build Cognito request", errRequest)
+	}
+	signed.Header.Set("Content-Type", contentType)
+	signed.Header.Set("X-Amz-Target", targetPrefix+action)
+	if errSign := sigv4.Sign(signed, payload, c.config.Credentials, cognitoService, c.config.region(), c.clock.Now()); errSign != nil {
+		return errors.NewUnexpected("failed to sign Cognito request", errSign)
+	}
+
+	headers := make(map[string]string, len(signed.Header))
+	for name := range signed.Header {
+		headers[name] = signed.Header.Get(name)
+	}
+
+	resp, errCall := c.httpClient.Do(ctx, httpclient.Request{
+		Method:  http.MethodPost,
+		URL:     c.config.endpoint(),
+		Headers: headers,
+		Body:    bytes.NewReader(payload),
+	})
+	if errCall != nil {
+		slog.ErrorContext(ctx, "Cognito request failed",
+			"error", errCall,
+			"action", action,
+		)
+		// the exception is in the body of the failed requests
+		var retryable *httpclient.RetryableError
+		if stderrors.As(errCall, &retryable) {
+			if retryable.StatusCode == http.StatusTooManyRequests {
+				return httpclient.ErrorFromCall(retryable.StatusCode, fmt.Sprintf("failed to %s", description), errCall)
+			}
+			return errorFromAPI(retryable.StatusCode, []byte(retryable.Message), fmt.Sprintf("failed to %s", description))
+		}
+		return errors.NewServiceUnavailable(fmt.Sprintf("failed to %s", description), errCall)
+	}
+
+	if response == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	if errUnmarshal := json.Unmarshal(resp.Body, response); errUnmarshal != nil {
+		return errors.NewUnexpected(fmt.Sprintf("failed to unmarshal %s response", action), errUnmarshal)
+	}
+	return nil
+}
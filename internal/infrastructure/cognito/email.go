@@ -0,0 +1,28 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// newEmailLinkingFlow creates the OTP verification of the alternate emails, Cognito only verifies
+// the primary email. The identity tokens are signed with the linking secret.
+func newEmailLinkingFlow(config Config) (*emaillinking.Flow, error) {
+	return emaillinking.New(config.EmailSender,
+		emaillinking.NewHMACSigner([]byte(config.LinkingSecret)),
+		fmt.Sprintf("%s#%s", config.issuer(), constants.ServiceName),
+		emaillinking.WithStore(config.VerificationCodes),
+		emaillinking.WithClock(config.Clock),
+	)
+}
+
+// normalizeEmail is the form of the alternate emails searched and linked
+func normalizeEmail(emailAddress string) string {
+	return strings.ToLower(strings.TrimSpace(emailAddress))
+}
@@ -0,0 +1,235 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+const (
+	subAttribute           = "sub"
+	emailAttribute         = "email"
+	emailVerifiedAttribute = "email_verified"
+	identitiesAttribute    = "identities"
+
+	// alternateEmailsAttribute holds the comma separated verified alternate emails of the user
+	alternateEmailsAttribute = "custom:alternate_emails"
+	// organizationVerifiedAttribute holds the organization verified badge computed by the service,
+	// the custom attribute names are limited to 20 characters
+	organizationVerifiedAttribute = "custom:org_verified"
+)
+
+// CognitoAttribute is a user attribute of the Cognito User Pools API (AttributeType)
+type CognitoAttribute struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// CognitoUser represents a user of the Cognito User Pools API (UserType)
+type CognitoUser struct {
+	Username   string             `json:"Username"`
+	Attributes []CognitoAttribute `json:"Attributes"`
+	Enabled    bool               `json:"Enabled"`
+	UserStatus string             `json:"UserStatus"`
+}
+
+// cognitoIdentity is an identity of the identities attribute, a federated login of the user
+type cognitoIdentity struct {
+	UserID       string `json:"userId"`
+	ProviderName string `json:"providerName"`
+	ProviderType string `json:"providerType"`
+}
+
+// metadataAttributes maps the user attributes to the metadata fields, the standard attributes
+// are used when there is one
+func metadataAttributes(meta *model.UserMetadata) map[string]**string {
+	return map[string]**string{
+		"name":                  &meta.Name,
+		"given_name":            &meta.GivenName,
+		"family_name":           &meta.FamilyName,
+		"picture":               &meta.Picture,
+		"zoneinfo":              &meta.Zoneinfo,
+		"address":               &meta.Address,
+		"phone_number":          &meta.PhoneNumber,
+		"custom:job_title":      &meta.JobTitle,
+		"custom:organization":   &meta.Organization,
+		"custom:country":        &meta.Country,
+		"custom:state_province": &meta.StateProvince,
+		"custom:city":           &meta.City,
+		"custom:postal_code":    &meta.PostalCode,
+		"custom:t_shirt_size":   &meta.TShirtSize,
+	}
+}
+
+// attribute returns the value of the attribute, nil when not set
+func (u *CognitoUser) attribute(name string) *string {
+	for _, attribute := range u.Attributes {
+		if attribute.Name == name {
+			value := attribute.Value
+			return &value
+		}
+	}
+	return nil
+}
+
+// SetAttributes sets the attributes, like the update applied by Cognito
+func (u *CognitoUser) SetAttributes(attributes []CognitoAttribute) {
+	for _, update := range attributes {
+		index := slices.IndexFunc(u.Attributes, func(attribute CognitoAttribute) bool {
+			return attribute.Name == update.Name
+		})
+		if index < 0 {
+			u.Attributes = append(u.Attributes, update)
+			continue
+		}
+		u.Attributes[index].Value = update.Value
+	}
+}
+
+// MetadataAttributes returns the attributes of the metadata fields present in the update, like
+// the Auth0 user_metadata patch the fields not present are left untouched
+func MetadataAttributes(meta *model.UserMetadata) []CognitoAttribute {
+	if meta == nil {
+		return nil
+	}
+
+	var attributes []CognitoAttribute
+	for name, field := range metadataAttributes(meta) {
+		if *field != nil {
+			attributes = append(attributes, CognitoAttribute{Name: name, Value: **field})
+		}
+	}
+	if meta.OrganizationVerified != nil {
+		attributes = append(attributes, CognitoAttribute{
+			Name:  organizationVerifiedAttribute,
+			Value: strconv.FormatBool(*meta.OrganizationVerified),
+		})
+	}
+	slices.SortFunc(attributes, func(a, b CognitoAttribute) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return attributes
+}
+
+// alternateEmails returns the verified alternate emails
+func (u *CognitoUser) alternateEmails() []string {
+	value := u.attribute(alternateEmailsAttribute)
+	if value == nil {
+		return nil
+	}
+	var emails []string
+	for _, email := range strings.Split(*value, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// HasAlternateEmail reports whether the email is one of the verified alternate emails
+func (u *CognitoUser) HasAlternateEmail(email string) bool {
+	return slices.ContainsFunc(u.alternateEmails(), func(existing string) bool {
+		return strings.EqualFold(existing, email)
+	})
+}
+
+// AddAlternateEmail returns the alternate emails attribute with the email added, it reports
+// false when already present
+func (u *CognitoUser) AddAlternateEmail(email string) (CognitoAttribute, bool) {
+	emails := u.alternateEmails()
+	if u.HasAlternateEmail(email) {
+		return CognitoAttribute{}, false
+	}
+	return CognitoAttribute{Name: alternateEmailsAttribute, Value: strings.Join(append(emails, email), ",")}, true
+}
+
+// RemoveAlternateEmail returns the alternate emails attribute without the email, it reports
+// false when not present
+func (u *CognitoUser) RemoveAlternateEmail(email string) (CognitoAttribute, bool) {
+	emails := u.alternateEmails()
+	index := slices.IndexFunc(emails, func(existing string) bool {
+		return strings.EqualFold(existing, email)
+	})
+	if index < 0 {
+		return CognitoAttribute{}, false
+	}
+	return CognitoAttribute{Name: alternateEmailsAttribute, Value: strings.Join(slices.Delete(emails, index, index+1), ",")}, true
+}
+
+// identities returns the federated logins of the identities attribute
+func (u *CognitoUser) identities() []cognitoIdentity {
+	value := u.attribute(identitiesAttribute)
+	if value == nil {
+		return nil
+	}
+	var identities []cognitoIdentity
+	if err := json.Unmarshal([]byte(*value), &identities); err != nil {
+		return nil
+	}
+	return identities
+}
+
+// ToUser converts a CognitoUser to a User, the user id is the sub of the user pool tokens
+func (u *CognitoUser) ToUser() *model.User {
+	meta := &model.UserMetadata{}
+	for name, field := range metadataAttributes(meta) {
+		*field = u.attribute(name)
+	}
+	if value := u.attribute(organizationVerifiedAttribute); value != nil {
+		if verified, err := strconv.ParseBool(*value); err == nil {
+			meta.OrganizationVerified = &verified
+		}
+	}
+
+	var alternateEmails []model.Email
+	for _, email := range u.alternateEmails() {
+		alternateEmails = append(alternateEmails, model.Email{
+			Email:    email,
+			Verified: true,
+		})
+	}
+
+	var identities []model.Identity
+	for _, identity := range u.identities() {
+		identities = append(identities, identity.ToIdentity())
+	}
+
+	var sub, email string
+	if value := u.attribute(subAttribute); value != nil {
+		sub = *value
+	}
+	if value := u.attribute(emailAttribute); value != nil {
+		email = *value
+	}
+	var emailVerified bool
+	if value := u.attribute(emailVerifiedAttribute); value != nil {
+		emailVerified, _ = strconv.ParseBool(*value)
+	}
+
+	return &model.User{
+		UserID:               sub,
+		Sub:                  sub,
+		Username:             u.Username,
+		PrimaryEmail:         email,
+		PrimaryEmailVerified: emailVerified,
+		AlternateEmails:      alternateEmails,
+		Identities:           identities,
+		UserMetadata:         meta,
+	}
+}
+
+// ToIdentity converts a cognitoIdentity to an Identity, the SAML and OIDC providers are the
+// enterprise ones
+func (i *cognitoIdentity) ToIdentity() model.Identity {
+	return model.Identity{
+		Provider:   i.ProviderName,
+		IdentityID: i.UserID,
+		IsSocial:   i.ProviderType != "SAML" && i.ProviderType != "OIDC",
+	}
+}
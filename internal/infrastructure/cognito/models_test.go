@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     *model.UserMetadata
+		expected []CognitoAttribute
+	}{
+		{
+			name: "standard and custom attributes, sorted",
+			meta: &model.UserMetadata{
+				GivenName:   converters.StringPtr("Jane"),
+				JobTitle:    converters.StringPtr("Engineer"),
+				PhoneNumber: converters.StringPtr("+351912345678"),
+			},
+			expected: []CognitoAttribute{
+				{Name: "custom:job_title", Value: "Engineer"},
+				{Name: "given_name", Value: "Jane"},
+				{Name: "phone_number", Value: "+351912345678"},
+			},
+		},
+		{
+			name:     "organization verified",
+			meta:     &model.UserMetadata{OrganizationVerified: converters.BoolPtr(false)},
+			expected: []CognitoAttribute{{Name: organizationVerifiedAttribute, Value: "false"}},
+		},
+		{
+			name: "nothing to update",
+			meta: &model.UserMetadata{},
+		},
+		{
+			name: "nil metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MetadataAttributes(tt.meta))
+		})
+	}
+}
+
+func TestCognitoUser_ToUser(t *testing.T) {
+	user := &CognitoUser{
+		Username: "jdoe",
+		Attributes: []CognitoAttribute{
+			{Name: "sub", Value: testUserID},
+			{Name: "email", Value: "jane@example.com"},
+			{Name: "email_verified", Value: "true"},
+			{Name: "name", Value: "Jane Doe"},
+			{Name: "custom:t_shirt_size", Value: "M"},
+			{Name: organizationVerifiedAttribute, Value: "true"},
+			{Name: alternateEmailsAttribute, Value: "jane@work.example.com, ,jane@old.example.com"},
+			{Name: identitiesAttribute, Value: `[{"userId":"1234","providerName":"Google","providerType":"Google"},{"userId":"abc","providerName":"LF-SSO","providerType":"SAML"}]`},
+		},
+	}
+
+	converted := user.ToUser()
+	assert.Equal(t, testUserID, converted.UserID)
+	assert.Equal(t, testUserID, converted.Sub)
+	assert.Equal(t, "jdoe", converted.Username)
+	assert.Equal(t, "jane@example.com", converted.PrimaryEmail)
+	assert.True(t, converted.PrimaryEmailVerified)
+	assert.Equal(t, "Jane Doe", *converted.UserMetadata.Name)
+	assert.Equal(t, "M", *converted.UserMetadata.TShirtSize)
+	assert.True(t, *converted.UserMetadata.OrganizationVerified)
+	assert.Nil(t, converted.UserMetadata.City)
+	assert.Equal(t, []model.Email{
+		{Email: "jane@work.example.com", Verified: true},
+		{Email: "jane@old.example.com", Verified: true},
+	}, converted.AlternateEmails)
+	assert.Equal(t, []model.Identity{
+		{Provider: "Google", IdentityID: "1234", IsSocial: true},
+		{Provider: "LF-SSO", IdentityID: "abc"},
+	}, converted.Identities)
+}
+
+func TestCognitoUser_AlternateEmails(t *testing.T) {
+	user := &CognitoUser{Attributes: []CognitoAttribute{{Name: alternateEmailsAttribute, Value: "jane@work.example.com"}}}
+
+	attribute, added := user.AddAlternateEmail("Jane@Work.example.com")
+	assert.False(t, added, "the emails are case insensitive")
+	assert.Empty(t, attribute)
+
+	attribute, added = user.AddAlternateEmail("jane@personal.example.com")
+	assert.True(t, added)
+	assert.Equal(t, CognitoAttribute{Name: alternateEmailsAttribute, Value: "jane@work.example.com,jane@personal.example.com"}, attribute)
+	user.SetAttributes([]CognitoAttribute{attribute})
+	assert.True(t, user.HasAlternateEmail("JANE@personal.example.com"))
+
+	attribute, removed := user.RemoveAlternateEmail("JANE@work.example.com")
+	assert.True(t, removed)
+	assert.Equal(t, CognitoAttribute{Name: alternateEmailsAttribute, Value: "jane@personal.example.com"}, attribute)
+
+	_, removed = (&CognitoUser{}).RemoveAlternateEmail("jane@work.example.com")
+	assert.False(t, removed)
+}
+
+func TestErrorFromAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   any
+	}{
+		{name: "user not found", statusCode: http.StatusBadRequest, body: `{"__type":"UserNotFoundException","message":"User does not exist."}`, expected: errors.NotFound{}},
+		{name: "namespaced type", statusCode: http.StatusBadRequest, body: `{"__type":"com.amazonaws.cognito#UserNotFoundException"}`, expected: errors.NotFound{}},
+		{name: "invalid parameter", statusCode: http.StatusBadRequest, body: `{"__type":"InvalidParameterException","message":"Invalid phone number format."}`, expected: errors.Validation{}},
+		{name: "throttled", statusCode: http.StatusBadRequest, body: `{"__type":"TooManyRequestsException"}`, expected: errors.TooManyRequests{}},
+		{name: "not authorized", statusCode: http.StatusBadRequest, body: `{"__type":"NotAuthorizedException"}`, expected: errors.Forbidden{}},
+		{name: "internal error", statusCode: http.StatusInternalServerError, body: `{"__type":"InternalErrorException"}`, expected: errors.ServiceUnavailable{}},
+		{name: "no exception", statusCode: http.StatusForbidden, body: `forbidden`, expected: errors.Forbidden{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.IsType(t, tt.expected, errorFromAPI(tt.statusCode, []byte(tt.body), "failed to update user"))
+		})
+	}
+}
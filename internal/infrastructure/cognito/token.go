@@ -0,0 +1,157 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// accessTokenUse is the token_use claim of the access tokens, the ID tokens are not accepted
+const accessTokenUse = "access"
+
+// jwtVerifier verifies the user access tokens issued by the user pool. The user pools sign the
+// ID and the access tokens with different keys, the key is picked by the kid of the token.
+type jwtVerifier struct {
+	publicKeys       map[string]*rsa.PublicKey
+	expectedIssuer   string
+	expectedClientID string
+	config           Config
+}
+
+// keyID returns the kid of the token header
+func keyID(token string) (string, error) {
+	cleanToken, isJWT := jwtparser.LooksLikeJWT(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if !isJWT {
+		return "", errors.NewValidation("invalid token format")
+	}
+	message, err := jws.Parse([]byte(cleanToken))
+	if err != nil || len(message.Signatures()) == 0 {
+		return "", errors.NewValidation("invalid token format")
+	}
+	return message.Signatures()[0].ProtectedHeaders().KeyID(), nil
+}
+
+// Verify verifies the token signature, issuer, app client (when configured), token use and the
+// required scopes, prefixed with the resource server
+func (j *jwtVerifier) Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, error) {
+	if j == nil {
+		return nil, errors.NewValidation("JWT verification configuration is required")
+	}
+
+	kid, errKid := keyID(token)
+	if errKid != nil {
+		return nil, errKid
+	}
+	publicKey, found := j.publicKeys[kid]
+	if !found {
+		slog.WarnContext(ctx, "unknown JWT signing key", "key_id", kid)
+		return nil, errors.NewUnauthorized("unknown token signing key")
+	}
+
+	scopes := make([]string, 0, len(requiredScopes))
+	for _, scope := range requiredScopes {
+		scopes = append(scopes, j.config.scope(scope))
+	}
+
+	claims, err := jwtparser.ParseVerified(ctx, token, &jwtparser.ParseOptions{
+		RequireExpiration: true,
+		AllowBearerPrefix: true,
+		RequireSubject:    true,
+		VerifySignature:   true,
+		SigningKey:        publicKey,
+		ExpectedIssuer:    j.expectedIssuer,
+		RequiredScopes:    scopes,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "JWT signature verification failed",
+			"error", err,
+			"required_scope", scopes,
+		)
+		return nil, err
+	}
+
+	// the access tokens carry the app client in client_id, they have no audience
+	if tokenUse, _ := claims.GetStringClaim("token_use"); tokenUse != accessTokenUse {
+		return nil, errors.NewUnauthorized(fmt.Sprintf("invalid token use %q, an access token is required", tokenUse))
+	}
+	if j.expectedClientID != "" {
+		if clientID, _ := claims.GetStringClaim("client_id"); clientID != j.expectedClientID {
+			return nil, errors.NewUnauthorized("token issued for another app client")
+		}
+	}
+
+	slog.DebugContext(ctx, "JWT signature verification successful",
+		"user_id", redaction.Redact(claims.Subject),
+		"required_scope", scopes,
+	)
+	return claims, nil
+}
+
+// newJWTVerifier loads the signing keys of the user pool from its JWKS
+func newJWTVerifier(ctx context.Context, config Config, httpClient *httpclient.Client) (*jwtVerifier, error) {
+	jwksURL := config.issuer() + "/.well-known/jwks.json"
+
+	apiRequest := httpclient.NewAPIRequest(
+		httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(jwksURL),
+		httpclient.WithDescription("fetch Cognito JWKS"),
+	)
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if _, err := apiRequest.Call(ctx, &jwks); err != nil {
+		return nil, errors.NewUnexpected("failed to fetch JWKS", err)
+	}
+
+	publicKeys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, rawKey := range jwks.Keys {
+		var key struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg,omitempty"`
+		}
+		if err := json.Unmarshal(rawKey, &key); err != nil {
+			continue
+		}
+		if key.Kty != "RSA" || key.Kid == "" || (key.Alg != "" && key.Alg != "RS256") {
+			continue
+		}
+
+		publicKey, err := jwtparser.LoadRSAPublicKeyFromJWK(rawKey)
+		if err != nil {
+			return nil, errors.NewUnexpected("failed to load RSA public key from JWK", err)
+		}
+		publicKeys[key.Kid] = publicKey
+	}
+	if len(publicKeys) == 0 {
+		return nil, errors.NewUnexpected(fmt.Sprintf("no suitable RSA key found in the JWKS of the user pool %s", config.UserPoolID))
+	}
+
+	slog.InfoContext(ctx, "JWT signature verification enabled",
+		"issuer", config.issuer(),
+		"client_id", config.ClientID,
+		"keys", len(publicKeys),
+	)
+
+	return &jwtVerifier{
+		publicKeys:       publicKeys,
+		expectedIssuer:   config.issuer(),
+		expectedClientID: config.ClientID,
+		config:           config,
+	}, nil
+}
@@ -0,0 +1,420 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// listUsersLimit is the largest page of ListUsers
+	listUsersLimit = 60
+
+	// federatedSubjectAttribute identifies the federated identities to unlink
+	federatedSubjectAttribute = "Cognito_Subject"
+)
+
+// listUsersResponse is the response of ListUsers
+type listUsersResponse struct {
+	Users           []CognitoUser `json:"Users"`
+	PaginationToken string        `json:"PaginationToken"`
+}
+
+// adminGetUserResponse is the response of AdminGetUser, the attributes are named differently
+type adminGetUserResponse struct {
+	Username       string             `json:"Username"`
+	UserAttributes []CognitoAttribute `json:"UserAttributes"`
+	Enabled        bool               `json:"Enabled"`
+	UserStatus     string             `json:"UserStatus"`
+}
+
+type userReaderWriter struct {
+	config           Config
+	client           *client
+	jwtVerifier      *jwtVerifier
+	emailLinkingFlow *emaillinking.Flow
+}
+
+// filterValue quotes the value of a ListUsers filter, the quotes and backslashes are escaped
+func filterValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// listUsers returns the first page of the users matching the filter
+func (u *userReaderWriter) listUsers(ctx context.Context, filter, paginationToken string) (*listUsersResponse, error) {
+	request := map[string]any{
+		"UserPoolId": u.config.UserPoolID,
+		"Limit":      listUsersLimit,
+	}
+	if filter != "" {
+		request["Filter"] = filter
+	}
+	if paginationToken != "" {
+		request["PaginationToken"] = paginationToken
+	}
+
+	var response listUsersResponse
+	if err := u.client.call(ctx, "ListUsers", request, &response, "list users"); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// findUser returns the first user matching the filter attribute
+func (u *userReaderWriter) findUser(ctx context.Context, attribute, value string, match func(*CognitoUser) bool) (*CognitoUser, error) {
+	response, err := u.listUsers(ctx, fmt.Sprintf("%s = %s", attribute, filterValue(value)), "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Users {
+		if match(&response.Users[i]) {
+			return &response.Users[i], nil
+		}
+	}
+	return nil, errors.NewNotFound("user not found")
+}
+
+// getCognitoUserBySub returns the user of the sub, the user id of the service
+func (u *userReaderWriter) getCognitoUserBySub(ctx context.Context, sub string) (*CognitoUser, error) {
+	if strings.TrimSpace(sub) == "" {
+		return nil, errors.NewValidation("user_id is required to get user")
+	}
+	return u.findUser(ctx, subAttribute, sub, func(candidate *CognitoUser) bool {
+		value := candidate.attribute(subAttribute)
+		return value != nil && *value == sub
+	})
+}
+
+// getCognitoUserByUsername returns the user of the username, or of one of its aliases
+func (u *userReaderWriter) getCognitoUserByUsername(ctx context.Context, username string) (*CognitoUser, error) {
+	var response adminGetUserResponse
+	request := map[string]any{
+		"UserPoolId": u.config.UserPoolID,
+		"Username":   username,
+	}
+	if err := u.client.call(ctx, "AdminGetUser", request, &response, "get user"); err != nil {
+		return nil, err
+	}
+	return &CognitoUser{
+		Username:   response.Username,
+		Attributes: response.UserAttributes,
+		Enabled:    response.Enabled,
+		UserStatus: response.UserStatus,
+	}, nil
+}
+
+// updateAttributes sets the attributes of the user, the attributes not present are left untouched
+func (u *userReaderWriter) updateAttributes(ctx context.Context, cognitoUser *CognitoUser, attributes []CognitoAttribute) error {
+	request := map[string]any{
+		"UserPoolId":     u.config.UserPoolID,
+		"Username":       cognitoUser.Username,
+		"UserAttributes": attributes,
+	}
+	if err := u.client.call(ctx, "AdminUpdateUserAttributes", request, nil, "update user"); err != nil {
+		return err
+	}
+	cognitoUser.SetAttributes(attributes)
+	return nil
+}
+
+func (u *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	slog.DebugContext(ctx, "getting user", "user_id", redaction.Redact(user.UserID))
+
+	cognitoUser, err := u.getCognitoUserBySub(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "user retrieved successfully", "user_id", redaction.Redact(user.UserID))
+	return cognitoUser.ToUser(), nil
+}
+
+func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+
+	if user == nil {
+		return nil, errors.NewValidation("user is required")
+	}
+
+	slog.DebugContext(ctx, "searching user", "criteria", criteria)
+
+	switch criteria {
+	case constants.CriteriaTypeEmail:
+		if strings.TrimSpace(user.PrimaryEmail) == "" {
+			return nil, errors.NewValidation("email is required")
+		}
+		cognitoUser, err := u.findUser(ctx, emailAttribute, user.PrimaryEmail, func(candidate *CognitoUser) bool {
+			email := candidate.attribute(emailAttribute)
+			return email != nil && strings.EqualFold(*email, user.PrimaryEmail)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return cognitoUser.ToUser(), nil
+	case constants.CriteriaTypeUsername:
+		if strings.TrimSpace(user.Username) == "" {
+			return nil, errors.NewValidation("username is required")
+		}
+		cognitoUser, err := u.getCognitoUserByUsername(ctx, user.Username)
+		if err != nil {
+			return nil, err
+		}
+		return cognitoUser.ToUser(), nil
+	case constants.CriteriaTypeAlternateEmail:
+		// only the first alternate email is supported
+		if len(user.AlternateEmails) == 0 || strings.TrimSpace(user.AlternateEmails[0].Email) == "" {
+			return nil, errors.NewValidation("alternate email is required")
+		}
+		return u.searchAlternateEmail(ctx, normalizeEmail(user.AlternateEmails[0].Email))
+	default:
+		return nil, errors.NewValidation(fmt.Sprintf("invalid criteria type: %s", criteria))
+	}
+}
+
+// searchAlternateEmail lists the user pool for the alternate email, ListUsers can't filter the
+// custom attributes
+func (u *userReaderWriter) searchAlternateEmail(ctx context.Context, alternateEmail string) (*model.User, error) {
+	paginationToken := ""
+	for {
+		response, err := u.listUsers(ctx, "", paginationToken)
+		if err != nil {
+			return nil, err
+		}
+		for i := range response.Users {
+			if response.Users[i].HasAlternateEmail(alternateEmail) {
+				return response.Users[i].ToUser(), nil
+			}
+		}
+		if response.PaginationToken == "" {
+			return nil, errors.NewNotFound("user not found")
+		}
+		paginationToken = response.PaginationToken
+	}
+}
+
+// MetadataLookup prepares the user for metadata lookup based on the input
+// Accepts JWT token, username, or user id (the sub of the user pool tokens)
+func (u *userReaderWriter) MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errors.NewValidation("input is required")
+	}
+
+	slog.DebugContext(ctx, "metadata lookup", "input", redaction.Redact(input))
+
+	if cleanToken, isJWT := jwt.LooksLikeJWT(input); isJWT {
+		claims, err := u.jwtVerifier.Verify(ctx, cleanToken, requiredScopes...)
+		if err != nil {
+			return nil, err
+		}
+		return &model.User{
+			Token:  cleanToken,
+			UserID: claims.Subject,
+			Sub:    claims.Subject,
+		}, nil
+	}
+
+	// the Cognito subs are UUIDs, anything else is a username
+	if _, errParse := uuid.Parse(input); errParse == nil {
+		slog.DebugContext(ctx, "canonical lookup strategy", "sub", redaction.Redact(input))
+		return &model.User{UserID: input, Sub: input}, nil
+	}
+
+	slog.DebugContext(ctx, "username search strategy", "username", redaction.Redact(input))
+	return &model.User{Username: input}, nil
+}
+
+func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	claims, errVerify := u.jwtVerifier.Verify(ctx, user.Token, constants.UserUpdateMetadataRequiredScope)
+	if errVerify != nil {
+		return nil, errVerify
+	}
+
+	if user.UserMetadata == nil {
+		return nil, errors.NewValidation("user_metadata is required for update")
+	}
+	if len(user.ClearFields) > 0 {
+		return nil, errors.NewValidation("clear_fields is not supported by the identity provider")
+	}
+
+	// the username of the sub is needed by the update
+	cognitoUser, err := u.getCognitoUserBySub(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if attributes := MetadataAttributes(user.UserMetadata); len(attributes) > 0 {
+		if errUpdate := u.updateAttributes(ctx, cognitoUser, attributes); errUpdate != nil {
+			return nil, errUpdate
+		}
+	}
+
+	slog.DebugContext(ctx, "user updated successfully", "user_id", redaction.Redact(claims.Subject))
+
+	return &model.User{
+		UserID:       claims.Subject,
+		Sub:          claims.Subject,
+		UserMetadata: cognitoUser.ToUser().UserMetadata,
+	}, nil
+}
+
+func (u *userReaderWriter) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
+	if strings.TrimSpace(alternateEmail) == "" {
+		return errors.NewValidation("alternate email is required")
+	}
+	return u.emailLinkingFlow.SendVerification(ctx, alternateEmail)
+}
+
+func (u *userReaderWriter) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
+	if email.Email == "" || email.OTP == "" {
+		return nil, errors.NewValidation("email and OTP are required")
+	}
+	return u.emailLinkingFlow.Verify(ctx, email.Email, email.OTP)
+}
+
+// ValidateLinkRequest only accepts the identity tokens of the verified alternate emails,
+// the federated identities are linked by Cognito itself on login
+func (u *userReaderWriter) ValidateLinkRequest(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.LinkWith.IdentityToken == "" {
+		return errors.NewValidation("link_with identity token is required")
+	}
+	_, err := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	return err
+}
+
+func (u *userReaderWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	email, errVerifiedEmail := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	if errVerifiedEmail != nil {
+		return errVerifiedEmail
+	}
+
+	cognitoUser, err := u.getCognitoUserBySub(ctx, request.User.UserID)
+	if err != nil {
+		return err
+	}
+
+	attribute, added := cognitoUser.AddAlternateEmail(email)
+	if !added {
+		slog.InfoContext(ctx, "email already exists in alternate email list",
+			"user_id", redaction.Redact(request.User.UserID),
+			"email", redaction.RedactEmail(email),
+		)
+		return nil
+	}
+
+	if errUpdate := u.updateAttributes(ctx, cognitoUser, []CognitoAttribute{attribute}); errUpdate != nil {
+		return errUpdate
+	}
+
+	slog.InfoContext(ctx, "successfully linked email identity",
+		"user_id", redaction.Redact(request.User.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return nil
+}
+
+// UnlinkIdentity removes an alternate email (provider email) or a federated identity
+func (u *userReaderWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("unlink identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+	if request.Unlink.Provider == "" {
+		return errors.NewValidation("provider is required")
+	}
+	if request.Unlink.IdentityID == "" {
+		return errors.NewValidation("identity_id is required")
+	}
+
+	slog.DebugContext(ctx, "unlinking identity from user",
+		"user_id", redaction.Redact(request.User.UserID),
+		"provider", request.Unlink.Provider,
+	)
+
+	cognitoUser, err := u.getCognitoUserBySub(ctx, request.User.UserID)
+	if err != nil {
+		return err
+	}
+
+	if request.Unlink.Provider == "email" {
+		attribute, removed := cognitoUser.RemoveAlternateEmail(request.Unlink.IdentityID)
+		if !removed {
+			return errors.NewNotFound("identity not found")
+		}
+		return u.updateAttributes(ctx, cognitoUser, []CognitoAttribute{attribute})
+	}
+
+	for _, identity := range cognitoUser.identities() {
+		if identity.ProviderName == request.Unlink.Provider && identity.UserID == request.Unlink.IdentityID {
+			unlink := map[string]any{
+				"UserPoolId": u.config.UserPoolID,
+				"User": map[string]string{
+					"ProviderName":           identity.ProviderName,
+					"ProviderAttributeName":  federatedSubjectAttribute,
+					"ProviderAttributeValue": identity.UserID,
+				},
+			}
+			return u.client.call(ctx, "AdminDisableProviderForUser", unlink, nil, "unlink identity")
+		}
+	}
+	return errors.NewNotFound("identity not found")
+}
+
+// NewUserReaderWriter creates a new UserReaderWriter backed by the Cognito User Pools API
+func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, config Config) (port.UserReaderWriter, error) {
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	httpClient := httpclient.NewClient(httpConfig)
+
+	jwtVerifier, err := newJWTVerifier(ctx, config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	emailLinkingFlow, err := newEmailLinkingFlow(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email linking flow: %w", err)
+	}
+
+	return &userReaderWriter{
+		config: config,
+		client: &client{
+			config:     config,
+			httpClient: httpClient,
+			clock:      clock.Or(config.Clock),
+		},
+		jwtVerifier:      jwtVerifier,
+		emailLinkingFlow: emailLinkingFlow,
+	}, nil
+}
@@ -0,0 +1,627 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cognito
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserPoolID     = "us-east-1_LfxTest"
+	testClientID       = "lfx-profile"
+	testResourceServer = "https://api.lfx.dev"
+	testUserID         = "4b8f3c4e-1f8a-4a57-9f55-2b1e2f0b8d11"
+)
+
+// fakeCognito serves the JWKS and the actions of the User Pools API used by the repository
+type fakeCognito struct {
+	t             *testing.T
+	server        *httptest.Server
+	accessKey     *rsa.PrivateKey
+	idKey         *rsa.PrivateKey
+	throttleUntil int
+
+	mu      sync.Mutex
+	users   []*CognitoUser
+	actions []string
+	filters []string
+	unlinks []map[string]string
+}
+
+func newFakeCognito(t *testing.T) *fakeCognito {
+	accessKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fake := &fakeCognito{
+		t:         t,
+		accessKey: accessKey,
+		idKey:     idKey,
+		users: []*CognitoUser{
+			{
+				Username: "jdoe",
+				Enabled:  true,
+				Attributes: []CognitoAttribute{
+					{Name: "sub", Value: testUserID},
+					{Name: "email", Value: "jane@example.com"},
+					{Name: "given_name", Value: "Jane"},
+					{Name: "custom:city", Value: "Lisbon"},
+					{Name: alternateEmailsAttribute, Value: "jane@work.example.com"},
+					{Name: identitiesAttribute, Value: `[{"userId":"1234","providerName":"Google","providerType":"Google"}]`},
+				},
+			},
+		},
+	}
+	// the other users fill the first page, the alternate email searches must paginate
+	for range listUsersLimit {
+		fake.users = append([]*CognitoUser{{Username: "other", Attributes: []CognitoAttribute{{Name: "sub", Value: "other"}}}}, fake.users...)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /"+testUserPoolID+"/.well-known/jwks.json", fake.jwks)
+	mux.HandleFunc("POST /", fake.api)
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeCognito) jwks(w http.ResponseWriter, _ *http.Request) {
+	keys := []any{}
+	for kid, privateKey := range map[string]*rsa.PrivateKey{"access-key": f.accessKey, "id-key": f.idKey} {
+		key, errKey := jwk.FromRaw(&privateKey.PublicKey)
+		require.NoError(f.t, errKey)
+		require.NoError(f.t, key.Set(jwk.KeyIDKey, kid))
+		require.NoError(f.t, key.Set(jwk.AlgorithmKey, "RS256"))
+		keys = append(keys, key)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+}
+
+// fail replies a Cognito exception
+func fail(w http.ResponseWriter, exception, message string) {
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(apiError{Type: exception, Message: message})
+}
+
+// api dispatches the actions, the requests must be signed
+func (f *fakeCognito) api(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+		!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/cognito-idp/aws4_request") {
+		fail(w, "UnrecognizedClientException", "the request is not signed")
+		return
+	}
+	if r.Header.Get("Content-Type") != contentType {
+		fail(w, "SerializationException", "invalid content type")
+		return
+	}
+
+	var request struct {
+		UserPoolID      string             `json:"UserPoolId"`
+		Username        string             `json:"Username"`
+		Filter          string             `json:"Filter"`
+		PaginationToken string             `json:"PaginationToken"`
+		UserAttributes  []CognitoAttribute `json:"UserAttributes"`
+		User            map[string]string  `json:"User"`
+	}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+	if request.UserPoolID != testUserPoolID {
+		fail(w, "ResourceNotFoundException", "user pool not found")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), targetPrefix)
+	f.actions = append(f.actions, action)
+	if f.throttleUntil > 0 {
+		f.throttleUntil--
+		fail(w, "TooManyRequestsException", "rate exceeded")
+		return
+	}
+
+	switch action {
+	case "ListUsers":
+		f.filters = append(f.filters, request.Filter)
+		f.listUsers(w, request.Filter, request.PaginationToken)
+	case "AdminGetUser":
+		for _, user := range f.users {
+			if strings.EqualFold(user.Username, request.Username) {
+				_ = json.NewEncoder(w).Encode(adminGetUserResponse{
+					Username:       user.Username,
+					UserAttributes: user.Attributes,
+					Enabled:        user.Enabled,
+				})
+				return
+			}
+		}
+		fail(w, "UserNotFoundException", "User does not exist.")
+	case "AdminUpdateUserAttributes":
+		for _, user := range f.users {
+			if user.Username == request.Username {
+				user.SetAttributes(request.UserAttributes)
+				_, _ = w.Write([]byte("{}"))
+				return
+			}
+		}
+		fail(w, "UserNotFoundException", "User does not exist.")
+	case "AdminDisableProviderForUser":
+		f.unlinks = append(f.unlinks, request.User)
+		_, _ = w.Write([]byte("{}"))
+	default:
+		fail(w, "InvalidAction", "unknown action "+action)
+	}
+}
+
+func (f *fakeCognito) listUsers(w http.ResponseWriter, filter, paginationToken string) {
+	matches := []CognitoUser{}
+	for _, user := range f.users {
+		if filter != "" {
+			name, value, _ := strings.Cut(filter, " = ")
+			attribute := user.attribute(name)
+			if attribute == nil || `"`+*attribute+`"` != value {
+				continue
+			}
+		}
+		matches = append(matches, *user)
+	}
+
+	start := 0
+	if paginationToken != "" {
+		start = listUsersLimit
+	}
+	response := listUsersResponse{Users: matches[min(start, len(matches)):min(start+listUsersLimit, len(matches))]}
+	if start+listUsersLimit < len(matches) {
+		response.PaginationToken = "page-2"
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// userToken issues an access token of the user pool
+func (f *fakeCognito) userToken(subject, scope string) string {
+	return f.token(jwt.MapClaims{
+		"sub":       subject,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+		"scope":     scope,
+		"iss":       f.server.URL + "/" + testUserPoolID,
+		"client_id": testClientID,
+		"token_use": "access",
+	}, "access-key", f.accessKey)
+}
+
+func (f *fakeCognito) token(claims jwt.MapClaims, kid string, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(f.t, err)
+	return signed
+}
+
+// fakeEmailSender keeps the last OTP sent to each recipient
+type fakeEmailSender struct {
+	mu   sync.Mutex
+	otps map[string]string
+}
+
+func (s *fakeEmailSender) SendTemplatedEmail(_ context.Context, _, to string, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otps[to] = data.(map[string]string)["OTP"]
+	return nil
+}
+
+func (s *fakeEmailSender) otp(to string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.otps[to]
+}
+
+var _ port.TemplatedEmailSender = (*fakeEmailSender)(nil)
+
+func testConfig(endpoint string) Config {
+	return Config{
+		UserPoolID:     testUserPoolID,
+		Endpoint:       endpoint,
+		Credentials:    sigv4.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		ClientID:       testClientID,
+		ResourceServer: testResourceServer,
+		LinkingSecret:  "linking-secret",
+	}
+}
+
+func newTestUserReaderWriter(t *testing.T) (*userReaderWriter, *fakeCognito, *fakeEmailSender, *clock.Fake) {
+	fake := newFakeCognito(t)
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	fakeClock := clock.NewFake(time.Now())
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetries = 0
+
+	config := testConfig(fake.server.URL)
+	config.EmailSender = sender
+	config.Clock = fakeClock
+
+	repository, err := NewUserReaderWriter(context.Background(), httpConfig, config)
+	require.NoError(t, err)
+	return repository.(*userReaderWriter), fake, sender, fakeClock
+}
+
+func TestNewUserReaderWriter_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(Config) Config
+	}{
+		{name: "missing user pool", config: func(c Config) Config { c.UserPoolID = ""; return c }},
+		{name: "user pool without region", config: func(c Config) Config { c.UserPoolID = "LfxTest"; return c }},
+		{name: "relative endpoint", config: func(c Config) Config { c.Endpoint = "cognito"; return c }},
+		{name: "missing credentials", config: func(c Config) Config { c.Credentials = sigv4.Credentials{}; return c }},
+		{name: "missing linking secret", config: func(c Config) Config { c.LinkingSecret = ""; return c }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUserReaderWriter(context.Background(), httpclient.DefaultConfig(), tt.config(testConfig("")))
+			require.Error(t, err)
+			assert.IsType(t, errors.Validation{}, err)
+		})
+	}
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	config := testConfig("")
+	assert.Equal(t, "https://cognito-idp.us-east-1.amazonaws.com/", config.endpoint())
+	assert.Equal(t, "https://cognito-idp.us-east-1.amazonaws.com/"+testUserPoolID, config.issuer())
+	assert.Equal(t, testResourceServer+"/"+constants.UserUpdateMetadataRequiredScope, config.scope(constants.UserUpdateMetadataRequiredScope))
+
+	config.Region = "eu-west-1"
+	config.ResourceServer = ""
+	assert.Equal(t, "https://cognito-idp.eu-west-1.amazonaws.com/", config.endpoint())
+	assert.Equal(t, constants.UserUpdateMetadataRequiredScope, config.scope(constants.UserUpdateMetadataRequiredScope))
+}
+
+func TestUserReaderWriter_GetUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	user, err := u.GetUser(ctx, &model.User{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, user.Sub)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail)
+	assert.Equal(t, "Lisbon", *user.UserMetadata.City)
+	assert.Equal(t, []model.Email{{Email: "jane@work.example.com", Verified: true}}, user.AlternateEmails)
+	assert.Equal(t, []model.Identity{{Provider: "Google", IdentityID: "1234", IsSocial: true}}, user.Identities)
+
+	fake.mu.Lock()
+	assert.Equal(t, `sub = "`+testUserID+`"`, fake.filters[len(fake.filters)-1])
+	fake.mu.Unlock()
+
+	_, err = u.GetUser(ctx, &model.User{UserID: "00000000-0000-0000-0000-000000000000"})
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	_, err = u.GetUser(ctx, &model.User{})
+	require.Error(t, err)
+	assert.IsType(t, errors.Validation{}, err)
+}
+
+func TestUserReaderWriter_SearchUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	tests := []struct {
+		name        string
+		user        *model.User
+		criteria    constants.CriteriaType
+		wantActions []string
+		wantErr     any
+	}{
+		{
+			name:        "by email",
+			user:        &model.User{PrimaryEmail: "jane@example.com"},
+			criteria:    constants.CriteriaTypeEmail,
+			wantActions: []string{"ListUsers"},
+		},
+		{
+			name:        "by username, case insensitive",
+			user:        &model.User{Username: "JDoe"},
+			criteria:    constants.CriteriaTypeUsername,
+			wantActions: []string{"AdminGetUser"},
+		},
+		{
+			name:        "by alternate email, through the pages",
+			user:        &model.User{AlternateEmails: []model.Email{{Email: "Jane@Work.example.com"}}},
+			criteria:    constants.CriteriaTypeAlternateEmail,
+			wantActions: []string{"ListUsers", "ListUsers"},
+		},
+		{
+			name:     "unknown username",
+			user:     &model.User{Username: "nobody"},
+			criteria: constants.CriteriaTypeUsername,
+			wantErr:  errors.NotFound{},
+		},
+		{
+			name:     "unknown alternate email",
+			user:     &model.User{AlternateEmails: []model.Email{{Email: "nobody@example.com"}}},
+			criteria: constants.CriteriaTypeAlternateEmail,
+			wantErr:  errors.NotFound{},
+		},
+		{
+			name:     "missing email",
+			user:     &model.User{},
+			criteria: constants.CriteriaTypeEmail,
+			wantErr:  errors.Validation{},
+		},
+		{
+			name:     "invalid criteria",
+			user:     &model.User{PrimaryEmail: "jane@example.com"},
+			criteria: "phone",
+			wantErr:  errors.Validation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.mu.Lock()
+			fake.actions = nil
+			fake.mu.Unlock()
+
+			user, err := u.SearchUser(ctx, tt.user, tt.criteria)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, user.UserID)
+
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			assert.Equal(t, tt.wantActions, fake.actions)
+		})
+	}
+}
+
+func TestUserReaderWriter_MetadataLookup(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	token := fake.userToken(testUserID, "openid profile")
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"sub":       testUserID,
+			"exp":       time.Now().Add(time.Hour).Unix(),
+			"iss":       fake.server.URL + "/" + testUserPoolID,
+			"client_id": testClientID,
+			"token_use": "access",
+		}
+		for name, value := range overrides {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *model.User
+		wantErr  bool
+	}{
+		{
+			name:     "user token",
+			input:    "Bearer " + token,
+			expected: &model.User{Token: token, UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:     "user id",
+			input:    testUserID,
+			expected: &model.User{UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:     "username",
+			input:    "jdoe",
+			expected: &model.User{Username: "jdoe"},
+		},
+		{
+			name:    "ID token",
+			input:   fake.token(claims(jwt.MapClaims{"token_use": "id", "aud": testClientID}), "id-key", fake.idKey),
+			wantErr: true,
+		},
+		{
+			name:    "token of another app client",
+			input:   fake.token(claims(jwt.MapClaims{"client_id": "other"}), "access-key", fake.accessKey),
+			wantErr: true,
+		},
+		{
+			name:    "token of another issuer",
+			input:   fake.token(claims(jwt.MapClaims{"iss": "https://other"}), "access-key", fake.accessKey),
+			wantErr: true,
+		},
+		{
+			name:    "token signed with the key of another kid",
+			input:   fake.token(claims(nil), "id-key", fake.accessKey),
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			input:   fake.token(claims(nil), "rotated-key", fake.accessKey),
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			input:   " ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := u.MetadataLookup(ctx, tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, user)
+		})
+	}
+}
+
+func TestUserReaderWriter_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	t.Run("requires the update scope of the resource server", func(t *testing.T) {
+		_, err := u.UpdateUser(ctx, &model.User{
+			Token:        fake.userToken(testUserID, "openid "+constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects clear_fields", func(t *testing.T) {
+		_, err := u.UpdateUser(ctx, &model.User{
+			Token:        fake.userToken(testUserID, testResourceServer+"/"+constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{},
+			ClearFields:  []string{"city"},
+		})
+		require.Error(t, err)
+		assert.IsType(t, errors.Validation{}, err)
+	})
+
+	t.Run("patches the metadata", func(t *testing.T) {
+		updated, err := u.UpdateUser(ctx, &model.User{
+			Token: fake.userToken(testUserID, "openid "+testResourceServer+"/"+constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{
+				FamilyName:           converters.StringPtr("Doe"),
+				JobTitle:             converters.StringPtr("Engineer"),
+				OrganizationVerified: converters.BoolPtr(true),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, updated.UserID)
+		assert.Equal(t, "Engineer", *updated.UserMetadata.JobTitle)
+		assert.Equal(t, "Lisbon", *updated.UserMetadata.City)
+		assert.True(t, *updated.UserMetadata.OrganizationVerified)
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		stored := fake.users[len(fake.users)-1]
+		assert.Equal(t, "Jane", *stored.attribute("given_name"))
+		assert.Equal(t, "Doe", *stored.attribute("family_name"))
+		assert.Equal(t, "Engineer", *stored.attribute("custom:job_title"))
+		assert.Equal(t, "true", *stored.attribute(organizationVerifiedAttribute))
+		assert.Equal(t, "jane@work.example.com", *stored.attribute(alternateEmailsAttribute))
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		fake.mu.Lock()
+		fake.throttleUntil = 1
+		fake.mu.Unlock()
+
+		_, err := u.UpdateUser(ctx, &model.User{
+			Token:        fake.userToken(testUserID, testResourceServer+"/"+constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+		})
+		require.Error(t, err)
+		assert.IsType(t, errors.TooManyRequests{}, err)
+	})
+}
+
+func TestUserReaderWriter_AlternateEmailLinking(t *testing.T) {
+	ctx := context.Background()
+	u, fake, sender, fakeClock := newTestUserReaderWriter(t)
+
+	const alternateEmail = "jane@personal.example.com"
+
+	require.NoError(t, u.SendVerificationAlternateEmail(ctx, alternateEmail))
+	otp := sender.otp(alternateEmail)
+	require.Len(t, otp, 6)
+
+	_, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: "000000" + otp})
+	require.Error(t, err, "a wrong OTP is rejected")
+
+	authResponse, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.NoError(t, err)
+	require.NotEmpty(t, authResponse.IDToken)
+
+	request := &model.LinkIdentity{}
+	request.User.UserID = testUserID
+	request.LinkWith.IdentityToken = authResponse.IDToken
+
+	require.NoError(t, u.ValidateLinkRequest(ctx, request))
+	require.NoError(t, u.LinkIdentity(ctx, request))
+	require.NoError(t, u.LinkIdentity(ctx, request), "linking twice is a no-op")
+
+	fake.mu.Lock()
+	assert.Equal(t, "jane@work.example.com,"+alternateEmail, *fake.users[len(fake.users)-1].attribute(alternateEmailsAttribute))
+	fake.mu.Unlock()
+
+	// the identity token expires
+	fakeClock.Advance(emaillinking.IdentityTokenTTL + time.Minute)
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+
+	// a token signed with another secret is rejected
+	request.LinkWith.IdentityToken = fake.userToken(testUserID, "")
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+}
+
+func TestUserReaderWriter_UnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	unlink := func(provider, identityID string) error {
+		request := &model.UnlinkIdentity{}
+		request.User.UserID = testUserID
+		request.Unlink.Provider = provider
+		request.Unlink.IdentityID = identityID
+		return u.UnlinkIdentity(ctx, request)
+	}
+
+	require.NoError(t, unlink("email", "Jane@Work.example.com"))
+	require.NoError(t, unlink("Google", "1234"))
+
+	err := unlink("email", "jane@work.example.com")
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	err = unlink("GitHub", "1234")
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Empty(t, *fake.users[len(fake.users)-1].attribute(alternateEmailsAttribute))
+	assert.Equal(t, []map[string]string{{
+		"ProviderName":           "Google",
+		"ProviderAttributeName":  federatedSubjectAttribute,
+		"ProviderAttributeValue": "1234",
+	}}, fake.unlinks)
+}
@@ -0,0 +1,287 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package consistency audits the internal indices of the users against the identity provider. A
+// sample of the users is read again from the provider and compared with what every index serves
+// for them, so a silent drift (a missed invalidation, a lost event) is found before it breaks the
+// lookups. The divergences are reported as metrics and can be repaired on the spot.
+package consistency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultInterval is how often the indices are audited
+	DefaultInterval = 24 * time.Hour
+	// DefaultSampleSize is the number of users audited by each run
+	DefaultSampleSize = 100
+)
+
+// Index is an internal index of the users derived from the identity provider
+type Index interface {
+	// Diverged reports whether the index serves the user of the sub differently than the
+	// provider, the user is nil when the provider no longer has it
+	Diverged(ctx context.Context, sub string, user *model.User) (bool, error)
+	// Repair aligns the index with the provider for the user of the sub
+	Repair(ctx context.Context, sub string, user *model.User) error
+}
+
+// Sampler returns the subs of the users known by an index, the sample is drawn from all of them
+type Sampler func(ctx context.Context) ([]string, error)
+
+// Report is the outcome of an audit run
+type Report struct {
+	// Sampled is the number of users compared
+	Sampled int
+	// Diverged is the number of divergent users by index
+	Diverged map[string]int
+	// Repaired is the number of repaired users by index
+	Repaired map[string]int
+	// Failed is the number of users that couldn't be compared
+	Failed int
+}
+
+type namedIndex struct {
+	name  string
+	index Index
+}
+
+// Auditor compares a sample of the users of the indices with the identity provider
+type Auditor struct {
+	provider   port.UserReader
+	indices    []namedIndex
+	samplers   []Sampler
+	sampleSize int
+	repair     bool
+	interval   time.Duration
+	shuffle    func(n int, swap func(i, j int))
+
+	sampled     metric.Int64Gauge
+	divergences metric.Int64Gauge
+}
+
+// Option configures the Auditor
+type Option func(*Auditor)
+
+// WithIndex adds an index to audit under the name reported by the metrics
+func WithIndex(name string, index Index) Option {
+	return func(a *Auditor) {
+		a.indices = append(a.indices, namedIndex{name: name, index: index})
+	}
+}
+
+// WithSampler adds a source of the subs the users are sampled from
+func WithSampler(sampler Sampler) Option {
+	return func(a *Auditor) {
+		a.samplers = append(a.samplers, sampler)
+	}
+}
+
+// WithSampleSize sets the number of users audited by each run
+func WithSampleSize(size int) Option {
+	return func(a *Auditor) {
+		if size > 0 {
+			a.sampleSize = size
+		}
+	}
+}
+
+// WithRepair repairs the divergent indices instead of only reporting them
+func WithRepair(repair bool) Option {
+	return func(a *Auditor) {
+		a.repair = repair
+	}
+}
+
+// WithInterval sets how often the indices are audited
+func WithInterval(interval time.Duration) Option {
+	return func(a *Auditor) {
+		if interval > 0 {
+			a.interval = interval
+		}
+	}
+}
+
+// sample returns up to sampleSize distinct subs of the samplers, a failing sampler is skipped
+func (a *Auditor) sample(ctx context.Context) ([]string, error) {
+	seen := make(map[string]struct{})
+	var (
+		subs   []string
+		failed []error
+	)
+	for _, sampler := range a.samplers {
+		listed, err := sampler(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to list the users to audit", "error", err)
+			failed = append(failed, err)
+			continue
+		}
+		for _, sub := range listed {
+			if _, ok := seen[sub]; ok || sub == "" {
+				continue
+			}
+			seen[sub] = struct{}{}
+			subs = append(subs, sub)
+		}
+	}
+	if len(subs) == 0 && len(failed) > 0 {
+		return nil, errors.Join(failed...)
+	}
+
+	// the order of the listings is stable, shuffle so every run audits other users
+	sort.Strings(subs)
+	a.shuffle(len(subs), func(i, j int) { subs[i], subs[j] = subs[j], subs[i] })
+	if len(subs) > a.sampleSize {
+		subs = subs[:a.sampleSize]
+	}
+	return subs, nil
+}
+
+// Audit compares a sample of the users with the identity provider, and repairs the divergent
+// indices when enabled
+func (a *Auditor) Audit(ctx context.Context) (*Report, error) {
+	subs, errSample := a.sample(ctx)
+	if errSample != nil {
+		return nil, errSample
+	}
+
+	report := &Report{
+		Diverged: make(map[string]int),
+		Repaired: make(map[string]int),
+	}
+	for _, sub := range subs {
+		var notFound errs.NotFound
+		user, errGetUser := a.provider.GetUser(ctx, &model.User{UserID: sub, Sub: sub})
+		if errGetUser != nil {
+			if !errors.As(errGetUser, &notFound) {
+				slog.WarnContext(ctx, "failed to read the audited user", "error", errGetUser, "sub", redaction.Redact(sub))
+				report.Failed++
+				continue
+			}
+			user = nil
+		}
+		report.Sampled++
+
+		for _, idx := range a.indices {
+			diverged, errDiverged := idx.index.Diverged(ctx, sub, user)
+			if errDiverged != nil {
+				slog.WarnContext(ctx, "failed to audit the index",
+					"error", errDiverged,
+					"index", idx.name,
+					"sub", redaction.Redact(sub),
+				)
+				continue
+			}
+			if !diverged {
+				continue
+			}
+			report.Diverged[idx.name]++
+			slog.WarnContext(ctx, "index diverged from the identity provider",
+				"index", idx.name,
+				"sub", redaction.Redact(sub),
+				"deleted", user == nil,
+			)
+
+			if !a.repair {
+				continue
+			}
+			if errRepair := idx.index.Repair(ctx, sub, user); errRepair != nil {
+				slog.WarnContext(ctx, "failed to repair the index",
+					"error", errRepair,
+					"index", idx.name,
+					"sub", redaction.Redact(sub),
+				)
+				continue
+			}
+			report.Repaired[idx.name]++
+		}
+	}
+
+	a.record(ctx, report)
+	slog.InfoContext(ctx, "consistency audit completed",
+		"sampled", report.Sampled,
+		"failed", report.Failed,
+		"diverged", report.Diverged,
+		"repaired", report.Repaired,
+	)
+	return report, nil
+}
+
+func (a *Auditor) record(ctx context.Context, report *Report) {
+	if a.sampled != nil {
+		a.sampled.Record(ctx, int64(report.Sampled))
+	}
+	if a.divergences == nil {
+		return
+	}
+	for _, idx := range a.indices {
+		a.divergences.Record(ctx, int64(report.Diverged[idx.name]), metric.WithAttributes(
+			attribute.String("index", idx.name),
+		))
+	}
+}
+
+// Run audits the indices periodically until the context is cancelled
+func (a *Auditor) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.Audit(ctx); err != nil {
+				slog.WarnContext(ctx, "consistency audit failed", "error", err)
+			}
+		}
+	}
+}
+
+// New creates the auditor of the indices, the users are read again from the provider, which
+// must not be served from the user cache
+func New(provider port.UserReader, opts ...Option) *Auditor {
+	a := &Auditor{
+		provider:   provider,
+		sampleSize: DefaultSampleSize,
+		interval:   DefaultInterval,
+		shuffle:    rand.Shuffle,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	meter := otel.Meter(constants.ServiceName)
+	sampled, errSampled := meter.Int64Gauge(
+		"auth_service.consistency.sampled",
+		metric.WithDescription("Number of users compared with the identity provider by the last consistency audit"),
+	)
+	if errSampled != nil {
+		slog.Warn("failed to create consistency audit gauge", "error", errSampled)
+	}
+	divergences, errDivergences := meter.Int64Gauge(
+		"auth_service.consistency.divergences",
+		metric.WithDescription("Number of sampled users served differently than the identity provider by the last consistency audit, by index"),
+	)
+	if errDivergences != nil {
+		slog.Warn("failed to create consistency audit gauge", "error", errDivergences)
+	}
+	a.sampled, a.divergences = sampled, divergences
+	return a
+}
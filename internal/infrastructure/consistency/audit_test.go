@@ -0,0 +1,134 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package consistency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeProvider serves the users by user_id
+type fakeProvider struct {
+	port.UserReader
+	users  map[string]*model.User
+	failed map[string]bool
+}
+
+func (f *fakeProvider) GetUser(_ context.Context, user *model.User) (*model.User, error) {
+	if f.failed[user.UserID] {
+		return nil, errs.NewServiceUnavailable("provider unavailable")
+	}
+	found, ok := f.users[user.UserID]
+	if !ok {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return found, nil
+}
+
+// fakeIndex serves a username per sub
+type fakeIndex struct {
+	usernames map[string]string
+	repaired  []string
+}
+
+func (f *fakeIndex) Diverged(_ context.Context, sub string, user *model.User) (bool, error) {
+	username, ok := f.usernames[sub]
+	if user == nil {
+		return ok, nil
+	}
+	return ok && username != user.Username, nil
+}
+
+func (f *fakeIndex) Repair(_ context.Context, sub string, user *model.User) error {
+	f.repaired = append(f.repaired, sub)
+	if user == nil {
+		delete(f.usernames, sub)
+		return nil
+	}
+	f.usernames[sub] = user.Username
+	return nil
+}
+
+func (f *fakeIndex) subs(context.Context) ([]string, error) {
+	var subs []string
+	for sub := range f.usernames {
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func TestAuditor_Audit(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{
+		users: map[string]*model.User{
+			"auth0|1": {UserID: "auth0|1", Username: "jane"},
+			"auth0|2": {UserID: "auth0|2", Username: "john-renamed"},
+			"auth0|4": {UserID: "auth0|4", Username: "joe"},
+		},
+		failed: map[string]bool{"auth0|4": true},
+	}
+
+	tests := []struct {
+		name         string
+		repair       bool
+		wantRepaired []string
+	}{
+		{name: "report only"},
+		{name: "repair", repair: true, wantRepaired: []string{"auth0|2", "auth0|3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := &fakeIndex{usernames: map[string]string{
+				"auth0|1": "jane",
+				"auth0|2": "john",
+				"auth0|3": "deleted",
+				"auth0|4": "joe",
+			}}
+			auditor := New(provider,
+				WithIndex("cache", index),
+				WithSampler(index.subs),
+				WithRepair(tt.repair),
+			)
+
+			report, err := auditor.Audit(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 3, report.Sampled)
+			assert.Equal(t, 1, report.Failed)
+			assert.Equal(t, 2, report.Diverged["cache"])
+			assert.ElementsMatch(t, tt.wantRepaired, index.repaired)
+			assert.Equal(t, len(tt.wantRepaired), report.Repaired["cache"])
+		})
+	}
+}
+
+func TestAuditor_Sample(t *testing.T) {
+	ctx := context.Background()
+	listed := func(subs ...string) Sampler {
+		return func(context.Context) ([]string, error) { return subs, nil }
+	}
+	failing := func(context.Context) ([]string, error) { return nil, errors.New("bucket unavailable") }
+
+	auditor := New(&fakeProvider{}, WithSampler(listed("auth0|1", "auth0|2")), WithSampler(listed("auth0|2", "", "auth0|3")), WithSampler(failing))
+	subs, err := auditor.sample(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"auth0|1", "auth0|2", "auth0|3"}, subs, "the subs are deduplicated across the samplers")
+
+	auditor = New(&fakeProvider{}, WithSampler(listed("auth0|1", "auth0|2", "auth0|3")), WithSampleSize(2))
+	subs, err = auditor.sample(ctx)
+	require.NoError(t, err)
+	assert.Len(t, subs, 2)
+
+	auditor = New(&fakeProvider{}, WithSampler(failing))
+	_, err = auditor.Audit(ctx)
+	assert.Error(t, err, "nothing to audit when every sampler failed")
+}
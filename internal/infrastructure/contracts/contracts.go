@@ -0,0 +1,232 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package contracts records the service levels of the operations for each consuming service
+// against the latency and error budgets it registered for them, so the complaints about a slow
+// or failing auth service can be checked against data.
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/usage"
+)
+
+const (
+	// DefaultWindow is how far back the samples are kept
+	DefaultWindow = time.Hour
+
+	// AnyOperation is the operation of a contract covering every operation of the caller
+	AnyOperation = "*"
+
+	// maxSamples bounds the samples kept per caller and operation within the window
+	maxSamples = 4096
+)
+
+// Contract is the budget of an operation registered by a consuming service, zero budgets aren't checked
+type Contract struct {
+	Caller        string
+	Operation     string
+	LatencyBudget time.Duration
+	ErrorBudget   float64
+}
+
+// ParseContracts parses a spec of the form "caller:operation=latency[/error_rate],..." into the
+// contracts, e.g. "project-service:lfx.auth-service.email_to_username=250ms/0.01". The operation
+// * covers the operations of the caller without their own contract.
+func ParseContracts(spec string) ([]Contract, error) {
+	var contracts []Contract
+	seen := make(map[string]struct{})
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, budgets, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid contract entry %q, expected caller:operation=latency[/error_rate]", entry)
+		}
+		caller, operation, ok := strings.Cut(key, ":")
+		caller, operation = usage.NormalizeCaller(caller), strings.TrimSpace(operation)
+		if !ok || operation == "" {
+			return nil, fmt.Errorf("invalid contract entry %q, expected caller:operation=latency[/error_rate]", entry)
+		}
+		if _, duplicate := seen[caller+":"+operation]; duplicate {
+			return nil, fmt.Errorf("duplicate contract of %s for %s", caller, operation)
+		}
+		seen[caller+":"+operation] = struct{}{}
+
+		contract := Contract{Caller: caller, Operation: operation}
+
+		rawLatency, rawErrorRate, hasErrorRate := strings.Cut(budgets, "/")
+		if rawLatency = strings.TrimSpace(rawLatency); rawLatency != "" && rawLatency != "0" {
+			latency, err := time.ParseDuration(rawLatency)
+			if err != nil || latency < 0 {
+				return nil, fmt.Errorf("invalid latency budget in %q", entry)
+			}
+			contract.LatencyBudget = latency
+		}
+		if hasErrorRate {
+			errorRate, err := strconv.ParseFloat(strings.TrimSpace(rawErrorRate), 64)
+			if err != nil || errorRate < 0 || errorRate > 1 {
+				return nil, fmt.Errorf("invalid error budget in %q, expected a number between 0 and 1", entry)
+			}
+			contract.ErrorBudget = errorRate
+		}
+		if contract.LatencyBudget == 0 && contract.ErrorBudget == 0 {
+			return nil, fmt.Errorf("contract entry %q has no budget", entry)
+		}
+
+		contracts = append(contracts, contract)
+	}
+	return contracts, nil
+}
+
+type sample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// key identifies the samples of an operation of a caller
+type key struct {
+	caller    string
+	operation string
+}
+
+// series are the samples of an operation of a caller, checked against its contract
+type series struct {
+	contract Contract
+	samples  []sample
+}
+
+// Tracker records the service levels of the contracted operations in a sliding window
+type Tracker struct {
+	mu        sync.Mutex
+	window    time.Duration
+	now       func() time.Time
+	contracts map[key]Contract
+	series    map[key]*series
+}
+
+// RecordSLI implements port.SLIRecorder
+func (t *Tracker) RecordSLI(caller, operation string, latency time.Duration, failed bool) {
+	caller = usage.NormalizeCaller(caller)
+	contract, ok := t.contracts[key{caller: caller, operation: operation}]
+	if !ok {
+		contract, ok = t.contracts[key{caller: caller, operation: AnyOperation}]
+	}
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := key{caller: caller, operation: operation}
+	s, ok := t.series[k]
+	if !ok {
+		s = &series{contract: contract}
+		t.series[k] = s
+	}
+	s.samples = append(s.samples, sample{at: t.now(), latency: latency, failed: failed})
+	if len(s.samples) > maxSamples {
+		s.samples = s.samples[len(s.samples)-maxSamples:]
+	}
+}
+
+// ContractReport implements port.ContractReportReader, the contracts without request in the
+// window are reported without samples
+func (t *Tracker) ContractReport(ctx context.Context) []model.ContractSLI {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := t.now().Add(-t.window)
+
+	reported := make(map[key]struct{}, len(t.series))
+	report := make([]model.ContractSLI, 0, len(t.series)+len(t.contracts))
+	for k, s := range t.series {
+		// drop the samples out of the window
+		first := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].at.After(cutoff) })
+		s.samples = s.samples[first:]
+
+		report = append(report, sli(k, s.contract, s.samples))
+		reported[k] = struct{}{}
+	}
+	for k, contract := range t.contracts {
+		if _, ok := reported[k]; !ok && k.operation != AnyOperation {
+			report = append(report, sli(k, contract, nil))
+		}
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Caller != report[j].Caller {
+			return report[i].Caller < report[j].Caller
+		}
+		return report[i].Operation < report[j].Operation
+	})
+	return report
+}
+
+func sli(k key, contract Contract, samples []sample) model.ContractSLI {
+	result := model.ContractSLI{
+		Caller:          k.caller,
+		Operation:       k.operation,
+		Samples:         len(samples),
+		LatencyBudgetMs: contract.LatencyBudget.Milliseconds(),
+		ErrorBudget:     contract.ErrorBudget,
+	}
+	if len(samples) == 0 {
+		return result
+	}
+
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, s := range samples {
+		latencies = append(latencies, s.latency)
+		if s.failed {
+			result.Failures++
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	// nearest-rank percentile
+	rank := (len(latencies)*95 + 99) / 100
+	p95 := latencies[rank-1]
+	result.P95Ms = p95.Milliseconds()
+	result.ErrorRate = float64(result.Failures) / float64(len(samples))
+
+	if contract.LatencyBudget > 0 && p95 > contract.LatencyBudget {
+		result.Breaches = append(result.Breaches, model.ContractBreachLatency)
+	}
+	if contract.ErrorBudget > 0 && result.ErrorRate > contract.ErrorBudget {
+		result.Breaches = append(result.Breaches, model.ContractBreachErrors)
+	}
+	return result
+}
+
+// NewTracker creates a tracker of the contracts keeping the samples of the given window,
+// DefaultWindow when zero
+func NewTracker(contracts []Contract, window time.Duration) *Tracker {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	t := &Tracker{
+		window:    window,
+		now:       time.Now,
+		contracts: make(map[key]Contract, len(contracts)),
+		series:    make(map[key]*series),
+	}
+	for _, contract := range contracts {
+		t.contracts[key{caller: contract.Caller, operation: contract.Operation}] = contract
+	}
+	return t
+}
@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package contracts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+func TestParseContracts(t *testing.T) {
+	contracts, err := ParseContracts(" Project-Service:lfx.auth-service.email_to_username=250ms/0.01, reporting-service:*=/0.05,search-service:lfx.auth-service.user.typeahead=100ms ")
+	require.NoError(t, err)
+	assert.Equal(t, []Contract{
+		{Caller: "project-service", Operation: "lfx.auth-service.email_to_username", LatencyBudget: 250 * time.Millisecond, ErrorBudget: 0.01},
+		{Caller: "reporting-service", Operation: AnyOperation, ErrorBudget: 0.05},
+		{Caller: "search-service", Operation: "lfx.auth-service.user.typeahead", LatencyBudget: 100 * time.Millisecond},
+	}, contracts)
+
+	empty, err := ParseContracts("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, spec := range []string{
+		"project-service=250ms",
+		"project-service:=250ms",
+		"project-service:lfx.auth-service.email_to_username",
+		"project-service:lfx.auth-service.email_to_username=fast",
+		"project-service:lfx.auth-service.email_to_username=250ms/2",
+		"project-service:lfx.auth-service.email_to_username=0",
+		"project-service:*=1s,project-service:*=2s",
+	} {
+		_, err := ParseContracts(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestTracker_ContractReport(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+
+	tracker := NewTracker([]Contract{
+		{Caller: "project-service", Operation: "lfx.auth-service.email_to_username", LatencyBudget: 250 * time.Millisecond, ErrorBudget: 0.01},
+		{Caller: "project-service", Operation: "lfx.auth-service.sub_to_email", LatencyBudget: time.Second},
+		{Caller: "reporting-service", Operation: AnyOperation, ErrorBudget: 0.5},
+	}, time.Hour)
+	tracker.now = fake.Now
+
+	// samples out of the window
+	for range 10 {
+		tracker.RecordSLI("project-service", "lfx.auth-service.email_to_username", 5*time.Second, true)
+	}
+	fake.Advance(2 * time.Hour)
+
+	for i := range 20 {
+		tracker.RecordSLI("Project-Service", "lfx.auth-service.email_to_username", time.Duration(i*20)*time.Millisecond, i == 0)
+	}
+	tracker.RecordSLI("reporting-service", "lfx.auth-service.usage.read", 10*time.Millisecond, false)
+	// without contract
+	tracker.RecordSLI("search-service", "lfx.auth-service.user.typeahead", time.Second, true)
+	tracker.RecordSLI("project-service", "lfx.auth-service.user.typeahead", time.Second, true)
+
+	report := tracker.ContractReport(context.Background())
+	assert.Equal(t, []model.ContractSLI{
+		{
+			Caller:          "project-service",
+			Operation:       "lfx.auth-service.email_to_username",
+			Samples:         20,
+			Failures:        1,
+			P95Ms:           360,
+			ErrorRate:       0.05,
+			LatencyBudgetMs: 250,
+			ErrorBudget:     0.01,
+			Breaches:        []string{model.ContractBreachLatency, model.ContractBreachErrors},
+		},
+		{
+			Caller:          "project-service",
+			Operation:       "lfx.auth-service.sub_to_email",
+			LatencyBudgetMs: 1000,
+		},
+		{
+			Caller:      "reporting-service",
+			Operation:   "lfx.auth-service.usage.read",
+			Samples:     1,
+			P95Ms:       10,
+			ErrorBudget: 0.5,
+		},
+	}, report)
+	assert.True(t, report[0].Breached())
+	assert.False(t, report[2].Breached())
+}
@@ -0,0 +1,239 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package dedupe collapses the identical user searches sent to the identity provider in a short
+// window, the storms of lookups of the same emails (committee imports) reach it once.
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// DefaultWindow is how long the result of a search answers its duplicates
+const DefaultWindow = time.Second
+
+const (
+	// resultForwarded is a search sent to the identity provider
+	resultForwarded = "forwarded"
+	// resultInFlight is a duplicate waiting for the identical search in flight
+	resultInFlight = "in_flight"
+	// resultRecent is a duplicate answered by the identical search completed within the window
+	resultRecent = "recent"
+)
+
+// recentSearch is the result of a completed search
+type recentSearch struct {
+	user *model.User
+	err  error
+	at   time.Time
+}
+
+// Option configures the user reader writer
+type Option func(*UserReaderWriter)
+
+// WithClock sets the clock the window is measured with
+func WithClock(c clock.Clock) Option {
+	return func(d *UserReaderWriter) {
+		d.clock = c
+	}
+}
+
+// UserReaderWriter collapses the identical SearchUser calls, those in flight wait for the first one
+// and those made within the window after it completed get its result. The changes made through it
+// forget the recent results. The other operations go straight to the identity provider.
+type UserReaderWriter struct {
+	port.UserReaderWriter
+	window   time.Duration
+	clock    clock.Clock
+	searches metric.Int64Counter
+
+	group     singleflight.Group
+	mu        sync.Mutex
+	recent    map[string]recentSearch
+	nextSweep time.Time
+	// generation counts the changes, a search started before a change doesn't store its result
+	generation uint64
+}
+
+func searchKey(user *model.User, criteria constants.CriteriaType) string {
+	var value string
+	switch criteria {
+	case constants.CriteriaTypeUsername:
+		value = user.Username
+	case constants.CriteriaTypeEmail:
+		value = strings.ToLower(user.PrimaryEmail)
+	case constants.CriteriaTypeAlternateEmail:
+		if len(user.AlternateEmails) > 0 {
+			value = strings.ToLower(user.AlternateEmails[0].Email)
+		}
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		return ""
+	}
+	return string(criteria) + ":" + value
+}
+
+// reusable tells whether the result can answer the duplicates made after the search completed, the
+// users not found do while the failures are retried
+func reusable(err error) bool {
+	var notFound errs.NotFound
+	return err == nil || errors.As(err, &notFound)
+}
+
+// SearchUser returns the user by username, email or alternate email, the identical searches in
+// flight or completed within the window share the result
+func (d *UserReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+	key := searchKey(user, criteria)
+	if key == "" {
+		return d.UserReaderWriter.SearchUser(ctx, user, criteria)
+	}
+	if recent, ok := d.lookup(key); ok {
+		d.record(ctx, resultRecent)
+		return shared(recent.user, recent.err, user)
+	}
+
+	forwarded := false
+	value, err, _ := d.group.Do(key, func() (any, error) {
+		forwarded = true
+		generation := d.currentGeneration()
+		found, errSearch := d.UserReaderWriter.SearchUser(ctx, user, criteria)
+		if reusable(errSearch) {
+			d.store(key, generation, found, errSearch)
+		}
+		return found, errSearch
+	})
+	if forwarded {
+		d.record(ctx, resultForwarded)
+		return value.(*model.User), err
+	}
+	d.record(ctx, resultInFlight)
+	found, _ := value.(*model.User)
+	return shared(found, err, user)
+}
+
+// shared returns a copy of the shared result, with the credentials of the request
+func shared(found *model.User, err error, request *model.User) (*model.User, error) {
+	if err != nil || found == nil {
+		return nil, err
+	}
+	user := found.Clone()
+	user.Token = request.Token
+	return user, nil
+}
+
+func (d *UserReaderWriter) lookup(key string) (recentSearch, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	recent, ok := d.recent[key]
+	if !ok || d.clock.Now().Sub(recent.at) >= d.window {
+		return recentSearch{}, false
+	}
+	return recent, true
+}
+
+func (d *UserReaderWriter) currentGeneration() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.generation
+}
+
+func (d *UserReaderWriter) store(key string, generation uint64, user *model.User, err error) {
+	if d.window <= 0 {
+		return
+	}
+	if user != nil {
+		user = user.Clone()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if generation != d.generation {
+		return
+	}
+	now := d.clock.Now()
+	// the expired results are swept once per window, the map only holds the searches of the last ones
+	if now.After(d.nextSweep) {
+		for k, recent := range d.recent {
+			if now.Sub(recent.at) >= d.window {
+				delete(d.recent, k)
+			}
+		}
+		d.nextSweep = now.Add(d.window)
+	}
+	d.recent[key] = recentSearch{user: user, err: err, at: now}
+}
+
+// forget drops the recent results, a change may have made them wrong
+func (d *UserReaderWriter) forget() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	clear(d.recent)
+	d.generation++
+}
+
+// UpdateUser updates the user and forgets the recent results
+func (d *UserReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	defer d.forget()
+	return d.UserReaderWriter.UpdateUser(ctx, user)
+}
+
+// LinkIdentity links the identity and forgets the recent results, the emails of the user changed
+func (d *UserReaderWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	defer d.forget()
+	return d.UserReaderWriter.LinkIdentity(ctx, request)
+}
+
+// UnlinkIdentity unlinks the identity and forgets the recent results, the emails of the user changed
+func (d *UserReaderWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	defer d.forget()
+	return d.UserReaderWriter.UnlinkIdentity(ctx, request)
+}
+
+func (d *UserReaderWriter) record(ctx context.Context, result string) {
+	if d.searches == nil {
+		return
+	}
+	d.searches.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// NewUserReaderWriter creates the user reader writer collapsing the identical searches of the identity
+// provider, a zero window only collapses those in flight
+func NewUserReaderWriter(next port.UserReaderWriter, window time.Duration, opts ...Option) *UserReaderWriter {
+	d := &UserReaderWriter{
+		UserReaderWriter: next,
+		window:           window,
+		recent:           make(map[string]recentSearch),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.clock = clock.Or(d.clock)
+
+	searches, errCounter := otel.Meter(constants.ServiceName).Int64Counter(
+		"auth_service.user_search.dedupe",
+		metric.WithDescription("Number of user searches by result: forwarded to the identity provider, or suppressed as a duplicate of a search in_flight or recent"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create user search dedupe counter", "error", errCounter)
+	}
+	d.searches = searches
+	return d
+}
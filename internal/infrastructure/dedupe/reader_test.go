@@ -0,0 +1,153 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package dedupe
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeProvider counts the searches, release holds them until closed when set
+type fakeProvider struct {
+	port.UserReaderWriter
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (f *fakeProvider) SearchUser(_ context.Context, user *model.User, _ constants.CriteriaType) (*model.User, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	if user.PrimaryEmail != "jane@example.com" {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return &model.User{UserID: "auth0|123", Username: "jdoe", PrimaryEmail: "jane@example.com", Token: user.Token}, nil
+}
+
+func (f *fakeProvider) UpdateUser(_ context.Context, user *model.User) (*model.User, error) {
+	return user, nil
+}
+
+func search(t *testing.T, d *UserReaderWriter, email string) (*model.User, error) {
+	t.Helper()
+	return d.SearchUser(context.Background(), &model.User{PrimaryEmail: email, Token: "token-" + email}, constants.CriteriaTypeEmail)
+}
+
+func TestUserReaderWriter_SearchUser_InFlight(t *testing.T) {
+	provider := &fakeProvider{release: make(chan struct{})}
+	d := NewUserReaderWriter(provider, time.Minute, WithClock(clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))))
+
+	const duplicates = 20
+	var wg sync.WaitGroup
+	users := make([]*model.User, duplicates)
+	for i := range duplicates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users[i], _ = search(t, d, "jane@example.com")
+		}()
+	}
+	// the searches wait for the first one held by the provider, those arriving after it get its result
+	require.Eventually(t, func() bool { return provider.calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), provider.calls.Load())
+	for _, user := range users {
+		require.NotNil(t, user)
+		assert.Equal(t, "jdoe", user.Username)
+	}
+	// every caller gets its own copy
+	users[0].Username = "changed"
+	assert.Equal(t, "jdoe", users[1].Username)
+}
+
+func TestUserReaderWriter_SearchUser_ZeroWindow(t *testing.T) {
+	provider := &fakeProvider{}
+	d := NewUserReaderWriter(provider, 0)
+
+	// the completed searches aren't kept
+	for range 2 {
+		_, err := search(t, d, "jane@example.com")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), provider.calls.Load())
+}
+
+func TestUserReaderWriter_SearchUser_Recent(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	provider := &fakeProvider{}
+	d := NewUserReaderWriter(provider, 2*time.Second, WithClock(fake))
+
+	user, err := search(t, d, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "token-jane@example.com", user.Token)
+
+	// the duplicates within the window are answered with the credentials of their request
+	fake.Advance(time.Second)
+	user, err = search(t, d, "Jane@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|123", user.UserID)
+	assert.Equal(t, "token-Jane@Example.com ", user.Token)
+	assert.Equal(t, int32(1), provider.calls.Load())
+
+	// the users not found are shared too
+	for range 3 {
+		_, err = search(t, d, "nobody@example.com")
+		var notFound errs.NotFound
+		assert.ErrorAs(t, err, &notFound)
+	}
+	assert.Equal(t, int32(2), provider.calls.Load())
+
+	// the window is over
+	fake.Advance(time.Second)
+	_, err = search(t, d, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), provider.calls.Load())
+
+	// a change forgets the recent results
+	_, err = d.UpdateUser(ctx, &model.User{UserID: "auth0|123"})
+	require.NoError(t, err)
+	_, err = search(t, d, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), provider.calls.Load())
+
+	// the other criteria are searched separately
+	_, err = d.SearchUser(ctx, &model.User{Username: "jane@example.com"}, constants.CriteriaTypeUsername)
+	assert.Error(t, err)
+	assert.Equal(t, int32(5), provider.calls.Load())
+}
+
+func TestUserReaderWriter_SearchUser_FailuresRetried(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	provider := &fakeProvider{err: errs.NewServiceUnavailable("auth0 unavailable")}
+	d := NewUserReaderWriter(provider, time.Minute, WithClock(fake))
+
+	_, err := search(t, d, "jane@example.com")
+	assert.Error(t, err)
+
+	provider.err = nil
+	user, err := search(t, d, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, int32(2), provider.calls.Load())
+}
@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package email
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitBuckets bounds the buckets kept in memory, the full buckets are dropped beyond it
+const maxRateLimitBuckets = 10000
+
+// templateLimit is the configured limit of a template, applied to each recipient
+type templateLimit struct {
+	every rate.Limit
+	burst int
+}
+
+// templateRateLimiter holds one token bucket per template name and recipient, so a noisy
+// recipient doesn't throttle the others. Templates without a configured limit are never throttled.
+type templateRateLimiter struct {
+	limits map[string]templateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+// allow reports whether an email for the given template can be sent now to the recipient,
+// otherwise it returns how long until the next one can be sent
+func (t *templateRateLimiter) allow(templateName, to string) (bool, time.Duration) {
+	limit, ok := t.limits[templateName]
+	if !ok {
+		return true, 0
+	}
+
+	reservation := t.bucket(templateName+":"+emailnorm.Canonical(to), limit).Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return true, 0
+	}
+	// the email is not sent, give the token back
+	reservation.Cancel()
+	return false, delay
+}
+
+// bucket returns the bucket of the key, created full on the first email
+func (t *templateRateLimiter) bucket(key string, limit templateLimit) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limiter, ok := t.buckets[key]; ok {
+		return limiter
+	}
+	if len(t.buckets) >= maxRateLimitBuckets {
+		// a full bucket is the same as a new one
+		now := time.Now()
+		for k, limiter := range t.buckets {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(t.buckets, k)
+			}
+		}
+	}
+	limiter := rate.NewLimiter(limit.every, limit.burst)
+	t.buckets[key] = limiter
+	return limiter
+}
+
+// parseTemplateRateLimits parses a spec of the form "name=count/period,..."
+// (e.g. "email_verification=5/1m") into a templateRateLimiter, the limits
+// apply to each recipient.
+func parseTemplateRateLimits(spec string) (*templateRateLimiter, error) {
+	limiter := &templateRateLimiter{
+		limits:  make(map[string]templateLimit),
+		buckets: make(map[string]*rate.Limiter),
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, limit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit entry %q", entry)
+		}
+		countRaw, periodRaw, ok := strings.Cut(limit, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit entry %q", entry)
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(countRaw))
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid rate limit count in %q", entry)
+		}
+		period, err := time.ParseDuration(strings.TrimSpace(periodRaw))
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("invalid rate limit period in %q", entry)
+		}
+
+		limiter.limits[strings.TrimSpace(name)] = templateLimit{every: rate.Every(period / time.Duration(count)), burst: count}
+	}
+
+	return limiter, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package email
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/ses"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/smtp"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const defaultFromAddress = "noreply@lfx.dev"
+
+// templatedSender renders templates and delivers them through the configured provider
+type templatedSender struct {
+	sender   port.EmailSender
+	renderer *renderer
+	limiter  *templateRateLimiter
+	from     string
+	fromName string
+}
+
+// SendTemplatedEmail renders the named template and sends it, honoring the per-template rate limits
+// of the recipient
+func (t *templatedSender) SendTemplatedEmail(ctx context.Context, templateName, to string, data any) error {
+
+	if allowed, retryAfter := t.limiter.allow(templateName, to); !allowed {
+		slog.WarnContext(ctx, "email template rate limit exceeded",
+			"template", templateName,
+			"to", redaction.RedactEmail(to),
+			"retry_after", retryAfter,
+		)
+		return errors.NewTooManyRequests("too many emails sent, please try again later").WithRetryAfter(retryAfter)
+	}
+
+	message, err := t.renderer.render(templateName, data)
+	if err != nil {
+		return err
+	}
+	message.From = t.from
+	message.FromName = t.fromName
+	message.To = to
+
+	return t.sender.SendEmail(ctx, message)
+}
+
+// NewSender returns the port.EmailSender for the provider configured via EMAIL_PROVIDER (smtp by default)
+func NewSender() (port.EmailSender, error) {
+	provider := os.Getenv(constants.EmailProviderEnvKey)
+	switch provider {
+	case "", constants.EmailProviderSMTP:
+		return smtp.NewSender(), nil
+	case constants.EmailProviderSES:
+		return ses.NewSender(), nil
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", provider)
+	}
+}
+
+// NewTemplatedSender creates a port.TemplatedEmailSender on top of the configured provider
+func NewTemplatedSender() (port.TemplatedEmailSender, error) {
+	sender, err := NewSender()
+	if err != nil {
+		return nil, err
+	}
+	return newTemplatedSender(sender)
+}
+
+func newTemplatedSender(sender port.EmailSender) (*templatedSender, error) {
+	renderer, err := newRenderer()
+	if err != nil {
+		return nil, err
+	}
+
+	limiter, err := parseTemplateRateLimits(os.Getenv(constants.EmailTemplateRateLimitsEnvKey))
+	if err != nil {
+		return nil, err
+	}
+
+	from := os.Getenv(constants.EmailFromAddressEnvKey)
+	if from == "" {
+		from = defaultFromAddress
+	}
+
+	return &templatedSender{
+		sender:   sender,
+		renderer: renderer,
+		limiter:  limiter,
+		from:     from,
+		fromName: os.Getenv(constants.EmailFromNameEnvKey),
+	}, nil
+}
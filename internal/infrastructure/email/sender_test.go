@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockEmailSender struct {
+	sent []*model.EmailMessage
+}
+
+func (m *mockEmailSender) SendEmail(ctx context.Context, message *model.EmailMessage) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+func TestTemplatedSender_SendTemplatedEmail(t *testing.T) {
+	t.Setenv(constants.EmailTemplateRateLimitsEnvKey, "email_verification=2/1h")
+	t.Setenv(constants.EmailFromAddressEnvKey, "")
+
+	mock := &mockEmailSender{}
+	sender, err := newTemplatedSender(mock)
+	if err != nil {
+		t.Fatalf("newTemplatedSender() error = %v", err)
+	}
+
+	ctx := context.Background()
+	data := map[string]string{"OTP": "123456"}
+
+	for i := 0; i < 2; i++ {
+		if err := sender.SendTemplatedEmail(ctx, TemplateEmailVerification, "user@example.com", data); err != nil {
+			t.Fatalf("SendTemplatedEmail() error = %v", err)
+		}
+	}
+
+	err = sender.SendTemplatedEmail(ctx, TemplateEmailVerification, "user@example.com", data)
+	tooMany, ok := err.(errors.TooManyRequests)
+	if !ok {
+		t.Fatalf("expected TooManyRequests error, got %v", err)
+	}
+	// 2 emails per hour, the next one can be sent in about 30 minutes
+	if retryAfter := tooMany.RetryAfter(); retryAfter < 29*time.Minute || retryAfter > 30*time.Minute {
+		t.Errorf("RetryAfter() = %v, want about 30m", retryAfter)
+	}
+
+	// the limit is kept per recipient, the other spellings of the mailbox share it
+	if err := sender.SendTemplatedEmail(ctx, TemplateEmailVerification, " User@Example.com", data); err == nil {
+		t.Error("expected the other spelling of the recipient to be throttled")
+	}
+	if err := sender.SendTemplatedEmail(ctx, TemplateEmailVerification, "other@example.com", data); err != nil {
+		t.Fatalf("SendTemplatedEmail() to another recipient error = %v", err)
+	}
+
+	if len(mock.sent) != 3 {
+		t.Fatalf("expected 3 sent emails, got %d", len(mock.sent))
+	}
+	message := mock.sent[0]
+	if message.From != defaultFromAddress {
+		t.Errorf("From = %q, want %q", message.From, defaultFromAddress)
+	}
+	if message.Subject != "Welcome to Linux Foundation" {
+		t.Errorf("Subject = %q", message.Subject)
+	}
+	if message.Body != "Your verification code is: 123456" {
+		t.Errorf("Body = %q", message.Body)
+	}
+}
+
+func TestTemplatedSender_NotificationTemplates(t *testing.T) {
+	mock := &mockEmailSender{}
+	sender, err := newTemplatedSender(mock)
+	if err != nil {
+		t.Fatalf("newTemplatedSender() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := sender.SendTemplatedEmail(ctx, TemplateProfileNudge, "user@example.com", map[string]any{
+		"Username": "jdoe",
+		"Reasons":  []string{"unverified_alternate_email"},
+	}); err != nil {
+		t.Fatalf("SendTemplatedEmail(%s) error = %v", TemplateProfileNudge, err)
+	}
+	if err := sender.SendTemplatedEmail(ctx, TemplatePrimaryEmailChanged, "user@example.com", map[string]any{
+		"Username": "jdoe",
+		"Email":    "n***@example.com",
+	}); err != nil {
+		t.Fatalf("SendTemplatedEmail(%s) error = %v", TemplatePrimaryEmailChanged, err)
+	}
+
+	if len(mock.sent) != 2 {
+		t.Fatalf("expected 2 sent emails, got %d", len(mock.sent))
+	}
+	if !strings.Contains(mock.sent[0].Body, "- unverified_alternate_email") {
+		t.Errorf("nudge Body = %q, want the reasons", mock.sent[0].Body)
+	}
+	if !strings.Contains(mock.sent[1].Body, "n***@example.com") {
+		t.Errorf("primary email changed Body = %q, want the new email", mock.sent[1].Body)
+	}
+}
+
+func TestTemplatedSender_UnknownTemplate(t *testing.T) {
+	sender, err := newTemplatedSender(&mockEmailSender{})
+	if err != nil {
+		t.Fatalf("newTemplatedSender() error = %v", err)
+	}
+
+	err = sender.SendTemplatedEmail(context.Background(), "unknown", "user@example.com", nil)
+	if _, ok := err.(errors.Validation); !ok {
+		t.Fatalf("expected Validation error, got %v", err)
+	}
+}
+
+func TestParseTemplateRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: 0},
+		{name: "single", spec: "email_verification=5/1m", want: 1},
+		{name: "multiple", spec: "a=5/1m, b=100/1h", want: 2},
+		{name: "missing period", spec: "a=5", wantErr: true},
+		{name: "invalid count", spec: "a=zero/1m", wantErr: true},
+		{name: "invalid period", spec: "a=5/soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, err := parseTemplateRateLimits(tt.spec)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("parseTemplateRateLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(limiter.limits) != tt.want {
+				t.Errorf("got %d limits, want %d", len(limiter.limits), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	tests := []struct {
+		provider string
+		wantErr  bool
+	}{
+		{provider: ""},
+		{provider: constants.EmailProviderSMTP},
+		{provider: constants.EmailProviderSES},
+		{provider: "carrier-pigeon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			t.Setenv(constants.EmailProviderEnvKey, tt.provider)
+			_, err := NewSender()
+			if tt.wantErr != (err != nil) {
+				t.Errorf("NewSender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// TemplateEmailVerification is the template used to deliver email verification OTPs
+	TemplateEmailVerification = "email_verification"
+	// TemplateProfileNudge is the template used to remind the users to re-verify a stale profile
+	TemplateProfileNudge = "profile_nudge"
+	// TemplatePrimaryEmailChanged is the template used to notify the previous primary email of a change
+	TemplatePrimaryEmailChanged = constants.EmailTemplatePrimaryEmailChanged
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// renderer renders the embedded email templates.
+//
+// Each template file must define a "subject" and a "body" block.
+type renderer struct {
+	templates map[string]*template.Template
+}
+
+// render executes the named template and returns the resulting message
+func (r *renderer) render(name string, data any) (*model.EmailMessage, error) {
+	tmpl, ok := r.templates[name]
+	if !ok {
+		return nil, errors.NewValidation(fmt.Sprintf("unknown email template: %s", name))
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, errors.NewUnexpected("failed to render email subject", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, errors.NewUnexpected("failed to render email body", err)
+	}
+
+	return &model.EmailMessage{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
+
+// newRenderer parses all embedded templates, keyed by file name without extension
+func newRenderer() (*renderer, error) {
+	files, err := templatesFS.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		tmpl, errParse := template.New(name).Option("missingkey=error").ParseFS(templatesFS, path.Join("templates", file.Name()))
+		if errParse != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", name, errParse)
+		}
+		templates[name] = tmpl
+	}
+
+	return &renderer{templates: templates}, nil
+}
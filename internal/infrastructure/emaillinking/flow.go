@@ -0,0 +1,205 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package emaillinking verifies the alternate emails with an OTP, for the identity providers only
+// verifying the primary email. Once verified, an identity token signed by the service proves the
+// ownership of the email to the linking request.
+//
+// The pending codes are kept in a Store: in memory the code must be verified on the replica that
+// sent it, the KV store shares them between the replicas.
+package emaillinking
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/email"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	jwtgenerator "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/password"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// CodeTTL is how long the OTP sent to an alternate email is valid
+	CodeTTL = 5 * time.Minute
+	// IdentityTokenTTL is how long the identity token of a verified alternate email can be linked
+	IdentityTokenTTL = 10 * time.Minute
+	// emailSubPrefix is the prefix of the subject of the identity tokens of the verified emails
+	emailSubPrefix = "email|"
+)
+
+// Flow sends the OTPs of the alternate emails, verifies them and the identity tokens it issues
+type Flow struct {
+	emailSender port.TemplatedEmailSender
+	signer      Signer
+	issuer      string
+	store       Store
+	clock       clock.Clock
+}
+
+// Option configures the flow
+type Option func(*Flow)
+
+// WithStore sets the store of the pending codes, in memory by default
+func WithStore(store Store) Option {
+	return func(f *Flow) {
+		f.store = store
+	}
+}
+
+// WithClock sets the time source of the expirations
+func WithClock(c clock.Clock) Option {
+	return func(f *Flow) {
+		f.clock = c
+	}
+}
+
+// normalizeEmail is the email the codes and the identity tokens are bound to
+func normalizeEmail(emailAddress string) string {
+	return strings.ToLower(strings.TrimSpace(emailAddress))
+}
+
+// hash returns the hash of the OTP of the email
+func hash(emailAddress, otp string) string {
+	sum := sha256.Sum256([]byte(emailAddress + ":" + otp))
+	return hex.EncodeToString(sum[:])
+}
+
+// SendVerification sends an OTP to the email, replacing the pending one
+func (f *Flow) SendVerification(ctx context.Context, emailAddress string) error {
+	otp, err := password.OnlyNumbers(6)
+	if err != nil {
+		return errors.NewUnexpected("failed to generate OTP", err)
+	}
+
+	key := normalizeEmail(emailAddress)
+	code := Code{Hash: hash(key, otp), ExpiresAt: f.clock.Now().Add(CodeTTL)}
+	if errPut := f.store.Put(ctx, key, code); errPut != nil {
+		slog.ErrorContext(ctx, "failed to store verification code", "error", errPut)
+		return errors.NewServiceUnavailable("failed to store verification code", errPut)
+	}
+
+	errSendEmail := f.emailSender.SendTemplatedEmail(ctx, email.TemplateEmailVerification, emailAddress, map[string]string{"OTP": otp})
+	if errSendEmail != nil {
+		slog.ErrorContext(ctx, "failed to send email", "error", errSendEmail)
+		var tooManyRequests errors.TooManyRequests
+		if stderrors.As(errSendEmail, &tooManyRequests) {
+			return errSendEmail
+		}
+		return errors.NewUnexpected("failed to send email", errSendEmail)
+	}
+
+	slog.InfoContext(ctx, "alternate email verification sent",
+		"email", redaction.RedactEmail(emailAddress),
+	)
+	return nil
+}
+
+// Verify checks the OTP and issues the identity token of the email, the OTP can only be used once
+func (f *Flow) Verify(ctx context.Context, emailAddress, otp string) (*model.AuthResponse, error) {
+	key := normalizeEmail(emailAddress)
+
+	code, exists, errGet := f.store.Get(ctx, key)
+	if errGet != nil {
+		slog.ErrorContext(ctx, "failed to get verification code", "error", errGet)
+		return nil, errors.NewServiceUnavailable("failed to get verification code", errGet)
+	}
+
+	expired := exists && f.clock.Now().After(code.ExpiresAt)
+	valid := exists && !expired && subtle.ConstantTimeCompare([]byte(code.Hash), []byte(hash(key, otp))) == 1
+	if expired || valid {
+		deleted, errDelete := f.store.Delete(ctx, key, code)
+		if errDelete != nil {
+			slog.ErrorContext(ctx, "failed to delete verification code", "error", errDelete)
+			return nil, errors.NewServiceUnavailable("failed to delete verification code", errDelete)
+		}
+		// another verification used the code in between
+		valid = valid && deleted
+	}
+
+	if !valid {
+		return nil, errors.NewValidation("invalid or expired verification code")
+	}
+
+	idToken, err := jwtgenerator.Generate(&jwtgenerator.GeneratorOptions{
+		TokenType:     jwtgenerator.TokenTypeIdentity,
+		Subject:       emailSubPrefix + key,
+		Email:         key,
+		Issuer:        f.issuer,
+		Audience:      f.issuer,
+		IssuedAt:      f.clock.Now(),
+		ExpiresIn:     IdentityTokenTTL,
+		SigningMethod: f.signer.algorithm,
+		SigningKey:    f.signer.signingKey,
+	})
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to generate ID token", err)
+	}
+
+	slog.DebugContext(ctx, "alternate email verified",
+		"email", redaction.RedactEmail(emailAddress),
+	)
+
+	return &model.AuthResponse{
+		IDToken:   idToken,
+		ExpiresIn: int(IdentityTokenTTL.Seconds()),
+		TokenType: "Bearer",
+	}, nil
+}
+
+// VerifiedEmail returns the email proven by the identity token issued by Verify
+func (f *Flow) VerifiedEmail(ctx context.Context, identityToken string) (string, error) {
+	token, err := jwt.Parse([]byte(strings.TrimSpace(identityToken)),
+		jwt.WithKey(f.signer.algorithm, f.signer.verificationKey),
+		jwt.WithIssuer(f.issuer),
+		jwt.WithAudience(f.issuer),
+		jwt.WithClock(f.clock),
+	)
+	if err != nil {
+		slog.WarnContext(ctx, "invalid identity token", "error", err)
+		return "", errors.NewValidation("invalid identity token")
+	}
+
+	emailAddress, _ := token.PrivateClaims()["email"].(string)
+	if emailAddress == "" || token.Subject() != emailSubPrefix+emailAddress {
+		return "", errors.NewValidation("identity token does not contain a verified email")
+	}
+	return emailAddress, nil
+}
+
+// New creates the flow, the identity tokens are signed by the signer for the issuer. The email
+// sender is configured from the environment when nil.
+func New(emailSender port.TemplatedEmailSender, signer Signer, issuer string, opts ...Option) (*Flow, error) {
+	if emailSender == nil {
+		templatedSender, err := email.NewTemplatedSender()
+		if err != nil {
+			return nil, err
+		}
+		emailSender = templatedSender
+	}
+
+	f := &Flow{
+		emailSender: emailSender,
+		signer:      signer,
+		issuer:      issuer,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.clock = clock.Or(f.clock)
+	if f.store == nil {
+		f.store = NewMemoryStore(f.clock)
+	}
+	return f, nil
+}
@@ -0,0 +1,225 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emaillinking
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeKV is an in-memory kvStore shared by the flows of the replicas
+type fakeKV struct {
+	mu       sync.Mutex
+	data     map[string]fakeEntry
+	sequence uint64
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Put(ctx context.Context, key string, value []byte) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sequence++
+	f.data[key] = fakeEntry{value: value, revision: f.sequence}
+	return f.sequence, nil
+}
+
+func (f *fakeKV) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.data[key].revision != revision {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.sequence++
+	f.data[key] = fakeEntry{value: value, revision: f.sequence}
+	return f.sequence, nil
+}
+
+// fakeEmailSender keeps the last OTP sent to each recipient
+type fakeEmailSender struct {
+	mu   sync.Mutex
+	otps map[string]string
+}
+
+func (s *fakeEmailSender) SendTemplatedEmail(_ context.Context, _, to string, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otps[to] = data.(map[string]string)["OTP"]
+	return nil
+}
+
+func (s *fakeEmailSender) otp(to string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.otps[to]
+}
+
+func TestFlow_VerifyOnAnotherReplica(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+
+	newReplica := func() *Flow {
+		flow, err := New(sender, NewHMACSigner([]byte("secret")), "https://idp.example.com#auth-service",
+			WithStore(&KVStore{kv: kv}),
+			WithClock(fake),
+		)
+		require.NoError(t, err)
+		return flow
+	}
+	sending, verifying := newReplica(), newReplica()
+
+	const emailAddress = "Jane@Personal.example.com"
+	require.NoError(t, sending.SendVerification(ctx, emailAddress))
+	otp := sender.otp(emailAddress)
+	require.Len(t, otp, 6)
+
+	for _, entry := range kv.data {
+		assert.NotContains(t, string(entry.value), otp, "only the hash of the OTP is stored")
+	}
+
+	_, err := verifying.Verify(ctx, emailAddress, otp+"0")
+	require.Error(t, err, "a wrong OTP is rejected")
+
+	authResponse, err := verifying.Verify(ctx, emailAddress, otp)
+	require.NoError(t, err)
+
+	_, err = sending.Verify(ctx, emailAddress, otp)
+	require.Error(t, err, "the OTP can only be used once")
+
+	verified, err := sending.VerifiedEmail(ctx, authResponse.IDToken)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@personal.example.com", verified)
+}
+
+func TestFlow_VerifyExpiredCode(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	store := NewMemoryStore(fake)
+
+	flow, err := New(sender, NewHMACSigner([]byte("secret")), "issuer", WithStore(store), WithClock(fake))
+	require.NoError(t, err)
+
+	require.NoError(t, flow.SendVerification(ctx, "jane@example.com"))
+	fake.Advance(CodeTTL + time.Second)
+
+	_, err = flow.Verify(ctx, "jane@example.com", sender.otp("jane@example.com"))
+	require.Error(t, err)
+	assert.IsType(t, errs.Validation{}, err)
+	assert.Empty(t, store.codes, "the expired code is removed")
+}
+
+// failingEmailSender fails every send with the error
+type failingEmailSender struct {
+	err error
+}
+
+func (s *failingEmailSender) SendTemplatedEmail(_ context.Context, _, _ string, _ any) error {
+	return s.err
+}
+
+func TestFlow_SendVerificationRateLimited(t *testing.T) {
+	ctx := context.Background()
+	limited := errs.NewTooManyRequests("too many emails")
+	sender := &failingEmailSender{err: fmt.Errorf("send verification: %w", limited)}
+
+	flow, err := New(sender, NewHMACSigner([]byte("secret")), "issuer")
+	require.NoError(t, err)
+
+	err = flow.SendVerification(ctx, "jane@example.com")
+	var tooManyRequests errs.TooManyRequests
+	assert.True(t, errors.As(err, &tooManyRequests), "the wrapped rate limit is kept, got %v", err)
+
+	sender.err = fmt.Errorf("smtp unavailable")
+	err = flow.SendVerification(ctx, "jane@example.com")
+	assert.IsType(t, errs.Unexpected{}, err)
+}
+
+func TestFlow_RSASigner(t *testing.T) {
+	ctx := context.Background()
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	flow, err := New(sender, NewRSASigner(privateKey), "issuer")
+	require.NoError(t, err)
+
+	require.NoError(t, flow.SendVerification(ctx, "jane@example.com"))
+	authResponse, err := flow.Verify(ctx, "jane@example.com", sender.otp("jane@example.com"))
+	require.NoError(t, err)
+
+	verified, err := flow.VerifiedEmail(ctx, authResponse.IDToken)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", verified)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := New(sender, NewRSASigner(otherKey), "issuer")
+	require.NoError(t, err)
+	_, err = other.VerifiedEmail(ctx, authResponse.IDToken)
+	require.Error(t, err, "a token signed with another key is rejected")
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	store := NewMemoryStore(fake)
+
+	require.NoError(t, store.Put(ctx, "old@example.com", Code{Hash: "old", ExpiresAt: fake.Now().Add(time.Minute)}))
+	require.NoError(t, store.Put(ctx, "jane@example.com", Code{Hash: "first", ExpiresAt: fake.Now().Add(CodeTTL)}))
+
+	first, ok, err := store.Get(ctx, "jane@example.com")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a code sent again in between isn't removed with the previous one
+	require.NoError(t, store.Put(ctx, "jane@example.com", Code{Hash: "second", ExpiresAt: fake.Now().Add(CodeTTL)}))
+	deleted, err := store.Delete(ctx, "jane@example.com", first)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	// the expired codes are pruned by the next code stored
+	fake.Advance(2 * time.Minute)
+	for i := range 3 {
+		require.NoError(t, store.Put(ctx, "user"+strconv.Itoa(i)+"@example.com", Code{ExpiresAt: fake.Now().Add(CodeTTL)}))
+	}
+	_, ok, err = store.Get(ctx, "old@example.com")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Len(t, store.codes, 4)
+}
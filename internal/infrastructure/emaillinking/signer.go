@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emaillinking
+
+import (
+	"crypto/rsa"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// Signer signs the identity tokens of the verified emails and verifies them back
+type Signer struct {
+	algorithm       jwa.SignatureAlgorithm
+	signingKey      any
+	verificationKey any
+}
+
+// NewHMACSigner creates a signer of HS256 tokens, any replica sharing the secret can verify them
+func NewHMACSigner(secret []byte) Signer {
+	return Signer{algorithm: jwa.HS256, signingKey: secret, verificationKey: secret}
+}
+
+// NewRSASigner creates a signer of RS256 tokens, verified with the public key of the private key
+func NewRSASigner(privateKey *rsa.PrivateKey) Signer {
+	return Signer{algorithm: jwa.RS256, signingKey: privateKey, verificationKey: &privateKey.PublicKey}
+}
@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emaillinking
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Code is the pending verification code of an email, only the hash of the OTP is kept
+type Code struct {
+	Hash      string    `json:"hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// revision tells the stored code apart from the codes sent again in between
+	revision uint64
+}
+
+// Store keeps the pending verification codes of the emails until they are verified or expire
+type Store interface {
+	// Put stores the code of the email, replacing the pending one
+	Put(ctx context.Context, email string, code Code) error
+	// Get returns the pending code of the email, false when there is none
+	Get(ctx context.Context, email string) (Code, bool, error)
+	// Delete removes the code returned by Get, false when it was replaced or removed in between
+	Delete(ctx context.Context, email string, code Code) (bool, error)
+}
+
+// MemoryStore keeps the codes in the memory of the replica, the code must be verified on the replica
+// that sent it
+type MemoryStore struct {
+	mu       sync.Mutex
+	codes    map[string]Code
+	revision uint64
+	clock    clock.Clock
+}
+
+// Put stores the code of the email, replacing the pending one
+func (m *MemoryStore) Put(_ context.Context, email string, code Code) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the expired codes are dropped on the way
+	now := m.clock.Now()
+	for key, pending := range m.codes {
+		if now.After(pending.ExpiresAt) {
+			delete(m.codes, key)
+		}
+	}
+
+	m.revision++
+	code.revision = m.revision
+	m.codes[email] = code
+	return nil
+}
+
+// Get returns the pending code of the email, false when there is none
+func (m *MemoryStore) Get(_ context.Context, email string) (Code, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code, ok := m.codes[email]
+	return code, ok, nil
+}
+
+// Delete removes the code returned by Get, false when it was replaced or removed in between
+func (m *MemoryStore) Delete(_ context.Context, email string, code Code) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pending, ok := m.codes[email]; !ok || pending.revision != code.revision {
+		return false, nil
+	}
+	delete(m.codes, email)
+	return true, nil
+}
+
+// NewMemoryStore creates a store keeping the codes in memory, the clock expires them
+func NewMemoryStore(c clock.Clock) *MemoryStore {
+	return &MemoryStore{
+		codes: make(map[string]Code),
+		clock: clock.Or(c),
+	}
+}
+
+// kvStore is the subset of the KV bucket used by the KV store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Put(ctx context.Context, key string, value []byte) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+}
+
+// KVStore keeps the codes in a NATS KV bucket shared by the replicas, so a code sent by a replica can
+// be verified on another one. The TTL of the bucket removes the expired codes.
+type KVStore struct {
+	kv kvStore
+}
+
+// key returns the key of the email, the emails aren't valid KV keys
+func key(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// Put stores the code of the email, replacing the pending one
+func (s *KVStore) Put(ctx context.Context, email string, code Code) error {
+	data, err := json.Marshal(code)
+	if err != nil {
+		return err
+	}
+	_, err = s.kv.Put(ctx, key(email), data)
+	return err
+}
+
+// Get returns the pending code of the email, false when there is none
+func (s *KVStore) Get(ctx context.Context, email string) (Code, bool, error) {
+	entry, err := s.kv.Get(ctx, key(email))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return Code{}, false, nil
+	}
+	if err != nil {
+		return Code{}, false, err
+	}
+	if len(entry.Value()) == 0 {
+		// the code was used
+		return Code{}, false, nil
+	}
+
+	var code Code
+	if err := json.Unmarshal(entry.Value(), &code); err != nil {
+		return Code{}, false, err
+	}
+	code.revision = entry.Revision()
+	return code, true, nil
+}
+
+// Delete removes the code returned by Get, false when it was replaced or removed in between, so
+// concurrent verifications on two replicas can't both use the code. The code is overwritten with
+// an empty value, removed by the TTL of the bucket.
+func (s *KVStore) Delete(ctx context.Context, email string, code Code) (bool, error) {
+	_, err := s.kv.Update(ctx, key(email), nil, code.revision)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NewKVStore creates a store keeping the codes in the given KV bucket
+func NewKVStore(kv jetstream.KeyValue) *KVStore {
+	return &KVStore{kv: kv}
+}
@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package eventschema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jsonschema"
+)
+
+//go:embed schemas/*.json
+var schemasFS embed.FS
+
+// schemaFiles are the schemas of the event subjects, the audit sink shares the audit events schema
+var schemaFiles = map[string]string{
+	constants.UserProfileChangedSubject: "user_profile_changed.json",
+	constants.UserMergedSubject:         "user_merged.json",
+	constants.AuditEventSubject:         "audit_event.json",
+	constants.UserAuditSubject:          "audit_event.json",
+	constants.UserProfileNudgeSubject:   "user_profile_nudge.json",
+	constants.AutheliaSyncStatusSubject: "authelia_sync_status.json",
+}
+
+// entry is the schema of a subject, as published and compiled
+type entry struct {
+	schema   model.EventSchema
+	compiled *jsonschema.Schema
+}
+
+// Registry holds the JSON Schemas of the events published by the service
+type Registry struct {
+	entries map[string]entry
+}
+
+// ValidateEvent validates the event against the schema of the subject, the error lists every
+// violation of the schema
+func (r *Registry) ValidateEvent(subject string, data []byte) error {
+	e, ok := r.entries[subject]
+	if !ok {
+		return errors.NewValidation(fmt.Sprintf("no event schema registered for subject %s", subject))
+	}
+	if err := e.compiled.Validate(data); err != nil {
+		return errors.NewValidation(fmt.Sprintf("invalid %s event", e.schema.Title), err)
+	}
+	return nil
+}
+
+// EventSchemas returns the schemas of the published events, ordered by subject
+func (r *Registry) EventSchemas() []model.EventSchema {
+	schemas := make([]model.EventSchema, 0, len(r.entries))
+	for _, e := range r.entries {
+		schemas = append(schemas, e.schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Subject < schemas[j].Subject
+	})
+	return schemas
+}
+
+// NewRegistry loads the embedded schemas of the events
+func NewRegistry() (*Registry, error) {
+	r := &Registry{entries: make(map[string]entry, len(schemaFiles))}
+	for subject, file := range schemaFiles {
+		data, errRead := schemasFS.ReadFile(path.Join("schemas", file))
+		if errRead != nil {
+			return nil, errors.NewUnexpected(fmt.Sprintf("failed to read event schema %s", file), errRead)
+		}
+
+		compiled, errCompile := jsonschema.Compile(data)
+		if errCompile != nil {
+			return nil, errors.NewUnexpected(fmt.Sprintf("failed to compile event schema %s", file), errCompile)
+		}
+
+		var annotations struct {
+			Title string `json:"title"`
+		}
+		if errUnmarshal := json.Unmarshal(data, &annotations); errUnmarshal != nil {
+			return nil, errors.NewUnexpected(fmt.Sprintf("failed to read the title of event schema %s", file), errUnmarshal)
+		}
+
+		r.entries[subject] = entry{
+			schema: model.EventSchema{
+				Subject: subject,
+				Title:   annotations.Title,
+				Schema:  json.RawMessage(data),
+			},
+			compiled: compiled,
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,110 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package eventschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ValidateEvent(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	// the events as marshaled by the publishers
+	tests := []struct {
+		subject string
+		event   any
+	}{
+		{
+			subject: constants.UserProfileChangedSubject,
+			event:   &model.UserProfileChanged{Sub: "auth0|123", Reason: model.ProfileChangeUpdate, ChangedAt: now},
+		},
+		{
+			subject: constants.UserMergedSubject,
+			event: &model.UserMerged{
+				PrimarySub:     "auth0|123",
+				SecondarySub:   "auth0|456",
+				ConflictPolicy: model.MergePolicyPrimary,
+				Conflicts:      []string{"name"},
+				MergedAt:       now,
+			},
+		},
+		{
+			subject: constants.AuditEventSubject,
+			event: &model.AuditEvent{
+				Action:  model.AuditActionUserUpdate,
+				Actor:   "auth0|123",
+				Target:  "auth0|123",
+				Changes: map[string]string{"name": "J***", "job_title": ""},
+				Outcome: model.AuditOutcomeSuccess,
+				Details: map[string]any{"changed": 1},
+				At:      now,
+			},
+		},
+		{
+			subject: constants.UserAuditSubject,
+			event:   &model.AuditEvent{Action: model.AuditActionUserSync, Outcome: model.AuditOutcomeFailure, Error: "boom", At: now},
+		},
+		{
+			subject: constants.UserProfileNudgeSubject,
+			event: &model.ProfileNudge{
+				Username:  "jdoe",
+				UpdatedAt: now,
+				Reasons:   []string{model.NudgeReasonMissingOrganization},
+			},
+		},
+		{
+			subject: constants.AutheliaSyncStatusSubject,
+			event:   &model.SyncStatus{Succeeded: true, Changed: 2, SyncedAt: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			data, errMarshal := json.Marshal(tt.event)
+			require.NoError(t, errMarshal)
+			assert.NoError(t, registry.ValidateEvent(tt.subject, data))
+		})
+	}
+}
+
+func TestRegistry_ValidateEvent_Invalid(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	errValidate := registry.ValidateEvent(constants.UserProfileChangedSubject, []byte(`{"sub":"","reason":"subscribed","changed_at":"now"}`))
+	require.Error(t, errValidate)
+	_, isValidation := errValidate.(errors.Validation)
+	assert.True(t, isValidation, "expected validation error, got %T", errValidate)
+	assert.Contains(t, errValidate.Error(), "invalid UserProfileChanged event")
+	assert.Contains(t, errValidate.Error(), "/reason")
+
+	errUnknown := registry.ValidateEvent("lfx.auth-service.unknown", []byte(`{}`))
+	assert.ErrorContains(t, errUnknown, "no event schema registered")
+}
+
+func TestRegistry_EventSchemas(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	schemas := registry.EventSchemas()
+	require.Len(t, schemas, len(schemaFiles))
+	for i, schema := range schemas {
+		if i > 0 {
+			assert.Less(t, schemas[i-1].Subject, schema.Subject)
+		}
+		assert.NotEmpty(t, schema.Title, schema.Subject)
+		assert.True(t, json.Valid(schema.Schema), schema.Subject)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+const (
+	// DefaultSigningKeysSecret is the name of the secret holding the signing keys by default
+	DefaultSigningKeysSecret = "auth-service-signing-keys"
+
+	// signingKeysSecretKey is the entry of the secret holding the keys
+	signingKeysSecretKey = "signing-keys.json"
+
+	// serviceAccountNamespaceFile is the namespace of the pod, mounted with its service account
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// SecretKeyStore stores the signing keys in a Kubernetes secret, the resource version of the
+// secret is the version of the keys
+type SecretKeyStore struct {
+	k8sClient kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// Load returns the keys of the secret, none when the secret doesn't exist
+func (s *SecretKeyStore) Load(ctx context.Context) ([]keyring.Key, string, error) {
+	secret, err := s.k8sClient.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", errors.NewServiceUnavailable("failed to get the signing keys secret", err)
+	}
+
+	var keys []keyring.Key
+	if data := secret.Data[signingKeysSecretKey]; len(data) > 0 {
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, "", errors.NewUnexpected("invalid signing keys secret", err)
+		}
+	}
+	return keys, secret.ResourceVersion, nil
+}
+
+// Save replaces the keys of the secret at the resource version, the secret is created when the
+// version is empty
+func (s *SecretKeyStore) Save(ctx context.Context, keys []keyring.Key, version string) error {
+	data, errMarshal := json.Marshal(keys)
+	if errMarshal != nil {
+		return errors.NewUnexpected("failed to encode the signing keys", errMarshal)
+	}
+
+	secrets := s.k8sClient.CoreV1().Secrets(s.namespace)
+	var err error
+	if version == "" {
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+			Data:       map[string][]byte{signingKeysSecretKey: data},
+		}, metav1.CreateOptions{})
+	} else {
+		var secret *corev1.Secret
+		secret, err = secrets.Get(ctx, s.name, metav1.GetOptions{})
+		if err == nil {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			// the update is rejected when the secret changed since the keys were loaded
+			secret.ResourceVersion = version
+			secret.Data[signingKeysSecretKey] = data
+			_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		}
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err), apierrors.IsNotFound(err):
+		return errors.NewConflict("signing keys secret changed", err)
+	default:
+		return errors.NewServiceUnavailable("failed to save the signing keys secret", err)
+	}
+}
+
+// NewSecretKeyStore creates the store of the signing keys in the secret, the namespace defaults to
+// the namespace of the pod
+func NewSecretKeyStore(ctx context.Context, namespace, name string) (*SecretKeyStore, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.NewValidation("the name of the signing keys secret is required")
+	}
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, errors.NewValidation("the namespace of the signing keys secret is required outside of a pod")
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+
+	k8sClient, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newSecretKeyStore(k8sClient, namespace, name), nil
+}
+
+func newSecretKeyStore(k8sClient kubernetes.Interface, namespace, name string) *SecretKeyStore {
+	return &SecretKeyStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+		name:      name,
+	}
+}
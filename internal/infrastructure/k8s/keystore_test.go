@@ -0,0 +1,56 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+func TestSecretKeyStore(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	store := newSecretKeyStore(client, "lfx", "auth-service-signing-keys")
+
+	keys, version, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys, "no keys until the secret is created")
+	assert.Empty(t, version)
+
+	createdAt := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	key := keyring.Key{ID: "k1", Secret: []byte("0123456789abcdef0123456789abcdef"), CreatedAt: createdAt, ActiveAt: createdAt}
+	require.NoError(t, store.Save(ctx, []keyring.Key{key}, ""))
+
+	keys, _, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID, keys[0].ID)
+	assert.Equal(t, key.Secret, keys[0].Secret)
+	assert.True(t, key.CreatedAt.Equal(keys[0].CreatedAt))
+
+	t.Run("the secret exists already", func(t *testing.T) {
+		err := store.Save(ctx, []keyring.Key{key}, "")
+		assert.IsType(t, errors.Conflict{}, err)
+	})
+
+	t.Run("the secret changed since loaded", func(t *testing.T) {
+		client.PrependReactor("update", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "auth-service-signing-keys", nil)
+		})
+		err := store.Save(ctx, []keyring.Key{key}, "1")
+		assert.IsType(t, errors.Conflict{}, err)
+	})
+}
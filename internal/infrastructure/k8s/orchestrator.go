@@ -227,6 +227,16 @@ func (k *K8sOrchestrator) Update(ctx context.Context, kind string, data ...any)
 }
 
 func (k *K8sOrchestrator) client(ctx context.Context) error {
+	k8sClient, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+	k.k8sClient = k8sClient
+	return nil
+}
+
+// newClient creates the Kubernetes client of the in-cluster config, or of the local kubeconfig
+func newClient(ctx context.Context) (kubernetes.Interface, error) {
 
 	findConfig := func() (*rest.Config, error) {
 		if _, exists := os.LookupEnv("KUBERNETES_SERVICE_HOST"); exists {
@@ -245,20 +255,19 @@ func (k *K8sOrchestrator) client(ctx context.Context) error {
 
 	k8sConfig, errFindConfig := findConfig()
 	if errFindConfig != nil {
-		return errors.NewUnexpected("failed to find Kubernetes config", errFindConfig)
+		return nil, errors.NewUnexpected("failed to find Kubernetes config", errFindConfig)
 	}
 
 	// Create Kubernetes client if config was loaded successfully
 	if k8sConfig != nil {
 		k8sClient, errNewForConfig := kubernetes.NewForConfig(k8sConfig)
 		if errNewForConfig != nil {
-			return errors.NewUnexpected("failed to create Kubernetes client", errNewForConfig)
+			return nil, errors.NewUnexpected("failed to create Kubernetes client", errNewForConfig)
 		}
-		k.k8sClient = k8sClient
-		return nil
+		return k8sClient, nil
 	}
 
-	return errors.NewUnexpected("failed to find Kubernetes config", errFindConfig)
+	return nil, errors.NewUnexpected("failed to find Kubernetes config", errFindConfig)
 }
 
 // NewK8sOrchestrator creates a new k8s orchestrator with auto-configured Kubernetes client
@@ -0,0 +1,174 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keycloak
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+const (
+	// alternateEmailAttribute holds the verified alternate emails of the user, one value per email
+	alternateEmailAttribute = "alternate_email"
+	// organizationVerifiedAttribute holds the organization verified badge computed by the service
+	organizationVerifiedAttribute = "organization_verified"
+)
+
+// KeycloakUser represents a user of the Keycloak Admin REST API (UserRepresentation)
+type KeycloakUser struct {
+	ID            string              `json:"id,omitempty"`
+	Username      string              `json:"username,omitempty"`
+	Email         string              `json:"email,omitempty"`
+	EmailVerified bool                `json:"emailVerified"`
+	FirstName     string              `json:"firstName,omitempty"`
+	LastName      string              `json:"lastName,omitempty"`
+	Enabled       bool                `json:"enabled"`
+	Attributes    map[string][]string `json:"attributes,omitempty"`
+}
+
+// KeycloakFederatedIdentity represents an identity brokered from another identity provider
+type KeycloakFederatedIdentity struct {
+	IdentityProvider string `json:"identityProvider"`
+	UserID           string `json:"userId"`
+	UserName         string `json:"userName"`
+}
+
+// metadataAttributes maps the user attributes to the metadata fields, the given and
+// family names are stored in the Keycloak first and last names instead
+func metadataAttributes(meta *model.UserMetadata) map[string]**string {
+	return map[string]**string{
+		"name":           &meta.Name,
+		"picture":        &meta.Picture,
+		"zoneinfo":       &meta.Zoneinfo,
+		"job_title":      &meta.JobTitle,
+		"organization":   &meta.Organization,
+		"country":        &meta.Country,
+		"state_province": &meta.StateProvince,
+		"city":           &meta.City,
+		"address":        &meta.Address,
+		"postal_code":    &meta.PostalCode,
+		"phone_number":   &meta.PhoneNumber,
+		"t_shirt_size":   &meta.TShirtSize,
+	}
+}
+
+// attribute returns the first value of the attribute, nil when not set
+func (u *KeycloakUser) attribute(name string) *string {
+	values := u.Attributes[name]
+	if len(values) == 0 {
+		return nil
+	}
+	value := values[0]
+	return &value
+}
+
+// setAttribute sets the attribute, an empty value removes it
+func (u *KeycloakUser) setAttribute(name string, value string) {
+	if u.Attributes == nil {
+		u.Attributes = make(map[string][]string)
+	}
+	if value == "" {
+		delete(u.Attributes, name)
+		return
+	}
+	u.Attributes[name] = []string{value}
+}
+
+// ApplyMetadata sets the metadata fields present in the update, like the Auth0 user_metadata
+// patch the fields not present are left untouched
+func (u *KeycloakUser) ApplyMetadata(meta *model.UserMetadata) {
+	if meta == nil {
+		return
+	}
+
+	if meta.GivenName != nil {
+		u.FirstName = *meta.GivenName
+	}
+	if meta.FamilyName != nil {
+		u.LastName = *meta.FamilyName
+	}
+	for name, field := range metadataAttributes(meta) {
+		if *field != nil {
+			u.setAttribute(name, **field)
+		}
+	}
+	if meta.OrganizationVerified != nil {
+		u.setAttribute(organizationVerifiedAttribute, strconv.FormatBool(*meta.OrganizationVerified))
+	}
+}
+
+// AddAlternateEmail adds the verified alternate email, it reports false when already present
+func (u *KeycloakUser) AddAlternateEmail(email string) bool {
+	for _, existing := range u.Attributes[alternateEmailAttribute] {
+		if strings.EqualFold(existing, email) {
+			return false
+		}
+	}
+	if u.Attributes == nil {
+		u.Attributes = make(map[string][]string)
+	}
+	u.Attributes[alternateEmailAttribute] = append(u.Attributes[alternateEmailAttribute], email)
+	return true
+}
+
+// RemoveAlternateEmail removes the alternate email, it reports false when not present
+func (u *KeycloakUser) RemoveAlternateEmail(email string) bool {
+	emails := u.Attributes[alternateEmailAttribute]
+	for i, existing := range emails {
+		if strings.EqualFold(existing, email) {
+			u.Attributes[alternateEmailAttribute] = append(emails[:i:i], emails[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ToUser converts a KeycloakUser to a User
+func (u *KeycloakUser) ToUser() *model.User {
+	meta := &model.UserMetadata{}
+	if u.FirstName != "" {
+		meta.GivenName = &u.FirstName
+	}
+	if u.LastName != "" {
+		meta.FamilyName = &u.LastName
+	}
+	for name, field := range metadataAttributes(meta) {
+		*field = u.attribute(name)
+	}
+	if value := u.attribute(organizationVerifiedAttribute); value != nil {
+		if verified, err := strconv.ParseBool(*value); err == nil {
+			meta.OrganizationVerified = &verified
+		}
+	}
+
+	var alternateEmails []model.Email
+	for _, email := range u.Attributes[alternateEmailAttribute] {
+		alternateEmails = append(alternateEmails, model.Email{
+			Email:    email,
+			Verified: true,
+		})
+	}
+
+	return &model.User{
+		UserID:               u.ID,
+		Sub:                  u.ID,
+		Username:             u.Username,
+		PrimaryEmail:         u.Email,
+		PrimaryEmailVerified: u.EmailVerified,
+		AlternateEmails:      alternateEmails,
+		UserMetadata:         meta,
+	}
+}
+
+// ToIdentity converts a KeycloakFederatedIdentity to an Identity
+func (i *KeycloakFederatedIdentity) ToIdentity() model.Identity {
+	return model.Identity{
+		Provider:   i.IdentityProvider,
+		IdentityID: i.UserID,
+		Nickname:   i.UserName,
+		IsSocial:   true,
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keycloak
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeycloakUser_ApplyMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     KeycloakUser
+		meta     *model.UserMetadata
+		expected KeycloakUser
+	}{
+		{
+			name: "sets names and attributes",
+			user: KeycloakUser{ID: "id"},
+			meta: &model.UserMetadata{
+				GivenName:  converters.StringPtr("Jane"),
+				FamilyName: converters.StringPtr("Doe"),
+				JobTitle:   converters.StringPtr("Engineer"),
+			},
+			expected: KeycloakUser{
+				ID:         "id",
+				FirstName:  "Jane",
+				LastName:   "Doe",
+				Attributes: map[string][]string{"job_title": {"Engineer"}},
+			},
+		},
+		{
+			name: "leaves the fields not present untouched",
+			user: KeycloakUser{
+				FirstName:  "Jane",
+				Attributes: map[string][]string{"city": {"Lisbon"}, alternateEmailAttribute: {"jane@example.com"}},
+			},
+			meta: &model.UserMetadata{Country: converters.StringPtr("Portugal")},
+			expected: KeycloakUser{
+				FirstName: "Jane",
+				Attributes: map[string][]string{
+					"city":                  {"Lisbon"},
+					"country":               {"Portugal"},
+					alternateEmailAttribute: {"jane@example.com"},
+				},
+			},
+		},
+		{
+			name:     "empty value removes the attribute",
+			user:     KeycloakUser{Attributes: map[string][]string{"city": {"Lisbon"}}},
+			meta:     &model.UserMetadata{City: converters.StringPtr("")},
+			expected: KeycloakUser{Attributes: map[string][]string{}},
+		},
+		{
+			name: "organization verified",
+			user: KeycloakUser{},
+			meta: &model.UserMetadata{OrganizationVerified: converters.BoolPtr(true)},
+			expected: KeycloakUser{
+				Attributes: map[string][]string{organizationVerifiedAttribute: {"true"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.user.ApplyMetadata(tt.meta)
+			assert.Equal(t, tt.expected, tt.user)
+		})
+	}
+}
+
+func TestKeycloakUser_ToUser(t *testing.T) {
+	keycloakUser := KeycloakUser{
+		ID:        "4b8f3c4e-1f8a-4a57-9f55-2b1e2f0b8d11",
+		Username:  "jdoe",
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		Attributes: map[string][]string{
+			"zoneinfo":                    {"Europe/Lisbon"},
+			organizationVerifiedAttribute: {"false"},
+			alternateEmailAttribute:       {"jane@work.example.com", "jane@home.example.com"},
+		},
+	}
+
+	user := keycloakUser.ToUser()
+
+	assert.Equal(t, keycloakUser.ID, user.UserID)
+	assert.Equal(t, keycloakUser.ID, user.Sub)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail)
+	assert.False(t, user.PrimaryEmailVerified)
+	require.NotNil(t, user.UserMetadata)
+	assert.Equal(t, "Jane", *user.UserMetadata.GivenName)
+	assert.Nil(t, user.UserMetadata.FamilyName)
+	assert.Equal(t, "Europe/Lisbon", *user.UserMetadata.Zoneinfo)
+	assert.Nil(t, user.UserMetadata.City)
+	require.NotNil(t, user.UserMetadata.OrganizationVerified)
+	assert.False(t, *user.UserMetadata.OrganizationVerified)
+	assert.Equal(t, []model.Email{
+		{Email: "jane@work.example.com", Verified: true},
+		{Email: "jane@home.example.com", Verified: true},
+	}, user.AlternateEmails)
+}
+
+func TestKeycloakUser_AlternateEmails(t *testing.T) {
+	user := KeycloakUser{}
+
+	assert.True(t, user.AddAlternateEmail("jane@example.com"))
+	assert.False(t, user.AddAlternateEmail("Jane@Example.com"))
+	assert.True(t, user.AddAlternateEmail("jane@work.example.com"))
+	assert.Equal(t, []string{"jane@example.com", "jane@work.example.com"}, user.Attributes[alternateEmailAttribute])
+
+	assert.True(t, user.RemoveAlternateEmail("JANE@example.com"))
+	assert.False(t, user.RemoveAlternateEmail("jane@example.com"))
+	assert.Equal(t, []string{"jane@work.example.com"}, user.Attributes[alternateEmailAttribute])
+}
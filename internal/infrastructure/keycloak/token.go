@@ -0,0 +1,131 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keycloak
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// newServiceAccountTokenSource returns the tokens of the service account of the client, used to
+// call the Admin REST API. The tokens are cached until they expire.
+func newServiceAccountTokenSource(ctx context.Context, config Config, httpClient *http.Client) oauth2.TokenSource {
+	credentials := clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.realmURL() + "/protocol/openid-connect/token",
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	return credentials.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, httpClient))
+}
+
+// jwtVerifier verifies the user tokens issued by the realm
+type jwtVerifier struct {
+	publicKey         *rsa.PublicKey
+	expectedIssuer    string
+	expectedAudiences []string
+}
+
+// Verify verifies the token signature, issuer, audience (when configured) and the required scopes
+func (j *jwtVerifier) Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, error) {
+	if j == nil {
+		return nil, errors.NewValidation("JWT verification configuration is required")
+	}
+
+	claims, err := jwtparser.ParseVerified(ctx, token, &jwtparser.ParseOptions{
+		RequireExpiration: true,
+		AllowBearerPrefix: true,
+		RequireSubject:    true,
+		VerifySignature:   true,
+		SigningKey:        j.publicKey,
+		ExpectedIssuer:    j.expectedIssuer,
+		ExpectedAudiences: j.expectedAudiences,
+		RequiredScopes:    requiredScopes,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "JWT signature verification failed",
+			"error", err,
+			"required_scope", requiredScopes,
+		)
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "JWT signature verification successful",
+		"user_id", redaction.Redact(claims.Subject),
+		"required_scope", requiredScopes,
+	)
+	return claims, nil
+}
+
+// newJWTVerifier loads the signing key of the realm from its JWKS
+func newJWTVerifier(ctx context.Context, config Config, httpClient *httpclient.Client) (*jwtVerifier, error) {
+	jwksURL := config.realmURL() + "/protocol/openid-connect/certs"
+
+	apiRequest := httpclient.NewAPIRequest(
+		httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(jwksURL),
+		httpclient.WithDescription("fetch Keycloak JWKS"),
+	)
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if _, err := apiRequest.Call(ctx, &jwks); err != nil {
+		return nil, errors.NewUnexpected("failed to fetch JWKS", err)
+	}
+
+	for _, rawKey := range jwks.Keys {
+		var key struct {
+			Kty string `json:"kty"`
+			Use string `json:"use,omitempty"`
+			Kid string `json:"kid,omitempty"`
+			Alg string `json:"alg,omitempty"`
+		}
+		if err := json.Unmarshal(rawKey, &key); err != nil {
+			continue
+		}
+		// the realms also publish the encryption keys (RSA-OAEP), only the signing ones are relevant
+		if key.Kty != "RSA" || (key.Use != "sig" && key.Use != "") || (key.Alg != "" && key.Alg != "RS256") {
+			continue
+		}
+
+		publicKey, err := jwtparser.LoadRSAPublicKeyFromJWK(rawKey)
+		if err != nil {
+			return nil, errors.NewUnexpected("failed to load RSA public key from JWK", err)
+		}
+
+		slog.InfoContext(ctx, "JWT signature verification enabled",
+			"issuer", config.realmURL(),
+			"audience", config.Audience,
+			"key_id", key.Kid,
+		)
+
+		// the audience is not checked when not configured
+		var expectedAudiences []string
+		if config.Audience != "" {
+			expectedAudiences = []string{config.Audience}
+		}
+
+		return &jwtVerifier{
+			publicKey:         publicKey,
+			expectedIssuer:    config.realmURL(),
+			expectedAudiences: expectedAudiences,
+		}, nil
+	}
+
+	return nil, errors.NewUnexpected(fmt.Sprintf("no suitable RSA key found in the JWKS of the realm %s", config.Realm))
+}
@@ -0,0 +1,422 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+
+	"golang.org/x/oauth2"
+)
+
+// Config holds the configuration for the Keycloak Admin REST API
+type Config struct {
+	// URL is the base URL of the Keycloak server, e.g. https://keycloak.example.com
+	URL string
+	// Realm is the realm of the users
+	Realm string
+	// ClientID and ClientSecret are the credentials of the confidential client, its service
+	// account must have the manage-users and view-users roles of the realm-management client
+	ClientID     string
+	ClientSecret string
+	// Audience is the expected audience of the user tokens, not checked when empty
+	Audience string
+	// EmailSender delivers the alternate email OTPs, configured from the environment when nil
+	EmailSender port.TemplatedEmailSender
+	// VerificationCodes keeps the pending alternate email OTPs, in the memory of the replica when nil
+	VerificationCodes emaillinking.Store
+	// Clock is the time source of the OTP expirations, the system clock when nil
+	Clock clock.Clock
+}
+
+// Validate checks the configuration is complete, without calling Keycloak
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.URL) == "" || strings.TrimSpace(c.Realm) == "" {
+		return errors.NewValidation("Keycloak URL and realm are required")
+	}
+	if parsed, err := url.Parse(c.URL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return errors.NewValidation(fmt.Sprintf("invalid Keycloak URL %q, expected an absolute URL", c.URL))
+	}
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return errors.NewValidation("Keycloak client ID and secret are required")
+	}
+	return nil
+}
+
+// realmURL is the base URL of the realm endpoints, it's also the issuer of the realm tokens
+func (c Config) realmURL() string {
+	return fmt.Sprintf("%s/realms/%s", strings.TrimRight(c.URL, "/"), url.PathEscape(c.Realm))
+}
+
+// adminURL is the URL of an Admin REST API endpoint of the realm, the arguments are escaped
+func (c Config) adminURL(path string, args ...string) string {
+	escaped := make([]any, 0, len(args))
+	for _, arg := range args {
+		escaped = append(escaped, url.PathEscape(arg))
+	}
+	return fmt.Sprintf("%s/admin/realms/%s/%s", strings.TrimRight(c.URL, "/"), url.PathEscape(c.Realm), fmt.Sprintf(path, escaped...))
+}
+
+type userReaderWriter struct {
+	config           Config
+	httpClient       *httpclient.Client
+	tokenSource      oauth2.TokenSource
+	jwtVerifier      *jwtVerifier
+	emailLinkingFlow *emaillinking.Flow
+}
+
+// call calls the Admin REST API with the service account token
+func (u *userReaderWriter) call(ctx context.Context, method, url string, body, response any, description string) error {
+	token, errToken := u.tokenSource.Token()
+	if errToken != nil {
+		slog.ErrorContext(ctx, "failed to get service account token", "error", errToken)
+		return errors.NewServiceUnavailable("failed to get Keycloak service account token", errToken)
+	}
+
+	options := []httpclient.RequestOption{
+		httpclient.WithMethod(method),
+		httpclient.WithURL(url),
+		httpclient.WithToken(token.AccessToken),
+		httpclient.WithDescription(description),
+	}
+	if body != nil {
+		options = append(options, httpclient.WithBody(body))
+	}
+
+	statusCode, errCall := httpclient.NewAPIRequest(u.httpClient, options...).Call(ctx, response)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "Keycloak request failed",
+			"error", errCall,
+			"status_code", statusCode,
+			"description", description,
+		)
+		return httpclient.ErrorFromCall(statusCode, fmt.Sprintf("failed to %s", description), errCall)
+	}
+	return nil
+}
+
+// getKeycloakUser reads the user representation
+func (u *userReaderWriter) getKeycloakUser(ctx context.Context, userID string) (*KeycloakUser, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, errors.NewValidation("user_id is required to get user")
+	}
+
+	var keycloakUser KeycloakUser
+	if err := u.call(ctx, http.MethodGet, u.config.adminURL("users/%s", userID), nil, &keycloakUser, "get user"); err != nil {
+		return nil, err
+	}
+	return &keycloakUser, nil
+}
+
+// putKeycloakUser stores the user representation, the attributes are replaced as a whole
+func (u *userReaderWriter) putKeycloakUser(ctx context.Context, keycloakUser *KeycloakUser) error {
+	return u.call(ctx, http.MethodPut, u.config.adminURL("users/%s", keycloakUser.ID), keycloakUser, nil, "update user")
+}
+
+func (u *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	slog.DebugContext(ctx, "getting user", "user_id", redaction.Redact(user.UserID))
+
+	keycloakUser, err := u.getKeycloakUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var federatedIdentities []KeycloakFederatedIdentity
+	errIdentities := u.call(ctx, http.MethodGet, u.config.adminURL("users/%s/federated-identity", keycloakUser.ID), nil, &federatedIdentities, "list federated identities")
+	if errIdentities != nil {
+		return nil, errIdentities
+	}
+
+	result := keycloakUser.ToUser()
+	for _, identity := range federatedIdentities {
+		result.Identities = append(result.Identities, identity.ToIdentity())
+	}
+
+	slog.DebugContext(ctx, "user retrieved successfully", "user_id", redaction.Redact(user.UserID))
+	return result, nil
+}
+
+func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+
+	if user == nil {
+		return nil, errors.NewValidation("user is required")
+	}
+
+	// exact matches only, Keycloak searches by substring otherwise
+	query := url.Values{"exact": []string{"true"}}
+	var match func(*KeycloakUser) bool
+
+	switch criteria {
+	case constants.CriteriaTypeEmail:
+		if strings.TrimSpace(user.PrimaryEmail) == "" {
+			return nil, errors.NewValidation("email is required")
+		}
+		query.Set("email", user.PrimaryEmail)
+		match = func(candidate *KeycloakUser) bool {
+			return strings.EqualFold(candidate.Email, user.PrimaryEmail)
+		}
+	case constants.CriteriaTypeUsername:
+		if strings.TrimSpace(user.Username) == "" {
+			return nil, errors.NewValidation("username is required")
+		}
+		query.Set("username", user.Username)
+		match = func(candidate *KeycloakUser) bool {
+			return strings.EqualFold(candidate.Username, user.Username)
+		}
+	case constants.CriteriaTypeAlternateEmail:
+		// only the first alternate email is supported
+		if len(user.AlternateEmails) == 0 || strings.TrimSpace(user.AlternateEmails[0].Email) == "" {
+			return nil, errors.NewValidation("alternate email is required")
+		}
+		alternateEmail := normalizeEmail(user.AlternateEmails[0].Email)
+		query.Set("q", fmt.Sprintf("%s:%s", alternateEmailAttribute, alternateEmail))
+		match = func(candidate *KeycloakUser) bool {
+			for _, email := range candidate.Attributes[alternateEmailAttribute] {
+				if strings.EqualFold(email, alternateEmail) {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return nil, errors.NewValidation(fmt.Sprintf("invalid criteria type: %s", criteria))
+	}
+
+	slog.DebugContext(ctx, "searching user", "criteria", criteria)
+
+	var keycloakUsers []KeycloakUser
+	if err := u.call(ctx, http.MethodGet, u.config.adminURL("users")+"?"+query.Encode(), nil, &keycloakUsers, "search user"); err != nil {
+		return nil, err
+	}
+
+	for i := range keycloakUsers {
+		if match(&keycloakUsers[i]) {
+			return keycloakUsers[i].ToUser(), nil
+		}
+	}
+	return nil, errors.NewNotFound("user not found")
+}
+
+// MetadataLookup prepares the user for metadata lookup based on the input
+// Accepts JWT token, username, or user id (the sub of the realm tokens)
+func (u *userReaderWriter) MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errors.NewValidation("input is required")
+	}
+
+	slog.DebugContext(ctx, "metadata lookup", "input", redaction.Redact(input))
+
+	if cleanToken, isJWT := jwt.LooksLikeJWT(input); isJWT {
+		claims, err := u.jwtVerifier.Verify(ctx, cleanToken, requiredScopes...)
+		if err != nil {
+			return nil, err
+		}
+		return &model.User{
+			Token:  cleanToken,
+			UserID: claims.Subject,
+			Sub:    claims.Subject,
+		}, nil
+	}
+
+	// the Keycloak user ids are UUIDs, anything else is a username
+	if _, errParse := uuid.Parse(input); errParse == nil {
+		slog.DebugContext(ctx, "canonical lookup strategy", "sub", redaction.Redact(input))
+		return &model.User{UserID: input, Sub: input}, nil
+	}
+
+	slog.DebugContext(ctx, "username search strategy", "username", redaction.Redact(input))
+	return &model.User{Username: input}, nil
+}
+
+func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	claims, errVerify := u.jwtVerifier.Verify(ctx, user.Token, constants.UserUpdateMetadataRequiredScope)
+	if errVerify != nil {
+		return nil, errVerify
+	}
+
+	if user.UserMetadata == nil {
+		return nil, errors.NewValidation("user_metadata is required for update")
+	}
+	if len(user.ClearFields) > 0 {
+		return nil, errors.NewValidation("clear_fields is not supported by the identity provider")
+	}
+
+	// the representation is read first, the update replaces the attributes as a whole
+	keycloakUser, err := u.getKeycloakUser(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	keycloakUser.ApplyMetadata(user.UserMetadata)
+
+	if errPut := u.putKeycloakUser(ctx, keycloakUser); errPut != nil {
+		return nil, errPut
+	}
+
+	slog.DebugContext(ctx, "user updated successfully", "user_id", redaction.Redact(claims.Subject))
+
+	return &model.User{
+		UserID:       keycloakUser.ID,
+		Sub:          keycloakUser.ID,
+		UserMetadata: keycloakUser.ToUser().UserMetadata,
+	}, nil
+}
+
+func (u *userReaderWriter) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
+	if strings.TrimSpace(alternateEmail) == "" {
+		return errors.NewValidation("alternate email is required")
+	}
+	return u.emailLinkingFlow.SendVerification(ctx, alternateEmail)
+}
+
+func (u *userReaderWriter) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
+	if email.Email == "" || email.OTP == "" {
+		return nil, errors.NewValidation("email and OTP are required")
+	}
+	return u.emailLinkingFlow.Verify(ctx, email.Email, email.OTP)
+}
+
+// ValidateLinkRequest only accepts the identity tokens of the verified alternate emails,
+// the brokered identities are linked by Keycloak itself on login
+func (u *userReaderWriter) ValidateLinkRequest(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.LinkWith.IdentityToken == "" {
+		return errors.NewValidation("link_with identity token is required")
+	}
+	_, err := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	return err
+}
+
+func (u *userReaderWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	email, errVerifiedEmail := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	if errVerifiedEmail != nil {
+		return errVerifiedEmail
+	}
+
+	keycloakUser, err := u.getKeycloakUser(ctx, request.User.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !keycloakUser.AddAlternateEmail(email) {
+		slog.InfoContext(ctx, "email already exists in alternate email list",
+			"user_id", redaction.Redact(request.User.UserID),
+			"email", redaction.RedactEmail(email),
+		)
+		return nil
+	}
+
+	if errPut := u.putKeycloakUser(ctx, keycloakUser); errPut != nil {
+		return errPut
+	}
+
+	slog.InfoContext(ctx, "successfully linked email identity",
+		"user_id", redaction.Redact(request.User.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return nil
+}
+
+// UnlinkIdentity removes an alternate email (provider email) or a brokered identity
+func (u *userReaderWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("unlink identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+	if request.Unlink.Provider == "" {
+		return errors.NewValidation("provider is required")
+	}
+	if request.Unlink.IdentityID == "" {
+		return errors.NewValidation("identity_id is required")
+	}
+
+	slog.DebugContext(ctx, "unlinking identity from user",
+		"user_id", redaction.Redact(request.User.UserID),
+		"provider", request.Unlink.Provider,
+	)
+
+	if request.Unlink.Provider == "email" {
+		keycloakUser, err := u.getKeycloakUser(ctx, request.User.UserID)
+		if err != nil {
+			return err
+		}
+		if !keycloakUser.RemoveAlternateEmail(request.Unlink.IdentityID) {
+			return errors.NewNotFound("identity not found")
+		}
+		return u.putKeycloakUser(ctx, keycloakUser)
+	}
+
+	var federatedIdentities []KeycloakFederatedIdentity
+	errIdentities := u.call(ctx, http.MethodGet, u.config.adminURL("users/%s/federated-identity", request.User.UserID), nil, &federatedIdentities, "list federated identities")
+	if errIdentities != nil {
+		return errIdentities
+	}
+	for _, identity := range federatedIdentities {
+		if identity.IdentityProvider == request.Unlink.Provider && identity.UserID == request.Unlink.IdentityID {
+			return u.call(ctx, http.MethodDelete,
+				u.config.adminURL("users/%s/federated-identity/%s", request.User.UserID, request.Unlink.Provider),
+				nil, nil, "unlink identity",
+			)
+		}
+	}
+	return errors.NewNotFound("identity not found")
+}
+
+// NewUserReaderWriter creates a new UserReaderWriter backed by the Keycloak Admin REST API
+func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, config Config) (port.UserReaderWriter, error) {
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	httpClient := httpclient.NewClient(httpConfig)
+
+	jwtVerifier, err := newJWTVerifier(ctx, config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	emailLinkingFlow, err := newEmailLinkingFlow(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email linking flow: %w", err)
+	}
+
+	tokenClient := &http.Client{Timeout: httpConfig.Timeout, Transport: httpConfig.Transport}
+
+	return &userReaderWriter{
+		config:           config,
+		httpClient:       httpClient,
+		tokenSource:      newServiceAccountTokenSource(ctx, config, tokenClient),
+		jwtVerifier:      jwtVerifier,
+		emailLinkingFlow: emailLinkingFlow,
+	}, nil
+}
@@ -0,0 +1,534 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keycloak
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRealm      = "lfx"
+	testAdminToken = "service-account-token"
+	testUserID     = "4b8f3c4e-1f8a-4a57-9f55-2b1e2f0b8d11"
+)
+
+// fakeKeycloak serves the token, JWKS and the user endpoints of the Admin REST API used by the repository
+type fakeKeycloak struct {
+	t          *testing.T
+	server     *httptest.Server
+	privateKey *rsa.PrivateKey
+
+	mu                  sync.Mutex
+	users               map[string]*KeycloakUser
+	federatedIdentities map[string][]KeycloakFederatedIdentity
+	searches            []string
+}
+
+func newFakeKeycloak(t *testing.T) *fakeKeycloak {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fake := &fakeKeycloak{
+		t:          t,
+		privateKey: privateKey,
+		users: map[string]*KeycloakUser{
+			testUserID: {
+				ID:         testUserID,
+				Username:   "jdoe",
+				Email:      "jane@example.com",
+				FirstName:  "Jane",
+				Enabled:    true,
+				Attributes: map[string][]string{"city": {"Lisbon"}, alternateEmailAttribute: {"jane@work.example.com"}},
+			},
+		},
+		federatedIdentities: map[string][]KeycloakFederatedIdentity{
+			testUserID: {{IdentityProvider: "github", UserID: "1234", UserName: "jdoe"}},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /realms/lfx/protocol/openid-connect/token", fake.token)
+	mux.HandleFunc("GET /realms/lfx/protocol/openid-connect/certs", fake.certs)
+	mux.HandleFunc("GET /admin/realms/lfx/users", fake.admin(fake.searchUsers))
+	mux.HandleFunc("GET /admin/realms/lfx/users/{id}", fake.admin(fake.getUser))
+	mux.HandleFunc("PUT /admin/realms/lfx/users/{id}", fake.admin(fake.putUser))
+	mux.HandleFunc("GET /admin/realms/lfx/users/{id}/federated-identity", fake.admin(fake.listIdentities))
+	mux.HandleFunc("DELETE /admin/realms/lfx/users/{id}/federated-identity/{provider}", fake.admin(fake.deleteIdentity))
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeKeycloak) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+	if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_secret") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": testAdminToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+	})
+}
+
+func (f *fakeKeycloak) certs(w http.ResponseWriter, _ *http.Request) {
+	encryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(f.t, err)
+
+	keys := []any{}
+	for use, privateKey := range map[string]*rsa.PrivateKey{"enc": encryptionKey, "sig": f.privateKey} {
+		key, errKey := jwk.FromRaw(&privateKey.PublicKey)
+		require.NoError(f.t, errKey)
+		require.NoError(f.t, key.Set(jwk.KeyUsageKey, use))
+		require.NoError(f.t, key.Set(jwk.KeyIDKey, use+"-key"))
+		if use == "sig" {
+			require.NoError(f.t, key.Set(jwk.AlgorithmKey, "RS256"))
+		} else {
+			require.NoError(f.t, key.Set(jwk.AlgorithmKey, "RSA-OAEP"))
+		}
+		keys = append(keys, key)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+}
+
+// admin rejects the calls without the service account token
+func (f *fakeKeycloak) admin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testAdminToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func (f *fakeKeycloak) searchUsers(w http.ResponseWriter, r *http.Request) {
+	f.searches = append(f.searches, r.URL.RawQuery)
+
+	query := r.URL.Query()
+	result := []KeycloakUser{}
+	for _, user := range f.users {
+		switch {
+		case query.Get("email") != "":
+			// Keycloak matches by substring unless exact is set, the repository must filter
+			if strings.Contains(user.Email, query.Get("email")) {
+				result = append(result, *user)
+			}
+		case query.Get("username") != "":
+			if strings.Contains(user.Username, strings.ToLower(query.Get("username"))) {
+				result = append(result, *user)
+			}
+		case query.Get("q") != "":
+			name, value, _ := strings.Cut(query.Get("q"), ":")
+			for _, attribute := range user.Attributes[name] {
+				if attribute == value {
+					result = append(result, *user)
+					break
+				}
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeKeycloak) getUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := f.users[r.PathValue("id")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(user)
+}
+
+func (f *fakeKeycloak) putUser(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.users[r.PathValue("id")]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var user KeycloakUser
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&user))
+	f.users[r.PathValue("id")] = &user
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeKeycloak) listIdentities(w http.ResponseWriter, r *http.Request) {
+	identities := f.federatedIdentities[r.PathValue("id")]
+	if identities == nil {
+		identities = []KeycloakFederatedIdentity{}
+	}
+	_ = json.NewEncoder(w).Encode(identities)
+}
+
+func (f *fakeKeycloak) deleteIdentity(w http.ResponseWriter, r *http.Request) {
+	identities := f.federatedIdentities[r.PathValue("id")]
+	for i, identity := range identities {
+		if identity.IdentityProvider == r.PathValue("provider") {
+			f.federatedIdentities[r.PathValue("id")] = append(identities[:i:i], identities[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// userToken issues a user token of the realm
+func (f *fakeKeycloak) userToken(subject, scope string) string {
+	claims := jwt.MapClaims{
+		"sub":   subject,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": scope,
+		"iss":   f.server.URL + "/realms/" + testRealm,
+		"aud":   "lfx-profile",
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(f.privateKey)
+	require.NoError(f.t, err)
+	return token
+}
+
+// fakeEmailSender keeps the last OTP sent to each recipient
+type fakeEmailSender struct {
+	mu   sync.Mutex
+	otps map[string]string
+}
+
+func (s *fakeEmailSender) SendTemplatedEmail(_ context.Context, _, to string, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otps[to] = data.(map[string]string)["OTP"]
+	return nil
+}
+
+func (s *fakeEmailSender) otp(to string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.otps[to]
+}
+
+var _ port.TemplatedEmailSender = (*fakeEmailSender)(nil)
+
+func newTestUserReaderWriter(t *testing.T) (*userReaderWriter, *fakeKeycloak, *fakeEmailSender, *clock.Fake) {
+	fake := newFakeKeycloak(t)
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	fakeClock := clock.NewFake(time.Now())
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetries = 0
+
+	repository, err := NewUserReaderWriter(context.Background(), httpConfig, Config{
+		URL:          fake.server.URL + "/",
+		Realm:        testRealm,
+		ClientID:     "lfx-auth-service",
+		ClientSecret: "secret",
+		Audience:     "lfx-profile",
+		EmailSender:  sender,
+		Clock:        fakeClock,
+	})
+	require.NoError(t, err)
+	return repository.(*userReaderWriter), fake, sender, fakeClock
+}
+
+func TestNewUserReaderWriter_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "missing URL", config: Config{Realm: testRealm, ClientID: "id", ClientSecret: "secret"}},
+		{name: "missing realm", config: Config{URL: "http://keycloak", ClientID: "id", ClientSecret: "secret"}},
+		{name: "missing client secret", config: Config{URL: "http://keycloak", Realm: testRealm, ClientID: "id"}},
+		{name: "relative URL", config: Config{URL: "keycloak", Realm: testRealm, ClientID: "id", ClientSecret: "secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUserReaderWriter(context.Background(), httpclient.DefaultConfig(), tt.config)
+			require.Error(t, err)
+			assert.IsType(t, errors.Validation{}, err)
+		})
+	}
+}
+
+func TestUserReaderWriter_GetUser(t *testing.T) {
+	ctx := context.Background()
+	u, _, _, _ := newTestUserReaderWriter(t)
+
+	user, err := u.GetUser(ctx, &model.User{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail)
+	assert.Equal(t, "Lisbon", *user.UserMetadata.City)
+	assert.Equal(t, []model.Identity{{Provider: "github", IdentityID: "1234", Nickname: "jdoe", IsSocial: true}}, user.Identities)
+
+	_, err = u.GetUser(ctx, &model.User{UserID: "00000000-0000-0000-0000-000000000000"})
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	_, err = u.GetUser(ctx, &model.User{})
+	require.Error(t, err)
+	assert.IsType(t, errors.Validation{}, err)
+}
+
+func TestUserReaderWriter_SearchUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	tests := []struct {
+		name      string
+		user      *model.User
+		criteria  constants.CriteriaType
+		wantQuery string
+		wantErr   any
+	}{
+		{
+			name:      "by email",
+			user:      &model.User{PrimaryEmail: "jane@example.com"},
+			criteria:  constants.CriteriaTypeEmail,
+			wantQuery: "email=jane%40example.com&exact=true",
+		},
+		{
+			name:      "by username, case insensitive",
+			user:      &model.User{Username: "JDoe"},
+			criteria:  constants.CriteriaTypeUsername,
+			wantQuery: "exact=true&username=JDoe",
+		},
+		{
+			name:      "by alternate email",
+			user:      &model.User{AlternateEmails: []model.Email{{Email: "Jane@Work.example.com"}}},
+			criteria:  constants.CriteriaTypeAlternateEmail,
+			wantQuery: "exact=true&q=alternate_email%3Ajane%40work.example.com",
+		},
+		{
+			name:     "substring matches are not exact",
+			user:     &model.User{PrimaryEmail: "e@example.com"},
+			criteria: constants.CriteriaTypeEmail,
+			wantErr:  errors.NotFound{},
+		},
+		{
+			name:     "missing email",
+			user:     &model.User{},
+			criteria: constants.CriteriaTypeEmail,
+			wantErr:  errors.Validation{},
+		},
+		{
+			name:     "invalid criteria",
+			user:     &model.User{PrimaryEmail: "jane@example.com"},
+			criteria: "phone",
+			wantErr:  errors.Validation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := u.SearchUser(ctx, tt.user, tt.criteria)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, user.UserID)
+
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			assert.Equal(t, tt.wantQuery, fake.searches[len(fake.searches)-1])
+		})
+	}
+}
+
+func TestUserReaderWriter_MetadataLookup(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	token := fake.userToken(testUserID, "openid profile")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *model.User
+		wantErr  bool
+	}{
+		{
+			name:     "user token",
+			input:    "Bearer " + token,
+			expected: &model.User{Token: token, UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:     "user id",
+			input:    testUserID,
+			expected: &model.User{UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:     "username",
+			input:    "jdoe",
+			expected: &model.User{Username: "jdoe"},
+		},
+		{
+			name:    "token of another issuer",
+			input:   signedToken(t, jwt.MapClaims{"sub": testUserID, "exp": time.Now().Add(time.Hour).Unix(), "iss": "https://other"}, fake.privateKey),
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			input:   " ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := u.MetadataLookup(ctx, tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, user)
+		})
+	}
+}
+
+func signedToken(t *testing.T, claims jwt.MapClaims, key *rsa.PrivateKey) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestUserReaderWriter_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	t.Run("requires the update scope", func(t *testing.T) {
+		_, err := u.UpdateUser(ctx, &model.User{
+			Token:        fake.userToken(testUserID, "openid"),
+			UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("patches the metadata", func(t *testing.T) {
+		updated, err := u.UpdateUser(ctx, &model.User{
+			Token: fake.userToken(testUserID, "openid "+constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{
+				FamilyName: converters.StringPtr("Doe"),
+				JobTitle:   converters.StringPtr("Engineer"),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Engineer", *updated.UserMetadata.JobTitle)
+		assert.Equal(t, "Lisbon", *updated.UserMetadata.City)
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		stored := fake.users[testUserID]
+		assert.Equal(t, "Jane", stored.FirstName)
+		assert.Equal(t, "Doe", stored.LastName)
+		assert.True(t, stored.Enabled)
+		assert.Equal(t, []string{"Engineer"}, stored.Attributes["job_title"])
+		assert.Equal(t, []string{"jane@work.example.com"}, stored.Attributes[alternateEmailAttribute])
+	})
+}
+
+func TestUserReaderWriter_AlternateEmailLinking(t *testing.T) {
+	ctx := context.Background()
+	u, fake, sender, fakeClock := newTestUserReaderWriter(t)
+
+	const alternateEmail = "jane@personal.example.com"
+
+	require.NoError(t, u.SendVerificationAlternateEmail(ctx, alternateEmail))
+	otp := sender.otp(alternateEmail)
+	require.Len(t, otp, 6)
+
+	_, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: "000000" + otp})
+	require.Error(t, err, "a wrong OTP is rejected")
+
+	authResponse, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.NoError(t, err)
+	require.NotEmpty(t, authResponse.IDToken)
+
+	_, err = u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.Error(t, err, "the OTP can only be used once")
+
+	request := &model.LinkIdentity{}
+	request.User.UserID = testUserID
+	request.LinkWith.IdentityToken = authResponse.IDToken
+
+	require.NoError(t, u.ValidateLinkRequest(ctx, request))
+	require.NoError(t, u.LinkIdentity(ctx, request))
+
+	fake.mu.Lock()
+	assert.Equal(t, []string{"jane@work.example.com", alternateEmail}, fake.users[testUserID].Attributes[alternateEmailAttribute])
+	fake.mu.Unlock()
+
+	// the identity token expires
+	fakeClock.Advance(emaillinking.IdentityTokenTTL + time.Minute)
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+
+	// a token signed with another secret is rejected
+	request.LinkWith.IdentityToken = fake.userToken(testUserID, "")
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+}
+
+func TestUserReaderWriter_VerifyAlternateEmailExpiredOTP(t *testing.T) {
+	ctx := context.Background()
+	u, _, sender, fakeClock := newTestUserReaderWriter(t)
+
+	require.NoError(t, u.SendVerificationAlternateEmail(ctx, "jane@personal.example.com"))
+	fakeClock.Advance(emaillinking.CodeTTL + time.Second)
+
+	_, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: "jane@personal.example.com", OTP: sender.otp("jane@personal.example.com")})
+	require.Error(t, err)
+	assert.IsType(t, errors.Validation{}, err)
+}
+
+func TestUserReaderWriter_UnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	unlink := func(provider, identityID string) error {
+		request := &model.UnlinkIdentity{}
+		request.User.UserID = testUserID
+		request.Unlink.Provider = provider
+		request.Unlink.IdentityID = identityID
+		return u.UnlinkIdentity(ctx, request)
+	}
+
+	require.NoError(t, unlink("email", "Jane@Work.example.com"))
+	require.NoError(t, unlink("github", "1234"))
+
+	err := unlink("github", "1234")
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Empty(t, fake.users[testUserID].Attributes[alternateEmailAttribute])
+	assert.Empty(t, fake.federatedIdentities[testUserID])
+}
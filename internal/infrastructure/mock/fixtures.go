@@ -0,0 +1,195 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// defaultReloadDelay is how long the changes of the users file settle before it is loaded again,
+// an editor or a ConfigMap update writes it in several steps
+const defaultReloadDelay = 200 * time.Millisecond
+
+// WithUsersFile loads the users from the YAML file instead of the embedded users.yaml, the file is
+// loaded again when it changes
+func WithUsersFile(path string) Option {
+	return func(u *userWriter) {
+		u.usersFile = path
+	}
+}
+
+// fixtures returns the users of the users file, or of the embedded users.yaml, with the digest of
+// the document
+func (u *userWriter) fixtures(ctx context.Context) ([]*model.User, [sha256.Size]byte, error) {
+	data, source := usersYAML, "embedded users.yaml"
+	if u.usersFile != "" {
+		fileData, err := os.ReadFile(u.usersFile)
+		if err != nil {
+			return nil, [sha256.Size]byte{}, fmt.Errorf("failed to read the users file: %w", err)
+		}
+		data, source = fileData, u.usersFile
+	}
+
+	users, err := loadUsersFromYAML(ctx, data, source)
+	if err != nil {
+		return nil, [sha256.Size]byte{}, err
+	}
+	return users, sha256.Sum256(data), nil
+}
+
+// replaceUsers replaces the stored users with the fixtures, the OTPs in flight are kept
+func (u *userWriter) replaceUsers(ctx context.Context, users []*model.User, digest [sha256.Size]byte) {
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
+	clear(u.users)
+	for _, user := range users {
+		// Add by user_id, sub, username and primary email
+		u.reindex(ctx, user, nil)
+		slog.DebugContext(ctx, "mock: loaded user", "user", user)
+	}
+	u.usersDigest = digest
+
+	slog.InfoContext(ctx, "mock: initialized user store", "total_users", len(users), "total_keys", len(u.users))
+}
+
+// ResetUsers replaces the users with those of the users file, or of the embedded users.yaml
+func (u *userWriter) ResetUsers(ctx context.Context) (int, error) {
+	users, digest, err := u.fixtures(ctx)
+	if err != nil {
+		return 0, errors.NewUnexpected("failed to load the mock users", err)
+	}
+	u.replaceUsers(ctx, users, digest)
+	return len(users), nil
+}
+
+// SeedUsers adds the users, those with the user id of a stored user replace it. None is added
+// when one of them has no user id or an identifier of another user.
+func (u *userWriter) SeedUsers(ctx context.Context, users []*model.User) (int, error) {
+	seeds := make([]*model.User, 0, len(users))
+	claimed := make(map[string]string)
+	for _, user := range users {
+		if user == nil || user.UserID == "" {
+			return 0, errors.NewValidation("user_id is required for every user")
+		}
+		for _, key := range indexKeys(user) {
+			if owner, exists := claimed[key]; exists && owner != user.UserID {
+				return 0, errors.NewValidation(fmt.Sprintf("identifier %s is used by two users", key))
+			}
+			claimed[key] = user.UserID
+		}
+		seeds = append(seeds, user.Clone())
+	}
+
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
+	// the replaced users free their identifiers
+	replaced := make(map[*model.User]bool)
+	for _, seed := range seeds {
+		if existing, exists := u.users[seed.UserID]; exists && existing.UserID == seed.UserID {
+			replaced[existing] = true
+		}
+	}
+	for key, user := range claimed {
+		if owner, exists := u.users[key]; exists && !replaced[owner] && owner.UserID != user {
+			return 0, errors.NewConflict(fmt.Sprintf("identifier %s already in use", key))
+		}
+	}
+
+	for key, owner := range u.users {
+		if replaced[owner] {
+			delete(u.users, key)
+		}
+	}
+	for _, seed := range seeds {
+		u.reindex(ctx, seed, nil)
+		slog.DebugContext(ctx, "mock: seeded user", "user_id", redaction.Redact(seed.UserID))
+	}
+
+	slog.InfoContext(ctx, "mock: users seeded", "seeded", len(seeds), "replaced", len(replaced), "total_keys", len(u.users))
+	return len(seeds), nil
+}
+
+// reloadUsersFile loads the users file again when its content changed, an invalid file keeps the
+// current users
+func (u *userWriter) reloadUsersFile(ctx context.Context) {
+	data, err := os.ReadFile(u.usersFile)
+	if err != nil {
+		slog.WarnContext(ctx, "mock: failed to read the users file, keeping the current users", "error", err, "path", u.usersFile)
+		return
+	}
+
+	u.usersMutex.RLock()
+	unchanged := sha256.Sum256(data) == u.usersDigest
+	u.usersMutex.RUnlock()
+	if unchanged {
+		return
+	}
+
+	users, digest, err := u.fixtures(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "mock: invalid users file, keeping the current users", "error", err, "path", u.usersFile)
+		return
+	}
+	u.replaceUsers(ctx, users, digest)
+	slog.InfoContext(ctx, "mock: users file reloaded", "path", u.usersFile, "users", len(users))
+}
+
+// watchUsersFile loads the users file again when it changes, until the context is cancelled. The
+// directory is watched: the editors and the ConfigMap mounts replace the file instead of writing
+// it, and the events of the other files are filtered out by the digest of the content.
+func (u *userWriter) watchUsersFile(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create the users file watcher: %w", err)
+	}
+	if errAdd := watcher.Add(filepath.Dir(u.usersFile)); errAdd != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch the users file directory: %w", errAdd)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		reload := time.NewTimer(u.reloadDelay)
+		reload.Stop()
+		defer reload.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				slog.DebugContext(ctx, "mock: users file directory changed", "event", event.String())
+				reload.Reset(u.reloadDelay)
+			case errWatch, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.WarnContext(ctx, "mock: users file watcher failed", "error", errWatch)
+			case <-reload.C:
+				u.reloadUsersFile(ctx)
+			}
+		}
+	}()
+
+	slog.InfoContext(ctx, "mock: watching the users file", "path", u.usersFile)
+	return nil
+}
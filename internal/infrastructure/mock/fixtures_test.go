@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const novaUsersFile = `users:
+  - user_id: "auth0|nova004"
+    sub: "auth0|nova004"
+    username: "nova.starlight"
+    primary_email: "nova@example.com"
+`
+
+const orionUsersFile = `users:
+  - user_id: "auth0|orion005"
+    username: "orion.hunter"
+    primary_email: "orion@example.com"
+`
+
+func found(t *testing.T, writer *userWriter, key string) bool {
+	t.Helper()
+	_, err := writer.GetUser(context.Background(), &model.User{UserID: key})
+	return err == nil
+}
+
+func TestUsersFile_Reload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "users.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(novaUsersFile), 0o600))
+
+	writer := NewUserReaderWriter(ctx, WithUsersFile(path), func(u *userWriter) { u.reloadDelay = 10 * time.Millisecond }).(*userWriter)
+	assert.True(t, found(t, writer, "nova.starlight"))
+	assert.False(t, found(t, writer, "zephyr.stormwind"), "the embedded users aren't loaded")
+
+	// the file is replaced, as an editor or a ConfigMap update would
+	next := filepath.Join(filepath.Dir(path), "users.yaml.tmp")
+	require.NoError(t, os.WriteFile(next, []byte(orionUsersFile), 0o600))
+	require.NoError(t, os.Rename(next, path))
+	require.Eventually(t, func() bool { return found(t, writer, "orion.hunter") }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, found(t, writer, "nova.starlight"))
+
+	// an invalid file keeps the current users
+	require.NoError(t, os.WriteFile(path, []byte("users: ["), 0o600))
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, found(t, writer, "orion.hunter"))
+}
+
+func TestSeedUsers(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		seed        []*model.User
+		found       []string
+		notFound    []string
+		expectedErr any
+	}{
+		{
+			name:  "new user",
+			seed:  []*model.User{{UserID: "auth0|nova004", Username: "nova.starlight", PrimaryEmail: "nova@example.com"}},
+			found: []string{"auth0|nova004", "nova.starlight", "nova@example.com", "zephyr.stormwind"},
+		},
+		{
+			name:     "existing user replaced",
+			seed:     []*model.User{{UserID: "auth0|zephyr001", Username: "zephyr.skyward"}},
+			found:    []string{"auth0|zephyr001", "zephyr.skyward"},
+			notFound: []string{"zephyr.stormwind", "zephyr.stormwind@mockdomain.com"},
+		},
+		{
+			name:        "identifier of another user",
+			seed:        []*model.User{{UserID: "auth0|nova004", Username: "aurora.moonbeam"}},
+			notFound:    []string{"auth0|nova004"},
+			expectedErr: errors.Conflict{},
+		},
+		{
+			name: "identifier of two seeded users",
+			seed: []*model.User{
+				{UserID: "auth0|nova004", Username: "nova.starlight"},
+				{UserID: "auth0|orion005", Username: "nova.starlight"},
+			},
+			notFound:    []string{"auth0|nova004", "auth0|orion005"},
+			expectedErr: errors.Validation{},
+		},
+		{
+			name:        "user without user id",
+			seed:        []*model.User{{Username: "nova.starlight"}},
+			notFound:    []string{"nova.starlight"},
+			expectedErr: errors.Validation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewUserReaderWriter(ctx).(*userWriter)
+
+			seeded, err := writer.SeedUsers(ctx, tt.seed)
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, len(tt.seed), seeded)
+			}
+
+			for _, key := range tt.found {
+				assert.True(t, found(t, writer, key), "key %s", key)
+			}
+			for _, key := range tt.notFound {
+				assert.False(t, found(t, writer, key), "key %s", key)
+			}
+		})
+	}
+}
+
+func TestResetUsers(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx).(*userWriter)
+
+	_, err := writer.SeedUsers(ctx, []*model.User{{UserID: "auth0|nova004", Username: "nova.starlight"}})
+	require.NoError(t, err)
+	_, err = writer.UpdateUser(ctx, &model.User{UserID: "auth0|zephyr001", Username: "zephyr.skyward"})
+	require.NoError(t, err)
+
+	reset, err := writer.ResetUsers(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, reset)
+	assert.False(t, found(t, writer, "nova.starlight"))
+	assert.False(t, found(t, writer, "zephyr.skyward"))
+	assert.True(t, found(t, writer, "zephyr.stormwind"))
+}
@@ -0,0 +1,163 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// DefaultIndexCheckInterval is how often the lookup keys are checked against the users
+const DefaultIndexCheckInterval = time.Minute
+
+// WithIndexCheckInterval checks the lookup keys against the users periodically, like the
+// reconciliation of the KV lookup index of a real store, disabled when zero
+func WithIndexCheckInterval(interval time.Duration) Option {
+	return func(u *userWriter) {
+		u.indexCheckInterval = max(interval, 0)
+	}
+}
+
+// indexKeys returns the lookup keys of the user: the user id, the sub, the username and the
+// primary email
+func indexKeys(user *model.User) []string {
+	var keys []string
+	for _, key := range []string{user.UserID, user.Sub, user.Username, user.PrimaryEmail} {
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// claimKeys reports a conflict when a lookup key of the user is owned by another user, the
+// caller holds the lock
+func (u *userWriter) claimKeys(user, owner *model.User) error {
+	for _, key := range indexKeys(user) {
+		if existing, exists := u.users[key]; exists && existing != owner {
+			return errors.NewConflict("user identifier already in use")
+		}
+	}
+	return nil
+}
+
+// reindex moves the user from its previous lookup keys to its current ones, the caller holds
+// the lock and checked the new keys are free
+func (u *userWriter) reindex(ctx context.Context, user *model.User, previousKeys []string) {
+	keys := indexKeys(user)
+	for _, key := range previousKeys {
+		if !slices.Contains(keys, key) && u.users[key] == user {
+			delete(u.users, key)
+			slog.DebugContext(ctx, "mock: lookup key removed", "key", redaction.Redact(key))
+		}
+	}
+	for _, key := range keys {
+		u.users[key] = user
+	}
+}
+
+// checkIndex removes the lookup keys no user claims anymore and adds the missing ones, it
+// returns the number of keys fixed. A key claimed by two users is left to its current owner.
+func (u *userWriter) checkIndex(ctx context.Context) int {
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
+	users := make(map[*model.User]struct{})
+	for _, user := range u.users {
+		users[user] = struct{}{}
+	}
+
+	fixed := 0
+	for key, user := range u.users {
+		if !slices.Contains(indexKeys(user), key) {
+			delete(u.users, key)
+			fixed++
+			slog.WarnContext(ctx, "mock: stale lookup key removed", "key", redaction.Redact(key))
+		}
+	}
+	for user := range users {
+		for _, key := range indexKeys(user) {
+			owner, exists := u.users[key]
+			switch {
+			case !exists:
+				u.users[key] = user
+				fixed++
+				slog.WarnContext(ctx, "mock: missing lookup key added", "key", redaction.Redact(key))
+			case owner != user:
+				slog.WarnContext(ctx, "mock: lookup key claimed by two users", "key", redaction.Redact(key))
+			}
+		}
+	}
+
+	slog.DebugContext(ctx, "mock: lookup keys checked", "users", len(users), "keys", len(u.users), "fixed", fixed)
+	return fixed
+}
+
+// runIndexCheck checks the lookup keys periodically until the context is cancelled
+func (u *userWriter) runIndexCheck(ctx context.Context) {
+	ticker := time.NewTicker(u.indexCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.checkIndex(ctx)
+		}
+	}
+}
+
+// RebuildEmailIndex rewrites the lookup keys of the user like checkIndex does for all of them, it
+// returns the number of keys fixed
+func (u *userWriter) RebuildEmailIndex(ctx context.Context, user *model.User) (int, error) {
+	if user == nil || user.UserID == "" {
+		return 0, errors.NewValidation("user_id is required")
+	}
+
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
+	// the record is found by its user id, falling back to the keys still pointing to it
+	var stored *model.User
+	for _, candidate := range u.users {
+		if candidate.UserID == user.UserID {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return 0, errors.NewNotFound("user not found")
+	}
+
+	fixed := 0
+	keys := indexKeys(stored)
+	for key, owner := range u.users {
+		if owner == stored && !slices.Contains(keys, key) {
+			delete(u.users, key)
+			fixed++
+			slog.WarnContext(ctx, "mock: stale lookup key removed", "key", redaction.Redact(key))
+		}
+	}
+	for _, key := range keys {
+		owner, exists := u.users[key]
+		switch {
+		case !exists:
+			u.users[key] = stored
+			fixed++
+			slog.WarnContext(ctx, "mock: missing lookup key added", "key", redaction.Redact(key))
+		case owner != stored:
+			slog.WarnContext(ctx, "mock: lookup key claimed by two users", "key", redaction.Redact(key))
+		}
+	}
+
+	slog.InfoContext(ctx, "mock: lookup keys of the user rebuilt", "user_id", redaction.Redact(user.UserID), "fixed", fixed)
+	return fixed, nil
+}
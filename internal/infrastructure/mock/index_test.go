@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUser_Reindex(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		update      *model.User
+		found       []string
+		notFound    []string
+		expectedErr any
+	}{
+		{
+			name:     "username changed",
+			update:   &model.User{UserID: "auth0|zephyr001", Username: "zephyr.skyward"},
+			found:    []string{"zephyr.skyward", "auth0|zephyr001", "zephyr.stormwind@mockdomain.com"},
+			notFound: []string{"zephyr.stormwind"},
+		},
+		{
+			name:     "primary email changed, looked up by username",
+			update:   &model.User{Username: "zephyr.stormwind", PrimaryEmail: "zephyr@skyward.example.com"},
+			found:    []string{"zephyr@skyward.example.com", "zephyr.stormwind"},
+			notFound: []string{"zephyr.stormwind@mockdomain.com"},
+		},
+		{
+			name:        "username of another user",
+			update:      &model.User{UserID: "auth0|zephyr001", Username: "aurora.moonbeam"},
+			found:       []string{"zephyr.stormwind"},
+			expectedErr: errors.Conflict{},
+		},
+		{
+			name:   "new user indexed by all its identifiers",
+			update: &model.User{UserID: "auth0|nova004", Username: "nova.starlight", PrimaryEmail: "nova@example.com"},
+			found:  []string{"auth0|nova004", "nova.starlight", "nova@example.com"},
+		},
+		{
+			name:        "new user with the email of another user",
+			update:      &model.User{UserID: "auth0|nova004", PrimaryEmail: "aurora.moonbeam@fantasycorp.io"},
+			notFound:    []string{"auth0|nova004"},
+			expectedErr: errors.Conflict{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewUserReaderWriter(ctx)
+
+			_, err := writer.UpdateUser(ctx, tt.update)
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, key := range tt.found {
+				_, errGet := writer.GetUser(ctx, &model.User{UserID: key})
+				assert.NoError(t, errGet, "key %s", key)
+			}
+			for _, key := range tt.notFound {
+				_, errGet := writer.GetUser(ctx, &model.User{UserID: key})
+				assert.IsType(t, errors.NotFound{}, errGet, "key %s", key)
+			}
+		})
+	}
+}
+
+func TestCheckIndex(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx).(*userWriter)
+
+	// a stale key left behind and a missing key, as a crash between two index writes would
+	writer.usersMutex.Lock()
+	zephyr := writer.users["auth0|zephyr001"]
+	writer.users["zephyr.old"] = zephyr
+	delete(writer.users, "aurora.moonbeam")
+	writer.usersMutex.Unlock()
+
+	assert.Equal(t, 2, writer.checkIndex(ctx))
+	assert.Equal(t, 0, writer.checkIndex(ctx), "the index is consistent once checked")
+
+	_, err := writer.GetUser(ctx, &model.User{Username: "zephyr.old"})
+	assert.IsType(t, errors.NotFound{}, err)
+	aurora, err := writer.GetUser(ctx, &model.User{Username: "aurora.moonbeam"})
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|aurora002", aurora.UserID)
+}
+
+func TestRebuildEmailIndex(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx).(*userWriter)
+
+	writer.usersMutex.Lock()
+	zephyr := writer.users["auth0|zephyr001"]
+	writer.users["zephyr.old"] = zephyr
+	delete(writer.users, "zephyr.stormwind@mockdomain.com")
+	delete(writer.users, "aurora.moonbeam")
+	writer.usersMutex.Unlock()
+
+	fixed, err := writer.RebuildEmailIndex(ctx, &model.User{UserID: "auth0|zephyr001"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fixed)
+
+	_, err = writer.GetUser(ctx, &model.User{Username: "zephyr.old"})
+	assert.IsType(t, errors.NotFound{}, err)
+	_, err = writer.GetUser(ctx, &model.User{PrimaryEmail: "zephyr.stormwind@mockdomain.com"})
+	assert.NoError(t, err)
+	// only the keys of the user are rebuilt
+	_, err = writer.GetUser(ctx, &model.User{Username: "aurora.moonbeam"})
+	assert.IsType(t, errors.NotFound{}, err)
+
+	_, err = writer.RebuildEmailIndex(ctx, &model.User{UserID: "auth0|nobody"})
+	assert.IsType(t, errors.NotFound{}, err)
+}
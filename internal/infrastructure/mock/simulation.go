@@ -0,0 +1,341 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Operations of the mock provider faults can be injected in
+const (
+	OperationGetUser                        = "get_user"
+	OperationSearchUser                     = "search_user"
+	OperationUpdateUser                     = "update_user"
+	OperationMetadataLookup                 = "metadata_lookup"
+	OperationSendAlternateEmailVerification = "send_alternate_email_verification"
+	OperationVerifyAlternateEmail           = "verify_alternate_email"
+	OperationLinkIdentity                   = "link_identity"
+	OperationLinkVerifiedEmail              = "link_verified_email"
+	OperationUnlinkIdentity                 = "unlink_identity"
+	OperationUnlinkAlternateEmail           = "unlink_alternate_email"
+)
+
+// operations are all the operations faults can be injected in
+var operations = []string{
+	OperationGetUser,
+	OperationSearchUser,
+	OperationUpdateUser,
+	OperationMetadataLookup,
+	OperationSendAlternateEmailVerification,
+	OperationVerifyAlternateEmail,
+	OperationLinkIdentity,
+	OperationLinkVerifiedEmail,
+	OperationUnlinkIdentity,
+	OperationUnlinkAlternateEmail,
+}
+
+// timeoutFaultLimit bounds the wait of a timeout fault for the requests without deadline
+const timeoutFaultLimit = 30 * time.Second
+
+// operationFault is the fault of an operation with its parsed latency
+type operationFault struct {
+	model.OperationFault
+	latencyMin time.Duration
+	latencyMax time.Duration
+}
+
+// simulation mimics the response times and the failures of a real provider, so the client
+// timeouts and retries can be tuned against the mock in staging. The faults can be replaced at
+// runtime, they are guarded by mu.
+type simulation struct {
+	mu sync.RWMutex
+	// latencyMin and latencyMax bound the uniform latency of every operation
+	latencyMin time.Duration
+	latencyMax time.Duration
+	// errorRate is the share of the operations failing, between 0 and 1
+	errorRate float64
+	// operations are the faults of the operations by name, in place of the latency of all of them
+	operations map[string]operationFault
+	random     func() float64
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// WithLatency delays every operation by a duration picked uniformly between min and max,
+// min alone when max is lower
+func WithLatency(minLatency, maxLatency time.Duration) Option {
+	return func(u *userWriter) {
+		u.simulation.latencyMin = max(minLatency, 0)
+		u.simulation.latencyMax = max(maxLatency, u.simulation.latencyMin)
+	}
+}
+
+// WithErrorRate fails the given share of the operations, between 0 and 1, with a service
+// unavailable error
+func WithErrorRate(rate float64) Option {
+	return func(u *userWriter) {
+		u.simulation.errorRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithOperationFaults injects the faults in the operations, see ParseOperationFaults
+func WithOperationFaults(faults map[string]model.OperationFault) Option {
+	return func(u *userWriter) {
+		parsed, err := parseOperationFaults(faults)
+		if err != nil {
+			slog.Error("mock: invalid operation faults ignored", "error", err)
+			return
+		}
+		u.simulation.operations = parsed
+	}
+}
+
+// ParseOperationFaults parses the comma separated faults of the operations, in the form
+// operation=error[/rate], e.g. search_user=rate_limited/0.5,update_user=conflict, and their
+// comma separated latencies in milliseconds, in the form operation=latency, e.g.
+// search_user=200-800
+func ParseOperationFaults(faultsSpec, latenciesSpec string) (map[string]model.OperationFault, error) {
+	faults := make(map[string]model.OperationFault)
+	for _, entry := range strings.Split(faultsSpec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		operation, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid fault %q, expected operation=error[/rate]", entry))
+		}
+		kind, rateValue, hasRate := strings.Cut(spec, "/")
+		fault := faults[strings.TrimSpace(operation)]
+		fault.Error = model.FaultKind(strings.TrimSpace(kind))
+		if hasRate {
+			rate, err := strconv.ParseFloat(strings.TrimSpace(rateValue), 64)
+			if err != nil {
+				return nil, errors.NewValidation(fmt.Sprintf("invalid fault %q, the rate must be a number between 0 and 1", entry))
+			}
+			fault.Rate = rate
+		}
+		faults[strings.TrimSpace(operation)] = fault
+	}
+	for _, entry := range strings.Split(latenciesSpec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		operation, latency, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid operation latency %q, expected operation=latency", entry))
+		}
+		fault := faults[strings.TrimSpace(operation)]
+		fault.LatencyMs = strings.TrimSpace(latency)
+		faults[strings.TrimSpace(operation)] = fault
+	}
+
+	// the faults are checked as they would be injected
+	if _, err := parseOperationFaults(faults); err != nil {
+		return nil, err
+	}
+	return faults, nil
+}
+
+// parseOperationFaults checks the faults of the operations and parses their latency
+func parseOperationFaults(faults map[string]model.OperationFault) (map[string]operationFault, error) {
+	parsed := make(map[string]operationFault, len(faults))
+	for operation, fault := range faults {
+		if !slices.Contains(operations, operation) {
+			return nil, errors.NewValidation(fmt.Sprintf("unknown operation %q, expected one of %v", operation, operations))
+		}
+		if fault.Error != "" {
+			kind, err := model.ParseFaultKind(string(fault.Error))
+			if err != nil {
+				return nil, err
+			}
+			fault.Error = kind
+		}
+		if fault.Rate < 0 || fault.Rate > 1 {
+			return nil, errors.NewValidation(fmt.Sprintf("invalid rate %v of %s, expected a number between 0 and 1", fault.Rate, operation))
+		}
+		entry := operationFault{OperationFault: fault}
+		if fault.LatencyMs != "" {
+			minLatency, maxLatency, err := ParseLatency(fault.LatencyMs)
+			if err != nil {
+				return nil, err
+			}
+			entry.latencyMin, entry.latencyMax = minLatency, maxLatency
+		}
+		parsed[operation] = entry
+	}
+	return parsed, nil
+}
+
+// ParseLatency parses a latency in milliseconds, either fixed ("150") or a range ("80-400")
+func ParseLatency(value string) (time.Duration, time.Duration, error) {
+	lower, upper, isRange := strings.Cut(strings.TrimSpace(value), "-")
+	if !isRange {
+		upper = lower
+	}
+	minMs, errMin := strconv.Atoi(strings.TrimSpace(lower))
+	maxMs, errMax := strconv.Atoi(strings.TrimSpace(upper))
+	if errMin != nil || errMax != nil || minMs < 0 || maxMs < minMs {
+		return 0, 0, errors.NewValidation(fmt.Sprintf("invalid latency %s, expected milliseconds or a min-max range", value))
+	}
+	return time.Duration(minMs) * time.Millisecond, time.Duration(maxMs) * time.Millisecond, nil
+}
+
+// sleepContext waits for the duration, or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// formatLatency formats a latency as ParseLatency parses it, empty when zero
+func formatLatency(minLatency, maxLatency time.Duration) string {
+	switch {
+	case maxLatency == 0:
+		return ""
+	case minLatency == maxLatency:
+		return strconv.FormatInt(minLatency.Milliseconds(), 10)
+	}
+	return fmt.Sprintf("%d-%d", minLatency.Milliseconds(), maxLatency.Milliseconds())
+}
+
+// latency returns a delay picked uniformly between min and max
+func (s *simulation) latency(minLatency, maxLatency time.Duration) time.Duration {
+	if maxLatency <= minLatency {
+		return minLatency
+	}
+	return minLatency + time.Duration(s.random()*float64(maxLatency-minLatency))
+}
+
+// faults returns the faults injected
+func (s *simulation) faults() model.ProviderFaults {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	faults := model.ProviderFaults{
+		LatencyMs: formatLatency(s.latencyMin, s.latencyMax),
+		ErrorRate: s.errorRate,
+	}
+	if len(s.operations) > 0 {
+		faults.Operations = make(map[string]model.OperationFault, len(s.operations))
+		for operation, fault := range s.operations {
+			faults.Operations[operation] = fault.OperationFault
+		}
+	}
+	return faults
+}
+
+// setFaults replaces the faults injected, nothing changes when one of them is invalid
+func (s *simulation) setFaults(faults model.ProviderFaults) error {
+	var minLatency, maxLatency time.Duration
+	if faults.LatencyMs != "" {
+		var err error
+		if minLatency, maxLatency, err = ParseLatency(faults.LatencyMs); err != nil {
+			return err
+		}
+	}
+	if faults.ErrorRate < 0 || faults.ErrorRate > 1 {
+		return errors.NewValidation(fmt.Sprintf("invalid error rate %v, expected a number between 0 and 1", faults.ErrorRate))
+	}
+	operationFaults, err := parseOperationFaults(faults.Operations)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencyMin, s.latencyMax = minLatency, maxLatency
+	s.errorRate = faults.ErrorRate
+	s.operations = operationFaults
+	return nil
+}
+
+// simulate applies the latency and the failures to the operation
+func (s *simulation) simulate(ctx context.Context, operation string) error {
+	if s.random == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	minLatency, maxLatency, errorRate := s.latencyMin, s.latencyMax, s.errorRate
+	fault, hasFault := s.operations[operation]
+	s.mu.RUnlock()
+	if hasFault && fault.LatencyMs != "" {
+		minLatency, maxLatency = fault.latencyMin, fault.latencyMax
+	}
+
+	if delay := s.latency(minLatency, maxLatency); delay > 0 {
+		if err := s.sleep(ctx, delay); err != nil {
+			return errors.NewServiceUnavailable(fmt.Sprintf("mock: %s interrupted", operation), err)
+		}
+	}
+
+	if hasFault && fault.Error != "" && (fault.Rate == 0 || s.random() < fault.Rate) {
+		slog.DebugContext(ctx, "mock: injected fault", "operation", operation, "fault", fault.Error)
+		return s.inject(ctx, operation, fault.Error)
+	}
+
+	if errorRate > 0 && s.random() < errorRate {
+		slog.DebugContext(ctx, "mock: simulated provider error", "operation", operation)
+		return errors.NewServiceUnavailable(fmt.Sprintf("mock: simulated provider error on %s", operation))
+	}
+	return nil
+}
+
+// inject returns the error of the fault, a timeout waits for the request deadline first
+func (s *simulation) inject(ctx context.Context, operation string, kind model.FaultKind) error {
+	message := fmt.Sprintf("mock: injected %s fault on %s", kind, operation)
+	switch kind {
+	case model.FaultRateLimited:
+		return errors.NewTooManyRequests(message)
+	case model.FaultTimeout:
+		if err := s.sleep(ctx, timeoutFaultLimit); err != nil {
+			return errors.NewServiceUnavailable(message, err)
+		}
+		return errors.NewServiceUnavailable(message)
+	case model.FaultNotFound:
+		return errors.NewNotFound(message)
+	case model.FaultConflict:
+		return errors.NewConflict(message)
+	case model.FaultUnauthorized:
+		return errors.NewUnauthorized(message)
+	case model.FaultUnexpected:
+		return errors.NewUnexpected(message)
+	}
+	return errors.NewServiceUnavailable(message)
+}
+
+// Faults returns the faults injected in the operations of the mock
+func (u *userWriter) Faults() model.ProviderFaults {
+	return u.simulation.faults()
+}
+
+// SetFaults replaces the faults injected in the operations of the mock, those of the environment
+// included, empty faults clear them
+func (u *userWriter) SetFaults(ctx context.Context, faults model.ProviderFaults) error {
+	if err := u.simulation.setFaults(faults); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "mock: faults replaced",
+		"latency_ms", faults.LatencyMs,
+		"error_rate", faults.ErrorRate,
+		"operations", len(faults.Operations),
+	)
+	return nil
+}
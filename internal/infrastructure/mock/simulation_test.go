@@ -0,0 +1,273 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestParseLatency(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantMin time.Duration
+		wantMax time.Duration
+		wantErr bool
+	}{
+		{name: "fixed", value: "150", wantMin: 150 * time.Millisecond, wantMax: 150 * time.Millisecond},
+		{name: "range", value: "80-400", wantMin: 80 * time.Millisecond, wantMax: 400 * time.Millisecond},
+		{name: "range with spaces", value: " 80 - 400 ", wantMin: 80 * time.Millisecond, wantMax: 400 * time.Millisecond},
+		{name: "zero", value: "0"},
+		{name: "not a number", value: "fast", wantErr: true},
+		{name: "duration unit", value: "150ms", wantErr: true},
+		{name: "inverted range", value: "400-80", wantErr: true},
+		{name: "negative", value: "-10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, gotMax, err := ParseLatency(tt.value)
+			if tt.wantErr {
+				if _, ok := err.(errors.Validation); !ok {
+					t.Fatalf("ParseLatency() expected Validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLatency() unexpected error: %v", err)
+			}
+			if gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("ParseLatency() = %v, %v, want %v, %v", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+// newSimulatedUserWriter creates a mock with a fixed random value, recording the delays instead of sleeping
+func newSimulatedUserWriter(random float64, opts ...Option) (*userWriter, *[]time.Duration) {
+	var slept []time.Duration
+	writer := NewUserReaderWriter(context.Background(), opts...).(*userWriter)
+	writer.simulation.random = func() float64 { return random }
+	writer.simulation.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return writer, &slept
+}
+
+func TestUserWriter_Simulation(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{Username: "zephyr.stormwind"}
+
+	t.Run("no simulation by default", func(t *testing.T) {
+		writer, slept := newSimulatedUserWriter(0)
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error: %v", err)
+		}
+		if len(*slept) != 0 {
+			t.Errorf("GetUser() slept %v, want no latency", *slept)
+		}
+	})
+
+	t.Run("latency picked in the range", func(t *testing.T) {
+		writer, slept := newSimulatedUserWriter(0.5, WithLatency(100*time.Millisecond, 300*time.Millisecond))
+		if _, err := writer.SearchUser(ctx, user, "unknown"); err != nil {
+			t.Fatalf("SearchUser() unexpected error: %v", err)
+		}
+		// the fallback to the user lookup isn't delayed twice
+		want := []time.Duration{200 * time.Millisecond}
+		if len(*slept) != 1 || (*slept)[0] != want[0] {
+			t.Errorf("SearchUser() slept %v, want %v", *slept, want)
+		}
+	})
+
+	t.Run("fixed latency", func(t *testing.T) {
+		writer, slept := newSimulatedUserWriter(0.9, WithLatency(150*time.Millisecond, 0))
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error: %v", err)
+		}
+		if len(*slept) != 1 || (*slept)[0] != 150*time.Millisecond {
+			t.Errorf("GetUser() slept %v, want 150ms", *slept)
+		}
+	})
+
+	t.Run("operations failing at the error rate", func(t *testing.T) {
+		writer, _ := newSimulatedUserWriter(0.2, WithErrorRate(0.25))
+		_, err := writer.GetUser(ctx, user)
+		if _, ok := err.(errors.ServiceUnavailable); !ok {
+			t.Fatalf("GetUser() expected ServiceUnavailable error, got %v", err)
+		}
+
+		writer, _ = newSimulatedUserWriter(0.3, WithErrorRate(0.25))
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("latency bounded by the deadline", func(t *testing.T) {
+		writer := NewUserReaderWriter(ctx, WithLatency(time.Minute, time.Minute)).(*userWriter)
+		deadlineCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := writer.GetUser(deadlineCtx, user)
+		if _, ok := err.(errors.ServiceUnavailable); !ok {
+			t.Fatalf("GetUser() expected ServiceUnavailable error, got %v", err)
+		}
+	})
+}
+
+func TestParseOperationFaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		faults    string
+		latencies string
+		want      map[string]model.OperationFault
+		wantErr   bool
+	}{
+		{name: "none", want: map[string]model.OperationFault{}},
+		{
+			name:   "error with and without rate",
+			faults: "search_user=rate_limited/0.5, update_user=conflict",
+			want: map[string]model.OperationFault{
+				OperationSearchUser: {Error: model.FaultRateLimited, Rate: 0.5},
+				OperationUpdateUser: {Error: model.FaultConflict},
+			},
+		},
+		{
+			name:      "latency of an operation",
+			faults:    "get_user=timeout/0.1",
+			latencies: "get_user=80-400,metadata_lookup=150",
+			want: map[string]model.OperationFault{
+				OperationGetUser:        {Error: model.FaultTimeout, Rate: 0.1, LatencyMs: "80-400"},
+				OperationMetadataLookup: {LatencyMs: "150"},
+			},
+		},
+		{name: "unknown operation", faults: "delete_user=conflict", wantErr: true},
+		{name: "unknown error", faults: "get_user=teapot", wantErr: true},
+		{name: "rate above one", faults: "get_user=conflict/2", wantErr: true},
+		{name: "rate not a number", faults: "get_user=conflict/half", wantErr: true},
+		{name: "missing error", faults: "get_user", wantErr: true},
+		{name: "invalid latency", latencies: "get_user=fast", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOperationFaults(tt.faults, tt.latencies)
+			if tt.wantErr {
+				if _, ok := err.(errors.Validation); !ok {
+					t.Fatalf("ParseOperationFaults() expected Validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOperationFaults() unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseOperationFaults() = %v, want %v", got, tt.want)
+			}
+			for operation, fault := range tt.want {
+				if got[operation] != fault {
+					t.Errorf("ParseOperationFaults()[%s] = %v, want %v", operation, got[operation], fault)
+				}
+			}
+		})
+	}
+}
+
+func TestUserWriter_OperationFaults(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{Username: "zephyr.stormwind"}
+
+	t.Run("error of the operation", func(t *testing.T) {
+		writer, _ := newSimulatedUserWriter(0.2, WithOperationFaults(map[string]model.OperationFault{
+			OperationSearchUser: {Error: model.FaultRateLimited, Rate: 0.5},
+			OperationGetUser:    {Error: model.FaultConflict, Rate: 0.1},
+		}))
+		_, err := writer.SearchUser(ctx, user, "unknown")
+		if _, ok := err.(errors.TooManyRequests); !ok {
+			t.Fatalf("SearchUser() expected TooManyRequests error, got %v", err)
+		}
+		// above the rate of the operation
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("latency of the operation over the global one", func(t *testing.T) {
+		writer, slept := newSimulatedUserWriter(0.5,
+			WithLatency(100*time.Millisecond, 100*time.Millisecond),
+			WithOperationFaults(map[string]model.OperationFault{OperationGetUser: {LatencyMs: "200-400"}}),
+		)
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error: %v", err)
+		}
+		if _, err := writer.UpdateUser(ctx, &model.User{UserID: "auth0|zephyr001", Username: "zephyr.stormwind"}); err != nil {
+			t.Fatalf("UpdateUser() unexpected error: %v", err)
+		}
+		want := []time.Duration{300 * time.Millisecond, 100 * time.Millisecond}
+		if len(*slept) != 2 || (*slept)[0] != want[0] || (*slept)[1] != want[1] {
+			t.Errorf("slept %v, want %v", *slept, want)
+		}
+	})
+
+	t.Run("timeout waits for the deadline", func(t *testing.T) {
+		writer := NewUserReaderWriter(ctx, WithOperationFaults(map[string]model.OperationFault{
+			OperationGetUser: {Error: model.FaultTimeout},
+		})).(*userWriter)
+		deadlineCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := writer.GetUser(deadlineCtx, user)
+		if _, ok := err.(errors.ServiceUnavailable); !ok {
+			t.Fatalf("GetUser() expected ServiceUnavailable error, got %v", err)
+		}
+		if deadlineCtx.Err() == nil {
+			t.Errorf("GetUser() returned before the deadline")
+		}
+	})
+
+	t.Run("faults replaced at runtime", func(t *testing.T) {
+		writer, _ := newSimulatedUserWriter(0.2, WithErrorRate(0.5))
+		faults := model.ProviderFaults{
+			LatencyMs:  "50",
+			Operations: map[string]model.OperationFault{OperationGetUser: {Error: model.FaultUnauthorized}},
+		}
+		if err := writer.SetFaults(ctx, faults); err != nil {
+			t.Fatalf("SetFaults() unexpected error: %v", err)
+		}
+		got := writer.Faults()
+		if got.LatencyMs != "50" || got.ErrorRate != 0 || got.Operations[OperationGetUser] != faults.Operations[OperationGetUser] {
+			t.Errorf("Faults() = %+v, want %+v", got, faults)
+		}
+		_, err := writer.GetUser(ctx, user)
+		if _, ok := err.(errors.Unauthorized); !ok {
+			t.Fatalf("GetUser() expected Unauthorized error, got %v", err)
+		}
+		// the error rate was cleared
+		if _, err := writer.MetadataLookup(ctx, "zephyr.stormwind"); err != nil {
+			t.Fatalf("MetadataLookup() unexpected error: %v", err)
+		}
+
+		// invalid faults keep the current ones
+		err = writer.SetFaults(ctx, model.ProviderFaults{Operations: map[string]model.OperationFault{"delete_user": {}}})
+		if _, ok := err.(errors.Validation); !ok {
+			t.Fatalf("SetFaults() expected Validation error, got %v", err)
+		}
+		if writer.Faults().LatencyMs != "50" {
+			t.Errorf("Faults() changed by invalid faults")
+		}
+
+		if err := writer.SetFaults(ctx, model.ProviderFaults{}); err != nil {
+			t.Fatalf("SetFaults() unexpected error: %v", err)
+		}
+		if _, err := writer.GetUser(ctx, user); err != nil {
+			t.Fatalf("GetUser() unexpected error after clearing the faults: %v", err)
+		}
+	})
+}
@@ -4,17 +4,22 @@
 package mock
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
 	_ "embed"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/collections"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/password"
@@ -29,12 +34,38 @@ type otpEntry struct {
 }
 
 type userWriter struct {
-	// In-memory storage for mock users
+	// In-memory storage for mock users, the lookup keys of a user share the same pointer
 	users map[string]*model.User
+	// Mutex for thread-safe user operations, the users are copied out of the lock so the
+	// callers never share the stored users
+	usersMutex sync.RWMutex
 	// In-memory storage for OTPs (email -> OTP)
 	otps map[string]*otpEntry
 	// Mutex for thread-safe OTP operations
 	otpMutex sync.RWMutex
+	// clock is the time source of the OTP expirations
+	clock clock.Clock
+	// simulation mimics the latency and the failures of a real provider
+	simulation simulation
+	// indexCheckInterval is how often the lookup keys are checked against the users, disabled when zero
+	indexCheckInterval time.Duration
+	// usersFile is the YAML file the users are loaded from instead of the embedded users.yaml,
+	// usersDigest is the digest of the fixtures loaded last, guarded by usersMutex
+	usersFile   string
+	usersDigest [sha256.Size]byte
+	// reloadDelay is how long the changes of the users file settle before it is loaded again
+	reloadDelay time.Duration
+}
+
+// Option configures the mock UserReaderWriter
+type Option func(*userWriter)
+
+// WithClock sets the time source of the OTP expirations, the tests use a fake clock
+// to expire the OTPs without waiting
+func WithClock(c clock.Clock) Option {
+	return func(u *userWriter) {
+		u.clock = c
+	}
 }
 
 //go:embed users.yaml
@@ -45,11 +76,11 @@ type UserData struct {
 	Users []model.User `yaml:"users"`
 }
 
-// loadUsersFromYAML loads users from embedded YAML file
-func loadUsersFromYAML(ctx context.Context) ([]*model.User, error) {
+// loadUsersFromYAML loads the users of a YAML document, the source is logged
+func loadUsersFromYAML(ctx context.Context, data []byte, source string) ([]*model.User, error) {
 	var userData UserData
-	if err := yaml.Unmarshal(usersYAML, &userData); err != nil {
-		slog.ErrorContext(ctx, "failed to unmarshal YAML users", "error", err)
+	if err := yaml.Unmarshal(data, &userData); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal YAML users", "error", err, "source", source)
 		return nil, fmt.Errorf("failed to unmarshal YAML users: %w", err)
 	}
 
@@ -58,11 +89,48 @@ func loadUsersFromYAML(ctx context.Context) ([]*model.User, error) {
 		users[i] = &userData.Users[i]
 	}
 
-	slog.InfoContext(ctx, "loaded users from embedded YAML", "count", len(users))
+	slog.InfoContext(ctx, "loaded users from YAML", "count", len(users), "source", source)
 	return users, nil
 }
 
 func (u *userWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := u.simulation.simulate(ctx, OperationGetUser); err != nil {
+		return nil, err
+	}
+	return u.getUser(ctx, user)
+}
+
+// lookupUser returns a copy of the user stored under the key
+func (u *userWriter) lookupUser(key string) (*model.User, bool) {
+	u.usersMutex.RLock()
+	defer u.usersMutex.RUnlock()
+
+	existingUser, exists := u.users[key]
+	if !exists {
+		return nil, false
+	}
+	return existingUser.Clone(), true
+}
+
+// emailLinked reports whether the normalized email is the primary or an alternate email of a user
+func (u *userWriter) emailLinked(normalizedEmail string) bool {
+	u.usersMutex.RLock()
+	defer u.usersMutex.RUnlock()
+
+	for _, user := range u.users {
+		if strings.ToLower(user.PrimaryEmail) == normalizedEmail {
+			return true
+		}
+		for _, altEmail := range user.AlternateEmails {
+			if strings.ToLower(altEmail.Email) == normalizedEmail {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (u *userWriter) getUser(ctx context.Context, user *model.User) (*model.User, error) {
 	slog.InfoContext(ctx, "mock: getting user", "user", user)
 
 	// For mock implementation, we'll use user_id, sub, username, or primary email as key
@@ -82,7 +150,7 @@ func (u *userWriter) GetUser(ctx context.Context, user *model.User) (*model.User
 	}
 
 	// Check if user exists in mock storage
-	if existingUser, exists := u.users[key]; exists {
+	if existingUser, exists := u.lookupUser(key); exists {
 		slog.InfoContext(ctx, "mock: user found in storage", "key", key)
 		return existingUser, nil
 	}
@@ -92,17 +160,21 @@ func (u *userWriter) GetUser(ctx context.Context, user *model.User) (*model.User
 	return nil, errors.NewNotFound("user not found")
 }
 
-func (u *userWriter) SearchUser(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+func (u *userWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 	slog.InfoContext(ctx, "mock: searching user", "user", user, "criteria", criteria)
 
+	if err := u.simulation.simulate(ctx, OperationSearchUser); err != nil {
+		return nil, err
+	}
+
 	// For mock implementation, we'll search by the criteria string as a key first
-	if existingUser, exists := u.users[criteria]; exists {
+	if existingUser, exists := u.lookupUser(string(criteria)); exists {
 		slog.InfoContext(ctx, "mock: user found by criteria", "criteria", criteria)
 		return existingUser, nil
 	}
 
 	// If not found by criteria, try GetUser behavior
-	result, err := u.GetUser(ctx, user)
+	result, err := u.getUser(ctx, user)
 	if err != nil {
 		// Return a more specific search error
 		slog.InfoContext(ctx, "mock: user not found by search criteria", "criteria", criteria)
@@ -115,6 +187,10 @@ func (u *userWriter) SearchUser(ctx context.Context, user *model.User, criteria
 func (u *userWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	slog.InfoContext(ctx, "mock: updating user", "user", user)
 
+	if err := u.simulation.simulate(ctx, OperationUpdateUser); err != nil {
+		return nil, err
+	}
+
 	// For mock implementation, we'll use user_id, sub, username, or primary email as key
 	key := user.UserID
 	if key == "" {
@@ -131,97 +207,97 @@ func (u *userWriter) UpdateUser(ctx context.Context, user *model.User) (*model.U
 		return nil, fmt.Errorf("mock: user identifier (user_id, sub, username, or primary email) is required")
 	}
 
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
 	// Get existing user from storage
 	existingUser, exists := u.users[key]
 	if !exists {
-		// If user doesn't exist, create a new one with the provided data
-		u.users[key] = user
+		// If user doesn't exist, create a new one with the provided data, indexed by all its identifiers
+		created := user.Clone()
+		if errClaim := u.claimKeys(created, nil); errClaim != nil {
+			return nil, errClaim
+		}
+		u.reindex(ctx, created, nil)
 		slog.InfoContext(ctx, "mock: new user created in storage", "key", key)
-		return user, nil
+		return created.Clone(), nil
 	}
 
-	// PATCH-style update: only update fields that are provided (non-empty/non-nil)
-	updatedUser := *existingUser // Create a copy of the existing user
+	// PATCH-style update: only update fields that are provided (non-empty/non-nil). The stored
+	// user is shared by all its lookup keys, so it's updated in place and a copy is returned.
+	update := user.Clone()
+
+	// the identifiers changed must not be the lookup keys of another user
+	previousKeys := indexKeys(existingUser)
+	identifiers := &model.User{
+		UserID:       cmp.Or(update.UserID, existingUser.UserID),
+		Sub:          cmp.Or(update.Sub, existingUser.Sub),
+		Username:     cmp.Or(update.Username, existingUser.Username),
+		PrimaryEmail: cmp.Or(update.PrimaryEmail, existingUser.PrimaryEmail),
+	}
+	if errClaim := u.claimKeys(identifiers, existingUser); errClaim != nil {
+		return nil, errClaim
+	}
 
 	// Update basic fields only if they're provided (non-empty)
-	if user.Token != "" {
-		updatedUser.Token = user.Token
+	if update.Token != "" {
+		existingUser.Token = update.Token
 	}
-	if user.UserID != "" {
-		updatedUser.UserID = user.UserID
+	if update.UserID != "" {
+		existingUser.UserID = update.UserID
 	}
-	if user.Sub != "" {
-		updatedUser.Sub = user.Sub
+	if update.Sub != "" {
+		existingUser.Sub = update.Sub
 	}
-	if user.Username != "" {
-		updatedUser.Username = user.Username
+	if update.Username != "" {
+		existingUser.Username = update.Username
 	}
-	if user.PrimaryEmail != "" {
-		updatedUser.PrimaryEmail = user.PrimaryEmail
+	if update.PrimaryEmail != "" {
+		existingUser.PrimaryEmail = update.PrimaryEmail
+		existingUser.PrimaryEmailVerified = update.PrimaryEmailVerified
 	}
 
-	// Update UserMetadata only if it's provided (not nil)
-	if user.UserMetadata != nil {
-		if updatedUser.UserMetadata == nil {
-			// If existing user has no metadata, use the provided metadata
-			updatedUser.UserMetadata = user.UserMetadata
-		} else {
-			// Partial update of metadata fields - only update non-nil fields
-			if user.UserMetadata.Picture != nil {
-				updatedUser.UserMetadata.Picture = user.UserMetadata.Picture
-			}
-			if user.UserMetadata.Zoneinfo != nil {
-				updatedUser.UserMetadata.Zoneinfo = user.UserMetadata.Zoneinfo
-			}
-			if user.UserMetadata.Name != nil {
-				updatedUser.UserMetadata.Name = user.UserMetadata.Name
-			}
-			if user.UserMetadata.GivenName != nil {
-				updatedUser.UserMetadata.GivenName = user.UserMetadata.GivenName
-			}
-			if user.UserMetadata.FamilyName != nil {
-				updatedUser.UserMetadata.FamilyName = user.UserMetadata.FamilyName
-			}
-			if user.UserMetadata.JobTitle != nil {
-				updatedUser.UserMetadata.JobTitle = user.UserMetadata.JobTitle
-			}
-			if user.UserMetadata.Organization != nil {
-				updatedUser.UserMetadata.Organization = user.UserMetadata.Organization
-			}
-			if user.UserMetadata.Country != nil {
-				updatedUser.UserMetadata.Country = user.UserMetadata.Country
-			}
-			if user.UserMetadata.StateProvince != nil {
-				updatedUser.UserMetadata.StateProvince = user.UserMetadata.StateProvince
-			}
-			if user.UserMetadata.City != nil {
-				updatedUser.UserMetadata.City = user.UserMetadata.City
-			}
-			if user.UserMetadata.Address != nil {
-				updatedUser.UserMetadata.Address = user.UserMetadata.Address
-			}
-			if user.UserMetadata.PostalCode != nil {
-				updatedUser.UserMetadata.PostalCode = user.UserMetadata.PostalCode
-			}
-			if user.UserMetadata.PhoneNumber != nil {
-				updatedUser.UserMetadata.PhoneNumber = user.UserMetadata.PhoneNumber
-			}
-			if user.UserMetadata.TShirtSize != nil {
-				updatedUser.UserMetadata.TShirtSize = user.UserMetadata.TShirtSize
-			}
+	// Update UserMetadata only if it's provided (not nil), only the non-nil fields
+	if update.UserMetadata != nil {
+		if existingUser.UserMetadata == nil {
+			existingUser.UserMetadata = &model.UserMetadata{}
+		}
+		existingUser.UserMetadata.Patch(update.UserMetadata)
+	}
+
+	if len(update.ClearFields) > 0 {
+		if existingUser.UserMetadata == nil {
+			existingUser.UserMetadata = &model.UserMetadata{}
 		}
+		existingUser.UserMetadata.Clear(update.ClearFields)
 	}
 
-	// Store the updated user back to storage
-	u.users[key] = &updatedUser
+	// the old identifiers no longer find the user, the new ones do
+	u.reindex(ctx, existingUser, previousKeys)
+
 	slog.InfoContext(ctx, "mock: user updated in storage with PATCH semantics", "key", key)
 
-	return &updatedUser, nil
+	return existingUser.Clone(), nil
+}
+
+// EnrichUserMetadata patches the metadata of the existing user with the user_id
+func (u *userWriter) EnrichUserMetadata(ctx context.Context, user *model.User) (*model.User, error) {
+	if user == nil || user.UserID == "" {
+		return nil, errors.NewValidation("user_id is required to update user")
+	}
+	if _, exists := u.lookupUser(user.UserID); !exists {
+		return nil, errors.NewNotFound("user not found")
+	}
+	return u.UpdateUser(ctx, &model.User{UserID: user.UserID, UserMetadata: user.UserMetadata})
 }
 
 func (u *userWriter) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
 	slog.DebugContext(ctx, "mock: sending alternate email verification", "alternate_email", redaction.Redact(alternateEmail))
 
+	if err := u.simulation.simulate(ctx, OperationSendAlternateEmailVerification); err != nil {
+		return err
+	}
+
 	// Validate email format
 	email := &model.Email{Email: alternateEmail}
 	if !email.IsValidEmail() {
@@ -230,17 +306,8 @@ func (u *userWriter) SendVerificationAlternateEmail(ctx context.Context, alterna
 
 	// Check if email is already registered as primary or alternate email
 	normalizedEmail := strings.ToLower(strings.TrimSpace(alternateEmail))
-	for _, user := range u.users {
-		// Check primary email
-		if strings.ToLower(user.PrimaryEmail) == normalizedEmail {
-			return errors.NewValidation("alternate email already linked")
-		}
-		// Check alternate emails
-		for _, altEmail := range user.AlternateEmails {
-			if strings.ToLower(altEmail.Email) == normalizedEmail {
-				return errors.NewValidation("alternate email already linked")
-			}
-		}
+	if u.emailLinked(normalizedEmail) {
+		return errors.NewValidation("alternate email already linked")
 	}
 
 	// Generate a 6-digit OTP
@@ -253,7 +320,7 @@ func (u *userWriter) SendVerificationAlternateEmail(ctx context.Context, alterna
 	u.otpMutex.Lock()
 	u.otps[normalizedEmail] = &otpEntry{
 		otp:       otp,
-		expiresAt: time.Now().Add(5 * time.Minute),
+		expiresAt: u.clock.Now().Add(5 * time.Minute),
 	}
 	u.otpMutex.Unlock()
 
@@ -269,6 +336,10 @@ func (u *userWriter) SendVerificationAlternateEmail(ctx context.Context, alterna
 func (u *userWriter) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
 	slog.DebugContext(ctx, "mock: verifying alternate email", "email", redaction.Redact(email.Email))
 
+	if err := u.simulation.simulate(ctx, OperationVerifyAlternateEmail); err != nil {
+		return nil, err
+	}
+
 	if email.Email == "" || email.OTP == "" {
 		return nil, errors.NewValidation("email and OTP are required")
 	}
@@ -281,45 +352,15 @@ func (u *userWriter) VerifyAlternateEmail(ctx context.Context, email *model.Emai
 	normalizedEmail := strings.ToLower(strings.TrimSpace(email.Email))
 
 	// Check if email is already linked (re-check to prevent race conditions)
-	for _, user := range u.users {
-		if strings.ToLower(user.PrimaryEmail) == normalizedEmail {
-			return nil, errors.NewValidation("alternate email already linked")
-		}
-		for _, altEmail := range user.AlternateEmails {
-			if strings.ToLower(altEmail.Email) == normalizedEmail {
-				return nil, errors.NewValidation("alternate email already linked")
-			}
-		}
-	}
-
-	// Verify OTP
-	u.otpMutex.RLock()
-	entry, exists := u.otps[normalizedEmail]
-	u.otpMutex.RUnlock()
-
-	if !exists {
-		return nil, errors.NewValidation("OTP not found or expired")
+	if u.emailLinked(normalizedEmail) {
+		return nil, errors.NewValidation("alternate email already linked")
 	}
 
-	// Check if OTP is expired
-	if time.Now().After(entry.expiresAt) {
-		// Clean up expired OTP
-		u.otpMutex.Lock()
-		delete(u.otps, normalizedEmail)
-		u.otpMutex.Unlock()
-		return nil, errors.NewValidation("OTP expired")
-	}
-
-	// Verify OTP matches
-	if entry.otp != email.OTP {
-		return nil, errors.NewValidation("invalid OTP")
+	// Verify and consume the OTP under the same lock, so concurrent verifications can't both use it
+	if errOTP := u.consumeOTP(normalizedEmail, email.OTP); errOTP != nil {
+		return nil, errOTP
 	}
 
-	// OTP is valid, clean it up
-	u.otpMutex.Lock()
-	delete(u.otps, normalizedEmail)
-	u.otpMutex.Unlock()
-
 	// Generate an identity token for the verified email using email| sub prefix
 	idToken, err := jwt.GenerateSimpleTestIdentityTokenWithSubject(email.Email, fmt.Sprintf("email|%s", normalizedEmail), 1*time.Hour)
 	if err != nil {
@@ -338,6 +379,33 @@ func (u *userWriter) VerifyAlternateEmail(ctx context.Context, email *model.Emai
 	}, nil
 }
 
+// consumeOTP verifies the OTP of the email and removes it once used or expired
+func (u *userWriter) consumeOTP(normalizedEmail, otp string) error {
+	u.otpMutex.Lock()
+	defer u.otpMutex.Unlock()
+
+	entry, exists := u.otps[normalizedEmail]
+	if !exists {
+		return errors.NewValidation("OTP not found or expired")
+	}
+
+	// Check if OTP is expired
+	if u.clock.Now().After(entry.expiresAt) {
+		// Clean up expired OTP
+		delete(u.otps, normalizedEmail)
+		return errors.NewValidation("OTP expired")
+	}
+
+	// Verify OTP matches
+	if entry.otp != otp {
+		return errors.NewValidation("invalid OTP")
+	}
+
+	// OTP is valid, clean it up
+	delete(u.otps, normalizedEmail)
+	return nil
+}
+
 func (u *userWriter) ValidateLinkRequest(ctx context.Context, _ *model.LinkIdentity) error {
 	slog.DebugContext(ctx, "no validations for mock request")
 	return nil
@@ -346,6 +414,10 @@ func (u *userWriter) ValidateLinkRequest(ctx context.Context, _ *model.LinkIdent
 func (u *userWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
 	slog.DebugContext(ctx, "mock: linking identity")
 
+	if err := u.simulation.simulate(ctx, OperationLinkIdentity); err != nil {
+		return err
+	}
+
 	if request == nil {
 		return errors.NewValidation("link identity request is required")
 	}
@@ -370,9 +442,29 @@ func (u *userWriter) LinkIdentity(ctx context.Context, request *model.LinkIdenti
 	return u.linkSocialIdentity(ctx, request, sub, request.LinkWith.IdentityToken)
 }
 
+// LinkVerifiedEmail links an email verified by the service (with a backup code) to the user
+func (u *userWriter) LinkVerifiedEmail(ctx context.Context, user *model.User, email string) error {
+	slog.DebugContext(ctx, "mock: linking verified email")
+
+	if err := u.simulation.simulate(ctx, OperationLinkVerifiedEmail); err != nil {
+		return err
+	}
+
+	if user == nil || user.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	request := &model.LinkIdentity{}
+	request.User.UserID = user.UserID
+	return u.linkEmailIdentity(ctx, request, email)
+}
+
 func (u *userWriter) linkEmailIdentity(ctx context.Context, request *model.LinkIdentity, email string) error {
 	normalizedEmail := strings.ToLower(strings.TrimSpace(email))
 
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
 	user, exists := u.users[request.User.UserID]
 	if !exists {
 		return errors.NewNotFound("user not found")
@@ -424,6 +516,9 @@ func (u *userWriter) linkSocialIdentity(ctx context.Context, request *model.Link
 
 	email, _ := jwt.ExtractEmail(ctx, identityToken)
 
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
 	user, exists := u.users[request.User.UserID]
 	if !exists {
 		return errors.NewNotFound("user not found")
@@ -453,6 +548,10 @@ func (u *userWriter) linkSocialIdentity(ctx context.Context, request *model.Link
 func (u *userWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
 	slog.DebugContext(ctx, "mock: unlinking identity")
 
+	if err := u.simulation.simulate(ctx, OperationUnlinkIdentity); err != nil {
+		return err
+	}
+
 	if request == nil {
 		return errors.NewValidation("unlink identity request is required")
 	}
@@ -461,6 +560,9 @@ func (u *userWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkId
 		return errors.NewValidation("user_id is required")
 	}
 
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
 	user, exists := u.users[request.User.UserID]
 	if !exists {
 		return errors.NewNotFound("user not found")
@@ -488,9 +590,83 @@ func (u *userWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkId
 	return nil
 }
 
+func (u *userWriter) UnlinkAlternateEmail(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+	slog.DebugContext(ctx, "mock: unlinking alternate email")
+
+	if err := u.simulation.simulate(ctx, OperationUnlinkAlternateEmail); err != nil {
+		return err
+	}
+
+	if request == nil {
+		return errors.NewValidation("unlink alternate email request is required")
+	}
+
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+
+	u.usersMutex.Lock()
+	defer u.usersMutex.Unlock()
+
+	user, exists := u.users[request.User.UserID]
+	if !exists {
+		return errors.NewNotFound("user not found")
+	}
+
+	if strings.EqualFold(user.PrimaryEmail, email) {
+		return errors.NewValidation("the primary email can't be unlinked")
+	}
+
+	remaining := collections.RemoveFromSlice(user.AlternateEmails, func(e model.Email) bool {
+		return strings.EqualFold(e.Email, email)
+	})
+	if len(remaining) == len(user.AlternateEmails) {
+		return errors.NewNotFound("alternate email is not linked to the user")
+	}
+	user.AlternateEmails = remaining
+
+	slog.InfoContext(ctx, "mock: alternate email unlinked",
+		"user_id", redaction.Redact(request.User.UserID),
+		"email", redaction.Redact(email),
+	)
+
+	return nil
+}
+
+// ChangePrimaryEmail changes the primary email of the existing user, an alternate email becoming the
+// primary one is removed from the alternate emails
+func (u *userWriter) ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error) {
+	slog.DebugContext(ctx, "mock: changing primary email")
+
+	if user == nil || user.UserID == "" {
+		return nil, errors.NewValidation("user_id is required")
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	u.usersMutex.Lock()
+	existingUser, exists := u.users[user.UserID]
+	if exists {
+		existingUser.AlternateEmails = collections.RemoveFromSlice(existingUser.AlternateEmails, func(e model.Email) bool {
+			return strings.EqualFold(e.Email, email)
+		})
+	}
+	u.usersMutex.Unlock()
+	if !exists {
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	return u.UpdateUser(ctx, &model.User{UserID: user.UserID, PrimaryEmail: email, PrimaryEmailVerified: true})
+}
+
 func (u *userWriter) MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error) {
 	slog.DebugContext(ctx, "mock: metadata lookup", "input", input)
 
+	if err := u.simulation.simulate(ctx, OperationMetadataLookup); err != nil {
+		return nil, err
+	}
+
 	// Trim whitespace from input
 	input = strings.TrimSpace(input)
 	if input == "" {
@@ -508,7 +684,7 @@ func (u *userWriter) MetadataLookup(ctx context.Context, input string, requiredS
 		} else {
 			// Successfully extracted sub from JWT
 			input = sub
-			slog.InfoContext(ctx, "mock: extracted sub from JWT", "sub", sub)
+			slog.InfoContext(ctx, "mock: extracted sub from JWT", "sub", redaction.Redact(sub))
 		}
 	}
 
@@ -520,21 +696,21 @@ func (u *userWriter) MetadataLookup(ctx context.Context, input string, requiredS
 		user.UserID = input
 		user.Username = ""
 		user.PrimaryEmail = ""
-		slog.InfoContext(ctx, "mock: canonical lookup strategy", "sub", input)
+		slog.InfoContext(ctx, "mock: canonical lookup strategy", "user", user)
 	case strings.Contains(input, "@"):
 		// Input looks like an email, use for email search
 		user.PrimaryEmail = strings.ToLower(input) // Normalize email to lowercase
 		user.Sub = ""
 		user.UserID = ""
 		user.Username = ""
-		slog.InfoContext(ctx, "mock: email search strategy", "email", user.PrimaryEmail)
+		slog.InfoContext(ctx, "mock: email search strategy", "user", user)
 	default:
 		// Input doesn't contain "|" or "@", use for username search
 		user.Username = input
 		user.Sub = ""
 		user.UserID = ""
 		user.PrimaryEmail = ""
-		slog.InfoContext(ctx, "mock: username search strategy", "username", input)
+		slog.InfoContext(ctx, "mock: username search strategy", "user", user)
 	}
 
 	return user, nil
@@ -548,67 +724,48 @@ func (u *userWriter) extractSubFromJWT(ctx context.Context, tokenString string)
 		return "", err
 	}
 
-	slog.DebugContext(ctx, "mock: extracted sub from JWT", "sub", subject)
+	slog.DebugContext(ctx, "mock: extracted sub from JWT", "sub", redaction.Redact(subject))
 	return subject, nil
 }
 
 // NewUserReaderWriter creates a new mock UserReaderWriter with YAML file as the data source
-func NewUserReaderWriter(ctx context.Context) port.UserReaderWriter {
+func NewUserReaderWriter(ctx context.Context, opts ...Option) port.UserReaderWriter {
 	users := make(map[string]*model.User)
 	otps := make(map[string]*otpEntry)
+	writer := &userWriter{
+		users:       users,
+		otps:        otps,
+		clock:       clock.System,
+		reloadDelay: defaultReloadDelay,
+		simulation: simulation{
+			random: rand.Float64,
+			sleep:  sleepContext,
+		},
+	}
+	for _, opt := range opts {
+		opt(writer)
+	}
+	if writer.indexCheckInterval > 0 {
+		go writer.runIndexCheck(ctx)
+	}
 
-	// Load users from embedded YAML file
-	mockUsers, err := loadUsersFromYAML(ctx)
+	// Load users from the users file, or the embedded YAML file
+	mockUsers, digest, err := writer.fixtures(ctx)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to load users from YAML file", "error", err)
-		return &userWriter{users: users, otps: otps} // Return empty store if YAML fails
-	}
-
-	if len(mockUsers) == 0 {
-		slog.WarnContext(ctx, "no users found in YAML file")
-		return &userWriter{users: users, otps: otps} // Return empty store if no users
+	} else {
+		if len(mockUsers) == 0 {
+			slog.WarnContext(ctx, "no users found in YAML file")
+		}
+		writer.replaceUsers(ctx, mockUsers, digest)
 	}
 
-	slog.InfoContext(ctx, "successfully loaded users from YAML file", "count", len(mockUsers))
-
-	// Add users to storage with multiple keys for lookup flexibility
-	for _, user := range mockUsers {
-		// Add by user_id (primary key)
-		if user.UserID != "" {
-			users[user.UserID] = user
-		}
-		// Add by sub if different from user_id
-		if user.Sub != "" && user.Sub != user.UserID {
-			users[user.Sub] = user
-		}
-		// Add by username
-		if user.Username != "" {
-			users[user.Username] = user
+	// the users file is loaded again when it changes, an invalid file keeps the current users
+	if writer.usersFile != "" {
+		if errWatch := writer.watchUsersFile(ctx); errWatch != nil {
+			slog.ErrorContext(ctx, "failed to watch the mock users file", "error", errWatch, "path", writer.usersFile)
 		}
-		// Add by primary email
-		if user.PrimaryEmail != "" {
-			users[user.PrimaryEmail] = user
-		}
-
-		slog.InfoContext(ctx, "mock: loaded user",
-			"user_id", user.UserID,
-			"sub", user.Sub,
-			"username", user.Username,
-			"primary_email", user.PrimaryEmail,
-			"name", func() string {
-				if user.UserMetadata != nil && user.UserMetadata.Name != nil {
-					return *user.UserMetadata.Name
-				}
-				return "unknown"
-			}(),
-		)
 	}
 
-	slog.InfoContext(ctx, "mock: initialized user store", "total_users", len(mockUsers), "total_keys", len(users))
-
-	return &userWriter{
-		users:    users,
-		otps:     otps,
-		otpMutex: sync.RWMutex{},
-	}
+	return writer
 }
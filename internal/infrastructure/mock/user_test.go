@@ -5,10 +5,16 @@ package mock
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	jwtpkg "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
 )
 
@@ -552,6 +558,36 @@ func TestVerifyAlternateEmail(t *testing.T) {
 		}
 	})
 
+	t.Run("expired OTP", func(t *testing.T) {
+		fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		writer := NewUserReaderWriter(ctx, WithClock(fake))
+		testEmail := "expired-otp@example.com"
+
+		// First send verification
+		err := writer.SendVerificationAlternateEmail(ctx, testEmail)
+		if err != nil {
+			t.Fatalf("SendVerificationAlternateEmail() error = %v", err)
+		}
+
+		uw := writer.(*userWriter)
+		uw.otpMutex.RLock()
+		entry := uw.otps[testEmail]
+		uw.otpMutex.RUnlock()
+
+		// The OTP is valid for 5 minutes
+		fake.Advance(6 * time.Minute)
+
+		emailModel := &model.Email{
+			Email: testEmail,
+			OTP:   entry.otp,
+		}
+
+		_, err = writer.VerifyAlternateEmail(ctx, emailModel)
+		if err == nil {
+			t.Error("VerifyAlternateEmail() expected error for expired OTP but got none")
+		}
+	})
+
 	t.Run("OTP not found", func(t *testing.T) {
 		writer := NewUserReaderWriter(ctx)
 
@@ -621,3 +657,137 @@ func TestLinkIdentity(t *testing.T) {
 		}
 	})
 }
+
+func TestUserReaderWriter_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx)
+
+	name := "Jane Doe"
+	created, err := writer.UpdateUser(ctx, &model.User{
+		UserID:       "auth0|jane",
+		Username:     "jane",
+		UserMetadata: &model.UserMetadata{Name: &name},
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser() unexpected error: %v", err)
+	}
+	name = "changed by the caller"
+	*created.UserMetadata.Name = "changed by the caller"
+
+	found, err := writer.GetUser(ctx, &model.User{UserID: "auth0|jane"})
+	if err != nil {
+		t.Fatalf("GetUser() unexpected error: %v", err)
+	}
+	if *found.UserMetadata.Name != "Jane Doe" {
+		t.Errorf("GetUser() name = %q, the store was mutated", *found.UserMetadata.Name)
+	}
+
+	found.Username = "mutated"
+	found.UserMetadata.Name = nil
+	again, err := writer.SearchUser(ctx, &model.User{}, "auth0|jane")
+	if err != nil {
+		t.Fatalf("SearchUser() unexpected error: %v", err)
+	}
+	if again.Username != "jane" || again.UserMetadata.Name == nil || *again.UserMetadata.Name != "Jane Doe" {
+		t.Errorf("SearchUser() = %+v, the store was mutated", again)
+	}
+}
+
+// TestUserReaderWriter_Concurrency runs the handlers concurrently, run with -race to detect
+// unsynchronized accesses to the store
+func TestUserReaderWriter_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			name := fmt.Sprintf("Zephyr %d", i)
+			if _, err := writer.UpdateUser(ctx, &model.User{UserID: "auth0|zephyr001", UserMetadata: &model.UserMetadata{Name: &name}}); err != nil {
+				t.Errorf("UpdateUser() unexpected error: %v", err)
+			}
+			if _, err := writer.GetUser(ctx, &model.User{UserID: "auth0|zephyr001"}); err != nil {
+				t.Errorf("GetUser() unexpected error: %v", err)
+			}
+			if _, err := writer.SearchUser(ctx, &model.User{Username: "zephyr.stormwind"}, "zephyr.stormwind"); err != nil {
+				t.Errorf("SearchUser() unexpected error: %v", err)
+			}
+
+			email := fmt.Sprintf("zephyr.%d@example.com", i)
+			idToken, err := jwtpkg.GenerateSimpleTestIdentityTokenWithSubject(email, "email|"+email, time.Hour)
+			if err != nil {
+				t.Errorf("failed to generate identity token: %v", err)
+				return
+			}
+			link := &model.LinkIdentity{}
+			link.User.UserID = "auth0|zephyr001"
+			link.LinkWith.IdentityToken = idToken
+			if err := writer.LinkIdentity(ctx, link); err != nil {
+				t.Errorf("LinkIdentity() unexpected error: %v", err)
+			}
+			if i%2 == 0 {
+				unlink := &model.UnlinkIdentity{}
+				unlink.User.UserID = "auth0|zephyr001"
+				unlink.Unlink.Provider = "email"
+				unlink.Unlink.IdentityID = email
+				if err := writer.UnlinkIdentity(ctx, unlink); err != nil {
+					t.Errorf("UnlinkIdentity() unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	byUsername, err := writer.GetUser(ctx, &model.User{Username: "zephyr.stormwind"})
+	if err != nil {
+		t.Fatalf("GetUser() unexpected error: %v", err)
+	}
+	linked := 0
+	for _, altEmail := range byUsername.AlternateEmails {
+		if strings.HasPrefix(altEmail.Email, "zephyr.") && strings.HasSuffix(altEmail.Email, "@example.com") {
+			linked++
+		}
+	}
+	if linked != workers/2 {
+		t.Errorf("GetUser() linked alternate emails = %d, want %d", linked, workers/2)
+	}
+	if byUsername.UserMetadata == nil || byUsername.UserMetadata.Name == nil {
+		t.Error("GetUser() lost the concurrent metadata updates")
+	}
+}
+
+// TestVerifyAlternateEmail_SingleUse verifies that concurrent verifications can't use the same OTP twice
+func TestVerifyAlternateEmail_SingleUse(t *testing.T) {
+	ctx := context.Background()
+	writer := NewUserReaderWriter(ctx)
+	testEmail := "single-use@example.com"
+
+	if err := writer.SendVerificationAlternateEmail(ctx, testEmail); err != nil {
+		t.Fatalf("SendVerificationAlternateEmail() error = %v", err)
+	}
+	uw := writer.(*userWriter)
+	uw.otpMutex.RLock()
+	otp := uw.otps[testEmail].otp
+	uw.otpMutex.RUnlock()
+
+	var verified atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := writer.VerifyAlternateEmail(ctx, &model.Email{Email: testEmail, OTP: otp}); err == nil {
+				verified.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := verified.Load(); got != 1 {
+		t.Errorf("VerifyAlternateEmail() succeeded %d times, want 1", got)
+	}
+}
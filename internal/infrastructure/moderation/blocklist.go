@@ -0,0 +1,120 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+const (
+	// redactedWord replaces the blocked words
+	redactedWord = "***"
+
+	// linkReason is told to the users when a link is rejected
+	linkReason = "links are not allowed"
+)
+
+// linkPattern matches the links, the URLs with a scheme and the www. hosts, the bare domains are
+// allowed since they name organizations (e.g. Booking.com)
+var linkPattern = regexp.MustCompile(`(?i)\b[a-z][a-z0-9+.-]*://\S*|\bwww\.\S+`)
+
+// textFields are the free text fields of the metadata, the blocked words are redacted from them
+var textFields = []string{"name", "given_name", "family_name", "job_title", "organization", "state_province", "city", "address"}
+
+// linkFields are the fields in which the links are moderated
+var linkFields = map[string]bool{"name": true, "given_name": true, "family_name": true, "job_title": true, "organization": true}
+
+// Blocklist is the default metadata policy, it moderates the links of the names, the job title and
+// the organization, and redacts the blocked words of the free text fields
+type Blocklist struct {
+	linkAction   model.ModerationAction
+	blockedWords *regexp.Regexp
+}
+
+// BlocklistOption configures the blocklist
+type BlocklistOption func(*Blocklist)
+
+// WithLinkAction sets what is done with the links, they are rejected by default
+func WithLinkAction(action model.ModerationAction) BlocklistOption {
+	return func(b *Blocklist) {
+		b.linkAction = action
+	}
+}
+
+// WithBlockedWords sets the words redacted from the free text fields, matched as whole words
+// regardless of the case
+func WithBlockedWords(words ...string) BlocklistOption {
+	return func(b *Blocklist) {
+		var quoted []string
+		for _, word := range words {
+			if word = strings.TrimSpace(word); word != "" {
+				quoted = append(quoted, regexp.QuoteMeta(word))
+			}
+		}
+		if len(quoted) > 0 {
+			b.blockedWords = regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+		}
+	}
+}
+
+// Review redacts the blocked words and moderates the links, a value left empty by the redaction is
+// rejected
+func (b *Blocklist) Review(_ context.Context, metadata *model.UserMetadata) ([]model.ModerationDecision, error) {
+	values := metadata.ModeratedValues()
+
+	var decisions []model.ModerationDecision
+	for _, field := range textFields {
+		original, ok := values[field]
+		if !ok {
+			continue
+		}
+
+		value := original
+		if b.blockedWords != nil {
+			value = b.blockedWords.ReplaceAllString(value, redactedWord)
+		}
+		if linkFields[field] && linkPattern.MatchString(value) {
+			switch b.linkAction {
+			case model.ModerationActionReject:
+				decisions = append(decisions, model.ModerationDecision{Field: field, Action: model.ModerationActionReject, Reason: linkReason})
+				continue
+			case model.ModerationActionRedact:
+				value = strings.Join(strings.Fields(linkPattern.ReplaceAllString(value, "")), " ")
+			case model.ModerationActionAllow:
+			}
+		}
+
+		switch value {
+		case original:
+		case "":
+			decisions = append(decisions, model.ModerationDecision{Field: field, Action: model.ModerationActionReject, Reason: linkReason})
+		default:
+			decisions = append(decisions, model.ModerationDecision{Field: field, Action: model.ModerationActionRedact, Value: value})
+		}
+	}
+	return decisions, nil
+}
+
+// NewBlocklist creates the blocklist policy
+func NewBlocklist(opts ...BlocklistOption) *Blocklist {
+	b := &Blocklist{linkAction: model.ModerationActionReject}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// ParseAction returns the moderation action of the value
+func ParseAction(value string) (model.ModerationAction, error) {
+	switch action := model.ModerationAction(strings.ToLower(strings.TrimSpace(value))); action {
+	case model.ModerationActionAllow, model.ModerationActionRedact, model.ModerationActionReject:
+		return action, nil
+	}
+	return "", fmt.Errorf("invalid moderation action %q, expected allow, redact or reject", value)
+}
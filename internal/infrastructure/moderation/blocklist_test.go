@@ -0,0 +1,81 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+func TestBlocklist_Review(t *testing.T) {
+	ptr := func(value string) *string { return &value }
+
+	tests := []struct {
+		name     string
+		opts     []BlocklistOption
+		metadata *model.UserMetadata
+		want     []model.ModerationDecision
+	}{
+		{
+			name:     "clean values allowed",
+			opts:     []BlocklistOption{WithBlockedWords("darn")},
+			metadata: &model.UserMetadata{Name: ptr("Jane Doe"), Organization: ptr("Booking.com")},
+		},
+		{
+			name:     "links rejected by default",
+			metadata: &model.UserMetadata{Organization: ptr("ACME https://spam.example"), City: ptr("www.example.com")},
+			want:     []model.ModerationDecision{{Field: "organization", Action: model.ModerationActionReject, Reason: linkReason}},
+		},
+		{
+			name:     "links redacted",
+			opts:     []BlocklistOption{WithLinkAction(model.ModerationActionRedact)},
+			metadata: &model.UserMetadata{JobTitle: ptr("Engineer at www.spam.example today")},
+			want:     []model.ModerationDecision{{Field: "job_title", Action: model.ModerationActionRedact, Value: "Engineer at today"}},
+		},
+		{
+			name:     "value made only of a link rejected when redacted",
+			opts:     []BlocklistOption{WithLinkAction(model.ModerationActionRedact)},
+			metadata: &model.UserMetadata{Name: ptr("http://spam.example")},
+			want:     []model.ModerationDecision{{Field: "name", Action: model.ModerationActionReject, Reason: linkReason}},
+		},
+		{
+			name:     "links allowed",
+			opts:     []BlocklistOption{WithLinkAction(model.ModerationActionAllow)},
+			metadata: &model.UserMetadata{Organization: ptr("https://acme.example")},
+		},
+		{
+			name:     "blocked words redacted as whole words regardless of the case",
+			opts:     []BlocklistOption{WithBlockedWords("darn", " ", "heck")},
+			metadata: &model.UserMetadata{GivenName: ptr("Darnell"), City: ptr("DARN city"), Address: ptr("1 Heck Street")},
+			want: []model.ModerationDecision{
+				{Field: "city", Action: model.ModerationActionRedact, Value: "*** city"},
+				{Field: "address", Action: model.ModerationActionRedact, Value: "1 *** Street"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decisions, err := NewBlocklist(tt.opts...).Review(context.Background(), tt.metadata)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, decisions)
+		})
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	action, err := ParseAction(" Redact ")
+	require.NoError(t, err)
+	assert.Equal(t, model.ModerationActionRedact, action)
+
+	_, err = ParseAction("")
+	assert.Error(t, err)
+	_, err = ParseAction("block")
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package moderation reviews the metadata set by the users before it reaches the identity provider:
+// the blocklist rejects the links and redacts the blocked words of the free text fields, the NATS
+// policy delegates the review to a centralized moderation service.
+package moderation
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+)
+
+// Chain reviews the metadata with every policy in order, each policy reviews the values as sent by
+// the user and a rejection by any of them fails the update
+type Chain []port.MetadataPolicy
+
+// Review returns the decisions of every policy
+func (c Chain) Review(ctx context.Context, metadata *model.UserMetadata) ([]model.ModerationDecision, error) {
+	var decisions []model.ModerationDecision
+	for _, policy := range c {
+		reviewed, err := policy.Review(ctx, metadata)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, reviewed...)
+	}
+	return decisions, nil
+}
@@ -0,0 +1,114 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// DefaultTimeout is how long the moderation service is awaited
+const DefaultTimeout = 2 * time.Second
+
+// reviewRequest is the request sent to the moderation service
+type reviewRequest struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// reviewReply is the reply of the moderation service, the fields without a decision are allowed
+type reviewReply struct {
+	Decisions []model.ModerationDecision `json:"decisions"`
+	Error     string                     `json:"error,omitempty"`
+}
+
+// NATSPolicy delegates the review of the metadata to the moderation service answering on the subject
+type NATSPolicy struct {
+	requester port.Requester
+	subject   string
+	timeout   time.Duration
+	failOpen  bool
+}
+
+// NATSOption configures the NATS policy
+type NATSOption func(*NATSPolicy)
+
+// WithTimeout sets how long the moderation service is awaited
+func WithTimeout(timeout time.Duration) NATSOption {
+	return func(p *NATSPolicy) {
+		p.timeout = timeout
+	}
+}
+
+// WithFailOpen allows the updates while the moderation service is unavailable, they fail by default
+func WithFailOpen(failOpen bool) NATSOption {
+	return func(p *NATSPolicy) {
+		p.failOpen = failOpen
+	}
+}
+
+// Review sends the set values to the moderation service and returns its decisions
+func (p *NATSPolicy) Review(ctx context.Context, metadata *model.UserMetadata) ([]model.ModerationDecision, error) {
+	values := metadata.ModeratedValues()
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	decisions, err := p.request(ctx, values)
+	if err != nil {
+		if p.failOpen {
+			slog.WarnContext(ctx, "metadata moderation unavailable, the update is allowed", "error", err, "subject", p.subject)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decisions, nil
+}
+
+func (p *NATSPolicy) request(ctx context.Context, values map[string]string) ([]model.ModerationDecision, error) {
+	payload, errMarshal := json.Marshal(reviewRequest{Fields: values})
+	if errMarshal != nil {
+		return nil, errors.NewUnexpected("failed to encode the moderation request", errMarshal)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	data, err := p.requester.Request(ctx, p.subject, payload)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable("metadata moderation unavailable", err)
+	}
+
+	var reply reviewReply
+	if err := json.Unmarshal(data, &reply); err != nil {
+		return nil, errors.NewServiceUnavailable("invalid metadata moderation reply", err)
+	}
+	if reply.Error != "" {
+		return nil, errors.NewServiceUnavailable("metadata moderation failed", fmt.Errorf("%s", reply.Error))
+	}
+	for _, decision := range reply.Decisions {
+		if _, err := ParseAction(string(decision.Action)); err != nil {
+			return nil, errors.NewServiceUnavailable("invalid metadata moderation reply", err)
+		}
+	}
+	return reply.Decisions, nil
+}
+
+// NewNATSPolicy creates the policy requesting the moderation service on the subject
+func NewNATSPolicy(requester port.Requester, subject string, opts ...NATSOption) *NATSPolicy {
+	p := &NATSPolicy{
+		requester: requester,
+		subject:   subject,
+		timeout:   DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
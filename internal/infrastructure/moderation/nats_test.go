@@ -0,0 +1,104 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeRequester answers the requests with the reply, or fails with the error
+type fakeRequester struct {
+	reply    string
+	err      error
+	subject  string
+	request  reviewRequest
+	deadline time.Time
+	calls    int
+}
+
+func (f *fakeRequester) Request(ctx context.Context, subject string, data []byte) ([]byte, error) {
+	f.calls++
+	f.subject = subject
+	f.deadline, _ = ctx.Deadline()
+	if err := json.Unmarshal(data, &f.request); err != nil {
+		return nil, err
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(f.reply), nil
+}
+
+func TestNATSPolicy_Review(t *testing.T) {
+	name := "Jane Doe"
+	metadata := &model.UserMetadata{Name: &name}
+
+	t.Run("returns the decisions of the moderation service", func(t *testing.T) {
+		requester := &fakeRequester{reply: `{"decisions":[{"field":"name","action":"redact","value":"Jane"}]}`}
+		policy := NewNATSPolicy(requester, "lfx.moderation.review", WithTimeout(time.Second))
+
+		decisions, err := policy.Review(context.Background(), metadata)
+		require.NoError(t, err)
+		assert.Equal(t, []model.ModerationDecision{{Field: "name", Action: model.ModerationActionRedact, Value: "Jane"}}, decisions)
+		assert.Equal(t, "lfx.moderation.review", requester.subject)
+		assert.Equal(t, map[string]string{"name": "Jane Doe"}, requester.request.Fields)
+		assert.WithinDuration(t, time.Now().Add(time.Second), requester.deadline, 500*time.Millisecond)
+	})
+
+	t.Run("nothing to review", func(t *testing.T) {
+		requester := &fakeRequester{}
+		decisions, err := NewNATSPolicy(requester, "lfx.moderation.review").Review(context.Background(), &model.UserMetadata{})
+		require.NoError(t, err)
+		assert.Empty(t, decisions)
+		assert.Zero(t, requester.calls)
+	})
+
+	failures := []struct {
+		name      string
+		requester *fakeRequester
+	}{
+		{name: "service unavailable", requester: &fakeRequester{err: errors.New("no responders available")}},
+		{name: "invalid reply", requester: &fakeRequester{reply: `not json`}},
+		{name: "reply error", requester: &fakeRequester{reply: `{"error":"model overloaded"}`}},
+		{name: "invalid action", requester: &fakeRequester{reply: `{"decisions":[{"field":"name","action":"block"}]}`}},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name+" fails closed", func(t *testing.T) {
+			_, err := NewNATSPolicy(tt.requester, "lfx.moderation.review").Review(context.Background(), metadata)
+			var unavailable errs.ServiceUnavailable
+			assert.ErrorAs(t, err, &unavailable)
+		})
+		t.Run(tt.name+" fails open", func(t *testing.T) {
+			decisions, err := NewNATSPolicy(tt.requester, "lfx.moderation.review", WithFailOpen(true)).Review(context.Background(), metadata)
+			require.NoError(t, err)
+			assert.Empty(t, decisions)
+		})
+	}
+}
+
+func TestChain_Review(t *testing.T) {
+	link := "https://spam.example"
+	metadata := &model.UserMetadata{Organization: &link}
+	chain := Chain{
+		NewBlocklist(),
+		NewNATSPolicy(&fakeRequester{reply: `{"decisions":[{"field":"organization","action":"allow"}]}`}, "lfx.moderation.review"),
+	}
+
+	decisions, err := chain.Review(context.Background(), metadata)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ModerationDecision{
+		{Field: "organization", Action: model.ModerationActionReject, Reason: linkReason},
+		{Field: "organization", Action: model.ModerationActionAllow},
+	}, decisions)
+}
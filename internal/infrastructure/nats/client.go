@@ -7,6 +7,8 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NATSClient wraps the NATS connection and provides access control operations
@@ -23,6 +26,37 @@ type NATSClient struct {
 	config  Config
 	kvStore map[string]jetstream.KeyValue
 	timeout time.Duration
+
+	// subscriptions are drained on shutdown
+	subscriptions []*nats.Subscription
+	subMu         sync.Mutex
+
+	// schemas validate the published events, optional
+	schemas port.EventSchemaRegistry
+
+	// pools handle the requests by priority, optional: each subscription handles its requests
+	// one by one otherwise
+	pools *workerPools
+}
+
+// Option configures the NATS client
+type Option func(*NATSClient)
+
+// WithEventSchemas validates the published events against their schemas, an invalid event isn't published
+func WithEventSchemas(schemas port.EventSchemaRegistry) Option {
+	return func(c *NATSClient) {
+		c.schemas = schemas
+	}
+}
+
+// WithWorkerPools handles the requests of the subscriptions on a pool of workers per priority,
+// the batch subjects on the batch pool and every other subject on the interactive pool
+func WithWorkerPools(sizes WorkerPoolSizes, batchSubjects []string) Option {
+	return func(c *NATSClient) {
+		if sizes.Enabled() {
+			c.pools = newWorkerPools(sizes, batchSubjects)
+		}
+	}
 }
 
 // NATSClientInterface defines the interface for NATS operations
@@ -40,6 +74,54 @@ func (c *NATSClient) Close() error {
 	return nil
 }
 
+// track keeps the subscription to drain it on shutdown
+func (c *NATSClient) track(sub *nats.Subscription, err error) (*nats.Subscription, error) {
+	if err != nil {
+		return nil, err
+	}
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	c.subscriptions = append(c.subscriptions, sub)
+	return sub, nil
+}
+
+// DrainSubscriptions stops the subscriptions from receiving new messages and waits, up to the
+// context deadline, for the messages already received to be handled. The connection stays
+// open so the replies and the last writes of the shutdown can still go through.
+func (c *NATSClient) DrainSubscriptions(ctx context.Context) error {
+	c.subMu.Lock()
+	subscriptions := c.subscriptions
+	c.subscriptions = nil
+	c.subMu.Unlock()
+
+	closed := make([]<-chan nats.SubStatus, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if !sub.IsValid() {
+			continue
+		}
+		statuses := sub.StatusChanged(nats.SubscriptionClosed)
+		if err := sub.Drain(); err != nil {
+			slog.WarnContext(ctx, "failed to drain NATS subscription", "error", err, "subject", sub.Subject)
+			continue
+		}
+		closed = append(closed, statuses)
+	}
+
+	for _, statuses := range closed {
+		select {
+		case <-statuses:
+		case <-ctx.Done():
+			return errors.NewUnexpected("NATS subscriptions drain timed out", ctx.Err())
+		}
+	}
+
+	// the requests received are still queued on the worker pools
+	if c.pools != nil {
+		return c.pools.close(ctx)
+	}
+	return nil
+}
+
 // IsReady checks if the NATS client is ready
 func (c *NATSClient) IsReady(ctx context.Context) error {
 	if c.conn == nil {
@@ -78,6 +160,18 @@ func (c *NATSClient) KeyValueStore(ctx context.Context, bucketName string) error
 	return nil
 }
 
+// JetStream returns a JetStream client using the current connection
+func (c *NATSClient) JetStream() (jetstream.JetStream, error) {
+	if c.conn == nil {
+		return nil, errors.NewServiceUnavailable("NATS client is not initialized or not connected")
+	}
+	js, err := jetstream.New(c.conn)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable("failed to create NATS JetStream client", err)
+	}
+	return js, nil
+}
+
 // GetKVStore returns the KV store for a given bucket name
 func (c *NATSClient) GetKVStore(bucketName string) (jetstream.KeyValue, bool) {
 	if c.kvStore == nil {
@@ -87,6 +181,42 @@ func (c *NATSClient) GetKVStore(bucketName string) (jetstream.KeyValue, bool) {
 	return kvStore, exists
 }
 
+// Publish publishes a fire-and-forget event on the given subject
+func (c *NATSClient) Publish(ctx context.Context, subject string, data []byte) error {
+	if err := c.IsReady(ctx); err != nil {
+		return err
+	}
+	if c.schemas != nil {
+		if err := c.schemas.ValidateEvent(subject, data); err != nil {
+			slog.ErrorContext(ctx, "event rejected, it doesn't match the schema of the subject",
+				"subject", subject,
+				"error", err,
+			)
+			return err
+		}
+	}
+	msg := &nats.Msg{Subject: subject, Data: data}
+	injectTraceContext(ctx, msg)
+	if err := c.conn.PublishMsg(msg); err != nil {
+		return errors.NewServiceUnavailable("failed to publish NATS message", err)
+	}
+	return nil
+}
+
+// Request sends a request on the given subject and waits for the reply until the context is done
+func (c *NATSClient) Request(ctx context.Context, subject string, data []byte) ([]byte, error) {
+	if err := c.IsReady(ctx); err != nil {
+		return nil, err
+	}
+	msg := &nats.Msg{Subject: subject, Data: data}
+	injectTraceContext(ctx, msg)
+	reply, err := c.conn.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		return nil, errors.NewServiceUnavailable("failed to request "+subject, err)
+	}
+	return reply.Data, nil
+}
+
 // SubscribeWithTransportMessenger subscribes to a subject with proper TransportMessenger handling
 func (c *NATSClient) SubscribeWithTransportMessenger(ctx context.Context, subject string, queueName string, handler func(context.Context, port.TransportMessenger)) (*nats.Subscription, error) {
 
@@ -94,9 +224,13 @@ func (c *NATSClient) SubscribeWithTransportMessenger(ctx context.Context, subjec
 		return nil, err
 	}
 
-	return c.conn.QueueSubscribe(subject, queueName, func(msg *nats.Msg) {
+	handle := func(msg *nats.Msg) {
 		transportMsg := NewTransportMessenger(msg)
 
+		// the request is served as part of the trace of the requester
+		ctx, span := startConsumerSpan(ctx, msg, trace.SpanKindServer)
+		defer span.End()
+
 		defer func() {
 			if r := recover(); r != nil {
 				slog.ErrorContext(ctx, "panic in NATS handler",
@@ -108,11 +242,70 @@ func (c *NATSClient) SubscribeWithTransportMessenger(ctx context.Context, subjec
 		}()
 
 		handler(ctx, transportMsg)
+	}
+	if c.pools == nil {
+		return c.track(c.conn.QueueSubscribe(subject, queueName, handle))
+	}
+
+	slog.DebugContext(ctx, "NATS subject handled by worker pool", "subject", subject, "priority", c.pools.priority(subject))
+	return c.track(c.conn.QueueSubscribe(subject, queueName, func(msg *nats.Msg) {
+		c.pools.submit(subject, func() { handle(msg) })
+	}))
+}
+
+// Subscribe subscribes to the events of a subject, without queue group: every replica
+// receives all the events
+func (c *NATSClient) Subscribe(ctx context.Context, subject string, handler func(context.Context, []byte)) (*nats.Subscription, error) {
+
+	if err := c.IsReady(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.conn.Subscribe(subject, func(msg *nats.Msg) {
+		ctx, span := startConsumerSpan(ctx, msg, trace.SpanKindConsumer)
+		defer span.End()
+
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx, "panic in NATS event handler",
+					"subject", subject,
+					"panic", r,
+				)
+			}
+		}()
+
+		handler(ctx, msg.Data)
 	})
 }
 
+// QueueSubscribe subscribes to the events of a subject in a queue group: each event is
+// received by only one replica. The subscription is drained on shutdown.
+func (c *NATSClient) QueueSubscribe(ctx context.Context, subject, queueName string, handler func(context.Context, []byte)) (*nats.Subscription, error) {
+
+	if err := c.IsReady(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.track(c.conn.QueueSubscribe(subject, queueName, func(msg *nats.Msg) {
+		ctx, span := startConsumerSpan(ctx, msg, trace.SpanKindConsumer)
+		defer span.End()
+
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx, "panic in NATS event handler",
+					"subject", subject,
+					"queue", queueName,
+					"panic", r,
+				)
+			}
+		}()
+
+		handler(ctx, msg.Data)
+	}))
+}
+
 // NewClient creates a new NATS client with the given configuration
-func NewClient(ctx context.Context, config Config) (*NATSClient, error) {
+func NewClient(ctx context.Context, config Config, options ...Option) (*NATSClient, error) {
 	slog.InfoContext(ctx, "creating NATS client",
 		"url", config.URL,
 		"timeout", config.Timeout,
@@ -158,6 +351,18 @@ func NewClient(ctx context.Context, config Config) (*NATSClient, error) {
 		config:  config,
 		timeout: config.Timeout,
 	}
+	for _, option := range options {
+		option(client)
+	}
+
+	if migrations, _ := strconv.ParseBool(os.Getenv(constants.StorageMigrationsEnvKey)); migrations {
+		applied, errMigrate := client.Migrate(ctx)
+		if errMigrate != nil {
+			slog.ErrorContext(ctx, "failed to apply the storage migrations", "error", errMigrate)
+			return nil, errors.NewServiceUnavailable("failed to apply the storage migrations", errMigrate)
+		}
+		slog.InfoContext(ctx, "storage migrations up to date", "applied", applied)
+	}
 
 	var buckets []string
 	// Check if Authelia is enabled by checking the environment variable directly
@@ -165,6 +370,9 @@ func NewClient(ctx context.Context, config Config) (*NATSClient, error) {
 		buckets = append(buckets, constants.KVBucketNameAutheliaUsers)
 		buckets = append(buckets, constants.KVBucketNameAutheliaEmailOTP)
 	}
+	if distributedLocks, _ := strconv.ParseBool(os.Getenv(constants.DistributedLocksEnvKey)); distributedLocks {
+		buckets = append(buckets, constants.KVBucketNameLocks)
+	}
 
 	for _, bucketName := range buckets {
 		if err := client.KeyValueStore(ctx, bucketName); err != nil {
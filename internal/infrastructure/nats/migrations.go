@@ -0,0 +1,231 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/lock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/migrate"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// migrationsVersionKey is the key of the migrations bucket recording the version applied
+	migrationsVersionKey = "version"
+
+	// migrationsLockName is the lock serializing the migrations of the replicas
+	migrationsLockName = "migrations"
+
+	// migrationsLockTimeout bounds the wait for the migrations of another replica
+	migrationsLockTimeout = 2 * time.Minute
+)
+
+// storageProvisioner is the subset of jetstream.JetStream creating the buckets and the streams
+type storageProvisioner interface {
+	CreateKeyValue(ctx context.Context, cfg jetstream.KeyValueConfig) (jetstream.KeyValue, error)
+	CreateStream(ctx context.Context, cfg jetstream.StreamConfig) (jetstream.Stream, error)
+}
+
+// storageMigrations are the migrations provisioning the KV buckets and the streams of the service,
+// with the configuration of the chart defaults. Existing buckets and streams are left as they are,
+// so the deployments provisioned by the chart are not changed.
+func storageMigrations(js storageProvisioner) []migrate.Migration {
+	return []migrate.Migration{
+		{
+			Version: 1,
+			Name:    "create the usage, locks, user cache and callers buckets",
+			Up: func(ctx context.Context) error {
+				return createKeyValues(ctx, js,
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameUsage,
+						History:      1,
+						Storage:      jetstream.FileStorage,
+						MaxValueSize: 64,
+						MaxBytes:     10 << 20,
+						TTL:          90 * 24 * time.Hour,
+					},
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameLocks,
+						History:      1,
+						Storage:      jetstream.FileStorage,
+						MaxValueSize: 1024,
+						MaxBytes:     10 << 20,
+					},
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameUserCache,
+						History:      1,
+						Storage:      jetstream.MemoryStorage,
+						MaxValueSize: 64 << 10,
+						MaxBytes:     100 << 20,
+						TTL:          time.Hour,
+					},
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameCallers,
+						History:      5,
+						Storage:      jetstream.FileStorage,
+						MaxValueSize: 4096,
+						MaxBytes:     1 << 20,
+					},
+				)
+			},
+		},
+		{
+			Version: 2,
+			Name:    "create the profiles stream",
+			Up: func(ctx context.Context) error {
+				return createStream(ctx, js, jetstream.StreamConfig{
+					Name:     constants.StreamNameProfiles,
+					Subjects: []string{constants.ProfilesSubject + ".>"},
+					// only the latest document of every user is kept
+					MaxMsgsPerSubject: 1,
+					Storage:           jetstream.FileStorage,
+					MaxBytes:          512 << 20,
+					Compression:       jetstream.S2Compression,
+				})
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create the authelia buckets and users events stream",
+			Up: func(ctx context.Context) error {
+				errBuckets := createKeyValues(ctx, js,
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameAutheliaUsers,
+						History:      5,
+						Storage:      jetstream.FileStorage,
+						MaxValueSize: 1 << 20,
+						MaxBytes:     10 << 20,
+						Compression:  true,
+					},
+					jetstream.KeyValueConfig{
+						Bucket:       constants.KVBucketNameAutheliaEmailOTP,
+						History:      1,
+						Storage:      jetstream.FileStorage,
+						MaxValueSize: 1024,
+						MaxBytes:     512 << 10,
+						Compression:  true,
+						TTL:          5 * time.Minute,
+					},
+				)
+				if errBuckets != nil {
+					return errBuckets
+				}
+				return createStream(ctx, js, jetstream.StreamConfig{
+					Name:        constants.StreamNameAutheliaUserEvents,
+					Subjects:    []string{constants.AutheliaUserEventsSubject + ".>"},
+					Storage:     jetstream.FileStorage,
+					MaxBytes:    100 << 20,
+					Compression: jetstream.S2Compression,
+				})
+			},
+		},
+		{
+			Version: 4,
+			Name:    "create the email linking codes bucket",
+			Up: func(ctx context.Context) error {
+				return createKeyValues(ctx, js, jetstream.KeyValueConfig{
+					Bucket:       constants.KVBucketNameEmailLinkingCodes,
+					History:      1,
+					Storage:      jetstream.FileStorage,
+					MaxValueSize: 1024,
+					MaxBytes:     10 << 20,
+					TTL:          5 * time.Minute,
+				})
+			},
+		},
+	}
+}
+
+// createKeyValues creates the KV buckets which don't exist yet
+func createKeyValues(ctx context.Context, js storageProvisioner, configs ...jetstream.KeyValueConfig) error {
+	for _, cfg := range configs {
+		_, err := js.CreateKeyValue(ctx, cfg)
+		if err != nil && !errors.Is(err, jetstream.ErrBucketExists) {
+			return errs.NewUnexpected(fmt.Sprintf("failed to create KV bucket %s", cfg.Bucket), err)
+		}
+	}
+	return nil
+}
+
+// createStream creates the stream when it doesn't exist yet
+func createStream(ctx context.Context, js storageProvisioner, cfg jetstream.StreamConfig) error {
+	_, err := js.CreateStream(ctx, cfg)
+	if err != nil && !errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+		return errs.NewUnexpected(fmt.Sprintf("failed to create stream %s", cfg.Name), err)
+	}
+	return nil
+}
+
+// kvVersionStore records the version of the migrations in the migrations bucket
+type kvVersionStore struct {
+	kv kvBucket
+}
+
+// Version returns the version of the last migration applied, zero when none was
+func (s *kvVersionStore) Version(ctx context.Context) (int, error) {
+	entry, err := s.kv.Get(ctx, migrationsVersionKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errs.NewUnexpected("failed to get the migrations version", err)
+	}
+	version, errParse := strconv.Atoi(string(entry.Value()))
+	if errParse != nil {
+		return 0, errs.NewUnexpected("invalid migrations version", errParse)
+	}
+	return version, nil
+}
+
+// SetVersion records the version of the last migration applied
+func (s *kvVersionStore) SetVersion(ctx context.Context, version int) error {
+	if _, err := s.kv.Put(ctx, migrationsVersionKey, []byte(strconv.Itoa(version))); err != nil {
+		return errs.NewUnexpected("failed to record the migrations version", err)
+	}
+	return nil
+}
+
+// Migrate applies the pending storage migrations, one replica at a time, and returns how many
+// were applied. The migrations bucket keeping the version and the lock is created first.
+func (c *NATSClient) Migrate(ctx context.Context) (int, error) {
+	js, err := c.JetStream()
+	if err != nil {
+		return 0, err
+	}
+
+	kv, errBucket := js.CreateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:  constants.KVBucketNameMigrations,
+		History: 1,
+		Storage: jetstream.FileStorage,
+	})
+	if errors.Is(errBucket, jetstream.ErrBucketExists) {
+		kv, errBucket = js.KeyValue(ctx, constants.KVBucketNameMigrations)
+	}
+	if errBucket != nil {
+		return 0, errs.NewUnexpected("failed to initialize the migrations KV bucket", errBucket)
+	}
+
+	locker := lock.NewLocker(kv)
+	runner := migrate.NewRunner(&kvVersionStore{kv: kv}, storageMigrations(js),
+		migrate.WithLock(func(ctx context.Context) (context.Context, func(), error) {
+			acquireCtx, cancel := context.WithTimeout(ctx, migrationsLockTimeout)
+			defer cancel()
+			lk, errLock := locker.Acquire(acquireCtx, migrationsLockName)
+			if errLock != nil {
+				return nil, nil, errLock
+			}
+			heldCtx, release := lk.Hold(ctx)
+			return heldCtx, release, nil
+		}),
+	)
+	return runner.Run(ctx)
+}
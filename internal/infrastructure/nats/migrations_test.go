@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/migrate"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// fakeProvisioner records the buckets and the streams created, the existing ones are rejected
+// like JetStream does
+type fakeProvisioner struct {
+	existing map[string]bool
+	created  []string
+}
+
+func (p *fakeProvisioner) CreateKeyValue(ctx context.Context, cfg jetstream.KeyValueConfig) (jetstream.KeyValue, error) {
+	if p.existing[cfg.Bucket] {
+		return nil, jetstream.ErrBucketExists
+	}
+	p.created = append(p.created, cfg.Bucket)
+	return nil, nil
+}
+
+func (p *fakeProvisioner) CreateStream(ctx context.Context, cfg jetstream.StreamConfig) (jetstream.Stream, error) {
+	if p.existing[cfg.Name] {
+		return nil, jetstream.ErrStreamNameAlreadyInUse
+	}
+	p.created = append(p.created, cfg.Name)
+	return nil, nil
+}
+
+func TestStorageMigrations(t *testing.T) {
+	ctx := context.Background()
+
+	provisioner := &fakeProvisioner{existing: map[string]bool{
+		constants.KVBucketNameUsage:  true,
+		constants.StreamNameProfiles: true,
+	}}
+	store := &kvVersionStore{kv: &fakeBucket{}}
+
+	applied, err := migrate.NewRunner(store, storageMigrations(provisioner)).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if applied != 4 {
+		t.Errorf("Run() applied %d migrations, want 4", applied)
+	}
+
+	want := []string{
+		constants.KVBucketNameLocks,
+		constants.KVBucketNameUserCache,
+		constants.KVBucketNameCallers,
+		constants.KVBucketNameAutheliaUsers,
+		constants.KVBucketNameAutheliaEmailOTP,
+		constants.StreamNameAutheliaUserEvents,
+		constants.KVBucketNameEmailLinkingCodes,
+	}
+	if len(provisioner.created) != len(want) {
+		t.Fatalf("created %v, want %v", provisioner.created, want)
+	}
+	for i := range want {
+		if provisioner.created[i] != want[i] {
+			t.Errorf("created %v, want %v", provisioner.created, want)
+		}
+	}
+
+	version, errVersion := store.Version(ctx)
+	if errVersion != nil || version != 4 {
+		t.Errorf("Version() = %d, %v, want 4", version, errVersion)
+	}
+
+	// the next run has nothing to apply
+	provisioner.created = nil
+	applied, err = migrate.NewRunner(store, storageMigrations(provisioner)).Run(ctx)
+	if err != nil || applied != 0 || len(provisioner.created) != 0 {
+		t.Errorf("second Run() = %d, %v, created %v, want nothing applied", applied, err, provisioner.created)
+	}
+}
+
+func TestKVVersionStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("zero without a version", func(t *testing.T) {
+		store := &kvVersionStore{kv: &fakeBucket{}}
+		version, err := store.Version(ctx)
+		if err != nil || version != 0 {
+			t.Errorf("Version() = %d, %v, want 0", version, err)
+		}
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		store := &kvVersionStore{kv: &fakeBucket{data: map[string][]byte{migrationsVersionKey: []byte("v2")}}}
+		if _, err := store.Version(ctx); err == nil {
+			t.Error("Version() expected an error")
+		}
+	})
+
+	t.Run("record failure", func(t *testing.T) {
+		store := &kvVersionStore{kv: &fakeBucket{putErr: errors.New("no responders")}}
+		if err := store.SetVersion(ctx, 1); err == nil {
+			t.Error("SetVersion() expected an error")
+		}
+	})
+}
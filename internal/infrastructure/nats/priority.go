@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Priority is the tier of the worker pool handling the requests of a subject
+type Priority string
+
+const (
+	// PriorityInteractive handles the requests of the users waiting for the reply
+	PriorityInteractive Priority = "interactive"
+
+	// PriorityBatch handles the requests of the bulk jobs, like the reindexes of the search
+	// indexers and the reports
+	PriorityBatch Priority = "batch"
+)
+
+// DefaultBatchSubjects are the subjects handled by the batch workers unless configured
+var DefaultBatchSubjects = []string{
+	constants.UserMetadataBulkReadSubject,
+	constants.UserMetadataEnrichSubject,
+	constants.UserEmailHashMembershipSubject,
+	constants.UsageReportSubject,
+	constants.ContractReportSubject,
+}
+
+// WorkerPoolSizes are the number of workers of each tier, the pools are disabled when zero
+type WorkerPoolSizes struct {
+	Interactive int
+	Batch       int
+}
+
+// Enabled reports whether the requests are handled by the worker pools
+func (s WorkerPoolSizes) Enabled() bool {
+	return s.Interactive > 0 && s.Batch > 0
+}
+
+// ParseWorkerPools parses the number of workers of the tiers, of the form: interactive=32,batch=4.
+// Both tiers are required, an empty value disables the pools.
+func ParseWorkerPools(spec string) (WorkerPoolSizes, error) {
+	var sizes WorkerPoolSizes
+	if strings.TrimSpace(spec) == "" {
+		return sizes, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return WorkerPoolSizes{}, fmt.Errorf("invalid worker pool entry %q", entry)
+		}
+		workers, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || workers <= 0 {
+			return WorkerPoolSizes{}, fmt.Errorf("invalid number of workers in %q", entry)
+		}
+		switch Priority(strings.TrimSpace(name)) {
+		case PriorityInteractive:
+			sizes.Interactive = workers
+		case PriorityBatch:
+			sizes.Batch = workers
+		default:
+			return WorkerPoolSizes{}, fmt.Errorf("unknown worker pool %q, expected %s or %s", name, PriorityInteractive, PriorityBatch)
+		}
+	}
+
+	if !sizes.Enabled() {
+		return WorkerPoolSizes{}, fmt.Errorf("both the %s and the %s worker pools are required", PriorityInteractive, PriorityBatch)
+	}
+	return sizes, nil
+}
+
+// workerPools handle the requests of each subject on the pool of its tier, so a flood of batch
+// requests queues behind the batch workers and never delays the interactive ones. The queues are
+// as long as the pools, a full queue blocks the subscription of the subject, which buffers the
+// next requests in the NATS client.
+type workerPools struct {
+	queues  map[Priority]chan func()
+	batch   map[string]struct{}
+	workers sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// priority returns the tier of the subject
+func (p *workerPools) priority(subject string) Priority {
+	if _, ok := p.batch[subject]; ok {
+		return PriorityBatch
+	}
+	return PriorityInteractive
+}
+
+// submit queues the job on the pool of the subject, the job runs inline once the pools are closed
+func (p *workerPools) submit(subject string, job func()) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		job()
+		return
+	}
+	p.queues[p.priority(subject)] <- job
+}
+
+// close stops the workers once the queued jobs are done, waiting up to the context deadline
+func (p *workerPools) close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.NewUnexpected("NATS worker pools drain timed out", ctx.Err())
+	}
+}
+
+// newWorkerPools starts the workers of the tiers
+func newWorkerPools(sizes WorkerPoolSizes, batchSubjects []string) *workerPools {
+	p := &workerPools{
+		queues: map[Priority]chan func(){
+			PriorityInteractive: make(chan func(), sizes.Interactive),
+			PriorityBatch:       make(chan func(), sizes.Batch),
+		},
+		batch: make(map[string]struct{}, len(batchSubjects)),
+	}
+	for _, subject := range batchSubjects {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			p.batch[subject] = struct{}{}
+		}
+	}
+
+	workers := map[Priority]int{PriorityInteractive: sizes.Interactive, PriorityBatch: sizes.Batch}
+	for priority, count := range workers {
+		queue := p.queues[priority]
+		for range count {
+			p.workers.Add(1)
+			go func() {
+				defer p.workers.Done()
+				for job := range queue {
+					job()
+				}
+			}()
+		}
+	}
+	return p
+}
@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+func TestParseWorkerPools(t *testing.T) {
+	sizes, err := ParseWorkerPools(" interactive=32, batch=4 ")
+	require.NoError(t, err)
+	assert.Equal(t, WorkerPoolSizes{Interactive: 32, Batch: 4}, sizes)
+	assert.True(t, sizes.Enabled())
+
+	sizes, err = ParseWorkerPools("")
+	require.NoError(t, err)
+	assert.False(t, sizes.Enabled())
+
+	for _, spec := range []string{"interactive=32", "batch=4", "interactive=0,batch=4", "interactive=x,batch=4", "interactive=32,bulk=4", "interactive"} {
+		_, err := ParseWorkerPools(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestWorkerPools_BatchFloodDoesNotStarveInteractive(t *testing.T) {
+	pools := newWorkerPools(WorkerPoolSizes{Interactive: 2, Batch: 1}, DefaultBatchSubjects)
+	assert.Equal(t, PriorityBatch, pools.priority(constants.UserMetadataBulkReadSubject))
+	assert.Equal(t, PriorityInteractive, pools.priority(constants.UserMetadataReadSubject))
+
+	// the batch worker is busy and its queue is full, the flood blocks its subscription
+	release := make(chan struct{})
+	var batchDone atomic.Int32
+	var flood sync.WaitGroup
+	flood.Add(1)
+	go func() {
+		defer flood.Done()
+		for range 5 {
+			pools.submit(constants.UserMetadataBulkReadSubject, func() {
+				<-release
+				batchDone.Add(1)
+			})
+		}
+	}()
+
+	interactive := make(chan struct{})
+	pools.submit(constants.UserMetadataReadSubject, func() { close(interactive) })
+	select {
+	case <-interactive:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the interactive request waited for the batch requests")
+	}
+	assert.Zero(t, batchDone.Load())
+
+	close(release)
+	flood.Wait()
+	require.NoError(t, pools.close(context.Background()))
+	assert.Equal(t, int32(5), batchDone.Load(), "the queued requests are handled before the pools close")
+
+	ran := false
+	pools.submit(constants.UserMetadataReadSubject, func() { ran = true })
+	assert.True(t, ran, "the requests received after the close run inline")
+}
+
+func TestWorkerPools_CloseTimeout(t *testing.T) {
+	pools := newWorkerPools(WorkerPoolSizes{Interactive: 1, Batch: 1}, nil)
+	release := make(chan struct{})
+	defer close(release)
+	pools.submit(constants.UserMetadataReadSubject, func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, pools.close(ctx))
+}
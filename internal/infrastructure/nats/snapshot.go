@@ -0,0 +1,152 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/archive"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// snapshotVersion is the version of the snapshot document layout
+const snapshotVersion = 1
+
+// Snapshot is the content of a KV snapshot archive
+type Snapshot struct {
+	Version   int                        `json:"version"`
+	CreatedAt time.Time                  `json:"created_at"`
+	Buckets   map[string][]SnapshotEntry `json:"buckets"`
+}
+
+// SnapshotEntry is a single key of a KV bucket
+type SnapshotEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// kvBucket is the subset of jetstream.KeyValue used to export and import snapshots
+type kvBucket interface {
+	Keys(ctx context.Context, opts ...jetstream.WatchOpt) ([]string, error)
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Put(ctx context.Context, key string, value []byte) (uint64, error)
+}
+
+func exportBuckets(ctx context.Context, buckets map[string]kvBucket, now time.Time) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: now.UTC(),
+		Buckets:   make(map[string][]SnapshotEntry, len(buckets)),
+	}
+
+	for name, bucket := range buckets {
+		keys, err := bucket.Keys(ctx)
+		if err != nil && !errors.Is(err, jetstream.ErrNoKeysFound) {
+			return nil, errs.NewUnexpected("failed to list keys of bucket "+name, err)
+		}
+		sort.Strings(keys)
+
+		entries := make([]SnapshotEntry, 0, len(keys))
+		for _, key := range keys {
+			entry, err := bucket.Get(ctx, key)
+			if err != nil {
+				// the key may have expired or been deleted while exporting
+				if errors.Is(err, jetstream.ErrKeyNotFound) {
+					continue
+				}
+				return nil, errs.NewUnexpected("failed to get key of bucket "+name, err)
+			}
+			entries = append(entries, SnapshotEntry{Key: key, Value: entry.Value()})
+		}
+		snapshot.Buckets[name] = entries
+	}
+	return snapshot, nil
+}
+
+func importBuckets(ctx context.Context, snapshot *Snapshot, buckets map[string]kvBucket) (int, error) {
+	if snapshot.Version != snapshotVersion {
+		return 0, errs.NewValidation("unsupported snapshot version")
+	}
+
+	restored := 0
+	for name, bucket := range buckets {
+		entries, ok := snapshot.Buckets[name]
+		if !ok {
+			slog.WarnContext(ctx, "bucket not found in snapshot, skipping", "bucket", name)
+			continue
+		}
+		for _, entry := range entries {
+			if _, err := bucket.Put(ctx, entry.Key, entry.Value); err != nil {
+				return restored, errs.NewUnexpected("failed to restore key of bucket "+name, err)
+			}
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+func (c *NATSClient) snapshotBuckets(ctx context.Context, names []string) (map[string]kvBucket, error) {
+	buckets := make(map[string]kvBucket, len(names))
+	for _, name := range names {
+		kv, ok := c.GetKVStore(name)
+		if !ok {
+			if err := c.KeyValueStore(ctx, name); err != nil {
+				return nil, errs.NewServiceUnavailable("failed to open KV bucket "+name, err)
+			}
+			kv, _ = c.GetKVStore(name)
+		}
+		buckets[name] = kv
+	}
+	return buckets, nil
+}
+
+// ExportSnapshot writes an encrypted archive of the given KV buckets to w
+func (c *NATSClient) ExportSnapshot(ctx context.Context, w io.Writer, passphrase []byte, names ...string) (*Snapshot, error) {
+	buckets, err := c.snapshotBuckets(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := exportBuckets(ctx, buckets, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to marshal snapshot", err)
+	}
+	if err := archive.Seal(w, passphrase, data); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ImportSnapshot restores the given KV buckets from an encrypted archive read from r,
+// keys present in the archive overwrite the current ones, other keys are left as they are
+func (c *NATSClient) ImportSnapshot(ctx context.Context, r io.Reader, passphrase []byte, names ...string) (int, error) {
+	data, err := archive.Open(r, passphrase)
+	if err != nil {
+		return 0, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return 0, errs.NewValidation("failed to unmarshal snapshot", err)
+	}
+
+	buckets, err := c.snapshotBuckets(ctx, names)
+	if err != nil {
+		return 0, err
+	}
+	return importBuckets(ctx, &snapshot, buckets)
+}
@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value []byte
+}
+
+func (e fakeEntry) Value() []byte { return e.value }
+
+// fakeBucket is an in-memory kvBucket for testing
+type fakeBucket struct {
+	data   map[string][]byte
+	putErr error
+}
+
+func (b *fakeBucket) Keys(ctx context.Context, opts ...jetstream.WatchOpt) ([]string, error) {
+	if len(b.data) == 0 {
+		return nil, jetstream.ErrNoKeysFound
+	}
+	keys := make([]string, 0, len(b.data))
+	for key := range b.data {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (b *fakeBucket) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	value, ok := b.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return fakeEntry{value: value}, nil
+}
+
+func (b *fakeBucket) Put(ctx context.Context, key string, value []byte) (uint64, error) {
+	if b.putErr != nil {
+		return 0, b.putErr
+	}
+	if b.data == nil {
+		b.data = make(map[string][]byte)
+	}
+	b.data[key] = value
+	return uint64(len(b.data)), nil
+}
+
+func TestExportImportBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	source := map[string]kvBucket{
+		"users": &fakeBucket{data: map[string][]byte{
+			"jane":                  []byte(`{"username":"jane"}`),
+			"lookup/users/email/ab": []byte("jane"),
+		}},
+		"otp": &fakeBucket{},
+	}
+
+	snapshot, err := exportBuckets(ctx, source, now)
+	if err != nil {
+		t.Fatalf("exportBuckets() unexpected error: %v", err)
+	}
+	if !snapshot.CreatedAt.Equal(now) || snapshot.Version != snapshotVersion {
+		t.Errorf("exportBuckets() header = %v/%d", snapshot.CreatedAt, snapshot.Version)
+	}
+	if got := len(snapshot.Buckets["users"]); got != 2 {
+		t.Fatalf("exported %d users keys, want 2", got)
+	}
+	if snapshot.Buckets["users"][0].Key != "jane" {
+		t.Errorf("exported keys should be sorted, got %q first", snapshot.Buckets["users"][0].Key)
+	}
+	if _, ok := snapshot.Buckets["otp"]; !ok {
+		t.Error("empty buckets should be part of the snapshot")
+	}
+
+	tests := []struct {
+		name     string
+		snapshot *Snapshot
+		target   map[string]kvBucket
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "restore all",
+			snapshot: snapshot,
+			target:   map[string]kvBucket{"users": &fakeBucket{}, "otp": &fakeBucket{}},
+			want:     2,
+		},
+		{
+			name:     "bucket missing from the snapshot is skipped",
+			snapshot: snapshot,
+			target:   map[string]kvBucket{"cache": &fakeBucket{}},
+		},
+		{
+			name:     "unsupported version",
+			snapshot: &Snapshot{Version: 99},
+			target:   map[string]kvBucket{"users": &fakeBucket{}},
+			wantErr:  true,
+		},
+		{
+			name:     "write failure",
+			snapshot: snapshot,
+			target:   map[string]kvBucket{"users": &fakeBucket{putErr: errors.New("kv down")}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importBuckets(ctx, tt.snapshot, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("importBuckets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("importBuckets() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// headerCarrier carries the trace context in the NATS message headers
+type headerCarrier nats.Header
+
+// Get returns the first value of the header
+func (h headerCarrier) Get(key string) string {
+	return nats.Header(h).Get(key)
+}
+
+// Set sets the header, replacing any existing value
+func (h headerCarrier) Set(key, value string) {
+	nats.Header(h).Set(key, value)
+}
+
+// Keys lists the header names
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+// startConsumerSpan continues the trace of the sender, carried in the message headers, and starts
+// the span of the processing of the message; the subscription context doesn't belong to any trace
+func startConsumerSpan(ctx context.Context, msg *nats.Msg, kind trace.SpanKind) (context.Context, trace.Span) {
+	if msg.Header != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Header))
+	}
+
+	return otel.Tracer(constants.ServiceName).Start(ctx, msg.Subject+" process",
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.operation.type", "process"),
+			attribute.String("messaging.destination.name", msg.Subject),
+		),
+	)
+}
+
+// injectTraceContext adds the trace context of ctx to the headers of the message
+func injectTraceContext(ctx context.Context, msg *nats.Msg) {
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Header))
+}
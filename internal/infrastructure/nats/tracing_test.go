@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	// the requester injects its trace context in the headers
+	requesterCtx, requesterSpan := otel.Tracer("requester").Start(context.Background(), "update user")
+	msg := &nats.Msg{Subject: "lfx.auth-service.user_metadata.update", Header: nats.Header{"X-Caller-Service": []string{"lfx-v2-ui"}}}
+	injectTraceContext(requesterCtx, msg)
+	requesterSpan.End()
+
+	assert.NotEmpty(t, msg.Header.Get("traceparent"))
+	assert.Equal(t, "lfx-v2-ui", msg.Header.Get("X-Caller-Service"), "existing headers are kept")
+
+	// the responder continues the trace
+	_, span := startConsumerSpan(context.Background(), msg, trace.SpanKindServer)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	consumer := spans[1]
+	assert.Equal(t, "lfx.auth-service.user_metadata.update process", consumer.Name())
+	assert.Equal(t, trace.SpanKindServer, consumer.SpanKind())
+	assert.Equal(t, requesterSpan.SpanContext().TraceID(), consumer.SpanContext().TraceID())
+	assert.Equal(t, requesterSpan.SpanContext().SpanID(), consumer.Parent().SpanID())
+	assert.True(t, consumer.Parent().IsRemote())
+}
+
+func TestStartConsumerSpan_WithoutHeaders(t *testing.T) {
+	ctx, span := startConsumerSpan(context.Background(), &nats.Msg{Subject: "lfx.auth-service.user_emails.read"}, trace.SpanKindServer)
+	defer span.End()
+	assert.NotNil(t, ctx)
+}
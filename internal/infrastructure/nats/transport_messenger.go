@@ -23,6 +23,14 @@ func (n *natsTransportMessenger) Data() []byte {
 	return n.msg.Data
 }
 
+// Header returns the first value of the NATS message header for the given key
+func (n *natsTransportMessenger) Header(key string) string {
+	if n.msg.Header == nil {
+		return ""
+	}
+	return n.msg.Header.Get(key)
+}
+
 // Respond sends a response to the NATS message
 func (n *natsTransportMessenger) Respond(data []byte) error {
 	return n.msg.Respond(data)
@@ -0,0 +1,136 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+const (
+	s3Service      = "s3"
+	defaultRegion  = "us-east-1"
+	gcsEndpoint    = "https://storage.googleapis.com"
+	gcsRegion      = "auto"
+	requestTimeout = 30 * time.Second
+
+	// cacheControl is set on the stored objects, their keys change with their content
+	cacheControl = "public, max-age=31536000, immutable"
+)
+
+// bucketStorage stores the objects in a S3 bucket, or a GCS bucket through its XML API which
+// accepts the requests signed with AWS Signature Version 4 and a HMAC key
+type bucketStorage struct {
+	bucket      string
+	region      string
+	endpoint    string
+	pathStyle   bool
+	baseURL     string
+	credentials sigv4.Credentials
+	httpClient  *http.Client
+}
+
+// objectURL returns the URL of the object in the bucket
+func (s *bucketStorage) objectURL(key string) string {
+	escaped := (&url.URL{Path: key}).EscapedPath()
+	if s.pathStyle {
+		return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, escaped)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, escaped)
+}
+
+// PutObject uploads the object to the bucket
+func (s *bucketStorage) PutObject(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return "", errors.NewUnexpected("failed to build the object storage request", err)
+	}
+	sum := sha256.Sum256(data)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Cache-Control", cacheControl)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+
+	if errSign := sigv4.Sign(req, data, s.credentials, s3Service, s.region, time.Now()); errSign != nil {
+		return "", errors.NewUnexpected("failed to sign the object storage request", errSign)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to upload the object", "error", err, "bucket", s.bucket)
+		return "", errors.NewServiceUnavailable("failed to store the object", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		slog.ErrorContext(ctx, "object storage returned error",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"bucket", s.bucket,
+		)
+		errStatus := fmt.Errorf("status code: %d", resp.StatusCode)
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			return "", errors.NewTooManyRequests("failed to store the object", errStatus)
+		case resp.StatusCode >= 500:
+			return "", errors.NewServiceUnavailable("failed to store the object", errStatus)
+		default:
+			return "", errors.NewUnexpected("failed to store the object", errStatus)
+		}
+	}
+
+	if s.baseURL != "" {
+		return s.baseURL + "/" + key, nil
+	}
+	return objectURL, nil
+}
+
+// newBucketStorage creates the storage of a s3 or gcs bucket
+func newBucketStorage(config Config) (*bucketStorage, error) {
+	if strings.TrimSpace(config.Bucket) == "" {
+		return nil, fmt.Errorf("the bucket of the %s object storage is required", config.Backend)
+	}
+	if config.Credentials.AccessKeyID == "" || config.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("the credentials of the %s object storage are required", config.Backend)
+	}
+
+	storage := &bucketStorage{
+		bucket:      config.Bucket,
+		region:      config.Region,
+		endpoint:    strings.TrimSuffix(config.Endpoint, "/"),
+		baseURL:     config.BaseURL,
+		credentials: config.Credentials,
+		httpClient:  &http.Client{Timeout: requestTimeout},
+	}
+	if config.Backend == BackendGCS {
+		storage.region = gcsRegion
+		if storage.endpoint == "" {
+			storage.endpoint = gcsEndpoint
+		}
+	}
+	if storage.region == "" {
+		storage.region = defaultRegion
+	}
+	if storage.endpoint != "" {
+		parsed, err := url.Parse(storage.endpoint)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid object storage endpoint %s, expected an absolute URL", config.Endpoint)
+		}
+		storage.pathStyle = true
+	}
+	return storage, nil
+}
@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// LocalPrefix is the path the objects of the local backend are served under by the HTTP server
+const LocalPrefix = "/objects"
+
+// localStorage stores the objects in a directory and serves them over HTTP, for the local
+// development and the single replica deployments with a persistent volume
+type localStorage struct {
+	dir     string
+	baseURL string
+	files   http.Handler
+}
+
+// PutObject writes the object to the directory, the file is renamed once written so it's never
+// served partially
+func (s *localStorage) PutObject(ctx context.Context, key, _ string, data []byte) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", errors.NewValidation("invalid object key")
+	}
+	target := filepath.Join(s.dir, filepath.FromSlash(clean))
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		slog.ErrorContext(ctx, "failed to create the object directory", "error", err)
+		return "", errors.NewUnexpected("failed to store the object", err)
+	}
+	temp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create the object file", "error", err)
+		return "", errors.NewUnexpected("failed to store the object", err)
+	}
+	defer os.Remove(temp.Name())
+
+	_, errWrite := temp.Write(data)
+	if errClose := temp.Close(); errWrite == nil {
+		errWrite = errClose
+	}
+	if errWrite == nil {
+		errWrite = os.Chmod(temp.Name(), 0o644)
+	}
+	if errWrite == nil {
+		errWrite = os.Rename(temp.Name(), target)
+	}
+	if errWrite != nil {
+		slog.ErrorContext(ctx, "failed to write the object file", "error", errWrite)
+		return "", errors.NewUnexpected("failed to store the object", errWrite)
+	}
+
+	return s.baseURL + LocalPrefix + clean, nil
+}
+
+// ServeHTTP serves the objects under LocalPrefix, the directories aren't listed
+func (s *localStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, LocalPrefix)
+	if name == "" || strings.HasSuffix(name, "/") || strings.Contains(name, "/.") {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	s.files.ServeHTTP(w, r)
+}
+
+// newLocalStorage creates the storage of a local directory
+func newLocalStorage(config Config) (*localStorage, error) {
+	if strings.TrimSpace(config.Dir) == "" {
+		return nil, fmt.Errorf("the directory of the local object storage is required")
+	}
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("the base URL of the local object storage is required")
+	}
+	return &localStorage{
+		dir:     config.Dir,
+		baseURL: config.BaseURL,
+		files:   http.StripPrefix(LocalPrefix, http.FileServer(http.Dir(config.Dir))),
+	}, nil
+}
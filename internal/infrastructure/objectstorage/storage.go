@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package objectstorage stores the objects served to the browsers, like the profile pictures, in
+// a S3 bucket, a GCS bucket (through its S3 compatible XML API) or a local directory.
+package objectstorage
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+const (
+	// BackendS3 stores the objects in a S3 bucket, or a bucket of a S3 compatible storage
+	BackendS3 = "s3"
+	// BackendGCS stores the objects in a GCS bucket, signed with a HMAC key of the bucket
+	BackendGCS = "gcs"
+	// BackendLocal stores the objects in a local directory, served by the HTTP server
+	BackendLocal = "local"
+)
+
+// Config is the configuration of the object storage
+type Config struct {
+	// Backend is one of s3, gcs or local
+	Backend string
+	// Bucket is the bucket of the s3 and gcs backends
+	Bucket string
+	// Region is the region of the bucket of the s3 backend (default: us-east-1)
+	Region string
+	// Endpoint is the endpoint of a S3 compatible storage, the objects are addressed by path
+	Endpoint string
+	// Credentials sign the requests of the s3 and gcs backends, the HMAC key for gcs
+	Credentials sigv4.Credentials
+	// Dir is the directory of the local backend
+	Dir string
+	// BaseURL is the URL the objects are served from, the key is appended to it. It's required
+	// for the local backend (the URL of the service), it defaults to the bucket URL otherwise.
+	BaseURL string
+}
+
+// New creates the object storage of the configured backend
+func New(config Config) (port.ObjectStorage, error) {
+	if config.BaseURL != "" {
+		parsed, err := url.Parse(config.BaseURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid object storage base URL %s, expected an absolute URL", config.BaseURL)
+		}
+		config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	}
+
+	switch config.Backend {
+	case BackendS3, BackendGCS:
+		return newBucketStorage(config)
+	case BackendLocal:
+		return newLocalStorage(config)
+	default:
+		return nil, fmt.Errorf("invalid object storage backend %q, expected s3, gcs or local", config.Backend)
+	}
+}
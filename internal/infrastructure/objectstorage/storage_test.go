@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package objectstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+var testCredentials = sigv4.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "s3", config: Config{Backend: BackendS3, Bucket: "pictures", Credentials: testCredentials}},
+		{name: "gcs", config: Config{Backend: BackendGCS, Bucket: "pictures", Credentials: testCredentials}},
+		{name: "local", config: Config{Backend: BackendLocal, Dir: t.TempDir(), BaseURL: "https://auth.example.org"}},
+		{name: "unknown backend", config: Config{Backend: "ftp"}, wantErr: true},
+		{name: "s3 without bucket", config: Config{Backend: BackendS3, Credentials: testCredentials}, wantErr: true},
+		{name: "gcs without credentials", config: Config{Backend: BackendGCS, Bucket: "pictures"}, wantErr: true},
+		{name: "invalid endpoint", config: Config{Backend: BackendS3, Bucket: "pictures", Endpoint: "minio:9000", Credentials: testCredentials}, wantErr: true},
+		{name: "local without base URL", config: Config{Backend: BackendLocal, Dir: t.TempDir()}, wantErr: true},
+		{name: "relative base URL", config: Config{Backend: BackendLocal, Dir: t.TempDir(), BaseURL: "/pictures"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBucketStorage_PutObject(t *testing.T) {
+	var (
+		path    string
+		body    string
+		headers http.Header
+		status  = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		path = r.URL.Path
+		headers = r.Header
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	storage, err := New(Config{Backend: BackendS3, Bucket: "pictures", Endpoint: server.URL, Credentials: testCredentials})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	objectURL, err := storage.PutObject(ctx, "profile-pictures/abc/def.png", "image/png", []byte("image"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/pictures/profile-pictures/abc/def.png", objectURL)
+	assert.Equal(t, "/pictures/profile-pictures/abc/def.png", path)
+	assert.Equal(t, "image", body)
+	assert.Equal(t, "image/png", headers.Get("Content-Type"))
+	assert.Equal(t, cacheControl, headers.Get("Cache-Control"))
+	assert.NotEmpty(t, headers.Get("X-Amz-Content-Sha256"))
+	assert.True(t, strings.HasPrefix(headers.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+	assert.Contains(t, headers.Get("Authorization"), "/us-east-1/s3/aws4_request")
+
+	t.Run("base URL", func(t *testing.T) {
+		storage, err := New(Config{Backend: BackendGCS, Bucket: "pictures", Endpoint: server.URL, Credentials: testCredentials, BaseURL: "https://cdn.example.org/"})
+		require.NoError(t, err)
+		objectURL, err := storage.PutObject(ctx, "profile-pictures/abc/def.png", "image/png", []byte("image"))
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.example.org/profile-pictures/abc/def.png", objectURL)
+		assert.Contains(t, headers.Get("Authorization"), "/auto/s3/aws4_request")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		_, err := storage.PutObject(ctx, "profile-pictures/abc/def.png", "image/png", []byte("image"))
+		assert.IsType(t, errors.ServiceUnavailable{}, err)
+
+		status = http.StatusForbidden
+		_, err = storage.PutObject(ctx, "profile-pictures/abc/def.png", "image/png", []byte("image"))
+		assert.IsType(t, errors.Unexpected{}, err)
+	})
+}
+
+func TestBucketStorage_objectURL(t *testing.T) {
+	storage, err := newBucketStorage(Config{Backend: BackendS3, Bucket: "pictures", Region: "eu-west-1", Credentials: testCredentials})
+	require.NoError(t, err)
+	assert.Equal(t, "https://pictures.s3.eu-west-1.amazonaws.com/profile-pictures/a%20b.png", storage.objectURL("profile-pictures/a b.png"))
+
+	storage, err = newBucketStorage(Config{Backend: BackendGCS, Bucket: "pictures", Credentials: testCredentials})
+	require.NoError(t, err)
+	assert.Equal(t, "https://storage.googleapis.com/pictures/profile-pictures/a.png", storage.objectURL("profile-pictures/a.png"))
+}
+
+func TestLocalStorage(t *testing.T) {
+	storage, err := New(Config{Backend: BackendLocal, Dir: t.TempDir(), BaseURL: "https://auth.example.org"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	objectURL, err := storage.PutObject(ctx, "profile-pictures/abc/def.png", "image/png", []byte("image"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example.org/objects/profile-pictures/abc/def.png", objectURL)
+
+	for _, key := range []string{"", "../escape.png", "profile-pictures/../../escape.png", "/absolute.png"} {
+		_, err := storage.PutObject(ctx, key, "image/png", []byte("image"))
+		assert.Error(t, err, key)
+	}
+
+	handler, ok := storage.(http.Handler)
+	require.True(t, ok, "the local storage serves its objects")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/objects/profile-pictures/abc/def.png", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image", rec.Body.String())
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+
+	for _, path := range []string{"/objects/profile-pictures/", "/objects/profile-pictures/abc/.upload-1", "/objects/missing.png"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+	}
+}
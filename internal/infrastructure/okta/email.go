@@ -0,0 +1,28 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"crypto/rsa"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// newEmailLinkingFlow creates the OTP verification of the alternate emails, Okta only verifies the
+// primary email. The identity tokens are signed with the private key of the service app.
+func newEmailLinkingFlow(config Config, privateKey *rsa.PrivateKey) (*emaillinking.Flow, error) {
+	return emaillinking.New(config.EmailSender,
+		emaillinking.NewRSASigner(privateKey),
+		config.baseURL()+"#"+constants.ServiceName,
+		emaillinking.WithStore(config.VerificationCodes),
+		emaillinking.WithClock(config.Clock),
+	)
+}
+
+// normalizeEmail is the form of the alternate emails searched and linked
+func normalizeEmail(emailAddress string) string {
+	return strings.ToLower(strings.TrimSpace(emailAddress))
+}
@@ -0,0 +1,178 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// OktaUser represents a user of the Okta Users API
+type OktaUser struct {
+	ID          string           `json:"id"`
+	Status      string           `json:"status,omitempty"`
+	Profile     OktaProfile      `json:"profile"`
+	Credentials *OktaCredentials `json:"credentials,omitempty"`
+}
+
+// OktaCredentials represents the credentials of an Okta user, only the emails are read
+type OktaCredentials struct {
+	Emails []OktaCredentialEmail `json:"emails,omitempty"`
+}
+
+// OktaCredentialEmail is an email of the user credentials with its verification status
+type OktaCredentialEmail struct {
+	Value  string `json:"value"`
+	Status string `json:"status"`
+	Type   string `json:"type"`
+}
+
+// primaryEmailVerified reports whether Okta verified the primary email of the user
+func (u *OktaUser) primaryEmailVerified() bool {
+	if u.Credentials == nil {
+		return false
+	}
+	for _, email := range u.Credentials.Emails {
+		if email.Type == "PRIMARY" && email.Status == "VERIFIED" && strings.EqualFold(email.Value, u.Profile.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// OktaProfile represents the profile of an Okta user. The base attributes are used when Okta
+// has one for the metadata field, the others (picture, country, t_shirt_size, organization_verified
+// and alternateEmails) are custom attributes that must be added to the default user profile.
+type OktaProfile struct {
+	Login                string   `json:"login,omitempty"`
+	Email                string   `json:"email,omitempty"`
+	DisplayName          *string  `json:"displayName,omitempty"`
+	FirstName            *string  `json:"firstName,omitempty"`
+	LastName             *string  `json:"lastName,omitempty"`
+	Title                *string  `json:"title,omitempty"`
+	Organization         *string  `json:"organization,omitempty"`
+	State                *string  `json:"state,omitempty"`
+	City                 *string  `json:"city,omitempty"`
+	StreetAddress        *string  `json:"streetAddress,omitempty"`
+	ZipCode              *string  `json:"zipCode,omitempty"`
+	MobilePhone          *string  `json:"mobilePhone,omitempty"`
+	Timezone             *string  `json:"timezone,omitempty"`
+	Picture              *string  `json:"picture,omitempty"`
+	Country              *string  `json:"country,omitempty"`
+	TShirtSize           *string  `json:"tShirtSize,omitempty"`
+	OrganizationVerified *bool    `json:"organizationVerified,omitempty"`
+	AlternateEmails      []string `json:"alternateEmails,omitempty"`
+}
+
+// OktaIdentityProvider represents an identity provider linked to an Okta user
+type OktaIdentityProvider struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// profileUpdateRequest is the body of a partial profile update, the attributes not
+// present in the profile are left untouched
+type profileUpdateRequest struct {
+	Profile any `json:"profile"`
+}
+
+// newProfileUpdate returns the partial profile setting the metadata fields present in the update
+func newProfileUpdate(meta *model.UserMetadata) *OktaProfile {
+	if meta == nil {
+		return &OktaProfile{}
+	}
+	return &OktaProfile{
+		DisplayName:          meta.Name,
+		FirstName:            meta.GivenName,
+		LastName:             meta.FamilyName,
+		Title:                meta.JobTitle,
+		Organization:         meta.Organization,
+		State:                meta.StateProvince,
+		City:                 meta.City,
+		StreetAddress:        meta.Address,
+		ZipCode:              meta.PostalCode,
+		MobilePhone:          meta.PhoneNumber,
+		Timezone:             meta.Zoneinfo,
+		Picture:              meta.Picture,
+		Country:              meta.Country,
+		TShirtSize:           meta.TShirtSize,
+		OrganizationVerified: meta.OrganizationVerified,
+	}
+}
+
+// HasAlternateEmail reports whether the email is one of the alternate emails of the user
+func (u *OktaUser) HasAlternateEmail(email string) bool {
+	for _, existing := range u.Profile.AlternateEmails {
+		if strings.EqualFold(existing, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// alternateEmailsWithout returns the alternate emails without the email, and whether it was present
+func (u *OktaUser) alternateEmailsWithout(email string) ([]string, bool) {
+	emails := make([]string, 0, len(u.Profile.AlternateEmails))
+	found := false
+	for _, existing := range u.Profile.AlternateEmails {
+		if strings.EqualFold(existing, email) {
+			found = true
+			continue
+		}
+		emails = append(emails, existing)
+	}
+	return emails, found
+}
+
+// ToUser converts an OktaUser to a User
+func (u *OktaUser) ToUser() *model.User {
+	p := u.Profile
+
+	var alternateEmails []model.Email
+	for _, email := range p.AlternateEmails {
+		alternateEmails = append(alternateEmails, model.Email{
+			Email:    email,
+			Verified: true,
+		})
+	}
+
+	return &model.User{
+		UserID:               u.ID,
+		Sub:                  u.ID,
+		Username:             p.Login,
+		PrimaryEmail:         p.Email,
+		PrimaryEmailVerified: u.primaryEmailVerified(),
+		AlternateEmails:      alternateEmails,
+		UserMetadata: &model.UserMetadata{
+			Name:                 p.DisplayName,
+			GivenName:            p.FirstName,
+			FamilyName:           p.LastName,
+			JobTitle:             p.Title,
+			Organization:         p.Organization,
+			StateProvince:        p.State,
+			City:                 p.City,
+			Address:              p.StreetAddress,
+			PostalCode:           p.ZipCode,
+			PhoneNumber:          p.MobilePhone,
+			Zoneinfo:             p.Timezone,
+			Picture:              p.Picture,
+			Country:              p.Country,
+			TShirtSize:           p.TShirtSize,
+			OrganizationVerified: p.OrganizationVerified,
+		},
+	}
+}
+
+// ToIdentity converts an OktaIdentityProvider to an Identity, the identity id is the
+// id of the identity provider since Okta unlinks the user per identity provider
+func (i *OktaIdentityProvider) ToIdentity() model.Identity {
+	return model.Identity{
+		Provider:   strings.ToLower(i.Type),
+		IdentityID: i.ID,
+		Name:       i.Name,
+		IsSocial:   i.Type != "SAML2" && i.Type != "OIDC",
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProfileUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     *model.UserMetadata
+		expected string
+	}{
+		{
+			name: "only the fields present are sent",
+			meta: &model.UserMetadata{
+				GivenName: converters.StringPtr("Jane"),
+				JobTitle:  converters.StringPtr("Engineer"),
+			},
+			expected: `{"profile":{"firstName":"Jane","title":"Engineer"}}`,
+		},
+		{
+			name: "empty values clear the attribute",
+			meta: &model.UserMetadata{
+				City:                 converters.StringPtr(""),
+				OrganizationVerified: converters.BoolPtr(false),
+			},
+			expected: `{"profile":{"city":"","organizationVerified":false}}`,
+		},
+		{
+			name:     "nil metadata",
+			meta:     nil,
+			expected: `{"profile":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(&profileUpdateRequest{Profile: newProfileUpdate(tt.meta)})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestOktaUser_ToUser(t *testing.T) {
+	oktaUser := OktaUser{
+		ID:     "00u1abcd2EFGH3ijk4x7",
+		Status: "ACTIVE",
+		Profile: OktaProfile{
+			Login:                "jane@example.com",
+			Email:                "jane@example.com",
+			FirstName:            converters.StringPtr("Jane"),
+			Title:                converters.StringPtr("Engineer"),
+			TShirtSize:           converters.StringPtr("M"),
+			OrganizationVerified: converters.BoolPtr(true),
+			AlternateEmails:      []string{"jane@work.example.com"},
+		},
+		Credentials: &OktaCredentials{
+			Emails: []OktaCredentialEmail{{Value: "jane@example.com", Status: "VERIFIED", Type: "PRIMARY"}},
+		},
+	}
+
+	user := oktaUser.ToUser()
+	assert.Equal(t, "00u1abcd2EFGH3ijk4x7", user.UserID)
+	assert.Equal(t, "00u1abcd2EFGH3ijk4x7", user.Sub)
+	assert.Equal(t, "jane@example.com", user.Username)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail)
+	assert.True(t, user.PrimaryEmailVerified)
+	assert.Equal(t, []model.Email{{Email: "jane@work.example.com", Verified: true}}, user.AlternateEmails)
+	assert.Equal(t, "Jane", *user.UserMetadata.GivenName)
+	assert.Equal(t, "Engineer", *user.UserMetadata.JobTitle)
+	assert.Equal(t, "M", *user.UserMetadata.TShirtSize)
+	assert.True(t, *user.UserMetadata.OrganizationVerified)
+	assert.Nil(t, user.UserMetadata.City)
+}
+
+func TestOktaUser_AlternateEmails(t *testing.T) {
+	oktaUser := OktaUser{Profile: OktaProfile{AlternateEmails: []string{"a@example.com", "B@example.com"}}}
+
+	assert.True(t, oktaUser.HasAlternateEmail("b@example.com"))
+	assert.False(t, oktaUser.HasAlternateEmail("c@example.com"))
+
+	emails, found := oktaUser.alternateEmailsWithout("b@EXAMPLE.com")
+	assert.True(t, found)
+	assert.Equal(t, []string{"a@example.com"}, emails)
+
+	emails, found = oktaUser.alternateEmailsWithout("c@example.com")
+	assert.False(t, found)
+	assert.Equal(t, []string{"a@example.com", "B@example.com"}, emails)
+}
+
+func TestOktaIdentityProvider_ToIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		idp      OktaIdentityProvider
+		expected model.Identity
+	}{
+		{
+			name:     "social",
+			idp:      OktaIdentityProvider{ID: "0oa62bfdiumsUndnZ0h7", Type: "GOOGLE", Name: "Google"},
+			expected: model.Identity{Provider: "google", IdentityID: "0oa62bfdiumsUndnZ0h7", Name: "Google", IsSocial: true},
+		},
+		{
+			name:     "enterprise",
+			idp:      OktaIdentityProvider{ID: "0oa62b57p7c8PaGpU0h7", Type: "SAML2", Name: "Acme SSO"},
+			expected: model.Identity{Provider: "saml2", IdentityID: "0oa62b57p7c8PaGpU0h7", Name: "Acme SSO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.idp.ToIdentity())
+		})
+	}
+}
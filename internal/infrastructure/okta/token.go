@@ -0,0 +1,296 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokensource"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	leeway = 60 * time.Second
+
+	// clientAssertionTTL is the lifetime of the client assertions, Okta rejects them past one hour
+	clientAssertionTTL = 5 * time.Minute
+
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+// TokenManager manages the Okta M2M tokens of the service app, the tokens are requested with
+// the client credentials grant authenticated with a private key JWT, as required by the Okta APIs
+type TokenManager struct {
+	tokenSource oauth2.TokenSource
+}
+
+// oktaTokenSource implements oauth2.TokenSource against the org authorization server
+type oktaTokenSource struct {
+	ctx        context.Context
+	httpClient *http.Client
+	tokenURL   string
+	clientID   string
+	privateKey *rsa.PrivateKey
+	scopes     []string
+}
+
+// clientAssertion signs a single use assertion authenticating the service app
+func (o *oktaTokenSource) clientAssertion() (string, error) {
+	opts := &jwtparser.GeneratorOptions{
+		TokenType:     jwtparser.TokenTypeAccess,
+		Subject:       o.clientID,
+		Issuer:        o.clientID,
+		Audience:      o.tokenURL,
+		ExpiresIn:     clientAssertionTTL,
+		SigningMethod: jwa.RS256,
+		SigningKey:    o.privateKey,
+		CustomClaims:  map[string]any{"jti": uuid.NewString()},
+	}
+	return jwtparser.Generate(opts)
+}
+
+// Token implements the oauth2.TokenSource interface
+func (o *oktaTokenSource) Token() (*oauth2.Token, error) {
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	assertion, err := o.clientAssertion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":            []string{"client_credentials"},
+		"scope":                 []string{strings.Join(o.scopes, " ")},
+		"client_assertion_type": []string{clientAssertionType},
+		"client_assertion":      []string{assertion},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token from Okta: %w", err)
+	}
+	defer response.Body.Close()
+
+	var tokenResponse struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int    `json:"expires_in"`
+		Scope            string `json:"scope"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode Okta token response (status %d): %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK || tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("failed to get token from Okta (status %d): %s %s",
+			response.StatusCode, tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+
+	// Convert Okta response to oauth2.Token with leeway for expiration
+	token := &oauth2.Token{
+		AccessToken: tokenResponse.AccessToken,
+		TokenType:   tokenResponse.TokenType,
+		Expiry:      time.Now().Add(time.Duration(tokenResponse.ExpiresIn)*time.Second - leeway),
+	}
+	return token.WithExtra(map[string]any{"scope": tokenResponse.Scope}), nil
+}
+
+// GetToken returns a valid M2M access token
+func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
+	token, err := tm.tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to get M2M token: %w", err)
+	}
+
+	if !token.Valid() {
+		return "", fmt.Errorf("token is not valid")
+	}
+
+	slog.DebugContext(ctx, "M2M token retrieved successfully",
+		"token_type", token.TokenType,
+		"expires_at", token.Expiry,
+	)
+
+	return token.AccessToken, nil
+}
+
+// parsePrivateKey parses the PEM encoded RSA private key of the service app, PKCS#8 or PKCS#1
+func parsePrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.NewValidation("failed to decode the PEM private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.NewValidation("failed to parse the private key", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.NewValidation("the private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// NewM2MTokenManager creates a new M2M token manager for the service app
+func NewM2MTokenManager(ctx context.Context, config Config, httpClient *http.Client) (*TokenManager, error) {
+	privateKey, err := parsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	tokenSource := &oktaTokenSource{
+		ctx:        ctx,
+		httpClient: httpClient,
+		tokenURL:   config.baseURL() + "/oauth2/v1/token",
+		clientID:   config.ClientID,
+		privateKey: privateKey,
+		scopes:     scopes,
+	}
+
+	// Cache the token, renewed in the background before it expires
+	return &TokenManager{
+		tokenSource: tokensource.NewRenewing(ctx, "okta", tokenSource),
+	}, nil
+}
+
+// jwtVerifier verifies the user access tokens issued by the authorization server
+type jwtVerifier struct {
+	publicKey         *rsa.PublicKey
+	expectedIssuer    string
+	expectedAudiences []string
+}
+
+// Verify verifies the token signature, issuer, audience and the required scopes. Okta
+// lists the scopes in the scp claim and the user id in the uid claim, the subject is the login.
+func (j *jwtVerifier) Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, string, error) {
+	if j == nil {
+		return nil, "", errors.NewValidation("JWT verification configuration is required")
+	}
+
+	claims, err := jwtparser.ParseVerified(ctx, token, &jwtparser.ParseOptions{
+		RequireExpiration: true,
+		AllowBearerPrefix: true,
+		RequireSubject:    true,
+		VerifySignature:   true,
+		SigningKey:        j.publicKey,
+		ExpectedIssuer:    j.expectedIssuer,
+		ExpectedAudiences: j.expectedAudiences,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "JWT signature verification failed", "error", err)
+		return nil, "", err
+	}
+
+	var scopes []string
+	if scp, ok := claims.Raw["scp"].([]any); ok {
+		for _, scope := range scp {
+			if s, ok := scope.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	for _, requiredScope := range requiredScopes {
+		if !slices.Contains(scopes, requiredScope) {
+			return nil, "", errors.NewValidation("missing required scope")
+		}
+	}
+
+	userID, _ := claims.Raw["uid"].(string)
+	if userID == "" {
+		return nil, "", errors.NewValidation("missing 'uid' claim in token")
+	}
+
+	slog.DebugContext(ctx, "JWT signature verification successful",
+		"user_id", redaction.Redact(userID),
+		"required_scope", requiredScopes,
+	)
+	return claims, userID, nil
+}
+
+// newJWTVerifier loads the signing key of the authorization server from its JWKS
+func newJWTVerifier(ctx context.Context, config Config, httpClient *httpclient.Client) (*jwtVerifier, error) {
+	issuer := config.issuer()
+
+	apiRequest := httpclient.NewAPIRequest(
+		httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(issuer+"/v1/keys"),
+		httpclient.WithDescription("fetch Okta JWKS"),
+	)
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if _, err := apiRequest.Call(ctx, &jwks); err != nil {
+		return nil, errors.NewUnexpected("failed to fetch JWKS", err)
+	}
+
+	for _, rawKey := range jwks.Keys {
+		var key struct {
+			Kty string `json:"kty"`
+			Use string `json:"use,omitempty"`
+			Kid string `json:"kid,omitempty"`
+		}
+		if err := json.Unmarshal(rawKey, &key); err != nil || key.Kty != "RSA" || (key.Use != "sig" && key.Use != "") {
+			continue
+		}
+
+		publicKey, err := jwtparser.LoadRSAPublicKeyFromJWK(rawKey)
+		if err != nil {
+			return nil, errors.NewUnexpected("failed to load RSA public key from JWK", err)
+		}
+
+		slog.InfoContext(ctx, "JWT signature verification enabled",
+			"issuer", issuer,
+			"audience", config.audience(),
+			"key_id", key.Kid,
+		)
+
+		return &jwtVerifier{
+			publicKey:         publicKey,
+			expectedIssuer:    issuer,
+			expectedAudiences: []string{config.audience()},
+		}, nil
+	}
+
+	return nil, errors.NewUnexpected(fmt.Sprintf("no suitable RSA key found in the JWKS of %s", issuer))
+}
@@ -0,0 +1,428 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// defaultScopes are the Okta API scopes granted to the service app
+var defaultScopes = []string{"okta.users.read", "okta.users.manage"}
+
+// Config holds the configuration for the Okta Users API
+type Config struct {
+	// Domain is the Okta org domain, e.g. linuxfoundation.okta.com
+	Domain string
+	// ClientID and PrivateKey (PEM) are the credentials of the API service app
+	ClientID   string
+	PrivateKey string
+	// Scopes are the Okta API scopes requested for the service app, defaultScopes when empty
+	Scopes []string
+	// Issuer is the authorization server of the user tokens, https://{Domain}/oauth2/default when empty
+	Issuer string
+	// Audience is the expected audience of the user tokens, api://default when empty
+	Audience string
+	// EmailSender delivers the alternate email OTPs, configured from the environment when nil
+	EmailSender port.TemplatedEmailSender
+	// VerificationCodes keeps the pending alternate email OTPs, in the memory of the replica when nil
+	VerificationCodes emaillinking.Store
+	// Clock is the time source of the OTP expirations, the system clock when nil
+	Clock clock.Clock
+}
+
+// Validate checks the configuration is complete and the private key parses, without calling Okta
+func (c Config) Validate() error {
+	if strings.TrimSpace(c.Domain) == "" {
+		return errors.NewValidation("Okta domain is required")
+	}
+	if c.ClientID == "" || c.PrivateKey == "" {
+		return errors.NewValidation("Okta client ID and private key are required")
+	}
+	_, err := parsePrivateKey(c.PrivateKey)
+	return err
+}
+
+// baseURL is the URL of the Okta org, the Domain can include the scheme in development
+func (c Config) baseURL() string {
+	domain := strings.TrimRight(c.Domain, "/")
+	if strings.HasPrefix(domain, "http://") || strings.HasPrefix(domain, "https://") {
+		return domain
+	}
+	return "https://" + domain
+}
+
+func (c Config) issuer() string {
+	if c.Issuer != "" {
+		return strings.TrimRight(c.Issuer, "/")
+	}
+	return c.baseURL() + "/oauth2/default"
+}
+
+func (c Config) audience() string {
+	if c.Audience != "" {
+		return c.Audience
+	}
+	return "api://default"
+}
+
+type userReaderWriter struct {
+	config           Config
+	httpClient       *httpclient.Client
+	m2mTokenManager  *TokenManager
+	jwtVerifier      *jwtVerifier
+	emailLinkingFlow *emaillinking.Flow
+}
+
+// call calls the Okta API with the M2M token
+func (u *userReaderWriter) call(ctx context.Context, method, path string, body, response any, description string) error {
+	m2mToken, errGetToken := u.m2mTokenManager.GetToken(ctx)
+	if errGetToken != nil {
+		return errors.NewUnexpected("failed to get M2M token", errGetToken)
+	}
+
+	options := []httpclient.RequestOption{
+		httpclient.WithMethod(method),
+		httpclient.WithURL(u.config.baseURL() + "/api/v1/" + path),
+		httpclient.WithToken(m2mToken),
+		httpclient.WithDescription(description),
+	}
+	if body != nil {
+		options = append(options, httpclient.WithBody(body))
+	}
+
+	statusCode, errCall := httpclient.NewAPIRequest(u.httpClient, options...).Call(ctx, response)
+	if errCall != nil {
+		slog.ErrorContext(ctx, "Okta request failed",
+			"error", errCall,
+			"status_code", statusCode,
+			"description", description,
+		)
+		return httpclient.ErrorFromCall(statusCode, fmt.Sprintf("failed to %s", description), errCall)
+	}
+	return nil
+}
+
+// getOktaUser reads the user, the id can also be the login
+func (u *userReaderWriter) getOktaUser(ctx context.Context, userID string) (*OktaUser, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, errors.NewValidation("user_id is required to get user")
+	}
+
+	var oktaUser OktaUser
+	if err := u.call(ctx, http.MethodGet, "users/"+url.PathEscape(userID), nil, &oktaUser, "get user"); err != nil {
+		return nil, err
+	}
+	return &oktaUser, nil
+}
+
+// updateProfile applies the partial profile update and returns the updated user
+func (u *userReaderWriter) updateProfile(ctx context.Context, userID string, profile any) (*OktaUser, error) {
+	var updated OktaUser
+	err := u.call(ctx, http.MethodPost, "users/"+url.PathEscape(userID), &profileUpdateRequest{Profile: profile}, &updated, "update user")
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (u *userReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	slog.DebugContext(ctx, "getting user", "user_id", redaction.Redact(user.UserID))
+
+	oktaUser, err := u.getOktaUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var identityProviders []OktaIdentityProvider
+	errIdentities := u.call(ctx, http.MethodGet, "users/"+url.PathEscape(oktaUser.ID)+"/idps", nil, &identityProviders, "list identity providers")
+	if errIdentities != nil {
+		return nil, errIdentities
+	}
+
+	result := oktaUser.ToUser()
+	for _, identityProvider := range identityProviders {
+		result.Identities = append(result.Identities, identityProvider.ToIdentity())
+	}
+
+	slog.DebugContext(ctx, "user retrieved successfully", "user_id", redaction.Redact(user.UserID))
+	return result, nil
+}
+
+func (u *userReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+
+	if user == nil {
+		return nil, errors.NewValidation("user is required")
+	}
+
+	var (
+		attribute string
+		value     string
+		match     func(*OktaUser) bool
+	)
+
+	switch criteria {
+	case constants.CriteriaTypeEmail:
+		attribute, value = "profile.email", strings.TrimSpace(user.PrimaryEmail)
+		match = func(candidate *OktaUser) bool {
+			return strings.EqualFold(candidate.Profile.Email, value)
+		}
+	case constants.CriteriaTypeUsername:
+		attribute, value = "profile.login", strings.TrimSpace(user.Username)
+		match = func(candidate *OktaUser) bool {
+			return strings.EqualFold(candidate.Profile.Login, value)
+		}
+	case constants.CriteriaTypeAlternateEmail:
+		// only the first alternate email is supported
+		if len(user.AlternateEmails) > 0 {
+			value = normalizeEmail(user.AlternateEmails[0].Email)
+		}
+		attribute = "profile.alternateEmails"
+		match = func(candidate *OktaUser) bool {
+			return candidate.HasAlternateEmail(value)
+		}
+	default:
+		return nil, errors.NewValidation(fmt.Sprintf("invalid criteria type: %s", criteria))
+	}
+	if value == "" {
+		return nil, errors.NewValidation(fmt.Sprintf("%s is required", criteria))
+	}
+
+	slog.DebugContext(ctx, "searching user", "criteria", criteria)
+
+	// the value is quoted in the search expression, the quotes and backslashes are escaped
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	query := url.Values{"search": []string{fmt.Sprintf(`%s eq "%s"`, attribute, escaped)}}
+
+	var oktaUsers []OktaUser
+	if err := u.call(ctx, http.MethodGet, "users?"+query.Encode(), nil, &oktaUsers, "search user"); err != nil {
+		return nil, err
+	}
+
+	for i := range oktaUsers {
+		if match(&oktaUsers[i]) {
+			return oktaUsers[i].ToUser(), nil
+		}
+	}
+	return nil, errors.NewNotFound("user not found")
+}
+
+// MetadataLookup prepares the user for metadata lookup based on the input
+// Accepts JWT token, Okta user id or login
+func (u *userReaderWriter) MetadataLookup(ctx context.Context, input string, requiredScopes ...string) (*model.User, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errors.NewValidation("input is required")
+	}
+
+	slog.DebugContext(ctx, "metadata lookup", "input", redaction.Redact(input))
+
+	if cleanToken, isJWT := jwt.LooksLikeJWT(input); isJWT {
+		_, userID, err := u.jwtVerifier.Verify(ctx, cleanToken, requiredScopes...)
+		if err != nil {
+			return nil, err
+		}
+		return &model.User{
+			Token:  cleanToken,
+			UserID: userID,
+			Sub:    userID,
+		}, nil
+	}
+
+	// the logins are usually emails, the Okta user ids never contain an @
+	if strings.Contains(input, "@") {
+		slog.DebugContext(ctx, "username search strategy", "username", redaction.Redact(input))
+		return &model.User{Username: input}, nil
+	}
+
+	slog.DebugContext(ctx, "canonical lookup strategy", "sub", redaction.Redact(input))
+	return &model.User{UserID: input, Sub: input}, nil
+}
+
+func (u *userReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+
+	_, userID, errVerify := u.jwtVerifier.Verify(ctx, user.Token, constants.UserUpdateMetadataRequiredScope)
+	if errVerify != nil {
+		return nil, errVerify
+	}
+
+	if user.UserMetadata == nil {
+		return nil, errors.NewValidation("user_metadata is required for update")
+	}
+	if len(user.ClearFields) > 0 {
+		return nil, errors.NewValidation("clear_fields is not supported by the identity provider")
+	}
+
+	updated, err := u.updateProfile(ctx, userID, newProfileUpdate(user.UserMetadata))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "user updated successfully", "user_id", redaction.Redact(userID))
+
+	return &model.User{
+		UserID:       updated.ID,
+		Sub:          updated.ID,
+		UserMetadata: updated.ToUser().UserMetadata,
+	}, nil
+}
+
+func (u *userReaderWriter) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
+	if strings.TrimSpace(alternateEmail) == "" {
+		return errors.NewValidation("alternate email is required")
+	}
+	return u.emailLinkingFlow.SendVerification(ctx, alternateEmail)
+}
+
+func (u *userReaderWriter) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
+	if email.Email == "" || email.OTP == "" {
+		return nil, errors.NewValidation("email and OTP are required")
+	}
+	return u.emailLinkingFlow.Verify(ctx, email.Email, email.OTP)
+}
+
+// ValidateLinkRequest only accepts the identity tokens of the verified alternate emails,
+// the identity providers are linked by Okta itself on login
+func (u *userReaderWriter) ValidateLinkRequest(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.LinkWith.IdentityToken == "" {
+		return errors.NewValidation("link_with identity token is required")
+	}
+	_, err := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	return err
+}
+
+func (u *userReaderWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("link identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+
+	email, errVerifiedEmail := u.emailLinkingFlow.VerifiedEmail(ctx, request.LinkWith.IdentityToken)
+	if errVerifiedEmail != nil {
+		return errVerifiedEmail
+	}
+
+	oktaUser, err := u.getOktaUser(ctx, request.User.UserID)
+	if err != nil {
+		return err
+	}
+
+	if oktaUser.HasAlternateEmail(email) {
+		slog.InfoContext(ctx, "email already exists in alternate email list",
+			"user_id", redaction.Redact(request.User.UserID),
+			"email", redaction.RedactEmail(email),
+		)
+		return nil
+	}
+
+	profile := &OktaProfile{AlternateEmails: append(oktaUser.Profile.AlternateEmails, email)}
+	if _, errUpdate := u.updateProfile(ctx, oktaUser.ID, profile); errUpdate != nil {
+		return errUpdate
+	}
+
+	slog.InfoContext(ctx, "successfully linked email identity",
+		"user_id", redaction.Redact(request.User.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+	return nil
+}
+
+// UnlinkIdentity removes an alternate email (provider email) or unlinks the user from an
+// identity provider, the identity id is then the identity provider id
+func (u *userReaderWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	if request == nil {
+		return errors.NewValidation("unlink identity request is required")
+	}
+	if request.User.UserID == "" {
+		return errors.NewValidation("user_id is required")
+	}
+	if request.Unlink.Provider == "" {
+		return errors.NewValidation("provider is required")
+	}
+	if request.Unlink.IdentityID == "" {
+		return errors.NewValidation("identity_id is required")
+	}
+
+	slog.DebugContext(ctx, "unlinking identity from user",
+		"user_id", redaction.Redact(request.User.UserID),
+		"provider", request.Unlink.Provider,
+	)
+
+	if request.Unlink.Provider != "email" {
+		return u.call(ctx, http.MethodDelete,
+			"idps/"+url.PathEscape(request.Unlink.IdentityID)+"/users/"+url.PathEscape(request.User.UserID),
+			nil, nil, "unlink identity",
+		)
+	}
+
+	oktaUser, err := u.getOktaUser(ctx, request.User.UserID)
+	if err != nil {
+		return err
+	}
+	emails, found := oktaUser.alternateEmailsWithout(request.Unlink.IdentityID)
+	if !found {
+		return errors.NewNotFound("identity not found")
+	}
+	// not an OktaProfile, the empty list clearing the attribute would be omitted
+	_, errUpdate := u.updateProfile(ctx, oktaUser.ID, map[string][]string{"alternateEmails": emails})
+	return errUpdate
+}
+
+// NewUserReaderWriter creates a new UserReaderWriter backed by the Okta Users API
+func NewUserReaderWriter(ctx context.Context, httpConfig httpclient.Config, config Config) (port.UserReaderWriter, error) {
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	privateKey, err := parsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := httpclient.NewClient(httpConfig)
+
+	m2mTokenManager, err := NewM2MTokenManager(ctx, config, &http.Client{Timeout: httpConfig.Timeout, Transport: httpConfig.Transport})
+	if err != nil {
+		return nil, err
+	}
+
+	jwtVerifier, err := newJWTVerifier(ctx, config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	emailLinkingFlow, err := newEmailLinkingFlow(config, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email linking flow: %w", err)
+	}
+
+	return &userReaderWriter{
+		config:           config,
+		httpClient:       httpClient,
+		m2mTokenManager:  m2mTokenManager,
+		jwtVerifier:      jwtVerifier,
+		emailLinkingFlow: emailLinkingFlow,
+	}, nil
+}
@@ -0,0 +1,657 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package okta
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/infrastructure/emaillinking"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "0oa8service1app2id3x7"
+	testServiceToken = "service-app-token"
+	testUserID       = "00u1abcd2EFGH3ijk4x7"
+	testIdpID        = "0oa62bfdiumsUndnZ0h7"
+)
+
+// fakeOkta serves the token, JWKS and the Users API endpoints used by the repository
+type fakeOkta struct {
+	t *testing.T
+
+	server *httptest.Server
+	// serviceAppKey is the key pair of the service app, authorizationServerKey signs the user tokens
+	serviceAppKey          *rsa.PrivateKey
+	authorizationServerKey *rsa.PrivateKey
+
+	mu                sync.Mutex
+	users             map[string]*OktaUser
+	identityProviders map[string][]OktaIdentityProvider
+	searches          []string
+	tokenRequests     int
+}
+
+func newFakeOkta(t *testing.T) *fakeOkta {
+	serviceAppKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	authorizationServerKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fake := &fakeOkta{
+		t:                      t,
+		serviceAppKey:          serviceAppKey,
+		authorizationServerKey: authorizationServerKey,
+		users: map[string]*OktaUser{
+			testUserID: {
+				ID:     testUserID,
+				Status: "ACTIVE",
+				Profile: OktaProfile{
+					Login:           "jane@example.com",
+					Email:           "jane@example.com",
+					FirstName:       converters.StringPtr("Jane"),
+					City:            converters.StringPtr("Lisbon"),
+					AlternateEmails: []string{"jane@work.example.com"},
+				},
+			},
+		},
+		identityProviders: map[string][]OktaIdentityProvider{
+			testUserID: {{ID: testIdpID, Type: "GOOGLE", Name: "Google"}},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/v1/token", fake.token)
+	mux.HandleFunc("GET /oauth2/default/v1/keys", fake.keys)
+	mux.HandleFunc("GET /api/v1/users", fake.api(fake.searchUsers))
+	mux.HandleFunc("GET /api/v1/users/{id}", fake.api(fake.getUser))
+	mux.HandleFunc("POST /api/v1/users/{id}", fake.api(fake.updateUser))
+	mux.HandleFunc("GET /api/v1/users/{id}/idps", fake.api(fake.listIdentityProviders))
+	mux.HandleFunc("DELETE /api/v1/idps/{idp}/users/{id}", fake.api(fake.unlinkIdentityProvider))
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+// token only accepts the client assertions signed with the key of the service app
+func (f *fakeOkta) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+
+	f.mu.Lock()
+	f.tokenRequests++
+	f.mu.Unlock()
+
+	assertion, err := jwt.Parse(r.PostForm.Get("client_assertion"), func(*jwt.Token) (any, error) {
+		return &f.serviceAppKey.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(testClientID),
+		jwt.WithSubject(testClientID),
+		jwt.WithAudience(f.server.URL+"/oauth2/v1/token"),
+	)
+	if err != nil ||
+		r.PostForm.Get("grant_type") != "client_credentials" ||
+		r.PostForm.Get("client_assertion_type") != clientAssertionType ||
+		r.PostForm.Get("scope") != "okta.users.read okta.users.manage" ||
+		assertion.Claims.(jwt.MapClaims)["jti"] == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": testServiceToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"scope":        r.PostForm.Get("scope"),
+	})
+}
+
+func (f *fakeOkta) keys(w http.ResponseWriter, _ *http.Request) {
+	key, err := jwk.FromRaw(&f.authorizationServerKey.PublicKey)
+	require.NoError(f.t, err)
+	require.NoError(f.t, key.Set(jwk.KeyUsageKey, "sig"))
+	require.NoError(f.t, key.Set(jwk.KeyIDKey, "default-key"))
+	require.NoError(f.t, key.Set(jwk.AlgorithmKey, "RS256"))
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": []any{key}})
+}
+
+// api rejects the calls without the service app token
+func (f *fakeOkta) api(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testServiceToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func (f *fakeOkta) searchUsers(w http.ResponseWriter, r *http.Request) {
+	search := r.URL.Query().Get("search")
+	f.searches = append(f.searches, search)
+
+	attribute, quoted, _ := strings.Cut(search, " eq ")
+	value := strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(strings.Trim(quoted, `"`))
+
+	// Okta compares the strings case insensitively
+	result := []OktaUser{}
+	for _, user := range f.users {
+		switch attribute {
+		case "profile.email":
+			if strings.EqualFold(user.Profile.Email, value) {
+				result = append(result, *user)
+			}
+		case "profile.login":
+			if strings.EqualFold(user.Profile.Login, value) {
+				result = append(result, *user)
+			}
+		case "profile.alternateEmails":
+			if user.HasAlternateEmail(value) {
+				result = append(result, *user)
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeOkta) getUser(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, user := range f.users {
+		if user.ID == id || strings.EqualFold(user.Profile.Login, id) {
+			_ = json.NewEncoder(w).Encode(user)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// updateUser applies a partial profile update, the attributes not present are left untouched
+func (f *fakeOkta) updateUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := f.users[r.PathValue("id")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var request struct {
+		Profile map[string]json.RawMessage `json:"profile"`
+	}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+
+	current, err := json.Marshal(user.Profile)
+	require.NoError(f.t, err)
+	profile := map[string]json.RawMessage{}
+	require.NoError(f.t, json.Unmarshal(current, &profile))
+	for attribute, value := range request.Profile {
+		profile[attribute] = value
+	}
+	merged, err := json.Marshal(profile)
+	require.NoError(f.t, err)
+
+	updated := OktaUser{ID: user.ID, Status: user.Status}
+	require.NoError(f.t, json.Unmarshal(merged, &updated.Profile))
+	f.users[user.ID] = &updated
+
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+func (f *fakeOkta) listIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	identityProviders := f.identityProviders[r.PathValue("id")]
+	if identityProviders == nil {
+		identityProviders = []OktaIdentityProvider{}
+	}
+	_ = json.NewEncoder(w).Encode(identityProviders)
+}
+
+func (f *fakeOkta) unlinkIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	identityProviders := f.identityProviders[r.PathValue("id")]
+	for i, identityProvider := range identityProviders {
+		if identityProvider.ID == r.PathValue("idp") {
+			f.identityProviders[r.PathValue("id")] = append(identityProviders[:i:i], identityProviders[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// userToken issues an access token of the default authorization server
+func (f *fakeOkta) userToken(userID string, scopes ...string) string {
+	return signedToken(f.t, jwt.MapClaims{
+		"sub": "jane@example.com",
+		"uid": userID,
+		"scp": scopes,
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iss": f.server.URL + "/oauth2/default",
+		"aud": "api://default",
+	}, f.authorizationServerKey)
+}
+
+func signedToken(t *testing.T, claims jwt.MapClaims, key *rsa.PrivateKey) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+// privateKeyPEM encodes the key as the service app private key is downloaded from Okta (PKCS#8)
+func privateKeyPEM(t *testing.T, key *rsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// fakeEmailSender keeps the last OTP sent to each recipient
+type fakeEmailSender struct {
+	mu   sync.Mutex
+	otps map[string]string
+}
+
+func (s *fakeEmailSender) SendTemplatedEmail(_ context.Context, _, to string, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.otps[to] = data.(map[string]string)["OTP"]
+	return nil
+}
+
+func (s *fakeEmailSender) otp(to string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.otps[to]
+}
+
+var _ port.TemplatedEmailSender = (*fakeEmailSender)(nil)
+
+func newTestUserReaderWriter(t *testing.T) (*userReaderWriter, *fakeOkta, *fakeEmailSender, *clock.Fake) {
+	fake := newFakeOkta(t)
+	sender := &fakeEmailSender{otps: make(map[string]string)}
+	fakeClock := clock.NewFake(time.Now())
+
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetries = 0
+
+	repository, err := NewUserReaderWriter(context.Background(), httpConfig, Config{
+		Domain:      fake.server.URL + "/",
+		ClientID:    testClientID,
+		PrivateKey:  privateKeyPEM(t, fake.serviceAppKey),
+		EmailSender: sender,
+		Clock:       fakeClock,
+	})
+	require.NoError(t, err)
+	return repository.(*userReaderWriter), fake, sender, fakeClock
+}
+
+func TestNewUserReaderWriter_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "missing domain", config: Config{ClientID: testClientID, PrivateKey: "key"}},
+		{name: "missing client ID", config: Config{Domain: "example.okta.com", PrivateKey: "key"}},
+		{name: "missing private key", config: Config{Domain: "example.okta.com", ClientID: testClientID}},
+		{name: "invalid private key", config: Config{Domain: "example.okta.com", ClientID: testClientID, PrivateKey: "not a PEM key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUserReaderWriter(context.Background(), httpclient.DefaultConfig(), tt.config)
+			require.Error(t, err)
+			assert.IsType(t, errors.Validation{}, err)
+		})
+	}
+}
+
+func TestConfig_BaseURL(t *testing.T) {
+	assert.Equal(t, "https://example.okta.com", Config{Domain: "example.okta.com"}.baseURL())
+	assert.Equal(t, "http://localhost:8080", Config{Domain: "http://localhost:8080/"}.baseURL())
+	assert.Equal(t, "https://example.okta.com/oauth2/default", Config{Domain: "example.okta.com"}.issuer())
+	assert.Equal(t, "https://example.okta.com/oauth2/aus1", Config{Domain: "example.okta.com", Issuer: "https://example.okta.com/oauth2/aus1/"}.issuer())
+}
+
+func TestTokenManager_ReusesToken(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	for range 3 {
+		token, err := u.m2mTokenManager.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, testServiceToken, token)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Equal(t, 1, fake.tokenRequests)
+}
+
+func TestTokenManager_RejectedAssertion(t *testing.T) {
+	fake := newFakeOkta(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tokenManager, err := NewM2MTokenManager(context.Background(), Config{
+		Domain:     fake.server.URL,
+		ClientID:   testClientID,
+		PrivateKey: privateKeyPEM(t, otherKey),
+	}, http.DefaultClient)
+	require.NoError(t, err)
+
+	_, err = tokenManager.GetToken(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_client")
+}
+
+func TestUserReaderWriter_GetUser(t *testing.T) {
+	ctx := context.Background()
+	u, _, _, _ := newTestUserReaderWriter(t)
+
+	user, err := u.GetUser(ctx, &model.User{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", user.Username)
+	assert.Equal(t, "jane@example.com", user.PrimaryEmail)
+	assert.Equal(t, "Lisbon", *user.UserMetadata.City)
+	assert.Equal(t, []model.Email{{Email: "jane@work.example.com", Verified: true}}, user.AlternateEmails)
+	assert.Equal(t, []model.Identity{{Provider: "google", IdentityID: testIdpID, Name: "Google", IsSocial: true}}, user.Identities)
+
+	_, err = u.GetUser(ctx, &model.User{UserID: "00u0000000000000000"})
+	require.Error(t, err)
+	assert.IsType(t, errors.NotFound{}, err)
+
+	_, err = u.GetUser(ctx, &model.User{})
+	require.Error(t, err)
+	assert.IsType(t, errors.Validation{}, err)
+}
+
+func TestUserReaderWriter_SearchUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	tests := []struct {
+		name       string
+		user       *model.User
+		criteria   constants.CriteriaType
+		wantSearch string
+		wantErr    any
+	}{
+		{
+			name:       "by email",
+			user:       &model.User{PrimaryEmail: "Jane@Example.com"},
+			criteria:   constants.CriteriaTypeEmail,
+			wantSearch: `profile.email eq "Jane@Example.com"`,
+		},
+		{
+			name:       "by username",
+			user:       &model.User{Username: "jane@example.com"},
+			criteria:   constants.CriteriaTypeUsername,
+			wantSearch: `profile.login eq "jane@example.com"`,
+		},
+		{
+			name:       "by alternate email",
+			user:       &model.User{AlternateEmails: []model.Email{{Email: "Jane@Work.example.com"}}},
+			criteria:   constants.CriteriaTypeAlternateEmail,
+			wantSearch: `profile.alternateEmails eq "jane@work.example.com"`,
+		},
+		{
+			name:     "quotes are escaped",
+			user:     &model.User{PrimaryEmail: `jane@example.com" or profile.login sw "`},
+			criteria: constants.CriteriaTypeEmail,
+			wantErr:  errors.NotFound{},
+		},
+		{
+			name:     "missing email",
+			user:     &model.User{},
+			criteria: constants.CriteriaTypeEmail,
+			wantErr:  errors.Validation{},
+		},
+		{
+			name:     "invalid criteria",
+			user:     &model.User{PrimaryEmail: "jane@example.com"},
+			criteria: "phone",
+			wantErr:  errors.Validation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := u.SearchUser(ctx, tt.user, tt.criteria)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, user.UserID)
+
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			assert.Equal(t, tt.wantSearch, fake.searches[len(fake.searches)-1])
+		})
+	}
+}
+
+func TestUserReaderWriter_MetadataLookup(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	token := fake.userToken(testUserID, "openid", "profile")
+
+	tests := []struct {
+		name     string
+		input    string
+		scopes   []string
+		expected *model.User
+		wantErr  bool
+	}{
+		{
+			name:     "user token",
+			input:    "Bearer " + token,
+			expected: &model.User{Token: token, UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:    "user token without the required scope",
+			input:   token,
+			scopes:  []string{constants.UserUpdateMetadataRequiredScope},
+			wantErr: true,
+		},
+		{
+			name:     "user id",
+			input:    testUserID,
+			expected: &model.User{UserID: testUserID, Sub: testUserID},
+		},
+		{
+			name:     "login",
+			input:    "jane@example.com",
+			expected: &model.User{Username: "jane@example.com"},
+		},
+		{
+			name: "token of another issuer",
+			input: signedToken(t, jwt.MapClaims{
+				"sub": "jane@example.com",
+				"uid": testUserID,
+				"exp": time.Now().Add(time.Hour).Unix(),
+				"iss": "https://other.okta.com/oauth2/default",
+				"aud": "api://default",
+			}, fake.authorizationServerKey),
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			input:   " ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := u.MetadataLookup(ctx, tt.input, tt.scopes...)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, user)
+		})
+	}
+}
+
+func TestUserReaderWriter_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	t.Run("requires the update scope", func(t *testing.T) {
+		_, err := u.UpdateUser(ctx, &model.User{
+			Token:        fake.userToken(testUserID, "openid"),
+			UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Engineer")},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("updates the profile partially", func(t *testing.T) {
+		updated, err := u.UpdateUser(ctx, &model.User{
+			Token: fake.userToken(testUserID, "openid", constants.UserUpdateMetadataRequiredScope),
+			UserMetadata: &model.UserMetadata{
+				FamilyName:           converters.StringPtr("Doe"),
+				JobTitle:             converters.StringPtr("Engineer"),
+				OrganizationVerified: converters.BoolPtr(true),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, updated.UserID)
+		assert.Equal(t, "Engineer", *updated.UserMetadata.JobTitle)
+		assert.Equal(t, "Lisbon", *updated.UserMetadata.City)
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		stored := fake.users[testUserID].Profile
+		assert.Equal(t, "Jane", *stored.FirstName)
+		assert.Equal(t, "Doe", *stored.LastName)
+		assert.True(t, *stored.OrganizationVerified)
+		assert.Equal(t, []string{"jane@work.example.com"}, stored.AlternateEmails)
+	})
+}
+
+func TestUserReaderWriter_AlternateEmailLinking(t *testing.T) {
+	ctx := context.Background()
+	u, fake, sender, fakeClock := newTestUserReaderWriter(t)
+
+	const alternateEmail = "jane@personal.example.com"
+
+	require.NoError(t, u.SendVerificationAlternateEmail(ctx, alternateEmail))
+	otp := sender.otp(alternateEmail)
+	require.Len(t, otp, 6)
+
+	_, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: "000000" + otp})
+	require.Error(t, err, "a wrong OTP is rejected")
+
+	authResponse, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.NoError(t, err)
+	require.NotEmpty(t, authResponse.IDToken)
+
+	_, err = u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.Error(t, err, "the OTP can only be used once")
+
+	request := &model.LinkIdentity{}
+	request.User.UserID = testUserID
+	request.LinkWith.IdentityToken = authResponse.IDToken
+
+	require.NoError(t, u.ValidateLinkRequest(ctx, request))
+	require.NoError(t, u.LinkIdentity(ctx, request))
+	require.NoError(t, u.LinkIdentity(ctx, request), "linking twice is a no-op")
+
+	fake.mu.Lock()
+	assert.Equal(t, []string{"jane@work.example.com", alternateEmail}, fake.users[testUserID].Profile.AlternateEmails)
+	fake.mu.Unlock()
+
+	// the identity token expires
+	fakeClock.Advance(emaillinking.IdentityTokenTTL + time.Minute)
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+
+	// a token signed by the authorization server is not an identity token
+	request.LinkWith.IdentityToken = fake.userToken(testUserID)
+	require.Error(t, u.ValidateLinkRequest(ctx, request))
+}
+
+func TestUserReaderWriter_VerifyAlternateEmailExpiredOTP(t *testing.T) {
+	ctx := context.Background()
+	u, _, sender, fakeClock := newTestUserReaderWriter(t)
+
+	const alternateEmail = "jane@personal.example.com"
+
+	require.NoError(t, u.SendVerificationAlternateEmail(ctx, alternateEmail))
+	otp := sender.otp(alternateEmail)
+
+	fakeClock.Advance(emaillinking.CodeTTL + time.Second)
+	_, err := u.VerifyAlternateEmail(ctx, &model.Email{Email: alternateEmail, OTP: otp})
+	require.Error(t, err)
+	assert.IsType(t, errors.Validation{}, err)
+}
+
+func TestUserReaderWriter_UnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	u, fake, _, _ := newTestUserReaderWriter(t)
+
+	unlink := func(provider, identityID string) error {
+		request := &model.UnlinkIdentity{}
+		request.User.UserID = testUserID
+		request.Unlink.Provider = provider
+		request.Unlink.IdentityID = identityID
+		return u.UnlinkIdentity(ctx, request)
+	}
+
+	t.Run("alternate email", func(t *testing.T) {
+		require.NoError(t, unlink("email", "Jane@Work.example.com"))
+
+		fake.mu.Lock()
+		assert.Empty(t, fake.users[testUserID].Profile.AlternateEmails)
+		fake.mu.Unlock()
+
+		err := unlink("email", "jane@work.example.com")
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFound{}, err)
+	})
+
+	t.Run("identity provider", func(t *testing.T) {
+		require.NoError(t, unlink("google", testIdpID))
+
+		fake.mu.Lock()
+		assert.Empty(t, fake.identityProviders[testUserID])
+		fake.mu.Unlock()
+
+		err := unlink("google", testIdpID)
+		require.Error(t, err)
+		assert.IsType(t, errors.NotFound{}, err)
+	})
+
+	t.Run("missing identity id", func(t *testing.T) {
+		err := unlink("google", "")
+		require.Error(t, err)
+		assert.IsType(t, errors.Validation{}, err)
+	})
+}
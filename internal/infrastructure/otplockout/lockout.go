@@ -0,0 +1,173 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package otplockout protects the verification of the email linking OTPs against brute force:
+// the failed attempts are counted per email, and the verification of the email is locked for a
+// cooldown once too many attempts failed. The counts are kept in a pluggable store, in memory or
+// in a NATS KV bucket shared by the replicas.
+package otplockout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// Policy locks the verification of an email for Cooldown once MaxFailures attempts failed within
+// the cooldown, a zero MaxFailures disables the lockout
+type Policy struct {
+	MaxFailures int64
+	Cooldown    time.Duration
+}
+
+// DefaultPolicy locks the verification for 15 minutes after 5 failed attempts
+var DefaultPolicy = Policy{MaxFailures: 5, Cooldown: 15 * time.Minute}
+
+// ParsePolicy parses a spec of the form "failures/cooldown" (e.g. "5/15m"), the default policy
+// when empty
+func ParsePolicy(spec string) (Policy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return DefaultPolicy, nil
+	}
+	failuresRaw, cooldownRaw, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid lockout policy %q, expected failures/cooldown", spec)
+	}
+	failures, err := strconv.ParseInt(strings.TrimSpace(failuresRaw), 10, 64)
+	if err != nil || failures < 0 {
+		return Policy{}, fmt.Errorf("invalid lockout failures in %q", spec)
+	}
+	cooldown, err := time.ParseDuration(strings.TrimSpace(cooldownRaw))
+	if err != nil || cooldown <= 0 {
+		return Policy{}, fmt.Errorf("invalid lockout cooldown in %q", spec)
+	}
+	return Policy{MaxFailures: failures, Cooldown: cooldown}, nil
+}
+
+// State is the failed attempts of an email
+type State struct {
+	Failures       int64     `json:"failures"`
+	FirstFailureAt time.Time `json:"first_failure_at"`
+	LockedUntil    time.Time `json:"locked_until,omitempty"`
+}
+
+// expiresAt is the time the state is no longer needed
+func (s State) expiresAt(cooldown time.Duration) time.Time {
+	expiresAt := s.FirstFailureAt.Add(cooldown)
+	if s.LockedUntil.After(expiresAt) {
+		return s.LockedUntil
+	}
+	return expiresAt
+}
+
+// Store keeps the states by key, Update applies the change atomically and Get returns the zero
+// state for an unknown key
+type Store interface {
+	Get(ctx context.Context, key string) (State, error)
+	Update(ctx context.Context, key string, update func(State) State, expiresAt func(State) time.Time) (State, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Lockout enforces the lockout policy
+type Lockout struct {
+	store  Store
+	policy Policy
+	now    func() time.Time
+}
+
+// key hashes the email, the keys don't expose the emails
+func key(email string) string {
+	digest := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return base64.RawURLEncoding.EncodeToString(digest[:16])
+}
+
+// locked returns the error of a locked verification
+func (l *Lockout) locked(lockedUntil, now time.Time) error {
+	return errs.NewTooManyRequests("too many failed verification attempts, try again later").WithRetryAfter(lockedUntil.Sub(now))
+}
+
+// Check fails with a TooManyRequests error while the verification of the email is locked.
+// It fails open: the verification is allowed when the store can't be read.
+func (l *Lockout) Check(ctx context.Context, email string) error {
+	if l.policy.MaxFailures <= 0 {
+		return nil
+	}
+	state, err := l.store.Get(ctx, key(email))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read the verification attempts, allowing the verification", "error", err)
+		return nil
+	}
+	if now := l.now(); now.Before(state.LockedUntil) {
+		return l.locked(state.LockedUntil, now)
+	}
+	return nil
+}
+
+// Fail records a failed attempt, it returns the number of failures and whether this attempt
+// locked the verification of the email
+func (l *Lockout) Fail(ctx context.Context, email string) (int64, bool) {
+	if l.policy.MaxFailures <= 0 {
+		return 0, false
+	}
+	now := l.now()
+	var justLocked bool
+	state, err := l.store.Update(ctx, key(email), func(state State) State {
+		justLocked = false
+		switch {
+		case now.Before(state.LockedUntil):
+			// the attempt raced the lock, it stays locked
+		case !state.LockedUntil.IsZero() || now.Sub(state.FirstFailureAt) >= l.policy.Cooldown:
+			// the lock expired, or the failures are older than the cooldown
+			state = State{}
+		}
+		if state.Failures == 0 {
+			state.FirstFailureAt = now
+		}
+		state.Failures++
+		if state.Failures >= l.policy.MaxFailures && state.LockedUntil.IsZero() {
+			state.LockedUntil = now.Add(l.policy.Cooldown)
+			justLocked = true
+		}
+		return state
+	}, func(state State) time.Time { return state.expiresAt(l.policy.Cooldown) })
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record the failed verification attempt", "error", err)
+		return 0, false
+	}
+	if justLocked {
+		slog.WarnContext(ctx, "email verification locked after too many failed attempts",
+			"email", redaction.RedactEmail(email),
+			"failures", state.Failures,
+			"locked_until", state.LockedUntil,
+		)
+	}
+	return state.Failures, justLocked
+}
+
+// Succeed forgets the failed attempts of the email
+func (l *Lockout) Succeed(ctx context.Context, email string) {
+	if l.policy.MaxFailures <= 0 {
+		return
+	}
+	if err := l.store.Delete(ctx, key(email)); err != nil {
+		slog.WarnContext(ctx, "failed to reset the verification attempts", "error", err)
+	}
+}
+
+// New creates a lockout keeping the failed attempts in the store
+func New(store Store, policy Policy) *Lockout {
+	return &Lockout{
+		store:  store,
+		policy: policy,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package otplockout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next updates fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+	getErr    error
+}
+
+func (f *fakeKV) Get(_ context.Context, key string) (jetstream.KeyValueEntry, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Create(_ context.Context, key string, value []byte, _ ...jetstream.KVCreateOpt) (uint64, error) {
+	if _, ok := f.data[key]; ok {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.data[key] = fakeEntry{value: value, revision: 1}
+	return 1, nil
+}
+
+func (f *fakeKV) Update(_ context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func (f *fakeKV) Delete(_ context.Context, key string, _ ...jetstream.KVDeleteOpt) error {
+	delete(f.data, key)
+	return nil
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Policy
+		wantErr bool
+	}{
+		{name: "default", spec: "", want: DefaultPolicy},
+		{name: "custom", spec: " 3 / 1h ", want: Policy{MaxFailures: 3, Cooldown: time.Hour}},
+		{name: "disabled", spec: "0/1m", want: Policy{MaxFailures: 0, Cooldown: time.Minute}},
+		{name: "missing cooldown", spec: "5", wantErr: true},
+		{name: "invalid failures", spec: "-1/15m", wantErr: true},
+		{name: "invalid cooldown", spec: "5/soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+func TestLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	stores := map[string]Store{
+		"memory": &MemoryStore{entries: make(map[string]memoryEntry), now: clock},
+		"kv":     &KVStore{kv: &fakeKV{data: make(map[string]fakeEntry), conflicts: 1}},
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			now = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+			lockout := New(store, Policy{MaxFailures: 3, Cooldown: 15 * time.Minute})
+			lockout.now = clock
+
+			for i := int64(1); i < 3; i++ {
+				require.NoError(t, lockout.Check(ctx, "jane@example.com"))
+				failures, locked := lockout.Fail(ctx, "Jane@Example.com")
+				assert.Equal(t, i, failures)
+				assert.False(t, locked)
+			}
+			failures, locked := lockout.Fail(ctx, "jane@example.com")
+			assert.Equal(t, int64(3), failures)
+			assert.True(t, locked, "the third failure locks the verification")
+
+			now = now.Add(5 * time.Minute)
+			err := lockout.Check(ctx, "jane@example.com")
+			var tooMany errs.TooManyRequests
+			require.True(t, errors.As(err, &tooMany))
+			assert.Equal(t, 10*time.Minute, tooMany.RetryAfter())
+			assert.NoError(t, lockout.Check(ctx, "john@example.com"), "the other emails are not locked")
+
+			_, locked = lockout.Fail(ctx, "jane@example.com")
+			assert.False(t, locked, "a raced attempt doesn't lock again")
+
+			now = now.Add(10 * time.Minute)
+			require.NoError(t, lockout.Check(ctx, "jane@example.com"), "the lock expired")
+			failures, _ = lockout.Fail(ctx, "jane@example.com")
+			assert.Equal(t, int64(1), failures, "the failures start over after a lock")
+
+			lockout.Succeed(ctx, "jane@example.com")
+			failures, _ = lockout.Fail(ctx, "jane@example.com")
+			assert.Equal(t, int64(1), failures, "a success forgets the failures")
+		})
+	}
+}
+
+func TestLockout_Cooldown(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	store := &MemoryStore{entries: make(map[string]memoryEntry), now: func() time.Time { return now }}
+	lockout := New(store, Policy{MaxFailures: 2, Cooldown: 15 * time.Minute})
+	lockout.now = func() time.Time { return now }
+
+	lockout.Fail(ctx, "jane@example.com")
+	now = now.Add(20 * time.Minute)
+	failures, locked := lockout.Fail(ctx, "jane@example.com")
+	assert.Equal(t, int64(1), failures, "the failures older than the cooldown are forgotten")
+	assert.False(t, locked)
+	assert.Len(t, store.entries, 1)
+}
+
+func TestLockout_FailOpen(t *testing.T) {
+	ctx := context.Background()
+	lockout := New(&KVStore{kv: &fakeKV{data: make(map[string]fakeEntry), getErr: errors.New("bucket unavailable")}}, DefaultPolicy)
+
+	failures, locked := lockout.Fail(ctx, "jane@example.com")
+	assert.Zero(t, failures)
+	assert.False(t, locked)
+	assert.NoError(t, lockout.Check(ctx, "jane@example.com"))
+
+	disabled := New(NewMemoryStore(), Policy{})
+	for i := 0; i < 10; i++ {
+		_, locked := disabled.Fail(ctx, "jane@example.com")
+		assert.False(t, locked)
+	}
+	assert.NoError(t, disabled.Check(ctx, "jane@example.com"))
+}
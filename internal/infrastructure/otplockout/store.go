@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package otplockout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// keyPrefix keeps the failed attempts apart from the other entries of the bucket
+	keyPrefix = "otplockout."
+
+	// maxUpdateAttempts bounds the attempts of a state updated concurrently
+	maxUpdateAttempts = 5
+)
+
+// memoryEntry is a state of the memory store
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the states in the memory of the replica
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+// Get returns the state of the key
+func (m *MemoryStore) Get(_ context.Context, key string) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok || !m.now().Before(entry.expiresAt) {
+		return State{}, nil
+	}
+	return entry.state, nil
+}
+
+// Update applies the update to the state of the key
+func (m *MemoryStore) Update(_ context.Context, key string, update func(State) State, expiresAt func(State) time.Time) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the expired states are dropped on the way
+	now := m.now()
+	for k, entry := range m.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(m.entries, k)
+		}
+	}
+
+	state := update(m.entries[key].state)
+	m.entries[key] = memoryEntry{state: state, expiresAt: expiresAt(state)}
+	return state, nil
+}
+
+// Delete removes the state of the key
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// NewMemoryStore creates a store keeping the states in memory, each replica counts its own attempts
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// kvStore is the subset of the KV bucket used by the KV store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+	Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error
+}
+
+// KVStore keeps the states in a NATS KV bucket shared by the replicas, the states carry their
+// own times so the stale ones left by the bucket are ignored by the lockout
+type KVStore struct {
+	kv kvStore
+}
+
+// Get returns the state of the key
+func (s *KVStore) Get(ctx context.Context, key string) (State, error) {
+	entry, err := s.kv.Get(ctx, keyPrefix+key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+	var state State
+	if err := json.Unmarshal(entry.Value(), &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+// Update applies the update to the state of the key, again when another replica changed it in between
+func (s *KVStore) Update(ctx context.Context, key string, update func(State) State, _ func(State) time.Time) (State, error) {
+	key = keyPrefix + key
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var (
+			current  State
+			revision uint64
+		)
+		entry, errGet := s.kv.Get(ctx, key)
+		switch {
+		case errors.Is(errGet, jetstream.ErrKeyNotFound):
+		case errGet != nil:
+			return State{}, errGet
+		default:
+			// a deleted key reads as not found, an unreadable state starts over
+			_ = json.Unmarshal(entry.Value(), &current)
+			revision = entry.Revision()
+		}
+
+		state := update(current)
+		data, errMarshal := json.Marshal(state)
+		if errMarshal != nil {
+			return State{}, errMarshal
+		}
+
+		var errWrite error
+		if revision == 0 {
+			_, errWrite = s.kv.Create(ctx, key, data)
+		} else {
+			_, errWrite = s.kv.Update(ctx, key, data, revision)
+		}
+		if errWrite == nil {
+			return state, nil
+		}
+		// the state changed in between, read it again
+	}
+
+	return State{}, errors.New("verification attempts contended")
+}
+
+// Delete removes the state of the key
+func (s *KVStore) Delete(ctx context.Context, key string) error {
+	err := s.kv.Delete(ctx, keyPrefix+key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil
+	}
+	return err
+}
+
+// NewKVStore creates a store keeping the states in the given KV bucket
+func NewKVStore(kv jetstream.KeyValue) *KVStore {
+	return &KVStore{kv: kv}
+}
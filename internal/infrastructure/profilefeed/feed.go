@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package profilefeed fans out the profile changed events received from NATS to the local
+// subscribers (the HTTP streams opened by browsers), every replica receives all the events
+// and notifies the streams it serves.
+package profilefeed
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultMaxSubscriptionsPerUser bounds the open streams of a user (tabs, devices)
+	DefaultMaxSubscriptionsPerUser = 10
+
+	// subscriptionBuffer is the number of events kept for a slow subscriber, the next ones are dropped.
+	// The events only tell the profile changed, a pending event is enough to refresh it.
+	subscriptionBuffer = 4
+)
+
+// Feed dispatches the profile changed events to the subscriptions of the user
+type Feed struct {
+	maxPerUser int
+
+	mu            sync.Mutex
+	subscriptions map[string]map[chan model.UserProfileChanged]struct{}
+}
+
+// SubscribeProfileChanges subscribes to the profile changes of the user until the context is done
+func (f *Feed) SubscribeProfileChanges(ctx context.Context, sub string) (<-chan model.UserProfileChanged, error) {
+	if sub == "" {
+		return nil, errs.NewValidation("sub is required")
+	}
+
+	ch := make(chan model.UserProfileChanged, subscriptionBuffer)
+
+	f.mu.Lock()
+	if len(f.subscriptions[sub]) >= f.maxPerUser {
+		f.mu.Unlock()
+		return nil, errs.NewTooManyRequests("too many open profile streams")
+	}
+	if f.subscriptions[sub] == nil {
+		f.subscriptions[sub] = make(map[chan model.UserProfileChanged]struct{})
+	}
+	f.subscriptions[sub][ch] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscriptions[sub], ch)
+		if len(f.subscriptions[sub]) == 0 {
+			delete(f.subscriptions, sub)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Dispatch notifies the subscriptions of the user of a profile changed event, it never blocks
+func (f *Feed) Dispatch(ctx context.Context, data []byte) {
+	var event model.UserProfileChanged
+	if err := json.Unmarshal(data, &event); err != nil || event.Sub == "" {
+		slog.WarnContext(ctx, "invalid profile changed event", "error", err)
+		return
+	}
+	// the republish requests only refresh the search stream, the profile didn't change
+	if event.Reason == model.ProfileChangeRepublish {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subscriptions[event.Sub] {
+		select {
+		case ch <- event:
+		default:
+			slog.DebugContext(ctx, "profile stream is behind, event dropped",
+				"sub", redaction.Redact(event.Sub),
+			)
+		}
+	}
+}
+
+// New creates a feed accepting up to maxPerUser subscriptions per user
+func New(maxPerUser int) *Feed {
+	if maxPerUser <= 0 {
+		maxPerUser = DefaultMaxSubscriptionsPerUser
+	}
+	return &Feed{
+		maxPerUser:    maxPerUser,
+		subscriptions: make(map[string]map[chan model.UserProfileChanged]struct{}),
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package profilefeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func receive(t *testing.T, ch <-chan model.UserProfileChanged) (model.UserProfileChanged, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the subscription")
+		return model.UserProfileChanged{}, false
+	}
+}
+
+func TestFeed_Dispatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := New(0)
+	mine, err := feed.SubscribeProfileChanges(ctx, "auth0|123")
+	if err != nil {
+		t.Fatalf("SubscribeProfileChanges() unexpected error: %v", err)
+	}
+	other, err := feed.SubscribeProfileChanges(ctx, "auth0|456")
+	if err != nil {
+		t.Fatalf("SubscribeProfileChanges() unexpected error: %v", err)
+	}
+
+	feed.Dispatch(ctx, []byte(`{"sub":"auth0|123","reason":"republish","changed_at":"2025-01-01T00:00:00Z"}`))
+	feed.Dispatch(ctx, []byte(`{"sub":"auth0|123","reason":"update","changed_at":"2025-01-01T00:00:00Z"}`))
+	feed.Dispatch(ctx, []byte(`not json`))
+
+	event, _ := receive(t, mine)
+	if event.Sub != "auth0|123" || event.Reason != model.ProfileChangeUpdate {
+		t.Errorf("received %+v", event)
+	}
+	select {
+	case event := <-other:
+		t.Errorf("other user received %+v", event)
+	default:
+	}
+}
+
+func TestFeed_SlowSubscriberDoesNotBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feed := New(0)
+	ch, err := feed.SubscribeProfileChanges(ctx, "auth0|123")
+	if err != nil {
+		t.Fatalf("SubscribeProfileChanges() unexpected error: %v", err)
+	}
+
+	for i := 0; i < subscriptionBuffer*3; i++ {
+		feed.Dispatch(ctx, []byte(`{"sub":"auth0|123","reason":"update"}`))
+	}
+	if len(ch) != subscriptionBuffer {
+		t.Errorf("pending events = %d, want %d", len(ch), subscriptionBuffer)
+	}
+}
+
+func TestFeed_SubscriptionLifecycle(t *testing.T) {
+	feed := New(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := feed.SubscribeProfileChanges(ctx, "auth0|123")
+	if err != nil {
+		t.Fatalf("SubscribeProfileChanges() unexpected error: %v", err)
+	}
+
+	_, errLimit := feed.SubscribeProfileChanges(context.Background(), "auth0|123")
+	var tooMany errs.TooManyRequests
+	if !errors.As(errLimit, &tooMany) {
+		t.Fatalf("SubscribeProfileChanges() error = %v, want TooManyRequests", errLimit)
+	}
+
+	// the channel is closed and the slot released once the context is done
+	cancel()
+	if _, ok := receive(t, ch); ok {
+		t.Fatal("subscription channel not closed")
+	}
+
+	other, cancelOther := context.WithCancel(context.Background())
+	defer cancelOther()
+	if _, err := feed.SubscribeProfileChanges(other, "auth0|123"); err != nil {
+		t.Errorf("SubscribeProfileChanges() after release unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package profileindex serves the typeahead search from an in-memory index of the public profile
+// documents of the profiles stream. Every replica follows the stream and keeps its own index, the
+// terms are the username and the names of the users, lowercased and without diacritics.
+package profileindex
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// term is an indexed prefix searchable value of a user
+type term struct {
+	value string
+	sub   string
+}
+
+func compareTerms(a, b term) int {
+	if c := strings.Compare(a.value, b.value); c != 0 {
+		return c
+	}
+	return strings.Compare(a.sub, b.sub)
+}
+
+// Index is the in-memory typeahead index of the public profiles
+type Index struct {
+	mu        sync.RWMutex
+	documents map[string]*model.ProfileDocument
+	// terms are sorted, the matches of a prefix are contiguous
+	terms []term
+
+	// ready is set once the documents of the stream are loaded
+	ready atomic.Bool
+}
+
+// normalize lowercases the value and removes the diacritics, so "jose" matches "José"
+func normalize(value string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		stripped = value
+	}
+	return strings.Join(strings.Fields(strings.ToLower(stripped)), " ")
+}
+
+// termsOf returns the searchable values of the document: the username, the full name and
+// each of its words, the given and family names
+func termsOf(document *model.ProfileDocument) []string {
+	var values []string
+	add := func(value string) {
+		value = normalize(value)
+		if value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	add(document.Username)
+	for _, name := range []string{document.Name, document.GivenName, document.FamilyName} {
+		add(name)
+		for _, word := range strings.Fields(name) {
+			add(word)
+		}
+	}
+	return values
+}
+
+func (i *Index) insertTerm(t term) {
+	position, found := slices.BinarySearchFunc(i.terms, t, compareTerms)
+	if !found {
+		i.terms = slices.Insert(i.terms, position, t)
+	}
+}
+
+func (i *Index) deleteTerm(t term) {
+	if position, found := slices.BinarySearchFunc(i.terms, t, compareTerms); found {
+		i.terms = slices.Delete(i.terms, position, position+1)
+	}
+}
+
+// Apply indexes the document, replacing the previous one of the user, the deleted users are removed
+func (i *Index) Apply(document *model.ProfileDocument) {
+	if document == nil || document.Sub == "" {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if previous, ok := i.documents[document.Sub]; ok {
+		for _, value := range termsOf(previous) {
+			i.deleteTerm(term{value: value, sub: previous.Sub})
+		}
+		delete(i.documents, document.Sub)
+	}
+
+	if document.Deleted {
+		return
+	}
+
+	i.documents[document.Sub] = document
+	for _, value := range termsOf(document) {
+		i.insertTerm(term{value: value, sub: document.Sub})
+	}
+}
+
+// Len returns the number of indexed users
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.documents)
+}
+
+// Typeahead returns up to limit users with a term starting with the query, in the order of the
+// matching terms: an exact username or name comes first
+func (i *Index) Typeahead(ctx context.Context, query string, limit int) ([]model.TypeaheadMatch, error) {
+	if !i.ready.Load() {
+		return nil, errs.NewServiceUnavailable("typeahead index is loading")
+	}
+
+	prefix := normalize(query)
+	if prefix == "" {
+		return nil, errs.NewValidation("query is required")
+	}
+	limit = model.TypeaheadLimit(limit)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	seen := make(map[string]struct{}, limit)
+	matches := make([]model.TypeaheadMatch, 0, limit)
+
+	position, _ := slices.BinarySearchFunc(i.terms, term{value: prefix}, compareTerms)
+	for ; position < len(i.terms) && len(matches) < limit; position++ {
+		t := i.terms[position]
+		if !strings.HasPrefix(t.value, prefix) {
+			break
+		}
+		if _, ok := seen[t.sub]; ok {
+			continue
+		}
+		seen[t.sub] = struct{}{}
+		matches = append(matches, i.documents[t.sub].TypeaheadMatch())
+	}
+
+	slog.DebugContext(ctx, "typeahead search", "matches", len(matches))
+	return matches, nil
+}
+
+// handle indexes a document of the stream, the index is ready once the stream is caught up
+func (i *Index) handle(ctx context.Context, msg jetstream.Msg) {
+	var document model.ProfileDocument
+	if err := json.Unmarshal(msg.Data(), &document); err != nil {
+		slog.WarnContext(ctx, "invalid profile document", "error", err, "subject", msg.Subject())
+	} else {
+		i.Apply(&document)
+	}
+
+	if metadata, err := msg.Metadata(); err == nil && metadata.NumPending == 0 && !i.ready.Load() {
+		i.ready.Store(true)
+		slog.InfoContext(ctx, "typeahead index loaded", "users", i.Len())
+	}
+}
+
+// Follow loads the latest document of every user from the profiles stream and keeps the index
+// up to date with the new ones until the context is done
+func (i *Index) Follow(ctx context.Context, js jetstream.JetStream) error {
+	stream, err := js.Stream(ctx, constants.StreamNameProfiles)
+	if err != nil {
+		return errs.NewUnexpected("profiles stream not found in NATS", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return errs.NewUnexpected("failed to get profiles stream info", err)
+	}
+	if info.State.Msgs == 0 {
+		i.ready.Store(true)
+	}
+
+	consumer, err := js.OrderedConsumer(ctx, constants.StreamNameProfiles, jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverLastPerSubjectPolicy,
+	})
+	if err != nil {
+		return errs.NewUnexpected("failed to create profiles consumer", err)
+	}
+
+	consumeContext, err := consumer.Consume(func(msg jetstream.Msg) {
+		i.handle(ctx, msg)
+	})
+	if err != nil {
+		return errs.NewUnexpected("failed to consume the profiles stream", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		consumeContext.Stop()
+	}()
+	return nil
+}
+
+// New creates an empty index, it's ready once Follow loaded the stream
+func New() *Index {
+	return &Index{
+		documents: make(map[string]*model.ProfileDocument),
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package profileindex
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeMsg is a message of the profiles stream
+type fakeMsg struct {
+	jetstream.Msg
+	data    []byte
+	pending uint64
+}
+
+func (f *fakeMsg) Data() []byte { return f.data }
+
+func (f *fakeMsg) Subject() string { return "lfx.auth-service.profiles.test" }
+
+func (f *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumPending: f.pending}, nil
+}
+
+func subsOf(matches []model.TypeaheadMatch) []string {
+	subs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		subs = append(subs, match.Sub)
+	}
+	return subs
+}
+
+func newLoadedIndex(documents ...*model.ProfileDocument) *Index {
+	index := New()
+	for _, document := range documents {
+		index.Apply(document)
+	}
+	index.ready.Store(true)
+	return index
+}
+
+func TestIndex_Typeahead(t *testing.T) {
+	ctx := context.Background()
+
+	index := newLoadedIndex(
+		&model.ProfileDocument{Sub: "auth0|1", Username: "jdoe", Name: "Jane Doe", Picture: "https://example.com/jane.png", Country: "PT"},
+		&model.ProfileDocument{Sub: "auth0|2", Username: "jsilva", Name: "José Silva"},
+		&model.ProfileDocument{Sub: "auth0|3", Username: "mdoerr", GivenName: "Max", FamilyName: "Doerr"},
+		&model.ProfileDocument{Sub: "auth0|4", Username: "zed"},
+	)
+	require.Equal(t, 4, index.Len())
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "username prefix", query: "jd", want: []string{"auth0|1"}},
+		{name: "family name prefix", query: "doe", want: []string{"auth0|1", "auth0|3"}},
+		{name: "full name prefix", query: "jane d", want: []string{"auth0|1"}},
+		{name: "diacritics and case are ignored", query: "JOSE", want: []string{"auth0|2"}},
+		{name: "a user matching several terms is returned once", query: "j", want: []string{"auth0|1", "auth0|2"}},
+		{name: "limit", query: "doe", limit: 1, want: []string{"auth0|1"}},
+		{name: "no match", query: "xy", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := index.Typeahead(ctx, tt.query, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, subsOf(matches))
+		})
+	}
+
+	t.Run("the matches only carry the public attributes of a mention", func(t *testing.T) {
+		matches, err := index.Typeahead(ctx, "max", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []model.TypeaheadMatch{{Sub: "auth0|3", Username: "mdoerr", Name: "Max Doerr"}}, matches)
+	})
+}
+
+func TestIndex_Apply(t *testing.T) {
+	ctx := context.Background()
+	index := newLoadedIndex(&model.ProfileDocument{Sub: "auth0|1", Username: "jdoe", Name: "Jane Doe"})
+
+	t.Run("a new document replaces the terms of the user", func(t *testing.T) {
+		index.Apply(&model.ProfileDocument{Sub: "auth0|1", Username: "jsmith", Name: "Jane Smith"})
+
+		matches, err := index.Typeahead(ctx, "doe", 0)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+
+		matches, err = index.Typeahead(ctx, "smi", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"auth0|1"}, subsOf(matches))
+	})
+
+	t.Run("a deleted user is removed", func(t *testing.T) {
+		index.Apply(model.DeletedProfileDocument("auth0|1", index.documents["auth0|1"].UpdatedAt))
+
+		matches, err := index.Typeahead(ctx, "jane", 0)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+		assert.Zero(t, index.Len())
+		assert.Empty(t, index.terms)
+	})
+}
+
+func TestIndex_Handle(t *testing.T) {
+	ctx := context.Background()
+	index := New()
+
+	_, err := index.Typeahead(ctx, "jane", 0)
+	require.Error(t, err)
+	assert.IsType(t, errs.ServiceUnavailable{}, err)
+
+	data, err := json.Marshal(&model.ProfileDocument{Sub: "auth0|1", Username: "jdoe", Name: "Jane Doe"})
+	require.NoError(t, err)
+
+	index.handle(ctx, &fakeMsg{data: data, pending: 1})
+	index.handle(ctx, &fakeMsg{data: []byte("not json"), pending: 1})
+	assert.False(t, index.ready.Load(), "the index is loading until the stream is caught up")
+
+	index.handle(ctx, &fakeMsg{data: []byte("not json")})
+	assert.True(t, index.ready.Load())
+
+	matches, err := index.Typeahead(ctx, "jane", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"auth0|1"}, subsOf(matches))
+}
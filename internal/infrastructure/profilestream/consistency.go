@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package profilestream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Diverged reports whether the document of the sub in the stream differs from the document of
+// the user read from the identity provider, the user is nil when it no longer exists
+func (p *Publisher) Diverged(ctx context.Context, sub string, user *model.User) (bool, error) {
+	if p.documents == nil {
+		return false, errs.NewUnexpected("profile documents reader not configured")
+	}
+
+	msg, errGet := p.documents.GetLastMsgForSubject(ctx, Subject(sub))
+	if errGet != nil {
+		if errors.Is(errGet, jetstream.ErrMsgNotFound) {
+			// a user never published, or not in the stream anymore
+			return user != nil, nil
+		}
+		return false, errs.NewUnexpected("failed to read profile document", errGet)
+	}
+
+	var published model.ProfileDocument
+	if err := json.Unmarshal(msg.Data, &published); err != nil {
+		return true, nil
+	}
+
+	// the update time of the document is not an attribute of the user
+	expected := model.DeletedProfileDocument(sub, published.UpdatedAt)
+	if user != nil {
+		expected = user.ProfileDocument(published.UpdatedAt)
+		expected.Sub = sub
+	}
+	return *expected != published, nil
+}
+
+// Repair publishes the document of the sub again
+func (p *Publisher) Repair(ctx context.Context, sub string, _ *model.User) error {
+	return p.Refresh(ctx, sub)
+}
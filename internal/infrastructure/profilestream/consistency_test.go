@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package profilestream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+// GetLastMsgForSubject returns the last document published on the subject
+func (f *fakeJetStream) GetLastMsgForSubject(_ context.Context, subject string) (*jetstream.RawStreamMsg, error) {
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		if f.messages[i].subject == subject {
+			return &jetstream.RawStreamMsg{Subject: subject, Data: f.messages[i].data}, nil
+		}
+	}
+	return nil, jetstream.ErrMsgNotFound
+}
+
+func TestPublisher_Diverged(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	reader := &fakeUserReader{users: map[string]*model.User{
+		"auth0|123": {UserID: "auth0|123", Username: "jdoe"},
+	}}
+	js := &fakeJetStream{}
+	publisher := NewPublisher(reader, js, WithClock(clock.NewFake(now)), WithDocumentReader(js))
+
+	diverged, err := publisher.Diverged(ctx, "auth0|123", reader.users["auth0|123"])
+	require.NoError(t, err)
+	assert.True(t, diverged, "the document of the user is missing")
+
+	diverged, err = publisher.Diverged(ctx, "auth0|456", nil)
+	require.NoError(t, err)
+	assert.False(t, diverged, "a user never published has no document")
+
+	require.NoError(t, publisher.Repair(ctx, "auth0|123", reader.users["auth0|123"]))
+	diverged, err = publisher.Diverged(ctx, "auth0|123", reader.users["auth0|123"])
+	require.NoError(t, err)
+	assert.False(t, diverged)
+
+	diverged, err = publisher.Diverged(ctx, "auth0|123", &model.User{UserID: "auth0|123", Username: "jdoe-renamed"})
+	require.NoError(t, err)
+	assert.True(t, diverged)
+
+	diverged, err = publisher.Diverged(ctx, "auth0|123", nil)
+	require.NoError(t, err)
+	assert.True(t, diverged, "the user deleted in the provider is not a tombstone yet")
+
+	_, err = NewPublisher(reader, js).Diverged(ctx, "auth0|123", nil)
+	assert.Error(t, err)
+}
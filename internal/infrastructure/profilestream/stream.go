@@ -0,0 +1,246 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package profilestream materializes the public profile documents in a JetStream stream for the
+// people search across LFX. The profile changed events are read through the identity provider
+// and the document of the user is published on its own subject, the stream keeps the latest
+// document of every user so the search indexers can bootstrap from it and follow the changes.
+package profilestream
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	resultPublished = "published"
+	resultDeleted   = "deleted"
+	resultFailed    = "failed"
+
+	// listFetchBatch is the number of documents fetched at once while listing the stream
+	listFetchBatch = 256
+)
+
+// JetStreamPublisher publishes the documents to the stream, implemented by jetstream.JetStream
+type JetStreamPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// DocumentReader reads the latest document of a subject, implemented by jetstream.Stream
+type DocumentReader interface {
+	GetLastMsgForSubject(ctx context.Context, subject string) (*jetstream.RawStreamMsg, error)
+}
+
+// Publisher publishes the profile document of the changed users
+type Publisher struct {
+	reader    port.UserReader
+	js        JetStreamPublisher
+	documents DocumentReader
+	clock     clock.Clock
+	published metric.Int64Counter
+}
+
+// Option configures the Publisher
+type Option func(*Publisher)
+
+// WithClock sets the time source of the document update times
+func WithClock(c clock.Clock) Option {
+	return func(p *Publisher) {
+		p.clock = c
+	}
+}
+
+// WithDocumentReader sets the reader of the published documents, the profiles stream by default
+func WithDocumentReader(documents DocumentReader) Option {
+	return func(p *Publisher) {
+		p.documents = documents
+	}
+}
+
+// Subject returns the subject of the documents of the user, the sub can contain characters
+// not allowed in a subject token (auth0|123 is fine, but not every provider id is)
+func Subject(sub string) string {
+	return constants.ProfilesSubject + "." + base64.RawURLEncoding.EncodeToString([]byte(sub))
+}
+
+// subFromSubject returns the sub of the user of a document subject
+func subFromSubject(subject string) (string, bool) {
+	token, found := strings.CutPrefix(subject, constants.ProfilesSubject+".")
+	if !found {
+		return "", false
+	}
+	sub, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sub) == 0 {
+		return "", false
+	}
+	return string(sub), true
+}
+
+// Handle publishes the document of the user of a profile changed event, it's the NATS handler
+// of the events. A failure is only logged, the next change or a republish catches up.
+func (p *Publisher) Handle(ctx context.Context, data []byte) {
+	var event model.UserProfileChanged
+	if err := json.Unmarshal(data, &event); err != nil || event.Sub == "" {
+		slog.WarnContext(ctx, "invalid profile changed event", "error", err)
+		return
+	}
+	if event.Reason == model.ProfileChangeSubscribed {
+		return
+	}
+
+	if err := p.Refresh(ctx, event.Sub); err != nil {
+		slog.ErrorContext(ctx, "failed to publish the profile document",
+			"error", err,
+			"sub", redaction.Redact(event.Sub),
+			"reason", event.Reason,
+		)
+	}
+}
+
+// Refresh reads the user from the identity provider and publishes its document, or its
+// tombstone when the user is no longer found
+func (p *Publisher) Refresh(ctx context.Context, sub string) error {
+	if sub == "" {
+		return errs.NewValidation("sub is required")
+	}
+
+	var (
+		document *model.ProfileDocument
+		result   = resultPublished
+		notFound errs.NotFound
+	)
+
+	user, errGetUser := p.reader.GetUser(ctx, &model.User{UserID: sub, Sub: sub})
+	switch {
+	case errGetUser == nil:
+		document = user.ProfileDocument(p.clock.Now().UTC())
+		// the document is published under the sub of the event, whatever the provider returns
+		document.Sub = sub
+	case errors.As(errGetUser, &notFound):
+		document = model.DeletedProfileDocument(sub, p.clock.Now().UTC())
+		result = resultDeleted
+	default:
+		p.record(ctx, resultFailed)
+		return errGetUser
+	}
+
+	data, errMarshal := json.Marshal(document)
+	if errMarshal != nil {
+		p.record(ctx, resultFailed)
+		return errs.NewUnexpected("failed to marshal profile document", errMarshal)
+	}
+
+	if _, errPublish := p.js.Publish(ctx, Subject(sub), data); errPublish != nil {
+		p.record(ctx, resultFailed)
+		return errs.NewServiceUnavailable("failed to publish profile document", errPublish)
+	}
+
+	p.record(ctx, result)
+	slog.DebugContext(ctx, "profile document published",
+		"sub", redaction.Redact(sub),
+		"result", result,
+	)
+	return nil
+}
+
+func (p *Publisher) record(ctx context.Context, result string) {
+	if p.published == nil {
+		return
+	}
+	p.published.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// ListSubs returns the subs of the users with a document in the stream, to republish them
+func ListSubs(ctx context.Context, js jetstream.JetStream) ([]string, error) {
+	stream, err := js.Stream(ctx, constants.StreamNameProfiles)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to get profiles stream", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to get profiles stream info", err)
+	}
+	if info.State.Msgs == 0 {
+		return nil, nil
+	}
+
+	consumer, err := js.OrderedConsumer(ctx, constants.StreamNameProfiles, jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverLastPerSubjectPolicy,
+	})
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to create profiles consumer", err)
+	}
+
+	var subs []string
+	for {
+		batch, err := consumer.Fetch(listFetchBatch, jetstream.FetchMaxWait(time.Second))
+		if err != nil {
+			return nil, errs.NewUnexpected("failed to fetch profile documents", err)
+		}
+
+		for msg := range batch.Messages() {
+			if sub, ok := subFromSubject(msg.Subject()); ok {
+				subs = append(subs, sub)
+			}
+
+			metadata, err := msg.Metadata()
+			if err != nil {
+				return nil, errs.NewUnexpected("failed to read profile document metadata", err)
+			}
+			if metadata.NumPending == 0 {
+				return subs, nil
+			}
+		}
+		if batch.Error() != nil {
+			return nil, errs.NewUnexpected("failed to fetch profile documents", batch.Error())
+		}
+	}
+}
+
+// NewPublisher creates the publisher of the documents of the users read from the reader
+func NewPublisher(reader port.UserReader, js JetStreamPublisher, opts ...Option) *Publisher {
+	p := &Publisher{
+		reader: reader,
+		js:     js,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.clock = clock.Or(p.clock)
+
+	published, errCounter := otel.Meter(constants.ServiceName).Int64Counter(
+		"auth_service.profile_stream.documents",
+		metric.WithDescription("Number of profile documents published to the profiles stream, by result"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create profile stream counter", "error", errCounter)
+	}
+	p.published = published
+	return p
+}
+
+// New creates the publisher on the profiles stream, which must exist
+func New(ctx context.Context, js jetstream.JetStream, reader port.UserReader, opts ...Option) (*Publisher, error) {
+	stream, err := js.Stream(ctx, constants.StreamNameProfiles)
+	if err != nil {
+		return nil, errs.NewUnexpected("profiles stream not found in NATS", err)
+	}
+	return NewPublisher(reader, js, append([]Option{WithDocumentReader(stream)}, opts...)...), nil
+}
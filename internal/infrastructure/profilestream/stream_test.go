@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package profilestream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeUserReader serves the users by user_id
+type fakeUserReader struct {
+	port.UserReader
+	users map[string]*model.User
+	err   error
+	reads int
+}
+
+func (f *fakeUserReader) GetUser(_ context.Context, user *model.User) (*model.User, error) {
+	f.reads++
+	if f.err != nil {
+		return nil, f.err
+	}
+	found, ok := f.users[user.UserID]
+	if !ok {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return found, nil
+}
+
+type publishedMessage struct {
+	subject string
+	data    []byte
+}
+
+// fakeJetStream keeps the published messages
+type fakeJetStream struct {
+	messages []publishedMessage
+	err      error
+}
+
+func (f *fakeJetStream) Publish(_ context.Context, subject string, data []byte, _ ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.messages = append(f.messages, publishedMessage{subject: subject, data: data})
+	return &jetstream.PubAck{Sequence: uint64(len(f.messages))}, nil
+}
+
+func (f *fakeJetStream) document(t *testing.T, i int) model.ProfileDocument {
+	t.Helper()
+	require.Greater(t, len(f.messages), i)
+	var document model.ProfileDocument
+	require.NoError(t, json.Unmarshal(f.messages[i].data, &document))
+	return document
+}
+
+func TestSubject(t *testing.T) {
+	for _, sub := range []string{"auth0|123", "google-oauth2|1.2 3", "00u1abcd2EFGH3ijk4x7"} {
+		subject := Subject(sub)
+		assert.NotContains(t, subject[len("lfx.auth-service.profiles."):], ".")
+		assert.NotContains(t, subject, " ")
+
+		decoded, ok := subFromSubject(subject)
+		assert.True(t, ok)
+		assert.Equal(t, sub, decoded)
+	}
+
+	_, ok := subFromSubject("lfx.auth-service.other.YXV0aDB8MTIz")
+	assert.False(t, ok)
+	_, ok = subFromSubject("lfx.auth-service.profiles.!")
+	assert.False(t, ok)
+}
+
+func TestPublisher_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	reader := &fakeUserReader{users: map[string]*model.User{
+		"auth0|123": {
+			UserID:       "auth0|123",
+			Username:     "jdoe",
+			PrimaryEmail: "jane@example.com",
+			UserMetadata: &model.UserMetadata{
+				Name:        converters.StringPtr("Jane Doe"),
+				PhoneNumber: converters.StringPtr("+351 000 000 000"),
+			},
+		},
+	}}
+	js := &fakeJetStream{}
+	publisher := NewPublisher(reader, js, WithClock(clock.NewFake(now)))
+
+	event := func(sub string, reason model.ProfileChangeReason) []byte {
+		data, err := json.Marshal(&model.UserProfileChanged{Sub: sub, Reason: reason, ChangedAt: now})
+		require.NoError(t, err)
+		return data
+	}
+
+	publisher.Handle(ctx, event("auth0|123", model.ProfileChangeUpdate))
+	require.Len(t, js.messages, 1)
+	assert.Equal(t, Subject("auth0|123"), js.messages[0].subject)
+	assert.Equal(t, model.ProfileDocument{Sub: "auth0|123", Username: "jdoe", Name: "Jane Doe", UpdatedAt: now}, js.document(t, 0))
+	assert.NotContains(t, string(js.messages[0].data), "jane@example.com")
+
+	t.Run("the subscribed events are not changes", func(t *testing.T) {
+		publisher.Handle(ctx, event("auth0|123", model.ProfileChangeSubscribed))
+		publisher.Handle(ctx, []byte("not json"))
+		assert.Len(t, js.messages, 1)
+	})
+
+	t.Run("a user no longer found is deleted", func(t *testing.T) {
+		publisher.Handle(ctx, event("auth0|456", model.ProfileChangeRepublish))
+		require.Len(t, js.messages, 2)
+		assert.Equal(t, Subject("auth0|456"), js.messages[1].subject)
+		assert.Equal(t, *model.DeletedProfileDocument("auth0|456", now), js.document(t, 1))
+	})
+}
+
+func TestPublisher_Refresh_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("the provider is unavailable", func(t *testing.T) {
+		js := &fakeJetStream{}
+		publisher := NewPublisher(&fakeUserReader{err: errs.NewServiceUnavailable("auth0 unavailable")}, js)
+
+		err := publisher.Refresh(ctx, "auth0|123")
+		require.Error(t, err)
+		assert.IsType(t, errs.ServiceUnavailable{}, err)
+		assert.Empty(t, js.messages, "a provider failure doesn't delete the document")
+	})
+
+	t.Run("the stream is unavailable", func(t *testing.T) {
+		publisher := NewPublisher(&fakeUserReader{}, &fakeJetStream{err: jetstream.ErrNoStreamResponse})
+
+		err := publisher.Refresh(ctx, "auth0|123")
+		require.Error(t, err)
+		assert.IsType(t, errs.ServiceUnavailable{}, err)
+	})
+
+	t.Run("sub is required", func(t *testing.T) {
+		reader := &fakeUserReader{}
+		publisher := NewPublisher(reader, &fakeJetStream{})
+
+		err := publisher.Refresh(ctx, "")
+		require.Error(t, err)
+		assert.IsType(t, errs.Validation{}, err)
+		assert.Zero(t, reader.reads)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package provenance stores the provenance of the metadata fields in a NATS KV bucket, one key per user,
+// so the replicas agree on the fields the enrichment jobs must not overwrite.
+package provenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// maxRecordAttempts is the number of attempts to record the provenance when another replica writes it concurrently
+const maxRecordAttempts = 5
+
+// kvStore is the subset of the NATS KV bucket used by the store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+}
+
+// Store is the provenance of the provenance KV bucket
+type Store struct {
+	kv kvStore
+}
+
+// key returns the key of the user, the user ids aren't valid KV keys
+func key(userID string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(userID)))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the provenance of the user and its revision, 0 when there is none
+func (s *Store) get(ctx context.Context, userID string) (model.MetadataProvenance, uint64, error) {
+	value, err := s.kv.Get(ctx, key(userID))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return model.MetadataProvenance{}, 0, nil
+		}
+		return nil, 0, errs.NewServiceUnavailable("failed to read the metadata provenance", err)
+	}
+
+	provenance := model.MetadataProvenance{}
+	if err := json.Unmarshal(value.Value(), &provenance); err != nil {
+		return nil, 0, errs.NewUnexpected("invalid metadata provenance", err)
+	}
+	return provenance, value.Revision(), nil
+}
+
+// GetProvenance returns the provenance of the metadata fields of the user, empty when none was recorded
+func (s *Store) GetProvenance(ctx context.Context, userID string) (model.MetadataProvenance, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, errs.NewValidation("user_id is required")
+	}
+	provenance, _, err := s.get(ctx, userID)
+	return provenance, err
+}
+
+// RecordProvenance sets the provenance of the fields of the user
+func (s *Store) RecordProvenance(ctx context.Context, userID string, fields []string, provenance model.FieldProvenance) error {
+	if strings.TrimSpace(userID) == "" {
+		return errs.NewValidation("user_id is required")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	for range maxRecordAttempts {
+		current, revision, errGet := s.get(ctx, userID)
+		if errGet != nil {
+			return errGet
+		}
+		current.Record(fields, provenance)
+
+		data, errMarshal := json.Marshal(current)
+		if errMarshal != nil {
+			return errs.NewUnexpected("failed to marshal metadata provenance", errMarshal)
+		}
+
+		if revision == 0 {
+			if _, errCreate := s.kv.Create(ctx, key(userID), data); errCreate != nil {
+				if errors.Is(errCreate, jetstream.ErrKeyExists) {
+					continue
+				}
+				return errs.NewServiceUnavailable("failed to write the metadata provenance", errCreate)
+			}
+			return nil
+		}
+		if _, errUpdate := s.kv.Update(ctx, key(userID), data, revision); errUpdate != nil {
+			slog.DebugContext(ctx, "metadata provenance changed concurrently, retrying",
+				"user_id", redaction.Redact(userID),
+				"error", errUpdate,
+			)
+			continue
+		}
+		return nil
+	}
+
+	return errs.NewConflict("metadata provenance changed concurrently, try again")
+}
+
+// New creates the provenance store on the provenance KV bucket
+func New(kv jetstream.KeyValue) *Store {
+	return newStore(kv)
+}
+
+func newStore(kv kvStore) *Store {
+	return &Store{kv: kv}
+}
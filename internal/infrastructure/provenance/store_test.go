@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package provenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next writes fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error) {
+	if _, exists := f.data[key]; exists {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.data[key] = fakeEntry{value: value, revision: 1}
+	return 1, nil
+}
+
+func (f *fakeKV) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func TestStore_RecordProvenance(t *testing.T) {
+	ctx := context.Background()
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	store := newStore(kv)
+	at := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	empty, err := store.GetProvenance(ctx, "auth0|jane")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	user := model.FieldProvenance{Source: model.ProvenanceSourceUser, Actor: "auth0|jane", UpdatedAt: at}
+	require.NoError(t, store.RecordProvenance(ctx, "auth0|jane", []string{"job_title", "organization", "organization_verified"}, user))
+
+	// the other fields are left untouched, concurrent writes are retried
+	kv.conflicts = 2
+	enrichment := model.FieldProvenance{Source: model.ProvenanceSourceEnrichment, Actor: "enrichment-job", UpdatedAt: at.Add(time.Hour)}
+	require.NoError(t, store.RecordProvenance(ctx, "auth0|jane", []string{"city"}, enrichment))
+
+	provenance, err := store.GetProvenance(ctx, "auth0|jane")
+	require.NoError(t, err)
+	assert.Equal(t, model.MetadataProvenance{
+		"job_title":    user,
+		"organization": user,
+		"city":         enrichment,
+	}, provenance)
+
+	// the users don't share their provenance
+	other, err := store.GetProvenance(ctx, "auth0|john")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestStore_Errors(t *testing.T) {
+	ctx := context.Background()
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	store := newStore(kv)
+
+	var validation errs.Validation
+	_, err := store.GetProvenance(ctx, " ")
+	assert.ErrorAs(t, err, &validation)
+	assert.ErrorAs(t, store.RecordProvenance(ctx, "", []string{"city"}, model.FieldProvenance{}), &validation)
+
+	provenance := model.FieldProvenance{Source: model.ProvenanceSourceUser}
+	require.NoError(t, store.RecordProvenance(ctx, "auth0|jane", []string{"city"}, provenance))
+	kv.conflicts = maxRecordAttempts
+	var conflict errs.Conflict
+	assert.ErrorAs(t, store.RecordProvenance(ctx, "auth0|jane", []string{"city"}, provenance), &conflict)
+}
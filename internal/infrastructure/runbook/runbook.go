@@ -0,0 +1,280 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package runbook runs the multi-step procedures of the incident runbooks as single operations:
+// renewing the M2M tokens and verifying the provider accepts them, refreshing the JWKS and
+// rebuilding the email index of a user. Each step is reported in the result and the operation is
+// recorded in the audit sink.
+package runbook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// Outcome is the outcome of an operation or of one of its steps
+type Outcome string
+
+const (
+	// OutcomeSuccess is a step which succeeded
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure is a step which failed
+	OutcomeFailure Outcome = "failure"
+	// OutcomeSkipped is a step not run, a previous one failed or it doesn't apply
+	OutcomeSkipped Outcome = "skipped"
+)
+
+const (
+	// StepRenew renews the M2M tokens
+	StepRenew = "renew"
+	// StepVerify calls the provider API with the renewed M2M tokens
+	StepVerify = "verify"
+	// StepRefresh fetches the JWKS
+	StepRefresh = "refresh"
+	// StepProviderIndex rebuilds the email index of the provider
+	StepProviderIndex = "provider_index"
+)
+
+// Index is an internal index of the users derived from the identity provider
+type Index interface {
+	// Repair aligns the index with the provider for the user of the sub
+	Repair(ctx context.Context, sub string, user *model.User) error
+}
+
+// Step is the outcome of a step of an operation
+type Step struct {
+	Name    string  `json:"name"`
+	Outcome Outcome `json:"outcome"`
+	Detail  string  `json:"detail,omitempty"`
+}
+
+// Result is the outcome of an operation, it failed when one of its steps did
+type Result struct {
+	Operation model.AuditAction `json:"operation"`
+	Target    string            `json:"target"`
+	Outcome   Outcome           `json:"outcome"`
+	Steps     []Step            `json:"steps"`
+	At        time.Time         `json:"at"`
+}
+
+// step appends the outcome of a step, err is nil on success
+func (r *Result) step(name string, err error, detail string) {
+	s := Step{Name: name, Outcome: OutcomeSuccess, Detail: detail}
+	if err != nil {
+		s.Outcome = OutcomeFailure
+		s.Detail = err.Error()
+		r.Outcome = OutcomeFailure
+	}
+	r.Steps = append(r.Steps, s)
+}
+
+// skip appends a step not run
+func (r *Result) skip(name, reason string) {
+	r.Steps = append(r.Steps, Step{Name: name, Outcome: OutcomeSkipped, Detail: reason})
+}
+
+// failure returns the detail of the first failed step
+func (r *Result) failure() string {
+	for _, s := range r.Steps {
+		if s.Outcome == OutcomeFailure {
+			return s.Name + ": " + s.Detail
+		}
+	}
+	return ""
+}
+
+type namedIndex struct {
+	name  string
+	index Index
+}
+
+// Runbook runs the runbook operations against the identity provider and the internal indices
+type Runbook struct {
+	provider  port.UserReader
+	renewer   port.M2MTokenRenewer
+	refresher port.JWKSRefresher
+	rebuilder port.EmailIndexRebuilder
+	indices   []namedIndex
+	sink      port.AuditSink
+	clock     clock.Clock
+}
+
+// Option configures the Runbook
+type Option func(*Runbook)
+
+// WithIndex adds an index repaired for the user whose email index is rebuilt, under the name of
+// its step
+func WithIndex(name string, index Index) Option {
+	return func(r *Runbook) {
+		r.indices = append(r.indices, namedIndex{name: name, index: index})
+	}
+}
+
+// WithAuditSink records the operations in the audit sink
+func WithAuditSink(sink port.AuditSink) Option {
+	return func(r *Runbook) {
+		r.sink = sink
+	}
+}
+
+// WithClock sets the clock the operations are timestamped with
+func WithClock(c clock.Clock) Option {
+	return func(r *Runbook) {
+		r.clock = c
+	}
+}
+
+func (r *Runbook) newResult(operation model.AuditAction, target string) *Result {
+	return &Result{
+		Operation: operation,
+		Target:    target,
+		Outcome:   OutcomeSuccess,
+		At:        r.clock.Now().UTC(),
+	}
+}
+
+// RenewM2MToken renews the M2M tokens of the identity provider and verifies its API accepts them
+func (r *Runbook) RenewM2MToken(ctx context.Context, actor string) (*Result, error) {
+	if r.renewer == nil {
+		return nil, errs.NewServiceUnavailable("the identity provider doesn't use M2M tokens")
+	}
+
+	result := r.newResult(model.AuditActionRunbookM2MToken, "m2m_token")
+	expiry, errRenew := r.renewer.RenewM2MToken(ctx)
+	result.step(StepRenew, errRenew, "expires at "+expiry.UTC().Format(time.RFC3339))
+	if errRenew != nil {
+		result.skip(StepVerify, "the renewal failed")
+	} else {
+		result.step(StepVerify, r.renewer.VerifyM2MToken(ctx), "accepted by the identity provider")
+	}
+
+	r.record(ctx, actor, result, nil)
+	return result, nil
+}
+
+// RefreshJWKS fetches the JWKS verifying the user tokens now, instead of waiting for a token
+// signed with an unknown key
+func (r *Runbook) RefreshJWKS(ctx context.Context, actor string) (*Result, error) {
+	if r.refresher == nil {
+		return nil, errs.NewServiceUnavailable("the identity provider doesn't verify the tokens with a JWKS")
+	}
+
+	result := r.newResult(model.AuditActionRunbookJWKS, "jwks")
+	keys, errRefresh := r.refresher.RefreshJWKS(ctx)
+	result.step(StepRefresh, errRefresh, fmt.Sprintf("%d signing keys loaded", keys))
+
+	r.record(ctx, actor, result, nil)
+	return result, nil
+}
+
+// RebuildEmailIndex rewrites the email index of the provider for the user, when it keeps one, and
+// repairs the internal indices from its record. The user is a sub, a username or an email.
+func (r *Runbook) RebuildEmailIndex(ctx context.Context, actor, input string) (*Result, error) {
+	user, errLookup := r.lookup(ctx, input)
+	if errLookup != nil {
+		// the user isn't resolved, the input may be an email
+		result := r.newResult(model.AuditActionRunbookEmailIndex, redaction.Redact(strings.TrimSpace(input)))
+		result.Outcome = OutcomeFailure
+		r.record(ctx, actor, result, errLookup)
+		return nil, errLookup
+	}
+
+	result := r.newResult(model.AuditActionRunbookEmailIndex, user.UserID)
+	if r.rebuilder == nil {
+		result.skip(StepProviderIndex, "the identity provider keeps no email index")
+	} else {
+		fixed, errRebuild := r.rebuilder.RebuildEmailIndex(ctx, user)
+		result.step(StepProviderIndex, errRebuild, fmt.Sprintf("%d keys fixed", fixed))
+	}
+	for _, idx := range r.indices {
+		result.step(idx.name, idx.index.Repair(ctx, user.UserID, user), "repaired")
+	}
+
+	r.record(ctx, actor, result, nil)
+	return result, nil
+}
+
+// lookup reads the user from the identity provider, never from an index
+func (r *Runbook) lookup(ctx context.Context, input string) (*model.User, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, errs.NewValidation("user is required")
+	}
+
+	user, err := r.provider.MetadataLookup(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case user.UserID != "":
+		user, err = r.provider.GetUser(ctx, user)
+	case user.Username != "":
+		user, err = r.provider.SearchUser(ctx, user, constants.CriteriaTypeUsername)
+	default:
+		user, err = r.provider.SearchUser(ctx, user, constants.CriteriaTypeEmail)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.UserID == "" {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return user, nil
+}
+
+// record records the operation in the audit sink, errOperation is the failure preventing its steps,
+// a failure to record is only logged
+func (r *Runbook) record(ctx context.Context, actor string, result *Result, errOperation error) {
+	slog.InfoContext(ctx, "runbook operation completed",
+		"operation", result.Operation,
+		"target", result.Target,
+		"outcome", result.Outcome,
+	)
+	if r.sink == nil {
+		return
+	}
+
+	event := &model.AuditEvent{
+		Action:  result.Operation,
+		Actor:   actor,
+		Target:  result.Target,
+		Outcome: model.AuditOutcomeSuccess,
+		Details: map[string]any{"steps": result.Steps},
+		At:      result.At,
+	}
+	switch {
+	case errOperation != nil:
+		event.Fail(errOperation)
+	case result.Outcome == OutcomeFailure:
+		event.Outcome = model.AuditOutcomeFailure
+		event.Error = result.failure()
+	}
+
+	if errRecord := r.sink.Record(ctx, event); errRecord != nil {
+		slog.ErrorContext(ctx, "failed to record runbook audit event", "error", errRecord)
+	}
+}
+
+// New creates the Runbook of the identity provider, the operations it has no capability for are
+// unavailable
+func New(provider port.UserReader, opts ...Option) *Runbook {
+	r := &Runbook{provider: provider}
+	r.renewer, _ = provider.(port.M2MTokenRenewer)
+	r.refresher, _ = provider.(port.JWKSRefresher)
+	r.rebuilder, _ = provider.(port.EmailIndexRebuilder)
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.clock = clock.Or(r.clock)
+	return r
+}
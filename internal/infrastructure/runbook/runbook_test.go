@@ -0,0 +1,213 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package runbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+var now = time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+// fakeReader knows a single user, jdoe
+type fakeReader struct{}
+
+func (fakeReader) GetUser(_ context.Context, user *model.User) (*model.User, error) {
+	if user.UserID != "auth0|123" {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return &model.User{UserID: "auth0|123", Username: "jdoe", PrimaryEmail: "jane@example.com"}, nil
+}
+
+func (f fakeReader) SearchUser(ctx context.Context, user *model.User, _ constants.CriteriaType) (*model.User, error) {
+	if user.Username != "jdoe" && user.PrimaryEmail != "jane@example.com" {
+		return nil, errs.NewNotFound("user not found")
+	}
+	return f.GetUser(ctx, &model.User{UserID: "auth0|123"})
+}
+
+func (fakeReader) MetadataLookup(_ context.Context, input string, _ ...string) (*model.User, error) {
+	return &model.User{Username: input}, nil
+}
+
+// fakeProvider has all the runbook capabilities
+type fakeProvider struct {
+	fakeReader
+	errRenew  error
+	errVerify error
+	errJWKS   error
+	rebuilt   []string
+}
+
+func (f *fakeProvider) RenewM2MToken(context.Context) (time.Time, error) {
+	if f.errRenew != nil {
+		return time.Time{}, f.errRenew
+	}
+	return now.Add(24 * time.Hour), nil
+}
+
+func (f *fakeProvider) VerifyM2MToken(context.Context) error {
+	return f.errVerify
+}
+
+func (f *fakeProvider) RefreshJWKS(context.Context) (int, error) {
+	return 2, f.errJWKS
+}
+
+func (f *fakeProvider) RebuildEmailIndex(_ context.Context, user *model.User) (int, error) {
+	f.rebuilt = append(f.rebuilt, user.UserID)
+	return 1, nil
+}
+
+type fakeIndex struct {
+	repaired []string
+	err      error
+}
+
+func (f *fakeIndex) Repair(_ context.Context, sub string, _ *model.User) error {
+	f.repaired = append(f.repaired, sub)
+	return f.err
+}
+
+type fakeSink struct {
+	events []*model.AuditEvent
+}
+
+func (f *fakeSink) Record(_ context.Context, event *model.AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func newRunbook(provider port.UserReader, sink *fakeSink, opts ...Option) *Runbook {
+	return New(provider, append(opts, WithAuditSink(sink), WithClock(clock.NewFake(now)))...)
+}
+
+func TestRunbook_RenewM2MToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		provider *fakeProvider
+		outcome  Outcome
+		steps    []Outcome
+	}{
+		{
+			name:     "renewed and verified",
+			provider: &fakeProvider{},
+			outcome:  OutcomeSuccess,
+			steps:    []Outcome{OutcomeSuccess, OutcomeSuccess},
+		},
+		{
+			name:     "renewal failed",
+			provider: &fakeProvider{errRenew: errs.NewServiceUnavailable("auth0 unavailable")},
+			outcome:  OutcomeFailure,
+			steps:    []Outcome{OutcomeFailure, OutcomeSkipped},
+		},
+		{
+			name:     "renewed token rejected",
+			provider: &fakeProvider{errVerify: errs.NewUnauthorized("invalid token")},
+			outcome:  OutcomeFailure,
+			steps:    []Outcome{OutcomeSuccess, OutcomeFailure},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &fakeSink{}
+			result, err := newRunbook(tt.provider, sink).RenewM2MToken(ctx, "admin|1")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.outcome, result.Outcome)
+			require.Len(t, result.Steps, len(tt.steps))
+			for i, outcome := range tt.steps {
+				assert.Equal(t, outcome, result.Steps[i].Outcome, result.Steps[i].Name)
+			}
+
+			require.Len(t, sink.events, 1)
+			event := sink.events[0]
+			assert.Equal(t, model.AuditActionRunbookM2MToken, event.Action)
+			assert.Equal(t, "admin|1", event.Actor)
+			assert.Equal(t, now, event.At)
+			assert.Equal(t, string(tt.outcome), string(event.Outcome))
+		})
+	}
+}
+
+func TestRunbook_RefreshJWKS(t *testing.T) {
+	sink := &fakeSink{}
+	result, err := newRunbook(&fakeProvider{}, sink).RefreshJWKS(context.Background(), "admin|1")
+	require.NoError(t, err)
+
+	assert.Equal(t, OutcomeSuccess, result.Outcome)
+	assert.Equal(t, []Step{{Name: StepRefresh, Outcome: OutcomeSuccess, Detail: "2 signing keys loaded"}}, result.Steps)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, model.AuditActionRunbookJWKS, sink.events[0].Action)
+}
+
+func TestRunbook_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	sink := &fakeSink{}
+	r := newRunbook(fakeReader{}, sink)
+
+	var unavailable errs.ServiceUnavailable
+	_, err := r.RenewM2MToken(ctx, "admin|1")
+	assert.ErrorAs(t, err, &unavailable)
+	_, err = r.RefreshJWKS(ctx, "admin|1")
+	assert.ErrorAs(t, err, &unavailable)
+	assert.Empty(t, sink.events)
+
+	// the internal indices are repaired even when the provider keeps no email index
+	result, err := r.RebuildEmailIndex(ctx, "admin|1", "jdoe")
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeSuccess, result.Outcome)
+	assert.Equal(t, []Step{{Name: StepProviderIndex, Outcome: OutcomeSkipped, Detail: "the identity provider keeps no email index"}}, result.Steps)
+}
+
+func TestRunbook_RebuildEmailIndex(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	cache := &fakeIndex{}
+	stream := &fakeIndex{err: errs.NewServiceUnavailable("stream unavailable")}
+	sink := &fakeSink{}
+	r := newRunbook(provider, sink, WithIndex("user_cache", cache), WithIndex("profile_stream", stream))
+
+	result, err := r.RebuildEmailIndex(ctx, "admin|1", " jdoe ")
+	require.NoError(t, err)
+
+	assert.Equal(t, "auth0|123", result.Target)
+	assert.Equal(t, OutcomeFailure, result.Outcome)
+	assert.Equal(t, []string{"auth0|123"}, provider.rebuilt)
+	assert.Equal(t, []string{"auth0|123"}, cache.repaired)
+	assert.Equal(t, []Step{
+		{Name: StepProviderIndex, Outcome: OutcomeSuccess, Detail: "1 keys fixed"},
+		{Name: "user_cache", Outcome: OutcomeSuccess, Detail: "repaired"},
+		{Name: "profile_stream", Outcome: OutcomeFailure, Detail: "stream unavailable"},
+	}, result.Steps)
+
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, model.AuditOutcomeFailure, sink.events[0].Outcome)
+	assert.Equal(t, "profile_stream: stream unavailable", sink.events[0].Error)
+
+	// an unknown user is reported and audited, nothing is rebuilt
+	_, err = r.RebuildEmailIndex(ctx, "admin|1", "nobody")
+	var notFound errs.NotFound
+	assert.ErrorAs(t, err, &notFound)
+	assert.Len(t, provider.rebuilt, 1)
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, model.AuditOutcomeFailure, sink.events[1].Outcome)
+
+	_, err = r.RebuildEmailIndex(ctx, "admin|1", " ")
+	var validation errs.Validation
+	assert.ErrorAs(t, err, &validation)
+}
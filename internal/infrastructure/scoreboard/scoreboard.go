@@ -0,0 +1,147 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package scoreboard keeps the recent latency and error rate of the upstream
+// identity providers, reported to callers through the provider status handler.
+package scoreboard
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+const (
+	// DefaultWindow is how far back the samples are kept
+	DefaultWindow = 5 * time.Minute
+
+	// maxSamples bounds the samples kept per provider within the window
+	maxSamples = 1024
+
+	// openErrorRate is the error rate from which the circuit is reported open
+	openErrorRate = 0.5
+
+	// minSamplesToOpen avoids opening the circuit on a couple of failed calls
+	minSamplesToOpen = 5
+)
+
+type sample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// Scoreboard records the outcome of the calls to each provider in a sliding window
+type Scoreboard struct {
+	mu        sync.Mutex
+	window    time.Duration
+	now       func() time.Time
+	providers map[string][]sample
+}
+
+// providerRecorder records the calls of a single provider
+type providerRecorder struct {
+	scoreboard *Scoreboard
+	provider   string
+}
+
+// Record implements httpclient.Recorder
+func (p providerRecorder) Record(latency time.Duration, failed bool) {
+	p.scoreboard.record(p.provider, latency, failed)
+}
+
+// Recorder returns the recorder to plug into the HTTP client of the provider
+func (s *Scoreboard) Recorder(provider string) httpclient.Recorder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.providers[provider]; !ok {
+		// listed as soon as it is registered, even before the first call
+		s.providers[provider] = nil
+	}
+	return providerRecorder{scoreboard: s, provider: provider}
+}
+
+func (s *Scoreboard) record(provider string, latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := append(s.providers[provider], sample{at: s.now(), latency: latency, failed: failed})
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+	s.providers[provider] = samples
+}
+
+// ProviderStatuses implements port.ProviderStatusReader
+func (s *Scoreboard) ProviderStatuses(ctx context.Context) []model.ProviderStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := s.now().Add(-s.window)
+
+	statuses := make([]model.ProviderStatus, 0, len(s.providers))
+	for provider, samples := range s.providers {
+		// drop the samples out of the window
+		first := sort.Search(len(samples), func(i int) bool { return samples[i].at.After(cutoff) })
+		samples = samples[first:]
+		s.providers[provider] = samples
+
+		statuses = append(statuses, status(provider, samples))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Provider < statuses[j].Provider })
+	return statuses
+}
+
+func status(provider string, samples []sample) model.ProviderStatus {
+	result := model.ProviderStatus{
+		Provider:     provider,
+		Samples:      len(samples),
+		CircuitState: model.CircuitStateClosed,
+	}
+	if len(samples) == 0 {
+		return result
+	}
+
+	latencies := make([]time.Duration, 0, len(samples))
+	failures := 0
+	for _, s := range samples {
+		latencies = append(latencies, s.latency)
+		if s.failed {
+			failures++
+			at := s.at
+			result.LastFailureAt = &at
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	// nearest-rank percentile
+	rank := (len(latencies)*95 + 99) / 100
+	result.P95Ms = latencies[rank-1].Milliseconds()
+	result.Failures = failures
+	result.ErrorRate = float64(failures) / float64(len(samples))
+
+	if len(samples) >= minSamplesToOpen && result.ErrorRate >= openErrorRate {
+		result.CircuitState = model.CircuitStateOpen
+		if !samples[len(samples)-1].failed {
+			result.CircuitState = model.CircuitStateHalfOpen
+		}
+	}
+	return result
+}
+
+// New creates a scoreboard keeping the samples of the given window, DefaultWindow when zero
+func New(window time.Duration) *Scoreboard {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Scoreboard{
+		window:    window,
+		now:       time.Now,
+		providers: make(map[string][]sample),
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package scoreboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+func TestScoreboard_ProviderStatuses(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		outcomes  []bool // failed
+		wantState string
+		wantRate  float64
+	}{
+		{name: "no calls", wantState: model.CircuitStateClosed},
+		{name: "healthy", outcomes: []bool{false, false, false, true, false}, wantState: model.CircuitStateClosed, wantRate: 0.2},
+		{name: "failing", outcomes: []bool{false, true, true, true, true}, wantState: model.CircuitStateOpen, wantRate: 0.8},
+		{name: "recovering", outcomes: []bool{true, true, true, true, false}, wantState: model.CircuitStateHalfOpen, wantRate: 0.8},
+		{name: "too few calls to open", outcomes: []bool{true, true}, wantState: model.CircuitStateClosed, wantRate: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(0)
+			s.now = func() time.Time { return now }
+			recorder := s.Recorder("auth0")
+			for i, failed := range tt.outcomes {
+				recorder.Record(time.Duration(i+1)*100*time.Millisecond, failed)
+			}
+
+			statuses := s.ProviderStatuses(context.Background())
+			if len(statuses) != 1 {
+				t.Fatalf("ProviderStatuses() returned %d providers, want 1", len(statuses))
+			}
+			got := statuses[0]
+			if got.Provider != "auth0" || got.Samples != len(tt.outcomes) {
+				t.Errorf("status = %+v", got)
+			}
+			if got.CircuitState != tt.wantState {
+				t.Errorf("CircuitState = %s, want %s", got.CircuitState, tt.wantState)
+			}
+			if got.Failures != int(tt.wantRate*float64(len(tt.outcomes))) {
+				t.Errorf("Failures = %d, want %v of %d calls", got.Failures, tt.wantRate, len(tt.outcomes))
+			}
+			if got.ErrorRate != tt.wantRate {
+				t.Errorf("ErrorRate = %v, want %v", got.ErrorRate, tt.wantRate)
+			}
+			if (got.LastFailureAt != nil) != (tt.wantRate > 0) {
+				t.Errorf("LastFailureAt = %v", got.LastFailureAt)
+			}
+		})
+	}
+}
+
+func TestScoreboard_P95AndWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := New(time.Minute)
+	s.now = func() time.Time { return now }
+
+	recorder := s.Recorder("auth0")
+	// an old slow call, out of the window by the time the status is read
+	recorder.Record(10*time.Second, true)
+
+	now = now.Add(2 * time.Minute)
+	for i := 1; i <= 100; i++ {
+		recorder.Record(time.Duration(i)*time.Millisecond, false)
+	}
+
+	got := s.ProviderStatuses(context.Background())[0]
+	if got.Samples != 100 {
+		t.Errorf("Samples = %d, want 100", got.Samples)
+	}
+	if got.P95Ms != 95 {
+		t.Errorf("P95Ms = %d, want 95", got.P95Ms)
+	}
+	if got.ErrorRate != 0 || got.LastFailureAt != nil {
+		t.Errorf("the failure out of the window should be ignored, got %+v", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package sendlimit limits the verification emails sent to an inbox and requested by a user,
+// so the email linking flow can't be used to flood arbitrary inboxes. The sends are counted
+// in fixed windows kept in a pluggable store, in memory or in a NATS KV bucket shared by the
+// replicas.
+package sendlimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// scopeEmail counts the sends to an email
+	scopeEmail = "email"
+	// scopeUser counts the sends requested by a user
+	scopeUser = "user"
+)
+
+// Limit allows Count sends per Period, a zero Count is unlimited
+type Limit struct {
+	Count  int64
+	Period time.Duration
+}
+
+// Limits are the limits of the sends per email and per user
+type Limits struct {
+	Email Limit
+	User  Limit
+}
+
+// DefaultLimits allow 3 sends per email per hour and 10 sends per user per day
+var DefaultLimits = Limits{
+	Email: Limit{Count: 3, Period: time.Hour},
+	User:  Limit{Count: 10, Period: 24 * time.Hour},
+}
+
+// ParseLimits parses a spec of the form "email=3/1h,user=10/24h" over the default limits,
+// a count of zero disables the limit
+func ParseLimits(spec string) (Limits, error) {
+	limits := DefaultLimits
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return Limits{}, fmt.Errorf("invalid send limit entry %q", entry)
+		}
+		countRaw, periodRaw, ok := strings.Cut(raw, "/")
+		if !ok {
+			return Limits{}, fmt.Errorf("invalid send limit entry %q", entry)
+		}
+		count, err := strconv.ParseInt(strings.TrimSpace(countRaw), 10, 64)
+		if err != nil || count < 0 {
+			return Limits{}, fmt.Errorf("invalid send limit count in %q", entry)
+		}
+		period, err := time.ParseDuration(strings.TrimSpace(periodRaw))
+		if err != nil || period <= 0 {
+			return Limits{}, fmt.Errorf("invalid send limit period in %q", entry)
+		}
+
+		switch strings.TrimSpace(name) {
+		case scopeEmail:
+			limits.Email = Limit{Count: count, Period: period}
+		case scopeUser:
+			limits.User = Limit{Count: count, Period: period}
+		default:
+			return Limits{}, fmt.Errorf("unknown send limit %q, expected %s or %s", name, scopeEmail, scopeUser)
+		}
+	}
+
+	return limits, nil
+}
+
+// Store counts the sends of the windows, Take records a send under the key unless the count
+// already reached the limit. The entries are not needed after expiresAt.
+type Store interface {
+	Take(ctx context.Context, key string, limit int64, expiresAt time.Time) (bool, error)
+}
+
+// Limiter enforces the send limits
+type Limiter struct {
+	store  Store
+	limits Limits
+	now    func() time.Time
+}
+
+// take records a send in the current window of the scope, it returns the time left in the
+// window when the limit is reached. The limiter fails open: a send is allowed when the store
+// can't be read, so an issue with the store doesn't block the email linking.
+func (l *Limiter) take(ctx context.Context, scope, subject string, limit Limit) (time.Duration, bool) {
+	if limit.Count <= 0 || subject == "" {
+		return 0, true
+	}
+
+	now := l.now().UTC()
+	start := now.Truncate(limit.Period)
+	end := start.Add(limit.Period)
+
+	// the subjects are hashed, the keys don't expose the emails
+	digest := sha256.Sum256([]byte(subject))
+	key := scope + "." + strconv.FormatInt(start.Unix(), 10) + "." + base64.RawURLEncoding.EncodeToString(digest[:16])
+
+	allowed, err := l.store.Take(ctx, key, limit.Count, end)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to count the verification email sends, allowing the send", "error", err, "scope", scope)
+		return 0, true
+	}
+	if !allowed {
+		return end.Sub(now), false
+	}
+	return 0, true
+}
+
+// Allow records a verification email sent to the email on behalf of the user, the user is
+// empty when the caller didn't identify it. It fails with a TooManyRequests error carrying
+// the wait until the end of the window once a limit is reached.
+func (l *Limiter) Allow(ctx context.Context, email, userID string) error {
+	// the user is checked first, an exhausted user doesn't use up the sends of the email
+	if wait, ok := l.take(ctx, scopeUser, userID, l.limits.User); !ok {
+		slog.WarnContext(ctx, "verification email sends per user exhausted", "user_id", redaction.Redact(userID))
+		return errs.NewTooManyRequests("too many verification emails requested, try again later").WithRetryAfter(wait)
+	}
+	if wait, ok := l.take(ctx, scopeEmail, strings.ToLower(strings.TrimSpace(email)), l.limits.Email); !ok {
+		slog.WarnContext(ctx, "verification email sends per email exhausted", "email", redaction.RedactEmail(email))
+		return errs.NewTooManyRequests("too many verification emails sent to this email, try again later").WithRetryAfter(wait)
+	}
+	return nil
+}
+
+// New creates a limiter counting the sends in the store
+func New(store Store, limits Limits) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: limits,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sendlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next updates fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+	getErr    error
+}
+
+func (f *fakeKV) Get(_ context.Context, key string) (jetstream.KeyValueEntry, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Create(_ context.Context, key string, value []byte, _ ...jetstream.KVCreateOpt) (uint64, error) {
+	if _, ok := f.data[key]; ok {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.data[key] = fakeEntry{value: value, revision: 1}
+	return 1, nil
+}
+
+func (f *fakeKV) Update(_ context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Limits
+		wantErr bool
+	}{
+		{name: "empty spec", spec: "", want: DefaultLimits},
+		{
+			name: "both limits",
+			spec: " email=5/30m, user=20/12h ",
+			want: Limits{Email: Limit{Count: 5, Period: 30 * time.Minute}, User: Limit{Count: 20, Period: 12 * time.Hour}},
+		},
+		{
+			name: "user limit disabled",
+			spec: "user=0/1h",
+			want: Limits{Email: DefaultLimits.Email, User: Limit{Count: 0, Period: time.Hour}},
+		},
+		{name: "unknown limit", spec: "ip=3/1h", wantErr: true},
+		{name: "missing period", spec: "email=3", wantErr: true},
+		{name: "invalid count", spec: "email=-1/1h", wantErr: true},
+		{name: "invalid period", spec: "email=3/0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := ParseLimits(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, limits)
+		})
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 10, 45, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	stores := map[string]Store{
+		"memory": &MemoryStore{entries: make(map[string]*memoryEntry), now: clock},
+		"kv":     &KVStore{kv: &fakeKV{data: make(map[string]fakeEntry), conflicts: 1}},
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			limiter := New(store, Limits{
+				Email: Limit{Count: 2, Period: time.Hour},
+				User:  Limit{Count: 3, Period: 24 * time.Hour},
+			})
+			limiter.now = clock
+
+			require.NoError(t, limiter.Allow(ctx, "jane@example.com", "auth0|jane"))
+			require.NoError(t, limiter.Allow(ctx, " Jane@Example.com", "auth0|jane"))
+
+			err := limiter.Allow(ctx, "jane@example.com", "auth0|john")
+			var tooMany errs.TooManyRequests
+			require.True(t, errors.As(err, &tooMany), "the sends to the email are exhausted")
+			assert.Equal(t, 15*time.Minute, tooMany.RetryAfter(), "the limit is renewed with the window")
+
+			require.NoError(t, limiter.Allow(ctx, "other@example.com", "auth0|jane"))
+			err = limiter.Allow(ctx, "another@example.com", "auth0|jane")
+			require.True(t, errors.As(err, &tooMany), "the sends of the user are exhausted")
+			assert.Equal(t, 13*time.Hour+15*time.Minute, tooMany.RetryAfter())
+
+			require.NoError(t, limiter.Allow(ctx, "another@example.com", ""), "the anonymous sends are only limited per email")
+		})
+	}
+}
+
+func TestLimiter_Windows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	store := &MemoryStore{entries: make(map[string]*memoryEntry), now: clock}
+	limiter := New(store, Limits{Email: Limit{Count: 1, Period: time.Hour}})
+	limiter.now = clock
+
+	require.NoError(t, limiter.Allow(ctx, "jane@example.com", ""))
+	require.Error(t, limiter.Allow(ctx, "jane@example.com", ""))
+
+	now = now.Add(time.Hour)
+	require.NoError(t, limiter.Allow(ctx, "jane@example.com", ""), "a new window starts")
+	assert.Len(t, store.entries, 1, "the expired windows are dropped")
+}
+
+func TestLimiter_FailOpen(t *testing.T) {
+	kv := &fakeKV{data: make(map[string]fakeEntry), getErr: errors.New("bucket unavailable")}
+	limiter := New(&KVStore{kv: kv}, Limits{Email: Limit{Count: 1, Period: time.Hour}})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Allow(context.Background(), "jane@example.com", "auth0|jane"))
+	}
+}
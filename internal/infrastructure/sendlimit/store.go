@@ -0,0 +1,119 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sendlimit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// keyPrefix keeps the send counts apart from the other entries of the bucket
+	keyPrefix = "sendlimit."
+
+	// maxTakeAttempts bounds the attempts of a count updated concurrently
+	maxTakeAttempts = 5
+)
+
+// memoryEntry is a count of the memory store
+type memoryEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the counts in the memory of the replica
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+	now     func() time.Time
+}
+
+// Take records a send under the key unless the count reached the limit
+func (m *MemoryStore) Take(_ context.Context, key string, limit int64, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the expired windows are dropped on the way
+	now := m.now()
+	for k, entry := range m.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(m.entries, k)
+		}
+	}
+
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &memoryEntry{expiresAt: expiresAt}
+		m.entries[key] = entry
+	}
+	if entry.count >= limit {
+		return false, nil
+	}
+	entry.count++
+	return true, nil
+}
+
+// NewMemoryStore creates a store keeping the counts in memory, each replica counts its own sends
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// kvStore is the subset of the KV bucket used by the KV store
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+}
+
+// KVStore keeps the counts in a NATS KV bucket shared by the replicas. The bucket is expected
+// to expire its entries, the windows are part of the keys so the stale ones are never read.
+type KVStore struct {
+	kv kvStore
+}
+
+// Take records a send under the key unless the count reached the limit
+func (s *KVStore) Take(ctx context.Context, key string, limit int64, _ time.Time) (bool, error) {
+	key = keyPrefix + key
+
+	for attempt := 0; attempt < maxTakeAttempts; attempt++ {
+		current, errGet := s.kv.Get(ctx, key)
+		if errGet != nil {
+			if !errors.Is(errGet, jetstream.ErrKeyNotFound) {
+				return false, errGet
+			}
+			_, errCreate := s.kv.Create(ctx, key, []byte("1"))
+			if errCreate == nil {
+				return true, nil
+			}
+			if !errors.Is(errCreate, jetstream.ErrKeyExists) {
+				return false, errCreate
+			}
+			continue
+		}
+
+		count, _ := strconv.ParseInt(string(current.Value()), 10, 64)
+		if count >= limit {
+			return false, nil
+		}
+		if _, errUpdate := s.kv.Update(ctx, key, []byte(strconv.FormatInt(count+1, 10)), current.Revision()); errUpdate == nil {
+			return true, nil
+		}
+		// the count changed in between, read it again
+	}
+
+	return false, errors.New("send count contended")
+}
+
+// NewKVStore creates a store keeping the counts in the given KV bucket
+func NewKVStore(kv jetstream.KeyValue) *KVStore {
+	return &KVStore{kv: kv}
+}
@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package ses
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sigv4"
+)
+
+const (
+	sesService     = "ses"
+	sendEmailPath  = "/v2/email/outbound-emails"
+	defaultRegion  = "us-east-1"
+	requestTimeout = 10 * time.Second
+)
+
+// config holds the configuration for the SES client
+type config struct {
+	// Region is the AWS region hosting the SES identity (e.g., "us-east-1")
+	Region string
+	// Endpoint is the SES API endpoint, derived from the region when empty
+	Endpoint string
+	// Credentials are the AWS credentials used to sign requests
+	Credentials sigv4.Credentials
+}
+
+// client is the SES v2 API client
+type client struct {
+	config
+	httpClient *http.Client
+}
+
+func (c *client) sendEmail(ctx context.Context, payload []byte) error {
+
+	url := c.Endpoint + sendEmailPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.NewUnexpected("failed to build SES request", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if errSign := sigv4.Sign(req, payload, c.Credentials, sesService, c.Region, time.Now()); errSign != nil {
+		return errors.NewUnexpected("failed to sign SES request", errSign)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send email via SES",
+			"error", err,
+			"region", c.Region,
+		)
+		return errors.NewUnexpected("failed to send email", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		slog.ErrorContext(ctx, "SES returned error",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"region", c.Region,
+		)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return errors.NewTooManyRequests("failed to send email", fmt.Errorf("status code: %d", resp.StatusCode))
+		}
+		return errors.NewUnexpected("failed to send email", fmt.Errorf("status code: %d", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// newClient creates a new SES client from environment variables
+func newClient() *client {
+	config := config{
+		Region:   os.Getenv(constants.EmailSESRegionEnvKey),
+		Endpoint: os.Getenv(constants.EmailSESEndpointEnvKey),
+		Credentials: sigv4.Credentials{
+			AccessKeyID:     os.Getenv(constants.AWSAccessKeyIDEnvKey),
+			SecretAccessKey: os.Getenv(constants.AWSSecretAccessKeyEnvKey),
+			SessionToken:    os.Getenv(constants.AWSSessionTokenEnvKey),
+		},
+	}
+
+	if config.Region == "" {
+		config.Region = defaultRegion
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", config.Region)
+	}
+
+	return &client{
+		config:     config,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package ses
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// sendEmailRequest is the SES v2 SendEmail request body
+type sendEmailRequest struct {
+	FromEmailAddress string      `json:"FromEmailAddress"`
+	Destination      destination `json:"Destination"`
+	Content          content     `json:"Content"`
+}
+
+type destination struct {
+	ToAddresses []string `json:"ToAddresses"`
+}
+
+type content struct {
+	Simple simpleContent `json:"Simple"`
+}
+
+type simpleContent struct {
+	Subject contentData `json:"Subject"`
+	Body    body        `json:"Body"`
+}
+
+type body struct {
+	Text *contentData `json:"Text,omitempty"`
+	HTML *contentData `json:"Html,omitempty"`
+}
+
+type contentData struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset,omitempty"`
+}
+
+// Sender is the SES sender that implements port.EmailSender
+type Sender struct {
+	client *client
+}
+
+// SendEmail sends an email message through the SES v2 API
+func (s *Sender) SendEmail(ctx context.Context, message *model.EmailMessage) error {
+	if message == nil {
+		return errors.NewValidation("email message is required")
+	}
+
+	if !message.IsValid() {
+		return errors.NewValidation("invalid email message")
+	}
+
+	fromAddress := message.From
+	if message.FromName != "" {
+		fromAddress = fmt.Sprintf("%s <%s>", message.FromName, message.From)
+	}
+
+	messageBody := &contentData{Data: message.Body, Charset: "UTF-8"}
+	request := sendEmailRequest{
+		FromEmailAddress: fromAddress,
+		Destination:      destination{ToAddresses: []string{message.To}},
+		Content: content{
+			Simple: simpleContent{
+				Subject: contentData{Data: message.Subject, Charset: "UTF-8"},
+			},
+		},
+	}
+	if message.IsHTML {
+		request.Content.Simple.Body.HTML = messageBody
+	} else {
+		request.Content.Simple.Body.Text = messageBody
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return errors.NewUnexpected("failed to marshal SES request", err)
+	}
+
+	if errSendEmail := s.client.sendEmail(ctx, payload); errSendEmail != nil {
+		return errSendEmail
+	}
+
+	slog.DebugContext(ctx, "email sent successfully via SES",
+		"region", s.client.Region,
+		"to", redaction.RedactEmail(message.To),
+		"subject", message.Subject,
+	)
+
+	return nil
+}
+
+// NewSender creates a new SES sender
+func NewSender() port.EmailSender {
+	return &Sender{
+		client: newClient(),
+	}
+}
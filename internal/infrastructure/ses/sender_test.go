@@ -0,0 +1,92 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package ses
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestSender_SendEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    *model.EmailMessage
+		statusCode int
+		wantErr    bool
+		wantType   any
+	}{
+		{
+			name: "plain text email",
+			message: &model.EmailMessage{
+				From:     "noreply@lfx.dev",
+				FromName: "LFX",
+				To:       "user@example.com",
+				Subject:  "Hello",
+				Body:     "Your verification code is: 123456",
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "invalid message",
+			message: &model.EmailMessage{To: "not-an-email"},
+			wantErr: true,
+		},
+		{
+			name: "throttled by SES",
+			message: &model.EmailMessage{
+				From:    "noreply@lfx.dev",
+				To:      "user@example.com",
+				Subject: "Hello",
+				Body:    "body",
+			},
+			statusCode: http.StatusTooManyRequests,
+			wantErr:    true,
+			wantType:   errors.TooManyRequests{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != sendEmailPath {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+					t.Errorf("request is not SigV4 signed")
+				}
+				var req sendEmailRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if req.Content.Simple.Body.Text == nil {
+					t.Errorf("expected text body")
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			t.Setenv(constants.EmailSESEndpointEnvKey, server.URL)
+			t.Setenv(constants.AWSAccessKeyIDEnvKey, "AKID")
+			t.Setenv(constants.AWSSecretAccessKeyEnvKey, "secret")
+
+			err := NewSender().SendEmail(context.Background(), tt.message)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("SendEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantType != nil {
+				if _, ok := err.(errors.TooManyRequests); !ok {
+					t.Errorf("expected TooManyRequests error, got %T", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usage
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// defaultBudgetName is the name of the budget entry applied to the callers without override
+	defaultBudgetName = "default"
+
+	// budgetKeyPrefix keeps the budget counts apart from the usage aggregates in the bucket
+	budgetKeyPrefix = "budget"
+)
+
+// Budgets are the daily budgets of the expensive operations per caller, zero means unlimited
+type Budgets struct {
+	Default int64
+	Callers map[string]int64
+}
+
+// For returns the daily budget of the caller
+func (b Budgets) For(caller string) int64 {
+	if budget, ok := b.Callers[NormalizeCaller(caller)]; ok {
+		return budget
+	}
+	return b.Default
+}
+
+// Enabled reports whether any caller has a limited budget
+func (b Budgets) Enabled() bool {
+	if b.Default > 0 {
+		return true
+	}
+	for _, budget := range b.Callers {
+		if budget > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBudgets parses a spec of the form "default=2000,caller=10000,..." into the daily budgets,
+// the default entry applies to the callers without override
+func ParseBudgets(spec string) (Budgets, error) {
+	budgets := Budgets{Callers: make(map[string]int64)}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return Budgets{}, fmt.Errorf("invalid budget entry %q", entry)
+		}
+		budget, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil || budget < 0 {
+			return Budgets{}, fmt.Errorf("invalid budget in %q", entry)
+		}
+
+		name = strings.TrimSpace(name)
+		if name == defaultBudgetName {
+			budgets.Default = budget
+			continue
+		}
+		budgets.Callers[NormalizeCaller(name)] = budget
+	}
+
+	return budgets, nil
+}
+
+// Guard enforces the daily budgets of the expensive operations, the counts are shared
+// by all the replicas through the usage KV bucket
+type Guard struct {
+	kv      kvStore
+	budgets Budgets
+	now     func() time.Time
+}
+
+// Charge accounts an operation of the class to the caller, the cheap operations are not budgeted.
+//
+// The guardrail fails open: when the counts can't be read or updated the operation is allowed,
+// so an issue with the bucket doesn't turn into an outage of the lookups.
+func (g *Guard) Charge(ctx context.Context, caller string, class model.CostClass) error {
+	if class != model.CostClassExpensive {
+		return nil
+	}
+
+	caller = NormalizeCaller(caller)
+	budget := g.budgets.For(caller)
+	if budget <= 0 {
+		return nil
+	}
+
+	now := g.now().UTC()
+	key := budgetKeyPrefix + "." + now.Format(dateLayout) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(caller)) + "." + string(class)
+
+	for attempt := 0; attempt < maxFlushAttempts; attempt++ {
+		current, errGet := g.kv.Get(ctx, key)
+		if errGet != nil {
+			if !errors.Is(errGet, jetstream.ErrKeyNotFound) {
+				slog.WarnContext(ctx, "failed to read cost budget, allowing the operation", "error", errGet)
+				return nil
+			}
+			_, errCreate := g.kv.Create(ctx, key, []byte("1"))
+			if errCreate == nil {
+				return nil
+			}
+			if !errors.Is(errCreate, jetstream.ErrKeyExists) {
+				slog.WarnContext(ctx, "failed to charge cost budget, allowing the operation", "error", errCreate)
+				return nil
+			}
+			continue
+		}
+
+		spent, _ := strconv.ParseInt(string(current.Value()), 10, 64)
+		if spent >= budget {
+			slog.WarnContext(ctx, "daily budget exhausted",
+				"caller", caller,
+				"cost_class", class,
+				"budget", budget,
+			)
+			// the budgets are renewed at midnight UTC
+			midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+			return errs.NewTooManyRequests("daily budget of expensive operations exhausted").WithRetryAfter(midnight.Sub(now))
+		}
+		if _, errUpdate := g.kv.Update(ctx, key, []byte(strconv.FormatInt(spent+1, 10)), current.Revision()); errUpdate == nil {
+			return nil
+		}
+		// the count changed in between, read it again
+	}
+
+	slog.WarnContext(ctx, "cost budget contended, allowing the operation", "caller", caller)
+	return nil
+}
+
+// NewGuard creates a guard keeping the counts in the given KV bucket
+func NewGuard(kv jetstream.KeyValue, budgets Budgets) *Guard {
+	return newGuard(kv, budgets, time.Now)
+}
+
+func newGuard(kv kvStore, budgets Budgets, now func() time.Time) *Guard {
+	return &Guard{
+		kv:      kv,
+		budgets: budgets,
+		now:     now,
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestParseBudgets(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		wantErr     bool
+		wantDefault int64
+		wantCallers map[string]int64
+	}{
+		{name: "empty spec", spec: "", wantCallers: map[string]int64{}},
+		{
+			name:        "default and overrides",
+			spec:        "default=100, Project-Service=1000,search-service=0",
+			wantDefault: 100,
+			wantCallers: map[string]int64{"project-service": 1000, "search-service": 0},
+		},
+		{name: "missing budget", spec: "default", wantErr: true},
+		{name: "invalid budget", spec: "default=many", wantErr: true},
+		{name: "negative budget", spec: "project-service=-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budgets, err := ParseBudgets(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBudgets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if budgets.Default != tt.wantDefault {
+				t.Errorf("Default = %d, want %d", budgets.Default, tt.wantDefault)
+			}
+			if len(budgets.Callers) != len(tt.wantCallers) {
+				t.Fatalf("Callers = %v, want %v", budgets.Callers, tt.wantCallers)
+			}
+			for caller, want := range tt.wantCallers {
+				if got := budgets.For(caller); got != want {
+					t.Errorf("For(%q) = %d, want %d", caller, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGuard_Charge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	budgets := Budgets{Default: 2, Callers: map[string]int64{"search-service": 0}}
+	guard := newGuard(kv, budgets, func() time.Time { return now })
+
+	for i := 0; i < 2; i++ {
+		if err := guard.Charge(ctx, "project-service", model.CostClassExpensive); err != nil {
+			t.Fatalf("Charge() #%d unexpected error: %v", i, err)
+		}
+	}
+
+	err := guard.Charge(ctx, "Project-Service", model.CostClassExpensive)
+	var tooMany errs.TooManyRequests
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("Charge() error = %v, want TooManyRequests", err)
+	}
+	if tooMany.RetryAfter() != 6*time.Hour {
+		t.Errorf("RetryAfter() = %v, want the time until midnight UTC", tooMany.RetryAfter())
+	}
+
+	// the cheap operations and the unlimited callers are not budgeted
+	if err := guard.Charge(ctx, "project-service", model.CostClassCheap); err != nil {
+		t.Errorf("Charge() cheap unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := guard.Charge(ctx, "search-service", model.CostClassExpensive); err != nil {
+			t.Fatalf("Charge() unlimited unexpected error: %v", err)
+		}
+	}
+
+	// the budgets are renewed every day
+	now = now.Add(7 * time.Hour)
+	if err := guard.Charge(ctx, "project-service", model.CostClassExpensive); err != nil {
+		t.Errorf("Charge() next day unexpected error: %v", err)
+	}
+
+	// the budget counts are not part of the usage report
+	records, errReport := newAccountant(kv, time.Now).UsageReport(ctx, now.AddDate(0, 0, -1), now, "")
+	if errReport != nil || len(records) != 0 {
+		t.Errorf("UsageReport() = %+v, %v, want no records", records, errReport)
+	}
+}
+
+func TestGuard_ChargeFailsOpen(t *testing.T) {
+	kv := &fakeKV{data: make(map[string]fakeEntry), getErr: errors.New("nats unavailable")}
+	guard := newGuard(kv, Budgets{Default: 1}, time.Now)
+
+	if err := guard.Charge(context.Background(), "project-service", model.CostClassExpensive); err != nil {
+		t.Errorf("Charge() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package usage counts the requests per caller and operation, the counts are aggregated
+// in memory and periodically added to the daily aggregates stored in a NATS KV bucket,
+// so all the replicas contribute to the same aggregates.
+package usage
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultFlushInterval is how often the in memory counts are added to the daily aggregates
+	DefaultFlushInterval = time.Minute
+
+	// dateLayout is the layout of the day of the aggregates, in UTC
+	dateLayout = "2006-01-02"
+
+	// maxCallerLength bounds the caller identity, it comes from a message header
+	maxCallerLength = 64
+
+	// maxFlushAttempts is the number of attempts to add a count when other replicas update the same aggregate
+	maxFlushAttempts = 5
+)
+
+// kvStore is the subset of the NATS KV bucket used by the accountant
+type kvStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+	ListKeysFiltered(ctx context.Context, filters ...string) (jetstream.KeyLister, error)
+}
+
+// counter identifies a daily aggregate
+type counter struct {
+	date      string
+	caller    string
+	operation string
+}
+
+// key is the KV key of the aggregate, the caller and the operation are encoded
+// since they can contain characters not allowed in keys
+func (c counter) key() string {
+	return c.date + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(c.caller)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(c.operation))
+}
+
+func parseKey(key string) (counter, bool) {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 {
+		return counter{}, false
+	}
+	caller, errCaller := base64.RawURLEncoding.DecodeString(parts[1])
+	operation, errOperation := base64.RawURLEncoding.DecodeString(parts[2])
+	if errCaller != nil || errOperation != nil {
+		return counter{}, false
+	}
+	return counter{date: parts[0], caller: string(caller), operation: string(operation)}, true
+}
+
+// NormalizeCaller bounds the caller identity, requests without caller are attributed to model.UnknownCaller
+func NormalizeCaller(caller string) string {
+	caller = strings.ToLower(strings.TrimSpace(caller))
+	if caller == "" {
+		return model.UnknownCaller
+	}
+	if len(caller) > maxCallerLength {
+		caller = caller[:maxCallerLength]
+	}
+	return caller
+}
+
+// Accountant counts the requests and persists the daily aggregates
+type Accountant struct {
+	kv  kvStore
+	now func() time.Time
+
+	mu      sync.Mutex
+	pending map[counter]int64
+}
+
+// RecordUsage counts a request of the caller for the operation, it only updates the in memory counts
+func (a *Accountant) RecordUsage(caller, operation string) {
+	c := counter{
+		date:      a.now().UTC().Format(dateLayout),
+		caller:    NormalizeCaller(caller),
+		operation: operation,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending[c]++
+}
+
+// Flush adds the in memory counts to the daily aggregates, the counts that couldn't
+// be added are kept for the next flush
+func (a *Accountant) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	pending := a.pending
+	a.pending = make(map[counter]int64)
+	a.mu.Unlock()
+
+	var firstErr error
+	for c, count := range pending {
+		if err := a.add(ctx, c.key(), count); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			a.mu.Lock()
+			a.pending[c] += count
+			a.mu.Unlock()
+		}
+	}
+	return firstErr
+}
+
+// add adds the count to the aggregate with compare-and-set, other replicas update the same keys
+func (a *Accountant) add(ctx context.Context, key string, count int64) error {
+	for attempt := 0; attempt < maxFlushAttempts; attempt++ {
+		current, errGet := a.kv.Get(ctx, key)
+		if errGet != nil {
+			if !errors.Is(errGet, jetstream.ErrKeyNotFound) {
+				return errGet
+			}
+			if _, errCreate := a.kv.Create(ctx, key, []byte(strconv.FormatInt(count, 10))); errCreate == nil || !errors.Is(errCreate, jetstream.ErrKeyExists) {
+				return errCreate
+			}
+			continue
+		}
+
+		stored, _ := strconv.ParseInt(string(current.Value()), 10, 64)
+		if _, errUpdate := a.kv.Update(ctx, key, []byte(strconv.FormatInt(stored+count, 10)), current.Revision()); errUpdate == nil {
+			return nil
+		}
+		// the aggregate changed in between, read it again
+	}
+	return errs.NewConflict("usage aggregate has been modified by another process, please retry")
+}
+
+// Run flushes the counts periodically until the context is done, then flushes one last time
+func (a *Accountant) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// the service is shutting down, use a fresh context for the last flush
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := a.Flush(flushCtx); err != nil {
+				slog.Error("failed to flush usage counts on shutdown", "error", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := a.Flush(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to flush usage counts", "error", err)
+			}
+		}
+	}
+}
+
+// UsageReport returns the daily aggregates between both days (inclusive), optionally for a single caller.
+// The counts not flushed yet are not included.
+func (a *Accountant) UsageReport(ctx context.Context, from, to time.Time, caller string) ([]model.UsageRecord, error) {
+	if caller != "" {
+		caller = NormalizeCaller(caller)
+	}
+
+	var records []model.UsageRecord
+	for day := from.UTC(); !day.After(to.UTC()); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+
+		filter := date + ".>"
+		if caller != "" {
+			filter = date + "." + base64.RawURLEncoding.EncodeToString([]byte(caller)) + ".>"
+		}
+
+		lister, errList := a.kv.ListKeysFiltered(ctx, filter)
+		if errList != nil {
+			return nil, errs.NewUnexpected("failed to list usage aggregates", errList)
+		}
+		for key := range lister.Keys() {
+			c, ok := parseKey(key)
+			if !ok {
+				continue
+			}
+			entry, errGet := a.kv.Get(ctx, key)
+			if errGet != nil {
+				if errors.Is(errGet, jetstream.ErrKeyNotFound) {
+					continue
+				}
+				return nil, errs.NewUnexpected("failed to read usage aggregate", errGet)
+			}
+			count, _ := strconv.ParseInt(string(entry.Value()), 10, 64)
+			records = append(records, model.UsageRecord{
+				Date:      c.date,
+				Caller:    c.caller,
+				Operation: c.operation,
+				Count:     count,
+			})
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Date != records[j].Date {
+			return records[i].Date < records[j].Date
+		}
+		if records[i].Caller != records[j].Caller {
+			return records[i].Caller < records[j].Caller
+		}
+		return records[i].Operation < records[j].Operation
+	})
+	return records, nil
+}
+
+// NewAccountant creates an accountant persisting the aggregates in the given KV bucket
+func NewAccountant(kv jetstream.KeyValue) *Accountant {
+	return newAccountant(kv, time.Now)
+}
+
+func newAccountant(kv kvStore, now func() time.Time) *Accountant {
+	return &Accountant{
+		kv:      kv,
+		now:     now,
+		pending: make(map[counter]int64),
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+type fakeLister struct {
+	jetstream.KeyLister
+	keys chan string
+}
+
+func (l fakeLister) Keys() <-chan string { return l.keys }
+
+// fakeKV is an in-memory kvStore, conflicts makes the next updates fail as if another replica wrote the key
+type fakeKV struct {
+	data      map[string]fakeEntry
+	conflicts int
+	getErr    error
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeKV) Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error) {
+	if _, ok := f.data[key]; ok {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.data[key] = fakeEntry{value: value, revision: 1}
+	return 1, nil
+}
+
+func (f *fakeKV) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return 0, errors.New("wrong last sequence")
+	}
+	if f.data[key].revision != revision {
+		return 0, errors.New("wrong last sequence")
+	}
+	f.data[key] = fakeEntry{value: value, revision: revision + 1}
+	return revision + 1, nil
+}
+
+func (f *fakeKV) ListKeysFiltered(ctx context.Context, filters ...string) (jetstream.KeyLister, error) {
+	keys := make(chan string, len(f.data))
+	for key := range f.data {
+		for _, filter := range filters {
+			if strings.HasPrefix(key, strings.TrimSuffix(filter, ">")) {
+				keys <- key
+			}
+		}
+	}
+	close(keys)
+	return fakeLister{keys: keys}, nil
+}
+
+func TestNormalizeCaller(t *testing.T) {
+	tests := []struct {
+		caller string
+		want   string
+	}{
+		{caller: "", want: model.UnknownCaller},
+		{caller: "  Project-Service ", want: "project-service"},
+		{caller: strings.Repeat("a", 100), want: strings.Repeat("a", maxCallerLength)},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeCaller(tt.caller); got != tt.want {
+			t.Errorf("NormalizeCaller(%q) = %q, want %q", tt.caller, got, tt.want)
+		}
+	}
+}
+
+func TestAccountant_FlushAndReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC)
+	kv := &fakeKV{data: make(map[string]fakeEntry)}
+	accountant := newAccountant(kv, func() time.Time { return now })
+
+	accountant.RecordUsage("project-service", "lfx.auth-service.user_metadata.read")
+	accountant.RecordUsage("project-service", "lfx.auth-service.user_metadata.read")
+	accountant.RecordUsage("", "lfx.auth-service.email_to_sub")
+	if err := accountant.Flush(ctx); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+
+	// another flush adds to the aggregates, even when other replicas updated them in between
+	kv.conflicts = 2
+	accountant.RecordUsage("project-service", "lfx.auth-service.user_metadata.read")
+	now = now.Add(2 * time.Hour)
+	accountant.RecordUsage("project-service", "lfx.auth-service.user_metadata.read")
+	if err := accountant.Flush(ctx); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	records, err := accountant.UsageReport(ctx, from, from.AddDate(0, 0, 1), "")
+	if err != nil {
+		t.Fatalf("UsageReport() unexpected error: %v", err)
+	}
+	want := []model.UsageRecord{
+		{Date: "2025-01-01", Caller: "project-service", Operation: "lfx.auth-service.user_metadata.read", Count: 3},
+		{Date: "2025-01-01", Caller: model.UnknownCaller, Operation: "lfx.auth-service.email_to_sub", Count: 1},
+		{Date: "2025-01-02", Caller: "project-service", Operation: "lfx.auth-service.user_metadata.read", Count: 1},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("UsageReport() = %+v, want %+v", records, want)
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("UsageReport()[%d] = %+v, want %+v", i, records[i], want[i])
+		}
+	}
+
+	records, err = accountant.UsageReport(ctx, from, from, "Project-Service")
+	if err != nil {
+		t.Fatalf("UsageReport() unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Count != 3 {
+		t.Errorf("UsageReport() for a caller = %+v", records)
+	}
+}
+
+func TestAccountant_FlushKeepsFailedCounts(t *testing.T) {
+	ctx := context.Background()
+	kv := &fakeKV{data: make(map[string]fakeEntry), getErr: errors.New("nats unavailable")}
+	accountant := newAccountant(kv, time.Now)
+
+	accountant.RecordUsage("project-service", "lfx.auth-service.user_metadata.read")
+	if err := accountant.Flush(ctx); err == nil {
+		t.Fatal("Flush() expected an error")
+	}
+
+	kv.getErr = nil
+	if err := accountant.Flush(ctx); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+	if len(kv.data) != 1 {
+		t.Errorf("aggregates = %d, want 1", len(kv.data))
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value []byte
+}
+
+func (e fakeEntry) Value() []byte { return e.value }
+
+// fakeKeyValue is an in-memory user cache KV bucket
+type fakeKeyValue struct {
+	jetstream.KeyValue
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newFakeKeyValue() *fakeKeyValue {
+	return &fakeKeyValue{data: make(map[string][]byte)}
+}
+
+func (f *fakeKeyValue) Get(_ context.Context, key string) (jetstream.KeyValueEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return fakeEntry{value: value}, nil
+}
+
+func (f *fakeKeyValue) Put(_ context.Context, key string, value []byte) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return uint64(len(f.data)), nil
+}
+
+func (f *fakeKeyValue) Delete(_ context.Context, key string, _ ...jetstream.KVDeleteOpt) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	return nil
+}
+
+// fakeKeyLister lists the keys of the fake bucket
+type fakeKeyLister struct {
+	keys chan string
+}
+
+func (l *fakeKeyLister) Keys() <-chan string { return l.keys }
+func (l *fakeKeyLister) Stop() error         { return nil }
+
+func (f *fakeKeyValue) ListKeys(_ context.Context, _ ...jetstream.WatchOpt) (jetstream.KeyLister, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lister := &fakeKeyLister{keys: make(chan string, len(f.data))}
+	for key := range f.data {
+		lister.keys <- key
+	}
+	close(lister.keys)
+	return lister, nil
+}
+
+func TestCaches(t *testing.T) {
+	ctx := context.Background()
+
+	caches := map[string]func(c clock.Clock) port.UserCache{
+		"memory": func(c clock.Clock) port.UserCache { return NewLRU(100, time.Minute, WithClock(c)) },
+		"nats":   func(c clock.Clock) port.UserCache { return NewKV(newFakeKeyValue(), time.Minute, WithClock(c)) },
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			fakeClock := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+			cache := newCache(fakeClock)
+
+			user := &model.User{Token: "secret-token", UserID: "auth0|123", Username: "jdoe"}
+			cache.Set(ctx, user, "sub:auth0|123", "username:jdoe")
+
+			found, ok := cache.Get(ctx, "username:jdoe")
+			require.True(t, ok)
+			assert.Equal(t, "auth0|123", found.UserID)
+			assert.Empty(t, found.Token, "the credentials are never cached")
+
+			found.Username = "changed"
+			found, ok = cache.Get(ctx, "sub:auth0|123")
+			require.True(t, ok)
+			assert.Equal(t, "jdoe", found.Username, "the cached users are copies")
+
+			cache.Delete(ctx, "sub:auth0|123")
+			_, ok = cache.Get(ctx, "sub:auth0|123")
+			assert.False(t, ok)
+
+			fakeClock.Advance(time.Minute)
+			_, ok = cache.Get(ctx, "username:jdoe")
+			assert.False(t, ok, "the users expire after the TTL")
+
+			stale, age, ok := cache.(staleCache).GetStale(ctx, "username:jdoe")
+			require.True(t, ok, "the expired users are kept for the stale fallback")
+			assert.Equal(t, "jdoe", stale.Username)
+			assert.Equal(t, time.Minute, age)
+			_, _, ok = cache.(staleCache).GetStale(ctx, "sub:auth0|123")
+			assert.False(t, ok, "the removed users are not kept")
+
+			_, ok = cache.Get(ctx, "email:unknown@example.com")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestLRU_Eviction(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRU(2, time.Minute)
+
+	cache.Set(ctx, &model.User{UserID: "auth0|1"}, "sub:auth0|1")
+	cache.Set(ctx, &model.User{UserID: "auth0|2"}, "sub:auth0|2")
+	_, ok := cache.Get(ctx, "sub:auth0|1")
+	require.True(t, ok)
+
+	cache.Set(ctx, &model.User{UserID: "auth0|3"}, "sub:auth0|3")
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get(ctx, "sub:auth0|2")
+	assert.False(t, ok, "the least recently used key is evicted")
+	_, ok = cache.Get(ctx, "sub:auth0|1")
+	assert.True(t, ok)
+}
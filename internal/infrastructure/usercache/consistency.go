@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// subLister is implemented by the caches able to list the cached users
+type subLister interface {
+	Subs(ctx context.Context) ([]string, error)
+}
+
+// Subs returns the subs of the users cached in the bucket, expired or not
+func (c *KV) Subs(ctx context.Context) ([]string, error) {
+	lister, err := c.kv.ListKeys(ctx)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoKeysFound) {
+			return nil, nil
+		}
+		return nil, errs.NewUnexpected("failed to list the user cache keys", err)
+	}
+	defer func() { _ = lister.Stop() }()
+
+	var subs []string
+	for encoded := range lister.Keys() {
+		key, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		if sub, ok := strings.CutPrefix(string(key), subKey("")); ok && sub != "" {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+// Subs returns the subs of the cached users not expired yet
+func (c *LRU) Subs(_ context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	var subs []string
+	for key, element := range c.entries {
+		if !now.Before(element.Value.(*lruEntry).expiresAt) {
+			continue
+		}
+		if sub, ok := strings.CutPrefix(key, subKey("")); ok && sub != "" {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+// Subs returns the subs of the cached users, none when the cache can't list them
+func (c *UserReaderWriter) Subs(ctx context.Context) ([]string, error) {
+	lister, ok := c.cache.(subLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.Subs(ctx)
+}
+
+// lookupEmails returns the alternate emails of the user, lowercased and sorted
+func lookupEmails(user *model.User) []string {
+	emails := make([]string, 0, len(user.AlternateEmails))
+	for _, email := range user.AlternateEmails {
+		emails = append(emails, strings.ToLower(strings.TrimSpace(email.Email)))
+	}
+	slices.Sort(emails)
+	return emails
+}
+
+// sameLookups reports whether the users are found by the same username and emails
+func sameLookups(cached, user *model.User) bool {
+	return cached.UserID == user.UserID &&
+		cached.Username == user.Username &&
+		strings.EqualFold(strings.TrimSpace(cached.PrimaryEmail), strings.TrimSpace(user.PrimaryEmail)) &&
+		slices.Equal(lookupEmails(cached), lookupEmails(user))
+}
+
+// Diverged reports whether the cache serves the user of the sub differently than the identity
+// provider, under its sub or one of its lookup keys, the user is nil when it no longer exists
+func (c *UserReaderWriter) Diverged(ctx context.Context, sub string, user *model.User) (bool, error) {
+	cached, ok := c.cache.Get(ctx, subKey(sub))
+	if user == nil {
+		return ok, nil
+	}
+	if ok && !sameLookups(cached, user) {
+		return true, nil
+	}
+
+	// a lookup key of the user serving another user breaks the lookups, e.g. the email to username
+	for _, key := range keysOf(user) {
+		if found, ok := c.cache.Get(ctx, key); ok && found.UserID != user.UserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Repair removes the user of the sub from the cache, under its cached and its current lookup keys,
+// the next lookups read it again from the identity provider
+func (c *UserReaderWriter) Repair(ctx context.Context, sub string, user *model.User) error {
+	c.Invalidate(ctx, sub)
+	if user != nil {
+		c.cache.Delete(ctx, keysOf(user)...)
+	}
+	return nil
+}
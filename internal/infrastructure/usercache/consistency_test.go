@@ -0,0 +1,104 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+func TestCaches_Subs(t *testing.T) {
+	ctx := context.Background()
+
+	caches := map[string]port.UserCache{
+		"memory": NewLRU(100, time.Minute),
+		"nats":   NewKV(newFakeKeyValue(), time.Minute),
+	}
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			cached := NewUserReaderWriter(newFakeProvider(), cache)
+			cache.Set(ctx, &model.User{UserID: "auth0|1", Username: "jane"}, keysOf(&model.User{UserID: "auth0|1", Username: "jane"})...)
+			cache.Set(ctx, &model.User{UserID: "auth0|2"}, subKey("auth0|2"))
+
+			subs, err := cached.Subs(ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"auth0|1", "auth0|2"}, subs)
+		})
+	}
+}
+
+func TestUserReaderWriter_Diverged(t *testing.T) {
+	ctx := context.Background()
+	current := func() *model.User { return newFakeProvider().users["auth0|123"].Clone() }
+
+	tests := []struct {
+		name         string
+		cache        func(cache port.UserCache)
+		user         *model.User
+		wantDiverged bool
+	}{
+		{
+			name:  "nothing cached",
+			cache: func(port.UserCache) {},
+			user:  current(),
+		},
+		{
+			name: "cached as in the provider",
+			cache: func(cache port.UserCache) {
+				cache.Set(ctx, current(), keysOf(current())...)
+			},
+			user: current(),
+		},
+		{
+			name: "stale alternate emails",
+			cache: func(cache port.UserCache) {
+				stale := current()
+				stale.AlternateEmails = nil
+				cache.Set(ctx, stale, keysOf(stale)...)
+			},
+			user:         current(),
+			wantDiverged: true,
+		},
+		{
+			name: "email served by another user",
+			cache: func(cache port.UserCache) {
+				cache.Set(ctx, &model.User{UserID: "auth0|456"}, searchKey(constants.CriteriaTypeEmail, "jane@example.com"))
+			},
+			user:         current(),
+			wantDiverged: true,
+		},
+		{
+			name: "user deleted in the provider",
+			cache: func(cache port.UserCache) {
+				cache.Set(ctx, current(), keysOf(current())...)
+			},
+			wantDiverged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewLRU(100, time.Minute)
+			tt.cache(cache)
+			cached := NewUserReaderWriter(newFakeProvider(), cache)
+
+			diverged, err := cached.Diverged(ctx, "auth0|123", tt.user)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDiverged, diverged)
+
+			require.NoError(t, cached.Repair(ctx, "auth0|123", tt.user))
+			diverged, err = cached.Diverged(ctx, "auth0|123", tt.user)
+			require.NoError(t, err)
+			assert.False(t, diverged, "the repair drops the divergent keys")
+		})
+	}
+}
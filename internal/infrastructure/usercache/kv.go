@@ -0,0 +1,123 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+// kvEntry is the value of a key of the KV cache, the expiration is kept with the user since
+// the bucket TTL can be longer than the cache TTL, the expired users are served by the stale
+// fallback until the bucket TTL removes them
+type kvEntry struct {
+	User      json.RawMessage `json:"user"`
+	CachedAt  time.Time       `json:"cached_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// KV is the cache shared by the replicas in the user cache KV bucket
+type KV struct {
+	kv    jetstream.KeyValue
+	ttl   time.Duration
+	clock clock.Clock
+}
+
+// kvKey returns the bucket key of a cache key, the emails and the subs have characters
+// not allowed in a bucket key
+func kvKey(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// entry returns the entry of the key, a bucket failure is a miss
+func (c *KV) entry(ctx context.Context, key string) (*kvEntry, bool) {
+	value, err := c.kv.Get(ctx, kvKey(key))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			slog.WarnContext(ctx, "failed to read the user cache", "error", err)
+		}
+		return nil, false
+	}
+
+	var entry kvEntry
+	if err := json.Unmarshal(value.Value(), &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Get returns the user cached under the key, until it expires, a bucket failure is a miss
+func (c *KV) Get(ctx context.Context, key string) (*model.User, bool) {
+	entry, ok := c.entry(ctx, key)
+	if !ok || !c.clock.Now().Before(entry.ExpiresAt) {
+		return nil, false
+	}
+	return decode(entry.User)
+}
+
+// GetStale returns the user cached under the key, expired or not, and how long ago it was cached
+func (c *KV) GetStale(ctx context.Context, key string) (*model.User, time.Duration, bool) {
+	entry, ok := c.entry(ctx, key)
+	if !ok {
+		return nil, 0, false
+	}
+	// the entries written before the cached time was kept were cached a TTL before their expiration
+	cachedAt := entry.CachedAt
+	if cachedAt.IsZero() {
+		cachedAt = entry.ExpiresAt.Add(-c.ttl)
+	}
+	user, ok := decode(entry.User)
+	return user, c.clock.Now().Sub(cachedAt), ok
+}
+
+// Set caches the user under the keys, a bucket failure is only logged
+func (c *KV) Set(ctx context.Context, user *model.User, keys ...string) {
+	data, err := encode(user)
+	if err != nil {
+		return
+	}
+	now := c.clock.Now()
+	value, err := json.Marshal(&kvEntry{User: data, CachedAt: now, ExpiresAt: now.Add(c.ttl)})
+	if err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		if _, err := c.kv.Put(ctx, kvKey(key), value); err != nil {
+			slog.WarnContext(ctx, "failed to write the user cache", "error", err)
+			return
+		}
+	}
+}
+
+// Delete removes the keys, a bucket failure is only logged
+func (c *KV) Delete(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := c.kv.Delete(ctx, kvKey(key)); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+			slog.WarnContext(ctx, "failed to invalidate the user cache", "error", err)
+		}
+	}
+}
+
+// NewKV creates the cache on the user cache KV bucket, served for ttl
+func NewKV(kv jetstream.KeyValue, ttl time.Duration, opts ...Option) *KV {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	o := newOptions(opts)
+	return &KV{
+		kv:    kv,
+		ttl:   ttl,
+		clock: o.clock,
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package usercache serves the repeated user lookups from a cache, to spare the identity provider
+// API limits. The users are cached under each of their lookup keys (sub, username, emails) for a
+// TTL, and invalidated when the service changes them.
+package usercache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+const (
+	// DefaultTTL is how long a cached user is served by default
+	DefaultTTL = time.Minute
+	// DefaultSize is the default maximum number of keys of the memory cache
+	DefaultSize = 10000
+)
+
+// Option configures the caches
+type Option func(*options)
+
+type options struct {
+	clock clock.Clock
+}
+
+// WithClock sets the time source of the expirations
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.clock = clock.Or(o.clock)
+	return o
+}
+
+// encode returns the cached form of the user, the caller credentials are never cached
+func encode(user *model.User) ([]byte, error) {
+	cached := *user
+	cached.Token = ""
+	return json.Marshal(&cached)
+}
+
+// decode returns a copy of the cached user, the callers are free to change it
+func decode(data []byte) (*model.User, bool) {
+	var user model.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, false
+	}
+	return &user, true
+}
+
+type lruEntry struct {
+	key       string
+	user      []byte
+	cachedAt  time.Time
+	expiresAt time.Time
+}
+
+// LRU is the in-memory cache of a replica, the least recently used keys are evicted first
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	clock   clock.Clock
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// Get returns the user cached under the key, until it expires. The expired users are kept until
+// evicted, for the stale fallback.
+func (c *LRU) Get(_ context.Context, key string) (*model.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.clock.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return decode(entry.user)
+}
+
+// GetStale returns the user cached under the key, expired or not, and how long ago it was cached
+func (c *LRU) GetStale(_ context.Context, key string) (*model.User, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	entry := element.Value.(*lruEntry)
+	user, ok := decode(entry.user)
+	return user, c.clock.Now().Sub(entry.cachedAt), ok
+}
+
+// Set caches the user under the keys
+func (c *LRU) Set(_ context.Context, user *model.User, keys ...string) {
+	data, err := encode(user)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	expiresAt := now.Add(c.ttl)
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			entry := element.Value.(*lruEntry)
+			entry.user, entry.cachedAt, entry.expiresAt = data, now, expiresAt
+			c.order.MoveToFront(element)
+			continue
+		}
+		c.entries[key] = c.order.PushFront(&lruEntry{key: key, user: data, cachedAt: now, expiresAt: expiresAt})
+		for c.order.Len() > c.size {
+			c.remove(c.order.Back())
+		}
+	}
+}
+
+// Delete removes the keys
+func (c *LRU) Delete(_ context.Context, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// Len returns the number of cached keys, expired or not
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
+
+// NewLRU creates the in-memory cache of up to size keys, served for ttl
+func NewLRU(size int, ttl time.Duration, opts ...Option) *LRU {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	o := newOptions(opts)
+	return &LRU{
+		size:    size,
+		ttl:     ttl,
+		clock:   o.clock,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	resultHit   = "hit"
+	resultMiss  = "miss"
+	resultStale = "stale"
+)
+
+// staleCache is implemented by the caches keeping the expired users, for the stale fallback
+type staleCache interface {
+	GetStale(ctx context.Context, key string) (*model.User, time.Duration, bool)
+}
+
+// ReaderOption configures the cached user reader writer
+type ReaderOption func(*UserReaderWriter)
+
+// WithStaleFallback serves the expired users cached up to maxStaleness ago when the identity
+// provider is unavailable, the users are marked stale with their age
+func WithStaleFallback(maxStaleness time.Duration) ReaderOption {
+	return func(c *UserReaderWriter) {
+		c.maxStaleness = maxStaleness
+	}
+}
+
+// UserReaderWriter serves the GetUser and SearchUser lookups of the identity provider from the
+// cache, the changes made through it invalidate the changed user. The other operations go
+// straight to the identity provider.
+type UserReaderWriter struct {
+	port.UserReaderWriter
+	cache   port.UserCache
+	lookups metric.Int64Counter
+
+	// maxStaleness is the age of the oldest user served while the identity provider is
+	// unavailable, zero disables the stale fallback
+	maxStaleness time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// Stats is the usage of the cache since the replica started
+type Stats struct {
+	Hits   int64
+	Misses int64
+	// Entries is the number of cached keys, nil when the cache doesn't count them
+	Entries *int
+}
+
+func subKey(sub string) string {
+	return "sub:" + sub
+}
+
+func searchKey(criteria constants.CriteriaType, value string) string {
+	if criteria != constants.CriteriaTypeUsername {
+		value = strings.ToLower(value)
+	}
+	return string(criteria) + ":" + strings.TrimSpace(value)
+}
+
+// keysOf returns the lookup keys of the user
+func keysOf(user *model.User) []string {
+	if user == nil {
+		return nil
+	}
+
+	var keys []string
+	add := func(key string, value string) {
+		if strings.TrimSpace(value) != "" {
+			keys = append(keys, key)
+		}
+	}
+	add(subKey(user.UserID), user.UserID)
+	if user.Sub != user.UserID {
+		add(subKey(user.Sub), user.Sub)
+	}
+	add(searchKey(constants.CriteriaTypeUsername, user.Username), user.Username)
+	add(searchKey(constants.CriteriaTypeEmail, user.PrimaryEmail), user.PrimaryEmail)
+	for _, email := range user.AlternateEmails {
+		add(searchKey(constants.CriteriaTypeAlternateEmail, email.Email), email.Email)
+	}
+	return keys
+}
+
+// userSearchKey returns the key of a search, empty when the search isn't cached
+func userSearchKey(user *model.User, criteria constants.CriteriaType) string {
+	var value string
+	switch criteria {
+	case constants.CriteriaTypeUsername:
+		value = user.Username
+	case constants.CriteriaTypeEmail:
+		value = user.PrimaryEmail
+	case constants.CriteriaTypeAlternateEmail:
+		if len(user.AlternateEmails) > 0 {
+			value = user.AlternateEmails[0].Email
+		}
+	}
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return searchKey(criteria, value)
+}
+
+// cached returns the user cached under the key, with the credentials of the request
+func (c *UserReaderWriter) cached(ctx context.Context, key string, request *model.User) (*model.User, bool) {
+	user, ok := c.cache.Get(ctx, key)
+	if !ok {
+		c.record(ctx, resultMiss)
+		return nil, false
+	}
+	c.record(ctx, resultHit)
+	user.Token = request.Token
+	return user, true
+}
+
+// stale returns the expired user cached under the key when the lookup failed because the identity
+// provider is unavailable, otherwise the error of the lookup
+func (c *UserReaderWriter) stale(ctx context.Context, key string, request *model.User, errLookup error) (*model.User, error) {
+	cache, ok := c.cache.(staleCache)
+	var unavailable errs.ServiceUnavailable
+	if c.maxStaleness <= 0 || !ok || !errors.As(errLookup, &unavailable) {
+		return nil, errLookup
+	}
+
+	user, age, ok := cache.GetStale(ctx, key)
+	if !ok || age > c.maxStaleness {
+		return nil, errLookup
+	}
+	c.record(ctx, resultStale)
+	slog.WarnContext(ctx, "identity provider unavailable, serving the stale cached user",
+		"error", errLookup,
+		"user", user,
+		"age", age,
+	)
+	user.Token = request.Token
+	user.StaleAge = age
+	return user, nil
+}
+
+// GetUser returns the user by its sub, from the cache when it's there
+func (c *UserReaderWriter) GetUser(ctx context.Context, user *model.User) (*model.User, error) {
+	sub := user.ProfileSub()
+	if sub == "" {
+		return c.UserReaderWriter.GetUser(ctx, user)
+	}
+	if found, ok := c.cached(ctx, subKey(sub), user); ok {
+		return found, nil
+	}
+
+	found, err := c.UserReaderWriter.GetUser(ctx, user)
+	if err != nil {
+		return c.stale(ctx, subKey(sub), user, err)
+	}
+	c.cache.Set(ctx, found, append(keysOf(found), subKey(sub))...)
+	return found, nil
+}
+
+// SearchUser returns the user by username, email or alternate email, from the cache when it's there
+func (c *UserReaderWriter) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+	key := userSearchKey(user, criteria)
+	if key == "" {
+		return c.UserReaderWriter.SearchUser(ctx, user, criteria)
+	}
+	if found, ok := c.cached(ctx, key, user); ok {
+		return found, nil
+	}
+
+	found, err := c.UserReaderWriter.SearchUser(ctx, user, criteria)
+	if err != nil {
+		return c.stale(ctx, key, user, err)
+	}
+	c.cache.Set(ctx, found, append(keysOf(found), key)...)
+	return found, nil
+}
+
+// UpdateUser updates the user and invalidates it, even when the update failed since it may be partial
+func (c *UserReaderWriter) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	updated, err := c.UserReaderWriter.UpdateUser(ctx, user)
+	c.Invalidate(ctx, user.ProfileSub())
+	c.cache.Delete(ctx, append(keysOf(user), keysOf(updated)...)...)
+	return updated, err
+}
+
+// LinkIdentity links the identity and invalidates the user, its emails changed
+func (c *UserReaderWriter) LinkIdentity(ctx context.Context, request *model.LinkIdentity) error {
+	err := c.UserReaderWriter.LinkIdentity(ctx, request)
+	c.Invalidate(ctx, request.User.UserID)
+	return err
+}
+
+// UnlinkIdentity unlinks the identity and invalidates the user, its emails changed
+func (c *UserReaderWriter) UnlinkIdentity(ctx context.Context, request *model.UnlinkIdentity) error {
+	err := c.UserReaderWriter.UnlinkIdentity(ctx, request)
+	c.Invalidate(ctx, request.User.UserID)
+	return err
+}
+
+// Invalidate removes the users of the subs, under all the keys they are cached
+func (c *UserReaderWriter) Invalidate(ctx context.Context, subs ...string) {
+	for _, sub := range subs {
+		if sub == "" {
+			continue
+		}
+		keys := []string{subKey(sub)}
+		if user, ok := c.cache.Get(ctx, subKey(sub)); ok {
+			keys = append(keys, keysOf(user)...)
+		}
+		c.cache.Delete(ctx, keys...)
+	}
+}
+
+// Handle invalidates the user of a profile changed event, it's the NATS handler of the events
+// so the changes made by the other replicas, or outside of the user reader writer, are seen
+func (c *UserReaderWriter) Handle(ctx context.Context, data []byte) {
+	var event model.UserProfileChanged
+	if err := json.Unmarshal(data, &event); err != nil || event.Sub == "" {
+		slog.WarnContext(ctx, "invalid profile changed event", "error", err)
+		return
+	}
+	if event.Reason == model.ProfileChangeSubscribed || event.Reason == model.ProfileChangeRepublish {
+		return
+	}
+
+	c.Invalidate(ctx, event.Sub)
+	slog.DebugContext(ctx, "user cache invalidated", "sub", redaction.Redact(event.Sub), "reason", event.Reason)
+}
+
+func (c *UserReaderWriter) record(ctx context.Context, result string) {
+	switch result {
+	case resultHit:
+		c.hits.Add(1)
+	case resultMiss:
+		c.misses.Add(1)
+	}
+	if c.lookups == nil {
+		return
+	}
+	c.lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+// Stats returns the usage of the cache since the replica started
+func (c *UserReaderWriter) Stats() Stats {
+	stats := Stats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+	if counted, ok := c.cache.(interface{ Len() int }); ok {
+		entries := counted.Len()
+		stats.Entries = &entries
+	}
+	return stats
+}
+
+// NewUserReaderWriter creates the cached user reader writer of the identity provider
+func NewUserReaderWriter(next port.UserReaderWriter, cache port.UserCache, opts ...ReaderOption) *UserReaderWriter {
+	c := &UserReaderWriter{
+		UserReaderWriter: next,
+		cache:            cache,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	lookups, errCounter := otel.Meter(constants.ServiceName).Int64Counter(
+		"auth_service.user_cache.lookups",
+		metric.WithDescription("Number of user lookups served by the users cache, by result: hit, miss or stale"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create user cache counter", "error", errCounter)
+	}
+	c.lookups = lookups
+	return c
+}
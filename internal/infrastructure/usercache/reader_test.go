@@ -0,0 +1,233 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package usercache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeProvider counts the calls to the identity provider
+type fakeProvider struct {
+	port.UserReaderWriter
+	users    map[string]*model.User
+	calls    int
+	updated  *model.User
+	unlinked bool
+	// err fails the lookups, like an identity provider outage
+	err error
+}
+
+func (f *fakeProvider) find(match func(*model.User) bool) (*model.User, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, user := range f.users {
+		if match(user) {
+			return user.Clone(), nil
+		}
+	}
+	return nil, errs.NewNotFound("user not found")
+}
+
+func (f *fakeProvider) GetUser(_ context.Context, user *model.User) (*model.User, error) {
+	return f.find(func(u *model.User) bool { return u.UserID == user.UserID })
+}
+
+func (f *fakeProvider) SearchUser(_ context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+	return f.find(func(u *model.User) bool {
+		switch criteria {
+		case constants.CriteriaTypeUsername:
+			return u.Username == user.Username
+		case constants.CriteriaTypeEmail:
+			return u.PrimaryEmail == user.PrimaryEmail
+		}
+		return false
+	})
+}
+
+func (f *fakeProvider) UpdateUser(_ context.Context, user *model.User) (*model.User, error) {
+	f.updated = user
+	f.users[user.UserID].UserMetadata = user.UserMetadata
+	updated := *f.users[user.UserID]
+	return &updated, nil
+}
+
+func (f *fakeProvider) UnlinkIdentity(_ context.Context, _ *model.UnlinkIdentity) error {
+	f.unlinked = true
+	return nil
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{users: map[string]*model.User{
+		"auth0|123": {
+			UserID:          "auth0|123",
+			Username:        "jdoe",
+			PrimaryEmail:    "jane@example.com",
+			AlternateEmails: []model.Email{{Email: "jane@work.example.com", Verified: true}},
+		},
+	}}
+}
+
+func TestUserReaderWriter_Lookups(t *testing.T) {
+	ctx := context.Background()
+	provider := newFakeProvider()
+	cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute))
+
+	user, err := cached.SearchUser(ctx, &model.User{Username: "jdoe", Token: "m2m"}, constants.CriteriaTypeUsername)
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|123", user.UserID)
+	assert.Equal(t, 1, provider.calls)
+
+	// the user is cached under all its lookup keys
+	user, err = cached.GetUser(ctx, &model.User{UserID: "auth0|123", Token: "user-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "user-token", user.Token, "the request credentials are kept")
+
+	_, err = cached.SearchUser(ctx, &model.User{PrimaryEmail: " Jane@Example.com"}, constants.CriteriaTypeEmail)
+	require.NoError(t, err)
+	_, err = cached.SearchUser(ctx, &model.User{AlternateEmails: []model.Email{{Email: "jane@work.example.com"}}}, constants.CriteriaTypeAlternateEmail)
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	stats := cached.Stats()
+	assert.Equal(t, int64(3), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	require.NotNil(t, stats.Entries)
+	assert.Equal(t, 4, *stats.Entries, "one key per lookup of the user")
+
+	t.Run("the errors are not cached", func(t *testing.T) {
+		for range 2 {
+			_, err := cached.GetUser(ctx, &model.User{UserID: "auth0|missing"})
+			require.Error(t, err)
+			assert.IsType(t, errs.NotFound{}, err)
+		}
+		assert.Equal(t, 3, provider.calls)
+	})
+}
+
+func TestUserReaderWriter_StaleFallback(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	provider := newFakeProvider()
+	cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute, WithClock(fakeClock)), WithStaleFallback(10*time.Minute))
+
+	_, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+	require.NoError(t, err)
+
+	fakeClock.Advance(5 * time.Minute)
+	provider.err = errs.NewServiceUnavailable("auth0 unavailable")
+	user, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123", Token: "user-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.Equal(t, "user-token", user.Token)
+	assert.True(t, user.Stale())
+	assert.Equal(t, 5*time.Minute, user.StaleAge)
+
+	user, err = cached.SearchUser(ctx, &model.User{PrimaryEmail: "jane@example.com"}, constants.CriteriaTypeEmail)
+	require.NoError(t, err)
+	assert.True(t, user.Stale())
+
+	t.Run("only while the provider is unavailable", func(t *testing.T) {
+		provider.err = errs.NewNotFound("user not found")
+		_, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+		assert.IsType(t, errs.NotFound{}, err)
+	})
+
+	t.Run("up to the max staleness", func(t *testing.T) {
+		provider.err = errs.NewServiceUnavailable("auth0 unavailable")
+		fakeClock.Advance(6 * time.Minute)
+		_, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+		assert.IsType(t, errs.ServiceUnavailable{}, err)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		provider := newFakeProvider()
+		cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute, WithClock(fakeClock)))
+		_, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+		require.NoError(t, err)
+
+		fakeClock.Advance(2 * time.Minute)
+		provider.err = errs.NewServiceUnavailable("auth0 unavailable")
+		_, err = cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+		assert.IsType(t, errs.ServiceUnavailable{}, err)
+	})
+}
+
+func TestUserReaderWriter_Invalidation(t *testing.T) {
+	ctx := context.Background()
+
+	lookup := func(t *testing.T, cached *UserReaderWriter) {
+		t.Helper()
+		_, err := cached.SearchUser(ctx, &model.User{Username: "jdoe"}, constants.CriteriaTypeUsername)
+		require.NoError(t, err)
+	}
+
+	t.Run("update", func(t *testing.T) {
+		provider := newFakeProvider()
+		cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute))
+		lookup(t, cached)
+
+		name := "Jane Doe"
+		_, err := cached.UpdateUser(ctx, &model.User{UserID: "auth0|123", UserMetadata: &model.UserMetadata{Name: &name}})
+		require.NoError(t, err)
+
+		user, err := cached.GetUser(ctx, &model.User{UserID: "auth0|123"})
+		require.NoError(t, err)
+		require.NotNil(t, user.UserMetadata)
+		assert.Equal(t, "Jane Doe", *user.UserMetadata.Name)
+		assert.Equal(t, 2, provider.calls)
+
+		lookup(t, cached)
+		assert.Equal(t, 2, provider.calls, "the user read after the update is cached")
+	})
+
+	t.Run("unlink", func(t *testing.T) {
+		provider := newFakeProvider()
+		cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute))
+		lookup(t, cached)
+
+		request := &model.UnlinkIdentity{}
+		request.User.UserID = "auth0|123"
+		require.NoError(t, cached.UnlinkIdentity(ctx, request))
+		assert.True(t, provider.unlinked)
+
+		lookup(t, cached)
+		assert.Equal(t, 2, provider.calls)
+	})
+
+	t.Run("profile changed event", func(t *testing.T) {
+		provider := newFakeProvider()
+		cached := NewUserReaderWriter(provider, NewLRU(100, time.Minute))
+		lookup(t, cached)
+
+		event := func(reason model.ProfileChangeReason) []byte {
+			data, err := json.Marshal(&model.UserProfileChanged{Sub: "auth0|123", Reason: reason})
+			require.NoError(t, err)
+			return data
+		}
+
+		cached.Handle(ctx, event(model.ProfileChangeSubscribed))
+		cached.Handle(ctx, []byte("not json"))
+		lookup(t, cached)
+		assert.Equal(t, 1, provider.calls)
+
+		cached.Handle(ctx, event(model.ProfileChangeMerge))
+		lookup(t, cached)
+		assert.Equal(t, 2, provider.calls, "the user is invalidated under all its keys")
+	})
+}
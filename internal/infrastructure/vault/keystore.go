@@ -0,0 +1,173 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package vault stores the signing keys of the service in a HashiCorp Vault KV version 2 secrets
+// engine, through its HTTP API
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Config is the location of the signing keys in Vault
+type Config struct {
+	// Address is the URL of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token authenticates the requests, it must read and write the secret
+	Token string
+	// Path is the path of the secret including its KV mount, e.g. secret/lfx/auth-service/signing-keys
+	Path string
+}
+
+// secret is the data of the KV secret
+type secret struct {
+	Keys []keyring.Key `json:"keys"`
+}
+
+// KeyStore stores the signing keys in a KV version 2 secret, the version of the secret is the
+// version of the keys so the writes are check-and-set
+type KeyStore struct {
+	address    string
+	token      string
+	dataURL    string
+	httpClient *http.Client
+}
+
+// Load returns the keys of the secret, none when the secret doesn't exist
+func (s *KeyStore) Load(ctx context.Context) ([]keyring.Key, string, error) {
+	status, body, err := s.do(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case status == http.StatusNotFound:
+		return nil, "", nil
+	case status != http.StatusOK:
+		return nil, "", s.statusError(ctx, "failed to read the signing keys", status, body)
+	}
+
+	var response struct {
+		Data struct {
+			Data     secret `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, "", errors.NewUnexpected("invalid signing keys secret", err)
+	}
+	return response.Data.Data.Keys, strconv.Itoa(response.Data.Metadata.Version), nil
+}
+
+// Save replaces the keys of the secret at the version, the empty version only creates the secret
+func (s *KeyStore) Save(ctx context.Context, keys []keyring.Key, version string) error {
+	cas := 0
+	if version != "" {
+		parsed, err := strconv.Atoi(version)
+		if err != nil {
+			return errors.NewValidation(fmt.Sprintf("invalid signing keys version %s", version))
+		}
+		cas = parsed
+	}
+
+	payload, errMarshal := json.Marshal(map[string]any{
+		"options": map[string]int{"cas": cas},
+		"data":    secret{Keys: keys},
+	})
+	if errMarshal != nil {
+		return errors.NewUnexpected("failed to encode the signing keys", errMarshal)
+	}
+
+	status, body, err := s.do(ctx, http.MethodPost, payload)
+	if err != nil {
+		return err
+	}
+	switch {
+	case status >= 200 && status < 300:
+		return nil
+	case status == http.StatusBadRequest && strings.Contains(string(body), "check-and-set"):
+		return errors.NewConflict("signing keys secret changed", fmt.Errorf("status code: %d", status))
+	default:
+		return s.statusError(ctx, "failed to save the signing keys", status, body)
+	}
+}
+
+func (s *KeyStore) do(ctx context.Context, method string, payload []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.dataURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, errors.NewUnexpected("failed to build the Vault request", err)
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to call Vault", "error", err, "address", s.address)
+		return 0, nil, errors.NewServiceUnavailable("failed to call Vault", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, errors.NewServiceUnavailable("failed to read the Vault response", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+func (s *KeyStore) statusError(ctx context.Context, message string, status int, body []byte) error {
+	slog.ErrorContext(ctx, "Vault returned error",
+		"status_code", status,
+		"response_body", string(body),
+		"address", s.address,
+	)
+	errStatus := fmt.Errorf("status code: %d", status)
+	switch {
+	case status == http.StatusTooManyRequests:
+		return errors.NewTooManyRequests(message, errStatus)
+	case status >= 500:
+		return errors.NewServiceUnavailable(message, errStatus)
+	default:
+		return errors.NewUnexpected(message, errStatus)
+	}
+}
+
+// NewKeyStore creates the store of the signing keys in the Vault secret
+func NewKeyStore(config Config) (*KeyStore, error) {
+	address := strings.TrimSuffix(strings.TrimSpace(config.Address), "/")
+	parsed, err := url.Parse(address)
+	if address == "" || err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Vault address %q, expected an absolute URL", config.Address)
+	}
+	if strings.TrimSpace(config.Token) == "" {
+		return nil, fmt.Errorf("the Vault token is required")
+	}
+	mount, path, found := strings.Cut(strings.Trim(config.Path, "/"), "/")
+	if !found || mount == "" || path == "" {
+		return nil, fmt.Errorf("invalid Vault secret path %q, expected <mount>/<path>", config.Path)
+	}
+
+	return &KeyStore{
+		address:    address,
+		token:      config.Token,
+		dataURL:    fmt.Sprintf("%s/v1/%s/data/%s", address, mount, path),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
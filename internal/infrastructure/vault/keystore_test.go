@@ -0,0 +1,118 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+// fakeVault is a KV version 2 secret, with the check-and-set of the writes
+type fakeVault struct {
+	mu      sync.Mutex
+	version int
+	data    json.RawMessage
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "vault-token" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if r.URL.Path != "/v1/secret/data/lfx/signing-keys" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if f.version == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"data": f.data, "metadata": map[string]int{"version": f.version}},
+		})
+	case http.MethodPost:
+		var request struct {
+			Options struct {
+				CAS int `json:"cas"`
+			} `json:"options"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if request.Options.CAS != f.version {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["check-and-set parameter did not match the current version"]}`))
+			return
+		}
+		f.version++
+		f.data = request.Data
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]int{"version": f.version}})
+	}
+}
+
+func TestKeyStore(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(&fakeVault{})
+	defer server.Close()
+
+	store, err := NewKeyStore(Config{Address: server.URL + "/", Token: "vault-token", Path: "/secret/lfx/signing-keys"})
+	require.NoError(t, err)
+
+	keys, version, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Empty(t, version)
+
+	createdAt := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	key := keyring.Key{ID: "k1", Secret: []byte("0123456789abcdef0123456789abcdef"), CreatedAt: createdAt, ActiveAt: createdAt}
+	require.NoError(t, store.Save(ctx, []keyring.Key{key}, version))
+
+	keys, version, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.Secret, keys[0].Secret)
+	assert.Equal(t, "1", version)
+
+	// another replica saved the keys meanwhile
+	err = store.Save(ctx, []keyring.Key{key}, "")
+	assert.IsType(t, errors.Conflict{}, err)
+	require.NoError(t, store.Save(ctx, []keyring.Key{key}, version))
+
+	t.Run("denied", func(t *testing.T) {
+		denied, err := NewKeyStore(Config{Address: server.URL, Token: "other-token", Path: "secret/lfx/signing-keys"})
+		require.NoError(t, err)
+		_, _, err = denied.Load(ctx)
+		assert.IsType(t, errors.Unexpected{}, err)
+	})
+}
+
+func TestNewKeyStore_InvalidConfig(t *testing.T) {
+	for _, config := range []Config{
+		{Address: "vault:8200", Token: "token", Path: "secret/keys"},
+		{Address: "https://vault:8200", Path: "secret/keys"},
+		{Address: "https://vault:8200", Token: "token", Path: "keys"},
+	} {
+		_, err := NewKeyStore(config)
+		assert.Error(t, err, config)
+	}
+}
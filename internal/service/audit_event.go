@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// publishAudit records the audit event of an operation in the audit sink and emits it, on top of
+// the audit log line, so the admin dashboards can follow the audit stream. The operation is already
+// done so a failure is only logged
+func (m *messageHandlerOrchestrator) publishAudit(ctx context.Context, event *model.AuditEvent) {
+	if event.At.IsZero() {
+		event.At = m.now()
+	}
+	if event.Outcome == "" {
+		event.Outcome = model.AuditOutcomeSuccess
+	}
+
+	if m.auditSink != nil {
+		if errRecord := m.auditSink.Record(ctx, event); errRecord != nil {
+			slog.ErrorContext(ctx, "failed to record audit event",
+				"error", errRecord,
+				"action", event.Action,
+				"target", redaction.Redact(event.Target),
+			)
+		}
+	}
+
+	if m.eventPublisher == nil {
+		return
+	}
+
+	data, errMarshal := json.Marshal(event)
+	if errMarshal != nil {
+		slog.ErrorContext(ctx, "failed to marshal audit event", "error", errMarshal)
+		return
+	}
+
+	if errPublish := m.eventPublisher.Publish(ctx, constants.AuditEventSubject, data); errPublish != nil {
+		slog.ErrorContext(ctx, "failed to publish audit event",
+			"error", errPublish,
+			"action", event.Action,
+			"target", redaction.Redact(event.Target),
+		)
+	}
+}
+
+// tokenSubject returns the sub of the token, empty when it can't be read
+func tokenSubject(ctx context.Context, token string) string {
+	subject, err := jwt.ExtractSubject(ctx, token)
+	if err != nil {
+		slog.DebugContext(ctx, "failed to extract the subject of the token", "error", err)
+		return ""
+	}
+	return subject
+}
+
+// auditChanges returns the metadata fields changed by the update with their redacted values,
+// the cleared fields with an empty value
+func auditChanges(user *model.User) map[string]string {
+	changes := make(map[string]string)
+	if user.UserMetadata != nil {
+		var fields map[string]any
+		data, errMarshal := json.Marshal(user.UserMetadata)
+		if errMarshal == nil && json.Unmarshal(data, &fields) == nil {
+			for field, value := range fields {
+				switch v := value.(type) {
+				case string:
+					changes[field] = redaction.Redact(v)
+				default:
+					changes[field] = fmt.Sprint(v)
+				}
+			}
+		}
+	}
+	for _, field := range user.ClearedMetadataFields() {
+		changes[field] = ""
+	}
+	return changes
+}
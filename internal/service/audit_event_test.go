@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// mockAuditSink records the audit events
+type mockAuditSink struct {
+	events []*model.AuditEvent
+}
+
+func (m *mockAuditSink) Record(_ context.Context, event *model.AuditEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestAuditChanges(t *testing.T) {
+	name, verified := "John Doe", true
+	user := &model.User{
+		UserMetadata: &model.UserMetadata{Name: &name, OrganizationVerified: &verified},
+		ClearFields:  []string{"phone_number", "organization"},
+	}
+
+	want := map[string]string{
+		"name":                  "Joh****",
+		"phone_number":          "",
+		"organization":          "",
+		"organization_verified": "",
+	}
+	if got := auditChanges(user); !reflect.DeepEqual(got, want) {
+		t.Errorf("auditChanges() = %v, want %v", got, want)
+	}
+}
+
+func TestMessageHandlerOrchestrator_UpdateUser_Audit(t *testing.T) {
+	ctx := context.Background()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "auth0|123"}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"token":         token,
+		"user_metadata": map[string]string{"job_title": "Cloud Architect"},
+	})
+
+	tests := []struct {
+		name        string
+		updateErr   error
+		wantOutcome model.AuditOutcome
+		wantError   string
+	}{
+		{name: "successful update", wantOutcome: model.AuditOutcomeSuccess},
+		{name: "failed update", updateErr: errs.NewUnexpected("failed to update user"), wantOutcome: model.AuditOutcomeFailure, wantError: "failed to update user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &mockAuditSink{}
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserWriterForMessageHandler(&mockUserServiceWriter{
+					updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+						return user, tt.updateErr
+					},
+				}),
+				WithAuditSinkForMessageHandler(sink),
+			)
+
+			if _, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: payload}); err != nil {
+				t.Fatalf("UpdateUser() unexpected error: %v", err)
+			}
+			if len(sink.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(sink.events))
+			}
+
+			event := sink.events[0]
+			if event.Action != model.AuditActionUserUpdate || event.Actor != "auth0|123" || event.Target != "auth0|123" {
+				t.Errorf("event = %+v, want the update of auth0|123", event)
+			}
+			if event.Outcome != tt.wantOutcome || event.Error != tt.wantError || event.At.IsZero() {
+				t.Errorf("event = %+v, want outcome %s", event, tt.wantOutcome)
+			}
+			if event.Changes["job_title"] != "Clo****" {
+				t.Errorf("changes = %v, want the redacted job title", event.Changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// authenticatorRequest represents the input for listing or deleting the user WebAuthn authenticators
+type authenticatorRequest struct {
+	User struct {
+		AuthToken string `json:"auth_token"`
+	} `json:"user"`
+	AuthenticatorID string `json:"authenticator_id,omitempty"`
+}
+
+// ListAuthenticators retrieves the WebAuthn authenticators (security keys and passkeys)
+// registered by the user
+func (m *messageHandlerOrchestrator) ListAuthenticators(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ListAuthenticators", msg)
+	defer span.End()
+
+	if m.authenticatorManager == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	var request authenticatorRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+
+	authToken := strings.TrimSpace(request.User.AuthToken)
+	if authToken == "" {
+		return m.errorResponse("auth_token is required"), nil
+	}
+
+	slog.DebugContext(ctx, "list authenticators",
+		"input", redaction.Redact(authToken),
+	)
+
+	user, err := m.userReader.MetadataLookup(ctx, authToken)
+	if err != nil {
+		slog.ErrorContext(ctx, "error looking up user for authenticator list",
+			"error", err,
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	authenticators, err := m.authenticatorManager.ListAuthenticators(ctx, user)
+	if err != nil {
+		slog.ErrorContext(ctx, "error listing authenticators",
+			"error", err,
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    m.applyResponsePolicy(ctx, authenticators),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// DeleteAuthenticator removes one of the user's WebAuthn authenticators, the token must carry
+// the same scope required to manage the linked identities
+func (m *messageHandlerOrchestrator) DeleteAuthenticator(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "DeleteAuthenticator", msg)
+	defer span.End()
+
+	if m.authenticatorManager == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	var request authenticatorRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+
+	authToken := strings.TrimSpace(request.User.AuthToken)
+	if authToken == "" {
+		return m.errorResponse("auth_token is required"), nil
+	}
+
+	authenticatorID := strings.TrimSpace(request.AuthenticatorID)
+	if authenticatorID == "" {
+		return m.errorResponse("authenticator_id is required"), nil
+	}
+
+	slog.DebugContext(ctx, "delete authenticator",
+		"input", redaction.Redact(authToken),
+		"authenticator_id", authenticatorID,
+	)
+
+	user, err := m.userReader.MetadataLookup(ctx, authToken, constants.UserUpdateIdentityRequiredScope)
+	if err != nil {
+		slog.ErrorContext(ctx, "error looking up user for authenticator delete",
+			"error", err,
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	if err := m.authenticatorManager.DeleteAuthenticator(ctx, user, authenticatorID); err != nil {
+		slog.ErrorContext(ctx, "error deleting authenticator",
+			"error", err,
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "authenticator deleted successfully",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,233 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockAuthenticatorManager struct {
+	authenticators []model.Authenticator
+	deletedID      string
+	deleteErr      error
+}
+
+func (m *mockAuthenticatorManager) ListAuthenticators(ctx context.Context, user *model.User) ([]model.Authenticator, error) {
+	return m.authenticators, nil
+}
+
+func (m *mockAuthenticatorManager) DeleteAuthenticator(ctx context.Context, user *model.User, authenticatorID string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deletedID = authenticatorID
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_ListAuthenticators(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		manager     *mockAuthenticatorManager
+		data        string
+		wantSuccess bool
+		wantError   string
+		wantCount   int
+	}{
+		{
+			name: "lists the authenticators",
+			manager: &mockAuthenticatorManager{authenticators: []model.Authenticator{
+				{ID: "webauthn-roaming|dev_1", Type: "webauthn-roaming"},
+				{ID: "passkey|dev_2", Type: "passkey", DeviceType: "multi_device", BackedUp: true},
+			}},
+			data:        `{"user":{"auth_token":"auth0|123"}}`,
+			wantSuccess: true,
+			wantCount:   2,
+		},
+		{
+			name:      "missing token",
+			manager:   &mockAuthenticatorManager{},
+			data:      `{"user":{}}`,
+			wantError: "auth_token is required",
+		},
+		{
+			name:      "invalid payload",
+			manager:   &mockAuthenticatorManager{},
+			data:      `not-json`,
+			wantError: "failed to unmarshal request",
+		},
+		{
+			name:      "provider without authenticators support",
+			data:      `{"user":{"auth_token":"auth0|123"}}`,
+			wantError: "auth service unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{userReader: &mockUserServiceReader{}}
+			if tt.manager != nil {
+				orchestrator.authenticatorManager = tt.manager
+			}
+
+			result, err := orchestrator.ListAuthenticators(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("ListAuthenticators() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool                  `json:"success"`
+				Error   string                `json:"error"`
+				Data    []model.Authenticator `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.wantSuccess || response.Error != tt.wantError || len(response.Data) != tt.wantCount {
+				t.Errorf("ListAuthenticators() = %s", result)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_DeleteAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		manager     *mockAuthenticatorManager
+		data        string
+		wantSuccess bool
+		wantError   string
+		wantDeleted string
+	}{
+		{
+			name:        "deletes the authenticator",
+			manager:     &mockAuthenticatorManager{},
+			data:        `{"user":{"auth_token":"auth0|123"},"authenticator_id":"passkey|dev_2"}`,
+			wantSuccess: true,
+			wantDeleted: "passkey|dev_2",
+		},
+		{
+			name:      "missing authenticator id",
+			manager:   &mockAuthenticatorManager{},
+			data:      `{"user":{"auth_token":"auth0|123"}}`,
+			wantError: "authenticator_id is required",
+		},
+		{
+			name:      "unknown authenticator",
+			manager:   &mockAuthenticatorManager{deleteErr: errs.NewNotFound("authenticator not found")},
+			data:      `{"user":{"auth_token":"auth0|123"},"authenticator_id":"sms|dev_3"}`,
+			wantError: "authenticator not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{
+				userReader:           &mockUserServiceReader{},
+				authenticatorManager: tt.manager,
+			}
+
+			result, err := orchestrator.DeleteAuthenticator(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("DeleteAuthenticator() unexpected error: %v", err)
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.wantSuccess || response.Error != tt.wantError {
+				t.Errorf("DeleteAuthenticator() = %s", result)
+			}
+			if tt.manager.deletedID != tt.wantDeleted {
+				t.Errorf("deleted = %q, want %q", tt.manager.deletedID, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_GetUserMetadata_LoginMethods(t *testing.T) {
+	ctx := context.Background()
+	name := "Jane Doe"
+
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(ctx context.Context, input string) (*model.User, error) {
+			return &model.User{UserID: input}, nil
+		},
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			return &model.User{
+				UserID:       user.UserID,
+				Identities:   []model.Identity{{Provider: "auth0"}, {Provider: "github"}},
+				UserMetadata: &model.UserMetadata{Name: &name},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name            string
+		manager         *mockAuthenticatorManager
+		wantMethods     []model.LoginMethod
+		wantRecommended model.LoginMethod
+	}{
+		{
+			name:            "identities only",
+			wantMethods:     []model.LoginMethod{model.LoginMethodGitHub, model.LoginMethodPassword},
+			wantRecommended: model.LoginMethodGitHub,
+		},
+		{
+			name:            "registered passkey",
+			manager:         &mockAuthenticatorManager{authenticators: []model.Authenticator{{ID: "passkey|dev_1", Type: "passkey"}}},
+			wantMethods:     []model.LoginMethod{model.LoginMethodPasskey, model.LoginMethodGitHub, model.LoginMethodPassword},
+			wantRecommended: model.LoginMethodPasskey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{userReader: reader}
+			if tt.manager != nil {
+				orchestrator.authenticatorManager = tt.manager
+			}
+
+			result, err := orchestrator.GetUserMetadata(ctx, &mockTransportMessenger{data: []byte("auth0|123")})
+			if err != nil {
+				t.Fatalf("GetUserMetadata() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool `json:"success"`
+				Data    struct {
+					Name                   string              `json:"name"`
+					LoginMethods           []model.LoginMethod `json:"login_methods"`
+					RecommendedLoginMethod model.LoginMethod   `json:"recommended_login_method"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if !response.Success || response.Data.Name != name {
+				t.Fatalf("GetUserMetadata() = %s", result)
+			}
+			if len(response.Data.LoginMethods) != len(tt.wantMethods) {
+				t.Fatalf("login_methods = %v, want %v", response.Data.LoginMethods, tt.wantMethods)
+			}
+			for i := range tt.wantMethods {
+				if response.Data.LoginMethods[i] != tt.wantMethods[i] {
+					t.Errorf("login_methods = %v, want %v", response.Data.LoginMethods, tt.wantMethods)
+				}
+			}
+			if response.Data.RecommendedLoginMethod != tt.wantRecommended {
+				t.Errorf("recommended_login_method = %q, want %q", response.Data.RecommendedLoginMethod, tt.wantRecommended)
+			}
+		})
+	}
+}
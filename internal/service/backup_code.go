@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// verifyEmailBackupCode verifies the email with a backup code issued by an admin instead of the OTP.
+// Auth0 can't issue an ID token without its OTP, the response holds a reference of the verified email
+// the identity linking accepts in place of the ID token reference, bound to the user when there is one.
+func (m *messageHandlerOrchestrator) verifyEmailBackupCode(ctx context.Context, email *model.Email, userID string, audit *model.AuditEvent) []byte {
+	if m.tokenRefSealer == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("token references are disabled"))
+	}
+
+	errRedeem := m.backupCodeStore.RedeemBackupCode(ctx, email.Email, email.OTP)
+	m.recordVerificationAttempt(ctx, model.AuditActionEmailLinkingLockout, email.Email, audit.Target, errRedeem)
+	if errRedeem != nil {
+		audit.Fail(errRedeem)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errRedeem)
+	}
+	audit.Details = map[string]any{"channel": "backup_code"}
+	m.publishAudit(ctx, audit)
+
+	ref, expiresAt, errSeal := m.tokenRefSealer.Seal(boundRefKind(verifiedEmailRefKind, userID), strings.ToLower(strings.TrimSpace(email.Email)))
+	if errSeal != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to seal verified email", errSeal))
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data: m.applyResponsePolicy(ctx, &model.AuthResponse{
+			IDTokenRef:          ref,
+			IDTokenRefExpiresAt: &expiresAt,
+		}),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
+	}
+	return responseJSON
+}
+
+// linkVerifiedEmail links the email verified with a backup code to the user of the link request
+func (m *messageHandlerOrchestrator) linkVerifiedEmail(ctx context.Context, linkRequest *model.LinkIdentity, email string) []byte {
+	if m.verifiedEmailLinker == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable"))
+	}
+
+	// the email might have been linked to another user since it was verified
+	errExists := m.checkEmailExists(ctx, email)
+	if errExists != nil {
+		return m.errorResponseFromError(ctx, errExists)
+	}
+
+	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, linkRequest.User.AuthToken)
+	if errMetadataLookup != nil {
+		return m.errorResponseFromError(ctx, errMetadataLookup)
+	}
+
+	errLink := m.verifiedEmailLinker.LinkVerifiedEmail(ctx, &model.User{UserID: user.UserID, Token: linkRequest.User.AuthToken}, email)
+	if errLink != nil {
+		return m.errorResponseFromError(ctx, errLink)
+	}
+
+	// a new verified email might match the organization domains
+	m.refreshOrganizationVerified(ctx, linkRequest.User.AuthToken)
+
+	m.publishProfileChanged(ctx, model.ProfileChangeIdentityLink, user)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "identity linked successfully",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
+	}
+	return responseJSON
+}
@@ -0,0 +1,146 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokenref"
+)
+
+type mockEmailBackupCodeStore struct {
+	redeemed map[string]string
+}
+
+func (m *mockEmailBackupCodeStore) IssueBackupCodes(ctx context.Context, email string) (*model.EmailBackupCodes, error) {
+	return nil, errs.NewUnexpected("not implemented")
+}
+
+func (m *mockEmailBackupCodeStore) RedeemBackupCode(ctx context.Context, email, code string) error {
+	if model.NormalizeBackupCode(code) != "ABCDE-FGHJK" {
+		return errs.NewValidation("invalid backup code")
+	}
+	m.redeemed[email] = code
+	return nil
+}
+
+type mockEmailHandler struct {
+	verified  bool
+	sent      []string
+	verifyErr error
+}
+
+func (m *mockEmailHandler) SendVerificationAlternateEmail(ctx context.Context, alternateEmail string) error {
+	m.sent = append(m.sent, alternateEmail)
+	return nil
+}
+
+func (m *mockEmailHandler) VerifyAlternateEmail(ctx context.Context, email *model.Email) (*model.AuthResponse, error) {
+	if m.verifyErr != nil {
+		return nil, m.verifyErr
+	}
+	m.verified = true
+	return &model.AuthResponse{IDToken: "id-token"}, nil
+}
+
+type mockVerifiedEmailLinker struct {
+	user  *model.User
+	email string
+}
+
+func (m *mockVerifiedEmailLinker) LinkVerifiedEmail(ctx context.Context, user *model.User, email string) error {
+	m.user, m.email = user, email
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_EmailBackupCodes(t *testing.T) {
+	ctx := context.Background()
+	sealer, err := tokenref.NewSealer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	store := &mockEmailBackupCodeStore{redeemed: make(map[string]string)}
+	emailHandler := &mockEmailHandler{}
+	linker := &mockVerifiedEmailLinker{}
+	identityLinker := &mockIdentityLinker{
+		validateLinkRequestFunc: func(_ context.Context, _ *model.LinkIdentity) error {
+			t.Error("the link request of a verified email has no identity token to validate")
+			return nil
+		},
+	}
+	reader := &mockUserServiceReader{
+		searchUserFunc: func(_ context.Context, _ *model.User, _ constants.CriteriaType) (*model.User, error) {
+			return nil, errs.NewNotFound("user not found")
+		},
+		metadataLookupFunc: func(_ context.Context, _ string) (*model.User, error) {
+			return &model.User{UserID: "auth0|user123"}, nil
+		},
+	}
+
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithEmailHandlerForMessageHandler(emailHandler),
+		WithIdentityLinkerForMessageHandler(identityLinker),
+		WithUserReaderForMessageHandler(reader),
+		WithTokenReferenceSealerForMessageHandler(sealer),
+		WithEmailBackupCodeStoreForMessageHandler(store),
+		WithVerifiedEmailLinkerForMessageHandler(linker),
+	)
+
+	// an invalid backup code is rejected without reaching the provider
+	result, err := orchestrator.VerifyEmailLinking(ctx, &mockTransportMessenger{data: []byte(`{"email":"jane@personal.example","otp":"ZZZZZ-ZZZZZ"}`)})
+	require.NoError(t, err)
+	var failed UserDataResponse
+	require.NoError(t, json.Unmarshal(result, &failed))
+	assert.False(t, failed.Success)
+	assert.Equal(t, "invalid backup code", failed.Error)
+	assert.False(t, emailHandler.verified)
+
+	// a valid backup code returns the reference of the verified email
+	result, err = orchestrator.VerifyEmailLinking(ctx, &mockTransportMessenger{data: []byte(`{"email":"Jane@Personal.example","otp":"abcde-fghjk"}`)})
+	require.NoError(t, err)
+	var verified struct {
+		Success bool               `json:"success"`
+		Error   string             `json:"error"`
+		Data    model.AuthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(result, &verified))
+	require.True(t, verified.Success, verified.Error)
+	assert.NotEmpty(t, verified.Data.IDTokenRef)
+	assert.Empty(t, verified.Data.IDToken)
+	assert.False(t, emailHandler.verified)
+
+	// the reference links the email
+	data := []byte(`{"user":{"auth_token":"some-auth-token"},"link_with":{"identity_token_ref":"` + verified.Data.IDTokenRef + `"}}`)
+	result, err = orchestrator.LinkIdentity(ctx, &mockTransportMessenger{data: data})
+	require.NoError(t, err)
+	var linked UserDataResponse
+	require.NoError(t, json.Unmarshal(result, &linked))
+	assert.True(t, linked.Success, linked.Error)
+	assert.Equal(t, "jane@personal.example", linker.email)
+	require.NotNil(t, linker.user)
+	assert.Equal(t, "auth0|user123", linker.user.UserID)
+
+	// with the user token, the reference is bound to the user and can change its primary email
+	result, err = orchestrator.VerifyEmailLinking(ctx, &mockTransportMessenger{data: []byte(`{"user":{"auth_token":"some-auth-token"},"email":"jane@personal.example","otp":"abcde-fghjk"}`)})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(result, &verified))
+	require.True(t, verified.Success, verified.Error)
+	email, err := orchestrator.(*messageHandlerOrchestrator).verifiedEmailFromRef(ctx, verified.Data.IDTokenRef, "auth0|user123")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@personal.example", email)
+	_, err = orchestrator.(*messageHandlerOrchestrator).verifiedEmailFromRef(ctx, verified.Data.IDTokenRef, "auth0|other")
+	assert.Error(t, err)
+
+	// the OTPs still go to the provider
+	_, err = orchestrator.VerifyEmailLinking(ctx, &mockTransportMessenger{data: []byte(`{"email":"jane@personal.example","otp":"123456"}`)})
+	require.NoError(t, err)
+	assert.True(t, emailHandler.verified)
+}
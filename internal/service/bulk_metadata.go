@@ -0,0 +1,135 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/concurrent"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// bulkMaxIdentifiers is the maximum number of identifiers of a bulk request
+	bulkMaxIdentifiers = 100
+	// bulkWorkers bounds the concurrent lookups of a bulk request, to spare the provider API limits
+	bulkWorkers = 8
+)
+
+// parseBulkIdentifiers returns the identifiers of a bulk request, a JSON array, without the empty
+// and the duplicated ones. The error is the message of the error response.
+func parseBulkIdentifiers(data []byte) ([]string, string) {
+	var identifiers []string
+	if err := json.Unmarshal(data, &identifiers); err != nil {
+		return nil, "failed to unmarshal request, expected a JSON array of identifiers"
+	}
+
+	unique := make([]string, 0, len(identifiers))
+	seen := make(map[string]struct{}, len(identifiers))
+	for _, identifier := range identifiers {
+		identifier = strings.TrimSpace(identifier)
+		if identifier == "" {
+			continue
+		}
+		if _, ok := seen[identifier]; ok {
+			continue
+		}
+		seen[identifier] = struct{}{}
+		unique = append(unique, identifier)
+	}
+
+	if len(unique) == 0 {
+		return nil, "at least one identifier is required"
+	}
+	if len(unique) > bulkMaxIdentifiers {
+		return nil, fmt.Sprintf("at most %d identifiers are allowed", bulkMaxIdentifiers)
+	}
+	return unique, ""
+}
+
+// runBulk calls lookup for each identifier with a bounded worker pool, lookup records its own
+// result so a failed lookup doesn't stop the others
+func runBulk(ctx context.Context, identifiers []string, lookup func(i int, identifier string)) error {
+	functions := make([]func() error, 0, len(identifiers))
+	for i, identifier := range identifiers {
+		functions = append(functions, func() error {
+			lookup(i, identifier)
+			return nil
+		})
+	}
+	return concurrent.NewWorkerPool(bulkWorkers).Run(ctx, functions...)
+}
+
+// BulkGetUserMetadata retrieves the metadata of several users in a single message, the request is a JSON
+// array of usernames, subs or tokens. The reply maps each identifier to its own response, a failed lookup
+// doesn't fail the others.
+func (m *messageHandlerOrchestrator) BulkGetUserMetadata(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "BulkGetUserMetadata", msg)
+	defer span.End()
+
+	if m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	identifiers, errMessage := parseBulkIdentifiers(msg.Data())
+	if errMessage != "" {
+		return m.errorResponse(errMessage), nil
+	}
+
+	oidc := strings.ToLower(strings.TrimSpace(msg.Header(constants.ResponseFormatHeader))) == constants.ResponseFormatOIDC
+
+	var mu sync.Mutex
+	results := make(map[string]UserDataResponse, len(identifiers))
+
+	errRun := runBulk(ctx, identifiers, func(_ int, identifier string) {
+		result := m.bulkUserMetadataResult(ctx, identifier, oidc)
+		mu.Lock()
+		results[identifier] = result
+		mu.Unlock()
+	})
+	if errRun != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("bulk user metadata lookup interrupted", errRun)), nil
+	}
+
+	slog.DebugContext(ctx, "bulk user metadata lookup", "identifiers", len(identifiers))
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    results,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// bulkUserMetadataResult looks up one identifier of a bulk lookup, shaped like a single lookup reply
+func (m *messageHandlerOrchestrator) bulkUserMetadataResult(ctx context.Context, identifier string, oidc bool) UserDataResponse {
+	user, err := m.lookupUser(ctx, identifier)
+	if err != nil {
+		return m.errorDataResponse(ctx, err)
+	}
+
+	var data any
+	if oidc {
+		data = user.OIDCClaims()
+	} else {
+		data = m.newUserMetadataResponse(ctx, user)
+	}
+
+	return UserDataResponse{
+		Success: true,
+		Data:    m.applyResponsePolicy(ctx, data),
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestMessageHandlerOrchestrator_BulkGetUserMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			switch user.UserID {
+			case "auth0|missing":
+				return nil, errs.NewNotFound("user not found")
+			case "auth0|down":
+				return nil, errs.NewServiceUnavailable("auth0 unavailable")
+			}
+			user.UserMetadata = &model.UserMetadata{Name: converters.StringPtr("User " + user.UserID)}
+			return user, nil
+		},
+	}
+
+	tooMany := make([]string, 0, bulkMaxIdentifiers+1)
+	for i := 0; i <= bulkMaxIdentifiers; i++ {
+		tooMany = append(tooMany, fmt.Sprintf(`"auth0|%d"`, i))
+	}
+
+	type result struct {
+		Success   bool   `json:"success"`
+		Error     string `json:"error"`
+		Retryable bool   `json:"retryable"`
+	}
+
+	tests := []struct {
+		name        string
+		noReader    bool
+		data        string
+		wantSuccess bool
+		wantError   string
+		wantResults map[string]result
+	}{
+		{
+			name:        "each identifier has its own result",
+			data:        `["auth0|123", " auth0|123 ", "", "auth0|missing", "auth0|down"]`,
+			wantSuccess: true,
+			wantResults: map[string]result{
+				"auth0|123":     {Success: true},
+				"auth0|missing": {Error: "user not found"},
+				"auth0|down":    {Error: "auth0 unavailable", Retryable: true},
+			},
+		},
+		{
+			name:      "not an array",
+			data:      `{"user":"auth0|123"}`,
+			wantError: "failed to unmarshal request, expected a JSON array of identifiers",
+		},
+		{
+			name:      "no identifier",
+			data:      `[" "]`,
+			wantError: "at least one identifier is required",
+		},
+		{
+			name:      "too many identifiers",
+			data:      "[" + strings.Join(tooMany, ",") + "]",
+			wantError: fmt.Sprintf("at most %d identifiers are allowed", bulkMaxIdentifiers),
+		},
+		{
+			name:      "no provider",
+			noReader:  true,
+			data:      `["auth0|123"]`,
+			wantError: "auth service unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{userReader: reader}
+			if tt.noReader {
+				orchestrator.userReader = nil
+			}
+
+			response, err := orchestrator.BulkGetUserMetadata(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("BulkGetUserMetadata() unexpected error: %v", err)
+			}
+
+			var got struct {
+				Success bool              `json:"success"`
+				Error   string            `json:"error"`
+				Data    map[string]result `json:"data"`
+			}
+			if err := json.Unmarshal(response, &got); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if got.Success != tt.wantSuccess || got.Error != tt.wantError || len(got.Data) != len(tt.wantResults) {
+				t.Fatalf("BulkGetUserMetadata() = %s", response)
+			}
+			for identifier, want := range tt.wantResults {
+				if got.Data[identifier] != want {
+					t.Errorf("BulkGetUserMetadata()[%q] = %+v, want %+v", identifier, got.Data[identifier], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_BulkGetUserMetadata_Data(t *testing.T) {
+	orchestrator := &messageHandlerOrchestrator{userReader: &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			user.UserMetadata = &model.UserMetadata{Name: converters.StringPtr("Jane Doe")}
+			return user, nil
+		},
+	}}
+
+	response, err := orchestrator.BulkGetUserMetadata(context.Background(), &mockTransportMessenger{data: []byte(`["auth0|123"]`)})
+	if err != nil {
+		t.Fatalf("BulkGetUserMetadata() unexpected error: %v", err)
+	}
+
+	var got struct {
+		Data map[string]struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(response, &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if name := got.Data["auth0|123"].Data["name"]; name != "Jane Doe" {
+		t.Errorf("BulkGetUserMetadata() = %s, want the metadata of the user", response)
+	}
+}
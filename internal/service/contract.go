@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// contractReportRequest represents the input for the contract report, all the contracts by default
+type contractReportRequest struct {
+	Caller       string `json:"caller,omitempty"`
+	BreachedOnly bool   `json:"breached_only,omitempty"`
+}
+
+// ContractReport reports the service levels measured for each consumer contract against its
+// budgets, so the complaints about a slow or failing service can be checked against data
+func (m *messageHandlerOrchestrator) ContractReport(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ContractReport", msg)
+	defer span.End()
+
+	if m.contractReportReader == nil {
+		return m.errorResponse("no consumer contract is registered"), nil
+	}
+
+	var request contractReportRequest
+	if len(strings.TrimSpace(string(msg.Data()))) > 0 {
+		if err := json.Unmarshal(msg.Data(), &request); err != nil {
+			return m.errorResponse("failed to unmarshal request"), nil
+		}
+	}
+	caller := strings.ToLower(strings.TrimSpace(request.Caller))
+
+	report := make([]model.ContractSLI, 0)
+	for _, sli := range m.contractReportReader.ContractReport(ctx) {
+		if caller != "" && sli.Caller != caller {
+			continue
+		}
+		if request.BreachedOnly && !sli.Breached() {
+			continue
+		}
+		report = append(report, sli)
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    report,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+type mockContractReportReader struct {
+	report []model.ContractSLI
+}
+
+func (m *mockContractReportReader) ContractReport(ctx context.Context) []model.ContractSLI {
+	return m.report
+}
+
+func TestMessageHandlerOrchestrator_ContractReport(t *testing.T) {
+	reader := &mockContractReportReader{report: []model.ContractSLI{
+		{Caller: "project-service", Operation: "lfx.auth-service.email_to_username", Samples: 20, P95Ms: 360, LatencyBudgetMs: 250, Breaches: []string{model.ContractBreachLatency}},
+		{Caller: "project-service", Operation: "lfx.auth-service.sub_to_email", LatencyBudgetMs: 1000},
+		{Caller: "reporting-service", Operation: "lfx.auth-service.usage.read", Samples: 1, ErrorBudget: 0.5},
+	}}
+
+	tests := []struct {
+		name           string
+		data           string
+		wantOperations []string
+	}{
+		{
+			name:           "all the contracts",
+			wantOperations: []string{"lfx.auth-service.email_to_username", "lfx.auth-service.sub_to_email", "lfx.auth-service.usage.read"},
+		},
+		{
+			name:           "contracts of a caller",
+			data:           `{"caller":"Project-Service"}`,
+			wantOperations: []string{"lfx.auth-service.email_to_username", "lfx.auth-service.sub_to_email"},
+		},
+		{
+			name:           "breached contracts",
+			data:           `{"breached_only":true}`,
+			wantOperations: []string{"lfx.auth-service.email_to_username"},
+		},
+		{
+			name:           "no match",
+			data:           `{"caller":"search-service"}`,
+			wantOperations: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{contractReportReader: reader}
+
+			responseJSON, err := orchestrator.ContractReport(context.Background(), &mockTransportMessenger{data: []byte(tt.data)})
+			require.NoError(t, err)
+
+			var response struct {
+				Success bool                `json:"success"`
+				Data    []model.ContractSLI `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(responseJSON, &response))
+			require.True(t, response.Success, string(responseJSON))
+
+			operations := make([]string, 0, len(response.Data))
+			for _, sli := range response.Data {
+				operations = append(operations, sli.Operation)
+			}
+			assert.Equal(t, tt.wantOperations, operations)
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_ContractReport_NoContracts(t *testing.T) {
+	orchestrator := &messageHandlerOrchestrator{}
+
+	responseJSON, err := orchestrator.ContractReport(context.Background(), &mockTransportMessenger{})
+	require.NoError(t, err)
+
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(responseJSON, &response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "no consumer contract is registered", response.Error)
+}
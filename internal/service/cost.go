@@ -0,0 +1,34 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// callerContextKey is the context key of the calling service
+type callerContextKey struct{}
+
+// ContextWithCaller returns a copy of the context carrying the calling service,
+// the operations are charged to it, see constants.CallerTokenHeader
+func ContextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// callerFromContext returns the calling service, empty when unknown
+func callerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerContextKey{}).(string)
+	return caller
+}
+
+// charge accounts an identity provider operation of the class to the caller before
+// performing it, it fails when the caller exhausted its daily budget
+func (m *messageHandlerOrchestrator) charge(ctx context.Context, class model.CostClass) error {
+	if m.costGuard == nil {
+		return nil
+	}
+	return m.costGuard.Charge(ctx, callerFromContext(ctx), class)
+}
@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// mockCostGuard is a mock implementation of port.CostGuard with a single budget for all the callers
+type mockCostGuard struct {
+	budget  int
+	charges map[string][]model.CostClass
+}
+
+func (m *mockCostGuard) Charge(ctx context.Context, caller string, class model.CostClass) error {
+	if m.charges == nil {
+		m.charges = make(map[string][]model.CostClass)
+	}
+	if len(m.charges[caller]) >= m.budget {
+		return errs.NewTooManyRequests("daily budget of expensive operations exhausted").WithRetryAfter(time.Hour)
+	}
+	m.charges[caller] = append(m.charges[caller], class)
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_CostGuard(t *testing.T) {
+	ctx := ContextWithCaller(context.Background(), "project-service")
+
+	searches := 0
+	guard := &mockCostGuard{budget: 2}
+	orchestrator := &messageHandlerOrchestrator{
+		userReader: &mockUserServiceReader{
+			searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+				searches++
+				return &model.User{UserID: "auth0|123", Username: "jdoe"}, nil
+			},
+		},
+		costGuard: guard,
+	}
+
+	// the lookups by id are cheap, they are not charged
+	if _, err := orchestrator.GetUserMetadata(ctx, &mockTransportMessenger{data: []byte("auth0|123")}); err != nil {
+		t.Fatalf("GetUserMetadata() unexpected error: %v", err)
+	}
+	if len(guard.charges["project-service"]) != 0 {
+		t.Fatalf("charges = %v, want none for the lookup by id", guard.charges)
+	}
+
+	// the searches by username and email are charged to the caller
+	if _, err := orchestrator.GetUserMetadata(ctx, &mockTransportMessenger{data: []byte("jdoe")}); err != nil {
+		t.Fatalf("GetUserMetadata() unexpected error: %v", err)
+	}
+	if result, _ := orchestrator.EmailToSub(ctx, &mockTransportMessenger{data: []byte("jdoe@example.com")}); string(result) != "auth0|123" {
+		t.Fatalf("EmailToSub() = %s", result)
+	}
+
+	// the budget is exhausted, the search isn't performed
+	result, err := orchestrator.EmailToUsername(ctx, &mockTransportMessenger{data: []byte("jdoe@example.com")})
+	if err != nil {
+		t.Fatalf("EmailToUsername() unexpected error: %v", err)
+	}
+	var response UserDataResponse
+	if err := json.Unmarshal(result, &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.Success || !response.Retryable || response.RetryAfterMs != time.Hour.Milliseconds() {
+		t.Errorf("EmailToUsername() = %s, want a retryable quota error", result)
+	}
+	if searches != 2 {
+		t.Errorf("searches = %d, want 2", searches)
+	}
+
+	// other callers have their own budget
+	otherCtx := ContextWithCaller(context.Background(), "search-service")
+	if result, _ := orchestrator.EmailToUsername(otherCtx, &mockTransportMessenger{data: []byte("jdoe@example.com")}); string(result) != "jdoe" {
+		t.Errorf("EmailToUsername() for another caller = %s", result)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockOrganizationAdminWriter struct {
+	admin   *model.OrganizationAdmin
+	updated *model.User
+}
+
+func (m *mockOrganizationAdminWriter) OrganizationAdminLookup(ctx context.Context, token string) (*model.OrganizationAdmin, error) {
+	if m.admin == nil {
+		return nil, errors.NewForbidden("user is not an organization admin")
+	}
+	return m.admin, nil
+}
+
+func (m *mockOrganizationAdminWriter) UpdateUserAsOrganizationAdmin(ctx context.Context, user *model.User) (*model.User, error) {
+	m.updated = user
+	return user, nil
+}
+
+func TestMessageHandlerOrchestrator_UpdateUserAsOrganizationAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	member := &model.User{
+		UserID:       "auth0|jane",
+		Username:     "jane",
+		PrimaryEmail: "jane@cncf.io",
+		UserMetadata: &model.UserMetadata{Organization: converters.StringPtr("CNCF")},
+	}
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			return member, nil
+		},
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			return member, nil
+		},
+	}
+	cncfAdmin := &model.OrganizationAdmin{UserID: "auth0|admin", Organizations: []string{"cncf", "openssf"}}
+
+	tests := []struct {
+		name         string
+		admin        *model.OrganizationAdmin
+		request      model.DelegatedUserUpdate
+		wantSuccess  bool
+		wantError    string
+		wantCode     string
+		wantVerified *bool
+	}{
+		{
+			name:  "admin updates the job title of a member",
+			admin: cncfAdmin,
+			request: model.DelegatedUserUpdate{
+				Token:        "admin-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Maintainer")},
+			},
+			wantSuccess: true,
+		},
+		{
+			name:  "admin moves a member to another managed organization",
+			admin: cncfAdmin,
+			request: model.DelegatedUserUpdate{
+				Token:        "admin-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{Organization: converters.StringPtr("OpenSSF")},
+			},
+			wantSuccess:  true,
+			wantVerified: converters.BoolPtr(false),
+		},
+		{
+			name:  "non delegated field is rejected",
+			admin: cncfAdmin,
+			request: model.DelegatedUserUpdate{
+				Token:        "admin-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{PhoneNumber: converters.StringPtr("+1")},
+			},
+			wantError: "field phone_number can't be updated by organization admins",
+		},
+		{
+			name:  "member of an organization not managed by the admin",
+			admin: &model.OrganizationAdmin{UserID: "auth0|admin", Organizations: []string{"openssf"}},
+			request: model.DelegatedUserUpdate{
+				Token:        "admin-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Maintainer")},
+			},
+			wantError: "user is not a member of an organization you manage",
+			wantCode:  errors.CodeForbidden,
+		},
+		{
+			name:  "move to an organization not managed by the admin",
+			admin: cncfAdmin,
+			request: model.DelegatedUserUpdate{
+				Token:        "admin-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{Organization: converters.StringPtr("Other")},
+			},
+			wantError: "organization is not managed by the admin",
+			wantCode:  errors.CodeForbidden,
+		},
+		{
+			name: "caller is not an admin",
+			request: model.DelegatedUserUpdate{
+				Token:        "user-token",
+				User:         "jane",
+				UserMetadata: &model.UserMetadata{JobTitle: converters.StringPtr("Maintainer")},
+			},
+			wantError: "user is not an organization admin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &mockOrganizationAdminWriter{admin: tt.admin}
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserReaderForMessageHandler(reader),
+				WithOrganizationAdminWriterForMessageHandler(writer),
+				WithOrganizationDomainsForMessageHandler(model.OrganizationDomains{"cncf": {"cncf.io"}}),
+			)
+
+			data, _ := json.Marshal(tt.request)
+			result, err := orchestrator.UpdateUserAsOrganizationAdmin(ctx, &mockTransportMessenger{data: data})
+			if err != nil {
+				t.Fatalf("UpdateUserAsOrganizationAdmin() unexpected error: %v", err)
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.wantSuccess || response.Error != tt.wantError {
+				t.Fatalf("response = %+v, want success=%v error=%q", response, tt.wantSuccess, tt.wantError)
+			}
+			if tt.wantCode != "" && response.ErrorCode != tt.wantCode {
+				t.Errorf("error_code = %q, want %q", response.ErrorCode, tt.wantCode)
+			}
+
+			if !tt.wantSuccess {
+				if writer.updated != nil {
+					t.Error("writer should not be called")
+				}
+				return
+			}
+			if writer.updated.UserID != member.UserID {
+				t.Errorf("updated user_id = %q, want %q", writer.updated.UserID, member.UserID)
+			}
+			got := writer.updated.UserMetadata.OrganizationVerified
+			if (got == nil) != (tt.wantVerified == nil) || (got != nil && *got != *tt.wantVerified) {
+				t.Errorf("OrganizationVerified = %v, want %v", got, tt.wantVerified)
+			}
+		})
+	}
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		orchestrator := NewMessageHandlerOrchestrator(WithUserReaderForMessageHandler(reader))
+		result, _ := orchestrator.UpdateUserAsOrganizationAdmin(ctx, &mockTransportMessenger{data: []byte(`{}`)})
+		var response UserDataResponse
+		_ = json.Unmarshal(result, &response)
+		if response.Error != "auth service unavailable" {
+			t.Errorf("error = %q, want auth service unavailable", response.Error)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// emailHashLength is the length of the hex encoded SHA-256 of an email index key
+const emailHashLength = 64
+
+// parseEmailHashes returns the lowercase email hashes of a membership request, a JSON array of
+// hex encoded SHA-256. The error is the message of the error response.
+func parseEmailHashes(data []byte) ([]string, string) {
+	hashes, errMessage := parseBulkIdentifiers(data)
+	if errMessage != "" {
+		return nil, errMessage
+	}
+
+	for i, hash := range hashes {
+		hash = strings.ToLower(hash)
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != emailHashLength {
+			return nil, "invalid hash, expected the hex encoded SHA-256 of the email"
+		}
+		hashes[i] = hash
+	}
+	return hashes, ""
+}
+
+// EmailHashMembership reports which of the email hashes belong to a user, as primary or alternate email,
+// for the privacy-sensitive callers checking a list of emails they must not disclose. The request is a JSON
+// array of the hex encoded SHA-256 of the canonical emails, the same hashes as the keys of the email index,
+// so the plaintext emails are never received.
+func (m *messageHandlerOrchestrator) EmailHashMembership(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "EmailHashMembership", msg)
+	defer span.End()
+
+	if m.emailHashMatcher == nil {
+		return m.errorResponse("email hash membership is not supported by the identity provider"), nil
+	}
+
+	hashes, errMessage := parseEmailHashes(msg.Data())
+	if errMessage != "" {
+		return m.errorResponse(errMessage), nil
+	}
+
+	members, err := m.emailHashMatcher.MatchEmailHashes(ctx, hashes)
+	if err != nil {
+		slog.ErrorContext(ctx, "error matching the email hashes",
+			"error", err,
+			"hashes", len(hashes),
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    members,
+	}
+
+	responseJSON, errMarshal := json.Marshal(response)
+	if errMarshal != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
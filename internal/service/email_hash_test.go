@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockEmailHashMatcher struct {
+	members map[string]bool
+	err     error
+}
+
+func (m *mockEmailHashMatcher) MatchEmailHashes(ctx context.Context, hashes []string) (map[string]bool, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	members := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		members[hash] = m.members[hash]
+	}
+	return members, nil
+}
+
+func TestMessageHandlerOrchestrator_EmailHashMembership(t *testing.T) {
+	member := strings.Repeat("a", emailHashLength)
+	stranger := strings.Repeat("b", emailHashLength)
+	matcher := &mockEmailHashMatcher{members: map[string]bool{member: true}}
+
+	tests := []struct {
+		name        string
+		matcher     *mockEmailHashMatcher
+		data        string
+		wantError   string
+		wantMembers map[string]bool
+	}{
+		{
+			name:        "members and strangers",
+			matcher:     matcher,
+			data:        `["` + strings.ToUpper(member) + `", "` + stranger + `", "` + member + `"]`,
+			wantMembers: map[string]bool{member: true, stranger: false},
+		},
+		{name: "not a hash", matcher: matcher, data: `["jane@example.com"]`, wantError: "invalid hash, expected the hex encoded SHA-256 of the email"},
+		{name: "short hash", matcher: matcher, data: `["abcd"]`, wantError: "invalid hash, expected the hex encoded SHA-256 of the email"},
+		{name: "not an array", matcher: matcher, data: `"` + member + `"`, wantError: "failed to unmarshal request, expected a JSON array of identifiers"},
+		{name: "empty request", matcher: matcher, data: `[]`, wantError: "at least one identifier is required"},
+		{name: "index unavailable", matcher: &mockEmailHashMatcher{err: errs.NewServiceUnavailable("nats unavailable")}, data: `["` + member + `"]`, wantError: "nats unavailable"},
+		{name: "not supported", data: `["` + member + `"]`, wantError: "email hash membership is not supported by the identity provider"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{}
+			if tt.matcher != nil {
+				orchestrator.emailHashMatcher = tt.matcher
+			}
+
+			response, err := orchestrator.EmailHashMembership(context.Background(), &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("EmailHashMembership() unexpected error: %v", err)
+			}
+
+			var got struct {
+				Success bool            `json:"success"`
+				Error   string          `json:"error"`
+				Data    map[string]bool `json:"data"`
+			}
+			if err := json.Unmarshal(response, &got); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if got.Error != tt.wantError || got.Success != (tt.wantError == "") {
+				t.Fatalf("EmailHashMembership() = %s, want error %q", response, tt.wantError)
+			}
+			if tt.wantMembers != nil && !reflect.DeepEqual(got.Data, tt.wantMembers) {
+				t.Errorf("EmailHashMembership() members = %v, want %v", got.Data, tt.wantMembers)
+			}
+		})
+	}
+}
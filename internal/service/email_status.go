@@ -0,0 +1,132 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// emailVerifiedResponse is the verification status of an email, the owner is only
+// returned to the callers allowed to see it
+type emailVerifiedResponse struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+	Sub      string `json:"sub,omitempty"`
+}
+
+// emailOwnerAllowed reports whether the caller may see the owner of a verified email, granted in the
+// environment or by the caller allowlist. The allowlist failures deny, the reply only misses the owner.
+func (m *messageHandlerOrchestrator) emailOwnerAllowed(ctx context.Context) bool {
+	return m.callerGranted(ctx, m.emailOwnerCallers, model.CallerCapabilityEmailOwner)
+}
+
+// callerGranted reports whether the caller is granted the capability, in the environment (callers)
+// or by the caller allowlist. The allowlist failures deny.
+func (m *messageHandlerOrchestrator) callerGranted(ctx context.Context, callers map[string]struct{}, capability model.CallerCapability) bool {
+	caller := callerFromContext(ctx)
+	if _, allowed := callers[caller]; allowed {
+		return true
+	}
+	if m.callerAllowlist == nil || caller == "" {
+		return false
+	}
+
+	entry, err := m.callerAllowlist.GetCaller(ctx, caller)
+	if err != nil {
+		var (
+			notFound   errs.NotFound
+			validation errs.Validation
+		)
+		if !errors.As(err, &notFound) && !errors.As(err, &validation) {
+			slog.WarnContext(ctx, "failed to read the caller allowlist", "error", err, "caller", caller)
+		}
+		return false
+	}
+	return entry.Has(capability)
+}
+
+// verifiedOwner returns the user with the email as primary email or verified alternate email,
+// nil when there is none
+func (m *messageHandlerOrchestrator) verifiedOwner(ctx context.Context, email string) (*model.User, error) {
+	var notFound errs.NotFound
+
+	user, err := m.searchByEmail(ctx, constants.CriteriaTypeEmail, email)
+	switch {
+	case err == nil && user.PrimaryEmailVerified:
+		return user, nil
+	case err == nil:
+		// an unverified primary email isn't owned, it may still be a verified alternate email
+	case !errors.As(err, &notFound):
+		return nil, err
+	}
+
+	user, err = m.searchByEmail(ctx, constants.CriteriaTypeAlternateEmail, email)
+	switch {
+	case err == nil:
+	case errors.As(err, &notFound):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	for _, alternate := range user.AlternateEmails {
+		if alternate.Verified && strings.EqualFold(strings.TrimSpace(alternate.Email), email) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsEmailVerified reports whether the email is the primary email or a verified alternate email of
+// any user, e.g. for the CLA service to decide whether a commit email is covered. An unknown email
+// is reported as not verified, so the reply doesn't tell whether the email exists, and the owner
+// is only returned to the callers allowed to see it.
+func (m *messageHandlerOrchestrator) IsEmailVerified(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "IsEmailVerified", msg)
+	defer span.End()
+
+	email := strings.ToLower(strings.TrimSpace(string(msg.Data())))
+	if email == "" {
+		return m.errorResponse("email is required"), nil
+	}
+
+	owner, err := m.verifiedOwner(ctx, email)
+	if err != nil {
+		slog.ErrorContext(ctx, "error checking the email verification status",
+			"error", err,
+			"email", redaction.RedactEmail(email),
+		)
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	status := emailVerifiedResponse{
+		Email:    email,
+		Verified: owner != nil,
+	}
+	if owner != nil && m.emailOwnerAllowed(ctx) {
+		status.Sub = owner.ProfileSub()
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    status,
+	}
+
+	responseJSON, errMarshal := json.Marshal(response)
+	if errMarshal != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,125 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeCallerAllowlist serves the entries of a map, the other callers are not found
+type fakeCallerAllowlist struct {
+	port.CallerAllowlist
+	entries map[string]*model.CallerAllowlistEntry
+}
+
+func (f *fakeCallerAllowlist) GetCaller(ctx context.Context, id string) (*model.CallerAllowlistEntry, error) {
+	if id == "flaky-service" {
+		return nil, errs.NewServiceUnavailable("KV unavailable")
+	}
+	if entry, ok := f.entries[id]; ok {
+		return entry, nil
+	}
+	return nil, errs.NewNotFound("caller not found")
+}
+
+func TestMessageHandlerOrchestrator_IsEmailVerified(t *testing.T) {
+	jane := &model.User{
+		UserID:               "auth0|jane",
+		PrimaryEmail:         "jane@example.com",
+		PrimaryEmailVerified: true,
+		AlternateEmails: []model.Email{
+			{Email: "jane@work.example.com", Verified: true},
+			{Email: "jane@old.example.com", Verified: false},
+		},
+	}
+
+	pat := &model.User{UserID: "auth0|pat", PrimaryEmail: "pat@example.com"}
+
+	reader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			switch criteria {
+			case constants.CriteriaTypeEmail:
+				if user.PrimaryEmail == "down@example.com" {
+					return nil, errs.NewServiceUnavailable("auth0 unavailable")
+				}
+				if user.PrimaryEmail == jane.PrimaryEmail {
+					return jane, nil
+				}
+				if user.PrimaryEmail == pat.PrimaryEmail {
+					return pat, nil
+				}
+			case constants.CriteriaTypeAlternateEmail:
+				for _, email := range jane.AlternateEmails {
+					if email.Email == user.AlternateEmails[0].Email {
+						return jane, nil
+					}
+				}
+			}
+			return nil, errs.NewNotFound("user not found")
+		},
+	}
+
+	tests := []struct {
+		name         string
+		caller       string
+		email        string
+		wantSuccess  bool
+		wantError    string
+		wantVerified bool
+		wantSub      string
+	}{
+		{name: "primary email", email: " Jane@Example.com ", wantSuccess: true, wantVerified: true},
+		{name: "verified alternate email", email: "jane@work.example.com", wantSuccess: true, wantVerified: true},
+		{name: "unverified alternate email", email: "jane@old.example.com", wantSuccess: true},
+		{name: "unverified primary email", caller: "cla-service", email: "pat@example.com", wantSuccess: true},
+		{name: "unknown email", email: "nobody@example.com", wantSuccess: true},
+		{name: "the owner is returned to the allowed callers", caller: "cla-service", email: "jane@work.example.com", wantSuccess: true, wantVerified: true, wantSub: "auth0|jane"},
+		{name: "no owner of an unverified email", caller: "cla-service", email: "jane@old.example.com", wantSuccess: true},
+		{name: "the owner is hidden from the other callers", caller: "project-service", email: "jane@example.com", wantSuccess: true, wantVerified: true},
+		{name: "the owner is returned to the allowlisted callers", caller: "crm-service", email: "jane@example.com", wantSuccess: true, wantVerified: true, wantSub: "auth0|jane"},
+		{name: "allowlisted without the capability", caller: "audit-service", email: "jane@example.com", wantSuccess: true, wantVerified: true},
+		{name: "allowlist unavailable", caller: "flaky-service", email: "jane@example.com", wantSuccess: true, wantVerified: true},
+		{name: "provider unavailable", email: "down@example.com", wantError: "auth0 unavailable"},
+		{name: "email is required", email: " ", wantError: "email is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserReaderForMessageHandler(reader),
+				WithEmailOwnerCallersForMessageHandler("cla-service", " "),
+				WithCallerAllowlistForMessageHandler(&fakeCallerAllowlist{entries: map[string]*model.CallerAllowlistEntry{
+					"crm-service":   {ID: "crm-service", Capabilities: []model.CallerCapability{model.CallerCapabilityEmailOwner}},
+					"audit-service": {ID: "audit-service", Capabilities: []model.CallerCapability{}},
+				}}),
+			)
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+
+			result, err := orchestrator.IsEmailVerified(ctx, &mockTransportMessenger{data: []byte(tt.email)})
+			if err != nil {
+				t.Fatalf("IsEmailVerified() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool                  `json:"success"`
+				Error   string                `json:"error"`
+				Data    emailVerifiedResponse `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.wantSuccess || response.Error != tt.wantError ||
+				response.Data.Verified != tt.wantVerified || response.Data.Sub != tt.wantSub {
+				t.Errorf("IsEmailVerified() = %s", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// envelope is the versioned response shape shared by all the handlers, the callers can tell a
+// success from a failure without sniffing the payload
+type envelope struct {
+	Version int `json:"version"`
+	rawUserDataResponse
+}
+
+// WantsEnvelope reports whether the caller asked for the versioned envelope with the
+// X-Response-Version header
+func WantsEnvelope(msg port.TransportMessenger) bool {
+	return strings.TrimSpace(msg.Header(constants.ResponseVersionHeader)) == strconv.Itoa(constants.ResponseEnvelopeVersion)
+}
+
+// EnvelopeResponse wraps the response of a handler in the versioned envelope. The JSON replies
+// already carrying success are completed with the version (and the error code of the failures),
+// the plain text replies (e.g. a username) and the other JSON values become the data.
+func EnvelopeResponse(response []byte) []byte {
+	out := envelope{Version: constants.ResponseEnvelopeVersion}
+	out.Success = true
+
+	trimmed := bytes.TrimSpace(response)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")) && isUserDataResponse(trimmed):
+		if err := json.Unmarshal(trimmed, &out); err != nil {
+			return response
+		}
+		out.Version = constants.ResponseEnvelopeVersion
+		if !out.Success && out.ErrorCode == "" {
+			out.ErrorCode = errs.CodeUnexpected
+		}
+	case (bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("["))) && json.Valid(trimmed):
+		out.Data = trimmed
+	default:
+		// the plain text replies are strings, even when they look like a JSON number or boolean
+		text, err := json.Marshal(string(response))
+		if err != nil {
+			return response
+		}
+		out.Data = text
+	}
+
+	enveloped, err := json.Marshal(out)
+	if err != nil {
+		return response
+	}
+	return enveloped
+}
+
+// EnvelopeError builds the versioned envelope of an error raised outside the handlers
+func EnvelopeError(message, code string) []byte {
+	out := envelope{Version: constants.ResponseEnvelopeVersion}
+	out.Error = message
+	out.ErrorCode = code
+	enveloped, _ := json.Marshal(out)
+	return enveloped
+}
+
+// isUserDataResponse reports whether the JSON object is a UserDataResponse, which has a success field
+func isUserDataResponse(object []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(object, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["success"]
+	return ok
+}
@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestEnvelopeResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{
+			name:     "plain text reply",
+			response: `zephyr.stormwind`,
+			want:     `{"version":2,"success":true,"data":"zephyr.stormwind"}`,
+		},
+		{
+			name:     "plain text reply looking like a number",
+			response: `12345`,
+			want:     `{"version":2,"success":true,"data":"12345"}`,
+		},
+		{
+			name:     "success reply",
+			response: `{"success":true,"data":{"username":"zephyr.stormwind"}}`,
+			want:     `{"version":2,"success":true,"data":{"username":"zephyr.stormwind"}}`,
+		},
+		{
+			name:     "message reply",
+			response: `{"success":true,"message":"user restored successfully"}`,
+			want:     `{"version":2,"success":true,"message":"user restored successfully"}`,
+		},
+		{
+			name:     "error reply",
+			response: `{"success":false,"error":"user not found","error_code":"not_found"}`,
+			want:     `{"version":2,"success":false,"error":"user not found","error_code":"not_found"}`,
+		},
+		{
+			name:     "retryable error reply",
+			response: `{"success":false,"error":"slow down","error_code":"too_many_requests","retryable":true,"retry_after_ms":5000}`,
+			want:     `{"version":2,"success":false,"error":"slow down","error_code":"too_many_requests","retryable":true,"retry_after_ms":5000}`,
+		},
+		{
+			name:     "error reply without code",
+			response: `{"success":false,"error":"boom"}`,
+			want:     `{"version":2,"success":false,"error":"boom","error_code":"unexpected"}`,
+		},
+		{
+			name:     "stale reply",
+			response: `{"success":true,"data":{"username":"zephyr.stormwind"},"stale":true,"age":30}`,
+			want:     `{"version":2,"success":true,"stale":true,"age":30,"data":{"username":"zephyr.stormwind"}}`,
+		},
+		{
+			name:     "other JSON object",
+			response: `{"sub":"auth0|123"}`,
+			want:     `{"version":2,"success":true,"data":{"sub":"auth0|123"}}`,
+		},
+		{
+			name:     "JSON array",
+			response: `[{"sub":"auth0|123"}]`,
+			want:     `{"version":2,"success":true,"data":[{"sub":"auth0|123"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(EnvelopeResponse([]byte(tt.response))); got != tt.want {
+				t.Errorf("EnvelopeResponse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvelopeError(t *testing.T) {
+	want := `{"version":2,"success":false,"error":"unknown subject","error_code":"not_found"}`
+	if got := string(EnvelopeError("unknown subject", errs.CodeNotFound)); got != want {
+		t.Errorf("EnvelopeError() = %s, want %s", got, want)
+	}
+}
+
+func TestWantsEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "no header", want: false},
+		{name: "version 2", headers: map[string]string{constants.ResponseVersionHeader: " 2 "}, want: true},
+		{name: "version 1", headers: map[string]string{constants.ResponseVersionHeader: "1"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WantsEnvelope(&mockTransportMessenger{headers: tt.headers}); got != tt.want {
+				t.Errorf("WantsEnvelope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/i18n"
+)
+
+// localeRequest captures the optional locale field present in JSON requests
+type localeRequest struct {
+	Locale string `json:"locale"`
+}
+
+// NegotiateLocale returns the locale for the message, preferring the
+// Accept-Language header over the "locale" field of JSON payloads.
+func NegotiateLocale(msg port.TransportMessenger) string {
+	var request localeRequest
+	// non-JSON payloads (e.g. a plain email) simply don't carry a locale
+	_ = json.Unmarshal(msg.Data(), &request)
+
+	return i18n.Negotiate(msg.Header(constants.AcceptLanguageHeader), request.Locale)
+}
+
+// LocalizeResponse translates the message and error of a UserDataResponse into
+// the locale negotiated for the message.
+//
+// Responses that are not a UserDataResponse (e.g. a plain username) are returned unchanged.
+func LocalizeResponse(msg port.TransportMessenger, response []byte) []byte {
+	locale := NegotiateLocale(msg)
+	if locale == i18n.DefaultLocale {
+		return response
+	}
+
+	var payload rawUserDataResponse
+	if err := json.Unmarshal(response, &payload); err != nil {
+		return response
+	}
+	if payload.Message == "" && payload.Error == "" {
+		return response
+	}
+
+	payload.Message = i18n.Translate(locale, payload.Message)
+	payload.Error = i18n.Translate(locale, payload.Error)
+
+	localized, err := json.Marshal(payload)
+	if err != nil {
+		return response
+	}
+	return localized
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+func TestLocalizeResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      *mockTransportMessenger
+		response string
+		want     string
+	}{
+		{
+			name:     "no locale keeps english",
+			msg:      &mockTransportMessenger{data: []byte("user@example.com")},
+			response: `{"success":false,"error":"invalid email"}`,
+			want:     `{"success":false,"error":"invalid email"}`,
+		},
+		{
+			name: "accept-language header",
+			msg: &mockTransportMessenger{
+				data:    []byte("user@example.com"),
+				headers: map[string]string{constants.AcceptLanguageHeader: "es-ES,es;q=0.9"},
+			},
+			response: `{"success":false,"error":"invalid email"}`,
+			want:     `{"success":false,"error":"correo electrónico no válido"}`,
+		},
+		{
+			name:     "locale request field",
+			msg:      &mockTransportMessenger{data: []byte(`{"locale":"pt-BR","email":"user@example.com"}`)},
+			response: `{"success":true,"message":"identity linked successfully"}`,
+			want:     `{"success":true,"message":"identidade vinculada com sucesso"}`,
+		},
+		{
+			name: "header takes precedence over request field",
+			msg: &mockTransportMessenger{
+				data:    []byte(`{"locale":"pt"}`),
+				headers: map[string]string{constants.AcceptLanguageHeader: "es"},
+			},
+			response: `{"success":true,"message":"identity linked successfully"}`,
+			want:     `{"success":true,"message":"identidad vinculada correctamente"}`,
+		},
+		{
+			name: "retry hint is preserved",
+			msg: &mockTransportMessenger{
+				headers: map[string]string{constants.AcceptLanguageHeader: "es"},
+			},
+			response: `{"success":false,"error":"invalid email","retryable":true,"retry_after_ms":2000}`,
+			want:     `{"success":false,"error":"correo electrónico no válido","retryable":true,"retry_after_ms":2000}`,
+		},
+		{
+			name: "data is preserved",
+			msg: &mockTransportMessenger{
+				headers: map[string]string{constants.AcceptLanguageHeader: "es"},
+			},
+			response: `{"success":true,"message":"alternate email verification sent","data":{"z":1,"a":"b"}}`,
+			want:     `{"success":true,"message":"se envió la verificación del correo electrónico alternativo","data":{"z":1,"a":"b"}}`,
+		},
+		{
+			name: "stale flags are preserved",
+			msg: &mockTransportMessenger{
+				headers: map[string]string{constants.AcceptLanguageHeader: "es"},
+			},
+			response: `{"success":true,"message":"alternate email verification sent","stale":true,"age":30}`,
+			want:     `{"success":true,"message":"se envió la verificación del correo electrónico alternativo","stale":true,"age":30}`,
+		},
+		{
+			name: "plain responses are untouched",
+			msg: &mockTransportMessenger{
+				headers: map[string]string{constants.AcceptLanguageHeader: "es"},
+			},
+			response: `johndoe`,
+			want:     `johndoe`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(LocalizeResponse(tt.msg, []byte(tt.response)))
+			if got != tt.want {
+				t.Errorf("LocalizeResponse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
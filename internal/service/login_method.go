@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// userMetadataResponse is the profile read response, the metadata fields are kept at the top
+// level and the login methods are computed by the service, they are never stored
+type userMetadataResponse struct {
+	*model.UserMetadata
+	LoginMethods           []model.LoginMethod `json:"login_methods,omitempty"`
+	RecommendedLoginMethod model.LoginMethod   `json:"recommended_login_method,omitempty"`
+}
+
+// newUserMetadataResponse builds the profile read response, including the login methods
+// so the login screen can pre-select the strongest one. It's nil when there is nothing to return.
+func (m *messageHandlerOrchestrator) newUserMetadataResponse(ctx context.Context, user *model.User) any {
+	methods := user.LoginMethods(m.hasPasskey(ctx, user))
+	if user.UserMetadata == nil && len(methods) == 0 {
+		return nil
+	}
+	return userMetadataResponse{
+		UserMetadata:           user.UserMetadata,
+		LoginMethods:           methods,
+		RecommendedLoginMethod: model.RecommendedLoginMethod(methods),
+	}
+}
+
+// hasPasskey reports whether the user registered a WebAuthn authenticator, it's best effort
+// and a failure only drops the passkey from the login methods
+func (m *messageHandlerOrchestrator) hasPasskey(ctx context.Context, user *model.User) bool {
+	if m.authenticatorManager == nil || user.UserID == "" {
+		return false
+	}
+
+	authenticators, errList := m.authenticatorManager.ListAuthenticators(ctx, user)
+	if errList != nil {
+		slog.WarnContext(ctx, "failed to list authenticators to compute login methods",
+			"error", errList,
+		)
+		return false
+	}
+	return len(authenticators) > 0
+}
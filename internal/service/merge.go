@@ -0,0 +1,201 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// mergeAccount is one of the accounts of a merge, the token proves the ownership of the account
+type mergeAccount struct {
+	AuthToken string `json:"auth_token"`
+}
+
+// mergeRequest represents the input for merging the secondary account into the primary one
+type mergeRequest struct {
+	Primary        mergeAccount `json:"primary"`
+	Secondary      mergeAccount `json:"secondary"`
+	ConflictPolicy string       `json:"conflict_policy,omitempty"`
+}
+
+// mergeResponse is the merged profile, conflicts lists the fields resolved with the conflict policy
+type mergeResponse struct {
+	UserMetadata *model.UserMetadata `json:"user_metadata,omitempty"`
+	Conflicts    []string            `json:"conflicts,omitempty"`
+}
+
+// ownedUser verifies the token proves the ownership of an account and loads the account,
+// only tokens are accepted since a sub or a username can be provided by anyone
+func (m *messageHandlerOrchestrator) ownedUser(ctx context.Context, token string) (*model.User, error) {
+	cleanToken, isJWT := jwt.LooksLikeJWT(strings.TrimSpace(token))
+	if !isJWT {
+		return nil, errs.NewValidation("auth_token is required for both accounts")
+	}
+
+	lookup, errMetadataLookup := m.userReader.MetadataLookup(ctx, cleanToken, constants.UserUpdateIdentityRequiredScope)
+	if errMetadataLookup != nil {
+		return nil, errMetadataLookup
+	}
+	// the provider credentials are used to read the full profile
+	lookup.Token = ""
+	return m.userReader.GetUser(ctx, lookup)
+}
+
+// MergeUsers merges the secondary account into the primary one, the owner must prove the ownership
+// of both accounts. The secondary identities are linked to the primary account, the metadata is merged
+// using the conflict policy and a user merged event is emitted for the downstream services.
+func (m *messageHandlerOrchestrator) MergeUsers(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "MergeUsers", msg)
+	defer span.End()
+
+	if m.userMerger == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	var request mergeRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+
+	policy, errPolicy := model.ParseMergePolicy(request.ConflictPolicy)
+	if errPolicy != nil {
+		return m.errorResponseFromError(ctx, errPolicy), nil
+	}
+
+	// both accounts are read and written, lock them before loading them
+	unlock, errLock := m.lockUsers(ctx,
+		tokenLockKey(ctx, request.Primary.AuthToken),
+		tokenLockKey(ctx, request.Secondary.AuthToken),
+	)
+	if errLock != nil {
+		return m.errorResponseFromError(ctx, errLock), nil
+	}
+	defer unlock()
+
+	primary, errPrimary := m.ownedUser(ctx, request.Primary.AuthToken)
+	if errPrimary != nil {
+		slog.ErrorContext(ctx, "error verifying primary account for merge",
+			"error", errPrimary,
+		)
+		return m.errorResponseFromError(ctx, errPrimary), nil
+	}
+
+	secondary, errSecondary := m.ownedUser(ctx, request.Secondary.AuthToken)
+	if errSecondary != nil {
+		slog.ErrorContext(ctx, "error verifying secondary account for merge",
+			"error", errSecondary,
+		)
+		return m.errorResponseFromError(ctx, errSecondary), nil
+	}
+
+	if primary.UserID == secondary.UserID {
+		return m.errorResponse("accounts are already the same"), nil
+	}
+
+	metadata, conflicts := model.MergeUserMetadata(primary.UserMetadata, secondary.UserMetadata, policy)
+
+	// the badge is recomputed with the emails of both accounts
+	if len(m.organizationDomains) > 0 && metadata.Organization != nil {
+		merged := &model.User{
+			PrimaryEmail:    primary.PrimaryEmail,
+			AlternateEmails: append(append([]model.Email{}, primary.AlternateEmails...), secondary.AlternateEmails...),
+		}
+		if secondary.PrimaryEmail != "" {
+			merged.AlternateEmails = append(merged.AlternateEmails, model.Email{Email: secondary.PrimaryEmail, Verified: true})
+		}
+		verified := merged.OrganizationVerified(m.organizationDomains, *metadata.Organization)
+		metadata.OrganizationVerified = &verified
+	}
+
+	mergedUser, errMerge := m.userMerger.MergeUsers(ctx, &model.UserMerge{
+		Primary:   primary,
+		Secondary: secondary,
+		Metadata:  metadata,
+	})
+	if errMerge != nil {
+		slog.ErrorContext(ctx, "error merging accounts",
+			"error", errMerge,
+			"primary", redaction.Redact(primary.UserID),
+			"secondary", redaction.Redact(secondary.UserID),
+		)
+		return m.errorResponseFromError(ctx, errMerge), nil
+	}
+
+	slog.InfoContext(ctx, "audit: accounts merged",
+		"primary", redaction.Redact(primary.UserID),
+		"secondary", redaction.Redact(secondary.UserID),
+		"conflict_policy", policy,
+		"conflicts", conflicts,
+	)
+
+	m.publishAudit(ctx, &model.AuditEvent{
+		Action: model.AuditActionMerge,
+		Actor:  primary.UserID,
+		Target: secondary.UserID,
+		Details: map[string]any{
+			"conflict_policy": policy,
+			"conflicts":       conflicts,
+		},
+	})
+	m.publishUserMerged(ctx, &model.UserMerged{
+		PrimarySub:        primary.UserID,
+		PrimaryUsername:   primary.Username,
+		SecondarySub:      secondary.UserID,
+		SecondaryUsername: secondary.Username,
+		ConflictPolicy:    policy,
+		Conflicts:         conflicts,
+		MergedAt:          m.now(),
+	})
+	// both accounts changed, the secondary one mustn't be served from the caches either
+	m.publishProfileChanged(ctx, model.ProfileChangeMerge, primary)
+	m.publishProfileChanged(ctx, model.ProfileChangeMerge, secondary)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "accounts merged successfully",
+		Data: m.applyResponsePolicy(ctx, mergeResponse{
+			UserMetadata: mergedUser.UserMetadata,
+			Conflicts:    conflicts,
+		}),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// publishUserMerged emits the user merged event, the merge is already done
+// so a failure is only logged
+func (m *messageHandlerOrchestrator) publishUserMerged(ctx context.Context, event *model.UserMerged) {
+	if m.eventPublisher == nil {
+		return
+	}
+
+	data, errMarshal := json.Marshal(event)
+	if errMarshal != nil {
+		slog.ErrorContext(ctx, "failed to marshal user merged event", "error", errMarshal)
+		return
+	}
+
+	if errPublish := m.eventPublisher.Publish(ctx, constants.UserMergedSubject, data); errPublish != nil {
+		slog.ErrorContext(ctx, "failed to publish user merged event",
+			"error", errPublish,
+			"primary", redaction.Redact(event.PrimarySub),
+			"secondary", redaction.Redact(event.SecondarySub),
+		)
+	}
+}
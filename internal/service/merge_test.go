@@ -0,0 +1,197 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+type mockUserMerger struct {
+	merge *model.UserMerge
+}
+
+func (m *mockUserMerger) MergeUsers(ctx context.Context, merge *model.UserMerge) (*model.User, error) {
+	m.merge = merge
+	return &model.User{UserID: merge.Primary.UserID, UserMetadata: merge.Metadata}, nil
+}
+
+// mockEventPublisher keeps the last event published on each subject, and all of them in order
+type mockEventPublisher struct {
+	events    map[string][]byte
+	published map[string][][]byte
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+	if m.events == nil {
+		m.events = make(map[string][]byte)
+		m.published = make(map[string][][]byte)
+	}
+	m.events[subject] = data
+	m.published[subject] = append(m.published[subject], data)
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_MergeUsers(t *testing.T) {
+	ctx := context.Background()
+	str := func(s string) *string { return &s }
+
+	token := func(sub string) string {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": sub}).SignedString([]byte("test-secret"))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return signed
+	}
+	subs := map[string]string{
+		token("auth0|primary"):    "auth0|primary",
+		token("github|secondary"): "github|secondary",
+	}
+	users := map[string]*model.User{
+		"auth0|primary":    {UserID: "auth0|primary", Username: "jdoe", UserMetadata: &model.UserMetadata{Name: str("Jane Doe")}},
+		"github|secondary": {UserID: "github|secondary", UserMetadata: &model.UserMetadata{Name: str("jdoe-gh"), City: str("Lisbon")}},
+	}
+
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(ctx context.Context, input string) (*model.User, error) {
+			sub, ok := subs[input]
+			if !ok {
+				return nil, fmt.Errorf("invalid token")
+			}
+			return &model.User{UserID: sub, Token: input}, nil
+		},
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			return users[user.UserID], nil
+		},
+	}
+
+	request := func(primary, secondary, policy string) []byte {
+		data, _ := json.Marshal(map[string]any{
+			"primary":         map[string]string{"auth_token": primary},
+			"secondary":       map[string]string{"auth_token": secondary},
+			"conflict_policy": policy,
+		})
+		return data
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		wantError     string
+		wantName      string
+		wantConflicts []string
+	}{
+		{
+			name:          "primary wins by default",
+			data:          request(token("auth0|primary"), token("github|secondary"), ""),
+			wantName:      "Jane Doe",
+			wantConflicts: []string{"name"},
+		},
+		{
+			name:          "secondary wins",
+			data:          request(token("auth0|primary"), token("github|secondary"), "secondary"),
+			wantName:      "jdoe-gh",
+			wantConflicts: []string{"name"},
+		},
+		{
+			name:      "sub is not a proof of ownership",
+			data:      request(token("auth0|primary"), "github|secondary", ""),
+			wantError: "auth_token is required for both accounts",
+		},
+		{
+			name:      "same account",
+			data:      request(token("auth0|primary"), token("auth0|primary"), ""),
+			wantError: "accounts are already the same",
+		},
+		{
+			name:      "invalid policy",
+			data:      request(token("auth0|primary"), token("github|secondary"), "newest"),
+			wantError: `invalid conflict policy: "newest"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merger := &mockUserMerger{}
+			publisher := &mockEventPublisher{}
+			orchestrator := &messageHandlerOrchestrator{
+				userReader:     reader,
+				userMerger:     merger,
+				eventPublisher: publisher,
+			}
+
+			result, err := orchestrator.MergeUsers(ctx, &mockTransportMessenger{data: tt.data})
+			if err != nil {
+				t.Fatalf("MergeUsers() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool   `json:"success"`
+				Error   string `json:"error"`
+				Data    struct {
+					UserMetadata *model.UserMetadata `json:"user_metadata"`
+					Conflicts    []string            `json:"conflicts"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || response.Error != tt.wantError {
+					t.Errorf("MergeUsers() = %s", result)
+				}
+				if merger.merge != nil || len(publisher.events) != 0 {
+					t.Error("MergeUsers() merged accounts on error")
+				}
+				return
+			}
+
+			if !response.Success || *response.Data.UserMetadata.Name != tt.wantName || *response.Data.UserMetadata.City != "Lisbon" {
+				t.Fatalf("MergeUsers() = %s", result)
+			}
+			if fmt.Sprint(response.Data.Conflicts) != fmt.Sprint(tt.wantConflicts) {
+				t.Errorf("conflicts = %v, want %v", response.Data.Conflicts, tt.wantConflicts)
+			}
+
+			var event model.UserMerged
+			if err := json.Unmarshal(publisher.events[constants.UserMergedSubject], &event); err != nil {
+				t.Fatalf("failed to unmarshal event: %v", err)
+			}
+			if event.PrimarySub != "auth0|primary" || event.SecondarySub != "github|secondary" {
+				t.Errorf("published %+v", event)
+			}
+
+			// both accounts changed, so both are invalidated
+			var changedSubs []string
+			for _, data := range publisher.published[constants.UserProfileChangedSubject] {
+				var changed model.UserProfileChanged
+				if err := json.Unmarshal(data, &changed); err != nil {
+					t.Fatalf("failed to unmarshal profile changed event: %v", err)
+				}
+				if changed.Reason != model.ProfileChangeMerge {
+					t.Errorf("profile changed event = %+v", changed)
+				}
+				changedSubs = append(changedSubs, changed.Sub)
+			}
+			if fmt.Sprint(changedSubs) != "[auth0|primary github|secondary]" {
+				t.Errorf("profile changed subs = %v", changedSubs)
+			}
+
+			var audit model.AuditEvent
+			if err := json.Unmarshal(publisher.events[constants.AuditEventSubject], &audit); err != nil {
+				t.Fatalf("failed to unmarshal audit event: %v", err)
+			}
+			if audit.Action != model.AuditActionMerge || audit.Actor != "auth0|primary" || audit.Target != "github|secondary" {
+				t.Errorf("audit event = %+v", audit)
+			}
+		})
+	}
+}
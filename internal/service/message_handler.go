@@ -4,15 +4,20 @@
 package service
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
 	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 )
@@ -23,8 +28,32 @@ type UserDataResponse struct {
 	Message string `json:"message,omitempty"`
 	Data    any    `json:"data,omitempty"`
 	Error   string `json:"error,omitempty"`
+	// ErrorCode is the stable identifier of the error, see errs.Code
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// Retryable and RetryAfterMs hint clients to back off when the error is transient
+	Retryable    bool  `json:"retryable,omitempty"`
+	RetryAfterMs int64 `json:"retry_after_ms,omitempty"`
+
+	// Violations are all the rules failed by an invalid request, only for the trusted callers
+	Violations model.ValidationViolations `json:"violations,omitempty"`
+
+	// Stale and Age mark the data served from the users cache while the identity provider is
+	// unavailable, Age is how long ago it was cached, in seconds
+	Stale bool  `json:"stale,omitempty"`
+	Age   int64 `json:"age,omitempty"`
 }
 
+// rawUserDataResponse is a UserDataResponse keeping its data untouched, the replies are rewritten
+// (enveloped, localized) through it so none of their fields is dropped
+type rawUserDataResponse struct {
+	UserDataResponse
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// circuitOpenRetryAfter is the retry hint of unexpected errors while an upstream provider is failing
+const circuitOpenRetryAfter = 30 * time.Second
+
 // messageHandlerOrchestrator orchestrates the message handling process
 type messageHandlerOrchestrator struct {
 	userWriter       port.UserWriter
@@ -32,6 +61,49 @@ type messageHandlerOrchestrator struct {
 	emailHandler     port.EmailHandler
 	identityLinker   port.IdentityLinker
 	identityUnlinker port.IdentityLinker
+	emailUnlinker    port.AlternateEmailUnlinker
+	userDeleter      port.UserDeleter
+
+	// verificationHandler verifies the phone numbers on the phoneLinkingChannel, nil when disabled
+	verificationHandler port.VerificationHandler
+	phoneLinkingChannel model.VerificationChannel
+
+	organizationDomains     model.OrganizationDomains
+	organizationAdminWriter port.OrganizationAdminWriter
+	authenticatorManager    port.AuthenticatorManager
+	userMerger              port.UserMerger
+
+	eventPublisher port.EventPublisher
+	auditSink      port.AuditSink
+
+	providerStatusReader port.ProviderStatusReader
+	usageReader          port.UsageReader
+	contractReportReader port.ContractReportReader
+	userFixtureLoader    port.UserFixtureLoader
+	faultInjector        port.FaultInjector
+	costGuard            port.CostGuard
+	emailSendLimiter     port.EmailSendLimiter
+	otpAttemptGuard      port.OTPAttemptGuard
+	responsePolicies     model.ResponsePolicies
+	userLocker           port.UserLocker
+	profileSearcher      port.ProfileSearcher
+	emailOwnerCallers    map[string]struct{}
+	verboseErrorCallers  map[string]struct{}
+	callerAllowlist      port.CallerAllowlist
+	emailHashMatcher     port.EmailHashMatcher
+	profileLinkSigner    port.ProfileLinkSigner
+	profileShareBaseURL  string
+	tokenRefSealer       port.TokenReferenceSealer
+	backupCodeStore      port.EmailBackupCodeStore
+	verifiedEmailLinker  port.VerifiedEmailLinker
+	primaryEmailChanger  port.PrimaryEmailChanger
+	notificationSender   port.TemplatedEmailSender
+	provenanceStore      port.MetadataProvenanceStore
+	metadataEnricher     port.UserMetadataEnricher
+	enrichmentCallers    map[string]struct{}
+	metadataPolicy       port.MetadataPolicy
+
+	clock clock.Clock
 }
 
 // messageHandlerOrchestratorOption defines a function type for setting options
@@ -58,6 +130,20 @@ func WithEmailHandlerForMessageHandler(emailHandler port.EmailHandler) messageHa
 	}
 }
 
+// WithVerificationHandlerForMessageHandler sets the one time password verification of the phone numbers
+func WithVerificationHandlerForMessageHandler(handler port.VerificationHandler) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.verificationHandler = handler
+	}
+}
+
+// WithPhoneLinkingChannelForMessageHandler sets the delivery channel of the phone verification codes
+func WithPhoneLinkingChannelForMessageHandler(channel model.VerificationChannel) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.phoneLinkingChannel = channel
+	}
+}
+
 // WithIdentityLinkerForMessageHandler sets the identity linker for the message handler orchestrator
 func WithIdentityLinkerForMessageHandler(identityLinker port.IdentityLinker) messageHandlerOrchestratorOption {
 	return func(m *messageHandlerOrchestrator) {
@@ -72,17 +158,327 @@ func WithIdentityUnlinkerForMessageHandler(identityUnlinker port.IdentityLinker)
 	}
 }
 
+// WithAlternateEmailUnlinkerForMessageHandler sets the alternate email unlinker for the message handler orchestrator
+func WithAlternateEmailUnlinkerForMessageHandler(emailUnlinker port.AlternateEmailUnlinker) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.emailUnlinker = emailUnlinker
+	}
+}
+
+// WithUserDeleterForMessageHandler sets the user deleter for the message handler orchestrator
+func WithUserDeleterForMessageHandler(userDeleter port.UserDeleter) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.userDeleter = userDeleter
+	}
+}
+
+// WithOrganizationDomainsForMessageHandler sets the registered organization domains used
+// to compute the organization verified badge
+func WithOrganizationDomainsForMessageHandler(domains model.OrganizationDomains) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.organizationDomains = domains
+	}
+}
+
+// WithOrganizationAdminWriterForMessageHandler sets the writer used for the profile updates
+// delegated to organization admins
+func WithOrganizationAdminWriterForMessageHandler(writer port.OrganizationAdminWriter) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.organizationAdminWriter = writer
+	}
+}
+
+// WithAuthenticatorManagerForMessageHandler sets the manager of the user WebAuthn authenticators
+func WithAuthenticatorManagerForMessageHandler(manager port.AuthenticatorManager) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.authenticatorManager = manager
+	}
+}
+
+// WithUserMergerForMessageHandler sets the merger of the user accounts
+func WithUserMergerForMessageHandler(merger port.UserMerger) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.userMerger = merger
+	}
+}
+
+// WithEventPublisherForMessageHandler sets the publisher of the events consumed by other services
+func WithEventPublisherForMessageHandler(publisher port.EventPublisher) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.eventPublisher = publisher
+	}
+}
+
+// WithAuditSinkForMessageHandler sets the sink recording the audit events of the user mutations
+func WithAuditSinkForMessageHandler(sink port.AuditSink) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.auditSink = sink
+	}
+}
+
+// WithProviderStatusReaderForMessageHandler sets the reader of the upstream providers health
+func WithProviderStatusReaderForMessageHandler(reader port.ProviderStatusReader) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.providerStatusReader = reader
+	}
+}
+
+// WithUsageReaderForMessageHandler sets the reader of the usage per caller and operation
+func WithUsageReaderForMessageHandler(reader port.UsageReader) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.usageReader = reader
+	}
+}
+
+// WithContractReportReaderForMessageHandler sets the reader of the service levels of the consumer contracts
+func WithContractReportReaderForMessageHandler(reader port.ContractReportReader) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.contractReportReader = reader
+	}
+}
+
+// WithUserFixtureLoaderForMessageHandler sets the loader of the users of the test providers
+func WithUserFixtureLoaderForMessageHandler(loader port.UserFixtureLoader) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.userFixtureLoader = loader
+	}
+}
+
+// WithFaultInjectorForMessageHandler sets the injector of the faults of the test providers
+func WithFaultInjectorForMessageHandler(injector port.FaultInjector) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.faultInjector = injector
+	}
+}
+
+// WithCostGuardForMessageHandler sets the guard enforcing the callers budgets of the expensive operations
+func WithCostGuardForMessageHandler(guard port.CostGuard) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.costGuard = guard
+	}
+}
+
+// WithEmailSendLimiterForMessageHandler sets the limits of the verification emails of the email linking
+func WithEmailSendLimiterForMessageHandler(limiter port.EmailSendLimiter) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.emailSendLimiter = limiter
+	}
+}
+
+// WithOTPAttemptGuardForMessageHandler sets the lockout of the email verification after repeated failed attempts
+func WithOTPAttemptGuardForMessageHandler(guard port.OTPAttemptGuard) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.otpAttemptGuard = guard
+	}
+}
+
+// WithResponsePoliciesForMessageHandler sets the fields each calling service must never see in the replies
+func WithResponsePoliciesForMessageHandler(policies model.ResponsePolicies) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.responsePolicies = policies
+	}
+}
+
+// WithUserLockerForMessageHandler sets the per-user locks serializing the concurrent updates
+// of the same user, the locks are local to the process by default
+func WithUserLockerForMessageHandler(locker port.UserLocker) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.userLocker = locker
+	}
+}
+
+// WithProfileSearcherForMessageHandler sets the typeahead search of the public profiles, optional
+func WithProfileSearcherForMessageHandler(searcher port.ProfileSearcher) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.profileSearcher = searcher
+	}
+}
+
+// WithEmailOwnerCallersForMessageHandler sets the calling services allowed to see the owner of a verified email
+func WithEmailOwnerCallersForMessageHandler(callers ...string) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.emailOwnerCallers = make(map[string]struct{}, len(callers))
+		for _, caller := range callers {
+			if caller = strings.TrimSpace(caller); caller != "" {
+				m.emailOwnerCallers[caller] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithVerboseErrorCallersForMessageHandler sets the calling services trusted with the list of the rules
+// failed by their invalid requests
+func WithVerboseErrorCallersForMessageHandler(callers ...string) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.verboseErrorCallers = make(map[string]struct{}, len(callers))
+		for _, caller := range callers {
+			if caller = strings.TrimSpace(caller); caller != "" {
+				m.verboseErrorCallers[caller] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithCallerAllowlistForMessageHandler sets the allowlist granting capabilities to the calling services,
+// on top of the ones granted in the environment
+func WithCallerAllowlistForMessageHandler(allowlist port.CallerAllowlist) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.callerAllowlist = allowlist
+	}
+}
+
+// WithEmailHashMatcherForMessageHandler sets the matcher of the email index hashes
+func WithEmailHashMatcherForMessageHandler(matcher port.EmailHashMatcher) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.emailHashMatcher = matcher
+	}
+}
+
+// WithProfileLinkSignerForMessageHandler sets the signer of the profile share links and their base URL,
+// the links only carry the token when the base URL is empty
+func WithProfileLinkSignerForMessageHandler(signer port.ProfileLinkSigner, baseURL string) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.profileLinkSigner = signer
+		m.profileShareBaseURL = strings.TrimSuffix(strings.TrimSpace(baseURL), "/")
+	}
+}
+
+// WithTokenReferenceSealerForMessageHandler sets the sealer of the references returned instead of the
+// raw ID tokens, the raw tokens are returned when nil
+func WithTokenReferenceSealerForMessageHandler(sealer port.TokenReferenceSealer) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.tokenRefSealer = sealer
+	}
+}
+
+// WithEmailBackupCodeStoreForMessageHandler sets the store of the backup codes verifying an email in
+// place of the OTP, the backup codes are disabled when nil
+func WithEmailBackupCodeStoreForMessageHandler(store port.EmailBackupCodeStore) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.backupCodeStore = store
+	}
+}
+
+// WithVerifiedEmailLinkerForMessageHandler sets the linker of the emails verified with a backup code
+func WithVerifiedEmailLinkerForMessageHandler(linker port.VerifiedEmailLinker) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.verifiedEmailLinker = linker
+	}
+}
+
+// WithPrimaryEmailChangerForMessageHandler sets the changer of the primary email to a verified email
+func WithPrimaryEmailChangerForMessageHandler(changer port.PrimaryEmailChanger) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.primaryEmailChanger = changer
+	}
+}
+
+// WithNotificationSenderForMessageHandler sets the sender notifying the previous primary email of a
+// change, no notice is sent when nil
+func WithNotificationSenderForMessageHandler(sender port.TemplatedEmailSender) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.notificationSender = sender
+	}
+}
+
+// WithMetadataProvenanceStoreForMessageHandler sets the store of the provenance of the metadata fields,
+// the provenance isn't recorded and the enrichment is disabled when nil
+func WithMetadataProvenanceStoreForMessageHandler(store port.MetadataProvenanceStore) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.provenanceStore = store
+	}
+}
+
+// WithMetadataEnricherForMessageHandler sets the writer of the metadata of the enrichment jobs, and the
+// calling services allowed to enrich the metadata on top of the caller allowlist
+func WithMetadataEnricherForMessageHandler(enricher port.UserMetadataEnricher, callers ...string) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.metadataEnricher = enricher
+		m.enrichmentCallers = make(map[string]struct{}, len(callers))
+		for _, caller := range callers {
+			if caller = strings.TrimSpace(caller); caller != "" {
+				m.enrichmentCallers[caller] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithMetadataPolicyForMessageHandler sets the moderation policy reviewing the metadata of the updates
+// before they reach the identity provider
+func WithMetadataPolicyForMessageHandler(policy port.MetadataPolicy) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.metadataPolicy = policy
+	}
+}
+
+// WithClockForMessageHandler sets the time source of the event timestamps and the usage report days
+func WithClockForMessageHandler(c clock.Clock) messageHandlerOrchestratorOption {
+	return func(m *messageHandlerOrchestrator) {
+		m.clock = c
+	}
+}
+
+// now returns the current time in UTC, from the system clock unless another one is set
+func (m *messageHandlerOrchestrator) now() time.Time {
+	return clock.Or(m.clock).Now().UTC()
+}
+
+// errorResponse builds the error response of an invalid request
 func (m *messageHandlerOrchestrator) errorResponse(error string) []byte {
 	response := UserDataResponse{
-		Success: false,
-		Error:   error,
+		Success:   false,
+		Error:     error,
+		ErrorCode: errs.CodeValidation,
 	}
 	responseJSON, _ := json.Marshal(response)
 	return responseJSON
 }
 
+// errorResponseFromError builds the error response, hinting the client when and
+// whether to retry for transient errors (rate limits, unavailable or failing upstreams)
+func (m *messageHandlerOrchestrator) errorResponseFromError(ctx context.Context, err error) []byte {
+	responseJSON, _ := json.Marshal(m.errorDataResponse(ctx, err))
+	return responseJSON
+}
+
+// errorDataResponse builds the error response of errorResponseFromError, before marshaling
+func (m *messageHandlerOrchestrator) errorDataResponse(ctx context.Context, err error) UserDataResponse {
+	recordSpanError(ctx, err)
+
+	response := UserDataResponse{
+		Success:   false,
+		Error:     err.Error(),
+		ErrorCode: errs.Code(err),
+	}
+
+	retryAfter, retryable := errs.RetryAfter(err)
+	if !retryable && m.providerCircuitOpen(ctx, err) {
+		retryAfter, retryable = circuitOpenRetryAfter, true
+	}
+	if retryable {
+		response.Retryable = true
+		response.RetryAfterMs = retryAfter.Milliseconds()
+	}
+
+	return response
+}
+
+// providerCircuitOpen reports whether an unexpected error happened while an upstream provider is failing
+func (m *messageHandlerOrchestrator) providerCircuitOpen(ctx context.Context, err error) bool {
+	var unexpected errs.Unexpected
+	if m.providerStatusReader == nil || !errors.As(err, &unexpected) {
+		return false
+	}
+	for _, status := range m.providerStatusReader.ProviderStatuses(ctx) {
+		if status.CircuitState == model.CircuitStateOpen {
+			return true
+		}
+	}
+	return false
+}
+
 // searchByEmail normalizes the email (lowercases and trims whitespace) and returns the matching user or an error
-func (m *messageHandlerOrchestrator) searchByEmail(ctx context.Context, criteria string, email string) (*model.User, error) {
+func (m *messageHandlerOrchestrator) searchByEmail(ctx context.Context, criteria constants.CriteriaType, email string) (*model.User, error) {
 	if m.userReader == nil {
 		return nil, errs.NewUnexpected("auth service unavailable")
 	}
@@ -98,6 +494,10 @@ func (m *messageHandlerOrchestrator) searchByEmail(ctx context.Context, criteria
 		user.AlternateEmails = []model.Email{{Email: email}}
 	}
 
+	if err := m.charge(ctx, model.CostClassExpensive); err != nil {
+		return nil, err
+	}
+
 	// SearchUser is used to find “root” user emails, not linked email
 	//
 	// Finding users by alternate emails is NOT available
@@ -112,6 +512,8 @@ func (m *messageHandlerOrchestrator) searchByEmail(ctx context.Context, criteria
 
 // EmailToUsername converts an email to a username
 func (m *messageHandlerOrchestrator) EmailToUsername(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "EmailToUsername", msg)
+	defer span.End()
 
 	email := strings.ToLower(strings.TrimSpace(string(msg.Data())))
 	if email == "" {
@@ -120,13 +522,15 @@ func (m *messageHandlerOrchestrator) EmailToUsername(ctx context.Context, msg po
 
 	user, err := m.searchByEmail(ctx, constants.CriteriaTypeEmail, email)
 	if err != nil {
-		return m.errorResponse(err.Error()), nil
+		return m.errorResponseFromError(ctx, err), nil
 	}
 	return []byte(user.Username), nil
 }
 
 // EmailToSub converts an email to a sub
 func (m *messageHandlerOrchestrator) EmailToSub(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "EmailToSub", msg)
+	defer span.End()
 
 	email := strings.ToLower(strings.TrimSpace(string(msg.Data())))
 	if email == "" {
@@ -135,17 +539,118 @@ func (m *messageHandlerOrchestrator) EmailToSub(ctx context.Context, msg port.Tr
 
 	user, err := m.searchByEmail(ctx, constants.CriteriaTypeEmail, email)
 	if err != nil {
-		return m.errorResponse(err.Error()), nil
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	return []byte(user.UserID), nil
+}
+
+// AlternateEmailToSub converts an alternate (linked) email to the sub of the user it is linked to
+func (m *messageHandlerOrchestrator) AlternateEmailToSub(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "AlternateEmailToSub", msg)
+	defer span.End()
+
+	email := strings.ToLower(strings.TrimSpace(string(msg.Data())))
+	if email == "" {
+		return m.errorResponse("email is required"), nil
+	}
+
+	user, err := m.searchByEmail(ctx, constants.CriteriaTypeAlternateEmail, email)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
 	}
 	return []byte(user.UserID), nil
 }
 
+// UsernameToEmail converts a username to the primary email of the user
+func (m *messageHandlerOrchestrator) UsernameToEmail(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UsernameToEmail", msg)
+	defer span.End()
+
+	username := strings.TrimSpace(string(msg.Data()))
+	if username == "" {
+		return m.errorResponse("username is required"), nil
+	}
+	if m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	// the reply is the email itself, it can't be masked field by field
+	if _, masked := m.responsePolicies.Mask(callerFromContext(ctx))["primary_email"]; masked {
+		return m.errorResponseFromError(ctx, errs.NewForbidden("the primary email is hidden from the caller")), nil
+	}
+
+	slog.DebugContext(ctx, "search by username",
+		"username", redaction.Redact(username),
+	)
+
+	if err := m.charge(ctx, model.CostClassExpensive); err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	user, err := m.userReader.SearchUser(ctx, &model.User{Username: username}, constants.CriteriaTypeUsername)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	return []byte(user.PrimaryEmail), nil
+}
+
+// SubToEmail converts a sub to the primary email of the user
+func (m *messageHandlerOrchestrator) SubToEmail(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SubToEmail", msg)
+	defer span.End()
+
+	// the reply is the email itself, it can't be masked field by field
+	if _, masked := m.responsePolicies.Mask(callerFromContext(ctx))["primary_email"]; masked {
+		return m.errorResponseFromError(ctx, errs.NewForbidden("the primary email is hidden from the caller")), nil
+	}
+
+	user, err := m.getUserBySub(ctx, msg)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	return []byte(user.PrimaryEmail), nil
+}
+
+// SubToUsername converts a sub to the username of the user
+func (m *messageHandlerOrchestrator) SubToUsername(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SubToUsername", msg)
+	defer span.End()
+
+	user, err := m.getUserBySub(ctx, msg)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	return []byte(user.Username), nil
+}
+
+// getUserBySub loads the user with the sub of the message from the provider
+func (m *messageHandlerOrchestrator) getUserBySub(ctx context.Context, msg port.TransportMessenger) (*model.User, error) {
+	sub := strings.TrimSpace(string(msg.Data()))
+	if sub == "" {
+		return nil, errs.NewValidation("sub is required")
+	}
+	if m.userReader == nil {
+		return nil, errs.NewUnexpected("auth service unavailable")
+	}
+
+	slog.DebugContext(ctx, "get user by sub",
+		"sub", redaction.Redact(sub),
+	)
+
+	return m.userReader.GetUser(ctx, &model.User{UserID: sub})
+}
+
 func (m *messageHandlerOrchestrator) getUserByInput(ctx context.Context, msg port.TransportMessenger) (*model.User, error) {
 	if m.userReader == nil {
 		return nil, errs.NewUnexpected("auth service unavailable")
 	}
 
-	input := strings.TrimSpace(string(msg.Data()))
+	return m.lookupUser(ctx, string(msg.Data()))
+}
+
+// lookupUser resolves the user by username, sub or token and loads it from the provider
+func (m *messageHandlerOrchestrator) lookupUser(ctx context.Context, input string) (*model.User, error) {
+	input = strings.TrimSpace(input)
 	if input == "" {
 		return nil, errs.NewValidation("input is required")
 	}
@@ -167,6 +672,9 @@ func (m *messageHandlerOrchestrator) getUserByInput(ctx context.Context, msg por
 		if user.UserID != "" {
 			return m.userReader.GetUser(ctx, user)
 		}
+		if err := m.charge(ctx, model.CostClassExpensive); err != nil {
+			return nil, err
+		}
 		return m.userReader.SearchUser(ctx, user, constants.CriteriaTypeUsername)
 	}
 
@@ -175,6 +683,8 @@ func (m *messageHandlerOrchestrator) getUserByInput(ctx context.Context, msg por
 
 // GetUserMetadata retrieves user metadata based on the input strategy
 func (m *messageHandlerOrchestrator) GetUserMetadata(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "GetUserMetadata", msg)
+	defer span.End()
 
 	userRetrieved, errGetUser := m.getUserByInput(ctx, msg)
 	if errGetUser != nil {
@@ -182,18 +692,30 @@ func (m *messageHandlerOrchestrator) GetUserMetadata(ctx context.Context, msg po
 			"error", errGetUser,
 			"input", redaction.Redact(string(msg.Data())),
 		)
-		return m.errorResponse(errGetUser.Error()), nil
+		return m.errorResponseFromError(ctx, errGetUser), nil
+	}
+
+	var data any
+	switch strings.ToLower(strings.TrimSpace(msg.Header(constants.ResponseFormatHeader))) {
+	case constants.ResponseFormatOIDC:
+		data = userRetrieved.OIDCClaims()
+	default:
+		data = m.newUserMetadataResponse(ctx, userRetrieved)
 	}
 
 	// Return success response with user metadata
 	response := UserDataResponse{
 		Success: true,
-		Data:    userRetrieved.UserMetadata,
+		Data:    m.applyResponsePolicy(ctx, data),
+	}
+	if userRetrieved.Stale() {
+		response.Stale = true
+		response.Age = int64(userRetrieved.StaleAge / time.Second)
 	}
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
@@ -202,6 +724,8 @@ func (m *messageHandlerOrchestrator) GetUserMetadata(ctx context.Context, msg po
 
 // GetUserEmails retrieves the user emails based on the input strategy
 func (m *messageHandlerOrchestrator) GetUserEmails(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "GetUserEmails", msg)
+	defer span.End()
 
 	user, errGetUser := m.getUserByInput(ctx, msg)
 	if errGetUser != nil {
@@ -209,17 +733,17 @@ func (m *messageHandlerOrchestrator) GetUserEmails(ctx context.Context, msg port
 			"error", errGetUser,
 			"input", redaction.Redact(string(msg.Data())),
 		)
-		return m.errorResponse(errGetUser.Error()), nil
+		return m.errorResponseFromError(ctx, errGetUser), nil
 	}
 
 	response := UserDataResponse{
 		Success: true,
-		Data:    map[string]any{"primary_email": user.PrimaryEmail, "alternate_emails": user.AlternateEmails},
+		Data:    m.applyResponsePolicy(ctx, map[string]any{"primary_email": user.PrimaryEmail, "alternate_emails": user.AlternateEmails}),
 	}
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
@@ -250,9 +774,11 @@ type identityProfileData struct {
 
 // ListIdentities retrieves the user's linked identities
 func (m *messageHandlerOrchestrator) ListIdentities(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ListIdentities", msg)
+	defer span.End()
 
 	if m.userReader == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	var request identityListRequest
@@ -274,7 +800,7 @@ func (m *messageHandlerOrchestrator) ListIdentities(ctx context.Context, msg por
 		slog.ErrorContext(ctx, "error looking up user for identity list",
 			"error", err,
 		)
-		return m.errorResponse(err.Error()), nil
+		return m.errorResponseFromError(ctx, err), nil
 	}
 
 	fullUser, err := m.userReader.GetUser(ctx, user)
@@ -282,7 +808,7 @@ func (m *messageHandlerOrchestrator) ListIdentities(ctx context.Context, msg por
 		slog.ErrorContext(ctx, "error getting user for identity list",
 			"error", err,
 		)
-		return m.errorResponse(err.Error()), nil
+		return m.errorResponseFromError(ctx, err), nil
 	}
 
 	identities := make([]identityResponse, 0, len(fullUser.Identities))
@@ -305,12 +831,12 @@ func (m *messageHandlerOrchestrator) ListIdentities(ctx context.Context, msg por
 
 	response := UserDataResponse{
 		Success: true,
-		Data:    identities,
+		Data:    m.applyResponsePolicy(ctx, identities),
 	}
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		return m.errorResponse("failed to marshal response"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
 	}
 
 	return responseJSON, nil
@@ -318,9 +844,11 @@ func (m *messageHandlerOrchestrator) ListIdentities(ctx context.Context, msg por
 
 // UpdateUser updates the user in the identity provider
 func (m *messageHandlerOrchestrator) UpdateUser(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UpdateUser", msg)
+	defer span.End()
 
 	if m.userWriter == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	user := &model.User{}
@@ -334,9 +862,43 @@ func (m *messageHandlerOrchestrator) UpdateUser(ctx context.Context, msg port.Tr
 	user.UserSanitize()
 
 	// Validate user data
-	if err := user.Validate(); err != nil {
-		responseJSON := m.errorResponse(err.Error())
-		return responseJSON, nil
+	if violations := user.Violations(); len(violations) > 0 {
+		return m.validationErrorResponse(ctx, violations), nil
+	}
+
+	// the values rejected by the moderation policy fail the update, the redacted ones are replaced
+	moderated, errModerate := m.moderateMetadata(ctx, user.UserMetadata)
+	if errModerate != nil {
+		return m.errorResponseFromError(ctx, errModerate), nil
+	}
+	if len(moderated) > 0 {
+		return m.validationErrorResponse(ctx, moderated), nil
+	}
+
+	// Serialize the concurrent updates of the user, up to the profile changed event
+	unlock, errLock := m.lockUsers(ctx, tokenLockKey(ctx, user.Token))
+	if errLock != nil {
+		return m.errorResponseFromError(ctx, errLock), nil
+	}
+	defer unlock()
+
+	// an update only clearing fields has no metadata to set
+	if user.UserMetadata == nil {
+		user.UserMetadata = &model.UserMetadata{}
+	}
+
+	// The organization verified badge is derived, recompute it when the organization changes
+	user.UserMetadata.OrganizationVerified = nil
+	if user.UserMetadata.Organization != nil {
+		user.UserMetadata.OrganizationVerified = m.organizationVerified(ctx, user.Token, *user.UserMetadata.Organization)
+	}
+
+	sub := tokenSubject(ctx, user.Token)
+	audit := &model.AuditEvent{
+		Action:  model.AuditActionUserUpdate,
+		Actor:   sub,
+		Target:  sub,
+		Changes: auditChanges(user),
 	}
 
 	// It's calling another service to update the user because in case of
@@ -344,46 +906,249 @@ func (m *messageHandlerOrchestrator) UpdateUser(ctx context.Context, msg port.Tr
 	// we can do without changing the user writer orchestrator
 	updatedUser, err := m.userWriter.UpdateUser(ctx, user)
 	if err != nil {
-		responseJSON := m.errorResponse(err.Error())
+		audit.Fail(err)
+		m.publishAudit(ctx, audit)
+		responseJSON := m.errorResponseFromError(ctx, err)
 		return responseJSON, nil
 	}
 
+	m.publishAudit(ctx, audit)
+	m.publishProfileChanged(ctx, model.ProfileChangeUpdate, updatedUser, user)
+
+	// the cleared fields are entered by the user too, the enrichment jobs must not fill them again
+	m.recordProvenance(ctx, cmp.Or(updatedUser.UserID, user.UserID, sub),
+		append(user.UserMetadata.Fields(), user.ClearFields...), model.ProvenanceSourceUser, sub)
+
 	// Return success response with user metadata
 	response := UserDataResponse{
 		Success: true,
-		Data:    updatedUser.UserMetadata,
+		Data:    m.applyResponsePolicy(ctx, updatedUser.UserMetadata),
 	}
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
 	return responseJSON, nil
 }
 
+// userLifecycleInput resolves the username or sub sent as the message payload
+func (m *messageHandlerOrchestrator) userLifecycleInput(ctx context.Context, msg port.TransportMessenger) (*model.User, error) {
+	if m.userReader == nil || m.userDeleter == nil {
+		return nil, errs.NewUnexpected("auth service unavailable")
+	}
+
+	input := strings.TrimSpace(string(msg.Data()))
+	if input == "" {
+		return nil, errs.NewValidation("input is required")
+	}
+
+	return m.userReader.MetadataLookup(ctx, input)
+}
+
+// SoftDeleteUser soft-deletes the user, keeping a tombstone that can be restored within the grace period
+func (m *messageHandlerOrchestrator) SoftDeleteUser(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SoftDeleteUser", msg)
+	defer span.End()
+
+	user, err := m.userLifecycleInput(ctx, msg)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	if errDelete := m.userDeleter.SoftDeleteUser(ctx, user); errDelete != nil {
+		return m.errorResponseFromError(ctx, errDelete), nil
+	}
+
+	m.publishProfileChanged(ctx, model.ProfileChangeDelete, user)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "user deleted successfully",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// RestoreUser restores a soft-deleted user within the grace period
+func (m *messageHandlerOrchestrator) RestoreUser(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "RestoreUser", msg)
+	defer span.End()
+
+	user, err := m.userLifecycleInput(ctx, msg)
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	restored, errRestore := m.userDeleter.RestoreUser(ctx, user)
+	if errRestore != nil {
+		return m.errorResponseFromError(ctx, errRestore), nil
+	}
+
+	m.publishProfileChanged(ctx, model.ProfileChangeRestore, restored, user)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "user restored successfully",
+		Data:    m.applyResponsePolicy(ctx, restored.UserMetadata),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// UpdateUserAsOrganizationAdmin updates a restricted subset of the profile of an organization
+// member on behalf of one of the organization admins
+func (m *messageHandlerOrchestrator) UpdateUserAsOrganizationAdmin(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UpdateUserAsOrganizationAdmin", msg)
+	defer span.End()
+
+	if m.organizationAdminWriter == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	request := &model.DelegatedUserUpdate{}
+	if err := json.Unmarshal(msg.Data(), request); err != nil {
+		return m.errorResponse("failed to unmarshal user data"), nil
+	}
+
+	(&model.User{UserMetadata: request.UserMetadata}).UserSanitize()
+
+	// only the delegated fields are accepted, per field
+	if err := request.Validate(); err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	moderated, errModerate := m.moderateMetadata(ctx, request.UserMetadata)
+	if errModerate != nil {
+		return m.errorResponseFromError(ctx, errModerate), nil
+	}
+	if len(moderated) > 0 {
+		return m.validationErrorResponse(ctx, moderated), nil
+	}
+
+	admin, errAdminLookup := m.organizationAdminWriter.OrganizationAdminLookup(ctx, request.Token)
+	if errAdminLookup != nil {
+		return m.errorResponseFromError(ctx, errAdminLookup), nil
+	}
+
+	member, errLookupUser := m.lookupUser(ctx, request.User)
+	if errLookupUser != nil {
+		return m.errorResponseFromError(ctx, errLookupUser), nil
+	}
+
+	// the admin must manage the member current organization, and the new one when it changes
+	var currentOrganization string
+	if member.UserMetadata != nil && member.UserMetadata.Organization != nil {
+		currentOrganization = *member.UserMetadata.Organization
+	}
+	if !admin.Manages(currentOrganization) {
+		slog.WarnContext(ctx, "organization admin update denied, user is not a member of a managed organization",
+			"admin", redaction.Redact(admin.UserID),
+			"user", redaction.Redact(member.UserID),
+		)
+		return m.errorResponseFromError(ctx, errs.NewForbidden("user is not a member of an organization you manage")), nil
+	}
+
+	fields := request.UserMetadata.Fields()
+	if request.UserMetadata.Organization != nil {
+		if !admin.Manages(*request.UserMetadata.Organization) {
+			return m.errorResponseFromError(ctx, errs.NewForbidden("organization is not managed by the admin")), nil
+		}
+		if len(m.organizationDomains) > 0 {
+			verified := member.OrganizationVerified(m.organizationDomains, *request.UserMetadata.Organization)
+			request.UserMetadata.OrganizationVerified = &verified
+		}
+	}
+
+	unlock, errLock := m.lockUsers(ctx, member.UserID, member.Sub)
+	if errLock != nil {
+		return m.errorResponseFromError(ctx, errLock), nil
+	}
+	defer unlock()
+
+	updatedUser, errUpdate := m.organizationAdminWriter.UpdateUserAsOrganizationAdmin(ctx, &model.User{
+		UserID:       member.UserID,
+		Sub:          member.Sub,
+		Username:     member.Username,
+		UserMetadata: request.UserMetadata,
+	})
+	if errUpdate != nil {
+		return m.errorResponseFromError(ctx, errUpdate), nil
+	}
+
+	slog.InfoContext(ctx, "audit: user metadata updated by organization admin",
+		"admin", redaction.Redact(admin.UserID),
+		"user", redaction.Redact(member.UserID),
+		"organization", currentOrganization,
+		"fields", fields,
+	)
+
+	m.publishAudit(ctx, &model.AuditEvent{
+		Action: model.AuditActionOrganizationAdminUpdate,
+		Actor:  admin.UserID,
+		Target: member.UserID,
+		Details: map[string]any{
+			"organization": currentOrganization,
+			"fields":       fields,
+		},
+	})
+	m.publishProfileChanged(ctx, model.ProfileChangeAdminUpdate, member)
+	m.recordProvenance(ctx, member.UserID, fields, model.ProvenanceSourceAdmin, admin.UserID)
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    m.applyResponsePolicy(ctx, updatedUser.UserMetadata),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
 func (m *messageHandlerOrchestrator) checkEmailExists(ctx context.Context, email string) error {
 
 	email = strings.ToLower(strings.TrimSpace(email))
 
-	var notFound errs.NotFound
-	for _, criteria := range []string{constants.CriteriaTypeAlternateEmail, constants.CriteriaTypeEmail} {
-		user, errSearch := m.searchByEmail(ctx, criteria, email)
-		if errSearch != nil && !errors.As(errSearch, &notFound) {
-			return errSearch
-		}
-		if user != nil && (user.UserID != "" || user.Username != "") {
-			slog.DebugContext(ctx, "user found", "user_id", redaction.Redact(user.UserID))
+	// the providers searching the exact address (Auth0) only find the spelling searched for, the
+	// canonical form is searched too so the canonical spelling is found from any other one
+	searches := []string{email}
+	if canonical := emailnorm.Canonical(email); canonical != email {
+		searches = append(searches, canonical)
+	}
 
-			if strings.EqualFold(user.PrimaryEmail, email) {
-				return errs.NewValidation("email already linked")
+	var notFound errs.NotFound
+	for _, search := range searches {
+		for _, criteria := range []constants.CriteriaType{constants.CriteriaTypeAlternateEmail, constants.CriteriaTypeEmail} {
+			user, errSearch := m.searchByEmail(ctx, criteria, search)
+			if errSearch != nil && !errors.As(errSearch, &notFound) {
+				return errSearch
 			}
+			if user != nil && (user.UserID != "" || user.Username != "") {
+				slog.DebugContext(ctx, "user found", "user_id", redaction.Redact(user.UserID))
 
-			for _, alternateEmail := range user.AlternateEmails {
-				if strings.EqualFold(alternateEmail.Email, email) && alternateEmail.Verified {
+				if emailnorm.Equal(user.PrimaryEmail, email) {
 					return errs.NewValidation("email already linked")
 				}
+
+				for _, alternateEmail := range user.AlternateEmails {
+					if emailnorm.Equal(alternateEmail.Email, email) && alternateEmail.Verified {
+						return errs.NewValidation("email already linked")
+					}
+				}
 			}
 		}
 	}
@@ -391,14 +1156,36 @@ func (m *messageHandlerOrchestrator) checkEmailExists(ctx context.Context, email
 	return nil
 }
 
+// parseStartEmailLinking reads the email linking request, either the bare email or the JSON
+// request carrying the token of the user
+func parseStartEmailLinking(data []byte) (*model.StartEmailLinking, error) {
+	request := &model.StartEmailLinking{}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		request.Email = string(trimmed)
+		return request, nil
+	}
+	if err := json.Unmarshal(trimmed, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
 // StartEmailLinking starts the email linking process
 func (m *messageHandlerOrchestrator) StartEmailLinking(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "StartEmailLinking", msg)
+	defer span.End()
 
 	if m.emailHandler == nil {
-		return m.errorResponse("email service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("email service unavailable")), nil
 	}
 
-	alternateEmailInput := strings.ToLower(strings.TrimSpace(string(msg.Data())))
+	request, errParse := parseStartEmailLinking(msg.Data())
+	if errParse != nil {
+		return m.errorResponse("failed to unmarshal email linking request"), nil
+	}
+
+	alternateEmailInput := strings.ToLower(strings.TrimSpace(request.Email))
 	if alternateEmailInput == "" {
 		return m.errorResponse("alternate email is required"), nil
 	}
@@ -410,13 +1197,40 @@ func (m *messageHandlerOrchestrator) StartEmailLinking(ctx context.Context, msg
 
 	err := m.checkEmailExists(ctx, alternateEmailInput)
 	if err != nil {
-		return m.errorResponse(err.Error()), nil
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	// the user is only known once the email is verified, the caller service is the actor
+	audit := &model.AuditEvent{
+		Action: model.AuditActionEmailLinkingStart,
+		Actor:  callerFromContext(ctx),
+		Target: redaction.RedactEmail(alternateEmailInput),
+	}
+
+	if m.emailSendLimiter != nil {
+		// the sends requested with a token are also limited per user
+		if request.User.AuthToken != "" && m.userReader != nil {
+			user, errMetadataLookup := m.userReader.MetadataLookup(ctx, request.User.AuthToken)
+			if errMetadataLookup != nil {
+				return m.errorResponseFromError(ctx, errMetadataLookup), nil
+			}
+			request.User.UserID = user.UserID
+			audit.Actor = user.UserID
+		}
+		if errLimit := m.emailSendLimiter.Allow(ctx, alternateEmailInput, request.User.UserID); errLimit != nil {
+			audit.Fail(errLimit)
+			m.publishAudit(ctx, audit)
+			return m.errorResponseFromError(ctx, errLimit), nil
+		}
 	}
 
 	errLinkAlternateEmail := m.emailHandler.SendVerificationAlternateEmail(ctx, alternateEmailInput)
 	if errLinkAlternateEmail != nil {
-		return m.errorResponse(errLinkAlternateEmail.Error()), nil
+		audit.Fail(errLinkAlternateEmail)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errLinkAlternateEmail), nil
 	}
+	m.publishAudit(ctx, audit)
 
 	// Return success response with user metadata
 	response := UserDataResponse{
@@ -426,7 +1240,7 @@ func (m *messageHandlerOrchestrator) StartEmailLinking(ctx context.Context, msg
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
@@ -435,57 +1249,126 @@ func (m *messageHandlerOrchestrator) StartEmailLinking(ctx context.Context, msg
 
 // VerifyEmailLinking verifies the email linking
 func (m *messageHandlerOrchestrator) VerifyEmailLinking(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "VerifyEmailLinking", msg)
+	defer span.End()
 
 	if m.emailHandler == nil {
-		return m.errorResponse("email service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("email service unavailable")), nil
 	}
 
-	email := &model.Email{}
-	err := json.Unmarshal(msg.Data(), email)
+	request := &model.VerifyEmailLinking{}
+	err := json.Unmarshal(msg.Data(), request)
 	if err != nil {
 		responseJSON := m.errorResponse("failed to unmarshal email data")
 		return responseJSON, nil
 	}
+	email := &request.Email
 
 	if !email.IsValidEmail() {
 		return m.errorResponse("invalid email"), nil
 	}
 
+	audit := &model.AuditEvent{
+		Action: model.AuditActionEmailLinkingVerify,
+		Actor:  callerFromContext(ctx),
+		Target: redaction.RedactEmail(email.Email),
+	}
+
+	// a locked email isn't verified at all, the attempts would be guesses of the OTP
+	if m.otpAttemptGuard != nil {
+		if errLocked := m.otpAttemptGuard.Check(ctx, email.Email); errLocked != nil {
+			audit.Fail(errLocked)
+			m.publishAudit(ctx, audit)
+			return m.errorResponseFromError(ctx, errLocked), nil
+		}
+	}
+
 	//
 	errExists := m.checkEmailExists(ctx, email.Email)
 	if errExists != nil {
-		return m.errorResponse(errExists.Error()), nil
+		return m.errorResponseFromError(ctx, errExists), nil
+	}
+
+	// the reference is bound to the user of the token, only that user can make the email its primary email
+	request.User.UserID = ""
+	if request.User.AuthToken != "" && m.userReader != nil {
+		user, errMetadataLookup := m.userReader.MetadataLookup(ctx, request.User.AuthToken)
+		if errMetadataLookup != nil {
+			return m.errorResponseFromError(ctx, errMetadataLookup), nil
+		}
+		request.User.UserID = user.UserID
+	}
+
+	if m.backupCodeStore != nil && model.IsBackupCode(email.OTP) {
+		return m.verifyEmailBackupCode(ctx, email, request.User.UserID, audit), nil
 	}
 
 	authResponse, errVerifyAlternateEmail := m.emailHandler.VerifyAlternateEmail(ctx, email)
+	m.recordVerificationAttempt(ctx, model.AuditActionEmailLinkingLockout, email.Email, audit.Target, errVerifyAlternateEmail)
 	if errVerifyAlternateEmail != nil {
-		return m.errorResponse(errVerifyAlternateEmail.Error()), nil
+		audit.Fail(errVerifyAlternateEmail)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errVerifyAlternateEmail), nil
+	}
+	m.publishAudit(ctx, audit)
+
+	authResponse, errSeal := m.sealIDToken(authResponse, request.User.UserID)
+	if errSeal != nil {
+		return m.errorResponseFromError(ctx, errSeal), nil
 	}
 
 	// Return success response with user metadata
 	response := UserDataResponse{
 		Success: true,
-		Data:    authResponse,
+		Data:    m.applyResponsePolicy(ctx, authResponse),
 	}
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
 	return responseJSON, nil
 }
 
+// recordVerificationAttempt counts the attempt of verifying the recipient (an email or a phone number)
+// for its lockout, only the rejected codes are failures: an unavailable provider says nothing about
+// the code. A lock is audited with the action, the target is the redacted recipient.
+func (m *messageHandlerOrchestrator) recordVerificationAttempt(ctx context.Context, action model.AuditAction, recipient, target string, err error) {
+	if m.otpAttemptGuard == nil {
+		return
+	}
+	if err == nil {
+		m.otpAttemptGuard.Succeed(ctx, recipient)
+		return
+	}
+	switch errs.Code(err) {
+	case errs.CodeValidation, errs.CodeUnauthorized, errs.CodeForbidden:
+		failures, locked := m.otpAttemptGuard.Fail(ctx, recipient)
+		if locked {
+			m.publishAudit(ctx, &model.AuditEvent{
+				Action:  action,
+				Actor:   callerFromContext(ctx),
+				Target:  target,
+				Outcome: model.AuditOutcomeFailure,
+				Details: map[string]any{"failures": failures},
+			})
+		}
+	}
+}
+
 // LinkIdentity links a verified email identity to a user account
 func (m *messageHandlerOrchestrator) LinkIdentity(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "LinkIdentity", msg)
+	defer span.End()
 
 	if m.identityLinker == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	if m.userReader == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	linkRequest := &model.LinkIdentity{}
@@ -495,22 +1378,36 @@ func (m *messageHandlerOrchestrator) LinkIdentity(ctx context.Context, msg port.
 		return responseJSON, nil
 	}
 
+	verifiedEmail, errResolve := m.resolveIdentityTokenRef(ctx, linkRequest)
+	if errResolve != nil {
+		return m.errorResponseFromError(ctx, errResolve), nil
+	}
+
+	if verifiedEmail != "" {
+		return m.linkVerifiedEmail(ctx, linkRequest, verifiedEmail), nil
+	}
+
 	errValidateLinkRequest := m.identityLinker.ValidateLinkRequest(ctx, linkRequest)
 	if errValidateLinkRequest != nil {
-		return m.errorResponse(errValidateLinkRequest.Error()), nil
+		return m.errorResponseFromError(ctx, errValidateLinkRequest), nil
 	}
 
 	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, linkRequest.User.AuthToken)
 	if errMetadataLookup != nil {
-		return m.errorResponse(errMetadataLookup.Error()), nil
+		return m.errorResponseFromError(ctx, errMetadataLookup), nil
 	}
 	linkRequest.User.UserID = user.UserID
 
 	errLinkIdentity := m.identityLinker.LinkIdentity(ctx, linkRequest)
 	if errLinkIdentity != nil {
-		return m.errorResponse(errLinkIdentity.Error()), nil
+		return m.errorResponseFromError(ctx, errLinkIdentity), nil
 	}
 
+	// a new verified email might match the organization domains
+	m.refreshOrganizationVerified(ctx, linkRequest.User.AuthToken)
+
+	m.publishProfileChanged(ctx, model.ProfileChangeIdentityLink, user)
+
 	// Return success response
 	response := UserDataResponse{
 		Success: true,
@@ -519,7 +1416,7 @@ func (m *messageHandlerOrchestrator) LinkIdentity(ctx context.Context, msg port.
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		errorResponseJSON := m.errorResponse("failed to marshal response")
+		errorResponseJSON := m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response"))
 		return errorResponseJSON, nil
 	}
 
@@ -528,13 +1425,15 @@ func (m *messageHandlerOrchestrator) LinkIdentity(ctx context.Context, msg port.
 
 // UnlinkIdentity removes a secondary identity from a user account
 func (m *messageHandlerOrchestrator) UnlinkIdentity(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UnlinkIdentity", msg)
+	defer span.End()
 
 	if m.identityUnlinker == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	if m.userReader == nil {
-		return m.errorResponse("auth service unavailable"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
 	}
 
 	unlinkRequest := &model.UnlinkIdentity{}
@@ -545,15 +1444,20 @@ func (m *messageHandlerOrchestrator) UnlinkIdentity(ctx context.Context, msg por
 
 	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, unlinkRequest.User.AuthToken, constants.UserUpdateIdentityRequiredScope)
 	if errMetadataLookup != nil {
-		return m.errorResponse(errMetadataLookup.Error()), nil
+		return m.errorResponseFromError(ctx, errMetadataLookup), nil
 	}
 	unlinkRequest.User.UserID = user.UserID
 
 	errUnlinkIdentity := m.identityUnlinker.UnlinkIdentity(ctx, unlinkRequest)
 	if errUnlinkIdentity != nil {
-		return m.errorResponse(errUnlinkIdentity.Error()), nil
+		return m.errorResponseFromError(ctx, errUnlinkIdentity), nil
 	}
 
+	// the removed email might have been the one matching the organization domains
+	m.refreshOrganizationVerified(ctx, unlinkRequest.User.AuthToken)
+
+	m.publishProfileChanged(ctx, model.ProfileChangeIdentityUnlink, user)
+
 	response := UserDataResponse{
 		Success: true,
 		Message: "identity unlinked successfully",
@@ -561,7 +1465,56 @@ func (m *messageHandlerOrchestrator) UnlinkIdentity(ctx context.Context, msg por
 
 	responseJSON, err := json.Marshal(response)
 	if err != nil {
-		return m.errorResponse("failed to marshal response"), nil
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// UnlinkAlternateEmail removes an alternate email from a user account, the primary email can't be unlinked
+func (m *messageHandlerOrchestrator) UnlinkAlternateEmail(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UnlinkAlternateEmail", msg)
+	defer span.End()
+
+	if m.emailUnlinker == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	unlinkRequest := &model.UnlinkAlternateEmail{}
+	err := json.Unmarshal(msg.Data(), unlinkRequest)
+	if err != nil {
+		return m.errorResponse("failed to unmarshal unlink alternate email request"), nil
+	}
+
+	unlinkRequest.Email = strings.ToLower(strings.TrimSpace(unlinkRequest.Email))
+	if unlinkRequest.Email == "" {
+		return m.errorResponseFromError(ctx, errs.NewValidation("email is required")), nil
+	}
+
+	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, unlinkRequest.User.AuthToken, constants.UserUpdateIdentityRequiredScope)
+	if errMetadataLookup != nil {
+		return m.errorResponseFromError(ctx, errMetadataLookup), nil
+	}
+	unlinkRequest.User.UserID = user.UserID
+
+	errUnlink := m.emailUnlinker.UnlinkAlternateEmail(ctx, unlinkRequest)
+	if errUnlink != nil {
+		return m.errorResponseFromError(ctx, errUnlink), nil
+	}
+
+	// the removed email might have been the one matching the organization domains
+	m.refreshOrganizationVerified(ctx, unlinkRequest.User.AuthToken)
+
+	m.publishProfileChanged(ctx, model.ProfileChangeIdentityUnlink, user)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "alternate email unlinked successfully",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
 	}
 
 	return responseJSON, nil
@@ -569,7 +1522,9 @@ func (m *messageHandlerOrchestrator) UnlinkIdentity(ctx context.Context, msg por
 
 // NewMessageHandlerOrchestrator creates a new message handler orchestrator using the option pattern
 func NewMessageHandlerOrchestrator(opts ...messageHandlerOrchestratorOption) port.MessageHandler {
-	m := &messageHandlerOrchestrator{}
+	m := &messageHandlerOrchestrator{
+		userLocker: NewLocalUserLocker(),
+	}
 	for _, opt := range opts {
 		opt(m)
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
@@ -17,7 +18,8 @@ import (
 
 // mockTransportMessenger is a mock implementation of port.TransportMessenger for testing
 type mockTransportMessenger struct {
-	data []byte
+	data    []byte
+	headers map[string]string
 }
 
 func (m *mockTransportMessenger) Subject() string {
@@ -28,6 +30,10 @@ func (m *mockTransportMessenger) Data() []byte {
 	return m.data
 }
 
+func (m *mockTransportMessenger) Header(key string) string {
+	return m.headers[key]
+}
+
 func (m *mockTransportMessenger) Respond(data []byte) error {
 	// Mock implementation - just return nil
 	return nil
@@ -76,7 +82,7 @@ func (m *mockUserServiceWriter) UpdateUser(ctx context.Context, user *model.User
 // mockUserServiceReader is a mock implementation of UserServiceReader for testing
 type mockUserServiceReader struct {
 	getUserFunc        func(ctx context.Context, user *model.User) (*model.User, error)
-	searchUserFunc     func(ctx context.Context, user *model.User, criteria string) (*model.User, error)
+	searchUserFunc     func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error)
 	metadataLookupFunc func(ctx context.Context, input string) (*model.User, error)
 }
 
@@ -87,7 +93,7 @@ func (m *mockUserServiceReader) GetUser(ctx context.Context, user *model.User) (
 	return user, nil
 }
 
-func (m *mockUserServiceReader) SearchUser(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+func (m *mockUserServiceReader) SearchUser(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 	if m.searchUserFunc != nil {
 		return m.searchUserFunc(ctx, user, criteria)
 	}
@@ -261,7 +267,7 @@ func TestMessageHandlerOrchestrator_UpdateUser(t *testing.T) {
 						FamilyName:    converters.StringPtr("Smith"),
 						JobTitle:      converters.StringPtr("Senior Engineer"),
 						Organization:  converters.StringPtr("Tech Corp"),
-						Country:       converters.StringPtr("USA"),
+						Country:       converters.StringPtr("US"),
 						StateProvince: converters.StringPtr("California"),
 						City:          converters.StringPtr("San Francisco"),
 						Address:       converters.StringPtr("123 Tech St"),
@@ -367,7 +373,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "successful email to username lookup",
 			messageData: []byte("zephyr.stormwind@mythicaltech.io"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					// Verify the search is called with correct parameters
 					if criteria != constants.CriteriaTypeEmail {
 						t.Errorf("Expected criteria %s, got %s", constants.CriteriaTypeEmail, criteria)
@@ -390,7 +396,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "email with whitespace is trimmed",
 			messageData: []byte("  mauriciozanetti86@gmail.com  "),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					// Verify the email was trimmed
 					if user.PrimaryEmail != "mauriciozanetti86@gmail.com" {
 						t.Errorf("Expected trimmed email mauriciozanetti86@gmail.com, got %s", user.PrimaryEmail)
@@ -409,7 +415,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "email is converted to lowercase",
 			messageData: []byte("UPPERCASE@EXAMPLE.COM"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					// Verify the email was lowercased
 					if user.PrimaryEmail != "uppercase@example.com" {
 						t.Errorf("Expected lowercased email uppercase@example.com, got %s", user.PrimaryEmail)
@@ -428,7 +434,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "empty email returns error",
 			messageData: []byte(""),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					t.Error("SearchUser should not be called for empty email")
 					return nil, errors.NewValidation("should not be called")
 				},
@@ -454,7 +460,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "whitespace-only email returns error",
 			messageData: []byte("   \t\n   "),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					t.Error("SearchUser should not be called for whitespace-only email")
 					return nil, errors.NewValidation("should not be called")
 				},
@@ -480,7 +486,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "user not found error",
 			messageData: []byte("notfound@example.com"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					return nil, errors.NewNotFound("user not found")
 				},
 			},
@@ -505,7 +511,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "search service error",
 			messageData: []byte("service.error@example.com"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					return nil, errors.NewUnexpected("database connection failed", nil)
 				},
 			},
@@ -530,7 +536,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "user with empty username",
 			messageData: []byte("empty.username@example.com"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					// Return user with empty username
 					return &model.User{
 						UserID:       "auth0|empty001",
@@ -546,7 +552,7 @@ func TestMessageHandlerOrchestrator_EmailToUsername(t *testing.T) {
 			name:        "complex email address",
 			messageData: []byte("test.user+tag@sub.example.co.uk"),
 			userReader: &mockUserServiceReader{
-				searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+				searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 					if user.PrimaryEmail != "test.user+tag@sub.example.co.uk" {
 						t.Errorf("Expected email test.user+tag@sub.example.co.uk, got %s", user.PrimaryEmail)
 					}
@@ -643,6 +649,210 @@ func TestMessageHandlerOrchestrator_EmailToUsername_NoUserReader(t *testing.T) {
 	}
 }
 
+func TestMessageHandlerOrchestrator_UsernameToEmail(t *testing.T) {
+	policies, errPolicies := model.ParseResponsePolicies("reporting-service=primary_email")
+	if errPolicies != nil {
+		t.Fatalf("ParseResponsePolicies() unexpected error: %v", errPolicies)
+	}
+
+	reader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			if criteria != constants.CriteriaTypeUsername {
+				t.Errorf("Expected criteria %s, got %s", constants.CriteriaTypeUsername, criteria)
+			}
+			if user.Username != "zephyr.stormwind" {
+				return nil, errors.NewNotFound("user not found")
+			}
+			return &model.User{Username: user.Username, PrimaryEmail: "zephyr.stormwind@mythicaltech.io"}, nil
+		},
+	}
+
+	tests := []struct {
+		name          string
+		caller        string
+		messageData   []byte
+		userReader    *mockUserServiceReader
+		expectedEmail string
+		expectedError string
+	}{
+		{name: "successful username to email lookup", messageData: []byte(" zephyr.stormwind\n"), userReader: reader, expectedEmail: "zephyr.stormwind@mythicaltech.io"},
+		{name: "user not found", messageData: []byte("nobody"), userReader: reader, expectedError: "user not found"},
+		{name: "empty username", messageData: []byte("  "), userReader: reader, expectedError: "username is required"},
+		{name: "no user reader", messageData: []byte("zephyr.stormwind"), expectedError: "auth service unavailable"},
+		{name: "email hidden from the caller", caller: "reporting-service", messageData: []byte("zephyr.stormwind"), userReader: reader, expectedError: "the primary email is hidden from the caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []messageHandlerOrchestratorOption{WithResponsePoliciesForMessageHandler(policies)}
+			if tt.userReader != nil {
+				opts = append(opts, WithUserReaderForMessageHandler(tt.userReader))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+			result, err := orchestrator.UsernameToEmail(ctx, &mockTransportMessenger{data: tt.messageData})
+			if err != nil {
+				t.Fatalf("UsernameToEmail() unexpected error: %v", err)
+			}
+
+			if tt.expectedError == "" {
+				if string(result) != tt.expectedEmail {
+					t.Errorf("UsernameToEmail() = %q, want %q", result, tt.expectedEmail)
+				}
+				return
+			}
+
+			var response struct {
+				Success bool   `json:"success"`
+				Error   string `json:"error"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("Failed to unmarshal error response: %v", err)
+			}
+			if response.Success || response.Error != tt.expectedError {
+				t.Errorf("UsernameToEmail() = %s, want error %q", result, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_AlternateEmailToSub(t *testing.T) {
+	reader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			if criteria != constants.CriteriaTypeAlternateEmail {
+				t.Errorf("Expected criteria %s, got %s", constants.CriteriaTypeAlternateEmail, criteria)
+			}
+			if len(user.AlternateEmails) != 1 || user.AlternateEmails[0].Email != "zephyr@personal.example" {
+				return nil, errors.NewNotFound("user not found")
+			}
+			return &model.User{UserID: "auth0|zephyr001", PrimaryEmail: "zephyr.stormwind@mythicaltech.io"}, nil
+		},
+	}
+
+	tests := []struct {
+		name          string
+		messageData   []byte
+		userReader    *mockUserServiceReader
+		expectedSub   string
+		expectedError string
+	}{
+		{name: "successful alternate email to sub lookup", messageData: []byte(" Zephyr@Personal.example\n"), userReader: reader, expectedSub: "auth0|zephyr001"},
+		{name: "email not linked", messageData: []byte("nobody@example.com"), userReader: reader, expectedError: "user not found"},
+		{name: "empty email", messageData: []byte("  "), userReader: reader, expectedError: "email is required"},
+		{name: "no user reader", messageData: []byte("zephyr@personal.example"), expectedError: "auth service unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []messageHandlerOrchestratorOption
+			if tt.userReader != nil {
+				opts = append(opts, WithUserReaderForMessageHandler(tt.userReader))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+
+			result, err := orchestrator.AlternateEmailToSub(context.Background(), &mockTransportMessenger{data: tt.messageData})
+			if err != nil {
+				t.Fatalf("AlternateEmailToSub() unexpected error: %v", err)
+			}
+
+			if tt.expectedError == "" {
+				if string(result) != tt.expectedSub {
+					t.Errorf("AlternateEmailToSub() = %q, want %q", result, tt.expectedSub)
+				}
+				return
+			}
+
+			var response struct {
+				Success bool   `json:"success"`
+				Error   string `json:"error"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("Failed to unmarshal error response: %v", err)
+			}
+			if response.Success || response.Error != tt.expectedError {
+				t.Errorf("AlternateEmailToSub() = %s, want error %q", result, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_SubToEmailAndUsername(t *testing.T) {
+	policies, errPolicies := model.ParseResponsePolicies("reporting-service=primary_email")
+	if errPolicies != nil {
+		t.Fatalf("ParseResponsePolicies() unexpected error: %v", errPolicies)
+	}
+
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			if user.UserID != "auth0|zephyr" {
+				return nil, errors.NewNotFound("user not found")
+			}
+			return &model.User{UserID: user.UserID, Username: "zephyr.stormwind", PrimaryEmail: "zephyr.stormwind@mythicaltech.io"}, nil
+		},
+	}
+
+	tests := []struct {
+		name             string
+		caller           string
+		messageData      []byte
+		userReader       *mockUserServiceReader
+		expectedEmail    string
+		expectedUsername string
+		expectedError    string
+		usernameError    string
+	}{
+		{name: "successful sub lookup", messageData: []byte(" auth0|zephyr\n"), userReader: reader, expectedEmail: "zephyr.stormwind@mythicaltech.io", expectedUsername: "zephyr.stormwind"},
+		{name: "user not found", messageData: []byte("auth0|nobody"), userReader: reader, expectedError: "user not found", usernameError: "user not found"},
+		{name: "empty sub", messageData: []byte("  "), userReader: reader, expectedError: "sub is required", usernameError: "sub is required"},
+		{name: "no user reader", messageData: []byte("auth0|zephyr"), expectedError: "auth service unavailable", usernameError: "auth service unavailable"},
+		{name: "email hidden from the caller", caller: "reporting-service", messageData: []byte("auth0|zephyr"), userReader: reader, expectedError: "the primary email is hidden from the caller", expectedUsername: "zephyr.stormwind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []messageHandlerOrchestratorOption{WithResponsePoliciesForMessageHandler(policies)}
+			if tt.userReader != nil {
+				opts = append(opts, WithUserReaderForMessageHandler(tt.userReader))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+
+			check := func(handler string, result []byte, want, wantError string) {
+				if wantError == "" {
+					if string(result) != want {
+						t.Errorf("%s() = %q, want %q", handler, result, want)
+					}
+					return
+				}
+
+				var response struct {
+					Success bool   `json:"success"`
+					Error   string `json:"error"`
+				}
+				if err := json.Unmarshal(result, &response); err != nil {
+					t.Fatalf("Failed to unmarshal error response: %v", err)
+				}
+				if response.Success || response.Error != wantError {
+					t.Errorf("%s() = %s, want error %q", handler, result, wantError)
+				}
+			}
+
+			email, err := orchestrator.SubToEmail(ctx, &mockTransportMessenger{data: tt.messageData})
+			if err != nil {
+				t.Fatalf("SubToEmail() unexpected error: %v", err)
+			}
+			check("SubToEmail", email, tt.expectedEmail, tt.expectedError)
+
+			username, err := orchestrator.SubToUsername(ctx, &mockTransportMessenger{data: tt.messageData})
+			if err != nil {
+				t.Fatalf("SubToUsername() unexpected error: %v", err)
+			}
+			check("SubToUsername", username, tt.expectedUsername, tt.usernameError)
+		})
+	}
+}
+
 func TestNewMessageHandlerOrchestrator(t *testing.T) {
 	t.Run("create orchestrator with options", func(t *testing.T) {
 		mockWriter := &mockUserServiceWriter{}
@@ -727,7 +937,7 @@ func TestWithUserWriterForMessageHandler(t *testing.T) {
 func TestWithUserReaderForMessageHandler(t *testing.T) {
 	t.Run("option sets user reader", func(t *testing.T) {
 		mockReader := &mockUserServiceReader{
-			searchUserFunc: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+			searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 				// Mark that this was called by modifying the user
 				user.Username = "reader-called"
 				return user, nil
@@ -915,7 +1125,7 @@ func TestMessageHandlerOrchestrator_GetUserMetadata(t *testing.T) {
 		input              string
 		mockMetadataLookup func(ctx context.Context, input string) (*model.User, error)
 		mockGetUser        func(ctx context.Context, user *model.User) (*model.User, error)
-		mockSearchUser     func(ctx context.Context, user *model.User, criteria string) (*model.User, error)
+		mockSearchUser     func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error)
 		expectedError      bool
 		expectedData       *model.UserMetadata
 		description        string
@@ -968,7 +1178,7 @@ func TestMessageHandlerOrchestrator_GetUserMetadata(t *testing.T) {
 					Username: "john.doe",
 				}, nil
 			},
-			mockSearchUser: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+			mockSearchUser: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 				// Verify the user was prepared correctly for search lookup
 				if user.Username != "john.doe" {
 					t.Errorf("User not prepared correctly for search lookup: Username=%q", user.Username)
@@ -1016,7 +1226,7 @@ func TestMessageHandlerOrchestrator_GetUserMetadata(t *testing.T) {
 					Username: "nonexistent.user",
 				}, nil
 			},
-			mockSearchUser: func(ctx context.Context, user *model.User, criteria string) (*model.User, error) {
+			mockSearchUser: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
 				return nil, errors.NewNotFound("user not found by criteria")
 			},
 			expectedError: true,
@@ -1320,6 +1530,134 @@ func TestMessageHandlerOrchestrator_UnlinkIdentity(t *testing.T) {
 	}
 }
 
+// mockAlternateEmailUnlinker is a mock implementation of port.AlternateEmailUnlinker for testing
+type mockAlternateEmailUnlinker struct {
+	unlinkAlternateEmailFunc func(ctx context.Context, request *model.UnlinkAlternateEmail) error
+}
+
+func (m *mockAlternateEmailUnlinker) UnlinkAlternateEmail(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+	if m.unlinkAlternateEmailFunc != nil {
+		return m.unlinkAlternateEmailFunc(ctx, request)
+	}
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_UnlinkAlternateEmail(t *testing.T) {
+	ctx := context.Background()
+
+	validPayload := func(email string) []byte {
+		req := &model.UnlinkAlternateEmail{Email: email}
+		req.User.AuthToken = "auth0|testuser"
+		data, _ := json.Marshal(req)
+		return data
+	}
+
+	userReader := &mockUserServiceReader{
+		metadataLookupFunc: func(ctx context.Context, input string) (*model.User, error) {
+			return &model.User{UserID: "auth0|testuser"}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		messageData    []byte
+		userReader     *mockUserServiceReader
+		emailUnlinker  *mockAlternateEmailUnlinker
+		validateResult func(t *testing.T, result []byte)
+	}{
+		{
+			name:        "nil emailUnlinker returns service unavailable",
+			messageData: validPayload("jane@personal.example"),
+			userReader:  userReader,
+			validateResult: func(t *testing.T, result []byte) {
+				assertErrorResponse(t, result, "auth service unavailable")
+			},
+		},
+		{
+			name:          "invalid JSON returns error",
+			messageData:   []byte(`{invalid json`),
+			userReader:    userReader,
+			emailUnlinker: &mockAlternateEmailUnlinker{},
+			validateResult: func(t *testing.T, result []byte) {
+				assertFailureResponse(t, result)
+			},
+		},
+		{
+			name:          "missing email returns error",
+			messageData:   validPayload("  "),
+			userReader:    userReader,
+			emailUnlinker: &mockAlternateEmailUnlinker{},
+			validateResult: func(t *testing.T, result []byte) {
+				assertErrorResponse(t, result, "email is required")
+			},
+		},
+		{
+			name:        "MetadataLookup failure returns error",
+			messageData: validPayload("jane@personal.example"),
+			userReader: &mockUserServiceReader{
+				metadataLookupFunc: func(ctx context.Context, input string) (*model.User, error) {
+					return nil, errors.NewUnauthorized("invalid token")
+				},
+			},
+			emailUnlinker: &mockAlternateEmailUnlinker{},
+			validateResult: func(t *testing.T, result []byte) {
+				assertErrorResponse(t, result, "invalid token")
+			},
+		},
+		{
+			name:        "primary email is rejected by the unlinker",
+			messageData: validPayload("jane@example.com"),
+			userReader:  userReader,
+			emailUnlinker: &mockAlternateEmailUnlinker{
+				unlinkAlternateEmailFunc: func(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+					return errors.NewValidation("the primary email can't be unlinked")
+				},
+			},
+			validateResult: func(t *testing.T, result []byte) {
+				assertErrorResponse(t, result, "the primary email can't be unlinked")
+			},
+		},
+		{
+			name:        "successful unlink passes the normalized email and resolved user",
+			messageData: validPayload(" Jane@Personal.example "),
+			userReader:  userReader,
+			emailUnlinker: &mockAlternateEmailUnlinker{
+				unlinkAlternateEmailFunc: func(ctx context.Context, request *model.UnlinkAlternateEmail) error {
+					if request.User.UserID != "auth0|testuser" {
+						t.Errorf("user_id should come from MetadataLookup, got %s", request.User.UserID)
+					}
+					if request.Email != "jane@personal.example" {
+						t.Errorf("expected email jane@personal.example, got %s", request.Email)
+					}
+					return nil
+				},
+			},
+			validateResult: func(t *testing.T, result []byte) {
+				assertSuccessResponse(t, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []messageHandlerOrchestratorOption{
+				WithUserReaderForMessageHandler(tt.userReader),
+			}
+			if tt.emailUnlinker != nil {
+				opts = append(opts, WithAlternateEmailUnlinkerForMessageHandler(tt.emailUnlinker))
+			}
+
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+			result, err := orchestrator.UnlinkAlternateEmail(ctx, &mockTransportMessenger{data: tt.messageData})
+
+			if err != nil {
+				t.Fatalf("UnlinkAlternateEmail() unexpected Go error: %v", err)
+			}
+			tt.validateResult(t, result)
+		})
+	}
+}
+
 func assertErrorResponse(t *testing.T, result []byte, wantErr string) {
 	t.Helper()
 	var resp UserDataResponse
@@ -1639,3 +1977,477 @@ func TestMessageHandlerOrchestrator_ListIdentities(t *testing.T) {
 		})
 	}
 }
+
+// mockUserDeleter is a mock implementation of port.UserDeleter for testing
+type mockUserDeleter struct {
+	softDeleteUserFunc func(ctx context.Context, user *model.User) error
+	restoreUserFunc    func(ctx context.Context, user *model.User) (*model.User, error)
+}
+
+func (m *mockUserDeleter) SoftDeleteUser(ctx context.Context, user *model.User) error {
+	if m.softDeleteUserFunc != nil {
+		return m.softDeleteUserFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *mockUserDeleter) RestoreUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if m.restoreUserFunc != nil {
+		return m.restoreUserFunc(ctx, user)
+	}
+	return user, nil
+}
+
+func TestMessageHandlerOrchestrator_SoftDeleteAndRestoreUser(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		messageData   []byte
+		deleter       *mockUserDeleter
+		restore       bool
+		expectSuccess bool
+		expectError   string
+		expectChanged model.ProfileChangeReason
+	}{
+		{
+			name:          "soft-delete by username",
+			messageData:   []byte("testuser"),
+			deleter:       &mockUserDeleter{},
+			expectSuccess: true,
+		},
+		{
+			name:          "soft-delete by sub",
+			messageData:   []byte("auth0|123"),
+			deleter:       &mockUserDeleter{},
+			expectSuccess: true,
+			expectChanged: model.ProfileChangeDelete,
+		},
+		{
+			name:          "restore by sub",
+			messageData:   []byte("auth0|123"),
+			deleter:       &mockUserDeleter{},
+			restore:       true,
+			expectSuccess: true,
+			expectChanged: model.ProfileChangeRestore,
+		},
+		{
+			name:        "restore after grace period",
+			messageData: []byte("testuser"),
+			deleter: &mockUserDeleter{
+				restoreUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+					return nil, errors.NewValidation("restore grace period has expired")
+				},
+			},
+			restore:     true,
+			expectError: "restore grace period has expired",
+		},
+		{
+			name:        "empty input",
+			messageData: []byte("  "),
+			deleter:     &mockUserDeleter{},
+			expectError: "input is required",
+		},
+		{
+			name:        "provider without internal store",
+			messageData: []byte("testuser"),
+			expectError: "auth service unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &mockEventPublisher{}
+			opts := []messageHandlerOrchestratorOption{
+				WithUserReaderForMessageHandler(&mockUserServiceReader{}),
+				WithEventPublisherForMessageHandler(publisher),
+			}
+			if tt.deleter != nil {
+				opts = append(opts, WithUserDeleterForMessageHandler(tt.deleter))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+
+			handler := orchestrator.SoftDeleteUser
+			if tt.restore {
+				handler = orchestrator.RestoreUser
+			}
+
+			result, err := handler(ctx, &mockTransportMessenger{data: tt.messageData})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.expectSuccess {
+				t.Errorf("expected success %v, got %v", tt.expectSuccess, response.Success)
+			}
+			if response.Error != tt.expectError {
+				t.Errorf("expected error %q, got %q", tt.expectError, response.Error)
+			}
+
+			// the change invalidates the users caches
+			var changed model.UserProfileChanged
+			if data, published := publisher.events[constants.UserProfileChangedSubject]; published {
+				if err := json.Unmarshal(data, &changed); err != nil {
+					t.Fatalf("failed to unmarshal profile changed event: %v", err)
+				}
+			}
+			if changed.Reason != tt.expectChanged {
+				t.Errorf("expected profile changed reason %q, got %q", tt.expectChanged, changed.Reason)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_CheckEmailExists(t *testing.T) {
+	ctx := context.Background()
+
+	reader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			// the provider only finds the exact address, stored in its canonical spelling
+			if criteria != constants.CriteriaTypeEmail || user.PrimaryEmail != "johndoe@gmail.com" {
+				return nil, errors.NewNotFound("user not found")
+			}
+			return &model.User{UserID: "auth0|123", PrimaryEmail: "johndoe@gmail.com"}, nil
+		},
+	}
+	orchestrator := &messageHandlerOrchestrator{userReader: reader}
+
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+	}{
+		{name: "same address", email: "johndoe@gmail.com", wantErr: true},
+		{name: "gmail dots and plus address", email: "John.Doe+lfx@gmail.com", wantErr: true},
+		{name: "different mailbox", email: "john.doe2@gmail.com", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := orchestrator.checkEmailExists(ctx, tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkEmailExists(%q) error = %v, wantErr %v", tt.email, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_GetUserMetadata_OIDCFormat(t *testing.T) {
+	ctx := context.Background()
+
+	givenName := "John"
+	city := "San Francisco"
+	orchestrator := &messageHandlerOrchestrator{
+		userReader: &mockUserServiceReader{
+			getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+				return &model.User{
+					UserID:       "auth0|123",
+					Username:     "jdoe",
+					PrimaryEmail: "jdoe@example.com",
+					UserMetadata: &model.UserMetadata{GivenName: &givenName, City: &city},
+				}, nil
+			},
+		},
+	}
+
+	msg := &mockTransportMessenger{
+		data:    []byte("auth0|123"),
+		headers: map[string]string{constants.ResponseFormatHeader: "OIDC"},
+	}
+	result, err := orchestrator.GetUserMetadata(ctx, msg)
+	if err != nil {
+		t.Fatalf("GetUserMetadata() unexpected error: %v", err)
+	}
+
+	var response struct {
+		Success bool             `json:"success"`
+		Data    model.OIDCClaims `json:"data"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if !response.Success {
+		t.Fatalf("GetUserMetadata() = %s, want success", result)
+	}
+	if response.Data.Sub != "auth0|123" || response.Data.PreferredUsername != "jdoe" || response.Data.GivenName != givenName {
+		t.Errorf("GetUserMetadata() claims = %+v", response.Data)
+	}
+	if response.Data.Address == nil || response.Data.Address.Locality != city {
+		t.Errorf("GetUserMetadata() address = %+v, want the city as locality", response.Data.Address)
+	}
+}
+
+func TestMessageHandlerOrchestrator_GetUserMetadata_Stale(t *testing.T) {
+	ctx := context.Background()
+
+	stale := false
+	orchestrator := &messageHandlerOrchestrator{
+		userReader: &mockUserServiceReader{
+			getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+				found := &model.User{UserID: "auth0|123", Username: "jdoe"}
+				if stale {
+					found.StaleAge = 4*time.Minute + 500*time.Millisecond
+				}
+				return found, nil
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		stale bool
+		want  UserDataResponse
+	}{
+		{stale: false, want: UserDataResponse{Success: true}},
+		{stale: true, want: UserDataResponse{Success: true, Stale: true, Age: 240}},
+	} {
+		stale = tc.stale
+		result, err := orchestrator.GetUserMetadata(ctx, &mockTransportMessenger{data: []byte("auth0|123")})
+		if err != nil {
+			t.Fatalf("GetUserMetadata() unexpected error: %v", err)
+		}
+
+		var response UserDataResponse
+		if err := json.Unmarshal(result, &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if response.Success != tc.want.Success || response.Stale != tc.want.Stale || response.Age != tc.want.Age {
+			t.Errorf("GetUserMetadata() stale %v = %s, want stale %v and age %d", tc.stale, result, tc.want.Stale, tc.want.Age)
+		}
+	}
+}
+
+// mockEmailSendLimiter allows the first sends to each email and records the users of the sends
+type mockEmailSendLimiter struct {
+	perEmail int
+	sends    map[string]int
+	users    []string
+}
+
+func (m *mockEmailSendLimiter) Allow(ctx context.Context, email, userID string) error {
+	m.users = append(m.users, userID)
+	if m.sends[email] >= m.perEmail {
+		return errors.NewTooManyRequests("too many verification emails sent to this email, try again later").WithRetryAfter(time.Minute)
+	}
+	m.sends[email]++
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_StartEmailLinking_SendLimits(t *testing.T) {
+	ctx := context.Background()
+
+	userReader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			return nil, errors.NewNotFound("user not found")
+		},
+		metadataLookupFunc: func(ctx context.Context, input string) (*model.User, error) {
+			if input != "user-token" {
+				return nil, errors.NewUnauthorized("invalid token")
+			}
+			return &model.User{UserID: "auth0|testuser"}, nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		messages    []string
+		wantSent    int
+		wantUsers   []string
+		wantLimited bool
+		wantError   string
+	}{
+		{
+			name:      "bare email is limited per email only",
+			messages:  []string{"jane@personal.example"},
+			wantSent:  1,
+			wantUsers: []string{""},
+		},
+		{
+			name:      "request with a token is limited per user",
+			messages:  []string{`{"user":{"auth_token":"user-token"},"email":"Jane@Personal.example"}`},
+			wantSent:  1,
+			wantUsers: []string{"auth0|testuser"},
+		},
+		{
+			name:      "invalid token is rejected before the send",
+			messages:  []string{`{"user":{"auth_token":"forged"},"email":"jane@personal.example"}`},
+			wantError: "invalid token",
+		},
+		{
+			name:        "sends over the limit are rejected",
+			messages:    []string{"jane@personal.example", "jane@personal.example", `{"email":"jane@personal.example"}`},
+			wantSent:    2,
+			wantUsers:   []string{"", "", ""},
+			wantLimited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailHandler := &mockEmailHandler{}
+			limiter := &mockEmailSendLimiter{perEmail: 2, sends: make(map[string]int)}
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithEmailHandlerForMessageHandler(emailHandler),
+				WithUserReaderForMessageHandler(userReader),
+				WithEmailSendLimiterForMessageHandler(limiter),
+			)
+
+			var response UserDataResponse
+			for _, message := range tt.messages {
+				result, err := orchestrator.StartEmailLinking(ctx, &mockTransportMessenger{data: []byte(message)})
+				if err != nil {
+					t.Fatalf("StartEmailLinking() unexpected error: %v", err)
+				}
+				response = UserDataResponse{}
+				if err := json.Unmarshal(result, &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+			}
+
+			if len(emailHandler.sent) != tt.wantSent {
+				t.Errorf("sent %d verification emails, want %d", len(emailHandler.sent), tt.wantSent)
+			}
+			if strings.Join(limiter.users, ",") != strings.Join(tt.wantUsers, ",") {
+				t.Errorf("limited users = %q, want %q", limiter.users, tt.wantUsers)
+			}
+			switch {
+			case tt.wantLimited:
+				if response.Success || response.ErrorCode != "too_many_requests" || !response.Retryable || response.RetryAfterMs != 60000 {
+					t.Errorf("StartEmailLinking() = %+v, want a retryable too_many_requests error", response)
+				}
+			case tt.wantError != "":
+				if response.Success || response.Error != tt.wantError {
+					t.Errorf("StartEmailLinking() error = %q, want %q", response.Error, tt.wantError)
+				}
+			default:
+				if !response.Success {
+					t.Errorf("StartEmailLinking() failed: %s", response.Error)
+				}
+			}
+		})
+	}
+}
+
+// mockOTPAttemptGuard locks an email once maxFailures attempts failed
+type mockOTPAttemptGuard struct {
+	maxFailures int64
+	failures    map[string]int64
+	succeeded   []string
+}
+
+func (m *mockOTPAttemptGuard) Check(ctx context.Context, email string) error {
+	if m.failures[email] >= m.maxFailures {
+		return errors.NewTooManyRequests("too many failed verification attempts, try again later").WithRetryAfter(time.Minute)
+	}
+	return nil
+}
+
+func (m *mockOTPAttemptGuard) Fail(ctx context.Context, email string) (int64, bool) {
+	m.failures[email]++
+	return m.failures[email], m.failures[email] == m.maxFailures
+}
+
+func (m *mockOTPAttemptGuard) Succeed(ctx context.Context, email string) {
+	delete(m.failures, email)
+	m.succeeded = append(m.succeeded, email)
+}
+
+func TestMessageHandlerOrchestrator_VerifyEmailLinking_Lockout(t *testing.T) {
+	ctx := context.Background()
+
+	userReader := &mockUserServiceReader{
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			return nil, errors.NewNotFound("user not found")
+		},
+	}
+
+	tests := []struct {
+		name         string
+		verifyErr    error
+		attempts     int
+		wantFailures int64
+		wantLocked   bool
+		wantLockouts int
+	}{
+		{
+			name:     "successful verification forgets the failures",
+			attempts: 1,
+		},
+		{
+			name:         "rejected codes are counted",
+			verifyErr:    errors.NewForbidden("wrong email or verification code"),
+			attempts:     1,
+			wantFailures: 1,
+		},
+		{
+			name:         "provider errors are not counted",
+			verifyErr:    errors.NewServiceUnavailable("provider unavailable"),
+			attempts:     3,
+			wantFailures: 0,
+		},
+		{
+			name:         "repeated failures lock the email",
+			verifyErr:    errors.NewValidation("invalid verification code"),
+			attempts:     3,
+			wantFailures: 2,
+			wantLocked:   true,
+			wantLockouts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emailHandler := &mockEmailHandler{verifyErr: tt.verifyErr}
+			guard := &mockOTPAttemptGuard{maxFailures: 2, failures: make(map[string]int64)}
+			sink := &mockAuditSink{}
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithEmailHandlerForMessageHandler(emailHandler),
+				WithUserReaderForMessageHandler(userReader),
+				WithOTPAttemptGuardForMessageHandler(guard),
+				WithAuditSinkForMessageHandler(sink),
+			)
+
+			var response UserDataResponse
+			for range tt.attempts {
+				result, err := orchestrator.VerifyEmailLinking(ctx, &mockTransportMessenger{data: []byte(`{"email":"jane@personal.example","otp":"123456"}`)})
+				if err != nil {
+					t.Fatalf("VerifyEmailLinking() unexpected error: %v", err)
+				}
+				response = UserDataResponse{}
+				if err := json.Unmarshal(result, &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+			}
+
+			if got := guard.failures["jane@personal.example"]; got != tt.wantFailures {
+				t.Errorf("failures = %d, want %d", got, tt.wantFailures)
+			}
+			if tt.verifyErr == nil && (!response.Success || len(guard.succeeded) != 1) {
+				t.Errorf("VerifyEmailLinking() = %+v, want a success recorded by the guard", response)
+			}
+			if tt.wantLocked && (response.Success || response.ErrorCode != "too_many_requests" || !response.Retryable || response.RetryAfterMs != 60000) {
+				t.Errorf("VerifyEmailLinking() = %+v, want a retryable too_many_requests error", response)
+			}
+
+			lockouts := 0
+			for _, event := range sink.events {
+				if event.Action != model.AuditActionEmailLinkingLockout {
+					continue
+				}
+				lockouts++
+				if event.Outcome != model.AuditOutcomeFailure || event.Target == "jane@personal.example" || event.Details["failures"] != int64(2) {
+					t.Errorf("lockout audit event = %+v, want a failure with the redacted email and the failures", event)
+				}
+			}
+			if lockouts != tt.wantLockouts {
+				t.Errorf("recorded %d lockout audit events, want %d", lockouts, tt.wantLockouts)
+			}
+			if len(sink.events) != tt.attempts+tt.wantLockouts {
+				t.Errorf("recorded %d audit events, want one per attempt and one per lockout", len(sink.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Names of the middlewares of the message handlers
+const (
+	MiddlewareRecover      = "recover"
+	MiddlewareUsage        = "usage"
+	MiddlewareSLI          = "sli"
+	MiddlewareSlowRequests = "slow_requests"
+)
+
+// DefaultMiddlewareOrder is the order of the middlewares of the message handlers, the first one
+// is the outermost: the recovery also covers the panics of the other middlewares
+var DefaultMiddlewareOrder = []string{MiddlewareRecover, MiddlewareUsage, MiddlewareSLI, MiddlewareSlowRequests}
+
+// Middleware wraps a message handler with a cross-cutting concern, so the handlers only hold
+// the logic of their operation
+type Middleware func(next MessageHandlerFunc) MessageHandlerFunc
+
+// Chain wraps the handler with the middlewares, the first one is the outermost
+func Chain(handler MessageHandlerFunc, middlewares ...Middleware) MessageHandlerFunc {
+	for _, middleware := range slices.Backward(middlewares) {
+		handler = middleware(handler)
+	}
+	return handler
+}
+
+// MiddlewareChain composes named middlewares in a configurable order
+type MiddlewareChain struct {
+	middlewares map[string]Middleware
+	order       []string
+}
+
+// NewMiddlewareChain creates an empty chain, a nil chain runs the handlers unwrapped
+func NewMiddlewareChain() *MiddlewareChain {
+	return &MiddlewareChain{middlewares: make(map[string]Middleware)}
+}
+
+// Register adds the middleware under its name, inside the middlewares registered before.
+// Registering a name again replaces the middleware in place.
+func (c *MiddlewareChain) Register(name string, middleware Middleware) *MiddlewareChain {
+	if _, ok := c.middlewares[name]; !ok {
+		c.order = append(c.order, name)
+	}
+	c.middlewares[name] = middleware
+	return c
+}
+
+// Order sets the order of the middlewares, the first one is the outermost and the registered
+// middlewares left out are disabled
+func (c *MiddlewareChain) Order(names ...string) error {
+	order := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := c.middlewares[name]; !ok {
+			return errs.NewValidation(fmt.Sprintf("unknown middleware %q, expected one of %s", name, strings.Join(c.Names(), ", ")))
+		}
+		if slices.Contains(order, name) {
+			return errs.NewValidation(fmt.Sprintf("middleware %q is listed twice", name))
+		}
+		order = append(order, name)
+	}
+	c.order = order
+	return nil
+}
+
+// Names returns the names of the registered middlewares, sorted
+func (c *MiddlewareChain) Names() []string {
+	names := make([]string, 0, len(c.middlewares))
+	for name := range c.middlewares {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Then wraps the handler with the middlewares of the chain, in order
+func (c *MiddlewareChain) Then(handler MessageHandlerFunc) MessageHandlerFunc {
+	if c == nil {
+		return handler
+	}
+	middlewares := make([]Middleware, 0, len(c.order))
+	for _, name := range c.order {
+		middlewares = append(middlewares, c.middlewares[name])
+	}
+	return Chain(handler, middlewares...)
+}
+
+// RecoverMiddleware answers an unexpected error when the handler panics, instead of leaving the
+// requester waiting until its timeout
+func RecoverMiddleware() Middleware {
+	return func(next MessageHandlerFunc) MessageHandlerFunc {
+		return func(ctx context.Context, msg port.TransportMessenger) (response []byte, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.ErrorContext(ctx, "panic in message handler",
+						"subject", msg.Subject(),
+						"panic", r,
+						"stack", string(debug.Stack()),
+					)
+					response, err = nil, errs.NewUnexpected("internal error")
+				}
+			}()
+			return next(ctx, msg)
+		}
+	}
+}
+
+// UsageMiddleware counts the requests per caller and operation, a nil recorder counts nothing
+func UsageMiddleware(recorder port.UsageRecorder) Middleware {
+	return func(next MessageHandlerFunc) MessageHandlerFunc {
+		if recorder == nil {
+			return next
+		}
+		return func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+			recorder.RecordUsage(callerFromContext(ctx), msg.Subject())
+			return next(ctx, msg)
+		}
+	}
+}
+
+// SLIMiddleware records the latency and the outcome of the requests for the consumer contracts,
+// a nil recorder records nothing. Only the failures of the service count against the error
+// budgets, not the invalid requests nor the users not found.
+func SLIMiddleware(recorder port.SLIRecorder) Middleware {
+	return func(next MessageHandlerFunc) MessageHandlerFunc {
+		if recorder == nil {
+			return next
+		}
+		return func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+			start := time.Now()
+			response, err := next(ctx, msg)
+			recorder.RecordSLI(callerFromContext(ctx), msg.Subject(), time.Since(start), serviceFailure(response, err))
+			return response, err
+		}
+	}
+}
+
+// serviceFailure reports whether the handler failed on the service side, the handlers answer
+// most errors in the response payload
+func serviceFailure(response []byte, err error) bool {
+	code := ""
+	if err != nil {
+		code = errs.Code(err)
+	} else {
+		var reply struct {
+			Success   *bool  `json:"success"`
+			ErrorCode string `json:"error_code"`
+		}
+		if json.Unmarshal(response, &reply) != nil || reply.Success == nil || *reply.Success {
+			return false
+		}
+		code = reply.ErrorCode
+	}
+	return code == errs.CodeUnexpected || code == errs.CodeServiceUnavailable
+}
+
+// Middleware logs the slow requests of the handler, a nil logger logs nothing
+func (l *SlowRequestLogger) Middleware() Middleware {
+	return func(next MessageHandlerFunc) MessageHandlerFunc {
+		if l == nil {
+			return next
+		}
+		return func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+			return l.Handle(ctx, msg, next)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// tracingMiddleware records the order the middlewares run in
+func tracingMiddleware(name string, calls *[]string) Middleware {
+	return func(next MessageHandlerFunc) MessageHandlerFunc {
+		return func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+			*calls = append(*calls, name)
+			return next(ctx, msg)
+		}
+	}
+}
+
+type mockUsageRecorder struct {
+	recorded [][2]string
+}
+
+func (m *mockUsageRecorder) RecordUsage(caller, operation string) {
+	m.recorded = append(m.recorded, [2]string{caller, operation})
+}
+
+func TestMiddlewareChain_Order(t *testing.T) {
+	var calls []string
+	handler := func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+		calls = append(calls, "handler")
+		return []byte("ok"), nil
+	}
+
+	chain := NewMiddlewareChain().
+		Register("a", tracingMiddleware("a", &calls)).
+		Register("b", tracingMiddleware("b", &calls)).
+		Register("c", tracingMiddleware("c", &calls))
+
+	response, err := chain.Then(handler)(context.Background(), &mockTransportMessenger{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(response))
+	assert.Equal(t, []string{"a", "b", "c", "handler"}, calls)
+
+	// reordered, with b disabled
+	calls = nil
+	require.NoError(t, chain.Order("c", " a"))
+	_, err = chain.Then(handler)(context.Background(), &mockTransportMessenger{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "handler"}, calls)
+
+	errUnknown := chain.Order("a", "metrics")
+	require.Error(t, errUnknown)
+	_, isValidation := errUnknown.(errs.Validation)
+	assert.True(t, isValidation, "expected validation error, got %T", errUnknown)
+	assert.ErrorContains(t, errUnknown, "expected one of a, b, c")
+	assert.ErrorContains(t, chain.Order("a", "a"), "listed twice")
+
+	// a nil chain runs the handler unwrapped
+	calls = nil
+	var nilChain *MiddlewareChain
+	_, err = nilChain.Then(handler)(context.Background(), &mockTransportMessenger{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"handler"}, calls)
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	handler := Chain(func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+		panic("boom")
+	}, RecoverMiddleware())
+
+	response, err := handler(context.Background(), &mockTransportMessenger{})
+	assert.Nil(t, response)
+	require.Error(t, err)
+	assert.Equal(t, errs.CodeUnexpected, errs.Code(err))
+}
+
+func TestUsageMiddleware(t *testing.T) {
+	recorder := &mockUsageRecorder{}
+	handler := func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+		return nil, nil
+	}
+
+	// the usage is charged to the authenticated caller, not to the caller claimed in a header
+	_, err := Chain(handler, UsageMiddleware(recorder))(ContextWithCaller(context.Background(), "project-service"), &mockTransportMessenger{
+		headers: map[string]string{"X-Caller-Service": "search-service"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"project-service", "test-subject"}}, recorder.recorded)
+
+	// without recorder the handler runs unwrapped
+	_, err = Chain(handler, UsageMiddleware(nil))(context.Background(), &mockTransportMessenger{})
+	assert.NoError(t, err)
+}
+
+type mockSLIRecorder struct {
+	failed []bool
+}
+
+func (m *mockSLIRecorder) RecordSLI(caller, operation string, latency time.Duration, failed bool) {
+	m.failed = append(m.failed, failed)
+}
+
+func TestSLIMiddleware(t *testing.T) {
+	recorder := &mockSLIRecorder{}
+
+	for _, reply := range []struct {
+		response []byte
+		err      error
+	}{
+		{response: []byte(`{"success":true}`)},
+		{response: []byte(`jdoe`)},
+		{response: []byte(`{"success":false,"error":"user not found","error_code":"not_found"}`)},
+		{response: []byte(`{"success":false,"error":"failed to search user","error_code":"unexpected"}`)},
+		{err: errs.NewServiceUnavailable("auth service unavailable")},
+		{err: errs.NewValidation("invalid subject")},
+	} {
+		handler := func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+			return reply.response, reply.err
+		}
+		_, _ = Chain(handler, SLIMiddleware(recorder))(context.Background(), &mockTransportMessenger{})
+	}
+
+	assert.Equal(t, []bool{false, false, false, true, true, false}, recorder.failed)
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// SetMockFaults replaces the faults injected in the operations of the mock provider and replies
+// with them, an empty payload clears them, so the callers test their retries and fallbacks without
+// restarting the service
+func (m *messageHandlerOrchestrator) SetMockFaults(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SetMockFaults", msg)
+	defer span.End()
+
+	if m.faultInjector == nil {
+		return m.errorResponseFromError(ctx, errs.NewServiceUnavailable("the faults are only injected in the mock provider")), nil
+	}
+
+	var faults model.ProviderFaults
+	if err := json.Unmarshal(msg.Data(), &faults); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+	if err := m.faultInjector.SetFaults(ctx, faults); err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	slog.InfoContext(ctx, "mock faults replaced", "operations", len(faults.Operations))
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    m.faultInjector.Faults(),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,104 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockFaultInjector struct {
+	faults model.ProviderFaults
+	err    error
+}
+
+func (m *mockFaultInjector) Faults() model.ProviderFaults {
+	return m.faults
+}
+
+func (m *mockFaultInjector) SetFaults(_ context.Context, faults model.ProviderFaults) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.faults = faults
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_SetMockFaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		err        error
+		wantError  string
+		wantFaults model.ProviderFaults
+	}{
+		{
+			name: "faults of an operation",
+			data: `{"latency_ms":"50","operations":{"search_user":{"error":"rate_limited","rate":0.5}}}`,
+			wantFaults: model.ProviderFaults{
+				LatencyMs:  "50",
+				Operations: map[string]model.OperationFault{"search_user": {Error: model.FaultRateLimited, Rate: 0.5}},
+			},
+		},
+		{
+			name: "faults cleared",
+			data: `{}`,
+		},
+		{
+			name:      "invalid payload",
+			data:      `timeout`,
+			wantError: "failed to unmarshal request",
+		},
+		{
+			name:      "invalid faults",
+			data:      `{"operations":{"delete_user":{"error":"conflict"}}}`,
+			err:       errs.NewValidation("unknown operation delete_user"),
+			wantError: "unknown operation delete_user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injector := &mockFaultInjector{err: tt.err}
+			orchestrator := &messageHandlerOrchestrator{faultInjector: injector}
+
+			responseJSON, err := orchestrator.SetMockFaults(context.Background(), &mockTransportMessenger{data: []byte(tt.data)})
+			require.NoError(t, err)
+
+			var response struct {
+				Success bool                 `json:"success"`
+				Error   string               `json:"error"`
+				Data    model.ProviderFaults `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(responseJSON, &response))
+			if tt.wantError != "" {
+				assert.False(t, response.Success)
+				assert.Equal(t, tt.wantError, response.Error)
+				return
+			}
+			require.True(t, response.Success, string(responseJSON))
+			assert.Equal(t, tt.wantFaults, response.Data)
+			assert.Equal(t, tt.wantFaults, injector.faults)
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_SetMockFaults_NotMock(t *testing.T) {
+	orchestrator := &messageHandlerOrchestrator{}
+
+	responseJSON, err := orchestrator.SetMockFaults(context.Background(), &mockTransportMessenger{data: []byte(`{}`)})
+	require.NoError(t, err)
+
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(responseJSON, &response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "the faults are only injected in the mock provider", response.Error)
+}
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// mockUsersSeedRequest represents the input for setting up the mock users, the users are reset to
+// their fixtures first when Reset is set
+type mockUsersSeedRequest struct {
+	Reset bool          `json:"reset,omitempty"`
+	Users []*model.User `json:"users,omitempty"`
+}
+
+// mockUsersSeedResponse is the number of users reset and seeded
+type mockUsersSeedResponse struct {
+	Reset  int `json:"reset"`
+	Seeded int `json:"seeded"`
+}
+
+// SeedMockUsers resets the users of the mock provider to their fixtures and seeds the given ones,
+// so the e2e tests set up their scenarios without rebuilding the service
+func (m *messageHandlerOrchestrator) SeedMockUsers(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "SeedMockUsers", msg)
+	defer span.End()
+
+	if m.userFixtureLoader == nil {
+		return m.errorResponseFromError(ctx, errs.NewServiceUnavailable("the mock users are only served by the mock provider")), nil
+	}
+
+	var request mockUsersSeedRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+	if !request.Reset && len(request.Users) == 0 {
+		return m.errorResponse("reset or users is required"), nil
+	}
+
+	var result mockUsersSeedResponse
+	if request.Reset {
+		reset, errReset := m.userFixtureLoader.ResetUsers(ctx)
+		if errReset != nil {
+			return m.errorResponseFromError(ctx, errReset), nil
+		}
+		result.Reset = reset
+	}
+	if len(request.Users) > 0 {
+		seeded, errSeed := m.userFixtureLoader.SeedUsers(ctx, request.Users)
+		if errSeed != nil {
+			return m.errorResponseFromError(ctx, errSeed), nil
+		}
+		result.Seeded = seeded
+	}
+
+	slog.InfoContext(ctx, "mock users set up", "reset", result.Reset, "seeded", result.Seeded)
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    result,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
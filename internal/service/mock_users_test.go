@@ -0,0 +1,114 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockUserFixtureLoader struct {
+	calls  []string
+	seeded []*model.User
+	err    error
+}
+
+func (m *mockUserFixtureLoader) ResetUsers(context.Context) (int, error) {
+	m.calls = append(m.calls, "reset")
+	return 3, nil
+}
+
+func (m *mockUserFixtureLoader) SeedUsers(_ context.Context, users []*model.User) (int, error) {
+	m.calls = append(m.calls, "seed")
+	m.seeded = users
+	return len(users), m.err
+}
+
+func TestMessageHandlerOrchestrator_SeedMockUsers(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		err       error
+		wantCalls []string
+		wantError string
+		wantReset int
+		wantSeed  int
+	}{
+		{
+			name:      "reset and seed",
+			data:      `{"reset":true,"users":[{"user_id":"auth0|nova004","username":"nova.starlight"}]}`,
+			wantCalls: []string{"reset", "seed"},
+			wantReset: 3,
+			wantSeed:  1,
+		},
+		{
+			name:      "seed only",
+			data:      `{"users":[{"user_id":"auth0|nova004"},{"user_id":"auth0|orion005"}]}`,
+			wantCalls: []string{"seed"},
+			wantSeed:  2,
+		},
+		{
+			name:      "nothing to do",
+			data:      `{}`,
+			wantError: "reset or users is required",
+		},
+		{
+			name:      "invalid payload",
+			data:      `nova`,
+			wantError: "failed to unmarshal request",
+		},
+		{
+			name:      "conflicting user",
+			data:      `{"users":[{"user_id":"auth0|nova004","username":"zephyr.stormwind"}]}`,
+			err:       errs.NewConflict("identifier zephyr.stormwind already in use"),
+			wantCalls: []string{"seed"},
+			wantError: "identifier zephyr.stormwind already in use",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := &mockUserFixtureLoader{err: tt.err}
+			orchestrator := &messageHandlerOrchestrator{userFixtureLoader: loader}
+
+			responseJSON, err := orchestrator.SeedMockUsers(context.Background(), &mockTransportMessenger{data: []byte(tt.data)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, loader.calls)
+
+			var response struct {
+				Success bool                  `json:"success"`
+				Error   string                `json:"error"`
+				Data    mockUsersSeedResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(responseJSON, &response))
+			if tt.wantError != "" {
+				assert.False(t, response.Success)
+				assert.Equal(t, tt.wantError, response.Error)
+				return
+			}
+			require.True(t, response.Success, string(responseJSON))
+			assert.Equal(t, tt.wantReset, response.Data.Reset)
+			assert.Equal(t, tt.wantSeed, response.Data.Seeded)
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_SeedMockUsers_NotMock(t *testing.T) {
+	orchestrator := &messageHandlerOrchestrator{}
+
+	responseJSON, err := orchestrator.SeedMockUsers(context.Background(), &mockTransportMessenger{data: []byte(`{"reset":true}`)})
+	require.NoError(t, err)
+
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(responseJSON, &response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "the mock users are only served by the mock provider", response.Error)
+}
@@ -0,0 +1,36 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// moderateMetadata reviews the metadata with the moderation policy, the redacted values are replaced
+// and the rejected ones are returned as violations, nothing is moderated without a policy
+func (m *messageHandlerOrchestrator) moderateMetadata(ctx context.Context, metadata *model.UserMetadata) (model.ValidationViolations, error) {
+	if m.metadataPolicy == nil || metadata == nil {
+		return nil, nil
+	}
+
+	decisions, err := m.metadataPolicy.Review(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := metadata.Moderate(decisions)
+	for _, decision := range decisions {
+		if decision.Action != model.ModerationActionAllow {
+			slog.InfoContext(ctx, "metadata moderated",
+				"field", decision.Field,
+				"action", decision.Action,
+				"reason", decision.Reason,
+			)
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,95 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeMetadataPolicy returns its decisions, or fails with its error
+type fakeMetadataPolicy struct {
+	decisions []model.ModerationDecision
+	err       error
+}
+
+func (f *fakeMetadataPolicy) Review(ctx context.Context, metadata *model.UserMetadata) ([]model.ModerationDecision, error) {
+	return f.decisions, f.err
+}
+
+func TestMessageHandlerOrchestrator_UpdateUser_Moderated(t *testing.T) {
+	payload := []byte(`{"token":"token","user_metadata":{"name":"Jane Darn","organization":"https://spam.example"}}`)
+
+	tests := []struct {
+		name          string
+		policy        *fakeMetadataPolicy
+		wantUpdated   bool
+		wantName      string
+		wantErrorCode string
+	}{
+		{
+			name:        "allowed",
+			policy:      &fakeMetadataPolicy{},
+			wantUpdated: true,
+			wantName:    "Jane Darn",
+		},
+		{
+			name:        "redacted",
+			policy:      &fakeMetadataPolicy{decisions: []model.ModerationDecision{{Field: "name", Action: model.ModerationActionRedact, Value: "Jane ***"}}},
+			wantUpdated: true,
+			wantName:    "Jane ***",
+		},
+		{
+			name: "rejected",
+			policy: &fakeMetadataPolicy{decisions: []model.ModerationDecision{
+				{Field: "name", Action: model.ModerationActionRedact, Value: "Jane ***"},
+				{Field: "organization", Action: model.ModerationActionReject, Reason: "links are not allowed"},
+			}},
+			wantErrorCode: "validation",
+		},
+		{
+			name:          "policy unavailable",
+			policy:        &fakeMetadataPolicy{err: errs.NewServiceUnavailable("metadata moderation unavailable")},
+			wantErrorCode: "service_unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *model.User
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserWriterForMessageHandler(&mockUserServiceWriter{
+					updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+						updated = user
+						return user, nil
+					},
+				}),
+				WithMetadataPolicyForMessageHandler(tt.policy),
+			)
+
+			result, err := orchestrator.UpdateUser(context.Background(), &mockTransportMessenger{data: payload})
+			if err != nil {
+				t.Fatalf("UpdateUser() unexpected error: %v", err)
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.ErrorCode != tt.wantErrorCode {
+				t.Errorf("UpdateUser() = %s, want error code %q", result, tt.wantErrorCode)
+			}
+			if (updated != nil) != tt.wantUpdated {
+				t.Fatalf("UpdateUser() updated = %v, want %v", updated != nil, tt.wantUpdated)
+			}
+			if tt.wantUpdated && *updated.UserMetadata.Name != tt.wantName {
+				t.Errorf("UpdateUser() name = %q, want %q", *updated.UserMetadata.Name, tt.wantName)
+			}
+		})
+	}
+}
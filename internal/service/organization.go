@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// currentUser loads the full user (including emails) identified by the auth token
+func (m *messageHandlerOrchestrator) currentUser(ctx context.Context, token string) (*model.User, error) {
+	lookup, errMetadataLookup := m.userReader.MetadataLookup(ctx, token)
+	if errMetadataLookup != nil {
+		return nil, errMetadataLookup
+	}
+	// the user token might not be allowed to read the full profile,
+	// let the provider use its own credentials instead
+	lookup.Token = ""
+	return m.userReader.GetUser(ctx, lookup)
+}
+
+// organizationVerified computes the organization verified badge for the user identified
+// by the auth token, nil means the badge can't be computed and should be left untouched
+func (m *messageHandlerOrchestrator) organizationVerified(ctx context.Context, token, organization string) *bool {
+	if len(m.organizationDomains) == 0 || m.userReader == nil {
+		return nil
+	}
+
+	user, errCurrentUser := m.currentUser(ctx, token)
+	if errCurrentUser != nil {
+		slog.WarnContext(ctx, "failed to load user to compute organization verified badge",
+			"error", errCurrentUser,
+		)
+		return nil
+	}
+
+	verified := user.OrganizationVerified(m.organizationDomains, organization)
+	return &verified
+}
+
+// refreshOrganizationVerified recomputes the organization verified badge after the user
+// emails changed, it's best effort and only logs failures. The user is read and the badge
+// written under the user lock, so a concurrent update isn't overwritten with a stale badge.
+func (m *messageHandlerOrchestrator) refreshOrganizationVerified(ctx context.Context, token string) {
+	if len(m.organizationDomains) == 0 || m.userReader == nil || m.userWriter == nil {
+		return
+	}
+
+	lookup, errMetadataLookup := m.userReader.MetadataLookup(ctx, token)
+	if errMetadataLookup != nil {
+		slog.WarnContext(ctx, "failed to look up user to refresh organization verified badge",
+			"error", errMetadataLookup,
+		)
+		return
+	}
+
+	unlock, errLock := m.lockUsers(ctx, lookup.UserID, lookup.Sub)
+	if errLock != nil {
+		slog.WarnContext(ctx, "failed to lock user to refresh organization verified badge",
+			"error", errLock,
+			"user_id", redaction.Redact(lookup.UserID),
+		)
+		return
+	}
+	defer unlock()
+
+	// the user token might not be allowed to read the full profile
+	lookup.Token = ""
+	user, errGetUser := m.userReader.GetUser(ctx, lookup)
+	if errGetUser != nil {
+		slog.WarnContext(ctx, "failed to load user to refresh organization verified badge",
+			"error", errGetUser,
+		)
+		return
+	}
+	m.updateOrganizationVerified(ctx, token, user)
+}
+
+// updateOrganizationVerified stores the organization verified badge computed from the emails of the
+// user when it changed, it's best effort and only logs failures. The caller holds the user lock.
+func (m *messageHandlerOrchestrator) updateOrganizationVerified(ctx context.Context, token string, user *model.User) {
+	if len(m.organizationDomains) == 0 || m.userWriter == nil || user == nil {
+		return
+	}
+
+	var organization string
+	current := false
+	if user.UserMetadata != nil {
+		if user.UserMetadata.Organization != nil {
+			organization = *user.UserMetadata.Organization
+		}
+		if user.UserMetadata.OrganizationVerified != nil {
+			current = *user.UserMetadata.OrganizationVerified
+		}
+	}
+
+	verified := user.OrganizationVerified(m.organizationDomains, organization)
+	if verified == current {
+		return
+	}
+
+	_, errUpdateUser := m.userWriter.UpdateUser(ctx, &model.User{
+		Token:        token,
+		Username:     user.Username,
+		Sub:          user.Sub,
+		UserMetadata: &model.UserMetadata{OrganizationVerified: &verified},
+	})
+	if errUpdateUser != nil {
+		slog.WarnContext(ctx, "failed to refresh organization verified badge",
+			"error", errUpdateUser,
+			"username", redaction.Redact(user.Username),
+		)
+		return
+	}
+
+	slog.DebugContext(ctx, "organization verified badge refreshed",
+		"username", redaction.Redact(user.Username),
+		"verified", verified,
+	)
+}
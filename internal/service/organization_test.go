@@ -0,0 +1,198 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+)
+
+func TestMessageHandlerOrchestrator_UpdateUser_OrganizationVerified(t *testing.T) {
+	ctx := context.Background()
+	domains := model.OrganizationDomains{"cncf": {"cncf.io"}}
+
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			if user.Token != "" {
+				t.Error("GetUser should not be called with the user token")
+			}
+			return &model.User{PrimaryEmail: "jane@cncf.io", PrimaryEmailVerified: true}, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		domains      model.OrganizationDomains
+		metadata     *model.UserMetadata
+		wantVerified *bool
+	}{
+		{
+			name:         "organization matching the user email domain",
+			domains:      domains,
+			metadata:     &model.UserMetadata{Organization: converters.StringPtr("CNCF")},
+			wantVerified: converters.BoolPtr(true),
+		},
+		{
+			name:         "organization not matching the user email domain",
+			domains:      domains,
+			metadata:     &model.UserMetadata{Organization: converters.StringPtr("Other")},
+			wantVerified: converters.BoolPtr(false),
+		},
+		{
+			name:         "caller supplied badge is ignored",
+			domains:      domains,
+			metadata:     &model.UserMetadata{Name: converters.StringPtr("Jane"), OrganizationVerified: converters.BoolPtr(true)},
+			wantVerified: nil,
+		},
+		{
+			name:         "no domains configured",
+			domains:      nil,
+			metadata:     &model.UserMetadata{Organization: converters.StringPtr("CNCF")},
+			wantVerified: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written *model.User
+			writer := &mockUserServiceWriter{
+				updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+					written = user
+					return user, nil
+				},
+			}
+
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserReaderForMessageHandler(reader),
+				WithUserWriterForMessageHandler(writer),
+				WithOrganizationDomainsForMessageHandler(tt.domains),
+			)
+
+			data, _ := json.Marshal(&model.User{Token: "user|token", UserMetadata: tt.metadata})
+			if _, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: data}); err != nil {
+				t.Fatalf("UpdateUser() unexpected error: %v", err)
+			}
+			if written == nil {
+				t.Fatal("expected the user writer to be called")
+			}
+
+			got := written.UserMetadata.OrganizationVerified
+			switch {
+			case tt.wantVerified == nil && got != nil:
+				t.Errorf("OrganizationVerified = %v, want nil", *got)
+			case tt.wantVerified != nil && (got == nil || *got != *tt.wantVerified):
+				t.Errorf("OrganizationVerified = %v, want %v", got, *tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_RefreshOrganizationVerified(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		user        *model.User
+		wantUpdate  bool
+		wantBadgeTo bool
+	}{
+		{
+			name: "badge granted after linking a matching email",
+			user: &model.User{
+				Username:        "jane",
+				PrimaryEmail:    "jane@example.com",
+				AlternateEmails: []model.Email{{Email: "jane@cncf.io", Verified: true}},
+				UserMetadata:    &model.UserMetadata{Organization: converters.StringPtr("CNCF")},
+			},
+			wantUpdate:  true,
+			wantBadgeTo: true,
+		},
+		{
+			name: "badge revoked after unlinking the matching email",
+			user: &model.User{
+				Username:     "jane",
+				PrimaryEmail: "jane@example.com",
+				UserMetadata: &model.UserMetadata{
+					Organization:         converters.StringPtr("CNCF"),
+					OrganizationVerified: converters.BoolPtr(true),
+				},
+			},
+			wantUpdate:  true,
+			wantBadgeTo: false,
+		},
+		{
+			name: "unchanged badge is not written",
+			user: &model.User{
+				Username:     "jane",
+				PrimaryEmail: "jane@example.com",
+				UserMetadata: &model.UserMetadata{Organization: converters.StringPtr("CNCF")},
+			},
+			wantUpdate: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written *model.User
+			locker := &recordingUserLocker{}
+			orchestrator := &messageHandlerOrchestrator{
+				organizationDomains: model.OrganizationDomains{"cncf": {"cncf.io"}},
+				userLocker:          locker,
+				userReader: &mockUserServiceReader{
+					getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+						if !locker.holds("user|token") {
+							t.Error("the user is read outside of its lock")
+						}
+						return tt.user, nil
+					},
+				},
+				userWriter: &mockUserServiceWriter{
+					updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+						if !locker.holds("user|token") {
+							t.Error("the badge is written outside of its lock")
+						}
+						written = user
+						return user, nil
+					},
+				},
+			}
+
+			orchestrator.refreshOrganizationVerified(ctx, "user|token")
+			if locker.holds("user|token") {
+				t.Error("the user lock is not released")
+			}
+
+			if (written != nil) != tt.wantUpdate {
+				t.Fatalf("update called = %v, want %v", written != nil, tt.wantUpdate)
+			}
+			if tt.wantUpdate {
+				if written.Username != "jane" || written.Token != "user|token" {
+					t.Errorf("unexpected update target: %+v", written)
+				}
+				if got := written.UserMetadata.OrganizationVerified; got == nil || *got != tt.wantBadgeTo {
+					t.Errorf("OrganizationVerified = %v, want %v", got, tt.wantBadgeTo)
+				}
+			}
+		})
+	}
+}
+
+// recordingUserLocker records the keys locked, without blocking
+type recordingUserLocker struct {
+	held []string
+}
+
+func (r *recordingUserLocker) LockUsers(ctx context.Context, keys ...string) (func(), error) {
+	r.held = append(r.held, keys...)
+	return func() { r.held = nil }, nil
+}
+
+func (r *recordingUserLocker) holds(key string) bool {
+	return slices.Contains(r.held, key)
+}
@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// phoneVerificationSent is the data of the reply of StartPhoneLinking
+type phoneVerificationSent struct {
+	Channel model.VerificationChannel `json:"channel"`
+}
+
+// parsePhoneLinking reads a phone linking request, the phone number is normalized
+func (m *messageHandlerOrchestrator) parsePhoneLinking(data []byte) (*model.PhoneLinking, []byte) {
+	request := &model.PhoneLinking{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, m.errorResponse("failed to unmarshal phone linking request")
+	}
+	if request.PhoneNumber == "" {
+		return nil, m.errorResponse("phone_number is required")
+	}
+	if !request.IsValidPhoneNumber() {
+		return nil, m.errorResponse("invalid phone number, expected the E.164 format like +14155550123")
+	}
+	request.PhoneNumber = request.NormalizedPhoneNumber()
+	return request, nil
+}
+
+// StartPhoneLinking sends the verification code of a phone number of the token bearer, on the channel
+// configured for the phone linking. The token is required so the sends are limited per user.
+func (m *messageHandlerOrchestrator) StartPhoneLinking(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "StartPhoneLinking", msg)
+	defer span.End()
+
+	if m.verificationHandler == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("phone linking unavailable")), nil
+	}
+
+	request, errResponse := m.parsePhoneLinking(msg.Data())
+	if errResponse != nil {
+		return errResponse, nil
+	}
+	if request.User.AuthToken == "" {
+		return m.errorResponse("auth_token is required"), nil
+	}
+
+	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, request.User.AuthToken)
+	if errMetadataLookup != nil {
+		return m.errorResponseFromError(ctx, errMetadataLookup), nil
+	}
+
+	audit := &model.AuditEvent{
+		Action:  model.AuditActionPhoneLinkingStart,
+		Actor:   user.UserID,
+		Target:  redaction.Redact(request.PhoneNumber),
+		Details: map[string]any{"channel": m.phoneLinkingChannel},
+	}
+
+	// a text message costs more than an email, the sends are limited like the verification emails
+	if m.emailSendLimiter != nil {
+		if errLimit := m.emailSendLimiter.Allow(ctx, request.PhoneNumber, user.UserID); errLimit != nil {
+			audit.Fail(errLimit)
+			m.publishAudit(ctx, audit)
+			return m.errorResponseFromError(ctx, errLimit), nil
+		}
+	}
+
+	errSend := m.verificationHandler.SendVerification(ctx, &model.Verification{
+		Channel:   m.phoneLinkingChannel,
+		Recipient: request.PhoneNumber,
+	})
+	if errSend != nil {
+		audit.Fail(errSend)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errSend), nil
+	}
+	m.publishAudit(ctx, audit)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "phone number verification sent",
+		Data:    phoneVerificationSent{Channel: m.phoneLinkingChannel},
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+	return responseJSON, nil
+}
+
+// VerifyPhoneLinking verifies a phone number with the code sent by StartPhoneLinking, the reply holds
+// the ID token of the passwordless identity of the phone number, linked with the identity linking
+func (m *messageHandlerOrchestrator) VerifyPhoneLinking(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "VerifyPhoneLinking", msg)
+	defer span.End()
+
+	if m.verificationHandler == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("phone linking unavailable")), nil
+	}
+
+	request, errResponse := m.parsePhoneLinking(msg.Data())
+	if errResponse != nil {
+		return errResponse, nil
+	}
+	if request.OTP == "" {
+		return m.errorResponse("otp is required"), nil
+	}
+
+	audit := &model.AuditEvent{
+		Action: model.AuditActionPhoneLinkingVerify,
+		Actor:  callerFromContext(ctx),
+		Target: redaction.Redact(request.PhoneNumber),
+	}
+
+	// a locked phone number isn't verified at all, the attempts would be guesses of the OTP
+	if m.otpAttemptGuard != nil {
+		if errLocked := m.otpAttemptGuard.Check(ctx, request.PhoneNumber); errLocked != nil {
+			audit.Fail(errLocked)
+			m.publishAudit(ctx, audit)
+			return m.errorResponseFromError(ctx, errLocked), nil
+		}
+	}
+
+	authResponse, errVerify := m.verificationHandler.Verify(ctx, &model.Verification{
+		Channel:   m.phoneLinkingChannel,
+		Recipient: request.PhoneNumber,
+		OTP:       request.OTP,
+	})
+	m.recordVerificationAttempt(ctx, model.AuditActionPhoneLinkingLockout, request.PhoneNumber, audit.Target, errVerify)
+	if errVerify != nil {
+		audit.Fail(errVerify)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errVerify), nil
+	}
+	m.publishAudit(ctx, audit)
+
+	authResponse, errSeal := m.sealIDToken(authResponse, "")
+	if errSeal != nil {
+		return m.errorResponseFromError(ctx, errSeal), nil
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    m.applyResponsePolicy(ctx, authResponse),
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+	return responseJSON, nil
+}
@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// mockVerificationHandler sends the code 123456 and records the verifications
+type mockVerificationHandler struct {
+	sent     []model.Verification
+	verified []model.Verification
+}
+
+func (m *mockVerificationHandler) SendVerification(_ context.Context, verification *model.Verification) error {
+	m.sent = append(m.sent, *verification)
+	return nil
+}
+
+func (m *mockVerificationHandler) Verify(_ context.Context, verification *model.Verification) (*model.AuthResponse, error) {
+	m.verified = append(m.verified, *verification)
+	if verification.OTP != "123456" {
+		return nil, errs.NewForbidden("wrong phone number or verification code")
+	}
+	return &model.AuthResponse{IDToken: "sms-id-token"}, nil
+}
+
+func TestMessageHandlerOrchestrator_PhoneLinking(t *testing.T) {
+	ctx := context.Background()
+
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(_ context.Context, input string) (*model.User, error) {
+			if input != "user-token" {
+				return nil, errs.NewUnauthorized("invalid token")
+			}
+			return &model.User{UserID: "auth0|user123"}, nil
+		},
+	}
+	handler := &mockVerificationHandler{}
+	limiter := &mockEmailSendLimiter{perEmail: 1, sends: make(map[string]int)}
+	guard := &mockOTPAttemptGuard{maxFailures: 2, failures: make(map[string]int64)}
+	sink := &mockAuditSink{}
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithUserReaderForMessageHandler(reader),
+		WithVerificationHandlerForMessageHandler(handler),
+		WithPhoneLinkingChannelForMessageHandler(model.VerificationChannelWhatsApp),
+		WithEmailSendLimiterForMessageHandler(limiter),
+		WithOTPAttemptGuardForMessageHandler(guard),
+		WithAuditSinkForMessageHandler(sink),
+	)
+
+	call := func(handle func(context.Context, *mockTransportMessenger) ([]byte, error), message string) UserDataResponse {
+		t.Helper()
+		result, err := handle(ctx, &mockTransportMessenger{data: []byte(message)})
+		require.NoError(t, err)
+		var response UserDataResponse
+		require.NoError(t, json.Unmarshal(result, &response))
+		return response
+	}
+	start := func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error) {
+		return orchestrator.StartPhoneLinking(ctx, msg)
+	}
+	verify := func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error) {
+		return orchestrator.VerifyPhoneLinking(ctx, msg)
+	}
+
+	t.Run("start", func(t *testing.T) {
+		response := call(start, `{"user":{"auth_token":"user-token"},"phone_number":"+1 (415) 555-0123"}`)
+		require.True(t, response.Success, response.Error)
+		assert.Equal(t, map[string]any{"channel": "whatsapp"}, response.Data)
+		require.Len(t, handler.sent, 1)
+		assert.Equal(t, model.Verification{Channel: model.VerificationChannelWhatsApp, Recipient: "+14155550123"}, handler.sent[0])
+		assert.Equal(t, []string{"auth0|user123"}, limiter.users)
+
+		response = call(start, `{"user":{"auth_token":"user-token"},"phone_number":"+14155550123"}`)
+		assert.Equal(t, "too_many_requests", response.ErrorCode, "the sends are limited per phone number")
+		assert.Len(t, handler.sent, 1)
+	})
+
+	t.Run("start rejects invalid requests", func(t *testing.T) {
+		for message, want := range map[string]string{
+			`{"phone_number":"+14155550123"}`:                                  "auth_token is required",
+			`{"user":{"auth_token":"user-token"},"phone_number":"4155550123"}`: "invalid phone number, expected the E.164 format like +14155550123",
+			`{"user":{"auth_token":"user-token"}}`:                             "phone_number is required",
+			`{"user":{"auth_token":"forged"},"phone_number":"+14155550199"}`:   "invalid token",
+			`not json`: "failed to unmarshal phone linking request",
+			`{"user":{"auth_token":"user-token"},"phone_number":"+0123456789"}`: "invalid phone number, expected the E.164 format like +14155550123",
+		} {
+			response := call(start, message)
+			assert.False(t, response.Success, message)
+			assert.Equal(t, want, response.Error, message)
+		}
+		assert.Len(t, handler.sent, 1)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		response := call(verify, `{"phone_number":"+14155550123","otp":"123456"}`)
+		require.True(t, response.Success, response.Error)
+		assert.Equal(t, "sms-id-token", response.Data.(map[string]any)["id_token"])
+		assert.Equal(t, model.VerificationChannelWhatsApp, handler.verified[0].Channel)
+
+		response = call(verify, `{"phone_number":"+14155550123"}`)
+		assert.Equal(t, "otp is required", response.Error)
+	})
+
+	t.Run("verify locks the phone number after repeated failures", func(t *testing.T) {
+		for range 3 {
+			call(verify, `{"phone_number":"+14155550123","otp":"000000"}`)
+		}
+		response := call(verify, `{"phone_number":"+14155550123","otp":"123456"}`)
+		assert.Equal(t, "too_many_requests", response.ErrorCode)
+		assert.Len(t, handler.verified, 3, "the attempts on a locked phone number are not verified")
+
+		var lockouts []*model.AuditEvent
+		for _, event := range sink.events {
+			if event.Action == model.AuditActionPhoneLinkingLockout {
+				lockouts = append(lockouts, event)
+			}
+		}
+		require.Len(t, lockouts, 1)
+		assert.NotContains(t, lockouts[0].Target, "5550123")
+	})
+}
+
+func TestMessageHandlerOrchestrator_PhoneLinking_Disabled(t *testing.T) {
+	orchestrator := NewMessageHandlerOrchestrator()
+
+	result, err := orchestrator.StartPhoneLinking(context.Background(), &mockTransportMessenger{data: []byte(`{"phone_number":"+14155550123"}`)})
+	require.NoError(t, err)
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(result, &response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "phone linking unavailable", response.Error)
+}
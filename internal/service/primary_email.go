@@ -0,0 +1,177 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/emailnorm"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// verifiedEmailFromRef returns the email proven by a reference returned by the email verification.
+// The reference was sealed by the service after the verification of the OTP (or the backup code),
+// so the ID token it holds is trusted without verifying it again. Only the references bound to the
+// user are accepted, the email was verified by the same user.
+func (m *messageHandlerOrchestrator) verifiedEmailFromRef(ctx context.Context, ref, userID string) (string, error) {
+	if m.tokenRefSealer == nil {
+		return "", errs.NewValidation("token references are disabled")
+	}
+	if strings.TrimSpace(ref) == "" {
+		return "", errs.NewValidation("identity_token_ref is required")
+	}
+	if userID == "" {
+		return "", errs.NewValidation("invalid token reference")
+	}
+
+	token, errOpen := m.tokenRefSealer.Open(boundRefKind(idTokenRefKind, userID), ref)
+	if errOpen != nil {
+		if m.backupCodeStore != nil {
+			if email, errEmail := m.tokenRefSealer.Open(boundRefKind(verifiedEmailRefKind, userID), ref); errEmail == nil {
+				return strings.ToLower(email), nil
+			}
+		}
+		return "", errOpen
+	}
+
+	email, errEmail := jwt.ExtractEmail(ctx, token)
+	if errEmail != nil {
+		return "", errs.NewValidation("identity token does not contain a verified email")
+	}
+	return strings.ToLower(strings.TrimSpace(email)), nil
+}
+
+// checkEmailOwner rejects an email used by another account, as primary or alternate email
+func (m *messageHandlerOrchestrator) checkEmailOwner(ctx context.Context, email, userID string) error {
+	var notFound errs.NotFound
+	for _, criteria := range []constants.CriteriaType{constants.CriteriaTypeEmail, constants.CriteriaTypeAlternateEmail} {
+		owner, errSearch := m.searchByEmail(ctx, criteria, email)
+		if errSearch != nil && !errors.As(errSearch, &notFound) {
+			return errSearch
+		}
+		if owner != nil && owner.UserID != "" && owner.UserID != userID {
+			return errs.NewConflict("email is used by another account")
+		}
+	}
+	return nil
+}
+
+// notifyPrimaryEmailChanged sends a notice of the change to the previous primary email, so its owner
+// can recover the account when they didn't change it. The failures are only logged, the change is done.
+func (m *messageHandlerOrchestrator) notifyPrimaryEmailChanged(ctx context.Context, user *model.User, email string) {
+	if m.notificationSender == nil || user.PrimaryEmail == "" {
+		return
+	}
+	errSend := m.notificationSender.SendTemplatedEmail(ctx, constants.EmailTemplatePrimaryEmailChanged, user.PrimaryEmail, map[string]any{
+		"Username": user.Username,
+		"Email":    redaction.RedactEmail(email),
+	})
+	if errSend != nil {
+		slog.WarnContext(ctx, "failed to notify the previous primary email",
+			"error", errSend,
+			"user_id", redaction.Redact(user.UserID),
+		)
+	}
+}
+
+// ChangePrimaryEmail changes the primary email of the token bearer to an email verified with the
+// email linking flow
+func (m *messageHandlerOrchestrator) ChangePrimaryEmail(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ChangePrimaryEmail", msg)
+	defer span.End()
+
+	if m.primaryEmailChanger == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	request := &model.ChangePrimaryEmail{}
+	if err := json.Unmarshal(msg.Data(), request); err != nil {
+		return m.errorResponse("failed to unmarshal change primary email request"), nil
+	}
+
+	lookup, errMetadataLookup := m.userReader.MetadataLookup(ctx, request.User.AuthToken, constants.UserUpdateIdentityRequiredScope)
+	if errMetadataLookup != nil {
+		return m.errorResponseFromError(ctx, errMetadataLookup), nil
+	}
+	request.User.UserID = lookup.UserID
+
+	email, errEmail := m.verifiedEmailFromRef(ctx, request.IdentityTokenRef, lookup.UserID)
+	if errEmail != nil {
+		return m.errorResponseFromError(ctx, errEmail), nil
+	}
+
+	unlock, errLock := m.lockUsers(ctx, lookup.UserID, lookup.Sub)
+	if errLock != nil {
+		return m.errorResponseFromError(ctx, errLock), nil
+	}
+	defer unlock()
+
+	// the user token might not be allowed to read the full profile
+	user, errGetUser := m.userReader.GetUser(ctx, &model.User{UserID: lookup.UserID, Sub: lookup.Sub})
+	if errGetUser != nil {
+		return m.errorResponseFromError(ctx, errGetUser), nil
+	}
+	if emailnorm.Equal(user.PrimaryEmail, email) {
+		return m.errorResponseFromError(ctx, errs.NewValidation("email is already the primary email")), nil
+	}
+
+	if errOwner := m.checkEmailOwner(ctx, email, user.UserID); errOwner != nil {
+		return m.errorResponseFromError(ctx, errOwner), nil
+	}
+
+	audit := &model.AuditEvent{
+		Action: model.AuditActionPrimaryEmailChange,
+		Actor:  user.UserID,
+		Target: user.UserID,
+		Details: map[string]any{
+			"previous_email": redaction.RedactEmail(user.PrimaryEmail),
+			"email":          redaction.RedactEmail(email),
+		},
+	}
+
+	updated, errChange := m.primaryEmailChanger.ChangePrimaryEmail(ctx, &model.User{
+		UserID: user.UserID,
+		Sub:    user.Sub,
+		Token:  request.User.AuthToken,
+	}, email)
+	if errChange != nil {
+		audit.Fail(errChange)
+		m.publishAudit(ctx, audit)
+		return m.errorResponseFromError(ctx, errChange), nil
+	}
+	m.publishAudit(ctx, audit)
+
+	// the new email might be the one matching the organization domains, the badge is computed from
+	// the updated user since the cached one is only invalidated by the profile changed event
+	m.updateOrganizationVerified(ctx, request.User.AuthToken, updated)
+
+	m.publishProfileChanged(ctx, model.ProfileChangePrimaryEmail, user, updated)
+	m.notifyPrimaryEmailChanged(ctx, user, email)
+
+	slog.DebugContext(ctx, "primary email changed",
+		"user_id", redaction.Redact(user.UserID),
+		"email", redaction.RedactEmail(email),
+	)
+
+	response := UserDataResponse{
+		Success: true,
+		Message: "primary email changed successfully",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,284 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokenref"
+)
+
+type mockPrimaryEmailChanger struct {
+	user  *model.User
+	email string
+	err   error
+}
+
+func (m *mockPrimaryEmailChanger) ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.user, m.email = user, email
+	return &model.User{UserID: user.UserID, PrimaryEmail: email}, nil
+}
+
+type mockNotificationSender struct {
+	templates  []string
+	recipients []string
+}
+
+func (m *mockNotificationSender) SendTemplatedEmail(ctx context.Context, templateName, to string, data any) error {
+	m.templates = append(m.templates, templateName)
+	m.recipients = append(m.recipients, to)
+	return nil
+}
+
+func TestMessageHandlerOrchestrator_ChangePrimaryEmail(t *testing.T) {
+	ctx := context.Background()
+	sealer, err := tokenref.NewSealer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "email|abc",
+		"email": "Jane@Personal.example",
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	ref, _, err := sealer.Seal(boundRefKind(idTokenRefKind, "auth0|jane"), idToken)
+	require.NoError(t, err)
+	otherRef, _, err := sealer.Seal(boundRefKind(idTokenRefKind, "auth0|other"), idToken)
+	require.NoError(t, err)
+	unboundRef, _, err := sealer.Seal(idTokenRefKind, idToken)
+	require.NoError(t, err)
+
+	payload := func(ref string) []byte {
+		return []byte(`{"user":{"auth_token":"some-auth-token"},"identity_token_ref":"` + ref + `"}`)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		primary    string
+		owners     map[constants.CriteriaType]*model.User
+		changerErr error
+		wantErr    string
+		wantAudit  model.AuditOutcome
+	}{
+		{
+			name:      "verified email becomes the primary email",
+			data:      payload(ref),
+			primary:   "jane@example.com",
+			wantAudit: model.AuditOutcomeSuccess,
+		},
+		{
+			name:    "alternate email of the user becomes the primary email",
+			data:    payload(ref),
+			primary: "jane@example.com",
+			owners: map[constants.CriteriaType]*model.User{
+				constants.CriteriaTypeAlternateEmail: {UserID: "auth0|jane"},
+			},
+			wantAudit: model.AuditOutcomeSuccess,
+		},
+		{
+			name:    "email of another account",
+			data:    payload(ref),
+			primary: "jane@example.com",
+			owners: map[constants.CriteriaType]*model.User{
+				constants.CriteriaTypeEmail: {UserID: "auth0|other"},
+			},
+			wantErr: "email is used by another account",
+		},
+		{
+			name:    "already the primary email",
+			data:    payload(ref),
+			primary: "jane@personal.example",
+			wantErr: "email is already the primary email",
+		},
+		{
+			name:    "reference required",
+			data:    payload(""),
+			primary: "jane@example.com",
+			wantErr: "identity_token_ref is required",
+		},
+		{
+			name:    "reference of another user",
+			data:    payload(otherRef),
+			primary: "jane@example.com",
+			wantErr: "invalid token reference",
+		},
+		{
+			name:    "reference not bound to a user",
+			data:    payload(unboundRef),
+			primary: "jane@example.com",
+			wantErr: "invalid token reference",
+		},
+		{
+			name:    "raw ID token is not a reference",
+			data:    payload(idToken),
+			primary: "jane@example.com",
+			wantErr: "invalid token reference",
+		},
+		{
+			name:       "provider failure is audited",
+			data:       payload(ref),
+			primary:    "jane@example.com",
+			changerErr: errs.NewValidation("the primary email of the account is managed by its identity provider"),
+			wantErr:    "the primary email of the account is managed by its identity provider",
+			wantAudit:  model.AuditOutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changer := &mockPrimaryEmailChanger{err: tt.changerErr}
+			reader := &mockUserServiceReader{
+				metadataLookupFunc: func(_ context.Context, input string) (*model.User, error) {
+					assert.Equal(t, "some-auth-token", input)
+					return &model.User{UserID: "auth0|jane", Sub: "auth0|jane"}, nil
+				},
+				getUserFunc: func(_ context.Context, user *model.User) (*model.User, error) {
+					return &model.User{UserID: user.UserID, Sub: user.Sub, PrimaryEmail: tt.primary}, nil
+				},
+				searchUserFunc: func(_ context.Context, _ *model.User, criteria constants.CriteriaType) (*model.User, error) {
+					if owner, ok := tt.owners[criteria]; ok {
+						return owner, nil
+					}
+					return nil, errs.NewNotFound("user not found")
+				},
+			}
+			sink := &mockAuditSink{}
+			notifier := &mockNotificationSender{}
+
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserReaderForMessageHandler(reader),
+				WithTokenReferenceSealerForMessageHandler(sealer),
+				WithPrimaryEmailChangerForMessageHandler(changer),
+				WithAuditSinkForMessageHandler(sink),
+				WithNotificationSenderForMessageHandler(notifier),
+			)
+
+			result, err := orchestrator.ChangePrimaryEmail(ctx, &mockTransportMessenger{data: tt.data})
+			require.NoError(t, err)
+
+			var response UserDataResponse
+			require.NoError(t, json.Unmarshal(result, &response))
+			if tt.wantErr != "" {
+				assert.False(t, response.Success)
+				assert.Equal(t, tt.wantErr, response.Error)
+				assert.Empty(t, notifier.recipients, "no notice without a change")
+			} else {
+				assert.True(t, response.Success, response.Error)
+				assert.Equal(t, "jane@personal.example", changer.email)
+				require.NotNil(t, changer.user)
+				assert.Equal(t, "auth0|jane", changer.user.UserID)
+				assert.Equal(t, []string{tt.primary}, notifier.recipients, "the previous primary email is notified")
+				assert.Equal(t, []string{constants.EmailTemplatePrimaryEmailChanged}, notifier.templates)
+			}
+
+			if tt.wantAudit == "" {
+				assert.Empty(t, sink.events)
+				return
+			}
+			require.Len(t, sink.events, 1)
+			assert.Equal(t, model.AuditActionPrimaryEmailChange, sink.events[0].Action)
+			assert.Equal(t, tt.wantAudit, sink.events[0].Outcome)
+			assert.Equal(t, "auth0|jane", sink.events[0].Target)
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_ChangePrimaryEmail_Unavailable(t *testing.T) {
+	ctx := context.Background()
+
+	// the references are required, the raw ID tokens are never trusted
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithUserReaderForMessageHandler(&mockUserServiceReader{}),
+		WithPrimaryEmailChangerForMessageHandler(&mockPrimaryEmailChanger{}),
+	)
+	result, err := orchestrator.ChangePrimaryEmail(ctx, &mockTransportMessenger{data: []byte(`{"identity_token_ref":"ref"}`)})
+	require.NoError(t, err)
+	assertErrorResponse(t, result, "token references are disabled")
+
+	orchestrator = NewMessageHandlerOrchestrator(WithUserReaderForMessageHandler(&mockUserServiceReader{}))
+	result, err = orchestrator.ChangePrimaryEmail(ctx, &mockTransportMessenger{data: []byte(`{}`)})
+	require.NoError(t, err)
+	assertErrorResponse(t, result, "auth service unavailable")
+}
+
+func TestMessageHandlerOrchestrator_ChangePrimaryEmail_OrganizationVerified(t *testing.T) {
+	ctx := context.Background()
+	sealer, err := tokenref.NewSealer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "email|abc",
+		"email": "jane@cncf.io",
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	ref, _, err := sealer.Seal(boundRefKind(idTokenRefKind, "auth0|jane"), idToken)
+	require.NoError(t, err)
+
+	// the cached user still has the previous primary email
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(_ context.Context, _ string) (*model.User, error) {
+			return &model.User{UserID: "auth0|jane", Sub: "auth0|jane"}, nil
+		},
+		getUserFunc: func(_ context.Context, user *model.User) (*model.User, error) {
+			return &model.User{UserID: user.UserID, Sub: user.Sub, PrimaryEmail: "jane@example.com", PrimaryEmailVerified: true}, nil
+		},
+		searchUserFunc: func(_ context.Context, _ *model.User, _ constants.CriteriaType) (*model.User, error) {
+			return nil, errs.NewNotFound("user not found")
+		},
+	}
+	var badge *model.User
+	writer := &mockUserServiceWriter{
+		updateUserFunc: func(_ context.Context, user *model.User) (*model.User, error) {
+			badge = user
+			return user, nil
+		},
+	}
+	changer := &updatedUserChanger{organization: "CNCF"}
+
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithUserReaderForMessageHandler(reader),
+		WithUserWriterForMessageHandler(writer),
+		WithTokenReferenceSealerForMessageHandler(sealer),
+		WithPrimaryEmailChangerForMessageHandler(changer),
+		WithOrganizationDomainsForMessageHandler(model.OrganizationDomains{"cncf": {"cncf.io"}}),
+	)
+
+	data := []byte(`{"user":{"auth_token":"some-auth-token"},"identity_token_ref":"` + ref + `"}`)
+	result, err := orchestrator.ChangePrimaryEmail(ctx, &mockTransportMessenger{data: data})
+	require.NoError(t, err)
+
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(result, &response))
+	require.True(t, response.Success, response.Error)
+	require.NotNil(t, badge, "the badge is computed from the updated user")
+	require.NotNil(t, badge.UserMetadata.OrganizationVerified)
+	assert.True(t, *badge.UserMetadata.OrganizationVerified)
+}
+
+// updatedUserChanger returns the full user after the change, as the identity providers do
+type updatedUserChanger struct {
+	organization string
+}
+
+func (u *updatedUserChanger) ChangePrimaryEmail(ctx context.Context, user *model.User, email string) (*model.User, error) {
+	return &model.User{
+		UserID:               user.UserID,
+		Sub:                  user.Sub,
+		PrimaryEmail:         email,
+		PrimaryEmailVerified: true,
+		UserMetadata:         &model.UserMetadata{Organization: &u.organization},
+	}, nil
+}
@@ -0,0 +1,52 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// publishProfileChanged emits the profile changed event of the first user with an identifier,
+// the change is already done so a failure is only logged
+func (m *messageHandlerOrchestrator) publishProfileChanged(ctx context.Context, reason model.ProfileChangeReason, users ...*model.User) {
+	if m.eventPublisher == nil {
+		return
+	}
+
+	var sub string
+	for _, user := range users {
+		if user != nil && user.ProfileSub() != "" {
+			sub = user.ProfileSub()
+			break
+		}
+	}
+	if sub == "" {
+		slog.WarnContext(ctx, "profile changed event skipped, the user is unknown", "reason", reason)
+		return
+	}
+
+	data, errMarshal := json.Marshal(&model.UserProfileChanged{
+		Sub:       sub,
+		Reason:    reason,
+		ChangedAt: m.now(),
+	})
+	if errMarshal != nil {
+		slog.ErrorContext(ctx, "failed to marshal profile changed event", "error", errMarshal)
+		return
+	}
+
+	if errPublish := m.eventPublisher.Publish(ctx, constants.UserProfileChangedSubject, data); errPublish != nil {
+		slog.ErrorContext(ctx, "failed to publish profile changed event",
+			"error", errPublish,
+			"sub", redaction.Redact(sub),
+			"reason", reason,
+		)
+	}
+}
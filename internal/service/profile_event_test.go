@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestMessageHandlerOrchestrator_UpdateUser_ProfileChanged(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"token":"token","user_metadata":{"name":"John Doe"}}`)
+
+	t.Run("publishes the change of the user", func(t *testing.T) {
+		publisher := &mockEventPublisher{}
+		orchestrator := &messageHandlerOrchestrator{
+			userWriter: &mockUserServiceWriter{
+				updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+					user.UserID = "auth0|123"
+					return user, nil
+				},
+			},
+			eventPublisher: publisher,
+		}
+
+		if _, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: payload}); err != nil {
+			t.Fatalf("UpdateUser() unexpected error: %v", err)
+		}
+
+		var event model.UserProfileChanged
+		if err := json.Unmarshal(publisher.events[constants.UserProfileChangedSubject], &event); err != nil {
+			t.Fatalf("failed to unmarshal event: %v", err)
+		}
+		if event.Sub != "auth0|123" || event.Reason != model.ProfileChangeUpdate || event.ChangedAt.IsZero() {
+			t.Errorf("published %+v", event)
+		}
+	})
+
+	t.Run("only the failure audited when the update fails", func(t *testing.T) {
+		publisher := &mockEventPublisher{}
+		orchestrator := &messageHandlerOrchestrator{
+			userWriter: &mockUserServiceWriter{
+				updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+					return nil, errs.NewUnexpected("failed to update user")
+				},
+			},
+			eventPublisher: publisher,
+		}
+
+		if _, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: payload}); err != nil {
+			t.Fatalf("UpdateUser() unexpected error: %v", err)
+		}
+		if _, published := publisher.events[constants.UserProfileChangedSubject]; published {
+			t.Errorf("published %v, want no profile change", publisher.events)
+		}
+		var audit model.AuditEvent
+		if err := json.Unmarshal(publisher.events[constants.AuditEventSubject], &audit); err != nil {
+			t.Fatalf("failed to unmarshal audit event: %v", err)
+		}
+		if audit.Action != model.AuditActionUserUpdate || audit.Outcome != model.AuditOutcomeFailure {
+			t.Errorf("audited %+v", audit)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// profileShareRequest is the request of a profile share link, the user is a token, a sub or a username
+type profileShareRequest struct {
+	User      string `json:"user"`
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
+
+// profileShareResponse is a profile share link, the URL is only set when the base URL is configured
+type profileShareResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateProfileShareLink signs a link granting read access to the shared view of a profile until it
+// expires, e.g. for an event page showing the speaker profiles to anonymous visitors
+func (m *messageHandlerOrchestrator) CreateProfileShareLink(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "CreateProfileShareLink", msg)
+	defer span.End()
+
+	if m.profileLinkSigner == nil {
+		return m.errorResponse("profile share links are disabled"), nil
+	}
+	if m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	var request profileShareRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+
+	var ttl time.Duration
+	if value := strings.TrimSpace(request.ExpiresIn); value != "" {
+		parsed, errParse := time.ParseDuration(value)
+		if errParse != nil {
+			return m.errorResponse("invalid expires_in, expected a duration like 24h"), nil
+		}
+		ttl = parsed
+	}
+
+	user, errLookup := m.lookupUser(ctx, request.User)
+	if errLookup != nil {
+		return m.errorResponseFromError(ctx, errLookup), nil
+	}
+
+	token, expiresAt, errSign := m.profileLinkSigner.Sign(user.ProfileSub(), ttl)
+	if errSign != nil {
+		return m.errorResponseFromError(ctx, errSign), nil
+	}
+
+	slog.InfoContext(ctx, "profile share link created",
+		"sub", redaction.Redact(user.ProfileSub()),
+		"caller", callerFromContext(ctx),
+		"expires_at", expiresAt,
+	)
+
+	link := profileShareResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.UTC(),
+	}
+	if m.profileShareBaseURL != "" {
+		link.URL = m.profileShareBaseURL + "/" + token
+	}
+
+	responseJSON, errMarshal := json.Marshal(UserDataResponse{
+		Success: true,
+		Data:    link,
+	})
+	if errMarshal != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
+
+// ResolveProfileShareLink returns the shared view of the profile of a share link token, the
+// invalid, expired and deleted profile links can't be told apart
+func (m *messageHandlerOrchestrator) ResolveProfileShareLink(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ResolveProfileShareLink", msg)
+	defer span.End()
+
+	if m.profileLinkSigner == nil {
+		return m.errorResponse("profile share links are disabled"), nil
+	}
+	if m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	var (
+		validation errs.Validation
+		notFound   errs.NotFound
+	)
+
+	sub, errVerify := m.profileLinkSigner.Verify(string(msg.Data()))
+	if errVerify != nil {
+		slog.DebugContext(ctx, "profile share link rejected", "error", errVerify)
+		return m.errorResponseFromError(ctx, errs.NewNotFound("profile not found")), nil
+	}
+
+	user, errLookup := m.lookupUser(ctx, sub)
+	switch {
+	case errLookup == nil:
+	case errors.As(errLookup, &validation), errors.As(errLookup, &notFound):
+		return m.errorResponseFromError(ctx, errs.NewNotFound("profile not found")), nil
+	default:
+		slog.ErrorContext(ctx, "error resolving profile share link",
+			"error", errLookup,
+			"sub", redaction.Redact(sub),
+		)
+		return m.errorResponseFromError(ctx, errLookup), nil
+	}
+
+	responseJSON, errMarshal := json.Marshal(UserDataResponse{
+		Success: true,
+		Data:    user.ProfileDocument(m.now()).SharedProfile(),
+	})
+	if errMarshal != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/sharelink"
+)
+
+func TestMessageHandlerOrchestrator_ProfileShareLinks(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	signer, err := sharelink.NewSigner([]byte("0123456789abcdef0123456789abcdef"), sharelink.WithClock(fake))
+	if err != nil {
+		t.Fatalf("NewSigner() unexpected error: %v", err)
+	}
+
+	jane := &model.User{
+		UserID:       "auth0|jane",
+		Username:     "jdoe",
+		PrimaryEmail: "jane@example.com",
+		UserMetadata: &model.UserMetadata{
+			Name:        converters.StringPtr("Jane Doe"),
+			JobTitle:    converters.StringPtr("Maintainer"),
+			PhoneNumber: converters.StringPtr("+351 000 000 000"),
+		},
+	}
+	var deleted bool
+
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			if user.UserID == jane.UserID && !deleted {
+				return jane, nil
+			}
+			if user.UserID == "auth0|down" {
+				return nil, errs.NewServiceUnavailable("auth0 unavailable")
+			}
+			return nil, errs.NewNotFound("user not found")
+		},
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			if criteria == constants.CriteriaTypeUsername && user.Username == jane.Username {
+				return jane, nil
+			}
+			return nil, errs.NewNotFound("user not found")
+		},
+	}
+
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithUserReaderForMessageHandler(reader),
+		WithProfileLinkSignerForMessageHandler(signer, "https://lfx.example.org/profiles/shared/"),
+		WithClockForMessageHandler(fake),
+	)
+
+	create := func(t *testing.T, data string) (profileShareResponse, string) {
+		t.Helper()
+		response, err := orchestrator.CreateProfileShareLink(ctx, &mockTransportMessenger{data: []byte(data)})
+		if err != nil {
+			t.Fatalf("CreateProfileShareLink() unexpected error: %v", err)
+		}
+		var got struct {
+			Success bool                 `json:"success"`
+			Error   string               `json:"error"`
+			Data    profileShareResponse `json:"data"`
+		}
+		if err := json.Unmarshal(response, &got); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return got.Data, got.Error
+	}
+
+	resolve := func(t *testing.T, token string) (model.SharedProfile, string) {
+		t.Helper()
+		response, err := orchestrator.ResolveProfileShareLink(ctx, &mockTransportMessenger{data: []byte(token)})
+		if err != nil {
+			t.Fatalf("ResolveProfileShareLink() unexpected error: %v", err)
+		}
+		var got struct {
+			Success bool                `json:"success"`
+			Error   string              `json:"error"`
+			Data    model.SharedProfile `json:"data"`
+		}
+		if err := json.Unmarshal(response, &got); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return got.Data, got.Error
+	}
+
+	t.Run("create and resolve", func(t *testing.T) {
+		link, errMessage := create(t, `{"user": "jdoe", "expires_in": "1h"}`)
+		if errMessage != "" {
+			t.Fatalf("CreateProfileShareLink() error = %q", errMessage)
+		}
+		if link.URL != "https://lfx.example.org/profiles/shared/"+link.Token {
+			t.Errorf("CreateProfileShareLink() url = %q", link.URL)
+		}
+		if !link.ExpiresAt.Equal(fake.Now().Add(time.Hour)) {
+			t.Errorf("CreateProfileShareLink() expires at %v", link.ExpiresAt)
+		}
+
+		profile, errMessage := resolve(t, link.Token)
+		want := model.SharedProfile{Username: "jdoe", Name: "Jane Doe", JobTitle: "Maintainer"}
+		if errMessage != "" || profile != want {
+			t.Errorf("ResolveProfileShareLink() = %+v, %q, want %+v", profile, errMessage, want)
+		}
+
+		fake.Advance(time.Hour)
+		defer fake.Advance(-time.Hour)
+		if _, errMessage := resolve(t, link.Token); errMessage != "profile not found" {
+			t.Errorf("ResolveProfileShareLink() of an expired link error = %q", errMessage)
+		}
+	})
+
+	t.Run("deleted profile", func(t *testing.T) {
+		link, _ := create(t, `{"user": "auth0|jane"}`)
+		deleted = true
+		defer func() { deleted = false }()
+
+		if _, errMessage := resolve(t, link.Token); errMessage != "profile not found" {
+			t.Errorf("ResolveProfileShareLink() of a deleted profile error = %q", errMessage)
+		}
+	})
+
+	t.Run("provider unavailable", func(t *testing.T) {
+		token, _, _ := signer.Sign("auth0|down", time.Hour)
+		if _, errMessage := resolve(t, token); errMessage != "auth0 unavailable" {
+			t.Errorf("ResolveProfileShareLink() error = %q", errMessage)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		data      string
+		wantError string
+	}{
+		{name: "invalid duration", data: `{"user": "jdoe", "expires_in": "soon"}`, wantError: "invalid expires_in, expected a duration like 24h"},
+		{name: "too long", data: `{"user": "jdoe", "expires_in": "720h"}`, wantError: "share link duration must be at most 168h0m0s"},
+		{name: "unknown user", data: `{"user": "nobody"}`, wantError: "user not found"},
+		{name: "no user", data: `{}`, wantError: "input is required"},
+		{name: "not an object", data: `"jdoe"`, wantError: "failed to unmarshal request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, errMessage := create(t, tt.data); errMessage != tt.wantError {
+				t.Errorf("CreateProfileShareLink() error = %q, want %q", errMessage, tt.wantError)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := NewMessageHandlerOrchestrator(WithUserReaderForMessageHandler(reader))
+		response, _ := disabled.ResolveProfileShareLink(ctx, &mockTransportMessenger{data: []byte("token")})
+		var got UserDataResponse
+		if err := json.Unmarshal(response, &got); err != nil || got.Error != "profile share links are disabled" {
+			t.Errorf("ResolveProfileShareLink() = %s", response)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// enrichmentResponse is the outcome of an enrichment, the skipped fields were entered by a person or
+// already set
+type enrichmentResponse struct {
+	Applied []string `json:"applied"`
+	Skipped []string `json:"skipped"`
+}
+
+// recordProvenance records the provenance of the fields set or cleared by an update. The update is
+// already stored, a failure is logged and the fields are left without provenance.
+func (m *messageHandlerOrchestrator) recordProvenance(ctx context.Context, userID string, fields []string, source model.ProvenanceSource, actor string) {
+	if m.provenanceStore == nil || userID == "" || len(fields) == 0 {
+		return
+	}
+
+	provenance := model.FieldProvenance{
+		Source:    source,
+		Actor:     actor,
+		UpdatedAt: m.now(),
+	}
+	if err := m.provenanceStore.RecordProvenance(ctx, userID, fields, provenance); err != nil {
+		slog.WarnContext(ctx, "failed to record the metadata provenance",
+			"error", err,
+			"user_id", redaction.Redact(userID),
+			"source", source,
+			"fields", fields,
+		)
+	}
+}
+
+// EnrichUserMetadata fills the metadata of a user on behalf of an enrichment job, the fields entered
+// by the user or an admin, or set before the provenance was tracked, are never overwritten
+func (m *messageHandlerOrchestrator) EnrichUserMetadata(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "EnrichUserMetadata", msg)
+	defer span.End()
+
+	if m.metadataEnricher == nil || m.provenanceStore == nil || m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	if !m.callerGranted(ctx, m.enrichmentCallers, model.CallerCapabilityMetadataEnrichment) {
+		return m.errorResponseFromError(ctx, errs.NewForbidden("caller is not allowed to enrich the user metadata")), nil
+	}
+
+	request := &model.EnrichUserMetadata{}
+	if err := json.Unmarshal(msg.Data(), request); err != nil {
+		return m.errorResponse("failed to unmarshal user data"), nil
+	}
+
+	(&model.User{UserMetadata: request.UserMetadata}).UserSanitize()
+
+	if err := request.Validate(); err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	// the enriched values go through the same moderation as the ones entered by the user
+	moderated, errModerate := m.moderateMetadata(ctx, request.UserMetadata)
+	if errModerate != nil {
+		return m.errorResponseFromError(ctx, errModerate), nil
+	}
+	if len(moderated) > 0 {
+		return m.validationErrorResponse(ctx, moderated), nil
+	}
+
+	member, errLookupUser := m.lookupUser(ctx, request.User)
+	if errLookupUser != nil {
+		return m.errorResponseFromError(ctx, errLookupUser), nil
+	}
+
+	// the provenance is read under the lock, a user update can't slip in between
+	unlock, errLock := m.lockUsers(ctx, member.UserID, member.Sub)
+	if errLock != nil {
+		return m.errorResponseFromError(ctx, errLock), nil
+	}
+	defer unlock()
+
+	// the current values are read again under the lock, the ones already set are kept
+	current, errGetUser := m.userReader.GetUser(ctx, member)
+	if errGetUser != nil {
+		return m.errorResponseFromError(ctx, errGetUser), nil
+	}
+
+	provenance, errProvenance := m.provenanceStore.GetProvenance(ctx, member.UserID)
+	if errProvenance != nil {
+		return m.errorResponseFromError(ctx, errProvenance), nil
+	}
+
+	result := enrichmentResponse{
+		Applied: []string{},
+		Skipped: provenance.Protected(request.UserMetadata.Fields(), current.UserMetadata),
+	}
+	if result.Skipped == nil {
+		result.Skipped = []string{}
+	}
+	metadata := request.UserMetadata.Without(result.Skipped)
+	result.Applied = append(result.Applied, metadata.Fields()...)
+
+	if len(result.Applied) > 0 {
+		if metadata.Organization != nil && len(m.organizationDomains) > 0 {
+			verified := current.OrganizationVerified(m.organizationDomains, *metadata.Organization)
+			metadata.OrganizationVerified = &verified
+		}
+
+		_, errEnrich := m.metadataEnricher.EnrichUserMetadata(ctx, &model.User{
+			UserID:       member.UserID,
+			Sub:          member.Sub,
+			Username:     member.Username,
+			UserMetadata: metadata,
+		})
+		if errEnrich != nil {
+			return m.errorResponseFromError(ctx, errEnrich), nil
+		}
+
+		caller := callerFromContext(ctx)
+		m.recordProvenance(ctx, member.UserID, result.Applied, model.ProvenanceSourceEnrichment, caller)
+		m.publishAudit(ctx, &model.AuditEvent{
+			Action: model.AuditActionEnrichment,
+			Actor:  caller,
+			Target: member.UserID,
+			Details: map[string]any{
+				"fields":  result.Applied,
+				"skipped": result.Skipped,
+			},
+		})
+		m.publishProfileChanged(ctx, model.ProfileChangeEnrichment, member)
+	}
+
+	slog.DebugContext(ctx, "user metadata enriched",
+		"user_id", redaction.Redact(member.UserID),
+		"applied", result.Applied,
+		"skipped", result.Skipped,
+	)
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    result,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,242 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockProvenanceStore struct {
+	provenance map[string]model.MetadataProvenance
+}
+
+func (m *mockProvenanceStore) GetProvenance(ctx context.Context, userID string) (model.MetadataProvenance, error) {
+	provenance := model.MetadataProvenance{}
+	for field, origin := range m.provenance[userID] {
+		provenance[field] = origin
+	}
+	return provenance, nil
+}
+
+func (m *mockProvenanceStore) RecordProvenance(ctx context.Context, userID string, fields []string, provenance model.FieldProvenance) error {
+	if m.provenance[userID] == nil {
+		m.provenance[userID] = model.MetadataProvenance{}
+	}
+	m.provenance[userID].Record(fields, provenance)
+	return nil
+}
+
+type mockMetadataEnricher struct {
+	enriched *model.User
+}
+
+func (m *mockMetadataEnricher) EnrichUserMetadata(ctx context.Context, user *model.User) (*model.User, error) {
+	m.enriched = user
+	return user, nil
+}
+
+func TestMessageHandlerOrchestrator_EnrichUserMetadata(t *testing.T) {
+	at := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		caller      string
+		provenance  model.MetadataProvenance
+		current     *model.UserMetadata
+		policy      *fakeMetadataPolicy
+		metadata    *model.UserMetadata
+		wantError   string
+		wantCode    string
+		wantApplied []string
+		wantSkipped []string
+	}{
+		{
+			name:   "fields without provenance are filled",
+			caller: "enrichment-job",
+			metadata: &model.UserMetadata{
+				JobTitle: converters.StringPtr("Maintainer"),
+				City:     converters.StringPtr("Lisbon"),
+			},
+			wantApplied: []string{"job_title", "city"},
+			wantSkipped: []string{},
+		},
+		{
+			name:   "values entered by a person are kept",
+			caller: "enrichment-job",
+			provenance: model.MetadataProvenance{
+				"job_title": {Source: model.ProvenanceSourceUser, Actor: "auth0|jane", UpdatedAt: at},
+				"city":      {Source: model.ProvenanceSourceEnrichment, Actor: "enrichment-job", UpdatedAt: at},
+				"country":   {Source: model.ProvenanceSourceAdmin, Actor: "auth0|admin", UpdatedAt: at},
+			},
+			metadata: &model.UserMetadata{
+				JobTitle: converters.StringPtr("Maintainer"),
+				City:     converters.StringPtr("Lisbon"),
+				Country:  converters.StringPtr("Portugal"),
+			},
+			wantApplied: []string{"city"},
+			wantSkipped: []string{"country", "job_title"},
+		},
+		{
+			name:   "values set before the provenance was tracked are kept",
+			caller: "enrichment-job",
+			provenance: model.MetadataProvenance{
+				"city": {Source: model.ProvenanceSourceEnrichment, Actor: "enrichment-job", UpdatedAt: at},
+			},
+			current: &model.UserMetadata{
+				JobTitle: converters.StringPtr("Engineer"),
+				City:     converters.StringPtr("Porto"),
+				Country:  converters.StringPtr(""),
+			},
+			metadata: &model.UserMetadata{
+				JobTitle: converters.StringPtr("Maintainer"),
+				City:     converters.StringPtr("Lisbon"),
+				Country:  converters.StringPtr("Portugal"),
+			},
+			wantApplied: []string{"city", "country"},
+			wantSkipped: []string{"job_title"},
+		},
+		{
+			name:   "nothing left to fill",
+			caller: "enrichment-job",
+			provenance: model.MetadataProvenance{
+				"job_title": {Source: model.ProvenanceSourceUser, UpdatedAt: at},
+			},
+			metadata:    &model.UserMetadata{JobTitle: converters.StringPtr("Maintainer")},
+			wantApplied: []string{},
+			wantSkipped: []string{"job_title"},
+		},
+		{
+			name:      "caller not allowed",
+			caller:    "search-service",
+			metadata:  &model.UserMetadata{JobTitle: converters.StringPtr("Maintainer")},
+			wantError: "caller is not allowed to enrich the user metadata",
+			wantCode:  errs.CodeForbidden,
+		},
+		{
+			name:   "rejected by the moderation policy",
+			caller: "enrichment-job",
+			policy: &fakeMetadataPolicy{decisions: []model.ModerationDecision{
+				{Field: "job_title", Action: model.ModerationActionReject, Reason: "links are not allowed"},
+			}},
+			metadata:  &model.UserMetadata{JobTitle: converters.StringPtr("https://spam.example")},
+			wantError: "job_title is not allowed: links are not allowed",
+			wantCode:  errs.CodeValidation,
+		},
+		{
+			name:      "derived field",
+			caller:    "enrichment-job",
+			metadata:  &model.UserMetadata{OrganizationVerified: converters.BoolPtr(true)},
+			wantError: "organization_verified can't be enriched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockProvenanceStore{provenance: map[string]model.MetadataProvenance{}}
+			if tt.provenance != nil {
+				store.provenance["auth0|jane"] = tt.provenance
+			}
+			member := &model.User{UserID: "auth0|jane", Username: "jane", UserMetadata: tt.current}
+			reader := &mockUserServiceReader{
+				getUserFunc: func(_ context.Context, _ *model.User) (*model.User, error) {
+					return member, nil
+				},
+				searchUserFunc: func(_ context.Context, _ *model.User, _ constants.CriteriaType) (*model.User, error) {
+					return member, nil
+				},
+			}
+			enricher := &mockMetadataEnricher{}
+			options := []messageHandlerOrchestratorOption{
+				WithUserReaderForMessageHandler(reader),
+				WithMetadataProvenanceStoreForMessageHandler(store),
+				WithMetadataEnricherForMessageHandler(enricher, "enrichment-job"),
+				WithClockForMessageHandler(clock.NewFake(at.Add(time.Hour))),
+			}
+			if tt.policy != nil {
+				options = append(options, WithMetadataPolicyForMessageHandler(tt.policy))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(options...)
+
+			data, err := json.Marshal(model.EnrichUserMetadata{User: "jane", UserMetadata: tt.metadata})
+			require.NoError(t, err)
+			result, err := orchestrator.EnrichUserMetadata(ContextWithCaller(context.Background(), tt.caller), &mockTransportMessenger{data: data})
+			require.NoError(t, err)
+
+			var response struct {
+				Success   bool               `json:"success"`
+				Error     string             `json:"error"`
+				ErrorCode string             `json:"error_code"`
+				Data      enrichmentResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(result, &response))
+			if tt.wantError != "" {
+				assert.False(t, response.Success)
+				assert.Equal(t, tt.wantError, response.Error)
+				if tt.wantCode != "" {
+					assert.Equal(t, tt.wantCode, response.ErrorCode)
+				}
+				assert.Nil(t, enricher.enriched)
+				return
+			}
+			require.True(t, response.Success, response.Error)
+			assert.ElementsMatch(t, tt.wantApplied, response.Data.Applied)
+			assert.Equal(t, tt.wantSkipped, response.Data.Skipped)
+
+			if len(tt.wantApplied) == 0 {
+				assert.Nil(t, enricher.enriched)
+				return
+			}
+			require.NotNil(t, enricher.enriched)
+			assert.ElementsMatch(t, tt.wantApplied, enricher.enriched.UserMetadata.Fields())
+			for _, field := range tt.wantApplied {
+				assert.Equal(t, model.FieldProvenance{
+					Source:    model.ProvenanceSourceEnrichment,
+					Actor:     "enrichment-job",
+					UpdatedAt: at.Add(time.Hour),
+				}, store.provenance["auth0|jane"][field])
+			}
+			for _, field := range tt.wantSkipped {
+				assert.Equal(t, tt.provenance[field], store.provenance["auth0|jane"][field])
+			}
+		})
+	}
+}
+
+func TestMessageHandlerOrchestrator_UpdateUser_RecordsProvenance(t *testing.T) {
+	store := &mockProvenanceStore{provenance: map[string]model.MetadataProvenance{}}
+	writer := &mockUserServiceWriter{
+		updateUserFunc: func(_ context.Context, user *model.User) (*model.User, error) {
+			return &model.User{UserID: "auth0|jane", UserMetadata: user.UserMetadata}, nil
+		},
+	}
+	orchestrator := NewMessageHandlerOrchestrator(
+		WithUserWriterForMessageHandler(writer),
+		WithMetadataProvenanceStoreForMessageHandler(store),
+	)
+
+	data := []byte(`{"token":"some-token","user_metadata":{"job_title":"Maintainer"},"clear_fields":["city"]}`)
+	result, err := orchestrator.UpdateUser(context.Background(), &mockTransportMessenger{data: data})
+	require.NoError(t, err)
+
+	var response UserDataResponse
+	require.NoError(t, json.Unmarshal(result, &response))
+	require.True(t, response.Success, response.Error)
+
+	provenance := store.provenance["auth0|jane"]
+	assert.Len(t, provenance, 2)
+	assert.Equal(t, model.ProvenanceSourceUser, provenance["job_title"].Source)
+	assert.Equal(t, model.ProvenanceSourceUser, provenance["city"].Source)
+}
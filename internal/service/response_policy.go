@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+// applyResponsePolicy removes from the reply data the fields the caller must never see,
+// at any depth, the data is returned untouched when the caller has no policy
+func (m *messageHandlerOrchestrator) applyResponsePolicy(ctx context.Context, data any) any {
+	mask := m.responsePolicies.Mask(callerFromContext(ctx))
+	if len(mask) == 0 || data == nil {
+		return data
+	}
+
+	raw, errMarshal := json.Marshal(data)
+	if errMarshal != nil {
+		// fail closed, the data can't be filtered
+		slog.ErrorContext(ctx, "failed to apply the response policy", "error", errMarshal)
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if errDecode := decoder.Decode(&generic); errDecode != nil {
+		slog.ErrorContext(ctx, "failed to apply the response policy", "error", errDecode)
+		return nil
+	}
+
+	return maskFields(generic, mask)
+}
+
+func maskFields(value any, mask map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for field, nested := range v {
+			if _, masked := mask[field]; masked {
+				delete(v, field)
+				continue
+			}
+			v[field] = maskFields(nested, mask)
+		}
+	case []any:
+		for i := range v {
+			v[i] = maskFields(v[i], mask)
+		}
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+func TestMessageHandlerOrchestrator_ResponsePolicies(t *testing.T) {
+	policies, err := model.ParseResponsePolicies("reporting-service=primary_email,email,phone_number")
+	if err != nil {
+		t.Fatalf("ParseResponsePolicies() unexpected error: %v", err)
+	}
+
+	phone := "+1 555 0100"
+	name := "John Doe"
+	orchestrator := &messageHandlerOrchestrator{
+		userReader: &mockUserServiceReader{
+			getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+				return &model.User{
+					UserID:          "auth0|123",
+					PrimaryEmail:    "jdoe@example.com",
+					AlternateEmails: []model.Email{{Email: "john@example.org", Verified: true}},
+					UserMetadata:    &model.UserMetadata{Name: &name, PhoneNumber: &phone},
+				}, nil
+			},
+		},
+		responsePolicies: policies,
+	}
+
+	tests := []struct {
+		name    string
+		caller  string
+		handler func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error)
+		masked  []string
+		kept    []string
+	}{
+		{
+			name:   "metadata without the phone number",
+			caller: "Reporting-Service",
+			handler: func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error) {
+				return orchestrator.GetUserMetadata(ctx, msg)
+			},
+			masked: []string{"phone_number"},
+			kept:   []string{"name"},
+		},
+		{
+			name:   "emails masked at any depth",
+			caller: "reporting-service",
+			handler: func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error) {
+				return orchestrator.GetUserEmails(ctx, msg)
+			},
+			masked: []string{"primary_email"},
+			kept:   []string{"alternate_emails"},
+		},
+		{
+			name:   "callers without policy see all the fields",
+			caller: "project-service",
+			handler: func(ctx context.Context, msg *mockTransportMessenger) ([]byte, error) {
+				return orchestrator.GetUserMetadata(ctx, msg)
+			},
+			kept: []string{"name", "phone_number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithCaller(context.Background(), tt.caller)
+
+			result, err := tt.handler(ctx, &mockTransportMessenger{data: []byte("auth0|123")})
+			if err != nil {
+				t.Fatalf("handler unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool           `json:"success"`
+				Data    map[string]any `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if !response.Success {
+				t.Fatalf("handler = %s, want success", result)
+			}
+			for _, field := range tt.masked {
+				if _, ok := response.Data[field]; ok {
+					t.Errorf("data = %v, %q must be masked", response.Data, field)
+				}
+			}
+			for _, field := range tt.kept {
+				if _, ok := response.Data[field]; !ok {
+					t.Errorf("data = %v, %q must be kept", response.Data, field)
+				}
+			}
+			if emails, ok := response.Data["alternate_emails"].([]any); ok {
+				for _, email := range emails {
+					if _, ok := email.(map[string]any)["email"]; ok {
+						t.Errorf("alternate email = %v, the nested email must be masked", email)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// rosterMember is a resolved roster entry, the canonical identity of the user and what the
+// roster displays, out of the public profile document
+type rosterMember struct {
+	Identifier   string `json:"identifier"`
+	Sub          string `json:"sub"`
+	Username     string `json:"username,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Picture      string `json:"picture,omitempty"`
+	JobTitle     string `json:"job_title,omitempty"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// rosterUnresolved is a roster entry without a user, with the retry hints of the failure
+type rosterUnresolved struct {
+	Identifier   string `json:"identifier"`
+	Error        string `json:"error"`
+	Retryable    bool   `json:"retryable,omitempty"`
+	RetryAfterMs int64  `json:"retry_after_ms,omitempty"`
+}
+
+// rosterResponse lists the resolved and the unresolved entries in the order of the request
+type rosterResponse struct {
+	Resolved   []rosterMember     `json:"resolved"`
+	Unresolved []rosterUnresolved `json:"unresolved"`
+}
+
+// resolveRosterEntry finds the user of a roster entry, an email is looked up by primary email
+// and then by alternate email, anything else as a username or a sub
+func (m *messageHandlerOrchestrator) resolveRosterEntry(ctx context.Context, identifier string) (*model.User, error) {
+	if !strings.Contains(identifier, "@") {
+		return m.lookupUser(ctx, identifier)
+	}
+
+	email := strings.ToLower(identifier)
+	user, err := m.searchByEmail(ctx, constants.CriteriaTypeEmail, email)
+	var notFound errs.NotFound
+	if err == nil || !errors.As(err, &notFound) {
+		return user, err
+	}
+
+	// rosters often list the work emails, linked as alternate emails
+	if linked, errLinked := m.searchByEmail(ctx, constants.CriteriaTypeAlternateEmail, email); errLinked == nil {
+		return linked, nil
+	}
+	return nil, err
+}
+
+// ResolveRoster resolves the entries of a committee or project roster in a single message, the
+// request is a JSON array of emails, usernames and subs. The entries without a user are reported
+// as unresolved, they don't fail the others.
+func (m *messageHandlerOrchestrator) ResolveRoster(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ResolveRoster", msg)
+	defer span.End()
+
+	if m.userReader == nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("auth service unavailable")), nil
+	}
+
+	identifiers, errMessage := parseBulkIdentifiers(msg.Data())
+	if errMessage != "" {
+		return m.errorResponse(errMessage), nil
+	}
+
+	members := make([]*rosterMember, len(identifiers))
+	failures := make([]*rosterUnresolved, len(identifiers))
+
+	errRun := runBulk(ctx, identifiers, func(i int, identifier string) {
+		user, err := m.resolveRosterEntry(ctx, identifier)
+		if err != nil {
+			failure := m.errorDataResponse(ctx, err)
+			failures[i] = &rosterUnresolved{
+				Identifier:   identifier,
+				Error:        failure.Error,
+				Retryable:    failure.Retryable,
+				RetryAfterMs: failure.RetryAfterMs,
+			}
+			return
+		}
+
+		document := user.ProfileDocument(m.now())
+		match := document.TypeaheadMatch()
+		members[i] = &rosterMember{
+			Identifier:   identifier,
+			Sub:          user.ProfileSub(),
+			Username:     match.Username,
+			Name:         match.Name,
+			Picture:      match.Picture,
+			JobTitle:     document.JobTitle,
+			Organization: document.Organization,
+		}
+	})
+	if errRun != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("roster resolution interrupted", errRun)), nil
+	}
+
+	roster := rosterResponse{
+		Resolved:   make([]rosterMember, 0, len(identifiers)),
+		Unresolved: make([]rosterUnresolved, 0),
+	}
+	for i := range identifiers {
+		if members[i] != nil {
+			roster.Resolved = append(roster.Resolved, *members[i])
+		}
+		if failures[i] != nil {
+			roster.Unresolved = append(roster.Unresolved, *failures[i])
+		}
+	}
+
+	slog.DebugContext(ctx, "roster resolved",
+		"resolved", len(roster.Resolved),
+		"unresolved", len(roster.Unresolved),
+	)
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    roster,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+func TestMessageHandlerOrchestrator_ResolveRoster(t *testing.T) {
+	ctx := context.Background()
+
+	jane := &model.User{
+		UserID:       "auth0|jane",
+		Username:     "jdoe",
+		PrimaryEmail: "jane@example.com",
+		AlternateEmails: []model.Email{
+			{Email: "jane@work.example.com", Verified: true},
+		},
+		UserMetadata: &model.UserMetadata{
+			GivenName:    converters.StringPtr("Jane"),
+			FamilyName:   converters.StringPtr("Doe"),
+			Organization: converters.StringPtr("CNCF"),
+			PhoneNumber:  converters.StringPtr("+351 000 000 000"),
+		},
+	}
+	john := &model.User{UserID: "auth0|john", Username: "jsmith", UserMetadata: &model.UserMetadata{Name: converters.StringPtr("John Smith")}}
+
+	reader := &mockUserServiceReader{
+		getUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			switch user.UserID {
+			case jane.UserID:
+				return jane, nil
+			case "auth0|down":
+				return nil, errs.NewServiceUnavailable("auth0 unavailable")
+			}
+			return nil, errs.NewNotFound("user not found")
+		},
+		searchUserFunc: func(ctx context.Context, user *model.User, criteria constants.CriteriaType) (*model.User, error) {
+			switch criteria {
+			case constants.CriteriaTypeUsername:
+				if user.Username == john.Username {
+					return john, nil
+				}
+			case constants.CriteriaTypeEmail:
+				if user.PrimaryEmail == jane.PrimaryEmail {
+					return jane, nil
+				}
+			case constants.CriteriaTypeAlternateEmail:
+				if user.AlternateEmails[0].Email == jane.AlternateEmails[0].Email {
+					return jane, nil
+				}
+			}
+			return nil, errs.NewNotFound("user not found")
+		},
+	}
+
+	t.Run("resolves the mixed identifiers", func(t *testing.T) {
+		orchestrator := &messageHandlerOrchestrator{userReader: reader}
+		data := `["Jane@Example.com", "jsmith", "auth0|down", "nobody@example.com", "jane@work.example.com", "auth0|jane"]`
+
+		response, err := orchestrator.ResolveRoster(ctx, &mockTransportMessenger{data: []byte(data)})
+		if err != nil {
+			t.Fatalf("ResolveRoster() unexpected error: %v", err)
+		}
+
+		var got struct {
+			Success bool           `json:"success"`
+			Data    rosterResponse `json:"data"`
+		}
+		if err := json.Unmarshal(response, &got); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !got.Success {
+			t.Fatalf("ResolveRoster() = %s", response)
+		}
+
+		janeMember := rosterMember{Sub: "auth0|jane", Username: "jdoe", Name: "Jane Doe", Organization: "CNCF"}
+		withIdentifier := func(member rosterMember, identifier string) rosterMember {
+			member.Identifier = identifier
+			return member
+		}
+		wantResolved := []rosterMember{
+			withIdentifier(janeMember, "Jane@Example.com"),
+			{Identifier: "jsmith", Sub: "auth0|john", Username: "jsmith", Name: "John Smith"},
+			withIdentifier(janeMember, "jane@work.example.com"),
+			withIdentifier(janeMember, "auth0|jane"),
+		}
+		if !reflect.DeepEqual(got.Data.Resolved, wantResolved) {
+			t.Errorf("ResolveRoster() resolved = %+v, want %+v", got.Data.Resolved, wantResolved)
+		}
+
+		wantUnresolved := []rosterUnresolved{
+			{Identifier: "auth0|down", Error: "auth0 unavailable", Retryable: true, RetryAfterMs: errs.DefaultServiceUnavailableRetryAfter.Milliseconds()},
+			{Identifier: "nobody@example.com", Error: "user not found"},
+		}
+		if !reflect.DeepEqual(got.Data.Unresolved, wantUnresolved) {
+			t.Errorf("ResolveRoster() unresolved = %+v, want %+v", got.Data.Unresolved, wantUnresolved)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		noReader  bool
+		data      string
+		wantError string
+	}{
+		{name: "not an array", data: `"jdoe"`, wantError: "failed to unmarshal request, expected a JSON array of identifiers"},
+		{name: "empty roster", data: `[]`, wantError: "at least one identifier is required"},
+		{name: "no provider", noReader: true, data: `["jdoe"]`, wantError: "auth service unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{userReader: reader}
+			if tt.noReader {
+				orchestrator.userReader = nil
+			}
+
+			response, err := orchestrator.ResolveRoster(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("ResolveRoster() unexpected error: %v", err)
+			}
+
+			var got UserDataResponse
+			if err := json.Unmarshal(response, &got); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if got.Success || got.Error != tt.wantError {
+				t.Errorf("ResolveRoster() = %s, want error %q", response, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+	"unicode/utf8"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultSlowRequestThreshold is the latency above which a request is logged
+	DefaultSlowRequestThreshold = 2 * time.Second
+
+	// DefaultSlowRequestSampleRate is the share of the slow requests logged with payload excerpts
+	DefaultSlowRequestSampleRate = 0.1
+
+	// slowRequestExcerptBytes bounds the payload excerpts of the log
+	slowRequestExcerptBytes = 512
+)
+
+// MessageHandlerFunc handles a message and returns the response
+type MessageHandlerFunc func(ctx context.Context, msg port.TransportMessenger) ([]byte, error)
+
+// SlowRequestLogger logs the requests slower than a threshold with the breakdown of the upstream
+// calls they made, and on a sample of them the redacted excerpts of the payloads, so the slow
+// requests can be diagnosed without enabling the debug logs
+type SlowRequestLogger struct {
+	threshold  time.Duration
+	sampleRate float64
+	random     func() float64
+	now        func() time.Time
+}
+
+// SlowRequestOption configures the slow request logger
+type SlowRequestOption func(*SlowRequestLogger)
+
+// WithSlowRequestSampleRate sets the share of the slow requests logged with payload excerpts, between 0 and 1
+func WithSlowRequestSampleRate(rate float64) SlowRequestOption {
+	return func(l *SlowRequestLogger) {
+		l.sampleRate = min(max(rate, 0), 1)
+	}
+}
+
+// NewSlowRequestLogger creates a logger of the requests slower than the threshold
+func NewSlowRequestLogger(threshold time.Duration, opts ...SlowRequestOption) *SlowRequestLogger {
+	l := &SlowRequestLogger{
+		threshold:  threshold,
+		sampleRate: DefaultSlowRequestSampleRate,
+		random:     rand.Float64,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Handle runs the handler, timing the upstream calls it makes, and logs the request when it's
+// slower than the threshold. A nil logger only runs the handler.
+func (l *SlowRequestLogger) Handle(ctx context.Context, msg port.TransportMessenger, handler MessageHandlerFunc) ([]byte, error) {
+	if l == nil {
+		return handler(ctx, msg)
+	}
+
+	ctx, timings := httpclient.ContextWithTimings(ctx)
+	start := l.now()
+	response, err := handler(ctx, msg)
+	elapsed := l.now().Sub(start)
+	if elapsed < l.threshold {
+		return response, err
+	}
+
+	calls := timings.Calls()
+	var upstream time.Duration
+	breakdown := make([]string, 0, len(calls))
+	for _, call := range calls {
+		upstream += call.Duration
+		breakdown = append(breakdown, fmt.Sprintf("%s %s status=%d attempts=%d duration_ms=%d waited_ms=%d",
+			call.Method, call.Endpoint, call.Status, call.Attempts, call.Duration.Milliseconds(), call.Waited.Milliseconds()))
+	}
+
+	attrs := []any{
+		"subject", msg.Subject(),
+		"caller", callerFromContext(ctx),
+		"duration_ms", elapsed.Milliseconds(),
+		"threshold_ms", l.threshold.Milliseconds(),
+		"upstream_calls", len(calls),
+		"upstream_ms", upstream.Milliseconds(),
+		"upstream", breakdown,
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	if l.sampleRate > 0 && l.random() < l.sampleRate {
+		attrs = append(attrs,
+			"request_excerpt", payloadExcerpt(msg.Data()),
+			"response_excerpt", payloadExcerpt(response),
+		)
+	}
+	slog.WarnContext(ctx, "slow request", attrs...)
+
+	return response, err
+}
+
+// payloadExcerpt returns the beginning of the payload, with the tokens and the emails redacted
+func payloadExcerpt(payload []byte) string {
+	redacted := redaction.RedactEmails(redaction.RedactJWTs(string(payload)))
+	if len(redacted) <= slowRequestExcerptBytes {
+		return redacted
+	}
+
+	// don't cut a rune in half
+	end := slowRequestExcerptBytes
+	for end > 0 && !utf8.RuneStart(redacted[end]) {
+		end--
+	}
+	return redacted[:end] + "…"
+}
@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+)
+
+// captureLogs records the logs of the test as JSON lines
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestSlowRequestLogger_Handle(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+	client := httpclient.NewClient(httpclient.DefaultConfig())
+
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		sample      float64
+		wantLogged  bool
+		wantExcerpt bool
+	}{
+		{name: "fast request", elapsed: time.Second},
+		{name: "slow request", elapsed: 8 * time.Second, sample: 0.5, wantLogged: true},
+		{name: "sampled slow request", elapsed: 8 * time.Second, sample: 0.05, wantLogged: true, wantExcerpt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			fakeClock := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+			logger := NewSlowRequestLogger(2*time.Second, WithSlowRequestSampleRate(0.1))
+			logger.now = fakeClock.Now
+			logger.random = func() float64 { return tt.sample }
+
+			msg := &mockTransportMessenger{data: []byte(`{"email":"john.doe@example.com"}`)}
+			ctx := ContextWithCaller(context.Background(), "projects-service")
+			response, err := logger.Handle(ctx, msg, func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+				_, errUpstream := client.Request(ctx, http.MethodGet, upstream.URL+"/api/v2/users/auth0|123", nil, nil)
+				fakeClock.Advance(tt.elapsed)
+				return []byte(`{"success":true}`), errUpstream
+			})
+			require.NoError(t, err)
+			assert.Equal(t, `{"success":true}`, string(response))
+
+			if !tt.wantLogged {
+				assert.Empty(t, logs.String())
+				return
+			}
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+			assert.Equal(t, "slow request", entry["msg"])
+			assert.Equal(t, "projects-service", entry["caller"])
+			assert.Equal(t, float64(tt.elapsed.Milliseconds()), entry["duration_ms"])
+			assert.Equal(t, float64(1), entry["upstream_calls"])
+			require.Len(t, entry["upstream"], 1)
+			assert.True(t, strings.HasPrefix(entry["upstream"].([]any)[0].(string), "GET /api/v2/users/* status=200 attempts=1"))
+
+			if !tt.wantExcerpt {
+				assert.NotContains(t, entry, "request_excerpt")
+				return
+			}
+			assert.Equal(t, `{"email":"joh****@example.com"}`, entry["request_excerpt"])
+			assert.Equal(t, `{"success":true}`, entry["response_excerpt"])
+		})
+	}
+}
+
+func TestSlowRequestLogger_Nil(t *testing.T) {
+	var logger *SlowRequestLogger
+	response, err := logger.Handle(context.Background(), &mockTransportMessenger{}, func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+		return []byte("jdoe"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", string(response))
+}
+
+func TestPayloadExcerpt(t *testing.T) {
+	long := strings.Repeat("é", slowRequestExcerptBytes)
+	excerpt := payloadExcerpt([]byte(long))
+	assert.True(t, strings.HasSuffix(excerpt, "…"))
+	assert.LessOrEqual(t, len(excerpt), slowRequestExcerptBytes+len("…"))
+	assert.Equal(t, strings.Repeat("é", slowRequestExcerptBytes/2)+"…", excerpt)
+}
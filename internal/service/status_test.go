@@ -0,0 +1,242 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockProviderStatusReader struct {
+	statuses []model.ProviderStatus
+}
+
+func (m *mockProviderStatusReader) ProviderStatuses(ctx context.Context) []model.ProviderStatus {
+	return m.statuses
+}
+
+func TestMessageHandlerOrchestrator_ProviderStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports the provider statuses", func(t *testing.T) {
+		orchestrator := &messageHandlerOrchestrator{
+			providerStatusReader: &mockProviderStatusReader{statuses: []model.ProviderStatus{
+				{Provider: "auth0", Samples: 10, P95Ms: 250, ErrorRate: 0.6, CircuitState: model.CircuitStateOpen},
+			}},
+		}
+
+		result, err := orchestrator.ProviderStatus(ctx, &mockTransportMessenger{})
+		if err != nil {
+			t.Fatalf("ProviderStatus() unexpected error: %v", err)
+		}
+
+		var response struct {
+			Success bool                   `json:"success"`
+			Data    []model.ProviderStatus `json:"data"`
+		}
+		if err := json.Unmarshal(result, &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if !response.Success || len(response.Data) != 1 || response.Data[0].CircuitState != model.CircuitStateOpen {
+			t.Errorf("ProviderStatus() = %s", result)
+		}
+	})
+
+	t.Run("unavailable without a reader", func(t *testing.T) {
+		orchestrator := &messageHandlerOrchestrator{}
+
+		result, _ := orchestrator.ProviderStatus(ctx, &mockTransportMessenger{})
+
+		var response UserDataResponse
+		if err := json.Unmarshal(result, &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if response.Success || response.Error != "auth service unavailable" {
+			t.Errorf("ProviderStatus() = %s", result)
+		}
+	})
+}
+
+func TestMessageHandlerOrchestrator_ErrorResponseFromError(t *testing.T) {
+	ctx := context.Background()
+	openCircuit := &mockProviderStatusReader{statuses: []model.ProviderStatus{
+		{Provider: "auth0", CircuitState: model.CircuitStateOpen},
+	}}
+
+	tests := []struct {
+		name           string
+		statusReader   *mockProviderStatusReader
+		err            error
+		wantRetryable  bool
+		wantRetryAfter int64
+	}{
+		{
+			name: "validation error is not retryable",
+			err:  errs.NewValidation("invalid email"),
+		},
+		{
+			name:           "rate limit carries the reset time",
+			err:            errs.NewTooManyRequests("too many emails sent, please try again later").WithRetryAfter(1500 * time.Millisecond),
+			wantRetryable:  true,
+			wantRetryAfter: 1500,
+		},
+		{
+			name:           "unavailable upstream",
+			err:            errs.NewServiceUnavailable("upstream unavailable"),
+			wantRetryable:  true,
+			wantRetryAfter: errs.DefaultServiceUnavailableRetryAfter.Milliseconds(),
+		},
+		{
+			name:         "unexpected error with healthy providers",
+			statusReader: &mockProviderStatusReader{statuses: []model.ProviderStatus{{Provider: "auth0", CircuitState: model.CircuitStateClosed}}},
+			err:          errs.NewUnexpected("failed to get user"),
+		},
+		{
+			name:           "unexpected error while the circuit is open",
+			statusReader:   openCircuit,
+			err:            errs.NewUnexpected("failed to get user"),
+			wantRetryable:  true,
+			wantRetryAfter: circuitOpenRetryAfter.Milliseconds(),
+		},
+		{
+			name:         "not found while the circuit is open",
+			statusReader: openCircuit,
+			err:          errs.NewNotFound("user not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{}
+			if tt.statusReader != nil {
+				orchestrator.providerStatusReader = tt.statusReader
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(orchestrator.errorResponseFromError(ctx, tt.err), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success || response.Error != tt.err.Error() {
+				t.Errorf("response = %+v", response)
+			}
+			if response.Retryable != tt.wantRetryable || response.RetryAfterMs != tt.wantRetryAfter {
+				t.Errorf("retry hint = %v/%d, want %v/%d", response.Retryable, response.RetryAfterMs, tt.wantRetryable, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+type mockUsageReader struct {
+	from, to time.Time
+	caller   string
+}
+
+func (m *mockUsageReader) UsageReport(ctx context.Context, from, to time.Time, caller string) ([]model.UsageRecord, error) {
+	m.from, m.to, m.caller = from, to, caller
+	return []model.UsageRecord{{Date: from.Format("2006-01-02"), Caller: "project-service", Operation: "lfx.auth-service.user_metadata.read", Count: 3}}, nil
+}
+
+func TestMessageHandlerOrchestrator_UsageReport(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		data       string
+		wantError  string
+		wantFrom   string
+		wantTo     string
+		wantCaller string
+	}{
+		{
+			name:       "range for a caller",
+			data:       `{"from":"2025-01-01","to":"2025-01-07","caller":"project-service"}`,
+			wantFrom:   "2025-01-01",
+			wantTo:     "2025-01-07",
+			wantCaller: "project-service",
+		},
+		{
+			name:     "single day",
+			data:     `{"to":"2025-01-07"}`,
+			wantFrom: "2025-01-07",
+			wantTo:   "2025-01-07",
+		},
+		{
+			name:     "today on the clock",
+			data:     `{}`,
+			wantFrom: "2025-01-10",
+			wantTo:   "2025-01-10",
+		},
+		{
+			name:      "invalid date",
+			data:      `{"from":"01/01/2025"}`,
+			wantError: "invalid date, expected YYYY-MM-DD",
+		},
+		{
+			name:      "reversed range",
+			data:      `{"from":"2025-01-07","to":"2025-01-01"}`,
+			wantError: "from must not be after to",
+		},
+		{
+			name:      "range too long",
+			data:      `{"from":"2025-01-01","to":"2025-03-01"}`,
+			wantError: "the report can't cover more than 31 days",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &mockUsageReader{}
+			orchestrator := &messageHandlerOrchestrator{
+				usageReader: reader,
+				clock:       clock.NewFake(time.Date(2025, 1, 10, 15, 30, 0, 0, time.UTC)),
+			}
+
+			result, err := orchestrator.UsageReport(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("UsageReport() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool                `json:"success"`
+				Error   string              `json:"error"`
+				Data    []model.UsageRecord `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if response.Success || response.Error != tt.wantError {
+					t.Errorf("UsageReport() = %s", result)
+				}
+				return
+			}
+			if !response.Success || len(response.Data) != 1 {
+				t.Fatalf("UsageReport() = %s", result)
+			}
+			if reader.from.Format("2006-01-02") != tt.wantFrom || reader.to.Format("2006-01-02") != tt.wantTo || reader.caller != tt.wantCaller {
+				t.Errorf("UsageReport() read from=%s to=%s caller=%q", reader.from, reader.to, reader.caller)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		orchestrator := &messageHandlerOrchestrator{}
+		result, _ := orchestrator.UsageReport(ctx, &mockTransportMessenger{})
+
+		var response UserDataResponse
+		if err := json.Unmarshal(result, &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if response.Success || response.Error != "usage accounting is disabled" {
+			t.Errorf("UsageReport() = %s", result)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// PayloadSchema is the schema of the request payload of a subject
+type PayloadSchema string
+
+const (
+	// PayloadNone is a payload the handler ignores
+	PayloadNone PayloadSchema = "none"
+	// PayloadText is a plain text payload, an email, a username, a sub or a token
+	PayloadText PayloadSchema = "text"
+	// PayloadJSON is a JSON document
+	PayloadJSON PayloadSchema = "json"
+	// PayloadTextOrJSON is a plain text email or a JSON document
+	PayloadTextOrJSON PayloadSchema = "text_or_json"
+	// PayloadOptionalJSON is a JSON document or an empty payload
+	PayloadOptionalJSON PayloadSchema = "optional_json"
+)
+
+// Subject pairs a NATS request subject with its handler, the criteria its users are searched by
+// and the schema of its payload
+type Subject struct {
+	// Name is the NATS subject
+	Name string
+	// Handler is the method of the message handler serving the subject
+	Handler func(h port.MessageHandler, ctx context.Context, msg port.TransportMessenger) ([]byte, error)
+	// Criteria are the criteria the users are searched by, in order, none when they are read by ID
+	Criteria []constants.CriteriaType
+	// Payload is the schema of the request payload
+	Payload PayloadSchema
+}
+
+// HandlerOf returns the handler of the subject served by the message handler
+func (s Subject) HandlerOf(h port.MessageHandler) MessageHandlerFunc {
+	return func(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+		return s.Handler(h, ctx, msg)
+	}
+}
+
+// subjects are the request subjects served by the service, every subject is subscribed to and
+// routed to its handler from here
+var subjects = []Subject{
+	// user read/write operations
+	{Name: constants.UserMetadataUpdateSubject, Handler: port.MessageHandler.UpdateUser, Payload: PayloadJSON},
+	{Name: constants.UserMetadataReadSubject, Handler: port.MessageHandler.GetUserMetadata, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadText},
+	{Name: constants.UserMetadataBulkReadSubject, Handler: port.MessageHandler.BulkGetUserMetadata, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadJSON},
+	{Name: constants.UserEmailReadSubject, Handler: port.MessageHandler.GetUserEmails, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadText},
+	{Name: constants.UserDeleteSubject, Handler: port.MessageHandler.SoftDeleteUser, Payload: PayloadText},
+	{Name: constants.UserRestoreSubject, Handler: port.MessageHandler.RestoreUser, Payload: PayloadText},
+	{Name: constants.UserMetadataAdminUpdateSubject, Handler: port.MessageHandler.UpdateUserAsOrganizationAdmin, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadJSON},
+	{Name: constants.UserMetadataEnrichSubject, Handler: port.MessageHandler.EnrichUserMetadata, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadJSON},
+	{Name: constants.UserMergeSubject, Handler: port.MessageHandler.MergeUsers, Payload: PayloadJSON},
+	// lookup operations
+	{Name: constants.UserEmailToUserSubject, Handler: port.MessageHandler.EmailToUsername, Criteria: []constants.CriteriaType{constants.CriteriaTypeEmail}, Payload: PayloadText},
+	{Name: constants.UserEmailToSubSubject, Handler: port.MessageHandler.EmailToSub, Criteria: []constants.CriteriaType{constants.CriteriaTypeEmail}, Payload: PayloadText},
+	{Name: constants.UserAlternateEmailToSubSubject, Handler: port.MessageHandler.AlternateEmailToSub, Criteria: []constants.CriteriaType{constants.CriteriaTypeAlternateEmail}, Payload: PayloadText},
+	{Name: constants.UserUsernameToEmailSubject, Handler: port.MessageHandler.UsernameToEmail, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadText},
+	{Name: constants.UserSubToEmailSubject, Handler: port.MessageHandler.SubToEmail, Payload: PayloadText},
+	{Name: constants.UserSubToUsernameSubject, Handler: port.MessageHandler.SubToUsername, Payload: PayloadText},
+	{Name: constants.UserRosterResolveSubject, Handler: port.MessageHandler.ResolveRoster, Criteria: []constants.CriteriaType{constants.CriteriaTypeEmail, constants.CriteriaTypeAlternateEmail, constants.CriteriaTypeUsername}, Payload: PayloadJSON},
+	{Name: constants.UserEmailVerifiedSubject, Handler: port.MessageHandler.IsEmailVerified, Criteria: []constants.CriteriaType{constants.CriteriaTypeEmail, constants.CriteriaTypeAlternateEmail}, Payload: PayloadText},
+	{Name: constants.UserEmailHashMembershipSubject, Handler: port.MessageHandler.EmailHashMembership, Payload: PayloadJSON},
+	// search operations
+	{Name: constants.UserTypeaheadSubject, Handler: port.MessageHandler.Typeahead, Payload: PayloadJSON},
+	// profile share links
+	{Name: constants.ProfileShareCreateSubject, Handler: port.MessageHandler.CreateProfileShareLink, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadJSON},
+	{Name: constants.ProfileShareResolveSubject, Handler: port.MessageHandler.ResolveProfileShareLink, Criteria: []constants.CriteriaType{constants.CriteriaTypeUsername}, Payload: PayloadText},
+	// email linking operations
+	{Name: constants.EmailLinkingSendVerificationSubject, Handler: port.MessageHandler.StartEmailLinking, Criteria: []constants.CriteriaType{constants.CriteriaTypeAlternateEmail, constants.CriteriaTypeEmail}, Payload: PayloadTextOrJSON},
+	{Name: constants.EmailLinkingVerifySubject, Handler: port.MessageHandler.VerifyEmailLinking, Criteria: []constants.CriteriaType{constants.CriteriaTypeAlternateEmail, constants.CriteriaTypeEmail}, Payload: PayloadJSON},
+	{Name: constants.EmailLinkingUnlinkSubject, Handler: port.MessageHandler.UnlinkAlternateEmail, Payload: PayloadJSON},
+	{Name: constants.PrimaryEmailChangeSubject, Handler: port.MessageHandler.ChangePrimaryEmail, Criteria: []constants.CriteriaType{constants.CriteriaTypeEmail, constants.CriteriaTypeAlternateEmail}, Payload: PayloadJSON},
+	// phone linking operations
+	{Name: constants.PhoneLinkingSendVerificationSubject, Handler: port.MessageHandler.StartPhoneLinking, Payload: PayloadJSON},
+	{Name: constants.PhoneLinkingVerifySubject, Handler: port.MessageHandler.VerifyPhoneLinking, Payload: PayloadJSON},
+	// identity linking/unlinking/listing operations
+	{Name: constants.UserIdentityLinkSubject, Handler: port.MessageHandler.LinkIdentity, Payload: PayloadJSON},
+	{Name: constants.UserIdentityUnlinkSubject, Handler: port.MessageHandler.UnlinkIdentity, Payload: PayloadJSON},
+	{Name: constants.UserIdentityListSubject, Handler: port.MessageHandler.ListIdentities, Payload: PayloadJSON},
+	{Name: constants.UserAuthenticatorListSubject, Handler: port.MessageHandler.ListAuthenticators, Payload: PayloadJSON},
+	{Name: constants.UserAuthenticatorDeleteSubject, Handler: port.MessageHandler.DeleteAuthenticator, Payload: PayloadJSON},
+	// service status operations
+	{Name: constants.ProviderStatusSubject, Handler: port.MessageHandler.ProviderStatus, Payload: PayloadNone},
+	{Name: constants.UsageReportSubject, Handler: port.MessageHandler.UsageReport, Payload: PayloadOptionalJSON},
+	{Name: constants.ContractReportSubject, Handler: port.MessageHandler.ContractReport, Payload: PayloadOptionalJSON},
+	// test fixtures operations
+	{Name: constants.MockUsersSeedSubject, Handler: port.MessageHandler.SeedMockUsers, Payload: PayloadJSON},
+	{Name: constants.MockFaultsSetSubject, Handler: port.MessageHandler.SetMockFaults, Payload: PayloadJSON},
+}
+
+// subjectsByName indexes the subjects by name
+var subjectsByName = func() map[string]Subject {
+	byName := make(map[string]Subject, len(subjects))
+	for _, subject := range subjects {
+		byName[subject.Name] = subject
+	}
+	return byName
+}()
+
+// Subjects returns the request subjects served by the service
+func Subjects() []Subject {
+	return append([]Subject(nil), subjects...)
+}
+
+// LookupSubject returns the request subject of the name, false when the service doesn't serve it
+func LookupSubject(name string) (Subject, bool) {
+	subject, ok := subjectsByName[name]
+	return subject, ok
+}
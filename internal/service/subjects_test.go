@@ -0,0 +1,56 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+func TestSubjects(t *testing.T) {
+	names := make(map[string]bool)
+	for _, subject := range Subjects() {
+		assert.False(t, names[subject.Name], "%s is registered once", subject.Name)
+		names[subject.Name] = true
+
+		assert.NotNil(t, subject.Handler, subject.Name)
+		assert.Contains(t, []PayloadSchema{PayloadNone, PayloadText, PayloadJSON, PayloadTextOrJSON, PayloadOptionalJSON}, subject.Payload, subject.Name)
+		for _, criteria := range subject.Criteria {
+			assert.NoError(t, criteria.Validate(), subject.Name)
+		}
+
+		found, ok := LookupSubject(subject.Name)
+		assert.True(t, ok)
+		assert.Equal(t, subject.Name, found.Name)
+	}
+
+	_, ok := LookupSubject(constants.UserProfileChangedSubject)
+	assert.False(t, ok, "the events aren't request subjects")
+}
+
+func TestSubject_HandlerOf(t *testing.T) {
+	handler := &messageHandlerOrchestrator{}
+	subject, ok := LookupSubject(constants.UserEmailToUserSubject)
+	require.True(t, ok)
+
+	response, err := subject.HandlerOf(handler)(context.Background(), &mockTransportMessenger{data: []byte("")})
+	require.NoError(t, err)
+	assert.Contains(t, string(response), "email is required", "routed to EmailToUsername")
+}
+
+func TestParseCriteriaType(t *testing.T) {
+	for _, criteria := range constants.CriteriaTypes() {
+		parsed, err := constants.ParseCriteriaType(string(criteria))
+		require.NoError(t, err)
+		assert.Equal(t, criteria, parsed)
+	}
+
+	_, err := constants.ParseCriteriaType("phone_number")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// idTokenRefKind binds the references to the ID tokens
+	idTokenRefKind = "id_token"
+
+	// verifiedEmailRefKind binds the references to the emails verified with a backup code
+	verifiedEmailRefKind = "verified_email"
+)
+
+// boundRefKind binds the kind of the references to the user, a reference sealed for a user only
+// resolves for the same user. The kind is left unbound without a user.
+func boundRefKind(kind, userID string) string {
+	if userID == "" {
+		return kind
+	}
+	return kind + ":" + userID
+}
+
+// openRef resolves a reference of the kind, bound to the user or unbound
+func (m *messageHandlerOrchestrator) openRef(kind, ref, userID string) (string, error) {
+	if userID != "" {
+		if value, err := m.tokenRefSealer.Open(boundRefKind(kind, userID), ref); err == nil {
+			return value, nil
+		}
+	}
+	return m.tokenRefSealer.Open(kind, ref)
+}
+
+// sealIDToken replaces the ID token of the response with its opaque reference, so the raw token
+// doesn't travel through the callers logs. The reference is bound to the user when there is one.
+// The response is returned as is when the references are disabled.
+func (m *messageHandlerOrchestrator) sealIDToken(authResponse *model.AuthResponse, userID string) (*model.AuthResponse, error) {
+	if m.tokenRefSealer == nil || authResponse == nil || authResponse.IDToken == "" {
+		return authResponse, nil
+	}
+
+	ref, expiresAt, err := m.tokenRefSealer.Seal(boundRefKind(idTokenRefKind, userID), authResponse.IDToken)
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to seal ID token", err)
+	}
+
+	sealed := *authResponse
+	sealed.IDToken = ""
+	sealed.IDTokenRef = ref
+	sealed.IDTokenRefExpiresAt = &expiresAt
+	return &sealed, nil
+}
+
+// resolveIdentityTokenRef sets the identity token of the link request from its reference, when
+// the caller sent a reference instead of the raw token. The email is returned instead when the
+// reference is the one of an email verified with a backup code, there is no identity token then.
+// A reference bound to a user only resolves for the user of the token.
+func (m *messageHandlerOrchestrator) resolveIdentityTokenRef(ctx context.Context, linkRequest *model.LinkIdentity) (string, error) {
+	ref := linkRequest.LinkWith.IdentityTokenRef
+	if ref == "" {
+		return "", nil
+	}
+	if linkRequest.LinkWith.IdentityToken != "" {
+		return "", errs.NewValidation("identity_token and identity_token_ref are mutually exclusive")
+	}
+	if m.tokenRefSealer == nil {
+		return "", errs.NewValidation("token references are disabled")
+	}
+
+	user, errMetadataLookup := m.userReader.MetadataLookup(ctx, linkRequest.User.AuthToken)
+	if errMetadataLookup != nil {
+		return "", errMetadataLookup
+	}
+
+	token, err := m.openRef(idTokenRefKind, ref, user.UserID)
+	if err != nil {
+		if m.backupCodeStore != nil {
+			if email, errEmail := m.openRef(verifiedEmailRefKind, ref, user.UserID); errEmail == nil {
+				linkRequest.LinkWith.IdentityTokenRef = ""
+				return email, nil
+			}
+		}
+		return "", err
+	}
+	linkRequest.LinkWith.IdentityToken = token
+	linkRequest.LinkWith.IdentityTokenRef = ""
+	return "", nil
+}
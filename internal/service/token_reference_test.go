@@ -0,0 +1,149 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/tokenref"
+)
+
+func TestMessageHandlerOrchestrator_sealIDToken(t *testing.T) {
+	sealer, err := tokenref.NewSealer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	issued := &model.AuthResponse{AccessToken: "access-token", IDToken: "id-token", TokenType: "Bearer"}
+
+	// disabled, the raw token is returned
+	unsealed, err := (&messageHandlerOrchestrator{}).sealIDToken(issued, "")
+	require.NoError(t, err)
+	assert.Same(t, issued, unsealed)
+
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(_ context.Context, input string) (*model.User, error) {
+			return &model.User{UserID: input}, nil
+		},
+	}
+	orchestrator := &messageHandlerOrchestrator{tokenRefSealer: sealer, userReader: reader}
+	sealed, err := orchestrator.sealIDToken(issued, "")
+	require.NoError(t, err)
+	assert.Empty(t, sealed.IDToken)
+	assert.NotEmpty(t, sealed.IDTokenRef)
+	assert.NotNil(t, sealed.IDTokenRefExpiresAt)
+	assert.Equal(t, "access-token", sealed.AccessToken)
+	assert.Equal(t, "id-token", issued.IDToken, "the provider response is left untouched")
+
+	responseJSON, err := json.Marshal(sealed)
+	require.NoError(t, err)
+	assert.NotContains(t, string(responseJSON), `"id_token"`)
+
+	// the reference resolves on the identity linking
+	linkRequest := &model.LinkIdentity{}
+	linkRequest.LinkWith.IdentityTokenRef = sealed.IDTokenRef
+	verifiedEmail, err := orchestrator.resolveIdentityTokenRef(context.Background(), linkRequest)
+	require.NoError(t, err)
+	assert.Empty(t, verifiedEmail)
+	assert.Equal(t, "id-token", linkRequest.LinkWith.IdentityToken)
+	assert.Empty(t, linkRequest.LinkWith.IdentityTokenRef)
+
+	// a reference bound to a user only resolves for that user
+	bound, err := orchestrator.sealIDToken(issued, "auth0|jane")
+	require.NoError(t, err)
+	linkRequest = &model.LinkIdentity{}
+	linkRequest.User.AuthToken = "auth0|other"
+	linkRequest.LinkWith.IdentityTokenRef = bound.IDTokenRef
+	_, err = orchestrator.resolveIdentityTokenRef(context.Background(), linkRequest)
+	assert.EqualError(t, err, "invalid token reference")
+
+	linkRequest.User.AuthToken = "auth0|jane"
+	_, err = orchestrator.resolveIdentityTokenRef(context.Background(), linkRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "id-token", linkRequest.LinkWith.IdentityToken)
+}
+
+func TestMessageHandlerOrchestrator_LinkIdentity_TokenReference(t *testing.T) {
+	sealer, err := tokenref.NewSealer([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	ref, _, err := sealer.Seal(idTokenRefKind, "some-identity-token")
+	require.NoError(t, err)
+
+	var linked string
+	linker := &mockIdentityLinker{
+		validateLinkRequestFunc: func(_ context.Context, _ *model.LinkIdentity) error { return nil },
+		linkIdentityFunc: func(_ context.Context, request *model.LinkIdentity) error {
+			linked = request.LinkWith.IdentityToken
+			return nil
+		},
+	}
+	reader := &mockUserServiceReader{
+		metadataLookupFunc: func(_ context.Context, _ string) (*model.User, error) {
+			return &model.User{UserID: "auth0|user123"}, nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		sealer      *tokenref.Sealer
+		linkWith    string
+		expectError string
+	}{
+		{
+			name:     "reference resolved",
+			sealer:   sealer,
+			linkWith: `{"identity_token_ref":"` + ref + `"}`,
+		},
+		{
+			name:        "invalid reference",
+			sealer:      sealer,
+			linkWith:    `{"identity_token_ref":"forged"}`,
+			expectError: "invalid token reference",
+		},
+		{
+			name:        "token and reference",
+			sealer:      sealer,
+			linkWith:    `{"identity_token":"some-identity-token","identity_token_ref":"` + ref + `"}`,
+			expectError: "identity_token and identity_token_ref are mutually exclusive",
+		},
+		{
+			name:        "references disabled",
+			linkWith:    `{"identity_token_ref":"` + ref + `"}`,
+			expectError: "token references are disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linked = ""
+			opts := []messageHandlerOrchestratorOption{
+				WithIdentityLinkerForMessageHandler(linker),
+				WithUserReaderForMessageHandler(reader),
+			}
+			if tt.sealer != nil {
+				opts = append(opts, WithTokenReferenceSealerForMessageHandler(tt.sealer))
+			}
+			orchestrator := NewMessageHandlerOrchestrator(opts...)
+
+			data := []byte(`{"user":{"auth_token":"some-auth-token"},"link_with":` + tt.linkWith + `}`)
+			result, err := orchestrator.LinkIdentity(context.Background(), &mockTransportMessenger{data: data})
+			require.NoError(t, err)
+
+			var response UserDataResponse
+			require.NoError(t, json.Unmarshal(result, &response))
+			if tt.expectError != "" {
+				assert.False(t, response.Success)
+				assert.Equal(t, tt.expectError, response.Error)
+				assert.Empty(t, linked)
+				return
+			}
+			assert.True(t, response.Success, response.Error)
+			assert.Equal(t, "some-identity-token", linked)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+// startSpan starts the span of a handler, child of the span of the transport (the NATS message
+// or the REST mirror request), the identity provider calls of the handler are its children
+func startSpan(ctx context.Context, handler string, msg port.TransportMessenger) (context.Context, trace.Span) {
+	return otel.Tracer(constants.ServiceName).Start(ctx, "auth_service."+handler,
+		trace.WithAttributes(
+			attribute.String("messaging.destination.name", msg.Subject()),
+			attribute.String("auth_service.caller", callerFromContext(ctx)),
+		),
+	)
+}
+
+// recordSpanError marks the span of the handler as failed, the handlers answer the errors
+// in the response payload so the span can't tell them from the returned error
+func recordSpanError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// recordSpans records the spans ended during the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestMessageHandlerOrchestrator_Spans(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     func(ctx context.Context, m *messageHandlerOrchestrator) ([]byte, error)
+		wantSpan   string
+		wantStatus codes.Code
+	}{
+		{
+			name: "successful handler",
+			handle: func(ctx context.Context, m *messageHandlerOrchestrator) ([]byte, error) {
+				m.providerStatusReader = &mockProviderStatusReader{statuses: []model.ProviderStatus{{Provider: "auth0"}}}
+				return m.ProviderStatus(ctx, &mockTransportMessenger{})
+			},
+			wantSpan:   "auth_service.ProviderStatus",
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "error answered in the response",
+			handle: func(ctx context.Context, m *messageHandlerOrchestrator) ([]byte, error) {
+				return m.GetUserMetadata(ctx, &mockTransportMessenger{data: []byte("jdoe")})
+			},
+			wantSpan:   "auth_service.GetUserMetadata",
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+			ctx := ContextWithCaller(context.Background(), "lfx-v2-project-service")
+
+			if _, err := tt.handle(ctx, &messageHandlerOrchestrator{}); err != nil {
+				t.Fatalf("handler unexpected error: %v", err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantSpan {
+				t.Errorf("span name = %q, want %q", span.Name(), tt.wantSpan)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			wantAttributes := map[attribute.Key]string{
+				"messaging.destination.name": "test-subject",
+				"auth_service.caller":        "lfx-v2-project-service",
+			}
+			for _, attr := range span.Attributes() {
+				if want, ok := wantAttributes[attr.Key]; ok && attr.Value.AsString() != want {
+					t.Errorf("span attribute %s = %q, want %q", attr.Key, attr.Value.AsString(), want)
+				}
+				delete(wantAttributes, attr.Key)
+			}
+			if len(wantAttributes) > 0 {
+				t.Errorf("missing span attributes %v", wantAttributes)
+			}
+		})
+	}
+}
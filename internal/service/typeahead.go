@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// typeaheadRequest represents the input for the typeahead search
+type typeaheadRequest struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// Typeahead returns the users whose username or name starts with the query, for the @-mention
+// features of the LFX tools. The matches only carry the public attributes needed to display them.
+func (m *messageHandlerOrchestrator) Typeahead(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "Typeahead", msg)
+	defer span.End()
+
+	if m.profileSearcher == nil {
+		return m.errorResponse("typeahead search is disabled"), nil
+	}
+
+	var request typeaheadRequest
+	if err := json.Unmarshal(msg.Data(), &request); err != nil {
+		return m.errorResponse("failed to unmarshal request"), nil
+	}
+
+	query := strings.TrimSpace(request.Query)
+	if utf8.RuneCountInString(query) < model.TypeaheadMinQueryLength {
+		return m.errorResponse(fmt.Sprintf("query must have at least %d characters", model.TypeaheadMinQueryLength)), nil
+	}
+
+	matches, err := m.profileSearcher.Typeahead(ctx, query, model.TypeaheadLimit(request.Limit))
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+	if matches == nil {
+		matches = []model.TypeaheadMatch{}
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    matches,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+type mockProfileSearcher struct {
+	matches []model.TypeaheadMatch
+	err     error
+	query   string
+	limit   int
+}
+
+func (m *mockProfileSearcher) Typeahead(ctx context.Context, query string, limit int) ([]model.TypeaheadMatch, error) {
+	m.query, m.limit = query, limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.matches) > limit {
+		return m.matches[:limit], nil
+	}
+	return m.matches, nil
+}
+
+func TestMessageHandlerOrchestrator_Typeahead(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		searcher    *mockProfileSearcher
+		data        string
+		wantSuccess bool
+		wantError   string
+		wantCount   int
+		wantQuery   string
+		wantLimit   int
+	}{
+		{
+			name: "returns the matches",
+			searcher: &mockProfileSearcher{matches: []model.TypeaheadMatch{
+				{Sub: "auth0|1", Username: "jdoe", Name: "Jane Doe"},
+				{Sub: "auth0|2", Username: "jdough", Name: "John Dough"},
+			}},
+			data:        `{"query":" jd "}`,
+			wantSuccess: true,
+			wantCount:   2,
+			wantQuery:   "jd",
+			wantLimit:   model.TypeaheadDefaultLimit,
+		},
+		{
+			name:        "the limit is capped",
+			searcher:    &mockProfileSearcher{},
+			data:        `{"query":"jane","limit":1000}`,
+			wantSuccess: true,
+			wantQuery:   "jane",
+			wantLimit:   model.TypeaheadMaxLimit,
+		},
+		{
+			name:      "query too short",
+			searcher:  &mockProfileSearcher{},
+			data:      `{"query":"é"}`,
+			wantError: "query must have at least 2 characters",
+		},
+		{
+			name:      "invalid payload",
+			searcher:  &mockProfileSearcher{},
+			data:      `not-json`,
+			wantError: "failed to unmarshal request",
+		},
+		{
+			name:      "index loading",
+			searcher:  &mockProfileSearcher{err: errs.NewServiceUnavailable("typeahead index is loading")},
+			data:      `{"query":"jane"}`,
+			wantError: "typeahead index is loading",
+			wantQuery: "jane",
+			wantLimit: model.TypeaheadDefaultLimit,
+		},
+		{
+			name:      "typeahead disabled",
+			data:      `{"query":"jane"}`,
+			wantError: "typeahead search is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := &messageHandlerOrchestrator{}
+			if tt.searcher != nil {
+				orchestrator.profileSearcher = tt.searcher
+			}
+
+			result, err := orchestrator.Typeahead(ctx, &mockTransportMessenger{data: []byte(tt.data)})
+			if err != nil {
+				t.Fatalf("Typeahead() unexpected error: %v", err)
+			}
+
+			var response struct {
+				Success bool                   `json:"success"`
+				Error   string                 `json:"error"`
+				Data    []model.TypeaheadMatch `json:"data"`
+			}
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success != tt.wantSuccess || response.Error != tt.wantError || len(response.Data) != tt.wantCount {
+				t.Errorf("Typeahead() = %s", result)
+			}
+			if tt.wantSuccess && response.Data == nil {
+				t.Errorf("Typeahead() data = null, want a list")
+			}
+			if tt.searcher != nil && (tt.searcher.query != tt.wantQuery || tt.searcher.limit != tt.wantLimit) {
+				t.Errorf("Typeahead() searched %q with limit %d, want %q with limit %d", tt.searcher.query, tt.searcher.limit, tt.wantQuery, tt.wantLimit)
+			}
+		})
+	}
+}
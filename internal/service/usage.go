@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// usageDateLayout is the layout of the days of the usage report
+	usageDateLayout = "2006-01-02"
+
+	// maxUsageReportDays bounds the days of a usage report
+	maxUsageReportDays = 31
+)
+
+// usageReportRequest represents the input for the usage report, the days are inclusive and default to today
+type usageReportRequest struct {
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Caller string `json:"caller,omitempty"`
+}
+
+// parseUsageDay parses a day of the usage report, an empty day is the fallback
+func parseUsageDay(day string, fallback time.Time) (time.Time, error) {
+	day = strings.TrimSpace(day)
+	if day == "" {
+		return fallback, nil
+	}
+	parsed, err := time.Parse(usageDateLayout, day)
+	if err != nil {
+		return time.Time{}, errs.NewValidation("invalid date, expected YYYY-MM-DD")
+	}
+	return parsed, nil
+}
+
+// UsageReport reports the number of requests per caller and operation and per day,
+// so platform owners can attribute the identity provider quota consumption to the callers
+func (m *messageHandlerOrchestrator) UsageReport(ctx context.Context, msg port.TransportMessenger) ([]byte, error) {
+	ctx, span := startSpan(ctx, "UsageReport", msg)
+	defer span.End()
+
+	if m.usageReader == nil {
+		return m.errorResponse("usage accounting is disabled"), nil
+	}
+
+	var request usageReportRequest
+	if len(strings.TrimSpace(string(msg.Data()))) > 0 {
+		if err := json.Unmarshal(msg.Data(), &request); err != nil {
+			return m.errorResponse("failed to unmarshal request"), nil
+		}
+	}
+
+	today := m.now().Truncate(24 * time.Hour)
+	to, errTo := parseUsageDay(request.To, today)
+	if errTo != nil {
+		return m.errorResponseFromError(ctx, errTo), nil
+	}
+	from, errFrom := parseUsageDay(request.From, to)
+	if errFrom != nil {
+		return m.errorResponseFromError(ctx, errFrom), nil
+	}
+	if from.After(to) {
+		return m.errorResponse("from must not be after to"), nil
+	}
+	if to.Sub(from) >= maxUsageReportDays*24*time.Hour {
+		return m.errorResponse("the report can't cover more than 31 days"), nil
+	}
+
+	records, err := m.usageReader.UsageReport(ctx, from, to, strings.TrimSpace(request.Caller))
+	if err != nil {
+		return m.errorResponseFromError(ctx, err), nil
+	}
+
+	response := UserDataResponse{
+		Success: true,
+		Data:    records,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return m.errorResponseFromError(ctx, errs.NewUnexpected("failed to marshal response")), nil
+	}
+
+	return responseJSON, nil
+}
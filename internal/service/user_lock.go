@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/port"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/concurrent"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+// localUserLocker serializes the updates of the same user within the replica
+type localUserLocker struct {
+	mutex *concurrent.StripedMutex
+}
+
+// LockUsers locks the users until the returned function is called
+func (l *localUserLocker) LockUsers(ctx context.Context, keys ...string) (func(), error) {
+	return l.mutex.Lock(keys...), nil
+}
+
+// NewLocalUserLocker creates a user locker serializing the updates within the process,
+// it doesn't coordinate multiple replicas
+func NewLocalUserLocker() port.UserLocker {
+	return &localUserLocker{
+		mutex: concurrent.NewStripedMutex(concurrent.DefaultStripes),
+	}
+}
+
+// lockUsers locks the non-empty keys, the update critical section (provider write, profile
+// changed event) of a user runs while the lock is held
+func (m *messageHandlerOrchestrator) lockUsers(ctx context.Context, keys ...string) (func(), error) {
+	lockKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			lockKeys = append(lockKeys, key)
+		}
+	}
+	if m.userLocker == nil || len(lockKeys) == 0 {
+		return func() {}, nil
+	}
+	return m.userLocker.LockUsers(ctx, lockKeys...)
+}
+
+// tokenLockKey is the lock key of the user identified by the token, the token is verified
+// later by the provider so the subject is only used to serialize the updates
+func tokenLockKey(ctx context.Context, token string) string {
+	subject, err := jwt.ExtractSubject(ctx, token)
+	if err != nil {
+		slog.DebugContext(ctx, "failed to extract the lock key from the token", "error", err)
+		return token
+	}
+	return subject
+}
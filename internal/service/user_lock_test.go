@@ -0,0 +1,95 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/converters"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+)
+
+func TestMessageHandlerOrchestrator_UpdateUser_Serialized(t *testing.T) {
+	ctx := context.Background()
+
+	token, err := jwt.GenerateSimpleTestAccessToken("auth0|user-1", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	var (
+		mu      sync.Mutex
+		inside  int
+		maxSeen int
+	)
+	writer := &mockUserServiceWriter{
+		updateUserFunc: func(ctx context.Context, user *model.User) (*model.User, error) {
+			mu.Lock()
+			inside++
+			maxSeen = max(maxSeen, inside)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inside--
+			mu.Unlock()
+			return user, nil
+		},
+	}
+	orchestrator := NewMessageHandlerOrchestrator(WithUserWriterForMessageHandler(writer))
+
+	data, _ := json.Marshal(&model.User{
+		Token:        token,
+		UserMetadata: &model.UserMetadata{Name: converters.StringPtr("John Doe")},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: data}); err != nil {
+				t.Errorf("UpdateUser() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxSeen != 1 {
+		t.Errorf("concurrent updates of the same user = %d, want 1", maxSeen)
+	}
+}
+
+func TestTokenLockKey(t *testing.T) {
+	ctx := context.Background()
+
+	token, err := jwt.GenerateSimpleTestAccessToken("auth0|user-1", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "jwt", token: token, want: "auth0|user-1"},
+		{name: "bearer jwt", token: "Bearer " + token, want: "auth0|user-1"},
+		{name: "opaque token", token: "opaque-token", want: "opaque-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenLockKey(ctx, tt.token); got != tt.want {
+				t.Errorf("tokenLockKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
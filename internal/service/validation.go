@@ -0,0 +1,38 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// verboseErrorsContextKey is the context key of the request for the failed rules
+type verboseErrorsContextKey struct{}
+
+// ContextWithVerboseErrors returns a copy of the context recording whether the caller asked for
+// every rule failed by an invalid request, see constants.ValidationDetailHeader
+func ContextWithVerboseErrors(ctx context.Context, requested bool) context.Context {
+	return context.WithValue(ctx, verboseErrorsContextKey{}, requested)
+}
+
+// verboseErrors reports whether the caller asked for the failed rules and is trusted with them,
+// the untrusted callers only get the terse error
+func (m *messageHandlerOrchestrator) verboseErrors(ctx context.Context) bool {
+	requested, _ := ctx.Value(verboseErrorsContextKey{}).(bool)
+	return requested && m.callerGranted(ctx, m.verboseErrorCallers, model.CallerCapabilityVerboseErrors)
+}
+
+// validationErrorResponse builds the error response of an invalid request, the error is the first
+// rule failed and the trusted callers asking for it get all of them
+func (m *messageHandlerOrchestrator) validationErrorResponse(ctx context.Context, violations model.ValidationViolations) []byte {
+	response := m.errorDataResponse(ctx, violations.Err())
+	if m.verboseErrors(ctx) {
+		response.Violations = violations
+	}
+	responseJSON, _ := json.Marshal(response)
+	return responseJSON
+}
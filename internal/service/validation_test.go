@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+func TestMessageHandlerOrchestrator_UpdateUser_Violations(t *testing.T) {
+	request, _ := json.Marshal(&model.User{ClearFields: []string{"username"}})
+
+	tests := []struct {
+		name           string
+		caller         string
+		verbose        bool
+		wantViolations int
+	}{
+		{name: "trusted caller asking for the violations", caller: "portal", verbose: true, wantViolations: 2},
+		{name: "allowlisted caller asking for the violations", caller: "crm-service", verbose: true, wantViolations: 2},
+		{name: "trusted caller not asking", caller: "portal"},
+		{name: "untrusted caller asking", caller: "project-service", verbose: true},
+		{name: "allowlist unavailable", caller: "flaky-service", verbose: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := NewMessageHandlerOrchestrator(
+				WithUserWriterForMessageHandler(&mockUserServiceWriter{}),
+				WithVerboseErrorCallersForMessageHandler("portal", ""),
+				WithCallerAllowlistForMessageHandler(&fakeCallerAllowlist{entries: map[string]*model.CallerAllowlistEntry{
+					"crm-service": {ID: "crm-service", Capabilities: []model.CallerCapability{model.CallerCapabilityVerboseErrors}},
+				}}),
+			)
+			ctx := ContextWithVerboseErrors(ContextWithCaller(context.Background(), tt.caller), tt.verbose)
+
+			result, err := orchestrator.UpdateUser(ctx, &mockTransportMessenger{data: request})
+			if err != nil {
+				t.Fatalf("UpdateUser() unexpected error: %v", err)
+			}
+
+			var response UserDataResponse
+			if err := json.Unmarshal(result, &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Success || response.Error != "token is required" || response.ErrorCode != "validation" {
+				t.Errorf("UpdateUser() = %s", result)
+			}
+			if len(response.Violations) != tt.wantViolations {
+				t.Errorf("UpdateUser() violations = %+v, want %d", response.Violations, tt.wantViolations)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+// WatermarkResponse adds the meta block identifying the deployment to a JSON object response.
+//
+// Responses that are not a JSON object (e.g. a plain username), or already carrying a meta
+// field, are returned unchanged.
+func WatermarkResponse(response []byte, meta model.ResponseMeta) []byte {
+	if meta.IsZero() || !bytes.HasPrefix(bytes.TrimSpace(response), []byte("{")) {
+		return response
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(response, &payload); err != nil {
+		return response
+	}
+	if _, exists := payload["meta"]; exists {
+		return response
+	}
+
+	encodedMeta, err := json.Marshal(meta)
+	if err != nil {
+		return response
+	}
+	payload["meta"] = encodedMeta
+
+	watermarked, err := json.Marshal(payload)
+	if err != nil {
+		return response
+	}
+	return watermarked
+}
@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package service
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/internal/domain/model"
+)
+
+func TestWatermarkResponse(t *testing.T) {
+	meta := model.ResponseMeta{Environment: "staging", Instance: "auth-service-7d9f-x2"}
+
+	tests := []struct {
+		name     string
+		meta     model.ResponseMeta
+		response string
+		want     string
+	}{
+		{
+			name:     "success response",
+			meta:     meta,
+			response: `{"success":true,"data":{"z":1,"a":"b"}}`,
+			want:     `{"data":{"z":1,"a":"b"},"meta":{"environment":"staging","instance":"auth-service-7d9f-x2"},"success":true}`,
+		},
+		{
+			name:     "error response",
+			meta:     model.ResponseMeta{Instance: "auth-service-7d9f-x2"},
+			response: `{"error":"unknown subject"}`,
+			want:     `{"error":"unknown subject","meta":{"instance":"auth-service-7d9f-x2"}}`,
+		},
+		{
+			name:     "nothing to stamp",
+			response: `{"success":true}`,
+			want:     `{"success":true}`,
+		},
+		{
+			name:     "plain responses are untouched",
+			meta:     meta,
+			response: `johndoe`,
+			want:     `johndoe`,
+		},
+		{
+			name:     "arrays are untouched",
+			meta:     meta,
+			response: `[{"sub":"auth0|123"}]`,
+			want:     `[{"sub":"auth0|123"}]`,
+		},
+		{
+			name:     "existing meta is kept",
+			meta:     meta,
+			response: `{"success":true,"meta":{"page":2}}`,
+			want:     `{"success":true,"meta":{"page":2}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(WatermarkResponse([]byte(tt.response), tt.meta))
+			if got != tt.want {
+				t.Errorf("WatermarkResponse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
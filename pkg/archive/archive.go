@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package archive seals data into passphrase encrypted archives.
+//
+// An archive is laid out as: magic | salt | nonce | AES-256-GCM(gzip(data)),
+// the key is derived from the passphrase with scrypt.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	magic     = "LFXARCH1"
+	saltSize  = 16
+	keySize   = 32
+	scryptN   = 1 << 15
+	scryptR   = 8
+	scryptP   = 1
+	minLength = len(magic) + saltSize
+)
+
+func deriveAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.NewValidation("archive passphrase is required")
+	}
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to derive archive key", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to create archive cipher", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to create archive cipher", err)
+	}
+	return aead, nil
+}
+
+// Seal compresses and encrypts data with the passphrase and writes the archive to w
+func Seal(w io.Writer, passphrase, data []byte) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return errors.NewUnexpected("failed to generate archive salt", err)
+	}
+	aead, err := deriveAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.NewUnexpected("failed to generate archive nonce", err)
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return errors.NewUnexpected("failed to compress archive", err)
+	}
+	if err := zw.Close(); err != nil {
+		return errors.NewUnexpected("failed to compress archive", err)
+	}
+
+	// the header is authenticated, so a tampered salt or magic fails to open
+	header := append([]byte(magic), salt...)
+	sealed := aead.Seal(nil, nonce, compressed.Bytes(), header)
+
+	for _, part := range [][]byte{header, nonce, sealed} {
+		if _, err := w.Write(part); err != nil {
+			return errors.NewUnexpected("failed to write archive", err)
+		}
+	}
+	return nil
+}
+
+// Open reads an archive from r and returns the decrypted data
+func Open(r io.Reader, passphrase []byte) ([]byte, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to read archive", err)
+	}
+	if len(raw) < minLength || string(raw[:len(magic)]) != magic {
+		return nil, errors.NewValidation("invalid archive format")
+	}
+	header, rest := raw[:minLength], raw[minLength:]
+
+	aead, err := deriveAEAD(passphrase, header[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, errors.NewValidation("invalid archive format")
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+
+	compressed, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return nil, errors.NewValidation("failed to decrypt archive, wrong passphrase or corrupted file")
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to decompress archive", err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to decompress archive", err)
+	}
+	return data, nil
+}
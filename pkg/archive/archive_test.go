@@ -0,0 +1,60 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package archive
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	data := []byte(`{"buckets":{"authelia-users":[]}}`)
+
+	var sealed bytes.Buffer
+	if err := Seal(&sealed, []byte("s3cret"), data); err != nil {
+		t.Fatalf("Seal() unexpected error: %v", err)
+	}
+	if bytes.Contains(sealed.Bytes(), []byte("authelia-users")) {
+		t.Fatal("Seal() archive contains plaintext")
+	}
+
+	tamper := func(i int) []byte {
+		b := bytes.Clone(sealed.Bytes())
+		b[i] ^= 0xff
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		archive    []byte
+		passphrase string
+		wantErr    bool
+	}{
+		{name: "valid passphrase", archive: sealed.Bytes(), passphrase: "s3cret"},
+		{name: "wrong passphrase", archive: sealed.Bytes(), passphrase: "other", wantErr: true},
+		{name: "empty passphrase", archive: sealed.Bytes(), passphrase: "", wantErr: true},
+		{name: "tampered salt", archive: tamper(len(magic)), passphrase: "s3cret", wantErr: true},
+		{name: "tampered payload", archive: tamper(sealed.Len() - 1), passphrase: "s3cret", wantErr: true},
+		{name: "not an archive", archive: []byte("plain text file"), passphrase: "s3cret", wantErr: true},
+		{name: "truncated", archive: sealed.Bytes()[:minLength+2], passphrase: "s3cret", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Open(bytes.NewReader(tt.archive), []byte(tt.passphrase))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, data) {
+				t.Errorf("Open() = %s, want %s", got, data)
+			}
+		})
+	}
+}
+
+func TestSeal_EmptyPassphrase(t *testing.T) {
+	if err := Seal(&bytes.Buffer{}, nil, []byte("data")); err == nil {
+		t.Error("Seal() expected error for an empty passphrase")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package clock provides the time source of the expirations (tokens, pending flows, grace
+// periods), so the tests can move the time forward instead of sleeping.
+//
+// The components taking a `now func() time.Time` accept the Now method of a Clock.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the clock of the system
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+// Now returns the current time of the system
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Or returns the clock, or the system clock when nil
+func Or(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
+
+// Fake is a clock for the tests, the time only moves when told to
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Now returns the time of the fake clock
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the time forward
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Set sets the time
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// NewFake creates a fake clock stopped at the given time
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
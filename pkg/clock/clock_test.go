@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	if !fake.Now().Equal(start) {
+		t.Fatalf("Now() = %v, want %v", fake.Now(), start)
+	}
+
+	fake.Advance(90 * time.Minute)
+	if want := start.Add(90 * time.Minute); !fake.Now().Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", fake.Now(), want)
+	}
+
+	fake.Set(start)
+	if !fake.Now().Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", fake.Now(), start)
+	}
+}
+
+func TestOr(t *testing.T) {
+	if Or(nil) != System {
+		t.Error("Or(nil) must be the system clock")
+	}
+
+	fake := NewFake(time.Time{})
+	if Or(fake) != fake {
+		t.Error("Or(fake) must be the fake clock")
+	}
+
+	if time.Since(System.Now()) > time.Minute {
+		t.Errorf("System.Now() = %v, want the current time", System.Now())
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package concurrent
+
+import (
+	"hash/fnv"
+	"slices"
+	"sync"
+)
+
+// DefaultStripes is the number of stripes of a StripedMutex when none is given
+const DefaultStripes = 256
+
+// StripedMutex serializes the work on the same keys with a fixed set of mutexes, the keys
+// are hashed to a stripe so the memory doesn't grow with the number of keys. Unrelated keys
+// can share a stripe, they are only serialized then.
+type StripedMutex struct {
+	stripes []sync.Mutex
+}
+
+// stripe returns the index of the stripe of the key
+func (s *StripedMutex) stripe(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(s.stripes)))
+}
+
+// Lock locks the stripes of the keys and returns the function releasing them. The stripes
+// are locked in order, so locking several keys at once can't deadlock with another Lock.
+func (s *StripedMutex) Lock(keys ...string) (unlock func()) {
+	indexes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		indexes = append(indexes, s.stripe(key))
+	}
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+
+	for _, i := range indexes {
+		s.stripes[i].Lock()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for i := len(indexes) - 1; i >= 0; i-- {
+				s.stripes[indexes[i]].Unlock()
+			}
+		})
+	}
+}
+
+// NewStripedMutex creates a new striped mutex with the specified number of stripes
+func NewStripedMutex(stripes int) *StripedMutex {
+	if stripes <= 0 {
+		stripes = DefaultStripes
+	}
+	return &StripedMutex{
+		stripes: make([]sync.Mutex, stripes),
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package concurrent
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripedMutex_SerializesSameKey(t *testing.T) {
+	mutex := NewStripedMutex(0)
+
+	var (
+		wg      sync.WaitGroup
+		inside  int
+		maxSeen int
+		mu      sync.Mutex
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := mutex.Lock("auth0|user-1")
+			defer unlock()
+
+			mu.Lock()
+			inside++
+			maxSeen = max(maxSeen, inside)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inside--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxSeen)
+}
+
+func TestStripedMutex_MultipleKeys(t *testing.T) {
+	mutex := NewStripedMutex(4)
+
+	// the same key twice, or two keys sharing a stripe, must not deadlock
+	unlock := mutex.Lock("a", "a", "b", "c", "d", "e")
+	unlock()
+	unlock() // releasing twice is a no-op
+
+	// opposite orders must not deadlock
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			mutex.Lock("primary", "secondary")()
+		}()
+		go func() {
+			defer wg.Done()
+			mutex.Lock("secondary", "primary")()
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Lock() deadlocked")
+	}
+}
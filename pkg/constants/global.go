@@ -8,6 +8,49 @@ const (
 	// ServiceName is the name of the auth service
 	ServiceName = "lfx-v2-auth-service"
 
+	// ServiceRegionEnvKey is the environment variable key for the region the service runs in,
+	// used to tag writes and events when running active/active across regions
+	ServiceRegionEnvKey = "SERVICE_REGION"
+
+	// ServiceEnvironmentEnvKey is the environment variable key for the environment the service runs in
+	// (e.g. production, staging), stamped on every response
+	ServiceEnvironmentEnvKey = "SERVICE_ENVIRONMENT"
+
+	// ServiceInstanceEnvKey is the environment variable key for the instance of the service, stamped on
+	// every response, the host name (the pod name) when unset
+	ServiceInstanceEnvKey = "SERVICE_INSTANCE"
+
+	// SlowRequestThresholdEnvKey is the environment variable key for the latency above which the
+	// requests are logged with their upstream calls, 0 disables the slow requests log
+	SlowRequestThresholdEnvKey = "SLOW_REQUEST_THRESHOLD"
+
+	// SlowRequestSampleRateEnvKey is the environment variable key for the share of the slow requests
+	// logged with redacted payload excerpts, between 0 and 1
+	SlowRequestSampleRateEnvKey = "SLOW_REQUEST_SAMPLE_RATE"
+
+	// MessageMiddlewaresEnvKey is the environment variable key for the comma separated middlewares
+	// of the message handlers, outermost first (recover, usage, sli, slow_requests), the middlewares
+	// left out are disabled
+	MessageMiddlewaresEnvKey = "MESSAGE_MIDDLEWARES"
+
+	// MessageWorkersEnvKey is the environment variable key for the worker pools handling the NATS
+	// requests by priority, unset handles the requests of each subject one by one
+	// The value is of the form: interactive=32,batch=4
+	MessageWorkersEnvKey = "MESSAGE_WORKERS"
+
+	// MessageBatchSubjectsEnvKey is the environment variable key for the comma separated subjects handled
+	// by the batch workers, replacing the default ones (bulk reads, enrichment, email hash membership
+	// and the reports)
+	MessageBatchSubjectsEnvKey = "MESSAGE_BATCH_SUBJECTS"
+
+	// ConsumerContractsEnvKey is the environment variable key for the comma separated service levels
+	// promised to the downstream consumers (caller:operation=latency[/error_rate])
+	ConsumerContractsEnvKey = "CONSUMER_CONTRACTS"
+
+	// ConsumerSLIWindowEnvKey is the environment variable key for the window the service levels of the
+	// consumer contracts are measured over
+	ConsumerSLIWindowEnvKey = "CONSUMER_SLI_WINDOW"
+
 	// UserRepositoryTypeEnvKey is the environment variable key for the user repository type
 	UserRepositoryTypeEnvKey = "USER_REPOSITORY_TYPE"
 
@@ -19,6 +62,42 @@ const (
 
 	// UserRepositoryTypeAuth0 is the value for the Auth0 user repository type
 	UserRepositoryTypeAuth0 = "auth0"
+
+	// UserRepositoryTypeKeycloak is the value for the Keycloak user repository type
+	UserRepositoryTypeKeycloak = "keycloak"
+
+	// UserRepositoryTypeOkta is the value for the Okta user repository type
+	UserRepositoryTypeOkta = "okta"
+
+	// UserRepositoryTypeCognito is the value for the AWS Cognito user repository type
+	UserRepositoryTypeCognito = "cognito"
+)
+
+const (
+	// Mock configuration
+	// MockProviderLatencyEnvKey is the environment variable key for the latency of the mock provider
+	// operations in milliseconds, fixed ("150") or picked uniformly in a range ("80-400")
+	MockProviderLatencyEnvKey = "MOCK_PROVIDER_LATENCY_MS"
+
+	// MockProviderErrorRateEnvKey is the environment variable key for the share of the mock provider
+	// operations failing with a service unavailable error, between 0 and 1
+	MockProviderErrorRateEnvKey = "MOCK_PROVIDER_ERROR_RATE"
+
+	// MockIndexCheckIntervalEnvKey is the environment variable key for how often the lookup keys of
+	// the mock users are checked against the users, a Go duration, 0 disables the check
+	MockIndexCheckIntervalEnvKey = "MOCK_INDEX_CHECK_INTERVAL"
+
+	// MockUsersFileEnvKey is the environment variable key for the YAML file the mock users are
+	// loaded from instead of the embedded users.yaml, loaded again when it changes
+	MockUsersFileEnvKey = "MOCK_USERS_FILE"
+
+	// MockProviderFaultsEnvKey is the environment variable key for the errors injected in the mock
+	// provider operations, comma separated operation=error[/rate], e.g. search_user=rate_limited/0.5
+	MockProviderFaultsEnvKey = "MOCK_PROVIDER_FAULTS"
+
+	// MockProviderOperationLatencyEnvKey is the environment variable key for the latency of single
+	// mock provider operations in milliseconds, comma separated operation=latency, e.g. get_user=80-400
+	MockProviderOperationLatencyEnvKey = "MOCK_PROVIDER_OPERATION_LATENCY_MS"
 )
 
 const (
@@ -37,6 +116,28 @@ const (
 
 	// AutheliaOIDCUserInfoURLEnvKey is the environment variable key for the OIDC userinfo URL
 	AutheliaOIDCUserInfoURLEnvKey = "AUTHELIA_OIDC_USERINFO_URL"
+
+	// AutheliaRestoreGracePeriodEnvKey is the environment variable key for the soft-delete restore grace period
+	AutheliaRestoreGracePeriodEnvKey = "AUTHELIA_RESTORE_GRACE_PERIOD"
+
+	// AutheliaStaleProfileMonthsEnvKey is the environment variable key for the number of months
+	// without updates after which a profile is considered stale, zero or unset disables the scan
+	AutheliaStaleProfileMonthsEnvKey = "AUTHELIA_STALE_PROFILE_MONTHS"
+
+	// AutheliaStaleProfileScanIntervalEnvKey is the environment variable key for the stale profile scan interval
+	AutheliaStaleProfileScanIntervalEnvKey = "AUTHELIA_STALE_PROFILE_SCAN_INTERVAL"
+
+	// AutheliaEventSourcingEnvKey is the environment variable key to journal the authelia users
+	// changes in a JetStream stream, so the KV store can be rebuilt from it
+	AutheliaEventSourcingEnvKey = "AUTHELIA_EVENT_SOURCING"
+
+	// AutheliaIndexReconcileIntervalEnvKey is the environment variable key for how often the lookup
+	// index is reconciled with the users when running active/active, unset disables it
+	AutheliaIndexReconcileIntervalEnvKey = "AUTHELIA_INDEX_RECONCILE_INTERVAL"
+
+	// AutheliaSyncDryRunEnvKey is the environment variable key to compute and log the changes of the
+	// startup sync without applying them to the storage, the ConfigMap and the Secrets
+	AutheliaSyncDryRunEnvKey = "AUTHELIA_SYNC_DRY_RUN"
 )
 
 const (
@@ -47,6 +148,11 @@ const (
 	// Auth0DomainEnvKey is the environment variable key for the Auth0 domain
 	Auth0DomainEnvKey = "AUTH0_DOMAIN"
 
+	// Auth0TenantsEnvKey is the environment variable key for the comma separated aliases of the
+	// additional Auth0 tenants served next to the primary one, their configuration is read from the
+	// Auth0 variables prefixed with the alias, e.g. AUTH0_COMMUNITY_DOMAIN for the community alias
+	Auth0TenantsEnvKey = "AUTH0_ADDITIONAL_TENANTS"
+
 	// Auth0 M2M Authentication configuration
 	// Auth0M2MClientIDEnvKey is the environment variable key for the Auth0 M2M client ID
 	Auth0M2MClientIDEnvKey = "AUTH0_M2M_CLIENT_ID"
@@ -57,6 +163,37 @@ const (
 	// Auth0AudienceEnvKey is the environment variable key for the Auth0 audience
 	Auth0AudienceEnvKey = "AUTH0_AUDIENCE"
 
+	// Auth0OrganizationAdminClaimEnvKey is the environment variable key for the custom claim
+	// listing the organizations the user administers
+	Auth0OrganizationAdminClaimEnvKey = "AUTH0_ORG_ADMIN_CLAIM"
+
+	// Auth0CassetteModeEnvKey is the environment variable key to record the Auth0 traffic to a cassette
+	// (record) or to replay it offline without the tenant credentials (replay), development only
+	Auth0CassetteModeEnvKey = "AUTH0_CASSETTE_MODE"
+
+	// Auth0CassettePathEnvKey is the environment variable key for the cassette file of the Auth0 traffic
+	Auth0CassettePathEnvKey = "AUTH0_CASSETTE_PATH"
+
+	// Auth0CanaryJWKSURLEnvKey is the environment variable key for a candidate JWKS source verifying a
+	// sample of the tokens next to the tenant one, the disagreements are logged before switching to it
+	Auth0CanaryJWKSURLEnvKey = "AUTH0_CANARY_JWKS_URL"
+
+	// Auth0CanarySampleRateEnvKey is the environment variable key for the share of the tokens verified
+	// with the candidate JWKS source, between 0 and 1
+	Auth0CanarySampleRateEnvKey = "AUTH0_CANARY_SAMPLE_RATE"
+
+	// Auth0TrustedIssuersEnvKey is the environment variable key for the OpenID Connect issuers trusted
+	// in addition to the tenant, comma separated issuer[=audience[|audience]] entries
+	Auth0TrustedIssuersEnvKey = "AUTH0_TRUSTED_ISSUERS"
+
+	// Auth0SearchMaxPagesEnvKey is the environment variable key for the maximum number of pages of
+	// the users read by a search by username or alternate email
+	Auth0SearchMaxPagesEnvKey = "AUTH0_SEARCH_MAX_PAGES"
+
+	// Auth0RateLimitsEnvKey is the environment variable key for the client side rate limits of the
+	// Management API endpoints, comma separated path-prefix=rate[/burst] entries in requests per second
+	Auth0RateLimitsEnvKey = "AUTH0_RATE_LIMITS"
+
 	// Auth0 LFX Profile Client configuration (Regular Web Application for passwordless flows)
 	// Auth0LFXProfileClientIDEnvKey is the environment variable key for the LFX Profile Auth0 client ID
 	Auth0LFXProfileClientIDEnvKey = "AUTH0_LFX_PROFILE_CLIENT_ID"
@@ -65,6 +202,375 @@ const (
 	Auth0LFXProfileClientSecretEnvKey = "AUTH0_LFX_PROFILE_CLIENT_SECRET"
 )
 
+const (
+	// Keycloak Admin REST API configuration
+	// KeycloakURLEnvKey is the environment variable key for the Keycloak base URL
+	KeycloakURLEnvKey = "KEYCLOAK_URL"
+
+	// KeycloakRealmEnvKey is the environment variable key for the Keycloak realm of the users
+	KeycloakRealmEnvKey = "KEYCLOAK_REALM"
+
+	// KeycloakClientIDEnvKey is the environment variable key for the client ID of the service account
+	KeycloakClientIDEnvKey = "KEYCLOAK_CLIENT_ID"
+
+	// KeycloakClientSecretEnvKey is the environment variable key for the client secret of the service account
+	KeycloakClientSecretEnvKey = "KEYCLOAK_CLIENT_SECRET"
+
+	// KeycloakAudienceEnvKey is the environment variable key for the expected audience of the user tokens
+	KeycloakAudienceEnvKey = "KEYCLOAK_AUDIENCE"
+)
+
+const (
+	// Okta Users API configuration
+	// OktaDomainEnvKey is the environment variable key for the Okta org domain
+	OktaDomainEnvKey = "OKTA_DOMAIN"
+
+	// OktaClientIDEnvKey is the environment variable key for the client ID of the Okta API service app
+	OktaClientIDEnvKey = "OKTA_CLIENT_ID"
+
+	// OktaPrivateBase64KeyEnvKey is the environment variable key for the base64 encoded private key
+	// of the Okta API service app
+	OktaPrivateBase64KeyEnvKey = "OKTA_PRIVATE_BASE64_KEY"
+
+	// OktaScopesEnvKey is the environment variable key for the space separated Okta API scopes of the service app
+	OktaScopesEnvKey = "OKTA_SCOPES"
+
+	// OktaIssuerEnvKey is the environment variable key for the authorization server of the user tokens
+	OktaIssuerEnvKey = "OKTA_ISSUER"
+
+	// OktaAudienceEnvKey is the environment variable key for the expected audience of the user tokens
+	OktaAudienceEnvKey = "OKTA_AUDIENCE"
+)
+
+const (
+	// AWS Cognito User Pools configuration, the requests are signed with the AWS_* credentials
+	// CognitoUserPoolIDEnvKey is the environment variable key for the user pool id, e.g. us-east-1_AbC123
+	CognitoUserPoolIDEnvKey = "COGNITO_USER_POOL_ID"
+
+	// CognitoRegionEnvKey is the environment variable key for the region of the user pool
+	CognitoRegionEnvKey = "COGNITO_REGION"
+
+	// CognitoEndpointEnvKey is the environment variable key for the Cognito API endpoint
+	CognitoEndpointEnvKey = "COGNITO_ENDPOINT"
+
+	// CognitoClientIDEnvKey is the environment variable key for the expected app client of the user tokens
+	CognitoClientIDEnvKey = "COGNITO_CLIENT_ID"
+
+	// CognitoResourceServerEnvKey is the environment variable key for the resource server of the custom scopes
+	CognitoResourceServerEnvKey = "COGNITO_RESOURCE_SERVER"
+
+	// CognitoLinkingSecretEnvKey is the environment variable key for the secret signing the identity
+	// tokens of the verified alternate emails
+	CognitoLinkingSecretEnvKey = "COGNITO_LINKING_SECRET"
+)
+
+const (
+	// Email provider configuration
+	// EmailProviderEnvKey is the environment variable key for the email provider (smtp or ses)
+	EmailProviderEnvKey = "EMAIL_PROVIDER"
+
+	// EmailProviderSMTP is the value for the SMTP email provider
+	EmailProviderSMTP = "smtp"
+
+	// EmailProviderSES is the value for the AWS SES email provider
+	EmailProviderSES = "ses"
+
+	// EmailNotificationsEnvKey is the environment variable key to email the notifications to the users through
+	// the configured email provider: the stale profile nudges and the notice of a primary email change
+	// sent to the previous primary email
+	EmailNotificationsEnvKey = "EMAIL_NOTIFICATIONS"
+
+	// EmailTemplatePrimaryEmailChanged is the email template notifying the previous primary email of a change,
+	// so the owner can recover the account when they didn't change it
+	EmailTemplatePrimaryEmailChanged = "primary_email_changed"
+
+	// EmailTemplateRateLimitsEnvKey is the environment variable key for the per-template rate limits
+	// The value is of the form: email_verification=5/1m,other_template=100/1h
+	EmailTemplateRateLimitsEnvKey = "EMAIL_TEMPLATE_RATE_LIMITS"
+
+	// AWS SES configuration
+	// EmailSESRegionEnvKey is the environment variable key for the SES region
+	EmailSESRegionEnvKey = "EMAIL_SES_REGION"
+
+	// EmailSESEndpointEnvKey is the environment variable key for overriding the SES API endpoint
+	EmailSESEndpointEnvKey = "EMAIL_SES_ENDPOINT"
+
+	// AWSAccessKeyIDEnvKey is the environment variable key for the AWS access key ID
+	AWSAccessKeyIDEnvKey = "AWS_ACCESS_KEY_ID"
+
+	// AWSSecretAccessKeyEnvKey is the environment variable key for the AWS secret access key
+	AWSSecretAccessKeyEnvKey = "AWS_SECRET_ACCESS_KEY"
+
+	// AWSSessionTokenEnvKey is the environment variable key for the AWS session token
+	AWSSessionTokenEnvKey = "AWS_SESSION_TOKEN"
+)
+
+const (
+	// OrganizationDomainsEnvKey is the environment variable key for the registered organization domains
+	// used to compute the organization verified badge
+	// The value is of the form: The Linux Foundation=linuxfoundation.org,lfx.dev;CNCF=cncf.io
+	OrganizationDomainsEnvKey = "ORGANIZATION_DOMAINS"
+
+	// EmailNormalizationEnvKey is the environment variable key to turn off the provider specific
+	// email canonicalization (Gmail dots and plus addresses, for example) used to match emails
+	EmailNormalizationEnvKey = "EMAIL_NORMALIZATION"
+
+	// UsageAccountingEnvKey is the environment variable key to count the requests per caller and
+	// operation, the daily aggregates are persisted in the usage KV bucket
+	UsageAccountingEnvKey = "USAGE_ACCOUNTING"
+
+	// CostBudgetsEnvKey is the environment variable key for the per caller daily budgets of the
+	// expensive identity provider operations (searches), the counts are kept in the usage KV bucket
+	// The value is of the form: default=2000,project-service=10000 (0 means unlimited)
+	CostBudgetsEnvKey = "COST_BUDGETS"
+
+	// EmailLinkingSendLimitsEnvKey is the environment variable key for the limits of the verification
+	// emails of the email linking, per email and per user requesting them
+	// The value is of the form: email=3/1h,user=10/24h (a count of 0 disables the limit)
+	EmailLinkingSendLimitsEnvKey = "EMAIL_LINKING_SEND_LIMITS"
+
+	// EmailLinkingSendLimitStoreEnvKey is the environment variable key for the store of the send counts,
+	// memory (per replica, the default) or nats (shared by the replicas through the usage KV bucket)
+	EmailLinkingSendLimitStoreEnvKey = "EMAIL_LINKING_SEND_LIMIT_STORE"
+
+	// PhoneLinkingEnvKey is the environment variable key to enable the phone number linking, the codes are
+	// sent by the passwordless SMS connection of the identity provider (Auth0 only)
+	PhoneLinkingEnvKey = "PHONE_LINKING"
+
+	// PhoneLinkingChannelEnvKey is the environment variable key for the delivery channel of the phone
+	// verification codes, sms (the default) or whatsapp
+	PhoneLinkingChannelEnvKey = "PHONE_LINKING_CHANNEL"
+
+	// ProfilePictureStorageEnvKey is the environment variable key for the storage of the uploaded profile
+	// pictures: s3, gcs or local, unset disables the uploads
+	ProfilePictureStorageEnvKey = "PROFILE_PICTURE_STORAGE"
+
+	// ProfilePictureBucketEnvKey is the environment variable key for the bucket of the s3 and gcs storages
+	ProfilePictureBucketEnvKey = "PROFILE_PICTURE_BUCKET"
+
+	// ProfilePictureRegionEnvKey is the environment variable key for the region of the s3 bucket
+	ProfilePictureRegionEnvKey = "PROFILE_PICTURE_REGION"
+
+	// ProfilePictureEndpointEnvKey is the environment variable key for the endpoint of a S3 compatible
+	// storage, like MinIO
+	ProfilePictureEndpointEnvKey = "PROFILE_PICTURE_ENDPOINT"
+
+	// ProfilePictureDirEnvKey is the environment variable key for the directory of the local storage
+	ProfilePictureDirEnvKey = "PROFILE_PICTURE_DIR"
+
+	// ProfilePictureBaseURLEnvKey is the environment variable key for the URL the pictures are served from,
+	// a CDN in front of the bucket or the URL of the service for the local storage
+	ProfilePictureBaseURLEnvKey = "PROFILE_PICTURE_BASE_URL"
+
+	// ProfilePictureMaxSizeEnvKey is the environment variable key for the maximum size of the uploaded
+	// pictures, in bytes
+	ProfilePictureMaxSizeEnvKey = "PROFILE_PICTURE_MAX_SIZE"
+
+	// EmailLinkingLockoutEnvKey is the environment variable key for the lockout of the email verification
+	// after repeated failed attempts of the same email
+	// The value is of the form: 5/15m, failures/cooldown (0 failures disables the lockout)
+	EmailLinkingLockoutEnvKey = "EMAIL_LINKING_LOCKOUT"
+
+	// EmailLinkingLockoutStoreEnvKey is the environment variable key for the store of the failed attempts,
+	// nats (shared by the replicas through the usage KV bucket, the default) or memory (per replica)
+	EmailLinkingLockoutStoreEnvKey = "EMAIL_LINKING_LOCKOUT_STORE"
+
+	// EmailLinkingCodeStoreEnvKey is the environment variable key for the store of the pending alternate email
+	// OTPs of Keycloak, Okta and Cognito, nats (shared by the replicas through the email linking codes KV
+	// bucket, the default) or memory (the OTP must be verified on the replica that sent it)
+	EmailLinkingCodeStoreEnvKey = "EMAIL_LINKING_CODE_STORE"
+
+	// DistributedLocksEnvKey is the environment variable key to coordinate the replicas with the locks
+	// of the locks KV bucket: the per-user updates, the Authelia sync and its background jobs
+	DistributedLocksEnvKey = "DISTRIBUTED_LOCKS"
+
+	// StorageMigrationsEnvKey is the environment variable key to create the KV buckets and the streams
+	// of the service at startup with the versioned storage migrations, instead of the chart
+	StorageMigrationsEnvKey = "STORAGE_MIGRATIONS"
+
+	// UserCacheEnvKey is the environment variable key for the cache of the users read from the identity
+	// provider: memory for a cache per replica, nats for the user cache KV bucket, unset disables it
+	UserCacheEnvKey = "USER_CACHE"
+
+	// UserCacheTTLEnvKey is the environment variable key for how long a cached user is served
+	UserCacheTTLEnvKey = "USER_CACHE_TTL"
+
+	// UserCacheSizeEnvKey is the environment variable key for the maximum number of keys of the memory cache
+	UserCacheSizeEnvKey = "USER_CACHE_SIZE"
+
+	// UserCacheStaleFallbackEnvKey is the environment variable key for the age of the oldest cached user
+	// served, marked stale, while the identity provider is unavailable, unset disables the fallback
+	UserCacheStaleFallbackEnvKey = "USER_CACHE_STALE_FALLBACK"
+
+	// UserSearchDedupeWindowEnvKey is the environment variable key for how long the result of a user search
+	// answers the identical searches, zero only collapses those in flight, unset disables the dedupe
+	UserSearchDedupeWindowEnvKey = "USER_SEARCH_DEDUPE_WINDOW"
+
+	// UserCacheMemory is the value for the in-memory users cache of every replica
+	UserCacheMemory = "memory"
+
+	// UserCacheNATS is the value for the users cache shared by the replicas in NATS KV
+	UserCacheNATS = "nats"
+
+	// ProfileStreamEnvKey is the environment variable key to publish the public profile documents
+	// of the changed users to the profiles stream, consumed by the people search indexers
+	ProfileStreamEnvKey = "PROFILE_STREAM"
+
+	// ConsistencyAuditEnvKey is the environment variable key to audit periodically a sample of the users
+	// of the internal indices (the users cache, the profiles stream) against the identity provider
+	ConsistencyAuditEnvKey = "CONSISTENCY_AUDIT"
+
+	// ConsistencyAuditIntervalEnvKey is the environment variable key for how often the indices are audited
+	ConsistencyAuditIntervalEnvKey = "CONSISTENCY_AUDIT_INTERVAL"
+
+	// ConsistencyAuditSampleSizeEnvKey is the environment variable key for the number of users audited by each run
+	ConsistencyAuditSampleSizeEnvKey = "CONSISTENCY_AUDIT_SAMPLE_SIZE"
+
+	// ConsistencyAuditRepairEnvKey is the environment variable key to repair the divergent indices
+	// found by the audit instead of only reporting them
+	ConsistencyAuditRepairEnvKey = "CONSISTENCY_AUDIT_REPAIR"
+
+	// ProfileTypeaheadEnvKey is the environment variable key to serve the typeahead search from an
+	// in-memory index of the profiles stream, built by every replica
+	ProfileTypeaheadEnvKey = "PROFILE_TYPEAHEAD"
+
+	// ProfileShareSecretEnvKey is the environment variable key for the key signing the profile share links,
+	// at least 32 bytes shared by the replicas, unset disables the share links
+	ProfileShareSecretEnvKey = "PROFILE_SHARE_SECRET"
+
+	// ProfileShareMaxTTLEnvKey is the environment variable key for the longest validity of a profile share link
+	ProfileShareMaxTTLEnvKey = "PROFILE_SHARE_MAX_TTL"
+
+	// SigningKeysStoreEnvKey is the environment variable key for where the rotated keys signing the internal
+	// tokens are stored, kubernetes or vault, unset signs with the static PROFILE_SHARE_SECRET
+	SigningKeysStoreEnvKey = "SIGNING_KEYS_STORE"
+
+	// SigningKeysRotationEnvKey is the environment variable key for how long a signing key signs before the next one
+	SigningKeysRotationEnvKey = "SIGNING_KEYS_ROTATION"
+
+	// SigningKeysRetentionEnvKey is the environment variable key for how long a superseded signing key keeps
+	// verifying the tokens it signed, at least PROFILE_SHARE_MAX_TTL
+	SigningKeysRetentionEnvKey = "SIGNING_KEYS_RETENTION"
+
+	// SigningKeysSecretEnvKey is the environment variable key for the name of the Kubernetes secret holding the signing keys,
+	// auth-service-signing-keys by default
+	SigningKeysSecretEnvKey = "SIGNING_KEYS_SECRET"
+
+	// SigningKeysNamespaceEnvKey is the environment variable key for the namespace of the signing keys secret,
+	// defaults to the namespace of the pod
+	SigningKeysNamespaceEnvKey = "SIGNING_KEYS_NAMESPACE"
+
+	// SigningKeysVaultPathEnvKey is the environment variable key for the path of the Vault KV version 2 secret
+	// holding the signing keys, including its mount, e.g. secret/lfx/auth-service/signing-keys
+	SigningKeysVaultPathEnvKey = "SIGNING_KEYS_VAULT_PATH"
+
+	// VaultAddrEnvKey is the environment variable key for the address of the Vault server
+	VaultAddrEnvKey = "VAULT_ADDR"
+
+	// VaultTokenEnvKey is the environment variable key for the token authenticating to the Vault server
+	VaultTokenEnvKey = "VAULT_TOKEN"
+
+	// TokenReferenceSecretEnvKey is the environment variable key for the secret encrypting the references
+	// returned instead of the raw ID tokens, at least 32 bytes shared by the replicas, unset returns the raw tokens
+	TokenReferenceSecretEnvKey = "TOKEN_REFERENCE_SECRET"
+
+	// TokenReferenceTTLEnvKey is the environment variable key for how long a token reference can be resolved
+	TokenReferenceTTLEnvKey = "TOKEN_REFERENCE_TTL"
+
+	// EmailBackupCodesEnvKey is the environment variable key to let the admins issue one-time backup codes
+	// verifying an email in place of the OTP, requires TOKEN_REFERENCE_SECRET
+	EmailBackupCodesEnvKey = "EMAIL_BACKUP_CODES"
+
+	// EmailBackupCodesTTLEnvKey is the environment variable key for how long the backup codes can be redeemed
+	EmailBackupCodesTTLEnvKey = "EMAIL_BACKUP_CODES_TTL"
+
+	// ProfileShareBaseURLEnvKey is the environment variable key for the base URL of the profile share links,
+	// the token is appended as last path segment, unset only returns the tokens
+	ProfileShareBaseURLEnvKey = "PROFILE_SHARE_BASE_URL"
+
+	// ResponsePoliciesEnvKey is the environment variable key for the per caller response policies,
+	// the reply fields each calling service must never see (see CallerTokenHeader)
+	// The value is of the form: reporting-service=primary_email,alternate_emails;search-service=phone_number
+	ResponsePoliciesEnvKey = "RESPONSE_POLICIES"
+
+	// EmailOwnerCallersEnvKey is the environment variable key for the comma separated calling services allowed
+	// to see the owner of a verified email (see CallerTokenHeader), the others only see the status
+	EmailOwnerCallersEnvKey = "EMAIL_OWNER_CALLERS"
+
+	// VerboseErrorCallersEnvKey is the environment variable key for the comma separated calling services
+	// trusted with the list of the rules failed by their invalid requests (see ValidationDetailHeader)
+	VerboseErrorCallersEnvKey = "VERBOSE_ERROR_CALLERS"
+
+	// MetadataEnrichmentCallersEnvKey is the environment variable key for the comma separated calling services
+	// (the enrichment jobs) allowed to fill the user metadata, requires METADATA_PROVENANCE
+	MetadataEnrichmentCallersEnvKey = "METADATA_ENRICHMENT_CALLERS"
+
+	// MetadataProvenanceEnvKey is the environment variable key to record the provenance of the metadata fields
+	// in the provenance KV bucket
+	MetadataProvenanceEnvKey = "METADATA_PROVENANCE"
+
+	// MetadataPolicyEnvKey is the environment variable key for the comma separated moderation policies
+	// reviewing the metadata updates in order (blocklist, nats), the updates aren't moderated when empty
+	MetadataPolicyEnvKey = "METADATA_POLICY"
+
+	// MetadataPolicyBlockedWordsEnvKey is the environment variable key for the comma separated words
+	// redacted from the metadata by the blocklist policy
+	MetadataPolicyBlockedWordsEnvKey = "METADATA_POLICY_BLOCKED_WORDS"
+
+	// MetadataPolicyLinksEnvKey is the environment variable key for the action of the blocklist policy on the
+	// links in the names, job title and organization (allow, redact or reject), defaults to reject
+	MetadataPolicyLinksEnvKey = "METADATA_POLICY_LINKS"
+
+	// MetadataPolicySubjectEnvKey is the environment variable key for the NATS subject of the moderation
+	// service reviewing the metadata, required by the nats policy
+	MetadataPolicySubjectEnvKey = "METADATA_POLICY_SUBJECT"
+
+	// MetadataPolicyTimeoutEnvKey is the environment variable key for how long the moderation service is awaited
+	MetadataPolicyTimeoutEnvKey = "METADATA_POLICY_TIMEOUT"
+
+	// MetadataPolicyFailOpenEnvKey is the environment variable key to allow the metadata updates while the
+	// moderation service is unavailable, they fail by default
+	MetadataPolicyFailOpenEnvKey = "METADATA_POLICY_FAIL_OPEN"
+
+	// CallerTokenIssuersEnvKey is the environment variable key for the comma separated issuers trusted to
+	// sign the service tokens of the callers (see CallerTokenHeader), of the form issuer[=audience[|audience]].
+	// Unset, the callers aren't identified: nothing is granted and the usage is attributed to unknown.
+	CallerTokenIssuersEnvKey = "CALLER_TOKEN_ISSUERS"
+
+	// CallerTokenClaimEnvKey is the environment variable key for the claim of the service tokens naming
+	// the caller (default: sub)
+	CallerTokenClaimEnvKey = "CALLER_TOKEN_CLAIM"
+
+	// CallerAllowlistEnvKey is the environment variable key to grant capabilities to the calling services
+	// from the caller allowlist KV bucket, managed with the admin REST API, on top of the environment
+	CallerAllowlistEnvKey = "CALLER_ALLOWLIST"
+
+	// AdminDashboardSubsEnvKey is the environment variable key for the comma separated subjects of the
+	// admins allowed to stream the internal events, unset disables the admin events endpoint
+	AdminDashboardSubsEnvKey = "ADMIN_DASHBOARD_SUBS"
+
+	// AdminDashboardOriginsEnvKey is the environment variable key for the comma separated origins of the
+	// admin dashboards allowed to open the events WebSocket, unset only accepts same origin requests
+	AdminDashboardOriginsEnvKey = "ADMIN_DASHBOARD_ORIGINS"
+
+	// AdminUIEnvKey is the environment variable key to serve the embedded admin UI under /admin/ui,
+	// its actions are authorized by the admin endpoints so it requires ADMIN_DASHBOARD_SUBS
+	AdminUIEnvKey = "ADMIN_UI"
+
+	// AuditSinksEnvKey is the environment variable key for the comma separated sinks of the audit events
+	// of the user mutations: log, nats, file or webhook (default: log)
+	AuditSinksEnvKey = "AUDIT_SINKS"
+
+	// AuditFilePathEnvKey is the environment variable key for the JSON lines file of the file audit sink
+	AuditFilePathEnvKey = "AUDIT_FILE_PATH"
+
+	// AuditWebhookURLEnvKey is the environment variable key for the URL the webhook audit sink posts to
+	AuditWebhookURLEnvKey = "AUDIT_WEBHOOK_URL"
+
+	// AuditWebhookTokenEnvKey is the environment variable key for the bearer token of the webhook audit sink
+	AuditWebhookTokenEnvKey = "AUDIT_WEBHOOK_TOKEN"
+)
+
 const (
 	// Email/SMTP configuration (generic for any SMTP provider: Mailpit, SendGrid, AWS SES, etc.)
 	// EmailSMTPHostEnvKey is the environment variable key for the SMTP server host
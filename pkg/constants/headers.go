@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package constants
+
+// Message header keys.
+const (
+	// AcceptLanguageHeader is the message header used to negotiate the response locale.
+	AcceptLanguageHeader = "Accept-Language"
+
+	// CallerTokenHeader is the message header carrying the service token of the calling service, signed
+	// by one of the CallerTokenIssuersEnvKey issuers. The caller it names is granted its capabilities and
+	// charged the usage of the service (and the identity provider quota).
+	CallerTokenHeader = "X-Caller-Token"
+
+	// ResponseFormatHeader is the message header selecting the output mode of the user metadata replies
+	ResponseFormatHeader = "X-Response-Format"
+
+	// ValidationDetailHeader is the message header asking for every rule failed by an invalid request,
+	// only honored for the trusted callers, see ValidationDetailVerbose
+	ValidationDetailHeader = "X-Validation-Detail"
+
+	// TenantHeader is the message header selecting the identity provider tenant serving the request
+	// when the deployment serves several, the requests carrying a user token are served by the tenant
+	// that issued it
+	TenantHeader = "X-Tenant"
+
+	// ResponseVersionHeader is the message header selecting the versioned response envelope,
+	// see ResponseEnvelopeVersion
+	ResponseVersionHeader = "X-Response-Version"
+
+	// EnvironmentHeader is the HTTP response header carrying the environment of the service,
+	// see ServiceEnvironmentEnvKey
+	EnvironmentHeader = "X-Service-Environment"
+
+	// InstanceHeader is the HTTP response header carrying the instance of the service that served
+	// the request, see ServiceInstanceEnvKey
+	InstanceHeader = "X-Service-Instance"
+)
+
+// ResponseEnvelopeVersion is the version of the response envelope, see ResponseVersionHeader.
+const ResponseEnvelopeVersion = 2
+
+// ValidationDetailVerbose asks for the list of the failed rules, see ValidationDetailHeader.
+const ValidationDetailVerbose = "verbose"
+
+// Response formats, see ResponseFormatHeader.
+const (
+	// ResponseFormatOIDC names the user attributes after the OIDC standard claims
+	ResponseFormatOIDC = "oidc"
+)
@@ -13,4 +13,46 @@ const (
 
 	// KVLookupPrefixAuthelia is the prefix for lookup keys in the KV store.
 	KVLookupPrefixAuthelia = "lookup/authelia-users/%s"
+
+	// KVBucketNameUsage is the name of the KV bucket for the daily usage aggregates per caller.
+	KVBucketNameUsage = "auth-service-usage"
+
+	// KVBucketNameLocks is the name of the KV bucket for the locks shared by the replicas.
+	KVBucketNameLocks = "auth-service-locks"
+
+	// KVBucketNameUserCache is the name of the KV bucket for the users cache shared by the replicas.
+	KVBucketNameUserCache = "auth-service-user-cache"
+
+	// KVBucketNameCallers is the name of the KV bucket for the caller allowlist, one key per calling service.
+	KVBucketNameCallers = "auth-service-callers"
+
+	// KVBucketNameBackupCodes is the name of the KV bucket for the hashed email backup codes, one key per email.
+	KVBucketNameBackupCodes = "auth-service-backup-codes"
+
+	// KVBucketNameEmailLinkingCodes is the name of the KV bucket for the pending OTPs of the alternate emails of the
+	// identity providers only verifying the primary email, one key per email.
+	KVBucketNameEmailLinkingCodes = "auth-service-email-linking-codes"
+
+	// KVBucketNameProvenance is the name of the KV bucket for the provenance of the metadata fields, one key per user.
+	KVBucketNameProvenance = "auth-service-provenance"
+
+	// KVBucketNameMigrations is the name of the KV bucket for the version of the storage migrations and their lock.
+	KVBucketNameMigrations = "auth-service-migrations"
+)
+
+// NATS JetStream stream names.
+const (
+	// StreamNameAutheliaUserEvents is the name of the stream journaling the authelia users changes.
+	StreamNameAutheliaUserEvents = "authelia-users-events"
+
+	// AutheliaUserEventsSubject is the subject prefix of the authelia users change events,
+	// the event type is appended to it, e.g. lfx.auth-service.authelia_users.events.user_set
+	AutheliaUserEventsSubject = "lfx.auth-service.authelia_users.events"
+
+	// StreamNameProfiles is the name of the stream keeping the latest public profile document of every user.
+	StreamNameProfiles = "auth-service-profiles"
+
+	// ProfilesSubject is the subject prefix of the public profile documents, the base64url encoded
+	// sub of the user is appended to it, e.g. lfx.auth-service.profiles.YXV0aDB8MTIz
+	ProfilesSubject = "lfx.auth-service.profiles"
 )
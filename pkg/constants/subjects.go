@@ -20,6 +20,46 @@ const (
 	// UserEmailToSubSubject is the subject for the user email to sub event.
 	// The subject is of the form: lfx.auth-service.email_to_sub
 	UserEmailToSubSubject = "lfx.auth-service.email_to_sub"
+
+	// UserAlternateEmailToSubSubject is the subject for the alternate (linked) email to sub event.
+	// The subject is of the form: lfx.auth-service.alternate_email_to_sub
+	UserAlternateEmailToSubSubject = "lfx.auth-service.alternate_email_to_sub"
+
+	// UserUsernameToEmailSubject is the subject for the username to primary email event.
+	// The subject is of the form: lfx.auth-service.username_to_email
+	UserUsernameToEmailSubject = "lfx.auth-service.username_to_email"
+
+	// UserSubToEmailSubject is the subject for the sub to primary email event.
+	// The subject is of the form: lfx.auth-service.sub_to_email
+	UserSubToEmailSubject = "lfx.auth-service.sub_to_email"
+
+	// UserSubToUsernameSubject is the subject for the sub to username event.
+	// The subject is of the form: lfx.auth-service.sub_to_username
+	UserSubToUsernameSubject = "lfx.auth-service.sub_to_username"
+
+	// UserEmailVerifiedSubject is the subject for the verification status of an email.
+	// The subject is of the form: lfx.auth-service.email_verified
+	UserEmailVerifiedSubject = "lfx.auth-service.email_verified"
+
+	// UserEmailHashMembershipSubject is the subject for the membership of the emails of the given index hashes.
+	// The subject is of the form: lfx.auth-service.email_hash.membership
+	UserEmailHashMembershipSubject = "lfx.auth-service.email_hash.membership"
+
+	// UserRosterResolveSubject is the subject for the resolution of the entries of a committee or project roster.
+	// The subject is of the form: lfx.auth-service.roster.resolve
+	UserRosterResolveSubject = "lfx.auth-service.roster.resolve"
+
+	// ProfileShareCreateSubject is the subject for the creation of a signed share link of a profile.
+	// The subject is of the form: lfx.auth-service.profile_share.create
+	ProfileShareCreateSubject = "lfx.auth-service.profile_share.create"
+
+	// ProfileShareResolveSubject is the subject for the resolution of a profile share link.
+	// The subject is of the form: lfx.auth-service.profile_share.resolve
+	ProfileShareResolveSubject = "lfx.auth-service.profile_share.resolve"
+
+	// UserTypeaheadSubject is the subject for the typeahead search of the users by username or name prefix.
+	// The subject is of the form: lfx.auth-service.user.typeahead
+	UserTypeaheadSubject = "lfx.auth-service.user.typeahead"
 )
 
 const (
@@ -34,9 +74,62 @@ const (
 	// The subject is of the form: lfx.auth-service.user_metadata.read
 	UserMetadataReadSubject = "lfx.auth-service.user_metadata.read"
 
+	// UserMetadataBulkReadSubject is the subject for the bulk user metadata read event.
+	// The subject is of the form: lfx.auth-service.user_metadata.bulk_read
+	UserMetadataBulkReadSubject = "lfx.auth-service.user_metadata.bulk_read"
+
+	// UserMetadataAdminUpdateSubject is the subject for the organization admin delegated user metadata update event.
+	// The subject is of the form: lfx.auth-service.user_metadata.admin_update
+	UserMetadataAdminUpdateSubject = "lfx.auth-service.user_metadata.admin_update"
+
+	// UserMetadataEnrichSubject is the subject for the user metadata update of the enrichment jobs.
+	// The subject is of the form: lfx.auth-service.user_metadata.enrich
+	UserMetadataEnrichSubject = "lfx.auth-service.user_metadata.enrich"
+
 	// UserEmailReadSubject is the subject for the user email read event.
 	// The subject is of the form: lfx.auth-service.user_emails.read
 	UserEmailReadSubject = "lfx.auth-service.user_emails.read"
+
+	// UserDeleteSubject is the subject for the user soft-delete event.
+	// The subject is of the form: lfx.auth-service.user.delete
+	UserDeleteSubject = "lfx.auth-service.user.delete"
+
+	// UserRestoreSubject is the subject for the user restore event.
+	// The subject is of the form: lfx.auth-service.user.restore
+	UserRestoreSubject = "lfx.auth-service.user.restore"
+
+	// UserMergeSubject is the subject for the account merge event.
+	// The subject is of the form: lfx.auth-service.user.merge
+	UserMergeSubject = "lfx.auth-service.user.merge"
+)
+
+const (
+
+	// Event subjects, published by the service and consumed by other services
+
+	// UserProfileNudgeSubject is the subject for the stale profile re-verification nudge event.
+	// The subject is of the form: lfx.auth-service.user_profile.nudge
+	UserProfileNudgeSubject = "lfx.auth-service.user_profile.nudge"
+
+	// UserMergedSubject is the subject for the event emitted once two accounts are merged.
+	// The subject is of the form: lfx.auth-service.user.merged
+	UserMergedSubject = "lfx.auth-service.user.merged"
+
+	// UserProfileChangedSubject is the subject for the event emitted after a change of a user profile.
+	// The subject is of the form: lfx.auth-service.user_profile.changed
+	UserProfileChangedSubject = "lfx.auth-service.user_profile.changed"
+
+	// AuditEventSubject is the subject for the event emitted after an audited operation.
+	// The subject is of the form: lfx.auth-service.audit
+	AuditEventSubject = "lfx.auth-service.audit"
+
+	// UserAuditSubject is the subject of the nats audit sink, recording the audit events of the user mutations.
+	// The subject is of the form: lfx.audit.user
+	UserAuditSubject = "lfx.audit.user"
+
+	// AutheliaSyncStatusSubject is the subject for the event emitted after a sync of the Authelia users.
+	// The subject is of the form: lfx.auth-service.authelia_sync.status
+	AutheliaSyncStatusSubject = "lfx.auth-service.authelia_sync.status"
 )
 
 const (
@@ -51,6 +144,22 @@ const (
 	// The subject is of the form: lfx.auth-service.email_linking.verify
 	EmailLinkingVerifySubject = "lfx.auth-service.email_linking.verify"
 
+	// EmailLinkingUnlinkSubject is the subject for the alternate email unlinking event.
+	// The subject is of the form: lfx.auth-service.email_linking.unlink
+	EmailLinkingUnlinkSubject = "lfx.auth-service.email_linking.unlink"
+
+	// PhoneLinkingSendVerificationSubject is the subject for the phone linking start event.
+	// The subject is of the form: lfx.auth-service.phone_linking.send_verification
+	PhoneLinkingSendVerificationSubject = "lfx.auth-service.phone_linking.send_verification"
+
+	// PhoneLinkingVerifySubject is the subject for the phone linking verify event.
+	// The subject is of the form: lfx.auth-service.phone_linking.verify
+	PhoneLinkingVerifySubject = "lfx.auth-service.phone_linking.verify"
+
+	// PrimaryEmailChangeSubject is the subject for the change of the primary email to a verified email.
+	// The subject is of the form: lfx.auth-service.primary_email.change
+	PrimaryEmailChangeSubject = "lfx.auth-service.primary_email.change"
+
 	// UserIdentityLinkSubject is the subject for the user identity linking event.
 	// The subject is of the form: lfx.auth-service.user_identity.link
 	UserIdentityLinkSubject = "lfx.auth-service.user_identity.link"
@@ -62,4 +171,45 @@ const (
 	// UserIdentityListSubject is the subject for listing user identities.
 	// The subject is of the form: lfx.auth-service.user_identity.list
 	UserIdentityListSubject = "lfx.auth-service.user_identity.list"
+
+	// UserAuthenticatorListSubject is the subject for listing the user WebAuthn authenticators.
+	// The subject is of the form: lfx.auth-service.user_authenticators.list
+	UserAuthenticatorListSubject = "lfx.auth-service.user_authenticators.list"
+
+	// UserAuthenticatorDeleteSubject is the subject for deleting a user WebAuthn authenticator.
+	// The subject is of the form: lfx.auth-service.user_authenticators.delete
+	UserAuthenticatorDeleteSubject = "lfx.auth-service.user_authenticators.delete"
+)
+
+const (
+
+	// Service status subjects
+
+	// ProviderStatusSubject is the subject for reading the recent health of the upstream identity providers.
+	// The subject is of the form: lfx.auth-service.provider_status.read
+	ProviderStatusSubject = "lfx.auth-service.provider_status.read"
+
+	// UsageReportSubject is the subject for the usage report per caller and operation.
+	// The subject is of the form: lfx.auth-service.usage.read
+	UsageReportSubject = "lfx.auth-service.usage.read"
+
+	// ContractReportSubject is the subject for the report of the service levels measured for the
+	// consumer contracts.
+	// The subject is of the form: lfx.auth-service.contracts.read
+	ContractReportSubject = "lfx.auth-service.contracts.read"
+)
+
+const (
+
+	// Test fixtures subjects
+
+	// MockUsersSeedSubject is the subject for resetting and seeding the users of the mock provider,
+	// so the e2e tests set up their scenarios at runtime.
+	// The subject is of the form: lfx.auth-service.mock_users.seed
+	MockUsersSeedSubject = "lfx.auth-service.mock_users.seed"
+
+	// MockFaultsSetSubject is the subject for replacing the faults injected in the operations of the
+	// mock provider, so the callers test their retries and fallbacks.
+	// The subject is of the form: lfx.auth-service.mock_faults.set
+	MockFaultsSetSubject = "lfx.auth-service.mock_faults.set"
 )
@@ -3,18 +3,53 @@
 
 package constants
 
+import "fmt"
+
+// CriteriaType is the attribute the users are searched by, the switches over it list every
+// criteria type so the exhaustive linter catches the ones left out
+type CriteriaType string
+
 const (
 	// CriteriaTypeEmail is the type of criteria for email
-	CriteriaTypeEmail = "email"
+	CriteriaTypeEmail CriteriaType = "email"
 	// CriteriaTypeUsername is the type of criteria for username
-	CriteriaTypeUsername = "username"
+	CriteriaTypeUsername CriteriaType = "username"
 	// CriteriaTypeAlternateEmail is the type of criteria for alternate email
-	CriteriaTypeAlternateEmail = "alternate_email"
+	CriteriaTypeAlternateEmail CriteriaType = "alternate_email"
 )
 
+// CriteriaTypes returns every criteria type
+func CriteriaTypes() []CriteriaType {
+	return []CriteriaType{CriteriaTypeEmail, CriteriaTypeUsername, CriteriaTypeAlternateEmail}
+}
+
+// Validate fails when the criteria type is unknown
+func (c CriteriaType) Validate() error {
+	switch c {
+	case CriteriaTypeEmail, CriteriaTypeUsername, CriteriaTypeAlternateEmail:
+		return nil
+	}
+	return fmt.Errorf("invalid criteria type %q", string(c))
+}
+
+// ParseCriteriaType returns the criteria type of the value, it fails when the value isn't a criteria type
+func ParseCriteriaType(value string) (CriteriaType, error) {
+	criteria := CriteriaType(value)
+	if err := criteria.Validate(); err != nil {
+		return "", err
+	}
+	return criteria, nil
+}
+
 const (
 	// UserUpdateMetadataRequiredScope is the Auth0 scope required to update the current user's metadata.
 	UserUpdateMetadataRequiredScope = "update:current_user_metadata"
 	// UserUpdateIdentityRequiredScope is the Auth0 scope required to link or unlink identities for the current user.
 	UserUpdateIdentityRequiredScope = "update:current_user_identities"
+	// UserReadMetadataRequiredScope is the scope required to read the metadata of any user over the REST mirrors.
+	UserReadMetadataRequiredScope = "read:users_metadata"
+	// AdminEventsRequiredScope is the scope required to stream the internal events to the admin dashboards.
+	AdminEventsRequiredScope = "read:auth_service_events"
+	// AdminConfigRequiredScope is the scope required to manage the configuration resources of the admin REST API.
+	AdminConfigRequiredScope = "manage:auth_service_config"
 )
@@ -7,3 +7,8 @@ package converters
 func StringPtr(s string) *string {
 	return &s
 }
+
+// BoolPtr converts a bool value to a bool pointer
+func BoolPtr(b bool) *bool {
+	return &b
+}
@@ -82,3 +82,15 @@ func TestStringPtrModification(t *testing.T) {
 		}
 	})
 }
+
+func TestBoolPtr(t *testing.T) {
+	for _, input := range []bool{true, false} {
+		result := BoolPtr(input)
+		if result == nil {
+			t.Fatal("BoolPtr() returned nil pointer")
+		}
+		if *result != input {
+			t.Errorf("BoolPtr() = %v, expected %v", *result, input)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package cursor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewCodec(t *testing.T) {
+	if _, err := NewCodec([]byte("short")); err == nil {
+		t.Error("NewCodec() accepted a short key")
+	}
+	if _, err := NewCodec(testKey); err != nil {
+		t.Errorf("NewCodec() unexpected error: %v", err)
+	}
+}
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	codec, err := NewCodec(testKey, WithTTL(time.Hour), WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewCodec() unexpected error: %v", err)
+	}
+	otherCodec, err := NewCodec([]byte("fedcba9876543210fedcba9876543210"), WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewCodec() unexpected error: %v", err)
+	}
+
+	token, err := codec.Encode(Cursor{Kind: "users", Offset: 50, PageToken: "abc"})
+	if err != nil {
+		t.Fatalf("Encode() unexpected error: %v", err)
+	}
+	otherToken, _ := otherCodec.Encode(Cursor{Kind: "users", Offset: 50})
+
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := payload[:len(payload)-2] + "x" + payload[len(payload)-1:] + "." + signature
+
+	tests := []struct {
+		name       string
+		kind       string
+		token      string
+		advance    time.Duration
+		wantOffset int
+		wantErr    string
+	}{
+		{name: "round trip", kind: "users", token: token, wantOffset: 50},
+		{name: "first page", kind: "users", token: " ", wantOffset: 0},
+		{name: "another list", kind: "identities", token: token, wantErr: "invalid cursor"},
+		{name: "tampered payload", kind: "users", token: tampered, wantErr: "invalid cursor"},
+		{name: "another key", kind: "users", token: otherToken, wantErr: "invalid cursor"},
+		{name: "garbage", kind: "users", token: "not-a-cursor", wantErr: "invalid cursor"},
+		{name: "expired", kind: "users", token: token, advance: 2 * time.Hour, wantErr: "cursor expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Add(tt.advance)
+
+			got, err := codec.Decode(tt.kind, tt.token)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Decode() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() unexpected error: %v", err)
+			}
+			if got.Offset != tt.wantOffset || got.Kind != tt.kind {
+				t.Errorf("Decode() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeRequiresKind(t *testing.T) {
+	codec, _ := NewCodec(testKey)
+	if _, err := codec.Encode(Cursor{Offset: 10}); err == nil {
+		t.Error("Encode() accepted a cursor without kind")
+	}
+}
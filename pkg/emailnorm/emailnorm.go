@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package emailnorm canonicalizes email addresses using the rules of the mailbox
+// providers, so the different spellings of the same mailbox (dots and plus addresses
+// in Gmail, for example) match the same account.
+//
+// The canonical form is only meant for matching and indexing, emails must always
+// be sent to the address provided by the user.
+package emailnorm
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// rule describes how a mailbox provider interprets the local part of the address
+type rule struct {
+	// canonicalDomain replaces the domain, for providers serving the same mailboxes under several domains
+	canonicalDomain string
+	// ignoreDots reports whether the dots in the local part are ignored by the provider
+	ignoreDots bool
+	// subaddressSeparator starts the tag appended to the local part, the tag is ignored by the provider
+	subaddressSeparator string
+}
+
+var rules = map[string]rule{
+	"gmail.com":      {ignoreDots: true, subaddressSeparator: "+"},
+	"googlemail.com": {canonicalDomain: "gmail.com", ignoreDots: true, subaddressSeparator: "+"},
+	"outlook.com":    {subaddressSeparator: "+"},
+	"hotmail.com":    {subaddressSeparator: "+"},
+	"live.com":       {subaddressSeparator: "+"},
+	"icloud.com":     {subaddressSeparator: "+"},
+	"me.com":         {subaddressSeparator: "+"},
+	"fastmail.com":   {subaddressSeparator: "+"},
+	"protonmail.com": {subaddressSeparator: "+"},
+	"proton.me":      {subaddressSeparator: "+"},
+	"yahoo.com":      {subaddressSeparator: "-"},
+}
+
+// rulesVersion must be bumped on any change of the rules, the canonical forms change with them
+const rulesVersion = "1"
+
+var disabled atomic.Bool
+
+// SetEnabled turns the provider specific rules on or off, when disabled Canonical
+// only trims and lowercases the address. The rules are enabled by default.
+func SetEnabled(enabled bool) {
+	disabled.Store(!enabled)
+}
+
+// Enabled reports whether the provider specific rules are applied
+func Enabled() bool {
+	return !disabled.Load()
+}
+
+// Version identifies the canonical forms returned by Canonical, it changes with the rules and
+// when they are turned off. The indexes keyed by the canonical forms are rebuilt when it changes.
+func Version() string {
+	if !Enabled() {
+		return "lowercase"
+	}
+	return "rules-v" + rulesVersion
+}
+
+// Canonical returns the canonical form of the email, used to match the different spellings
+// of the same mailbox. Addresses of unknown providers are only trimmed and lowercased.
+//
+// Examples:
+//   - Canonical("John.Doe+lfx@Gmail.com") → "johndoe@gmail.com"
+//   - Canonical("jdoe@googlemail.com") → "jdoe@gmail.com"
+//   - Canonical("john.doe+lfx@example.com") → "john.doe+lfx@example.com"
+func Canonical(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !Enabled() {
+		return email
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	r, ok := rules[domain]
+	if !ok {
+		return email
+	}
+
+	if r.subaddressSeparator != "" {
+		if index := strings.Index(local, r.subaddressSeparator); index > 0 {
+			local = local[:index]
+		}
+	}
+	if r.ignoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	if r.canonicalDomain != "" {
+		domain = r.canonicalDomain
+	}
+	if local == "" {
+		return email
+	}
+
+	return local + "@" + domain
+}
+
+// Equal reports whether both emails are the same mailbox
+func Equal(a, b string) bool {
+	return Canonical(a) == Canonical(b)
+}
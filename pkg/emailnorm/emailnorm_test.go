@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emailnorm
+
+import "testing"
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{name: "empty", email: "", want: ""},
+		{name: "trims and lowercases", email: "  John.Doe@Example.COM ", want: "john.doe@example.com"},
+		{name: "unknown provider keeps dots and tags", email: "john.doe+lfx@example.com", want: "john.doe+lfx@example.com"},
+		{name: "gmail dots", email: "j.o.h.n.doe@gmail.com", want: "johndoe@gmail.com"},
+		{name: "gmail plus address", email: "John.Doe+LFX@Gmail.com", want: "johndoe@gmail.com"},
+		{name: "googlemail alias", email: "john.doe@googlemail.com", want: "johndoe@gmail.com"},
+		{name: "outlook keeps dots", email: "john.doe+news@outlook.com", want: "john.doe@outlook.com"},
+		{name: "yahoo dash subaddress", email: "john-news@yahoo.com", want: "john@yahoo.com"},
+		{name: "leading separator is kept", email: "+john@gmail.com", want: "+john@gmail.com"},
+		{name: "only dots is kept", email: "...@gmail.com", want: "...@gmail.com"},
+		{name: "missing at", email: "john.doe", want: "john.doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Canonical(tt.email); got != tt.want {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(true) })
+
+	enabledVersion := Version()
+	SetEnabled(false)
+	if Version() == enabledVersion {
+		t.Error("Version() didn't change when the rules were disabled")
+	}
+	if got := Canonical(" John.Doe+LFX@Gmail.com"); got != "john.doe+lfx@gmail.com" {
+		t.Errorf("Canonical() with rules disabled = %q", got)
+	}
+	if Equal("john.doe@gmail.com", "johndoe@gmail.com") {
+		t.Error("Equal() with rules disabled matched different spellings")
+	}
+
+	SetEnabled(true)
+	if !Equal("john.doe@gmail.com", "JohnDoe+x@googlemail.com") {
+		t.Error("Equal() didn't match the same gmail mailbox")
+	}
+}
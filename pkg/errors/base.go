@@ -3,12 +3,18 @@
 
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // base is a struct that holds the common fields for error types
 type base struct {
 	message string
 	err     error
+
+	// retryAfter is the retry hint of the retryable errors, zero means the default one
+	retryAfter time.Duration
 }
 
 // error is a method that returns the error message for the base struct
@@ -3,7 +3,10 @@
 
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Validation represents a validation error in the application.
 type Validation struct {
@@ -104,3 +107,37 @@ func NewConflict(message string, err ...error) Conflict {
 		},
 	}
 }
+
+// TooManyRequests represents a rate limit error in the application.
+type TooManyRequests struct {
+	base
+}
+
+// Error returns the error message for TooManyRequests.
+func (t TooManyRequests) Error() string {
+	return t.error()
+}
+
+// RetryAfter returns how long the caller should wait before retrying.
+func (t TooManyRequests) RetryAfter() time.Duration {
+	if t.retryAfter > 0 {
+		return t.retryAfter
+	}
+	return DefaultTooManyRequestsRetryAfter
+}
+
+// WithRetryAfter returns a copy of the error hinting the caller to retry after the given delay.
+func (t TooManyRequests) WithRetryAfter(retryAfter time.Duration) TooManyRequests {
+	t.retryAfter = retryAfter
+	return t
+}
+
+// NewTooManyRequests creates a new TooManyRequests error with the provided message.
+func NewTooManyRequests(message string, err ...error) TooManyRequests {
+	return TooManyRequests{
+		base: base{
+			message: message,
+			err:     errors.Join(err...),
+		},
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"errors"
+)
+
+// Error codes of the responses, stable identifiers the callers can branch on instead of the messages
+const (
+	CodeValidation         = "validation"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeTooManyRequests    = "too_many_requests"
+	CodeServiceUnavailable = "service_unavailable"
+	CodeUnexpected         = "unexpected"
+)
+
+// Code returns the error code of err, CodeUnexpected for the errors of other types
+func Code(err error) string {
+	switch {
+	case errors.As(err, new(Validation)):
+		return CodeValidation
+	case errors.As(err, new(Unauthorized)):
+		return CodeUnauthorized
+	case errors.As(err, new(Forbidden)):
+		return CodeForbidden
+	case errors.As(err, new(NotFound)):
+		return CodeNotFound
+	case errors.As(err, new(Conflict)):
+		return CodeConflict
+	case errors.As(err, new(TooManyRequests)):
+		return CodeTooManyRequests
+	case errors.As(err, new(ServiceUnavailable)), errors.Is(err, context.DeadlineExceeded):
+		return CodeServiceUnavailable
+	default:
+		return CodeUnexpected
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "validation", err: NewValidation("invalid email"), want: CodeValidation},
+		{name: "unauthorized", err: NewUnauthorized("invalid token"), want: CodeUnauthorized},
+		{name: "forbidden", err: NewForbidden("not an admin"), want: CodeForbidden},
+		{name: "not found", err: NewNotFound("user not found"), want: CodeNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("lookup: %w", NewNotFound("user not found")), want: CodeNotFound},
+		{name: "conflict", err: NewConflict("email already linked"), want: CodeConflict},
+		{name: "rate limited", err: NewTooManyRequests("slow down"), want: CodeTooManyRequests},
+		{name: "unavailable", err: NewServiceUnavailable("down"), want: CodeServiceUnavailable},
+		{name: "upstream timeout", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: CodeServiceUnavailable},
+		{name: "unexpected", err: NewUnexpected("boom"), want: CodeUnexpected},
+		{name: "untyped", err: errors.New("boom"), want: CodeUnexpected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.want {
+				t.Errorf("Code() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultTooManyRequestsRetryAfter is the retry hint of rate limited requests without a known reset time
+	DefaultTooManyRequestsRetryAfter = 5 * time.Second
+
+	// DefaultServiceUnavailableRetryAfter is the retry hint of unavailable dependencies and upstream timeouts
+	DefaultServiceUnavailableRetryAfter = 2 * time.Second
+)
+
+// retryAfterer is implemented by the errors worth retrying
+type retryAfterer interface {
+	RetryAfter() time.Duration
+}
+
+// RetryAfter returns how long the caller should wait before retrying the request that failed with err,
+// the second value is false when retrying is pointless (e.g. validation or not found errors)
+func RetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var retryable retryAfterer
+	if errors.As(err, &retryable) {
+		return retryable.RetryAfter(), true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return DefaultServiceUnavailableRetryAfter, true
+	}
+	return 0, false
+}
@@ -0,0 +1,49 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+		want          time.Duration
+	}{
+		{name: "nil", err: nil},
+		{name: "validation", err: NewValidation("invalid email")},
+		{name: "not found", err: NewNotFound("user not found")},
+		{name: "unexpected", err: NewUnexpected("boom")},
+		{name: "rate limited", err: NewTooManyRequests("slow down"), wantRetryable: true, want: DefaultTooManyRequestsRetryAfter},
+		{
+			name:          "rate limited with reset time",
+			err:           NewTooManyRequests("slow down").WithRetryAfter(42 * time.Second),
+			wantRetryable: true,
+			want:          42 * time.Second,
+		},
+		{name: "unavailable", err: NewServiceUnavailable("down"), wantRetryable: true, want: DefaultServiceUnavailableRetryAfter},
+		{
+			name:          "wrapped unavailable",
+			err:           fmt.Errorf("lookup: %w", NewServiceUnavailable("down").WithRetryAfter(time.Second)),
+			wantRetryable: true,
+			want:          time.Second,
+		},
+		{name: "upstream timeout", err: fmt.Errorf("call: %w", context.DeadlineExceeded), wantRetryable: true, want: DefaultServiceUnavailableRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, retryable := RetryAfter(tt.err)
+			if retryable != tt.wantRetryable || got != tt.want {
+				t.Errorf("RetryAfter() = %v, %v, want %v, %v", got, retryable, tt.want, tt.wantRetryable)
+			}
+		})
+	}
+}
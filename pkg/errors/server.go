@@ -3,7 +3,10 @@
 
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Unexpected represents an unexpected error in the application.
 type Unexpected struct {
@@ -35,6 +38,20 @@ func (su ServiceUnavailable) Error() string {
 	return su.error()
 }
 
+// RetryAfter returns how long the caller should wait before retrying.
+func (su ServiceUnavailable) RetryAfter() time.Duration {
+	if su.retryAfter > 0 {
+		return su.retryAfter
+	}
+	return DefaultServiceUnavailableRetryAfter
+}
+
+// WithRetryAfter returns a copy of the error hinting the caller to retry after the given delay.
+func (su ServiceUnavailable) WithRetryAfter(retryAfter time.Duration) ServiceUnavailable {
+	su.retryAfter = retryAfter
+	return su
+}
+
 // NewServiceUnavailable creates a new ServiceUnavailable error with the provided message.
 func NewServiceUnavailable(message string, err ...error) ServiceUnavailable {
 	return ServiceUnavailable{
@@ -0,0 +1,278 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode is the mode of a cassette, recording the real responses or replaying them offline
+type CassetteMode string
+
+const (
+	// CassetteModeRecord forwards the requests to the upstream and records the interactions
+	CassetteModeRecord CassetteMode = "record"
+
+	// CassetteModeReplay replays the recorded interactions, the upstream is never reached
+	CassetteModeReplay CassetteMode = "replay"
+
+	// sanitizedValue replaces the credentials in the recorded interactions
+	sanitizedValue = "REDACTED"
+)
+
+// sanitizedFields are the credentials removed from the recorded requests and responses (JSON or
+// form encoded), the requests are matched on their sanitized form so replays don't need them
+var sanitizedFields = map[string]struct{}{
+	"access_token":     {},
+	"id_token":         {},
+	"refresh_token":    {},
+	"client_id":        {},
+	"client_secret":    {},
+	"client_assertion": {},
+	"password":         {},
+	"otp":              {},
+}
+
+// CassetteRequest is a recorded request, without the headers (tokens)
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response, only the content type header is kept
+type CassetteResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette is an http.RoundTripper recording the interactions with an upstream to a file, or
+// replaying them from the file without reaching the upstream (local development and CI)
+type Cassette struct {
+	mode      CassetteMode
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[int]struct{}
+}
+
+// Mode returns the mode of the cassette
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// RoundTrip records or replays the request depending on the mode of the cassette
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		read, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = read
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := CassetteRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   sanitizeBody(body, req.Header.Get("Content-Type")),
+	}
+
+	if c.mode == CassetteModeReplay {
+		return c.replay(req, request)
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, errRead := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if errRead != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", errRead)
+	}
+	// the caller gets the real response, only the recorded one is sanitized
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	errRecord := c.record(Interaction{
+		Request: request,
+		Response: CassetteResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        sanitizeBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	})
+	if errRecord != nil {
+		return nil, errRecord
+	}
+
+	return resp, nil
+}
+
+// replay returns the first recorded response of the request not replayed yet, the last one
+// once all of them were replayed (tokens and keys are fetched more than once)
+func (c *Cassette) replay(req *http.Request, request CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for i, interaction := range c.interactions {
+		if interaction.Request != request {
+			continue
+		}
+		match = i
+		if _, done := c.replayed[i]; !done {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s has no interaction recorded for %s %s", c.path, request.Method, request.URL)
+	}
+	c.replayed[match] = struct{}{}
+
+	recorded := c.interactions[match].Response
+	header := make(http.Header)
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// record appends the interaction and saves the cassette, so a recording session
+// can be stopped at any time
+func (c *Cassette) record(interaction Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, interaction)
+
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save cassette: %w", err)
+	}
+	return nil
+}
+
+// sanitizeBody replaces the credentials of a JSON or form encoded body
+func sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			for key := range values {
+				if _, sensitive := sanitizedFields[key]; sensitive {
+					values.Set(key, sanitizedValue)
+				}
+			}
+			// Encode sorts the keys, the body is stable across runs
+			return values.Encode()
+		}
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err == nil {
+		sanitized, errMarshal := json.Marshal(sanitizeValue(value))
+		if errMarshal == nil {
+			return string(sanitized)
+		}
+	}
+
+	return string(body)
+}
+
+func sanitizeValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if _, sensitive := sanitizedFields[key]; sensitive {
+				v[key] = sanitizedValue
+				continue
+			}
+			v[key] = sanitizeValue(nested)
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			v[i] = sanitizeValue(nested)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// NewCassette creates a cassette recording to path the interactions forwarded to the transport,
+// or replaying the interactions recorded in path
+func NewCassette(mode CassetteMode, path string, transport http.RoundTripper) (*Cassette, error) {
+	if path == "" {
+		return nil, fmt.Errorf("cassette path is required")
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	c := &Cassette{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+		replayed:  make(map[int]struct{}),
+	}
+
+	switch mode {
+	case CassetteModeRecord:
+		// a recording starts from scratch, the stale interactions would never be replayed
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	case CassetteModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &c.interactions); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q, expected %s or %s", mode, CassetteModeRecord, CassetteModeReplay)
+	}
+
+	return c, nil
+}
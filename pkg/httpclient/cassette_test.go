@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "auth0.json")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		switch r.URL.Path {
+		case "/oauth/token":
+			_, _ = w.Write([]byte(`{"access_token":"secret-token","expires_in":86400,"token_type":"Bearer"}`))
+		case "/api/v2/users/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"statusCode":404,"message":"The user does not exist."}`))
+		default:
+			_, _ = w.Write([]byte(`{"user_id":"auth0|123","name":"call-` + strconv.Itoa(calls) + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	form := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
+	requests := func(client *Client) []*Response {
+		t.Helper()
+		var responses []*Response
+		for _, req := range []Request{
+			{Method: http.MethodPost, URL: server.URL + "/oauth/token", Headers: form, Body: strings.NewReader("client_id=abc&client_assertion=jwt-1&grant_type=client_credentials")},
+			{Method: http.MethodGet, URL: server.URL + "/api/v2/users/auth0|123"},
+			{Method: http.MethodGet, URL: server.URL + "/api/v2/users/auth0|123"},
+			{Method: http.MethodGet, URL: server.URL + "/api/v2/users/auth0|123"},
+		} {
+			response, err := client.Do(ctx, req)
+			if err != nil {
+				t.Fatalf("Do(%s) unexpected error: %v", req.URL, err)
+			}
+			responses = append(responses, response)
+		}
+		if _, err := client.Do(ctx, Request{Method: http.MethodGet, URL: server.URL + "/api/v2/users/missing"}); err == nil {
+			t.Fatal("Do() for a missing user, want an error")
+		}
+		return responses
+	}
+
+	recorder, err := NewCassette(CassetteModeRecord, path, nil)
+	if err != nil {
+		t.Fatalf("NewCassette() unexpected error: %v", err)
+	}
+	recorded := requests(NewClient(Config{Transport: recorder}))
+	if !strings.Contains(string(recorded[0].Body), "secret-token") {
+		t.Errorf("recording body = %s, the caller must get the real response", recorded[0].Body)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the cassette: %v", err)
+	}
+	for _, secret := range []string{"secret-token", "jwt-1", "abc", "X-RateLimit"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q, must be sanitized", secret)
+		}
+	}
+
+	// the replay doesn't reach the upstream, the credentials differ
+	server.Close()
+	player, err := NewCassette(CassetteModeReplay, path, nil)
+	if err != nil {
+		t.Fatalf("NewCassette() unexpected error: %v", err)
+	}
+	client := NewClient(Config{Transport: player})
+
+	token, err := client.Do(ctx, Request{Method: http.MethodPost, URL: server.URL + "/oauth/token", Headers: form, Body: strings.NewReader("client_id=other&client_assertion=jwt-2&grant_type=client_credentials")})
+	if err != nil {
+		t.Fatalf("Do() replay unexpected error: %v", err)
+	}
+	if !strings.Contains(string(token.Body), `"access_token":"REDACTED"`) {
+		t.Errorf("replayed token = %s", token.Body)
+	}
+
+	// the repeated requests are replayed in order, then the last one again
+	for _, want := range []string{"call-2", "call-3", "call-4", "call-4"} {
+		response, err := client.Do(ctx, Request{Method: http.MethodGet, URL: server.URL + "/api/v2/users/auth0|123"})
+		if err != nil {
+			t.Fatalf("Do() replay unexpected error: %v", err)
+		}
+		if !strings.Contains(string(response.Body), want) {
+			t.Errorf("replayed body = %s, want %s", response.Body, want)
+		}
+	}
+
+	_, err = client.Do(ctx, Request{Method: http.MethodGet, URL: server.URL + "/api/v2/users/missing"})
+	if retryable, ok := err.(*RetryableError); !ok || retryable.StatusCode != http.StatusNotFound {
+		t.Errorf("Do() replay of a missing user error = %v, want the recorded 404", err)
+	}
+
+	if _, err := client.Do(ctx, Request{Method: http.MethodGet, URL: server.URL + "/api/v2/users/never-recorded"}); err == nil {
+		t.Error("Do() of a request never recorded, want an error")
+	}
+}
+
+func TestNewCassette(t *testing.T) {
+	if _, err := NewCassette("rewind", filepath.Join(t.TempDir(), "cassette.json"), nil); err == nil {
+		t.Error("NewCassette() with an invalid mode, want an error")
+	}
+	if _, err := NewCassette(CassetteModeReplay, filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Error("NewCassette() replaying a missing cassette, want an error")
+	}
+	if _, err := NewCassette(CassetteModeRecord, "", nil); err == nil {
+		t.Error("NewCassette() without path, want an error")
+	}
+}
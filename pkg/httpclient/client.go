@@ -4,6 +4,7 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,12 +14,22 @@ import (
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 )
 
 // Client represents a generic HTTP client with retry logic
 type Client struct {
 	config     Config
 	httpClient *http.Client
+
+	// limiters pace the requests of the endpoints with a configured rate limit, the other
+	// endpoints share the default limiter
+	limiters       []*endpointLimiter
+	defaultLimiter *endpointLimiter
+
+	clock clock.Clock
+	sleep func(context.Context, time.Duration) error
 }
 
 // Request represents an HTTP request configuration
@@ -40,32 +51,84 @@ type Response struct {
 type RetryableError struct {
 	StatusCode int
 	Message    string
+	// RetryAfter is the wait asked by the upstream before retrying, when rate limited or unavailable
+	RetryAfter time.Duration
+	// Attempts is the number of attempts made before giving up, the message is the upstream body
+	// of the last one and is left untouched, the callers parse it
+	Attempts int
 }
 
 func (e *RetryableError) Error() string {
 	return e.Message
 }
 
-// Do executes an HTTP request with retry logic
+// Do executes an HTTP request with retry logic. The requests are paced by the rate limit of
+// their endpoint, and a rate limited request is retried after the wait asked by the upstream
+// (Retry-After or X-RateLimit-Reset headers) when it's not longer than MaxRetryWait. A request
+// isn't retried when the retry can't end before the deadline of the context, the error of the
+// last attempt is returned with the number of attempts made.
 func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
-	var lastErr error
+	// the body is sent again by the retries
+	var body []byte
+	if req.Body != nil {
+		buffered, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = buffered
+	}
+
+	limiter := c.limiterFor(req.URL)
+
+	// the call is timed for the slow requests log when the context collects the timings
+	call := UpstreamCall{Method: req.Method, Endpoint: endpointOf(req.URL)}
+	if timings := timingsFrom(ctx); timings != nil {
+		started := time.Now()
+		defer func() {
+			call.Duration = time.Since(started)
+			timings.add(call)
+		}()
+	}
 
+	var (
+		lastErr error
+		delay   time.Duration
+	)
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		waitStart := time.Now()
 		if attempt > 0 {
-			// Calculate delay with optional exponential backoff
-			delay := c.config.RetryDelay
-			if c.config.RetryBackoff {
-				delay = time.Duration(int64(delay) * int64(1<<(attempt-1)))
+			if err := c.sleep(ctx, delay); err != nil {
+				return nil, err
 			}
+		}
 
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
+		errWait := limiter.wait(ctx, c.clock.Now(), c.sleep)
+		call.Waited += time.Since(waitStart)
+		if errWait != nil {
+			return nil, errWait
+		}
+		if body != nil {
+			req.Body = bytes.NewReader(body)
 		}
 
+		start := time.Now()
 		response, err := c.doRequest(ctx, req)
+		attemptDuration := time.Since(start)
+		if c.config.Recorder != nil {
+			c.config.Recorder.Record(attemptDuration, c.shouldRetry(err))
+		}
+		call.Attempts++
+		call.Status = 0
+		if response != nil {
+			call.Status = response.StatusCode
+			// the other requests to the endpoint wait for the quota to be restored
+			if reset, ok := rateLimitReset(response.Headers); ok {
+				if latest := c.clock.Now().Add(c.maxRetryWait()); reset.After(latest) {
+					reset = latest
+				}
+				limiter.pause(reset)
+			}
+		}
 		if err == nil {
 			return response, nil
 		}
@@ -76,13 +139,68 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 		if !c.shouldRetry(err) {
 			break
 		}
+
+		// Calculate delay with optional exponential backoff, unless the upstream tells how long to wait
+		delay = c.config.RetryDelay
+		if c.config.RetryBackoff {
+			delay = time.Duration(int64(delay) * int64(1<<attempt))
+		}
+		if retryableErr, ok := err.(*RetryableError); ok && retryableErr.RetryAfter > 0 {
+			if retryableErr.RetryAfter > c.maxRetryWait() {
+				break
+			}
+			delay = retryableErr.RetryAfter
+		}
+		if attempt < c.config.MaxRetries {
+			// the retry would answer after the caller stopped waiting, spare the quota
+			if !fitsDeadline(ctx, c.clock.Now(), delay+attemptDuration) {
+				slog.WarnContext(ctx, "request failed, no time left to retry before the deadline",
+					"error", err,
+					"attempt", attempt+1,
+					"delay", delay,
+				)
+				break
+			}
+			slog.WarnContext(ctx, "request failed, retrying",
+				"error", err,
+				"attempt", attempt+1,
+				"delay", delay,
+			)
+		}
 	}
 
-	slog.ErrorContext(ctx, "request failed", "error", lastErr)
+	withAttempts(lastErr, call.Attempts)
+	slog.ErrorContext(ctx, "request failed", "error", lastErr, "attempts", call.Attempts)
 
 	return nil, lastErr
 }
 
+// fitsDeadline reports whether the next attempt, expected to take the given time, ends before the
+// deadline of the context, if any
+func fitsDeadline(ctx context.Context, now time.Time, expected time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+	return now.Add(expected).Before(deadline)
+}
+
+// withAttempts records the number of attempts made in the last error of the request, when it's an
+// upstream error, its message is kept
+func withAttempts(err error, attempts int) {
+	if retryableErr, ok := err.(*RetryableError); ok {
+		retryableErr.Attempts = attempts
+	}
+}
+
+// maxRetryWait is the longest wait honored from the rate limit headers
+func (c *Client) maxRetryWait() time.Duration {
+	if c.config.MaxRetryWait > 0 {
+		return c.config.MaxRetryWait
+	}
+	return defaultMaxRetryWait
+}
+
 // doRequest performs a single HTTP request
 func (c *Client) doRequest(ctx context.Context, reqConfig Request) (*Response, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, reqConfig.Method, reqConfig.URL, reqConfig.Body)
@@ -121,6 +239,9 @@ func (c *Client) doRequest(ctx context.Context, reqConfig Request) (*Response, e
 			StatusCode: resp.StatusCode,
 			Message:    string(body),
 		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			err.RetryAfter, _ = retryAfter(resp.Header, c.clock.Now())
+		}
 		return response, err
 	}
 
@@ -160,11 +281,19 @@ func (c *Client) Request(ctx context.Context, verb, url string, body io.Reader,
 // NewClient creates a new HTTP client with the given configuration.
 // The client is instrumented with OpenTelemetry for distributed tracing.
 func NewClient(config Config) *Client {
+	transport := config.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
 	return &Client{
 		config: config,
 		httpClient: &http.Client{
 			Timeout:   config.Timeout,
-			Transport: otelhttp.NewTransport(http.DefaultTransport),
+			Transport: otelhttp.NewTransport(transport),
 		},
+		limiters:       newEndpointLimiters(config.RateLimits),
+		defaultLimiter: &endpointLimiter{},
+		clock:          clock.System,
+		sleep:          sleepContext,
 	}
 }
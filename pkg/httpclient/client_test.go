@@ -230,3 +230,46 @@ func TestDefaultConfig(t *testing.T) {
 		t.Error("Expected default retry backoff to be true")
 	}
 }
+
+type recordedAttempt struct {
+	latency time.Duration
+	failed  bool
+}
+
+type fakeRecorder struct {
+	attempts []recordedAttempt
+}
+
+func (f *fakeRecorder) Record(latency time.Duration, failed bool) {
+	f.attempts = append(f.attempts, recordedAttempt{latency: latency, failed: failed})
+}
+
+func TestClient_Recorder(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusNotFound}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[calls])
+		calls++
+	}))
+	defer server.Close()
+
+	recorder := &fakeRecorder{}
+	client := NewClient(Config{
+		Timeout:    5 * time.Second,
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+		Recorder:   recorder,
+	})
+
+	if _, err := client.Request(context.Background(), http.MethodGet, server.URL, nil, nil); err == nil {
+		t.Fatal("Expected error for the not found response")
+	}
+
+	// the server error is an upstream failure, the not found is a client error
+	if len(recorder.attempts) != 2 {
+		t.Fatalf("Expected 2 recorded attempts, got %d", len(recorder.attempts))
+	}
+	if !recorder.attempts[0].failed || recorder.attempts[1].failed {
+		t.Errorf("Expected only the first attempt to be a failure, got %+v", recorder.attempts)
+	}
+}
@@ -4,6 +4,7 @@
 package httpclient
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -20,6 +21,25 @@ type Config struct {
 
 	// RetryBackoff enables exponential backoff for retries
 	RetryBackoff bool
+
+	// MaxRetryWait is the longest wait honored from the Retry-After and X-RateLimit-Reset headers
+	// of a rate limited request, the request fails instead when the upstream asks for longer (10s when zero)
+	MaxRetryWait time.Duration
+
+	// RateLimits pace the requests per endpoint on the client side, the longest matching path prefix applies
+	RateLimits []EndpointRateLimit
+
+	// Recorder receives the outcome of every request attempt, optional
+	Recorder Recorder
+
+	// Transport sends the requests (a Cassette in development), http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// Recorder tracks the latency and failures of the upstream the client talks to,
+// failed is only set for upstream failures (server errors, rate limiting, network)
+type Recorder interface {
+	Record(latency time.Duration, failed bool)
 }
 
 // DefaultConfig returns a Config with sensible defaults
@@ -29,5 +49,6 @@ func DefaultConfig() Config {
 		MaxRetries:   2,
 		RetryDelay:   1 * time.Second,
 		RetryBackoff: true,
+		MaxRetryWait: defaultMaxRetryWait,
 	}
 }
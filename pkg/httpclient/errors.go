@@ -4,6 +4,7 @@
 package httpclient
 
 import (
+	stderrors "errors"
 	"net/http"
 
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
@@ -20,8 +21,24 @@ func ErrorFromStatusCode(statusCode int, message string) error {
 		return errors.NewForbidden(message)
 	case http.StatusNotFound:
 		return errors.NewNotFound(message)
+	case http.StatusTooManyRequests:
+		return errors.NewTooManyRequests(message)
 	case http.StatusInternalServerError:
 		return errors.NewUnexpected(message)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return errors.NewServiceUnavailable(message)
 	}
 	return errors.NewUnexpected(message)
 }
+
+// ErrorFromCall returns the error of a failed API call based on the http status code, the rate
+// limited calls carry the wait asked by the upstream so the callers know when to retry
+func ErrorFromCall(statusCode int, message string, errCall error) error {
+	err := ErrorFromStatusCode(statusCode, message)
+
+	var retryable *RetryableError
+	if tooManyRequests, ok := err.(errors.TooManyRequests); ok && stderrors.As(errCall, &retryable) && retryable.RetryAfter > 0 {
+		return tooManyRequests.WithRetryAfter(retryable.RetryAfter)
+	}
+	return err
+}
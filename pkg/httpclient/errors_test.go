@@ -61,12 +61,26 @@ func TestErrorFromStatusCode(t *testing.T) {
 			expectedError: "unknown error",
 		},
 		{
-			name:          "ServiceUnavailable returns Unexpected error",
+			name:          "ServiceUnavailable returns ServiceUnavailable error",
 			statusCode:    http.StatusServiceUnavailable,
 			message:       "service unavailable",
-			expectedType:  "*errors.Unexpected",
+			expectedType:  "*errors.ServiceUnavailable",
 			expectedError: "service unavailable",
 		},
+		{
+			name:          "GatewayTimeout returns ServiceUnavailable error",
+			statusCode:    http.StatusGatewayTimeout,
+			message:       "upstream timeout",
+			expectedType:  "*errors.ServiceUnavailable",
+			expectedError: "upstream timeout",
+		},
+		{
+			name:          "TooManyRequests returns TooManyRequests error",
+			statusCode:    http.StatusTooManyRequests,
+			message:       "rate limited",
+			expectedType:  "*errors.TooManyRequests",
+			expectedError: "rate limited",
+		},
 		{
 			name:          "Empty message",
 			statusCode:    http.StatusBadRequest,
@@ -108,6 +122,14 @@ func TestErrorFromStatusCode(t *testing.T) {
 				if _, ok := err.(errors.NotFound); !ok {
 					t.Errorf("expected error type %s, got %T", tt.expectedType, err)
 				}
+			case "*errors.ServiceUnavailable":
+				if _, ok := err.(errors.ServiceUnavailable); !ok {
+					t.Errorf("expected error type %s, got %T", tt.expectedType, err)
+				}
+			case "*errors.TooManyRequests":
+				if _, ok := err.(errors.TooManyRequests); !ok {
+					t.Errorf("expected error type %s, got %T", tt.expectedType, err)
+				}
 			case "*errors.Unexpected":
 				if _, ok := err.(errors.Unexpected); !ok {
 					t.Errorf("expected error type %s, got %T", tt.expectedType, err)
@@ -0,0 +1,175 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxRetryWait is the longest wait honored from the rate limit headers when the
+// configuration doesn't set one
+const defaultMaxRetryWait = 10 * time.Second
+
+// EndpointRateLimit limits the requests sent to the endpoints whose path starts with PathPrefix
+// with a token bucket, refilled at Rate requests per second up to Burst requests
+type EndpointRateLimit struct {
+	PathPrefix string
+	Rate       float64
+	Burst      int
+}
+
+// ParseRateLimits parses the comma separated endpoint rate limits, in the form
+// path-prefix=rate[/burst], e.g. /api/v2/users=10/20,/api/v2/jobs=2. The burst defaults
+// to the rate, rounded up.
+func ParseRateLimits(value string) ([]EndpointRateLimit, error) {
+	var limits []EndpointRateLimit
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, spec, ok := strings.Cut(entry, "=")
+		prefix = strings.TrimSpace(prefix)
+		if !ok || !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("invalid rate limit %q, expected path-prefix=rate[/burst]", entry)
+		}
+
+		rateValue, burstValue, hasBurst := strings.Cut(strings.TrimSpace(spec), "/")
+		limit, err := strconv.ParseFloat(rateValue, 64)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, the rate must be a positive number of requests per second", entry)
+		}
+		burst := int(math.Ceil(limit))
+		if hasBurst {
+			burst, err = strconv.Atoi(burstValue)
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid rate limit %q, the burst must be a positive number of requests", entry)
+			}
+		}
+
+		limits = append(limits, EndpointRateLimit{PathPrefix: prefix, Rate: limit, Burst: burst})
+	}
+	return limits, nil
+}
+
+// endpointLimiter paces the requests of an endpoint: the token bucket of the configured limit,
+// if any, and the pause requested by the upstream once the quota of the window is exhausted
+type endpointLimiter struct {
+	prefix  string
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// wait blocks until the endpoint can be called, or the context is done
+func (l *endpointLimiter) wait(ctx context.Context, now time.Time, sleep func(context.Context, time.Duration) error) error {
+	l.mu.Lock()
+	pause := l.pausedUntil.Sub(now)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		if err := sleep(ctx, pause); err != nil {
+			return err
+		}
+	}
+	if l.limiter != nil {
+		return l.limiter.Wait(ctx)
+	}
+	return nil
+}
+
+// pause holds the requests of the endpoint until the given time
+func (l *endpointLimiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// newEndpointLimiters creates the limiters of the configured endpoints
+func newEndpointLimiters(limits []EndpointRateLimit) []*endpointLimiter {
+	limiters := make([]*endpointLimiter, 0, len(limits))
+	for _, limit := range limits {
+		limiters = append(limiters, &endpointLimiter{
+			prefix:  limit.PathPrefix,
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), max(limit.Burst, 1)),
+		})
+	}
+	return limiters
+}
+
+// limiterFor returns the limiter of the longest path prefix matching the URL, the
+// unconfigured endpoints share the limiter without token bucket
+func (c *Client) limiterFor(rawURL string) *endpointLimiter {
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+	}
+
+	match := c.defaultLimiter
+	for _, limiter := range c.limiters {
+		if strings.HasPrefix(path, limiter.prefix) && len(limiter.prefix) > len(match.prefix) {
+			match = limiter
+		}
+	}
+	return match
+}
+
+// rateLimitReset returns when the quota of the current window is restored, when the upstream
+// reports it's exhausted with the X-RateLimit-Remaining and X-RateLimit-Reset (epoch seconds) headers
+func rateLimitReset(header http.Header) (time.Time, bool) {
+	if strings.TrimSpace(header.Get("X-RateLimit-Remaining")) != "0" {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(strings.TrimSpace(header.Get("X-RateLimit-Reset")), 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
+
+// retryAfter returns how long the upstream asks to wait before retrying, from the Retry-After
+// header (seconds or HTTP date) or else the reset of the exhausted rate limit window
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+	if reset, ok := rateLimitReset(header); ok {
+		return max(reset.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the duration, or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []EndpointRateLimit
+		wantErr bool
+	}{
+		{name: "empty", value: ""},
+		{
+			name:  "rate and burst",
+			value: "/api/v2/users=10/20, /api/v2/jobs=0.5",
+			want: []EndpointRateLimit{
+				{PathPrefix: "/api/v2/users", Rate: 10, Burst: 20},
+				{PathPrefix: "/api/v2/jobs", Rate: 0.5, Burst: 1},
+			},
+		},
+		{name: "missing rate", value: "/api/v2/users", wantErr: true},
+		{name: "relative prefix", value: "api/v2/users=10", wantErr: true},
+		{name: "zero rate", value: "/api/v2/users=0", wantErr: true},
+		{name: "invalid burst", value: "/api/v2/users=10/many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRateLimits(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", header: http.Header{"Retry-After": []string{"3"}}, want: 3 * time.Second, wantOK: true},
+		{name: "http date", header: http.Header{"Retry-After": []string{now.Add(5 * time.Second).Format(http.TimeFormat)}}, want: 5 * time.Second, wantOK: true},
+		{
+			name: "exhausted window",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(7*time.Second).Unix(), 10)},
+			},
+			want:   7 * time.Second,
+			wantOK: true,
+		},
+		{
+			name: "window with quota left",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"4"},
+				"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(7*time.Second).Unix(), 10)},
+			},
+		},
+		{name: "no hint", header: http.Header{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.header, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// rateLimitedUpstream answers 429 to the first requests, then 200
+type rateLimitedUpstream struct {
+	mu       sync.Mutex
+	limited  int
+	header   http.Header
+	requests int
+	bodies   []string
+}
+
+func (u *rateLimitedUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	u.bodies = append(u.bodies, string(body))
+	u.requests++
+
+	if u.requests <= u.limited {
+		for key, values := range u.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"Too Many Requests"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+// newTestClient returns a client recording its waits instead of sleeping
+func newTestClient(config Config, now time.Time) (*Client, *[]time.Duration) {
+	client := NewClient(config)
+	fakeClock := clock.NewFake(now)
+	client.clock = fakeClock
+
+	var waits []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		fakeClock.Advance(d)
+		return nil
+	}
+	return client, &waits
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	now := time.Now()
+
+	t.Run("retries after the wait asked by the upstream", func(t *testing.T) {
+		upstream := &rateLimitedUpstream{limited: 1, header: http.Header{"Retry-After": []string{"2"}}}
+		server := httptest.NewServer(upstream)
+		defer server.Close()
+
+		client, waits := newTestClient(Config{MaxRetries: 2, RetryDelay: time.Second, RetryBackoff: true}, now)
+
+		response, err := client.Request(context.Background(), http.MethodPost, server.URL+"/api/v2/users", strings.NewReader(`{"name":"jdoe"}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+		assert.Equal(t, []string{`{"name":"jdoe"}`, `{"name":"jdoe"}`}, upstream.bodies, "the body is sent again")
+	})
+
+	t.Run("fails with the hint when the wait is too long", func(t *testing.T) {
+		upstream := &rateLimitedUpstream{limited: 3, header: http.Header{"Retry-After": []string{"60"}}}
+		server := httptest.NewServer(upstream)
+		defer server.Close()
+
+		client, waits := newTestClient(Config{MaxRetries: 2, RetryDelay: time.Second, MaxRetryWait: 10 * time.Second}, now)
+
+		_, err := client.Request(context.Background(), http.MethodGet, server.URL+"/api/v2/users/auth0|123", nil, nil)
+		require.Error(t, err)
+		assert.Empty(t, *waits)
+		assert.Equal(t, 1, upstream.requests)
+
+		converted := ErrorFromCall(http.StatusTooManyRequests, "failed to get user", err)
+		assert.IsType(t, errors.TooManyRequests{}, converted)
+		retryAfter, retryable := errors.RetryAfter(converted)
+		assert.True(t, retryable)
+		assert.Equal(t, 60*time.Second, retryAfter)
+	})
+
+	t.Run("pauses the endpoint once the window is exhausted", func(t *testing.T) {
+		reset := now.Add(5 * time.Second).Truncate(time.Second)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v2/users" {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client, waits := newTestClient(Config{RateLimits: []EndpointRateLimit{{PathPrefix: "/api/v2/users", Rate: 100, Burst: 100}}}, now)
+		ctx := context.Background()
+
+		_, err := client.Request(ctx, http.MethodGet, server.URL+"/api/v2/users", nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, *waits)
+
+		// another endpoint isn't paused
+		_, err = client.Request(ctx, http.MethodGet, server.URL+"/api/v2/jobs", nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, *waits)
+
+		_, err = client.Request(ctx, http.MethodGet, server.URL+"/api/v2/users/auth0|123", nil, nil)
+		require.NoError(t, err)
+		require.Len(t, *waits, 1)
+		assert.Equal(t, reset.Sub(now), (*waits)[0])
+	})
+}
+
+func TestClient_LimiterFor(t *testing.T) {
+	client := NewClient(Config{RateLimits: []EndpointRateLimit{
+		{PathPrefix: "/api/v2/users", Rate: 10, Burst: 10},
+		{PathPrefix: "/api/v2/users-by-email", Rate: 5, Burst: 5},
+	}})
+
+	assert.Equal(t, "/api/v2/users", client.limiterFor("https://lfx.auth0.com/api/v2/users/auth0|123").prefix)
+	assert.Equal(t, "/api/v2/users-by-email", client.limiterFor("https://lfx.auth0.com/api/v2/users-by-email?email=a@b.c").prefix)
+	assert.Same(t, client.defaultLimiter, client.limiterFor("https://lfx.auth0.com/api/v2/jobs"))
+}
+
+func TestClient_RetryDeadline(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		deadline     time.Duration
+		wantRequests int
+		wantWaits    []time.Duration
+	}{
+		{
+			name:         "retries up to the max without a deadline",
+			wantRequests: 3,
+			wantWaits:    []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:         "stops retrying when the retry can't end before the deadline",
+			deadline:     2500 * time.Millisecond,
+			wantRequests: 2,
+			wantWaits:    []time.Duration{time.Second},
+		},
+		{
+			name:         "doesn't retry when the deadline is too close",
+			deadline:     500 * time.Millisecond,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client, waits := newTestClient(Config{MaxRetries: 2, RetryDelay: time.Second, RetryBackoff: true}, now)
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, now.Add(tt.deadline))
+				defer cancel()
+			}
+
+			_, err := client.Request(ctx, http.MethodGet, server.URL+"/api/v2/users", nil, nil)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantRequests, requests)
+			assert.Equal(t, tt.wantWaits, *waits)
+
+			retryableErr, ok := err.(*RetryableError)
+			require.True(t, ok, "the error type is kept")
+			assert.Equal(t, http.StatusServiceUnavailable, retryableErr.StatusCode)
+			assert.Equal(t, tt.wantRequests, retryableErr.Attempts)
+			assert.NotContains(t, err.Error(), "attempts", "the upstream message is kept")
+		})
+	}
+}
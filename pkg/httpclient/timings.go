@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UpstreamCall is the timing of a request to the upstream, retries included
+type UpstreamCall struct {
+	Method string
+	// Endpoint is the path of the request, without the query and with the user identifiers masked
+	Endpoint string
+	// Status is the status code of the last attempt, zero when no response was received
+	Status   int
+	Attempts int
+	// Duration is the time spent in the request, Waited the part spent waiting for the rate
+	// limits and between the retries
+	Duration time.Duration
+	Waited   time.Duration
+}
+
+// Timings collects the upstream calls made on behalf of a request
+type Timings struct {
+	mu    sync.Mutex
+	calls []UpstreamCall
+}
+
+// Calls returns the upstream calls collected so far
+func (t *Timings) Calls() []UpstreamCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]UpstreamCall(nil), t.calls...)
+}
+
+func (t *Timings) add(call UpstreamCall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls = append(t.calls, call)
+}
+
+type timingsKey struct{}
+
+// ContextWithTimings returns a context collecting the upstream calls made with it
+func ContextWithTimings(ctx context.Context) (context.Context, *Timings) {
+	timings := &Timings{}
+	return context.WithValue(ctx, timingsKey{}, timings), timings
+}
+
+// timingsFrom returns the timings collected for the context, nil when not collected
+func timingsFrom(ctx context.Context) *Timings {
+	timings, _ := ctx.Value(timingsKey{}).(*Timings)
+	return timings
+}
+
+// endpointOf returns the path of the URL, the segments carrying a user identifier
+// (e.g. auth0|123 or an email) masked
+func endpointOf(rawURL string) string {
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.EscapedPath()
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		lower := strings.ToLower(segment)
+		if strings.ContainsAny(lower, "|@") || strings.Contains(lower, "%7c") || strings.Contains(lower, "%40") {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
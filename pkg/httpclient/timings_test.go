@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointOf(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "plain path", url: "https://lfx.auth0.com/api/v2/jobs", want: "/api/v2/jobs"},
+		{name: "user id", url: "https://lfx.auth0.com/api/v2/users/auth0|123", want: "/api/v2/users/*"},
+		{name: "escaped user id", url: "https://lfx.auth0.com/api/v2/users/auth0%7C123/identities", want: "/api/v2/users/*/identities"},
+		{name: "query dropped", url: "https://lfx.auth0.com/api/v2/users-by-email?email=jdoe@example.com", want: "/api/v2/users-by-email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointOf(tt.url))
+		})
+	}
+}
+
+func TestClient_Timings(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(Config{MaxRetries: 1, RetryDelay: time.Second}, time.Now())
+
+	_, err := client.Request(context.Background(), http.MethodGet, server.URL+"/api/v2/jobs", nil, nil)
+	require.NoError(t, err, "the calls are only timed when the context collects them")
+
+	ctx, timings := ContextWithTimings(context.Background())
+	requests.Store(0)
+	_, err = client.Request(ctx, http.MethodGet, server.URL+"/api/v2/users/auth0|123", nil, nil)
+	require.NoError(t, err)
+
+	calls := timings.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, http.MethodGet, calls[0].Method)
+	assert.Equal(t, "/api/v2/users/*", calls[0].Endpoint)
+	assert.Equal(t, http.StatusOK, calls[0].Status)
+	assert.Equal(t, 2, calls[0].Attempts)
+	assert.GreaterOrEqual(t, calls[0].Duration, calls[0].Waited)
+}
@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package i18n provides the message catalog used to localize response messages.
+//
+// The catalog is keyed by the English message (gettext style), so any message
+// without a translation is returned unchanged.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale used when no preference matches the catalog
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	// catalog maps a locale to its English → translated messages
+	catalog = map[string]map[string]string{}
+
+	supported []language.Tag
+	matcher   language.Matcher
+)
+
+func init() {
+	supported = []language.Tag{language.English}
+
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read locales: %v", err))
+	}
+
+	for _, file := range files {
+		locale := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		content, errRead := localesFS.ReadFile(path.Join("locales", file.Name()))
+		if errRead != nil {
+			panic(fmt.Sprintf("failed to read locale %s: %v", locale, errRead))
+		}
+
+		messages := map[string]string{}
+		if errUnmarshal := json.Unmarshal(content, &messages); errUnmarshal != nil {
+			panic(fmt.Sprintf("failed to parse locale %s: %v", locale, errUnmarshal))
+		}
+
+		catalog[locale] = messages
+		supported = append(supported, language.Make(locale))
+	}
+
+	matcher = language.NewMatcher(supported)
+}
+
+// Negotiate returns the best supported locale for the given preferences.
+//
+// Each preference can be a single locale (e.g. "pt-BR") or an Accept-Language
+// header value (e.g. "pt-BR,pt;q=0.9,en;q=0.8"). Empty preferences are ignored.
+func Negotiate(preferences ...string) string {
+	var tags []language.Tag
+	for _, preference := range preferences {
+		if strings.TrimSpace(preference) == "" {
+			continue
+		}
+		parsed, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, parsed...)
+	}
+	if len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+
+	base, _ := supported[index].Base()
+	return base.String()
+}
+
+// Translate returns the message translated into the given locale.
+//
+// Wrapped errors ("message: cause") are translated by their leading message
+// when there is no translation for the full text.
+func Translate(locale, message string) string {
+	messages, ok := catalog[locale]
+	if !ok || message == "" {
+		return message
+	}
+
+	if translated, ok := messages[message]; ok {
+		return translated
+	}
+
+	if prefix, rest, found := strings.Cut(message, ": "); found {
+		if translated, ok := messages[prefix]; ok {
+			return translated + ": " + rest
+		}
+	}
+
+	return message
+}
@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []string
+		want        string
+	}{
+		{name: "no preference", preferences: nil, want: "en"},
+		{name: "empty preference", preferences: []string{""}, want: "en"},
+		{name: "exact match", preferences: []string{"es"}, want: "es"},
+		{name: "regional variant", preferences: []string{"pt-BR"}, want: "pt"},
+		{name: "accept-language with weights", preferences: []string{"fr-CA,fr;q=0.9,es;q=0.8"}, want: "es"},
+		{name: "unsupported falls back", preferences: []string{"ja"}, want: "en"},
+		{name: "first non-empty preference wins", preferences: []string{"", "pt"}, want: "pt"},
+		{name: "invalid header ignored", preferences: []string{";;;"}, want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.preferences...); got != tt.want {
+				t.Errorf("Negotiate(%v) = %q, want %q", tt.preferences, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		message string
+		want    string
+	}{
+		{name: "english passthrough", locale: "en", message: "invalid email", want: "invalid email"},
+		{name: "translated", locale: "es", message: "invalid email", want: "correo electrónico no válido"},
+		{name: "wrapped error", locale: "pt", message: "auth service unavailable: timeout", want: "serviço de autenticação indisponível: timeout"},
+		{name: "unknown message", locale: "pt", message: "something new", want: "something new"},
+		{name: "unknown locale", locale: "de", message: "invalid email", want: "invalid email"},
+		{name: "empty message", locale: "es", message: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.locale, tt.message); got != tt.want {
+				t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.message, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package jsonschema validates JSON documents against the subset of JSON Schema (draft 2020-12)
+// used by the schemas of the events published by the service: type, properties, required,
+// additionalProperties, items, enum, minLength and the date-time format. The other keywords
+// (title, description, $schema, $id, examples...) are annotations and are ignored.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	types                []string
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	forbidAdditional     bool
+	items                *Schema
+	enum                 []any
+	format               string
+	minLength            *int
+}
+
+// Violation is a rule of the schema the document doesn't follow
+type Violation struct {
+	// Path is the JSON pointer of the value, empty for the document
+	Path    string
+	Message string
+}
+
+// Error lists the violations of a document
+type Error struct {
+	Violations []Violation
+}
+
+// Error returns the violations, one per path
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		path := violation.Path
+		if path == "" {
+			path = "/"
+		}
+		messages = append(messages, path+": "+violation.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// rawSchema is the JSON representation of the supported keywords
+type rawSchema struct {
+	Type                 json.RawMessage       `json:"type"`
+	Properties           map[string]*rawSchema `json:"properties"`
+	Required             []string              `json:"required"`
+	AdditionalProperties json.RawMessage       `json:"additionalProperties"`
+	Items                *rawSchema            `json:"items"`
+	Enum                 []any                 `json:"enum"`
+	Format               string                `json:"format"`
+	MinLength            *int                  `json:"minLength"`
+}
+
+// Compile parses the schema, the unsupported types are rejected
+func Compile(data []byte) (*Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return compile(&raw, "")
+}
+
+func compile(raw *rawSchema, path string) (*Schema, error) {
+	schema := &Schema{
+		required:  raw.Required,
+		enum:      raw.Enum,
+		format:    raw.Format,
+		minLength: raw.MinLength,
+	}
+
+	if len(raw.Type) > 0 {
+		var types []string
+		if err := json.Unmarshal(raw.Type, &types); err != nil {
+			var single string
+			if errSingle := json.Unmarshal(raw.Type, &single); errSingle != nil {
+				return nil, fmt.Errorf("invalid type at %q, expected a string or a list of strings", path)
+			}
+			types = []string{single}
+		}
+		for _, t := range types {
+			switch t {
+			case "object", "array", "string", "integer", "number", "boolean", "null":
+			default:
+				return nil, fmt.Errorf("unsupported type %q at %q", t, path)
+			}
+		}
+		schema.types = types
+	}
+
+	if len(raw.Properties) > 0 {
+		schema.properties = make(map[string]*Schema, len(raw.Properties))
+		for name, property := range raw.Properties {
+			compiled, err := compile(property, path+"/properties/"+name)
+			if err != nil {
+				return nil, err
+			}
+			schema.properties[name] = compiled
+		}
+	}
+
+	if additional := bytes.TrimSpace(raw.AdditionalProperties); len(additional) > 0 {
+		switch string(additional) {
+		case "true":
+		case "false":
+			schema.forbidAdditional = true
+		default:
+			var rawAdditional rawSchema
+			if err := json.Unmarshal(additional, &rawAdditional); err != nil {
+				return nil, fmt.Errorf("invalid additionalProperties at %q: %w", path, err)
+			}
+			compiled, err := compile(&rawAdditional, path+"/additionalProperties")
+			if err != nil {
+				return nil, err
+			}
+			schema.additionalProperties = compiled
+		}
+	}
+
+	if raw.Items != nil {
+		compiled, err := compile(raw.Items, path+"/items")
+		if err != nil {
+			return nil, err
+		}
+		schema.items = compiled
+	}
+	return schema, nil
+}
+
+// Validate validates the JSON document, the error is an *Error listing every violation
+func (s *Schema) Validate(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return &Error{Violations: []Violation{{Message: "invalid JSON: " + err.Error()}}}
+	}
+
+	var violations []Violation
+	s.validate(value, "", &violations)
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+func (s *Schema) validate(value any, path string, violations *[]Violation) {
+	report := func(format string, args ...any) {
+		*violations = append(*violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(t string) bool { return hasType(value, t) }) {
+		report("expected %s, got %s", strings.Join(s.types, " or "), typeOf(value))
+		return
+	}
+
+	if len(s.enum) > 0 && !slices.ContainsFunc(s.enum, func(allowed any) bool { return equal(allowed, value) }) {
+		report("value %v is not one of the allowed values", value)
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.minLength != nil && len([]rune(v)) < *s.minLength {
+			report("expected at least %d characters", *s.minLength)
+		}
+		if s.format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				report("expected an RFC 3339 date-time")
+			}
+		}
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				report("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propertyPath := path + "/" + name
+			if property, ok := s.properties[name]; ok {
+				property.validate(v[name], propertyPath, violations)
+				continue
+			}
+			switch {
+			case s.forbidAdditional:
+				*violations = append(*violations, Violation{Path: propertyPath, Message: "unexpected property"})
+			case s.additionalProperties != nil:
+				s.additionalProperties.validate(v[name], propertyPath, violations)
+			}
+		}
+	case []any:
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, fmt.Sprintf("%s/%d", path, i), violations)
+			}
+		}
+	}
+}
+
+// hasType reports whether the decoded JSON value is of the JSON Schema type
+func hasType(value any, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := number.Int64()
+		return err == nil
+	}
+	return false
+}
+
+// typeOf returns the JSON type of the decoded value
+func typeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// equal compares an enum value of the schema with a decoded value, the numbers of the
+// document are json.Number and those of the schema float64
+func equal(allowed, value any) bool {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return err == nil && reflect.DeepEqual(allowed, f)
+	}
+	return reflect.DeepEqual(allowed, value)
+}
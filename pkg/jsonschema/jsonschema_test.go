@@ -0,0 +1,100 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Test",
+	"type": "object",
+	"properties": {
+		"sub": {"type": "string", "minLength": 1},
+		"reason": {"type": "string", "enum": ["update", "merge"]},
+		"changed_at": {"type": "string", "format": "date-time"},
+		"count": {"type": "integer"},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"details": {"type": "object", "additionalProperties": {"type": ["string", "null"]}}
+	},
+	"required": ["sub", "reason", "changed_at"],
+	"additionalProperties": false
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := Compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		document       string
+		wantViolations []Violation
+	}{
+		{
+			name:     "valid document",
+			document: `{"sub": "auth0|123", "reason": "update", "changed_at": "2026-10-16T10:00:00.123Z", "count": 2, "tags": ["a"], "details": {"a": "b", "c": null}}`,
+		},
+		{
+			name:     "missing required properties",
+			document: `{"sub": "auth0|123"}`,
+			wantViolations: []Violation{
+				{Path: "", Message: `missing required property "reason"`},
+				{Path: "", Message: `missing required property "changed_at"`},
+			},
+		},
+		{
+			name:     "invalid values",
+			document: `{"sub": "", "reason": "delete", "changed_at": "yesterday", "count": 1.5, "tags": ["a", 1], "details": {"a": 1}, "extra": true}`,
+			wantViolations: []Violation{
+				{Path: "/changed_at", Message: "expected an RFC 3339 date-time"},
+				{Path: "/count", Message: "expected integer, got number"},
+				{Path: "/details/a", Message: "expected string or null, got number"},
+				{Path: "/extra", Message: "unexpected property"},
+				{Path: "/reason", Message: "value delete is not one of the allowed values"},
+				{Path: "/sub", Message: "expected at least 1 characters"},
+				{Path: "/tags/1", Message: "expected string, got number"},
+			},
+		},
+		{
+			name:           "not an object",
+			document:       `[]`,
+			wantViolations: []Violation{{Path: "", Message: "expected object, got array"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errValidate := schema.Validate([]byte(tt.document))
+			if len(tt.wantViolations) == 0 {
+				assert.NoError(t, errValidate)
+				return
+			}
+			var validationErr *Error
+			require.ErrorAs(t, errValidate, &validationErr)
+			assert.Equal(t, tt.wantViolations, validationErr.Violations)
+		})
+	}
+}
+
+func TestSchema_Validate_InvalidJSON(t *testing.T) {
+	schema, err := Compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, schema.Validate([]byte(`{"sub":`)), "invalid JSON")
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	_, err := Compile([]byte(`{"type": "date"}`))
+	assert.ErrorContains(t, err, `unsupported type "date"`)
+
+	_, err = Compile([]byte(`{"type": 1}`))
+	assert.Error(t, err)
+
+	_, err = Compile([]byte(`not json`))
+	assert.Error(t, err)
+}
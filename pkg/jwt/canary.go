@@ -0,0 +1,160 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package jwt
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultCanarySampleRate is the share of the tokens verified by the candidate
+	DefaultCanarySampleRate = 0.1
+
+	// DefaultCanaryTimeout bounds the candidate verification, it runs after the primary answered
+	DefaultCanaryTimeout = 5 * time.Second
+)
+
+const (
+	// canaryAgree is a candidate accepting (or rejecting) the same token with the same claims
+	canaryAgree = "agree"
+	// canaryDisagree is a candidate with a different outcome or different claims
+	canaryDisagree = "disagree"
+)
+
+// VerifyFunc verifies a token and returns its claims
+type VerifyFunc func(ctx context.Context, token string, requiredScopes ...string) (*Claims, error)
+
+// Canary runs a candidate verifier next to the primary one on a sample of the tokens, to de-risk a
+// change of verification library or JWKS source. The disagreements are logged and counted, the
+// primary result is always the one returned and the candidate runs in the background.
+type Canary struct {
+	primary    VerifyFunc
+	candidate  VerifyFunc
+	sampleRate float64
+	timeout    time.Duration
+	random     func() float64
+	comparison metric.Int64Counter
+	// pending tracks the candidate verifications still running
+	pending sync.WaitGroup
+}
+
+// CanaryOption configures the canary
+type CanaryOption func(*Canary)
+
+// WithCanarySampleRate sets the share of the tokens verified by the candidate, between 0 and 1
+func WithCanarySampleRate(rate float64) CanaryOption {
+	return func(c *Canary) {
+		c.sampleRate = min(max(rate, 0), 1)
+	}
+}
+
+// WithCanaryTimeout bounds the candidate verification
+func WithCanaryTimeout(timeout time.Duration) CanaryOption {
+	return func(c *Canary) {
+		c.timeout = timeout
+	}
+}
+
+// NewCanary creates a canary comparing the candidate verifier to the primary one
+func NewCanary(primary, candidate VerifyFunc, opts ...CanaryOption) *Canary {
+	c := &Canary{
+		primary:    primary,
+		candidate:  candidate,
+		sampleRate: DefaultCanarySampleRate,
+		timeout:    DefaultCanaryTimeout,
+		random:     rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	comparison, errCounter := otel.Meter(constants.ServiceName).Int64Counter(
+		"auth_service.jwt.canary.comparisons",
+		metric.WithDescription("Number of tokens verified by both the primary and the candidate verifier, by result"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create JWT canary comparison counter", "error", errCounter)
+	}
+	c.comparison = comparison
+
+	return c
+}
+
+// Verify verifies the token with the primary verifier, a sample of the tokens is also verified
+// by the candidate once the primary answered
+func (c *Canary) Verify(ctx context.Context, token string, requiredScopes ...string) (*Claims, error) {
+	claims, err := c.primary(ctx, token, requiredScopes...)
+
+	if c.candidate != nil && c.random() < c.sampleRate {
+		c.pending.Add(1)
+		go func() {
+			defer c.pending.Done()
+			c.compare(context.WithoutCancel(ctx), token, requiredScopes, claims, err)
+		}()
+	}
+
+	return claims, err
+}
+
+// compare verifies the token with the candidate and records the outcome
+func (c *Canary) compare(ctx context.Context, token string, requiredScopes []string, primaryClaims *Claims, primaryErr error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	candidateClaims, candidateErr := c.candidate(ctx, token, requiredScopes...)
+
+	result := canaryAgree
+	if !sameOutcome(primaryClaims, primaryErr, candidateClaims, candidateErr) {
+		result = canaryDisagree
+
+		attrs := []any{
+			"required_scope", requiredScopes,
+			"primary_error", primaryErr,
+			"candidate_error", candidateErr,
+		}
+		if primaryClaims != nil {
+			attrs = append(attrs, "user_id", redaction.Redact(primaryClaims.Subject))
+		}
+		slog.WarnContext(ctx, "JWT verifier canary disagreement", attrs...)
+	}
+
+	if c.comparison != nil {
+		c.comparison.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+	}
+}
+
+// sameOutcome tells whether both verifiers accepted the token with the same claims, or both
+// rejected it, the reasons of a rejection are not compared as the libraries word them differently
+func sameOutcome(primary *Claims, primaryErr error, candidate *Claims, candidateErr error) bool {
+	if primaryErr != nil || candidateErr != nil {
+		return primaryErr != nil && candidateErr != nil
+	}
+	if primary == nil || candidate == nil {
+		return primary == candidate
+	}
+	return primary.Subject == candidate.Subject &&
+		primary.Issuer == candidate.Issuer &&
+		primary.Scope == candidate.Scope &&
+		sameTime(primary.ExpiresAt, candidate.ExpiresAt) &&
+		slices.Equal(primary.Audience, candidate.Audience)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package jwt
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanary_Verify(t *testing.T) {
+	ctx := context.Background()
+	primaryClaims := &Claims{Subject: "auth0|123", Issuer: "https://lfx.auth0.com/"}
+
+	tests := []struct {
+		name           string
+		primaryErr     error
+		sample         float64
+		wantCandidates int32
+	}{
+		{name: "sampled token", sample: 0.05, wantCandidates: 1},
+		{name: "sampled rejected token", primaryErr: errors.NewValidation("token has expired"), sample: 0.05, wantCandidates: 1},
+		{name: "token out of the sample", sample: 0.5, wantCandidates: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var candidates atomic.Int32
+			primary := func(ctx context.Context, token string, requiredScopes ...string) (*Claims, error) {
+				if tt.primaryErr != nil {
+					return nil, tt.primaryErr
+				}
+				return primaryClaims, nil
+			}
+			candidate := func(ctx context.Context, token string, requiredScopes ...string) (*Claims, error) {
+				candidates.Add(1)
+				assert.Equal(t, []string{"read:current_user"}, requiredScopes)
+				// the candidate always disagrees, the primary result must be returned anyway
+				return nil, errors.NewValidation("unknown key")
+			}
+
+			canary := NewCanary(primary, candidate, WithCanarySampleRate(0.1), WithCanaryTimeout(time.Second))
+			canary.random = func() float64 { return tt.sample }
+
+			claims, err := canary.Verify(ctx, "token", "read:current_user")
+			canary.pending.Wait()
+
+			if tt.primaryErr != nil {
+				assert.Equal(t, tt.primaryErr, err)
+				assert.Nil(t, claims)
+			} else {
+				require.NoError(t, err)
+				assert.Same(t, primaryClaims, claims)
+			}
+			assert.Equal(t, tt.wantCandidates, candidates.Load())
+		})
+	}
+}
+
+func TestCanary_SampleRateBounds(t *testing.T) {
+	assert.Equal(t, float64(1), NewCanary(nil, nil, WithCanarySampleRate(2)).sampleRate)
+	assert.Equal(t, float64(0), NewCanary(nil, nil, WithCanarySampleRate(-1)).sampleRate)
+	assert.Equal(t, DefaultCanarySampleRate, NewCanary(nil, nil).sampleRate)
+}
+
+func TestSameOutcome(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	claims := &Claims{
+		Subject:   "auth0|123",
+		Issuer:    "https://lfx.auth0.com/",
+		Audience:  []string{"https://lfx.auth0.com/api/v2/"},
+		Scope:     "read:current_user",
+		ExpiresAt: &expiresAt,
+	}
+	sameExpiration := expiresAt.In(time.Local)
+	rejected := errors.NewValidation("invalid signature")
+
+	tests := []struct {
+		name         string
+		primary      *Claims
+		primaryErr   error
+		candidate    *Claims
+		candidateErr error
+		want         bool
+	}{
+		{name: "same claims", primary: claims, candidate: &Claims{Subject: claims.Subject, Issuer: claims.Issuer, Audience: claims.Audience, Scope: claims.Scope, ExpiresAt: &sameExpiration}, want: true},
+		{name: "both rejected for different reasons", primaryErr: rejected, candidateErr: errors.NewValidation("token has expired"), want: true},
+		{name: "only the candidate rejects", primary: claims, candidateErr: rejected, want: false},
+		{name: "only the primary rejects", primaryErr: rejected, candidate: claims, want: false},
+		{name: "different subject", primary: claims, candidate: &Claims{Subject: "auth0|456", Issuer: claims.Issuer, Audience: claims.Audience, Scope: claims.Scope, ExpiresAt: claims.ExpiresAt}, want: false},
+		{name: "different audience", primary: claims, candidate: &Claims{Subject: claims.Subject, Issuer: claims.Issuer, Scope: claims.Scope, ExpiresAt: claims.ExpiresAt}, want: false},
+		{name: "missing expiration", primary: claims, candidate: &Claims{Subject: claims.Subject, Issuer: claims.Issuer, Audience: claims.Audience, Scope: claims.Scope}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sameOutcome(tt.primary, tt.primaryErr, tt.candidate, tt.candidateErr))
+		})
+	}
+}
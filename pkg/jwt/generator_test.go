@@ -44,7 +44,7 @@ func TestGenerateAccessToken(t *testing.T) {
 			VerifySignature:   true,
 			SigningKey:        publicKey,
 			ExpectedIssuer:    opts.Issuer,
-			ExpectedAudience:  opts.Audience,
+			ExpectedAudiences: []string{opts.Audience},
 			RequireExpiration: true,
 			RequireSubject:    true,
 		}
@@ -53,7 +53,7 @@ func TestGenerateAccessToken(t *testing.T) {
 
 		assert.Equal(t, opts.Subject, claims.Subject)
 		assert.Equal(t, opts.Issuer, claims.Issuer)
-		assert.Equal(t, opts.Audience, claims.Audience)
+		assert.Equal(t, []string{opts.Audience}, claims.Audience)
 		assert.Equal(t, opts.Scope, claims.Scope)
 		assert.NotNil(t, claims.ExpiresAt)
 		assert.NotNil(t, claims.IssuedAt)
@@ -132,7 +132,7 @@ func TestGenerateIdentityToken(t *testing.T) {
 			VerifySignature:   true,
 			SigningKey:        publicKey,
 			ExpectedIssuer:    opts.Issuer,
-			ExpectedAudience:  opts.Audience,
+			ExpectedAudiences: []string{opts.Audience},
 			RequireExpiration: true,
 			RequireSubject:    false, // Identity tokens may not have subject
 		}
@@ -144,7 +144,7 @@ func TestGenerateIdentityToken(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, opts.Email, email)
 		assert.Equal(t, opts.Issuer, claims.Issuer)
-		assert.Equal(t, opts.Audience, claims.Audience)
+		assert.Equal(t, []string{opts.Audience}, claims.Audience)
 	})
 
 	t.Run("identity token with HMAC signing", func(t *testing.T) {
@@ -335,7 +335,7 @@ func TestGenerateAccessTokenConvenience(t *testing.T) {
 		VerifySignature:   true,
 		SigningKey:        publicKey,
 		ExpectedIssuer:    "https://test.auth0.com/",
-		ExpectedAudience:  "https://test.auth0.com/api/v2/",
+		ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 		RequireExpiration: true,
 		RequireSubject:    true,
 		RequiredScopes:    []string{"read:current_user"},
@@ -367,7 +367,7 @@ func TestGenerateIdentityTokenConvenience(t *testing.T) {
 		VerifySignature:   true,
 		SigningKey:        publicKey,
 		ExpectedIssuer:    "https://test.auth0.com/",
-		ExpectedAudience:  "https://test.auth0.com/api/v2/",
+		ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 		RequireExpiration: true,
 		RequireSubject:    false,
 	}
@@ -510,7 +510,7 @@ func TestGenerateSimpleTestAccessToken(t *testing.T) {
 
 	assert.Equal(t, "simple-user", claims.Subject)
 	assert.Equal(t, "https://test.any.com/", claims.Issuer)
-	assert.Equal(t, "https://test.any.com/api/v2/", claims.Audience)
+	assert.Equal(t, []string{"https://test.any.com/api/v2/"}, claims.Audience)
 	assert.Equal(t, "read:current_user", claims.Scope)
 }
 
@@ -532,7 +532,7 @@ func TestGenerateSimpleTestIdentityToken(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "simple@example.com", email)
 	assert.Equal(t, "https://test.any.com/", claims.Issuer)
-	assert.Equal(t, "https://test.any.com/api/v2/", claims.Audience)
+	assert.Equal(t, []string{"https://test.any.com/api/v2/"}, claims.Audience)
 }
 
 func TestGetDefaultTestPublicKey(t *testing.T) {
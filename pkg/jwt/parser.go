@@ -5,6 +5,10 @@ package jwt
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"fmt"
 	"log/slog"
@@ -13,10 +17,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
@@ -28,7 +35,7 @@ type Claims struct {
 	IssuedAt  *time.Time     `json:"iat,omitempty"`
 	NotBefore *time.Time     `json:"nbf,omitempty"`
 	Issuer    string         `json:"iss,omitempty"`
-	Audience  string         `json:"aud,omitempty"`
+	Audience  []string       `json:"aud,omitempty"`
 	Scope     string         `json:"scope,omitempty"`
 	Raw       map[string]any `json:"-"` // Raw claims for additional fields
 }
@@ -45,12 +52,18 @@ type ParseOptions struct {
 	RequireSubject bool
 	// VerifySignature enables signature verification
 	VerifySignature bool
-	// SigningKey is the key used for signature verification (RSA public key)
-	SigningKey *rsa.PublicKey
+	// SigningKey is the key used for signature verification (*rsa.PublicKey, *ecdsa.PublicKey
+	// or ed25519.PublicKey), it must be of the type of the algorithm of the token
+	SigningKey crypto.PublicKey
+	// AllowedAlgorithms are the signing algorithms accepted, RS256 only when empty
+	AllowedAlgorithms []jwa.SignatureAlgorithm
 	// ExpectedIssuer validates the 'iss' claim matches this value
 	ExpectedIssuer string
-	// ExpectedAudience validates the 'aud' claim matches this value
-	ExpectedAudience string
+	// ExpectedAudiences validates the 'aud' claim contains at least one of these values,
+	// tokens issued for several APIs carry all of them
+	ExpectedAudiences []string
+	// Clock is the time source of the expiration check, the system clock when nil
+	Clock clock.Clock
 }
 
 // DefaultParseOptions returns sensible default options
@@ -96,7 +109,7 @@ func ParseUnverified(ctx context.Context, tokenString string, opts *ParseOptions
 
 	// Validate expiration if required
 	if opts.RequireExpiration {
-		if err := validateExpiration(claims); err != nil {
+		if err := validateExpiration(claims, clock.Or(opts.Clock).Now()); err != nil {
 			return nil, err
 		}
 	}
@@ -143,8 +156,13 @@ func ParseVerified(ctx context.Context, tokenString string, opts *ParseOptions)
 		}
 	}
 
+	alg, err := signingAlgorithm(cleanToken, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse the token with jwx
-	token, errParse := jwt.Parse([]byte(cleanToken), jwt.WithKey(jwa.RS256, opts.SigningKey))
+	token, errParse := jwt.Parse([]byte(cleanToken), jwt.WithKey(alg, opts.SigningKey), jwt.WithClock(clock.Or(opts.Clock)))
 	if errParse != nil {
 		return nil, errParse
 	}
@@ -163,15 +181,15 @@ func ParseVerified(ctx context.Context, tokenString string, opts *ParseOptions)
 	}
 
 	// Validate audience if specified
-	if opts.ExpectedAudience != "" {
-		if err := validateAudience(claims, opts.ExpectedAudience); err != nil {
+	if len(opts.ExpectedAudiences) > 0 {
+		if err := validateAudience(claims, opts.ExpectedAudiences); err != nil {
 			return nil, err
 		}
 	}
 
 	// Validate expiration if required
 	if opts.RequireExpiration {
-		if err := validateExpiration(claims); err != nil {
+		if err := validateExpiration(claims, clock.Or(opts.Clock).Now()); err != nil {
 			return nil, err
 		}
 	}
@@ -201,6 +219,61 @@ func ParseVerified(ctx context.Context, tokenString string, opts *ParseOptions)
 	return claims, nil
 }
 
+// signingAlgorithm returns the algorithm of the token header once it is checked against the allowed
+// ones and the type of the signing key, so a token can't choose how its signature is verified
+// (e.g. HS256 with the public key as the secret)
+func signingAlgorithm(token string, opts *ParseOptions) (jwa.SignatureAlgorithm, error) {
+	// worded as the jwx parse error, which the malformed tokens used to fail with
+	message, err := jws.Parse([]byte(token))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse jws: %w", err)
+	}
+	signatures := message.Signatures()
+	if len(signatures) != 1 {
+		return "", errors.NewValidation("token must have exactly one signature")
+	}
+	alg := signatures[0].ProtectedHeaders().Algorithm()
+
+	allowed := opts.AllowedAlgorithms
+	if len(allowed) == 0 {
+		allowed = []jwa.SignatureAlgorithm{jwa.RS256}
+	}
+	if !slices.Contains(allowed, alg) {
+		return "", errors.NewValidation(fmt.Sprintf("token signing algorithm %q is not allowed", alg))
+	}
+	if !keyMatchesAlgorithm(alg, opts.SigningKey) {
+		return "", errors.NewValidation(fmt.Sprintf("token signing algorithm %q does not match the signing key", alg))
+	}
+	return alg, nil
+}
+
+// keyMatchesAlgorithm reports whether the key is a public key of the type the algorithm signs with,
+// the symmetric algorithms never match
+func keyMatchesAlgorithm(alg jwa.SignatureAlgorithm, key crypto.PublicKey) bool {
+	switch alg {
+	case jwa.RS256, jwa.RS384, jwa.RS512, jwa.PS256, jwa.PS384, jwa.PS512:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		return ok && rsaKey != nil
+	case jwa.ES256:
+		return isECDSAKey(key, elliptic.P256())
+	case jwa.ES384:
+		return isECDSAKey(key, elliptic.P384())
+	case jwa.ES512:
+		return isECDSAKey(key, elliptic.P521())
+	case jwa.EdDSA:
+		edKey, ok := key.(ed25519.PublicKey)
+		return ok && len(edKey) == ed25519.PublicKeySize
+	default:
+		return false
+	}
+}
+
+// isECDSAKey reports whether the key is an ECDSA public key on the curve
+func isECDSAKey(key crypto.PublicKey, curve elliptic.Curve) bool {
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	return ok && ecKey != nil && ecKey.Curve == curve
+}
+
 // extractClaimsFromJWT extracts claims from a jwx JWT token
 func extractClaimsFromJWT(token jwt.Token) (*Claims, error) {
 	claims := &Claims{
@@ -211,11 +284,8 @@ func extractClaimsFromJWT(token jwt.Token) (*Claims, error) {
 	claims.Subject = token.Subject()
 	claims.Issuer = token.Issuer()
 
-	// Handle audience (jwx returns []string)
-	audience := token.Audience()
-	if len(audience) > 0 {
-		claims.Audience = audience[0] // Take the first audience
-	}
+	// A single audience is a string in the token, jwx returns it as a list too
+	claims.Audience = token.Audience()
 
 	// Extract email from private claims
 	if email, ok := token.Get("email"); ok {
@@ -291,10 +361,32 @@ func ExtractEmail(ctx context.Context, tokenString string) (string, error) {
 		return "", errors.NewValidation("missing or invalid 'email' claim in token")
 	}
 
-	slog.DebugContext(ctx, "extracted email from JWT", "email", claims.Email)
+	slog.DebugContext(ctx, "extracted email from JWT", "email", redaction.RedactEmail(claims.Email))
 	return claims.Email, nil
 }
 
+// ExtractIssuer is a convenience function that extracts only the 'iss' claim from a JWT token,
+// the token must still be verified by the issuer it names
+func ExtractIssuer(ctx context.Context, tokenString string) (string, error) {
+	opts := &ParseOptions{
+		RequireExpiration: false,
+		AllowBearerPrefix: true,
+		RequireSubject:    false,
+	}
+
+	claims, err := ParseUnverified(ctx, tokenString, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(claims.Issuer) == "" {
+		return "", errors.NewValidation("missing or invalid 'iss' claim in token")
+	}
+
+	slog.DebugContext(ctx, "extracted issuer from JWT", "issuer", claims.Issuer)
+	return claims.Issuer, nil
+}
+
 // validateSubject checks if the token has a valid subject
 func validateSubject(claims *Claims) error {
 	if strings.TrimSpace(claims.Subject) == "" {
@@ -303,13 +395,13 @@ func validateSubject(claims *Claims) error {
 	return nil
 }
 
-// validateExpiration checks if the token is expired
-func validateExpiration(claims *Claims) error {
+// validateExpiration checks if the token is expired at the given time
+func validateExpiration(claims *Claims, now time.Time) error {
 	if claims.ExpiresAt == nil {
 		return errors.NewValidation("missing 'exp' claim in token")
 	}
 
-	if time.Now().After(*claims.ExpiresAt) {
+	if now.After(*claims.ExpiresAt) {
 		return errors.NewValidation(fmt.Sprintf("token has expired at %v", *claims.ExpiresAt))
 	}
 
@@ -346,17 +438,19 @@ func validateIssuer(claims *Claims, expectedIssuer string) error {
 	return nil
 }
 
-// validateAudience checks if the token audience matches the expected value
-func validateAudience(claims *Claims, expectedAudience string) error {
-	if claims.Audience == "" {
+// validateAudience checks if one of the token audiences matches one of the expected values
+func validateAudience(claims *Claims, expectedAudiences []string) error {
+	if len(claims.Audience) == 0 {
 		return errors.NewValidation("missing 'aud' claim in token")
 	}
 
-	if claims.Audience != expectedAudience {
-		return errors.NewValidation("invalid audience")
+	for _, audience := range claims.Audience {
+		if slices.Contains(expectedAudiences, audience) {
+			return nil
+		}
 	}
 
-	return nil
+	return errors.NewValidation("invalid audience")
 }
 
 // GetClaim is a helper to extract a specific claim from the raw claims
@@ -425,3 +519,24 @@ func LoadRSAPublicKeyFromJWK(jwkData []byte) (*rsa.PublicKey, error) {
 
 	return &rsaKey, nil
 }
+
+// LoadPublicKeyFromJWK loads a public key from JWK (JSON Web Key) format, an *rsa.PublicKey,
+// an *ecdsa.PublicKey or an ed25519.PublicKey depending on the key type
+func LoadPublicKeyFromJWK(jwkData []byte) (crypto.PublicKey, error) {
+	key, err := jwk.ParseKey(jwkData)
+	if err != nil {
+		return nil, errors.NewValidation("failed to parse JWK: %w", err)
+	}
+
+	var raw any
+	if err := key.Raw(&raw); err != nil {
+		return nil, errors.NewValidation("failed to get public key from JWK: %w", err)
+	}
+
+	switch publicKey := raw.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, errors.NewValidation(fmt.Sprintf("unsupported JWK key type %q, expected a public key", key.KeyType()))
+	}
+}
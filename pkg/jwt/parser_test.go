@@ -5,13 +5,22 @@ package jwt
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,7 +55,7 @@ func TestParseUnverified(t *testing.T) {
 		assert.NotNil(t, claims.IssuedAt)
 		assert.WithinDuration(t, iat, *claims.IssuedAt, time.Second)
 		assert.Equal(t, "test-issuer", claims.Issuer)
-		assert.Equal(t, "test-audience", claims.Audience)
+		assert.Equal(t, []string{"test-audience"}, claims.Audience)
 		assert.Equal(t, "read write update:current_user_metadata", claims.Scope)
 	})
 
@@ -80,6 +89,28 @@ func TestParseUnverified(t *testing.T) {
 		assert.Contains(t, err.Error(), "exp")
 	})
 
+	t.Run("token expires on the clock", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "user123",
+			"exp": now.Add(time.Hour).Unix(),
+		})
+
+		tokenString, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		fake := clock.NewFake(now)
+		opts := DefaultParseOptions()
+		opts.Clock = fake
+
+		_, err = ParseUnverified(ctx, tokenString, opts)
+		require.NoError(t, err)
+
+		fake.Advance(2 * time.Hour)
+		_, err = ParseUnverified(ctx, tokenString, opts)
+		assert.Error(t, err)
+	})
+
 	t.Run("missing required scope", func(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 			"sub":   "user123",
@@ -307,6 +338,42 @@ func TestExtractEmail(t *testing.T) {
 	})
 }
 
+func TestExtractIssuer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid token with issuer", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iss": "https://community.auth0.com/",
+			"sub": "auth0|123456789",
+		})
+
+		tokenString, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		issuer, err := ExtractIssuer(ctx, "Bearer "+tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, "https://community.auth0.com/", issuer)
+	})
+
+	t.Run("missing issuer claim", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "auth0|123456789",
+		})
+
+		tokenString, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = ExtractIssuer(ctx, tokenString)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing or invalid 'iss' claim")
+	})
+
+	t.Run("invalid token format", func(t *testing.T) {
+		_, err := ExtractIssuer(ctx, "invalid.token")
+		assert.Error(t, err)
+	})
+}
+
 func TestClaimsHelpers(t *testing.T) {
 	claims := &Claims{
 		Subject: "user123",
@@ -371,12 +438,20 @@ func TestParseVerified(t *testing.T) {
 		t.Fatalf("Failed to sign token: %v", err)
 	}
 
+	// tokens issued for several APIs carry all their audiences
+	claims["aud"] = []string{"https://api.lfx.dev/", "https://test.auth0.com/api/v2/"}
+	multiAudienceToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
 	tests := []struct {
-		name        string
-		token       string
-		opts        *ParseOptions
-		expectError bool
-		errorType   error
+		name          string
+		token         string
+		opts          *ParseOptions
+		expectError   bool
+		errorType     error
+		wantAudiences []string
 	}{
 		{
 			name:  "valid token with signature verification",
@@ -385,7 +460,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 				RequiredScopes:    []string{"read:current_user"},
@@ -399,7 +474,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 				AllowBearerPrefix: true,
@@ -413,7 +488,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        &rsa.PublicKey{}, // Wrong key
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 			},
@@ -426,7 +501,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://wrong.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 			},
@@ -439,12 +514,45 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://wrong.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://wrong.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 			},
 			expectError: true,
 		},
+		{
+			name:  "one of the audiences of the token",
+			token: multiAudienceToken,
+			opts: &ParseOptions{
+				VerifySignature:   true,
+				SigningKey:        publicKey,
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
+				RequireSubject:    true,
+			},
+			wantAudiences: []string{"https://api.lfx.dev/", "https://test.auth0.com/api/v2/"},
+		},
+		{
+			name:  "one of the expected audiences",
+			token: tokenString,
+			opts: &ParseOptions{
+				VerifySignature:   true,
+				SigningKey:        publicKey,
+				ExpectedAudiences: []string{"https://api.lfx.dev/", "https://test.auth0.com/api/v2/"},
+				RequireSubject:    true,
+			},
+			wantAudiences: []string{"https://test.auth0.com/api/v2/"},
+		},
+		{
+			name:  "none of the audiences of the token",
+			token: multiAudienceToken,
+			opts: &ParseOptions{
+				VerifySignature:   true,
+				SigningKey:        publicKey,
+				ExpectedAudiences: []string{"https://wrong.auth0.com/api/v2/", "https://api.wrong.dev/"},
+				RequireSubject:    true,
+			},
+			expectError: true,
+		},
 		{
 			name:  "expired token",
 			token: createExpiredToken(t, privateKey),
@@ -452,12 +560,26 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 			},
 			expectError: true,
 		},
+		{
+			name:  "token expired on the clock",
+			token: tokenString,
+			opts: &ParseOptions{
+				VerifySignature:   true,
+				SigningKey:        publicKey,
+				ExpectedIssuer:    "https://test.auth0.com/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
+				RequireExpiration: true,
+				RequireSubject:    true,
+				Clock:             clock.NewFake(now.Add(2 * time.Hour)),
+			},
+			expectError: true,
+		},
 		{
 			name:  "missing required scope",
 			token: tokenString,
@@ -465,7 +587,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        publicKey,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 				RequiredScopes:    []string{"admin:all"}, // Not in token
@@ -479,7 +601,7 @@ func TestParseVerified(t *testing.T) {
 				VerifySignature:   true,
 				SigningKey:        nil,
 				ExpectedIssuer:    "https://test.auth0.com/",
-				ExpectedAudience:  "https://test.auth0.com/api/v2/",
+				ExpectedAudiences: []string{"https://test.auth0.com/api/v2/"},
 				RequireExpiration: true,
 				RequireSubject:    true,
 			},
@@ -519,10 +641,141 @@ func TestParseVerified(t *testing.T) {
 			if claims.Subject != "test-user-123" {
 				t.Errorf("Expected subject 'test-user-123', got '%s'", claims.Subject)
 			}
+			if tt.wantAudiences != nil && !slices.Equal(claims.Audience, tt.wantAudiences) {
+				t.Errorf("Expected audiences %v, got %v", tt.wantAudiences, claims.Audience)
+			}
 		})
 	}
 }
 
+func TestParseVerified_Algorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecKey384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sign := func(method jwt.SigningMethod, key any) string {
+		token, err := jwt.NewWithClaims(method, jwt.MapClaims{
+			"sub": "test-user-123",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+
+	// the classic confusion, an HS256 token whose secret is the public key of the verifier
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		token     string
+		key       any
+		allowed   []jwa.SignatureAlgorithm
+		wantError string
+	}{
+		{name: "RS256 by default", token: sign(jwt.SigningMethodRS256, rsaKey), key: &rsaKey.PublicKey},
+		{name: "ES256", token: sign(jwt.SigningMethodES256, ecKey), key: &ecKey.PublicKey, allowed: []jwa.SignatureAlgorithm{jwa.ES256}},
+		{name: "EdDSA", token: sign(jwt.SigningMethodEdDSA, edKey), key: edPublicKey, allowed: []jwa.SignatureAlgorithm{jwa.EdDSA}},
+		{
+			name:      "ES256 not allowed by default",
+			token:     sign(jwt.SigningMethodES256, ecKey),
+			key:       &ecKey.PublicKey,
+			wantError: `token signing algorithm "ES256" is not allowed`,
+		},
+		{
+			name:      "ES256 token with an RSA key",
+			token:     sign(jwt.SigningMethodES256, ecKey),
+			key:       &rsaKey.PublicKey,
+			allowed:   []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256},
+			wantError: `token signing algorithm "ES256" does not match the signing key`,
+		},
+		{
+			name:      "RS256 token with an ECDSA key",
+			token:     sign(jwt.SigningMethodRS256, rsaKey),
+			key:       &ecKey.PublicKey,
+			allowed:   []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256},
+			wantError: `token signing algorithm "RS256" does not match the signing key`,
+		},
+		{
+			name:      "ES384 token with a P-256 key",
+			token:     sign(jwt.SigningMethodES384, ecKey384),
+			key:       &ecKey.PublicKey,
+			allowed:   []jwa.SignatureAlgorithm{jwa.ES256, jwa.ES384},
+			wantError: `token signing algorithm "ES384" does not match the signing key`,
+		},
+		{
+			name:      "HS256 token signed with the public key",
+			token:     sign(jwt.SigningMethodHS256, rsaPublicDER),
+			key:       &rsaKey.PublicKey,
+			allowed:   []jwa.SignatureAlgorithm{jwa.RS256, jwa.HS256},
+			wantError: `token signing algorithm "HS256" does not match the signing key`,
+		},
+		{
+			name:      "EdDSA token with an Ed25519 private key",
+			token:     sign(jwt.SigningMethodEdDSA, edKey),
+			key:       edKey,
+			allowed:   []jwa.SignatureAlgorithm{jwa.EdDSA},
+			wantError: `token signing algorithm "EdDSA" does not match the signing key`,
+		},
+		{
+			name:      "none",
+			token:     sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType),
+			key:       &rsaKey.PublicKey,
+			wantError: `token signing algorithm "none" is not allowed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ParseVerified(context.Background(), tt.token, &ParseOptions{
+				VerifySignature:   true,
+				SigningKey:        tt.key,
+				AllowedAlgorithms: tt.allowed,
+				RequireExpiration: true,
+				RequireSubject:    true,
+			})
+			if tt.wantError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "test-user-123", claims.Subject)
+		})
+	}
+}
+
+func TestLoadPublicKeyFromJWK(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwkData := func(raw any) []byte {
+		key, err := jwk.FromRaw(raw)
+		require.NoError(t, err)
+		data, err := json.Marshal(key)
+		require.NoError(t, err)
+		return data
+	}
+
+	loaded, err := LoadPublicKeyFromJWK(jwkData(&ecKey.PublicKey))
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(loaded))
+
+	loaded, err = LoadPublicKeyFromJWK(jwkData(edPublicKey))
+	require.NoError(t, err)
+	assert.True(t, edPublicKey.Equal(loaded))
+
+	_, err = LoadPublicKeyFromJWK(jwkData([]byte("0123456789abcdef0123456789abcdef")))
+	assert.EqualError(t, err, `unsupported JWK key type "oct", expected a public key`)
+}
+
 func TestLoadRSAPublicKeyFromJWK(t *testing.T) {
 	// Generate a test RSA key pair
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
@@ -0,0 +1,330 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package keyring manages the keys signing the tokens issued by the service, e.g. the profile share
+// links. The keys are rotated on a schedule and shared by the replicas through a store (a Kubernetes
+// secret or Vault). Several keys are active at once: the newest signs, the previous ones keep
+// verifying the tokens they signed until the retention after they were superseded. A token carries
+// the ID of its key (kid) so the verifier selects it.
+//
+// A new key only starts signing after the propagation delay, once every replica reloaded it, so
+// the tokens it signs are verified by all of them.
+package keyring
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// KeySize is the size of the generated keys
+	KeySize = 32
+
+	// DefaultRotation is how long a key signs before the next one is generated
+	DefaultRotation = 30 * 24 * time.Hour
+
+	// DefaultRetention is how long a superseded key keeps verifying the tokens it signed, it must
+	// be at least the validity of the tokens
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// DefaultPropagation is the delay before a new key signs, the replicas reload it meanwhile
+	DefaultPropagation = 5 * time.Minute
+
+	// DefaultRefresh is how often the replicas reload the keys and rotate them when due, it must be
+	// shorter than the propagation delay
+	DefaultRefresh = time.Minute
+
+	// saveAttempts is the number of rotations tried when other replicas change the keys concurrently
+	saveAttempts = 3
+)
+
+// Key is a signing key
+type Key struct {
+	// ID is the key ID (kid) carried by the tokens the key signed, empty for a static key
+	ID     string `json:"kid"`
+	Secret []byte `json:"secret"`
+
+	CreatedAt time.Time `json:"created_at"`
+	// ActiveAt is when the key starts signing, the tokens are only verified before
+	ActiveAt time.Time `json:"active_at"`
+}
+
+// Store persists the keys shared by the replicas, with optimistic concurrency
+type Store interface {
+	// Load returns the keys and their version, no keys and an empty version when none is stored
+	Load(ctx context.Context) ([]Key, string, error)
+	// Save replaces the keys of the version, it fails with a conflict when they changed since
+	Save(ctx context.Context, keys []Key, version string) error
+}
+
+// Static is a single key never rotated, with an empty ID
+type Static []byte
+
+// SigningKey returns the key
+func (s Static) SigningKey() (Key, error) {
+	return Key{Secret: s}, nil
+}
+
+// Key returns the key for the empty ID
+func (s Static) Key(id string) (Key, bool) {
+	return Key{Secret: s}, id == ""
+}
+
+// Manager rotates the keys of the store and selects the keys signing and verifying the tokens
+type Manager struct {
+	store       Store
+	rotation    time.Duration
+	retention   time.Duration
+	propagation time.Duration
+	legacy      []byte
+	clock       clock.Clock
+
+	mu sync.RWMutex
+	// keys are the loaded keys, the newest first
+	keys []Key
+}
+
+// Option configures the manager
+type Option func(*Manager)
+
+// WithRotation sets how long a key signs before the next one is generated
+func WithRotation(rotation time.Duration) Option {
+	return func(m *Manager) {
+		m.rotation = rotation
+	}
+}
+
+// WithRetention sets how long a superseded key keeps verifying the tokens it signed
+func WithRetention(retention time.Duration) Option {
+	return func(m *Manager) {
+		m.retention = retention
+	}
+}
+
+// WithPropagation sets the delay before a new key signs
+func WithPropagation(propagation time.Duration) Option {
+	return func(m *Manager) {
+		m.propagation = propagation
+	}
+}
+
+// WithLegacyKey keeps verifying the tokens without key ID with the static key they were signed
+// with before the rotation was enabled
+func WithLegacyKey(secret []byte) Option {
+	return func(m *Manager) {
+		m.legacy = append([]byte(nil), secret...)
+	}
+}
+
+// WithClock sets the time source of the rotations
+func WithClock(c clock.Clock) Option {
+	return func(m *Manager) {
+		m.clock = c
+	}
+}
+
+// NewManager loads the keys of the store, the first key is generated when there is none
+func NewManager(ctx context.Context, store Store, opts ...Option) (*Manager, error) {
+	m := &Manager{
+		store:       store,
+		rotation:    DefaultRotation,
+		retention:   DefaultRetention,
+		propagation: DefaultPropagation,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.clock = clock.Or(m.clock)
+
+	if err := m.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	m.observeAges()
+	return m, nil
+}
+
+// SigningKey returns the newest active key
+func (m *Manager) SigningKey() (Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.signingKey(m.clock.Now())
+}
+
+func (m *Manager) signingKey(now time.Time) (Key, error) {
+	for _, key := range m.keys {
+		if !now.Before(key.ActiveAt) {
+			return key, nil
+		}
+	}
+	return Key{}, errors.NewServiceUnavailable("no active signing key")
+}
+
+// Key returns the key of the ID verifying the tokens, the empty ID is the legacy key
+func (m *Manager) Key(id string) (Key, bool) {
+	if id == "" {
+		return Key{Secret: m.legacy}, len(m.legacy) > 0
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, key := range m.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Refresh reloads the keys of the store, and rotates them when the newest key is older than the
+// rotation period. A rotation racing with another replica reloads the keys it saved.
+func (m *Manager) Refresh(ctx context.Context) error {
+	for range saveAttempts {
+		keys, version, errLoad := m.store.Load(ctx)
+		if errLoad != nil {
+			return errLoad
+		}
+
+		rotated, changed, errRotate := m.rotate(keys)
+		if errRotate != nil {
+			return errRotate
+		}
+		if !changed {
+			m.set(rotated)
+			return nil
+		}
+
+		errSave := m.store.Save(ctx, rotated, version)
+		if errSave == nil {
+			m.set(rotated)
+			slog.InfoContext(ctx, "signing keys rotated", "kid", rotated[0].ID, "active_at", rotated[0].ActiveAt, "keys", len(rotated))
+			return nil
+		}
+		var conflict errors.Conflict
+		if !stderrors.As(errSave, &conflict) {
+			return errSave
+		}
+		slog.DebugContext(ctx, "signing keys changed by another replica, reloading them")
+	}
+	return errors.NewConflict("signing keys changed concurrently, rotation skipped")
+}
+
+// Run refreshes the keys every interval until the context is done
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to refresh the signing keys", "error", err)
+			}
+		}
+	}
+}
+
+// rotate returns the keys, newest first, with a new key when the newest is older than the rotation
+// period, and without the keys superseded for longer than the retention
+func (m *Manager) rotate(keys []Key) ([]Key, bool, error) {
+	keys = append([]Key(nil), keys...)
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+
+	now := m.clock.Now()
+	changed := false
+	if len(keys) == 0 || now.Sub(keys[0].CreatedAt) >= m.rotation {
+		// the first key signs right away, there is no other key to sign meanwhile
+		activeAt := now.Add(m.propagation)
+		if len(keys) == 0 {
+			activeAt = now
+		}
+		key, err := newKey(now, activeAt)
+		if err != nil {
+			return nil, false, err
+		}
+		keys = append([]Key{key}, keys...)
+		changed = true
+	}
+
+	// a key is superseded once the next one became active
+	kept := keys[:1]
+	for i := 1; i < len(keys); i++ {
+		if now.Sub(keys[i-1].ActiveAt) < m.retention {
+			kept = append(kept, keys[i])
+			continue
+		}
+		changed = true
+	}
+	return kept, changed, nil
+}
+
+func (m *Manager) set(keys []Key) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = keys
+}
+
+// observeAges reports the age of the keys, the operators alert on a signing key older than the
+// rotation period, a rotation failing
+func (m *Manager) observeAges() {
+	meter := otel.Meter(constants.ServiceName)
+	ages, errGauge := meter.Float64ObservableGauge(
+		"auth_service.signing_keys.age",
+		metric.WithUnit("s"),
+		metric.WithDescription("Age of the signing keys, by key ID and whether the key signs"),
+	)
+	if errGauge != nil {
+		slog.Warn("failed to create signing keys age gauge", "error", errGauge)
+		return
+	}
+
+	_, errCallback := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		now := m.clock.Now()
+		signing, _ := m.signingKey(now)
+		for _, key := range m.keys {
+			observer.ObserveFloat64(ages, now.Sub(key.CreatedAt).Seconds(), metric.WithAttributes(
+				attribute.String("kid", key.ID),
+				attribute.Bool("signing", key.ID == signing.ID),
+			))
+		}
+		return nil
+	}, ages)
+	if errCallback != nil {
+		slog.Warn("failed to observe signing keys age", "error", errCallback)
+	}
+}
+
+// newKey generates a key
+func newKey(createdAt, activeAt time.Time) (Key, error) {
+	id := make([]byte, 8)
+	secret := make([]byte, KeySize)
+	if _, err := rand.Read(id); err != nil {
+		return Key{}, errors.NewUnexpected("failed to generate signing key ID", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, errors.NewUnexpected("failed to generate signing key", err)
+	}
+	return Key{
+		ID:        hex.EncodeToString(id),
+		Secret:    secret,
+		CreatedAt: createdAt,
+		ActiveAt:  activeAt,
+	}, nil
+}
@@ -0,0 +1,146 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package keyring
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// memoryStore is the store shared by the managers of a test, like the replicas share a secret
+type memoryStore struct {
+	mu      sync.Mutex
+	keys    []Key
+	version int
+	// conflicts fail the next saves, like the rotations of other replicas
+	conflicts int
+}
+
+func (s *memoryStore) Load(_ context.Context) ([]Key, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version == 0 {
+		return nil, "", nil
+	}
+	return append([]Key(nil), s.keys...), strconv.Itoa(s.version), nil
+}
+
+func (s *memoryStore) Save(_ context.Context, keys []Key, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conflicts > 0 {
+		s.conflicts--
+		return errors.NewConflict("keys changed")
+	}
+	if version != "" && version != strconv.Itoa(s.version) || version == "" && s.version != 0 {
+		return errors.NewConflict("keys changed")
+	}
+	s.keys = append([]Key(nil), keys...)
+	s.version++
+	return nil
+}
+
+func TestManager_Rotation(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	store := &memoryStore{}
+	opts := []Option{WithRotation(24 * time.Hour), WithRetention(48 * time.Hour), WithPropagation(5 * time.Minute), WithClock(fakeClock)}
+
+	manager, err := NewManager(ctx, store, opts...)
+	require.NoError(t, err)
+	first, err := manager.SigningKey()
+	require.NoError(t, err, "the first key signs right away")
+	assert.Len(t, first.Secret, KeySize)
+	assert.NotEmpty(t, first.ID)
+
+	// the other replicas load the same keys
+	replica, err := NewManager(ctx, store, opts...)
+	require.NoError(t, err)
+	replicaKey, err := replica.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, replicaKey.ID)
+
+	fakeClock.Advance(24 * time.Hour)
+	require.NoError(t, manager.Refresh(ctx))
+	require.Len(t, store.keys, 2)
+	second := store.keys[0]
+	assert.NotEqual(t, first.ID, second.ID)
+
+	signing, err := manager.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, signing.ID, "the new key only signs after the propagation")
+	_, found := replica.Key(second.ID)
+	assert.False(t, found, "the replica didn't reload the keys yet")
+
+	require.NoError(t, replica.Refresh(ctx))
+	assert.Len(t, store.keys, 2, "the keys are rotated once")
+	_, found = replica.Key(second.ID)
+	assert.True(t, found)
+
+	fakeClock.Advance(5 * time.Minute)
+	signing, err = manager.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, signing.ID)
+	verifying, found := manager.Key(first.ID)
+	assert.True(t, found, "the superseded key verifies the tokens it signed")
+	assert.Equal(t, first.Secret, verifying.Secret)
+
+	// the first key is removed the retention after it was superseded, a third key is created meanwhile
+	fakeClock.Advance(48 * time.Hour)
+	require.NoError(t, manager.Refresh(ctx))
+	_, found = manager.Key(first.ID)
+	assert.False(t, found)
+	_, found = manager.Key(second.ID)
+	assert.True(t, found)
+	assert.Len(t, store.keys, 2)
+}
+
+func TestManager_ConcurrentRotation(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{conflicts: 1}
+
+	manager, err := NewManager(ctx, store)
+	require.NoError(t, err, "the rotation is retried once another replica saved its keys")
+	_, err = manager.SigningKey()
+	require.NoError(t, err)
+
+	_, err = NewManager(ctx, &memoryStore{conflicts: saveAttempts})
+	assert.IsType(t, errors.Conflict{}, err, "the rotation gives up after the attempts")
+}
+
+func TestManager_LegacyKey(t *testing.T) {
+	legacy := []byte("0123456789abcdef0123456789abcdef")
+	manager, err := NewManager(context.Background(), &memoryStore{}, WithLegacyKey(legacy))
+	require.NoError(t, err)
+
+	key, found := manager.Key("")
+	assert.True(t, found)
+	assert.Equal(t, legacy, key.Secret)
+
+	withoutLegacy, err := NewManager(context.Background(), &memoryStore{})
+	require.NoError(t, err)
+	_, found = withoutLegacy.Key("")
+	assert.False(t, found)
+}
+
+func TestStatic(t *testing.T) {
+	key, err := Static("secret").SigningKey()
+	require.NoError(t, err)
+	assert.Empty(t, key.ID)
+	assert.Equal(t, []byte("secret"), key.Secret)
+
+	_, found := Static("secret").Key("")
+	assert.True(t, found)
+	_, found = Static("secret").Key("k1")
+	assert.False(t, found)
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Lead runs the job on a single replica at a time until the context is done. The replicas
+// compete for the lock and the one holding it runs the job, the job context is cancelled when
+// the lock is lost so another replica can take over. The job is expected to run until its
+// context is done, it is started again on the next election when it returns earlier.
+func (l *Locker) Lead(ctx context.Context, name string, job func(ctx context.Context)) {
+	for {
+		lk, err := l.TryAcquire(ctx, name)
+		if err == nil {
+			slog.InfoContext(ctx, "elected leader", "lock", name, "owner", l.owner)
+			leaderCtx, release := lk.Hold(ctx)
+			job(leaderCtx)
+			release()
+		} else {
+			var conflict errs.Conflict
+			if !errors.As(err, &conflict) {
+				slog.WarnContext(ctx, "leader election failed", "lock", name, "error", err)
+			}
+		}
+
+		// the holder renews every third of the TTL, check again at the same pace
+		timer := time.NewTimer(l.ttl / 3)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package lock provides locks shared by the replicas of the service, backed by a NATS KV
+// bucket. A lock is a key created by its holder and updated or deleted with a revision
+// check, so only the holder can renew or release it. A lock not renewed before its TTL
+// expires can be taken over by another replica, the holder finds it lost on the next renewal.
+package lock
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultTTL is how long a lock is held without being renewed
+	DefaultTTL = 30 * time.Second
+
+	// minRetryDelay and maxRetryDelay bound the wait between the attempts of Acquire
+	minRetryDelay = 50 * time.Millisecond
+	maxRetryDelay = time.Second
+)
+
+// Store is the subset of the NATS KV bucket used by the locks
+type Store interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Create(ctx context.Context, key string, value []byte, opts ...jetstream.KVCreateOpt) (uint64, error)
+	Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error)
+	Delete(ctx context.Context, key string, opts ...jetstream.KVDeleteOpt) error
+}
+
+// record is the value of a lock key
+type record struct {
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Option configures a Locker
+type Option func(*Locker)
+
+// WithTTL sets how long the locks are held without being renewed
+func WithTTL(ttl time.Duration) Option {
+	return func(l *Locker) {
+		if ttl > 0 {
+			l.ttl = ttl
+		}
+	}
+}
+
+// WithOwner sets the owner recorded in the locks, the host name by default
+func WithOwner(owner string) Option {
+	return func(l *Locker) {
+		if owner != "" {
+			l.owner = owner
+		}
+	}
+}
+
+// WithClock sets the time source of the lock expirations
+func WithClock(c clock.Clock) Option {
+	return func(l *Locker) {
+		l.clock = clock.Or(c)
+	}
+}
+
+// Locker acquires the locks of a replica. The expirations are compared across replicas,
+// the TTL must be well above the clock skew between them.
+type Locker struct {
+	store   Store
+	owner   string
+	ttl     time.Duration
+	clock   clock.Clock
+	metrics *metrics
+}
+
+// key is the KV key of the lock, the names can contain characters not allowed in keys
+func key(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// kind is the metrics attribute of the lock, the name up to the first slash,
+// so the per-user locks don't create a series per user
+func kind(name string) string {
+	before, _, _ := strings.Cut(name, "/")
+	return before
+}
+
+func (l *Locker) record(name string) ([]byte, error) {
+	return json.Marshal(record{
+		Name:      name,
+		Owner:     l.owner,
+		ExpiresAt: l.clock.Now().Add(l.ttl).UTC(),
+	})
+}
+
+// TryAcquire acquires the lock once, it returns a Conflict error when another holder has it
+func (l *Locker) TryAcquire(ctx context.Context, name string) (*Lock, error) {
+	value, errMarshal := l.record(name)
+	if errMarshal != nil {
+		return nil, errs.NewUnexpected("failed to marshal lock", errMarshal)
+	}
+
+	revision, errCreate := l.store.Create(ctx, key(name), value)
+	if errCreate == nil {
+		return l.newLock(name, revision), nil
+	}
+	if !errors.Is(errCreate, jetstream.ErrKeyExists) {
+		return nil, errs.NewServiceUnavailable("failed to create lock", errCreate)
+	}
+
+	entry, errGet := l.store.Get(ctx, key(name))
+	if errGet != nil {
+		if errors.Is(errGet, jetstream.ErrKeyNotFound) {
+			// released in between, the next attempt can create it
+			l.metrics.contended(ctx, name, resultBusy)
+			return nil, errs.NewConflict(fmt.Sprintf("lock %s is busy", name))
+		}
+		return nil, errs.NewServiceUnavailable("failed to get lock", errGet)
+	}
+
+	var current record
+	if errUnmarshal := json.Unmarshal(entry.Value(), &current); errUnmarshal == nil && l.clock.Now().Before(current.ExpiresAt) {
+		l.metrics.contended(ctx, name, resultBusy)
+		return nil, errs.NewConflict(fmt.Sprintf("lock %s is held by %s", name, current.Owner))
+	}
+
+	// expired (or unreadable), take it over unless someone else did first
+	revision, errUpdate := l.store.Update(ctx, key(name), value, entry.Revision())
+	if errUpdate != nil {
+		if !errors.Is(errUpdate, jetstream.ErrKeyExists) {
+			return nil, errs.NewServiceUnavailable("failed to take over lock", errUpdate)
+		}
+		l.metrics.contended(ctx, name, resultBusy)
+		return nil, errs.NewConflict(fmt.Sprintf("lock %s is busy", name), errUpdate)
+	}
+
+	slog.WarnContext(ctx, "expired lock taken over",
+		"lock", name,
+		"previous_owner", current.Owner,
+	)
+	l.metrics.contended(ctx, name, resultTakeover)
+	return l.newLock(name, revision), nil
+}
+
+// Acquire waits for the lock until the context is done, it returns the Conflict
+// error of the last attempt when the lock is still held by then
+func (l *Locker) Acquire(ctx context.Context, name string) (*Lock, error) {
+	start := l.clock.Now()
+	delay := minRetryDelay
+	for {
+		lk, err := l.TryAcquire(ctx, name)
+		if err == nil {
+			l.metrics.waited(ctx, name, l.clock.Now().Sub(start))
+			return lk, nil
+		}
+		var conflict errs.Conflict
+		if !errors.As(err, &conflict) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.metrics.waited(ctx, name, l.clock.Now().Sub(start))
+			return nil, err
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// NewLocker creates a Locker on the KV bucket
+func NewLocker(store Store, opts ...Option) *Locker {
+	owner, _ := os.Hostname()
+	l := &Locker{
+		store:   store,
+		owner:   strings.TrimSpace(owner + " " + uuid.NewString()[:8]),
+		ttl:     DefaultTTL,
+		clock:   clock.System,
+		metrics: newMetrics(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Lock is a lock held by the replica
+type Lock struct {
+	locker *Locker
+	name   string
+
+	mu       sync.Mutex
+	revision uint64
+	released bool
+}
+
+func (l *Locker) newLock(name string, revision uint64) *Lock {
+	return &Lock{locker: l, name: name, revision: revision}
+}
+
+// Name returns the name of the lock
+func (lk *Lock) Name() string {
+	return lk.name
+}
+
+// Renew extends the lock for another TTL, it returns a Conflict error when the lock was lost
+func (lk *Lock) Renew(ctx context.Context) error {
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+
+	if lk.released {
+		return errs.NewConflict(fmt.Sprintf("lock %s was released", lk.name))
+	}
+
+	value, errMarshal := lk.locker.record(lk.name)
+	if errMarshal != nil {
+		return errs.NewUnexpected("failed to marshal lock", errMarshal)
+	}
+
+	revision, errUpdate := lk.locker.store.Update(ctx, key(lk.name), value, lk.revision)
+	if errUpdate != nil {
+		if errors.Is(errUpdate, jetstream.ErrKeyExists) || errors.Is(errUpdate, jetstream.ErrKeyNotFound) {
+			lk.released = true
+			lk.locker.metrics.contended(ctx, lk.name, resultLost)
+			return errs.NewConflict(fmt.Sprintf("lock %s was lost", lk.name), errUpdate)
+		}
+		return errs.NewServiceUnavailable("failed to renew lock", errUpdate)
+	}
+	lk.revision = revision
+	return nil
+}
+
+// Release releases the lock, releasing a lock already lost or released is a no-op
+func (lk *Lock) Release(ctx context.Context) error {
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+
+	if lk.released {
+		return nil
+	}
+	lk.released = true
+
+	errDelete := lk.locker.store.Delete(ctx, key(lk.name), jetstream.LastRevision(lk.revision))
+	if errDelete != nil && !errors.Is(errDelete, jetstream.ErrKeyNotFound) && !errors.Is(errDelete, jetstream.ErrKeyExists) {
+		return errs.NewServiceUnavailable("failed to release lock", errDelete)
+	}
+	return nil
+}
+
+// Hold renews the lock in the background until the returned function is called, which
+// also releases it. The returned context is cancelled when the lock is lost.
+func (lk *Lock) Hold(ctx context.Context) (context.Context, func()) {
+	heldCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(lk.locker.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heldCtx.Done():
+				return
+			case <-ticker.C:
+				if err := lk.Renew(heldCtx); err != nil {
+					var conflict errs.Conflict
+					if errors.As(err, &conflict) {
+						slog.WarnContext(ctx, "lock lost", "lock", lk.name, "error", err)
+						cancel()
+						return
+					}
+					// transient, the lock is still ours until it expires
+					slog.WarnContext(ctx, "failed to renew lock", "lock", lk.name, "error", err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return heldCtx, func() {
+		once.Do(func() {
+			cancel()
+			<-done
+			if err := lk.Release(context.WithoutCancel(ctx)); err != nil {
+				slog.WarnContext(ctx, "failed to release lock", "lock", lk.name, "error", err)
+			}
+		})
+	}
+}
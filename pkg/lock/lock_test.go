@@ -0,0 +1,282 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package lock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+	value    []byte
+	revision uint64
+}
+
+func (e fakeEntry) Value() []byte    { return e.value }
+func (e fakeEntry) Revision() uint64 { return e.revision }
+
+// fakeStore is an in-memory Store with the revision checks of the NATS KV bucket
+type fakeStore struct {
+	mu       sync.Mutex
+	data     map[string]fakeEntry
+	sequence uint64
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{data: make(map[string]fakeEntry)}
+}
+
+func (f *fakeStore) Get(_ context.Context, key string) (jetstream.KeyValueEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.data[key]
+	if !ok {
+		return nil, jetstream.ErrKeyNotFound
+	}
+	return entry, nil
+}
+
+func (f *fakeStore) Create(_ context.Context, key string, value []byte, _ ...jetstream.KVCreateOpt) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.data[key]; ok {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.sequence++
+	f.data[key] = fakeEntry{value: value, revision: f.sequence}
+	return f.sequence, nil
+}
+
+func (f *fakeStore) Update(_ context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.data[key]
+	if !ok || entry.revision != revision {
+		return 0, jetstream.ErrKeyExists
+	}
+	f.sequence++
+	f.data[key] = fakeEntry{value: value, revision: f.sequence}
+	return f.sequence, nil
+}
+
+func (f *fakeStore) Delete(_ context.Context, key string, _ ...jetstream.KVDeleteOpt) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	return nil
+}
+
+func (f *fakeStore) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.data)
+}
+
+func TestLocker_TryAcquire(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	fakeClock := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	replicaA := NewLocker(store, WithOwner("replica-a"), WithClock(fakeClock))
+	replicaB := NewLocker(store, WithOwner("replica-b"), WithClock(fakeClock))
+
+	lk, err := replicaA.TryAcquire(ctx, "authelia/sync")
+	require.NoError(t, err)
+	assert.Equal(t, "authelia/sync", lk.Name())
+
+	_, err = replicaB.TryAcquire(ctx, "authelia/sync")
+	require.Error(t, err)
+	assert.IsType(t, errs.Conflict{}, err)
+	assert.Contains(t, err.Error(), "replica-a")
+
+	// renewing keeps it held past the first TTL
+	fakeClock.Advance(DefaultTTL - time.Second)
+	require.NoError(t, lk.Renew(ctx))
+	fakeClock.Advance(DefaultTTL - time.Second)
+	_, err = replicaB.TryAcquire(ctx, "authelia/sync")
+	require.Error(t, err)
+
+	require.NoError(t, lk.Release(ctx))
+	require.NoError(t, lk.Release(ctx), "releasing twice is a no-op")
+	assert.Zero(t, store.len())
+
+	_, err = replicaB.TryAcquire(ctx, "authelia/sync")
+	require.NoError(t, err)
+}
+
+func TestLocker_TakeOverExpired(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	fakeClock := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	replicaA := NewLocker(store, WithOwner("replica-a"), WithClock(fakeClock), WithTTL(time.Minute))
+	replicaB := NewLocker(store, WithOwner("replica-b"), WithClock(fakeClock), WithTTL(time.Minute))
+
+	stale, err := replicaA.TryAcquire(ctx, "authelia/reindex")
+	require.NoError(t, err)
+
+	fakeClock.Advance(time.Minute + time.Second)
+	current, err := replicaB.TryAcquire(ctx, "authelia/reindex")
+	require.NoError(t, err)
+
+	// the previous holder finds the lock lost and can't release the new holder's lock
+	err = stale.Renew(ctx)
+	require.Error(t, err)
+	assert.IsType(t, errs.Conflict{}, err)
+	require.NoError(t, stale.Release(ctx))
+	assert.Equal(t, 1, store.len())
+
+	require.NoError(t, current.Renew(ctx))
+}
+
+func TestLocker_Acquire(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+
+	replicaA := NewLocker(store, WithOwner("replica-a"))
+	replicaB := NewLocker(store, WithOwner("replica-b"))
+
+	lk, err := replicaA.TryAcquire(ctx, "job")
+	require.NoError(t, err)
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, 150*time.Millisecond)
+		defer cancel()
+		_, err := replicaB.Acquire(timeoutCtx, "job")
+		require.Error(t, err)
+		assert.IsType(t, errs.Conflict{}, err)
+	})
+
+	t.Run("waits for the release", func(t *testing.T) {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_ = lk.Release(ctx)
+		}()
+		timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, err := replicaB.Acquire(timeoutCtx, "job")
+		require.NoError(t, err)
+	})
+}
+
+func TestLock_Hold(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	locker := NewLocker(store, WithTTL(60*time.Millisecond))
+
+	lk, err := locker.TryAcquire(ctx, "job")
+	require.NoError(t, err)
+
+	heldCtx, release := lk.Hold(ctx)
+
+	// renewed in the background, longer than the TTL
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, heldCtx.Err())
+	_, err = NewLocker(store).TryAcquire(ctx, "job")
+	require.Error(t, err)
+
+	release()
+	release()
+	assert.Error(t, heldCtx.Err())
+	assert.Zero(t, store.len())
+}
+
+func TestLock_HoldLost(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	locker := NewLocker(store, WithTTL(30*time.Millisecond))
+
+	lk, err := locker.TryAcquire(ctx, "job")
+	require.NoError(t, err)
+	heldCtx, release := lk.Hold(ctx)
+	defer release()
+
+	// another replica took it over
+	require.NoError(t, store.Delete(ctx, key("job")))
+	_, err = NewLocker(store).TryAcquire(ctx, "job")
+	require.NoError(t, err)
+
+	select {
+	case <-heldCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the context of a lost lock must be cancelled")
+	}
+}
+
+func TestLocker_LockUsers(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+
+	replicaA := NewLocker(store)
+	replicaB := NewLocker(store)
+
+	unlock, err := replicaA.LockUsers(ctx, "auth0|jdoe", "auth0|jsmith", "auth0|jdoe")
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.len())
+
+	var locked atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		unlockB, errB := replicaB.LockUsers(ctx, "auth0|jsmith")
+		if errB == nil {
+			locked.Store(true)
+			unlockB()
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, locked.Load(), "the user is locked by the other replica")
+
+	unlock()
+	<-done
+	assert.True(t, locked.Load())
+	assert.Zero(t, store.len())
+}
+
+func TestLocker_Lead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := newFakeStore()
+
+	var running, maxRunning, runs atomic.Int32
+	job := func(jobCtx context.Context) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		runs.Add(1)
+		select {
+		case <-jobCtx.Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewLocker(store, WithTTL(30*time.Millisecond)).Lead(ctx, "authelia/stale-scan", job)
+		}()
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxRunning.Load(), "a single replica runs the job at a time")
+	assert.Greater(t, runs.Load(), int32(1), "the job is started again after it returns")
+	assert.Zero(t, store.len())
+}
@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package lock
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+const (
+	// resultBusy is an attempt finding the lock held by another replica
+	resultBusy = "busy"
+	// resultTakeover is an expired lock taken over from another replica
+	resultTakeover = "takeover"
+	// resultLost is a lock found taken over on renewal
+	resultLost = "lost"
+)
+
+// metrics records the contention on the locks, the instruments are nil when they can't be created
+type metrics struct {
+	contention metric.Int64Counter
+	wait       metric.Float64Histogram
+}
+
+func (m *metrics) contended(ctx context.Context, name, result string) {
+	if m == nil || m.contention == nil {
+		return
+	}
+	m.contention.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("lock", kind(name)),
+		attribute.String("result", result),
+	))
+}
+
+func (m *metrics) waited(ctx context.Context, name string, wait time.Duration) {
+	if m == nil || m.wait == nil {
+		return
+	}
+	m.wait.Record(ctx, wait.Seconds(), metric.WithAttributes(attribute.String("lock", kind(name))))
+}
+
+func newMetrics() *metrics {
+	meter := otel.Meter(constants.ServiceName)
+
+	contention, errCounter := meter.Int64Counter(
+		"auth_service.lock.contention",
+		metric.WithDescription("Number of lock attempts finding the lock held, taken over or lost, by result"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create lock contention counter", "error", errCounter)
+	}
+
+	wait, errHistogram := meter.Float64Histogram(
+		"auth_service.lock.wait",
+		metric.WithDescription("Time spent waiting to acquire a lock"),
+		metric.WithUnit("s"),
+	)
+	if errHistogram != nil {
+		slog.Warn("failed to create lock wait histogram", "error", errHistogram)
+	}
+
+	return &metrics{contention: contention, wait: wait}
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package lock
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// usersLockPrefix is the name prefix of the per-user locks
+const usersLockPrefix = "user/"
+
+// LockUsers locks the users across the replicas until the returned function is called,
+// it waits for the locks up to the lock TTL or until the context is done. The keys are
+// locked in order, so locking several users at once can't deadlock with another LockUsers.
+func (l *Locker) LockUsers(ctx context.Context, keys ...string) (func(), error) {
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		names = append(names, usersLockPrefix+k)
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	releases := make([]func(), 0, len(names))
+	unlock := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	acquireCtx, cancel := context.WithTimeout(ctx, l.ttl)
+	defer cancel()
+
+	for _, name := range names {
+		lk, err := l.Acquire(acquireCtx, name)
+		if err != nil {
+			unlock()
+			slog.WarnContext(ctx, "failed to lock user", "error", err)
+			return nil, err
+		}
+		// renewed until unlocked, even when the request context is done before
+		_, release := lk.Hold(context.WithoutCancel(ctx))
+		releases = append(releases, release)
+	}
+	return unlock, nil
+}
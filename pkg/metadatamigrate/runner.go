@@ -0,0 +1,282 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package metadatamigrate rewrites the metadata of every user of the identity provider when
+// the metadata fields are renamed or restructured. The users are read page by page from a
+// source, the transform returns the patch of each user and the patches are sent one by one,
+// backing off while the provider is rate limiting. The position of the next page is recorded
+// in a checkpoint after every page, so an interrupted run resumes where it stopped.
+package metadatamigrate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts of a patch failing with a retryable error
+	DefaultMaxAttempts = 5
+
+	// maxBackoff bounds the wait between the attempts of a patch
+	maxBackoff = 2 * time.Minute
+
+	// maxFailedUsers bounds the users recorded as failed in the checkpoint
+	maxFailedUsers = 100
+)
+
+// validName matches the names of the migrations, they are part of the checkpoint keys
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// User is a user read from the source, with its raw metadata
+type User struct {
+	UserID   string
+	Metadata map[string]any
+}
+
+// Source lists the users page by page, the position of the first page is empty and the
+// position of the next page is empty after the last one
+type Source interface {
+	ListUsersMetadata(ctx context.Context, position string) (users []User, next string, err error)
+}
+
+// Patcher patches the metadata of a user, a nil value removes the field
+type Patcher interface {
+	PatchUserMetadata(ctx context.Context, userID string, patch map[string]any) error
+}
+
+// Transform returns the patch of the metadata of a user, nil when the user needs no change.
+// The transform must be idempotent, the users of an interrupted page are transformed again.
+type Transform func(metadata map[string]any) (map[string]any, error)
+
+// Checkpoint is the progress of a migration
+type Checkpoint struct {
+	// Position is the position of the next page to migrate
+	Position string `json:"position,omitempty"`
+	// Scanned is the number of users read
+	Scanned int `json:"scanned"`
+	// Patched is the number of users patched, or to patch on a dry run
+	Patched int `json:"patched"`
+	// Failed is the number of users not patched
+	Failed int `json:"failed"`
+	// FailedUsers are the first users not patched, to migrate them again once the cause is fixed
+	FailedUsers []string `json:"failed_users,omitempty"`
+	// Done is set once the last page was migrated
+	Done      bool      `json:"done"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CheckpointStore records the progress of the migrations by name
+type CheckpointStore interface {
+	// Load returns the checkpoint of the migration, nil when it never ran
+	Load(ctx context.Context, name string) (*Checkpoint, error)
+	Save(ctx context.Context, name string, checkpoint *Checkpoint) error
+}
+
+// Option configures the Runner
+type Option func(*Runner)
+
+// WithDryRun counts the users to patch without patching them, the checkpoint isn't recorded
+func WithDryRun(dryRun bool) Option {
+	return func(r *Runner) {
+		r.dryRun = dryRun
+	}
+}
+
+// WithMaxAttempts sets the number of attempts of a patch failing with a retryable error
+func WithMaxAttempts(attempts int) Option {
+	return func(r *Runner) {
+		if attempts > 0 {
+			r.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff sets the first wait between the attempts of a patch, doubled on every attempt,
+// the wait hinted by the provider is honored when longer
+func WithBackoff(backoff time.Duration) Option {
+	return func(r *Runner) {
+		if backoff > 0 {
+			r.backoff = backoff
+		}
+	}
+}
+
+// Runner migrates the metadata of the users of a source
+type Runner struct {
+	name        string
+	source      Source
+	patcher     Patcher
+	store       CheckpointStore
+	transform   Transform
+	dryRun      bool
+	maxAttempts int
+	backoff     time.Duration
+	now         func() time.Time
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// sleep waits for the duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// patch sends the patch of the user, retrying the retryable errors with an exponential backoff
+func (r *Runner) patch(ctx context.Context, userID string, patch map[string]any) error {
+	wait := r.backoff
+	for attempt := 1; ; attempt++ {
+		err := r.patcher.PatchUserMetadata(ctx, userID, patch)
+		if err == nil {
+			return nil
+		}
+		retryAfter, retryable := errs.RetryAfter(err)
+		if !retryable || attempt >= r.maxAttempts {
+			return err
+		}
+
+		slog.WarnContext(ctx, "user metadata patch failed, backing off",
+			"error", err,
+			"user_id", redaction.Redact(userID),
+			"attempt", attempt,
+			"wait", max(wait, retryAfter),
+		)
+		if errSleep := r.sleep(ctx, max(wait, retryAfter)); errSleep != nil {
+			return errSleep
+		}
+		wait = min(wait*2, maxBackoff)
+	}
+}
+
+// migratePage transforms and patches the users of a page
+func (r *Runner) migratePage(ctx context.Context, users []User, checkpoint *Checkpoint) error {
+	for _, user := range users {
+		checkpoint.Scanned++
+
+		patch, errTransform := r.transform(user.Metadata)
+		if errTransform != nil {
+			slog.WarnContext(ctx, "failed to transform the user metadata", "error", errTransform, "user_id", redaction.Redact(user.UserID))
+			checkpoint.fail(user.UserID)
+			continue
+		}
+		if len(patch) == 0 {
+			continue
+		}
+		if r.dryRun {
+			checkpoint.Patched++
+			continue
+		}
+
+		if errPatch := r.patch(ctx, user.UserID, patch); errPatch != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.WarnContext(ctx, "failed to patch the user metadata", "error", errPatch, "user_id", redaction.Redact(user.UserID))
+			checkpoint.fail(user.UserID)
+			continue
+		}
+		checkpoint.Patched++
+	}
+	return nil
+}
+
+// fail records a user not patched
+func (c *Checkpoint) fail(userID string) {
+	c.Failed++
+	if len(c.FailedUsers) < maxFailedUsers {
+		c.FailedUsers = append(c.FailedUsers, userID)
+	}
+}
+
+// Run migrates the users from the checkpoint of the migration until the last page, or until
+// the context is done. A completed migration isn't run again, Reset starts it over.
+func (r *Runner) Run(ctx context.Context) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{}
+	if !r.dryRun {
+		loaded, errLoad := r.store.Load(ctx, r.name)
+		if errLoad != nil {
+			return nil, errLoad
+		}
+		if loaded != nil {
+			checkpoint = loaded
+		}
+	}
+	if checkpoint.Done {
+		return checkpoint, nil
+	}
+	if checkpoint.Position != "" {
+		slog.InfoContext(ctx, "resuming the metadata migration", "migration", r.name, "scanned", checkpoint.Scanned)
+	}
+
+	for {
+		users, next, errList := r.source.ListUsersMetadata(ctx, checkpoint.Position)
+		if errList != nil {
+			return checkpoint, errList
+		}
+
+		// the page is recorded once all its users are migrated, an interrupted page runs again
+		if errPage := r.migratePage(ctx, users, checkpoint); errPage != nil {
+			return checkpoint, errPage
+		}
+		checkpoint.Position = next
+		checkpoint.Done = next == ""
+		checkpoint.UpdatedAt = r.now().UTC()
+
+		if !r.dryRun {
+			if errSave := r.store.Save(ctx, r.name, checkpoint); errSave != nil {
+				return checkpoint, errSave
+			}
+		}
+		slog.InfoContext(ctx, "metadata migration page done",
+			"migration", r.name,
+			"scanned", checkpoint.Scanned,
+			"patched", checkpoint.Patched,
+			"failed", checkpoint.Failed,
+		)
+		if checkpoint.Done {
+			return checkpoint, nil
+		}
+	}
+}
+
+// Reset drops the checkpoint of the migration, the next run starts from the first user
+func (r *Runner) Reset(ctx context.Context) error {
+	return r.store.Save(ctx, r.name, &Checkpoint{UpdatedAt: r.now().UTC()})
+}
+
+// NewRunner creates the runner of the named migration, the name keys its checkpoint
+func NewRunner(name string, source Source, patcher Patcher, store CheckpointStore, transform Transform, opts ...Option) (*Runner, error) {
+	if !validName.MatchString(name) {
+		return nil, errs.NewValidation(fmt.Sprintf("invalid migration name %q, expected lowercase letters, digits, - and _", name))
+	}
+	if source == nil || patcher == nil || store == nil || transform == nil {
+		return nil, errs.NewValidation("the source, the patcher, the checkpoint store and the transform are required")
+	}
+
+	r := &Runner{
+		name:        name,
+		source:      source,
+		patcher:     patcher,
+		store:       store,
+		transform:   transform,
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     time.Second,
+		now:         time.Now,
+		sleep:       sleep,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
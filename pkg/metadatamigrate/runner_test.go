@@ -0,0 +1,248 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package metadatamigrate
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// fakeSource serves the users by pages of two, the position is the index of the page
+type fakeSource struct {
+	users []User
+	fail  map[string]bool
+}
+
+func (f *fakeSource) ListUsersMetadata(_ context.Context, position string) ([]User, string, error) {
+	if f.fail[position] {
+		return nil, "", errs.NewServiceUnavailable("provider unavailable")
+	}
+	start := 0
+	if position != "" {
+		start, _ = strconv.Atoi(position)
+	}
+	end := min(start+2, len(f.users))
+	next := ""
+	if end < len(f.users) {
+		next = strconv.Itoa(end)
+	}
+	return f.users[start:end], next, nil
+}
+
+// fakePatcher applies the patches, rate limiting the first attempts of the users in limited
+type fakePatcher struct {
+	users    map[string]map[string]any
+	limited  map[string]int
+	rejected map[string]bool
+	patches  int
+}
+
+func (f *fakePatcher) PatchUserMetadata(_ context.Context, userID string, patch map[string]any) error {
+	if f.limited[userID] > 0 {
+		f.limited[userID]--
+		return errs.NewTooManyRequests("rate limited").WithRetryAfter(3 * time.Second)
+	}
+	if f.rejected[userID] {
+		return errs.NewValidation("invalid metadata")
+	}
+	f.patches++
+	for key, value := range patch {
+		if value == nil {
+			delete(f.users[userID], key)
+			continue
+		}
+		f.users[userID][key] = value
+	}
+	return nil
+}
+
+type memoryStore struct {
+	checkpoints map[string]Checkpoint
+}
+
+func (m *memoryStore) Load(_ context.Context, name string) (*Checkpoint, error) {
+	checkpoint, ok := m.checkpoints[name]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (m *memoryStore) Save(_ context.Context, name string, checkpoint *Checkpoint) error {
+	m.checkpoints[name] = *checkpoint
+	return nil
+}
+
+func newFixtures() (*fakeSource, *fakePatcher) {
+	source := &fakeSource{}
+	patcher := &fakePatcher{users: make(map[string]map[string]any), limited: make(map[string]int), rejected: make(map[string]bool)}
+	for i := range 5 {
+		userID := "auth0|" + strconv.Itoa(i)
+		metadata := map[string]any{"job_title": "engineer"}
+		if i%2 == 0 {
+			metadata = map[string]any{"title": "engineer"}
+		}
+		source.users = append(source.users, User{UserID: userID, Metadata: metadata})
+		patcher.users[userID] = metadata
+	}
+	return source, patcher
+}
+
+func TestRunner_Run(t *testing.T) {
+	ctx := context.Background()
+	source, patcher := newFixtures()
+	patcher.limited["auth0|2"] = 2
+	patcher.rejected["auth0|4"] = true
+	store := &memoryStore{checkpoints: make(map[string]Checkpoint)}
+
+	var waits []time.Duration
+	runner, err := NewRunner("rename-title", source, patcher, store, RenameFields(map[string]string{"title": "job_title"}))
+	require.NoError(t, err)
+	runner.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	checkpoint, err := runner.Run(ctx)
+	require.NoError(t, err)
+	assert.True(t, checkpoint.Done)
+	assert.Equal(t, 5, checkpoint.Scanned)
+	assert.Equal(t, 2, checkpoint.Patched)
+	assert.Equal(t, 1, checkpoint.Failed)
+	assert.Equal(t, []string{"auth0|4"}, checkpoint.FailedUsers)
+	assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, waits, "the wait hinted by the provider is honored")
+	assert.Equal(t, map[string]any{"job_title": "engineer"}, patcher.users["auth0|0"])
+
+	checkpoint, err = runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, checkpoint.Scanned, "a completed migration isn't run again")
+
+	require.NoError(t, runner.Reset(ctx))
+	checkpoint, err = runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, checkpoint.Scanned)
+	assert.Equal(t, 0, checkpoint.Patched, "the transform is idempotent")
+}
+
+func TestRunner_Resume(t *testing.T) {
+	ctx := context.Background()
+	source, patcher := newFixtures()
+	source.fail = map[string]bool{"4": true}
+	store := &memoryStore{checkpoints: make(map[string]Checkpoint)}
+
+	runner, err := NewRunner("rename-title", source, patcher, store, RenameFields(map[string]string{"title": "job_title"}))
+	require.NoError(t, err)
+
+	checkpoint, err := runner.Run(ctx)
+	require.Error(t, err)
+	assert.False(t, checkpoint.Done)
+	assert.Equal(t, "4", store.checkpoints["rename-title"].Position)
+	assert.Equal(t, 4, store.checkpoints["rename-title"].Scanned)
+
+	source.fail = nil
+	checkpoint, err = runner.Run(ctx)
+	require.NoError(t, err)
+	assert.True(t, checkpoint.Done)
+	assert.Equal(t, 5, checkpoint.Scanned)
+	assert.Equal(t, 3, patcher.patches, "the pages already migrated are not read again")
+}
+
+func TestRunner_DryRun(t *testing.T) {
+	ctx := context.Background()
+	source, patcher := newFixtures()
+	store := &memoryStore{checkpoints: make(map[string]Checkpoint)}
+
+	runner, err := NewRunner("rename-title", source, patcher, store, RenameFields(map[string]string{"title": "job_title"}), WithDryRun(true))
+	require.NoError(t, err)
+
+	checkpoint, err := runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, checkpoint.Patched)
+	assert.Zero(t, patcher.patches)
+	assert.Empty(t, store.checkpoints)
+}
+
+func TestRunner_Backoff(t *testing.T) {
+	ctx := context.Background()
+	source, patcher := newFixtures()
+	patcher.limited["auth0|0"] = 10
+	store := &memoryStore{checkpoints: make(map[string]Checkpoint)}
+
+	runner, err := NewRunner("rename-title", source, patcher, store, RenameFields(map[string]string{"title": "job_title"}),
+		WithMaxAttempts(3),
+		WithBackoff(5*time.Second),
+	)
+	require.NoError(t, err)
+	var waits []time.Duration
+	runner.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	checkpoint, err := runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second}, waits)
+	assert.Equal(t, []string{"auth0|0"}, checkpoint.FailedUsers)
+
+	t.Run("interrupted while backing off", func(t *testing.T) {
+		source, patcher := newFixtures()
+		patcher.limited["auth0|0"] = 1
+		store := &memoryStore{checkpoints: make(map[string]Checkpoint)}
+		runner, err := NewRunner("rename-title", source, patcher, store, RenameFields(map[string]string{"title": "job_title"}))
+		require.NoError(t, err)
+		runner.sleep = func(context.Context, time.Duration) error { return context.Canceled }
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = runner.Run(cancelled)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Empty(t, store.checkpoints, "the interrupted page runs again")
+	})
+}
+
+func TestNewRunner_Validation(t *testing.T) {
+	source, patcher := newFixtures()
+	store := &memoryStore{}
+	transform := RenameFields(nil)
+
+	_, err := NewRunner("Rename Title", source, patcher, store, transform)
+	assert.Error(t, err)
+	_, err = NewRunner("rename-title", source, patcher, nil, transform)
+	assert.Error(t, err)
+}
+
+func TestParseRenames(t *testing.T) {
+	renames, err := ParseRenames(" title=job_title, org = organization ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"title": "job_title", "org": "organization"}, renames)
+
+	for _, value := range []string{"title", "title=", "=job_title", "title=title", "title=a,title=b"} {
+		_, err := ParseRenames(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	rename := RenameFields(map[string]string{"title": "job_title"})
+
+	patch, err := rename(map[string]any{"title": "engineer", "name": "Jane"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"job_title": "engineer", "title": nil}, patch)
+
+	patch, err = rename(map[string]any{"title": "old", "job_title": "engineer"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"title": nil}, patch, "the value under the new name is kept")
+
+	patch, err = rename(map[string]any{"job_title": "engineer"})
+	require.NoError(t, err)
+	assert.Nil(t, patch)
+}
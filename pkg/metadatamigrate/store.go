@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package metadatamigrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// checkpointKeyPrefix is the key prefix of the checkpoints, the migrations bucket also keeps
+// the version of the storage migrations
+const checkpointKeyPrefix = "metadata-migration."
+
+// KVStore is the subset of the NATS KV bucket used by the checkpoints
+type KVStore interface {
+	Get(ctx context.Context, key string) (jetstream.KeyValueEntry, error)
+	Put(ctx context.Context, key string, value []byte) (uint64, error)
+}
+
+type kvCheckpointStore struct {
+	kv KVStore
+}
+
+// Load returns the checkpoint of the migration, nil when it never ran
+func (s *kvCheckpointStore) Load(ctx context.Context, name string) (*Checkpoint, error) {
+	entry, err := s.kv.Get(ctx, checkpointKeyPrefix+name)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewUnexpected("failed to get the migration checkpoint", err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(entry.Value(), &checkpoint); err != nil {
+		return nil, errs.NewUnexpected("invalid migration checkpoint", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save records the checkpoint of the migration
+func (s *kvCheckpointStore) Save(ctx context.Context, name string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errs.NewUnexpected("failed to marshal the migration checkpoint", err)
+	}
+	if _, err := s.kv.Put(ctx, checkpointKeyPrefix+name, data); err != nil {
+		return errs.NewUnexpected("failed to record the migration checkpoint", err)
+	}
+	return nil
+}
+
+// NewKVCheckpointStore creates the checkpoint store on the KV bucket
+func NewKVCheckpointStore(kv KVStore) CheckpointStore {
+	return &kvCheckpointStore{kv: kv}
+}
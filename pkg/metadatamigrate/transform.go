@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package metadatamigrate
+
+import (
+	"fmt"
+	"strings"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// ParseRenames parses the comma separated field renames, in the form old=new
+func ParseRenames(value string) (map[string]string, error) {
+	renames := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(entry, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" || from == to {
+			return nil, errs.NewValidation(fmt.Sprintf("invalid rename %q, expected old=new", entry))
+		}
+		if _, exists := renames[from]; exists {
+			return nil, errs.NewValidation(fmt.Sprintf("duplicate rename of %s", from))
+		}
+		renames[from] = to
+	}
+	return renames, nil
+}
+
+// RenameFields moves the values of the renamed fields to their new name. A value already set
+// under the new name is kept, the old field is removed in every case, so the transform is
+// idempotent.
+func RenameFields(renames map[string]string) Transform {
+	return func(metadata map[string]any) (map[string]any, error) {
+		patch := make(map[string]any)
+		for from, to := range renames {
+			value, ok := metadata[from]
+			if !ok {
+				continue
+			}
+			if current, set := metadata[to]; !set || current == nil {
+				patch[to] = value
+			}
+			patch[from] = nil
+		}
+		if len(patch) == 0 {
+			return nil, nil
+		}
+		return patch, nil
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package migrate runs the versioned migrations provisioning the storage of the service at
+// startup. The migrations are applied in version order, the version of the last one applied is
+// recorded in a version store so each migration runs once per deployment. The runs of the
+// replicas are serialized by a lock, the migrations still have to be idempotent since a replica
+// can stop between a migration and the record of its version.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+// Migration is a step of the storage provisioning
+type Migration struct {
+	// Version orders the migrations, it must be unique and above zero
+	Version int
+	// Name describes the migration in the logs
+	Name string
+	Up   func(ctx context.Context) error
+}
+
+// VersionStore records the version of the last migration applied, zero when none was
+type VersionStore interface {
+	Version(ctx context.Context) (int, error)
+	SetVersion(ctx context.Context, version int) error
+}
+
+// LockFunc acquires the lock serializing the runs, it returns the context of the run, done when
+// the lock is lost, and the release of the lock
+type LockFunc func(ctx context.Context) (context.Context, func(), error)
+
+// Option configures the Runner
+type Option func(*Runner)
+
+// WithLock sets the lock serializing the runs of the replicas
+func WithLock(lock LockFunc) Option {
+	return func(r *Runner) {
+		if lock != nil {
+			r.lock = lock
+		}
+	}
+}
+
+// Runner applies the pending migrations
+type Runner struct {
+	store      VersionStore
+	migrations []Migration
+	lock       LockFunc
+}
+
+// NewRunner creates a runner of the migrations, recording the versions applied in the store
+func NewRunner(store VersionStore, migrations []Migration, opts ...Option) *Runner {
+	r := &Runner{
+		store:      store,
+		migrations: slices.Clone(migrations),
+		lock: func(ctx context.Context) (context.Context, func(), error) {
+			return ctx, func() {}, nil
+		},
+	}
+	slices.SortFunc(r.migrations, func(a, b Migration) int {
+		return a.Version - b.Version
+	})
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// validate checks the versions are unique and above zero
+func (r *Runner) validate() error {
+	for i, migration := range r.migrations {
+		if migration.Version <= 0 {
+			return errs.NewValidation(fmt.Sprintf("invalid version %d of migration %s", migration.Version, migration.Name))
+		}
+		if i > 0 && r.migrations[i-1].Version == migration.Version {
+			return errs.NewValidation(fmt.Sprintf("duplicate migration version %d", migration.Version))
+		}
+	}
+	return nil
+}
+
+// Run applies the migrations above the recorded version and returns how many were applied. It
+// stops at the first failure, the migrations applied before it stay recorded.
+func (r *Runner) Run(ctx context.Context) (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+
+	ctx, release, errLock := r.lock(ctx)
+	if errLock != nil {
+		return 0, errLock
+	}
+	defer release()
+
+	current, errVersion := r.store.Version(ctx)
+	if errVersion != nil {
+		return 0, errVersion
+	}
+
+	applied := 0
+	for _, migration := range r.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return applied, errs.NewUnexpected("migrations interrupted", err)
+		}
+
+		slog.InfoContext(ctx, "applying migration",
+			"version", migration.Version,
+			"migration", migration.Name,
+		)
+		if err := migration.Up(ctx); err != nil {
+			return applied, errs.NewUnexpected(fmt.Sprintf("migration %d %s failed", migration.Version, migration.Name), err)
+		}
+		if err := r.store.SetVersion(ctx, migration.Version); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+
+	if applied > 0 {
+		slog.InfoContext(ctx, "migrations applied",
+			"applied", applied,
+			"from_version", current,
+			"to_version", r.migrations[len(r.migrations)-1].Version,
+		)
+	}
+	return applied, nil
+}
@@ -0,0 +1,156 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	errs "github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryVersionStore is an in-memory VersionStore
+type memoryVersionStore struct {
+	version int
+}
+
+func (s *memoryVersionStore) Version(context.Context) (int, error) {
+	return s.version, nil
+}
+
+func (s *memoryVersionStore) SetVersion(_ context.Context, version int) error {
+	s.version = version
+	return nil
+}
+
+func TestRunner_Run(t *testing.T) {
+	ctx := context.Background()
+
+	var ran []int
+	migration := func(version int, err error) Migration {
+		return Migration{
+			Version: version,
+			Name:    "migration",
+			Up: func(context.Context) error {
+				ran = append(ran, version)
+				return err
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		current     int
+		migrations  []Migration
+		wantRan     []int
+		wantApplied int
+		wantVersion int
+		wantErr     bool
+	}{
+		{
+			name:        "applies the migrations in version order",
+			migrations:  []Migration{migration(2, nil), migration(1, nil), migration(3, nil)},
+			wantRan:     []int{1, 2, 3},
+			wantApplied: 3,
+			wantVersion: 3,
+		},
+		{
+			name:        "skips the migrations already applied",
+			current:     2,
+			migrations:  []Migration{migration(1, nil), migration(2, nil), migration(3, nil)},
+			wantRan:     []int{3},
+			wantApplied: 1,
+			wantVersion: 3,
+		},
+		{
+			name:        "nothing to apply",
+			current:     3,
+			migrations:  []Migration{migration(1, nil), migration(3, nil)},
+			wantVersion: 3,
+		},
+		{
+			name:        "stops at the first failure",
+			migrations:  []Migration{migration(1, nil), migration(2, errors.New("boom")), migration(3, nil)},
+			wantRan:     []int{1, 2},
+			wantApplied: 1,
+			wantVersion: 1,
+			wantErr:     true,
+		},
+		{
+			name:       "rejects duplicate versions",
+			migrations: []Migration{migration(1, nil), migration(1, nil)},
+			wantErr:    true,
+		},
+		{
+			name:       "rejects versions below one",
+			migrations: []Migration{migration(0, nil)},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran = nil
+			store := &memoryVersionStore{version: tt.current}
+
+			applied, err := NewRunner(store, tt.migrations).Run(ctx)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRan, ran)
+			assert.Equal(t, tt.wantApplied, applied)
+			assert.Equal(t, tt.wantVersion, store.version)
+		})
+	}
+}
+
+func TestRunner_Lock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs the migrations holding the lock", func(t *testing.T) {
+		held, released := false, false
+		store := &memoryVersionStore{}
+		runner := NewRunner(store, []Migration{{
+			Version: 1,
+			Name:    "create bucket",
+			Up: func(context.Context) error {
+				assert.True(t, held, "the lock is held")
+				assert.False(t, released, "the lock is not released yet")
+				return nil
+			},
+		}}, WithLock(func(ctx context.Context) (context.Context, func(), error) {
+			held = true
+			return ctx, func() { released = true }, nil
+		}))
+
+		applied, err := runner.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.True(t, released)
+	})
+
+	t.Run("fails when the lock can't be acquired", func(t *testing.T) {
+		store := &memoryVersionStore{}
+		runner := NewRunner(store, []Migration{{
+			Version: 1,
+			Name:    "create bucket",
+			Up: func(context.Context) error {
+				t.Error("the migration must not run")
+				return nil
+			},
+		}}, WithLock(func(ctx context.Context) (context.Context, func(), error) {
+			return nil, nil, errs.NewConflict("lock held by replica-b")
+		}))
+
+		_, err := runner.Run(ctx)
+		require.Error(t, err)
+		assert.IsType(t, errs.Conflict{}, err)
+		assert.Zero(t, store.version)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package oidc verifies the tokens of any OpenID Connect compliant issuer, the signing keys are
+// discovered from the provider metadata (/.well-known/openid-configuration) of each issuer.
+package oidc
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// discoveryPath is the path of the provider metadata, relative to the issuer
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Issuer is a trusted token issuer
+type Issuer struct {
+	// URL is the issuer identifier, it must match the iss claim of the tokens exactly
+	// (including the trailing slash, if any)
+	URL string
+	// Audiences are the audiences accepted, the token must carry one of them. The audience
+	// isn't checked when empty.
+	Audiences []string
+}
+
+// discoveryURL returns the URL of the provider metadata of the issuer
+func (i Issuer) discoveryURL() string {
+	return strings.TrimSuffix(i.URL, "/") + discoveryPath
+}
+
+// validate checks the issuer is an absolute https URL, http is only accepted for the
+// loopback addresses
+func (i Issuer) validate() error {
+	parsed, err := url.Parse(i.URL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid issuer %q, expected an absolute URL", i.URL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("invalid issuer %q, the issuer can't have a query or a fragment", i.URL)
+	}
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		if host := parsed.Hostname(); host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return fmt.Errorf("invalid issuer %q, https is required", i.URL)
+		}
+	default:
+		return fmt.Errorf("invalid issuer %q, https is required", i.URL)
+	}
+	return nil
+}
+
+// ParseIssuers parses the comma separated trusted issuers, in the form issuer[=audience[|audience]],
+// e.g. https://sso.example.org/=https://api.example.org/,https://accounts.example.com
+func ParseIssuers(value string) ([]Issuer, error) {
+	var issuers []Issuer
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		issuerURL, audiences, _ := strings.Cut(entry, "=")
+		issuer := Issuer{URL: strings.TrimSpace(issuerURL)}
+		for _, audience := range strings.Split(audiences, "|") {
+			if audience = strings.TrimSpace(audience); audience != "" {
+				issuer.Audiences = append(issuer.Audiences, audience)
+			}
+		}
+		if err := issuer.validate(); err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(issuers, func(existing Issuer) bool { return existing.URL == issuer.URL }) {
+			return nil, fmt.Errorf("duplicate issuer %q", issuer.URL)
+		}
+		issuers = append(issuers, issuer)
+	}
+	return issuers, nil
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssuers(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []Issuer
+		wantErr  string
+	}{
+		{name: "empty"},
+		{
+			name:  "issuers with and without audiences",
+			value: "https://sso.example.org/=https://api.example.org/|https://other.example.org/, https://accounts.example.com",
+			expected: []Issuer{
+				{URL: "https://sso.example.org/", Audiences: []string{"https://api.example.org/", "https://other.example.org/"}},
+				{URL: "https://accounts.example.com"},
+			},
+		},
+		{name: "loopback over http", value: "http://localhost:9091", expected: []Issuer{{URL: "http://localhost:9091"}}},
+		{name: "plain http", value: "http://sso.example.org/", wantErr: "https is required"},
+		{name: "relative URL", value: "sso.example.org", wantErr: "expected an absolute URL"},
+		{name: "query", value: "https://sso.example.org/?tenant=a", wantErr: "query or a fragment"},
+		{name: "duplicate", value: "https://sso.example.org/,https://sso.example.org/=aud", wantErr: "duplicate issuer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuers, err := ParseIssuers(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, issuers)
+		})
+	}
+}
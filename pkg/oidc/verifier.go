@@ -0,0 +1,364 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	jwtparser "github.com/linuxfoundation/lfx-v2-auth-service/pkg/jwt"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/redaction"
+)
+
+// DefaultMinRefreshInterval is the shortest interval between two JWKS fetches of an issuer, a
+// token signed with an unknown key refreshes the JWKS (key rotation) at most once per interval
+const DefaultMinRefreshInterval = time.Minute
+
+// providerMetadata is the subset of the OpenID provider metadata used by the verifier
+type providerMetadata struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// signingKey is a key of the JWKS of an issuer with the algorithm it signs with
+type signingKey struct {
+	key       crypto.PublicKey
+	algorithm jwa.SignatureAlgorithm
+}
+
+// trustedIssuer holds the signing keys of an issuer, refreshed when a token carries an unknown kid
+type trustedIssuer struct {
+	config  Issuer
+	jwksURI string
+
+	mu        sync.Mutex
+	keys      map[string]signingKey
+	fetchedAt time.Time
+}
+
+// Verifier verifies the tokens of several trusted issuers, the issuer of a token is picked by its
+// iss claim and the signing key by its kid
+type Verifier struct {
+	httpClient         *httpclient.Client
+	issuers            map[string]*trustedIssuer
+	minRefreshInterval time.Duration
+	clock              clock.Clock
+}
+
+// Option configures the Verifier
+type Option func(*Verifier)
+
+// WithClock sets the time source of the expiration checks and of the JWKS refreshes
+func WithClock(c clock.Clock) Option {
+	return func(v *Verifier) {
+		v.clock = c
+	}
+}
+
+// WithMinRefreshInterval sets the shortest interval between two JWKS fetches of an issuer
+func WithMinRefreshInterval(interval time.Duration) Option {
+	return func(v *Verifier) {
+		v.minRefreshInterval = interval
+	}
+}
+
+// Verify verifies the token signature, issuer, audience (when the issuer has some), expiration and
+// the required scopes
+func (v *Verifier) Verify(ctx context.Context, token string, requiredScopes ...string) (*jwtparser.Claims, error) {
+	if v == nil {
+		return nil, errors.NewValidation("JWT verification configuration is required")
+	}
+
+	cleanToken, isJWT := jwtparser.LooksLikeJWT(token)
+	if !isJWT {
+		return nil, errors.NewValidation("invalid token format")
+	}
+	unverified, errParse := jwtparser.ParseUnverified(ctx, cleanToken, &jwtparser.ParseOptions{})
+	if errParse != nil {
+		return nil, errParse
+	}
+	issuer, trusted := v.issuers[unverified.Issuer]
+	if !trusted {
+		slog.WarnContext(ctx, "token of an untrusted issuer", "issuer", unverified.Issuer)
+		return nil, errors.NewUnauthorized("untrusted token issuer")
+	}
+
+	kid, errKid := keyID(cleanToken)
+	if errKid != nil {
+		return nil, errKid
+	}
+	key, errKey := v.signingKey(ctx, issuer, kid)
+	if errKey != nil {
+		return nil, errKey
+	}
+
+	claims, err := jwtparser.ParseVerified(ctx, cleanToken, &jwtparser.ParseOptions{
+		RequireExpiration: true,
+		RequireSubject:    true,
+		VerifySignature:   true,
+		SigningKey:        key.key,
+		AllowedAlgorithms: []jwa.SignatureAlgorithm{key.algorithm},
+		ExpectedIssuer:    issuer.config.URL,
+		ExpectedAudiences: issuer.config.Audiences,
+		RequiredScopes:    requiredScopes,
+		Clock:             v.clock,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "JWT signature verification failed",
+			"error", err,
+			"issuer", issuer.config.URL,
+			"required_scope", requiredScopes,
+		)
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "JWT signature verification successful",
+		"user_id", redaction.Redact(claims.Subject),
+		"issuer", claims.Issuer,
+		"required_scope", requiredScopes,
+	)
+	return claims, nil
+}
+
+// Issuers returns the URLs of the trusted issuers
+func (v *Verifier) Issuers() []string {
+	issuers := make([]string, 0, len(v.issuers))
+	for url := range v.issuers {
+		issuers = append(issuers, url)
+	}
+	return issuers
+}
+
+// Refresh fetches the JWKS of every issuer now, regardless of the minimum refresh interval, and
+// returns the number of signing keys loaded. The keys of an issuer failing to be fetched are kept.
+func (v *Verifier) Refresh(ctx context.Context) (int, error) {
+	loaded := 0
+	var firstErr error
+	for _, issuer := range v.issuers {
+		issuer.mu.Lock()
+		keys, err := v.fetchKeys(ctx, issuer.jwksURI)
+		if err == nil {
+			issuer.keys = keys
+			issuer.fetchedAt = v.clock.Now()
+		}
+		loaded += len(issuer.keys)
+		issuer.mu.Unlock()
+
+		if err != nil {
+			slog.WarnContext(ctx, "failed to refresh the JWKS", "issuer", issuer.config.URL, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		slog.InfoContext(ctx, "JWKS refreshed", "issuer", issuer.config.URL, "keys", len(keys))
+	}
+	return loaded, firstErr
+}
+
+// keyID returns the kid of the token header, empty when the token has none
+func keyID(token string) (string, error) {
+	message, err := jws.Parse([]byte(token))
+	if err != nil || len(message.Signatures()) != 1 {
+		return "", errors.NewValidation("invalid token format")
+	}
+	return message.Signatures()[0].ProtectedHeaders().KeyID(), nil
+}
+
+// signingKey returns the key of the kid, the JWKS is fetched again when the kid is unknown and the
+// last fetch is older than the minimum refresh interval. A token without kid is accepted when the
+// issuer publishes a single key.
+func (v *Verifier) signingKey(ctx context.Context, issuer *trustedIssuer, kid string) (signingKey, error) {
+	issuer.mu.Lock()
+	defer issuer.mu.Unlock()
+
+	if key, found := issuer.lookup(kid); found {
+		return key, nil
+	}
+
+	if v.clock.Now().Sub(issuer.fetchedAt) >= v.minRefreshInterval {
+		slog.InfoContext(ctx, "unknown JWT signing key, refreshing the JWKS",
+			"issuer", issuer.config.URL,
+			"key_id", kid,
+		)
+		keys, err := v.fetchKeys(ctx, issuer.jwksURI)
+		if err != nil {
+			// the current keys are kept, the next unknown kid retries after the interval
+			slog.WarnContext(ctx, "failed to refresh the JWKS", "issuer", issuer.config.URL, "error", err)
+		} else {
+			issuer.keys = keys
+		}
+		issuer.fetchedAt = v.clock.Now()
+
+		if key, found := issuer.lookup(kid); found {
+			return key, nil
+		}
+	}
+
+	slog.WarnContext(ctx, "unknown JWT signing key", "issuer", issuer.config.URL, "key_id", kid)
+	return signingKey{}, errors.NewUnauthorized("unknown token signing key")
+}
+
+// lookup returns the key of the kid, the caller holds the lock
+func (i *trustedIssuer) lookup(kid string) (signingKey, bool) {
+	if kid == "" && len(i.keys) == 1 {
+		for _, key := range i.keys {
+			return key, true
+		}
+	}
+	key, found := i.keys[kid]
+	return key, found
+}
+
+// discover fetches the provider metadata of the issuer, the issuer of the metadata must be the
+// configured one (OpenID Connect Discovery 1.0, section 4.3)
+func (v *Verifier) discover(ctx context.Context, issuer Issuer) (*providerMetadata, error) {
+	apiRequest := httpclient.NewAPIRequest(
+		v.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(issuer.discoveryURL()),
+		httpclient.WithDescription("fetch OpenID provider metadata"),
+	)
+
+	var metadata providerMetadata
+	if _, err := apiRequest.Call(ctx, &metadata); err != nil {
+		return nil, errors.NewUnexpected(fmt.Sprintf("failed to fetch the provider metadata of %s", issuer.URL), err)
+	}
+	if metadata.Issuer != issuer.URL {
+		return nil, errors.NewUnexpected(fmt.Sprintf("the provider metadata of %s is for the issuer %q", issuer.URL, metadata.Issuer))
+	}
+	if metadata.JWKSURI == "" {
+		return nil, errors.NewUnexpected(fmt.Sprintf("the provider metadata of %s has no jwks_uri", issuer.URL))
+	}
+	return &metadata, nil
+}
+
+// fetchKeys fetches the signing keys of the JWKS, the encryption keys and the keys of unsupported
+// types are skipped
+func (v *Verifier) fetchKeys(ctx context.Context, jwksURI string) (map[string]signingKey, error) {
+	apiRequest := httpclient.NewAPIRequest(
+		v.httpClient,
+		httpclient.WithMethod(http.MethodGet),
+		httpclient.WithURL(jwksURI),
+		httpclient.WithDescription("fetch OIDC JWKS"),
+	)
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if _, err := apiRequest.Call(ctx, &jwks); err != nil {
+		return nil, errors.NewUnexpected("failed to fetch JWKS", err)
+	}
+
+	keys := make(map[string]signingKey, len(jwks.Keys))
+	for _, rawKey := range jwks.Keys {
+		var header struct {
+			Kid string `json:"kid"`
+			Use string `json:"use,omitempty"`
+			Alg string `json:"alg,omitempty"`
+		}
+		if err := json.Unmarshal(rawKey, &header); err != nil || (header.Use != "" && header.Use != "sig") {
+			continue
+		}
+		publicKey, err := jwtparser.LoadPublicKeyFromJWK(rawKey)
+		if err != nil {
+			slog.DebugContext(ctx, "skipping JWK", "key_id", header.Kid, "error", err)
+			continue
+		}
+		algorithm := jwa.SignatureAlgorithm(header.Alg)
+		if header.Alg == "" {
+			algorithm = defaultAlgorithm(publicKey)
+		}
+		keys[header.Kid] = signingKey{key: publicKey, algorithm: algorithm}
+	}
+	if len(keys) == 0 {
+		return nil, errors.NewUnexpected(fmt.Sprintf("no signing key found in the JWKS %s", jwksURI))
+	}
+	return keys, nil
+}
+
+// defaultAlgorithm returns the algorithm of a key published without alg
+func defaultAlgorithm(key crypto.PublicKey) jwa.SignatureAlgorithm {
+	switch publicKey := key.(type) {
+	case *rsa.PublicKey:
+		return jwa.RS256
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve.Params().BitSize {
+		case 384:
+			return jwa.ES384
+		case 521:
+			return jwa.ES512
+		default:
+			return jwa.ES256
+		}
+	case ed25519.PublicKey:
+		return jwa.EdDSA
+	default:
+		return jwa.RS256
+	}
+}
+
+// NewVerifier discovers the trusted issuers and loads their signing keys, it fails when one of
+// them can't be discovered
+func NewVerifier(ctx context.Context, httpClient *httpclient.Client, issuers []Issuer, opts ...Option) (*Verifier, error) {
+	if len(issuers) == 0 {
+		return nil, errors.NewValidation("at least one trusted issuer is required")
+	}
+
+	v := &Verifier{
+		httpClient:         httpClient,
+		issuers:            make(map[string]*trustedIssuer, len(issuers)),
+		minRefreshInterval: DefaultMinRefreshInterval,
+		clock:              clock.System,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	for _, issuer := range issuers {
+		if err := issuer.validate(); err != nil {
+			return nil, errors.NewValidation(err.Error())
+		}
+		if _, exists := v.issuers[issuer.URL]; exists {
+			return nil, errors.NewValidation(fmt.Sprintf("duplicate issuer %q", issuer.URL))
+		}
+
+		metadata, err := v.discover(ctx, issuer)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := v.fetchKeys(ctx, metadata.JWKSURI)
+		if err != nil {
+			return nil, err
+		}
+		v.issuers[issuer.URL] = &trustedIssuer{
+			config:    issuer,
+			jwksURI:   metadata.JWKSURI,
+			keys:      keys,
+			fetchedAt: v.clock.Now(),
+		}
+
+		slog.InfoContext(ctx, "OIDC issuer trusted",
+			"issuer", issuer.URL,
+			"audiences", issuer.Audiences,
+			"jwks_uri", metadata.JWKSURI,
+			"keys", len(keys),
+		)
+	}
+	return v, nil
+}
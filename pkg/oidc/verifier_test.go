@@ -0,0 +1,272 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider serves the provider metadata and the JWKS of an issuer under its path
+type fakeProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	path   string
+	// metadataIssuer overrides the issuer of the provider metadata
+	metadataIssuer string
+
+	mu          sync.Mutex
+	keys        map[string]crypto.Signer
+	jwksFetches atomic.Int32
+}
+
+func newFakeProvider(t *testing.T, server *httptest.Server, mux *http.ServeMux, path string) *fakeProvider {
+	t.Helper()
+	provider := &fakeProvider{t: t, server: server, path: path, keys: map[string]crypto.Signer{}}
+	mux.HandleFunc("GET "+path+discoveryPath, provider.metadata)
+	mux.HandleFunc("GET "+path+"/jwks.json", provider.jwks)
+	return provider
+}
+
+func (p *fakeProvider) issuer() string {
+	return p.server.URL + p.path + "/"
+}
+
+func (p *fakeProvider) metadata(w http.ResponseWriter, _ *http.Request) {
+	issuer := p.issuer()
+	if p.metadataIssuer != "" {
+		issuer = p.metadataIssuer
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"issuer":   issuer,
+		"jwks_uri": p.server.URL + p.path + "/jwks.json",
+	})
+}
+
+func (p *fakeProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	p.jwksFetches.Add(1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := []any{}
+	for kid, signer := range p.keys {
+		key, err := jwk.FromRaw(signer.Public())
+		require.NoError(p.t, err)
+		require.NoError(p.t, key.Set(jwk.KeyIDKey, kid))
+		keys = append(keys, key)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+}
+
+// addKey adds an RSA key to the JWKS
+func (p *fakeProvider) addKey(kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(p.t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[kid] = key
+}
+
+// addECKey adds a P-256 key to the JWKS
+func (p *fakeProvider) addECKey(kid string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[kid] = key
+}
+
+// token signs the claims with the key of the kid, the issuer claim defaults to the provider one
+func (p *fakeProvider) token(kid string, claims jwt.MapClaims) string {
+	p.mu.Lock()
+	signer := p.keys[kid]
+	p.mu.Unlock()
+
+	full := jwt.MapClaims{
+		"iss": p.issuer(),
+		"sub": "user-1",
+		"aud": "https://api.example.org/",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		full[name] = value
+	}
+
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if _, isEC := signer.(*ecdsa.PrivateKey); isEC {
+		method = jwt.SigningMethodES256
+	}
+	token := jwt.NewWithClaims(method, full)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(signer)
+	require.NoError(p.t, err)
+	return signed
+}
+
+// newTestProviders starts a server with two issuers, tenant-a (RSA) and tenant-b (EC)
+func newTestProviders(t *testing.T) (*fakeProvider, *fakeProvider) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	providerA := newFakeProvider(t, server, mux, "/tenant-a")
+	providerA.addKey("a-1")
+	providerB := newFakeProvider(t, server, mux, "/tenant-b")
+	providerB.addECKey("b-1")
+	return providerA, providerB
+}
+
+func testHTTPClient() *httpclient.Client {
+	httpConfig := httpclient.DefaultConfig()
+	httpConfig.MaxRetries = 0
+	return httpclient.NewClient(httpConfig)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	providerA, providerB := newTestProviders(t)
+	ctx := context.Background()
+
+	verifier, err := NewVerifier(ctx, testHTTPClient(), []Issuer{
+		{URL: providerA.issuer(), Audiences: []string{"https://api.example.org/"}},
+		{URL: providerB.issuer()},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{providerA.issuer(), providerB.issuer()}, verifier.Issuers())
+
+	tests := []struct {
+		name    string
+		token   string
+		scopes  []string
+		wantErr bool
+	}{
+		{name: "RSA token of the first issuer", token: providerA.token("a-1", nil)},
+		{name: "EC token of the second issuer", token: providerB.token("b-1", jwt.MapClaims{"aud": "anything"})},
+		{name: "bearer prefix", token: "Bearer " + providerA.token("a-1", nil)},
+		{name: "required scope", token: providerA.token("a-1", jwt.MapClaims{"scope": "openid update:current_user_metadata"}), scopes: []string{"update:current_user_metadata"}},
+		{name: "missing scope", token: providerA.token("a-1", nil), scopes: []string{"update:current_user_metadata"}, wantErr: true},
+		{name: "untrusted issuer", token: providerA.token("a-1", jwt.MapClaims{"iss": "https://evil.example.com/"}), wantErr: true},
+		{name: "issuer without the trailing slash", token: providerA.token("a-1", jwt.MapClaims{"iss": providerA.server.URL + "/tenant-a"}), wantErr: true},
+		{name: "wrong audience", token: providerA.token("a-1", jwt.MapClaims{"aud": "https://other.example.org/"}), wantErr: true},
+		{name: "key of another issuer", token: providerB.token("b-1", jwt.MapClaims{"iss": providerA.issuer()}), wantErr: true},
+		{name: "expired", token: providerA.token("a-1", jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}), wantErr: true},
+		{name: "not a JWT", token: "opaque-token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(ctx, tt.token, tt.scopes...)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.Subject)
+		})
+	}
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	providerA, _ := newTestProviders(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Now())
+
+	verifier, err := NewVerifier(ctx, testHTTPClient(), []Issuer{{URL: providerA.issuer()}}, WithClock(fake))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, providerA.jwksFetches.Load())
+
+	// the rotated key is unknown until the refresh interval elapsed
+	providerA.addKey("a-2")
+	_, err = verifier.Verify(ctx, providerA.token("a-2", nil))
+	assert.IsType(t, errors.Unauthorized{}, err)
+	assert.EqualValues(t, 1, providerA.jwksFetches.Load(), "the JWKS was just fetched")
+
+	fake.Advance(DefaultMinRefreshInterval)
+	claims, err := verifier.Verify(ctx, providerA.token("a-2", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.EqualValues(t, 2, providerA.jwksFetches.Load())
+
+	// the unknown kids don't refetch the JWKS on every token
+	providerA.addKey("a-3")
+	for range 5 {
+		_, err = verifier.Verify(ctx, providerA.token("a-3", nil))
+		assert.IsType(t, errors.Unauthorized{}, err)
+	}
+	assert.EqualValues(t, 2, providerA.jwksFetches.Load())
+}
+
+func TestNewVerifier(t *testing.T) {
+	providerA, providerB := newTestProviders(t)
+	providerB.metadataIssuer = "https://impostor.example.com/"
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		issuers []Issuer
+		wantErr string
+	}{
+		{name: "no issuer", wantErr: "at least one trusted issuer is required"},
+		{name: "metadata of another issuer", issuers: []Issuer{{URL: providerB.issuer()}}, wantErr: "is for the issuer"},
+		{name: "unknown issuer", issuers: []Issuer{{URL: providerA.server.URL + "/missing/"}}, wantErr: "failed to fetch the provider metadata"},
+		{name: "duplicate issuer", issuers: []Issuer{{URL: providerA.issuer()}, {URL: providerA.issuer()}}, wantErr: "duplicate issuer"},
+		{name: "plain http", issuers: []Issuer{{URL: "http://sso.example.org/"}}, wantErr: "https is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVerifier(ctx, testHTTPClient(), tt.issuers)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifier_Refresh(t *testing.T) {
+	providerA, providerB := newTestProviders(t)
+	ctx := context.Background()
+	fake := clock.NewFake(time.Now())
+
+	verifier, err := NewVerifier(ctx, testHTTPClient(), []Issuer{{URL: providerA.issuer()}, {URL: providerB.issuer()}}, WithClock(fake))
+	require.NoError(t, err)
+
+	// the refresh doesn't wait for the minimum refresh interval
+	providerA.addKey("a-2")
+	loaded, err := verifier.Refresh(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded)
+	assert.EqualValues(t, 2, providerA.jwksFetches.Load())
+	_, err = verifier.Verify(ctx, providerA.token("a-2", nil))
+	require.NoError(t, err)
+
+	// the keys of an issuer failing to be fetched are kept
+	token := providerB.token("b-1", nil)
+	providerB.mu.Lock()
+	clear(providerB.keys)
+	providerB.mu.Unlock()
+	loaded, err = verifier.Refresh(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 3, loaded)
+	_, err = verifier.Verify(ctx, token)
+	assert.NoError(t, err)
+}
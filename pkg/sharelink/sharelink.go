@@ -0,0 +1,167 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package sharelink provides the signed tokens of the profile share links, granting read access to
+// the public view of a profile outside authenticated contexts, e.g. a speaker profile on an event page.
+//
+// A token carries the subject of the profile and its expiration, signed with HMAC-SHA256 so it can't
+// be forged or extended. Tokens are URL safe, they are the last segment of the share link. The tokens
+// signed with rotated keys carry the ID of their key, see keyring.Manager.
+package sharelink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+const (
+	// MinKeySize is the minimum size of the signing key
+	MinKeySize = 32
+
+	// DefaultTTL is how long a link is valid when the caller doesn't ask for a duration
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultMaxTTL is the longest validity a caller can ask for
+	DefaultMaxTTL = 7 * 24 * time.Hour
+)
+
+// kind tells the share link tokens apart from the other tokens signed with the same key
+const kind = "profile"
+
+// link is the signed payload of a token
+type link struct {
+	Kind string `json:"k"`
+	// KeyID is the ID of the key signing the token, empty for a static key
+	KeyID     string `json:"kid,omitempty"`
+	Sub       string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Keys are the keys signing the tokens and verifying them by key ID, see keyring.Manager
+type Keys interface {
+	SigningKey() (keyring.Key, error)
+	Key(id string) (keyring.Key, bool)
+}
+
+// Signer issues and verifies the share link tokens
+type Signer struct {
+	keys   Keys
+	maxTTL time.Duration
+	clock  clock.Clock
+}
+
+// Option configures the signer
+type Option func(*Signer)
+
+// WithMaxTTL sets the longest validity a caller can ask for
+func WithMaxTTL(maxTTL time.Duration) Option {
+	return func(s *Signer) {
+		s.maxTTL = maxTTL
+	}
+}
+
+// WithClock sets the time source of the expirations
+func WithClock(c clock.Clock) Option {
+	return func(s *Signer) {
+		s.clock = c
+	}
+}
+
+// NewSigner creates a signer with the given key, all the replicas must share the key
+// to resolve the links issued by each other
+func NewSigner(key []byte, opts ...Option) (*Signer, error) {
+	if len(key) < MinKeySize {
+		return nil, errors.NewValidation("share link signing key must be at least 32 bytes")
+	}
+
+	return NewRotatingSigner(keyring.Static(append([]byte(nil), key...)), opts...), nil
+}
+
+// NewRotatingSigner creates a signer with the keys of a keyring, the tokens carry the ID of the key
+// signing them
+func NewRotatingSigner(keys Keys, opts ...Option) *Signer {
+	s := &Signer{
+		keys:   keys,
+		maxTTL: DefaultMaxTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.clock = clock.Or(s.clock)
+	return s
+}
+
+func sign(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Sign returns the token of a link to the profile of the subject, valid for the given duration,
+// DefaultTTL when zero
+func (s *Signer) Sign(sub string, ttl time.Duration) (string, time.Time, error) {
+	sub = strings.TrimSpace(sub)
+	if sub == "" {
+		return "", time.Time{}, errors.NewValidation("sub is required")
+	}
+	switch {
+	case ttl == 0:
+		ttl = DefaultTTL
+	case ttl < 0:
+		return "", time.Time{}, errors.NewValidation("share link duration must be positive")
+	case ttl > s.maxTTL:
+		return "", time.Time{}, errors.NewValidation("share link duration must be at most " + s.maxTTL.String())
+	}
+
+	key, errKey := s.keys.SigningKey()
+	if errKey != nil {
+		return "", time.Time{}, errKey
+	}
+
+	expiresAt := s.clock.Now().Add(ttl).Truncate(time.Second)
+	payload, err := json.Marshal(link{Kind: kind, KeyID: key.ID, Sub: sub, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, errors.NewUnexpected("failed to encode share link", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(sign(key.Secret, payload)), expiresAt, nil
+}
+
+// Verify returns the subject of the profile of the token, once verified it was issued by the
+// signer and is not expired
+func (s *Signer) Verify(token string) (string, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(strings.TrimSpace(token), ".")
+	if !found {
+		return "", errors.NewValidation("invalid share link")
+	}
+	payload, errPayload := base64.RawURLEncoding.DecodeString(encodedPayload)
+	signature, errSignature := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if errPayload != nil || errSignature != nil {
+		return "", errors.NewValidation("invalid share link")
+	}
+
+	// the key ID selects the key verifying the signature, the rest is only trusted once verified
+	var l link
+	if err := json.Unmarshal(payload, &l); err != nil {
+		return "", errors.NewValidation("invalid share link")
+	}
+	key, found := s.keys.Key(l.KeyID)
+	if !found || !hmac.Equal(signature, sign(key.Secret, payload)) || l.Kind != kind || l.Sub == "" {
+		return "", errors.NewValidation("invalid share link")
+	}
+
+	if !s.clock.Now().Before(time.Unix(l.ExpiresAt, 0)) {
+		return "", errors.NewValidation("share link expired")
+	}
+
+	return l.Sub, nil
+}
@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sharelink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/keyring"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewSigner(t *testing.T) {
+	if _, err := NewSigner([]byte("short")); err == nil {
+		t.Error("NewSigner() accepted a short key")
+	}
+	if _, err := NewSigner(testKey); err != nil {
+		t.Errorf("NewSigner() unexpected error: %v", err)
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	signer, _ := NewSigner(testKey, WithMaxTTL(48*time.Hour), WithClock(clock.NewFake(now)))
+
+	tests := []struct {
+		name          string
+		sub           string
+		ttl           time.Duration
+		wantExpiresAt time.Time
+		wantErr       string
+	}{
+		{name: "default duration", sub: "auth0|jane", wantExpiresAt: now.Add(DefaultTTL)},
+		{name: "requested duration", sub: "auth0|jane", ttl: time.Hour, wantExpiresAt: now.Add(time.Hour)},
+		{name: "too long", sub: "auth0|jane", ttl: 72 * time.Hour, wantErr: "share link duration must be at most 48h0m0s"},
+		{name: "negative duration", sub: "auth0|jane", ttl: -time.Hour, wantErr: "share link duration must be positive"},
+		{name: "no sub", sub: " ", wantErr: "sub is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, expiresAt, err := signer.Sign(tt.sub, tt.ttl)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Sign() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sign() unexpected error: %v", err)
+			}
+			if !expiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("Sign() expires at %v, want %v", expiresAt, tt.wantExpiresAt)
+			}
+			if strings.ContainsAny(token, "+/=") {
+				t.Errorf("Sign() token %q is not URL safe", token)
+			}
+		})
+	}
+}
+
+func TestSigner_Verify(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	signer, _ := NewSigner(testKey, WithClock(fake))
+	otherSigner, _ := NewSigner([]byte("fedcba9876543210fedcba9876543210"), WithClock(fake))
+
+	token, _, err := signer.Sign("auth0|jane", time.Hour)
+	if err != nil {
+		t.Fatalf("Sign() unexpected error: %v", err)
+	}
+	otherToken, _, _ := otherSigner.Sign("auth0|jane", time.Hour)
+
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := payload[:len(payload)-2] + "x" + payload[len(payload)-1:] + "." + signature
+
+	tests := []struct {
+		name    string
+		token   string
+		advance time.Duration
+		wantSub string
+		wantErr string
+	}{
+		{name: "round trip", token: token, wantSub: "auth0|jane"},
+		{name: "tampered payload", token: tampered, wantErr: "invalid share link"},
+		{name: "another key", token: otherToken, wantErr: "invalid share link"},
+		{name: "garbage", token: "not-a-link", wantErr: "invalid share link"},
+		{name: "expired", token: token, advance: time.Hour, wantErr: "share link expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.Advance(tt.advance)
+			defer fake.Advance(-tt.advance)
+
+			sub, err := signer.Verify(tt.token)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Verify() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() unexpected error: %v", err)
+			}
+			if sub != tt.wantSub {
+				t.Errorf("Verify() = %q, want %q", sub, tt.wantSub)
+			}
+		})
+	}
+}
+
+// rotatingKeys is a keyring signing with one of its keys
+type rotatingKeys struct {
+	signing string
+	keys    map[string][]byte
+}
+
+func (r *rotatingKeys) SigningKey() (keyring.Key, error) {
+	return keyring.Key{ID: r.signing, Secret: r.keys[r.signing]}, nil
+}
+
+func (r *rotatingKeys) Key(id string) (keyring.Key, bool) {
+	secret, ok := r.keys[id]
+	return keyring.Key{ID: id, Secret: secret}, ok
+}
+
+func TestRotatingSigner(t *testing.T) {
+	keys := &rotatingKeys{signing: "k1", keys: map[string][]byte{
+		"":   testKey,
+		"k1": []byte("k1-secret-k1-secret-k1-secret-k1"),
+		"k2": []byte("k2-secret-k2-secret-k2-secret-k2"),
+	}}
+	signer := NewRotatingSigner(keys)
+
+	legacySigner, _ := NewSigner(testKey)
+	legacyToken, _, _ := legacySigner.Sign("auth0|legacy", time.Hour)
+	oldToken, _, _ := signer.Sign("auth0|jane", time.Hour)
+
+	keys.signing = "k2"
+	newToken, _, _ := signer.Sign("auth0|john", time.Hour)
+
+	for token, wantSub := range map[string]string{legacyToken: "auth0|legacy", oldToken: "auth0|jane", newToken: "auth0|john"} {
+		sub, err := signer.Verify(token)
+		if err != nil || sub != wantSub {
+			t.Errorf("Verify() = %q, %v, want %q", sub, err, wantSub)
+		}
+	}
+	if _, err := legacySigner.Verify(newToken); err == nil {
+		t.Error("Verify() accepted a token of a rotated key with the static key")
+	}
+
+	// the retired keys don't verify the tokens they signed anymore
+	delete(keys.keys, "k1")
+	if _, err := signer.Verify(oldToken); err == nil || err.Error() != "invalid share link" {
+		t.Errorf("Verify() error = %v, want invalid share link", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package tokenref provides the opaque references of the tokens issued to the callers, so the raw
+// tokens don't end up in the logs of the services relaying the responses.
+//
+// A reference carries the token and its expiration encrypted with AES-256-GCM: nothing is stored on
+// the service side, the caller keeps the reference and hands it back on the follow-up call, where it
+// is resolved to the token. References are URL safe.
+package tokenref
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/errors"
+)
+
+const (
+	// MinKeySize is the minimum size of the encryption secret
+	MinKeySize = 32
+
+	// DefaultTTL is how long a reference can be resolved after it was issued
+	DefaultTTL = 10 * time.Minute
+)
+
+// reference is the encrypted payload of a reference
+type reference struct {
+	Kind      string `json:"k"`
+	Token     string `json:"t"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Sealer issues and resolves the token references
+type Sealer struct {
+	aead  cipher.AEAD
+	ttl   time.Duration
+	clock clock.Clock
+}
+
+// Option configures the sealer
+type Option func(*Sealer)
+
+// WithTTL sets how long a reference can be resolved after it was issued
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Sealer) {
+		s.ttl = ttl
+	}
+}
+
+// WithClock sets the time source of the expirations
+func WithClock(c clock.Clock) Option {
+	return func(s *Sealer) {
+		s.clock = c
+	}
+}
+
+// NewSealer creates a sealer encrypting with a key derived from the secret, all the replicas must
+// share the secret to resolve the references issued by each other
+func NewSealer(secret []byte, opts ...Option) (*Sealer, error) {
+	if len(secret) < MinKeySize {
+		return nil, errors.NewValidation("token reference secret must be at least 32 bytes")
+	}
+
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to create token reference cipher", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.NewUnexpected("failed to create token reference cipher", err)
+	}
+
+	s := &Sealer{
+		aead: aead,
+		ttl:  DefaultTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.clock = clock.Or(s.clock)
+	return s, nil
+}
+
+// Seal returns the reference of a token of the given kind (e.g. "id_token") and its expiration,
+// the reference only resolves for the same kind
+func (s *Sealer) Seal(kind, token string) (string, time.Time, error) {
+	if token == "" {
+		return "", time.Time{}, errors.NewValidation("token is required")
+	}
+
+	expiresAt := s.clock.Now().Add(s.ttl).Truncate(time.Second)
+	payload, err := json.Marshal(reference{Kind: kind, Token: token, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, errors.NewUnexpected("failed to encode token reference", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(payload)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", time.Time{}, errors.NewUnexpected("failed to generate token reference nonce", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, payload, []byte(kind))), expiresAt, nil
+}
+
+// Open returns the token of the reference, once verified it was issued by the sealer for the given
+// kind and is not expired
+func (s *Sealer) Open(kind, ref string) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(ref))
+	if err != nil || len(sealed) < s.aead.NonceSize() {
+		return "", errors.NewValidation("invalid token reference")
+	}
+
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	payload, err := s.aead.Open(nil, nonce, ciphertext, []byte(kind))
+	if err != nil {
+		return "", errors.NewValidation("invalid token reference")
+	}
+
+	var r reference
+	if err := json.Unmarshal(payload, &r); err != nil || r.Kind != kind || r.Token == "" {
+		return "", errors.NewValidation("invalid token reference")
+	}
+
+	if !s.clock.Now().Before(time.Unix(r.ExpiresAt, 0)) {
+		return "", errors.NewValidation("token reference expired")
+	}
+
+	return r.Token, nil
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package tokenref
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewSealer(t *testing.T) {
+	if _, err := NewSealer([]byte("short")); err == nil {
+		t.Error("NewSealer() accepted a short secret")
+	}
+	if _, err := NewSealer(testSecret); err != nil {
+		t.Errorf("NewSealer() unexpected error: %v", err)
+	}
+}
+
+func TestSealer_SealOpen(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	sealer, _ := NewSealer(testSecret, WithTTL(5*time.Minute), WithClock(fake))
+
+	ref, expiresAt, err := sealer.Seal("id_token", "eyJhbGciOiJSUzI1NiJ9.payload.signature")
+	if err != nil {
+		t.Fatalf("Seal() unexpected error: %v", err)
+	}
+	if !expiresAt.Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("Seal() expires at %v, want %v", expiresAt, now.Add(5*time.Minute))
+	}
+	if strings.ContainsAny(ref, "+/=") {
+		t.Errorf("Seal() reference %q is not URL safe", ref)
+	}
+	if strings.Contains(ref, "eyJhbGciOiJSUzI1NiJ9") {
+		t.Errorf("Seal() reference %q leaks the token", ref)
+	}
+
+	again, _, _ := sealer.Seal("id_token", "eyJhbGciOiJSUzI1NiJ9.payload.signature")
+	if again == ref {
+		t.Error("Seal() returned the same reference twice")
+	}
+
+	token, err := sealer.Open("id_token", ref)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	if token != "eyJhbGciOiJSUzI1NiJ9.payload.signature" {
+		t.Errorf("Open() = %q", token)
+	}
+
+	tampered := []byte(ref)
+	tampered[20] ^= 1
+
+	other, _ := NewSealer([]byte("fedcba9876543210fedcba9876543210"), WithClock(fake))
+	tests := []struct {
+		name    string
+		sealer  *Sealer
+		kind    string
+		ref     string
+		wantErr string
+	}{
+		{name: "other kind", sealer: sealer, kind: "access_token", ref: ref, wantErr: "invalid token reference"},
+		{name: "other secret", sealer: other, kind: "id_token", ref: ref, wantErr: "invalid token reference"},
+		{name: "tampered", sealer: sealer, kind: "id_token", ref: string(tampered), wantErr: "invalid token reference"},
+		{name: "not base64", sealer: sealer, kind: "id_token", ref: "not a reference!", wantErr: "invalid token reference"},
+		{name: "too short", sealer: sealer, kind: "id_token", ref: "AAAA", wantErr: "invalid token reference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.sealer.Open(tt.kind, tt.ref)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Open() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	fake.Advance(5 * time.Minute)
+	if _, err := sealer.Open("id_token", ref); err == nil || err.Error() != "token reference expired" {
+		t.Errorf("Open() error = %v, want expired", err)
+	}
+
+	if _, _, err := sealer.Seal("id_token", ""); err == nil {
+		t.Error("Seal() accepted an empty token")
+	}
+}
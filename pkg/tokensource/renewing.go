@@ -0,0 +1,278 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+// Package tokensource caches the M2M tokens of the identity providers and renews them before
+// they expire, so the requests never wait for a token under normal operation.
+package tokensource
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/constants"
+)
+
+const (
+	// DefaultRenewAfter is the share of the token lifetime after which the token is renewed
+	DefaultRenewAfter = 0.8
+
+	// DefaultAttempts is the number of attempts of a fetch without a valid token
+	DefaultAttempts = 3
+
+	// DefaultRetryDelay is the base delay between the attempts, doubled on each retry and jittered
+	DefaultRetryDelay = 200 * time.Millisecond
+
+	// maxBackgroundRetryDelay bounds the delay between the renewal attempts in the background
+	maxBackgroundRetryDelay = 30 * time.Second
+)
+
+const (
+	// modeOnDemand is a fetch blocking the callers, no valid token is cached
+	modeOnDemand = "on_demand"
+	// modeBackground is a renewal of a still valid token
+	modeBackground = "background"
+	// modeForced is a renewal asked for by an operator
+	modeForced = "forced"
+)
+
+// Renewing is an oauth2.TokenSource caching the token of the underlying source. The token is
+// renewed in the background once DefaultRenewAfter of its lifetime elapsed, the concurrent
+// fetches share a single request and the failed fetches are retried with a jittered backoff.
+type Renewing struct {
+	ctx        context.Context
+	provider   string
+	source     oauth2.TokenSource
+	clock      clock.Clock
+	renewAfter float64
+	attempts   int
+	retryDelay time.Duration
+	random     func() float64
+	failures   metric.Int64Counter
+
+	group singleflight.Group
+
+	mu      sync.RWMutex
+	token   *oauth2.Token
+	renewAt time.Time
+	timer   *time.Timer
+}
+
+// Option configures the token source
+type Option func(*Renewing)
+
+// WithClock sets the clock of the token expirations
+func WithClock(c clock.Clock) Option {
+	return func(r *Renewing) {
+		r.clock = clock.Or(c)
+	}
+}
+
+// WithRenewAfter sets the share of the token lifetime after which the token is renewed, between 0 and 1
+func WithRenewAfter(share float64) Option {
+	return func(r *Renewing) {
+		if share > 0 && share <= 1 {
+			r.renewAfter = share
+		}
+	}
+}
+
+// WithRetries sets the number of attempts of a fetch without a valid token and the base delay between them
+func WithRetries(attempts int, delay time.Duration) Option {
+	return func(r *Renewing) {
+		r.attempts = max(attempts, 1)
+		r.retryDelay = delay
+	}
+}
+
+// NewRenewing wraps the token source of the provider, the background renewals stop with the context
+func NewRenewing(ctx context.Context, provider string, source oauth2.TokenSource, opts ...Option) *Renewing {
+	r := &Renewing{
+		ctx:        ctx,
+		provider:   provider,
+		source:     source,
+		clock:      clock.System,
+		renewAfter: DefaultRenewAfter,
+		attempts:   DefaultAttempts,
+		retryDelay: DefaultRetryDelay,
+		random:     rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	failures, errCounter := otel.Meter(constants.ServiceName).Int64Counter(
+		"auth_service.m2m_token.refresh_failures",
+		metric.WithDescription("Number of failed M2M token fetches, by provider and mode"),
+	)
+	if errCounter != nil {
+		slog.Warn("failed to create M2M token refresh failure counter", "error", errCounter)
+	}
+	r.failures = failures
+
+	return r
+}
+
+// Token returns the cached token, a token past its renewal time is returned while it is
+// renewed in the background. Without a valid token the callers wait for a single fetch.
+func (r *Renewing) Token() (*oauth2.Token, error) {
+	now := r.clock.Now()
+
+	r.mu.RLock()
+	token, renewAt := r.token, r.renewAt
+	r.mu.RUnlock()
+
+	if r.valid(token, now) {
+		if !renewAt.IsZero() && !now.Before(renewAt) {
+			r.renewInBackground()
+		}
+		return token, nil
+	}
+
+	result, err, _ := r.group.Do("token", func() (any, error) {
+		// a fetch may have completed while waiting for the lock of the group
+		r.mu.RLock()
+		cached := r.token
+		r.mu.RUnlock()
+		if r.valid(cached, r.clock.Now()) {
+			return cached, nil
+		}
+		return r.fetch(modeOnDemand, r.attempts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*oauth2.Token), nil
+}
+
+// Renew replaces the cached token with a new one whatever its expiration, e.g. to check the
+// credentials, the callers keep the current token until the new one is issued
+func (r *Renewing) Renew() (*oauth2.Token, error) {
+	result, err, _ := r.group.Do("token", func() (any, error) {
+		return r.fetch(modeForced, r.attempts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*oauth2.Token), nil
+}
+
+// valid reports whether the token can still be used
+func (r *Renewing) valid(token *oauth2.Token, now time.Time) bool {
+	return token != nil && token.AccessToken != "" && (token.Expiry.IsZero() || now.Before(token.Expiry))
+}
+
+// renewInBackground renews the token without blocking, joining the fetch in flight if any
+func (r *Renewing) renewInBackground() {
+	if r.ctx.Err() != nil {
+		return
+	}
+	r.group.DoChan("token", func() (any, error) {
+		token, err := r.fetch(modeBackground, 1)
+		if err != nil {
+			r.retryInBackground()
+		}
+		return token, err
+	})
+}
+
+// retryInBackground schedules a new renewal attempt after a failed one, while the cached token is valid
+func (r *Renewing) retryInBackground() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	if !r.valid(r.token, now) {
+		// the next caller fetches the token
+		return
+	}
+	delay := min(r.jitter(r.retryDelay), maxBackgroundRetryDelay)
+	if !r.token.Expiry.IsZero() {
+		delay = min(delay, r.token.Expiry.Sub(now))
+	}
+	// the callers don't trigger renewals before the retry
+	r.renewAt = now.Add(delay)
+	r.scheduleLocked(delay)
+}
+
+// fetch requests a token from the underlying source and caches it
+func (r *Renewing) fetch(mode string, attempts int) (*oauth2.Token, error) {
+	var lastErr error
+	for attempt := range attempts {
+		if attempt > 0 {
+			delay := r.jitter(r.retryDelay * time.Duration(1<<(attempt-1)))
+			slog.WarnContext(r.ctx, "failed to fetch M2M token, retrying",
+				"provider", r.provider,
+				"error", lastErr,
+				"attempt", attempt,
+				"delay", delay,
+			)
+			select {
+			case <-r.ctx.Done():
+				return nil, fmt.Errorf("failed to fetch M2M token: %w", r.ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+
+		issuedAt := r.clock.Now()
+		token, err := r.source.Token()
+		if err == nil {
+			r.store(token, issuedAt)
+			return token, nil
+		}
+		lastErr = err
+	}
+
+	if r.failures != nil {
+		r.failures.Add(r.ctx, 1, metric.WithAttributes(
+			attribute.String("provider", r.provider),
+			attribute.String("mode", mode),
+		))
+	}
+	slog.ErrorContext(r.ctx, "failed to fetch M2M token",
+		"provider", r.provider,
+		"mode", mode,
+		"attempts", attempts,
+		"error", lastErr,
+	)
+	return nil, lastErr
+}
+
+// store caches the token and schedules its renewal
+func (r *Renewing) store(token *oauth2.Token, issuedAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.token = token
+	r.renewAt = time.Time{}
+	if token.Expiry.IsZero() {
+		return
+	}
+
+	lifetime := token.Expiry.Sub(issuedAt)
+	r.renewAt = issuedAt.Add(time.Duration(float64(lifetime) * r.renewAfter))
+	r.scheduleLocked(r.renewAt.Sub(issuedAt))
+}
+
+// scheduleLocked replaces the pending renewal, the lock must be held
+func (r *Renewing) scheduleLocked(delay time.Duration) {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.timer = time.AfterFunc(max(delay, 0), r.renewInBackground)
+}
+
+// jitter spreads the delay between half and all of it, so the replicas don't retry in lockstep
+func (r *Renewing) jitter(delay time.Duration) time.Duration {
+	return delay/2 + time.Duration(r.random()*float64(delay/2))
+}
@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package tokensource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/linuxfoundation/lfx-v2-auth-service/pkg/clock"
+)
+
+// fakeSource issues tokens valid for an hour, failing the first calls when told to
+type fakeSource struct {
+	clock    clock.Clock
+	calls    atomic.Int32
+	failures atomic.Int32
+	release  chan struct{}
+}
+
+func (f *fakeSource) Token() (*oauth2.Token, error) {
+	call := f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	if f.failures.Add(-1) >= 0 {
+		return nil, errors.New("unavailable")
+	}
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", call),
+		Expiry:      f.clock.Now().Add(time.Hour),
+	}, nil
+}
+
+func newTestSource(t *testing.T, source *fakeSource, fake *clock.Fake) *Renewing {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return NewRenewing(ctx, "test", source, WithClock(fake), WithRetries(3, time.Millisecond))
+}
+
+func TestRenewing_ConcurrentCallersShareTheFetch(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	source := &fakeSource{clock: fake, release: make(chan struct{})}
+	renewing := newTestSource(t, source, fake)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := renewing.Token()
+			if assert.NoError(t, err) {
+				tokens[i] = token.AccessToken
+			}
+		}()
+	}
+
+	require.Eventually(t, func() bool { return source.calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(source.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), source.calls.Load())
+	for _, token := range tokens {
+		assert.Equal(t, "token-1", token)
+	}
+}
+
+func TestRenewing_ProactiveRenewal(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	source := &fakeSource{clock: fake}
+	renewing := newTestSource(t, source, fake)
+
+	token, err := renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	// before the renewal time the cached token is served
+	fake.Advance(47 * time.Minute)
+	token, err = renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.Equal(t, int32(1), source.calls.Load())
+
+	// past 80% of the lifetime the cached token is served while renewed in the background
+	fake.Advance(2 * time.Minute)
+	token, err = renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	require.Eventually(t, func() bool {
+		token, err := renewing.Token()
+		return err == nil && token.AccessToken == "token-2"
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), source.calls.Load())
+}
+
+func TestRenewing_RetriesWithoutValidToken(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	source := &fakeSource{clock: fake}
+	source.failures.Store(2)
+	renewing := newTestSource(t, source, fake)
+
+	token, err := renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-3", token.AccessToken)
+	assert.Equal(t, int32(3), source.calls.Load())
+
+	// all the attempts failing
+	source.failures.Store(3)
+	fake.Advance(2 * time.Hour)
+	_, err = renewing.Token()
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, int32(6), source.calls.Load())
+}
+
+func TestRenewing_FailedBackgroundRenewalKeepsTheToken(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	source := &fakeSource{clock: fake}
+	renewing := newTestSource(t, source, fake)
+
+	_, err := renewing.Token()
+	require.NoError(t, err)
+
+	source.failures.Store(1)
+	fake.Advance(50 * time.Minute)
+	token, err := renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	// the failed renewal is retried in the background, the cached token is served meanwhile
+	require.Eventually(t, func() bool {
+		token, err := renewing.Token()
+		return err == nil && token.AccessToken == "token-3"
+	}, time.Second, time.Millisecond)
+}
+
+func TestRenewing_Renew(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC))
+	source := &fakeSource{clock: fake}
+	renewing := newTestSource(t, source, fake)
+
+	token, err := renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+
+	// the valid token is replaced
+	renewed, err := renewing.Renew()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", renewed.AccessToken)
+	token, err = renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+
+	// a failed renewal keeps the current token
+	source.failures.Store(3)
+	_, err = renewing.Renew()
+	assert.Error(t, err)
+	token, err = renewing.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+}